@@ -79,7 +79,7 @@ func main() {
 	diBuilder.Add(di.Def{
 		Name: static.DiState,
 		Build: func(ctn di.Container) (interface{}, error) {
-			return (*dgrs.State)(nil), nil
+			return (dgrs.IState)(nil), nil
 		},
 	})
 
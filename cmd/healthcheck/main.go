@@ -37,6 +37,10 @@ func main() {
 	if !status.AllOk {
 		exit(3, "\nsystem state faulty\n")
 	}
+
+	if status.Degraded {
+		fmt.Println("\nsystem state degraded")
+	}
 }
 
 func checkErr(err error) {
@@ -23,11 +23,17 @@ import (
 	"github.com/zekroTJA/shinpuru/internal/listeners"
 	"github.com/zekroTJA/shinpuru/internal/services/backup"
 	"github.com/zekroTJA/shinpuru/internal/services/birthday"
+	"github.com/zekroTJA/shinpuru/internal/services/broadcast"
+	"github.com/zekroTJA/shinpuru/internal/services/components"
 	"github.com/zekroTJA/shinpuru/internal/services/config"
 	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/services/emojiimport"
 	"github.com/zekroTJA/shinpuru/internal/services/guildlog"
+	"github.com/zekroTJA/shinpuru/internal/services/invitetracker"
 	"github.com/zekroTJA/shinpuru/internal/services/karma"
 	"github.com/zekroTJA/shinpuru/internal/services/kvcache"
+	"github.com/zekroTJA/shinpuru/internal/services/listenerregistry"
+	"github.com/zekroTJA/shinpuru/internal/services/msgsender"
 	"github.com/zekroTJA/shinpuru/internal/services/permissions"
 	"github.com/zekroTJA/shinpuru/internal/services/report"
 	"github.com/zekroTJA/shinpuru/internal/services/timeprovider"
@@ -40,6 +46,7 @@ import (
 	"github.com/zekroTJA/shinpuru/pkg/argp"
 	"github.com/zekroTJA/shinpuru/pkg/lokiwriter"
 	"github.com/zekroTJA/shinpuru/pkg/onetimeauth/v2"
+	"github.com/zekroTJA/shinpuru/pkg/redisguard"
 	"github.com/zekroTJA/shinpuru/pkg/startuptime"
 
 	"github.com/zekroTJA/shinpuru/pkg/angularservice"
@@ -105,6 +112,7 @@ func main() {
 	flagQuiet, _ := argp.Bool("-quiet", false, "Hide startup message.")
 	_, _ = argp.Bool("-docker", false, "Docker mode (deprecated)")
 	flagVersion, _ := argp.Bool("-v", false, "Show version information")
+	flagMigrateTo, _ := argp.Int("-migrateto", -1, "Migrate the database to the given version and exit.")
 
 	if flagHelp, _ := argp.Bool("-h", false, "Display help."); flagHelp {
 		fmt.Println("Usage:\n" + argp.Help())
@@ -168,11 +176,31 @@ func main() {
 		},
 	})
 
+	// Initialize redis connection guard
+	diBuilder.Add(di.Def{
+		Name: static.DiRedisGuard,
+		Build: func(ctn di.Container) (interface{}, error) {
+			return inits.InitRedisGuard(ctn), nil
+		},
+		Close: func(obj interface{}) error {
+			obj.(*redisguard.Guard).Close()
+			return nil
+		},
+	})
+
+	// Initialize database cache
+	diBuilder.Add(di.Def{
+		Name: static.DiDatabaseCache,
+		Build: func(ctn di.Container) (interface{}, error) {
+			return inits.InitDatabaseCache(ctn), nil
+		},
+	})
+
 	// Initialize database middleware and shutdown routine
 	diBuilder.Add(di.Def{
 		Name: static.DiDatabase,
 		Build: func(ctn di.Container) (interface{}, error) {
-			return inits.InitDatabase(ctn), nil
+			return inits.InitDatabase(ctn, flagMigrateTo), nil
 		},
 		Close: func(obj interface{}) error {
 			database := obj.(database.Database)
@@ -391,6 +419,62 @@ func main() {
 		},
 	})
 
+	// Initialize broadcast service
+	diBuilder.Add(di.Def{
+		Name: static.DiBroadcast,
+		Build: func(ctn di.Container) (interface{}, error) {
+			return broadcast.New(ctn), nil
+		},
+	})
+
+	// Initialize message component registry
+	diBuilder.Add(di.Def{
+		Name: static.DiComponents,
+		Build: func(ctn di.Container) (interface{}, error) {
+			return components.New(ctn), nil
+		},
+	})
+
+	// Initialize invite tracker
+	diBuilder.Add(di.Def{
+		Name: static.DiInviteTracker,
+		Build: func(ctn di.Container) (interface{}, error) {
+			return invitetracker.New(ctn), nil
+		},
+	})
+
+	// Initialize emoji importer
+	diBuilder.Add(di.Def{
+		Name: static.DiEmojiImporter,
+		Build: func(ctn di.Container) (interface{}, error) {
+			return emojiimport.New(ctn), nil
+		},
+	})
+
+	// Initialize message sender
+	diBuilder.Add(di.Def{
+		Name: static.DiMessageSender,
+		Build: func(ctn di.Container) (interface{}, error) {
+			return msgsender.New(ctn), nil
+		},
+	})
+
+	// Initialize listener registry
+	diBuilder.Add(di.Def{
+		Name: static.DiListenerRegistry,
+		Build: func(ctn di.Container) (interface{}, error) {
+			return listenerregistry.New(), nil
+		},
+	})
+
+	// Initialize self-check
+	diBuilder.Add(di.Def{
+		Name: static.DiSelfCheck,
+		Build: func(ctn di.Container) (interface{}, error) {
+			return inits.InitSelfCheck(ctn), nil
+		},
+	})
+
 	// Build dependency injection container
 	ctn := diBuilder.Build()
 	// Tear down dependency instances
@@ -439,6 +523,18 @@ func main() {
 		setupDevMode()
 	}
 
+	if flagMigrateTo > -1 {
+		// The database is migrated to the given version
+		// on initialization.
+		ctn.Get(static.DiDatabase)
+		log.Info().Field("version", flagMigrateTo).Msg("Database migration finished")
+		return
+	}
+
+	// Verify that all critical dependencies
+	// are available before starting up.
+	inits.RunSelfCheck(ctn)
+
 	ctn.Get(static.DiCommandHandler)
 
 	// Initialize discord session and event
@@ -1,15 +1,22 @@
 package inits
 
 import (
+	"errors"
+	"fmt"
 	"math/rand"
+	"net/http"
+	"strings"
 	"sync/atomic"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/gorilla/websocket"
 	"github.com/sarulabs/di/v2"
 	"github.com/zekroTJA/shinpuru/internal/listeners"
 	"github.com/zekroTJA/shinpuru/internal/services/config"
+	"github.com/zekroTJA/shinpuru/internal/services/listenerregistry"
 	"github.com/zekroTJA/shinpuru/internal/util"
+	"github.com/zekroTJA/shinpuru/internal/util/intents"
 	"github.com/zekroTJA/shinpuru/internal/util/snowflakenodes"
 	"github.com/zekroTJA/shinpuru/internal/util/static"
 	"github.com/zekroTJA/shinpuru/pkg/discordutil"
@@ -17,6 +24,13 @@ import (
 	"github.com/zekrotja/rogu/log"
 )
 
+// Gateway close codes sent by Discord when the
+// requested intents are invalid or not granted.
+const (
+	closeCodeInvalidIntents    = 4013
+	closeCodeDisallowedIntents = 4014
+)
+
 func InitDiscordBotSession(container di.Container) (release func()) {
 	release = func() {}
 
@@ -32,11 +46,27 @@ func InitDiscordBotSession(container di.Container) (release func()) {
 	cfg := container.Get(static.DiConfig).(config.Provider)
 
 	session.Token = "Bot " + cfg.Config().Discord.Token
-	session.Identify.Intents = discordgo.MakeIntent(static.Intents)
 	session.StateEnabled = false
 
+	requestedIntents, features, err := intents.Compute(cfg.Config().Discord.Intents.Features)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid intents config")
+	}
+	for _, f := range features {
+		log.Info().
+			Field("feature", f.Name).
+			Field("intents", strings.Join(intents.IntentNames(f.Intents), ", ")).
+			Field("reason", f.Reason).
+			Msg("Enabling gateway intents")
+	}
+	session.Identify.Intents = requestedIntents
+
+	if err = validateToken(session, cfg.Config().Discord.Token); err != nil {
+		log.Fatal().Err(err).Msg("Discord bot token validation failed")
+	}
+
 	if shardCfg := cfg.Config().Discord.Sharding; shardCfg.Total > 1 {
-		st := container.Get(static.DiState).(*dgrs.State)
+		st := container.Get(static.DiState).(dgrs.IState)
 
 		var id int
 		if shardCfg.AutoID {
@@ -69,7 +99,10 @@ func InitDiscordBotSession(container di.Container) (release func()) {
 		session.Identify.Shard = &[2]int{id, shardCfg.Total}
 	}
 
+	reg := container.Get(static.DiListenerRegistry).(*listenerregistry.Registry)
+
 	listenerInviteBlock := listeners.NewListenerInviteBlock(container)
+	listenerWordFilter := listeners.NewListenerWordFilter(container)
 	listenerGhostPing := listeners.NewListenerGhostPing(container)
 	listenerColors := listeners.NewColorListener(container)
 
@@ -83,64 +116,161 @@ func InitDiscordBotSession(container di.Container) (release func()) {
 	listenerAutoVoice := listeners.NewListenerAutoVoice(container)
 	listenerGuilds := listeners.NewListenerGuildAdd(container)
 	listenerRoleSelects := listeners.NewListenerRoleselect(container)
+	listenerRules := listeners.NewListenerRules(container)
+	listenerGiveaway := listeners.NewListenerGiveaway(container)
 	listenerStatus := listeners.NewListenerStatus()
+	listenerInviteTracking := listeners.NewListenerInviteTracking(container)
+	listenerMemberRemove := listeners.NewListenerMemberRemove(container)
+	listenerKarma := listeners.NewListenerKarma(container)
+	listenerReactionReport := listeners.NewListenerReactionReport(container)
+	listenerBotNick := listeners.NewListenerBotNick(container)
+	listenerInactivity := listeners.NewListenerInactivity(container)
+
+	session.AddHandler(listenerregistry.Wrap(reg, "ready", listeners.NewListenerReady(container).Handler))
+	session.AddHandler(listenerregistry.Wrap(reg, "memberadd", listeners.NewListenerMemberAdd(container).Handler))
+	session.AddHandler(listenerregistry.Wrap(reg, "memberremove", listenerMemberRemove.Handler))
+	session.AddHandler(listenerregistry.Wrap(reg, "memberremove", listenerMemberRemove.HandlerBan))
+	session.AddHandler(listenerregistry.Wrap(reg, "vote", listeners.NewListenerVote(container).Handler))
+	session.AddHandler(listenerregistry.Wrap(reg, "channelcreate", listeners.NewListenerChannelCreate(container).Handler))
+	session.AddHandler(listenerregistry.Wrap(reg, "modlogroutes", listeners.NewListenerModLogRoutes(container).HandlerChannelDelete))
+	session.AddHandler(listenerregistry.Wrap(reg, "voicelog", listeners.NewListenerVoiceUpdate(container).Handler))
+	session.AddHandler(listenerregistry.Wrap(reg, "karma", discordutil.WrapHandler(listenerKarma.Handler)))
+	session.AddHandler(listenerregistry.Wrap(reg, "karma", discordutil.WrapHandler(listenerKarma.HandlerRemove)))
+	session.AddHandler(listenerregistry.Wrap(reg, "antiraid", discordutil.WrapHandler(listeners.NewListenerAntiraid(container).HandlerMemberAdd)))
+	session.AddHandler(listenerregistry.Wrap(reg, "botmention", listeners.NewListenerBotMention(container).Listener))
+	session.AddHandler(listenerregistry.Wrap(reg, "dmsync", listeners.NewListenerDMSync(container).Handler))
+	session.AddHandler(listenerregistry.Wrap(reg, "modmail", listeners.NewListenerModmail(container).HandlerMessageCreate))
+	session.AddHandler(listenerregistry.Wrap(reg, "commandsuggest", listeners.NewListenerCommandSuggest(container).HandlerMessageCreate))
+	session.AddHandler(listenerregistry.Wrap(reg, "postban", discordutil.WrapHandler(listeners.NewListenerPostBan(container).Handler)))
+	session.AddHandler(listenerregistry.Wrap(reg, "components", discordutil.WrapHandler(listeners.NewListenerComponents(container).HandlerInteractionCreate)))
+	session.AddHandler(listenerregistry.Wrap(reg, "autothread", discordutil.WrapHandler(listeners.NewListenerAutoThread(container).HandlerMessageCreate)))
+	session.AddHandler(listenerregistry.Wrap(reg, "autopublish", discordutil.WrapHandler(listeners.NewListenerAutoPublish(container).HandlerMessageCreate)))
+	session.AddHandler(listenerregistry.Wrap(reg, "stickymessage", discordutil.WrapHandler(listeners.NewListenerStickyMessage(container).HandlerMessageCreate)))
+	session.AddHandler(listenerregistry.Wrap(reg, "reactionreport", discordutil.WrapHandler(listenerReactionReport.HandlerReactionAdd)))
+	session.AddHandler(listenerregistry.Wrap(reg, "reactionreport", discordutil.WrapHandler(listenerReactionReport.HandlerMessageDelete)))
+	session.AddHandler(listenerregistry.Wrap(reg, "reactionreport", discordutil.WrapHandler(listenerReactionReport.HandlerMessageBulkDelete)))
+	session.AddHandler(listenerregistry.Wrap(reg, "messagestats", discordutil.WrapHandler(listeners.NewListenerMessageStats(container).HandlerMessageCreate)))
+	session.AddHandler(listenerregistry.Wrap(reg, "inactivity", discordutil.WrapHandler(listenerInactivity.HandlerMessageCreate)))
+	session.AddHandler(listenerregistry.Wrap(reg, "inactivity", discordutil.WrapHandler(listenerInactivity.HandlerVoiceStateUpdate)))
+	session.AddHandler(listenerregistry.Wrap(reg, "botnick", discordutil.WrapHandler(listenerBotNick.HandlerGuildCreate)))
+	session.AddHandler(listenerregistry.Wrap(reg, "botnick", discordutil.WrapHandler(listenerBotNick.HandlerMemberUpdate)))
+
+	session.AddHandler(listenerregistry.Wrap(reg, "ghostping", listenerGhostPing.HandlerMessageCreate))
+	session.AddHandler(listenerregistry.Wrap(reg, "ghostping", listenerGhostPing.HandlerMessageDelete))
+	session.AddHandler(listenerregistry.Wrap(reg, "inviteblock", discordutil.WrapHandler(listenerInviteBlock.HandlerMessageSend)))
+	session.AddHandler(listenerregistry.Wrap(reg, "inviteblock", discordutil.WrapHandler(listenerInviteBlock.HandlerMessageEdit)))
+	session.AddHandler(listenerregistry.Wrap(reg, "wordfilter", discordutil.WrapHandler(listenerWordFilter.HandlerMessageSend)))
+	session.AddHandler(listenerregistry.Wrap(reg, "wordfilter", discordutil.WrapHandler(listenerWordFilter.HandlerMessageEdit)))
 
-	session.AddHandler(listeners.NewListenerReady(container).Handler)
-	session.AddHandler(listeners.NewListenerMemberAdd(container).Handler)
-	session.AddHandler(listeners.NewListenerMemberRemove(container).Handler)
-	session.AddHandler(listeners.NewListenerVote(container).Handler)
-	session.AddHandler(listeners.NewListenerChannelCreate(container).Handler)
-	session.AddHandler(listeners.NewListenerVoiceUpdate(container).Handler)
-	session.AddHandler(discordutil.WrapHandler(listeners.NewListenerKarma(container).Handler))
-	session.AddHandler(discordutil.WrapHandler(listeners.NewListenerAntiraid(container).HandlerMemberAdd))
-	session.AddHandler(listeners.NewListenerBotMention(container).Listener)
-	session.AddHandler(listeners.NewListenerDMSync(container).Handler)
-	session.AddHandler(discordutil.WrapHandler(listeners.NewListenerPostBan(container).Handler))
+	session.AddHandler(listenerregistry.Wrap(reg, "codeexec", listenerJDoodle.HandlerMessageCreate))
+	session.AddHandler(listenerregistry.Wrap(reg, "codeexec", listenerJDoodle.HandlerMessageUpdate))
+	session.AddHandler(listenerregistry.Wrap(reg, "codeexec", listenerJDoodle.HandlerReactionAdd))
 
-	session.AddHandler(listenerGhostPing.HandlerMessageCreate)
-	session.AddHandler(listenerGhostPing.HandlerMessageDelete)
-	session.AddHandler(discordutil.WrapHandler(listenerInviteBlock.HandlerMessageSend))
-	session.AddHandler(discordutil.WrapHandler(listenerInviteBlock.HandlerMessageEdit))
+	session.AddHandler(listenerregistry.Wrap(reg, "colors", listenerColors.HandlerMessageCreate))
+	session.AddHandler(listenerregistry.Wrap(reg, "colors", listenerColors.HandlerMessageEdit))
+	session.AddHandler(listenerregistry.Wrap(reg, "colors", listenerColors.HandlerMessageReaction))
 
-	session.AddHandler(listenerJDoodle.HandlerMessageCreate)
-	session.AddHandler(listenerJDoodle.HandlerMessageUpdate)
-	session.AddHandler(listenerJDoodle.HandlerReactionAdd)
+	session.AddHandler(listenerregistry.Wrap(reg, "starboard", listenerStarboard.ListenerReactionAdd))
+	session.AddHandler(listenerregistry.Wrap(reg, "starboard", listenerStarboard.ListenerReactionRemove))
 
-	session.AddHandler(listenerColors.HandlerMessageCreate)
-	session.AddHandler(listenerColors.HandlerMessageEdit)
-	session.AddHandler(listenerColors.HandlerMessageReaction)
+	session.AddHandler(listenerregistry.Wrap(reg, "verification", listenerVerification.HandlerMemberAdd))
+	session.AddHandler(listenerregistry.Wrap(reg, "verification", listenerVerification.HandlerMemberRemove))
 
-	session.AddHandler(listenerStarboard.ListenerReactionAdd)
-	session.AddHandler(listenerStarboard.ListenerReactionRemove)
+	session.AddHandler(listenerregistry.Wrap(reg, "autovoice", listenerAutoVoice.HandlerVoiceUpdate))
+	session.AddHandler(listenerregistry.Wrap(reg, "autovoice", listenerAutoVoice.HandlerChannelDelete))
 
-	session.AddHandler(listenerVerification.HandlerMemberAdd)
-	session.AddHandler(listenerVerification.HandlerMemberRemove)
+	session.AddHandler(listenerregistry.Wrap(reg, "guilds", listenerGuilds.HandlerReady))
+	session.AddHandler(listenerregistry.Wrap(reg, "guilds", listenerGuilds.HandlerCreate))
 
-	session.AddHandler(listenerAutoVoice.HandlerVoiceUpdate)
-	session.AddHandler(listenerAutoVoice.HandlerChannelDelete)
+	session.AddHandler(listenerregistry.Wrap(reg, "roleselect", discordutil.WrapHandler(listenerRoleSelects.HandlerMessageBulkDelete)))
+	session.AddHandler(listenerregistry.Wrap(reg, "roleselect", discordutil.WrapHandler(listenerRoleSelects.HandlerMessageDelete)))
+	session.AddHandler(listenerregistry.Wrap(reg, "roleselect", discordutil.WrapHandler(listenerRoleSelects.Ready)))
 
-	session.AddHandler(listenerGuilds.HandlerReady)
-	session.AddHandler(listenerGuilds.HandlerCreate)
+	session.AddHandler(listenerregistry.Wrap(reg, "rules", discordutil.WrapHandler(listenerRules.HandlerMessageBulkDelete)))
+	session.AddHandler(listenerregistry.Wrap(reg, "rules", discordutil.WrapHandler(listenerRules.HandlerMessageDelete)))
+	session.AddHandler(listenerregistry.Wrap(reg, "rules", discordutil.WrapHandler(listenerRules.Ready)))
 
-	session.AddHandler(discordutil.WrapHandler(listenerRoleSelects.HandlerMessageBulkDelete))
-	session.AddHandler(discordutil.WrapHandler(listenerRoleSelects.HandlerMessageDelete))
-	session.AddHandler(discordutil.WrapHandler(listenerRoleSelects.Ready))
+	session.AddHandler(listenerregistry.Wrap(reg, "giveaway", discordutil.WrapHandler(listenerGiveaway.HandlerMessageBulkDelete)))
+	session.AddHandler(listenerregistry.Wrap(reg, "giveaway", discordutil.WrapHandler(listenerGiveaway.HandlerMessageDelete)))
+	session.AddHandler(listenerregistry.Wrap(reg, "giveaway", discordutil.WrapHandler(listenerGiveaway.Ready)))
 
-	session.AddHandler(listenerStatus.ListenerConnect)
-	session.AddHandler(listenerStatus.ListenerDisconnect)
+	session.AddHandler(listenerregistry.Wrap(reg, "status", listenerStatus.ListenerConnect))
+	session.AddHandler(listenerregistry.Wrap(reg, "status", listenerStatus.ListenerDisconnect))
+
+	session.AddHandler(listenerregistry.Wrap(reg, "invitetracking", discordutil.WrapHandler(listenerInviteTracking.HandlerGuildCreate)))
+	session.AddHandler(listenerregistry.Wrap(reg, "invitetracking", discordutil.WrapHandler(listenerInviteTracking.HandlerGuildDelete)))
+	session.AddHandler(listenerregistry.Wrap(reg, "invitetracking", discordutil.WrapHandler(listenerInviteTracking.HandlerInviteCreate)))
+	session.AddHandler(listenerregistry.Wrap(reg, "invitetracking", discordutil.WrapHandler(listenerInviteTracking.HandlerMemberAdd)))
 
 	session.AddHandler(func(s *discordgo.Session, e *discordgo.MessageCreate) {
 		atomic.AddUint64(&util.StatsMessagesAnalysed, 1)
 	})
 
 	if cfg.Config().Metrics.Enable {
-		session.AddHandler(listeners.NewListenerMetrics().Listener)
+		session.AddHandler(listenerregistry.Wrap(reg, "metrics", listeners.NewListenerMetrics().Listener))
 	}
 
 	err = session.Open()
 	if err != nil {
+		if msg := intentsErrorMessage(err, requestedIntents); msg != "" {
+			log.Fatal().Err(err).Msg(msg)
+		}
 		log.Fatal().Err(err).Msg("Failed connecting Discord bot session")
 	}
 
 	return
 }
+
+// intentsErrorMessage returns an actionable message if
+// the given error is caused by Discord rejecting the
+// requested gateway intents. Otherwise, an empty string
+// is returned.
+func intentsErrorMessage(err error, requested discordgo.Intent) string {
+	var closeErr *websocket.CloseError
+	if !errors.As(err, &closeErr) {
+		return ""
+	}
+
+	switch closeErr.Code {
+	case closeCodeInvalidIntents:
+		return "Discord rejected the requested gateway intents as invalid"
+	case closeCodeDisallowedIntents:
+		return fmt.Sprintf("Discord did not grant the privileged intents %s: enable them "+
+			"for the bot in the Discord developer portal or disable the features requiring "+
+			"them via 'discord.intents.features' in the config",
+			strings.Join(intents.IntentNames(requested&intents.Privileged), ", "))
+	}
+
+	return ""
+}
+
+// validateToken checks that a bot token is configured
+// and that it is accepted by the Discord API by
+// requesting the current user with the given session.
+func validateToken(session *discordgo.Session, token string) error {
+	if strings.TrimSpace(token) == "" {
+		return errors.New("no Discord bot token configured: set 'discord.token' in " +
+			"the config or pass it via the environment variable SP_DISCORD_TOKEN")
+	}
+
+	_, err := session.User("@me")
+	if err == nil {
+		return nil
+	}
+
+	var restErr *discordgo.RESTError
+	if errors.As(err, &restErr) && restErr.Response != nil &&
+		restErr.Response.StatusCode == http.StatusUnauthorized {
+		hint := "make sure the token is copied from the 'Bot' section of your application " +
+			"in the Discord developer portal and has not been regenerated since"
+		if strings.HasPrefix(token, "Bot ") {
+			hint = "remove the 'Bot ' prefix from the configured token"
+		} else if token != strings.TrimSpace(token) {
+			hint = "remove the leading or trailing whitespace from the configured token"
+		}
+		return fmt.Errorf("the Discord bot token has been rejected by Discord: %s", hint)
+	}
+
+	return fmt.Errorf("the Discord bot token could not be validated, check the "+
+		"network connection to the Discord API: %s", err.Error())
+}
@@ -16,6 +16,8 @@ func InitCodeExec(container di.Container) codeexec.Factory {
 	log := log.Tagged("CodeExec")
 	log.Info().Msg("Initializing code execution ...")
 
+	var factory codeexec.Factory
+
 	switch strings.ToLower(cfg.Config().CodeExec.Type) {
 
 	case "ranna":
@@ -23,9 +25,11 @@ func InitCodeExec(container di.Container) codeexec.Factory {
 		if err != nil {
 			log.Fatal().Err(err).Msg("Failed setting up ranna factroy")
 		}
-		return exec
+		factory = exec
 
 	default:
-		return codeexec.NewJdoodleFactory(container)
+		factory = codeexec.NewJdoodleFactory(container)
 	}
+
+	return codeexec.NewLimitedFactory(factory, cfg.Config().CodeExec.Limits)
 }
@@ -13,31 +13,35 @@ import (
 	"github.com/zekroTJA/shinpuru/internal/usercommands"
 	"github.com/zekroTJA/shinpuru/internal/util/embedded"
 	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/pkg/guardedstate"
 	"github.com/zekroTJA/shinpuru/pkg/rediscmdstore"
+	"github.com/zekroTJA/shinpuru/pkg/redisguard"
 	"github.com/zekrotja/dgrs"
 	"github.com/zekrotja/ken"
 	"github.com/zekrotja/ken/middlewares/cmdhelp"
-	"github.com/zekrotja/ken/state"
 	"github.com/zekrotja/ken/store"
 	"github.com/zekrotja/rogu/log"
 )
 
 func InitCommandHandler(container di.Container) (k *ken.Ken, err error) {
 	session := container.Get(static.DiDiscordSession).(*discordgo.Session)
-	st := container.Get(static.DiState).(*dgrs.State)
+	st := container.Get(static.DiState).(dgrs.IState)
 	perms := container.Get(static.DiPermissions).(*permissions.Permissions)
 	rd, _ := container.Get(static.DiRedis).(*redis.Client)
+	guard, _ := container.Get(static.DiRedisGuard).(*redisguard.Guard)
 
 	log := log.Tagged("CmdHandler")
 	log.Info().Msg("Initializing command handler ...")
 
 	var cmdStore store.CommandStore
-	if rd != nil {
+	if rd != nil && guard != nil && guard.Available() {
 		cmdStore = rediscmdstore.New(rd, fmt.Sprintf("snp:cmdstore:%s", embedded.AppCommit))
+	} else if rd != nil {
+		log.Warn().Msg("Redis is unavailable; commands are not cached and will be re-registered")
 	}
 
 	k, err = ken.New(session, ken.Options{
-		State:              state.NewDgrs(st),
+		State:              guardedstate.NewKenState(st),
 		CommandStore:       cmdStore,
 		DependencyProvider: container,
 		OnSystemError:      systemErrorHandler,
@@ -55,10 +59,14 @@ func InitCommandHandler(container di.Container) (k *ken.Ken, err error) {
 		new(usercommands.User),
 
 		new(messagecommands.Quote),
+		new(messagecommands.Pin),
 
 		new(slashcommands.Autorole),
 		new(slashcommands.Autovc),
 		new(slashcommands.Backup),
+		new(slashcommands.BackupDiff),
+		new(slashcommands.BackupSchedule),
+		new(slashcommands.Botnick),
 		new(slashcommands.Bug),
 		new(slashcommands.Clear),
 		new(slashcommands.Vote),
@@ -70,6 +78,7 @@ func InitCommandHandler(container di.Container) (k *ken.Ken, err error) {
 		new(slashcommands.Say),
 		new(slashcommands.Notify),
 		new(slashcommands.Mvall),
+		new(slashcommands.VoiceMove),
 		new(slashcommands.Lock),
 		new(slashcommands.Inviteblock),
 		new(slashcommands.Ghostping),
@@ -85,10 +94,25 @@ func InitCommandHandler(container di.Container) (k *ken.Ken, err error) {
 		new(slashcommands.Login),
 		new(slashcommands.Quote),
 		new(slashcommands.Stats),
+		new(slashcommands.About),
 		new(slashcommands.Karma),
+		new(slashcommands.KarmaReset),
 		new(slashcommands.Guild),
 		new(slashcommands.Id),
 		new(slashcommands.Snowflake),
+		new(slashcommands.Ping),
+		new(slashcommands.Visibility),
+		new(slashcommands.EmojiImport),
+		new(slashcommands.RawMessage),
+		new(slashcommands.TempRole),
+		new(slashcommands.AutoThread),
+		new(slashcommands.AutoPublish),
+		new(slashcommands.ReactionReport),
+		new(slashcommands.Sticky),
+		new(slashcommands.AuditLog),
+		new(slashcommands.Note),
+		new(slashcommands.WordFilter),
+		new(slashcommands.Inactivity),
 		new(slashcommands.Maintenance),
 		new(slashcommands.Info),
 		new(slashcommands.Help),
@@ -97,6 +121,21 @@ func InitCommandHandler(container di.Container) (k *ken.Ken, err error) {
 		new(slashcommands.Ban),
 		new(slashcommands.Roleselect),
 		new(slashcommands.Modnot),
+		new(slashcommands.Autodelete),
+		new(slashcommands.Palette),
+		new(slashcommands.Color),
+		new(slashcommands.Confirmations),
+		new(slashcommands.Permcheck),
+		new(slashcommands.Modmail),
+		new(slashcommands.Rules),
+		new(slashcommands.Commandsuggestions),
+		new(slashcommands.Cmdblocklist),
+		new(slashcommands.Commands),
+		new(slashcommands.Giveaway),
+		new(slashcommands.Guildstats),
+		new(slashcommands.MessageStats),
+		new(slashcommands.Pin),
+		new(slashcommands.Invite),
 	)
 	if err != nil {
 		return
@@ -110,11 +149,14 @@ func InitCommandHandler(container di.Container) (k *ken.Ken, err error) {
 	}
 
 	err = k.RegisterMiddlewares(
+		middleware.NewDmGuildMiddleware(container),
+		middleware.NewCommandBlocklistMiddleware(container),
 		middleware.NewDisableCommandsMiddleware(container),
 		perms,
 		cmdhelp.New("help"),
 		middleware.NewCommandStatsMiddleware(),
 		middleware.NewCommandLoggingMiddleware(container),
+		middleware.NewAutoDeleteMiddleware(container),
 	)
 
 	return
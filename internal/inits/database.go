@@ -2,18 +2,26 @@ package inits
 
 import (
 	"strings"
+	"time"
 
-	goredis "github.com/go-redis/redis/v8"
 	"github.com/sarulabs/di/v2"
 	"github.com/zekroTJA/shinpuru/internal/services/config"
 	"github.com/zekroTJA/shinpuru/internal/services/database"
 	"github.com/zekroTJA/shinpuru/internal/services/database/mysql"
+	"github.com/zekroTJA/shinpuru/internal/services/database/postgres"
 	"github.com/zekroTJA/shinpuru/internal/services/database/redis"
+	"github.com/zekroTJA/shinpuru/internal/services/database/settingscache"
+	"github.com/zekroTJA/shinpuru/internal/services/database/sqldb"
+	"github.com/zekroTJA/shinpuru/internal/services/kvcache"
 	"github.com/zekroTJA/shinpuru/internal/util/static"
 	"github.com/zekrotja/rogu/log"
 )
 
-func InitDatabase(container di.Container) database.Database {
+// InitDatabase connects to the configured database and
+// migrates it to the given version. If migrateTo is
+// negative, the database is migrated to the latest
+// version.
+func InitDatabase(container di.Container, migrateTo int) database.Database {
 	var db database.Database
 	var err error
 
@@ -24,10 +32,36 @@ func InitDatabase(container di.Container) database.Database {
 	drv := strings.ToLower(cfg.Config().Database.Type)
 	log.Info().Field("driver", drv).Msg("Initializing database ...")
 
+	retryCfg := cfg.Config().Database.Retry
+	retry := database.RetryOptions{
+		MaxAttempts:  retryCfg.MaxAttempts,
+		InitialDelay: time.Duration(retryCfg.InitialDelaySeconds) * time.Second,
+		MaxDelay:     time.Duration(retryCfg.MaxDelaySeconds) * time.Second,
+		Timeout:      time.Duration(retryCfg.TimeoutSeconds) * time.Second,
+	}
+	onRetry := func(attempt int, delay time.Duration, err error) {
+		log.Warn().Err(err).
+			Field("attempt", attempt).
+			Field("maxattempts", retry.MaxAttempts).
+			Field("delay", delay.String()).
+			Msg("Failed connecting to database, retrying ...")
+	}
+
+	queriesCfg := cfg.Config().Database.Queries
+	queryOpts := sqldb.Options{
+		CacheStatements:     queriesCfg.CacheStatements,
+		MaxCachedStatements: queriesCfg.MaxCachedStatements,
+		Instrument:          queriesCfg.Instrument,
+		SlowQueryThreshold:  time.Duration(queriesCfg.SlowQueryThresholdMillis) * time.Millisecond,
+	}
+
 	switch drv {
 	case "mysql", "mariadb":
-		db = mysql.New()
-		err = db.Connect(cfg.Config().Database.MySql)
+		db = mysql.New(queryOpts)
+		err = database.Connect(db, retry, onRetry, cfg.Config().Database.MySql)
+	case "postgres", "postgresql":
+		db = postgres.New(queryOpts)
+		err = database.Connect(db, retry, onRetry, cfg.Config().Database.Postgres)
 	default:
 		log.Fatal().Field("driver", drv).Msg("Unsupported database driver")
 	}
@@ -37,8 +71,14 @@ func InitDatabase(container di.Container) database.Database {
 	}
 
 	if m, ok := db.(database.Migration); ok {
-		log.Info().Msg("Checking database for migrations and apply if needed...")
-		if err = m.Migrate(); err != nil {
+		if migrateTo < 0 {
+			log.Info().Msg("Checking database for migrations and apply if needed...")
+			err = m.Migrate()
+		} else {
+			log.Info().Field("version", migrateTo).Msg("Migrating database to target version ...")
+			err = m.MigrateTo(migrateTo)
+		}
+		if err != nil {
 			log.Fatal().Err(err).Msg("Database migration failed")
 		}
 	} else {
@@ -47,13 +87,20 @@ func InitDatabase(container di.Container) database.Database {
 
 	// Redis Database Cache
 	if cfg.Config().Cache.CacheDatabase {
-		rd := container.Get(static.DiRedis).(*goredis.Client)
-		db = redis.NewRedisMiddleware(db, rd)
+		cache := container.Get(static.DiDatabaseCache).(*redis.Cache)
+		db = redis.NewRedisMiddleware(db, cache)
 		log.Info().Msg("Enabled Redis as database cache")
 	} else {
 		log.Warn().Msg("Database cache is disabled! You can enbale it in the config (.cache.cachedatabase).")
 	}
 
+	// Guild Settings Cache
+	if cfg.Config().Cache.CacheGuildSettings {
+		kvc := container.Get(static.DiKVCache).(kvcache.Provider)
+		db = settingscache.New(db, kvc)
+		log.Info().Msg("Enabled guild settings cache")
+	}
+
 	log.Info().Msg("Connected to database")
 
 	return db
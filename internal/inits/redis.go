@@ -0,0 +1,45 @@
+package inits
+
+import (
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/sarulabs/di/v2"
+	dbredis "github.com/zekroTJA/shinpuru/internal/services/database/redis"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/pkg/redisguard"
+	"github.com/zekrotja/rogu/log"
+)
+
+// redisCheckInterval is the interval in which the
+// redis connection is checked.
+const redisCheckInterval = 10 * time.Second
+
+func InitRedisGuard(container di.Container) *redisguard.Guard {
+	rd := container.Get(static.DiRedis).(*redis.Client)
+
+	log := log.Tagged("Redis")
+
+	g := redisguard.New(rd, redisCheckInterval)
+	rd.AddHook(g)
+
+	g.OnFailure(func(err error) {
+		log.Warn().Err(err).Msg("Redis is unavailable; falling back to uncached and in-memory behavior")
+	})
+	g.OnRecover(func() {
+		log.Info().Msg("Redis is available again")
+	})
+
+	if err := g.Err(); err != nil {
+		log.Warn().Err(err).Msg("Redis is unavailable; falling back to uncached and in-memory behavior")
+	}
+
+	return g
+}
+
+func InitDatabaseCache(container di.Container) *dbredis.Cache {
+	rd := container.Get(static.DiRedis).(*redis.Client)
+	g := container.Get(static.DiRedisGuard).(*redisguard.Guard)
+
+	return dbredis.NewCache(rd, g)
+}
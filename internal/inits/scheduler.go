@@ -9,13 +9,19 @@ import (
 	"github.com/zekroTJA/shinpuru/internal/services/config"
 	"github.com/zekroTJA/shinpuru/internal/services/database"
 	"github.com/zekroTJA/shinpuru/internal/services/guildlog"
+	"github.com/zekroTJA/shinpuru/internal/services/kvcache"
+	"github.com/zekroTJA/shinpuru/internal/services/msgsender"
 	"github.com/zekroTJA/shinpuru/internal/services/report"
 	"github.com/zekroTJA/shinpuru/internal/services/scheduler"
 	"github.com/zekroTJA/shinpuru/internal/services/timeprovider"
 	"github.com/zekroTJA/shinpuru/internal/services/verification"
 	"github.com/zekroTJA/shinpuru/internal/util"
 	"github.com/zekroTJA/shinpuru/internal/util/antiraid"
+	"github.com/zekroTJA/shinpuru/internal/util/giveaway"
+	"github.com/zekroTJA/shinpuru/internal/util/inactivity"
+	"github.com/zekroTJA/shinpuru/internal/util/messagestats"
 	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/internal/util/temprole"
 	"github.com/zekroTJA/shinpuru/pkg/discordutil"
 	"github.com/zekroTJA/shinpuru/pkg/twitchnotify"
 	"github.com/zekrotja/dgrs"
@@ -35,6 +41,8 @@ func InitScheduler(container di.Container) scheduler.Provider {
 	s := container.Get(static.DiDiscordSession).(*discordgo.Session)
 	st := container.Get(static.DiState).(dgrs.IState)
 	tp := container.Get(static.DiTimeProvider).(timeprovider.Provider)
+	kvc := container.Get(static.DiKVCache).(kvcache.Provider)
+	sender := container.Get(static.DiMessageSender).(*msgsender.Sender)
 
 	shardID, shardTotal := discordutil.GetShardOfSession(s)
 
@@ -67,6 +75,12 @@ func InitScheduler(container di.Container) scheduler.Provider {
 			go gb.BackupAllGuilds()
 		})
 
+	schedule(log, sched, "scheduled guild backups",
+		staticSpec("@every 1m"),
+		func() {
+			go gb.RunSchedules()
+		})
+
 	schedule(log, sched, "twitch notify",
 		func() string {
 			if shardTotal > 1 && shardID != 0 {
@@ -83,6 +97,19 @@ func InitScheduler(container di.Container) scheduler.Provider {
 			}
 		})
 
+	schedule(log, sched, "twitch eventsub sync",
+		func() string {
+			if tnw == nil || !tnw.UsesEventSub() || (shardTotal > 1 && shardID != 0) {
+				return ""
+			}
+			return "@every 10m"
+		},
+		func() {
+			if err := tnw.SyncSubscriptions(); err != nil {
+				log.Error().Err(err).Msg("Failed syncing twitch eventsub subscriptions")
+			}
+		})
+
 	schedule(log, sched, "report expiration",
 		func() string {
 			if shardTotal > 1 && shardID != 0 {
@@ -120,6 +147,14 @@ func InitScheduler(container di.Container) scheduler.Provider {
 			return "@every 1h"
 		}, antiraid.FlushExpired(db, gl, tp))
 
+	schedule(log, sched, "giveaway end",
+		staticSpec("@every 30s"),
+		giveaway.EndExpired(db, s, gl, tp))
+
+	schedule(log, sched, "temporary role removal",
+		staticSpec("@every 1m"),
+		temprole.RemoveExpired(db, s, gl, tp))
+
 	schedule(log, sched, "birthday notifications",
 		func() string {
 			return "0 0 * * * *"
@@ -127,6 +162,26 @@ func InitScheduler(container di.Container) scheduler.Provider {
 			bd.Schedule()
 		})
 
+	schedule(log, sched, "message stats flush",
+		staticSpec("@every 5m"),
+		messagestats.Flush(db, kvc))
+
+	schedule(log, sched, "message stats cleanup",
+		func() string {
+			if shardTotal > 1 && shardID != 0 {
+				return ""
+			}
+			return "@every 24h"
+		}, messagestats.Cleanup(db, tp))
+
+	schedule(log, sched, "inactivity flush",
+		staticSpec("@every 5m"),
+		inactivity.Flush(db, kvc))
+
+	schedule(log, sched, "inactivity role removal",
+		staticSpec("@every 1h"),
+		inactivity.Process(db, s, st, sender, gl, tp))
+
 	schedule(log, sched, "guild membercount refresh",
 		staticSpec("@every 24h"),
 		func() {
@@ -0,0 +1,60 @@
+package inits
+
+import (
+	"github.com/bwmarrin/discordgo"
+	"github.com/sarulabs/di/v2"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/services/selfcheck"
+	"github.com/zekroTJA/shinpuru/internal/services/storage"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/pkg/redisguard"
+	"github.com/zekrotja/rogu/log"
+)
+
+func InitSelfCheck(container di.Container) *selfcheck.SelfCheck {
+	s := selfcheck.New(selfcheck.DefaultTimeout)
+
+	s.Add("database", true, func() error {
+		return container.Get(static.DiDatabase).(database.Database).Status()
+	})
+
+	s.Add("discord", true, func() error {
+		session := container.Get(static.DiDiscordSession).(*discordgo.Session)
+		_, err := session.User("@me")
+		return err
+	})
+
+	s.Add("redis", false, func() error {
+		g := container.Get(static.DiRedisGuard).(*redisguard.Guard)
+		g.Check()
+		return g.Err()
+	})
+
+	s.Add("storage", false, func() error {
+		return container.Get(static.DiObjectStorage).(storage.Storage).Status()
+	})
+
+	return s
+}
+
+// RunSelfCheck performs the startup self-check and logs
+// the summary. If a critical check failed, the startup
+// is aborted.
+func RunSelfCheck(container di.Container) {
+	s := container.Get(static.DiSelfCheck).(*selfcheck.SelfCheck)
+
+	log := log.Tagged("SelfCheck")
+	log.Info().Msg("Running self-check ...")
+
+	report := s.Run()
+	summary := "Self-check summary\n" + report.Table()
+
+	switch report.Status() {
+	case selfcheck.StatusOk:
+		log.Info().Msg(summary)
+	case selfcheck.StatusWarn:
+		log.Warn().Msg(summary)
+	default:
+		log.Fatal().Msg(summary)
+	}
+}
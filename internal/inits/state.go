@@ -7,16 +7,22 @@ import (
 	"github.com/go-redis/redis/v8"
 	"github.com/sarulabs/di/v2"
 	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/pkg/guardedstate"
+	"github.com/zekroTJA/shinpuru/pkg/redisguard"
 	"github.com/zekrotja/dgrs"
 )
 
 const day = 24 * time.Hour
 
-func InitState(container di.Container) (s *dgrs.State, err error) {
+// InitState initializes the dgrs state. While redis is
+// unavailable, the returned state requests objects from
+// the Discord API directly.
+func InitState(container di.Container) (s dgrs.IState, err error) {
 	session := container.Get(static.DiDiscordSession).(*discordgo.Session)
 	rd := container.Get(static.DiRedis).(*redis.Client)
+	guard := container.Get(static.DiRedisGuard).(*redisguard.Guard)
 
-	return dgrs.New(dgrs.Options{
+	st, err := dgrs.New(dgrs.Options{
 		RedisClient:    rd,
 		DiscordSession: session,
 		FetchAndStore:  true,
@@ -27,4 +33,9 @@ func InitState(container di.Container) (s *dgrs.State, err error) {
 			Presence: 30 * day,
 		},
 	})
+	if err != nil {
+		return
+	}
+
+	return guardedstate.New(st, session, guard), nil
 }
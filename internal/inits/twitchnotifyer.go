@@ -1,6 +1,8 @@
 package inits
 
 import (
+	"strings"
+
 	"github.com/sarulabs/di/v2"
 	"github.com/zekroTJA/shinpuru/internal/listeners"
 	"github.com/zekroTJA/shinpuru/internal/services/config"
@@ -23,6 +25,22 @@ func InitTwitchNotifyWorker(container di.Container) *twitchnotify.NotifyWorker {
 	log := log.Tagged("TwitchNotify")
 	log.Info().Msg("Initializing twitch notifications ...")
 
+	tnCfg := twitchnotify.Config{
+		TimerDelay: 0,
+	}
+
+	// EventSub requires a public HTTPS callback, so stream
+	// states are polled when none is available.
+	wsCfg := cfg.Config().WebServer
+	secret := wsCfg.WebhookSecrets["twitch"]
+	if wsCfg.Enabled && secret != "" && strings.HasPrefix(wsCfg.PublicAddr, "https://") {
+		tnCfg.EventSubCallback = strings.TrimSuffix(wsCfg.PublicAddr, "/") + "/api/v1/twitch/eventsub"
+		tnCfg.EventSubSecret = secret
+		log.Info().Field("callback", tnCfg.EventSubCallback).Msg("Using EventSub for stream notifications")
+	} else {
+		log.Info().Msg("No public HTTPS callback or twitch webhook secret configured; polling stream states")
+	}
+
 	tnw, err := twitchnotify.New(
 		twitchnotify.Credentials{
 			ClientID:     cfg.Config().TwitchApp.ClientID,
@@ -30,11 +48,12 @@ func InitTwitchNotifyWorker(container di.Container) *twitchnotify.NotifyWorker {
 		},
 		listener.HandlerWentOnline,
 		listener.HandlerWentOffline,
-		twitchnotify.Config{
-			TimerDelay: 0,
-		},
+		tnCfg,
 	)
 
+	if err == twitchnotify.ErrInvalidEventSubSecret {
+		log.Fatal().Err(err).Msg("Twitch webhook secret is invalid")
+	}
 	if err != nil {
 		log.Fatal().Err(err).Msg("Twitch app credentials are invalid")
 	}
@@ -8,11 +8,13 @@ import (
 	"github.com/bwmarrin/discordgo"
 	"github.com/sarulabs/di/v2"
 	"github.com/zekroTJA/ratelimit"
+	"github.com/zekroTJA/shinpuru/internal/models"
 	"github.com/zekroTJA/shinpuru/internal/services/database"
 	"github.com/zekroTJA/shinpuru/internal/services/guildlog"
 	"github.com/zekroTJA/shinpuru/internal/services/timeprovider"
 	"github.com/zekroTJA/shinpuru/internal/services/verification"
 	"github.com/zekroTJA/shinpuru/internal/util/antiraid"
+	"github.com/zekroTJA/shinpuru/internal/util/modlog"
 	"github.com/zekroTJA/shinpuru/internal/util/static"
 	"github.com/zekroTJA/shinpuru/pkg/discordutil"
 	"github.com/zekroTJA/shinpuru/pkg/voidbuffer/v2"
@@ -174,7 +176,7 @@ func (l *ListenerAntiraid) HandlerMemberAdd(s discordutil.ISession, e *discordgo
 		}
 	}
 
-	if chanID, _ := l.db.GetGuildModLog(e.GuildID); chanID != "" {
+	if chanID, _ := modlog.Channel(l.db, e.GuildID, models.ModLogActionAntiraid); chanID != "" {
 		s.ChannelMessageSendEmbed(chanID, &discordgo.MessageEmbed{
 			Color: static.ColorEmbedOrange,
 			Title: "⚠ GUILD RAID ALERT",
@@ -51,6 +51,7 @@ func getAntiraidHandlerMock(prep ...func(t antiraidHandlerMock)) antiraidHandler
 	t.db.On("GetAntiraidRegeneration", mock.Anything).Return(30, nil)
 	t.db.On("GetAntiraidBurst", mock.Anything).Return(3, nil)
 	t.db.On("GetGuildModLog", mock.Anything).Return("", nil)
+	t.db.On("GetGuildModLogRoute", mock.Anything, mock.Anything).Return("", nil)
 	t.db.On("GetAntiraidVerification", mock.Anything).Return(false, nil)
 	t.db.On("AddToAntiraidJoinList", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
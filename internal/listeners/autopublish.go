@@ -0,0 +1,168 @@
+package listeners
+
+import (
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/sarulabs/di/v2"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/services/guildlog"
+	"github.com/zekroTJA/shinpuru/internal/services/timeprovider"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/pkg/discordutil"
+	"github.com/zekroTJA/timedmap"
+	"github.com/zekrotja/dgrs"
+	"github.com/zekrotja/rogu"
+	"github.com/zekrotja/rogu/log"
+)
+
+const (
+	// autoPublishLimit is the number of messages which
+	// can be published per channel in autoPublishWindow
+	// according to Discord's crosspost rate limit.
+	autoPublishLimit  = 10
+	autoPublishWindow = 1 * time.Hour
+	// autoPublishWarnCooldown is the time after which a
+	// misconfiguration is logged again for the same
+	// channel to avoid flooding the guild log.
+	autoPublishWarnCooldown = 1 * time.Hour
+)
+
+type autoPublishBudget struct {
+	reset  time.Time
+	used   int
+	warned bool
+}
+
+type ListenerAutoPublish struct {
+	db  database.Database
+	st  dgrs.IState
+	tp  timeprovider.Provider
+	gl  guildlog.Logger
+	log rogu.Logger
+
+	mtx       sync.Mutex
+	budgets   *timedmap.TimedMap
+	permWarns *timedmap.TimedMap
+	// typeWarns contains the channels which have been
+	// warned about not being announcement channels.
+	typeWarns *timedmap.TimedMap
+}
+
+func NewListenerAutoPublish(container di.Container) *ListenerAutoPublish {
+	return &ListenerAutoPublish{
+		db:  container.Get(static.DiDatabase).(database.Database),
+		st:  container.Get(static.DiState).(dgrs.IState),
+		tp:  container.Get(static.DiTimeProvider).(timeprovider.Provider),
+		gl:  container.Get(static.DiGuildLog).(guildlog.Logger).Section("autopublish"),
+		log: log.Tagged("AutoPublish"),
+
+		budgets:   timedmap.New(10 * time.Minute),
+		permWarns: timedmap.New(10 * time.Minute),
+		typeWarns: timedmap.New(10 * time.Minute),
+	}
+}
+
+func (l *ListenerAutoPublish) HandlerMessageCreate(s discordutil.ISession, e *discordgo.MessageCreate) {
+	if e.GuildID == "" || e.Author == nil {
+		return
+	}
+
+	if e.Type != discordgo.MessageTypeDefault && e.Type != discordgo.MessageTypeReply {
+		return
+	}
+
+	if e.Flags&(discordgo.MessageFlagsCrossPosted|discordgo.MessageFlagsIsCrossPosted) != 0 {
+		return
+	}
+
+	cfg, err := l.db.GetAutoPublish(e.GuildID, e.ChannelID)
+	if database.IsErrDatabaseNotFound(err) {
+		return
+	}
+	if err != nil {
+		l.log.Error().Err(err).Fields("gid", e.GuildID, "cid", e.ChannelID).Msg("Failed getting auto publish config")
+		return
+	}
+
+	if cfg.SkipBots && (e.Author.Bot || e.WebhookID != "") {
+		return
+	}
+
+	ch, err := l.st.Channel(e.ChannelID)
+	if err != nil {
+		l.log.Error().Err(err).Fields("gid", e.GuildID, "cid", e.ChannelID).Msg("Failed getting channel")
+		return
+	}
+	if ch.Type != discordgo.ChannelTypeGuildNews {
+		l.warnChannelType(e.GuildID, e.ChannelID)
+		return
+	}
+
+	if !l.takeBudget(e.GuildID, e.ChannelID) {
+		return
+	}
+
+	_, err = s.ChannelMessageCrosspost(e.ChannelID, e.ID)
+	if discordutil.IsErrCode(err, discordgo.ErrCodeMissingPermissions) ||
+		discordutil.IsErrCode(err, discordgo.ErrCodeMissingAccess) {
+		l.warnPermission(e.GuildID, e.ChannelID)
+		return
+	}
+	if discordutil.IsErrCode(err, discordgo.ErrCodeMessageAlreadyCrossposted) {
+		return
+	}
+	if err != nil {
+		l.log.Error().Err(err).Fields("gid", e.GuildID, "cid", e.ChannelID).Msg("Failed publishing message")
+	}
+}
+
+// takeBudget returns true if a message can be published
+// in the channel without exceeding the crosspost rate
+// limit. When the limit is reached, a warning is logged
+// once per window.
+func (l *ListenerAutoPublish) takeBudget(guildID, channelID string) bool {
+	now := l.tp.Now()
+
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	b, ok := l.budgets.GetValue(channelID).(*autoPublishBudget)
+	if !ok || !now.Before(b.reset) {
+		b = &autoPublishBudget{reset: now.Add(autoPublishWindow)}
+		l.budgets.Set(channelID, b, autoPublishWindow)
+	}
+
+	if b.used >= autoPublishLimit {
+		if !b.warned {
+			b.warned = true
+			l.gl.Warnf(guildID,
+				"Publish rate limit reached in channel %s; new messages are not published until %s",
+				channelID, b.reset.Format(time.RFC1123))
+		}
+		return false
+	}
+
+	b.used++
+	return true
+}
+
+func (l *ListenerAutoPublish) warnChannelType(guildID, channelID string) {
+	if l.typeWarns.Contains(channelID) {
+		return
+	}
+	l.typeWarns.Set(channelID, struct{}{}, autoPublishWarnCooldown)
+
+	l.gl.Warnf(guildID,
+		"Channel %s is configured for auto publish but is not an announcement channel", channelID)
+}
+
+func (l *ListenerAutoPublish) warnPermission(guildID, channelID string) {
+	if l.permWarns.Contains(channelID) {
+		return
+	}
+	l.permWarns.Set(channelID, struct{}{}, autoPublishWarnCooldown)
+
+	l.gl.Warnf(guildID, "Missing permission to publish messages in channel %s", channelID)
+}
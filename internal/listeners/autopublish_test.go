@@ -0,0 +1,131 @@
+package listeners
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/sarulabs/di/v2"
+	"github.com/stretchr/testify/mock"
+	"github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/mocks"
+)
+
+type autoPublishMock struct {
+	session *mocks.ISession
+	db      *mocks.Database
+	state   *mocks.IState
+	tp      *mocks.TimeProvider
+	logger  *mocks.Logger
+
+	ct di.Container
+}
+
+func getAutoPublishMock(now *time.Time, f ...func(m autoPublishMock)) autoPublishMock {
+	var t autoPublishMock
+
+	t.session = &mocks.ISession{}
+	t.db = &mocks.Database{}
+	t.state = &mocks.IState{}
+	t.tp = &mocks.TimeProvider{}
+	t.logger = &mocks.Logger{}
+
+	t.tp.On("Now").Return(func() time.Time { return *now })
+	t.logger.On("Warnf", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	t.logger.On("Warnf", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	t.logger.On("Section", mock.Anything).Return(t.logger)
+
+	if len(f) != 0 {
+		f[0](t)
+	}
+
+	ct, _ := di.NewBuilder()
+	ct.Add(
+		di.Def{
+			Name:  static.DiDatabase,
+			Build: func(ctn di.Container) (interface{}, error) { return t.db, nil },
+		},
+		di.Def{
+			Name:  static.DiState,
+			Build: func(ctn di.Container) (interface{}, error) { return t.state, nil },
+		},
+		di.Def{
+			Name:  static.DiTimeProvider,
+			Build: func(ctn di.Container) (interface{}, error) { return t.tp, nil },
+		},
+		di.Def{
+			Name:  static.DiGuildLog,
+			Build: func(ctn di.Container) (interface{}, error) { return t.logger, nil },
+		},
+	)
+
+	t.ct = ct.Build()
+
+	return t
+}
+
+func TestAutoPublishHandlerMessageCreate(t *testing.T) {
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	m := getAutoPublishMock(&now, func(t autoPublishMock) {
+		t.db.On("GetAutoPublish", "guild-id", "channel-news").Return(models.AutoPublishConfig{
+			GuildID:   "guild-id",
+			ChannelID: "channel-news",
+			SkipBots:  true,
+		}, nil)
+		t.db.On("GetAutoPublish", "guild-id", "channel-text").Return(models.AutoPublishConfig{
+			GuildID:   "guild-id",
+			ChannelID: "channel-text",
+		}, nil)
+		t.db.On("GetAutoPublish", "guild-id", mock.Anything).
+			Return(models.AutoPublishConfig{}, database.ErrDatabaseNotFound)
+
+		t.state.On("Channel", "channel-news").
+			Return(&discordgo.Channel{ID: "channel-news", Type: discordgo.ChannelTypeGuildNews}, nil)
+		t.state.On("Channel", "channel-text").
+			Return(&discordgo.Channel{ID: "channel-text", Type: discordgo.ChannelTypeGuildText}, nil)
+
+		t.session.On("ChannelMessageCrosspost", mock.Anything, mock.Anything).
+			Return(&discordgo.Message{}, nil)
+	})
+
+	l := NewListenerAutoPublish(m.ct)
+
+	getMessage := func(channelID string, bot bool) *discordgo.MessageCreate {
+		return &discordgo.MessageCreate{Message: &discordgo.Message{
+			ID:        "msg-id",
+			GuildID:   "guild-id",
+			ChannelID: channelID,
+			Author:    &discordgo.User{ID: "user-id", Bot: bot},
+		}}
+	}
+
+	// Not configured channel
+	l.HandlerMessageCreate(m.session, getMessage("channel-other", false))
+	m.session.AssertNotCalled(t, "ChannelMessageCrosspost", mock.Anything, mock.Anything)
+
+	// Skipped bot messages
+	l.HandlerMessageCreate(m.session, getMessage("channel-news", true))
+	m.session.AssertNotCalled(t, "ChannelMessageCrosspost", mock.Anything, mock.Anything)
+
+	// Not an announcement channel is only warned once
+	l.HandlerMessageCreate(m.session, getMessage("channel-text", false))
+	l.HandlerMessageCreate(m.session, getMessage("channel-text", false))
+	m.session.AssertNotCalled(t, "ChannelMessageCrosspost", mock.Anything, mock.Anything)
+	m.logger.AssertNumberOfCalls(t, "Warnf", 1)
+
+	// Published until the rate limit is reached
+	for i := 0; i < autoPublishLimit+2; i++ {
+		l.HandlerMessageCreate(m.session, getMessage("channel-news", false))
+	}
+	m.session.AssertNumberOfCalls(t, "ChannelMessageCrosspost", autoPublishLimit)
+	m.session.AssertCalled(t, "ChannelMessageCrosspost", "channel-news", "msg-id")
+	m.logger.AssertNumberOfCalls(t, "Warnf", 2)
+
+	// Published again after the window
+	now = now.Add(autoPublishWindow)
+	l.HandlerMessageCreate(m.session, getMessage("channel-news", false))
+	m.session.AssertNumberOfCalls(t, "ChannelMessageCrosspost", autoPublishLimit+1)
+}
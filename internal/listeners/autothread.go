@@ -0,0 +1,118 @@
+package listeners
+
+import (
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/sarulabs/di/v2"
+	"github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/services/guildlog"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/pkg/discordutil"
+	"github.com/zekroTJA/timedmap"
+	"github.com/zekrotja/rogu"
+	"github.com/zekrotja/rogu/log"
+)
+
+const (
+	autoThreadMaxNameLen      = 100
+	autoThreadArchiveDuration = 24 * 60
+	// autoThreadPermWarnCooldown is the time after which
+	// a missing permission is logged again for the same
+	// channel to avoid flooding the guild log.
+	autoThreadPermWarnCooldown = 1 * time.Hour
+)
+
+type ListenerAutoThread struct {
+	db  database.Database
+	gl  guildlog.Logger
+	log rogu.Logger
+
+	cooldowns *timedmap.TimedMap
+	permWarns *timedmap.TimedMap
+}
+
+func NewListenerAutoThread(container di.Container) *ListenerAutoThread {
+	return &ListenerAutoThread{
+		db:  container.Get(static.DiDatabase).(database.Database),
+		gl:  container.Get(static.DiGuildLog).(guildlog.Logger).Section("autothread"),
+		log: log.Tagged("AutoThread"),
+
+		cooldowns: timedmap.New(10 * time.Minute),
+		permWarns: timedmap.New(10 * time.Minute),
+	}
+}
+
+func (l *ListenerAutoThread) HandlerMessageCreate(s discordutil.ISession, e *discordgo.MessageCreate) {
+	if e.GuildID == "" || e.Author == nil || e.Author.Bot || e.WebhookID != "" {
+		return
+	}
+
+	if e.Type != discordgo.MessageTypeDefault && e.Type != discordgo.MessageTypeReply {
+		return
+	}
+
+	cfg, err := l.db.GetAutoThread(e.GuildID, e.ChannelID)
+	if database.IsErrDatabaseNotFound(err) {
+		return
+	}
+	if err != nil {
+		l.log.Error().Err(err).Fields("gid", e.GuildID, "cid", e.ChannelID).Msg("Failed getting auto thread config")
+		return
+	}
+
+	if cfg.AttachmentsOnly && len(e.Attachments) == 0 {
+		return
+	}
+
+	cooldownKey := e.ChannelID + e.Author.ID
+	if l.cooldowns.Contains(cooldownKey) {
+		return
+	}
+
+	_, err = s.MessageThreadStartComplex(e.ChannelID, e.ID, &discordgo.ThreadStart{
+		Name:                autoThreadName(cfg, e.Message),
+		AutoArchiveDuration: autoThreadArchiveDuration,
+	})
+	if discordutil.IsErrCode(err, discordgo.ErrCodeMissingPermissions) ||
+		discordutil.IsErrCode(err, discordgo.ErrCodeMissingAccess) {
+		if !l.permWarns.Contains(e.ChannelID) {
+			l.permWarns.Set(e.ChannelID, struct{}{}, autoThreadPermWarnCooldown)
+			l.gl.Warnf(e.GuildID, "Missing permission to create threads in channel %s", e.ChannelID)
+		}
+		return
+	}
+	if err != nil {
+		l.log.Error().Err(err).Fields("gid", e.GuildID, "cid", e.ChannelID).Msg("Failed creating thread")
+		return
+	}
+
+	if cfg.Cooldown > 0 {
+		l.cooldowns.Set(cooldownKey, struct{}{}, cfg.Cooldown)
+	}
+}
+
+// autoThreadName returns the name of the thread to be
+// created for msg depending on the name source of the
+// given config.
+func autoThreadName(cfg models.AutoThreadConfig, msg *discordgo.Message) (name string) {
+	if cfg.NameSource != models.AutoThreadNameAuthor {
+		for _, line := range strings.Split(msg.ContentWithMentionsReplaced(), "\n") {
+			if name = strings.TrimSpace(line); name != "" {
+				break
+			}
+		}
+	}
+
+	if name == "" {
+		name = msg.Author.Username
+	}
+
+	if r := []rune(name); len(r) > autoThreadMaxNameLen {
+		name = string(r[:autoThreadMaxNameLen-1]) + "…"
+	}
+
+	return name
+}
@@ -0,0 +1,157 @@
+package listeners
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/sarulabs/di/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/mocks"
+)
+
+type autoThreadMock struct {
+	session *mocks.ISession
+	db      *mocks.Database
+	logger  *mocks.Logger
+
+	ct di.Container
+}
+
+func getAutoThreadMock(f ...func(m autoThreadMock)) autoThreadMock {
+	var t autoThreadMock
+
+	t.session = &mocks.ISession{}
+	t.db = &mocks.Database{}
+	t.logger = &mocks.Logger{}
+
+	t.logger.On("Warnf", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	t.logger.On("Section", mock.Anything).Return(t.logger)
+
+	if len(f) != 0 {
+		f[0](t)
+	}
+
+	ct, _ := di.NewBuilder()
+	ct.Add(
+		di.Def{
+			Name:  static.DiDatabase,
+			Build: func(ctn di.Container) (interface{}, error) { return t.db, nil },
+		},
+		di.Def{
+			Name:  static.DiGuildLog,
+			Build: func(ctn di.Container) (interface{}, error) { return t.logger, nil },
+		},
+	)
+
+	t.ct = ct.Build()
+
+	return t
+}
+
+func TestAutoThreadHandlerMessageCreate(t *testing.T) {
+	m := getAutoThreadMock(func(t autoThreadMock) {
+		t.db.On("GetAutoThread", "guild-id", "channel-id").Return(models.AutoThreadConfig{
+			GuildID:    "guild-id",
+			ChannelID:  "channel-id",
+			NameSource: models.AutoThreadNameContent,
+			Cooldown:   time.Minute,
+		}, nil)
+		t.db.On("GetAutoThread", "guild-id", "channel-attachments").Return(models.AutoThreadConfig{
+			GuildID:         "guild-id",
+			ChannelID:       "channel-attachments",
+			AttachmentsOnly: true,
+			NameSource:      models.AutoThreadNameAuthor,
+		}, nil)
+		t.db.On("GetAutoThread", "guild-id", "channel-noperm").Return(models.AutoThreadConfig{
+			GuildID:    "guild-id",
+			ChannelID:  "channel-noperm",
+			NameSource: models.AutoThreadNameContent,
+		}, nil)
+		t.db.On("GetAutoThread", "guild-id", mock.Anything).
+			Return(models.AutoThreadConfig{}, database.ErrDatabaseNotFound)
+
+		t.session.On("MessageThreadStartComplex", "channel-noperm", mock.Anything, mock.Anything).
+			Return(nil, &discordgo.RESTError{Message: &discordgo.APIErrorMessage{
+				Code: discordgo.ErrCodeMissingPermissions,
+			}})
+		t.session.On("MessageThreadStartComplex", mock.Anything, mock.Anything, mock.Anything).
+			Return(&discordgo.Channel{}, nil)
+	})
+
+	l := NewListenerAutoThread(m.ct)
+
+	getMessage := func(channelID, userID string, attachments bool) *discordgo.MessageCreate {
+		msg := &discordgo.Message{
+			ID:        "msg-id",
+			GuildID:   "guild-id",
+			ChannelID: channelID,
+			Content:   "hello",
+			Author:    &discordgo.User{ID: userID, Username: "user"},
+		}
+		if attachments {
+			msg.Attachments = []*discordgo.MessageAttachment{{}}
+		}
+		return &discordgo.MessageCreate{Message: msg}
+	}
+
+	// Not configured channel
+	l.HandlerMessageCreate(m.session, getMessage("channel-other", "user-1", false))
+	m.session.AssertNotCalled(t, "MessageThreadStartComplex", "channel-other", mock.Anything, mock.Anything)
+
+	// Bot messages
+	e := getMessage("channel-id", "bot", false)
+	e.Author.Bot = true
+	l.HandlerMessageCreate(m.session, e)
+	m.session.AssertNotCalled(t, "MessageThreadStartComplex", mock.Anything, mock.Anything, mock.Anything)
+
+	// Created and then on cooldown for the same user
+	l.HandlerMessageCreate(m.session, getMessage("channel-id", "user-1", false))
+	l.HandlerMessageCreate(m.session, getMessage("channel-id", "user-1", false))
+	l.HandlerMessageCreate(m.session, getMessage("channel-id", "user-2", false))
+	m.session.AssertNumberOfCalls(t, "MessageThreadStartComplex", 2)
+	m.session.AssertCalled(t, "MessageThreadStartComplex", "channel-id", "msg-id", &discordgo.ThreadStart{
+		Name:                "hello",
+		AutoArchiveDuration: autoThreadArchiveDuration,
+	})
+
+	// Attachments only
+	l.HandlerMessageCreate(m.session, getMessage("channel-attachments", "user-1", false))
+	m.session.AssertNumberOfCalls(t, "MessageThreadStartComplex", 2)
+	l.HandlerMessageCreate(m.session, getMessage("channel-attachments", "user-1", true))
+	m.session.AssertCalled(t, "MessageThreadStartComplex", "channel-attachments", "msg-id", &discordgo.ThreadStart{
+		Name:                "user",
+		AutoArchiveDuration: autoThreadArchiveDuration,
+	})
+
+	// Missing permission is only logged once
+	l.HandlerMessageCreate(m.session, getMessage("channel-noperm", "user-1", false))
+	l.HandlerMessageCreate(m.session, getMessage("channel-noperm", "user-2", false))
+	m.logger.AssertNumberOfCalls(t, "Warnf", 1)
+}
+
+func TestAutoThreadName(t *testing.T) {
+	cfg := models.AutoThreadConfig{NameSource: models.AutoThreadNameContent}
+	msg := &discordgo.Message{
+		Content: "\n  first line  \nsecond line",
+		Author:  &discordgo.User{Username: "user"},
+	}
+
+	assert.Equal(t, "first line", autoThreadName(cfg, msg))
+
+	msg.Content = ""
+	assert.Equal(t, "user", autoThreadName(cfg, msg))
+
+	msg.Content = strings.Repeat("a", 150)
+	name := autoThreadName(cfg, msg)
+	assert.Equal(t, autoThreadMaxNameLen, len([]rune(name)))
+	assert.True(t, strings.HasSuffix(name, "…"))
+
+	cfg.NameSource = models.AutoThreadNameAuthor
+	assert.Equal(t, "user", autoThreadName(cfg, msg))
+}
@@ -14,7 +14,7 @@ import (
 
 type ListenerAutoVoice struct {
 	db              database.Database
-	st              *dgrs.State
+	st              dgrs.IState
 	pmw             *permissions.Permissions
 	autovcCache     map[string]string
 	voiceStateCache map[string]*discordgo.VoiceState
@@ -23,7 +23,7 @@ type ListenerAutoVoice struct {
 func NewListenerAutoVoice(container di.Container) *ListenerAutoVoice {
 	return &ListenerAutoVoice{
 		db:              container.Get(static.DiDatabase).(database.Database),
-		st:              container.Get(static.DiState).(*dgrs.State),
+		st:              container.Get(static.DiState).(dgrs.IState),
 		pmw:             container.Get(static.DiPermissions).(*permissions.Permissions),
 		autovcCache:     map[string]string{},
 		voiceStateCache: map[string]*discordgo.VoiceState{},
@@ -17,7 +17,7 @@ import (
 
 type ListenerBotMention struct {
 	config config.Provider
-	st     *dgrs.State
+	st     dgrs.IState
 	tp     timeprovider.Provider
 
 	idLen int32
@@ -26,7 +26,7 @@ type ListenerBotMention struct {
 func NewListenerBotMention(container di.Container) *ListenerBotMention {
 	return &ListenerBotMention{
 		config: container.Get(static.DiConfig).(config.Provider),
-		st:     container.Get(static.DiState).(*dgrs.State),
+		st:     container.Get(static.DiState).(dgrs.IState),
 		tp:     container.Get(static.DiTimeProvider).(timeprovider.Provider),
 		idLen:  0,
 	}
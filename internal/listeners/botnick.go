@@ -0,0 +1,93 @@
+package listeners
+
+import (
+	"sync"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/sarulabs/di/v2"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/services/guildlog"
+	"github.com/zekroTJA/shinpuru/internal/util/botnick"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/pkg/discordutil"
+	"github.com/zekrotja/dgrs"
+	"github.com/zekrotja/rogu"
+	"github.com/zekrotja/rogu/log"
+)
+
+type ListenerBotNick struct {
+	db  database.Database
+	st  dgrs.IState
+	gl  guildlog.Logger
+	log rogu.Logger
+
+	// warned contains the IDs of the guilds in which
+	// the missing permission has been reported already.
+	warned sync.Map
+}
+
+func NewListenerBotNick(container di.Container) *ListenerBotNick {
+	return &ListenerBotNick{
+		db:  container.Get(static.DiDatabase).(database.Database),
+		st:  container.Get(static.DiState).(dgrs.IState),
+		gl:  container.Get(static.DiGuildLog).(guildlog.Logger).Section("botnick"),
+		log: log.Tagged("BotNick"),
+	}
+}
+
+func (l *ListenerBotNick) HandlerGuildCreate(s discordutil.ISession, e *discordgo.GuildCreate) {
+	self, err := l.st.SelfUser()
+	if err != nil {
+		l.log.Error().Err(err).Msg("Failed getting self user")
+		return
+	}
+
+	var member *discordgo.Member
+	for _, m := range e.Members {
+		if m.User != nil && m.User.ID == self.ID {
+			member = m
+			break
+		}
+	}
+	if member == nil {
+		if member, err = l.st.Member(e.ID, self.ID); err != nil {
+			l.log.Error().Err(err).Field("gid", e.ID).Msg("Failed getting self member")
+			return
+		}
+	}
+
+	l.apply(s, e.ID, member.Nick)
+}
+
+func (l *ListenerBotNick) HandlerMemberUpdate(s discordutil.ISession, e *discordgo.GuildMemberUpdate) {
+	if e.Member == nil || e.User == nil {
+		return
+	}
+
+	self, err := l.st.SelfUser()
+	if err != nil {
+		l.log.Error().Err(err).Msg("Failed getting self user")
+		return
+	}
+	if e.User.ID != self.ID {
+		return
+	}
+
+	l.apply(s, e.GuildID, e.Nick)
+}
+
+func (l *ListenerBotNick) apply(s discordutil.ISession, guildID, current string) {
+	err := botnick.ApplyConfigured(s, l.st, l.db, guildID, current)
+	if err == botnick.ErrMissingPermission {
+		if _, warned := l.warned.LoadOrStore(guildID, struct{}{}); !warned {
+			l.gl.Warnf(guildID, "Failed applying the configured bot nickname: %s", err.Error())
+		}
+		return
+	}
+	if err != nil {
+		l.log.Error().Err(err).Field("gid", guildID).Msg("Failed applying bot nickname")
+		return
+	}
+
+	l.warned.Delete(guildID)
+}
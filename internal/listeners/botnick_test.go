@@ -0,0 +1,104 @@
+package listeners
+
+import (
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/sarulabs/di/v2"
+	"github.com/stretchr/testify/mock"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/mocks"
+)
+
+type botNickMock struct {
+	session *mocks.ISession
+	db      *mocks.Database
+	state   *mocks.IState
+	logger  *mocks.Logger
+
+	ct di.Container
+}
+
+func getBotNickMock() botNickMock {
+	var t botNickMock
+
+	t.session = &mocks.ISession{}
+	t.db = &mocks.Database{}
+	t.state = &mocks.IState{}
+	t.logger = &mocks.Logger{}
+
+	t.logger.On("Section", mock.Anything).Return(t.logger)
+	t.logger.On("Warnf", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	t.state.On("SelfUser").Return(&discordgo.User{ID: "self-id"}, nil)
+	for _, id := range []string{"guild-id", "guild-noperm"} {
+		t.state.On("Guild", id).Return(&discordgo.Guild{ID: id, OwnerID: "owner-id"}, nil)
+		t.state.On("Member", id, "self-id").Return(&discordgo.Member{Roles: []string{"role-nick"}}, nil)
+	}
+	t.state.On("Roles", "guild-id").Return([]*discordgo.Role{
+		{ID: "guild-id"},
+		{ID: "role-nick", Permissions: discordgo.PermissionChangeNickname},
+	}, nil)
+	t.state.On("Roles", "guild-noperm").Return([]*discordgo.Role{
+		{ID: "guild-noperm"},
+		{ID: "role-nick"},
+	}, nil)
+
+	t.db.On("GetGuildBotNickname", "guild-id").Return("shinpuru", nil)
+	t.db.On("GetGuildBotNickname", "guild-noperm").Return("shinpuru", nil)
+	t.db.On("GetGuildBotNickname", mock.Anything).Return("", database.ErrDatabaseNotFound)
+
+	t.session.On("GuildMemberNickname", "guild-id", "@me", "shinpuru").Return(nil)
+
+	ct, _ := di.NewBuilder()
+	ct.Add(
+		di.Def{
+			Name:  static.DiDatabase,
+			Build: func(ctn di.Container) (interface{}, error) { return t.db, nil },
+		},
+		di.Def{
+			Name:  static.DiState,
+			Build: func(ctn di.Container) (interface{}, error) { return t.state, nil },
+		},
+		di.Def{
+			Name:  static.DiGuildLog,
+			Build: func(ctn di.Container) (interface{}, error) { return t.logger, nil },
+		},
+	)
+
+	t.ct = ct.Build()
+
+	return t
+}
+
+func TestBotNickHandlerMemberUpdate(t *testing.T) {
+	m := getBotNickMock()
+	l := NewListenerBotNick(m.ct)
+
+	getUpdate := func(guildID, userID, nick string) *discordgo.GuildMemberUpdate {
+		return &discordgo.GuildMemberUpdate{Member: &discordgo.Member{
+			GuildID: guildID,
+			User:    &discordgo.User{ID: userID},
+			Nick:    nick,
+		}}
+	}
+
+	// Other members and matching nicknames are ignored
+	l.HandlerMemberUpdate(m.session, getUpdate("guild-id", "other-id", "other"))
+	l.HandlerMemberUpdate(m.session, getUpdate("guild-id", "self-id", "shinpuru"))
+	l.HandlerMemberUpdate(m.session, getUpdate("guild-other", "self-id", "other"))
+	m.session.AssertNotCalled(t, "GuildMemberNickname", mock.Anything, mock.Anything, mock.Anything)
+
+	// Reset nickname is re-applied
+	l.HandlerMemberUpdate(m.session, getUpdate("guild-id", "self-id", ""))
+	m.session.AssertCalled(t, "GuildMemberNickname", "guild-id", "@me", "shinpuru")
+
+	// Missing permission is reported to the guild log once
+	// and the nickname is not tried to be changed
+	l.HandlerMemberUpdate(m.session, getUpdate("guild-noperm", "self-id", ""))
+	l.HandlerMemberUpdate(m.session, getUpdate("guild-noperm", "self-id", ""))
+	m.logger.AssertNumberOfCalls(t, "Warnf", 1)
+	m.logger.AssertCalled(t, "Warnf", "guild-noperm", mock.Anything, mock.Anything)
+	m.session.AssertNotCalled(t, "GuildMemberNickname", "guild-noperm", mock.Anything, mock.Anything)
+}
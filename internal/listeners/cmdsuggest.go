@@ -0,0 +1,219 @@
+package listeners
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/sarulabs/di/v2"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/services/permissions"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/pkg/slices"
+	"github.com/zekrotja/ken"
+	"github.com/zekrotja/rogu"
+	"github.com/zekrotja/rogu/log"
+)
+
+type ListenerCommandSuggest struct {
+	db  database.Database
+	pmw *permissions.Permissions
+	ken *ken.Ken
+	log rogu.Logger
+}
+
+func NewListenerCommandSuggest(container di.Container) *ListenerCommandSuggest {
+	return &ListenerCommandSuggest{
+		db:  container.Get(static.DiDatabase).(database.Database),
+		pmw: container.Get(static.DiPermissions).(*permissions.Permissions),
+		ken: container.Get(static.DiCommandHandler).(*ken.Ken),
+		log: log.Tagged("CmdSuggest"),
+	}
+}
+
+// HandlerMessageCreate suggests the closest slash command
+// when a message starts with the legacy guild prefix.
+//
+// In prefix-less channels, messages without the prefix are
+// handled as well. To not respond to regular chatter, only
+// messages starting with the exact name of a command are
+// considered there.
+func (l *ListenerCommandSuggest) HandlerMessageCreate(s *discordgo.Session, e *discordgo.MessageCreate) {
+	if e.Author == nil || e.Author.Bot || e.GuildID == "" {
+		return
+	}
+
+	input, prefixless, ok := l.commandInput(e.GuildID, e.ChannelID, e.Content)
+	if !ok {
+		return
+	}
+
+	commands, err := l.permittedCommands(s, e.GuildID, e.Author.ID)
+	if err != nil {
+		l.log.Error().Err(err).Field("gid", e.GuildID).Msg("Failed getting permitted commands")
+		return
+	}
+
+	desc, ok := suggestion(input, prefixless, commands)
+	if !ok {
+		return
+	}
+
+	s.ChannelMessageSendEmbedReply(e.ChannelID, &discordgo.MessageEmbed{
+		Color:       static.ColorEmbedDefault,
+		Description: desc,
+	}, e.Reference())
+}
+
+// commandInput returns the part of the message content
+// which is checked for command suggestions. ok is false
+// when suggestions are disabled on the guild or when the
+// message neither starts with the guild prefix nor has
+// been sent in a prefix-less channel.
+func (l *ListenerCommandSuggest) commandInput(guildID, channelID, content string) (input string, prefixless, ok bool) {
+	disabled, err := l.db.GetGuildCommandSuggestionsDisable(guildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		l.log.Error().Err(err).Field("gid", guildID).Msg("Failed getting command suggestions state")
+		return
+	}
+	if disabled {
+		return
+	}
+
+	prefix, err := l.db.GetGuildPrefix(guildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		l.log.Error().Err(err).Field("gid", guildID).Msg("Failed getting guild prefix")
+		return
+	}
+	if prefix != "" && strings.HasPrefix(content, prefix) {
+		return content[len(prefix):], false, true
+	}
+
+	channels, err := l.db.GetPrefixlessChannels(guildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		l.log.Error().Err(err).Field("gid", guildID).Msg("Failed getting prefixless channels")
+		return
+	}
+	if !slices.Contains(channels, channelID) {
+		return
+	}
+
+	return content, true, true
+}
+
+// suggestion returns the description of the suggestion
+// for the given input. In prefix-less channels, only
+// inputs starting with the exact name of a command are
+// answered. ok is false if nothing should be suggested.
+func suggestion(input string, prefixless bool, commands []string) (desc string, ok bool) {
+	fields := strings.Fields(input)
+	if len(fields) == 0 {
+		return
+	}
+
+	name := strings.ToLower(fields[0])
+	if prefixless {
+		if !slices.Contains(commands, name) {
+			return
+		}
+		desc = fmt.Sprintf("`%s` is available as slash command `/%s`.\n"+
+			"shinpuru uses slash commands, so type `/` in the chat to see all available commands.", name, name)
+		return desc, true
+	}
+
+	if name, ok = suggest(name, commands); !ok {
+		return
+	}
+	desc = fmt.Sprintf("Did you mean `/%s`?\n"+
+		"shinpuru uses slash commands, so type `/` in the chat to see all available commands.", name)
+	return desc, true
+}
+
+// permittedCommands returns the names of all commands
+// the user is permitted to execute.
+func (l *ListenerCommandSuggest) permittedCommands(s *discordgo.Session, guildID, userID string) ([]string, error) {
+	perms, _, err := l.pmw.GetPermissions(s, guildID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	infos := l.ken.GetCommandInfo()
+	commands := make([]string, 0, len(infos))
+	for _, info := range infos {
+		rDomain := info.Implementations["Domain"]
+		if len(rDomain) != 1 {
+			continue
+		}
+		domain, ok := rDomain[0].(string)
+		if !ok || !perms.Check(domain) {
+			continue
+		}
+		commands = append(commands, info.ApplicationCommand.Name)
+	}
+
+	return commands, nil
+}
+
+// suggest returns the command name which is closest to
+// the given input by Levenshtein distance. ok is false
+// when no command is within the threshold, which is a
+// third of the input length but at least 2. The distance
+// must also be smaller than the input length so that
+// very short inputs do not match arbitrary commands.
+//
+// When multiple commands have the same distance, the
+// lexicographically smallest one is returned.
+func suggest(input string, commands []string) (name string, ok bool) {
+	inputLen := len([]rune(input))
+	threshold := inputLen / 3
+	if threshold < 2 {
+		threshold = 2
+	}
+
+	best := -1
+	for _, cmd := range commands {
+		d := levenshtein(input, cmd)
+		if d > threshold || d >= inputLen {
+			continue
+		}
+		if best < 0 || d < best || (d == best && cmd < name) {
+			best = d
+			name = cmd
+		}
+	}
+
+	return name, best >= 0
+}
+
+// levenshtein returns the minimum number of single
+// rune insertions, deletions or substitutions required
+// to change a into b.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = prev[j] + 1
+			if v := curr[j-1] + 1; v < curr[j] {
+				curr[j] = v
+			}
+			if v := prev[j-1] + cost; v < curr[j] {
+				curr[j] = v
+			}
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
@@ -0,0 +1,117 @@
+package listeners
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/zekroTJA/shinpuru/mocks"
+	"github.com/zekrotja/rogu/log"
+)
+
+func TestLevenshtein(t *testing.T) {
+	assert.Equal(t, 0, levenshtein("", ""))
+	assert.Equal(t, 0, levenshtein("ban", "ban"))
+	assert.Equal(t, 3, levenshtein("", "ban"))
+	assert.Equal(t, 3, levenshtein("ban", ""))
+	assert.Equal(t, 2, levenshtein("bna", "ban"))
+	assert.Equal(t, 3, levenshtein("kitten", "sitting"))
+	assert.Equal(t, 1, levenshtein("über", "uber"))
+}
+
+func TestSuggest(t *testing.T) {
+	commands := []string{"ban", "kick", "mute", "report", "karma", "help"}
+
+	name, ok := suggest("bna", commands)
+	assert.True(t, ok)
+	assert.Equal(t, "ban", name)
+
+	name, ok = suggest("reprot", commands)
+	assert.True(t, ok)
+	assert.Equal(t, "report", name)
+
+	name, ok = suggest("krama", commands)
+	assert.True(t, ok)
+	assert.Equal(t, "karma", name)
+
+	name, ok = suggest("help", commands)
+	assert.True(t, ok)
+	assert.Equal(t, "help", name)
+
+	_, ok = suggest("xyz", commands)
+	assert.False(t, ok)
+
+	_, ok = suggest("configuration", commands)
+	assert.False(t, ok)
+
+	// Distances smaller than the threshold but not
+	// smaller than the input length do not match.
+	_, ok = suggest("b", commands)
+	assert.False(t, ok)
+
+	_, ok = suggest("ban", nil)
+	assert.False(t, ok)
+
+	// On equal distances, the lexicographically
+	// smallest command is chosen.
+	name, ok = suggest("mote", []string{"mute", "mate"})
+	assert.True(t, ok)
+	assert.Equal(t, "mate", name)
+}
+
+func getCommandSuggestListener(disabled bool) (*ListenerCommandSuggest, *mocks.Database) {
+	db := &mocks.Database{}
+	db.On("GetGuildCommandSuggestionsDisable", "guild").Return(disabled, nil)
+	db.On("GetGuildPrefix", "guild").Return("!", nil)
+	db.On("GetPrefixlessChannels", "guild").Return([]string{"prefixless"}, nil)
+
+	return &ListenerCommandSuggest{db: db, log: log.Tagged("Test")}, db
+}
+
+func TestCommandInput(t *testing.T) {
+	l, _ := getCommandSuggestListener(false)
+
+	input, prefixless, ok := l.commandInput("guild", "channel", "!bna @user")
+	assert.True(t, ok)
+	assert.False(t, prefixless)
+	assert.Equal(t, "bna @user", input)
+
+	input, prefixless, ok = l.commandInput("guild", "prefixless", "ban @user")
+	assert.True(t, ok)
+	assert.True(t, prefixless)
+	assert.Equal(t, "ban @user", input)
+
+	_, _, ok = l.commandInput("guild", "channel", "ban @user")
+	assert.False(t, ok)
+}
+
+func TestCommandInputDisabled(t *testing.T) {
+	l, db := getCommandSuggestListener(true)
+
+	_, _, ok := l.commandInput("guild", "prefixless", "ban @user")
+	assert.False(t, ok)
+
+	db.AssertNotCalled(t, "GetGuildPrefix", mock.Anything)
+	db.AssertNotCalled(t, "GetPrefixlessChannels", mock.Anything)
+}
+
+func TestSuggestion(t *testing.T) {
+	commands := []string{"ban", "kick", "mute"}
+
+	desc, ok := suggestion("bna @user", false, commands)
+	assert.True(t, ok)
+	assert.Contains(t, desc, "`/ban`")
+
+	desc, ok = suggestion("Ban @user", true, commands)
+	assert.True(t, ok)
+	assert.Contains(t, desc, "`/ban`")
+
+	_, ok = suggestion("bna @user", true, commands)
+	assert.False(t, ok)
+
+	_, ok = suggestion("hello there", true, commands)
+	assert.False(t, ok)
+
+	_, ok = suggestion("   ", false, commands)
+	assert.False(t, ok)
+}
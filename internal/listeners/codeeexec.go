@@ -1,6 +1,8 @@
 package listeners
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -9,6 +11,8 @@ import (
 	"github.com/sarulabs/di/v2"
 	"github.com/zekroTJA/timedmap"
 	"github.com/zekrotja/dgrs"
+	"github.com/zekrotja/rogu"
+	"github.com/zekrotja/rogu/log"
 	"github.com/zekrotja/sop"
 	"golang.org/x/time/rate"
 
@@ -16,6 +20,7 @@ import (
 	"github.com/zekroTJA/shinpuru/internal/services/config"
 	"github.com/zekroTJA/shinpuru/internal/services/database"
 	"github.com/zekroTJA/shinpuru/internal/services/permissions"
+	"github.com/zekroTJA/shinpuru/internal/services/storage"
 	"github.com/zekroTJA/shinpuru/internal/util"
 	"github.com/zekroTJA/shinpuru/internal/util/static"
 	"github.com/zekroTJA/shinpuru/pkg/discordutil"
@@ -30,6 +35,12 @@ const (
 
 	limitTMCleanupInterval = 30 * time.Second // 10 * time.Minute
 	limitTMLifetime        = 24 * time.Hour
+
+	// execInlineOutputLimit is the maximum size of an
+	// output displayed in an embed field. This leaves
+	// room for the code block and the output link
+	// within the field value limit of 1024 characters.
+	execInlineOutputLimit = 850
 )
 
 var (
@@ -51,8 +62,10 @@ type ListenerCodeexec struct {
 	db       database.Database
 	execFact codeexec.Factory
 	pmw      *permissions.Permissions
-	st       *dgrs.State
+	st       dgrs.IState
 	cfg      config.Provider
+	storage  storage.Storage
+	log      rogu.Logger
 
 	specs  models.SpecMap
 	limits *timedmap.TimedMap
@@ -75,8 +88,10 @@ func NewListenerJdoodle(container di.Container) (l *ListenerCodeexec, err error)
 	l.db = container.Get(static.DiDatabase).(database.Database)
 	l.pmw = container.Get(static.DiPermissions).(*permissions.Permissions)
 	l.execFact = container.Get(static.DiCodeExecFactory).(codeexec.Factory)
-	l.st = container.Get(static.DiState).(*dgrs.State)
+	l.st = container.Get(static.DiState).(dgrs.IState)
 	l.cfg = container.Get(static.DiConfig).(config.Provider)
+	l.storage = container.Get(static.DiObjectStorage).(storage.Storage)
+	l.log = log.Tagged("CodeExec")
 
 	l.limits = timedmap.New(limitTMCleanupInterval)
 	l.msgMap = timedmap.New(removeHandlerCleanupInterval)
@@ -110,12 +125,13 @@ func (l *ListenerCodeexec) handler(s *discordgo.Session, e *discordgo.Message) {
 		return
 	}
 
+	lang = strings.ToLower(lang)
 	_repl, ok := replaces[lang]
 	if ok {
 		lang = _repl
 	}
 
-	spec, isValidLang := l.specs.Get(lang)
+	lang, spec, isValidLang := codeexec.ResolveLanguage(l.specs, lang)
 	if !isValidLang {
 		return
 	}
@@ -128,6 +144,14 @@ func (l *ListenerCodeexec) handler(s *discordgo.Session, e *discordgo.Message) {
 		return
 	}
 
+	allowed, err := l.db.GetGuildCodeExecLanguages(e.GuildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return
+	}
+	if !codeexec.IsLanguageAllowed(l.specs, lang, allowed) {
+		return
+	}
+
 	wrapper, err := l.execFact.NewExecutor(e.GuildID)
 	if err != nil || wrapper == nil {
 		return
@@ -217,17 +241,23 @@ func (l *ListenerCodeexec) HandlerReactionAdd(s *discordgo.Session, eReact *disc
 		return
 	}
 
-	result, err := jdMsg.wrapper.Exec(codeexec.Payload{
+	result, err := jdMsg.wrapper.Exec(context.Background(), codeexec.Payload{
 		Language: jdMsg.lang,
 		Code:     jdMsg.script,
 		Inline:   eReact.Emoji.Name == inlineReactionEmoji,
 	})
 
 	if err != nil {
+		desc := fmt.Sprintf("API responded with following error: ```\n%s\n```", err.Error())
+		if errors.Is(err, codeexec.ErrTimeout) {
+			desc = "The execution timed out."
+		} else if errors.Is(err, codeexec.ErrBusy) {
+			desc = "The server is currently busy. Please try again later."
+		}
 		s.ChannelMessageEditEmbed(resMsg.ChannelID, resMsg.ID, &discordgo.MessageEmbed{
 			Color:       static.ColorEmbedError,
 			Title:       "Execution Error",
-			Description: fmt.Sprintf("API responded with following error: ```\n%s\n```", err.Error()),
+			Description: desc,
 		})
 		discordutil.DeleteMessageLater(s, resMsg.Message, 15*time.Second)
 	} else {
@@ -249,10 +279,10 @@ func (l *ListenerCodeexec) HandlerReactionAdd(s *discordgo.Session, eReact *disc
 		}
 
 		if result.StdOut != "" {
-			emb.AddField("StdOut", "```\n"+result.StdOut+"\n```")
+			emb.AddField("StdOut", l.formatOutput(eReact.UserID, result.StdOut))
 		}
 		if result.StdErr != "" {
-			emb.AddField("StdErr", "```\n"+result.StdErr+"\n```")
+			emb.AddField("StdErr", l.formatOutput(eReact.UserID, result.StdErr))
 		}
 		if result.CpuUsed != "" {
 			emb.AddInlineField("CPU Time", result.CpuUsed)
@@ -270,6 +300,38 @@ func (l *ListenerCodeexec) HandlerReactionAdd(s *discordgo.Session, eReact *disc
 	}
 }
 
+func (l *ListenerCodeexec) formatOutput(userID, output string) string {
+	inline, truncated := codeexec.TruncateOutput(output, execInlineOutputLimit)
+	value := "```\n" + inline + "\n```"
+
+	if truncated && l.cfg.Config().CodeExec.Limits.UploadOutput {
+		link, err := l.uploadOutput(userID, output)
+		if err != nil {
+			l.log.Error().Err(err).Msg("Failed uploading code execution output")
+		} else {
+			value += fmt.Sprintf("\n[Full output](%s) (only accessible by the executor)", link)
+		}
+	}
+
+	return value
+}
+
+func (l *ListenerCodeexec) uploadOutput(userID, output string) (link string, err error) {
+	id, err := codeexec.NewOutputID()
+	if err != nil {
+		return
+	}
+
+	err = l.storage.PutObject(static.StorageBucketCodeExec, codeexec.OutputObjectName(userID, id),
+		strings.NewReader(output), int64(len(output)), "text/plain")
+	if err != nil {
+		return
+	}
+
+	link = fmt.Sprintf("%s/codeexec/%s", l.cfg.Config().WebServer.PublicAddr, id)
+	return
+}
+
 func (l *ListenerCodeexec) parseMessageContent(content string) (lang string, script string, ok bool) {
 	spl := strings.Split(content, "```")
 	if len(spl) < 3 {
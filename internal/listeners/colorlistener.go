@@ -10,11 +10,15 @@ import (
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/sarulabs/di/v2"
-	"github.com/zekroTJA/colorname"
+	"github.com/zekroTJA/shinpuru/internal/models"
 	"github.com/zekroTJA/shinpuru/internal/services/config"
 	"github.com/zekroTJA/shinpuru/internal/services/database"
 	"github.com/zekroTJA/shinpuru/internal/services/guildlog"
+	"github.com/zekroTJA/shinpuru/internal/services/kvcache"
+	"github.com/zekroTJA/shinpuru/internal/services/msgsender"
 	"github.com/zekroTJA/shinpuru/internal/services/permissions"
+	"github.com/zekroTJA/shinpuru/internal/services/timeprovider"
+	"github.com/zekroTJA/shinpuru/internal/util"
 	"github.com/zekroTJA/shinpuru/internal/util/static"
 	"github.com/zekroTJA/shinpuru/pkg/colors"
 	"github.com/zekroTJA/timedmap"
@@ -28,16 +32,28 @@ const (
 )
 
 var (
-	rxColorHex = regexp.MustCompile(`^#?[\dA-Fa-f]{6,8}$`)
+	rxColorHex  = regexp.MustCompile(`^#?[\dA-Fa-f]{6,8}$`)
+	rxColorName = regexp.MustCompile(`(?i)\bcolou?r:\s*([a-z]+)\b`)
 )
 
+// colorReaction holds the color of a reaction as well
+// as the color it is compared against, if any.
+type colorReaction struct {
+	clr        *color.RGBA
+	contrastTo *color.RGBA
+}
+
 type ColorListener struct {
-	db         database.Database
-	gl         guildlog.Logger
-	pmw        *permissions.Permissions
-	st         *dgrs.State
-	log        rogu.Logger
-	publicAddr string
+	db          database.Database
+	gl          guildlog.Logger
+	pmw         *permissions.Permissions
+	st          dgrs.IState
+	sender      *msgsender.Sender
+	kvc         kvcache.Provider
+	tp          timeprovider.Provider
+	log         rogu.Logger
+	publicAddr  string
+	reactionCfg models.ColorReactions
 
 	emojiCache *timedmap.TimedMap
 }
@@ -45,13 +61,17 @@ type ColorListener struct {
 func NewColorListener(container di.Container) *ColorListener {
 	cfg := container.Get(static.DiConfig).(config.Provider)
 	return &ColorListener{
-		db:         container.Get(static.DiDatabase).(database.Database),
-		gl:         container.Get(static.DiGuildLog).(guildlog.Logger).Section("colorlistener"),
-		pmw:        container.Get(static.DiPermissions).(*permissions.Permissions),
-		st:         container.Get(static.DiState).(*dgrs.State),
-		log:        log.Tagged("ColorListener"),
-		publicAddr: cfg.Config().WebServer.PublicAddr,
-		emojiCache: timedmap.New(1 * time.Minute),
+		db:          container.Get(static.DiDatabase).(database.Database),
+		gl:          container.Get(static.DiGuildLog).(guildlog.Logger).Section("colorlistener"),
+		pmw:         container.Get(static.DiPermissions).(*permissions.Permissions),
+		st:          container.Get(static.DiState).(dgrs.IState),
+		sender:      container.Get(static.DiMessageSender).(*msgsender.Sender),
+		kvc:         container.Get(static.DiKVCache).(kvcache.Provider),
+		tp:          container.Get(static.DiTimeProvider).(timeprovider.Provider),
+		log:         log.Tagged("ColorListener"),
+		publicAddr:  cfg.Config().WebServer.PublicAddr,
+		reactionCfg: cfg.Config().Discord.ColorReactions,
+		emojiCache:  timedmap.New(1 * time.Minute),
 	}
 }
 
@@ -78,10 +98,11 @@ func (l *ColorListener) HandlerMessageReaction(s *discordgo.Session, e *discordg
 		return
 	}
 
-	clr, ok := l.emojiCache.GetValue(cacheKey).(*color.RGBA)
+	reaction, ok := l.emojiCache.GetValue(cacheKey).(colorReaction)
 	if !ok {
 		return
 	}
+	clr := reaction.clr
 
 	allowed, _, _ := l.pmw.CheckPermissions(s, e.GuildID, e.UserID, "sp.chat.colorreactions")
 	if !allowed {
@@ -94,56 +115,50 @@ func (l *ColorListener) HandlerMessageReaction(s *discordgo.Session, e *discordg
 		return
 	}
 
-	hexClr := colors.ToHex(clr)
-	intClr := colors.ToInt(clr)
-	cC, cM, cY, cK := color.RGBToCMYK(clr.R, clr.G, clr.B)
-	yY, yCb, yCr := color.RGBToYCbCr(clr.R, clr.G, clr.B)
-
-	colorName := "*could not be fetched*"
-	matches := colorname.FindRGBA(clr)
-	if len(matches) > 0 {
-		precision := (1 - matches[0].AvgDiff/255) * 100
-		colorName = fmt.Sprintf("**%s** *(%0.1f%%)*", matches[0].Name, precision)
+	emb := util.ColorEmbed(clr, l.publicAddr)
+	emb.Footer = &discordgo.MessageEmbedFooter{
+		Text: "Activated by " + user.String(),
 	}
 
-	desc := fmt.Sprintf(
-		"%s\n\n```\n"+
-			"Hex:    #%s\n"+
-			"Int:    %d\n"+
-			"RGBA:   %03d, %03d, %03d, %03d\n"+
-			"CMYK:   %03d, %03d, %03d, %03d\n"+
-			"YCbCr:  %03d, %03d, %03d\n"+
-			"```",
-		colorName,
-		hexClr,
-		intClr,
-		clr.R, clr.G, clr.B, clr.A,
-		cC, cM, cY, cK,
-		yY, yCb, yCr,
-	)
-
-	emb := &discordgo.MessageEmbed{
-		Color:       intClr,
-		Title:       "#" + hexClr,
-		Description: desc,
-		Footer: &discordgo.MessageEmbedFooter{
-			Text: "Activated by " + user.String(),
-		},
-		Thumbnail: &discordgo.MessageEmbedThumbnail{
-			URL: fmt.Sprintf("%s/api/util/color/%s?size=64", l.publicAddr, hexClr),
-		},
+	if reaction.contrastTo != nil && l.reactionCfg.GradientPreviews {
+		emb.Thumbnail.URL = fmt.Sprintf("%s/api/util/color/%s?size=64&to=%s",
+			l.publicAddr, colors.ToHex(clr), colors.ToHex(reaction.contrastTo))
 	}
 
-	_, err = s.ChannelMessageSendComplex(e.ChannelID, &discordgo.MessageSend{
+	if reaction.contrastTo != nil {
+		ratio := colors.ContrastRatio(clr, reaction.contrastTo)
+		emb.Fields = []*discordgo.MessageEmbedField{
+			{
+				Name: "Contrast to #" + colors.ToHex(reaction.contrastTo),
+				Value: fmt.Sprintf(
+					"```\n"+
+						"Ratio:        %.2f:1\n"+
+						"Normal Text:  %s\n"+
+						"Large Text:   %s\n"+
+						"```",
+					ratio,
+					colors.WCAGLevel(ratio, false),
+					colors.WCAGLevel(ratio, true),
+				),
+			},
+		}
+	}
+
+	err = l.sender.Enqueue(s, e.GuildID, e.ChannelID, &discordgo.MessageSend{
 		Embed: emb,
 		Reference: &discordgo.MessageReference{
 			MessageID: e.MessageID,
 			ChannelID: e.ChannelID,
 			GuildID:   e.GuildID,
 		},
+	}, func(_ *discordgo.Message, err error) {
+		if err != nil {
+			l.log.Error().Err(err).Msg("Could not send embed message")
+			l.gl.Errorf(e.GuildID, "Failed sending embed message: %s", err.Error())
+		}
 	})
 	if err != nil {
-		l.log.Error().Err(err).Msg("Could not send embed message")
+		l.log.Error().Err(err).Msg("Could not enqueue embed message")
 		l.gl.Errorf(e.GuildID, "Failed sending embed message: %s", err.Error())
 	}
 
@@ -167,6 +182,15 @@ func (l *ColorListener) process(s *discordgo.Session, m *discordgo.Message, remo
 		}
 	}
 
+	// Find CSS color names in message content which
+	// are explicitly marked like 'color: tomato' to
+	// avoid reacting to common words.
+	for _, v := range rxColorName.FindAllStringSubmatch(content, -1) {
+		if _, ok := colors.FromName(v[1]); ok {
+			matches = appendIfUnique(matches, strings.ToLower(v[1]))
+		}
+	}
+
 	// Get color reaction enabled guild setting
 	// and return when disabled
 	active, err := l.db.GetGuildColorReaction(m.GuildID)
@@ -191,6 +215,10 @@ func (l *ColorListener) process(s *discordgo.Session, m *discordgo.Message, remo
 		matches = matches[:colorMatchesCap]
 	}
 
+	if !l.checkLimits(m, len(matches)) {
+		return
+	}
+
 	if removeReactions {
 		if err := s.MessageReactionsRemoveAll(m.ChannelID, m.ID); err != nil {
 			l.log.Error().Err(err).Msg("Could not remove previous color reactions")
@@ -198,26 +226,34 @@ func (l *ColorListener) process(s *discordgo.Session, m *discordgo.Message, remo
 		}
 	}
 
-	// Execute reaction for each match
-	for _, hexClr := range matches {
-		l.createReaction(s, m, hexClr)
+	// Parse hex color codes and color names to
+	// color.RGBA objects
+	clrs := make([]*color.RGBA, 0, len(matches))
+	for _, match := range matches {
+		clr, err := parseColorMatch(match)
+		if err != nil {
+			l.log.Error().Err(err).Msg("Failed parsing color code")
+			l.gl.Errorf(m.GuildID, "Failed parsing color code: %s", err.Error())
+			continue
+		}
+		clrs = append(clrs, clr)
 	}
-}
 
-func (l *ColorListener) createReaction(s *discordgo.Session, m *discordgo.Message, hexClr string) {
-	// Remove trailing '#' from color code,
-	// when existent
-	if strings.HasPrefix(hexClr, "#") {
-		hexClr = hexClr[1:]
+	// Execute reaction for each match. When exactly
+	// two colors were found, each of them is compared
+	// against the other one.
+	for i, clr := range clrs {
+		reaction := colorReaction{clr: clr}
+		if len(clrs) == 2 {
+			reaction.contrastTo = clrs[1-i]
+		}
+		l.createReaction(s, m, reaction)
 	}
+}
 
-	// Parse hex color code to color.RGBA object
-	clr, err := colors.FromHex(hexClr)
-	if err != nil {
-		l.log.Error().Err(err).Msg("Failed parsing color code")
-		l.gl.Errorf(m.GuildID, "Failed parsing color code: %s", err.Error())
-		return
-	}
+func (l *ColorListener) createReaction(s *discordgo.Session, m *discordgo.Message, reaction colorReaction) {
+	clr := reaction.clr
+	hexClr := colors.ToHex(clr)
 
 	// Create a 24x24 px image with the parsed color
 	// rendered as PNG into a buffer
@@ -263,9 +299,54 @@ func (l *ColorListener) createReaction(s *discordgo.Session, m *discordgo.Messag
 		return
 	}
 
-	// Set messageID + emojiID with RGBA color object
+	// Set messageID + emojiID with the color reaction
 	// to emojiCache
-	l.emojiCache.Set(m.ID+emoji.ID, clr, 24*time.Hour)
+	l.emojiCache.Set(m.ID+emoji.ID, reaction, 24*time.Hour)
+}
+
+// checkLimits returns false if the author of the message
+// is on cooldown in the channel or if creating n further
+// reactions would exceed the reaction limit of the guild.
+func (l *ColorListener) checkLimits(m *discordgo.Message, n int) bool {
+	if l.reactionCfg.UserCooldownSeconds > 0 && m.Author != nil {
+		// The lifetime of the key is reset on each
+		// message, so users spamming colors stay
+		// on cooldown until they stop.
+		key := fmt.Sprintf("colorreaction:cooldown:%s:%s", m.ChannelID, m.Author.ID)
+		cooldown := time.Duration(l.reactionCfg.UserCooldownSeconds) * time.Second
+		if l.kvc.Incr(key, 1, cooldown) > 1 {
+			return false
+		}
+	}
+
+	if l.reactionCfg.GuildLimit > 0 && l.reactionCfg.GuildLimitSeconds > 0 {
+		window := time.Duration(l.reactionCfg.GuildLimitSeconds) * time.Second
+		key := fmt.Sprintf("colorreaction:guild:%s:%d", m.GuildID, l.tp.Now().Unix()/int64(l.reactionCfg.GuildLimitSeconds))
+		limit := int64(l.reactionCfg.GuildLimit)
+		count := l.kvc.Incr(key, int64(n), window)
+		if count > limit {
+			// Only log when the limit is exceeded for the
+			// first time in the current window.
+			if count-int64(n) <= limit {
+				l.log.Warn().Field("gid", m.GuildID).Msg("Color reaction limit exceeded")
+				l.gl.Warnf(m.GuildID, "Color reaction limit of %d reactions per %s exceeded; further color reactions are skipped",
+					l.reactionCfg.GuildLimit, window)
+			}
+			return false
+		}
+	}
+
+	return true
+}
+
+// parseColorMatch returns the color of the passed
+// match which is either a hex color code or a CSS
+// color name.
+func parseColorMatch(match string) (*color.RGBA, error) {
+	if rxColorHex.MatchString(match) {
+		return colors.FromHex(match)
+	}
+	return colors.ParseAny(match)
 }
 
 // appendIfUnique appends the given elem to the
@@ -0,0 +1,67 @@
+package listeners
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/services/kvcache"
+	"github.com/zekroTJA/shinpuru/mocks"
+	"github.com/zekrotja/rogu/log"
+)
+
+func getColorListener(now *time.Time, cfg models.ColorReactions) (*ColorListener, *mocks.Logger) {
+	tp := &mocks.TimeProvider{}
+	tp.On("Now").Return(func() time.Time { return *now })
+
+	logger := &mocks.Logger{}
+	logger.On("Warnf", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	return &ColorListener{
+		gl:          logger,
+		kvc:         kvcache.NewTimedmapCache(time.Minute),
+		tp:          tp,
+		log:         log.Tagged("ColorListener"),
+		reactionCfg: cfg,
+	}, logger
+}
+
+func colorMessage(channelID, userID string) *discordgo.Message {
+	return &discordgo.Message{
+		GuildID:   "guild",
+		ChannelID: channelID,
+		Author:    &discordgo.User{ID: userID},
+	}
+}
+
+func TestColorListenerCheckLimitsCooldown(t *testing.T) {
+	now := time.Unix(0, 0)
+	l, _ := getColorListener(&now, models.ColorReactions{UserCooldownSeconds: 10})
+
+	assert.True(t, l.checkLimits(colorMessage("chan-a", "user-a"), 1))
+	assert.False(t, l.checkLimits(colorMessage("chan-a", "user-a"), 1))
+
+	// The cooldown is per user and channel
+	assert.True(t, l.checkLimits(colorMessage("chan-b", "user-a"), 1))
+	assert.True(t, l.checkLimits(colorMessage("chan-a", "user-b"), 1))
+}
+
+func TestColorListenerCheckLimitsGuild(t *testing.T) {
+	now := time.Unix(0, 0)
+	l, logger := getColorListener(&now, models.ColorReactions{GuildLimit: 3, GuildLimitSeconds: 60})
+
+	assert.True(t, l.checkLimits(colorMessage("chan", "user-a"), 2))
+	assert.True(t, l.checkLimits(colorMessage("chan", "user-b"), 1))
+	assert.False(t, l.checkLimits(colorMessage("chan", "user-c"), 1))
+	assert.False(t, l.checkLimits(colorMessage("chan", "user-d"), 1))
+
+	// The limit is only logged once per window
+	logger.AssertNumberOfCalls(t, "Warnf", 1)
+
+	// A new window starts after the limit interval
+	now = now.Add(60 * time.Second)
+	assert.True(t, l.checkLimits(colorMessage("chan", "user-c"), 1))
+}
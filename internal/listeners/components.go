@@ -0,0 +1,42 @@
+package listeners
+
+import (
+	"github.com/bwmarrin/discordgo"
+	"github.com/sarulabs/di/v2"
+	"github.com/zekroTJA/shinpuru/internal/services/components"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/pkg/discordutil"
+	"github.com/zekrotja/rogu"
+	"github.com/zekrotja/rogu/log"
+)
+
+// ListenerComponents answers interactions with message
+// components which have been created via the component
+// registry but are not registered anymore, for example
+// because they have expired or the bot has been restarted.
+type ListenerComponents struct {
+	reg *components.Registry
+	log rogu.Logger
+}
+
+func NewListenerComponents(container di.Container) *ListenerComponents {
+	return &ListenerComponents{
+		reg: container.Get(static.DiComponents).(*components.Registry),
+		log: log.Tagged("Components"),
+	}
+}
+
+func (l *ListenerComponents) HandlerInteractionCreate(s discordutil.ISession, e *discordgo.InteractionCreate) {
+	if e.Type != discordgo.InteractionMessageComponent {
+		return
+	}
+
+	customID := e.MessageComponentData().CustomID
+	if !components.IsManaged(customID) || l.reg.IsRegistered(customID) {
+		return
+	}
+
+	if err := components.RespondUnknown(s, e.Interaction); err != nil {
+		l.log.Error().Err(err).Field("customID", customID).Msg("Failed responding to unknown component interaction")
+	}
+}
@@ -9,12 +9,12 @@ import (
 )
 
 type ListenerDMSync struct {
-	st *dgrs.State
+	st dgrs.IState
 }
 
 func NewListenerDMSync(container di.Container) *ListenerDMSync {
 	return &ListenerDMSync{
-		st: container.Get(static.DiState).(*dgrs.State),
+		st: container.Get(static.DiState).(dgrs.IState),
 	}
 }
 
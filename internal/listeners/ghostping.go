@@ -27,7 +27,7 @@ type ListenerGhostPing struct {
 	gl              guildlog.Logger
 	msgCache        *timedmap.TimedMap
 	recentlyDeleted map[string]struct{}
-	st              *dgrs.State
+	st              dgrs.IState
 	log             rogu.Logger
 }
 
@@ -35,7 +35,7 @@ func NewListenerGhostPing(container di.Container) *ListenerGhostPing {
 	return &ListenerGhostPing{
 		db:       container.Get(static.DiDatabase).(database.Database),
 		gl:       container.Get(static.DiGuildLog).(guildlog.Logger).Section("ghostping"),
-		st:       container.Get(static.DiState).(*dgrs.State),
+		st:       container.Get(static.DiState).(dgrs.IState),
 		msgCache: timedmap.New(gpTick),
 		log:      log.Tagged("Ghostping"),
 	}
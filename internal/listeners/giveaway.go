@@ -0,0 +1,96 @@
+package listeners
+
+import (
+	"github.com/bwmarrin/discordgo"
+	"github.com/sarulabs/di/v2"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/services/guildlog"
+	"github.com/zekroTJA/shinpuru/internal/services/timeprovider"
+	"github.com/zekroTJA/shinpuru/internal/util/giveaway"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/pkg/discordutil"
+	"github.com/zekrotja/ken"
+	"github.com/zekrotja/rogu"
+	"github.com/zekrotja/rogu/log"
+)
+
+type ListenerGiveaway struct {
+	db  database.Database
+	ken ken.IKen
+	gl  guildlog.Logger
+	tp  timeprovider.Provider
+	log rogu.Logger
+}
+
+func NewListenerGiveaway(container di.Container) *ListenerGiveaway {
+	return &ListenerGiveaway{
+		db:  container.Get(static.DiDatabase).(database.Database),
+		ken: container.Get(static.DiCommandHandler).(ken.IKen),
+		gl:  container.Get(static.DiGuildLog).(guildlog.Logger).Section("giveaway"),
+		tp:  container.Get(static.DiTimeProvider).(timeprovider.Provider),
+		log: log.Tagged("Giveaway"),
+	}
+}
+
+func (l *ListenerGiveaway) HandlerMessageDelete(s discordutil.ISession, e *discordgo.MessageDelete) {
+	l.handleDeleted(s, e.GuildID, e.ID)
+}
+
+func (l *ListenerGiveaway) HandlerMessageBulkDelete(s discordutil.ISession, e *discordgo.MessageDeleteBulk) {
+	for _, msgID := range e.Messages {
+		l.handleDeleted(s, e.GuildID, msgID)
+	}
+}
+
+func (l *ListenerGiveaway) Ready(s discordutil.ISession, e *discordgo.Ready) {
+	giveaways, err := l.db.GetGiveaways("", true)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		l.log.Error().Err(err).Msg("Retrieving active giveaways failed")
+		return
+	}
+
+	for _, g := range giveaways {
+		err = giveaway.AttachEnterButton(l.db, l.ken.Components(), l.tp, g)
+		if err == nil {
+			continue
+		}
+		if discordutil.IsErrMessageGone(err) {
+			l.handleDeleted(s, g.GuildID, g.MessageID)
+			continue
+		}
+		l.log.Error().Err(err).Fields(
+			"id", g.ID,
+			"guild", g.GuildID,
+			"channel", g.ChannelID,
+			"message", g.MessageID,
+		).Msg("Re-Attaching enter button failed")
+	}
+}
+
+func (l *ListenerGiveaway) handleDeleted(s discordutil.ISession, guildID, messageID string) {
+	if guildID == "" {
+		return
+	}
+
+	g, err := l.db.GetGiveawayByMessage(messageID)
+	if database.IsErrDatabaseNotFound(err) {
+		return
+	}
+	if err != nil {
+		l.log.Error().Err(err).Field("message", messageID).Msg("Failed getting giveaway")
+		return
+	}
+
+	if g.Ended {
+		return
+	}
+
+	g.MessageID = ""
+	err = giveaway.End(l.db, s, &g, "The giveaway message has been deleted, so the giveaway has ended early.")
+	if err != nil {
+		l.gl.Errorf(guildID, "Failed ending giveaway %s after its message has been deleted: %s", g.ID, err.Error())
+		return
+	}
+
+	l.gl.Warnf(guildID, "The message of giveaway %s has been deleted, so the giveaway has been ended early.", g.ID)
+}
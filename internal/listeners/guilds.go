@@ -15,7 +15,7 @@ import (
 
 type ListenerGuilds struct {
 	cfg config.Provider
-	st  *dgrs.State
+	st  dgrs.IState
 	tp  timeprovider.Provider
 
 	lockUntil *time.Time
@@ -24,7 +24,7 @@ type ListenerGuilds struct {
 func NewListenerGuildAdd(container di.Container) *ListenerGuilds {
 	return &ListenerGuilds{
 		cfg: container.Get(static.DiConfig).(config.Provider),
-		st:  container.Get(static.DiState).(*dgrs.State),
+		st:  container.Get(static.DiState).(dgrs.IState),
 		tp:  container.Get(static.DiTimeProvider).(timeprovider.Provider),
 	}
 }
@@ -0,0 +1,59 @@
+package listeners
+
+import (
+	"github.com/bwmarrin/discordgo"
+	"github.com/sarulabs/di/v2"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/services/kvcache"
+	"github.com/zekroTJA/shinpuru/internal/services/timeprovider"
+	"github.com/zekroTJA/shinpuru/internal/util/inactivity"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/pkg/discordutil"
+	"github.com/zekrotja/rogu"
+	"github.com/zekrotja/rogu/log"
+)
+
+type ListenerInactivity struct {
+	db  database.Database
+	kvc kvcache.Provider
+	tp  timeprovider.Provider
+	log rogu.Logger
+}
+
+func NewListenerInactivity(container di.Container) *ListenerInactivity {
+	return &ListenerInactivity{
+		db:  container.Get(static.DiDatabase).(database.Database),
+		kvc: container.Get(static.DiKVCache).(kvcache.Provider),
+		tp:  container.Get(static.DiTimeProvider).(timeprovider.Provider),
+		log: log.Tagged("Inactivity"),
+	}
+}
+
+func (l *ListenerInactivity) HandlerMessageCreate(s discordutil.ISession, e *discordgo.MessageCreate) {
+	if e.GuildID == "" || e.Author == nil || e.Author.Bot || e.WebhookID != "" {
+		return
+	}
+
+	l.track(e.GuildID, e.Author.ID)
+}
+
+func (l *ListenerInactivity) HandlerVoiceStateUpdate(s discordutil.ISession, e *discordgo.VoiceStateUpdate) {
+	if e.GuildID == "" || e.ChannelID == "" || (e.Member != nil && e.Member.User != nil && e.Member.User.Bot) {
+		return
+	}
+
+	l.track(e.GuildID, e.UserID)
+}
+
+func (l *ListenerInactivity) track(guildID, userID string) {
+	settings, err := l.db.GetInactivitySettings(guildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		l.log.Error().Err(err).Field("gid", guildID).Msg("Failed getting inactivity settings")
+		return
+	}
+	if !settings.Enabled {
+		return
+	}
+
+	inactivity.Track(l.kvc, l.tp, guildID, userID)
+}
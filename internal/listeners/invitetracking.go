@@ -0,0 +1,54 @@
+package listeners
+
+import (
+	"github.com/bwmarrin/discordgo"
+	"github.com/sarulabs/di/v2"
+	"github.com/zekroTJA/shinpuru/internal/services/invitetracker"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/pkg/discordutil"
+	"github.com/zekrotja/rogu"
+	"github.com/zekrotja/rogu/log"
+)
+
+type ListenerInviteTracking struct {
+	it  *invitetracker.InviteTracker
+	log rogu.Logger
+}
+
+func NewListenerInviteTracking(ctn di.Container) ListenerInviteTracking {
+	return ListenerInviteTracking{
+		it:  ctn.Get(static.DiInviteTracker).(*invitetracker.InviteTracker),
+		log: log.Tagged("InviteTracking"),
+	}
+}
+
+func (l ListenerInviteTracking) HandlerGuildCreate(s discordutil.ISession, e *discordgo.GuildCreate) {
+	err := l.it.Snapshot(e.ID)
+	// Listing invites requires the permission to manage
+	// the guild, which is not granted on all guilds.
+	if discordutil.IsErrCode(err, discordgo.ErrCodeMissingPermissions) {
+		return
+	}
+	if err != nil {
+		l.log.Error().Err(err).Field("gid", e.ID).Msg("Failed taking invite snapshot")
+	}
+}
+
+func (l ListenerInviteTracking) HandlerGuildDelete(s discordutil.ISession, e *discordgo.GuildDelete) {
+	l.it.Forget(e.ID)
+}
+
+func (l ListenerInviteTracking) HandlerInviteCreate(s discordutil.ISession, e *discordgo.InviteCreate) {
+	l.it.Add(e.GuildID, e.Invite)
+}
+
+func (l ListenerInviteTracking) HandlerMemberAdd(s discordutil.ISession, e *discordgo.GuildMemberAdd) {
+	if e.User.Bot {
+		return
+	}
+
+	_, err := l.it.Attribute(e.GuildID, e.User.ID)
+	if err != nil && !discordutil.IsErrCode(err, discordgo.ErrCodeMissingPermissions) {
+		l.log.Error().Err(err).Fields("gid", e.GuildID, "uid", e.User.ID).Msg("Failed attributing join to invite")
+	}
+}
@@ -40,6 +40,14 @@ const (
 	typRemove = -1 // remove 1 karma
 )
 
+// appliedKarma is a karma change applied by a
+// reaction to a message.
+type appliedKarma struct {
+	authorID  string
+	value     int
+	penalized bool
+}
+
 type ListenerKarma struct {
 	db    database.Database
 	gl    guildlog.Logger
@@ -102,12 +110,7 @@ func (l *ListenerKarma) Handler(s discordutil.ISession, e *discordgo.MessageReac
 	}
 
 	// Get the type of karma change by the emote used
-	var typ int
-	if strings.Contains(reactionsAddKarma, e.MessageReaction.Emoji.Name) {
-		typ = typAdd
-	} else if strings.Contains(reactionsRemoveKarma, e.MessageReaction.Emoji.Name) {
-		typ = typRemove
-	}
+	typ := karmaChangeType(e.MessageReaction.Emoji.Name, reactionsAddKarma, reactionsRemoveKarma)
 
 	// When none of the specified emotes was used, return
 	if typ == typNull {
@@ -172,8 +175,10 @@ func (l *ListenerKarma) Handler(s discordutil.ISession, e *discordgo.MessageReac
 		return
 	}
 
-	// Take a karma token from the users rate limiter
-	if !l.rateLimiterTake(e.UserID, e.GuildID) {
+	// Check if the user has karma tokens left. The token
+	// is only consumed after the karma has been changed.
+	limiter, ok := l.rateLimiter(e.UserID, e.GuildID)
+	if !ok || limiter.Tokens() < 1 {
 		ch, err := s.UserChannelCreate(e.UserID)
 		if err == nil {
 			util.SendEmbedError(s, ch.ID,
@@ -182,6 +187,34 @@ func (l *ListenerKarma) Handler(s discordutil.ISession, e *discordgo.MessageReac
 		return
 	}
 
+	// Check and count the karma limits of the guild
+	err = l.karma.CheckLimits(e.GuildID, e.UserID, msg.Author.ID)
+	if limitErr, ok := err.(*karma.LimitError); ok {
+		ch, err := s.UserChannelCreate(e.UserID)
+		if err == nil {
+			util.SendEmbedError(s, ch.ID, limitErr.Error())
+		}
+		return
+	}
+	if err != nil {
+		l.log.Error().Err(err).Field("gid", e.GuildID).Msg("Failed checking karma limits")
+		l.gl.Errorf(e.GuildID, "Failed checking karma limits: %s", err.Error())
+		return
+	}
+
+	// Remember if the executor is penalized for the
+	// downvote so that the penalty can be refunded when
+	// the reaction is removed again.
+	var penalized bool
+	if typ == typRemove {
+		penalized, err = l.db.GetKarmaPenalty(e.GuildID)
+		if err != nil && !database.IsErrDatabaseNotFound(err) {
+			l.log.Error().Err(err).Field("gid", e.GuildID).Msg("Failed getting karma penalty state")
+			l.gl.Errorf(e.GuildID, "Failed getting karma penalty state: %s", err.Error())
+			return
+		}
+	}
+
 	err = l.karma.Update(e.GuildID, msg.Author.ID, e.UserID, typ)
 	if err != nil {
 		l.log.Error().Err(err).Fields("gid", e.GuildID, "uid", e.UserID).Msg("Failed altering karma value")
@@ -189,29 +222,101 @@ func (l *ListenerKarma) Handler(s discordutil.ISession, e *discordgo.MessageReac
 		return
 	}
 
+	limiter.Allow()
+
 	// Mark the message as applied by the user
-	l.applyMessage(e.UserID, e.MessageID)
+	l.applyMessage(e.UserID, e.MessageID, msg.Author.ID, typ, penalized)
+}
+
+func (l *ListenerKarma) HandlerRemove(s discordutil.ISession, e *discordgo.MessageReactionRemove) {
+	// Only changes which have been applied by the user
+	// to the message can be reversed
+	applied, ok := l.getAppliedMessage(e.UserID, e.MessageID)
+	if !ok {
+		return
+	}
+
+	reactionsAddKarma, reactionsRemoveKarma, err := l.db.GetKarmaEmotes(e.GuildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		l.log.Error().Err(err).Field("gid", e.GuildID).Msg("Failed getting karma emotes")
+		l.gl.Errorf(e.GuildID, "Failed getting karma emotes: %s", err.Error())
+		return
+	}
+
+	// Return when the removed reaction is not the one
+	// which has applied the change
+	typ := karmaChangeType(e.MessageReaction.Emoji.Name, reactionsAddKarma, reactionsRemoveKarma)
+	if typ == typNull || typ != applied.value {
+		return
+	}
+
+	// The executor is not passed so that reversing an
+	// upvote does not apply the karma penalty.
+	err = l.karma.Update(e.GuildID, applied.authorID, "", -applied.value)
+	if err != nil {
+		l.log.Error().Err(err).Fields("gid", e.GuildID, "uid", e.UserID).Msg("Failed reversing karma value")
+		l.gl.Errorf(e.GuildID, "Failed reversing karma value (%s): %s", e.UserID, err.Error())
+		return
+	}
+
+	// Refund the penalty which has been applied to the
+	// executor for the reversed downvote.
+	if applied.penalized {
+		err = l.karma.Update(e.GuildID, e.UserID, "", 1)
+		if err != nil {
+			l.log.Error().Err(err).Fields("gid", e.GuildID, "uid", e.UserID).Msg("Failed refunding karma penalty")
+			l.gl.Errorf(e.GuildID, "Failed refunding karma penalty (%s): %s", e.UserID, err.Error())
+		}
+	}
+
+	l.msgsApplied.Remove(appliedMessageKey(e.UserID, e.MessageID))
+}
+
+// karmaChangeType returns the type of karma change
+// for the given emote name.
+func karmaChangeType(emoteName, reactionsAddKarma, reactionsRemoveKarma string) int {
+	if emoteName == "" {
+		return typNull
+	}
+	if strings.Contains(reactionsAddKarma, emoteName) {
+		return typAdd
+	}
+	if strings.Contains(reactionsRemoveKarma, emoteName) {
+		return typRemove
+	}
+	return typNull
 }
 
 // isMessageAlreadyApplied returns true, if the user has already
 // changed karma by reaction to the specified message in the
 // time span of lifetimePerMessage.
 func (l *ListenerKarma) isMessageAlreadyApplied(userID, msgID string) bool {
-	key := fmt.Sprintf("%s:%s", userID, msgID)
-	return l.msgsApplied.Contains(key)
+	return l.msgsApplied.Contains(appliedMessageKey(userID, msgID))
 }
 
 // applyMessage registers this message as karma change from
 // the specified user for the time span of lifetimePerMessage.
-func (l *ListenerKarma) applyMessage(userID, msgID string) {
-	key := fmt.Sprintf("%s:%s", userID, msgID)
-	l.msgsApplied.Set(key, true, lifetimePerMessage)
+func (l *ListenerKarma) applyMessage(userID, msgID, authorID string, value int, penalized bool) {
+	l.msgsApplied.Set(appliedMessageKey(userID, msgID),
+		appliedKarma{authorID: authorID, value: value, penalized: penalized}, lifetimePerMessage)
+}
+
+// getAppliedMessage returns the karma change applied by the
+// specified user to the message, if any.
+func (l *ListenerKarma) getAppliedMessage(userID, msgID string) (applied appliedKarma, ok bool) {
+	applied, ok = l.msgsApplied.GetValue(appliedMessageKey(userID, msgID)).(appliedKarma)
+	return
+}
+
+func appliedMessageKey(userID, msgID string) string {
+	return fmt.Sprintf("%s:%s", userID, msgID)
 }
 
-// rateLimiterTake tries to take a ticket from the users
-// karma change rate limiter. If all tickets are exceed,
-// false will be returned; otherwise the result is true.
-func (l *ListenerKarma) rateLimiterTake(userID, guildID string) bool {
+// rateLimiter returns the karma change rate limiter of
+// the user on the guild. If no karma tokens are configured
+// for the guild or they could not be retrieved, false is
+// returned.
+func (l *ListenerKarma) rateLimiter(userID, guildID string) (*ratelimit.Limiter, bool) {
 	key := fmt.Sprintf("%s:%s", userID, guildID)
 
 	limiter, ok := l.limiters.GetValue(key).(*ratelimit.Limiter)
@@ -220,10 +325,10 @@ func (l *ListenerKarma) rateLimiterTake(userID, guildID string) bool {
 	if err != nil && !database.IsErrDatabaseNotFound(err) {
 		l.log.Error().Err(err).Field("gid", guildID).Msg("Failed getting karma tokens")
 		l.gl.Errorf(guildID, "Failed getting karma tokens: %s", err.Error())
-		return false
+		return nil, false
 	}
 	if rateLimiterTokens < 1 {
-		return false
+		return nil, false
 	}
 
 	rateLimiterRestore := time.Hour / time.Duration(rateLimiterTokens)
@@ -236,5 +341,5 @@ func (l *ListenerKarma) rateLimiterTake(userID, guildID string) bool {
 
 	l.limiters.SetExpires(key, lifetimeRateLimiter)
 
-	return limiter.Allow()
+	return limiter, true
 }
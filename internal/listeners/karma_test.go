@@ -1,6 +1,7 @@
 package listeners
 
 import (
+	"errors"
 	"math/rand"
 	"strconv"
 	"testing"
@@ -8,6 +9,7 @@ import (
 	"github.com/bwmarrin/discordgo"
 	"github.com/sarulabs/di/v2"
 	"github.com/stretchr/testify/mock"
+	"github.com/zekroTJA/shinpuru/internal/services/karma"
 	"github.com/zekroTJA/shinpuru/internal/util/static"
 	"github.com/zekroTJA/shinpuru/mocks"
 )
@@ -43,6 +45,7 @@ func getKarmaHandlerMock(prep ...func(t karmaHandlerMock)) karmaHandlerMock {
 
 	t.db.On("GetKarmaTokens", mock.Anything).Return(3, nil)
 	t.db.On("GetKarmaEmotes", mock.Anything).Return(karmaUp, karmaDown, nil)
+	t.db.On("GetKarmaPenalty", mock.Anything).Return(true, nil)
 
 	t.st.On("SelfUser").Return(&discordgo.User{ID: "self-id"}, nil)
 	t.st.On("Message", "channel-id", "message-self").Return(&discordgo.Message{
@@ -84,13 +87,18 @@ func getKarmaHandlerMock(prep ...func(t karmaHandlerMock)) karmaHandlerMock {
 	t.karma.On("IsBlockListed", mock.Anything, "user-blocked").Return(true, nil)
 	t.karma.On("IsBlockListed", mock.Anything, mock.Anything).Return(false, nil)
 	t.karma.On("Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	t.karma.On("CheckLimits", mock.Anything, "user-id-2", mock.Anything).
+		Return(&karma.LimitError{Limit: karma.LimitGiveCooldown})
+	t.karma.On("CheckLimits", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
 	t.session.On("User", "user-bot").Return(&discordgo.User{ID: "user-bot", Bot: true}, nil)
 	t.session.On("User", "user-id").Return(&discordgo.User{ID: "user-id"}, nil)
 	t.session.On("User", "user-id-2").Return(&discordgo.User{ID: "user-id-2"}, nil)
 	t.session.On("User", "user-id-3").Return(&discordgo.User{ID: "user-id-3"}, nil)
 	t.session.On("UserChannelCreate", "user-id").Return(&discordgo.Channel{ID: "user-id"}, nil)
+	t.session.On("UserChannelCreate", "user-id-2").Return(&discordgo.Channel{ID: "user-id-2"}, nil)
 	t.session.On("ChannelMessageSendEmbed", "user-id", mock.Anything).Return(nil, nil)
+	t.session.On("ChannelMessageSendEmbed", "user-id-2", mock.Anything).Return(nil, nil)
 	t.session.On("ChannelMessage", "channel-id", "message-uncached").Return(&discordgo.Message{
 		ID:        "message-id",
 		ChannelID: "channel-id",
@@ -206,6 +214,22 @@ func TestKarmaHandler(t *testing.T) {
 
 	m.karma.AssertNotCalled(t, "Update", "guild-disabled", "author-id", "user-id", 1)
 
+	// Karma limit exceeded
+	l.Handler(m.session, &discordgo.MessageReactionAdd{
+		MessageReaction: &discordgo.MessageReaction{
+			UserID:    "user-id-2",
+			MessageID: "message-id-1",
+			ChannelID: "channel-id",
+			GuildID:   "guild-enabled",
+			Emoji: discordgo.Emoji{
+				Name: karmaUp,
+			},
+		},
+	})
+
+	m.karma.AssertNotCalled(t, "Update", "guild-enabled", "author-id", "user-id-2", 1)
+	m.session.AssertCalled(t, "ChannelMessageSendEmbed", "user-id-2", mock.Anything)
+
 	// Only apply to message once
 	for i := 0; i < 3; i++ {
 		l.Handler(m.session, &discordgo.MessageReactionAdd{
@@ -263,3 +287,140 @@ func TestKarmaHandler(t *testing.T) {
 
 	m.karma.AssertNumberOfCalls(t, "Update", 3)
 }
+
+func TestKarmaHandlerKeepsTokensOnFailure(t *testing.T) {
+	m := getKarmaHandlerMock(func(t karmaHandlerMock) {
+		t.karma.On("Update", mock.Anything, mock.Anything, "user-id-3", mock.Anything).
+			Return(errors.New("test error"))
+		t.logger.On("Errorf", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	})
+
+	l := NewListenerKarma(m.ct)
+
+	// More failed attempts than tokens are available
+	for i := 0; i < 5; i++ {
+		l.Handler(m.session, &discordgo.MessageReactionAdd{
+			MessageReaction: &discordgo.MessageReaction{
+				UserID:    "user-id-3",
+				MessageID: "message-id-" + strconv.Itoa(i),
+				ChannelID: "channel-id",
+				GuildID:   "guild-enabled",
+				Emoji: discordgo.Emoji{
+					Name: karmaUp,
+				},
+			},
+		})
+	}
+
+	m.karma.AssertNumberOfCalls(t, "Update", 5)
+}
+
+func TestKarmaHandlerRemove(t *testing.T) {
+	m := getKarmaHandlerMock()
+
+	l := NewListenerKarma(m.ct)
+
+	reaction := func(messageID, emoji string) *discordgo.MessageReaction {
+		return &discordgo.MessageReaction{
+			UserID:    "user-id",
+			MessageID: messageID,
+			ChannelID: "channel-id",
+			GuildID:   "guild-enabled",
+			Emoji: discordgo.Emoji{
+				Name: emoji,
+			},
+		}
+	}
+
+	// Not applied message
+	l.HandlerRemove(m.session, &discordgo.MessageReactionRemove{
+		MessageReaction: reaction("message-id-1", karmaUp),
+	})
+
+	m.karma.AssertNumberOfCalls(t, "Update", 0)
+
+	l.Handler(m.session, &discordgo.MessageReactionAdd{
+		MessageReaction: reaction("message-id-1", karmaUp),
+	})
+
+	m.karma.AssertCalled(t, "Update", "guild-enabled", "author-id", "user-id", 1)
+
+	// Other reaction than the applied one
+	l.HandlerRemove(m.session, &discordgo.MessageReactionRemove{
+		MessageReaction: reaction("message-id-1", karmaDown),
+	})
+
+	m.karma.AssertNumberOfCalls(t, "Update", 1)
+
+	// Applied reaction
+	l.HandlerRemove(m.session, &discordgo.MessageReactionRemove{
+		MessageReaction: reaction("message-id-1", karmaUp),
+	})
+
+	m.karma.AssertCalled(t, "Update", "guild-enabled", "author-id", "", -1)
+	m.karma.AssertNumberOfCalls(t, "Update", 2)
+
+	// Reversed only once
+	l.HandlerRemove(m.session, &discordgo.MessageReactionRemove{
+		MessageReaction: reaction("message-id-1", karmaUp),
+	})
+
+	m.karma.AssertNumberOfCalls(t, "Update", 2)
+
+	// Message can be voted again after reversal
+	l.Handler(m.session, &discordgo.MessageReactionAdd{
+		MessageReaction: reaction("message-id-1", karmaDown),
+	})
+
+	m.karma.AssertCalled(t, "Update", "guild-enabled", "author-id", "user-id", -1)
+	m.karma.AssertNumberOfCalls(t, "Update", 3)
+}
+
+func TestKarmaHandlerRemoveRefundsPenalty(t *testing.T) {
+	m := getKarmaHandlerMock(func(t karmaHandlerMock) {
+		t.db.On("GetKarmaPenalty", "guild-enabled").Return(true, nil)
+	})
+
+	l := NewListenerKarma(m.ct)
+
+	reaction := &discordgo.MessageReaction{
+		UserID:    "user-id",
+		MessageID: "message-id-1",
+		ChannelID: "channel-id",
+		GuildID:   "guild-enabled",
+		Emoji: discordgo.Emoji{
+			Name: karmaDown,
+		},
+	}
+
+	l.Handler(m.session, &discordgo.MessageReactionAdd{MessageReaction: reaction})
+	m.karma.AssertCalled(t, "Update", "guild-enabled", "author-id", "user-id", -1)
+
+	l.HandlerRemove(m.session, &discordgo.MessageReactionRemove{MessageReaction: reaction})
+	m.karma.AssertCalled(t, "Update", "guild-enabled", "author-id", "", 1)
+	m.karma.AssertCalled(t, "Update", "guild-enabled", "user-id", "", 1)
+	m.karma.AssertNumberOfCalls(t, "Update", 3)
+}
+
+func TestKarmaHandlerRemoveWithoutPenalty(t *testing.T) {
+	m := getKarmaHandlerMock(func(t karmaHandlerMock) {
+		t.db.On("GetKarmaPenalty", "guild-enabled").Return(false, nil)
+	})
+
+	l := NewListenerKarma(m.ct)
+
+	reaction := &discordgo.MessageReaction{
+		UserID:    "user-id",
+		MessageID: "message-id-1",
+		ChannelID: "channel-id",
+		GuildID:   "guild-enabled",
+		Emoji: discordgo.Emoji{
+			Name: karmaDown,
+		},
+	}
+
+	l.Handler(m.session, &discordgo.MessageReactionAdd{MessageReaction: reaction})
+	l.HandlerRemove(m.session, &discordgo.MessageReactionRemove{MessageReaction: reaction})
+	m.karma.AssertNotCalled(t, "Update", "guild-enabled", "user-id", "", 1)
+	m.karma.AssertNumberOfCalls(t, "Update", 2)
+}
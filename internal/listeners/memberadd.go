@@ -1,12 +1,12 @@
 package listeners
 
 import (
-	"strings"
-
 	"github.com/bwmarrin/discordgo"
 	"github.com/sarulabs/di/v2"
 	"github.com/zekroTJA/shinpuru/internal/services/database"
 	"github.com/zekroTJA/shinpuru/internal/services/guildlog"
+	"github.com/zekroTJA/shinpuru/internal/services/kvcache"
+	"github.com/zekroTJA/shinpuru/internal/util/membermsg"
 	"github.com/zekroTJA/shinpuru/internal/util/static"
 	"github.com/zekroTJA/shinpuru/pkg/embedbuilder"
 	"github.com/zekroTJA/shinpuru/pkg/stringutil"
@@ -14,14 +14,16 @@ import (
 )
 
 type ListenerMemberAdd struct {
-	db database.Database
-	gl guildlog.Logger
+	db        database.Database
+	gl        guildlog.Logger
+	debouncer *membermsg.Debouncer
 }
 
 func NewListenerMemberAdd(container di.Container) *ListenerMemberAdd {
 	return &ListenerMemberAdd{
-		db: container.Get(static.DiDatabase).(database.Database),
-		gl: container.Get(static.DiGuildLog).(guildlog.Logger).Section("memberadd"),
+		db:        container.Get(static.DiDatabase).(database.Database),
+		gl:        container.Get(static.DiGuildLog).(guildlog.Logger).Section("memberadd"),
+		debouncer: membermsg.NewDebouncer(container.Get(static.DiKVCache).(kvcache.Provider)),
 	}
 }
 
@@ -55,22 +57,33 @@ func (l *ListenerMemberAdd) Handler(s *discordgo.Session, e *discordgo.GuildMemb
 		}
 	}
 
+	gracePeriod, noteRejoin, err := l.db.GetGuildMemberMsgGrace(e.GuildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		log.Error().Tag("MemberMsg").Err(err).Field("gid", e.GuildID).Msg("Failed getting member message grace period from database")
+		l.gl.Errorf(e.GuildID, "Failed getting member message grace period from database: %s", err.Error())
+	}
+	rejoined := l.debouncer.Join(e.GuildID, e.User.ID, gracePeriod)
+	if rejoined && !noteRejoin {
+		return
+	}
+
 	chanID, msg, err := l.db.GetGuildJoinMsg(e.GuildID)
 	if err == nil && msg != "" && chanID != "" {
 		txt := ""
-		if strings.Contains(msg, "[ment]") {
+		if membermsg.Mentions(msg) {
 			txt = e.User.Mention()
 		}
 
-		msg = strings.Replace(msg, "[user]", e.User.Username, -1)
-		msg = strings.Replace(msg, "[ment]", e.User.Mention(), -1)
+		emb := embedbuilder.New().
+			WithColor(static.ColorEmbedDefault).
+			WithDescription(membermsg.Render(msg, e.User))
+		if rejoined {
+			emb.WithFooter("Rejoined the guild", "", "")
+		}
 
 		s.ChannelMessageSendComplex(chanID, &discordgo.MessageSend{
 			Content: txt,
-			Embed: embedbuilder.New().
-				WithColor(static.ColorEmbedDefault).
-				WithDescription(msg).
-				Build(),
+			Embed:   emb.Build(),
 		})
 	}
 }
@@ -1,31 +1,68 @@
 package listeners
 
 import (
-	"strings"
+	"time"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/sarulabs/di/v2"
 	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/services/guildlog"
+	"github.com/zekroTJA/shinpuru/internal/services/kvcache"
 	"github.com/zekroTJA/shinpuru/internal/util"
+	"github.com/zekroTJA/shinpuru/internal/util/membermsg"
 	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekrotja/rogu/log"
 )
 
+// leaveMsgDelay is the time the leave message is delayed
+// to wait for a ban event which might be received after
+// the member remove event.
+const leaveMsgDelay = 3 * time.Second
+
 type ListenerMemberRemove struct {
-	db database.Database
+	db        database.Database
+	gl        guildlog.Logger
+	debouncer *membermsg.Debouncer
 }
 
 func NewListenerMemberRemove(container di.Container) *ListenerMemberRemove {
 	return &ListenerMemberRemove{
-		db: container.Get(static.DiDatabase).(database.Database),
+		db:        container.Get(static.DiDatabase).(database.Database),
+		gl:        container.Get(static.DiGuildLog).(guildlog.Logger).Section("memberremove"),
+		debouncer: membermsg.NewDebouncer(container.Get(static.DiKVCache).(kvcache.Provider)),
 	}
 }
 
 func (l *ListenerMemberRemove) Handler(s *discordgo.Session, e *discordgo.GuildMemberRemove) {
-	chanID, msg, err := l.db.GetGuildLeaveMsg(e.GuildID)
-	if err == nil && msg != "" && chanID != "" {
-		msg = strings.Replace(msg, "[user]", e.User.Username, -1)
-		msg = strings.Replace(msg, "[ment]", e.User.Mention(), -1)
+	gracePeriod, _, err := l.db.GetGuildMemberMsgGrace(e.GuildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		log.Error().Tag("MemberMsg").Err(err).Field("gid", e.GuildID).Msg("Failed getting member message grace period from database")
+		l.gl.Errorf(e.GuildID, "Failed getting member message grace period from database: %s", err.Error())
+	}
+	if !l.debouncer.Leave(e.GuildID, e.User.ID, gracePeriod) {
+		return
+	}
 
-		util.SendEmbed(s, chanID, msg, "", 0)
+	chanID, msg, err := l.db.GetGuildLeaveMsg(e.GuildID)
+	if err != nil || msg == "" || chanID == "" {
+		return
 	}
+
+	// The ban event is not guaranteed to be received
+	// before the member remove event, so the message is
+	// delayed and the ban is checked again afterwards.
+	time.AfterFunc(leaveMsgDelay, func() {
+		if l.debouncer.Banned(e.GuildID, e.User.ID) {
+			return
+		}
+		util.SendEmbed(s, chanID, membermsg.Render(msg, e.User), "", 0)
+	})
+}
+
+// HandlerBan records bans so that the member remove
+// event fired by Discord on bans does not result in
+// a leave message. Bans are logged in the mod log
+// instead.
+func (l *ListenerMemberRemove) HandlerBan(s *discordgo.Session, e *discordgo.GuildBanAdd) {
+	l.debouncer.Ban(e.GuildID, e.User.ID)
 }
@@ -0,0 +1,47 @@
+package listeners
+
+import (
+	"github.com/bwmarrin/discordgo"
+	"github.com/sarulabs/di/v2"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/services/kvcache"
+	"github.com/zekroTJA/shinpuru/internal/services/timeprovider"
+	"github.com/zekroTJA/shinpuru/internal/util/messagestats"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/pkg/discordutil"
+	"github.com/zekrotja/rogu"
+	"github.com/zekrotja/rogu/log"
+)
+
+type ListenerMessageStats struct {
+	db  database.Database
+	kvc kvcache.Provider
+	tp  timeprovider.Provider
+	log rogu.Logger
+}
+
+func NewListenerMessageStats(container di.Container) *ListenerMessageStats {
+	return &ListenerMessageStats{
+		db:  container.Get(static.DiDatabase).(database.Database),
+		kvc: container.Get(static.DiKVCache).(kvcache.Provider),
+		tp:  container.Get(static.DiTimeProvider).(timeprovider.Provider),
+		log: log.Tagged("MessageStats"),
+	}
+}
+
+func (l *ListenerMessageStats) HandlerMessageCreate(s discordutil.ISession, e *discordgo.MessageCreate) {
+	if e.GuildID == "" || e.Author == nil || e.Author.Bot || e.WebhookID != "" {
+		return
+	}
+
+	enabled, err := l.db.GetGuildMessageStatsEnabled(e.GuildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		l.log.Error().Err(err).Field("gid", e.GuildID).Msg("Failed getting message stats setting")
+		return
+	}
+	if !enabled {
+		return
+	}
+
+	messagestats.Track(l.kvc, l.tp, e.GuildID, e.ChannelID, e.Author.ID)
+}
@@ -0,0 +1,33 @@
+package listeners
+
+import (
+	"github.com/bwmarrin/discordgo"
+	"github.com/sarulabs/di/v2"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/util/modlog"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekrotja/rogu"
+	"github.com/zekrotja/rogu/log"
+)
+
+type ListenerModLogRoutes struct {
+	db  database.Database
+	log rogu.Logger
+}
+
+func NewListenerModLogRoutes(container di.Container) *ListenerModLogRoutes {
+	return &ListenerModLogRoutes{
+		db:  container.Get(static.DiDatabase).(database.Database),
+		log: log.Tagged("ModLogRoutes"),
+	}
+}
+
+func (l *ListenerModLogRoutes) HandlerChannelDelete(s *discordgo.Session, e *discordgo.ChannelDelete) {
+	if e.GuildID == "" {
+		return
+	}
+
+	if err := modlog.RemoveChannelRoutes(l.db, e.GuildID, e.Channel.ID); err != nil {
+		l.log.Error().Err(err).Fields("gid", e.GuildID, "cid", e.Channel.ID).Msg("Failed removing mod log routes")
+	}
+}
@@ -0,0 +1,240 @@
+package listeners
+
+import (
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/rs/xid"
+	"github.com/sarulabs/di/v2"
+	"github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/util/modmail"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/pkg/discordutil"
+	"github.com/zekrotja/dgrs"
+	"github.com/zekrotja/ken"
+	"github.com/zekrotja/rogu"
+	"github.com/zekrotja/rogu/log"
+)
+
+// maxModmailGuildOptions is the maximum amount of
+// options of a select menu.
+const maxModmailGuildOptions = 25
+
+type ListenerModmail struct {
+	db  database.Database
+	st  dgrs.IState
+	ken *ken.Ken
+	log rogu.Logger
+}
+
+func NewListenerModmail(container di.Container) *ListenerModmail {
+	return &ListenerModmail{
+		db:  container.Get(static.DiDatabase).(database.Database),
+		st:  container.Get(static.DiState).(dgrs.IState),
+		ken: container.Get(static.DiCommandHandler).(*ken.Ken),
+		log: log.Tagged("Modmail"),
+	}
+}
+
+func (l *ListenerModmail) HandlerMessageCreate(s *discordgo.Session, e *discordgo.MessageCreate) {
+	if e.Author == nil || e.Author.Bot {
+		return
+	}
+
+	if e.GuildID == "" {
+		l.handleDM(s, e.Message)
+	} else {
+		l.handleThreadMessage(s, e.Message)
+	}
+}
+
+func (l *ListenerModmail) handleDM(s *discordgo.Session, msg *discordgo.Message) {
+	threads, err := l.db.GetModmailThreadsByUser(msg.Author.ID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		l.log.Error().Err(err).Field("uid", msg.Author.ID).Msg("Failed getting modmail threads")
+		return
+	}
+
+	// A user can only have one open modmail conversation
+	// at a time, so all further messages are relayed to it.
+	if len(threads) > 0 {
+		err = l.relayToThread(s, threads[0], msg)
+		if err == nil {
+			return
+		}
+		if !discordutil.IsErrCode(err, discordgo.ErrCodeUnknownChannel) {
+			l.log.Error().Err(err).Field("uid", msg.Author.ID).Msg("Failed relaying modmail message")
+			return
+		}
+		// The thread has been deleted, so a new
+		// conversation is opened.
+		l.db.RemoveModmailThread(threads[0].ThreadID)
+	}
+
+	guilds, err := l.modmailGuilds(msg.Author.ID)
+	if err != nil {
+		l.log.Error().Err(err).Field("uid", msg.Author.ID).Msg("Failed getting modmail guilds")
+		return
+	}
+
+	switch len(guilds) {
+	case 0:
+		s.ChannelMessageSendEmbed(msg.ChannelID, &discordgo.MessageEmbed{
+			Color: static.ColorEmbedError,
+			Description: "You don't share any guild with me which has modmail enabled, " +
+				"so your message can not be forwarded.",
+		})
+	case 1:
+		l.open(s, guilds[0], msg)
+	default:
+		l.askForGuild(s, guilds, msg)
+	}
+}
+
+func (l *ListenerModmail) handleThreadMessage(s *discordgo.Session, msg *discordgo.Message) {
+	ch, err := l.st.Channel(msg.ChannelID)
+	if err != nil || ch == nil || !ch.IsThread() {
+		return
+	}
+
+	t, err := l.db.GetModmailThread(msg.ChannelID)
+	if database.IsErrDatabaseNotFound(err) {
+		return
+	}
+	if err != nil {
+		l.log.Error().Err(err).Field("threadid", msg.ChannelID).Msg("Failed getting modmail thread")
+		return
+	}
+
+	g, err := l.st.Guild(t.GuildID)
+	if err != nil {
+		l.log.Error().Err(err).Field("gid", t.GuildID).Msg("Failed getting guild")
+		return
+	}
+
+	_, err = discordutil.SendDMEmbed(s, t.UserID,
+		modmail.RelayEmbed(msg, static.ColorEmbedCyan, "Staff of "+g.Name))
+	if err != nil {
+		s.ChannelMessageSendEmbed(msg.ChannelID, &discordgo.MessageEmbed{
+			Color: static.ColorEmbedError,
+			Description: "The message could not be delivered to the user. " +
+				"They might have closed their DMs or left the guild.",
+		})
+		return
+	}
+
+	s.MessageReactionAdd(msg.ChannelID, msg.ID, "✅")
+}
+
+func (l *ListenerModmail) relayToThread(s *discordgo.Session, t models.ModmailThread, msg *discordgo.Message) (err error) {
+	_, err = s.ChannelMessageSendEmbed(t.ThreadID,
+		modmail.RelayEmbed(msg, static.ColorEmbedDefault, ""))
+	if err != nil {
+		return
+	}
+	s.MessageReactionAdd(msg.ChannelID, msg.ID, "✅")
+	return
+}
+
+func (l *ListenerModmail) open(s *discordgo.Session, guild *discordgo.Guild, msg *discordgo.Message) {
+	t, err := modmail.Open(l.db, s, guild.ID, msg.Author)
+	if err != nil {
+		l.log.Error().Err(err).Field("gid", guild.ID).Msg("Failed opening modmail thread")
+		s.ChannelMessageSendEmbed(msg.ChannelID, &discordgo.MessageEmbed{
+			Color:       static.ColorEmbedError,
+			Description: "Failed opening a modmail conversation. Please try again later.",
+		})
+		return
+	}
+
+	if err = l.relayToThread(s, t, msg); err != nil {
+		l.log.Error().Err(err).Field("gid", guild.ID).Msg("Failed relaying modmail message")
+		return
+	}
+
+	s.ChannelMessageSendEmbed(msg.ChannelID, &discordgo.MessageEmbed{
+		Color: static.ColorEmbedGreen,
+		Description: fmt.Sprintf("Your message has been forwarded to the staff of **%s**. "+
+			"Further messages will be forwarded as well until the conversation is closed.",
+			guild.Name),
+	})
+}
+
+func (l *ListenerModmail) askForGuild(s *discordgo.Session, guilds []*discordgo.Guild, msg *discordgo.Message) {
+	if len(guilds) > maxModmailGuildOptions {
+		guilds = guilds[:maxModmailGuildOptions]
+	}
+
+	options := make([]discordgo.SelectMenuOption, len(guilds))
+	for i, g := range guilds {
+		options[i] = discordgo.SelectMenuOption{
+			Label: g.Name,
+			Value: g.ID,
+		}
+	}
+
+	prompt, err := s.ChannelMessageSendEmbed(msg.ChannelID, &discordgo.MessageEmbed{
+		Color:       static.ColorEmbedDefault,
+		Description: "To which guild's staff do you want to send your message?",
+	})
+	if err != nil {
+		return
+	}
+
+	_, err = l.ken.Components().Add(prompt.ID, prompt.ChannelID).
+		AddActionsRow(func(b ken.ComponentAssembler) {
+			b.Add(discordgo.SelectMenu{
+				CustomID:    xid.New().String(),
+				Placeholder: "Select a guild",
+				Options:     options,
+			}, func(ctx ken.ComponentContext) bool {
+				ctx.Respond(&discordgo.InteractionResponse{
+					Type: discordgo.InteractionResponseDeferredMessageUpdate,
+				})
+
+				values := ctx.GetData().Values
+				if len(values) == 0 {
+					return false
+				}
+				for _, g := range guilds {
+					if g.ID == values[0] {
+						l.open(s, g, msg)
+						return true
+					}
+				}
+				return false
+			})
+		}, true).
+		Build()
+	if err != nil {
+		l.log.Error().Err(err).Msg("Failed attaching guild select")
+	}
+}
+
+// modmailGuilds returns all guilds the user is member of
+// which have a modmail channel set.
+func (l *ListenerModmail) modmailGuilds(userID string) ([]*discordgo.Guild, error) {
+	guildIDs, err := l.st.UserGuilds(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	guilds := make([]*discordgo.Guild, 0, len(guildIDs))
+	for _, id := range guildIDs {
+		chanID, err := l.db.GetGuildModmailChannel(id)
+		if err != nil && !database.IsErrDatabaseNotFound(err) {
+			return nil, err
+		}
+		if chanID == "" {
+			continue
+		}
+		g, err := l.st.Guild(id)
+		if err != nil {
+			return nil, err
+		}
+		guilds = append(guilds, g)
+	}
+
+	return guilds, nil
+}
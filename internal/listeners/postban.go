@@ -11,6 +11,7 @@ import (
 	"github.com/zekroTJA/shinpuru/internal/services/guildlog"
 	"github.com/zekroTJA/shinpuru/internal/services/permissions"
 	"github.com/zekroTJA/shinpuru/internal/services/report"
+	"github.com/zekroTJA/shinpuru/internal/util/modlog"
 	"github.com/zekroTJA/shinpuru/internal/util/static"
 	"github.com/zekroTJA/shinpuru/pkg/discordutil"
 	"github.com/zekroTJA/shinpuru/pkg/stringutil"
@@ -26,7 +27,7 @@ type ListenerPostBan struct {
 	gl  guildlog.Logger
 	rep report.Provider
 	pmw permissions.Provider
-	st  *dgrs.State
+	st  dgrs.IState
 	log rogu.Logger
 }
 
@@ -37,14 +38,14 @@ func NewListenerPostBan(ctn di.Container) ListenerPostBan {
 		gl:  ctn.Get(static.DiGuildLog).(guildlog.Logger).Section("postban"),
 		rep: ctn.Get(static.DiReport).(report.Provider),
 		pmw: ctn.Get(static.DiPermissions).(permissions.Provider),
-		st:  ctn.Get(static.DiState).(*dgrs.State),
+		st:  ctn.Get(static.DiState).(dgrs.IState),
 		log: log.Tagged("PostBan"),
 	}
 }
 
 func (t ListenerPostBan) Handler(s discordutil.ISession, e *discordgo.GuildBanAdd) {
-	modlogChan, err := t.db.GetGuildModLog(e.GuildID)
-	if err != nil && !database.IsErrDatabaseNotFound(err) {
+	modlogChan, err := modlog.Channel(t.db, e.GuildID, models.ModLogActionBan)
+	if err != nil {
 		t.error(e.GuildID, "failed getting modlog channel", err)
 		return
 	}
@@ -92,7 +93,7 @@ func (t ListenerPostBan) Handler(s discordutil.ISession, e *discordgo.GuildBanAd
 		Anonymous:  true,
 	}
 
-	emb := rep.AsEmbed("")
+	emb := rep.AsEmbed("", nil)
 	emb.Title = "User banned"
 	emb.Description = "A user has just been banned."
 
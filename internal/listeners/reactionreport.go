@@ -0,0 +1,210 @@
+package listeners
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/sarulabs/di/v2"
+	"github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/services/guildlog"
+	"github.com/zekroTJA/shinpuru/internal/services/kvcache"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/pkg/discordutil"
+	"github.com/zekroTJA/shinpuru/pkg/embedbuilder"
+	"github.com/zekroTJA/shinpuru/pkg/keylock"
+	"github.com/zekroTJA/shinpuru/pkg/stringutil"
+	"github.com/zekrotja/dgrs"
+	"github.com/zekrotja/rogu"
+	"github.com/zekrotja/rogu/log"
+)
+
+const (
+	// reactionReportLifetime is the time in which further
+	// reports of the same message are added to the already
+	// sent report instead of sending a new one.
+	reactionReportLifetime = 24 * time.Hour
+	// reactionReportMaxContent is the maximum length of the
+	// message content shown in the report.
+	reactionReportMaxContent = 1000
+)
+
+// reactionReport is a message reported by reaction and
+// the report sent to the moderators.
+type reactionReport struct {
+	GuildID     string
+	ChannelID   string
+	MessageID   string
+	AuthorID    string
+	Content     string
+	Attachments int
+	Reporters   []string
+	Deleted     bool
+
+	ReportChannelID string
+	ReportMessageID string
+}
+
+func (r *reactionReport) embed() *discordgo.MessageEmbed {
+	content := r.Content
+	if len([]rune(content)) > reactionReportMaxContent {
+		content = string([]rune(content)[:reactionReportMaxContent]) + "…"
+	}
+	if content == "" {
+		content = "*The message has no text content.*"
+	}
+	if r.Attachments > 0 {
+		content += fmt.Sprintf("\n\n*+ %d attachment(s)*", r.Attachments)
+	}
+
+	reporters := make([]string, len(r.Reporters))
+	for i, id := range r.Reporters {
+		reporters[i] = "<@" + id + ">"
+	}
+
+	message := fmt.Sprintf("[Jump to message](%s)", discordutil.GetMessageLink(
+		&discordgo.Message{ChannelID: r.ChannelID, ID: r.MessageID}, r.GuildID))
+	color := static.ColorEmbedOrange
+	if r.Deleted {
+		message = "*The message has been deleted.*"
+		color = static.ColorEmbedGray
+	}
+
+	return embedbuilder.New().
+		WithColor(color).
+		WithTitle("Message Reported").
+		WithDescription(content).
+		AddInlineField("Author", "<@"+r.AuthorID+">").
+		AddInlineField("Channel", "<#"+r.ChannelID+">").
+		AddInlineField(fmt.Sprintf("Reported by (%d)", len(r.Reporters)), strings.Join(reporters, ", ")).
+		AddField("Message", message).
+		WithFooter("Message ID: "+r.MessageID, "", "").
+		Build()
+}
+
+type ListenerReactionReport struct {
+	db  database.Database
+	st  dgrs.IState
+	kvc kvcache.Provider
+	gl  guildlog.Logger
+	log rogu.Logger
+
+	locks keylock.Locker
+}
+
+func NewListenerReactionReport(container di.Container) *ListenerReactionReport {
+	return &ListenerReactionReport{
+		db:  container.Get(static.DiDatabase).(database.Database),
+		st:  container.Get(static.DiState).(dgrs.IState),
+		kvc: container.Get(static.DiKVCache).(kvcache.Provider),
+		gl:  container.Get(static.DiGuildLog).(guildlog.Logger).Section("reactionreport"),
+		log: log.Tagged("ReactionReport"),
+	}
+}
+
+func (l *ListenerReactionReport) HandlerReactionAdd(s discordutil.ISession, e *discordgo.MessageReactionAdd) {
+	if e.GuildID == "" || e.Member != nil && e.Member.User != nil && e.Member.User.Bot {
+		return
+	}
+
+	cfg, err := l.db.GetReactionReportConfig(e.GuildID)
+	if err != nil {
+		if !database.IsErrDatabaseNotFound(err) {
+			l.log.Error().Err(err).Field("gid", e.GuildID).Msg("Failed getting reaction report config")
+		}
+		return
+	}
+	if !cfg.Enabled() || !cfg.Matches(e.Emoji) {
+		return
+	}
+
+	// The reaction is removed so that the reporter
+	// is only visible to the moderators.
+	if err = s.MessageReactionRemove(e.ChannelID, e.MessageID, e.Emoji.APIName(), e.UserID); err != nil {
+		l.gl.Warnf(e.GuildID, "Failed removing report reaction in channel <#%s>: %s", e.ChannelID, err.Error())
+	}
+
+	defer l.locks.Lock(e.MessageID)()
+
+	key := "reactionreport:msg:" + e.MessageID
+	rep, _ := l.kvc.Get(key).(*reactionReport)
+	if rep != nil && stringutil.ContainsAny(e.UserID, rep.Reporters) {
+		return
+	}
+
+	if cfg.Cooldown > 0 {
+		cooldownKey := fmt.Sprintf("reactionreport:cooldown:%s:%s", e.GuildID, e.UserID)
+		if l.kvc.Incr(cooldownKey, 1, time.Duration(cfg.Cooldown)*time.Second) > 1 {
+			return
+		}
+	}
+
+	if rep != nil {
+		rep.Reporters = append(rep.Reporters, e.UserID)
+		if _, err = s.ChannelMessageEditEmbed(rep.ReportChannelID, rep.ReportMessageID, rep.embed()); err != nil {
+			l.gl.Errorf(e.GuildID, "Failed updating reaction report: %s", err.Error())
+		}
+		return
+	}
+
+	msg, err := l.st.Message(e.ChannelID, e.MessageID)
+	if err != nil {
+		if !discordutil.IsErrCode(err, discordgo.ErrCodeUnknownMessage) {
+			l.log.Error().Err(err).Field("gid", e.GuildID).Msg("Failed getting reported message")
+		}
+		return
+	}
+
+	rep = l.newReport(e.GuildID, msg, cfg)
+	rep.Reporters = []string{e.UserID}
+
+	sent, err := s.ChannelMessageSendEmbed(cfg.ChannelID, rep.embed())
+	if err != nil {
+		l.gl.Errorf(e.GuildID, "Failed sending reaction report to channel <#%s>: %s", cfg.ChannelID, err.Error())
+		return
+	}
+
+	rep.ReportMessageID = sent.ID
+	l.kvc.Set(key, rep, reactionReportLifetime)
+}
+
+func (l *ListenerReactionReport) HandlerMessageDelete(s discordutil.ISession, e *discordgo.MessageDelete) {
+	l.markDeleted(s, e.ID)
+}
+
+func (l *ListenerReactionReport) HandlerMessageBulkDelete(s discordutil.ISession, e *discordgo.MessageDeleteBulk) {
+	for _, id := range e.Messages {
+		l.markDeleted(s, id)
+	}
+}
+
+func (l *ListenerReactionReport) newReport(guildID string, msg *discordgo.Message, cfg models.ReactionReportConfig) *reactionReport {
+	rep := &reactionReport{
+		GuildID:         guildID,
+		ChannelID:       msg.ChannelID,
+		MessageID:       msg.ID,
+		Content:         msg.Content,
+		Attachments:     len(msg.Attachments),
+		ReportChannelID: cfg.ChannelID,
+	}
+	if msg.Author != nil {
+		rep.AuthorID = msg.Author.ID
+	}
+	return rep
+}
+
+func (l *ListenerReactionReport) markDeleted(s discordutil.ISession, messageID string) {
+	defer l.locks.Lock(messageID)()
+
+	rep, _ := l.kvc.Get("reactionreport:msg:" + messageID).(*reactionReport)
+	if rep == nil || rep.Deleted {
+		return
+	}
+
+	rep.Deleted = true
+	if _, err := s.ChannelMessageEditEmbed(rep.ReportChannelID, rep.ReportMessageID, rep.embed()); err != nil {
+		l.gl.Errorf(rep.GuildID, "Failed updating reaction report: %s", err.Error())
+	}
+}
@@ -0,0 +1,154 @@
+package listeners
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/sarulabs/di/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/services/kvcache"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/mocks"
+)
+
+type reactionReportMock struct {
+	session *mocks.ISession
+	db      *mocks.Database
+	state   *mocks.IState
+	logger  *mocks.Logger
+
+	ct di.Container
+}
+
+func getReactionReportMock(f ...func(m reactionReportMock)) reactionReportMock {
+	var t reactionReportMock
+
+	t.session = &mocks.ISession{}
+	t.db = &mocks.Database{}
+	t.state = &mocks.IState{}
+	t.logger = &mocks.Logger{}
+
+	t.logger.On("Section", mock.Anything).Return(t.logger)
+
+	if len(f) != 0 {
+		f[0](t)
+	}
+
+	ct, _ := di.NewBuilder()
+	ct.Add(
+		di.Def{
+			Name:  static.DiDatabase,
+			Build: func(ctn di.Container) (interface{}, error) { return t.db, nil },
+		},
+		di.Def{
+			Name:  static.DiState,
+			Build: func(ctn di.Container) (interface{}, error) { return t.state, nil },
+		},
+		di.Def{
+			Name:  static.DiKVCache,
+			Build: func(ctn di.Container) (interface{}, error) { return kvcache.NewTimedmapCache(time.Minute), nil },
+		},
+		di.Def{
+			Name:  static.DiGuildLog,
+			Build: func(ctn di.Container) (interface{}, error) { return t.logger, nil },
+		},
+	)
+
+	t.ct = ct.Build()
+
+	return t
+}
+
+func TestReactionReportConfigMatches(t *testing.T) {
+	cfg := models.ReactionReportConfig{Emoji: "🚩"}
+	assert.True(t, cfg.Matches(discordgo.Emoji{Name: "🚩"}))
+	assert.False(t, cfg.Matches(discordgo.Emoji{Name: "⭐"}))
+
+	cfg.Emoji = "<:report:123>"
+	assert.True(t, cfg.Matches(discordgo.Emoji{ID: "123", Name: "report"}))
+	assert.False(t, cfg.Matches(discordgo.Emoji{ID: "456", Name: "report"}))
+	assert.False(t, cfg.Matches(discordgo.Emoji{Name: "report"}))
+}
+
+func TestReactionReportConfigValidEmoji(t *testing.T) {
+	valid := []string{"🚩", "👍🏽", "<:report:123>", "<a:report:123>"}
+	for _, e := range valid {
+		assert.True(t, models.ReactionReportConfig{Emoji: e}.ValidEmoji(), e)
+	}
+
+	invalid := []string{"", "report", "🚩🚩", "<:report:>", ":report:"}
+	for _, e := range invalid {
+		assert.False(t, models.ReactionReportConfig{Emoji: e}.ValidEmoji(), e)
+	}
+}
+
+func TestReactionReportHandlerReactionAdd(t *testing.T) {
+	m := getReactionReportMock(func(t reactionReportMock) {
+		t.db.On("GetReactionReportConfig", "guild-id").Return(models.ReactionReportConfig{
+			GuildID:   "guild-id",
+			ChannelID: "channel-mod",
+			Emoji:     "🚩",
+			Cooldown:  60,
+		}, nil)
+		t.db.On("GetReactionReportConfig", mock.Anything).
+			Return(models.ReactionReportConfig{}, database.ErrDatabaseNotFound)
+
+		t.state.On("Message", "channel-id", "msg-id").Return(&discordgo.Message{
+			ID:        "msg-id",
+			ChannelID: "channel-id",
+			Content:   "some content",
+			Author:    &discordgo.User{ID: "author-id"},
+		}, nil)
+
+		t.session.On("MessageReactionRemove", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(nil)
+		t.session.On("ChannelMessageSendEmbed", "channel-mod", mock.Anything).
+			Return(&discordgo.Message{ID: "report-id"}, nil)
+		t.session.On("ChannelMessageEditEmbed", "channel-mod", "report-id", mock.Anything).
+			Return(&discordgo.Message{ID: "report-id"}, nil)
+	})
+
+	l := NewListenerReactionReport(m.ct)
+
+	getReaction := func(guildID, userID, emoji string) *discordgo.MessageReactionAdd {
+		return &discordgo.MessageReactionAdd{MessageReaction: &discordgo.MessageReaction{
+			GuildID:   guildID,
+			ChannelID: "channel-id",
+			MessageID: "msg-id",
+			UserID:    userID,
+			Emoji:     discordgo.Emoji{Name: emoji},
+		}}
+	}
+
+	// Not configured guild and other emojis
+	l.HandlerReactionAdd(m.session, getReaction("guild-other", "user-1", "🚩"))
+	l.HandlerReactionAdd(m.session, getReaction("guild-id", "user-1", "⭐"))
+	m.session.AssertNotCalled(t, "MessageReactionRemove", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	m.session.AssertNotCalled(t, "ChannelMessageSendEmbed", mock.Anything, mock.Anything)
+
+	// First report is sent and the reaction is removed
+	l.HandlerReactionAdd(m.session, getReaction("guild-id", "user-1", "🚩"))
+	m.session.AssertCalled(t, "MessageReactionRemove", "channel-id", "msg-id", "🚩", "user-1")
+	m.session.AssertNumberOfCalls(t, "ChannelMessageSendEmbed", 1)
+	emb := m.session.Calls[len(m.session.Calls)-1].Arguments.Get(1).(*discordgo.MessageEmbed)
+	assert.Equal(t, "some content", emb.Description)
+
+	// Reports of the same message are de-duplicated
+	l.HandlerReactionAdd(m.session, getReaction("guild-id", "user-1", "🚩"))
+	m.session.AssertNumberOfCalls(t, "ChannelMessageEditEmbed", 0)
+	l.HandlerReactionAdd(m.session, getReaction("guild-id", "user-2", "🚩"))
+	m.session.AssertNumberOfCalls(t, "ChannelMessageSendEmbed", 1)
+	m.session.AssertNumberOfCalls(t, "ChannelMessageEditEmbed", 1)
+	emb = m.session.Calls[len(m.session.Calls)-1].Arguments.Get(2).(*discordgo.MessageEmbed)
+	assert.Equal(t, "Reported by (2)", emb.Fields[2].Name)
+
+	// Deleted messages are marked in the report
+	l.HandlerMessageDelete(m.session, &discordgo.MessageDelete{Message: &discordgo.Message{ID: "msg-id"}})
+	m.session.AssertNumberOfCalls(t, "ChannelMessageEditEmbed", 2)
+	emb = m.session.Calls[len(m.session.Calls)-1].Arguments.Get(2).(*discordgo.MessageEmbed)
+	assert.Equal(t, static.ColorEmbedGray, emb.Color)
+}
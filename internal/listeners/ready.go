@@ -9,6 +9,7 @@ import (
 	"github.com/zekrotja/rogu"
 	"github.com/zekrotja/rogu/log"
 
+	"github.com/zekroTJA/shinpuru/internal/services/broadcast"
 	"github.com/zekroTJA/shinpuru/internal/services/database"
 	"github.com/zekroTJA/shinpuru/internal/services/guildlog"
 	"github.com/zekroTJA/shinpuru/internal/services/scheduler"
@@ -21,9 +22,10 @@ import (
 
 type ListenerReady struct {
 	db    database.Database
+	bc    *broadcast.BroadcastService
 	gl    guildlog.Logger
 	sched scheduler.Provider
-	st    *dgrs.State
+	st    dgrs.IState
 	tp    timeprovider.Provider
 	log   rogu.Logger
 }
@@ -31,9 +33,10 @@ type ListenerReady struct {
 func NewListenerReady(container di.Container) *ListenerReady {
 	return &ListenerReady{
 		db:    container.Get(static.DiDatabase).(database.Database),
+		bc:    container.Get(static.DiBroadcast).(*broadcast.BroadcastService),
 		gl:    container.Get(static.DiGuildLog).(guildlog.Logger).Section("ready"),
 		sched: container.Get(static.DiScheduler).(scheduler.Provider),
-		st:    container.Get(static.DiState).(*dgrs.State),
+		st:    container.Get(static.DiState).(dgrs.IState),
 		tp:    container.Get(static.DiTimeProvider).(timeprovider.Provider),
 		log:   log.Tagged("Ready"),
 	}
@@ -81,6 +84,10 @@ func (l *ListenerReady) Handler(s *discordgo.Session, e *discordgo.Ready) {
 		}
 	}
 
+	if err = l.bc.Resume(); err != nil {
+		l.log.Error().Err(err).Msg("Failed resuming broadcasts")
+	}
+
 	time.Sleep(1 * time.Second)
 
 	l.log.Info().Field("n", len(e.Guilds)).Msg("Start caching members of guilds ...")
@@ -0,0 +1,104 @@
+package listeners
+
+import (
+	"github.com/bwmarrin/discordgo"
+	"github.com/sarulabs/di/v2"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/services/guildlog"
+	"github.com/zekroTJA/shinpuru/internal/util/rules"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/pkg/discordutil"
+	"github.com/zekrotja/ken"
+	"github.com/zekrotja/rogu"
+	"github.com/zekrotja/rogu/log"
+)
+
+type ListenerRules struct {
+	db  database.Database
+	ken ken.IKen
+	gl  guildlog.Logger
+	log rogu.Logger
+}
+
+func NewListenerRules(container di.Container) *ListenerRules {
+	return &ListenerRules{
+		db:  container.Get(static.DiDatabase).(database.Database),
+		ken: container.Get(static.DiCommandHandler).(ken.IKen),
+		gl:  container.Get(static.DiGuildLog).(guildlog.Logger).Section("rules"),
+		log: log.Tagged("Rules"),
+	}
+}
+
+func (l *ListenerRules) HandlerMessageDelete(s discordutil.ISession, e *discordgo.MessageDelete) {
+	l.handleDeleted(e.GuildID, e.ID)
+}
+
+func (l *ListenerRules) HandlerMessageBulkDelete(s discordutil.ISession, e *discordgo.MessageDeleteBulk) {
+	for _, msgID := range e.Messages {
+		l.handleDeleted(e.GuildID, msgID)
+	}
+}
+
+func (l *ListenerRules) Ready(s discordutil.ISession, e *discordgo.Ready) {
+	allRules, err := l.db.GetAllGuildRules()
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		l.log.Error().Err(err).Msg("Retrieving stored rules failed")
+		return
+	}
+
+	for _, r := range allRules {
+		if r.MessageID == "" {
+			continue
+		}
+		err = rules.AttachAcceptButton(l.db, l.ken.Components(), r)
+		if err == nil {
+			continue
+		}
+		if discordutil.IsErrMessageGone(err) {
+			l.forgetMessage(r.GuildID)
+			continue
+		}
+		l.log.Error().Err(err).Fields(
+			"guild", r.GuildID,
+			"channel", r.ChannelID,
+			"message", r.MessageID,
+		).Msg("Re-Attaching accept button failed")
+	}
+}
+
+func (l *ListenerRules) handleDeleted(guildID, messageID string) {
+	if guildID == "" {
+		return
+	}
+
+	r, err := l.db.GetGuildRules(guildID)
+	if database.IsErrDatabaseNotFound(err) {
+		return
+	}
+	if err != nil {
+		l.log.Error().Err(err).Field("guild", guildID).Msg("Failed getting rules")
+		return
+	}
+
+	if r.MessageID != messageID {
+		return
+	}
+
+	l.forgetMessage(guildID)
+	l.gl.Warnf(guildID, "The rules message has been deleted. Use `/rules set` to post the rules again.")
+}
+
+// forgetMessage removes the message reference from the
+// rules of the guild so that the rules are posted as new
+// message the next time they are set.
+func (l *ListenerRules) forgetMessage(guildID string) {
+	r, err := l.db.GetGuildRules(guildID)
+	if err != nil {
+		return
+	}
+
+	r.MessageID = ""
+	if err = l.db.SetGuildRules(r); err != nil {
+		l.log.Error().Err(err).Field("guild", guildID).Msg("Failed removing rules message reference")
+	}
+}
@@ -39,13 +39,14 @@ var (
 )
 
 type ListenerStarboard struct {
-	publicAddr string
+	publicAddr      string
+	attachmentHosts []string
 
 	db    database.Database
 	gl    guildlog.Logger
 	st    storage.Storage
 	karma *karma.Service
-	state *dgrs.State
+	state dgrs.IState
 	log   rogu.Logger
 }
 
@@ -53,12 +54,14 @@ func NewListenerStarboard(container di.Container) *ListenerStarboard {
 	cfg := container.Get(static.DiConfig).(config.Provider)
 	return &ListenerStarboard{
 		publicAddr: cfg.Config().WebServer.PublicAddr,
-		db:         container.Get(static.DiDatabase).(database.Database),
-		gl:         container.Get(static.DiGuildLog).(guildlog.Logger).Section("starboard"),
-		st:         container.Get(static.DiObjectStorage).(storage.Storage),
-		karma:      container.Get(static.DiKarma).(*karma.Service),
-		state:      container.Get(static.DiState).(*dgrs.State),
-		log:        log.Tagged("Starboard"),
+		attachmentHosts: imgstore.AllowedHosts(
+			cfg.Config().WebServer.PublicAddr, cfg.Config().WebServer.AttachmentHosts),
+		db:    container.Get(static.DiDatabase).(database.Database),
+		gl:    container.Get(static.DiGuildLog).(guildlog.Logger).Section("starboard"),
+		st:    container.Get(static.DiObjectStorage).(storage.Storage),
+		karma: container.Get(static.DiKarma).(*karma.Service),
+		state: container.Get(static.DiState).(dgrs.IState),
+		log:   log.Tagged("Starboard"),
 	}
 }
 
@@ -375,7 +378,7 @@ func (l *ListenerStarboard) blurImage(sourceURL string) (targetURL string, err e
 		return
 	}
 
-	img, err := imgstore.DownloadFromURL(sourceURL)
+	img, err := imgstore.DownloadFromURL(sourceURL, l.attachmentHosts)
 	if err != nil {
 		return
 	}
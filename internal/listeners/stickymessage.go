@@ -0,0 +1,113 @@
+package listeners
+
+import (
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/sarulabs/di/v2"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/services/guildlog"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/internal/util/stickymsg"
+	"github.com/zekroTJA/shinpuru/pkg/discordutil"
+	"github.com/zekroTJA/timedmap"
+	"github.com/zekrotja/dgrs"
+	"github.com/zekrotja/rogu"
+	"github.com/zekrotja/rogu/log"
+)
+
+const (
+	// stickyRepostInterval is the time after a message
+	// after which the sticky message is reposted. All
+	// messages sent in the meantime trigger no further
+	// reposts.
+	stickyRepostInterval = 5 * time.Second
+	// stickyPermWarnCooldown is the time after which
+	// a missing permission is logged again for the same
+	// channel to avoid flooding the guild log.
+	stickyPermWarnCooldown = 1 * time.Hour
+)
+
+type ListenerStickyMessage struct {
+	db  database.Database
+	st  dgrs.IState
+	gl  guildlog.Logger
+	log rogu.Logger
+
+	mtx       sync.Mutex
+	pending   map[string]struct{}
+	permWarns *timedmap.TimedMap
+
+	afterFunc func(d time.Duration, f func())
+}
+
+func NewListenerStickyMessage(container di.Container) *ListenerStickyMessage {
+	return &ListenerStickyMessage{
+		db:  container.Get(static.DiDatabase).(database.Database),
+		st:  container.Get(static.DiState).(dgrs.IState),
+		gl:  container.Get(static.DiGuildLog).(guildlog.Logger).Section("stickymessage"),
+		log: log.Tagged("StickyMessage"),
+
+		pending:   make(map[string]struct{}),
+		permWarns: timedmap.New(10 * time.Minute),
+
+		afterFunc: func(d time.Duration, f func()) { time.AfterFunc(d, f) },
+	}
+}
+
+func (l *ListenerStickyMessage) HandlerMessageCreate(s discordutil.ISession, e *discordgo.MessageCreate) {
+	if e.GuildID == "" || e.Author == nil {
+		return
+	}
+
+	self, err := l.st.SelfUser()
+	if err != nil {
+		l.log.Error().Err(err).Msg("Failed getting self user")
+		return
+	}
+	if e.Author.ID == self.ID {
+		return
+	}
+
+	_, err = l.db.GetStickyMessage(e.GuildID, e.ChannelID)
+	if database.IsErrDatabaseNotFound(err) {
+		return
+	}
+	if err != nil {
+		l.log.Error().Err(err).Fields("gid", e.GuildID, "cid", e.ChannelID).Msg("Failed getting sticky message")
+		return
+	}
+
+	l.mtx.Lock()
+	_, ok := l.pending[e.ChannelID]
+	l.pending[e.ChannelID] = struct{}{}
+	l.mtx.Unlock()
+
+	if ok {
+		return
+	}
+
+	l.afterFunc(stickyRepostInterval, func() {
+		l.mtx.Lock()
+		delete(l.pending, e.ChannelID)
+		l.mtx.Unlock()
+
+		l.repost(s, e.GuildID, e.ChannelID)
+	})
+}
+
+func (l *ListenerStickyMessage) repost(s discordutil.ISession, guildID, channelID string) {
+	err := stickymsg.Repost(l.db, s, guildID, channelID)
+	if discordutil.IsErrCode(err, discordgo.ErrCodeMissingPermissions) ||
+		discordutil.IsErrCode(err, discordgo.ErrCodeMissingAccess) {
+		if !l.permWarns.Contains(channelID) {
+			l.permWarns.Set(channelID, struct{}{}, stickyPermWarnCooldown)
+			l.gl.Warnf(guildID, "Missing permission to repost sticky message in channel %s", channelID)
+		}
+		return
+	}
+	if err != nil {
+		l.log.Error().Err(err).Fields("gid", guildID, "cid", channelID).Msg("Failed reposting sticky message")
+	}
+}
@@ -0,0 +1,120 @@
+package listeners
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/sarulabs/di/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/mocks"
+)
+
+type stickyMessageMock struct {
+	session *mocks.ISession
+	db      *mocks.Database
+	state   *mocks.IState
+	logger  *mocks.Logger
+
+	ct di.Container
+}
+
+func getStickyMessageMock(f ...func(m stickyMessageMock)) stickyMessageMock {
+	var t stickyMessageMock
+
+	t.session = &mocks.ISession{}
+	t.db = &mocks.Database{}
+	t.state = &mocks.IState{}
+	t.logger = &mocks.Logger{}
+
+	t.state.On("SelfUser").Return(&discordgo.User{ID: "self-id"}, nil)
+	t.logger.On("Warnf", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	t.logger.On("Section", mock.Anything).Return(t.logger)
+
+	if len(f) != 0 {
+		f[0](t)
+	}
+
+	ct, _ := di.NewBuilder()
+	ct.Add(
+		di.Def{
+			Name:  static.DiDatabase,
+			Build: func(ctn di.Container) (interface{}, error) { return t.db, nil },
+		},
+		di.Def{
+			Name:  static.DiState,
+			Build: func(ctn di.Container) (interface{}, error) { return t.state, nil },
+		},
+		di.Def{
+			Name:  static.DiGuildLog,
+			Build: func(ctn di.Container) (interface{}, error) { return t.logger, nil },
+		},
+	)
+
+	t.ct = ct.Build()
+
+	return t
+}
+
+func TestStickyMessageHandlerMessageCreate(t *testing.T) {
+	sm := models.StickyMessage{
+		GuildID:   "guild-id",
+		ChannelID: "channel-id",
+		Content:   "sticky",
+		MessageID: "sticky-id",
+	}
+
+	m := getStickyMessageMock(func(t stickyMessageMock) {
+		t.db.On("GetStickyMessage", "guild-id", "channel-id").Return(sm, nil)
+		t.db.On("GetStickyMessage", "guild-id", mock.Anything).
+			Return(models.StickyMessage{}, database.ErrDatabaseNotFound)
+		t.db.On("SetStickyMessage", mock.Anything).Return(nil)
+
+		t.session.On("ChannelMessageDelete", "channel-id", "sticky-id").Return(nil)
+		t.session.On("ChannelMessageSendComplex", "channel-id", mock.Anything).
+			Return(&discordgo.Message{ID: "new-sticky-id"}, nil)
+	})
+
+	l := NewListenerStickyMessage(m.ct)
+
+	var scheduled []func()
+	l.afterFunc = func(d time.Duration, f func()) {
+		assert.Equal(t, stickyRepostInterval, d)
+		scheduled = append(scheduled, f)
+	}
+
+	getMessage := func(channelID, userID string) *discordgo.MessageCreate {
+		return &discordgo.MessageCreate{Message: &discordgo.Message{
+			GuildID:   "guild-id",
+			ChannelID: channelID,
+			Author:    &discordgo.User{ID: userID},
+		}}
+	}
+
+	// Own messages and channels without sticky
+	// messages do not trigger a repost.
+	l.HandlerMessageCreate(m.session, getMessage("channel-id", "self-id"))
+	l.HandlerMessageCreate(m.session, getMessage("channel-other", "user-id"))
+	assert.Len(t, scheduled, 0)
+
+	// A burst of messages triggers only one repost.
+	l.HandlerMessageCreate(m.session, getMessage("channel-id", "user-id"))
+	l.HandlerMessageCreate(m.session, getMessage("channel-id", "user-id"))
+	l.HandlerMessageCreate(m.session, getMessage("channel-id", "other-user-id"))
+	assert.Len(t, scheduled, 1)
+
+	scheduled[0]()
+	m.session.AssertCalled(t, "ChannelMessageDelete", "channel-id", "sticky-id")
+	m.session.AssertNumberOfCalls(t, "ChannelMessageSendComplex", 1)
+	sm.MessageID = "new-sticky-id"
+	m.db.AssertCalled(t, "SetStickyMessage", sm)
+
+	// After the repost, new messages schedule
+	// the next repost.
+	l.HandlerMessageCreate(m.session, getMessage("channel-id", "user-id"))
+	assert.Len(t, scheduled, 2)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/zekroTJA/shinpuru/internal/services/database"
 	"github.com/zekroTJA/shinpuru/internal/services/guildlog"
 	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/pkg/discordutil"
 	"github.com/zekroTJA/shinpuru/pkg/twitchnotify"
 	"github.com/zekrotja/rogu"
 	"github.com/zekrotja/rogu/log"
@@ -61,14 +62,21 @@ func (l *ListenerTwitchNotify) HandlerWentOnline(d *twitchnotify.Stream, u *twit
 
 	msgs := make([]*discordgo.Message, 0)
 	for _, not := range nots {
-		emb := twitchnotify.GetEmbed(d, u)
-		msg, err := l.session.ChannelMessageSendEmbed(not.ChannelID, emb)
+		msg, err := l.session.ChannelMessageSendComplex(not.ChannelID, twitchnotify.GetMessage(not, d, u))
 		if err != nil {
+			// Only remove the notification when the target channel
+			// is gone. Other errors like an invalid rendered message
+			// must not delete the configuration of the guild.
+			if !discordutil.IsErrCode(err, discordgo.ErrCodeUnknownChannel) {
+				l.log.Error().Err(err).Fields("gid", not.GuildID, "uid", u.ID).Msg("Failed sending Twitch notification")
+				l.gl.Errorf(not.GuildID, "Failed sending twitch notification (%s): %s", u.ID, err.Error())
+				continue
+			}
 			if err = l.db.DeleteTwitchNotify(u.ID, not.GuildID); err != nil {
 				l.log.Error().Err(err).Msg("Failed removing Twitch notify entry from database")
 				l.gl.Errorf(not.GuildID, "Failed removing twitch notify entry from database (%s): %s", u.ID, err.Error())
 			}
-			return
+			continue
 		}
 		msgs = append(msgs, msg)
 	}
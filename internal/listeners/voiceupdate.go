@@ -20,7 +20,7 @@ var voiceStateCashe = map[string]*discordgo.VoiceState{}
 type ListenerVoiceUpdate struct {
 	db database.Database
 	gl guildlog.Logger
-	st *dgrs.State
+	st dgrs.IState
 	tp timeprovider.Provider
 }
 
@@ -28,7 +28,7 @@ func NewListenerVoiceUpdate(container di.Container) *ListenerVoiceUpdate {
 	return &ListenerVoiceUpdate{
 		db: container.Get(static.DiDatabase).(database.Database),
 		gl: container.Get(static.DiGuildLog).(guildlog.Logger).Section("voicelog"),
-		st: container.Get(static.DiState).(*dgrs.State),
+		st: container.Get(static.DiState).(dgrs.IState),
 		tp: container.Get(static.DiTimeProvider).(timeprovider.Provider),
 	}
 }
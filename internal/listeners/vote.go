@@ -14,14 +14,14 @@ import (
 type ListenerVote struct {
 	db database.Database
 	gl guildlog.Logger
-	st *dgrs.State
+	st dgrs.IState
 }
 
 func NewListenerVote(container di.Container) *ListenerVote {
 	return &ListenerVote{
 		db: container.Get(static.DiDatabase).(database.Database),
 		gl: container.Get(static.DiGuildLog).(guildlog.Logger).Section("votes"),
-		st: container.Get(static.DiState).(*dgrs.State),
+		st: container.Get(static.DiState).(dgrs.IState),
 	}
 }
 
@@ -0,0 +1,255 @@
+package listeners
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/sarulabs/di/v2"
+	"github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/services/guildlog"
+	"github.com/zekroTJA/shinpuru/internal/services/msgsender"
+	"github.com/zekroTJA/shinpuru/internal/services/report"
+	"github.com/zekroTJA/shinpuru/internal/services/timeprovider"
+	"github.com/zekroTJA/shinpuru/internal/util"
+	"github.com/zekroTJA/shinpuru/internal/util/modlog"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/pkg/discordutil"
+	"github.com/zekroTJA/shinpuru/pkg/stringutil"
+	"github.com/zekroTJA/shinpuru/pkg/wordfilter"
+	"github.com/zekroTJA/timedmap"
+	"github.com/zekrotja/dgrs"
+	"github.com/zekrotja/rogu"
+	"github.com/zekrotja/rogu/log"
+)
+
+type ListenerWordFilter struct {
+	db     database.Database
+	st     dgrs.IState
+	rep    report.Provider
+	tp     timeprovider.Provider
+	sender *msgsender.Sender
+	gl     guildlog.Logger
+	log    rogu.Logger
+
+	filters *timedmap.TimedMap
+}
+
+// compiledFilter holds a compiled word filter together
+// with the signature of the entries it was built from.
+type compiledFilter struct {
+	signature string
+	filter    *wordfilter.Filter
+}
+
+const compiledFilterLifetime = 30 * time.Minute
+
+func NewListenerWordFilter(container di.Container) *ListenerWordFilter {
+	return &ListenerWordFilter{
+		db:     container.Get(static.DiDatabase).(database.Database),
+		st:     container.Get(static.DiState).(dgrs.IState),
+		rep:    container.Get(static.DiReport).(report.Provider),
+		tp:     container.Get(static.DiTimeProvider).(timeprovider.Provider),
+		sender: container.Get(static.DiMessageSender).(*msgsender.Sender),
+		gl:     container.Get(static.DiGuildLog).(guildlog.Logger).Section("wordfilter"),
+		log:    log.Tagged("WordFilter"),
+
+		filters: timedmap.New(10 * time.Minute),
+	}
+}
+
+func (l *ListenerWordFilter) HandlerMessageSend(s discordutil.ISession, e *discordgo.MessageCreate) {
+	l.check(s, e.Message)
+}
+
+func (l *ListenerWordFilter) HandlerMessageEdit(s discordutil.ISession, e *discordgo.MessageUpdate) {
+	l.check(s, e.Message)
+}
+
+func (l *ListenerWordFilter) check(s discordutil.ISession, msg *discordgo.Message) {
+	if msg.GuildID == "" || msg.Author == nil || msg.Author.Bot || msg.Content == "" {
+		return
+	}
+
+	settings, err := l.db.GetWordFilterSettings(msg.GuildID)
+	if database.IsErrDatabaseNotFound(err) {
+		return
+	}
+	if err != nil {
+		l.log.Error().Err(err).Field("gid", msg.GuildID).Msg("Failed getting word filter settings")
+		return
+	}
+	if !settings.Enabled || stringutil.ContainsAny(msg.ChannelID, settings.ExemptChannels) {
+		return
+	}
+
+	exempt, err := l.isExempt(msg, settings)
+	if err != nil {
+		l.log.Error().Err(err).Fields("gid", msg.GuildID, "uid", msg.Author.ID).Msg("Failed getting member")
+		return
+	}
+	if exempt {
+		return
+	}
+
+	entries, err := l.db.GetWordFilterEntries(msg.GuildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		l.log.Error().Err(err).Field("gid", msg.GuildID).Msg("Failed getting word filter entries")
+		return
+	}
+	if len(entries) == 0 {
+		return
+	}
+
+	filter, err := l.getFilter(msg.GuildID, entries)
+	if err != nil {
+		l.log.Error().Err(err).Field("gid", msg.GuildID).Msg("Failed compiling word filter")
+		l.gl.Errorf(msg.GuildID, "Failed compiling word filter: %s", err.Error())
+		return
+	}
+
+	pattern, ok := filter.Match(msg.Content)
+	if !ok {
+		return
+	}
+
+	l.execute(s, msg, settings, pattern)
+}
+
+// getFilter returns the compiled filter of the given guild
+// from cache. The filter is only re-compiled when the
+// entries changed since it has been compiled last.
+func (l *ListenerWordFilter) getFilter(guildID string, entries []models.WordFilterEntry) (*wordfilter.Filter, error) {
+	var sig strings.Builder
+	for _, e := range entries {
+		sig.WriteString(string(e.Mode))
+		sig.WriteByte(0)
+		sig.WriteString(e.Pattern)
+		sig.WriteByte(0)
+	}
+	signature := sig.String()
+
+	if c, ok := l.filters.GetValue(guildID).(compiledFilter); ok && c.signature == signature {
+		l.filters.Refresh(guildID, compiledFilterLifetime)
+		return c.filter, nil
+	}
+
+	patterns := make([]wordfilter.Pattern, len(entries))
+	for i, e := range entries {
+		patterns[i] = wordfilter.Pattern{
+			Pattern:   e.Pattern,
+			WholeWord: e.Mode != models.WordFilterModeSubstring,
+		}
+	}
+
+	filter, err := wordfilter.New(patterns...)
+	if err != nil {
+		return nil, err
+	}
+
+	l.filters.Set(guildID, compiledFilter{signature, filter}, compiledFilterLifetime)
+	return filter, nil
+}
+
+func (l *ListenerWordFilter) isExempt(msg *discordgo.Message, settings models.WordFilterSettings) (bool, error) {
+	if len(settings.ExemptRoles) == 0 {
+		return false, nil
+	}
+
+	member := msg.Member
+	if member == nil {
+		var err error
+		if member, err = l.st.Member(msg.GuildID, msg.Author.ID); err != nil {
+			return false, err
+		}
+	}
+
+	for _, roleID := range member.Roles {
+		if stringutil.ContainsAny(roleID, settings.ExemptRoles) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (l *ListenerWordFilter) execute(
+	s discordutil.ISession,
+	msg *discordgo.Message,
+	settings models.WordFilterSettings,
+	pattern string,
+) {
+	err := s.ChannelMessageDelete(msg.ChannelID, msg.ID)
+	if discordutil.IsErrCode(err, discordgo.ErrCodeMissingPermissions) ||
+		discordutil.IsErrCode(err, discordgo.ErrCodeMissingAccess) {
+		l.gl.Warnf(msg.GuildID, "Missing permission to delete filtered message in channel %s", msg.ChannelID)
+	} else if err != nil {
+		l.log.Error().Err(err).Fields("gid", msg.GuildID, "cid", msg.ChannelID).Msg("Failed deleting filtered message")
+	}
+
+	reason := fmt.Sprintf("Automatic word filter: the message contained the filtered pattern `%s`.", pattern)
+
+	switch settings.Action {
+	case models.WordFilterActionWarn, models.WordFilterActionMute:
+		self, err := l.st.SelfUser()
+		if err != nil {
+			l.log.Error().Err(err).Msg("Failed getting self user")
+			return
+		}
+
+		rep := models.Report{
+			Type:       models.TypeWarn,
+			GuildID:    msg.GuildID,
+			ExecutorID: self.ID,
+			VictimID:   msg.Author.ID,
+			Msg:        reason,
+		}
+
+		if settings.Action == models.WordFilterActionMute {
+			timeout := l.tp.Now().Add(settings.MuteDuration)
+			rep.Timeout = &timeout
+			_, err = l.rep.PushMute(rep)
+		} else {
+			_, err = l.rep.PushReport(rep)
+		}
+
+		if err != nil {
+			l.log.Error().Err(err).Fields("gid", msg.GuildID, "uid", msg.Author.ID).Msg("Failed executing word filter action")
+			l.gl.Errorf(msg.GuildID, "Failed executing word filter action %s on %s: %s",
+				settings.Action, msg.Author.ID, err.Error())
+		}
+
+	default:
+		if ch, err := s.UserChannelCreate(msg.Author.ID); err == nil {
+			util.SendEmbedError(s, ch.ID,
+				fmt.Sprintf("Your message in <#%s> has been deleted because it contained a filtered word.", msg.ChannelID))
+		}
+
+		err = modlog.Send(l.db, l.sender, s, msg.GuildID, models.ModLogActionWordFilter, &discordgo.MessageEmbed{
+			Color:       static.ColorEmbedOrange,
+			Title:       "Word Filter",
+			Description: reason,
+			Fields: []*discordgo.MessageEmbedField{
+				{
+					Name:   "Author",
+					Value:  fmt.Sprintf("%s (%s)", msg.Author.String(), msg.Author.Mention()),
+					Inline: true,
+				},
+				{
+					Name:   "Channel",
+					Value:  fmt.Sprintf("<#%s>", msg.ChannelID),
+					Inline: true,
+				},
+				{
+					Name:  "Message",
+					Value: stringutil.Truncate(msg.Content, 1024),
+				},
+			},
+		})
+		if err != nil {
+			l.log.Error().Err(err).Field("gid", msg.GuildID).Msg("Failed sending mod log entry")
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package listeners
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/sarulabs/di/v2"
+	"github.com/stretchr/testify/mock"
+	"github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/services/msgsender"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/mocks"
+)
+
+type wordFilterMock struct {
+	session *mocks.ISession
+	db      *mocks.Database
+	state   *mocks.IState
+	rep     *mocks.ReportProvider
+	tp      *mocks.TimeProvider
+	cfg     *mocks.ConfigProvider
+	logger  *mocks.Logger
+
+	ct di.Container
+}
+
+func getWordFilterMock(f ...func(m wordFilterMock)) wordFilterMock {
+	var t wordFilterMock
+
+	t.session = &mocks.ISession{}
+	t.db = &mocks.Database{}
+	t.state = &mocks.IState{}
+	t.rep = &mocks.ReportProvider{}
+	t.tp = &mocks.TimeProvider{}
+	t.cfg = &mocks.ConfigProvider{}
+	t.logger = &mocks.Logger{}
+
+	t.state.On("SelfUser").Return(&discordgo.User{ID: "self-id"}, nil)
+	t.tp.On("Now").Return(time.Unix(0, 0))
+	t.cfg.On("Config").Return(&models.Config{})
+	t.logger.On("Section", mock.Anything).Return(t.logger)
+
+	t.db.On("GetWordFilterEntries", mock.Anything).Return([]models.WordFilterEntry{
+		{Pattern: "bad", Mode: models.WordFilterModeWord},
+		{Pattern: "evil", Mode: models.WordFilterModeSubstring},
+	}, nil)
+	t.db.On("GetGuildModLogRoute", mock.Anything, mock.Anything).Return("", database.ErrDatabaseNotFound)
+	t.db.On("GetGuildModLog", mock.Anything).Return("", database.ErrDatabaseNotFound)
+
+	t.session.On("ChannelMessageDelete", mock.Anything, mock.Anything).Return(nil)
+	t.session.On("UserChannelCreate", mock.Anything).Return(&discordgo.Channel{ID: "dm-id"}, nil)
+	t.session.On("ChannelMessageSendEmbed", mock.Anything, mock.Anything).Return(nil, nil)
+
+	if len(f) != 0 {
+		f[0](t)
+	}
+
+	ct, _ := di.NewBuilder()
+	ct.Add(
+		di.Def{
+			Name:  static.DiDatabase,
+			Build: func(ctn di.Container) (interface{}, error) { return t.db, nil },
+		},
+		di.Def{
+			Name:  static.DiState,
+			Build: func(ctn di.Container) (interface{}, error) { return t.state, nil },
+		},
+		di.Def{
+			Name:  static.DiReport,
+			Build: func(ctn di.Container) (interface{}, error) { return t.rep, nil },
+		},
+		di.Def{
+			Name:  static.DiTimeProvider,
+			Build: func(ctn di.Container) (interface{}, error) { return t.tp, nil },
+		},
+		di.Def{
+			Name:  static.DiConfig,
+			Build: func(ctn di.Container) (interface{}, error) { return t.cfg, nil },
+		},
+		di.Def{
+			Name:  static.DiMessageSender,
+			Build: func(ctn di.Container) (interface{}, error) { return msgsender.New(ctn), nil },
+		},
+		di.Def{
+			Name:  static.DiGuildLog,
+			Build: func(ctn di.Container) (interface{}, error) { return t.logger, nil },
+		},
+	)
+
+	t.ct = ct.Build()
+
+	return t
+}
+
+func getWordFilterMessage(id, channelID, content string, roles ...string) *discordgo.MessageCreate {
+	return &discordgo.MessageCreate{Message: &discordgo.Message{
+		ID:        id,
+		GuildID:   "guild-id",
+		ChannelID: channelID,
+		Content:   content,
+		Author:    &discordgo.User{ID: "user-id"},
+		Member:    &discordgo.Member{Roles: roles},
+	}}
+}
+
+func TestWordFilterHandlerMessageSend(t *testing.T) {
+	m := getWordFilterMock(func(t wordFilterMock) {
+		t.db.On("GetWordFilterSettings", "guild-id").Return(models.WordFilterSettings{
+			GuildID:        "guild-id",
+			Enabled:        true,
+			Action:         models.WordFilterActionDelete,
+			ExemptRoles:    []string{"exempt-role"},
+			ExemptChannels: []string{"exempt-channel"},
+		}, nil)
+	})
+
+	l := NewListenerWordFilter(m.ct)
+
+	l.HandlerMessageSend(m.session, getWordFilterMessage("msg-0", "channel-id", "this is fine"))
+	l.HandlerMessageSend(m.session, getWordFilterMessage("msg-1", "channel-id", "badminton"))
+	l.HandlerMessageSend(m.session, getWordFilterMessage("msg-2", "exempt-channel", "bad"))
+	l.HandlerMessageSend(m.session, getWordFilterMessage("msg-3", "channel-id", "bad", "exempt-role"))
+	m.session.AssertNotCalled(t, "ChannelMessageDelete", mock.Anything, mock.Anything)
+
+	l.HandlerMessageSend(m.session, getWordFilterMessage("msg-4", "channel-id", "this is b@d!"))
+	m.session.AssertCalled(t, "ChannelMessageDelete", "channel-id", "msg-4")
+
+	l.HandlerMessageSend(m.session, getWordFilterMessage("msg-5", "channel-id", "3v1lness"))
+	m.session.AssertCalled(t, "ChannelMessageDelete", "channel-id", "msg-5")
+
+	m.rep.AssertNotCalled(t, "PushReport", mock.Anything)
+}
+
+func TestWordFilterHandlerMessageSendDisabled(t *testing.T) {
+	m := getWordFilterMock(func(t wordFilterMock) {
+		t.db.On("GetWordFilterSettings", "guild-id").Return(models.WordFilterSettings{
+			GuildID: "guild-id",
+			Action:  models.WordFilterActionDelete,
+		}, nil)
+	})
+
+	l := NewListenerWordFilter(m.ct)
+
+	l.HandlerMessageSend(m.session, getWordFilterMessage("msg-0", "channel-id", "bad"))
+	m.session.AssertNotCalled(t, "ChannelMessageDelete", mock.Anything, mock.Anything)
+}
+
+func TestWordFilterHandlerMessageSendActions(t *testing.T) {
+	m := getWordFilterMock(func(t wordFilterMock) {
+		t.db.On("GetWordFilterSettings", "guild-warn").Return(models.WordFilterSettings{
+			GuildID: "guild-warn",
+			Enabled: true,
+			Action:  models.WordFilterActionWarn,
+		}, nil)
+		t.db.On("GetWordFilterSettings", "guild-mute").Return(models.WordFilterSettings{
+			GuildID:      "guild-mute",
+			Enabled:      true,
+			Action:       models.WordFilterActionMute,
+			MuteDuration: time.Hour,
+		}, nil)
+
+		t.rep.On("PushReport", mock.Anything).Return(models.Report{}, nil)
+		t.rep.On("PushMute", mock.Anything).Return(models.Report{}, nil)
+	})
+
+	l := NewListenerWordFilter(m.ct)
+
+	msg := getWordFilterMessage("msg-0", "channel-id", "bad")
+	msg.GuildID = "guild-warn"
+	l.HandlerMessageSend(m.session, msg)
+	m.rep.AssertCalled(t, "PushReport", mock.MatchedBy(func(rep models.Report) bool {
+		return rep.Type == models.TypeWarn && rep.GuildID == "guild-warn" &&
+			rep.VictimID == "user-id" && rep.ExecutorID == "self-id"
+	}))
+
+	msg = getWordFilterMessage("msg-1", "channel-id", "bad")
+	msg.GuildID = "guild-mute"
+	l.HandlerMessageSend(m.session, msg)
+	timeout := time.Unix(0, 0).Add(time.Hour)
+	m.rep.AssertCalled(t, "PushMute", mock.MatchedBy(func(rep models.Report) bool {
+		return rep.GuildID == "guild-mute" && rep.Timeout != nil && rep.Timeout.Equal(timeout)
+	}))
+
+	m.session.AssertNumberOfCalls(t, "ChannelMessageDelete", 2)
+	m.session.AssertNotCalled(t, "UserChannelCreate", mock.Anything)
+}
@@ -0,0 +1,22 @@
+package messagecommands
+
+import (
+	"github.com/zekroTJA/shinpuru/internal/services/permissions"
+	"github.com/zekroTJA/shinpuru/internal/slashcommands"
+	"github.com/zekrotja/ken"
+)
+
+type Pin struct {
+	slashcommands.Pin
+}
+
+var (
+	_ ken.MessageCommand      = (*Pin)(nil)
+	_ permissions.PermCommand = (*Pin)(nil)
+)
+
+func (c *Pin) TypeMessage() {}
+
+func (c *Pin) Name() string {
+	return "pinmessage"
+}
@@ -0,0 +1,88 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/sarulabs/di/v2"
+	"github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekrotja/ken"
+	"github.com/zekrotja/rogu"
+	"github.com/zekrotja/rogu/log"
+)
+
+// autoDeleteMaxPending is the maximum amount of deletions
+// which can be scheduled at the same time.
+const autoDeleteMaxPending = 1000
+
+// AutoDeleteMiddleware implements ken.MiddlewareAfter to
+// delete command responses after the delay configured
+// for the guild or channel.
+type AutoDeleteMiddleware struct {
+	db      database.Database
+	log     rogu.Logger
+	pending chan struct{}
+}
+
+var _ ken.MiddlewareAfter = (*AutoDeleteMiddleware)(nil)
+
+// NewAutoDeleteMiddleware returns a new instance of
+// AutoDeleteMiddleware.
+func NewAutoDeleteMiddleware(ctn di.Container) *AutoDeleteMiddleware {
+	return &AutoDeleteMiddleware{
+		db:      ctn.Get(static.DiDatabase).(database.Database),
+		log:     log.Tagged("AutoDelete"),
+		pending: make(chan struct{}, autoDeleteMaxPending),
+	}
+}
+
+func (m *AutoDeleteMiddleware) After(ctx *ken.Ctx, cmdError error) (err error) {
+	event := ctx.GetEvent()
+	if event.GuildID == "" || event.AppPermissions&discordgo.PermissionManageMessages == 0 {
+		return
+	}
+
+	cfg, err := m.db.GetAutoDelete(event.GuildID, event.ChannelID)
+	if database.IsErrDatabaseNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		m.log.Error().Err(err).Field("gid", event.GuildID).Msg("Failed getting auto delete config")
+		return nil
+	}
+
+	delay := cfg.Delay
+	if cmdError != nil {
+		delay = cfg.ErrorDelay
+	}
+	if delay <= 0 {
+		return
+	}
+	if delay > models.AutoDeleteMaxDelay {
+		delay = models.AutoDeleteMaxDelay
+	}
+
+	select {
+	case m.pending <- struct{}{}:
+	default:
+		m.log.Warn().Field("gid", event.GuildID).Msg("Too many pending deletions; skipping")
+		return
+	}
+
+	// The context is recycled after the middlewares
+	// have been executed, so the values must be
+	// captured before.
+	session := ctx.GetSession()
+	interaction := event.Interaction
+
+	time.AfterFunc(delay, func() {
+		defer func() { <-m.pending }()
+		if err := session.InteractionResponseDelete(interaction); err != nil {
+			m.log.Debug().Err(err).Field("gid", interaction.GuildID).Msg("Failed deleting command response")
+		}
+	})
+
+	return
+}
@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"github.com/sarulabs/di/v2"
+	"github.com/zekroTJA/shinpuru/internal/services/config"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/util/cmdblocklist"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekrotja/ken"
+	"github.com/zekrotja/rogu/log"
+)
+
+// blocklistCommand is the name of the command managing
+// the blocklist, which can therefore not be blocked.
+const blocklistCommand = "cmdblocklist"
+
+type CommandBlocklistMiddleware struct {
+	cfg config.Provider
+	db  database.Database
+}
+
+var (
+	_ ken.MiddlewareBefore = (*CommandBlocklistMiddleware)(nil)
+)
+
+func NewCommandBlocklistMiddleware(ctn di.Container) *CommandBlocklistMiddleware {
+	return &CommandBlocklistMiddleware{
+		cfg: ctn.Get(static.DiConfig).(config.Provider),
+		db:  ctn.Get(static.DiDatabase).(database.Database),
+	}
+}
+
+func (m *CommandBlocklistMiddleware) Before(ctx *ken.Ctx) (next bool, err error) {
+	next = true
+
+	name := ctx.Command.Name()
+	if name == blocklistCommand {
+		return
+	}
+
+	// The bot owner is still able to execute blocked
+	// commands to verify fixes.
+	if ctx.User() != nil && ctx.User().ID == m.cfg.Config().Discord.OwnerID {
+		return
+	}
+
+	bl, err := cmdblocklist.Get(m.db)
+	if err != nil {
+		// The blocklist must not prevent command
+		// execution when it can not be retrieved.
+		log.Error().Tag("CmdBlocklist").Err(err).Msg("Failed getting command blocklist")
+		err = nil
+		return
+	}
+
+	if !bl.IsBlocked(name) {
+		return
+	}
+
+	next = false
+	ctx.SetEphemeral(true)
+
+	if bl.Silent {
+		// The interaction must be acknowledged anyway, so
+		// the response is deferred and removed afterwards.
+		if err = ctx.Defer(); err != nil {
+			return
+		}
+		err = ctx.GetSession().InteractionResponseDelete(ctx.GetEvent().Interaction)
+		return
+	}
+
+	err = ctx.RespondError(
+		"This command is temporarily disabled for maintenance. Please try again later.", "")
+
+	return
+}
@@ -3,13 +3,24 @@ package middleware
 import (
 	"github.com/sarulabs/di/v2"
 	"github.com/zekroTJA/shinpuru/internal/services/config"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
 	"github.com/zekroTJA/shinpuru/internal/util/static"
 	"github.com/zekroTJA/shinpuru/pkg/stringutil"
 	"github.com/zekrotja/ken"
 )
 
+// DefaultGuildDisabledMessage is the message responded when
+// a command is disabled on a guild and no custom message
+// has been set.
+const DefaultGuildDisabledMessage = "This command is disabled on this guild."
+
+// MaxGuildDisabledMessageLength is the maximum length
+// of the message responded on disabled commands.
+const MaxGuildDisabledMessageLength = 500
+
 type DisableCommandsMiddleware struct {
 	cfg config.Provider
+	db  database.Database
 }
 
 var (
@@ -19,6 +30,7 @@ var (
 func NewDisableCommandsMiddleware(ctn di.Container) *DisableCommandsMiddleware {
 	return &DisableCommandsMiddleware{
 		cfg: ctn.Get(static.DiConfig).(config.Provider),
+		db:  ctn.Get(static.DiDatabase).(database.Database),
 	}
 }
 
@@ -28,8 +40,43 @@ func (m *DisableCommandsMiddleware) Before(ctx *ken.Ctx) (next bool, err error)
 	if m.isDisabled(ctx.Command.Name()) {
 		next = false
 		err = ctx.RespondError("This command is disabled by config.", "")
+		return
+	}
+
+	guildID := ctx.GetEvent().GuildID
+	if guildID == "" || stringutil.ContainsAny(ctx.Command.Name(), static.EssentialCommands) {
+		return
+	}
+
+	disabled, err := m.db.GetGuildDisabledCommands(guildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return
+	}
+	err = nil
+
+	if !stringutil.ContainsAny(ctx.Command.Name(), disabled) {
+		return
 	}
 
+	next = false
+
+	msg, silent, err := m.db.GetGuildDisabledCommandMessage(guildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return
+	}
+	err = nil
+
+	if silent {
+		return
+	}
+
+	if msg == "" {
+		msg = DefaultGuildDisabledMessage
+	}
+
+	ctx.SetEphemeral(true)
+	err = ctx.RespondError(msg, "")
+
 	return
 }
 
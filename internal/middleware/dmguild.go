@@ -0,0 +1,194 @@
+package middleware
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/sarulabs/di/v2"
+	"github.com/zekroTJA/shinpuru/internal/util/cmdutil"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekrotja/dgrs"
+	"github.com/zekrotja/ken"
+)
+
+// maxListedGuilds is the maximum number of shared
+// guilds listed when no guild has been specified.
+const maxListedGuilds = 25
+
+// GuildScopedCommand is a DM capable command which is
+// executed in the context of a guild.
+//
+// When executed in DMs, the guild is specified via the
+// option returned by cmdutil.GuildOption, which must
+// be shared between the executing user and the bot.
+type GuildScopedCommand interface {
+	ken.DmCapable
+
+	// IsGuildScoped returns true if the command
+	// requires a guild context in DMs.
+	IsGuildScoped() bool
+}
+
+type DmGuildMiddleware struct {
+	st dgrs.IState
+}
+
+var (
+	_ ken.MiddlewareBefore = (*DmGuildMiddleware)(nil)
+)
+
+func NewDmGuildMiddleware(ctn di.Container) *DmGuildMiddleware {
+	return &DmGuildMiddleware{
+		st: ctn.Get(static.DiState).(dgrs.IState),
+	}
+}
+
+// Before sets the guild of guild scoped commands executed
+// in DMs as guild of the command event, so that following
+// middlewares and the command itself can handle the command
+// like it was executed in the guild.
+func (m *DmGuildMiddleware) Before(ctx *ken.Ctx) (next bool, err error) {
+	return m.before(ctx, ctx.Command)
+}
+
+func (m *DmGuildMiddleware) before(ctx ken.Context, command ken.Command) (next bool, err error) {
+	next = true
+
+	cmd, ok := command.(GuildScopedCommand)
+	if !ok || !cmd.IsGuildScoped() || ctx.User() == nil {
+		return
+	}
+
+	input, _ := findStringOption(ctx.GetEvent().ApplicationCommandData().Options, cmdutil.GuildOptionName)
+
+	if ctx.GetEvent().GuildID != "" {
+		if input != "" {
+			next = false
+			err = ctx.RespondError(
+				fmt.Sprintf("The `%s` option can only be used in DMs.", cmdutil.GuildOptionName), "")
+		}
+		return
+	}
+
+	next = false
+
+	guilds, err := m.sharedGuilds(ctx.User().ID)
+	if err != nil {
+		return
+	}
+
+	if input == "" {
+		err = ctx.RespondEmbed(&discordgo.MessageEmbed{
+			Color: static.ColorEmbedDefault,
+			Description: fmt.Sprintf("Please specify the guild to execute this command in "+
+				"with the `%s` option.\n\n**Shared guilds:**\n%s",
+				cmdutil.GuildOptionName, formatGuildList(guilds)),
+		})
+		return
+	}
+
+	guild, ok := resolveGuild(input, guilds)
+	if !ok {
+		err = ctx.RespondError(
+			fmt.Sprintf("You do not share a guild `%s` with me.\n\n**Shared guilds:**\n%s",
+				input, formatGuildList(guilds)), "")
+		return
+	}
+
+	ctx.GetEvent().GuildID = guild.ID
+	next = true
+
+	return
+}
+
+// sharedGuilds returns all guilds the given user and the
+// bot are both member of sorted by name.
+func (m *DmGuildMiddleware) sharedGuilds(userID string) ([]*discordgo.Guild, error) {
+	guildIDs, err := m.st.UserGuilds(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	guilds := make([]*discordgo.Guild, 0, len(guildIDs))
+	for _, id := range guildIDs {
+		guild, err := m.st.Guild(id)
+		if err != nil {
+			continue
+		}
+		guilds = append(guilds, guild)
+	}
+
+	sort.Slice(guilds, func(i, j int) bool {
+		return strings.ToLower(guilds[i].Name) < strings.ToLower(guilds[j].Name)
+	})
+
+	return guilds, nil
+}
+
+// resolveGuild returns the guild of the given list
+// which ID equals input. Otherwise, the guild with the
+// case insensitively matching name is returned, if the
+// name is unique.
+func resolveGuild(input string, guilds []*discordgo.Guild) (guild *discordgo.Guild, ok bool) {
+	input = strings.TrimSpace(input)
+
+	for _, g := range guilds {
+		if g.ID == input {
+			return g, true
+		}
+	}
+
+	for _, g := range guilds {
+		if !strings.EqualFold(g.Name, input) {
+			continue
+		}
+		if guild != nil {
+			return nil, false
+		}
+		guild = g
+	}
+
+	return guild, guild != nil
+}
+
+// findStringOption returns the value of the string option
+// with the given name. Options of sub commands and sub
+// command groups are searched as well.
+func findStringOption(
+	options []*discordgo.ApplicationCommandInteractionDataOption,
+	name string,
+) (string, bool) {
+	for _, opt := range options {
+		switch opt.Type {
+		case discordgo.ApplicationCommandOptionSubCommand,
+			discordgo.ApplicationCommandOptionSubCommandGroup:
+			if v, ok := findStringOption(opt.Options, name); ok {
+				return v, true
+			}
+		case discordgo.ApplicationCommandOptionString:
+			if opt.Name == name {
+				return opt.StringValue(), true
+			}
+		}
+	}
+	return "", false
+}
+
+func formatGuildList(guilds []*discordgo.Guild) string {
+	if len(guilds) == 0 {
+		return "*none*"
+	}
+
+	var sb strings.Builder
+	for i, g := range guilds {
+		if i == maxListedGuilds {
+			fmt.Fprintf(&sb, "*and %d more ...*", len(guilds)-i)
+			break
+		}
+		fmt.Fprintf(&sb, "- %s (`%s`)\n", g.Name, g.ID)
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,169 @@
+package middleware
+
+import (
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/zekroTJA/shinpuru/mocks"
+	"github.com/zekrotja/ken"
+)
+
+type testCommand struct {
+	guildScoped bool
+}
+
+func (c testCommand) Name() string          { return "test" }
+func (c testCommand) Description() string   { return "test" }
+func (c testCommand) Run(ken.Context) error { return nil }
+func (c testCommand) IsDmCapable() bool     { return true }
+func (c testCommand) IsGuildScoped() bool   { return c.guildScoped }
+
+func getDmGuildContext(guildID, guildOption string) (*mocks.KenContext, *discordgo.InteractionCreate) {
+	var options []*discordgo.ApplicationCommandInteractionDataOption
+	if guildOption != "" {
+		options = append(options, &discordgo.ApplicationCommandInteractionDataOption{
+			Type:  discordgo.ApplicationCommandOptionString,
+			Name:  "guild",
+			Value: guildOption,
+		})
+	}
+
+	event := &discordgo.InteractionCreate{
+		Interaction: &discordgo.Interaction{
+			Type:    discordgo.InteractionApplicationCommand,
+			GuildID: guildID,
+			Data:    discordgo.ApplicationCommandInteractionData{Options: options},
+		},
+	}
+
+	ctx := &mocks.KenContext{}
+	ctx.On("GetEvent").Return(event)
+	ctx.On("User").Return(&discordgo.User{ID: "user"})
+	ctx.On("RespondEmbed", mock.Anything).Return(nil)
+	ctx.On("RespondError", mock.Anything, mock.Anything).Return(nil)
+
+	return ctx, event
+}
+
+func getDmGuildMiddleware() *DmGuildMiddleware {
+	st := &mocks.IState{}
+	st.On("UserGuilds", "user").Return([]string{"1", "2"}, nil)
+	st.On("Guild", "1").Return(&discordgo.Guild{ID: "1", Name: "Shinpuru Dev"}, nil)
+	st.On("Guild", "2").Return(&discordgo.Guild{ID: "2", Name: "Gaming"}, nil)
+
+	return &DmGuildMiddleware{st: st}
+}
+
+func TestResolveGuild(t *testing.T) {
+	guilds := []*discordgo.Guild{
+		{ID: "1", Name: "Shinpuru Dev"},
+		{ID: "2", Name: "gaming"},
+		{ID: "3", Name: "Gaming"},
+		{ID: "4", Name: "4"},
+	}
+
+	g, ok := resolveGuild("1", guilds)
+	assert.True(t, ok)
+	assert.Equal(t, "1", g.ID)
+
+	g, ok = resolveGuild(" shinpuru dev ", guilds)
+	assert.True(t, ok)
+	assert.Equal(t, "1", g.ID)
+
+	// IDs take precedence over names.
+	g, ok = resolveGuild("4", guilds)
+	assert.True(t, ok)
+	assert.Equal(t, "4", g.ID)
+
+	// Ambiguous names are not resolved.
+	_, ok = resolveGuild("gaming", guilds)
+	assert.False(t, ok)
+
+	_, ok = resolveGuild("unknown", guilds)
+	assert.False(t, ok)
+
+	_, ok = resolveGuild("1", nil)
+	assert.False(t, ok)
+}
+
+func TestFindStringOption(t *testing.T) {
+	options := []*discordgo.ApplicationCommandInteractionDataOption{
+		{
+			Type: discordgo.ApplicationCommandOptionSubCommand,
+			Name: "show",
+			Options: []*discordgo.ApplicationCommandInteractionDataOption{
+				{Type: discordgo.ApplicationCommandOptionUser, Name: "user", Value: "123"},
+				{Type: discordgo.ApplicationCommandOptionString, Name: "guild", Value: "my guild"},
+			},
+		},
+	}
+
+	v, ok := findStringOption(options, "guild")
+	assert.True(t, ok)
+	assert.Equal(t, "my guild", v)
+
+	_, ok = findStringOption(options, "user")
+	assert.False(t, ok)
+
+	_, ok = findStringOption(nil, "guild")
+	assert.False(t, ok)
+}
+
+func TestBefore(t *testing.T) {
+	m := getDmGuildMiddleware()
+
+	t.Run("dm-resolved", func(t *testing.T) {
+		ctx, event := getDmGuildContext("", "shinpuru dev")
+		next, err := m.before(ctx, testCommand{guildScoped: true})
+		assert.Nil(t, err)
+		assert.True(t, next)
+		assert.Equal(t, "1", event.GuildID)
+		ctx.AssertNotCalled(t, "RespondError", mock.Anything, mock.Anything)
+	})
+
+	t.Run("dm-no-guild", func(t *testing.T) {
+		ctx, event := getDmGuildContext("", "")
+		next, err := m.before(ctx, testCommand{guildScoped: true})
+		assert.Nil(t, err)
+		assert.False(t, next)
+		assert.Equal(t, "", event.GuildID)
+		ctx.AssertCalled(t, "RespondEmbed", mock.Anything)
+	})
+
+	t.Run("dm-unknown-guild", func(t *testing.T) {
+		ctx, event := getDmGuildContext("", "3")
+		next, err := m.before(ctx, testCommand{guildScoped: true})
+		assert.Nil(t, err)
+		assert.False(t, next)
+		assert.Equal(t, "", event.GuildID)
+		ctx.AssertCalled(t, "RespondError", mock.Anything, mock.Anything)
+	})
+
+	t.Run("dm-not-guild-scoped", func(t *testing.T) {
+		ctx, event := getDmGuildContext("", "1")
+		next, err := m.before(ctx, testCommand{})
+		assert.Nil(t, err)
+		assert.True(t, next)
+		assert.Equal(t, "", event.GuildID)
+	})
+
+	t.Run("guild", func(t *testing.T) {
+		ctx, event := getDmGuildContext("2", "")
+		next, err := m.before(ctx, testCommand{guildScoped: true})
+		assert.Nil(t, err)
+		assert.True(t, next)
+		assert.Equal(t, "2", event.GuildID)
+		ctx.AssertNotCalled(t, "RespondError", mock.Anything, mock.Anything)
+	})
+
+	t.Run("guild-with-guild-option", func(t *testing.T) {
+		ctx, event := getDmGuildContext("2", "1")
+		next, err := m.before(ctx, testCommand{guildScoped: true})
+		assert.Nil(t, err)
+		assert.False(t, next)
+		assert.Equal(t, "2", event.GuildID)
+		ctx.AssertCalled(t, "RespondError", mock.Anything, mock.Anything)
+	})
+}
@@ -10,3 +10,13 @@ type APITokenEntry struct {
 	LastAccess time.Time `json:"lastaccess"`
 	Hits       int       `json:"hits"`
 }
+
+// APITokenUsageEntry describes a single request
+// authenticated with an API token.
+type APITokenUsageEntry struct {
+	Timestamp time.Time `json:"timestamp"`
+	Method    string    `json:"method"`
+	Route     string    `json:"route"`
+	Status    int       `json:"status"`
+	IP        string    `json:"ip"`
+}
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// AutoDeleteMaxDelay is the maximum delay after which
+// a command response can be deleted. Interaction tokens
+// are only valid for 15 minutes after creation.
+const AutoDeleteMaxDelay = 14 * time.Minute
+
+// AutoDeleteConfig holds the delays after which
+// command responses are deleted in a guild or a
+// specific channel of a guild.
+//
+// An empty ChannelID marks the guild wide default
+// config. A delay of 0 disables the deletion.
+type AutoDeleteConfig struct {
+	GuildID    string        `json:"guildid"`
+	ChannelID  string        `json:"channelid"`
+	Delay      time.Duration `json:"delay"`
+	ErrorDelay time.Duration `json:"errordelay"`
+}
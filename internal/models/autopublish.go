@@ -0,0 +1,13 @@
+package models
+
+// AutoPublishConfig specifies that new messages in the
+// given announcement channel are published automatically
+// so that they reach all following servers.
+//
+// If SkipBots is true, messages of bots and webhooks
+// are not published.
+type AutoPublishConfig struct {
+	GuildID   string `json:"guildid"`
+	ChannelID string `json:"channelid"`
+	SkipBots  bool   `json:"skipbots"`
+}
@@ -0,0 +1,82 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/zekroTJA/shinpuru/pkg/validation"
+)
+
+// AutoThreadMaxCooldown is the maximum cooldown which
+// can be set for auto thread configs.
+const AutoThreadMaxCooldown = 24 * time.Hour
+
+// AutoThreadDefaultCooldown is the cooldown which is
+// used if none is specified.
+const AutoThreadDefaultCooldown = 1 * time.Minute
+
+// AutoThreadNameSource specifies from what the name of
+// an automatically created thread is taken.
+type AutoThreadNameSource string
+
+const (
+	AutoThreadNameContent AutoThreadNameSource = "content"
+	AutoThreadNameAuthor  AutoThreadNameSource = "author"
+)
+
+// Validate returns true if the name source is a
+// valid AutoThreadNameSource.
+func (s AutoThreadNameSource) Validate() bool {
+	return s == AutoThreadNameContent || s == AutoThreadNameAuthor
+}
+
+// AutoThreadConfig specifies that a thread is created
+// automatically for new messages in the given channel.
+//
+// If AttachmentsOnly is true, threads are only created
+// for messages with attachments. Cooldown is the time
+// a member has to wait after a thread was created for
+// one of their messages before the next thread is
+// created for them in the channel.
+//
+// In JSON, the cooldown is represented in seconds and
+// defaults to AutoThreadDefaultCooldown if not set.
+type AutoThreadConfig struct {
+	GuildID         string               `json:"guildid"`
+	ChannelID       string               `json:"channelid"`
+	AttachmentsOnly bool                 `json:"attachmentsonly"`
+	NameSource      AutoThreadNameSource `json:"namesource"`
+	Cooldown        time.Duration        `json:"cooldown" swaggertype:"integer"`
+}
+
+type autoThreadConfigAlias AutoThreadConfig
+
+func (c AutoThreadConfig) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		autoThreadConfigAlias
+		Cooldown int64 `json:"cooldown"`
+	}{autoThreadConfigAlias(c), int64(c.Cooldown / time.Second)})
+}
+
+func (c *AutoThreadConfig) UnmarshalJSON(data []byte) error {
+	v := struct {
+		*autoThreadConfigAlias
+		Cooldown *int64 `json:"cooldown"`
+	}{autoThreadConfigAlias: (*autoThreadConfigAlias)(c)}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	c.Cooldown = AutoThreadDefaultCooldown
+	if v.Cooldown != nil {
+		c.Cooldown = time.Duration(*v.Cooldown) * time.Second
+	}
+	return nil
+}
+
+func (c *AutoThreadConfig) Validate() error {
+	var errs validation.Errors
+	errs.Assert(c.NameSource.Validate(), "namesource", "invalid value for name source")
+	errs.Assert(c.Cooldown >= 0 && c.Cooldown <= AutoThreadMaxCooldown, "cooldown",
+		"must be in range of 0 and "+AutoThreadMaxCooldown.String())
+	return errs.Err()
+}
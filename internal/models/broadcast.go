@@ -0,0 +1,74 @@
+package models
+
+import (
+	"time"
+
+	"github.com/bwmarrin/snowflake"
+)
+
+// BroadcastStatus describes the delivery status of
+// a broadcast to a single guild.
+type BroadcastStatus string
+
+const (
+	BroadcastStatusSent      BroadcastStatus = "sent"
+	BroadcastStatusFailed    BroadcastStatus = "failed"
+	BroadcastStatusDMsClosed BroadcastStatus = "dmsclosed"
+)
+
+// Broadcast is an announcement of the bot owner which
+// is delivered to all guilds.
+type Broadcast struct {
+	ID       snowflake.ID `json:"id"`
+	AuthorID string       `json:"author_id"`
+	Content  string       `json:"content"`
+	Created  time.Time    `json:"created"`
+	Finished bool         `json:"finished"`
+}
+
+// BroadcastDelivery is the delivery result of a
+// broadcast to a single guild.
+type BroadcastDelivery struct {
+	BroadcastID snowflake.ID    `json:"broadcast_id"`
+	GuildID     string          `json:"guild_id"`
+	Status      BroadcastStatus `json:"status"`
+	Error       string          `json:"error,omitempty"`
+}
+
+// BroadcastReport summarizes the deliveries of a
+// broadcast.
+type BroadcastReport struct {
+	Broadcast
+
+	Succeeded int `json:"succeeded"`
+	Failed    int `json:"failed"`
+	DMsClosed int `json:"dms_closed"`
+
+	// Failures contains all deliveries which have
+	// not been sent successfully.
+	Failures []BroadcastDelivery `json:"failures"`
+}
+
+// NewBroadcastReport returns the report of the given
+// broadcast summarizing the given deliveries.
+func NewBroadcastReport(b Broadcast, deliveries []BroadcastDelivery) BroadcastReport {
+	r := BroadcastReport{
+		Broadcast: b,
+		Failures:  make([]BroadcastDelivery, 0),
+	}
+
+	for _, d := range deliveries {
+		switch d.Status {
+		case BroadcastStatusSent:
+			r.Succeeded++
+			continue
+		case BroadcastStatusDMsClosed:
+			r.DMsClosed++
+		default:
+			r.Failed++
+		}
+		r.Failures = append(r.Failures, d)
+	}
+
+	return r
+}
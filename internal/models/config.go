@@ -15,6 +15,19 @@ var DefaultConfig = Config{
 			Burst:        5,
 			LimitSeconds: 3,
 		},
+		SendRetry: SendRetry{
+			MaxAttempts:        3,
+			InitialDelayMillis: 500,
+			MaxDelayMillis:     10000,
+			DeadLetters:        100,
+			QueueSize:          50,
+		},
+		ColorReactions: ColorReactions{
+			UserCooldownSeconds: 10,
+			GuildLimit:          20,
+			GuildLimitSeconds:   60,
+			GradientPreviews:    true,
+		},
 	},
 	Permissions: Permissions{
 		DefaultUserRules:  static.DefaultUserRules,
@@ -23,6 +36,21 @@ var DefaultConfig = Config{
 	Database: DatabaseType{
 		Type:  "mysql",
 		MySql: DatabaseCreds{},
+		Postgres: DatabasePostgres{
+			MaxOpenConns:           20,
+			MaxIdleConns:           5,
+			ConnMaxLifetimeSeconds: 300,
+		},
+		Retry: DatabaseRetry{
+			MaxAttempts:         10,
+			InitialDelaySeconds: 1,
+			MaxDelaySeconds:     30,
+			TimeoutSeconds:      120,
+		},
+		Queries: DatabaseQueries{
+			MaxCachedStatements:      500,
+			SlowQueryThresholdMillis: 500,
+		},
 	},
 	Cache: Cache{
 		Redis: CacheRedis{
@@ -30,7 +58,8 @@ var DefaultConfig = Config{
 			Password: "",
 			Type:     0,
 		},
-		CacheDatabase: true,
+		CacheDatabase:      true,
+		CacheGuildSettings: true,
 	},
 	Logging: Logging{
 		CommandLogging: true,
@@ -68,6 +97,20 @@ var DefaultConfig = Config{
 			Burst:        30,
 			LimitSeconds: 3,
 		},
+		RateLimitGroups: map[string]Ratelimit{
+			"auth": {
+				Enabled:      true,
+				Burst:        10,
+				LimitSeconds: 6,
+			},
+		},
+		BodyLimit: WebServerBodyLimit{
+			MaxBytes: 1024 * 1024,
+		},
+		AttachmentHosts: []string{
+			"cdn.discordapp.com",
+			"media.discordapp.net",
+		},
 	},
 	Metrics: Metrics{
 		Enable: false,
@@ -89,6 +132,12 @@ var DefaultConfig = Config{
 			Burst:        5,
 			LimitSeconds: 60,
 		},
+		Limits: CodeExecLimits{
+			MaxExecutions:  5,
+			MaxQueued:      20,
+			TimeoutSeconds: 30,
+			MaxOutputBytes: 64 * 1024,
+		},
 	},
 }
 
@@ -96,16 +145,73 @@ var DefaultConfig = Config{
 // to the Discord API application and using the
 // OAuth2 workflow for web frontend authorization.
 type Discord struct {
-	Token                  string    `json:"token"`
-	GeneralPrefix          string    `json:"generalprefix"`
-	OwnerID                string    `json:"ownerid"`
-	ClientID               string    `json:"clientid"`
-	ClientSecret           string    `json:"clientsecret"`
-	GuildBackupLoc         string    `json:"guildbackuploc"`
-	GlobalCommandRateLimit Ratelimit `json:"globalcommandratelimit"`
-	DisabledCommands       []string  `json:"disabledcommands"`
-	Sharding               Sharding  `json:"sharding"`
-	GuildsLimit            int       `json:"guildslimit"`
+	Token                  string         `json:"token"`
+	GeneralPrefix          string         `json:"generalprefix"`
+	OwnerID                string         `json:"ownerid"`
+	ClientID               string         `json:"clientid"`
+	ClientSecret           string         `json:"clientsecret"`
+	GuildBackupLoc         string         `json:"guildbackuploc"`
+	GlobalCommandRateLimit Ratelimit      `json:"globalcommandratelimit"`
+	DisabledCommands       []string       `json:"disabledcommands"`
+	Sharding               Sharding       `json:"sharding"`
+	GuildsLimit            int            `json:"guildslimit"`
+	Intents                Intents        `json:"intents"`
+	SendRetry              SendRetry      `json:"sendretry"`
+	ColorReactions         ColorReactions `json:"colorreactions"`
+	Reports                Reports        `json:"reports"`
+	// PublicResourceUsage shows the resource usage of
+	// the bot in the about and stats commands and the
+	// system information API route to all users instead
+	// of only to the bot owner.
+	PublicResourceUsage bool `json:"publicresourceusage"`
+}
+
+// Reports holds the preferences for the handling
+// of moderation reports.
+type Reports struct {
+	// HardDeleteRevoked deletes revoked reports from
+	// the database instead of keeping them with their
+	// revocation for auditing.
+	HardDeleteRevoked bool `json:"harddeleterevoked"`
+}
+
+// ColorReactions holds the limits for color reactions
+// which protect the bot from being rate limited by the
+// creation of guild emojis. A value of 0 disables the
+// respective limit.
+type ColorReactions struct {
+	// UserCooldownSeconds is the time a user has to wait
+	// in a channel before their messages get color
+	// reactions again.
+	UserCooldownSeconds int `json:"usercooldownseconds"`
+	// GuildLimit is the maximum number of color reactions
+	// per guild within GuildLimitSeconds.
+	GuildLimit        int `json:"guildlimit"`
+	GuildLimitSeconds int `json:"guildlimitseconds"`
+	// GradientPreviews enables animated gradient
+	// thumbnails for messages containing exactly
+	// two colors.
+	GradientPreviews bool `json:"gradientpreviews"`
+}
+
+// SendRetry holds the preferences for retrying message
+// sends which failed due to transient errors and for
+// queueing message sends.
+type SendRetry struct {
+	MaxAttempts        int `json:"maxattempts"`
+	InitialDelayMillis int `json:"initialdelaymillis"`
+	MaxDelayMillis     int `json:"maxdelaymillis"`
+	DeadLetters        int `json:"deadletters"`
+	QueueSize          int `json:"queuesize"`
+}
+
+// Intents holds the configuration of the gateway
+// intents requested from Discord.
+type Intents struct {
+	// Features maps feature names to their enabled
+	// state. Features which are not set use their
+	// default state.
+	Features map[string]bool `json:"features"`
 }
 
 // Sharding holds configuration for guild event sharding.
@@ -139,16 +245,49 @@ type CacheRedis struct {
 // database module to be used and the seperate
 // "slots" for database configurations.
 type DatabaseType struct {
-	Type  string        `json:"type"`
-	MySql DatabaseCreds `json:"mysql"`
-	Redis CacheRedis    `json:"redis"`
+	Type     string           `json:"type"`
+	MySql    DatabaseCreds    `json:"mysql"`
+	Postgres DatabasePostgres `json:"postgres"`
+	Redis    CacheRedis       `json:"redis"`
+	Retry    DatabaseRetry    `json:"retry"`
+	Queries  DatabaseQueries  `json:"queries"`
+}
+
+// DatabasePostgres holds the connection string and
+// the connection pool preferences to connect to a
+// PostgreSQL database.
+type DatabasePostgres struct {
+	DSN                    string `json:"dsn"`
+	MaxOpenConns           int    `json:"maxopenconns"`
+	MaxIdleConns           int    `json:"maxidleconns"`
+	ConnMaxLifetimeSeconds int    `json:"connmaxlifetimeseconds"`
+}
+
+// DatabaseRetry holds the preferences for retrying
+// the database connection on startup.
+type DatabaseRetry struct {
+	MaxAttempts         int `json:"maxattempts"`
+	InitialDelaySeconds int `json:"initialdelayseconds"`
+	MaxDelaySeconds     int `json:"maxdelayseconds"`
+	TimeoutSeconds      int `json:"timeoutseconds"`
+}
+
+// DatabaseQueries holds the preferences for caching
+// prepared statements and instrumenting queries of the
+// SQL database drivers.
+type DatabaseQueries struct {
+	CacheStatements          bool `json:"cachestatements"`
+	MaxCachedStatements      int  `json:"maxcachedstatements"`
+	Instrument               bool `json:"instrument"`
+	SlowQueryThresholdMillis int  `json:"slowquerythresholdmillis"`
 }
 
 // Cache holds the preferences for caching
 // services.
 type Cache struct {
-	Redis         CacheRedis `json:"redis"`
-	CacheDatabase bool       `json:"cachedatabase"`
+	Redis              CacheRedis `json:"redis"`
+	CacheDatabase      bool       `json:"cachedatabase"`
+	CacheGuildSettings bool       `json:"cacheguildsettings"`
 }
 
 // LokiLogging holds configuration to push
@@ -176,16 +315,41 @@ type TwitchApp struct {
 // WebServer holds general configurations for
 // the exposed web server.
 type WebServer struct {
-	Enabled         bool         `json:"enabled"`
-	Addr            string       `json:"addr"`
-	TLS             WebServerTLS `json:"tls"`
-	APITokenKey     string       `json:"apitokenkey"`
-	PublicAddr      string       `json:"publicaddr"`
-	LandingPage     LandingPage  `json:"landingpage"`
-	DebugPublicAddr string       `json:"debugpublicaddr,omitempty"`
-	RateLimit       Ratelimit    `json:"ratelimit"`
-	Captcha         Captcha      `json:"captcha"`
-	AccessToken     AccessToken  `json:"accesstoken"`
+	Enabled         bool                 `json:"enabled"`
+	Addr            string               `json:"addr"`
+	TLS             WebServerTLS         `json:"tls"`
+	APITokenKey     string               `json:"apitokenkey"`
+	PublicAddr      string               `json:"publicaddr"`
+	LandingPage     LandingPage          `json:"landingpage"`
+	DebugPublicAddr string               `json:"debugpublicaddr,omitempty"`
+	RateLimit       Ratelimit            `json:"ratelimit"`
+	RateLimitGroups map[string]Ratelimit `json:"ratelimitgroups"`
+	Captcha         Captcha              `json:"captcha"`
+	AccessToken     AccessToken          `json:"accesstoken"`
+	CORS            WebServerCORS        `json:"cors"`
+	WebhookSecrets  map[string]string    `json:"webhooksecrets"`
+	BodyLimit       WebServerBodyLimit   `json:"bodylimit"`
+	// AttachmentHosts are the hosts report attachments
+	// may be downloaded from additionally to the host of
+	// PublicAddr. Entries prefixed with "*." also match
+	// all subdomains.
+	AttachmentHosts []string `json:"attachmenthosts"`
+	// RequireConfirmation enables the requirement of a
+	// confirmation token on sensitive routes.
+	RequireConfirmation bool `json:"requireconfirmation"`
+}
+
+// WebServerCORS holds the cross origin resource
+// sharing configuration of the web server.
+type WebServerCORS struct {
+	AllowOrigins []string `json:"alloworigins"`
+}
+
+// WebServerBodyLimit holds the maximum request body
+// sizes of the web server.
+type WebServerBodyLimit struct {
+	MaxBytes int            `json:"maxbytes"`
+	Routes   map[string]int `json:"routes"`
 }
 
 // AccessToken holds the secret and lifetime for
@@ -272,9 +436,31 @@ type Schedules struct {
 // CodeExec wraps configurations for the
 // code execution API used.
 type CodeExec struct {
-	Type      string        `json:"type"`
-	Ranna     CodeExecRanna `json:"ranna"`
-	RateLimit Ratelimit     `json:"ratelimit"`
+	Type      string         `json:"type"`
+	Ranna     CodeExecRanna  `json:"ranna"`
+	RateLimit Ratelimit      `json:"ratelimit"`
+	Limits    CodeExecLimits `json:"limits"`
+}
+
+// CodeExecLimits holds the limits applied to all
+// code executions.
+//
+// MaxExecutions of 0 disables the concurrency
+// limit. MaxQueued of 0 disables the queue, so
+// executions are rejected while all slots are in
+// use. TimeoutSeconds of 0 disables the execution
+// timeout. MaxOutputBytes of 0 disables the
+// truncation of StdOut and StdErr.
+//
+// When UploadOutput is enabled, outputs which are
+// too large to be displayed in chat are uploaded
+// to the object storage and linked instead.
+type CodeExecLimits struct {
+	MaxExecutions  int  `json:"maxexecutions"`
+	MaxQueued      int  `json:"maxqueued"`
+	TimeoutSeconds int  `json:"timeoutseconds"`
+	MaxOutputBytes int  `json:"maxoutputbytes"`
+	UploadOutput   bool `json:"uploadoutput"`
 }
 
 // CodeExecRanna holds configuration values
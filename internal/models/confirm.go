@@ -0,0 +1,16 @@
+package models
+
+// ConfirmAction is the identifier of a destructive
+// action which can be configured to require a
+// confirmation of the invoking user.
+type ConfirmAction string
+
+const (
+	ConfirmActionBan ConfirmAction = "ban"
+)
+
+// ConfirmActions contains all actions which
+// can require a confirmation.
+var ConfirmActions = []ConfirmAction{
+	ConfirmActionBan,
+}
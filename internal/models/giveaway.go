@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+
+	"github.com/bwmarrin/snowflake"
+)
+
+// Giveaway holds the configuration and state of
+// a giveaway. Members can only enter when they
+// have the role RoleID (if set) and at least
+// MinKarma karma points (if greater than 0).
+type Giveaway struct {
+	ID        snowflake.ID `json:"id"`
+	GuildID   string       `json:"guildid"`
+	ChannelID string       `json:"channelid"`
+	MessageID string       `json:"messageid"`
+	CreatorID string       `json:"creatorid"`
+	Prize     string       `json:"prize"`
+	Winners   int          `json:"winners"`
+	RoleID    string       `json:"roleid"`
+	MinKarma  int          `json:"minkarma"`
+	Expires   time.Time    `json:"expires"`
+	Ended     bool         `json:"ended"`
+	WinnerIDs []string     `json:"winnerids"`
+}
@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+// GuildStats holds aggregated statistics of a guild
+// computed from the state.
+//
+// MembersComplete is false if not all members of the
+// guild are available in the state, so Humans and Bots
+// only cover a part of the members. Presences is nil
+// if no presence data is available for the guild.
+type GuildStats struct {
+	GuildID         string              `json:"guildid"`
+	Created         time.Time           `json:"created"`
+	Members         int                 `json:"members"`
+	Humans          int                 `json:"humans"`
+	Bots            int                 `json:"bots"`
+	MembersComplete bool                `json:"members_complete"`
+	Roles           int                 `json:"roles"`
+	Channels        GuildChannelStats   `json:"channels"`
+	PremiumTier     int                 `json:"premium_tier"`
+	PremiumCount    int                 `json:"premium_count"`
+	Presences       *GuildPresenceStats `json:"presences"`
+	Computed        time.Time           `json:"computed"`
+}
+
+// GuildChannelStats holds the number of channels
+// of a guild by channel type.
+type GuildChannelStats struct {
+	Total    int `json:"total"`
+	Text     int `json:"text"`
+	News     int `json:"news"`
+	Voice    int `json:"voice"`
+	Stage    int `json:"stage"`
+	Category int `json:"category"`
+	Forum    int `json:"forum"`
+	Other    int `json:"other"`
+}
+
+// GuildPresenceStats holds the number of members of
+// a guild by presence status.
+type GuildPresenceStats struct {
+	Online       int `json:"online"`
+	Idle         int `json:"idle"`
+	DoNotDisturb int `json:"dnd"`
+	Offline      int `json:"offline"`
+}
@@ -11,6 +11,10 @@ type HealthcheckResponse struct {
 	Redis    HealthcheckStatus `json:"redis"`
 	Discord  HealthcheckStatus `json:"discord"`
 	AllOk    bool              `json:"all_ok"`
+	// Degraded is true when optional services like
+	// Redis are unavailable and fallback behavior
+	// is used.
+	Degraded bool `json:"degraded"`
 }
 
 func HealthcheckStatusFromError(err error) HealthcheckStatus {
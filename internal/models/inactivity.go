@@ -0,0 +1,63 @@
+package models
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/zekroTJA/shinpuru/pkg/validation"
+)
+
+const (
+	// InactivityMinDays is the minimum number of days
+	// members must be inactive before the role is removed.
+	InactivityMinDays = 7
+	// InactivityMaxDays is the maximum number of days
+	// members must be inactive before the role is removed.
+	InactivityMaxDays = 365
+	// InactivityDefaultDays is the default number of days
+	// members must be inactive before the role is removed.
+	InactivityDefaultDays = 30
+	// InactivityDefaultWarnDays is the default number of
+	// days members are warned before the role is removed.
+	InactivityDefaultWarnDays = 3
+)
+
+// InactivitySettings holds the configuration of a guild
+// to remove a role from members which have not sent any
+// messages or joined any voice channels for Days days.
+//
+// Members are warned via DM WarnDays days before the role
+// is removed. Since is the time the removal has been
+// enabled. Activity is only tracked while the removal is
+// enabled, so no member is considered inactive for longer
+// than since this time.
+type InactivitySettings struct {
+	GuildID  string    `json:"guildid"`
+	Enabled  bool      `json:"enabled"`
+	RoleID   string    `json:"roleid"`
+	Days     int       `json:"days"`
+	WarnDays int       `json:"warndays"`
+	Since    time.Time `json:"since"`
+}
+
+func (s *InactivitySettings) Validate() error {
+	var errs validation.Errors
+	errs.Assert(!s.Enabled || s.RoleID != "", "roleid", "must be set")
+	errs.Assert(s.Days >= InactivityMinDays && s.Days <= InactivityMaxDays, "days",
+		"must be in range of "+strconv.Itoa(InactivityMinDays)+" and "+strconv.Itoa(InactivityMaxDays))
+	errs.Assert(s.WarnDays >= 1 && s.WarnDays < s.Days, "warndays",
+		"must be at least 1 and less than days")
+	return errs.Err()
+}
+
+// MemberActivity holds the time a member of a guild has
+// been active the last time. Warned is set to the time
+// the member has been warned about the removal of the
+// inactivity role and is reset when the member becomes
+// active again.
+type MemberActivity struct {
+	GuildID    string     `json:"guildid"`
+	UserID     string     `json:"userid"`
+	LastActive time.Time  `json:"lastactive"`
+	Warned     *time.Time `json:"warned"`
+}
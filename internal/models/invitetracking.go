@@ -0,0 +1,96 @@
+package models
+
+import "time"
+
+// InviteJoinSource describes how the invite used by a
+// joining member has been determined.
+type InviteJoinSource string
+
+const (
+	// InviteJoinSourceInvite is set when the join has been
+	// attributed to a regular invite code.
+	InviteJoinSourceInvite InviteJoinSource = "invite"
+	// InviteJoinSourceVanity is set when the join has been
+	// attributed to the vanity URL of the guild.
+	InviteJoinSourceVanity InviteJoinSource = "vanity"
+	// InviteJoinSourceUnknown is set when the used invite
+	// could not be determined, for example because multiple
+	// members joined via different invites at the same time.
+	InviteJoinSourceUnknown InviteJoinSource = "unknown"
+)
+
+// TrackedInvite is an invite created via the bot with
+// additional metadata used to attribute joins.
+type TrackedInvite struct {
+	Code      string    `json:"code"`
+	GuildID   string    `json:"guild_id"`
+	ChannelID string    `json:"channel_id"`
+	CreatorID string    `json:"creator_id"`
+	Label     string    `json:"label"`
+	Created   time.Time `json:"created"`
+}
+
+// InviteJoin is the attribution of a member join to
+// the invite used.
+//
+// InviterID is the ID of the user who created the
+// invite as reported by Discord. It is empty for the
+// vanity URL and unknown sources.
+type InviteJoin struct {
+	GuildID   string           `json:"guild_id"`
+	UserID    string           `json:"user_id"`
+	Code      string           `json:"code"`
+	Source    InviteJoinSource `json:"source"`
+	InviterID string           `json:"inviter_id,omitempty"`
+	Timestamp time.Time        `json:"timestamp"`
+}
+
+// InviteJoinStats contains the number of joins
+// attributed to an invite code.
+//
+// Label and CreatorID are only set if the invite
+// has been created via the bot.
+type InviteJoinStats struct {
+	Code      string           `json:"code"`
+	Source    InviteJoinSource `json:"source"`
+	Label     string           `json:"label,omitempty"`
+	CreatorID string           `json:"creator_id,omitempty"`
+	InviterID string           `json:"inviter_id,omitempty"`
+	Joins     int              `json:"joins"`
+}
+
+// Inviter returns the ID of the user responsible for
+// the invite. For invites created via the bot, this
+// is the user who requested the invite.
+func (s InviteJoinStats) Inviter() string {
+	if s.CreatorID != "" {
+		return s.CreatorID
+	}
+	return s.InviterID
+}
+
+// InviteAnalyticsEntry contains the join statistics of
+// an invite and whether it can still be used.
+type InviteAnalyticsEntry struct {
+	InviteJoinStats
+	// Active is false if the invite has expired or has
+	// been deleted. The joins are kept nevertheless.
+	Active bool `json:"active"`
+}
+
+// InviterStats contains the invites created by a
+// user and the number of joins via these invites.
+type InviterStats struct {
+	UserID string   `json:"user_id"`
+	Codes  []string `json:"codes"`
+	Joins  int      `json:"joins"`
+}
+
+// InviteAnalytics contains the join statistics per
+// invite and the leaderboard of the top inviters of
+// a guild.
+type InviteAnalytics struct {
+	TotalJoins int                    `json:"total_joins"`
+	Invites    []InviteAnalyticsEntry `json:"invites"`
+	Inviters   []InviterStats         `json:"inviters"`
+}
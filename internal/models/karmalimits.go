@@ -0,0 +1,54 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// maxKarmaLimitSeconds is the maximum value for the
+// cooldown and the receive interval of karma limits.
+const maxKarmaLimitSeconds = int(24 * time.Hour / time.Second)
+
+// KarmaLimits contains the limits of karma which can
+// be given or received by a member of a guild.
+//
+// A value of 0 disables the corresponding limit.
+type KarmaLimits struct {
+	// GiveCooldown is the time in seconds a member
+	// has to wait between giving karma.
+	GiveCooldown int `json:"give_cooldown"`
+	// GiveDailyCap is the maximum amount of karma
+	// changes a member can give per day.
+	GiveDailyCap int `json:"give_daily_cap"`
+	// ReceiveCap is the maximum amount of karma
+	// changes a member can receive per
+	// ReceiveInterval.
+	ReceiveCap int `json:"receive_cap"`
+	// ReceiveInterval is the time window in seconds
+	// for ReceiveCap.
+	ReceiveInterval int `json:"receive_interval"`
+}
+
+// DefaultKarmaLimits are the limits applied to guilds
+// which have not configured any limits.
+var DefaultKarmaLimits = KarmaLimits{
+	GiveCooldown:    10,
+	GiveDailyCap:    50,
+	ReceiveCap:      20,
+	ReceiveInterval: 3600,
+}
+
+// Validate returns an error if any of the limits is
+// out of range.
+func (l KarmaLimits) Validate() error {
+	if l.GiveCooldown < 0 || l.GiveDailyCap < 0 || l.ReceiveCap < 0 || l.ReceiveInterval < 0 {
+		return errors.New("limits must not be negative")
+	}
+	if l.GiveCooldown > maxKarmaLimitSeconds || l.ReceiveInterval > maxKarmaLimitSeconds {
+		return errors.New("cooldown and receive interval must not be longer than one day")
+	}
+	if l.ReceiveCap > 0 && l.ReceiveInterval == 0 {
+		return errors.New("receive interval must be set when the receive cap is enabled")
+	}
+	return nil
+}
@@ -1,10 +1,9 @@
 package models
 
 import (
-	"errors"
-
 	"github.com/bwmarrin/snowflake"
 	"github.com/zekroTJA/shinpuru/pkg/checksum"
+	"github.com/zekroTJA/shinpuru/pkg/validation"
 )
 
 type KarmaAction string
@@ -49,14 +48,10 @@ type KarmaRule struct {
 }
 
 func (r *KarmaRule) Validate() error {
-	if !r.Trigger.Validate() {
-		return errors.New("invalid value for trigger")
-	}
-	if !r.Action.Validate() {
-		return errors.New("invalid value for action")
-	}
-
-	return nil
+	var errs validation.Errors
+	errs.Assert(r.Trigger.Validate(), "trigger", "invalid value for trigger")
+	errs.Assert(r.Action.Validate(), "action", "invalid value for action")
+	return errs.Err()
 }
 
 func (r *KarmaRule) CalculateChecksum() string {
@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// MessageStatsEntry is the number of messages a user
+// has sent in a channel within the hour starting at
+// Hour. Message contents are never stored.
+type MessageStatsEntry struct {
+	GuildID   string    `json:"guild_id"`
+	ChannelID string    `json:"channel_id"`
+	UserID    string    `json:"user_id"`
+	Hour      time.Time `json:"hour"`
+	Count     int       `json:"count"`
+}
+
+// MessageStatsChannel is the number of messages
+// sent in a channel.
+type MessageStatsChannel struct {
+	ChannelID string `json:"channel_id"`
+	Count     int    `json:"count"`
+}
+
+// MessageStatsUser is the number of messages
+// sent by a user.
+type MessageStatsUser struct {
+	UserID string `json:"user_id"`
+	Count  int    `json:"count"`
+}
+
+// MessageStats contains the aggregated message
+// statistics of a guild over a period.
+type MessageStats struct {
+	Since      time.Time             `json:"since"`
+	Total      int                   `json:"total"`
+	Channels   []MessageStatsChannel `json:"channels"`
+	TopPosters []MessageStatsUser    `json:"top_posters"`
+	// Hours contains the number of messages per
+	// hour of the day (UTC).
+	Hours [24]int `json:"hours"`
+}
@@ -0,0 +1,103 @@
+package models
+
+// ModLogFormat specifies the verbosity of the report
+// embeds posted in the mod log channel of a guild.
+type ModLogFormat string
+
+const (
+	// ModLogFormatMinimal posts a single line per report.
+	ModLogFormatMinimal ModLogFormat = "minimal"
+	// ModLogFormatStandard posts the report embed.
+	ModLogFormatStandard ModLogFormat = "standard"
+	// ModLogFormatDetailed posts the report embed with
+	// additional details about the target.
+	ModLogFormatDetailed ModLogFormat = "detailed"
+
+	// ModLogFormatDefault is used when no format is set.
+	ModLogFormatDefault = ModLogFormatStandard
+)
+
+// ModLogFormats contains all valid mod log formats.
+var ModLogFormats = []ModLogFormat{
+	ModLogFormatMinimal,
+	ModLogFormatStandard,
+	ModLogFormatDetailed,
+}
+
+func (f ModLogFormat) Validate() bool {
+	switch f {
+	case ModLogFormatMinimal, ModLogFormatStandard, ModLogFormatDetailed:
+		return true
+	default:
+		return false
+	}
+}
+
+// ModLogAction is the kind of entry posted in the mod
+// log. Each action can be routed to a dedicated channel
+// instead of the default mod log channel of the guild.
+type ModLogAction string
+
+const (
+	ModLogActionKick       ModLogAction = "kick"
+	ModLogActionBan        ModLogAction = "ban"
+	ModLogActionMute       ModLogAction = "mute"
+	ModLogActionWarn       ModLogAction = "warn"
+	ModLogActionAd         ModLogAction = "ad"
+	ModLogActionUnban      ModLogAction = "unban"
+	ModLogActionUnmute     ModLogAction = "unmute"
+	ModLogActionCustom     ModLogAction = "custom"
+	ModLogActionRevoke     ModLogAction = "revoke"
+	ModLogActionPin        ModLogAction = "pin"
+	ModLogActionAntiraid   ModLogAction = "antiraid"
+	ModLogActionWordFilter ModLogAction = "wordfilter"
+	ModLogActionInactivity ModLogAction = "inactivity"
+)
+
+// ModLogActions contains all valid mod log actions.
+var ModLogActions = []ModLogAction{
+	ModLogActionKick,
+	ModLogActionBan,
+	ModLogActionMute,
+	ModLogActionWarn,
+	ModLogActionAd,
+	ModLogActionUnban,
+	ModLogActionUnmute,
+	ModLogActionCustom,
+	ModLogActionRevoke,
+	ModLogActionPin,
+	ModLogActionAntiraid,
+	ModLogActionWordFilter,
+	ModLogActionInactivity,
+}
+
+func (a ModLogAction) Validate() bool {
+	for _, v := range ModLogActions {
+		if v == a {
+			return true
+		}
+	}
+	return false
+}
+
+// ModLogActionOf returns the mod log action of reports
+// of the given type. All custom report types share the
+// action ModLogActionCustom.
+func ModLogActionOf(typ ReportType) ModLogAction {
+	switch typ {
+	case TypeKick:
+		return ModLogActionKick
+	case TypeBan:
+		return ModLogActionBan
+	case TypeMute:
+		return ModLogActionMute
+	case TypeWarn:
+		return ModLogActionWarn
+	case TypeAd:
+		return ModLogActionAd
+	case TypeUnban, TypeUnbanRejected:
+		return ModLogActionUnban
+	default:
+		return ModLogActionCustom
+	}
+}
@@ -0,0 +1,10 @@
+package models
+
+// ModmailThread maps a user to the thread in the
+// modmail channel of a guild which is used to relay
+// messages between the user and the guild staff.
+type ModmailThread struct {
+	GuildID  string `json:"guildid"`
+	UserID   string `json:"userid"`
+	ThreadID string `json:"threadid"`
+}
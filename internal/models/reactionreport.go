@@ -0,0 +1,51 @@
+package models
+
+import (
+	"regexp"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/makeworld-the-better-one/go-isemoji"
+)
+
+// DefaultReactionReportCooldown is the default time in
+// seconds after which a member can report another message
+// by reaction.
+const DefaultReactionReportCooldown = 300
+
+var rxCustomEmoji = regexp.MustCompile(`^<a?:\w+:(\d+)>$`)
+
+// ReactionReportConfig contains the settings of a guild
+// for reporting messages to the moderators by reacting
+// with an emoji. Reaction reports are disabled if no
+// channel is set.
+type ReactionReportConfig struct {
+	GuildID   string `json:"guild_id"`
+	ChannelID string `json:"channel_id"`
+	// Emoji is either a unicode emoji or a custom
+	// emoji in the format <:name:id>.
+	Emoji string `json:"emoji"`
+	// Cooldown is the time in seconds after which a
+	// member can report another message.
+	Cooldown int `json:"cooldown"`
+}
+
+// Enabled returns true if a channel and an emoji
+// are set.
+func (c ReactionReportConfig) Enabled() bool {
+	return c.ChannelID != "" && c.Emoji != ""
+}
+
+// ValidEmoji returns true if the emoji is either a
+// single unicode emoji or a custom emoji.
+func (c ReactionReportConfig) ValidEmoji() bool {
+	return rxCustomEmoji.MatchString(c.Emoji) || isemoji.IsEmojiNonStrict(c.Emoji)
+}
+
+// Matches returns true if the given reaction emoji
+// is the configured report emoji.
+func (c ReactionReportConfig) Matches(emoji discordgo.Emoji) bool {
+	if m := rxCustomEmoji.FindStringSubmatch(c.Emoji); m != nil {
+		return emoji.ID == m[1]
+	}
+	return emoji.ID == "" && emoji.Name == c.Emoji
+}
@@ -3,6 +3,7 @@ package models
 import (
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
@@ -62,6 +63,22 @@ func TypeFromString(s string) (typ ReportType, err error) {
 	return
 }
 
+// TypeFromName returns the report type by its name
+// as listed in ReportTypes (case insensitive) or by
+// its numeric representation.
+func TypeFromName(name string) (typ ReportType, err error) {
+	name = strings.TrimSpace(name)
+	for i, t := range ReportTypes {
+		if strings.EqualFold(t, name) {
+			return ReportType(i), nil
+		}
+	}
+	if typ, err = TypeFromString(name); err != nil {
+		err = fmt.Errorf("unknown report type '%s'", name)
+	}
+	return
+}
+
 // Report describes a report object.
 type Report struct {
 	ID            snowflake.ID `json:"id"`
@@ -73,6 +90,39 @@ type Report struct {
 	AttachmentURL string       `json:"attachment_url"`
 	Timeout       *time.Time   `json:"timeout"`
 	Anonymous     bool         `json:"-"`
+	// Silent suppresses the notification sent
+	// to the victim via DM.
+	Silent bool `json:"-"`
+	// Revocation is set when the report has been
+	// revoked and is kept for auditing.
+	Revocation *ReportRevocation `json:"revocation,omitempty"`
+}
+
+// ReportRevokeOptions specifies the side effects
+// of a report revocation.
+type ReportRevokeOptions struct {
+	// Unban lifts the ban of the victim when the
+	// revoked report is a ban report.
+	Unban bool
+	// Modlog posts the revocation to the mod log
+	// channel of the guild.
+	Modlog bool
+}
+
+// DefaultReportRevokeOptions unbans the victim of
+// ban reports and posts the revocation to the mod
+// log.
+var DefaultReportRevokeOptions = ReportRevokeOptions{
+	Unban:  true,
+	Modlog: true,
+}
+
+// ReportRevocation holds the information about
+// who revoked a report, when and why.
+type ReportRevocation struct {
+	ExecutorID string    `json:"executor_id"`
+	Reason     string    `json:"reason"`
+	Timestamp  time.Time `json:"timestamp"`
 }
 
 // GetTimestamp returns the timestamp when the
@@ -86,10 +136,12 @@ func (r *Report) GetTimestamp() time.Time {
 // report. publicAddr is passed to generate a
 // public link for a potential report attachment
 // to be displayed in the embeds image section.
-func (r *Report) AsEmbed(publicAddr string) *discordgo.MessageEmbed {
+// types is used to resolve the name and color
+// of custom report types.
+func (r *Report) AsEmbed(publicAddr string, types ReportTypeSet) *discordgo.MessageEmbed {
 	emb := &discordgo.MessageEmbed{
 		Title: "Case " + r.ID.String(),
-		Color: ReportColors[r.Type],
+		Color: types.Color(r.Type),
 		Fields: []*discordgo.MessageEmbedField{
 			{
 				Inline: true,
@@ -103,7 +155,7 @@ func (r *Report) AsEmbed(publicAddr string) *discordgo.MessageEmbed {
 			},
 			{
 				Name:  "Type",
-				Value: ReportTypes[r.Type],
+				Value: types.Name(r.Type),
 			},
 			{
 				Name:  "Description",
@@ -136,8 +188,9 @@ func (r *Report) AsEmbed(publicAddr string) *discordgo.MessageEmbed {
 
 // AsEmbedField creates a discordgo.MessageEmbedField from
 // the report. publicAddr is passed to generate a publicly
-// available link embedded in the embed field.
-func (r *Report) AsEmbedField(publicAddr string) *discordgo.MessageEmbedField {
+// available link embedded in the embed field. types is
+// used to resolve the name of custom report types.
+func (r *Report) AsEmbedField(publicAddr string, types ReportTypeSet) *discordgo.MessageEmbedField {
 	attachmentTxt := ""
 	if r.AttachmentURL != "" {
 		attachmentTxt = fmt.Sprintf("Attachment: [[open](%s)]\n", imgstore.GetLink(r.AttachmentURL, publicAddr))
@@ -146,6 +199,6 @@ func (r *Report) AsEmbedField(publicAddr string) *discordgo.MessageEmbedField {
 	return &discordgo.MessageEmbedField{
 		Name: "Case " + r.ID.String(),
 		Value: fmt.Sprintf("Time: %s\nExecutor: <@%s>\nTarget: <@%s>\nType: `%s`\n%s__Reason__:\n%s",
-			r.GetTimestamp().Format("2006/01/02 15:04:05"), r.ExecutorID, r.VictimID, ReportTypes[r.Type], attachmentTxt, r.Msg),
+			r.GetTimestamp().Format("2006/01/02 15:04:05"), r.ExecutorID, r.VictimID, types.Name(r.Type), attachmentTxt, r.Msg),
 	}
 }
@@ -0,0 +1,157 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// EscalationAction is the action which is performed
+// automatically when a user crosses an escalation
+// threshold.
+type EscalationAction string
+
+const (
+	EscalationActionMute EscalationAction = "mute"
+	EscalationActionKick EscalationAction = "kick"
+	EscalationActionBan  EscalationAction = "ban"
+)
+
+const (
+	// MaxEscalationThresholds is the maximum number of
+	// escalation thresholds per guild.
+	MaxEscalationThresholds = 10
+
+	// MaxEscalationMuteDuration is the maximum duration
+	// of mutes applied by escalation thresholds, which
+	// is the maximum timeout duration allowed by Discord.
+	MaxEscalationMuteDuration = 28 * 24 * time.Hour
+
+	maxEscalationDecayDays = 365
+)
+
+// EscalationThreshold describes an action which is
+// performed when a user reaches Points.
+type EscalationThreshold struct {
+	Points int              `json:"points"`
+	Action EscalationAction `json:"action"`
+	// Duration is the duration of the mute or ban in
+	// seconds. A Duration of 0 results in a permanent
+	// ban. Mutes require a duration.
+	Duration int `json:"duration"`
+}
+
+// GetDuration returns the duration of the action.
+func (t EscalationThreshold) GetDuration() time.Duration {
+	return time.Duration(t.Duration) * time.Second
+}
+
+// ReportEscalation contains the point values of report
+// types and the escalation thresholds of a guild.
+type ReportEscalation struct {
+	GuildID string `json:"guildid"`
+	Enabled bool   `json:"enabled"`
+	// DecayDays is the number of days after which the
+	// points of a report have decayed linearly to 0.
+	// A value of 0 disables the decay.
+	DecayDays  int                   `json:"decaydays"`
+	Points     map[ReportType]int    `json:"points"`
+	Thresholds []EscalationThreshold `json:"thresholds"`
+}
+
+// PointsOf returns the points of a single report at
+// the given time after applying the decay.
+func (e ReportEscalation) PointsOf(rep Report, now time.Time) float64 {
+	points := float64(e.Points[rep.Type])
+	if points <= 0 || e.DecayDays <= 0 {
+		return points
+	}
+
+	decay := time.Duration(e.DecayDays) * 24 * time.Hour
+	age := now.Sub(rep.GetTimestamp())
+	if age >= decay {
+		return 0
+	}
+	if age < 0 {
+		age = 0
+	}
+
+	return points * (1 - float64(age)/float64(decay))
+}
+
+// Accumulate returns the sum of the decayed points of
+// the given reports at the given time rounded down.
+func (e ReportEscalation) Accumulate(reps []Report, now time.Time) int {
+	var points float64
+	for _, rep := range reps {
+		points += e.PointsOf(rep, now)
+	}
+	return int(math.Floor(points + 1e-9))
+}
+
+// Crossed returns the threshold with the highest points
+// which lies in the range (before, after]. ok is false
+// if no threshold has been crossed.
+func (e ReportEscalation) Crossed(before, after int) (t EscalationThreshold, ok bool) {
+	for _, th := range e.Thresholds {
+		if th.Points > before && th.Points <= after && (!ok || th.Points > t.Points) {
+			t, ok = th, true
+		}
+	}
+	return
+}
+
+// Validate returns an error if the escalation config
+// contains invalid values or references report types
+// which are not contained in types. Thresholds are
+// sorted by their points ascending.
+func (e *ReportEscalation) Validate(types ReportTypeSet) error {
+	if e.DecayDays < 0 || e.DecayDays > maxEscalationDecayDays {
+		return fmt.Errorf("decaydays must be in range [0, %d]", maxEscalationDecayDays)
+	}
+
+	for typ, p := range e.Points {
+		if !types.Has(typ) {
+			return fmt.Errorf("unknown report type %d", typ)
+		}
+		if p < 0 {
+			return errors.New("points must not be negative")
+		}
+	}
+
+	if len(e.Thresholds) > MaxEscalationThresholds {
+		return fmt.Errorf("there must not be more than %d thresholds", MaxEscalationThresholds)
+	}
+
+	seen := make(map[int]bool, len(e.Thresholds))
+	for _, t := range e.Thresholds {
+		if t.Points < 1 {
+			return errors.New("threshold points must be larger than 0")
+		}
+		if seen[t.Points] {
+			return fmt.Errorf("there are multiple thresholds for %d points", t.Points)
+		}
+		seen[t.Points] = true
+
+		if t.Duration < 0 {
+			return errors.New("threshold duration must not be negative")
+		}
+		switch t.Action {
+		case EscalationActionMute:
+			if t.Duration == 0 || t.GetDuration() > MaxEscalationMuteDuration {
+				return errors.New("mute duration must be in range (0, 28 days]")
+			}
+		case EscalationActionKick, EscalationActionBan:
+		default:
+			return fmt.Errorf("invalid threshold action '%s'", t.Action)
+		}
+	}
+
+	sort.Slice(e.Thresholds, func(i, j int) bool {
+		return e.Thresholds[i].Points < e.Thresholds[j].Points
+	})
+
+	return nil
+}
@@ -0,0 +1,156 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// CustomReportTypeOffset is the lowest ID of custom
+// report types. Custom report type IDs are namespaced
+// above this offset so that they can never collide
+// with built-in report types.
+const CustomReportTypeOffset ReportType = 1000
+
+const (
+	// CustomReportTypeColor is the color of custom report
+	// types which have no color specified.
+	CustomReportTypeColor = 0x607D8B
+
+	// MaxCustomReportTypes is the maximum number of custom
+	// report types per guild.
+	MaxCustomReportTypes = 25
+
+	maxCustomReportTypeNameLen = 32
+)
+
+// CustomReportType is a report type defined by a guild
+// in addition to the built-in report types.
+type CustomReportType struct {
+	GuildID string     `json:"guildid"`
+	ID      ReportType `json:"id"`
+	Name    string     `json:"name"`
+	Color   int        `json:"color"`
+}
+
+// IsCustom returns true if the report type is in the
+// namespace of custom report types.
+func (t ReportType) IsCustom() bool {
+	return t >= CustomReportTypeOffset
+}
+
+// ReportTypeSet contains the custom report types of a
+// guild by their ID and resolves built-in as well as
+// custom report types. A nil ReportTypeSet only
+// resolves built-in report types.
+type ReportTypeSet map[ReportType]CustomReportType
+
+// NewReportTypeSet returns a new ReportTypeSet
+// containing the given custom report types.
+func NewReportTypeSet(custom []CustomReportType) ReportTypeSet {
+	s := make(ReportTypeSet, len(custom))
+	for _, t := range custom {
+		s[t.ID] = t
+	}
+	return s
+}
+
+// Has returns true if typ is either a built-in report
+// type or a custom report type contained in the set.
+func (s ReportTypeSet) Has(typ ReportType) bool {
+	if !typ.IsCustom() {
+		return typ >= 0 && typ <= TypeMax
+	}
+	_, ok := s[typ]
+	return ok
+}
+
+// Name returns the display name of the given report
+// type. Report types which can not be resolved, for
+// example because the custom type has been removed,
+// are displayed with their ID.
+func (s ReportTypeSet) Name(typ ReportType) string {
+	if !typ.IsCustom() && typ >= 0 && typ <= TypeMax {
+		return ReportTypes[typ]
+	}
+	if t, ok := s[typ]; ok {
+		return t.Name
+	}
+	return fmt.Sprintf("UNKNOWN (%d)", typ)
+}
+
+// Color returns the embed color of the given
+// report type.
+func (s ReportTypeSet) Color(typ ReportType) int {
+	if !typ.IsCustom() && typ >= 0 && typ <= TypeMax {
+		return ReportColors[typ]
+	}
+	if t, ok := s[typ]; ok && t.Color != 0 {
+		return t.Color
+	}
+	return CustomReportTypeColor
+}
+
+// FromName returns the report type by its name
+// (case insensitive) or by its numeric ID. Built-in
+// report types take precedence over custom ones.
+func (s ReportTypeSet) FromName(name string) (typ ReportType, err error) {
+	name = strings.TrimSpace(name)
+	if typ, err = TypeFromName(name); err == nil {
+		return
+	}
+
+	for _, t := range s {
+		if strings.EqualFold(t.Name, name) {
+			return t.ID, nil
+		}
+	}
+
+	if id, errConv := strconv.Atoi(name); errConv == nil && s.Has(ReportType(id)) {
+		return ReportType(id), nil
+	}
+
+	return 0, fmt.Errorf("unknown report type '%s'", name)
+}
+
+// Validate returns an error if the given custom report
+// type has an invalid name or color or if its name is
+// already used by a built-in report type or another
+// custom report type of the set.
+func (s ReportTypeSet) Validate(t CustomReportType) error {
+	name := strings.TrimSpace(t.Name)
+	if name == "" {
+		return errors.New("name must not be empty")
+	}
+	if len(name) > maxCustomReportTypeNameLen {
+		return fmt.Errorf("name must not be longer than %d characters", maxCustomReportTypeNameLen)
+	}
+	if _, err := strconv.Atoi(name); err == nil {
+		return errors.New("name must not be numeric")
+	}
+	if t.Color < 0 || t.Color > 0xFFFFFF {
+		return errors.New("color must be in range [0x000000, 0xFFFFFF]")
+	}
+	if _, err := TypeFromName(name); err == nil {
+		return fmt.Errorf("name '%s' is used by a built-in report type", name)
+	}
+	for _, other := range s {
+		if other.ID != t.ID && strings.EqualFold(other.Name, name) {
+			return fmt.Errorf("name '%s' is already used by another report type", name)
+		}
+	}
+	return nil
+}
+
+// NextID returns the next free custom report
+// type ID of the set.
+func (s ReportTypeSet) NextID() ReportType {
+	next := CustomReportTypeOffset
+	for id := range s {
+		if id >= next {
+			next = id + 1
+		}
+	}
+	return next
+}
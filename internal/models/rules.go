@@ -0,0 +1,14 @@
+package models
+
+// GuildRules holds the rules text of a guild and the
+// message the rules are posted in. Version is increased
+// each time the rules are changed in a way which
+// requires members to accept them again.
+type GuildRules struct {
+	GuildID   string `json:"guildid"`
+	ChannelID string `json:"channelid"`
+	MessageID string `json:"messageid"`
+	RoleID    string `json:"roleid"`
+	Content   string `json:"content"`
+	Version   int    `json:"version"`
+}
@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+
+	"github.com/bwmarrin/snowflake"
+)
+
+// SettingsAuditEntry records a change of a
+// guild setting including the user who
+// changed it and the old and new value.
+type SettingsAuditEntry struct {
+	ID        snowflake.ID `json:"id"`
+	GuildID   string       `json:"guildid"`
+	ActorID   string       `json:"actorid"`
+	Field     string       `json:"field"`
+	OldValue  string       `json:"oldvalue"`
+	NewValue  string       `json:"newvalue"`
+	Timestamp time.Time    `json:"timestamp"`
+}
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"unicode/utf8"
+
+	"github.com/zekroTJA/shinpuru/pkg/validation"
+)
+
+// StickyMessageMaxLen is the maximum length of the
+// content of a sticky message.
+const StickyMessageMaxLen = 2000
+
+// StickyMessage is a message which is reposted at the
+// bottom of the channel when new messages are sent.
+//
+// MessageID is the ID of the currently posted sticky
+// message in the channel and is empty if it has not
+// been posted yet.
+type StickyMessage struct {
+	GuildID   string `json:"guildid"`
+	ChannelID string `json:"channelid"`
+	Content   string `json:"content"`
+	MessageID string `json:"messageid"`
+}
+
+func (sm *StickyMessage) Validate() error {
+	var errs validation.Errors
+	errs.Assert(sm.Content != "", "content", "must not be empty")
+	errs.Assert(utf8.RuneCountInString(sm.Content) <= StickyMessageMaxLen, "content",
+		"must not be longer than 2000 characters")
+	return errs.Err()
+}
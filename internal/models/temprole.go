@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+
+	"github.com/bwmarrin/snowflake"
+)
+
+// TempRole holds a role assigned to a member which
+// is removed automatically when it expires.
+//
+// Attempts counts the failed tries to remove the
+// role after it has expired.
+type TempRole struct {
+	ID         snowflake.ID `json:"id"`
+	GuildID    string       `json:"guildid"`
+	UserID     string       `json:"userid"`
+	RoleID     string       `json:"roleid"`
+	ExecutorID string       `json:"executorid"`
+	Expires    time.Time    `json:"expires"`
+	Attempts   int          `json:"attempts"`
+}
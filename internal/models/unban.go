@@ -1,10 +1,10 @@
 package models
 
 import (
-	"errors"
 	"time"
 
 	"github.com/bwmarrin/snowflake"
+	"github.com/zekroTJA/shinpuru/pkg/validation"
 )
 
 type UnbanRequestState int
@@ -30,14 +30,10 @@ type UnbanRequest struct {
 }
 
 func (r *UnbanRequest) Validate() error {
-	if r.GuildID == "" {
-		return errors.New("invalid guild ID")
-	}
-	if r.Message == "" {
-		return errors.New("message must be provided")
-	}
-
-	return nil
+	var errs validation.Errors
+	errs.Assert(r.GuildID != "", "guild_id", "invalid guild ID")
+	errs.Assert(r.Message != "", "message", "message must be provided")
+	return errs.Err()
 }
 
 func (r *UnbanRequest) Hydrate() *UnbanRequest {
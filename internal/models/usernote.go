@@ -0,0 +1,49 @@
+package models
+
+import (
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/bwmarrin/snowflake"
+	"github.com/zekroTJA/shinpuru/pkg/stringutil"
+	"github.com/zekroTJA/shinpuru/pkg/validation"
+)
+
+// UserNoteMaxLen is the maximum length of the
+// content of a user note.
+const UserNoteMaxLen = 1000
+
+// UserNote is a private note of a moderator on a
+// user of a guild. Other than reports, notes have
+// no effect on the user and are only visible to
+// moderators.
+type UserNote struct {
+	ID       snowflake.ID `json:"id"`
+	GuildID  string       `json:"guildid"`
+	UserID   string       `json:"userid"`
+	AuthorID string       `json:"authorid"`
+	Content  string       `json:"content"`
+	Created  time.Time    `json:"created"`
+}
+
+func (n *UserNote) Validate() error {
+	var errs validation.Errors
+	errs.Assert(n.Content != "", "content", "must not be empty")
+	errs.Assert(utf8.RuneCountInString(n.Content) <= UserNoteMaxLen, "content",
+		"must not be longer than 1000 characters")
+	return errs.Err()
+}
+
+// AsEmbedField creates a discordgo.MessageEmbedField from
+// the note which is prefixed to be distinguishable from
+// report fields. The value is truncated to the maximum
+// length of embed field values.
+func (n *UserNote) AsEmbedField() *discordgo.MessageEmbedField {
+	return &discordgo.MessageEmbedField{
+		Name: "📝 Note " + n.ID.String(),
+		Value: stringutil.Truncate(fmt.Sprintf("Time: %s\nAuthor: <@%s>\n__Note__:\n%s",
+			n.Created.Format("2006/01/02 15:04:05"), n.AuthorID, n.Content), 1024),
+	}
+}
@@ -0,0 +1,97 @@
+package models
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/zekroTJA/shinpuru/pkg/validation"
+)
+
+const (
+	// WordFilterMaxPatternLen is the maximum length
+	// of a word filter pattern.
+	WordFilterMaxPatternLen = 100
+	// WordFilterMaxMuteDuration is the maximum mute
+	// duration which can be set for the word filter.
+	WordFilterMaxMuteDuration = 28 * 24 * time.Hour
+)
+
+// WordFilterAction is the action performed when a
+// message matches the word filter of a guild. The
+// message is deleted for every action.
+type WordFilterAction string
+
+const (
+	WordFilterActionDelete WordFilterAction = "delete"
+	WordFilterActionWarn   WordFilterAction = "warn"
+	WordFilterActionMute   WordFilterAction = "mute"
+)
+
+// Validate returns true if the action is a valid
+// WordFilterAction.
+func (a WordFilterAction) Validate() bool {
+	return a == WordFilterActionDelete || a == WordFilterActionWarn || a == WordFilterActionMute
+}
+
+// WordFilterMode specifies how a word filter pattern
+// is matched against messages.
+type WordFilterMode string
+
+const (
+	// WordFilterModeWord only matches whole words so that
+	// words containing the pattern are not affected.
+	WordFilterModeWord WordFilterMode = "word"
+	// WordFilterModeSubstring matches the pattern in any
+	// part of the message.
+	WordFilterModeSubstring WordFilterMode = "substring"
+)
+
+// Validate returns true if the mode is a valid
+// WordFilterMode.
+func (m WordFilterMode) Validate() bool {
+	return m == WordFilterModeWord || m == WordFilterModeSubstring
+}
+
+// WordFilterSettings holds the word filter configuration
+// of a guild.
+//
+// Messages of members with one of the ExemptRoles or in
+// one of the ExemptChannels are not checked. MuteDuration
+// is the timeout duration used for WordFilterActionMute.
+type WordFilterSettings struct {
+	GuildID        string           `json:"guildid"`
+	Enabled        bool             `json:"enabled"`
+	Action         WordFilterAction `json:"action"`
+	MuteDuration   time.Duration    `json:"muteduration"`
+	ExemptRoles    []string         `json:"exemptroles"`
+	ExemptChannels []string         `json:"exemptchannels"`
+}
+
+func (s *WordFilterSettings) Validate() error {
+	var errs validation.Errors
+	errs.Assert(s.Action.Validate(), "action", "invalid value for action")
+	errs.Assert(s.MuteDuration >= 0 && s.MuteDuration <= WordFilterMaxMuteDuration, "muteduration",
+		"must be in range of 0 and "+WordFilterMaxMuteDuration.String())
+	errs.Assert(s.Action != WordFilterActionMute || s.MuteDuration > 0, "muteduration",
+		"must be set for the mute action")
+	return errs.Err()
+}
+
+// WordFilterEntry is a banned word or phrase of the
+// word filter of a guild. An asterisk (*) in the pattern
+// matches any amount of letters or digits.
+type WordFilterEntry struct {
+	GuildID string         `json:"guildid"`
+	Pattern string         `json:"pattern"`
+	Mode    WordFilterMode `json:"mode"`
+}
+
+func (e *WordFilterEntry) Validate() error {
+	var errs validation.Errors
+	errs.Assert(strings.Trim(e.Pattern, "* ") != "", "pattern", "must not be empty")
+	errs.Assert(utf8.RuneCountInString(e.Pattern) <= WordFilterMaxPatternLen, "pattern",
+		"must not be longer than 100 characters")
+	errs.Assert(e.Mode.Validate(), "mode", "invalid value for mode")
+	return errs.Err()
+}
@@ -12,6 +12,9 @@ type Entry struct {
 	GuildID   string    `json:"guild_id"`
 	Timestamp time.Time `json:"timestamp"`
 	FileID    string    `json:"file_id"`
+	// Manual is true if the backup has been created on
+	// request and not by the automatic backup routine.
+	Manual bool `json:"manual"`
 }
 
 func (t Entry) String() string {
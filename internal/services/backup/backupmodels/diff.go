@@ -0,0 +1,260 @@
+package backupmodels
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// Change describes a changed property of an object
+// between two backups.
+type Change struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// ObjectRef references a role or channel in a backup.
+type ObjectRef struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// ModifiedObject is a role or channel which exists in
+// both backups but has changed properties.
+type ModifiedObject struct {
+	ObjectRef
+	Changes []Change `json:"changes"`
+}
+
+// ObjectsDiff contains the added, removed and modified
+// objects of one kind between two backups.
+type ObjectsDiff struct {
+	Added    []ObjectRef      `json:"added"`
+	Removed  []ObjectRef      `json:"removed"`
+	Modified []ModifiedObject `json:"modified"`
+}
+
+// Empty returns true if no objects have been added,
+// removed or modified.
+func (d ObjectsDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Modified) == 0
+}
+
+// Diff contains the differences between two backups
+// of the same guild.
+type Diff struct {
+	From     Entry       `json:"from"`
+	To       Entry       `json:"to"`
+	Settings []Change    `json:"settings"`
+	Roles    ObjectsDiff `json:"roles"`
+	Channels ObjectsDiff `json:"channels"`
+	// MembersAdded and MembersRemoved are the number of
+	// members which are only present in one of the backups.
+	MembersAdded   int `json:"members_added"`
+	MembersRemoved int `json:"members_removed"`
+	// Notes contains hints about parts of the backups
+	// which could not be compared.
+	Notes []string `json:"notes"`
+}
+
+// Empty returns true if there are no differences
+// between both backups.
+func (d *Diff) Empty() bool {
+	return len(d.Settings) == 0 && d.Roles.Empty() && d.Channels.Empty() &&
+		d.MembersAdded == 0 && d.MembersRemoved == 0
+}
+
+// ComputeDiff returns the differences from backup from
+// to backup to.
+//
+// Parts which are missing in one of the backups, for
+// example because it has been created by an older
+// version, are skipped and noted in Diff.Notes.
+func ComputeDiff(from, to *Object) *Diff {
+	d := &Diff{
+		From: Entry{Timestamp: from.Timestamp, FileID: from.ID},
+		To:   Entry{Timestamp: to.Timestamp, FileID: to.ID},
+	}
+
+	if from.Guild != nil && to.Guild != nil {
+		d.Settings = diffGuild(from.Guild, to.Guild)
+	} else {
+		d.Notes = append(d.Notes, "Guild settings are missing in one of the backups.")
+	}
+
+	d.Roles = diffRoles(from.Roles, to.Roles)
+	d.Channels = diffChannels(from.Channels, to.Channels)
+
+	if from.Members == nil || to.Members == nil {
+		d.Notes = append(d.Notes, "Members are missing in one of the backups.")
+	} else {
+		fromMembers := make(map[string]struct{}, len(from.Members))
+		for _, m := range from.Members {
+			if m != nil {
+				fromMembers[m.ID] = struct{}{}
+			}
+		}
+		for _, m := range to.Members {
+			if m == nil {
+				continue
+			}
+			if _, ok := fromMembers[m.ID]; ok {
+				delete(fromMembers, m.ID)
+			} else {
+				d.MembersAdded++
+			}
+		}
+		d.MembersRemoved = len(fromMembers)
+	}
+
+	return d
+}
+
+func diffGuild(from, to *Guild) (changes []Change) {
+	changes = appendChange(changes, "name", from.Name, to.Name)
+	changes = appendChange(changes, "afk_channel_id", from.AfkChannelID, to.AfkChannelID)
+	changes = appendChange(changes, "afk_timeout", itoa(from.AfkTimeout), itoa(to.AfkTimeout))
+	changes = appendChange(changes, "verification_level",
+		itoa(from.VerificationLevel), itoa(to.VerificationLevel))
+	changes = appendChange(changes, "default_message_notifications",
+		itoa(from.DefaultMessageNotifications), itoa(to.DefaultMessageNotifications))
+	return
+}
+
+func diffRoles(from, to []*Role) (d ObjectsDiff) {
+	fromMap := make(map[string]*Role, len(from))
+	for _, r := range from {
+		if r != nil {
+			fromMap[r.ID] = r
+		}
+	}
+
+	for _, r := range to {
+		if r == nil {
+			continue
+		}
+		old, ok := fromMap[r.ID]
+		if !ok {
+			d.Added = append(d.Added, ObjectRef{r.ID, r.Name})
+			continue
+		}
+		delete(fromMap, r.ID)
+
+		var changes []Change
+		changes = appendChange(changes, "name", old.Name, r.Name)
+		changes = appendChange(changes, "color", itoa(old.Color), itoa(r.Color))
+		changes = appendChange(changes, "position", itoa(old.Position), itoa(r.Position))
+		changes = appendChange(changes, "permissions",
+			strconv.FormatInt(old.Permissions, 10), strconv.FormatInt(r.Permissions, 10))
+		changes = appendChange(changes, "hoist", btoa(old.Hoist), btoa(r.Hoist))
+		changes = appendChange(changes, "mentionable", btoa(old.Mentionable), btoa(r.Mentionable))
+		if len(changes) != 0 {
+			d.Modified = append(d.Modified, ModifiedObject{ObjectRef{r.ID, r.Name}, changes})
+		}
+	}
+
+	for _, r := range fromMap {
+		d.Removed = append(d.Removed, ObjectRef{r.ID, r.Name})
+	}
+
+	d.sort()
+	return
+}
+
+func diffChannels(from, to []*Channel) (d ObjectsDiff) {
+	fromMap := make(map[string]*Channel, len(from))
+	for _, c := range from {
+		if c != nil {
+			fromMap[c.ID] = c
+		}
+	}
+
+	for _, c := range to {
+		if c == nil {
+			continue
+		}
+		old, ok := fromMap[c.ID]
+		if !ok {
+			d.Added = append(d.Added, ObjectRef{c.ID, c.Name})
+			continue
+		}
+		delete(fromMap, c.ID)
+
+		var changes []Change
+		changes = appendChange(changes, "name", old.Name, c.Name)
+		changes = appendChange(changes, "topic", old.Topic, c.Topic)
+		changes = appendChange(changes, "type", itoa(old.Type), itoa(c.Type))
+		changes = appendChange(changes, "nsfw", btoa(old.NSFW), btoa(c.NSFW))
+		changes = appendChange(changes, "position", itoa(old.Position), itoa(c.Position))
+		changes = appendChange(changes, "bitrate", itoa(old.Bitrate), itoa(c.Bitrate))
+		changes = appendChange(changes, "user_limit", itoa(old.UserLimit), itoa(c.UserLimit))
+		changes = appendChange(changes, "parent_id", old.ParentID, c.ParentID)
+		if !equalOverwrites(old, c) {
+			changes = append(changes, Change{
+				Field: "permission_overwrites",
+				Old:   fmt.Sprintf("%d overwrite(s)", len(old.PermissionOverwrites)),
+				New:   fmt.Sprintf("%d overwrite(s)", len(c.PermissionOverwrites)),
+			})
+		}
+		if len(changes) != 0 {
+			d.Modified = append(d.Modified, ModifiedObject{ObjectRef{c.ID, c.Name}, changes})
+		}
+	}
+
+	for _, c := range fromMap {
+		d.Removed = append(d.Removed, ObjectRef{c.ID, c.Name})
+	}
+
+	d.sort()
+	return
+}
+
+func equalOverwrites(a, b *Channel) bool {
+	if len(a.PermissionOverwrites) != len(b.PermissionOverwrites) {
+		return false
+	}
+
+	aMap := make(map[string]string, len(a.PermissionOverwrites))
+	for _, o := range a.PermissionOverwrites {
+		if o != nil {
+			aMap[o.ID] = fmt.Sprintf("%d:%d:%d", o.Type, o.Allow, o.Deny)
+		}
+	}
+	for _, o := range b.PermissionOverwrites {
+		if o == nil {
+			continue
+		}
+		if v, ok := aMap[o.ID]; !ok || v != fmt.Sprintf("%d:%d:%d", o.Type, o.Allow, o.Deny) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (d *ObjectsDiff) sort() {
+	byName := func(s []ObjectRef) func(i, j int) bool {
+		return func(i, j int) bool { return s[i].Name < s[j].Name }
+	}
+	sort.Slice(d.Added, byName(d.Added))
+	sort.Slice(d.Removed, byName(d.Removed))
+	sort.Slice(d.Modified, func(i, j int) bool {
+		return d.Modified[i].Name < d.Modified[j].Name
+	})
+}
+
+func appendChange(changes []Change, field, old, new string) []Change {
+	if old == new {
+		return changes
+	}
+	return append(changes, Change{Field: field, Old: old, New: new})
+}
+
+func itoa(v int) string {
+	return strconv.Itoa(v)
+}
+
+func btoa(v bool) string {
+	return strconv.FormatBool(v)
+}
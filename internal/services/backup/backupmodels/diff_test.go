@@ -0,0 +1,76 @@
+package backupmodels
+
+import (
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeDiff(t *testing.T) {
+	from := &Object{
+		ID:    "from",
+		Guild: &Guild{Name: "guild", AfkTimeout: 300},
+		Roles: []*Role{
+			{ID: "r1", Name: "admin", Color: 1},
+			{ID: "r2", Name: "removed"},
+		},
+		Channels: []*Channel{
+			{ID: "c1", Name: "general", PermissionOverwrites: []*discordgo.PermissionOverwrite{
+				{ID: "r1", Allow: 1},
+			}},
+			{ID: "c2", Name: "unchanged"},
+		},
+		Members: []*Member{{ID: "m1"}, {ID: "m2"}},
+	}
+	to := &Object{
+		ID:    "to",
+		Guild: &Guild{Name: "new guild", AfkTimeout: 300},
+		Roles: []*Role{
+			{ID: "r1", Name: "admin", Color: 2},
+			{ID: "r3", Name: "added"},
+		},
+		Channels: []*Channel{
+			{ID: "c1", Name: "general", PermissionOverwrites: []*discordgo.PermissionOverwrite{
+				{ID: "r1", Allow: 2},
+			}},
+			{ID: "c2", Name: "unchanged"},
+		},
+		Members: []*Member{{ID: "m2"}, {ID: "m3"}, {ID: "m4"}},
+	}
+
+	d := ComputeDiff(from, to)
+	assert.False(t, d.Empty())
+	assert.Equal(t, "from", d.From.FileID)
+	assert.Equal(t, "to", d.To.FileID)
+
+	assert.Equal(t, []Change{{"name", "guild", "new guild"}}, d.Settings)
+
+	assert.Equal(t, []ObjectRef{{"r3", "added"}}, d.Roles.Added)
+	assert.Equal(t, []ObjectRef{{"r2", "removed"}}, d.Roles.Removed)
+	assert.Equal(t, []ModifiedObject{{ObjectRef{"r1", "admin"}, []Change{{"color", "1", "2"}}}}, d.Roles.Modified)
+
+	assert.Empty(t, d.Channels.Added)
+	assert.Empty(t, d.Channels.Removed)
+	assert.Len(t, d.Channels.Modified, 1)
+	assert.Equal(t, "permission_overwrites", d.Channels.Modified[0].Changes[0].Field)
+
+	assert.Equal(t, 2, d.MembersAdded)
+	assert.Equal(t, 1, d.MembersRemoved)
+	assert.Empty(t, d.Notes)
+
+	assert.True(t, ComputeDiff(from, from).Empty())
+}
+
+func TestComputeDiffMissingParts(t *testing.T) {
+	from := &Object{Roles: []*Role{{ID: "r1", Name: "role"}}}
+	to := &Object{
+		Guild:   &Guild{Name: "guild"},
+		Roles:   []*Role{{ID: "r1", Name: "role"}},
+		Members: []*Member{{ID: "m1"}},
+	}
+
+	d := ComputeDiff(from, to)
+	assert.True(t, d.Empty())
+	assert.Len(t, d.Notes, 2)
+}
@@ -0,0 +1,78 @@
+package backupmodels
+
+import (
+	"errors"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+const (
+	// MinScheduleInterval is the minimum time between
+	// two scheduled backups of a guild.
+	MinScheduleInterval = 6 * time.Hour
+
+	// scheduleCheckRuns is the number of upcoming runs
+	// checked against MinScheduleInterval.
+	scheduleCheckRuns = 32
+)
+
+var ErrScheduleTooFrequent = errors.New("scheduled backups must be at least 6 hours apart")
+
+// Schedule contains the settings for recurring backups
+// of a guild.
+type Schedule struct {
+	GuildID string `json:"guild_id"`
+	// Spec is a standard cron spec (minute, hour, day
+	// of month, month, day of week) or one of the
+	// descriptors @daily and @weekly. Times are UTC.
+	Spec string `json:"spec"`
+	// Retention is the number of automatic backups
+	// which are kept for the guild.
+	Retention int `json:"retention"`
+	// NotifyChannelID is the channel a summary is
+	// sent to after each scheduled backup.
+	NotifyChannelID string `json:"notify_channel_id"`
+	// NotifyOwner specifies whether the summary is
+	// sent to the owner of the guild via DM.
+	NotifyOwner bool      `json:"notify_owner"`
+	LastRun     time.Time `json:"last_run"`
+}
+
+// ParseScheduleSpec parses the given cron spec and
+// returns ErrScheduleTooFrequent if two of the upcoming
+// runs after now are less than MinScheduleInterval apart.
+func ParseScheduleSpec(spec string, now time.Time) (cron.Schedule, error) {
+	sched, err := cron.ParseStandard(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	last := sched.Next(now.UTC())
+	for i := 0; i < scheduleCheckRuns; i++ {
+		next := sched.Next(last)
+		if next.Sub(last) < MinScheduleInterval {
+			return nil, ErrScheduleTooFrequent
+		}
+		last = next
+	}
+
+	return sched, nil
+}
+
+// Due returns true if a scheduled backup should be
+// created at the given time.
+func (s Schedule) Due(now time.Time) bool {
+	next := s.Next()
+	return !next.IsZero() && !next.After(now.UTC())
+}
+
+// Next returns the time of the next scheduled backup
+// or the zero time if the spec is invalid.
+func (s Schedule) Next() time.Time {
+	sched, err := cron.ParseStandard(s.Spec)
+	if err != nil {
+		return time.Time{}
+	}
+	return sched.Next(s.LastRun.UTC())
+}
@@ -0,0 +1,43 @@
+package backupmodels
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseScheduleSpec(t *testing.T) {
+	now := time.Date(2022, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	_, err := ParseScheduleSpec("@daily", now)
+	assert.Nil(t, err)
+
+	_, err = ParseScheduleSpec("@weekly", now)
+	assert.Nil(t, err)
+
+	_, err = ParseScheduleSpec("0 6,18 * * *", now)
+	assert.Nil(t, err)
+
+	_, err = ParseScheduleSpec("0 * * * *", now)
+	assert.ErrorIs(t, err, ErrScheduleTooFrequent)
+
+	_, err = ParseScheduleSpec("0 6,8 * * *", now)
+	assert.ErrorIs(t, err, ErrScheduleTooFrequent)
+
+	_, err = ParseScheduleSpec("invalid", now)
+	assert.NotNil(t, err)
+}
+
+func TestScheduleDue(t *testing.T) {
+	lastRun := time.Date(2022, 1, 1, 12, 0, 0, 0, time.UTC)
+	s := Schedule{Spec: "@daily", LastRun: lastRun}
+
+	assert.Equal(t, time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC), s.Next())
+	assert.False(t, s.Due(lastRun.Add(11*time.Hour)))
+	assert.True(t, s.Due(lastRun.Add(12*time.Hour)))
+
+	s.Spec = "invalid"
+	assert.True(t, s.Next().IsZero())
+	assert.False(t, s.Due(lastRun.Add(48*time.Hour)))
+}
@@ -4,6 +4,10 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/sarulabs/di/v2"
@@ -15,6 +19,7 @@ import (
 	"github.com/zekroTJA/shinpuru/internal/util/snowflakenodes"
 	"github.com/zekroTJA/shinpuru/internal/util/static"
 	"github.com/zekroTJA/shinpuru/pkg/discordutil"
+	"github.com/zekroTJA/shinpuru/pkg/embedbuilder"
 	"github.com/zekroTJA/shinpuru/pkg/inline"
 	"github.com/zekrotja/dgrs"
 	"github.com/zekrotja/rogu"
@@ -27,8 +32,19 @@ import (
 const (
 	// tickRate = 30 * time.Second
 	tickRate = 12 * time.Hour
+
+	// MaxBackups is the maximum number of automatic
+	// and, separately, manual backups stored per guild.
+	// When exceeded, the oldest backups of the same kind
+	// are removed.
+	MaxBackups = 10
 )
 
+// ErrBackupRunning is returned when a backup of a
+// guild is requested while another backup of the
+// same guild is being created.
+var ErrBackupRunning = errors.New("a backup of this guild is currently being created")
+
 // GuildBackups provides functionalities to backup
 // and restore a guild to and from a JSON file.
 type GuildBackups struct {
@@ -36,9 +52,28 @@ type GuildBackups struct {
 	db      database.Database
 	gl      guildlog.Logger
 	st      storage.Storage
-	state   *dgrs.State
+	state   dgrs.IState
 	tp      timeprovider.Provider
 	log     rogu.Logger
+
+	running  sync.Map
+	schedMtx sync.Mutex
+}
+
+// Result contains the entry and a summary of a
+// created backup.
+type Result struct {
+	Entry    backupmodels.Entry
+	Size     int64
+	Channels int
+	Roles    int
+	Members  int
+	// Removed is the number of old backups which
+	// have been removed due to the retention.
+	Removed int
+	// Warnings contains problems which occurred while
+	// creating the backup that did not cause it to fail.
+	Warnings []string
 }
 
 // asyncWriteStatus writes the passed status to the
@@ -69,6 +104,24 @@ func (bck *GuildBackups) guilds() (guilds []string, err error) {
 	return
 }
 
+// getEntry returns the backup entry of the guild with
+// the given file ID or database.ErrDatabaseNotFound, if
+// the guild has no such backup.
+func (bck *GuildBackups) getEntry(guildID, fileID string) (entry backupmodels.Entry, err error) {
+	backups, err := bck.db.GetBackups(guildID)
+	if err != nil {
+		return
+	}
+
+	for _, b := range backups {
+		if b.FileID == fileID {
+			return b, nil
+		}
+	}
+
+	return entry, database.ErrDatabaseNotFound
+}
+
 // New initializes a new GuildBackups instance using
 // the passed discordgo Session, database provider,
 // and storage provider. Also, the ticker loop is
@@ -79,7 +132,7 @@ func New(container di.Container) *GuildBackups {
 	bck.gl = container.Get(static.DiGuildLog).(guildlog.Logger).Section("backup")
 	bck.st = container.Get(static.DiObjectStorage).(storage.Storage)
 	bck.session = container.Get(static.DiDiscordSession).(*discordgo.Session)
-	bck.state = container.Get(static.DiState).(*dgrs.State)
+	bck.state = container.Get(static.DiState).(dgrs.IState)
 	bck.tp = container.Get(static.DiTimeProvider).(timeprovider.Provider)
 	bck.log = log.Tagged("GuildBackup")
 	return bck
@@ -87,21 +140,32 @@ func New(container di.Container) *GuildBackups {
 
 // BackupAllGuilds iterates through all guilds
 // which have guild backups enabled and initiates
-// the backup routines one after one.
+// the backup routines one after one. Guilds with
+// a backup schedule are skipped because they are
+// backed up by RunSchedules.
 // Guild backups are not created in new goroutines
 // because of potential rate limit exceedance.
 func (bck *GuildBackups) BackupAllGuilds() {
 	guilds, err := bck.guilds()
-
-	bck.log.Info().Fields("nGuilds", len(guilds)).Msg("Backing up guilds ...")
-
 	if err != nil {
 		bck.log.Error().Err(err).Msg("Failed getting guilds to back up")
 		return
 	}
 
+	schedules, err := bck.schedules()
+	if err != nil {
+		bck.log.Error().Err(err).Msg("Failed getting backup schedules")
+		return
+	}
+	guilds = sop.Slice(guilds).Filter(func(v string, i int) bool {
+		_, scheduled := schedules[v]
+		return !scheduled
+	}).Unwrap()
+
+	bck.log.Info().Fields("nGuilds", len(guilds)).Msg("Backing up guilds ...")
+
 	for _, g := range guilds {
-		err = bck.BackupGuild(g)
+		_, err = bck.backupGuild(g, false)
 		if err != nil {
 			bck.log.Error().Err(err).Field("gid", g).Msg("Failed creating backup for guild")
 			bck.gl.Errorf(g, "Failed creating guild backup: %s", err.Error())
@@ -110,18 +174,34 @@ func (bck *GuildBackups) BackupAllGuilds() {
 	}
 }
 
-// BackupGuild creates a backup of a single guild
+// BackupGuild creates a manual backup of a single guild
 // and writes the resulting JSON file to the specified
-// storage. If the backup creation fails, the error is
-// returned.
-func (bck *GuildBackups) BackupGuild(guildID string) error {
+// storage. The entry of the created backup is returned.
+// If the backup creation fails, the error is returned.
+//
+// Manual backups are rotated separately from the
+// automatic backups, so they do not displace them.
+//
+// If another backup of the guild is currently being
+// created, ErrBackupRunning is returned.
+func (bck *GuildBackups) BackupGuild(guildID string) (entry backupmodels.Entry, err error) {
+	res, err := bck.backupGuild(guildID, true)
+	return res.Entry, err
+}
+
+func (bck *GuildBackups) backupGuild(guildID string, manual bool) (res Result, err error) {
 	if bck.session == nil {
-		return errors.New("session is nil")
+		return res, errors.New("session is nil")
+	}
+
+	if _, running := bck.running.LoadOrStore(guildID, struct{}{}); running {
+		return res, ErrBackupRunning
 	}
+	defer bck.running.Delete(guildID)
 
 	g, err := bck.state.Guild(guildID, true)
 	if err != nil {
-		return err
+		return
 	}
 
 	backup := new(backupmodels.Object)
@@ -137,7 +217,7 @@ func (bck *GuildBackups) BackupGuild(guildID string) error {
 
 	chans, err := bck.state.Channels(g.ID, true)
 	if err != nil {
-		return err
+		return
 	}
 
 	for _, c := range chans {
@@ -171,7 +251,7 @@ func (bck *GuildBackups) BackupGuild(guildID string) error {
 
 	members, err := bck.state.Members(g.ID, true)
 	if err != nil {
-		return err
+		return
 	}
 	for _, m := range members {
 		backup.Members = append(backup.Members, &backupmodels.Member{
@@ -182,6 +262,10 @@ func (bck *GuildBackups) BackupGuild(guildID string) error {
 			Roles: m.Roles,
 		})
 	}
+	if len(members) < g.MemberCount {
+		res.Warnings = append(res.Warnings, fmt.Sprintf(
+			"Only %d of %d members could be backed up.", len(members), g.MemberCount))
+	}
 
 	backup.ID = snowflakenodes.NodeBackup.Generate().String()
 
@@ -191,45 +275,290 @@ func (bck *GuildBackups) BackupGuild(guildID string) error {
 	enc.SetIndent("", "  ")
 	err = enc.Encode(backup)
 	if err != nil {
-		return err
+		return
 	}
 
 	err = bck.st.PutObject(static.StorageBucketBackups, backup.ID, buff, int64(buff.Len()), "application/json")
 	if err != nil {
-		return err
+		return
 	}
 
-	err = bck.db.AddBackup(g.ID, backup.ID)
+	err = bck.db.AddBackup(g.ID, backup.ID, manual)
 	if err != nil {
 		bck.st.DeleteObject(static.StorageBucketBackups, backup.ID)
-		return err
+		return
 	}
 
-	cBackups, err := bck.db.GetBackups(g.ID)
+	res.Entry = backupmodels.Entry{
+		GuildID:   g.ID,
+		Timestamp: backup.Timestamp,
+		FileID:    backup.ID,
+		Manual:    manual,
+	}
+	res.Size = int64(buff.Len())
+	res.Channels = len(backup.Channels)
+	res.Roles = len(backup.Roles)
+	res.Members = len(backup.Members)
+
+	// The backup has been created at this point, so
+	// failing to remove old backups is only a warning.
+	res.Removed, err = bck.removeExceeding(g.ID, manual)
 	if err != nil {
-		return err
+		bck.log.Error().Err(err).Field("gid", g.ID).Msg("Failed removing old backups")
+		bck.gl.Errorf(g.ID, "Failed removing old backups: %s", err.Error())
+		res.Warnings = append(res.Warnings, "Failed removing old backups: "+err.Error())
+		err = nil
+	}
+
+	return
+}
+
+// removeExceeding removes the oldest manual or automatic
+// backups of the guild which exceed the retention and
+// returns the number of removed backups. The retention of
+// the backup schedule only applies to automatic backups.
+func (bck *GuildBackups) removeExceeding(guildID string, manual bool) (n int, err error) {
+	retention := MaxBackups
+	if !manual {
+		sched, err := bck.db.GetBackupSchedule(guildID)
+		if err != nil && !database.IsErrDatabaseNotFound(err) {
+			return 0, err
+		}
+		if err == nil && sched.Retention > 0 && sched.Retention < MaxBackups {
+			retention = sched.Retention
+		}
+	}
+
+	backups, err := bck.db.GetBackups(guildID)
+	if err != nil {
+		return
 	}
 
-	if len(cBackups) > 10 {
-		var lastEntry *backupmodels.Entry
-		for _, b := range cBackups {
-			if lastEntry == nil || b.Timestamp.Before(lastEntry.Timestamp) {
-				lastEntry = &b
+	cBackups := sop.Slice(backups).Filter(func(v backupmodels.Entry, i int) bool {
+		return v.Manual == manual
+	}).Unwrap()
+
+	if len(cBackups) > retention {
+		sort.Slice(cBackups, func(i, j int) bool {
+			return cBackups[i].Timestamp.Before(cBackups[j].Timestamp)
+		})
+
+		for _, b := range cBackups[:len(cBackups)-retention] {
+			if err = bck.DeleteBackup(guildID, b.FileID); err != nil {
+				return
 			}
+			n++
 		}
+	}
+
+	return
+}
+
+// RunSchedules creates backups of all guilds which have
+// backups enabled and a backup schedule which is due.
+// After each scheduled backup, a summary is sent to the
+// notification targets of the schedule.
+//
+// If a previous call is still running, the call returns
+// immediately.
+func (bck *GuildBackups) RunSchedules() {
+	if !bck.schedMtx.TryLock() {
+		return
+	}
+	defer bck.schedMtx.Unlock()
+
+	guilds, err := bck.guilds()
+	if err != nil {
+		bck.log.Error().Err(err).Msg("Failed getting guilds to back up")
+		return
+	}
 
-		err = bck.st.DeleteObject(static.StorageBucketBackups, lastEntry.FileID)
+	schedules, err := bck.schedules()
+	if err != nil {
+		bck.log.Error().Err(err).Msg("Failed getting backup schedules")
+		return
+	}
+
+	for _, g := range guilds {
+		sched, ok := schedules[g]
+		if !ok || !sched.Due(bck.tp.Now()) {
+			continue
+		}
+
+		// The last run is updated before creating the backup
+		// so that failed backups are not retried until the
+		// next scheduled run.
+		sched.LastRun = bck.tp.Now()
+		if err = bck.db.SetBackupSchedule(sched); err != nil {
+			bck.log.Error().Err(err).Field("gid", g).Msg("Failed updating backup schedule")
+			continue
+		}
+
+		res, err := bck.backupGuild(g, false)
+		if err == ErrBackupRunning {
+			bck.log.Debug().Field("gid", g).Msg("Skipped scheduled backup because another backup is running")
+			continue
+		}
 		if err != nil {
-			return err
+			bck.log.Error().Err(err).Field("gid", g).Msg("Failed creating scheduled backup for guild")
+			bck.gl.Errorf(g, "Failed creating scheduled guild backup: %s", err.Error())
+		}
+
+		bck.notify(sched, res, err)
+		time.Sleep(1 * time.Second)
+	}
+}
+
+func (bck *GuildBackups) schedules() (res map[string]backupmodels.Schedule, err error) {
+	schedules, err := bck.db.GetBackupSchedules()
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return nil, err
+	}
+
+	res = make(map[string]backupmodels.Schedule, len(schedules))
+	for _, s := range schedules {
+		res[s.GuildID] = s
+	}
+	return res, nil
+}
+
+// notify sends a summary of the scheduled backup or the
+// error, if the backup has failed, to the notification
+// targets of the given schedule.
+func (bck *GuildBackups) notify(sched backupmodels.Schedule, res Result, backupErr error) {
+	if sched.NotifyChannelID == "" && !sched.NotifyOwner {
+		return
+	}
+
+	g, err := bck.state.Guild(sched.GuildID)
+	if err != nil {
+		bck.log.Error().Err(err).Field("gid", sched.GuildID).Msg("Failed getting guild")
+		return
+	}
+
+	emb := ScheduledBackupEmbed(g.Name, sched, res, backupErr)
+
+	if sched.NotifyChannelID != "" {
+		if _, err = bck.session.ChannelMessageSendEmbed(sched.NotifyChannelID, emb); err != nil {
+			bck.gl.Errorf(g.ID, "Failed sending backup notification to channel <#%s>: %s",
+				sched.NotifyChannelID, err.Error())
 		}
+	}
 
-		err = bck.db.DeleteBackup(g.ID, lastEntry.FileID)
+	if sched.NotifyOwner {
+		ch, err := bck.session.UserChannelCreate(g.OwnerID)
+		if err == nil {
+			_, err = bck.session.ChannelMessageSendEmbed(ch.ID, emb)
+		}
 		if err != nil {
-			return err
+			bck.gl.Errorf(g.ID, "Failed sending backup notification to the guild owner: %s", err.Error())
 		}
 	}
+}
+
+// ScheduledBackupEmbed returns the notification embed of
+// a scheduled backup of the given guild. If backupErr is
+// not nil, the embed reports the failure of the backup.
+// The error itself is not shown, because the embed may
+// be sent to a channel which is visible to all members.
+func ScheduledBackupEmbed(guildName string, sched backupmodels.Schedule, res Result, backupErr error) *discordgo.MessageEmbed {
+	emb := embedbuilder.New().
+		WithTitle("Scheduled Backup").
+		WithFooter("Next backup", "", "").
+		WithTimestamp(sched.Next())
+
+	if backupErr != nil {
+		return emb.
+			WithColor(static.ColorEmbedError).
+			WithDescription(fmt.Sprintf(
+				"Creating the scheduled backup of **%s** has failed. "+
+					"The error has been written to the guild log.", guildName)).
+			Build()
+	}
+
+	emb.
+		WithColor(static.ColorEmbedGreen).
+		WithDescription(fmt.Sprintf("A scheduled backup of **%s** has been created.", guildName)).
+		AddInlineField("Backup ID", "`"+res.Entry.FileID+"`").
+		AddInlineField("Size", fmt.Sprintf("`%.1f KiB`", float64(res.Size)/1024)).
+		AddInlineField("Objects", fmt.Sprintf("Channels: `%d`\nRoles: `%d`\nMembers: `%d`",
+			res.Channels, res.Roles, res.Members))
+
+	if res.Removed > 0 {
+		emb.AddField("Retention", fmt.Sprintf("`%d` old backup(s) have been removed.", res.Removed))
+	}
+
+	if len(res.Warnings) != 0 {
+		emb.
+			WithColor(static.ColorEmbedOrange).
+			AddField("Warnings", "- "+strings.Join(res.Warnings, "\n- "))
+	}
+
+	return emb.Build()
+}
+
+// DeleteBackup removes the backup with the given file
+// ID of the given guild from the storage and the database.
+//
+// If the guild has no backup with the given file ID,
+// database.ErrDatabaseNotFound is returned.
+func (bck *GuildBackups) DeleteBackup(guildID, fileID string) error {
+	_, err := bck.getEntry(guildID, fileID)
+	if err != nil {
+		return err
+	}
+
+	err = bck.st.DeleteObject(static.StorageBucketBackups, fileID)
+	if err != nil {
+		return err
+	}
+
+	return bck.db.DeleteBackup(guildID, fileID)
+}
+
+// GetBackup returns the backup object of the guild
+// by the given file ID.
+//
+// If the guild has no backup with the given file ID,
+// database.ErrDatabaseNotFound is returned.
+func (bck *GuildBackups) GetBackup(guildID, fileID string) (backup *backupmodels.Object, err error) {
+	if _, err = bck.getEntry(guildID, fileID); err != nil {
+		return
+	}
+
+	reader, _, err := bck.st.GetObject(static.StorageBucketBackups, fileID)
+	if err != nil {
+		return
+	}
+	defer reader.Close()
+
+	backup = new(backupmodels.Object)
+	err = json.NewDecoder(reader).Decode(backup)
+
+	return
+}
+
+// DiffBackups returns the differences between the backups
+// of the guild specified by fromID and toID.
+//
+// If the guild has no backup with one of the given file IDs,
+// database.ErrDatabaseNotFound is returned.
+func (bck *GuildBackups) DiffBackups(guildID, fromID, toID string) (diff *backupmodels.Diff, err error) {
+	from, err := bck.GetBackup(guildID, fromID)
+	if err != nil {
+		return
+	}
 
-	return err
+	to, err := bck.GetBackup(guildID, toID)
+	if err != nil {
+		return
+	}
+
+	diff = backupmodels.ComputeDiff(from, to)
+	diff.From.GuildID = guildID
+	diff.To.GuildID = guildID
+
+	return
 }
 
 // RestoreBackup tries to restore a guild structure by
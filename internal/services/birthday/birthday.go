@@ -26,7 +26,7 @@ import (
 type BirthdayService struct {
 	gif     *giphy.Client
 	db      database.Database
-	st      *dgrs.State
+	st      dgrs.IState
 	session *discordgo.Session
 	gl      guildlog.Logger
 	tp      timeprovider.Provider
@@ -36,7 +36,7 @@ type BirthdayService struct {
 func New(ctn di.Container) *BirthdayService {
 	b := &BirthdayService{
 		db:      ctn.Get(static.DiDatabase).(database.Database),
-		st:      ctn.Get(static.DiState).(*dgrs.State),
+		st:      ctn.Get(static.DiState).(dgrs.IState),
 		session: ctn.Get(static.DiDiscordSession).(*discordgo.Session),
 		gl:      ctn.Get(static.DiGuildLog).(guildlog.Logger).Section("birthday"),
 		tp:      ctn.Get(static.DiTimeProvider).(timeprovider.Provider),
@@ -0,0 +1,255 @@
+// Package broadcast provides a service to deliver
+// announcements of the bot owner to all guilds.
+package broadcast
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/bwmarrin/snowflake"
+	"github.com/sarulabs/di/v2"
+	"github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/services/timeprovider"
+	"github.com/zekroTJA/shinpuru/internal/util/snowflakenodes"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/pkg/discordutil"
+	"github.com/zekrotja/dgrs"
+	"github.com/zekrotja/rogu"
+	"github.com/zekrotja/rogu/log"
+)
+
+// deliveryInterval is the delay between two deliveries
+// to stay well within the rate limits of Discord for
+// creating DM channels and sending messages.
+const deliveryInterval = 1 * time.Second
+
+var (
+	ErrRunning   = errors.New("another broadcast is currently being delivered")
+	ErrDuplicate = errors.New("a broadcast with the same content has already been sent")
+	ErrEmpty     = errors.New("content must not be empty")
+)
+
+type BroadcastService struct {
+	s   discordutil.ISession
+	db  database.Database
+	st  dgrs.IState
+	tp  timeprovider.Provider
+	log rogu.Logger
+
+	interval time.Duration
+	running  int32
+}
+
+func New(ctn di.Container) *BroadcastService {
+	return &BroadcastService{
+		s:        ctn.Get(static.DiDiscordSession).(discordutil.ISession),
+		db:       ctn.Get(static.DiDatabase).(database.Database),
+		st:       ctn.Get(static.DiState).(dgrs.IState),
+		tp:       ctn.Get(static.DiTimeProvider).(timeprovider.Provider),
+		log:      log.Tagged("Broadcast"),
+		interval: deliveryInterval,
+	}
+}
+
+// Start saves a new broadcast with the given content and
+// delivers it to all guilds in the background.
+//
+// Only one broadcast can be delivered at a time. If a
+// broadcast with the same content has been sent before,
+// ErrDuplicate is returned unless force is true.
+func (b *BroadcastService) Start(authorID, content string, force bool) (bc models.Broadcast, err error) {
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return bc, ErrEmpty
+	}
+
+	if !atomic.CompareAndSwapInt32(&b.running, 0, 1) {
+		return bc, ErrRunning
+	}
+	defer func() {
+		if err != nil {
+			atomic.StoreInt32(&b.running, 0)
+		}
+	}()
+
+	if !force {
+		var sent []models.Broadcast
+		sent, err = b.db.GetBroadcasts(false)
+		if err != nil && !database.IsErrDatabaseNotFound(err) {
+			return
+		}
+		for _, s := range sent {
+			if s.Content == content {
+				return bc, ErrDuplicate
+			}
+		}
+	}
+
+	bc = models.Broadcast{
+		ID:       snowflakenodes.NodeBroadcasts.Generate(),
+		AuthorID: authorID,
+		Content:  content,
+		Created:  b.tp.Now(),
+	}
+
+	if err = b.db.AddBroadcast(bc); err != nil {
+		return
+	}
+
+	go func() {
+		defer atomic.StoreInt32(&b.running, 0)
+		b.run(bc)
+	}()
+
+	return bc, nil
+}
+
+// Resume continues the delivery of all unfinished
+// broadcasts in the background, for example after the
+// delivery has been interrupted by a restart. Guilds
+// which have already received a broadcast are skipped.
+func (b *BroadcastService) Resume() error {
+	unfinished, err := b.db.GetBroadcasts(true)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return err
+	}
+	if len(unfinished) == 0 {
+		return nil
+	}
+
+	if !atomic.CompareAndSwapInt32(&b.running, 0, 1) {
+		return nil
+	}
+
+	go func() {
+		defer atomic.StoreInt32(&b.running, 0)
+		// Broadcasts are listed by creation date descending,
+		// so they are resumed in reverse order.
+		for i := len(unfinished) - 1; i >= 0; i-- {
+			b.log.Info().Field("id", unfinished[i].ID).Msg("Resuming broadcast")
+			b.run(unfinished[i])
+		}
+	}()
+
+	return nil
+}
+
+// Report returns the delivery report of the broadcast
+// with the given ID.
+func (b *BroadcastService) Report(id snowflake.ID) (r models.BroadcastReport, err error) {
+	bc, err := b.db.GetBroadcast(id)
+	if err != nil {
+		return
+	}
+
+	deliveries, err := b.db.GetBroadcastDeliveries(bc.ID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return
+	}
+
+	return models.NewBroadcastReport(bc, deliveries), nil
+}
+
+func (b *BroadcastService) run(bc models.Broadcast) {
+	deliveries, err := b.db.GetBroadcastDeliveries(bc.ID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		b.log.Error().Err(err).Field("id", bc.ID).Msg("Failed getting broadcast deliveries")
+		return
+	}
+
+	delivered := make(map[string]bool, len(deliveries))
+	for _, d := range deliveries {
+		delivered[d.GuildID] = true
+	}
+
+	guilds, err := b.st.Guilds()
+	if err != nil {
+		b.log.Error().Err(err).Field("id", bc.ID).Msg("Failed getting guilds")
+		return
+	}
+
+	for _, guild := range guilds {
+		if delivered[guild.ID] {
+			continue
+		}
+
+		d := b.deliver(bc, guild)
+		if err = b.db.AddBroadcastDelivery(d); err != nil {
+			// If the delivery can not be recorded, the broadcast
+			// is aborted to prevent sending it multiple times
+			// to the same guild when resumed.
+			b.log.Error().Err(err).Field("id", bc.ID).Msg("Failed saving broadcast delivery")
+			return
+		}
+
+		time.Sleep(b.interval)
+	}
+
+	if err = b.db.SetBroadcastFinished(bc.ID); err != nil {
+		b.log.Error().Err(err).Field("id", bc.ID).Msg("Failed setting broadcast finished")
+		return
+	}
+
+	b.log.Info().Field("id", bc.ID).Msg("Broadcast finished")
+}
+
+// deliver sends the broadcast into the announcement
+// channel of the guild. If no announcement channel is
+// set or the channel does not belong to the guild, the
+// broadcast is sent to the owner of the guild via DM.
+func (b *BroadcastService) deliver(bc models.Broadcast, guild *discordgo.Guild) models.BroadcastDelivery {
+	d := models.BroadcastDelivery{
+		BroadcastID: bc.ID,
+		GuildID:     guild.ID,
+		Status:      models.BroadcastStatusSent,
+	}
+
+	emb := &discordgo.MessageEmbed{
+		Color:       static.ColorEmbedDefault,
+		Title:       "📢 Announcement",
+		Description: bc.Content,
+		Timestamp:   bc.Created.Format(time.RFC3339),
+	}
+
+	chanID, err := b.db.GetGuildAnnouncementChannel(guild.ID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return failedDelivery(d, err)
+	}
+
+	if chanID != "" {
+		if ch, err := b.st.Channel(chanID); err != nil || ch.GuildID != guild.ID {
+			chanID = ""
+		}
+	}
+
+	if chanID == "" {
+		ch, err := b.s.UserChannelCreate(guild.OwnerID)
+		if err != nil {
+			return failedDelivery(d, err)
+		}
+		chanID = ch.ID
+		emb.Footer = &discordgo.MessageEmbedFooter{
+			Text: fmt.Sprintf("You receive this message because you own the guild %s.", guild.Name),
+		}
+	}
+
+	if _, err = b.s.ChannelMessageSendEmbed(chanID, emb); err != nil {
+		return failedDelivery(d, err)
+	}
+
+	return d
+}
+
+func failedDelivery(d models.BroadcastDelivery, err error) models.BroadcastDelivery {
+	d.Status = models.BroadcastStatusFailed
+	if discordutil.IsErrCode(err, discordgo.ErrCodeCannotSendMessagesToThisUser) {
+		d.Status = models.BroadcastStatusDMsClosed
+	}
+	d.Error = err.Error()
+	return d
+}
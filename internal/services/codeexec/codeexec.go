@@ -1,6 +1,10 @@
 package codeexec
 
 import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/ranna-go/ranna/pkg/models"
@@ -8,6 +12,16 @@ import (
 
 var AvailableFactories = []string{"ranna", "jdoodle"}
 
+var (
+	// ErrTimeout is returned when an execution took
+	// longer than the configured timeout.
+	ErrTimeout = errors.New("execution timed out")
+
+	// ErrBusy is returned when too many executions
+	// are currently running or queued.
+	ErrBusy = errors.New("server busy")
+)
+
 type Payload struct {
 	Language    string
 	Code        string
@@ -22,6 +36,10 @@ type Response struct {
 	ExecTime time.Duration
 	MemUsed  string
 	CpuUsed  string
+
+	// Truncated is true when StdOut or StdErr
+	// exceeded the output limit and were cut.
+	Truncated bool
 }
 
 type Factory interface {
@@ -31,5 +49,63 @@ type Factory interface {
 }
 
 type Executor interface {
-	Exec(Payload) (Response, error)
+	Exec(ctx context.Context, p Payload) (Response, error)
+}
+
+// SupportedLanguages returns the sorted names of
+// all languages supported by the given factory.
+func SupportedLanguages(f Factory) ([]string, error) {
+	specs, err := f.Specs()
+	if err != nil {
+		return nil, err
+	}
+
+	langs := make([]string, 0, len(specs))
+	for lang := range specs {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+
+	return langs, nil
+}
+
+// ResolveLanguage returns the lower case name and the
+// spec of the passed language after resolving the
+// aliases defined in the passed specs.
+func ResolveLanguage(specs models.SpecMap, lang string) (string, *models.Spec, bool) {
+	lang = strings.ToLower(lang)
+	// Limit the iterations in case of cyclic aliases.
+	for i := 0; i <= len(specs); i++ {
+		spec := specs[lang]
+		if spec == nil {
+			return "", nil, false
+		}
+		if spec.Use == "" {
+			return lang, spec, true
+		}
+		lang = strings.ToLower(spec.Use)
+	}
+	return "", nil, false
+}
+
+// IsLanguageAllowed returns true if allowed is empty or
+// if the passed language resolves to the same language
+// as any of the allowed languages.
+func IsLanguageAllowed(specs models.SpecMap, lang string, allowed []string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+
+	lang, _, ok := ResolveLanguage(specs, lang)
+	if !ok {
+		return false
+	}
+
+	for _, a := range allowed {
+		if a, _, ok := ResolveLanguage(specs, a); ok && a == lang {
+			return true
+		}
+	}
+
+	return false
 }
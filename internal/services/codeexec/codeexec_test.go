@@ -0,0 +1,32 @@
+package codeexec
+
+import (
+	"testing"
+
+	"github.com/ranna-go/ranna/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsLanguageAllowed(t *testing.T) {
+	specs := models.SpecMap{
+		"python3": &models.Spec{},
+		"py3":     &models.Spec{Use: "python3"},
+		"go":      &models.Spec{},
+		"a":       &models.Spec{Use: "b"},
+		"b":       &models.Spec{Use: "a"},
+	}
+
+	lang, _, ok := ResolveLanguage(specs, "PY3")
+	assert.True(t, ok)
+	assert.Equal(t, "python3", lang)
+
+	_, _, ok = ResolveLanguage(specs, "a")
+	assert.False(t, ok)
+
+	assert.True(t, IsLanguageAllowed(specs, "Go", nil))
+	assert.True(t, IsLanguageAllowed(specs, "Python3", []string{"python3"}))
+	assert.True(t, IsLanguageAllowed(specs, "py3", []string{"Python3"}))
+	assert.True(t, IsLanguageAllowed(specs, "python3", []string{"py3"}))
+	assert.False(t, IsLanguageAllowed(specs, "go", []string{"python3"}))
+	assert.False(t, IsLanguageAllowed(specs, "rust", []string{"python3"}))
+}
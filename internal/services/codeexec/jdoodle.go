@@ -1,6 +1,7 @@
 package codeexec
 
 import (
+	"context"
 	"strings"
 
 	"github.com/ranna-go/ranna/pkg/models"
@@ -65,9 +66,9 @@ type JdoodleExecutor struct {
 	clientSecret string
 }
 
-func (e *JdoodleExecutor) Exec(p Payload) (res Response, err error) {
+func (e *JdoodleExecutor) Exec(ctx context.Context, p Payload) (res Response, err error) {
 	w := jdoodle.NewWrapper(e.clientId, e.clientSecret)
-	r, err := w.ExecuteScript(p.Language, p.Code)
+	r, err := w.ExecuteScriptContext(ctx, p.Language, p.Code)
 	if err != nil {
 		return
 	}
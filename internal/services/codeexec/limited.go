@@ -0,0 +1,166 @@
+package codeexec
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	sharedmodels "github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/services/metrics"
+)
+
+// LimitedFactory wraps a Factory and limits the
+// amount of concurrently running executions as
+// well as the duration of each execution of the
+// executors created by it.
+type LimitedFactory struct {
+	Factory
+
+	slots     chan struct{}
+	queue     chan struct{}
+	timeout   time.Duration
+	maxOutput int
+}
+
+var _ Factory = (*LimitedFactory)(nil)
+
+// NewLimitedFactory returns a new LimitedFactory
+// wrapping the passed factory with the given limits.
+func NewLimitedFactory(factory Factory, limits sharedmodels.CodeExecLimits) *LimitedFactory {
+	f := &LimitedFactory{
+		Factory:   factory,
+		timeout:   time.Duration(limits.TimeoutSeconds) * time.Second,
+		maxOutput: limits.MaxOutputBytes,
+	}
+
+	if limits.MaxExecutions > 0 {
+		f.slots = make(chan struct{}, limits.MaxExecutions)
+		f.queue = make(chan struct{}, limits.MaxQueued)
+	}
+
+	return f
+}
+
+func (f *LimitedFactory) NewExecutor(guildID string) (exec Executor, err error) {
+	exec, err = f.Factory.NewExecutor(guildID)
+	if err != nil || exec == nil {
+		return
+	}
+
+	exec = &limitedExecutor{Executor: exec, f: f}
+	return
+}
+
+type limitedExecutor struct {
+	Executor
+
+	f *LimitedFactory
+}
+
+func (e *limitedExecutor) Exec(ctx context.Context, p Payload) (res Response, err error) {
+	if e.f.slots != nil {
+		if err = e.acquire(ctx); err != nil {
+			return
+		}
+	}
+
+	// The timeout starts after a free slot has been
+	// acquired, so waiting in the queue does not
+	// shorten the execution time.
+	ctx, cancel := e.withTimeout(ctx)
+	defer cancel()
+
+	type result struct {
+		res Response
+		err error
+	}
+	resC := make(chan result, 1)
+
+	// The context is passed down to the executor which
+	// aborts the execution request when the context is
+	// cancelled on return. The slot is only released
+	// when the executor has returned, so executions
+	// which do not stop immediately are still counted.
+	start := time.Now()
+	go func() {
+		if e.f.slots != nil {
+			defer e.release()
+		}
+		res, err := e.Executor.Exec(ctx, p)
+		resC <- result{res, err}
+	}()
+
+	select {
+	case r := <-resC:
+		res, err = r.res, r.err
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		err = ErrTimeout
+	}
+	if err != nil {
+		return
+	}
+
+	// Not all executors report the execution time, so
+	// the duration of the request is used instead.
+	if res.ExecTime == 0 {
+		res.ExecTime = time.Since(start)
+	}
+
+	if e.f.maxOutput > 0 {
+		var outTrunc, errTrunc bool
+		res.StdOut, outTrunc = TruncateOutput(res.StdOut, e.f.maxOutput)
+		res.StdErr, errTrunc = TruncateOutput(res.StdErr, e.f.maxOutput)
+		res.Truncated = outTrunc || errTrunc
+	}
+
+	return
+}
+
+func (e *limitedExecutor) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if e.f.timeout > 0 {
+		return context.WithTimeout(ctx, e.f.timeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+func (e *limitedExecutor) acquire(ctx context.Context) error {
+	select {
+	case e.f.slots <- struct{}{}:
+		metrics.CodeExecActive.Inc()
+		return nil
+	default:
+	}
+
+	select {
+	case e.f.queue <- struct{}{}:
+	default:
+		return ErrBusy
+	}
+
+	metrics.CodeExecQueued.Inc()
+	defer func() {
+		<-e.f.queue
+		metrics.CodeExecQueued.Dec()
+	}()
+
+	// Waiting for a free slot is limited by the
+	// execution timeout as well.
+	ctx, cancel := e.withTimeout(ctx)
+	defer cancel()
+
+	select {
+	case e.f.slots <- struct{}{}:
+		metrics.CodeExecActive.Inc()
+		return nil
+	case <-ctx.Done():
+		return ErrTimeout
+	}
+}
+
+func (e *limitedExecutor) release() {
+	<-e.f.slots
+	metrics.CodeExecActive.Dec()
+}
@@ -0,0 +1,71 @@
+package codeexec
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ranna-go/ranna/pkg/models"
+	"github.com/stretchr/testify/assert"
+	sharedmodels "github.com/zekroTJA/shinpuru/internal/models"
+)
+
+type testFactory struct {
+	exec Executor
+}
+
+func (f testFactory) Name() string                         { return "test" }
+func (f testFactory) Specs() (models.SpecMap, error)       { return nil, nil }
+func (f testFactory) NewExecutor(string) (Executor, error) { return f.exec, nil }
+
+type testExecutor func(ctx context.Context, p Payload) (Response, error)
+
+func (e testExecutor) Exec(ctx context.Context, p Payload) (Response, error) {
+	return e(ctx, p)
+}
+
+func TestLimitedExecTimeout(t *testing.T) {
+	done := make(chan struct{})
+	cancelled := make(chan struct{})
+	f := NewLimitedFactory(testFactory{testExecutor(func(ctx context.Context, p Payload) (Response, error) {
+		<-ctx.Done()
+		close(cancelled)
+		// Simulate an executor which does not stop
+		// immediately after cancellation.
+		<-done
+		return Response{}, ctx.Err()
+	})}, sharedmodels.CodeExecLimits{MaxExecutions: 1, TimeoutSeconds: 1})
+
+	exec, _ := f.NewExecutor("")
+
+	start := time.Now()
+	_, err := exec.Exec(context.Background(), Payload{})
+	assert.ErrorIs(t, err, ErrTimeout)
+	assert.Less(t, time.Since(start), 2*time.Second)
+
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Fatal("execution has not been cancelled")
+	}
+
+	// The slot is held until the executor returns and
+	// no executions are queued.
+	_, err = exec.Exec(context.Background(), Payload{})
+	assert.ErrorIs(t, err, ErrBusy)
+
+	close(done)
+}
+
+func TestLimitedExecOutput(t *testing.T) {
+	f := NewLimitedFactory(testFactory{testExecutor(func(ctx context.Context, p Payload) (Response, error) {
+		return Response{StdOut: "hello world"}, nil
+	})}, sharedmodels.CodeExecLimits{MaxExecutions: 1, MaxQueued: 1, MaxOutputBytes: 5})
+
+	exec, _ := f.NewExecutor("")
+
+	res, err := exec.Exec(context.Background(), Payload{})
+	assert.Nil(t, err)
+	assert.True(t, res.Truncated)
+	assert.NotZero(t, res.ExecTime)
+}
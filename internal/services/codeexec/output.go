@@ -0,0 +1,65 @@
+package codeexec
+
+import (
+	"encoding/hex"
+	"regexp"
+	"unicode/utf8"
+
+	"github.com/zekroTJA/shinpuru/pkg/random"
+)
+
+const (
+	truncationMarker = "\n[output truncated]"
+
+	outputIDLen = 16
+)
+
+var outputIDRx = regexp.MustCompile(`^[0-9a-f]{32}$`)
+
+// NewOutputID returns a new random ID for an uploaded
+// output which can not be guessed.
+func NewOutputID() (string, error) {
+	data, err := random.GetRandByteArray(outputIDLen)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(data), nil
+}
+
+// IsValidOutputID returns true if the passed ID has
+// the format of IDs returned by NewOutputID.
+func IsValidOutputID(id string) bool {
+	return outputIDRx.MatchString(id)
+}
+
+// OutputObjectName returns the name of the storage
+// object of the uploaded output with the given ID. The
+// name contains the ID of the user who executed the code,
+// so outputs can only be retrieved by this user.
+func OutputObjectName(userID, id string) string {
+	return userID + "-" + id
+}
+
+// TruncateOutput cuts the passed output to a maximum
+// of limit bytes and appends a marker to indicate that
+// the output has been truncated. The marker is included
+// in the limit. Multi-byte characters are never split.
+//
+// If the output was truncated, true is returned.
+func TruncateOutput(output string, limit int) (string, bool) {
+	if limit < 0 || len(output) <= limit {
+		return output, false
+	}
+
+	marker := truncationMarker
+	if limit <= len(marker) {
+		marker = ""
+	}
+
+	cut := limit - len(marker)
+	for cut > 0 && !utf8.RuneStart(output[cut]) {
+		cut--
+	}
+
+	return output[:cut] + marker, true
+}
@@ -0,0 +1,58 @@
+package codeexec
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTruncateOutput(t *testing.T) {
+	out := strings.Repeat("a", 100)
+
+	res, truncated := TruncateOutput(out, 100)
+	assert.False(t, truncated)
+	assert.Equal(t, out, res)
+
+	res, truncated = TruncateOutput(out, 200)
+	assert.False(t, truncated)
+	assert.Equal(t, out, res)
+
+	res, truncated = TruncateOutput(out, -1)
+	assert.False(t, truncated)
+	assert.Equal(t, out, res)
+
+	res, truncated = TruncateOutput(out, 99)
+	assert.True(t, truncated)
+	assert.Equal(t, 99, len(res))
+	assert.True(t, strings.HasSuffix(res, truncationMarker))
+	assert.Equal(t, out[:99-len(truncationMarker)], strings.TrimSuffix(res, truncationMarker))
+
+	res, truncated = TruncateOutput(out, len(truncationMarker))
+	assert.True(t, truncated)
+	assert.Equal(t, out[:len(truncationMarker)], res)
+
+	res, truncated = TruncateOutput(out, 0)
+	assert.True(t, truncated)
+	assert.Equal(t, "", res)
+
+	// 'ä' is encoded as two bytes, so the cut must
+	// not happen in between of them.
+	out = strings.Repeat("ä", 50)
+	res, truncated = TruncateOutput(out, len(truncationMarker)+5)
+	assert.True(t, truncated)
+	assert.Equal(t, strings.Repeat("ä", 2)+truncationMarker, res)
+}
+
+func TestNewOutputID(t *testing.T) {
+	id, err := NewOutputID()
+	assert.Nil(t, err)
+	assert.True(t, IsValidOutputID(id))
+
+	other, err := NewOutputID()
+	assert.Nil(t, err)
+	assert.NotEqual(t, id, other)
+
+	assert.False(t, IsValidOutputID("1234567890"))
+	assert.False(t, IsValidOutputID("../"+id[3:]))
+}
@@ -1,7 +1,12 @@
 package codeexec
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"net/http"
 	"strings"
 	"time"
 
@@ -64,16 +69,24 @@ func (e *RannaFactory) NewExecutor(guildID string) (exec Executor, err error) {
 	if err != nil {
 		return
 	}
-	exec = &RannaExecutor{client}
+	exec = &RannaExecutor{client, e.cfg}
 	return
 }
 
 type RannaExecutor struct {
 	client ranna.Client
+	cfg    *sharedmodels.CodeExecRanna
 }
 
-func (e *RannaExecutor) Exec(p Payload) (res Response, err error) {
-	r, err := e.client.Exec(models.ExecutionRequest{
+// Exec sends the execution request to the ranna instance.
+//
+// Because the ranna client does not support passing a
+// context, the request is performed directly so that it
+// can be aborted when ctx is done. The sandbox itself is
+// killed by ranna after its configured sandbox timeout,
+// so it should not exceed the code exec timeout.
+func (e *RannaExecutor) Exec(ctx context.Context, p Payload) (res Response, err error) {
+	body, err := json.Marshal(models.ExecutionRequest{
 		Language:         p.Language,
 		Code:             p.Code,
 		Arguments:        p.Args,
@@ -83,6 +96,41 @@ func (e *RannaExecutor) Exec(p Payload) (res Response, err error) {
 	if err != nil {
 		return
 	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		fmt.Sprintf("%s/%s/exec", e.cfg.Endpoint, e.cfg.ApiVersion), bytes.NewReader(body))
+	if err != nil {
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "shinpuru")
+	if e.cfg.Token != "" {
+		req.Header.Set("Authorization", e.cfg.Token)
+	}
+
+	httpRes, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return
+	}
+	defer httpRes.Body.Close()
+
+	if httpRes.StatusCode >= 400 {
+		resErr := &ranna.ResponseError{
+			ErrorModel: &models.ErrorModel{
+				Code:  httpRes.StatusCode,
+				Error: "unknown",
+			},
+		}
+		json.NewDecoder(httpRes.Body).Decode(resErr.ErrorModel)
+		err = resErr
+		return
+	}
+
+	var r models.ExecutionResponse
+	if err = json.NewDecoder(httpRes.Body).Decode(&r); err != nil {
+		return
+	}
 	res.StdOut = r.StdOut
 	res.StdErr = r.StdErr
 	res.ExecTime = time.Duration(r.ExecTimeMS) * time.Millisecond
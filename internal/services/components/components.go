@@ -0,0 +1,278 @@
+// Package components provides a registry for message
+// components like buttons and select menus on top of
+// the component handler of ken, which adds ownership
+// and expiry to registered components.
+package components
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/rs/xid"
+	"github.com/sarulabs/di/v2"
+	"github.com/zekroTJA/shinpuru/internal/services/timeprovider"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/pkg/discordutil"
+	"github.com/zekrotja/ken"
+)
+
+// IDPrefix is the prefix of all custom IDs generated
+// by NewID. Interactions with components carrying this
+// prefix which are not registered anymore are answered
+// with an expiry notice.
+const IDPrefix = "spc:"
+
+const (
+	noticeExpired  = "This interaction has expired. Please run the command again."
+	noticeNotOwner = "This interaction is reserved for the user who invoked the command."
+)
+
+// NewID returns a new unique custom ID for a message
+// component.
+func NewID() string {
+	return IDPrefix + xid.New().String()
+}
+
+// IsManaged returns true if the given custom ID has
+// been generated by NewID.
+func IsManaged(customID string) bool {
+	return strings.HasPrefix(customID, IDPrefix)
+}
+
+// Registry keeps track of the custom IDs of the message
+// components registered via its builders.
+type Registry struct {
+	kc *ken.ComponentHandler
+	tp timeprovider.Provider
+
+	mtx sync.RWMutex
+	ids map[string]struct{}
+}
+
+func New(ctn di.Container) *Registry {
+	return &Registry{
+		kc:  ctn.Get(static.DiCommandHandler).(ken.IKen).Components(),
+		tp:  ctn.Get(static.DiTimeProvider).(timeprovider.Provider),
+		ids: make(map[string]struct{}),
+	}
+}
+
+// Add returns a new Builder which attaches the added
+// components to the given message on build.
+func (r *Registry) Add(messageID, channelID string) *Builder {
+	return &Builder{
+		r:   r,
+		ken: r.kc.Add(messageID, channelID),
+	}
+}
+
+// IsRegistered returns true if a handler is registered
+// for the given custom ID.
+func (r *Registry) IsRegistered(customID string) bool {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	_, ok := r.ids[customID]
+	return ok
+}
+
+func (r *Registry) register(ids []string) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	for _, id := range ids {
+		r.ids[id] = struct{}{}
+	}
+}
+
+func (r *Registry) forget(ids ...string) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	for _, id := range ids {
+		delete(r.ids, id)
+	}
+}
+
+// Builder assembles the message components and registers
+// their handlers.
+type Builder struct {
+	r   *Registry
+	ken *ken.ComponentBuilder
+
+	ownerID  string
+	lifetime time.Duration
+	deadline time.Time
+	ids      []string
+}
+
+// Owner restricts the interaction with the components
+// to the user with the given ID. Other users receive an
+// ephemeral notice.
+func (b *Builder) Owner(userID string) *Builder {
+	b.ownerID = userID
+	return b
+}
+
+// Expires sets the lifetime of the components after
+// which they are removed from the message and their
+// handlers are unregistered.
+func (b *Builder) Expires(lifetime time.Duration) *Builder {
+	b.lifetime = lifetime
+	return b
+}
+
+// AddActionsRow adds an action row assembled by the given
+// build function. If once is true, the whole action row is
+// removed after the first successful interaction with one
+// of its components.
+func (b *Builder) AddActionsRow(build func(a ken.ComponentAssembler), once ...bool) *Builder {
+	b.ken.AddActionsRow(rowBuilder(b, build, once...), once...)
+	return b
+}
+
+// Build attaches the components to the message and
+// registers their handlers.
+//
+// The returned function removes the components from the
+// message and unregisters their handlers. It is called
+// automatically when the lifetime of the components has
+// passed.
+func (b *Builder) Build() (unreg func() error, err error) {
+	if b.lifetime > 0 {
+		b.deadline = b.r.tp.Now().Add(b.lifetime)
+	}
+
+	b.r.register(b.ids)
+	kunreg, err := b.ken.Build()
+	if err != nil {
+		b.r.forget(b.ids...)
+		return
+	}
+
+	var (
+		once   sync.Once
+		result error
+	)
+	unreg = func() error {
+		once.Do(func() {
+			b.r.forget(b.ids...)
+			result = kunreg()
+		})
+		return result
+	}
+
+	if b.lifetime > 0 {
+		time.AfterFunc(b.lifetime, func() { unreg() })
+	}
+
+	return unreg, nil
+}
+
+// wrap returns a handler which checks the ownership and
+// lifetime of the component before calling the given
+// handler. The custom IDs contained in group are forgotten
+// after the first successful interaction if once is true.
+func (b *Builder) wrap(handler ken.ComponentHandlerFunc, once bool, group *[]string) ken.ComponentHandlerFunc {
+	return func(ctx ken.ComponentContext) bool {
+		if !b.deadline.IsZero() && b.r.tp.Now().After(b.deadline) {
+			respondNotice(ctx, noticeExpired)
+			return false
+		}
+
+		if b.ownerID != "" && ctx.User().ID != b.ownerID {
+			respondNotice(ctx, noticeNotOwner)
+			return false
+		}
+
+		ok := handler(ctx)
+		if ok && once {
+			b.r.forget(*group...)
+		}
+		return ok
+	}
+}
+
+// assembler wraps the handlers of all added components
+// and records their custom IDs.
+type assembler struct {
+	b   *Builder
+	ken ken.ComponentAssembler
+
+	once  bool
+	group *[]string
+}
+
+func (a *assembler) Add(component discordgo.MessageComponent, handler ken.ComponentHandlerFunc, once ...bool) ken.ComponentAssembler {
+	id := customID(component)
+	if id == "" || handler == nil {
+		a.ken.Add(component, handler, once...)
+		return a
+	}
+
+	a.b.ids = append(a.b.ids, id)
+
+	isOnce, group := a.once, a.group
+	if isOnce {
+		*group = append(*group, id)
+	} else if len(once) != 0 && once[0] {
+		isOnce, group = true, &[]string{id}
+	}
+
+	a.ken.Add(component, a.b.wrap(handler, isOnce, group), once...)
+	return a
+}
+
+func (a *assembler) AddActionsRow(build func(b ken.ComponentAssembler), once ...bool) ken.ComponentAssembler {
+	a.ken.AddActionsRow(rowBuilder(a.b, build, once...), once...)
+	return a
+}
+
+// rowBuilder returns a build function for ken which passes
+// a wrapping assembler to the given build function.
+func rowBuilder(b *Builder, build func(a ken.ComponentAssembler), once ...bool) func(kb ken.ComponentAssembler) {
+	return func(kb ken.ComponentAssembler) {
+		build(&assembler{
+			b:     b,
+			ken:   kb,
+			once:  len(once) != 0 && once[0],
+			group: &[]string{},
+		})
+	}
+}
+
+func customID(component discordgo.MessageComponent) string {
+	switch c := component.(type) {
+	case discordgo.Button:
+		return c.CustomID
+	case *discordgo.Button:
+		return c.CustomID
+	case discordgo.SelectMenu:
+		return c.CustomID
+	case *discordgo.SelectMenu:
+		return c.CustomID
+	}
+	return ""
+}
+
+func respondNotice(ctx ken.ComponentContext, content string) {
+	ctx.Respond(&discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: content,
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
+// RespondUnknown answers the given interaction with an
+// ephemeral notice that the interacted component is not
+// available anymore.
+func RespondUnknown(s discordutil.ISession, i *discordgo.Interaction) error {
+	return s.InteractionRespond(i, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: noticeExpired,
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
@@ -0,0 +1,71 @@
+package database
+
+import (
+	"fmt"
+	"time"
+)
+
+// RetryOptions specifies how often and how long
+// connecting to the database is retried.
+type RetryOptions struct {
+	// MaxAttempts is the maximum number of connection
+	// attempts. Values below 1 result in a single attempt.
+	MaxAttempts int
+	// InitialDelay is the delay before the first retry.
+	// The delay is doubled after each failed attempt.
+	InitialDelay time.Duration
+	// MaxDelay caps the delay between two attempts.
+	MaxDelay time.Duration
+	// Timeout is the maximum total time to wait for the
+	// database. No timeout is applied if it is 0.
+	Timeout time.Duration
+}
+
+// RetryFunc is called after each failed connection
+// attempt which is retried after the given delay.
+type RetryFunc func(attempt int, delay time.Duration, err error)
+
+// Connect connects the given database using the passed
+// credentials. Failed attempts are retried with an
+// exponential backoff as specified in opts until either
+// the maximum number of attempts or the timeout has been
+// reached, in which case the last connection error is
+// returned.
+func Connect(db Database, opts RetryOptions, onRetry RetryFunc, credentials ...interface{}) (err error) {
+	if opts.MaxAttempts < 1 {
+		opts.MaxAttempts = 1
+	}
+
+	start := time.Now()
+	delay := opts.InitialDelay
+
+	for attempt := 1; ; attempt++ {
+		if err = db.Connect(credentials...); err == nil {
+			return nil
+		}
+
+		// Release resources which may have been allocated
+		// by the failed attempt before trying again.
+		db.Close()
+
+		if attempt >= opts.MaxAttempts {
+			return fmt.Errorf("database is not reachable after %d attempts: %s", attempt, err.Error())
+		}
+
+		if opts.MaxDelay > 0 && delay > opts.MaxDelay {
+			delay = opts.MaxDelay
+		}
+
+		if opts.Timeout > 0 && time.Since(start)+delay > opts.Timeout {
+			return fmt.Errorf("database is not reachable within %s (%d attempts): %s",
+				opts.Timeout, attempt, err.Error())
+		}
+
+		if onRetry != nil {
+			onRetry(attempt, delay, err)
+		}
+
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
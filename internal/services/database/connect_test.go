@@ -0,0 +1,90 @@
+package database
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// delayedDatabase is a database which becomes available
+// after a specific number of connection attempts.
+type delayedDatabase struct {
+	Database
+
+	availableAfter int
+	attempts       int
+	closed         int
+}
+
+func (d *delayedDatabase) Connect(credentials ...interface{}) error {
+	d.attempts++
+	if d.attempts < d.availableAfter {
+		return errors.New("connection refused")
+	}
+	return nil
+}
+
+func (d *delayedDatabase) Close() {
+	d.closed++
+}
+
+func TestConnect(t *testing.T) {
+	opts := RetryOptions{
+		MaxAttempts:  5,
+		InitialDelay: time.Millisecond,
+		MaxDelay:     4 * time.Millisecond,
+	}
+
+	// Available immediately.
+	db := &delayedDatabase{availableAfter: 1}
+	err := Connect(db, opts, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, db.attempts)
+	assert.Equal(t, 0, db.closed)
+
+	// Available after some attempts.
+	var delays []time.Duration
+	onRetry := func(attempt int, delay time.Duration, err error) {
+		delays = append(delays, delay)
+	}
+
+	db = &delayedDatabase{availableAfter: 5}
+	err = Connect(db, opts, onRetry)
+	assert.Nil(t, err)
+	assert.Equal(t, 5, db.attempts)
+	assert.Equal(t, 4, db.closed)
+	assert.Equal(t, []time.Duration{
+		time.Millisecond,
+		2 * time.Millisecond,
+		4 * time.Millisecond,
+		4 * time.Millisecond,
+	}, delays)
+
+	// Never available.
+	db = &delayedDatabase{availableAfter: 10}
+	err = Connect(db, opts, nil)
+	assert.EqualError(t, err, "database is not reachable after 5 attempts: connection refused")
+	assert.Equal(t, 5, db.attempts)
+
+	// No retries.
+	db = &delayedDatabase{availableAfter: 2}
+	err = Connect(db, RetryOptions{}, nil)
+	assert.NotNil(t, err)
+	assert.Equal(t, 1, db.attempts)
+}
+
+func TestConnectTimeout(t *testing.T) {
+	opts := RetryOptions{
+		MaxAttempts:  100,
+		InitialDelay: 10 * time.Millisecond,
+		Timeout:      25 * time.Millisecond,
+	}
+
+	db := &delayedDatabase{availableAfter: 100}
+	err := Connect(db, opts, nil)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "not reachable within")
+	assert.Equal(t, 2, db.attempts)
+}
@@ -45,6 +45,14 @@ type Database interface {
 	GetGuildModLog(guildID string) (string, error)
 	SetGuildModLog(guildID, chanID string) error
 
+	GetGuildModLogFormat(guildID string) (models.ModLogFormat, error)
+	SetGuildModLogFormat(guildID string, format models.ModLogFormat) error
+
+	GetGuildModLogRoute(guildID string, action models.ModLogAction) (string, error)
+	GetGuildModLogRoutes(guildID string) (map[models.ModLogAction]string, error)
+	SetGuildModLogRoute(guildID string, action models.ModLogAction, channelID string) error
+	RemoveGuildModLogRoute(guildID string, action models.ModLogAction) error
+
 	GetGuildVoiceLog(guildID string) (string, error)
 	SetGuildVoiceLog(guildID, chanID string) error
 
@@ -68,6 +76,9 @@ type Database interface {
 	GetGuildCodeExecEnabled(guildID string) (bool, error)
 	SetGuildCodeExecEnabled(guildID string, enabled bool) error
 
+	GetGuildCodeExecLanguages(guildID string) ([]string, error)
+	SetGuildCodeExecLanguages(guildID string, languages []string) error
+
 	GetGuildBackup(guildID string) (bool, error)
 	SetGuildBackup(guildID string, enabled bool) error
 
@@ -80,12 +91,39 @@ type Database interface {
 	GetGuildLeaveMsg(guildID string) (string, string, error)
 	SetGuildLeaveMsg(guildID string, channelID string, msg string) error
 
+	GetGuildMemberMsgGrace(guildID string) (period time.Duration, noteRejoin bool, err error)
+	SetGuildMemberMsgGrace(guildID string, period time.Duration, noteRejoin bool) error
+
 	GetGuildColorReaction(guildID string) (bool, error)
 	SetGuildColorReaction(guildID string, enable bool) error
 
 	GetGuildLogDisable(guildID string) (bool, error)
 	SetGuildLogDisable(guildID string, enabled bool) error
 
+	GetGuildConfirmActions(guildID string) ([]models.ConfirmAction, error)
+	SetGuildConfirmActions(guildID string, actions []models.ConfirmAction) error
+
+	GetGuildPermDeniedMessage(guildID string) (msg string, silent bool, err error)
+	SetGuildPermDeniedMessage(guildID string, msg string, silent bool) error
+
+	GetGuildCommandSuggestionsDisable(guildID string) (bool, error)
+	SetGuildCommandSuggestionsDisable(guildID string, disabled bool) error
+
+	GetPrefixlessChannels(guildID string) ([]string, error)
+	AddPrefixlessChannel(guildID, channelID string) error
+	RemovePrefixlessChannel(guildID, channelID string) error
+
+	GetGuildEmbedColor(guildID string) (int, error)
+	SetGuildEmbedColor(guildID string, color int) error
+
+	GetGuildDisabledCommands(guildID string) ([]string, error)
+	SetGuildDisabledCommand(guildID, command string, disabled bool) error
+	GetGuildDisabledCommandMessage(guildID string) (msg string, silent bool, err error)
+	SetGuildDisabledCommandMessage(guildID string, msg string, silent bool) error
+
+	GetGuildModmailChannel(guildID string) (string, error)
+	SetGuildModmailChannel(guildID, chanID string) error
+
 	GetGuildAPI(guildID string) (models.GuildAPISettings, error)
 	SetGuildAPI(guildID string, settings models.GuildAPISettings) error
 
@@ -98,6 +136,18 @@ type Database interface {
 	GetGuildModNot(guildID string) (string, error)
 	SetGuildModNot(guildID string, chanID string) error
 
+	GetGuildAnnouncementChannel(guildID string) (string, error)
+	SetGuildAnnouncementChannel(guildID, chanID string) error
+
+	GetGuildPinRotation(guildID string) (bool, error)
+	SetGuildPinRotation(guildID string, enabled bool) error
+
+	GetGuildMessageStatsEnabled(guildID string) (bool, error)
+	SetGuildMessageStatsEnabled(guildID string, enabled bool) error
+
+	GetGuildBotNickname(guildID string) (string, error)
+	SetGuildBotNickname(guildID, nickname string) error
+
 	//////////////////////////////////////////////////////
 	//// USER SETTINGS
 
@@ -122,13 +172,25 @@ type Database interface {
 
 	AddReport(rep models.Report) error
 	DeleteReport(id snowflake.ID) error
+	RevokeReport(id snowflake.ID, rev models.ReportRevocation) error
 	GetReport(id snowflake.ID) (models.Report, error)
 	GetReportsGuild(guildID string, offset, limit int) ([]models.Report, error)
 	GetReportsFiltered(guildID, memberID string, repType models.ReportType, offset, limit int) ([]models.Report, error)
 	GetReportsGuildCount(guildID string) (int, error)
 	GetReportsFilteredCount(guildID, memberID string, repType int) (int, error)
+	GetRevokedReportsGuild(guildID string, offset, limit int) ([]models.Report, error)
+	GetRevokedReportsGuildCount(guildID string) (int, error)
 	GetExpiredReports() ([]models.Report, error)
 	ExpireReports(id ...string) (err error)
+	GetReportImport(guildID, externalID string) (snowflake.ID, error)
+	AddReportImport(guildID, externalID string, reportID snowflake.ID) error
+
+	GetGuildReportTypes(guildID string) ([]models.CustomReportType, error)
+	SetGuildReportType(t models.CustomReportType) error
+	DeleteGuildReportType(guildID string, id models.ReportType) error
+
+	GetGuildReportEscalation(guildID string) (models.ReportEscalation, error)
+	SetGuildReportEscalation(e models.ReportEscalation) error
 
 	//////////////////////////////////////////////////////
 	//// UNBAN REQUESTS
@@ -158,9 +220,14 @@ type Database interface {
 	//////////////////////////////////////////////////////
 	//// GUILD BACKUPS
 
-	AddBackup(guildID, fileID string) error
+	AddBackup(guildID, fileID string, manual bool) error
 	DeleteBackup(guildID, fileID string) error
 	GetBackups(guildID string) ([]backupmodels.Entry, error)
+
+	GetBackupSchedule(guildID string) (backupmodels.Schedule, error)
+	GetBackupSchedules() ([]backupmodels.Schedule, error)
+	SetBackupSchedule(s backupmodels.Schedule) error
+	DeleteBackupSchedule(guildID string) error
 	GetGuilds() ([]string, error)
 
 	//////////////////////////////////////////////////////
@@ -179,6 +246,7 @@ type Database interface {
 	SetAPIToken(token models.APITokenEntry) error
 	GetAPIToken(userID string) (models.APITokenEntry, error)
 	DeleteAPIToken(userID string) error
+	AddAPITokenHit(userID string, t time.Time) error
 
 	//////////////////////////////////////////////////////
 	//// KARMA
@@ -188,6 +256,7 @@ type Database interface {
 	GetKarmaGuild(guildID string, limit int) ([]models.GuildKarma, error)
 	SetKarma(userID, guildID string, val int) error
 	UpdateKarma(userID, guildID string, diff int) error
+	ResetKarmaGuild(guildID string) error
 
 	SetKarmaState(guildID string, state bool) error
 	GetKarmaState(guildID string) (bool, error)
@@ -201,6 +270,9 @@ type Database interface {
 	SetKarmaPenalty(guildID string, state bool) error
 	GetKarmaPenalty(guildID string) (bool, error)
 
+	SetKarmaLimits(guildID string, limits models.KarmaLimits) error
+	GetKarmaLimits(guildID string) (models.KarmaLimits, error)
+
 	GetKarmaBlockList(guildID string) ([]string, error)
 	IsKarmaBlockListed(guildID, userID string) (bool, error)
 	AddKarmaBlockList(guildID, userID string) error
@@ -285,6 +357,122 @@ type Database interface {
 	AddRoleSelects(v []models.RoleSelect) error
 	GetRoleSelects() ([]models.RoleSelect, error)
 	RemoveRoleSelect(guildID, channelID, messageID string) error
+
+	//////////////////////////////////////////////////////
+	//// AUTO DELETE
+
+	GetAutoDelete(guildID, channelID string) (models.AutoDeleteConfig, error)
+	GetAutoDeletes(guildID string) ([]models.AutoDeleteConfig, error)
+	SetAutoDelete(cfg models.AutoDeleteConfig) error
+	RemoveAutoDelete(guildID, channelID string) error
+
+	GetAutoThread(guildID, channelID string) (models.AutoThreadConfig, error)
+	GetAutoThreads(guildID string) ([]models.AutoThreadConfig, error)
+	SetAutoThread(cfg models.AutoThreadConfig) error
+	RemoveAutoThread(guildID, channelID string) error
+
+	GetAutoPublish(guildID, channelID string) (models.AutoPublishConfig, error)
+	GetAutoPublishes(guildID string) ([]models.AutoPublishConfig, error)
+	SetAutoPublish(cfg models.AutoPublishConfig) error
+	RemoveAutoPublish(guildID, channelID string) error
+
+	GetStickyMessage(guildID, channelID string) (models.StickyMessage, error)
+	GetStickyMessages(guildID string) ([]models.StickyMessage, error)
+	SetStickyMessage(sm models.StickyMessage) error
+	RemoveStickyMessage(guildID, channelID string) error
+
+	//////////////////////////////////////////////////////
+	//// SETTINGS AUDIT
+
+	AddSettingsAuditEntry(e models.SettingsAuditEntry) error
+	GetSettingsAuditEntries(guildID string, offset, limit int) ([]models.SettingsAuditEntry, error)
+	GetSettingsAuditEntriesCount(guildID string) (int, error)
+
+	//////////////////////////////////////////////////////
+	//// MODMAIL
+
+	GetModmailThread(threadID string) (models.ModmailThread, error)
+	GetModmailThreadsByUser(userID string) ([]models.ModmailThread, error)
+	SetModmailThread(t models.ModmailThread) error
+	RemoveModmailThread(threadID string) error
+
+	//////////////////////////////////////////////////////
+	//// RULES
+
+	GetGuildRules(guildID string) (models.GuildRules, error)
+	GetAllGuildRules() ([]models.GuildRules, error)
+	SetGuildRules(r models.GuildRules) error
+	GetRulesAcceptance(guildID, userID string) (int, error)
+	SetRulesAcceptance(guildID, userID string, version int) error
+
+	GetGiveaway(id snowflake.ID) (models.Giveaway, error)
+	GetGiveawayByMessage(messageID string) (models.Giveaway, error)
+	GetGiveaways(guildID string, activeOnly bool) ([]models.Giveaway, error)
+	SetGiveaway(g models.Giveaway) error
+	EndGiveaway(id snowflake.ID, winnerIDs []string) (bool, error)
+	AddGiveawayEntry(id snowflake.ID, guildID, userID string) (bool, error)
+	GetGiveawayEntries(id snowflake.ID) ([]string, error)
+
+	//////////////////////////////////////////////////////
+	//// TEMPORARY ROLES
+
+	GetTempRole(id snowflake.ID) (models.TempRole, error)
+	GetTempRoles(guildID, userID string) ([]models.TempRole, error)
+	SetTempRole(t models.TempRole) error
+	RemoveTempRole(id snowflake.ID) error
+
+	//////////////////////////////////////////////////////
+	//// USER NOTES
+
+	AddUserNote(n models.UserNote) error
+	GetUserNotes(guildID, userID string) ([]models.UserNote, error)
+	DeleteUserNote(guildID string, id snowflake.ID) error
+
+	//////////////////////////////////////////////////////
+	//// WORD FILTER
+
+	GetWordFilterSettings(guildID string) (models.WordFilterSettings, error)
+	SetWordFilterSettings(s models.WordFilterSettings) error
+	GetWordFilterEntries(guildID string) ([]models.WordFilterEntry, error)
+	SetWordFilterEntry(e models.WordFilterEntry) error
+	RemoveWordFilterEntry(guildID, pattern string) error
+
+	//////////////////////////////////////////////////////
+	//// BROADCASTS
+
+	AddBroadcast(b models.Broadcast) error
+	SetBroadcastFinished(id snowflake.ID) error
+	GetBroadcast(id snowflake.ID) (models.Broadcast, error)
+	GetBroadcasts(unfinishedOnly bool) ([]models.Broadcast, error)
+	AddBroadcastDelivery(d models.BroadcastDelivery) error
+	GetBroadcastDeliveries(id snowflake.ID) ([]models.BroadcastDelivery, error)
+
+	//////////////////////////////////////////////////////
+	//// INVITE TRACKING
+
+	AddTrackedInvite(inv models.TrackedInvite) error
+	GetTrackedInvites(guildID string) ([]models.TrackedInvite, error)
+	AddInviteJoin(j models.InviteJoin) error
+	GetInviteJoinStats(guildID string) ([]models.InviteJoinStats, error)
+
+	AddMessageStats(entries []models.MessageStatsEntry) error
+	GetMessageStats(guildID string, since time.Time) ([]models.MessageStatsEntry, error)
+	DeleteMessageStats(guildID string) error
+	CleanupMessageStats(before time.Time) (int64, error)
+
+	GetReactionReportConfig(guildID string) (models.ReactionReportConfig, error)
+	SetReactionReportConfig(cfg models.ReactionReportConfig) error
+
+	//////////////////////////////////////////////////////
+	//// INACTIVITY
+
+	GetInactivitySettings(guildID string) (models.InactivitySettings, error)
+	GetEnabledInactivitySettings() ([]models.InactivitySettings, error)
+	SetInactivitySettings(s models.InactivitySettings) error
+	GetMemberActivities(guildID string) ([]models.MemberActivity, error)
+	SetMemberActivities(entries []models.MemberActivity) error
+	SetMemberActivityWarned(a models.MemberActivity) error
+	DeleteMemberActivities(guildID string) error
 }
 
 // IsErrDatabaseNotFound returns true if the passed err
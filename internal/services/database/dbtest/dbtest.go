@@ -0,0 +1,123 @@
+// Package dbtest provides test cases which are run
+// against all implementations of the Database interface
+// to ensure that they behave the same.
+package dbtest
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/snowflake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+)
+
+// uniqueID returns a numeric ID which is unique across
+// test runs so that tests against persistent databases
+// do not collide with data of previous runs.
+func uniqueID(offset int) string {
+	return strconv.FormatInt(time.Now().UnixNano()+int64(offset), 10)
+}
+
+// ReportsFilteredCount tests GetReportsFilteredCount.
+func ReportsFilteredCount(t *testing.T, db database.Database) {
+	guildID := uniqueID(0)
+
+	reps := []models.Report{
+		{Type: models.TypeWarn, VictimID: "1"},
+		{Type: models.TypeKick, VictimID: "1"},
+		{Type: models.TypeWarn, VictimID: "2"},
+		{Type: models.TypeWarn, VictimID: "1"},
+	}
+	for i, rep := range reps {
+		id, _ := strconv.ParseInt(uniqueID(i+1), 10, 64)
+		rep.ID = snowflake.ID(id)
+		rep.GuildID = guildID
+		require.Nil(t, db.AddReport(rep))
+		reps[i] = rep
+	}
+	t.Cleanup(func() {
+		for _, rep := range reps {
+			db.DeleteReport(rep.ID)
+		}
+	})
+
+	// Revoked reports are not counted.
+	require.Nil(t, db.RevokeReport(reps[3].ID, models.ReportRevocation{
+		ExecutorID: "mod",
+		Reason:     "mistake",
+		Timestamp:  time.Now(),
+	}))
+
+	cases := []struct {
+		memberID string
+		repType  int
+		expected int
+	}{
+		{"", -1, 3},
+		{"1", -1, 2},
+		{"2", -1, 1},
+		{"3", -1, 0},
+		{"", int(models.TypeWarn), 2},
+		{"1", int(models.TypeWarn), 1},
+		{"1", int(models.TypeBan), 0},
+		// Values must not be interpolated into the query.
+		{`1" OR "1"="1`, -1, 0},
+		{`1' OR '1'='1`, -1, 0},
+	}
+	for _, c := range cases {
+		n, err := db.GetReportsFilteredCount(guildID, c.memberID, c.repType)
+		assert.Nil(t, err)
+		assert.Equal(t, c.expected, n, "member: %s, type: %d", c.memberID, c.repType)
+	}
+
+	_, err := db.GetReportsFilteredCount(`1' OR '1'='1`, "", -1)
+	assert.NotNil(t, err)
+}
+
+// ExpiredReports tests GetExpiredReports.
+func ExpiredReports(t *testing.T, db database.Database) {
+	guildID := uniqueID(0)
+
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+	reps := []models.Report{
+		{Type: models.TypeMute, VictimID: "1", Timeout: &past},
+		{Type: models.TypeMute, VictimID: "2", Timeout: &future},
+		{Type: models.TypeMute, VictimID: "3"},
+		{Type: models.TypeMute, VictimID: "4", Timeout: &past},
+	}
+	for i, rep := range reps {
+		id, _ := strconv.ParseInt(uniqueID(i+1), 10, 64)
+		rep.ID = snowflake.ID(id)
+		rep.GuildID = guildID
+		require.Nil(t, db.AddReport(rep))
+		reps[i] = rep
+	}
+	t.Cleanup(func() {
+		for _, rep := range reps {
+			db.DeleteReport(rep.ID)
+		}
+	})
+
+	// Revoked reports do not expire.
+	require.Nil(t, db.RevokeReport(reps[3].ID, models.ReportRevocation{
+		ExecutorID: "mod",
+		Reason:     "mistake",
+		Timestamp:  time.Now(),
+	}))
+
+	expired, err := db.GetExpiredReports()
+	require.Nil(t, err)
+
+	var ids []snowflake.ID
+	for _, rep := range expired {
+		if rep.GuildID == guildID {
+			ids = append(ids, rep.ID)
+		}
+	}
+	assert.Equal(t, []snowflake.ID{reps[0].ID}, ids)
+}
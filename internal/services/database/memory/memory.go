@@ -0,0 +1,2989 @@
+// Package memory provides an implementation of the
+// Database interface which keeps all data in memory.
+//
+// It is meant to be used in unit tests of services which
+// depend on the database, so that these can be executed
+// without a running database server.
+package memory
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/snowflake"
+	"github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/services/backup/backupmodels"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/util/tag"
+	"github.com/zekroTJA/shinpuru/internal/util/vote"
+	"github.com/zekroTJA/shinpuru/pkg/permissions"
+	"github.com/zekroTJA/shinpuru/pkg/stringutil"
+	"github.com/zekroTJA/shinpuru/pkg/twitchnotify"
+)
+
+// ErrDuplicateEntry is returned when an entry is inserted
+// with a key which already exists, like a primary key
+// constraint violation of the SQL middlewares.
+var ErrDuplicateEntry = errors.New("duplicate entry")
+
+type guildUser struct {
+	guildID string
+	userID  string
+}
+
+type guildChannel struct {
+	guildID   string
+	channelID string
+}
+
+type messageStatsKey struct {
+	guildChannel
+	userID string
+	hour   int64
+}
+
+type rolePermission struct {
+	guildID     string
+	permissions permissions.PermissionArray
+}
+
+type refreshToken struct {
+	token   string
+	expires time.Time
+}
+
+type lockedChannel struct {
+	guildID     string
+	executorID  string
+	permissions string
+}
+
+type karmaSettings struct {
+	state     bool
+	emotesInc string
+	emotesDec string
+	tokens    int
+	penalty   bool
+	limits    models.KarmaLimits
+}
+
+type antiraidSettings struct {
+	state        bool
+	regeneration int
+	burst        int
+	verification bool
+}
+
+type rulesAcceptance struct {
+	version   int
+	timestamp time.Time
+}
+
+// MemoryMiddleware implements the Database interface
+// keeping all data in maps and slices in memory.
+//
+// All methods are safe for concurrent use. Returned
+// values are copies, so modifying them does not alter
+// the stored data.
+type MemoryMiddleware struct {
+	mtx sync.RWMutex
+
+	settings map[string]string
+	guilds   map[string]map[string]string
+	users    map[string]map[string]string
+
+	permissions      map[string]rolePermission
+	disabledCommands map[string][]string
+	voicelogIgnores  map[string][]string
+	guildAPI         map[string]models.GuildAPISettings
+	refreshTokens    map[string]refreshToken
+	apiTokens        map[string]models.APITokenEntry
+
+	reports          map[snowflake.ID]models.Report
+	reportImports    map[guildChannel]snowflake.ID
+	reportTypes      map[string]map[models.ReportType]models.CustomReportType
+	reportEscalation map[string]string
+	modLogRoutes     map[string]map[models.ModLogAction]string
+	unbanRequests    map[snowflake.ID]models.UnbanRequest
+
+	votes          map[string]string
+	twitchNotifies []twitchnotify.DBEntry
+	backups        []backupmodels.Entry
+	backupScheds   map[string]backupmodels.Schedule
+	tags           map[snowflake.ID]tag.Tag
+
+	karma          map[guildUser]int
+	karmaSettings  map[string]karmaSettings
+	karmaBlocklist map[string][]string
+	karmaRules     map[snowflake.ID]models.KarmaRule
+
+	lockedChannels   map[string]lockedChannel
+	antiraidSettings map[string]antiraidSettings
+	antiraidJoinlog  []models.JoinLogEntry
+
+	starboardConfigs map[string]models.StarboardConfig
+	starboardEntries map[string]models.StarboardEntry
+
+	guildlog          map[snowflake.ID]models.GuildLogEntry
+	verificationQueue []models.VerificationQueueEntry
+	birthdays         []models.Birthday
+	roleSelects       []models.RoleSelect
+	autoDeletes       map[guildChannel]models.AutoDeleteConfig
+	autoThreads       map[guildChannel]models.AutoThreadConfig
+	autoPublishes     map[guildChannel]models.AutoPublishConfig
+	prefixless        map[guildChannel]bool
+	stickyMessages    map[guildChannel]models.StickyMessage
+	settingsAudit     []models.SettingsAuditEntry
+	modmailThreads    map[string]models.ModmailThread
+
+	guildRules       map[string]models.GuildRules
+	rulesAcceptances map[guildUser]rulesAcceptance
+
+	giveaways       map[snowflake.ID]models.Giveaway
+	giveawayEntries map[snowflake.ID][]guildUser
+
+	broadcasts          map[snowflake.ID]models.Broadcast
+	broadcastDeliveries map[snowflake.ID][]models.BroadcastDelivery
+
+	trackedInvites map[string]models.TrackedInvite
+	inviteJoins    []models.InviteJoin
+	messageStats   map[messageStatsKey]models.MessageStatsEntry
+
+	reactionReportConfigs map[string]models.ReactionReportConfig
+
+	tempRoles map[snowflake.ID]models.TempRole
+	userNotes map[snowflake.ID]models.UserNote
+
+	wordFilterSettings map[string]models.WordFilterSettings
+	wordFilterEntries  map[string]map[string]models.WordFilterEntry
+
+	inactivitySettings map[string]models.InactivitySettings
+	memberActivities   map[guildUser]models.MemberActivity
+}
+
+var _ database.Database = (*MemoryMiddleware)(nil)
+
+func New() *MemoryMiddleware {
+	return &MemoryMiddleware{
+		settings:            make(map[string]string),
+		guilds:              make(map[string]map[string]string),
+		users:               make(map[string]map[string]string),
+		permissions:         make(map[string]rolePermission),
+		disabledCommands:    make(map[string][]string),
+		voicelogIgnores:     make(map[string][]string),
+		guildAPI:            make(map[string]models.GuildAPISettings),
+		refreshTokens:       make(map[string]refreshToken),
+		apiTokens:           make(map[string]models.APITokenEntry),
+		reports:             make(map[snowflake.ID]models.Report),
+		reportImports:       make(map[guildChannel]snowflake.ID),
+		reportTypes:         make(map[string]map[models.ReportType]models.CustomReportType),
+		reportEscalation:    make(map[string]string),
+		modLogRoutes:        make(map[string]map[models.ModLogAction]string),
+		unbanRequests:       make(map[snowflake.ID]models.UnbanRequest),
+		votes:               make(map[string]string),
+		tags:                make(map[snowflake.ID]tag.Tag),
+		karma:               make(map[guildUser]int),
+		karmaSettings:       make(map[string]karmaSettings),
+		karmaBlocklist:      make(map[string][]string),
+		karmaRules:          make(map[snowflake.ID]models.KarmaRule),
+		lockedChannels:      make(map[string]lockedChannel),
+		antiraidSettings:    make(map[string]antiraidSettings),
+		starboardConfigs:    make(map[string]models.StarboardConfig),
+		starboardEntries:    make(map[string]models.StarboardEntry),
+		guildlog:            make(map[snowflake.ID]models.GuildLogEntry),
+		autoDeletes:         make(map[guildChannel]models.AutoDeleteConfig),
+		autoThreads:         make(map[guildChannel]models.AutoThreadConfig),
+		autoPublishes:       make(map[guildChannel]models.AutoPublishConfig),
+		prefixless:          make(map[guildChannel]bool),
+		stickyMessages:      make(map[guildChannel]models.StickyMessage),
+		modmailThreads:      make(map[string]models.ModmailThread),
+		guildRules:          make(map[string]models.GuildRules),
+		rulesAcceptances:    make(map[guildUser]rulesAcceptance),
+		giveaways:           make(map[snowflake.ID]models.Giveaway),
+		giveawayEntries:     make(map[snowflake.ID][]guildUser),
+		broadcasts:          make(map[snowflake.ID]models.Broadcast),
+		broadcastDeliveries: make(map[snowflake.ID][]models.BroadcastDelivery),
+		trackedInvites:      make(map[string]models.TrackedInvite),
+		messageStats:        make(map[messageStatsKey]models.MessageStatsEntry),
+		backupScheds:        make(map[string]backupmodels.Schedule),
+		tempRoles:           make(map[snowflake.ID]models.TempRole),
+		userNotes:           make(map[snowflake.ID]models.UserNote),
+		wordFilterSettings:  make(map[string]models.WordFilterSettings),
+		wordFilterEntries:   make(map[string]map[string]models.WordFilterEntry),
+		inactivitySettings:  make(map[string]models.InactivitySettings),
+		memberActivities:    make(map[guildUser]models.MemberActivity),
+
+		reactionReportConfigs: make(map[string]models.ReactionReportConfig),
+	}
+}
+
+func (m *MemoryMiddleware) Connect(credentials ...interface{}) error {
+	return nil
+}
+
+func (m *MemoryMiddleware) Close() {}
+
+func (m *MemoryMiddleware) Status() error {
+	return nil
+}
+
+// --- SETTINGS ---
+
+func (m *MemoryMiddleware) GetSetting(setting string) (string, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	value, ok := m.settings[setting]
+	if !ok {
+		return "", database.ErrDatabaseNotFound
+	}
+	return value, nil
+}
+
+func (m *MemoryMiddleware) SetSetting(setting, value string) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.settings[setting] = value
+	return nil
+}
+
+func (m *MemoryMiddleware) GetGuildPermissions(guildID string) (map[string]permissions.PermissionArray, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	results := make(map[string]permissions.PermissionArray)
+	for roleID, p := range m.permissions {
+		if p.guildID == guildID {
+			results[roleID] = append(permissions.PermissionArray{}, p.permissions...)
+		}
+	}
+	return results, nil
+}
+
+func (m *MemoryMiddleware) SetGuildRolePermission(guildID, roleID string, p permissions.PermissionArray) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if len(p) == 0 {
+		delete(m.permissions, roleID)
+		return nil
+	}
+
+	m.permissions[roleID] = rolePermission{
+		guildID:     guildID,
+		permissions: append(permissions.PermissionArray{}, p...),
+	}
+	return nil
+}
+
+func (m *MemoryMiddleware) GetGuildDisabledCommands(guildID string) ([]string, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	return append([]string{}, m.disabledCommands[guildID]...), nil
+}
+
+func (m *MemoryMiddleware) SetGuildDisabledCommand(guildID, command string, disabled bool) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.disabledCommands[guildID] = setListEntry(m.disabledCommands[guildID], command, disabled)
+	return nil
+}
+
+func (m *MemoryMiddleware) GetGuildVoiceLogIgnores(guildID string) ([]string, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	return append([]string{}, m.voicelogIgnores[guildID]...), nil
+}
+
+func (m *MemoryMiddleware) IsGuildVoiceLogIgnored(guildID, channelID string) (bool, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	return contains(m.voicelogIgnores[guildID], channelID), nil
+}
+
+func (m *MemoryMiddleware) SetGuildVoiceLogIngore(guildID, channelID string) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.voicelogIgnores[guildID] = setListEntry(m.voicelogIgnores[guildID], channelID, true)
+	return nil
+}
+
+func (m *MemoryMiddleware) RemoveGuildVoiceLogIgnore(guildID, channelID string) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.voicelogIgnores[guildID] = setListEntry(m.voicelogIgnores[guildID], channelID, false)
+	return nil
+}
+
+func (m *MemoryMiddleware) GetGuildAPI(guildID string) (models.GuildAPISettings, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	settings, ok := m.guildAPI[guildID]
+	if !ok {
+		return models.GuildAPISettings{}, database.ErrDatabaseNotFound
+	}
+	return settings, nil
+}
+
+func (m *MemoryMiddleware) SetGuildAPI(guildID string, settings models.GuildAPISettings) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.guildAPI[guildID] = settings
+	return nil
+}
+
+func (m *MemoryMiddleware) GetGuilds() ([]string, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	guilds := make([]string, 0)
+	for guildID, settings := range m.guilds {
+		if settings["backup"] == "1" {
+			guilds = append(guilds, guildID)
+		}
+	}
+	sort.Strings(guilds)
+	return guilds, nil
+}
+
+// --- USERS ---
+
+func (m *MemoryMiddleware) GetUserByRefreshToken(token string) (string, time.Time, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	for userID, t := range m.refreshTokens {
+		if t.token == token {
+			return userID, t.expires, nil
+		}
+	}
+	return "", time.Time{}, database.ErrDatabaseNotFound
+}
+
+func (m *MemoryMiddleware) SetUserRefreshToken(userID, token string, expires time.Time) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.refreshTokens[userID] = refreshToken{token: token, expires: expires}
+	return nil
+}
+
+func (m *MemoryMiddleware) RevokeUserRefreshToken(userID string) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	delete(m.refreshTokens, userID)
+	return nil
+}
+
+func (m *MemoryMiddleware) CleanupExpiredRefreshTokens() (n int64, err error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	now := time.Now()
+	for userID, t := range m.refreshTokens {
+		if t.expires.Before(now) {
+			delete(m.refreshTokens, userID)
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (m *MemoryMiddleware) SetAPIToken(token models.APITokenEntry) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.apiTokens[token.UserID] = token
+	return nil
+}
+
+func (m *MemoryMiddleware) GetAPIToken(userID string) (models.APITokenEntry, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	token, ok := m.apiTokens[userID]
+	if !ok {
+		return models.APITokenEntry{}, database.ErrDatabaseNotFound
+	}
+	return token, nil
+}
+
+func (m *MemoryMiddleware) AddAPITokenHit(userID string, t time.Time) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	token, ok := m.apiTokens[userID]
+	if !ok {
+		return nil
+	}
+	token.Hits++
+	token.LastAccess = t
+	m.apiTokens[userID] = token
+	return nil
+}
+
+func (m *MemoryMiddleware) DeleteAPIToken(userID string) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	delete(m.apiTokens, userID)
+	return nil
+}
+
+func (m *MemoryMiddleware) FlushUserData(userID string) (res map[string]int, err error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	res = make(map[string]int)
+
+	for id, rep := range m.reports {
+		if rep.ExecutorID == userID && rep.VictimID != userID {
+			rep.ExecutorID = "000000000000000000"
+			m.reports[id] = rep
+			res["reports"]++
+		}
+	}
+
+	for k, v := range m.karma {
+		if k.userID == userID && v >= 0 {
+			delete(m.karma, k)
+			res["karma"]++
+		}
+	}
+
+	// The deletions are executed in the same order as the
+	// user tables of the SQL middlewares, so that the
+	// counts of tables listed twice are the same.
+	deleteJoinlog := func() (n int) {
+		m.antiraidJoinlog, n = filter(m.antiraidJoinlog, func(e models.JoinLogEntry) bool {
+			return e.UserID != userID
+		})
+		return
+	}
+	deleteAPIToken := func() int {
+		_, ok := m.apiTokens[userID]
+		delete(m.apiTokens, userID)
+		return boolToInt(ok)
+	}
+	deleteRefreshToken := func() int {
+		_, ok := m.refreshTokens[userID]
+		delete(m.refreshTokens, userID)
+		return boolToInt(ok)
+	}
+	deleteStarboardEntries := func() (n int) {
+		for id, e := range m.starboardEntries {
+			if e.AuthorID == userID {
+				delete(m.starboardEntries, id)
+				n++
+			}
+		}
+		return
+	}
+	deleteTags := func() (n int) {
+		for id, t := range m.tags {
+			if t.CreatorID == userID {
+				delete(m.tags, id)
+				n++
+			}
+		}
+		return
+	}
+	deleteUnbanRequests := func(match func(models.UnbanRequest) bool) (n int) {
+		for id, r := range m.unbanRequests {
+			if match(r) {
+				delete(m.unbanRequests, id)
+				n++
+			}
+		}
+		return
+	}
+	deleteModmailThreads := func() (n int) {
+		for id, t := range m.modmailThreads {
+			if t.UserID == userID {
+				delete(m.modmailThreads, id)
+				n++
+			}
+		}
+		return
+	}
+	deleteGiveawayEntries := func() (n int) {
+		for id, entries := range m.giveawayEntries {
+			var d int
+			m.giveawayEntries[id], d = filter(entries, func(e guildUser) bool {
+				return e.userID != userID
+			})
+			n += d
+		}
+		return
+	}
+	deleteRulesAcceptances := func() (n int) {
+		for k := range m.rulesAcceptances {
+			if k.userID == userID {
+				delete(m.rulesAcceptances, k)
+				n++
+			}
+		}
+		return
+	}
+	deleteUser := func() int {
+		_, ok := m.users[userID]
+		delete(m.users, userID)
+		return boolToInt(ok)
+	}
+	deleteBirthdays := func() (n int) {
+		m.birthdays, n = filter(m.birthdays, func(b models.Birthday) bool {
+			return b.UserID != userID
+		})
+		return
+	}
+	deleteInviteJoins := func() (n int) {
+		m.inviteJoins, n = filter(m.inviteJoins, func(j models.InviteJoin) bool {
+			return j.UserID != userID
+		})
+		return
+	}
+	deleteMemberActivities := func() (n int) {
+		for k := range m.memberActivities {
+			if k.userID == userID {
+				delete(m.memberActivities, k)
+				n++
+			}
+		}
+		return
+	}
+
+	res["antiraidJoinlog"] = deleteJoinlog()
+	res["apitokens"] = deleteAPIToken()
+	res["refreshTokens"] = deleteRefreshToken()
+	res["starboardEntries"] = deleteStarboardEntries()
+	res["tags"] = deleteTags()
+	res["unbanRequests"] = deleteUnbanRequests(func(r models.UnbanRequest) bool {
+		return r.UserID == userID
+	})
+	res["modmailThreads"] = deleteModmailThreads()
+	res["giveawayEntries"] = deleteGiveawayEntries()
+	res["rulesAcceptances"] = deleteRulesAcceptances()
+	res["unbanRequests"] = deleteUnbanRequests(func(r models.UnbanRequest) bool {
+		return r.ProcessedBy == userID
+	})
+	res["users"] = deleteUser()
+	res["birthdays"] = deleteBirthdays()
+	res["inviteJoins"] = deleteInviteJoins()
+	res["memberActivity"] = deleteMemberActivities()
+
+	return res, nil
+}
+
+// --- REPORTS ---
+
+func (m *MemoryMiddleware) AddReport(rep models.Report) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if _, ok := m.reports[rep.ID]; ok {
+		return ErrDuplicateEntry
+	}
+	m.reports[rep.ID] = copyReport(rep)
+	return nil
+}
+
+func (m *MemoryMiddleware) DeleteReport(id snowflake.ID) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	delete(m.reports, id)
+	return nil
+}
+
+func (m *MemoryMiddleware) RevokeReport(id snowflake.ID, rev models.ReportRevocation) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	rep, ok := m.reports[id]
+	if !ok {
+		return database.ErrDatabaseNotFound
+	}
+	rep.Revocation = &rev
+	m.reports[id] = copyReport(rep)
+	return nil
+}
+
+func (m *MemoryMiddleware) GetReport(id snowflake.ID) (models.Report, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	rep, ok := m.reports[id]
+	if !ok {
+		return models.Report{}, database.ErrDatabaseNotFound
+	}
+	return copyReport(rep), nil
+}
+
+func (m *MemoryMiddleware) GetReportsGuild(guildID string, offset, limit int) ([]models.Report, error) {
+	if limit == 0 {
+		limit = 1000
+	}
+	return m.GetReportsFiltered(guildID, "", -1, offset, limit)
+}
+
+func (m *MemoryMiddleware) GetReportsFiltered(
+	guildID, memberID string,
+	repType models.ReportType,
+	offset, limit int,
+) ([]models.Report, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	results := m.filterReports(guildID, memberID, repType)
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].ID > results[j].ID
+	})
+	results = page(results, offset, limit)
+	if len(results) == 0 {
+		return nil, nil
+	}
+	return results, nil
+}
+
+func (m *MemoryMiddleware) GetReportsGuildCount(guildID string) (int, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	return len(m.filterReports(guildID, "", -1)), nil
+}
+
+func (m *MemoryMiddleware) GetReportsFilteredCount(guildID, memberID string, repType int) (int, error) {
+	if !stringutil.IsInteger(guildID) {
+		return 0, fmt.Errorf("invalid argument type")
+	}
+
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	return len(m.filterReports(guildID, memberID, models.ReportType(repType))), nil
+}
+
+func (m *MemoryMiddleware) GetRevokedReportsGuild(guildID string, offset, limit int) ([]models.Report, error) {
+	if limit == 0 {
+		limit = 1000
+	}
+
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	results := m.filterRevokedReports(guildID)
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Revocation.Timestamp.After(results[j].Revocation.Timestamp)
+	})
+	results = page(results, offset, limit)
+	if len(results) == 0 {
+		return nil, nil
+	}
+	return results, nil
+}
+
+func (m *MemoryMiddleware) GetRevokedReportsGuildCount(guildID string) (int, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	return len(m.filterRevokedReports(guildID)), nil
+}
+
+func (m *MemoryMiddleware) GetExpiredReports() ([]models.Report, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	now := time.Now()
+	results := make([]models.Report, 0)
+	for _, rep := range m.reports {
+		if rep.Revocation == nil && rep.Timeout != nil && !rep.Timeout.After(now) {
+			results = append(results, copyReport(rep))
+		}
+	}
+	return results, nil
+}
+
+func (m *MemoryMiddleware) ExpireReports(ids ...string) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	for _, id := range ids {
+		sf, err := snowflake.ParseString(id)
+		if err != nil {
+			continue
+		}
+		if rep, ok := m.reports[sf]; ok {
+			rep.Timeout = nil
+			m.reports[sf] = rep
+		}
+	}
+	return nil
+}
+
+func (m *MemoryMiddleware) GetReportImport(guildID, externalID string) (snowflake.ID, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	id, ok := m.reportImports[guildChannel{guildID, externalID}]
+	if !ok {
+		return 0, database.ErrDatabaseNotFound
+	}
+	return id, nil
+}
+
+func (m *MemoryMiddleware) AddReportImport(guildID, externalID string, reportID snowflake.ID) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	key := guildChannel{guildID, externalID}
+	if _, ok := m.reportImports[key]; ok {
+		return ErrDuplicateEntry
+	}
+	m.reportImports[key] = reportID
+	return nil
+}
+
+func (m *MemoryMiddleware) GetGuildReportTypes(guildID string) ([]models.CustomReportType, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	res := make([]models.CustomReportType, 0, len(m.reportTypes[guildID]))
+	for _, t := range m.reportTypes[guildID] {
+		res = append(res, t)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].ID < res[j].ID
+	})
+	return res, nil
+}
+
+func (m *MemoryMiddleware) SetGuildReportType(t models.CustomReportType) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	types, ok := m.reportTypes[t.GuildID]
+	if !ok {
+		types = make(map[models.ReportType]models.CustomReportType)
+		m.reportTypes[t.GuildID] = types
+	}
+	types[t.ID] = t
+	return nil
+}
+
+func (m *MemoryMiddleware) DeleteGuildReportType(guildID string, id models.ReportType) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if _, ok := m.reportTypes[guildID][id]; !ok {
+		return database.ErrDatabaseNotFound
+	}
+	delete(m.reportTypes[guildID], id)
+	return nil
+}
+
+func (m *MemoryMiddleware) GetGuildReportEscalation(guildID string) (e models.ReportEscalation, err error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	data, ok := m.reportEscalation[guildID]
+	if !ok {
+		return e, database.ErrDatabaseNotFound
+	}
+
+	err = json.Unmarshal([]byte(data), &e)
+	e.GuildID = guildID
+	return
+}
+
+func (m *MemoryMiddleware) SetGuildReportEscalation(e models.ReportEscalation) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.reportEscalation[e.GuildID] = string(data)
+	return nil
+}
+
+// filterReports returns all reports matching the given
+// guild, member and type which have not been revoked.
+// Empty IDs and negative types match all reports. The
+// lock must be held by the caller.
+func (m *MemoryMiddleware) filterReports(guildID, memberID string, repType models.ReportType) []models.Report {
+	results := make([]models.Report, 0)
+	for _, rep := range m.reports {
+		if rep.Revocation != nil {
+			continue
+		}
+		if guildID != "" && rep.GuildID != guildID {
+			continue
+		}
+		if memberID != "" && rep.VictimID != memberID {
+			continue
+		}
+		if repType > -1 && rep.Type != repType {
+			continue
+		}
+		results = append(results, copyReport(rep))
+	}
+	return results
+}
+
+// filterRevokedReports returns all revoked reports of
+// the given guild. The lock must be held by the caller.
+func (m *MemoryMiddleware) filterRevokedReports(guildID string) []models.Report {
+	results := make([]models.Report, 0)
+	for _, rep := range m.reports {
+		if rep.Revocation != nil && rep.GuildID == guildID {
+			results = append(results, copyReport(rep))
+		}
+	}
+	return results
+}
+
+// --- UNBAN REQUESTS ---
+
+func (m *MemoryMiddleware) GetGuildUnbanRequests(guildID string, limit, offset int) ([]models.UnbanRequest, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	r := make([]models.UnbanRequest, 0)
+	for _, req := range m.unbanRequests {
+		if req.GuildID == guildID {
+			r = append(r, req)
+		}
+	}
+	sort.Slice(r, func(i, j int) bool {
+		return r[i].ID > r[j].ID
+	})
+	return page(r, offset, limit), nil
+}
+
+func (m *MemoryMiddleware) GetGuildUnbanRequestsCount(guildID string, state *models.UnbanRequestState) (n int, err error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	for _, req := range m.unbanRequests {
+		if req.GuildID == guildID && (state == nil || req.Status == *state) {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (m *MemoryMiddleware) GetGuildUserUnbanRequests(userID, guildID string) ([]models.UnbanRequest, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	r := make([]models.UnbanRequest, 0)
+	for _, req := range m.unbanRequests {
+		if req.UserID == userID && (guildID == "" || req.GuildID == guildID) {
+			r = append(r, req)
+		}
+	}
+	sort.Slice(r, func(i, j int) bool {
+		return r[i].ID < r[j].ID
+	})
+	return r, nil
+}
+
+func (m *MemoryMiddleware) GetUnbanRequest(id string) (models.UnbanRequest, error) {
+	sf, err := snowflake.ParseString(id)
+	if err != nil {
+		return models.UnbanRequest{}, database.ErrDatabaseNotFound
+	}
+
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	req, ok := m.unbanRequests[sf]
+	if !ok {
+		return models.UnbanRequest{}, database.ErrDatabaseNotFound
+	}
+	return req, nil
+}
+
+func (m *MemoryMiddleware) AddUnbanRequest(request models.UnbanRequest) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if _, ok := m.unbanRequests[request.ID]; ok {
+		return ErrDuplicateEntry
+	}
+	m.unbanRequests[request.ID] = request
+	return nil
+}
+
+func (m *MemoryMiddleware) UpdateUnbanRequest(request models.UnbanRequest) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	req, ok := m.unbanRequests[request.ID]
+	if !ok {
+		return nil
+	}
+	req.ProcessedBy = request.ProcessedBy
+	req.Status = request.Status
+	req.Processed = request.Processed
+	req.ProcessedMessage = request.ProcessedMessage
+	m.unbanRequests[request.ID] = req
+	return nil
+}
+
+// --- VOTES ---
+
+func (m *MemoryMiddleware) GetVotes() (map[string]vote.Vote, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	results := make(map[string]vote.Vote)
+	for id, rawData := range m.votes {
+		v, err := vote.Unmarshal(rawData)
+		if err != nil {
+			delete(m.votes, id)
+			continue
+		}
+		results[v.ID] = v
+	}
+	return results, nil
+}
+
+func (m *MemoryMiddleware) AddUpdateVote(v vote.Vote) error {
+	rawData, err := v.Marshal()
+	if err != nil {
+		return err
+	}
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.votes[v.ID] = rawData
+	return nil
+}
+
+func (m *MemoryMiddleware) DeleteVote(voteID string) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	delete(m.votes, voteID)
+	return nil
+}
+
+// --- TWITCH NOTIFY ---
+
+func (m *MemoryMiddleware) GetAllTwitchNotifies(twitchUserID string) ([]twitchnotify.DBEntry, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	results := make([]twitchnotify.DBEntry, 0)
+	for _, e := range m.twitchNotifies {
+		if twitchUserID == "" || e.TwitchUserID == twitchUserID {
+			results = append(results, e)
+		}
+	}
+	return results, nil
+}
+
+func (m *MemoryMiddleware) GetTwitchNotify(twitchUserID, guildID string) (twitchnotify.DBEntry, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	for _, e := range m.twitchNotifies {
+		if e.TwitchUserID == twitchUserID && e.GuildID == guildID {
+			return e, nil
+		}
+	}
+	return twitchnotify.DBEntry{TwitchUserID: twitchUserID, GuildID: guildID},
+		database.ErrDatabaseNotFound
+}
+
+func (m *MemoryMiddleware) SetTwitchNotify(twitchNotify twitchnotify.DBEntry) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	for i, e := range m.twitchNotifies {
+		if e.TwitchUserID == twitchNotify.TwitchUserID && e.GuildID == twitchNotify.GuildID {
+			m.twitchNotifies[i] = twitchNotify
+			return nil
+		}
+	}
+	m.twitchNotifies = append(m.twitchNotifies, twitchNotify)
+	return nil
+}
+
+func (m *MemoryMiddleware) DeleteTwitchNotify(twitchUserID, guildID string) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.twitchNotifies, _ = filter(m.twitchNotifies, func(e twitchnotify.DBEntry) bool {
+		return e.TwitchUserID != twitchUserID || e.GuildID != guildID
+	})
+	return nil
+}
+
+// --- BACKUPS ---
+
+func (m *MemoryMiddleware) AddBackup(guildID, fileID string, manual bool) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.backups = append(m.backups, backupmodels.Entry{
+		GuildID:   guildID,
+		Timestamp: time.Unix(time.Now().Unix(), 0),
+		FileID:    fileID,
+		Manual:    manual,
+	})
+	return nil
+}
+
+func (m *MemoryMiddleware) DeleteBackup(guildID, fileID string) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.backups, _ = filter(m.backups, func(e backupmodels.Entry) bool {
+		return e.GuildID != guildID || e.FileID != fileID
+	})
+	return nil
+}
+
+func (m *MemoryMiddleware) GetBackups(guildID string) ([]backupmodels.Entry, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	backups := make([]backupmodels.Entry, 0)
+	for _, e := range m.backups {
+		if e.GuildID == guildID {
+			backups = append(backups, e)
+		}
+	}
+	return backups, nil
+}
+
+func (m *MemoryMiddleware) GetBackupSchedule(guildID string) (backupmodels.Schedule, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	s, ok := m.backupScheds[guildID]
+	if !ok {
+		return backupmodels.Schedule{}, database.ErrDatabaseNotFound
+	}
+	return s, nil
+}
+
+func (m *MemoryMiddleware) GetBackupSchedules() ([]backupmodels.Schedule, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	res := make([]backupmodels.Schedule, 0, len(m.backupScheds))
+	for _, s := range m.backupScheds {
+		res = append(res, s)
+	}
+	return res, nil
+}
+
+func (m *MemoryMiddleware) SetBackupSchedule(s backupmodels.Schedule) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.backupScheds[s.GuildID] = s
+	return nil
+}
+
+func (m *MemoryMiddleware) DeleteBackupSchedule(guildID string) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	delete(m.backupScheds, guildID)
+	return nil
+}
+
+// --- TAGS ---
+
+func (m *MemoryMiddleware) AddTag(t tag.Tag) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if _, ok := m.tags[t.ID]; ok {
+		return ErrDuplicateEntry
+	}
+	m.tags[t.ID] = truncateTag(t)
+	return nil
+}
+
+func (m *MemoryMiddleware) EditTag(t tag.Tag) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if _, ok := m.tags[t.ID]; ok {
+		m.tags[t.ID] = truncateTag(t)
+	}
+	return nil
+}
+
+func (m *MemoryMiddleware) GetTagByID(id snowflake.ID) (tag.Tag, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	t, ok := m.tags[id]
+	if !ok {
+		return tag.Tag{}, database.ErrDatabaseNotFound
+	}
+	return t, nil
+}
+
+func (m *MemoryMiddleware) GetTagByIdent(ident string, guildID string) (tag.Tag, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	for _, t := range m.tags {
+		if t.Ident == ident && t.GuildID == guildID {
+			return t, nil
+		}
+	}
+	return tag.Tag{}, database.ErrDatabaseNotFound
+}
+
+func (m *MemoryMiddleware) GetGuildTags(guildID string) ([]tag.Tag, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	tags := make([]tag.Tag, 0)
+	for _, t := range m.tags {
+		if t.GuildID == guildID {
+			tags = append(tags, t)
+		}
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		return tags[i].ID < tags[j].ID
+	})
+	return tags, nil
+}
+
+func (m *MemoryMiddleware) DeleteTag(id snowflake.ID) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	delete(m.tags, id)
+	return nil
+}
+
+// --- KARMA ---
+
+func (m *MemoryMiddleware) GetKarma(userID, guildID string) (int, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	v, ok := m.karma[guildUser{guildID, userID}]
+	if !ok {
+		return 0, database.ErrDatabaseNotFound
+	}
+	return v, nil
+}
+
+func (m *MemoryMiddleware) GetKarmaSum(userID string) (sum int, err error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	for k, v := range m.karma {
+		if k.userID == userID {
+			sum += v
+		}
+	}
+	return sum, nil
+}
+
+func (m *MemoryMiddleware) GetKarmaGuild(guildID string, limit int) ([]models.GuildKarma, error) {
+	if limit < 1 {
+		limit = 1000
+	}
+
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	res := make([]models.GuildKarma, 0)
+	for k, v := range m.karma {
+		if k.guildID == guildID {
+			res = append(res, models.GuildKarma{UserID: k.userID, GuildID: guildID, Value: v})
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Value == res[j].Value {
+			return res[i].UserID < res[j].UserID
+		}
+		return res[i].Value > res[j].Value
+	})
+	return page(res, 0, limit), nil
+}
+
+func (m *MemoryMiddleware) SetKarma(userID, guildID string, val int) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.karma[guildUser{guildID, userID}] = val
+	return nil
+}
+
+func (m *MemoryMiddleware) ResetKarmaGuild(guildID string) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	for k := range m.karma {
+		if k.guildID == guildID {
+			delete(m.karma, k)
+		}
+	}
+	return nil
+}
+
+func (m *MemoryMiddleware) UpdateKarma(userID, guildID string, diff int) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.karma[guildUser{guildID, userID}] += diff
+	return nil
+}
+
+func (m *MemoryMiddleware) SetKarmaState(guildID string, state bool) error {
+	m.updateKarmaSettings(guildID, func(s *karmaSettings) { s.state = state })
+	return nil
+}
+
+func (m *MemoryMiddleware) GetKarmaState(guildID string) (bool, error) {
+	s, err := m.getKarmaSettings(guildID)
+	return s.state, err
+}
+
+func (m *MemoryMiddleware) SetKarmaEmotes(guildID, emotesInc, emotesDec string) error {
+	m.updateKarmaSettings(guildID, func(s *karmaSettings) {
+		s.emotesInc = emotesInc
+		s.emotesDec = emotesDec
+	})
+	return nil
+}
+
+func (m *MemoryMiddleware) GetKarmaEmotes(guildID string) (emotesInc, emotesDec string, err error) {
+	s, err := m.getKarmaSettings(guildID)
+	return s.emotesInc, s.emotesDec, err
+}
+
+func (m *MemoryMiddleware) SetKarmaTokens(guildID string, tokens int) error {
+	m.updateKarmaSettings(guildID, func(s *karmaSettings) { s.tokens = tokens })
+	return nil
+}
+
+func (m *MemoryMiddleware) GetKarmaTokens(guildID string) (int, error) {
+	s, err := m.getKarmaSettings(guildID)
+	return s.tokens, err
+}
+
+func (m *MemoryMiddleware) SetKarmaPenalty(guildID string, state bool) error {
+	m.updateKarmaSettings(guildID, func(s *karmaSettings) { s.penalty = state })
+	return nil
+}
+
+func (m *MemoryMiddleware) GetKarmaPenalty(guildID string) (bool, error) {
+	s, err := m.getKarmaSettings(guildID)
+	return s.penalty, err
+}
+
+func (m *MemoryMiddleware) SetKarmaLimits(guildID string, limits models.KarmaLimits) error {
+	m.updateKarmaSettings(guildID, func(s *karmaSettings) { s.limits = limits })
+	return nil
+}
+
+func (m *MemoryMiddleware) GetKarmaLimits(guildID string) (models.KarmaLimits, error) {
+	s, err := m.getKarmaSettings(guildID)
+	return s.limits, err
+}
+
+func (m *MemoryMiddleware) GetKarmaBlockList(guildID string) ([]string, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	return append([]string{}, m.karmaBlocklist[guildID]...), nil
+}
+
+func (m *MemoryMiddleware) IsKarmaBlockListed(guildID, userID string) (bool, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	return contains(m.karmaBlocklist[guildID], userID), nil
+}
+
+func (m *MemoryMiddleware) AddKarmaBlockList(guildID, userID string) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.karmaBlocklist[guildID] = setListEntry(m.karmaBlocklist[guildID], userID, true)
+	return nil
+}
+
+func (m *MemoryMiddleware) RemoveKarmaBlockList(guildID, userID string) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.karmaBlocklist[guildID] = setListEntry(m.karmaBlocklist[guildID], userID, false)
+	return nil
+}
+
+func (m *MemoryMiddleware) GetKarmaRules(guildID string) ([]models.KarmaRule, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	res := make([]models.KarmaRule, 0)
+	for _, r := range m.karmaRules {
+		if r.GuildID == guildID {
+			res = append(res, r)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].ID < res[j].ID
+	})
+	return res, nil
+}
+
+func (m *MemoryMiddleware) CheckKarmaRule(guildID, checksum string) (bool, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	for _, r := range m.karmaRules {
+		if r.GuildID == guildID && r.Checksum == checksum {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *MemoryMiddleware) AddOrUpdateKarmaRule(rule models.KarmaRule) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if r, ok := m.karmaRules[rule.ID]; ok && r.GuildID != rule.GuildID {
+		return nil
+	}
+	m.karmaRules[rule.ID] = rule
+	return nil
+}
+
+func (m *MemoryMiddleware) RemoveKarmaRule(guildID string, id snowflake.ID) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if r, ok := m.karmaRules[id]; ok && r.GuildID == guildID {
+		delete(m.karmaRules, id)
+	}
+	return nil
+}
+
+func (m *MemoryMiddleware) getKarmaSettings(guildID string) (karmaSettings, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	s, ok := m.karmaSettings[guildID]
+	if !ok {
+		return karmaSettings{}, database.ErrDatabaseNotFound
+	}
+	return s, nil
+}
+
+// updateKarmaSettings applies update to the karma settings
+// of the guild. If no settings exist, they are created
+// with the same default values as in the SQL schema.
+func (m *MemoryMiddleware) updateKarmaSettings(guildID string, update func(*karmaSettings)) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	s, ok := m.karmaSettings[guildID]
+	if !ok {
+		s = karmaSettings{state: true, tokens: 1, limits: models.DefaultKarmaLimits}
+	}
+	update(&s)
+	m.karmaSettings[guildID] = s
+}
+
+// --- CHAN LOCK ---
+
+func (m *MemoryMiddleware) SetLockChan(chanID, guildID, executorID, permissions string) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if _, ok := m.lockedChannels[chanID]; ok {
+		return ErrDuplicateEntry
+	}
+	m.lockedChannels[chanID] = lockedChannel{
+		guildID:     guildID,
+		executorID:  executorID,
+		permissions: permissions,
+	}
+	return nil
+}
+
+func (m *MemoryMiddleware) GetLockChan(chanID string) (guildID, executorID, permissions string, err error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	c, ok := m.lockedChannels[chanID]
+	if !ok {
+		err = database.ErrDatabaseNotFound
+		return
+	}
+	return c.guildID, c.executorID, c.permissions, nil
+}
+
+func (m *MemoryMiddleware) GetLockChannels(guildID string) ([]string, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	chanIDs := make([]string, 0)
+	for id, c := range m.lockedChannels {
+		if c.guildID == guildID {
+			chanIDs = append(chanIDs, id)
+		}
+	}
+	sort.Strings(chanIDs)
+	return chanIDs, nil
+}
+
+func (m *MemoryMiddleware) DeleteLockChan(chanID string) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	delete(m.lockedChannels, chanID)
+	return nil
+}
+
+// --- ANTI RAID ---
+
+func (m *MemoryMiddleware) SetAntiraidState(guildID string, state bool) error {
+	m.updateAntiraidSettings(guildID, func(s *antiraidSettings) { s.state = state })
+	return nil
+}
+
+func (m *MemoryMiddleware) GetAntiraidState(guildID string) (bool, error) {
+	s, err := m.getAntiraidSettings(guildID)
+	return s.state, err
+}
+
+func (m *MemoryMiddleware) SetAntiraidRegeneration(guildID string, periodSecs int) error {
+	m.updateAntiraidSettings(guildID, func(s *antiraidSettings) { s.regeneration = periodSecs })
+	return nil
+}
+
+func (m *MemoryMiddleware) GetAntiraidRegeneration(guildID string) (int, error) {
+	s, err := m.getAntiraidSettings(guildID)
+	return s.regeneration, err
+}
+
+func (m *MemoryMiddleware) SetAntiraidBurst(guildID string, burst int) error {
+	m.updateAntiraidSettings(guildID, func(s *antiraidSettings) { s.burst = burst })
+	return nil
+}
+
+func (m *MemoryMiddleware) GetAntiraidBurst(guildID string) (int, error) {
+	s, err := m.getAntiraidSettings(guildID)
+	return s.burst, err
+}
+
+func (m *MemoryMiddleware) SetAntiraidVerification(guildID string, state bool) error {
+	m.updateAntiraidSettings(guildID, func(s *antiraidSettings) { s.verification = state })
+	return nil
+}
+
+func (m *MemoryMiddleware) GetAntiraidVerification(guildID string) (bool, error) {
+	s, err := m.getAntiraidSettings(guildID)
+	return s.verification, err
+}
+
+func (m *MemoryMiddleware) AddToAntiraidJoinList(guildID, userID, userTag string, accountCreated time.Time) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.antiraidJoinlog = append(m.antiraidJoinlog, models.JoinLogEntry{
+		GuildID:   guildID,
+		UserID:    userID,
+		Tag:       userTag,
+		Created:   accountCreated,
+		Timestamp: time.Now(),
+	})
+	return nil
+}
+
+func (m *MemoryMiddleware) GetAntiraidJoinList(guildID string) (res []models.JoinLogEntry, err error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	for _, e := range m.antiraidJoinlog {
+		if guildID == "" || e.GuildID == guildID {
+			res = append(res, e)
+		}
+	}
+	return res, nil
+}
+
+func (m *MemoryMiddleware) FlushAntiraidJoinList(guildID string) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.antiraidJoinlog, _ = filter(m.antiraidJoinlog, func(e models.JoinLogEntry) bool {
+		return e.GuildID != guildID
+	})
+	return nil
+}
+
+func (m *MemoryMiddleware) RemoveAntiraidJoinList(guildID, userID string) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.antiraidJoinlog, _ = filter(m.antiraidJoinlog, func(e models.JoinLogEntry) bool {
+		return e.GuildID != guildID || e.UserID != userID
+	})
+	return nil
+}
+
+func (m *MemoryMiddleware) getAntiraidSettings(guildID string) (antiraidSettings, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	s, ok := m.antiraidSettings[guildID]
+	if !ok {
+		return antiraidSettings{}, database.ErrDatabaseNotFound
+	}
+	return s, nil
+}
+
+// updateAntiraidSettings applies update to the antiraid
+// settings of the guild. If no settings exist, they are
+// created with the same default values as in the SQL
+// schema.
+func (m *MemoryMiddleware) updateAntiraidSettings(guildID string, update func(*antiraidSettings)) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	s, ok := m.antiraidSettings[guildID]
+	if !ok {
+		s = antiraidSettings{state: true}
+	}
+	update(&s)
+	m.antiraidSettings[guildID] = s
+}
+
+// --- STARBOARD ---
+
+func (m *MemoryMiddleware) SetStarboardConfig(config models.StarboardConfig) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.starboardConfigs[config.GuildID] = config
+	return nil
+}
+
+func (m *MemoryMiddleware) GetStarboardConfig(guildID string) (models.StarboardConfig, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	config, ok := m.starboardConfigs[guildID]
+	if !ok {
+		return models.StarboardConfig{}, database.ErrDatabaseNotFound
+	}
+	return config, nil
+}
+
+func (m *MemoryMiddleware) SetStarboardEntry(e models.StarboardEntry) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if curr, ok := m.starboardEntries[e.MessageID]; ok {
+		curr.Score = e.Score
+		curr.Deleted = e.Deleted
+		curr.StarboardID = e.StarboardID
+		m.starboardEntries[e.MessageID] = curr
+		return nil
+	}
+
+	e.MediaURLs = append([]string{}, e.MediaURLs...)
+	m.starboardEntries[e.MessageID] = e
+	return nil
+}
+
+func (m *MemoryMiddleware) RemoveStarboardEntry(msgID string) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	delete(m.starboardEntries, msgID)
+	return nil
+}
+
+func (m *MemoryMiddleware) GetStarboardEntries(
+	guildID string,
+	sortBy models.StarboardSortBy,
+	limit, offset int,
+) ([]models.StarboardEntry, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	res := make([]models.StarboardEntry, 0)
+	for _, e := range m.starboardEntries {
+		if e.GuildID == guildID {
+			e.MediaURLs = append([]string{}, e.MediaURLs...)
+			res = append(res, e)
+		}
+	}
+
+	switch sortBy {
+	case models.StarboardSortByLatest:
+		sort.Slice(res, func(i, j int) bool {
+			return res[i].StarboardID > res[j].StarboardID
+		})
+	case models.StarboardSortByMostRated:
+		sort.Slice(res, func(i, j int) bool {
+			return res[i].Score > res[j].Score
+		})
+	}
+
+	return page(res, offset, limit), nil
+}
+
+func (m *MemoryMiddleware) GetStarboardEntriesCount(guildID string) (n int, err error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	for _, e := range m.starboardEntries {
+		if e.GuildID == guildID {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (m *MemoryMiddleware) GetStarboardEntry(messageID string) (models.StarboardEntry, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	e, ok := m.starboardEntries[messageID]
+	if !ok {
+		return models.StarboardEntry{}, database.ErrDatabaseNotFound
+	}
+	e.MediaURLs = append([]string{}, e.MediaURLs...)
+	return e, nil
+}
+
+// --- GUILDLOG ---
+
+func (m *MemoryMiddleware) GetGuildLogEntries(
+	guildID string,
+	offset, limit int,
+	severity models.GuildLogSeverity,
+	ascending bool,
+) ([]models.GuildLogEntry, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	res := m.filterGuildLog(guildID, severity)
+	sort.Slice(res, func(i, j int) bool {
+		if ascending {
+			return res[i].Timestamp.Before(res[j].Timestamp)
+		}
+		return res[i].Timestamp.After(res[j].Timestamp)
+	})
+	return page(res, offset, limit), nil
+}
+
+func (m *MemoryMiddleware) GetGuildLogEntriesCount(guildID string, severity models.GuildLogSeverity) (int, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	return len(m.filterGuildLog(guildID, severity)), nil
+}
+
+func (m *MemoryMiddleware) AddGuildLogEntry(entry models.GuildLogEntry) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if _, ok := m.guildlog[entry.ID]; ok {
+		return ErrDuplicateEntry
+	}
+	m.guildlog[entry.ID] = entry
+	return nil
+}
+
+func (m *MemoryMiddleware) DeleteLogEntry(guildID string, id snowflake.ID) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if e, ok := m.guildlog[id]; ok && e.GuildID == guildID {
+		delete(m.guildlog, id)
+	}
+	return nil
+}
+
+func (m *MemoryMiddleware) DeleteLogEntries(guildID string) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	for id, e := range m.guildlog {
+		if e.GuildID == guildID {
+			delete(m.guildlog, id)
+		}
+	}
+	return nil
+}
+
+// filterGuildLog returns all log entries of the guild
+// with the given severity. A negative severity matches
+// all entries. The lock must be held by the caller.
+func (m *MemoryMiddleware) filterGuildLog(guildID string, severity models.GuildLogSeverity) []models.GuildLogEntry {
+	res := make([]models.GuildLogEntry, 0)
+	for _, e := range m.guildlog {
+		if e.GuildID == guildID && (severity < 0 || e.Severity == severity) {
+			res = append(res, e)
+		}
+	}
+	return res
+}
+
+// --- FUNCTIONALITIES ---
+
+func (m *MemoryMiddleware) FlushGuildData(guildID string) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	isGuild := func(id string) bool { return id == guildID }
+
+	m.antiraidJoinlog, _ = filter(m.antiraidJoinlog, func(e models.JoinLogEntry) bool { return !isGuild(e.GuildID) })
+	delete(m.antiraidSettings, guildID)
+	m.backups, _ = filter(m.backups, func(e backupmodels.Entry) bool { return !isGuild(e.GuildID) })
+	deleteWhere(m.lockedChannels, func(c lockedChannel) bool { return isGuild(c.guildID) })
+	delete(m.guildAPI, guildID)
+	deleteWhere(m.guildlog, func(e models.GuildLogEntry) bool { return isGuild(e.GuildID) })
+	delete(m.guilds, guildID)
+	for k := range m.karma {
+		if isGuild(k.guildID) {
+			delete(m.karma, k)
+		}
+	}
+	delete(m.karmaBlocklist, guildID)
+	deleteWhere(m.karmaRules, func(r models.KarmaRule) bool { return isGuild(r.GuildID) })
+	delete(m.karmaSettings, guildID)
+	deleteWhere(m.permissions, func(p rolePermission) bool { return isGuild(p.guildID) })
+	deleteWhere(m.reports, func(r models.Report) bool { return isGuild(r.GuildID) })
+	delete(m.starboardConfigs, guildID)
+	deleteWhere(m.starboardEntries, func(e models.StarboardEntry) bool { return isGuild(e.GuildID) })
+	deleteWhere(m.tags, func(t tag.Tag) bool { return isGuild(t.GuildID) })
+	m.twitchNotifies, _ = filter(m.twitchNotifies, func(e twitchnotify.DBEntry) bool { return !isGuild(e.GuildID) })
+	deleteWhere(m.unbanRequests, func(r models.UnbanRequest) bool { return isGuild(r.GuildID) })
+	m.verificationQueue, _ = filter(m.verificationQueue, func(e models.VerificationQueueEntry) bool { return !isGuild(e.GuildID) })
+	delete(m.voicelogIgnores, guildID)
+	m.birthdays, _ = filter(m.birthdays, func(b models.Birthday) bool { return !isGuild(b.GuildID) })
+	for k := range m.autoDeletes {
+		if isGuild(k.guildID) {
+			delete(m.autoDeletes, k)
+		}
+	}
+	for k := range m.autoThreads {
+		if isGuild(k.guildID) {
+			delete(m.autoThreads, k)
+		}
+	}
+	for k := range m.autoPublishes {
+		if isGuild(k.guildID) {
+			delete(m.autoPublishes, k)
+		}
+	}
+	for k := range m.prefixless {
+		if isGuild(k.guildID) {
+			delete(m.prefixless, k)
+		}
+	}
+	for k := range m.stickyMessages {
+		if isGuild(k.guildID) {
+			delete(m.stickyMessages, k)
+		}
+	}
+	m.settingsAudit, _ = filter(m.settingsAudit, func(e models.SettingsAuditEntry) bool { return !isGuild(e.GuildID) })
+	for k := range m.reportImports {
+		if isGuild(k.guildID) {
+			delete(m.reportImports, k)
+		}
+	}
+	deleteWhere(m.modmailThreads, func(t models.ModmailThread) bool { return isGuild(t.GuildID) })
+	delete(m.guildRules, guildID)
+	for k := range m.rulesAcceptances {
+		if isGuild(k.guildID) {
+			delete(m.rulesAcceptances, k)
+		}
+	}
+	delete(m.disabledCommands, guildID)
+	deleteWhere(m.giveaways, func(g models.Giveaway) bool { return isGuild(g.GuildID) })
+	for id, entries := range m.giveawayEntries {
+		m.giveawayEntries[id], _ = filter(entries, func(e guildUser) bool { return !isGuild(e.guildID) })
+	}
+	delete(m.reportTypes, guildID)
+	delete(m.reportEscalation, guildID)
+	delete(m.modLogRoutes, guildID)
+	for id, deliveries := range m.broadcastDeliveries {
+		m.broadcastDeliveries[id], _ = filter(deliveries, func(d models.BroadcastDelivery) bool { return !isGuild(d.GuildID) })
+	}
+	deleteWhere(m.trackedInvites, func(inv models.TrackedInvite) bool { return isGuild(inv.GuildID) })
+	m.inviteJoins, _ = filter(m.inviteJoins, func(j models.InviteJoin) bool { return !isGuild(j.GuildID) })
+	deleteWhere(m.messageStats, func(e models.MessageStatsEntry) bool { return isGuild(e.GuildID) })
+	delete(m.reactionReportConfigs, guildID)
+	delete(m.backupScheds, guildID)
+	deleteWhere(m.tempRoles, func(t models.TempRole) bool { return isGuild(t.GuildID) })
+	deleteWhere(m.userNotes, func(n models.UserNote) bool { return isGuild(n.GuildID) })
+	delete(m.wordFilterSettings, guildID)
+	delete(m.wordFilterEntries, guildID)
+	delete(m.inactivitySettings, guildID)
+	deleteWhere(m.memberActivities, func(a models.MemberActivity) bool { return isGuild(a.GuildID) })
+
+	return nil
+}
+
+// --- VERIFICATION QUEUE ---
+
+func (m *MemoryMiddleware) GetVerificationQueue(guildID, userID string) (res []models.VerificationQueueEntry, err error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	for _, e := range m.verificationQueue {
+		if (guildID == "" || e.GuildID == guildID) && (userID == "" || e.UserID == userID) {
+			res = append(res, e)
+		}
+	}
+	return res, nil
+}
+
+func (m *MemoryMiddleware) FlushVerificationQueue(guildID string) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.verificationQueue, _ = filter(m.verificationQueue, func(e models.VerificationQueueEntry) bool {
+		return guildID != "" && e.GuildID != guildID
+	})
+	return nil
+}
+
+func (m *MemoryMiddleware) AddVerificationQueue(e models.VerificationQueueEntry) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	for i, curr := range m.verificationQueue {
+		if curr.GuildID == e.GuildID && curr.UserID == e.UserID {
+			m.verificationQueue[i].Timestamp = e.Timestamp
+			return nil
+		}
+	}
+	m.verificationQueue = append(m.verificationQueue, e)
+	return nil
+}
+
+func (m *MemoryMiddleware) RemoveVerificationQueue(guildID, userID string) (bool, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	var n int
+	m.verificationQueue, n = filter(m.verificationQueue, func(e models.VerificationQueueEntry) bool {
+		return e.GuildID != guildID || e.UserID != userID
+	})
+	return n > 0, nil
+}
+
+// --- BIRTHDAYS ---
+
+func (m *MemoryMiddleware) GetBirthdays(guildID string) (bd []models.Birthday, err error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	for _, b := range m.birthdays {
+		if guildID == "" || b.GuildID == guildID {
+			bd = append(bd, b)
+		}
+	}
+	return bd, nil
+}
+
+func (m *MemoryMiddleware) SetBirthday(bd models.Birthday) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	for i, b := range m.birthdays {
+		if b.GuildID == bd.GuildID && b.UserID == bd.UserID {
+			m.birthdays[i] = bd
+			return nil
+		}
+	}
+	m.birthdays = append(m.birthdays, bd)
+	return nil
+}
+
+func (m *MemoryMiddleware) DeleteBirthday(guildID, userID string) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.birthdays, _ = filter(m.birthdays, func(b models.Birthday) bool {
+		return b.GuildID != guildID || b.UserID != userID
+	})
+	return nil
+}
+
+// --- ROLE SELECT ---
+
+func (m *MemoryMiddleware) AddRoleSelects(v []models.RoleSelect) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	for _, rs := range v {
+		if !contains(m.roleSelects, rs) {
+			m.roleSelects = append(m.roleSelects, rs)
+		}
+	}
+	return nil
+}
+
+func (m *MemoryMiddleware) GetRoleSelects() ([]models.RoleSelect, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	if len(m.roleSelects) == 0 {
+		return nil, nil
+	}
+	return append([]models.RoleSelect{}, m.roleSelects...), nil
+}
+
+func (m *MemoryMiddleware) RemoveRoleSelect(guildID, channelID, messageID string) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.roleSelects, _ = filter(m.roleSelects, func(rs models.RoleSelect) bool {
+		return rs.GuildID != guildID || rs.ChannelID != channelID || rs.MessageID != messageID
+	})
+	return nil
+}
+
+// --- AUTO DELETE ---
+
+func (m *MemoryMiddleware) GetAutoDelete(guildID, channelID string) (models.AutoDeleteConfig, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	// Channel specific configs take precedence over the
+	// guild wide default config with an empty channelID.
+	if cfg, ok := m.autoDeletes[guildChannel{guildID, channelID}]; ok {
+		return cfg, nil
+	}
+	if cfg, ok := m.autoDeletes[guildChannel{guildID, ""}]; ok {
+		return cfg, nil
+	}
+	return models.AutoDeleteConfig{}, database.ErrDatabaseNotFound
+}
+
+func (m *MemoryMiddleware) GetAutoDeletes(guildID string) (res []models.AutoDeleteConfig, err error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	for k, cfg := range m.autoDeletes {
+		if k.guildID == guildID {
+			res = append(res, cfg)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].ChannelID < res[j].ChannelID
+	})
+	return res, nil
+}
+
+func (m *MemoryMiddleware) SetAutoDelete(cfg models.AutoDeleteConfig) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.autoDeletes[guildChannel{cfg.GuildID, cfg.ChannelID}] = cfg
+	return nil
+}
+
+func (m *MemoryMiddleware) RemoveAutoDelete(guildID, channelID string) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	delete(m.autoDeletes, guildChannel{guildID, channelID})
+	return nil
+}
+
+// --- AUTO THREADS ---
+
+func (m *MemoryMiddleware) GetAutoThread(guildID, channelID string) (models.AutoThreadConfig, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	cfg, ok := m.autoThreads[guildChannel{guildID, channelID}]
+	if !ok {
+		return models.AutoThreadConfig{}, database.ErrDatabaseNotFound
+	}
+	return cfg, nil
+}
+
+func (m *MemoryMiddleware) GetAutoThreads(guildID string) (res []models.AutoThreadConfig, err error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	res = make([]models.AutoThreadConfig, 0)
+	for k, cfg := range m.autoThreads {
+		if k.guildID == guildID {
+			res = append(res, cfg)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].ChannelID < res[j].ChannelID
+	})
+	return res, nil
+}
+
+func (m *MemoryMiddleware) SetAutoThread(cfg models.AutoThreadConfig) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.autoThreads[guildChannel{cfg.GuildID, cfg.ChannelID}] = cfg
+	return nil
+}
+
+func (m *MemoryMiddleware) RemoveAutoThread(guildID, channelID string) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	delete(m.autoThreads, guildChannel{guildID, channelID})
+	return nil
+}
+
+// --- AUTO PUBLISH ---
+
+func (m *MemoryMiddleware) GetAutoPublish(guildID, channelID string) (models.AutoPublishConfig, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	cfg, ok := m.autoPublishes[guildChannel{guildID, channelID}]
+	if !ok {
+		return models.AutoPublishConfig{}, database.ErrDatabaseNotFound
+	}
+	return cfg, nil
+}
+
+func (m *MemoryMiddleware) GetAutoPublishes(guildID string) (res []models.AutoPublishConfig, err error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	res = make([]models.AutoPublishConfig, 0)
+	for k, cfg := range m.autoPublishes {
+		if k.guildID == guildID {
+			res = append(res, cfg)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].ChannelID < res[j].ChannelID
+	})
+	return res, nil
+}
+
+func (m *MemoryMiddleware) SetAutoPublish(cfg models.AutoPublishConfig) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.autoPublishes[guildChannel{cfg.GuildID, cfg.ChannelID}] = cfg
+	return nil
+}
+
+func (m *MemoryMiddleware) RemoveAutoPublish(guildID, channelID string) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	delete(m.autoPublishes, guildChannel{guildID, channelID})
+	return nil
+}
+
+// --- PREFIXLESS CHANNELS ---
+
+func (m *MemoryMiddleware) GetPrefixlessChannels(guildID string) (res []string, err error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	res = make([]string, 0)
+	for k := range m.prefixless {
+		if k.guildID == guildID {
+			res = append(res, k.channelID)
+		}
+	}
+	sort.Strings(res)
+	return res, nil
+}
+
+func (m *MemoryMiddleware) AddPrefixlessChannel(guildID, channelID string) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.prefixless[guildChannel{guildID, channelID}] = true
+	return nil
+}
+
+func (m *MemoryMiddleware) RemovePrefixlessChannel(guildID, channelID string) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	delete(m.prefixless, guildChannel{guildID, channelID})
+	return nil
+}
+
+// --- STICKY MESSAGES ---
+
+func (m *MemoryMiddleware) GetStickyMessage(guildID, channelID string) (models.StickyMessage, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	sm, ok := m.stickyMessages[guildChannel{guildID, channelID}]
+	if !ok {
+		return models.StickyMessage{}, database.ErrDatabaseNotFound
+	}
+	return sm, nil
+}
+
+func (m *MemoryMiddleware) GetStickyMessages(guildID string) (res []models.StickyMessage, err error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	res = make([]models.StickyMessage, 0)
+	for k, sm := range m.stickyMessages {
+		if k.guildID == guildID {
+			res = append(res, sm)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].ChannelID < res[j].ChannelID
+	})
+	return res, nil
+}
+
+func (m *MemoryMiddleware) SetStickyMessage(sm models.StickyMessage) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.stickyMessages[guildChannel{sm.GuildID, sm.ChannelID}] = sm
+	return nil
+}
+
+func (m *MemoryMiddleware) RemoveStickyMessage(guildID, channelID string) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	delete(m.stickyMessages, guildChannel{guildID, channelID})
+	return nil
+}
+
+// --- SETTINGS AUDIT ---
+
+func (m *MemoryMiddleware) AddSettingsAuditEntry(e models.SettingsAuditEntry) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	for _, curr := range m.settingsAudit {
+		if curr.ID == e.ID {
+			return ErrDuplicateEntry
+		}
+	}
+	m.settingsAudit = append(m.settingsAudit, e)
+	return nil
+}
+
+func (m *MemoryMiddleware) GetSettingsAuditEntries(guildID string, offset, limit int) ([]models.SettingsAuditEntry, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	res := make([]models.SettingsAuditEntry, 0)
+	for _, e := range m.settingsAudit {
+		if e.GuildID == guildID {
+			res = append(res, e)
+		}
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].Timestamp.After(res[j].Timestamp)
+	})
+	return page(res, offset, limit), nil
+}
+
+func (m *MemoryMiddleware) GetSettingsAuditEntriesCount(guildID string) (n int, err error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	for _, e := range m.settingsAudit {
+		if e.GuildID == guildID {
+			n++
+		}
+	}
+	return n, nil
+}
+
+// --- MODMAIL ---
+
+func (m *MemoryMiddleware) GetModmailThread(threadID string) (models.ModmailThread, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	t, ok := m.modmailThreads[threadID]
+	if !ok {
+		return models.ModmailThread{}, database.ErrDatabaseNotFound
+	}
+	return t, nil
+}
+
+func (m *MemoryMiddleware) GetModmailThreadsByUser(userID string) ([]models.ModmailThread, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	res := make([]models.ModmailThread, 0)
+	for _, t := range m.modmailThreads {
+		if t.UserID == userID {
+			res = append(res, t)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].ThreadID < res[j].ThreadID
+	})
+	return res, nil
+}
+
+func (m *MemoryMiddleware) SetModmailThread(t models.ModmailThread) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if _, ok := m.modmailThreads[t.ThreadID]; ok {
+		return ErrDuplicateEntry
+	}
+	m.modmailThreads[t.ThreadID] = t
+	return nil
+}
+
+func (m *MemoryMiddleware) RemoveModmailThread(threadID string) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	delete(m.modmailThreads, threadID)
+	return nil
+}
+
+// --- RULES ---
+
+func (m *MemoryMiddleware) GetGuildRules(guildID string) (models.GuildRules, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	r, ok := m.guildRules[guildID]
+	if !ok {
+		return models.GuildRules{}, database.ErrDatabaseNotFound
+	}
+	return r, nil
+}
+
+func (m *MemoryMiddleware) GetAllGuildRules() ([]models.GuildRules, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	res := make([]models.GuildRules, 0, len(m.guildRules))
+	for _, r := range m.guildRules {
+		res = append(res, r)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].GuildID < res[j].GuildID
+	})
+	return res, nil
+}
+
+func (m *MemoryMiddleware) SetGuildRules(r models.GuildRules) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.guildRules[r.GuildID] = r
+	return nil
+}
+
+func (m *MemoryMiddleware) GetRulesAcceptance(guildID, userID string) (int, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	a, ok := m.rulesAcceptances[guildUser{guildID, userID}]
+	if !ok {
+		return 0, database.ErrDatabaseNotFound
+	}
+	return a.version, nil
+}
+
+func (m *MemoryMiddleware) SetRulesAcceptance(guildID, userID string, version int) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.rulesAcceptances[guildUser{guildID, userID}] = rulesAcceptance{
+		version:   version,
+		timestamp: time.Now(),
+	}
+	return nil
+}
+
+// --- GIVEAWAYS ---
+
+func (m *MemoryMiddleware) GetGiveaway(id snowflake.ID) (models.Giveaway, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	g, ok := m.giveaways[id]
+	if !ok {
+		return models.Giveaway{}, database.ErrDatabaseNotFound
+	}
+	return copyGiveaway(g), nil
+}
+
+func (m *MemoryMiddleware) GetGiveawayByMessage(messageID string) (models.Giveaway, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	for _, g := range m.giveaways {
+		if g.MessageID == messageID {
+			return copyGiveaway(g), nil
+		}
+	}
+	return models.Giveaway{}, database.ErrDatabaseNotFound
+}
+
+func (m *MemoryMiddleware) GetGiveaways(guildID string, activeOnly bool) ([]models.Giveaway, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	res := make([]models.Giveaway, 0)
+	for _, g := range m.giveaways {
+		if guildID != "" && g.GuildID != guildID {
+			continue
+		}
+		if activeOnly && g.Ended {
+			continue
+		}
+		res = append(res, copyGiveaway(g))
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Expires.After(res[j].Expires)
+	})
+	return res, nil
+}
+
+func (m *MemoryMiddleware) SetGiveaway(g models.Giveaway) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if curr, ok := m.giveaways[g.ID]; ok {
+		g.GuildID = curr.GuildID
+		g.CreatorID = curr.CreatorID
+	}
+	m.giveaways[g.ID] = copyGiveaway(g)
+	return nil
+}
+
+func (m *MemoryMiddleware) EndGiveaway(id snowflake.ID, winnerIDs []string) (bool, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	g, ok := m.giveaways[id]
+	if !ok || g.Ended {
+		return false, nil
+	}
+	g.Ended = true
+	g.WinnerIDs = append([]string{}, winnerIDs...)
+	m.giveaways[id] = g
+	return true, nil
+}
+
+func (m *MemoryMiddleware) AddGiveawayEntry(id snowflake.ID, guildID, userID string) (bool, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	for _, e := range m.giveawayEntries[id] {
+		if e.userID == userID {
+			return false, nil
+		}
+	}
+	m.giveawayEntries[id] = append(m.giveawayEntries[id], guildUser{guildID, userID})
+	return true, nil
+}
+
+func (m *MemoryMiddleware) GetGiveawayEntries(id snowflake.ID) ([]string, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	res := make([]string, 0, len(m.giveawayEntries[id]))
+	for _, e := range m.giveawayEntries[id] {
+		res = append(res, e.userID)
+	}
+	return res, nil
+}
+
+// --- BROADCASTS ---
+
+func (m *MemoryMiddleware) AddBroadcast(b models.Broadcast) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if _, ok := m.broadcasts[b.ID]; ok {
+		return ErrDuplicateEntry
+	}
+	m.broadcasts[b.ID] = b
+	return nil
+}
+
+func (m *MemoryMiddleware) SetBroadcastFinished(id snowflake.ID) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if b, ok := m.broadcasts[id]; ok {
+		b.Finished = true
+		m.broadcasts[id] = b
+	}
+	return nil
+}
+
+func (m *MemoryMiddleware) GetBroadcast(id snowflake.ID) (models.Broadcast, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	b, ok := m.broadcasts[id]
+	if !ok {
+		return models.Broadcast{}, database.ErrDatabaseNotFound
+	}
+	return b, nil
+}
+
+func (m *MemoryMiddleware) GetBroadcasts(unfinishedOnly bool) ([]models.Broadcast, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	res := make([]models.Broadcast, 0)
+	for _, b := range m.broadcasts {
+		if !unfinishedOnly || !b.Finished {
+			res = append(res, b)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Created.After(res[j].Created)
+	})
+	return res, nil
+}
+
+func (m *MemoryMiddleware) AddBroadcastDelivery(d models.BroadcastDelivery) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	deliveries := m.broadcastDeliveries[d.BroadcastID]
+	for i, curr := range deliveries {
+		if curr.GuildID == d.GuildID {
+			deliveries[i] = d
+			return nil
+		}
+	}
+	m.broadcastDeliveries[d.BroadcastID] = append(deliveries, d)
+	return nil
+}
+
+func (m *MemoryMiddleware) GetBroadcastDeliveries(id snowflake.ID) ([]models.BroadcastDelivery, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	return append([]models.BroadcastDelivery{}, m.broadcastDeliveries[id]...), nil
+}
+
+// --- TEMPORARY ROLES ---
+
+func (m *MemoryMiddleware) GetTempRole(id snowflake.ID) (models.TempRole, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	t, ok := m.tempRoles[id]
+	if !ok {
+		return models.TempRole{}, database.ErrDatabaseNotFound
+	}
+	return t, nil
+}
+
+func (m *MemoryMiddleware) GetTempRoles(guildID, userID string) ([]models.TempRole, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	res := make([]models.TempRole, 0)
+	for _, t := range m.tempRoles {
+		if guildID != "" && t.GuildID != guildID {
+			continue
+		}
+		if userID != "" && t.UserID != userID {
+			continue
+		}
+		res = append(res, t)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Expires.Before(res[j].Expires)
+	})
+	return res, nil
+}
+
+func (m *MemoryMiddleware) SetTempRole(t models.TempRole) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if curr, ok := m.tempRoles[t.ID]; ok {
+		curr.Expires = t.Expires
+		curr.Attempts = t.Attempts
+		t = curr
+	}
+	m.tempRoles[t.ID] = t
+	return nil
+}
+
+func (m *MemoryMiddleware) RemoveTempRole(id snowflake.ID) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	delete(m.tempRoles, id)
+	return nil
+}
+
+// --- USER NOTES ---
+
+func (m *MemoryMiddleware) AddUserNote(n models.UserNote) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.userNotes[n.ID] = n
+	return nil
+}
+
+func (m *MemoryMiddleware) GetUserNotes(guildID, userID string) ([]models.UserNote, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	res := make([]models.UserNote, 0)
+	for _, n := range m.userNotes {
+		if n.GuildID == guildID && n.UserID == userID {
+			res = append(res, n)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Created.After(res[j].Created)
+	})
+	return res, nil
+}
+
+func (m *MemoryMiddleware) DeleteUserNote(guildID string, id snowflake.ID) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	n, ok := m.userNotes[id]
+	if !ok || n.GuildID != guildID {
+		return database.ErrDatabaseNotFound
+	}
+	delete(m.userNotes, id)
+	return nil
+}
+
+// --- WORD FILTER ---
+
+func (m *MemoryMiddleware) GetWordFilterSettings(guildID string) (models.WordFilterSettings, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	s, ok := m.wordFilterSettings[guildID]
+	if !ok {
+		return models.WordFilterSettings{}, database.ErrDatabaseNotFound
+	}
+	s.ExemptRoles = append([]string{}, s.ExemptRoles...)
+	s.ExemptChannels = append([]string{}, s.ExemptChannels...)
+	return s, nil
+}
+
+func (m *MemoryMiddleware) SetWordFilterSettings(s models.WordFilterSettings) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	s.ExemptRoles = append([]string{}, s.ExemptRoles...)
+	s.ExemptChannels = append([]string{}, s.ExemptChannels...)
+	m.wordFilterSettings[s.GuildID] = s
+	return nil
+}
+
+func (m *MemoryMiddleware) GetWordFilterEntries(guildID string) ([]models.WordFilterEntry, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	res := make([]models.WordFilterEntry, 0, len(m.wordFilterEntries[guildID]))
+	for _, e := range m.wordFilterEntries[guildID] {
+		res = append(res, e)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Pattern < res[j].Pattern
+	})
+	return res, nil
+}
+
+func (m *MemoryMiddleware) SetWordFilterEntry(e models.WordFilterEntry) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	entries, ok := m.wordFilterEntries[e.GuildID]
+	if !ok {
+		entries = make(map[string]models.WordFilterEntry)
+		m.wordFilterEntries[e.GuildID] = entries
+	}
+	entries[e.Pattern] = e
+	return nil
+}
+
+func (m *MemoryMiddleware) RemoveWordFilterEntry(guildID, pattern string) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	delete(m.wordFilterEntries[guildID], pattern)
+	return nil
+}
+
+// --- INVITE TRACKING ---
+
+func (m *MemoryMiddleware) AddTrackedInvite(inv models.TrackedInvite) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if _, ok := m.trackedInvites[inv.Code]; ok {
+		return ErrDuplicateEntry
+	}
+	m.trackedInvites[inv.Code] = inv
+	return nil
+}
+
+func (m *MemoryMiddleware) GetTrackedInvites(guildID string) ([]models.TrackedInvite, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	res := make([]models.TrackedInvite, 0)
+	for _, inv := range m.trackedInvites {
+		if inv.GuildID == guildID {
+			res = append(res, inv)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Created.After(res[j].Created)
+	})
+	return res, nil
+}
+
+func (m *MemoryMiddleware) AddInviteJoin(j models.InviteJoin) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.inviteJoins = append(m.inviteJoins, j)
+	return nil
+}
+
+func (m *MemoryMiddleware) GetInviteJoinStats(guildID string) ([]models.InviteJoinStats, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	type statsKey struct {
+		code   string
+		source models.InviteJoinSource
+	}
+
+	index := make(map[statsKey]int)
+	res := make([]models.InviteJoinStats, 0)
+	for _, j := range m.inviteJoins {
+		if j.GuildID != guildID {
+			continue
+		}
+
+		key := statsKey{j.Code, j.Source}
+		i, ok := index[key]
+		if !ok {
+			s := models.InviteJoinStats{Code: j.Code, Source: j.Source}
+			if inv, ok := m.trackedInvites[j.Code]; ok && inv.GuildID == guildID {
+				s.Label = inv.Label
+				s.CreatorID = inv.CreatorID
+			}
+			i = len(res)
+			index[key] = i
+			res = append(res, s)
+		}
+		res[i].Joins++
+		if j.InviterID > res[i].InviterID {
+			res[i].InviterID = j.InviterID
+		}
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].Joins > res[j].Joins
+	})
+	return res, nil
+}
+
+// --- MESSAGE STATS ---
+
+func (m *MemoryMiddleware) AddMessageStats(entries []models.MessageStatsEntry) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	for _, e := range entries {
+		key := messageStatsKey{guildChannel{e.GuildID, e.ChannelID}, e.UserID, e.Hour.Unix()}
+		if curr, ok := m.messageStats[key]; ok {
+			e.Count += curr.Count
+		}
+		m.messageStats[key] = e
+	}
+	return nil
+}
+
+func (m *MemoryMiddleware) GetMessageStats(guildID string, since time.Time) ([]models.MessageStatsEntry, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	res := make([]models.MessageStatsEntry, 0)
+	for _, e := range m.messageStats {
+		if e.GuildID == guildID && !e.Hour.Before(since) {
+			res = append(res, e)
+		}
+	}
+	return res, nil
+}
+
+func (m *MemoryMiddleware) DeleteMessageStats(guildID string) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	deleteWhere(m.messageStats, func(e models.MessageStatsEntry) bool { return e.GuildID == guildID })
+	return nil
+}
+
+func (m *MemoryMiddleware) CleanupMessageStats(before time.Time) (int64, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	var n int64
+	for k, e := range m.messageStats {
+		if e.Hour.Before(before) {
+			delete(m.messageStats, k)
+			n++
+		}
+	}
+	return n, nil
+}
+
+// --- REACTION REPORTS ---
+
+func (m *MemoryMiddleware) GetReactionReportConfig(guildID string) (models.ReactionReportConfig, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	cfg, ok := m.reactionReportConfigs[guildID]
+	if !ok {
+		return models.ReactionReportConfig{}, database.ErrDatabaseNotFound
+	}
+	return cfg, nil
+}
+
+func (m *MemoryMiddleware) SetReactionReportConfig(cfg models.ReactionReportConfig) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.reactionReportConfigs[cfg.GuildID] = cfg
+	return nil
+}
+
+// --- INACTIVITY ---
+
+func (m *MemoryMiddleware) GetInactivitySettings(guildID string) (models.InactivitySettings, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	s, ok := m.inactivitySettings[guildID]
+	if !ok {
+		return models.InactivitySettings{}, database.ErrDatabaseNotFound
+	}
+	return s, nil
+}
+
+func (m *MemoryMiddleware) GetEnabledInactivitySettings() ([]models.InactivitySettings, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	res := make([]models.InactivitySettings, 0)
+	for _, s := range m.inactivitySettings {
+		if s.Enabled {
+			res = append(res, s)
+		}
+	}
+	return res, nil
+}
+
+func (m *MemoryMiddleware) SetInactivitySettings(s models.InactivitySettings) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.inactivitySettings[s.GuildID] = s
+	return nil
+}
+
+func (m *MemoryMiddleware) GetMemberActivities(guildID string) ([]models.MemberActivity, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	res := make([]models.MemberActivity, 0)
+	for _, a := range m.memberActivities {
+		if a.GuildID == guildID {
+			res = append(res, copyMemberActivity(a))
+		}
+	}
+	return res, nil
+}
+
+func (m *MemoryMiddleware) SetMemberActivities(entries []models.MemberActivity) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	for _, a := range entries {
+		m.memberActivities[guildUser{a.GuildID, a.UserID}] = copyMemberActivity(a)
+	}
+	return nil
+}
+
+func (m *MemoryMiddleware) SetMemberActivityWarned(a models.MemberActivity) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	key := guildUser{a.GuildID, a.UserID}
+	if curr, ok := m.memberActivities[key]; ok {
+		a.LastActive = curr.LastActive
+	}
+	m.memberActivities[key] = copyMemberActivity(a)
+	return nil
+}
+
+func (m *MemoryMiddleware) DeleteMemberActivities(guildID string) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	deleteWhere(m.memberActivities, func(a models.MemberActivity) bool { return a.GuildID == guildID })
+	return nil
+}
+
+// --- HELPERS ---
+
+// page returns the slice of s specified by offset and
+// limit like the LIMIT and OFFSET clauses in SQL.
+func page[T any](s []T, offset, limit int) []T {
+	if offset < 0 {
+		offset = 0
+	}
+	if offset >= len(s) {
+		return s[:0]
+	}
+	s = s[offset:]
+	if limit >= 0 && limit < len(s) {
+		s = s[:limit]
+	}
+	return s
+}
+
+// filter returns all elements of s for which keep
+// returns true and the number of removed elements.
+func filter[T any](s []T, keep func(T) bool) ([]T, int) {
+	res := s[:0]
+	for _, v := range s {
+		if keep(v) {
+			res = append(res, v)
+		}
+	}
+	n := len(s) - len(res)
+	return res, n
+}
+
+// deleteWhere removes all entries from m for which
+// match returns true.
+func deleteWhere[K comparable, V any](m map[K]V, match func(V) bool) {
+	for k, v := range m {
+		if match(v) {
+			delete(m, k)
+		}
+	}
+}
+
+func contains[T comparable](s []T, v T) bool {
+	for _, e := range s {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
+
+// setListEntry adds v to s if set is true and it is not
+// contained yet. Otherwise, v is removed from s.
+func setListEntry[T comparable](s []T, v T, set bool) []T {
+	if set {
+		if !contains(s, v) {
+			s = append(s, v)
+		}
+		return s
+	}
+	s, _ = filter(s, func(e T) bool { return e != v })
+	return s
+}
+
+func boolToInt(v bool) int {
+	if v {
+		return 1
+	}
+	return 0
+}
+
+func copyReport(rep models.Report) models.Report {
+	if rep.Timeout != nil {
+		timeout := *rep.Timeout
+		rep.Timeout = &timeout
+	}
+	if rep.Revocation != nil {
+		rev := *rep.Revocation
+		rep.Revocation = &rev
+	}
+	return rep
+}
+
+func copyGiveaway(g models.Giveaway) models.Giveaway {
+	g.WinnerIDs = append([]string{}, g.WinnerIDs...)
+	return g
+}
+
+func copyMemberActivity(a models.MemberActivity) models.MemberActivity {
+	if a.Warned != nil {
+		warned := *a.Warned
+		a.Warned = &warned
+	}
+	return a
+}
+
+// truncateTag truncates the timestamps of the tag to
+// seconds like they are stored by the SQL middlewares.
+func truncateTag(t tag.Tag) tag.Tag {
+	t.Created = time.Unix(t.Created.Unix(), 0)
+	t.LastEdit = time.Unix(t.LastEdit.Unix(), 0)
+	return t
+}
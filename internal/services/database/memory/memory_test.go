@@ -0,0 +1,304 @@
+package memory
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/snowflake"
+	"github.com/stretchr/testify/assert"
+	"github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/services/database/dbtest"
+	"github.com/zekroTJA/shinpuru/pkg/twitchnotify"
+)
+
+func TestGuildSettings(t *testing.T) {
+	db := New()
+
+	_, err := db.GetGuildPrefix("guild")
+	assert.ErrorIs(t, err, database.ErrDatabaseNotFound)
+
+	assert.Nil(t, db.SetGuildPrefix("guild", "!"))
+	prefix, err := db.GetGuildPrefix("guild")
+	assert.Nil(t, err)
+	assert.Equal(t, "!", prefix)
+
+	assert.Nil(t, db.SetGuildBackup("guild", true))
+	guilds, err := db.GetGuilds()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"guild"}, guilds)
+}
+
+func TestKarma(t *testing.T) {
+	db := New()
+
+	_, err := db.GetKarma("user", "guild")
+	assert.ErrorIs(t, err, database.ErrDatabaseNotFound)
+
+	assert.Nil(t, db.UpdateKarma("user", "guild", 2))
+	assert.Nil(t, db.UpdateKarma("user", "guild", 3))
+	assert.Nil(t, db.SetKarma("other", "guild", 10))
+	assert.Nil(t, db.SetKarma("user", "other", -1))
+
+	v, err := db.GetKarma("user", "guild")
+	assert.Nil(t, err)
+	assert.Equal(t, 5, v)
+
+	sum, err := db.GetKarmaSum("user")
+	assert.Nil(t, err)
+	assert.Equal(t, 4, sum)
+
+	top, err := db.GetKarmaGuild("guild", 1)
+	assert.Nil(t, err)
+	assert.Equal(t, []models.GuildKarma{{UserID: "other", GuildID: "guild", Value: 10}}, top)
+
+	assert.Nil(t, db.ResetKarmaGuild("guild"))
+	top, err = db.GetKarmaGuild("guild", 0)
+	assert.Nil(t, err)
+	assert.Empty(t, top)
+	v, err = db.GetKarma("user", "other")
+	assert.Nil(t, err)
+	assert.Equal(t, -1, v)
+
+	// Settings are created with the schema defaults.
+	assert.Nil(t, db.SetKarmaPenalty("guild", true))
+	state, err := db.GetKarmaState("guild")
+	assert.Nil(t, err)
+	assert.True(t, state)
+	tokens, err := db.GetKarmaTokens("guild")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, tokens)
+	limits, err := db.GetKarmaLimits("guild")
+	assert.Nil(t, err)
+	assert.Equal(t, models.DefaultKarmaLimits, limits)
+
+	_, err = db.GetKarmaLimits("other")
+	assert.ErrorIs(t, err, database.ErrDatabaseNotFound)
+}
+
+func TestReports(t *testing.T) {
+	db := New()
+
+	for i := 1; i <= 5; i++ {
+		typ := models.TypeWarn
+		if i%2 == 0 {
+			typ = models.TypeKick
+		}
+		err := db.AddReport(models.Report{
+			ID:       snowflake.ID(i),
+			Type:     typ,
+			GuildID:  "1",
+			VictimID: "victim",
+		})
+		assert.Nil(t, err)
+	}
+
+	assert.ErrorIs(t, db.AddReport(models.Report{ID: 1}), ErrDuplicateEntry)
+
+	reps, err := db.GetReportsGuild("1", 1, 2)
+	assert.Nil(t, err)
+	assert.Len(t, reps, 2)
+	assert.Equal(t, snowflake.ID(4), reps[0].ID)
+	assert.Equal(t, snowflake.ID(3), reps[1].ID)
+
+	n, err := db.GetReportsFilteredCount("1", "", int(models.TypeKick))
+	assert.Nil(t, err)
+	assert.Equal(t, 2, n)
+
+	assert.Nil(t, db.DeleteReport(1))
+	_, err = db.GetReport(1)
+	assert.ErrorIs(t, err, database.ErrDatabaseNotFound)
+
+	rev := models.ReportRevocation{ExecutorID: "mod", Reason: "mistake", Timestamp: time.Now()}
+	assert.Nil(t, db.RevokeReport(2, rev))
+	assert.ErrorIs(t, db.RevokeReport(1, rev), database.ErrDatabaseNotFound)
+
+	n, err = db.GetReportsGuildCount("1")
+	assert.Nil(t, err)
+	assert.Equal(t, 3, n)
+
+	rep, err := db.GetReport(2)
+	assert.Nil(t, err)
+	assert.Equal(t, &rev, rep.Revocation)
+
+	reps, err = db.GetRevokedReportsGuild("1", 0, 0)
+	assert.Nil(t, err)
+	assert.Len(t, reps, 1)
+	assert.Equal(t, snowflake.ID(2), reps[0].ID)
+
+	n, err = db.GetRevokedReportsGuildCount("1")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, n)
+}
+
+func TestReportsFilteredCount(t *testing.T) {
+	dbtest.ReportsFilteredCount(t, New())
+}
+
+func TestExpiredReports(t *testing.T) {
+	dbtest.ExpiredReports(t, New())
+}
+
+func TestUserNotes(t *testing.T) {
+	db := New()
+
+	now := time.Now()
+	assert.Nil(t, db.AddUserNote(models.UserNote{ID: 1, GuildID: "1", UserID: "user", Created: now.Add(-time.Hour)}))
+	assert.Nil(t, db.AddUserNote(models.UserNote{ID: 2, GuildID: "1", UserID: "user", Created: now}))
+	assert.Nil(t, db.AddUserNote(models.UserNote{ID: 3, GuildID: "2", UserID: "user", Created: now}))
+
+	notes, err := db.GetUserNotes("1", "user")
+	assert.Nil(t, err)
+	assert.Len(t, notes, 2)
+	assert.Equal(t, snowflake.ID(2), notes[0].ID)
+	assert.Equal(t, snowflake.ID(1), notes[1].ID)
+
+	// Notes can only be deleted from the guild they belong to.
+	assert.ErrorIs(t, db.DeleteUserNote("1", 3), database.ErrDatabaseNotFound)
+	assert.Nil(t, db.DeleteUserNote("2", 3))
+
+	notes, err = db.GetUserNotes("2", "user")
+	assert.Nil(t, err)
+	assert.Len(t, notes, 0)
+}
+
+func TestFlushUserData(t *testing.T) {
+	db := New()
+
+	assert.Nil(t, db.AddReport(models.Report{ID: 1, ExecutorID: "user", VictimID: "other"}))
+	assert.Nil(t, db.AddReport(models.Report{ID: 2, ExecutorID: "user", VictimID: "user"}))
+	assert.Nil(t, db.SetKarma("user", "guild", 3))
+	assert.Nil(t, db.SetKarma("user", "other", -3))
+	assert.Nil(t, db.SetUserOTAEnabled("user", true))
+
+	res, err := db.FlushUserData("user")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, res["reports"])
+	assert.Equal(t, 1, res["karma"])
+	assert.Equal(t, 1, res["users"])
+
+	rep, err := db.GetReport(1)
+	assert.Nil(t, err)
+	assert.Equal(t, "000000000000000000", rep.ExecutorID)
+
+	_, err = db.GetKarma("user", "guild")
+	assert.ErrorIs(t, err, database.ErrDatabaseNotFound)
+	v, err := db.GetKarma("user", "other")
+	assert.Nil(t, err)
+	assert.Equal(t, -3, v)
+}
+
+func TestAutoDelete(t *testing.T) {
+	db := New()
+
+	_, err := db.GetAutoDelete("guild", "channel")
+	assert.ErrorIs(t, err, database.ErrDatabaseNotFound)
+
+	guildCfg := models.AutoDeleteConfig{GuildID: "guild", Delay: time.Minute}
+	chanCfg := models.AutoDeleteConfig{GuildID: "guild", ChannelID: "channel", ErrorDelay: time.Second}
+	assert.Nil(t, db.SetAutoDelete(guildCfg))
+
+	cfg, err := db.GetAutoDelete("guild", "channel")
+	assert.Nil(t, err)
+	assert.Equal(t, guildCfg, cfg)
+
+	assert.Nil(t, db.SetAutoDelete(chanCfg))
+	cfg, err = db.GetAutoDelete("guild", "channel")
+	assert.Nil(t, err)
+	assert.Equal(t, chanCfg, cfg)
+
+	cfgs, err := db.GetAutoDeletes("guild")
+	assert.Nil(t, err)
+	assert.Equal(t, []models.AutoDeleteConfig{guildCfg, chanCfg}, cfgs)
+
+	assert.Nil(t, db.RemoveAutoDelete("guild", ""))
+	cfgs, err = db.GetAutoDeletes("guild")
+	assert.Nil(t, err)
+	assert.Equal(t, []models.AutoDeleteConfig{chanCfg}, cfgs)
+}
+
+func TestTwitchNotifies(t *testing.T) {
+	db := New()
+
+	entry := twitchnotify.DBEntry{
+		GuildID:      "guild",
+		ChannelID:    "channel",
+		TwitchUserID: "twitch",
+		Template:     "{streamer} is live",
+		RoleID:       "role",
+	}
+	assert.Nil(t, db.SetTwitchNotify(entry))
+
+	entry.ChannelID = "other"
+	entry.Plain = true
+	assert.Nil(t, db.SetTwitchNotify(entry))
+
+	res, err := db.GetTwitchNotify("twitch", "guild")
+	assert.Nil(t, err)
+	assert.Equal(t, entry, res)
+
+	all, err := db.GetAllTwitchNotifies("")
+	assert.Nil(t, err)
+	assert.Equal(t, []twitchnotify.DBEntry{entry}, all)
+}
+
+func TestAPITokenHits(t *testing.T) {
+	db := New()
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Hits of missing tokens are ignored.
+	assert.Nil(t, db.AddAPITokenHit("user", now))
+	_, err := db.GetAPIToken("user")
+	assert.ErrorIs(t, err, database.ErrDatabaseNotFound)
+
+	assert.Nil(t, db.SetAPIToken(models.APITokenEntry{UserID: "user", Hits: 1}))
+	assert.Nil(t, db.AddAPITokenHit("user", now))
+	assert.Nil(t, db.AddAPITokenHit("user", now.Add(time.Minute)))
+
+	token, err := db.GetAPIToken("user")
+	assert.Nil(t, err)
+	assert.Equal(t, 3, token.Hits)
+	assert.Equal(t, now.Add(time.Minute), token.LastAccess)
+}
+
+func TestEndGiveaway(t *testing.T) {
+	db := New()
+	id := snowflake.ID(1)
+
+	ok, err := db.EndGiveaway(id, nil)
+	assert.Nil(t, err)
+	assert.False(t, ok)
+
+	assert.Nil(t, db.SetGiveaway(models.Giveaway{ID: id, GuildID: "guild", Winners: 1}))
+
+	ok, err = db.EndGiveaway(id, []string{"user"})
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	// Giveaways can only be ended once.
+	ok, err = db.EndGiveaway(id, []string{"other"})
+	assert.Nil(t, err)
+	assert.False(t, ok)
+
+	g, err := db.GetGiveaway(id)
+	assert.Nil(t, err)
+	assert.True(t, g.Ended)
+	assert.Equal(t, []string{"user"}, g.WinnerIDs)
+}
+
+func TestGuildEmbedColor(t *testing.T) {
+	db := New()
+
+	_, err := db.GetGuildEmbedColor("guild")
+	assert.ErrorIs(t, err, database.ErrDatabaseNotFound)
+
+	// Black is a valid color and not treated as unset.
+	assert.Nil(t, db.SetGuildEmbedColor("guild", 0))
+	clr, err := db.GetGuildEmbedColor("guild")
+	assert.Nil(t, err)
+	assert.Equal(t, 0, clr)
+
+	assert.Nil(t, db.SetGuildEmbedColor("guild", -1))
+	_, err = db.GetGuildEmbedColor("guild")
+	assert.ErrorIs(t, err, database.ErrDatabaseNotFound)
+}
@@ -0,0 +1,555 @@
+package memory
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+)
+
+// getGuildSetting returns the value of the given key of
+// the guild's settings. Like a missing table row in the
+// SQL middlewares, ErrDatabaseNotFound is returned if no
+// setting has been set for the guild yet.
+func (m *MemoryMiddleware) getGuildSetting(guildID, key string) (string, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	settings, ok := m.guilds[guildID]
+	if !ok {
+		return "", database.ErrDatabaseNotFound
+	}
+	return settings[key], nil
+}
+
+func (m *MemoryMiddleware) setGuildSetting(guildID, key string, value string) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	settings, ok := m.guilds[guildID]
+	if !ok {
+		settings = make(map[string]string)
+		m.guilds[guildID] = settings
+	}
+	settings[key] = value
+	return nil
+}
+
+func (m *MemoryMiddleware) getUserSetting(userID, key string) (string, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	settings, ok := m.users[userID]
+	if !ok {
+		return "", database.ErrDatabaseNotFound
+	}
+	return settings[key], nil
+}
+
+func (m *MemoryMiddleware) setUserSetting(userID, key string, value string) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	settings, ok := m.users[userID]
+	if !ok {
+		settings = make(map[string]string)
+		m.users[userID] = settings
+	}
+	settings[key] = value
+	return nil
+}
+
+func (m *MemoryMiddleware) GetGuildPrefix(guildID string) (string, error) {
+	val, err := m.getGuildSetting(guildID, "prefix")
+	return val, err
+}
+
+func (m *MemoryMiddleware) SetGuildPrefix(guildID, newPrefix string) error {
+	return m.setGuildSetting(guildID, "prefix", newPrefix)
+}
+
+func (m *MemoryMiddleware) GetGuildAutoRole(guildID string) ([]string, error) {
+	val, err := m.getGuildSetting(guildID, "autorole")
+	if val == "" {
+		return []string{}, err
+	}
+	return strings.Split(val, ";"), err
+}
+
+func (m *MemoryMiddleware) SetGuildAutoRole(guildID string, autoRoleIDs []string) error {
+	return m.setGuildSetting(guildID, "autorole", strings.Join(autoRoleIDs, ";"))
+}
+
+func (m *MemoryMiddleware) GetGuildAutoVC(guildID string) ([]string, error) {
+	val, err := m.getGuildSetting(guildID, "autovc")
+	if val == "" {
+		return []string{}, err
+	}
+	return strings.Split(val, ";"), err
+}
+
+func (m *MemoryMiddleware) SetGuildAutoVC(guildID string, autoVCIDs []string) error {
+	return m.setGuildSetting(guildID, "autovc", strings.Join(autoVCIDs, ";"))
+}
+
+func (m *MemoryMiddleware) GetGuildModLog(guildID string) (string, error) {
+	val, err := m.getGuildSetting(guildID, "modlogchanID")
+	return val, err
+}
+
+func (m *MemoryMiddleware) SetGuildModLog(guildID, chanID string) error {
+	return m.setGuildSetting(guildID, "modlogchanID", chanID)
+}
+
+func (m *MemoryMiddleware) GetGuildModLogFormat(guildID string) (models.ModLogFormat, error) {
+	val, err := m.getGuildSetting(guildID, "modlogFormat")
+	return models.ModLogFormat(val), err
+}
+
+func (m *MemoryMiddleware) SetGuildModLogFormat(guildID string, format models.ModLogFormat) error {
+	return m.setGuildSetting(guildID, "modlogFormat", string(format))
+}
+
+func (m *MemoryMiddleware) GetGuildModLogRoute(guildID string, action models.ModLogAction) (string, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	channelID, ok := m.modLogRoutes[guildID][action]
+	if !ok {
+		return "", database.ErrDatabaseNotFound
+	}
+	return channelID, nil
+}
+
+func (m *MemoryMiddleware) GetGuildModLogRoutes(guildID string) (map[models.ModLogAction]string, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	res := make(map[models.ModLogAction]string)
+	for action, channelID := range m.modLogRoutes[guildID] {
+		res[action] = channelID
+	}
+	return res, nil
+}
+
+func (m *MemoryMiddleware) SetGuildModLogRoute(guildID string, action models.ModLogAction, channelID string) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	routes, ok := m.modLogRoutes[guildID]
+	if !ok {
+		routes = make(map[models.ModLogAction]string)
+		m.modLogRoutes[guildID] = routes
+	}
+	routes[action] = channelID
+	return nil
+}
+
+func (m *MemoryMiddleware) RemoveGuildModLogRoute(guildID string, action models.ModLogAction) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	delete(m.modLogRoutes[guildID], action)
+	return nil
+}
+
+func (m *MemoryMiddleware) GetGuildVoiceLog(guildID string) (string, error) {
+	val, err := m.getGuildSetting(guildID, "voicelogchanID")
+	return val, err
+}
+
+func (m *MemoryMiddleware) SetGuildVoiceLog(guildID, chanID string) error {
+	return m.setGuildSetting(guildID, "voicelogchanID", chanID)
+}
+
+func (m *MemoryMiddleware) GetGuildNotifyRole(guildID string) (string, error) {
+	val, err := m.getGuildSetting(guildID, "notifyRoleID")
+	return val, err
+}
+
+func (m *MemoryMiddleware) SetGuildNotifyRole(guildID, roleID string) error {
+	return m.setGuildSetting(guildID, "notifyRoleID", roleID)
+}
+
+func (m *MemoryMiddleware) GetGuildGhostpingMsg(guildID string) (string, error) {
+	val, err := m.getGuildSetting(guildID, "ghostPingMsg")
+	return val, err
+}
+
+func (m *MemoryMiddleware) SetGuildGhostpingMsg(guildID, msg string) error {
+	return m.setGuildSetting(guildID, "ghostPingMsg", msg)
+}
+
+func (m *MemoryMiddleware) GetGuildColorReaction(guildID string) (enabled bool, err error) {
+	val, err := m.getGuildSetting(guildID, "colorReaction")
+	return val == "1", err
+}
+
+func (m *MemoryMiddleware) SetGuildColorReaction(guildID string, enabled bool) error {
+	var val string
+	if enabled {
+		val = "1"
+	}
+	return m.setGuildSetting(guildID, "colorReaction", val)
+}
+
+func (m *MemoryMiddleware) GetGuildJdoodleKey(guildID string) (string, error) {
+	val, err := m.getGuildSetting(guildID, "jdoodleToken")
+	return val, err
+}
+
+func (m *MemoryMiddleware) SetGuildJdoodleKey(guildID, key string) error {
+	return m.setGuildSetting(guildID, "jdoodleToken", key)
+}
+
+func (m *MemoryMiddleware) GetGuildCodeExecEnabled(guildID string) (bool, error) {
+	val, err := m.getGuildSetting(guildID, "codeExecEnabled")
+	return val == "1", err
+}
+
+func (m *MemoryMiddleware) SetGuildCodeExecEnabled(guildID string, enabled bool) error {
+	var val string
+	if enabled {
+		val = "1"
+	}
+	return m.setGuildSetting(guildID, "codeExecEnabled", val)
+}
+
+func (m *MemoryMiddleware) GetGuildCodeExecLanguages(guildID string) ([]string, error) {
+	val, err := m.getGuildSetting(guildID, "codeExecLanguages")
+	if val == "" {
+		return []string{}, err
+	}
+	return strings.Split(val, ","), err
+}
+
+func (m *MemoryMiddleware) SetGuildCodeExecLanguages(guildID string, languages []string) error {
+	return m.setGuildSetting(guildID, "codeExecLanguages", strings.Join(languages, ","))
+}
+
+func (m *MemoryMiddleware) GetGuildBackup(guildID string) (bool, error) {
+	val, err := m.getGuildSetting(guildID, "backup")
+	return val == "1", err
+}
+
+func (m *MemoryMiddleware) SetGuildBackup(guildID string, enabled bool) error {
+	var val string
+	if enabled {
+		val = "1"
+	}
+	return m.setGuildSetting(guildID, "backup", val)
+}
+
+func (m *MemoryMiddleware) GetGuildInviteBlock(guildID string) (string, error) {
+	return m.getGuildSetting(guildID, "inviteBlock")
+}
+
+func (m *MemoryMiddleware) SetGuildInviteBlock(guildID string, data string) error {
+	return m.setGuildSetting(guildID, "inviteBlock", data)
+}
+
+func (m *MemoryMiddleware) GetGuildJoinMsg(guildID string) (string, string, error) {
+	data, err := m.getGuildSetting(guildID, "joinMsg")
+	if err != nil {
+		return "", "", err
+	}
+	if data == "" {
+		return "", "", nil
+	}
+
+	i := strings.Index(data, "|")
+	if i < 0 || len(data) < i+1 {
+		return "", "", nil
+	}
+
+	return data[:i], data[i+1:], nil
+}
+
+func (m *MemoryMiddleware) SetGuildJoinMsg(guildID string, msg string, channelID string) error {
+	return m.setGuildSetting(guildID, "joinMsg", fmt.Sprintf("%s|%s", msg, channelID))
+}
+
+func (m *MemoryMiddleware) GetGuildLeaveMsg(guildID string) (string, string, error) {
+	data, err := m.getGuildSetting(guildID, "leaveMsg")
+	if err != nil {
+		return "", "", err
+	}
+	if data == "" {
+		return "", "", nil
+	}
+
+	i := strings.Index(data, "|")
+	if i < 0 || len(data) < i+1 {
+		return "", "", nil
+	}
+
+	return data[:i], data[i+1:], nil
+}
+
+func (m *MemoryMiddleware) SetGuildLeaveMsg(guildID string, channelID string, msg string) error {
+	return m.setGuildSetting(guildID, "leaveMsg", fmt.Sprintf("%s|%s", channelID, msg))
+}
+
+func (m *MemoryMiddleware) GetUserOTAEnabled(userID string) (enabled bool, err error) {
+	v, err := m.getUserSetting(userID, "enableOTA")
+	enabled = v == "1"
+	return
+}
+
+func (m *MemoryMiddleware) SetUserOTAEnabled(userID string, enabled bool) error {
+	v := "0"
+	if enabled {
+		v = "1"
+	}
+	return m.setUserSetting(userID, "enableOTA", v)
+}
+
+func (m *MemoryMiddleware) GetUserVerified(userID string) (enabled bool, err error) {
+	v, err := m.getUserSetting(userID, "verified")
+	enabled = v == "1"
+	return
+}
+
+func (m *MemoryMiddleware) SetUserVerified(userID string, enabled bool) error {
+	v := "0"
+	if enabled {
+		v = "1"
+	}
+	return m.setUserSetting(userID, "verified", v)
+}
+
+func (m *MemoryMiddleware) GetUserStarboardOptout(userID string) (enabled bool, err error) {
+	v, err := m.getUserSetting(userID, "starboardOptout")
+	enabled = v == "1"
+	return
+}
+
+func (m *MemoryMiddleware) SetUserStarboardOptout(userID string, enabled bool) error {
+	v := "0"
+	if enabled {
+		v = "1"
+	}
+	return m.setUserSetting(userID, "starboardOptout", v)
+}
+
+func (m *MemoryMiddleware) GetGuildLogDisable(guildID string) (bool, error) {
+	val, err := m.getGuildSetting(guildID, "guildlogDisable")
+	return val == "1", err
+}
+
+func (m *MemoryMiddleware) SetGuildLogDisable(guildID string, enabled bool) error {
+	var val string
+	if enabled {
+		val = "1"
+	}
+	return m.setGuildSetting(guildID, "guildlogDisable", val)
+}
+
+func (m *MemoryMiddleware) GetGuildCommandSuggestionsDisable(guildID string) (bool, error) {
+	val, err := m.getGuildSetting(guildID, "cmdSuggestDisable")
+	return val == "1", err
+}
+
+func (m *MemoryMiddleware) SetGuildCommandSuggestionsDisable(guildID string, disabled bool) error {
+	var val string
+	if disabled {
+		val = "1"
+	}
+	return m.setGuildSetting(guildID, "cmdSuggestDisable", val)
+}
+
+func (m *MemoryMiddleware) GetGuildEmbedColor(guildID string) (int, error) {
+	val, err := m.getGuildSetting(guildID, "embedColor")
+	if err != nil {
+		return 0, err
+	}
+	if val == "" {
+		return 0, database.ErrDatabaseNotFound
+	}
+	return strconv.Atoi(val)
+}
+
+func (m *MemoryMiddleware) SetGuildEmbedColor(guildID string, color int) error {
+	var val string
+	if color >= 0 {
+		val = strconv.Itoa(color)
+	}
+	return m.setGuildSetting(guildID, "embedColor", val)
+}
+
+func (m *MemoryMiddleware) GetGuildDisabledCommandMessage(guildID string) (string, bool, error) {
+	data, err := m.getGuildSetting(guildID, "disabledCmdMsg")
+	if err != nil || data == "" {
+		return "", false, err
+	}
+
+	i := strings.Index(data, "|")
+	if i < 0 {
+		return "", false, nil
+	}
+
+	return data[i+1:], data[:i] == "1", nil
+}
+
+func (m *MemoryMiddleware) SetGuildDisabledCommandMessage(guildID string, msg string, silent bool) error {
+	silentS := "0"
+	if silent {
+		silentS = "1"
+	}
+	return m.setGuildSetting(guildID, "disabledCmdMsg", fmt.Sprintf("%s|%s", silentS, msg))
+}
+
+func (m *MemoryMiddleware) GetGuildConfirmActions(guildID string) ([]models.ConfirmAction, error) {
+	val, err := m.getGuildSetting(guildID, "confirmActions")
+	if val == "" {
+		return []models.ConfirmAction{}, err
+	}
+	split := strings.Split(val, ",")
+	actions := make([]models.ConfirmAction, len(split))
+	for i, a := range split {
+		actions[i] = models.ConfirmAction(a)
+	}
+	return actions, err
+}
+
+func (m *MemoryMiddleware) SetGuildConfirmActions(guildID string, actions []models.ConfirmAction) error {
+	split := make([]string, len(actions))
+	for i, a := range actions {
+		split[i] = string(a)
+	}
+	return m.setGuildSetting(guildID, "confirmActions", strings.Join(split, ","))
+}
+
+func (m *MemoryMiddleware) GetGuildPermDeniedMessage(guildID string) (string, bool, error) {
+	data, err := m.getGuildSetting(guildID, "permDeniedMsg")
+	if err != nil || data == "" {
+		return "", false, err
+	}
+
+	i := strings.Index(data, "|")
+	if i < 0 {
+		return "", false, nil
+	}
+
+	return data[i+1:], data[:i] == "1", nil
+}
+
+func (m *MemoryMiddleware) SetGuildPermDeniedMessage(guildID string, msg string, silent bool) error {
+	silentS := "0"
+	if silent {
+		silentS = "1"
+	}
+	return m.setGuildSetting(guildID, "permDeniedMsg", fmt.Sprintf("%s|%s", silentS, msg))
+}
+
+func (m *MemoryMiddleware) GetGuildModmailChannel(guildID string) (string, error) {
+	return m.getGuildSetting(guildID, "modmailChanID")
+}
+
+func (m *MemoryMiddleware) SetGuildModmailChannel(guildID, chanID string) error {
+	return m.setGuildSetting(guildID, "modmailChanID", chanID)
+}
+
+func (m *MemoryMiddleware) GetGuildVerificationRequired(guildID string) (bool, error) {
+	val, err := m.getGuildSetting(guildID, "requireUserVerification")
+	return val == "1", err
+}
+
+func (m *MemoryMiddleware) SetGuildVerificationRequired(guildID string, enable bool) error {
+	var val string
+	if enable {
+		val = "1"
+	}
+	return m.setGuildSetting(guildID, "requireUserVerification", val)
+}
+
+func (m *MemoryMiddleware) GetGuildBirthdayChan(guildID string) (chanID string, err error) {
+	chanID, err = m.getGuildSetting(guildID, "birthdaychanID")
+	return
+}
+
+func (m *MemoryMiddleware) SetGuildBirthdayChan(guildID string, chanID string) (err error) {
+	err = m.setGuildSetting(guildID, "birthdaychanID", chanID)
+	return
+}
+
+func (m *MemoryMiddleware) GetGuildAnnouncementChannel(guildID string) (string, error) {
+	return m.getGuildSetting(guildID, "announcementChannel")
+}
+
+func (m *MemoryMiddleware) SetGuildAnnouncementChannel(guildID, chanID string) error {
+	return m.setGuildSetting(guildID, "announcementChannel", chanID)
+}
+
+func (m *MemoryMiddleware) GetGuildMemberMsgGrace(guildID string) (time.Duration, bool, error) {
+	data, err := m.getGuildSetting(guildID, "memberMsgGrace")
+	if err != nil || data == "" {
+		return 0, false, err
+	}
+
+	i := strings.Index(data, "|")
+	if i < 0 {
+		return 0, false, nil
+	}
+
+	seconds, err := strconv.Atoi(data[i+1:])
+	if err != nil {
+		return 0, false, err
+	}
+
+	return time.Duration(seconds) * time.Second, data[:i] == "1", nil
+}
+
+func (m *MemoryMiddleware) SetGuildMemberMsgGrace(guildID string, period time.Duration, noteRejoin bool) error {
+	noteS := "0"
+	if noteRejoin {
+		noteS = "1"
+	}
+	return m.setGuildSetting(guildID, "memberMsgGrace", fmt.Sprintf("%s|%d", noteS, int(period.Seconds())))
+}
+
+func (m *MemoryMiddleware) GetGuildPinRotation(guildID string) (bool, error) {
+	val, err := m.getGuildSetting(guildID, "pinRotation")
+	return val == "1", err
+}
+
+func (m *MemoryMiddleware) SetGuildPinRotation(guildID string, enabled bool) error {
+	var val string
+	if enabled {
+		val = "1"
+	}
+	return m.setGuildSetting(guildID, "pinRotation", val)
+}
+
+func (m *MemoryMiddleware) GetGuildMessageStatsEnabled(guildID string) (bool, error) {
+	val, err := m.getGuildSetting(guildID, "messageStats")
+	return val == "1", err
+}
+
+func (m *MemoryMiddleware) SetGuildMessageStatsEnabled(guildID string, enabled bool) error {
+	var val string
+	if enabled {
+		val = "1"
+	}
+	return m.setGuildSetting(guildID, "messageStats", val)
+}
+
+func (m *MemoryMiddleware) GetGuildBotNickname(guildID string) (string, error) {
+	return m.getGuildSetting(guildID, "botNickname")
+}
+
+func (m *MemoryMiddleware) SetGuildBotNickname(guildID, nickname string) error {
+	return m.setGuildSetting(guildID, "botNickname", nickname)
+}
+
+func (m *MemoryMiddleware) GetGuildModNot(guildID string) (string, error) {
+	val, err := m.getGuildSetting(guildID, "modnotchanID")
+	return val, err
+}
+
+func (m *MemoryMiddleware) SetGuildModNot(guildID, chanID string) error {
+	return m.setGuildSetting(guildID, "modnotchanID", chanID)
+}
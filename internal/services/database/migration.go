@@ -7,4 +7,8 @@ type Migration interface {
 	// model is up to date and migrates the database to
 	// the latest state.
 	Migrate() error
+
+	// MigrateTo applies or reverts migrations until the
+	// database model is at the given version.
+	MigrateTo(version int) error
 }
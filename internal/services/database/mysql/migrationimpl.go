@@ -2,6 +2,7 @@ package mysql
 
 import (
 	"database/sql"
+	"fmt"
 	"time"
 
 	"github.com/go-sql-driver/mysql"
@@ -10,6 +11,13 @@ import (
 
 type migrationFunc func(*sql.Tx) error
 
+// migrationStep holds the functions to apply and to
+// revert a single migration.
+type migrationStep struct {
+	Up   migrationFunc
+	Down migrationFunc
+}
+
 type migration struct {
 	Version       int
 	Applied       time.Time
@@ -18,6 +26,28 @@ type migration struct {
 }
 
 func (m *MysqlMiddleware) Migrate() (err error) {
+	return m.MigrateTo(len(migrations) - 1)
+}
+
+// MigrateTo applies or reverts migrations until the
+// database is at the given version.
+//
+// Each migration is executed in its own transaction
+// together with updating the version in the migrations
+// table. If a migration fails, its transaction is rolled
+// back and the database stays at the version of the last
+// successful migration.
+//
+// Because MySQL commits schema changes implicitly, the
+// changes of a failed migration can not always be rolled
+// back. Therefore, all migrations must be safe to be
+// executed again.
+func (m *MysqlMiddleware) MigrateTo(version int) (err error) {
+	if version < 0 || version >= len(migrations) {
+		return fmt.Errorf("invalid migration version %d (latest is %d)",
+			version, len(migrations)-1)
+	}
+
 	mig, err := m.getLatestMigration()
 	if err == sql.ErrNoRows {
 		mig = &migration{
@@ -27,20 +57,50 @@ func (m *MysqlMiddleware) Migrate() (err error) {
 		return err
 	}
 
-	tx, err := m.Db.Begin()
-	if err != nil {
-		return err
-	}
-	for i := mig.Version + 1; i < len(migrationFuncs); i++ {
+	for i := mig.Version + 1; i <= version; i++ {
 		m.log.Info().Field("version", i).Msg("Applying migration ...")
-		if err = migrationFuncs[i](tx); err != nil {
+		if err = m.runMigration(i, migrations[i].Up, putMigrationVersion); err != nil {
 			return err
 		}
-		if err = putMigrationVersion(tx, i); err != nil {
+	}
+
+	for i := mig.Version; i > version; i-- {
+		if migrations[i].Down == nil {
+			return fmt.Errorf("migration %d can not be reverted", i)
+		}
+		m.log.Info().Field("version", i).Msg("Reverting migration ...")
+		if err = m.runMigration(i, migrations[i].Down, deleteMigrationVersion); err != nil {
 			return err
 		}
 	}
 
+	return nil
+}
+
+func (m *MysqlMiddleware) runMigration(
+	version int,
+	f migrationFunc,
+	record func(*sql.Tx, int) error,
+) (err error) {
+	tx, err := m.Db.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			err = fmt.Errorf("migration %d failed: %s", version, err.Error())
+		}
+	}()
+
+	if err = f(tx); err != nil {
+		return
+	}
+	if err = record(tx, version); err != nil {
+		return
+	}
+
 	return tx.Commit()
 }
 
@@ -63,6 +123,11 @@ func putMigrationVersion(tx *sql.Tx, i int) (err error) {
 	return
 }
 
+func deleteMigrationVersion(tx *sql.Tx, i int) (err error) {
+	_, err = tx.Exec(`DELETE FROM migrations WHERE version >= ?`, i)
+	return
+}
+
 // --- UTILITIES ---
 
 func createTableColumnIfNotExists(m *sql.Tx, table, definition string) (err error) {
@@ -76,3 +141,20 @@ func createTableColumnIfNotExists(m *sql.Tx, table, definition string) (err erro
 
 	return err
 }
+
+// dropColumns returns a migration function which removes
+// the given columns from the table if they exist.
+func dropColumns(table string, columns ...string) migrationFunc {
+	return func(m *sql.Tx) (err error) {
+		for _, column := range columns {
+			_, err = m.Exec("ALTER TABLE `" + table + "` DROP COLUMN `" + column + "`")
+			if e, ok := err.(*mysql.MySQLError); ok && e.Number == 1091 {
+				err = nil
+			}
+			if err != nil {
+				return
+			}
+		}
+		return
+	}
+}
@@ -2,23 +2,47 @@ package mysql
 
 import (
 	"database/sql"
+
+	"github.com/go-sql-driver/mysql"
 )
 
-var migrationFuncs = []migrationFunc{
-	migration_0,
-	migration_1,
-	migration_2,
-	migration_3,
-	migration_4,
-	migration_5,
-	migration_6,
-	migration_7,
-	migration_8,
-	migration_9,
-	migration_10,
-	migration_11,
-	migration_12,
-	migration_13,
+// migrations contains all database migrations ordered by
+// their version. Migrations without a down function can
+// not be reverted.
+var migrations = []migrationStep{
+	{Up: migration_0},
+	{Up: migration_1, Down: dropColumns("starboardEntries", "deleted")},
+	{Up: migration_2, Down: dropColumns("starboardConfig", "karmaGain")},
+	{Up: migration_3, Down: dropColumns("guilds", "guildlogDisable")},
+	{Up: migration_4, Down: dropColumns("karmaSettings", "penalty")},
+	{Up: migration_5, Down: dropColumns("reports", "timeout")},
+	{Up: migration_6},
+	{Up: migration_7, Down: dropColumns("antiraidJoinlog", "accountCreated")},
+	{Up: migration_8, Down: migration_8_down},
+	{Up: migration_9, Down: migration_9_down},
+	{Up: migration_10, Down: dropColumns("guilds", "birthdaychanID")},
+	{Up: migration_11, Down: dropColumns("guilds", "autovc")},
+	{Up: migration_12, Down: migration_12_down},
+	{Up: migration_13, Down: dropColumns("guilds", "modnotchanID")},
+	{Up: migration_14, Down: dropColumns("guilds", "codeExecLanguages")},
+	{Up: migration_15, Down: dropColumns("guilds", "confirmActions")},
+	{Up: migration_16, Down: dropColumns("guilds", "permDeniedMsg")},
+	{Up: migration_17, Down: dropColumns("guilds", "modmailChanID")},
+	{Up: migration_18, Down: dropColumns("guilds", "cmdSuggestDisable")},
+	{Up: migration_19, Down: dropColumns("guilds", "disabledCmdMsg")},
+	{Up: migration_20, Down: dropColumns("guilds", "embedColor")},
+	{Up: migration_21, Down: dropColumns("guilds", "announcementChannel")},
+	{Up: migration_22, Down: dropColumns("guilds", "pinRotation")},
+	{Up: migration_23, Down: dropColumns("guilds", "modlogFormat")},
+	{Up: migration_24, Down: dropColumns("guilds", "memberMsgGrace")},
+	{Up: migration_25, Down: dropColumns("reports", "revokedAt", "revokedBy", "revokeReason")},
+	{Up: migration_26, Down: dropColumns("karmaSettings", "giveCooldown", "giveDailyCap", "receiveCap", "receiveInterval")},
+	{Up: migration_27, Down: dropColumns("twitchnotify", "template", "roleID", "plain")},
+	{Up: migration_28, Down: dropColumns("guilds", "messageStats")},
+	{Up: migration_29, Down: dropColumns("guilds", "botNickname")},
+	{Up: migration_30, Down: dropColumns("inviteJoins", "inviterID")},
+	{Up: migration_31, Down: dropColumns("giveawayEntries", "guildID")},
+	{Up: migration_32, Down: dropColumns("backups", "manual")},
 }
 
 // VERSION 0:
@@ -160,3 +184,218 @@ func migration_13(m *sql.Tx) (err error) {
 
 	return err
 }
+
+// VERSION 14:
+// - add property `codeExecLanguages` to `guilds`
+func migration_14(m *sql.Tx) (err error) {
+	return createTableColumnIfNotExists(m,
+		"guilds", "`codeExecLanguages` text NOT NULL DEFAULT ''")
+}
+
+// VERSION 15:
+// - add property `confirmActions` to `guilds`
+func migration_15(m *sql.Tx) (err error) {
+	return createTableColumnIfNotExists(m,
+		"guilds", "`confirmActions` text NOT NULL DEFAULT ''")
+}
+
+// VERSION 16:
+// - add property `permDeniedMsg` to `guilds`
+func migration_16(m *sql.Tx) (err error) {
+	return createTableColumnIfNotExists(m,
+		"guilds", "`permDeniedMsg` text NOT NULL DEFAULT ''")
+}
+
+// VERSION 17:
+// - add property `modmailChanID` to `guilds`
+func migration_17(m *sql.Tx) (err error) {
+	return createTableColumnIfNotExists(m,
+		"guilds", "`modmailChanID` varchar(25) NOT NULL DEFAULT ''")
+}
+
+// VERSION 18:
+// - add property `cmdSuggestDisable` to `guilds`
+func migration_18(m *sql.Tx) (err error) {
+	return createTableColumnIfNotExists(m,
+		"guilds", "`cmdSuggestDisable` text NOT NULL DEFAULT ''")
+}
+
+// VERSION 19:
+// - add property `disabledCmdMsg` to `guilds`
+func migration_19(m *sql.Tx) (err error) {
+	return createTableColumnIfNotExists(m,
+		"guilds", "`disabledCmdMsg` text NOT NULL DEFAULT ''")
+}
+
+// VERSION 20:
+// - add property `embedColor` to `guilds`
+func migration_20(m *sql.Tx) (err error) {
+	return createTableColumnIfNotExists(m,
+		"guilds", "`embedColor` text NOT NULL DEFAULT ''")
+}
+
+// VERSION 21:
+// - add property `announcementChannel` to `guilds`
+func migration_21(m *sql.Tx) (err error) {
+	return createTableColumnIfNotExists(m,
+		"guilds", "`announcementChannel` varchar(25) NOT NULL DEFAULT ''")
+}
+
+// VERSION 22:
+// - add property `pinRotation` to `guilds`
+func migration_22(m *sql.Tx) (err error) {
+	return createTableColumnIfNotExists(m,
+		"guilds", "`pinRotation` text NOT NULL DEFAULT ''")
+}
+
+// VERSION 23:
+// - add property `modlogFormat` to `guilds`
+func migration_23(m *sql.Tx) (err error) {
+	return createTableColumnIfNotExists(m,
+		"guilds", "`modlogFormat` text NOT NULL DEFAULT ''")
+}
+
+// VERSION 24:
+// - add property `memberMsgGrace` to `guilds`
+func migration_24(m *sql.Tx) (err error) {
+	return createTableColumnIfNotExists(m,
+		"guilds", "`memberMsgGrace` text NOT NULL DEFAULT ''")
+}
+
+// VERSION 25:
+// - add property `revokedAt` to `reports`
+// - add property `revokedBy` to `reports`
+// - add property `revokeReason` to `reports`
+func migration_25(m *sql.Tx) (err error) {
+	err = createTableColumnIfNotExists(m,
+		"reports", "`revokedAt` timestamp NULL DEFAULT NULL")
+	if err != nil {
+		return
+	}
+	err = createTableColumnIfNotExists(m,
+		"reports", "`revokedBy` text NOT NULL DEFAULT ''")
+	if err != nil {
+		return
+	}
+	err = createTableColumnIfNotExists(m,
+		"reports", "`revokeReason` text NOT NULL DEFAULT ''")
+	return
+}
+
+// VERSION 26:
+// - add property `giveCooldown` to `karmaSettings`
+// - add property `giveDailyCap` to `karmaSettings`
+// - add property `receiveCap` to `karmaSettings`
+// - add property `receiveInterval` to `karmaSettings`
+func migration_26(m *sql.Tx) (err error) {
+	err = createTableColumnIfNotExists(m,
+		"karmaSettings", "`giveCooldown` int(11) NOT NULL DEFAULT '10'")
+	if err != nil {
+		return
+	}
+	err = createTableColumnIfNotExists(m,
+		"karmaSettings", "`giveDailyCap` int(11) NOT NULL DEFAULT '50'")
+	if err != nil {
+		return
+	}
+	err = createTableColumnIfNotExists(m,
+		"karmaSettings", "`receiveCap` int(11) NOT NULL DEFAULT '20'")
+	if err != nil {
+		return
+	}
+	err = createTableColumnIfNotExists(m,
+		"karmaSettings", "`receiveInterval` int(11) NOT NULL DEFAULT '3600'")
+	return
+}
+
+// VERSION 27:
+// - add property `template` to `twitchnotify`
+// - add property `roleID` to `twitchnotify`
+// - add property `plain` to `twitchnotify`
+func migration_27(m *sql.Tx) (err error) {
+	err = createTableColumnIfNotExists(m,
+		"twitchnotify", "`template` text NOT NULL DEFAULT ''")
+	if err != nil {
+		return
+	}
+	err = createTableColumnIfNotExists(m,
+		"twitchnotify", "`roleID` varchar(25) NOT NULL DEFAULT ''")
+	if err != nil {
+		return
+	}
+	err = createTableColumnIfNotExists(m,
+		"twitchnotify", "`plain` int(1) NOT NULL DEFAULT '0'")
+	return
+}
+
+// VERSION 28:
+// - add property `messageStats` to `guilds`
+func migration_28(m *sql.Tx) (err error) {
+	return createTableColumnIfNotExists(m,
+		"guilds", "`messageStats` text NOT NULL DEFAULT ''")
+}
+
+// VERSION 29:
+// - add property `botNickname` to `guilds`
+func migration_29(m *sql.Tx) (err error) {
+	return createTableColumnIfNotExists(m,
+		"guilds", "`botNickname` text NOT NULL DEFAULT ''")
+}
+
+// VERSION 30:
+// - add property `inviterID` to `inviteJoins`
+func migration_30(m *sql.Tx) (err error) {
+	return createTableColumnIfNotExists(m,
+		"inviteJoins", "`inviterID` varchar(25) NOT NULL DEFAULT ''")
+}
+
+// VERSION 31:
+// - add property `guildID` to `giveawayEntries`
+func migration_31(m *sql.Tx) (err error) {
+	err = createTableColumnIfNotExists(m,
+		"giveawayEntries", "`guildID` varchar(25) NOT NULL DEFAULT ''")
+	if err != nil {
+		return
+	}
+	_, err = m.Exec("UPDATE `giveawayEntries` e " +
+		"INNER JOIN `giveaways` g ON g.id = e.giveawayID " +
+		"SET e.guildID = g.guildID WHERE e.guildID = ''")
+	return
+}
+
+// VERSION 32:
+// - add property `manual` to `backups`
+func migration_32(m *sql.Tx) (err error) {
+	return createTableColumnIfNotExists(m,
+		"backups", "`manual` int(1) NOT NULL DEFAULT '0'")
+}
+
+// --- DOWN MIGRATIONS ---
+
+func migration_8_down(m *sql.Tx) (err error) {
+	if err = dropColumns("users", "verified")(m); err != nil {
+		return
+	}
+	if err = dropColumns("guilds", "requireUserVerification")(m); err != nil {
+		return
+	}
+	return dropColumns("antiraidSettings", "verification")(m)
+}
+
+func migration_9_down(m *sql.Tx) (err error) {
+	if err = dropColumns("guilds", "codeExecEnabled")(m); err != nil {
+		return
+	}
+	return dropColumns("users", "starboardOptout")(m)
+}
+
+func migration_12_down(m *sql.Tx) (err error) {
+	_, err = m.Exec(`ALTER TABLE unbanRequests DROP FOREIGN KEY FK_reportID`)
+	if e, ok := err.(*mysql.MySQLError); ok && e.Number == 1091 {
+		err = nil
+	}
+	if err != nil {
+		return
+	}
+	return dropColumns("unbanRequests", "reportID")(m)
+}
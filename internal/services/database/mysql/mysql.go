@@ -2,13 +2,16 @@ package mysql
 
 import (
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/zekroTJA/shinpuru/internal/models"
 	"github.com/zekroTJA/shinpuru/internal/services/backup/backupmodels"
 	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/services/database/sqldb"
 	"github.com/zekroTJA/shinpuru/internal/util/tag"
 	"github.com/zekroTJA/shinpuru/internal/util/vote"
 	"github.com/zekroTJA/shinpuru/pkg/multierror"
@@ -25,15 +28,18 @@ import (
 // MysqlMiddleware implements the Database interface for
 // MariaDB or MysqlMiddleware.
 type MysqlMiddleware struct {
-	Db  *sql.DB
+	Db  *sqldb.DB
 	log rogu.Logger
+
+	queryOpts sqldb.Options
 }
 
 var _ database.Database = (*MysqlMiddleware)(nil)
 
-func New() *MysqlMiddleware {
+func New(queryOpts sqldb.Options) *MysqlMiddleware {
 	return &MysqlMiddleware{
-		log: log.Tagged("Database"),
+		log:       log.Tagged("Database"),
+		queryOpts: queryOpts,
 	}
 }
 
@@ -59,6 +65,34 @@ var guildTables = []string{
 	"verificationQueue",
 	"voicelogBlocklist",
 	"birthdays",
+	"autodelete",
+	"settingsAudit",
+	"reportImports",
+	"modmailThreads",
+	"guildRules",
+	"rulesAcceptances",
+	"guildDisabledCommands",
+	"giveaways",
+	"giveawayEntries",
+	"guildReportTypes",
+	"reportEscalation",
+	"broadcastDeliveries",
+	"trackedInvites",
+	"inviteJoins",
+	"tempRoles",
+	"autoThreads",
+	"stickyMessages",
+	"modLogRoutes",
+	"userNotes",
+	"wordFilterSettings",
+	"wordFilterEntries",
+	"autoPublish",
+	"prefixlessChannels",
+	"messageStats",
+	"reactionReportConfig",
+	"backupSchedules",
+	"inactivitySettings",
+	"memberActivity",
 }
 
 type tableColumn struct {
@@ -73,9 +107,14 @@ var userTables = []tableColumn{
 	{"starboardEntries", "authorID"},
 	{"tags", "creatorID"},
 	{"unbanRequests", "userID"},
+	{"modmailThreads", "userID"},
+	{"giveawayEntries", "userID"},
+	{"rulesAcceptances", "userID"},
 	{"unbanRequests", "processedBy"},
 	{"users", "userID"},
 	{"birthdays", "userID"},
+	{"inviteJoins", "userID"},
+	{"memberActivity", "userID"},
 }
 
 func (m *MysqlMiddleware) setup() (err error) {
@@ -110,12 +149,19 @@ func (m *MysqlMiddleware) setup() (err error) {
 		"`ghostPingMsg` text NOT NULL DEFAULT ''," +
 		"`jdoodleToken` text NOT NULL DEFAULT ''," +
 		"`codeExecEnabled` text NOT NULL DEFAULT ''," +
+		"`codeExecLanguages` text NOT NULL DEFAULT ''," +
 		"`backup` text NOT NULL DEFAULT ''," +
 		"`inviteBlock` text NOT NULL DEFAULT ''," +
 		"`joinMsg` text NOT NULL DEFAULT ''," +
 		"`leaveMsg` text NOT NULL DEFAULT ''," +
 		"`colorReaction` text NOT NULL DEFAULT ''," +
 		"`guildlogDisable` text NOT NULL DEFAULT ''," +
+		"`confirmActions` text NOT NULL DEFAULT ''," +
+		"`permDeniedMsg` text NOT NULL DEFAULT ''," +
+		"`modmailChanID` varchar(25) NOT NULL DEFAULT ''," +
+		"`cmdSuggestDisable` text NOT NULL DEFAULT ''," +
+		"`disabledCmdMsg` text NOT NULL DEFAULT ''," +
+		"`embedColor` text NOT NULL DEFAULT ''," +
 		"`requireUserVerification` text NOT NULL DEFAULT ''," +
 		"`birthdaychanID` text NOT NULL DEFAULT ''," +
 		"`modnotchanID` varchar(25) NOT NULL DEFAULT ''," +
@@ -155,6 +201,9 @@ func (m *MysqlMiddleware) setup() (err error) {
 		"`msg` text NOT NULL DEFAULT ''," +
 		"`attachment` text NOT NULL DEFAULT ''," +
 		"`timeout` timestamp NULL DEFAULT NULL," +
+		"`revokedAt` timestamp NULL DEFAULT NULL," +
+		"`revokedBy` text NOT NULL DEFAULT ''," +
+		"`revokeReason` text NOT NULL DEFAULT ''," +
 		"PRIMARY KEY (`id`)" +
 		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;")
 	if err != nil {
@@ -182,6 +231,9 @@ func (m *MysqlMiddleware) setup() (err error) {
 		"`guildID` text NOT NULL DEFAULT ''," +
 		"`channelID` text NOT NULL DEFAULT ''," +
 		"`twitchUserID` text NOT NULL DEFAULT ''," +
+		"`template` text NOT NULL DEFAULT ''," +
+		"`roleID` varchar(25) NOT NULL DEFAULT ''," +
+		"`plain` int(1) NOT NULL DEFAULT '0'," +
 		"PRIMARY KEY (`iid`)" +
 		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;")
 	if err != nil {
@@ -244,6 +296,10 @@ func (m *MysqlMiddleware) setup() (err error) {
 		"`emotesDec` text NOT NULL DEFAULT ''," +
 		"`tokens` bigint(20) NOT NULL DEFAULT '1'," +
 		"`penalty` int(1) NOT NULL DEFAULT '0'," +
+		"`giveCooldown` int(11) NOT NULL DEFAULT '10'," +
+		"`giveDailyCap` int(11) NOT NULL DEFAULT '50'," +
+		"`receiveCap` int(11) NOT NULL DEFAULT '20'," +
+		"`receiveInterval` int(11) NOT NULL DEFAULT '3600'," +
 		"PRIMARY KEY (`guildID`)" +
 		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;")
 	if err != nil {
@@ -434,6 +490,348 @@ func (m *MysqlMiddleware) setup() (err error) {
 		return
 	}
 
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS `stickyMessages` (" +
+		"`guildID` varchar(25) NOT NULL," +
+		"`channelID` varchar(25) NOT NULL," +
+		"`content` text NOT NULL," +
+		"`messageID` varchar(25) NOT NULL DEFAULT ''," +
+		"PRIMARY KEY (`guildID`, `channelID`)" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS `modLogRoutes` (" +
+		"`guildID` varchar(25) NOT NULL," +
+		"`action` varchar(16) NOT NULL," +
+		"`channelID` varchar(25) NOT NULL," +
+		"PRIMARY KEY (`guildID`, `action`)" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS `userNotes` (" +
+		"`id` varchar(25) NOT NULL," +
+		"`guildID` varchar(25) NOT NULL," +
+		"`userID` varchar(25) NOT NULL," +
+		"`authorID` varchar(25) NOT NULL," +
+		"`content` text NOT NULL," +
+		"`created` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP()," +
+		"PRIMARY KEY (`id`)," +
+		"KEY `guildUser` (`guildID`, `userID`)" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS `wordFilterSettings` (" +
+		"`guildID` varchar(25) NOT NULL," +
+		"`enabled` int(1) NOT NULL DEFAULT '0'," +
+		"`action` varchar(16) NOT NULL DEFAULT 'delete'," +
+		"`muteDuration` bigint(20) NOT NULL DEFAULT '0'," +
+		"`exemptRoles` text NOT NULL," +
+		"`exemptChannels` text NOT NULL," +
+		"PRIMARY KEY (`guildID`)" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS `wordFilterEntries` (" +
+		"`guildID` varchar(25) NOT NULL," +
+		"`pattern` varchar(100) NOT NULL," +
+		"`mode` varchar(16) NOT NULL DEFAULT 'word'," +
+		"PRIMARY KEY (`guildID`, `pattern`)" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS `autoThreads` (" +
+		"`guildID` varchar(25) NOT NULL," +
+		"`channelID` varchar(25) NOT NULL," +
+		"`attachmentsOnly` int(1) NOT NULL DEFAULT '0'," +
+		"`nameSource` varchar(16) NOT NULL DEFAULT 'content'," +
+		"`cooldown` bigint(20) NOT NULL DEFAULT '0'," +
+		"PRIMARY KEY (`guildID`, `channelID`)" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS `autodelete` (" +
+		"`guildID` varchar(25) NOT NULL," +
+		"`channelID` varchar(25) NOT NULL DEFAULT ''," +
+		"`delay` bigint(20) NOT NULL DEFAULT '0'," +
+		"`errorDelay` bigint(20) NOT NULL DEFAULT '0'," +
+		"PRIMARY KEY (`guildID`, `channelID`)" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS `settingsAudit` (" +
+		"`id` varchar(25) NOT NULL," +
+		"`guildID` varchar(25) NOT NULL DEFAULT ''," +
+		"`actorID` varchar(25) NOT NULL DEFAULT ''," +
+		"`field` varchar(64) NOT NULL DEFAULT ''," +
+		"`oldValue` text NOT NULL DEFAULT ''," +
+		"`newValue` text NOT NULL DEFAULT ''," +
+		"`timestamp` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP()," +
+		"PRIMARY KEY (`id`)" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS `reportImports` (" +
+		"`guildID` varchar(25) NOT NULL," +
+		"`externalID` varchar(64) NOT NULL," +
+		"`reportID` varchar(25) NOT NULL," +
+		"PRIMARY KEY (`guildID`, `externalID`)" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS `modmailThreads` (" +
+		"`threadID` varchar(25) NOT NULL," +
+		"`guildID` varchar(25) NOT NULL," +
+		"`userID` varchar(25) NOT NULL," +
+		"PRIMARY KEY (`threadID`)" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS `guildRules` (" +
+		"`guildID` varchar(25) NOT NULL," +
+		"`channelID` varchar(25) NOT NULL DEFAULT ''," +
+		"`messageID` varchar(25) NOT NULL DEFAULT ''," +
+		"`roleID` varchar(25) NOT NULL DEFAULT ''," +
+		"`content` text NOT NULL DEFAULT ''," +
+		"`version` int(11) NOT NULL DEFAULT '0'," +
+		"PRIMARY KEY (`guildID`)" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS `giveaways` (" +
+		"`id` varchar(25) NOT NULL," +
+		"`guildID` varchar(25) NOT NULL," +
+		"`channelID` varchar(25) NOT NULL DEFAULT ''," +
+		"`messageID` varchar(25) NOT NULL DEFAULT ''," +
+		"`creatorID` varchar(25) NOT NULL DEFAULT ''," +
+		"`prize` text NOT NULL DEFAULT ''," +
+		"`winners` int(11) NOT NULL DEFAULT '1'," +
+		"`roleID` varchar(25) NOT NULL DEFAULT ''," +
+		"`minKarma` int(11) NOT NULL DEFAULT '0'," +
+		"`expires` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP()," +
+		"`ended` int(1) NOT NULL DEFAULT '0'," +
+		"`winnerIDs` text NOT NULL DEFAULT ''," +
+		"PRIMARY KEY (`id`)" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS `tempRoles` (" +
+		"`id` varchar(25) NOT NULL," +
+		"`guildID` varchar(25) NOT NULL," +
+		"`userID` varchar(25) NOT NULL," +
+		"`roleID` varchar(25) NOT NULL," +
+		"`executorID` varchar(25) NOT NULL DEFAULT ''," +
+		"`expires` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP()," +
+		"`attempts` int(11) NOT NULL DEFAULT '0'," +
+		"PRIMARY KEY (`id`)," +
+		"KEY `guildID` (`guildID`)" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS `giveawayEntries` (" +
+		"`giveawayID` varchar(25) NOT NULL," +
+		"`guildID` varchar(25) NOT NULL," +
+		"`userID` varchar(25) NOT NULL," +
+		"PRIMARY KEY (`giveawayID`, `userID`)" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS `guildReportTypes` (" +
+		"`guildID` varchar(25) NOT NULL," +
+		"`typeID` int(11) NOT NULL," +
+		"`name` varchar(32) NOT NULL DEFAULT ''," +
+		"`color` int(11) NOT NULL DEFAULT '0'," +
+		"PRIMARY KEY (`guildID`, `typeID`)" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS `reportEscalation` (" +
+		"`guildID` varchar(25) NOT NULL," +
+		"`data` text NOT NULL DEFAULT ''," +
+		"PRIMARY KEY (`guildID`)" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS `broadcasts` (" +
+		"`id` varchar(25) NOT NULL," +
+		"`authorID` varchar(25) NOT NULL DEFAULT ''," +
+		"`content` text NOT NULL DEFAULT ''," +
+		"`created` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP()," +
+		"`finished` int(1) NOT NULL DEFAULT '0'," +
+		"PRIMARY KEY (`id`)" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS `broadcastDeliveries` (" +
+		"`broadcastID` varchar(25) NOT NULL," +
+		"`guildID` varchar(25) NOT NULL," +
+		"`status` varchar(16) NOT NULL DEFAULT ''," +
+		"`error` text NOT NULL DEFAULT ''," +
+		"PRIMARY KEY (`broadcastID`, `guildID`)" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS `guildDisabledCommands` (" +
+		"`guildID` varchar(25) NOT NULL," +
+		"`command` varchar(32) NOT NULL," +
+		"PRIMARY KEY (`guildID`, `command`)" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS `rulesAcceptances` (" +
+		"`guildID` varchar(25) NOT NULL," +
+		"`userID` varchar(25) NOT NULL," +
+		"`version` int(11) NOT NULL DEFAULT '0'," +
+		"`timestamp` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP()," +
+		"PRIMARY KEY (`guildID`, `userID`)" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS `trackedInvites` (" +
+		"`code` varchar(32) NOT NULL," +
+		"`guildID` varchar(25) NOT NULL," +
+		"`channelID` varchar(25) NOT NULL DEFAULT ''," +
+		"`creatorID` varchar(25) NOT NULL DEFAULT ''," +
+		"`label` text NOT NULL DEFAULT ''," +
+		"`created` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP()," +
+		"PRIMARY KEY (`code`)" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS `inviteJoins` (" +
+		"`iid` int(11) NOT NULL AUTO_INCREMENT," +
+		"`guildID` varchar(25) NOT NULL," +
+		"`userID` varchar(25) NOT NULL," +
+		"`code` varchar(32) NOT NULL DEFAULT ''," +
+		"`source` varchar(16) NOT NULL DEFAULT ''," +
+		"`timestamp` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP()," +
+		"PRIMARY KEY (`iid`)," +
+		"KEY `guildID` (`guildID`)" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS `autoPublish` (" +
+		"`guildID` varchar(25) NOT NULL," +
+		"`channelID` varchar(25) NOT NULL," +
+		"`skipBots` int(1) NOT NULL DEFAULT '0'," +
+		"PRIMARY KEY (`guildID`, `channelID`)" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS `prefixlessChannels` (" +
+		"`guildID` varchar(25) NOT NULL," +
+		"`channelID` varchar(25) NOT NULL," +
+		"PRIMARY KEY (`guildID`, `channelID`)" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS `messageStats` (" +
+		"`guildID` varchar(25) NOT NULL," +
+		"`channelID` varchar(25) NOT NULL," +
+		"`userID` varchar(25) NOT NULL," +
+		"`hour` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP()," +
+		"`count` int(11) NOT NULL DEFAULT '0'," +
+		"PRIMARY KEY (`guildID`, `channelID`, `userID`, `hour`)," +
+		"KEY `hour` (`hour`)" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS `reactionReportConfig` (" +
+		"`guildID` varchar(25) NOT NULL," +
+		"`channelID` varchar(25) NOT NULL DEFAULT ''," +
+		"`emoji` varchar(100) NOT NULL DEFAULT ''," +
+		"`cooldown` int(11) NOT NULL DEFAULT '300'," +
+		"PRIMARY KEY (`guildID`)" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS `backupSchedules` (" +
+		"`guildID` varchar(25) NOT NULL," +
+		"`spec` varchar(100) NOT NULL DEFAULT ''," +
+		"`retention` int(11) NOT NULL DEFAULT '10'," +
+		"`notifyChannelID` varchar(25) NOT NULL DEFAULT ''," +
+		"`notifyOwner` int(1) NOT NULL DEFAULT '0'," +
+		"`lastRun` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP()," +
+		"PRIMARY KEY (`guildID`)" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS `inactivitySettings` (" +
+		"`guildID` varchar(25) NOT NULL," +
+		"`enabled` int(1) NOT NULL DEFAULT '0'," +
+		"`roleID` varchar(25) NOT NULL DEFAULT ''," +
+		"`days` int(11) NOT NULL DEFAULT '0'," +
+		"`warnDays` int(11) NOT NULL DEFAULT '0'," +
+		"`since` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP()," +
+		"PRIMARY KEY (`guildID`)" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS `memberActivity` (" +
+		"`guildID` varchar(25) NOT NULL," +
+		"`userID` varchar(25) NOT NULL," +
+		"`lastActive` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP()," +
+		"`warned` timestamp NULL DEFAULT NULL," +
+		"PRIMARY KEY (`guildID`, `userID`)" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;")
+	if err != nil {
+		return
+	}
+
 	err = tx.Commit()
 	return
 }
@@ -443,10 +841,13 @@ func (m *MysqlMiddleware) Connect(credentials ...interface{}) (err error) {
 	dsn := fmt.Sprintf("%s:%s@tcp(%s)/%s?collation=utf8mb4_unicode_ci&parseTime=true",
 		creds.User, creds.Password, creds.Host, creds.Database)
 
-	if m.Db, err = sql.Open("mysql", dsn); err != nil {
+	sqlDb, err := sql.Open("mysql", dsn)
+	if err != nil {
 		return
 	}
 
+	m.Db = sqldb.New(sqlDb, m.queryOpts, m.log)
+
 	err = m.setup()
 	return
 }
@@ -563,6 +964,67 @@ func (m *MysqlMiddleware) SetGuildModLog(guildID, chanID string) error {
 	return m.setGuildSetting(guildID, "modlogchanID", chanID)
 }
 
+func (m *MysqlMiddleware) GetGuildModLogFormat(guildID string) (models.ModLogFormat, error) {
+	val, err := m.getGuildSetting(guildID, "modlogFormat")
+	return models.ModLogFormat(val), err
+}
+
+func (m *MysqlMiddleware) SetGuildModLogFormat(guildID string, format models.ModLogFormat) error {
+	return m.setGuildSetting(guildID, "modlogFormat", string(format))
+}
+
+func (m *MysqlMiddleware) GetGuildModLogRoute(guildID string, action models.ModLogAction) (channelID string, err error) {
+	err = m.Db.QueryRow(`
+		SELECT channelID
+		FROM modLogRoutes
+		WHERE guildID = ? AND action = ?
+	`, guildID, action).Scan(&channelID)
+	return channelID, wrapNotFoundError(err)
+}
+
+func (m *MysqlMiddleware) GetGuildModLogRoutes(guildID string) (map[models.ModLogAction]string, error) {
+	rows, err := m.Db.Query(`
+		SELECT action, channelID
+		FROM modLogRoutes
+		WHERE guildID = ?
+	`, guildID)
+	if err != nil {
+		return nil, wrapNotFoundError(err)
+	}
+	defer rows.Close()
+
+	res := make(map[models.ModLogAction]string)
+	for rows.Next() {
+		var (
+			action    models.ModLogAction
+			channelID string
+		)
+		if err = rows.Scan(&action, &channelID); err != nil {
+			return nil, err
+		}
+		res[action] = channelID
+	}
+
+	return res, nil
+}
+
+func (m *MysqlMiddleware) SetGuildModLogRoute(guildID string, action models.ModLogAction, channelID string) error {
+	_, err := m.Db.Exec(`
+		INSERT INTO modLogRoutes (guildID, action, channelID)
+		VALUES (?, ?, ?)
+		ON DUPLICATE KEY UPDATE channelID = ?
+	`, guildID, action, channelID, channelID)
+	return err
+}
+
+func (m *MysqlMiddleware) RemoveGuildModLogRoute(guildID string, action models.ModLogAction) error {
+	_, err := m.Db.Exec(`
+		DELETE FROM modLogRoutes
+		WHERE guildID = ? AND action = ?
+	`, guildID, action)
+	return wrapNotFoundError(err)
+}
+
 func (m *MysqlMiddleware) GetGuildVoiceLog(guildID string) (string, error) {
 	val, err := m.getGuildSetting(guildID, "voicelogchanID")
 	return val, err
@@ -667,9 +1129,21 @@ func (m *MysqlMiddleware) SetGuildCodeExecEnabled(guildID string, enabled bool)
 	return m.setGuildSetting(guildID, "codeExecEnabled", val)
 }
 
-func (m *MysqlMiddleware) GetGuildBackup(guildID string) (bool, error) {
-	val, err := m.getGuildSetting(guildID, "backup")
-	return val == "1", err
+func (m *MysqlMiddleware) GetGuildCodeExecLanguages(guildID string) ([]string, error) {
+	val, err := m.getGuildSetting(guildID, "codeExecLanguages")
+	if val == "" {
+		return []string{}, err
+	}
+	return strings.Split(val, ","), err
+}
+
+func (m *MysqlMiddleware) SetGuildCodeExecLanguages(guildID string, languages []string) error {
+	return m.setGuildSetting(guildID, "codeExecLanguages", strings.Join(languages, ","))
+}
+
+func (m *MysqlMiddleware) GetGuildBackup(guildID string) (bool, error) {
+	val, err := m.getGuildSetting(guildID, "backup")
+	return val == "1", err
 }
 
 func (m *MysqlMiddleware) SetGuildBackup(guildID string, enabled bool) error {
@@ -712,16 +1186,42 @@ func (m *MysqlMiddleware) DeleteReport(id snowflake.ID) error {
 	return err
 }
 
+func (m *MysqlMiddleware) RevokeReport(id snowflake.ID, rev models.ReportRevocation) error {
+	res, err := m.Db.Exec(`
+		UPDATE reports
+		SET revokedAt = ?, revokedBy = ?, revokeReason = ?
+		WHERE id = ?`, rev.Timestamp, rev.ExecutorID, rev.Reason, id)
+	if err != nil {
+		return err
+	}
+	ar, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if ar == 0 {
+		return database.ErrDatabaseNotFound
+	}
+	return nil
+}
+
 func (m *MysqlMiddleware) GetReport(id snowflake.ID) (models.Report, error) {
 	rep := models.Report{}
 
+	var rev models.ReportRevocation
+	var revokedAt *time.Time
 	row := m.Db.QueryRow(`
-		SELECT id, type, guildID, executorID, victimID, msg, attachment, timeout
+		SELECT id, type, guildID, executorID, victimID, msg, attachment, timeout,
+			revokedAt, revokedBy, revokeReason
 		FROM reports WHERE id = ?`, id)
-	err := row.Scan(&rep.ID, &rep.Type, &rep.GuildID, &rep.ExecutorID, &rep.VictimID, &rep.Msg, &rep.AttachmentURL, &rep.Timeout)
+	err := row.Scan(&rep.ID, &rep.Type, &rep.GuildID, &rep.ExecutorID, &rep.VictimID, &rep.Msg, &rep.AttachmentURL, &rep.Timeout,
+		&revokedAt, &rev.ExecutorID, &rev.Reason)
 	if err == sql.ErrNoRows {
 		return models.Report{}, database.ErrDatabaseNotFound
 	}
+	if revokedAt != nil {
+		rev.Timestamp = *revokedAt
+		rep.Revocation = &rev
+	}
 
 	return rep, err
 }
@@ -733,7 +1233,7 @@ func (m *MysqlMiddleware) GetReportsGuild(guildID string, offset, limit int) ([]
 
 	rows, err := m.Db.Query(`
 		SELECT id, type, guildID, executorID, victimID, msg, attachment, timeout
-		FROM reports WHERE guildID = ?
+		FROM reports WHERE guildID = ? AND revokedAt IS NULL
 		ORDER BY id DESC
 		LIMIT ?, ?
 	`, guildID, offset, limit)
@@ -755,7 +1255,7 @@ func (m *MysqlMiddleware) GetReportsGuild(guildID string, offset, limit int) ([]
 
 func (m *MysqlMiddleware) GetReportsFiltered(guildID, memberID string, repType models.ReportType, offset, limit int) ([]models.Report, error) {
 	args := []interface{}{}
-	query := `SELECT id, type, guildID, executorID, victimID, msg, attachment, timeout FROM reports WHERE true`
+	query := `SELECT id, type, guildID, executorID, victimID, msg, attachment, timeout FROM reports WHERE revokedAt IS NULL`
 	if guildID != "" {
 		query += " AND guildID = ?"
 		args = append(args, guildID)
@@ -789,7 +1289,43 @@ func (m *MysqlMiddleware) GetReportsFiltered(guildID, memberID string, repType m
 }
 
 func (m *MysqlMiddleware) GetReportsGuildCount(guildID string) (count int, err error) {
-	err = m.Db.QueryRow("SELECT COUNT(id) FROM reports WHERE guildID = ?", guildID).Scan(&count)
+	err = m.Db.QueryRow("SELECT COUNT(id) FROM reports WHERE guildID = ? AND revokedAt IS NULL", guildID).Scan(&count)
+	return
+}
+
+func (m *MysqlMiddleware) GetRevokedReportsGuild(guildID string, offset, limit int) ([]models.Report, error) {
+	if limit == 0 {
+		limit = 1000
+	}
+
+	rows, err := m.Db.Query(`
+		SELECT id, type, guildID, executorID, victimID, msg, attachment, timeout,
+			revokedAt, revokedBy, revokeReason
+		FROM reports WHERE guildID = ? AND revokedAt IS NOT NULL
+		ORDER BY revokedAt DESC
+		LIMIT ?, ?
+	`, guildID, offset, limit)
+	var results []models.Report
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var rep models.Report
+		var rev models.ReportRevocation
+		err := rows.Scan(&rep.ID, &rep.Type, &rep.GuildID, &rep.ExecutorID,
+			&rep.VictimID, &rep.Msg, &rep.AttachmentURL, &rep.Timeout,
+			&rev.Timestamp, &rev.ExecutorID, &rev.Reason)
+		if err != nil {
+			return nil, err
+		}
+		rep.Revocation = &rev
+		results = append(results, rep)
+	}
+	return results, nil
+}
+
+func (m *MysqlMiddleware) GetRevokedReportsGuildCount(guildID string) (count int, err error) {
+	err = m.Db.QueryRow("SELECT COUNT(id) FROM reports WHERE guildID = ? AND revokedAt IS NOT NULL", guildID).Scan(&count)
 	return
 }
 
@@ -799,15 +1335,18 @@ func (m *MysqlMiddleware) GetReportsFilteredCount(guildID, memberID string, repT
 		return
 	}
 
-	query := fmt.Sprintf(`SELECT COUNT(id) FROM reports WHERE guildID = "%s"`, guildID)
+	args := []interface{}{guildID}
+	query := `SELECT COUNT(id) FROM reports WHERE guildID = ? AND revokedAt IS NULL`
 	if memberID != "" {
-		query += fmt.Sprintf(` AND victimID = "%s"`, memberID)
+		query += " AND victimID = ?"
+		args = append(args, memberID)
 	}
-	if repType != -1 {
-		query += fmt.Sprintf(` AND type = %d`, repType)
+	if repType > -1 {
+		query += " AND type = ?"
+		args = append(args, repType)
 	}
 
-	err = m.Db.QueryRow(query).Scan(&count)
+	err = m.Db.QueryRow(query, args...).Scan(&count)
 	return
 }
 
@@ -815,7 +1354,7 @@ func (m *MysqlMiddleware) GetExpiredReports() (results []models.Report, err erro
 	rows, err := m.Db.Query(`
 		SELECT id, type, guildID, executorID, victimID, msg, attachment, timeout
 		FROM reports
-		WHERE timeout <= CURRENT_TIMESTAMP`)
+		WHERE timeout <= CURRENT_TIMESTAMP AND revokedAt IS NULL`)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			err = nil
@@ -854,6 +1393,95 @@ func (m *MysqlMiddleware) ExpireReports(ids ...string) (err error) {
 	return
 }
 
+func (m *MysqlMiddleware) GetReportImport(guildID, externalID string) (reportID snowflake.ID, err error) {
+	err = m.Db.QueryRow(`
+		SELECT reportID FROM reportImports
+		WHERE guildID = ? AND externalID = ?`, guildID, externalID).
+		Scan(&reportID)
+	err = wrapNotFoundError(err)
+	return
+}
+
+func (m *MysqlMiddleware) AddReportImport(guildID, externalID string, reportID snowflake.ID) error {
+	_, err := m.Db.Exec(`
+		INSERT INTO reportImports (guildID, externalID, reportID)
+		VALUES (?, ?, ?)`, guildID, externalID, reportID)
+	return err
+}
+
+func (m *MysqlMiddleware) GetGuildReportTypes(guildID string) (res []models.CustomReportType, err error) {
+	rows, err := m.Db.Query(
+		"SELECT typeID, name, color FROM guildReportTypes WHERE guildID = ? ORDER BY typeID ASC", guildID)
+	err = wrapNotFoundError(err)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	res = make([]models.CustomReportType, 0)
+	for rows.Next() {
+		t := models.CustomReportType{GuildID: guildID}
+		if err = rows.Scan(&t.ID, &t.Name, &t.Color); err != nil {
+			return
+		}
+		res = append(res, t)
+	}
+
+	return
+}
+
+func (m *MysqlMiddleware) SetGuildReportType(t models.CustomReportType) (err error) {
+	_, err = m.Db.Exec(`
+		INSERT INTO guildReportTypes (guildID, typeID, name, color)
+		VALUES (?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE name = ?, color = ?`,
+		t.GuildID, t.ID, t.Name, t.Color, t.Name, t.Color)
+	return
+}
+
+func (m *MysqlMiddleware) DeleteGuildReportType(guildID string, id models.ReportType) (err error) {
+	res, err := m.Db.Exec("DELETE FROM guildReportTypes WHERE guildID = ? AND typeID = ?", guildID, id)
+	if err != nil {
+		return
+	}
+	ar, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+	if ar == 0 {
+		err = database.ErrDatabaseNotFound
+	}
+	return
+}
+
+func (m *MysqlMiddleware) GetGuildReportEscalation(guildID string) (e models.ReportEscalation, err error) {
+	var data string
+	err = m.Db.QueryRow("SELECT data FROM reportEscalation WHERE guildID = ?", guildID).
+		Scan(&data)
+	err = wrapNotFoundError(err)
+	if err != nil {
+		return
+	}
+
+	err = json.Unmarshal([]byte(data), &e)
+	e.GuildID = guildID
+	return
+}
+
+func (m *MysqlMiddleware) SetGuildReportEscalation(e models.ReportEscalation) (err error) {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+
+	_, err = m.Db.Exec(`
+		INSERT INTO reportEscalation (guildID, data)
+		VALUES (?, ?)
+		ON DUPLICATE KEY UPDATE data = ?`,
+		e.GuildID, string(data), string(data))
+	return
+}
+
 func (m *MysqlMiddleware) GetVotes() (map[string]vote.Vote, error) {
 	rows, err := m.Db.Query("SELECT id, data FROM votes")
 	results := make(map[string]vote.Vote)
@@ -900,15 +1528,19 @@ func (m *MysqlMiddleware) GetTwitchNotify(twitchUserID, guildID string) (twitchn
 		TwitchUserID: twitchUserID,
 		GuildID:      guildID,
 	}
-	err := m.Db.QueryRow("SELECT channelID FROM twitchnotify WHERE twitchUserID = ? AND guildID = ?",
-		twitchUserID, guildID).Scan(&t.ChannelID)
+	err := m.Db.QueryRow(
+		"SELECT channelID, template, roleID, plain FROM twitchnotify WHERE twitchUserID = ? AND guildID = ?",
+		twitchUserID, guildID).Scan(&t.ChannelID, &t.Template, &t.RoleID, &t.Plain)
 	err = wrapNotFoundError(err)
 	return t, err
 }
 
 func (m *MysqlMiddleware) SetTwitchNotify(twitchNotify twitchnotify.DBEntry) error {
-	res, err := m.Db.Exec("UPDATE twitchnotify SET channelID = ? WHERE twitchUserID = ? AND guildID = ?",
-		twitchNotify.ChannelID, twitchNotify.TwitchUserID, twitchNotify.GuildID)
+	res, err := m.Db.Exec(
+		"UPDATE twitchnotify SET channelID = ?, template = ?, roleID = ?, plain = ? "+
+			"WHERE twitchUserID = ? AND guildID = ?",
+		twitchNotify.ChannelID, twitchNotify.Template, twitchNotify.RoleID, twitchNotify.Plain,
+		twitchNotify.TwitchUserID, twitchNotify.GuildID)
 	if err != nil {
 		return err
 	}
@@ -917,8 +1549,11 @@ func (m *MysqlMiddleware) SetTwitchNotify(twitchNotify twitchnotify.DBEntry) err
 		return err
 	}
 	if ar == 0 {
-		_, err = m.Db.Exec("INSERT INTO twitchnotify (twitchUserID, guildID, channelID) VALUES (?, ?, ?)",
-			twitchNotify.TwitchUserID, twitchNotify.GuildID, twitchNotify.ChannelID)
+		_, err = m.Db.Exec(
+			"INSERT INTO twitchnotify (twitchUserID, guildID, channelID, template, roleID, plain) "+
+				"VALUES (?, ?, ?, ?, ?, ?)",
+			twitchNotify.TwitchUserID, twitchNotify.GuildID, twitchNotify.ChannelID,
+			twitchNotify.Template, twitchNotify.RoleID, twitchNotify.Plain)
 	}
 	return err
 }
@@ -929,18 +1564,20 @@ func (m *MysqlMiddleware) DeleteTwitchNotify(twitchUserID, guildID string) error
 }
 
 func (m *MysqlMiddleware) GetAllTwitchNotifies(twitchUserID string) ([]twitchnotify.DBEntry, error) {
-	query := "SELECT twitchUserID, guildID, channelID FROM twitchnotify"
+	args := []interface{}{}
+	query := "SELECT twitchUserID, guildID, channelID, template, roleID, plain FROM twitchnotify"
 	if twitchUserID != "" {
-		query += " WHERE twitchUserID = " + twitchUserID
+		query += " WHERE twitchUserID = ?"
+		args = append(args, twitchUserID)
 	}
-	rows, err := m.Db.Query(query)
+	rows, err := m.Db.Query(query, args...)
 	results := make([]twitchnotify.DBEntry, 0)
 	if err != nil {
 		return nil, err
 	}
 	for rows.Next() {
 		var t twitchnotify.DBEntry
-		err = rows.Scan(&t.TwitchUserID, &t.GuildID, &t.ChannelID)
+		err = rows.Scan(&t.TwitchUserID, &t.GuildID, &t.ChannelID, &t.Template, &t.RoleID, &t.Plain)
 		if err == nil {
 			results = append(results, t)
 		}
@@ -948,9 +1585,9 @@ func (m *MysqlMiddleware) GetAllTwitchNotifies(twitchUserID string) ([]twitchnot
 	return results, nil
 }
 
-func (m *MysqlMiddleware) AddBackup(guildID, fileID string) error {
+func (m *MysqlMiddleware) AddBackup(guildID, fileID string, manual bool) error {
 	timestamp := time.Now().Unix()
-	_, err := m.Db.Exec("INSERT INTO backups (guildID, timestamp, fileID) VALUES (?, ?, ?)", guildID, timestamp, fileID)
+	_, err := m.Db.Exec("INSERT INTO backups (guildID, timestamp, fileID, manual) VALUES (?, ?, ?, ?)", guildID, timestamp, fileID, manual)
 	return err
 }
 
@@ -1010,7 +1647,7 @@ func (m *MysqlMiddleware) SetGuildLeaveMsg(guildID string, channelID string, msg
 }
 
 func (m *MysqlMiddleware) GetBackups(guildID string) ([]backupmodels.Entry, error) {
-	rows, err := m.Db.Query("SELECT guildID, timestamp, fileID FROM backups WHERE guildID = ?", guildID)
+	rows, err := m.Db.Query("SELECT guildID, timestamp, fileID, manual FROM backups WHERE guildID = ?", guildID)
 	if err == sql.ErrNoRows {
 		return nil, database.ErrDatabaseNotFound
 	}
@@ -1022,7 +1659,7 @@ func (m *MysqlMiddleware) GetBackups(guildID string) ([]backupmodels.Entry, erro
 	for rows.Next() {
 		var be backupmodels.Entry
 		var timeStampUnix int64
-		err = rows.Scan(&be.GuildID, &timeStampUnix, &be.FileID)
+		err = rows.Scan(&be.GuildID, &timeStampUnix, &be.FileID, &be.Manual)
 		if err != nil {
 			return nil, err
 		}
@@ -1178,6 +1815,13 @@ func (m *MysqlMiddleware) SetAPIToken(token models.APITokenEntry) (err error) {
 	return
 }
 
+func (m *MysqlMiddleware) AddAPITokenHit(userID string, t time.Time) (err error) {
+	_, err = m.Db.Exec(
+		"UPDATE apitokens SET hits = hits + 1, lastAccess = ? WHERE userID = ?",
+		t, userID)
+	return
+}
+
 func (m *MysqlMiddleware) GetAPIToken(userID string) (t models.APITokenEntry, err error) {
 	err = m.Db.QueryRow(
 		"SELECT userID, salt, created, expires, lastAccess, hits "+
@@ -1259,6 +1903,11 @@ func (m *MysqlMiddleware) SetKarma(userID, guildID string, val int) (err error)
 	return
 }
 
+func (m *MysqlMiddleware) ResetKarmaGuild(guildID string) (err error) {
+	_, err = m.Db.Exec("DELETE FROM karma WHERE guildID = ?", guildID)
+	return
+}
+
 func (m *MysqlMiddleware) UpdateKarma(userID, guildID string, diff int) (err error) {
 	res, err := m.Db.Exec("UPDATE karma SET value = value + ? WHERE userID = ? AND guildID = ?",
 		diff, userID, guildID)
@@ -1350,6 +1999,26 @@ func (m *MysqlMiddleware) GetKarmaPenalty(guildID string) (state bool, err error
 	return
 }
 
+func (m *MysqlMiddleware) SetKarmaLimits(guildID string, limits models.KarmaLimits) (err error) {
+	_, err = m.Db.Exec(
+		"INSERT INTO karmaSettings (guildID, giveCooldown, giveDailyCap, receiveCap, receiveInterval) "+
+			"VALUES (?, ?, ?, ?, ?) "+
+			"ON DUPLICATE KEY UPDATE giveCooldown = ?, giveDailyCap = ?, receiveCap = ?, receiveInterval = ?",
+		guildID, limits.GiveCooldown, limits.GiveDailyCap, limits.ReceiveCap, limits.ReceiveInterval,
+		limits.GiveCooldown, limits.GiveDailyCap, limits.ReceiveCap, limits.ReceiveInterval)
+
+	return
+}
+
+func (m *MysqlMiddleware) GetKarmaLimits(guildID string) (limits models.KarmaLimits, err error) {
+	err = m.Db.QueryRow(
+		"SELECT giveCooldown, giveDailyCap, receiveCap, receiveInterval FROM karmaSettings WHERE guildID = ?",
+		guildID).Scan(&limits.GiveCooldown, &limits.GiveDailyCap, &limits.ReceiveCap, &limits.ReceiveInterval)
+	err = wrapNotFoundError(err)
+
+	return
+}
+
 func (m *MysqlMiddleware) GetKarmaBlockList(guildID string) (list []string, err error) {
 	row, err := m.Db.Query("SELECT userID FROM karmaBlocklist WHERE guildID = ?", guildID)
 	err = wrapNotFoundError(err)
@@ -1824,8 +2493,8 @@ func (m *MysqlMiddleware) GetStarboardEntries(
 
 	query := fmt.Sprintf("SELECT messageID, starboardID, guildID, channelID, authorID, content, mediaURLs, score, deleted "+
 		"FROM starboardEntries "+
-		"WHERE guildID = ? %s LIMIT %d OFFSET %d", sort, limit, offset)
-	row, err := m.Db.Query(query, guildID)
+		"WHERE guildID = ? %s LIMIT ? OFFSET ?", sort)
+	row, err := m.Db.Query(query, guildID, limit, offset)
 	err = wrapNotFoundError(err)
 	if err != nil {
 		return
@@ -1994,6 +2663,142 @@ func (m *MysqlMiddleware) SetGuildLogDisable(guildID string, enabled bool) error
 	return m.setGuildSetting(guildID, "guildlogDisable", val)
 }
 
+func (m *MysqlMiddleware) GetGuildCommandSuggestionsDisable(guildID string) (bool, error) {
+	val, err := m.getGuildSetting(guildID, "cmdSuggestDisable")
+	return val == "1", err
+}
+
+func (m *MysqlMiddleware) SetGuildCommandSuggestionsDisable(guildID string, disabled bool) error {
+	var val string
+	if disabled {
+		val = "1"
+	}
+	return m.setGuildSetting(guildID, "cmdSuggestDisable", val)
+}
+
+func (m *MysqlMiddleware) GetGuildEmbedColor(guildID string) (int, error) {
+	val, err := m.getGuildSetting(guildID, "embedColor")
+	if err != nil {
+		return 0, err
+	}
+	if val == "" {
+		return 0, database.ErrDatabaseNotFound
+	}
+	return strconv.Atoi(val)
+}
+
+func (m *MysqlMiddleware) SetGuildEmbedColor(guildID string, color int) error {
+	var val string
+	if color >= 0 {
+		val = strconv.Itoa(color)
+	}
+	return m.setGuildSetting(guildID, "embedColor", val)
+}
+
+func (m *MysqlMiddleware) GetGuildDisabledCommands(guildID string) (res []string, err error) {
+	rows, err := m.Db.Query("SELECT command FROM guildDisabledCommands WHERE guildID = ?", guildID)
+	err = wrapNotFoundError(err)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	res = make([]string, 0)
+	var cmd string
+	for rows.Next() {
+		if err = rows.Scan(&cmd); err != nil {
+			return
+		}
+		res = append(res, cmd)
+	}
+
+	return
+}
+
+func (m *MysqlMiddleware) SetGuildDisabledCommand(guildID, command string, disabled bool) (err error) {
+	if disabled {
+		_, err = m.Db.Exec("INSERT IGNORE INTO guildDisabledCommands (guildID, command) VALUES (?, ?)",
+			guildID, command)
+	} else {
+		_, err = m.Db.Exec("DELETE FROM guildDisabledCommands WHERE guildID = ? AND command = ?",
+			guildID, command)
+	}
+	return
+}
+
+func (m *MysqlMiddleware) GetGuildDisabledCommandMessage(guildID string) (string, bool, error) {
+	data, err := m.getGuildSetting(guildID, "disabledCmdMsg")
+	if err != nil || data == "" {
+		return "", false, err
+	}
+
+	i := strings.Index(data, "|")
+	if i < 0 {
+		return "", false, nil
+	}
+
+	return data[i+1:], data[:i] == "1", nil
+}
+
+func (m *MysqlMiddleware) SetGuildDisabledCommandMessage(guildID string, msg string, silent bool) error {
+	silentS := "0"
+	if silent {
+		silentS = "1"
+	}
+	return m.setGuildSetting(guildID, "disabledCmdMsg", fmt.Sprintf("%s|%s", silentS, msg))
+}
+
+func (m *MysqlMiddleware) GetGuildConfirmActions(guildID string) ([]models.ConfirmAction, error) {
+	val, err := m.getGuildSetting(guildID, "confirmActions")
+	if val == "" {
+		return []models.ConfirmAction{}, err
+	}
+	split := strings.Split(val, ",")
+	actions := make([]models.ConfirmAction, len(split))
+	for i, a := range split {
+		actions[i] = models.ConfirmAction(a)
+	}
+	return actions, err
+}
+
+func (m *MysqlMiddleware) SetGuildConfirmActions(guildID string, actions []models.ConfirmAction) error {
+	split := make([]string, len(actions))
+	for i, a := range actions {
+		split[i] = string(a)
+	}
+	return m.setGuildSetting(guildID, "confirmActions", strings.Join(split, ","))
+}
+
+func (m *MysqlMiddleware) GetGuildPermDeniedMessage(guildID string) (string, bool, error) {
+	data, err := m.getGuildSetting(guildID, "permDeniedMsg")
+	if err != nil || data == "" {
+		return "", false, err
+	}
+
+	i := strings.Index(data, "|")
+	if i < 0 {
+		return "", false, nil
+	}
+
+	return data[i+1:], data[:i] == "1", nil
+}
+
+func (m *MysqlMiddleware) SetGuildPermDeniedMessage(guildID string, msg string, silent bool) error {
+	silentS := "0"
+	if silent {
+		silentS = "1"
+	}
+	return m.setGuildSetting(guildID, "permDeniedMsg", fmt.Sprintf("%s|%s", silentS, msg))
+}
+
+func (m *MysqlMiddleware) GetGuildModmailChannel(guildID string) (string, error) {
+	return m.getGuildSetting(guildID, "modmailChanID")
+}
+
+func (m *MysqlMiddleware) SetGuildModmailChannel(guildID, chanID string) error {
+	return m.setGuildSetting(guildID, "modmailChanID", chanID)
+}
+
 func (m *MysqlMiddleware) GetGuildLogEntries(
 	guildID string,
 	offset, limit int,
@@ -2363,6 +3168,75 @@ func (m *MysqlMiddleware) RemoveRoleSelect(guildID, channelID, messageID string)
 	return wrapNotFoundError(err)
 }
 
+func (m *MysqlMiddleware) GetGuildAnnouncementChannel(guildID string) (string, error) {
+	return m.getGuildSetting(guildID, "announcementChannel")
+}
+
+func (m *MysqlMiddleware) SetGuildAnnouncementChannel(guildID, chanID string) error {
+	return m.setGuildSetting(guildID, "announcementChannel", chanID)
+}
+
+func (m *MysqlMiddleware) GetGuildMemberMsgGrace(guildID string) (time.Duration, bool, error) {
+	data, err := m.getGuildSetting(guildID, "memberMsgGrace")
+	if err != nil || data == "" {
+		return 0, false, err
+	}
+
+	i := strings.Index(data, "|")
+	if i < 0 {
+		return 0, false, nil
+	}
+
+	seconds, err := strconv.Atoi(data[i+1:])
+	if err != nil {
+		return 0, false, err
+	}
+
+	return time.Duration(seconds) * time.Second, data[:i] == "1", nil
+}
+
+func (m *MysqlMiddleware) SetGuildMemberMsgGrace(guildID string, period time.Duration, noteRejoin bool) error {
+	noteS := "0"
+	if noteRejoin {
+		noteS = "1"
+	}
+	return m.setGuildSetting(guildID, "memberMsgGrace", fmt.Sprintf("%s|%d", noteS, int(period.Seconds())))
+}
+
+func (m *MysqlMiddleware) GetGuildPinRotation(guildID string) (bool, error) {
+	val, err := m.getGuildSetting(guildID, "pinRotation")
+	return val == "1", err
+}
+
+func (m *MysqlMiddleware) SetGuildPinRotation(guildID string, enabled bool) error {
+	var val string
+	if enabled {
+		val = "1"
+	}
+	return m.setGuildSetting(guildID, "pinRotation", val)
+}
+
+func (m *MysqlMiddleware) GetGuildMessageStatsEnabled(guildID string) (bool, error) {
+	val, err := m.getGuildSetting(guildID, "messageStats")
+	return val == "1", err
+}
+
+func (m *MysqlMiddleware) SetGuildMessageStatsEnabled(guildID string, enabled bool) error {
+	var val string
+	if enabled {
+		val = "1"
+	}
+	return m.setGuildSetting(guildID, "messageStats", val)
+}
+
+func (m *MysqlMiddleware) GetGuildBotNickname(guildID string) (string, error) {
+	return m.getGuildSetting(guildID, "botNickname")
+}
+
+func (m *MysqlMiddleware) SetGuildBotNickname(guildID, nickname string) error {
+	return m.setGuildSetting(guildID, "botNickname", nickname)
+}
+
 func (m *MysqlMiddleware) GetGuildModNot(guildID string) (string, error) {
 	val, err := m.getGuildSetting(guildID, "modnotchanID")
 	return val, err
@@ -2372,11 +3246,1080 @@ func (m *MysqlMiddleware) SetGuildModNot(guildID, chanID string) error {
 	return m.setGuildSetting(guildID, "modnotchanID", chanID)
 }
 
-/////////// HELPER ///////////////
+func (m *MysqlMiddleware) GetAutoDelete(guildID, channelID string) (cfg models.AutoDeleteConfig, err error) {
+	// Channel specific configs take precedence over the
+	// guild wide default config with an empty channelID.
+	err = m.Db.QueryRow(`
+		SELECT guildID, channelID, delay, errorDelay
+		FROM autodelete
+		WHERE guildID = ? AND (channelID = ? OR channelID = '')
+		ORDER BY channelID DESC
+		LIMIT 1
+	`, guildID, channelID).
+		Scan(&cfg.GuildID, &cfg.ChannelID, &cfg.Delay, &cfg.ErrorDelay)
+	return cfg, wrapNotFoundError(err)
+}
 
-func wrapNotFoundError(err error) error {
-	if err == sql.ErrNoRows {
-		err = database.ErrDatabaseNotFound
+func (m *MysqlMiddleware) GetAutoDeletes(guildID string) ([]models.AutoDeleteConfig, error) {
+	rows, err := m.Db.Query(`
+		SELECT guildID, channelID, delay, errorDelay
+		FROM autodelete
+		WHERE guildID = ?
+	`, guildID)
+	if err != nil {
+		return nil, wrapNotFoundError(err)
 	}
-	return err
+
+	var res []models.AutoDeleteConfig
+	for rows.Next() {
+		var cfg models.AutoDeleteConfig
+		err = rows.Scan(&cfg.GuildID, &cfg.ChannelID, &cfg.Delay, &cfg.ErrorDelay)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, cfg)
+	}
+
+	return res, nil
+}
+
+func (m *MysqlMiddleware) SetAutoDelete(cfg models.AutoDeleteConfig) error {
+	_, err := m.Db.Exec(`
+		INSERT INTO autodelete (guildID, channelID, delay, errorDelay)
+		VALUES (?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE delay = ?, errorDelay = ?
+	`, cfg.GuildID, cfg.ChannelID, cfg.Delay, cfg.ErrorDelay, cfg.Delay, cfg.ErrorDelay)
+	return err
+}
+
+func (m *MysqlMiddleware) RemoveAutoDelete(guildID, channelID string) error {
+	_, err := m.Db.Exec(`
+		DELETE FROM autodelete
+		WHERE guildID = ? AND channelID = ?
+	`, guildID, channelID)
+	return wrapNotFoundError(err)
+}
+
+func (m *MysqlMiddleware) GetAutoThread(guildID, channelID string) (cfg models.AutoThreadConfig, err error) {
+	err = m.Db.QueryRow(`
+		SELECT guildID, channelID, attachmentsOnly, nameSource, cooldown
+		FROM autoThreads
+		WHERE guildID = ? AND channelID = ?
+	`, guildID, channelID).
+		Scan(&cfg.GuildID, &cfg.ChannelID, &cfg.AttachmentsOnly, &cfg.NameSource, &cfg.Cooldown)
+	return cfg, wrapNotFoundError(err)
+}
+
+func (m *MysqlMiddleware) GetAutoThreads(guildID string) ([]models.AutoThreadConfig, error) {
+	rows, err := m.Db.Query(`
+		SELECT guildID, channelID, attachmentsOnly, nameSource, cooldown
+		FROM autoThreads
+		WHERE guildID = ?
+	`, guildID)
+	if err != nil {
+		return nil, wrapNotFoundError(err)
+	}
+	defer rows.Close()
+
+	res := make([]models.AutoThreadConfig, 0)
+	for rows.Next() {
+		var cfg models.AutoThreadConfig
+		err = rows.Scan(&cfg.GuildID, &cfg.ChannelID, &cfg.AttachmentsOnly, &cfg.NameSource, &cfg.Cooldown)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, cfg)
+	}
+
+	return res, nil
+}
+
+func (m *MysqlMiddleware) SetAutoThread(cfg models.AutoThreadConfig) error {
+	_, err := m.Db.Exec(`
+		INSERT INTO autoThreads (guildID, channelID, attachmentsOnly, nameSource, cooldown)
+		VALUES (?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE attachmentsOnly = ?, nameSource = ?, cooldown = ?
+	`, cfg.GuildID, cfg.ChannelID, cfg.AttachmentsOnly, cfg.NameSource, cfg.Cooldown,
+		cfg.AttachmentsOnly, cfg.NameSource, cfg.Cooldown)
+	return err
+}
+
+func (m *MysqlMiddleware) RemoveAutoThread(guildID, channelID string) error {
+	_, err := m.Db.Exec(`
+		DELETE FROM autoThreads
+		WHERE guildID = ? AND channelID = ?
+	`, guildID, channelID)
+	return wrapNotFoundError(err)
+}
+
+func (m *MysqlMiddleware) GetStickyMessage(guildID, channelID string) (sm models.StickyMessage, err error) {
+	err = m.Db.QueryRow(`
+		SELECT guildID, channelID, content, messageID
+		FROM stickyMessages
+		WHERE guildID = ? AND channelID = ?
+	`, guildID, channelID).
+		Scan(&sm.GuildID, &sm.ChannelID, &sm.Content, &sm.MessageID)
+	return sm, wrapNotFoundError(err)
+}
+
+func (m *MysqlMiddleware) GetStickyMessages(guildID string) ([]models.StickyMessage, error) {
+	rows, err := m.Db.Query(`
+		SELECT guildID, channelID, content, messageID
+		FROM stickyMessages
+		WHERE guildID = ?
+	`, guildID)
+	if err != nil {
+		return nil, wrapNotFoundError(err)
+	}
+	defer rows.Close()
+
+	res := make([]models.StickyMessage, 0)
+	for rows.Next() {
+		var sm models.StickyMessage
+		err = rows.Scan(&sm.GuildID, &sm.ChannelID, &sm.Content, &sm.MessageID)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, sm)
+	}
+
+	return res, nil
+}
+
+func (m *MysqlMiddleware) SetStickyMessage(sm models.StickyMessage) error {
+	_, err := m.Db.Exec(`
+		INSERT INTO stickyMessages (guildID, channelID, content, messageID)
+		VALUES (?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE content = ?, messageID = ?
+	`, sm.GuildID, sm.ChannelID, sm.Content, sm.MessageID,
+		sm.Content, sm.MessageID)
+	return err
+}
+
+func (m *MysqlMiddleware) RemoveStickyMessage(guildID, channelID string) error {
+	_, err := m.Db.Exec(`
+		DELETE FROM stickyMessages
+		WHERE guildID = ? AND channelID = ?
+	`, guildID, channelID)
+	return wrapNotFoundError(err)
+}
+
+func splitWordFilterList(val string) []string {
+	res := make([]string, 0)
+	for _, v := range strings.Split(val, ";") {
+		if v != "" {
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
+func (m *MysqlMiddleware) GetWordFilterSettings(guildID string) (s models.WordFilterSettings, err error) {
+	var exemptRoles, exemptChannels string
+	err = m.Db.QueryRow(`
+		SELECT guildID, enabled, action, muteDuration, exemptRoles, exemptChannels
+		FROM wordFilterSettings
+		WHERE guildID = ?
+	`, guildID).
+		Scan(&s.GuildID, &s.Enabled, &s.Action, &s.MuteDuration, &exemptRoles, &exemptChannels)
+	s.ExemptRoles = splitWordFilterList(exemptRoles)
+	s.ExemptChannels = splitWordFilterList(exemptChannels)
+	return s, wrapNotFoundError(err)
+}
+
+func (m *MysqlMiddleware) SetWordFilterSettings(s models.WordFilterSettings) error {
+	exemptRoles := strings.Join(s.ExemptRoles, ";")
+	exemptChannels := strings.Join(s.ExemptChannels, ";")
+	_, err := m.Db.Exec(`
+		INSERT INTO wordFilterSettings (guildID, enabled, action, muteDuration, exemptRoles, exemptChannels)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE enabled = ?, action = ?, muteDuration = ?, exemptRoles = ?, exemptChannels = ?
+	`, s.GuildID, s.Enabled, s.Action, s.MuteDuration, exemptRoles, exemptChannels,
+		s.Enabled, s.Action, s.MuteDuration, exemptRoles, exemptChannels)
+	return err
+}
+
+func (m *MysqlMiddleware) GetWordFilterEntries(guildID string) ([]models.WordFilterEntry, error) {
+	rows, err := m.Db.Query(`
+		SELECT guildID, pattern, mode
+		FROM wordFilterEntries
+		WHERE guildID = ?
+		ORDER BY pattern ASC
+	`, guildID)
+	if err != nil {
+		return nil, wrapNotFoundError(err)
+	}
+	defer rows.Close()
+
+	res := make([]models.WordFilterEntry, 0)
+	for rows.Next() {
+		var e models.WordFilterEntry
+		if err = rows.Scan(&e.GuildID, &e.Pattern, &e.Mode); err != nil {
+			return nil, err
+		}
+		res = append(res, e)
+	}
+
+	return res, nil
+}
+
+func (m *MysqlMiddleware) SetWordFilterEntry(e models.WordFilterEntry) error {
+	_, err := m.Db.Exec(`
+		INSERT INTO wordFilterEntries (guildID, pattern, mode)
+		VALUES (?, ?, ?)
+		ON DUPLICATE KEY UPDATE mode = ?
+	`, e.GuildID, e.Pattern, e.Mode, e.Mode)
+	return err
+}
+
+func (m *MysqlMiddleware) RemoveWordFilterEntry(guildID, pattern string) error {
+	_, err := m.Db.Exec(`
+		DELETE FROM wordFilterEntries
+		WHERE guildID = ? AND pattern = ?
+	`, guildID, pattern)
+	return wrapNotFoundError(err)
+}
+
+func (m *MysqlMiddleware) AddSettingsAuditEntry(e models.SettingsAuditEntry) error {
+	_, err := m.Db.Exec(`
+		INSERT INTO settingsAudit (id, guildID, actorID, field, oldValue, newValue, `+"`timestamp`"+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`, e.ID, e.GuildID, e.ActorID, e.Field, e.OldValue, e.NewValue, e.Timestamp)
+	return err
+}
+
+func (m *MysqlMiddleware) GetSettingsAuditEntries(guildID string, offset, limit int) ([]models.SettingsAuditEntry, error) {
+	rows, err := m.Db.Query(`
+		SELECT id, actorID, field, oldValue, newValue, `+"`timestamp`"+`
+		FROM settingsAudit
+		WHERE guildID = ?
+		ORDER BY `+"`timestamp`"+` DESC
+		LIMIT ?, ?
+	`, guildID, offset, limit)
+	if err != nil {
+		return nil, wrapNotFoundError(err)
+	}
+
+	res := make([]models.SettingsAuditEntry, 0)
+	for rows.Next() {
+		e := models.SettingsAuditEntry{GuildID: guildID}
+		err = rows.Scan(&e.ID, &e.ActorID, &e.Field, &e.OldValue, &e.NewValue, &e.Timestamp)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, e)
+	}
+
+	return res, nil
+}
+
+func (m *MysqlMiddleware) GetSettingsAuditEntriesCount(guildID string) (n int, err error) {
+	err = m.Db.QueryRow(
+		"SELECT COUNT(id) FROM settingsAudit WHERE guildID = ?",
+		guildID).Scan(&n)
+	return n, wrapNotFoundError(err)
+}
+
+func (m *MysqlMiddleware) GetModmailThread(threadID string) (t models.ModmailThread, err error) {
+	err = m.Db.QueryRow(
+		"SELECT threadID, guildID, userID FROM modmailThreads WHERE threadID = ?",
+		threadID).Scan(&t.ThreadID, &t.GuildID, &t.UserID)
+	err = wrapNotFoundError(err)
+	return
+}
+
+func (m *MysqlMiddleware) GetModmailThreadsByUser(userID string) ([]models.ModmailThread, error) {
+	rows, err := m.Db.Query(
+		"SELECT threadID, guildID, userID FROM modmailThreads WHERE userID = ?",
+		userID)
+	if err != nil {
+		return nil, wrapNotFoundError(err)
+	}
+
+	res := make([]models.ModmailThread, 0)
+	for rows.Next() {
+		var t models.ModmailThread
+		if err = rows.Scan(&t.ThreadID, &t.GuildID, &t.UserID); err != nil {
+			return nil, err
+		}
+		res = append(res, t)
+	}
+
+	return res, nil
+}
+
+func (m *MysqlMiddleware) SetModmailThread(t models.ModmailThread) error {
+	_, err := m.Db.Exec(
+		"INSERT INTO modmailThreads (threadID, guildID, userID) VALUES (?, ?, ?)",
+		t.ThreadID, t.GuildID, t.UserID)
+	return err
+}
+
+func (m *MysqlMiddleware) RemoveModmailThread(threadID string) error {
+	_, err := m.Db.Exec("DELETE FROM modmailThreads WHERE threadID = ?", threadID)
+	return err
+}
+
+func (m *MysqlMiddleware) GetGuildRules(guildID string) (r models.GuildRules, err error) {
+	err = m.Db.QueryRow(
+		"SELECT guildID, channelID, messageID, roleID, content, version "+
+			"FROM guildRules WHERE guildID = ?",
+		guildID).Scan(&r.GuildID, &r.ChannelID, &r.MessageID, &r.RoleID, &r.Content, &r.Version)
+	err = wrapNotFoundError(err)
+	return
+}
+
+func (m *MysqlMiddleware) GetAllGuildRules() ([]models.GuildRules, error) {
+	rows, err := m.Db.Query(
+		"SELECT guildID, channelID, messageID, roleID, content, version FROM guildRules")
+	if err != nil {
+		return nil, wrapNotFoundError(err)
+	}
+
+	res := make([]models.GuildRules, 0)
+	for rows.Next() {
+		var r models.GuildRules
+		err = rows.Scan(&r.GuildID, &r.ChannelID, &r.MessageID, &r.RoleID, &r.Content, &r.Version)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, r)
+	}
+
+	return res, nil
+}
+
+func (m *MysqlMiddleware) SetGuildRules(r models.GuildRules) error {
+	_, err := m.Db.Exec(
+		"INSERT INTO guildRules (guildID, channelID, messageID, roleID, content, version) "+
+			"VALUES (?, ?, ?, ?, ?, ?) "+
+			"ON DUPLICATE KEY UPDATE channelID = ?, messageID = ?, roleID = ?, content = ?, version = ?",
+		r.GuildID, r.ChannelID, r.MessageID, r.RoleID, r.Content, r.Version,
+		r.ChannelID, r.MessageID, r.RoleID, r.Content, r.Version)
+	return err
+}
+
+func (m *MysqlMiddleware) GetRulesAcceptance(guildID, userID string) (version int, err error) {
+	err = m.Db.QueryRow(
+		"SELECT version FROM rulesAcceptances WHERE guildID = ? AND userID = ?",
+		guildID, userID).Scan(&version)
+	err = wrapNotFoundError(err)
+	return
+}
+
+func (m *MysqlMiddleware) SetRulesAcceptance(guildID, userID string, version int) error {
+	_, err := m.Db.Exec(
+		"INSERT INTO rulesAcceptances (guildID, userID, version) VALUES (?, ?, ?) "+
+			"ON DUPLICATE KEY UPDATE version = ?, timestamp = CURRENT_TIMESTAMP()",
+		guildID, userID, version, version)
+	return err
+}
+
+/////////// HELPER ///////////////
+
+func wrapNotFoundError(err error) error {
+	if err == sql.ErrNoRows {
+		err = database.ErrDatabaseNotFound
+	}
+	return err
+}
+
+const giveawayColumns = "id, guildID, channelID, messageID, creatorID, prize, " +
+	"winners, roleID, minKarma, expires, ended, winnerIDs"
+
+func scanGiveaway(row interface{ Scan(...interface{}) error }) (g models.Giveaway, err error) {
+	var winnerIDs string
+	err = row.Scan(&g.ID, &g.GuildID, &g.ChannelID, &g.MessageID, &g.CreatorID, &g.Prize,
+		&g.Winners, &g.RoleID, &g.MinKarma, &g.Expires, &g.Ended, &winnerIDs)
+	g.WinnerIDs = []string{}
+	if winnerIDs != "" {
+		g.WinnerIDs = strings.Split(winnerIDs, ",")
+	}
+	return
+}
+
+func (m *MysqlMiddleware) GetGiveaway(id snowflake.ID) (g models.Giveaway, err error) {
+	g, err = scanGiveaway(m.Db.QueryRow(
+		"SELECT "+giveawayColumns+" FROM giveaways WHERE id = ?", id))
+	err = wrapNotFoundError(err)
+	return
+}
+
+func (m *MysqlMiddleware) GetGiveawayByMessage(messageID string) (g models.Giveaway, err error) {
+	g, err = scanGiveaway(m.Db.QueryRow(
+		"SELECT "+giveawayColumns+" FROM giveaways WHERE messageID = ?", messageID))
+	err = wrapNotFoundError(err)
+	return
+}
+
+func (m *MysqlMiddleware) GetGiveaways(guildID string, activeOnly bool) ([]models.Giveaway, error) {
+	query := "SELECT " + giveawayColumns + " FROM giveaways WHERE 1"
+	args := make([]interface{}, 0, 1)
+	if guildID != "" {
+		query += " AND guildID = ?"
+		args = append(args, guildID)
+	}
+	if activeOnly {
+		query += " AND ended = 0"
+	}
+	query += " ORDER BY expires DESC"
+
+	rows, err := m.Db.Query(query, args...)
+	if err != nil {
+		return nil, wrapNotFoundError(err)
+	}
+	defer rows.Close()
+
+	res := make([]models.Giveaway, 0)
+	for rows.Next() {
+		g, err := scanGiveaway(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, g)
+	}
+
+	return res, nil
+}
+
+func (m *MysqlMiddleware) SetGiveaway(g models.Giveaway) error {
+	winnerIDs := strings.Join(g.WinnerIDs, ",")
+	_, err := m.Db.Exec(
+		"INSERT INTO giveaways ("+giveawayColumns+") "+
+			"VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) "+
+			"ON DUPLICATE KEY UPDATE channelID = ?, messageID = ?, prize = ?, winners = ?, "+
+			"roleID = ?, minKarma = ?, expires = ?, ended = ?, winnerIDs = ?",
+		g.ID, g.GuildID, g.ChannelID, g.MessageID, g.CreatorID, g.Prize,
+		g.Winners, g.RoleID, g.MinKarma, g.Expires, g.Ended, winnerIDs,
+		g.ChannelID, g.MessageID, g.Prize, g.Winners,
+		g.RoleID, g.MinKarma, g.Expires, g.Ended, winnerIDs)
+	return err
+}
+
+func (m *MysqlMiddleware) EndGiveaway(id snowflake.ID, winnerIDs []string) (bool, error) {
+	res, err := m.Db.Exec(
+		"UPDATE giveaways SET ended = 1, winnerIDs = ? WHERE id = ? AND ended = 0",
+		strings.Join(winnerIDs, ","), id)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+func (m *MysqlMiddleware) AddGiveawayEntry(id snowflake.ID, guildID, userID string) (bool, error) {
+	res, err := m.Db.Exec(
+		"INSERT IGNORE INTO giveawayEntries (giveawayID, guildID, userID) VALUES (?, ?, ?)",
+		id, guildID, userID)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+func (m *MysqlMiddleware) GetGiveawayEntries(id snowflake.ID) ([]string, error) {
+	rows, err := m.Db.Query(
+		"SELECT userID FROM giveawayEntries WHERE giveawayID = ?", id)
+	if err != nil {
+		return nil, wrapNotFoundError(err)
+	}
+	defer rows.Close()
+
+	res := make([]string, 0)
+	var userID string
+	for rows.Next() {
+		if err = rows.Scan(&userID); err != nil {
+			return nil, err
+		}
+		res = append(res, userID)
+	}
+
+	return res, nil
+}
+
+const tempRoleColumns = "id, guildID, userID, roleID, executorID, expires, attempts"
+
+func scanTempRole(row interface{ Scan(...interface{}) error }) (t models.TempRole, err error) {
+	err = row.Scan(&t.ID, &t.GuildID, &t.UserID, &t.RoleID, &t.ExecutorID, &t.Expires, &t.Attempts)
+	return
+}
+
+func (m *MysqlMiddleware) GetTempRole(id snowflake.ID) (t models.TempRole, err error) {
+	t, err = scanTempRole(m.Db.QueryRow(
+		"SELECT "+tempRoleColumns+" FROM tempRoles WHERE id = ?", id))
+	err = wrapNotFoundError(err)
+	return
+}
+
+func (m *MysqlMiddleware) GetTempRoles(guildID, userID string) ([]models.TempRole, error) {
+	query := "SELECT " + tempRoleColumns + " FROM tempRoles WHERE 1"
+	args := make([]interface{}, 0, 2)
+	if guildID != "" {
+		query += " AND guildID = ?"
+		args = append(args, guildID)
+	}
+	if userID != "" {
+		query += " AND userID = ?"
+		args = append(args, userID)
+	}
+	query += " ORDER BY expires ASC"
+
+	rows, err := m.Db.Query(query, args...)
+	if err != nil {
+		return nil, wrapNotFoundError(err)
+	}
+	defer rows.Close()
+
+	res := make([]models.TempRole, 0)
+	for rows.Next() {
+		t, err := scanTempRole(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, t)
+	}
+
+	return res, nil
+}
+
+func (m *MysqlMiddleware) SetTempRole(t models.TempRole) error {
+	_, err := m.Db.Exec(
+		"INSERT INTO tempRoles ("+tempRoleColumns+") VALUES (?, ?, ?, ?, ?, ?, ?) "+
+			"ON DUPLICATE KEY UPDATE expires = ?, attempts = ?",
+		t.ID, t.GuildID, t.UserID, t.RoleID, t.ExecutorID, t.Expires, t.Attempts,
+		t.Expires, t.Attempts)
+	return err
+}
+
+func (m *MysqlMiddleware) RemoveTempRole(id snowflake.ID) error {
+	_, err := m.Db.Exec("DELETE FROM tempRoles WHERE id = ?", id)
+	return err
+}
+
+const userNoteColumns = "id, guildID, userID, authorID, content, created"
+
+func scanUserNote(row interface{ Scan(...interface{}) error }) (n models.UserNote, err error) {
+	err = row.Scan(&n.ID, &n.GuildID, &n.UserID, &n.AuthorID, &n.Content, &n.Created)
+	return
+}
+
+func (m *MysqlMiddleware) AddUserNote(n models.UserNote) error {
+	_, err := m.Db.Exec(
+		"INSERT INTO userNotes ("+userNoteColumns+") VALUES (?, ?, ?, ?, ?, ?)",
+		n.ID, n.GuildID, n.UserID, n.AuthorID, n.Content, n.Created)
+	return err
+}
+
+func (m *MysqlMiddleware) GetUserNotes(guildID, userID string) ([]models.UserNote, error) {
+	rows, err := m.Db.Query(
+		"SELECT "+userNoteColumns+" FROM userNotes WHERE guildID = ? AND userID = ? ORDER BY created DESC",
+		guildID, userID)
+	if err != nil {
+		return nil, wrapNotFoundError(err)
+	}
+	defer rows.Close()
+
+	res := make([]models.UserNote, 0)
+	for rows.Next() {
+		n, err := scanUserNote(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, n)
+	}
+
+	return res, nil
+}
+
+func (m *MysqlMiddleware) DeleteUserNote(guildID string, id snowflake.ID) error {
+	res, err := m.Db.Exec("DELETE FROM userNotes WHERE guildID = ? AND id = ?", guildID, id)
+	if err != nil {
+		return err
+	}
+	ar, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if ar == 0 {
+		return database.ErrDatabaseNotFound
+	}
+	return nil
+}
+
+const broadcastColumns = "id, authorID, content, created, finished"
+
+func scanBroadcast(row interface{ Scan(...interface{}) error }) (b models.Broadcast, err error) {
+	err = row.Scan(&b.ID, &b.AuthorID, &b.Content, &b.Created, &b.Finished)
+	return
+}
+
+func (m *MysqlMiddleware) AddBroadcast(b models.Broadcast) error {
+	_, err := m.Db.Exec(
+		"INSERT INTO broadcasts ("+broadcastColumns+") VALUES (?, ?, ?, ?, ?)",
+		b.ID, b.AuthorID, b.Content, b.Created, b.Finished)
+	return err
+}
+
+func (m *MysqlMiddleware) SetBroadcastFinished(id snowflake.ID) error {
+	_, err := m.Db.Exec("UPDATE broadcasts SET finished = 1 WHERE id = ?", id)
+	return err
+}
+
+func (m *MysqlMiddleware) GetBroadcast(id snowflake.ID) (b models.Broadcast, err error) {
+	b, err = scanBroadcast(m.Db.QueryRow(
+		"SELECT "+broadcastColumns+" FROM broadcasts WHERE id = ?", id))
+	err = wrapNotFoundError(err)
+	return
+}
+
+func (m *MysqlMiddleware) GetBroadcasts(unfinishedOnly bool) ([]models.Broadcast, error) {
+	query := "SELECT " + broadcastColumns + " FROM broadcasts"
+	if unfinishedOnly {
+		query += " WHERE finished = 0"
+	}
+	query += " ORDER BY created DESC"
+
+	rows, err := m.Db.Query(query)
+	if err != nil {
+		return nil, wrapNotFoundError(err)
+	}
+	defer rows.Close()
+
+	res := make([]models.Broadcast, 0)
+	for rows.Next() {
+		b, err := scanBroadcast(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, b)
+	}
+
+	return res, nil
+}
+
+func (m *MysqlMiddleware) AddBroadcastDelivery(d models.BroadcastDelivery) error {
+	_, err := m.Db.Exec(
+		"INSERT INTO broadcastDeliveries (broadcastID, guildID, status, error) VALUES (?, ?, ?, ?) "+
+			"ON DUPLICATE KEY UPDATE status = ?, error = ?",
+		d.BroadcastID, d.GuildID, d.Status, d.Error, d.Status, d.Error)
+	return err
+}
+
+func (m *MysqlMiddleware) GetBroadcastDeliveries(id snowflake.ID) ([]models.BroadcastDelivery, error) {
+	rows, err := m.Db.Query(
+		"SELECT guildID, status, error FROM broadcastDeliveries WHERE broadcastID = ?", id)
+	if err != nil {
+		return nil, wrapNotFoundError(err)
+	}
+	defer rows.Close()
+
+	res := make([]models.BroadcastDelivery, 0)
+	for rows.Next() {
+		d := models.BroadcastDelivery{BroadcastID: id}
+		if err = rows.Scan(&d.GuildID, &d.Status, &d.Error); err != nil {
+			return nil, err
+		}
+		res = append(res, d)
+	}
+
+	return res, nil
+}
+
+func (m *MysqlMiddleware) AddTrackedInvite(inv models.TrackedInvite) error {
+	_, err := m.Db.Exec(
+		"INSERT INTO trackedInvites (code, guildID, channelID, creatorID, label, created) "+
+			"VALUES (?, ?, ?, ?, ?, ?)",
+		inv.Code, inv.GuildID, inv.ChannelID, inv.CreatorID, inv.Label, inv.Created)
+	return err
+}
+
+func (m *MysqlMiddleware) GetTrackedInvites(guildID string) ([]models.TrackedInvite, error) {
+	rows, err := m.Db.Query(
+		"SELECT code, guildID, channelID, creatorID, label, created FROM trackedInvites "+
+			"WHERE guildID = ? ORDER BY created DESC", guildID)
+	if err != nil {
+		return nil, wrapNotFoundError(err)
+	}
+	defer rows.Close()
+
+	res := make([]models.TrackedInvite, 0)
+	for rows.Next() {
+		var inv models.TrackedInvite
+		err = rows.Scan(&inv.Code, &inv.GuildID, &inv.ChannelID, &inv.CreatorID, &inv.Label, &inv.Created)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, inv)
+	}
+
+	return res, nil
+}
+
+func (m *MysqlMiddleware) AddInviteJoin(j models.InviteJoin) error {
+	_, err := m.Db.Exec(
+		"INSERT INTO inviteJoins (guildID, userID, code, source, inviterID, timestamp) VALUES (?, ?, ?, ?, ?, ?)",
+		j.GuildID, j.UserID, j.Code, j.Source, j.InviterID, j.Timestamp)
+	return err
+}
+
+func (m *MysqlMiddleware) GetInviteJoinStats(guildID string) ([]models.InviteJoinStats, error) {
+	rows, err := m.Db.Query(
+		"SELECT j.code, j.source, COALESCE(MAX(t.label), ''), COALESCE(MAX(t.creatorID), ''), "+
+			"MAX(j.inviterID), COUNT(*) AS joins "+
+			"FROM inviteJoins AS j LEFT JOIN trackedInvites AS t ON t.code = j.code AND t.guildID = j.guildID "+
+			"WHERE j.guildID = ? GROUP BY j.code, j.source ORDER BY joins DESC", guildID)
+	if err != nil {
+		return nil, wrapNotFoundError(err)
+	}
+	defer rows.Close()
+
+	res := make([]models.InviteJoinStats, 0)
+	for rows.Next() {
+		var s models.InviteJoinStats
+		if err = rows.Scan(&s.Code, &s.Source, &s.Label, &s.CreatorID, &s.InviterID, &s.Joins); err != nil {
+			return nil, err
+		}
+		res = append(res, s)
+	}
+
+	return res, nil
+}
+
+func (m *MysqlMiddleware) GetAutoPublish(guildID, channelID string) (cfg models.AutoPublishConfig, err error) {
+	err = m.Db.QueryRow(`
+		SELECT guildID, channelID, skipBots
+		FROM autoPublish
+		WHERE guildID = ? AND channelID = ?
+	`, guildID, channelID).
+		Scan(&cfg.GuildID, &cfg.ChannelID, &cfg.SkipBots)
+	return cfg, wrapNotFoundError(err)
+}
+
+func (m *MysqlMiddleware) GetAutoPublishes(guildID string) ([]models.AutoPublishConfig, error) {
+	rows, err := m.Db.Query(`
+		SELECT guildID, channelID, skipBots
+		FROM autoPublish
+		WHERE guildID = ?
+	`, guildID)
+	if err != nil {
+		return nil, wrapNotFoundError(err)
+	}
+	defer rows.Close()
+
+	res := make([]models.AutoPublishConfig, 0)
+	for rows.Next() {
+		var cfg models.AutoPublishConfig
+		if err = rows.Scan(&cfg.GuildID, &cfg.ChannelID, &cfg.SkipBots); err != nil {
+			return nil, err
+		}
+		res = append(res, cfg)
+	}
+
+	return res, nil
+}
+
+func (m *MysqlMiddleware) SetAutoPublish(cfg models.AutoPublishConfig) error {
+	_, err := m.Db.Exec(`
+		INSERT INTO autoPublish (guildID, channelID, skipBots)
+		VALUES (?, ?, ?)
+		ON DUPLICATE KEY UPDATE skipBots = ?
+	`, cfg.GuildID, cfg.ChannelID, cfg.SkipBots, cfg.SkipBots)
+	return err
+}
+
+func (m *MysqlMiddleware) RemoveAutoPublish(guildID, channelID string) error {
+	_, err := m.Db.Exec(`
+		DELETE FROM autoPublish
+		WHERE guildID = ? AND channelID = ?
+	`, guildID, channelID)
+	return wrapNotFoundError(err)
+}
+
+func (m *MysqlMiddleware) GetPrefixlessChannels(guildID string) ([]string, error) {
+	rows, err := m.Db.Query(`
+		SELECT channelID
+		FROM prefixlessChannels
+		WHERE guildID = ?
+	`, guildID)
+	if err != nil {
+		return nil, wrapNotFoundError(err)
+	}
+	defer rows.Close()
+
+	res := make([]string, 0)
+	for rows.Next() {
+		var channelID string
+		if err = rows.Scan(&channelID); err != nil {
+			return nil, err
+		}
+		res = append(res, channelID)
+	}
+
+	return res, nil
+}
+
+func (m *MysqlMiddleware) AddPrefixlessChannel(guildID, channelID string) error {
+	_, err := m.Db.Exec(`
+		INSERT IGNORE INTO prefixlessChannels (guildID, channelID)
+		VALUES (?, ?)
+	`, guildID, channelID)
+	return err
+}
+
+func (m *MysqlMiddleware) RemovePrefixlessChannel(guildID, channelID string) error {
+	_, err := m.Db.Exec(`
+		DELETE FROM prefixlessChannels
+		WHERE guildID = ? AND channelID = ?
+	`, guildID, channelID)
+	return wrapNotFoundError(err)
+}
+
+func (m *MysqlMiddleware) AddMessageStats(entries []models.MessageStatsEntry) (err error) {
+	tx, err := m.Db.Begin()
+	if err != nil {
+		return
+	}
+
+	for _, e := range entries {
+		_, err = tx.Exec(`
+			INSERT INTO messageStats (guildID, channelID, userID, hour, count)
+			VALUES (?, ?, ?, ?, ?)
+			ON DUPLICATE KEY UPDATE count = count + ?
+		`, e.GuildID, e.ChannelID, e.UserID, e.Hour, e.Count, e.Count)
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (m *MysqlMiddleware) GetMessageStats(guildID string, since time.Time) ([]models.MessageStatsEntry, error) {
+	rows, err := m.Db.Query(`
+		SELECT guildID, channelID, userID, hour, count
+		FROM messageStats
+		WHERE guildID = ? AND hour >= ?
+	`, guildID, since)
+	if err != nil {
+		return nil, wrapNotFoundError(err)
+	}
+	defer rows.Close()
+
+	res := make([]models.MessageStatsEntry, 0)
+	for rows.Next() {
+		var e models.MessageStatsEntry
+		if err = rows.Scan(&e.GuildID, &e.ChannelID, &e.UserID, &e.Hour, &e.Count); err != nil {
+			return nil, err
+		}
+		res = append(res, e)
+	}
+
+	return res, nil
+}
+
+func (m *MysqlMiddleware) DeleteMessageStats(guildID string) error {
+	_, err := m.Db.Exec(`
+		DELETE FROM messageStats
+		WHERE guildID = ?
+	`, guildID)
+	return wrapNotFoundError(err)
+}
+
+func (m *MysqlMiddleware) CleanupMessageStats(before time.Time) (n int64, err error) {
+	res, err := m.Db.Exec(`
+		DELETE FROM messageStats
+		WHERE hour < ?
+	`, before)
+	if err != nil {
+		return
+	}
+
+	n, err = res.RowsAffected()
+	return
+}
+
+func (m *MysqlMiddleware) GetReactionReportConfig(guildID string) (cfg models.ReactionReportConfig, err error) {
+	err = m.Db.QueryRow(`
+		SELECT guildID, channelID, emoji, cooldown
+		FROM reactionReportConfig
+		WHERE guildID = ?
+	`, guildID).
+		Scan(&cfg.GuildID, &cfg.ChannelID, &cfg.Emoji, &cfg.Cooldown)
+	return cfg, wrapNotFoundError(err)
+}
+
+func (m *MysqlMiddleware) SetReactionReportConfig(cfg models.ReactionReportConfig) error {
+	_, err := m.Db.Exec(`
+		INSERT INTO reactionReportConfig (guildID, channelID, emoji, cooldown)
+		VALUES (?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE channelID = ?, emoji = ?, cooldown = ?
+	`, cfg.GuildID, cfg.ChannelID, cfg.Emoji, cfg.Cooldown,
+		cfg.ChannelID, cfg.Emoji, cfg.Cooldown)
+	return err
+}
+
+func (m *MysqlMiddleware) GetBackupSchedule(guildID string) (s backupmodels.Schedule, err error) {
+	err = m.Db.QueryRow(`
+		SELECT guildID, spec, retention, notifyChannelID, notifyOwner, lastRun
+		FROM backupSchedules
+		WHERE guildID = ?
+	`, guildID).
+		Scan(&s.GuildID, &s.Spec, &s.Retention, &s.NotifyChannelID, &s.NotifyOwner, &s.LastRun)
+	return s, wrapNotFoundError(err)
+}
+
+func (m *MysqlMiddleware) GetBackupSchedules() ([]backupmodels.Schedule, error) {
+	rows, err := m.Db.Query(`
+		SELECT guildID, spec, retention, notifyChannelID, notifyOwner, lastRun
+		FROM backupSchedules
+	`)
+	if err != nil {
+		return nil, wrapNotFoundError(err)
+	}
+	defer rows.Close()
+
+	res := make([]backupmodels.Schedule, 0)
+	for rows.Next() {
+		var s backupmodels.Schedule
+		err = rows.Scan(&s.GuildID, &s.Spec, &s.Retention, &s.NotifyChannelID, &s.NotifyOwner, &s.LastRun)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, s)
+	}
+
+	return res, nil
+}
+
+func (m *MysqlMiddleware) SetBackupSchedule(s backupmodels.Schedule) error {
+	_, err := m.Db.Exec(`
+		INSERT INTO backupSchedules (guildID, spec, retention, notifyChannelID, notifyOwner, lastRun)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE spec = ?, retention = ?, notifyChannelID = ?, notifyOwner = ?, lastRun = ?
+	`, s.GuildID, s.Spec, s.Retention, s.NotifyChannelID, s.NotifyOwner, s.LastRun,
+		s.Spec, s.Retention, s.NotifyChannelID, s.NotifyOwner, s.LastRun)
+	return err
+}
+
+func (m *MysqlMiddleware) DeleteBackupSchedule(guildID string) error {
+	_, err := m.Db.Exec(`
+		DELETE FROM backupSchedules
+		WHERE guildID = ?
+	`, guildID)
+	return wrapNotFoundError(err)
+}
+
+func (m *MysqlMiddleware) GetInactivitySettings(guildID string) (s models.InactivitySettings, err error) {
+	err = m.Db.QueryRow(`
+		SELECT guildID, enabled, roleID, days, warnDays, since
+		FROM inactivitySettings
+		WHERE guildID = ?
+	`, guildID).
+		Scan(&s.GuildID, &s.Enabled, &s.RoleID, &s.Days, &s.WarnDays, &s.Since)
+	return s, wrapNotFoundError(err)
+}
+
+func (m *MysqlMiddleware) GetEnabledInactivitySettings() ([]models.InactivitySettings, error) {
+	rows, err := m.Db.Query(`
+		SELECT guildID, enabled, roleID, days, warnDays, since
+		FROM inactivitySettings
+		WHERE enabled = 1
+	`)
+	if err != nil {
+		return nil, wrapNotFoundError(err)
+	}
+	defer rows.Close()
+
+	res := make([]models.InactivitySettings, 0)
+	for rows.Next() {
+		var s models.InactivitySettings
+		err = rows.Scan(&s.GuildID, &s.Enabled, &s.RoleID, &s.Days, &s.WarnDays, &s.Since)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, s)
+	}
+
+	return res, nil
+}
+
+func (m *MysqlMiddleware) SetInactivitySettings(s models.InactivitySettings) error {
+	// Since is only set once the removal has been enabled.
+	// Zero timestamps are rejected in NO_ZERO_DATE mode, so
+	// the current value is kept in this case.
+	var since *time.Time
+	if !s.Since.IsZero() {
+		since = &s.Since
+	}
+
+	_, err := m.Db.Exec(`
+		INSERT INTO inactivitySettings (guildID, enabled, roleID, days, warnDays, since)
+		VALUES (?, ?, ?, ?, ?, COALESCE(?, CURRENT_TIMESTAMP()))
+		ON DUPLICATE KEY UPDATE enabled = ?, roleID = ?, days = ?, warnDays = ?, since = COALESCE(?, since)
+	`, s.GuildID, s.Enabled, s.RoleID, s.Days, s.WarnDays, since,
+		s.Enabled, s.RoleID, s.Days, s.WarnDays, since)
+	return err
+}
+
+func (m *MysqlMiddleware) GetMemberActivities(guildID string) ([]models.MemberActivity, error) {
+	rows, err := m.Db.Query(`
+		SELECT guildID, userID, lastActive, warned
+		FROM memberActivity
+		WHERE guildID = ?
+	`, guildID)
+	if err != nil {
+		return nil, wrapNotFoundError(err)
+	}
+	defer rows.Close()
+
+	res := make([]models.MemberActivity, 0)
+	for rows.Next() {
+		var a models.MemberActivity
+		if err = rows.Scan(&a.GuildID, &a.UserID, &a.LastActive, &a.Warned); err != nil {
+			return nil, err
+		}
+		res = append(res, a)
+	}
+
+	return res, nil
+}
+
+func (m *MysqlMiddleware) SetMemberActivities(entries []models.MemberActivity) (err error) {
+	tx, err := m.Db.Begin()
+	if err != nil {
+		return
+	}
+
+	for _, a := range entries {
+		_, err = tx.Exec(`
+			INSERT INTO memberActivity (guildID, userID, lastActive, warned)
+			VALUES (?, ?, ?, ?)
+			ON DUPLICATE KEY UPDATE lastActive = ?, warned = ?
+		`, a.GuildID, a.UserID, a.LastActive, a.Warned, a.LastActive, a.Warned)
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (m *MysqlMiddleware) SetMemberActivityWarned(a models.MemberActivity) error {
+	_, err := m.Db.Exec(`
+		INSERT INTO memberActivity (guildID, userID, lastActive, warned)
+		VALUES (?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE warned = ?
+	`, a.GuildID, a.UserID, a.LastActive, a.Warned, a.Warned)
+	return err
+}
+
+func (m *MysqlMiddleware) DeleteMemberActivities(guildID string) error {
+	_, err := m.Db.Exec(`
+		DELETE FROM memberActivity
+		WHERE guildID = ?
+	`, guildID)
+	return wrapNotFoundError(err)
 }
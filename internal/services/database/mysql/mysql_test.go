@@ -0,0 +1,40 @@
+package mysql
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/services/database/dbtest"
+	"github.com/zekroTJA/shinpuru/internal/services/database/sqldb"
+)
+
+// connect connects to the MySQL database specified by the
+// SP_TEST_MYSQL_* environment variables. If no host is
+// specified, the test is skipped.
+func connect(t *testing.T) *MysqlMiddleware {
+	host := os.Getenv("SP_TEST_MYSQL_HOST")
+	if host == "" {
+		t.Skip("SP_TEST_MYSQL_HOST is not set")
+	}
+
+	db := New(sqldb.Options{})
+	require.Nil(t, db.Connect(models.DatabaseCreds{
+		Host:     host,
+		User:     os.Getenv("SP_TEST_MYSQL_USER"),
+		Password: os.Getenv("SP_TEST_MYSQL_PASSWORD"),
+		Database: os.Getenv("SP_TEST_MYSQL_DATABASE"),
+	}))
+	t.Cleanup(db.Close)
+
+	return db
+}
+
+func TestReportsFilteredCount(t *testing.T) {
+	dbtest.ReportsFilteredCount(t, connect(t))
+}
+
+func TestExpiredReports(t *testing.T) {
+	dbtest.ExpiredReports(t, connect(t))
+}
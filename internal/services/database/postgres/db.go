@@ -0,0 +1,108 @@
+package postgres
+
+import (
+	"database/sql"
+	"strconv"
+	"strings"
+
+	"github.com/zekroTJA/shinpuru/internal/services/database/sqldb"
+)
+
+// db wraps a *sqldb.DB to allow using the MySQL style `?`
+// placeholders in queries, which are rebound to the
+// positional `$n` placeholders used by PostgreSQL.
+type db struct {
+	*sqldb.DB
+}
+
+func (d *db) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return d.DB.Exec(rebind(query), normalizeArgs(args)...)
+}
+
+func (d *db) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return d.DB.Query(rebind(query), normalizeArgs(args)...)
+}
+
+func (d *db) QueryRow(query string, args ...interface{}) *sql.Row {
+	return d.DB.QueryRow(rebind(query), normalizeArgs(args)...)
+}
+
+func (d *db) Begin() (*tx, error) {
+	t, err := d.DB.Begin()
+	if err != nil {
+		return nil, err
+	}
+	return &tx{t}, nil
+}
+
+// tx wraps a *sql.Tx to rebind query placeholders
+// like db does.
+type tx struct {
+	*sql.Tx
+}
+
+func (t *tx) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return t.Tx.Exec(rebind(query), normalizeArgs(args)...)
+}
+
+func (t *tx) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return t.Tx.Query(rebind(query), normalizeArgs(args)...)
+}
+
+func (t *tx) QueryRow(query string, args ...interface{}) *sql.Row {
+	return t.Tx.QueryRow(rebind(query), normalizeArgs(args)...)
+}
+
+// rebind replaces all `?` placeholders in the query with
+// `$1`, `$2`, ... Question marks in quoted strings and
+// identifiers are left untouched.
+func rebind(query string) string {
+	if !strings.Contains(query, "?") {
+		return query
+	}
+
+	var sb strings.Builder
+	sb.Grow(len(query) + 8)
+
+	var quote rune
+	n := 0
+	for _, c := range query {
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '?':
+			n++
+			sb.WriteByte('$')
+			sb.WriteString(strconv.Itoa(n))
+			continue
+		}
+		sb.WriteRune(c)
+	}
+
+	return sb.String()
+}
+
+// normalizeArgs converts argument values which are
+// handled implicitly by MySQL but not by PostgreSQL.
+//
+// Booleans are stored as integers in the schema and
+// byte slices are stored as text instead of bytea.
+func normalizeArgs(args []interface{}) []interface{} {
+	for i, arg := range args {
+		switch v := arg.(type) {
+		case bool:
+			if v {
+				args[i] = 1
+			} else {
+				args[i] = 0
+			}
+		case []byte:
+			args[i] = string(v)
+		}
+	}
+	return args
+}
@@ -0,0 +1,25 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRebind(t *testing.T) {
+	assert.Equal(t,
+		"SELECT id FROM reports",
+		rebind("SELECT id FROM reports"))
+	assert.Equal(t,
+		"SELECT id FROM reports WHERE guildID = $1 AND type = $2 LIMIT $3 OFFSET $4",
+		rebind("SELECT id FROM reports WHERE guildID = ? AND type = ? LIMIT ? OFFSET ?"))
+	assert.Equal(t,
+		`UPDATE guilds SET prefix = '?' WHERE "a?b" = $1`,
+		rebind(`UPDATE guilds SET prefix = '?' WHERE "a?b" = ?`))
+}
+
+func TestNormalizeArgs(t *testing.T) {
+	assert.Equal(t,
+		[]interface{}{1, 0, "data", "str", 3},
+		normalizeArgs([]interface{}{true, false, []byte("data"), "str", 3}))
+}
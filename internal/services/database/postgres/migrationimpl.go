@@ -0,0 +1,145 @@
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/zekroTJA/shinpuru/internal/util/embedded"
+)
+
+type migrationFunc func(*tx) error
+
+// migrationStep holds the functions to apply and to
+// revert a single migration.
+type migrationStep struct {
+	Up   migrationFunc
+	Down migrationFunc
+}
+
+type migration struct {
+	Version       int
+	Applied       time.Time
+	ReleaseTag    string
+	ReleaseCommit string
+}
+
+func (m *PostgresMiddleware) Migrate() (err error) {
+	return m.MigrateTo(len(migrations) - 1)
+}
+
+// MigrateTo applies or reverts migrations until the
+// database is at the given version.
+//
+// Each migration is executed in its own transaction
+// together with updating the version in the migrations
+// table. Because schema changes are transactional in
+// PostgreSQL, a failed migration is rolled back entirely
+// and the database stays at the version of the last
+// successful migration.
+func (m *PostgresMiddleware) MigrateTo(version int) (err error) {
+	if version < 0 || version >= len(migrations) {
+		return fmt.Errorf("invalid migration version %d (latest is %d)",
+			version, len(migrations)-1)
+	}
+
+	mig, err := m.getLatestMigration()
+	if err == sql.ErrNoRows {
+		mig = &migration{
+			Version: -1,
+		}
+	} else if err != nil {
+		return err
+	}
+
+	for i := mig.Version + 1; i <= version; i++ {
+		m.log.Info().Field("version", i).Msg("Applying migration ...")
+		if err = m.runMigration(i, migrations[i].Up, putMigrationVersion); err != nil {
+			return err
+		}
+	}
+
+	for i := mig.Version; i > version; i-- {
+		if migrations[i].Down == nil {
+			return fmt.Errorf("migration %d can not be reverted", i)
+		}
+		m.log.Info().Field("version", i).Msg("Reverting migration ...")
+		if err = m.runMigration(i, migrations[i].Down, deleteMigrationVersion); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *PostgresMiddleware) runMigration(
+	version int,
+	f migrationFunc,
+	record func(*tx, int) error,
+) (err error) {
+	tx, err := m.Db.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			err = fmt.Errorf("migration %d failed: %s", version, err.Error())
+		}
+	}()
+
+	if err = f(tx); err != nil {
+		return
+	}
+	if err = record(tx, version); err != nil {
+		return
+	}
+
+	return tx.Commit()
+}
+
+func (m *PostgresMiddleware) getLatestMigration() (mig *migration, err error) {
+	mig = new(migration)
+	row := m.Db.QueryRow(
+		`SELECT version, applied, releaseTag, releaseCommit
+		FROM migrations
+		ORDER BY version DESC
+		LIMIT 1`)
+	err = row.Scan(&mig.Version, &mig.Applied, &mig.ReleaseTag, &mig.ReleaseCommit)
+	return
+}
+
+func putMigrationVersion(t *tx, i int) (err error) {
+	_, err = t.Exec(
+		`INSERT INTO migrations (version, applied, releaseTag, releaseCommit)
+		VALUES (?, ?, ?, ?)`,
+		i, time.Now(), embedded.AppVersion, embedded.AppCommit)
+	return
+}
+
+func deleteMigrationVersion(t *tx, i int) (err error) {
+	_, err = t.Exec(`DELETE FROM migrations WHERE version >= ?`, i)
+	return
+}
+
+// --- UTILITIES ---
+
+func createTableColumnIfNotExists(m *tx, table, definition string) (err error) {
+	_, err = m.Exec("ALTER TABLE " + table + " ADD COLUMN IF NOT EXISTS " + definition)
+	return
+}
+
+// dropColumns returns a migration function which removes
+// the given columns from the table if they exist.
+func dropColumns(table string, columns ...string) migrationFunc {
+	return func(m *tx) (err error) {
+		for _, column := range columns {
+			_, err = m.Exec("ALTER TABLE " + table + " DROP COLUMN IF EXISTS " + column)
+			if err != nil {
+				return
+			}
+		}
+		return
+	}
+}
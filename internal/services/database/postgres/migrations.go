@@ -0,0 +1,149 @@
+package postgres
+
+// migrations contains all database migrations ordered by
+// their version. Migrations without a down function can
+// not be reverted.
+//
+// The PostgreSQL schema has been introduced with the state
+// of the latest MySQL migration, so its versions are
+// counted separately.
+var migrations = []migrationStep{
+	{Up: migration_0},
+	{Up: migration_1, Down: dropColumns("guilds", "modlogFormat")},
+	{Up: migration_2, Down: dropColumns("guilds", "memberMsgGrace")},
+	{Up: migration_3, Down: dropColumns("reports", "revokedAt", "revokedBy", "revokeReason")},
+	{Up: migration_4, Down: dropColumns("karmaSettings", "giveCooldown", "giveDailyCap", "receiveCap", "receiveInterval")},
+	{Up: migration_5, Down: dropColumns("twitchnotify", "template", "roleID", "plain")},
+	{Up: migration_6, Down: dropColumns("guilds", "messageStats")},
+	{Up: migration_7, Down: dropColumns("guilds", "botNickname")},
+	{Up: migration_8, Down: dropColumns("inviteJoins", "inviterID")},
+	{Up: migration_9, Down: dropColumns("giveawayEntries", "guildID")},
+	{Up: migration_10, Down: dropColumns("backups", "manual")},
+}
+
+// VERSION 0:
+// - base state
+func migration_0(m *tx) (err error) {
+	return
+}
+
+// VERSION 1:
+// - add property `modlogFormat` to `guilds`
+func migration_1(m *tx) (err error) {
+	return createTableColumnIfNotExists(m,
+		"guilds", "modlogFormat text NOT NULL DEFAULT ''")
+}
+
+// VERSION 2:
+// - add property `memberMsgGrace` to `guilds`
+func migration_2(m *tx) (err error) {
+	return createTableColumnIfNotExists(m,
+		"guilds", "memberMsgGrace text NOT NULL DEFAULT ''")
+}
+
+// VERSION 3:
+// - add property `revokedAt` to `reports`
+// - add property `revokedBy` to `reports`
+// - add property `revokeReason` to `reports`
+func migration_3(m *tx) (err error) {
+	err = createTableColumnIfNotExists(m,
+		"reports", "revokedAt timestamptz NULL DEFAULT NULL")
+	if err != nil {
+		return
+	}
+	err = createTableColumnIfNotExists(m,
+		"reports", "revokedBy text NOT NULL DEFAULT ''")
+	if err != nil {
+		return
+	}
+	err = createTableColumnIfNotExists(m,
+		"reports", "revokeReason text NOT NULL DEFAULT ''")
+	return
+}
+
+// VERSION 4:
+// - add property `giveCooldown` to `karmaSettings`
+// - add property `giveDailyCap` to `karmaSettings`
+// - add property `receiveCap` to `karmaSettings`
+// - add property `receiveInterval` to `karmaSettings`
+func migration_4(m *tx) (err error) {
+	err = createTableColumnIfNotExists(m,
+		"karmaSettings", "giveCooldown integer NOT NULL DEFAULT '10'")
+	if err != nil {
+		return
+	}
+	err = createTableColumnIfNotExists(m,
+		"karmaSettings", "giveDailyCap integer NOT NULL DEFAULT '50'")
+	if err != nil {
+		return
+	}
+	err = createTableColumnIfNotExists(m,
+		"karmaSettings", "receiveCap integer NOT NULL DEFAULT '20'")
+	if err != nil {
+		return
+	}
+	err = createTableColumnIfNotExists(m,
+		"karmaSettings", "receiveInterval integer NOT NULL DEFAULT '3600'")
+	return
+}
+
+// VERSION 5:
+// - add property `template` to `twitchnotify`
+// - add property `roleID` to `twitchnotify`
+// - add property `plain` to `twitchnotify`
+func migration_5(m *tx) (err error) {
+	err = createTableColumnIfNotExists(m,
+		"twitchnotify", "template text NOT NULL DEFAULT ''")
+	if err != nil {
+		return
+	}
+	err = createTableColumnIfNotExists(m,
+		"twitchnotify", "roleID varchar(25) NOT NULL DEFAULT ''")
+	if err != nil {
+		return
+	}
+	err = createTableColumnIfNotExists(m,
+		"twitchnotify", "plain integer NOT NULL DEFAULT '0'")
+	return
+}
+
+// VERSION 6:
+// - add property `messageStats` to `guilds`
+func migration_6(m *tx) (err error) {
+	return createTableColumnIfNotExists(m,
+		"guilds", "messageStats text NOT NULL DEFAULT ''")
+}
+
+// VERSION 7:
+// - add property `botNickname` to `guilds`
+func migration_7(m *tx) (err error) {
+	return createTableColumnIfNotExists(m,
+		"guilds", "botNickname text NOT NULL DEFAULT ''")
+}
+
+// VERSION 8:
+// - add property `inviterID` to `inviteJoins`
+func migration_8(m *tx) (err error) {
+	return createTableColumnIfNotExists(m,
+		"inviteJoins", "inviterID varchar(25) NOT NULL DEFAULT ''")
+}
+
+// VERSION 9:
+// - add property `guildID` to `giveawayEntries`
+func migration_9(m *tx) (err error) {
+	err = createTableColumnIfNotExists(m,
+		"giveawayEntries", "guildID varchar(25) NOT NULL DEFAULT ''")
+	if err != nil {
+		return
+	}
+	_, err = m.Exec("UPDATE giveawayEntries e SET guildID = g.guildID " +
+		"FROM giveaways g WHERE g.id = e.giveawayID AND e.guildID = ''")
+	return
+}
+
+// VERSION 10:
+// - add property `manual` to `backups`
+func migration_10(m *tx) (err error) {
+	return createTableColumnIfNotExists(m,
+		"backups", "manual integer NOT NULL DEFAULT '0'")
+}
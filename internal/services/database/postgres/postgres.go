@@ -0,0 +1,4343 @@
+package postgres
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/services/backup/backupmodels"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/services/database/sqldb"
+	"github.com/zekroTJA/shinpuru/internal/util/tag"
+	"github.com/zekroTJA/shinpuru/internal/util/vote"
+	"github.com/zekroTJA/shinpuru/pkg/multierror"
+	"github.com/zekroTJA/shinpuru/pkg/permissions"
+	"github.com/zekroTJA/shinpuru/pkg/stringutil"
+	"github.com/zekroTJA/shinpuru/pkg/twitchnotify"
+	"github.com/zekrotja/rogu"
+	"github.com/zekrotja/rogu/log"
+
+	"github.com/bwmarrin/snowflake"
+	_ "github.com/lib/pq"
+)
+
+// PostgresMiddleware implements the Database interface for
+// PostgreSQL.
+//
+// Queries are written with `?` placeholders like in the
+// MySQL middleware, which are rebound by Db before being
+// passed to the driver.
+type PostgresMiddleware struct {
+	Db  *db
+	log rogu.Logger
+
+	queryOpts sqldb.Options
+}
+
+var _ database.Database = (*PostgresMiddleware)(nil)
+
+func New(queryOpts sqldb.Options) *PostgresMiddleware {
+	return &PostgresMiddleware{
+		log:       log.Tagged("Database"),
+		queryOpts: queryOpts,
+	}
+}
+
+var guildTables = []string{
+	"antiraidJoinlog",
+	"antiraidSettings",
+	"backups",
+	"chanlock",
+	"guildapi",
+	"guildlog",
+	"guilds",
+	"karma",
+	"karmaBlocklist",
+	"karmaRules",
+	"karmaSettings",
+	"permissions",
+	"reports",
+	"starboardConfig",
+	"starboardEntries",
+	"tags",
+	"twitchnotify",
+	"unbanRequests",
+	"verificationQueue",
+	"voicelogBlocklist",
+	"birthdays",
+	"autodelete",
+	"settingsAudit",
+	"reportImports",
+	"modmailThreads",
+	"guildRules",
+	"rulesAcceptances",
+	"guildDisabledCommands",
+	"giveaways",
+	"giveawayEntries",
+	"guildReportTypes",
+	"reportEscalation",
+	"broadcastDeliveries",
+	"trackedInvites",
+	"inviteJoins",
+	"tempRoles",
+	"autoThreads",
+	"stickyMessages",
+	"modLogRoutes",
+	"userNotes",
+	"wordFilterSettings",
+	"wordFilterEntries",
+	"autoPublish",
+	"prefixlessChannels",
+	"messageStats",
+	"reactionReportConfig",
+	"backupSchedules",
+	"inactivitySettings",
+	"memberActivity",
+}
+
+type tableColumn struct {
+	Table  string
+	Column string
+}
+
+var userTables = []tableColumn{
+	{"antiraidJoinlog", "userID"},
+	{"apitokens", "userID"},
+	{"refreshTokens", "userID"},
+	{"starboardEntries", "authorID"},
+	{"tags", "creatorID"},
+	{"unbanRequests", "userID"},
+	{"modmailThreads", "userID"},
+	{"giveawayEntries", "userID"},
+	{"rulesAcceptances", "userID"},
+	{"unbanRequests", "processedBy"},
+	{"users", "userID"},
+	{"birthdays", "userID"},
+	{"inviteJoins", "userID"},
+	{"memberActivity", "userID"},
+}
+
+func (m *PostgresMiddleware) setup() (err error) {
+	if err = m.Status(); err != nil {
+		return
+	}
+
+	tx, err := m.Db.Begin()
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS migrations (" +
+		"version integer NOT NULL DEFAULT '0'," +
+		"applied timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP," +
+		"releaseTag text NOT NULL DEFAULT ''," +
+		"releaseCommit text NOT NULL DEFAULT ''," +
+		"PRIMARY KEY (version)" +
+		")")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS guilds (" +
+		"guildID varchar(25) NOT NULL," +
+		"prefix text NOT NULL DEFAULT ''," +
+		"autorole text NOT NULL DEFAULT ''," +
+		"autovc text NOT NULL DEFAULT ''," +
+		"modlogchanID text NOT NULL DEFAULT ''," +
+		"voicelogchanID text NOT NULL DEFAULT ''," +
+		"notifyRoleID text NOT NULL DEFAULT ''," +
+		"ghostPingMsg text NOT NULL DEFAULT ''," +
+		"jdoodleToken text NOT NULL DEFAULT ''," +
+		"codeExecEnabled text NOT NULL DEFAULT ''," +
+		"codeExecLanguages text NOT NULL DEFAULT ''," +
+		"backup text NOT NULL DEFAULT ''," +
+		"inviteBlock text NOT NULL DEFAULT ''," +
+		"joinMsg text NOT NULL DEFAULT ''," +
+		"leaveMsg text NOT NULL DEFAULT ''," +
+		"colorReaction text NOT NULL DEFAULT ''," +
+		"guildlogDisable text NOT NULL DEFAULT ''," +
+		"confirmActions text NOT NULL DEFAULT ''," +
+		"permDeniedMsg text NOT NULL DEFAULT ''," +
+		"modmailChanID varchar(25) NOT NULL DEFAULT ''," +
+		"cmdSuggestDisable text NOT NULL DEFAULT ''," +
+		"disabledCmdMsg text NOT NULL DEFAULT ''," +
+		"embedColor text NOT NULL DEFAULT ''," +
+		"requireUserVerification text NOT NULL DEFAULT ''," +
+		"birthdaychanID text NOT NULL DEFAULT ''," +
+		"modnotchanID varchar(25) NOT NULL DEFAULT ''," +
+		"announcementChannel varchar(25) NOT NULL DEFAULT ''," +
+		"pinRotation text NOT NULL DEFAULT ''," +
+		"modlogFormat text NOT NULL DEFAULT ''," +
+		"memberMsgGrace text NOT NULL DEFAULT ''," +
+		"messageStats text NOT NULL DEFAULT ''," +
+		"botNickname text NOT NULL DEFAULT ''," +
+		"PRIMARY KEY (guildID)" +
+		")")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS users (" +
+		"userID varchar(25) NOT NULL," +
+		"enableOTA text NOT NULL DEFAULT '0'," +
+		"verified text NOT NULL DEFAULT '0'," +
+		"starboardOptout text NOT NULL DEFAULT '0'," +
+		"PRIMARY KEY (userID)" +
+		")")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS permissions (" +
+		"roleID varchar(25) NOT NULL," +
+		"guildID text NOT NULL DEFAULT ''," +
+		"permission text NOT NULL DEFAULT ''," +
+		"PRIMARY KEY (roleID)" +
+		")")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS reports (" +
+		"id varchar(25) NOT NULL," +
+		"type integer NOT NULL DEFAULT '0'," +
+		"guildID text NOT NULL DEFAULT ''," +
+		"executorID text NOT NULL DEFAULT ''," +
+		"victimID text NOT NULL DEFAULT ''," +
+		"msg text NOT NULL DEFAULT ''," +
+		"attachment text NOT NULL DEFAULT ''," +
+		"timeout timestamptz NULL DEFAULT NULL," +
+		"revokedAt timestamptz NULL DEFAULT NULL," +
+		"revokedBy text NOT NULL DEFAULT ''," +
+		"revokeReason text NOT NULL DEFAULT ''," +
+		"PRIMARY KEY (id)" +
+		")")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS settings (" +
+		"iid serial," +
+		"setting text NOT NULL DEFAULT ''," +
+		"value text NOT NULL DEFAULT ''," +
+		"PRIMARY KEY (iid)" +
+		")")
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS votes (" +
+		"id varchar(25) NOT NULL," +
+		"data text NOT NULL DEFAULT ''," +
+		"PRIMARY KEY (id)" +
+		")")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS twitchnotify (" +
+		"iid serial," +
+		"guildID text NOT NULL DEFAULT ''," +
+		"channelID text NOT NULL DEFAULT ''," +
+		"twitchUserID text NOT NULL DEFAULT ''," +
+		"template text NOT NULL DEFAULT ''," +
+		"roleID varchar(25) NOT NULL DEFAULT ''," +
+		"plain integer NOT NULL DEFAULT '0'," +
+		"PRIMARY KEY (iid)" +
+		")")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS backups (" +
+		"iid serial," +
+		"guildID text NOT NULL DEFAULT ''," +
+		"timestamp bigint NOT NULL DEFAULT 0," +
+		"fileID text NOT NULL DEFAULT ''," +
+		"manual integer NOT NULL DEFAULT '0'," +
+		"PRIMARY KEY (iid)" +
+		")")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS tags (" +
+		"id varchar(25) NOT NULL," +
+		"ident text NOT NULL DEFAULT ''," +
+		"creatorID text NOT NULL DEFAULT ''," +
+		"guildID text NOT NULL DEFAULT ''," +
+		"content text NOT NULL DEFAULT ''," +
+		"created bigint NOT NULL DEFAULT 0," +
+		"lastEdit bigint NOT NULL DEFAULT 0," +
+		"PRIMARY KEY (id)" +
+		")")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS apitokens (" +
+		"userID varchar(25) NOT NULL," +
+		"salt text NOT NULL," +
+		"created timestamptz NOT NULL," +
+		"expires timestamptz NOT NULL," +
+		"lastAccess timestamptz NOT NULL," +
+		"hits bigint NOT NULL," +
+		"PRIMARY KEY (userID)" +
+		")")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS karma (" +
+		"iid serial," +
+		"guildID text NOT NULL DEFAULT ''," +
+		"userID text NOT NULL DEFAULT ''," +
+		"value bigint NOT NULL DEFAULT '0'," +
+		"PRIMARY KEY (iid)" +
+		")")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS karmaSettings (" +
+		"guildID varchar(25) NOT NULL DEFAULT ''," +
+		"state integer NOT NULL DEFAULT '1'," +
+		"emotesInc text NOT NULL DEFAULT ''," +
+		"emotesDec text NOT NULL DEFAULT ''," +
+		"tokens bigint NOT NULL DEFAULT '1'," +
+		"penalty integer NOT NULL DEFAULT '0'," +
+		"giveCooldown integer NOT NULL DEFAULT '10'," +
+		"giveDailyCap integer NOT NULL DEFAULT '50'," +
+		"receiveCap integer NOT NULL DEFAULT '20'," +
+		"receiveInterval integer NOT NULL DEFAULT '3600'," +
+		"PRIMARY KEY (guildID)" +
+		")")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS karmaBlocklist (" +
+		"iid serial," +
+		"userID varchar(25) NOT NULL DEFAULT ''," +
+		"guildID varchar(25) NOT NULL DEFAULT ''," +
+		"PRIMARY KEY (iid)" +
+		")")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS karmaRules (" +
+		"id varchar(25) NOT NULL," +
+		"guildID varchar(25) NOT NULL DEFAULT ''," +
+		"trigger integer NOT NULL DEFAULT '0'," +
+		"value integer NOT NULL DEFAULT '0'," +
+		"action varchar(30) NOT NULL DEFAULT ''," +
+		"argument text NOT NULL DEFAULT ''," +
+		"checksum text NOT NULL DEFAULT ''," +
+		"PRIMARY KEY (id)" +
+		")")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS chanlock (" +
+		"chanID varchar(25) NOT NULL," +
+		"guildID text NOT NULL DEFAULT ''," +
+		"executorID text NOT NULL DEFAULT ''," +
+		"permissions text NOT NULL DEFAULT ''," +
+		"PRIMARY KEY (chanID)" +
+		")")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS antiraidSettings (" +
+		"guildID varchar(25) NOT NULL DEFAULT ''," +
+		"state integer NOT NULL DEFAULT '1'," +
+		"\"limit\" bigint NOT NULL DEFAULT '0'," +
+		"burst bigint NOT NULL DEFAULT '0'," +
+		"verification integer NOT NULL DEFAULT 0," +
+		"PRIMARY KEY (guildID)" +
+		")")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS antiraidJoinlog (" +
+		"iid serial," +
+		"userID varchar(25) NOT NULL DEFAULT ''," +
+		"guildID varchar(25) NOT NULL DEFAULT ''," +
+		"tag text NOT NULL DEFAULT ''," +
+		"accountCreated timestamptz NOT NULL DEFAULT 'epoch'," +
+		"timestamp timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP," +
+		"PRIMARY KEY (iid)" +
+		")")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS unbanRequests (" +
+		"id varchar(25) NOT NULL DEFAULT ''," +
+		"userID varchar(25) NOT NULL DEFAULT ''," +
+		"guildID varchar(25) NOT NULL DEFAULT ''," +
+		"userTag text NOT NULL DEFAULT ''," +
+		"message text NOT NULL DEFAULT ''," +
+		"processedBy varchar(25) NOT NULL DEFAULT ''," +
+		"status integer NOT NULL DEFAULT '0'," +
+		"processed timestamptz," +
+		"processedMessage text NOT NULL DEFAULT ''," +
+		"reportID varchar(25) NOT NULL, " +
+		"PRIMARY KEY (id)," +
+		"FOREIGN KEY (reportID) REFERENCES reports(id)" +
+		")")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS voicelogBlocklist (" +
+		"iid serial," +
+		"guildID varchar(25) NOT NULL DEFAULT ''," +
+		"channelID varchar(25) NOT NULL DEFAULT ''," +
+		"PRIMARY KEY (iid)" +
+		")")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS starboardConfig (" +
+		"guildID varchar(25) NOT NULL DEFAULT ''," +
+		"channelID varchar(25) NOT NULL DEFAULT ''," +
+		"threshold integer NOT NULL DEFAULT '0'," +
+		"emojiID text NOT NULL DEFAULT ''," +
+		"karmaGain integer NOT NULL DEFAULT '3'," +
+		"PRIMARY KEY (guildID)" +
+		")")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS starboardEntries (" +
+		"messageID varchar(25) NOT NULL DEFAULT ''," +
+		"starboardID varchar(25) NOT NULL DEFAULT ''," +
+		"guildID varchar(25) NOT NULL DEFAULT ''," +
+		"channelID varchar(25) NOT NULL DEFAULT ''," +
+		"authorID varchar(25) NOT NULL DEFAULT ''," +
+		"content text NOT NULL DEFAULT ''," +
+		"mediaURLs text NOT NULL DEFAULT ''," +
+		"score integer NOT NULL DEFAULT '0'," +
+		"deleted integer NOT NULL DEFAULT '0'," +
+		"PRIMARY KEY (messageID)" +
+		")")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS refreshTokens (" +
+		"userID varchar(25) NOT NULL DEFAULT ''," +
+		"token text NOT NULL DEFAULT ''," +
+		"expires timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP," +
+		"PRIMARY KEY (userID)" +
+		")")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS guildlog (" +
+		"id varchar(25) NOT NULL DEFAULT ''," +
+		"guildID varchar(25) NOT NULL DEFAULT ''," +
+		"module varchar(30) NOT NULL DEFAULT ''," +
+		"message text NOT NULL DEFAULT ''," +
+		"severity integer NOT NULL DEFAULT '0'," +
+		"timestamp timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP," +
+		"PRIMARY KEY (id)" +
+		")")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS guildapi (" +
+		"guildID varchar(25) NOT NULL DEFAULT ''," +
+		"enabled integer NOT NULL DEFAULT '0'," +
+		"origins text NOT NULL DEFAULT ''," +
+		"tokenHash text NOT NULL DEFAULT ''," +
+		"PRIMARY KEY (guildID)" +
+		")")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS verificationQueue (" +
+		"iid serial," +
+		"guildID varchar(25) NOT NULL DEFAULT ''," +
+		"userID varchar(25) NOT NULL DEFAULT ''," +
+		"timestamp timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP," +
+		"PRIMARY KEY (iid)" +
+		")")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS birthdays (" +
+		"iid serial," +
+		"guildID varchar(25) NOT NULL DEFAULT ''," +
+		"userID varchar(25) NOT NULL DEFAULT ''," +
+		"date timestamptz," +
+		"showYear integer NOT NULL DEFAULT '0'," +
+		"PRIMARY KEY (iid)" +
+		")")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS roleselect (" +
+		"guildID varchar(25) NOT NULL," +
+		"channelID varchar(25) NOT NULL," +
+		"messageID varchar(25) NOT NULL," +
+		"roleID varchar(25) NOT NULL," +
+		"PRIMARY KEY (guildID, channelID, messageID, roleID)" +
+		")")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS stickyMessages (" +
+		"guildID varchar(25) NOT NULL," +
+		"channelID varchar(25) NOT NULL," +
+		"content text NOT NULL," +
+		"messageID varchar(25) NOT NULL DEFAULT ''," +
+		"PRIMARY KEY (guildID, channelID)" +
+		")")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS modLogRoutes (" +
+		"guildID varchar(25) NOT NULL," +
+		"action varchar(16) NOT NULL," +
+		"channelID varchar(25) NOT NULL," +
+		"PRIMARY KEY (guildID, action)" +
+		")")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS userNotes (" +
+		"id varchar(25) NOT NULL," +
+		"guildID varchar(25) NOT NULL," +
+		"userID varchar(25) NOT NULL," +
+		"authorID varchar(25) NOT NULL," +
+		"content text NOT NULL," +
+		"created timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP," +
+		"PRIMARY KEY (id)" +
+		")")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE INDEX IF NOT EXISTS userNotes_guildID_userID ON userNotes (guildID, userID)")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS wordFilterSettings (" +
+		"guildID varchar(25) NOT NULL," +
+		"enabled integer NOT NULL DEFAULT '0'," +
+		"action varchar(16) NOT NULL DEFAULT 'delete'," +
+		"muteDuration bigint NOT NULL DEFAULT '0'," +
+		"exemptRoles text NOT NULL DEFAULT ''," +
+		"exemptChannels text NOT NULL DEFAULT ''," +
+		"PRIMARY KEY (guildID)" +
+		")")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS wordFilterEntries (" +
+		"guildID varchar(25) NOT NULL," +
+		"pattern varchar(100) NOT NULL," +
+		"mode varchar(16) NOT NULL DEFAULT 'word'," +
+		"PRIMARY KEY (guildID, pattern)" +
+		")")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS autoThreads (" +
+		"guildID varchar(25) NOT NULL," +
+		"channelID varchar(25) NOT NULL," +
+		"attachmentsOnly integer NOT NULL DEFAULT '0'," +
+		"nameSource varchar(16) NOT NULL DEFAULT 'content'," +
+		"cooldown bigint NOT NULL DEFAULT '0'," +
+		"PRIMARY KEY (guildID, channelID)" +
+		")")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS autodelete (" +
+		"guildID varchar(25) NOT NULL," +
+		"channelID varchar(25) NOT NULL DEFAULT ''," +
+		"delay bigint NOT NULL DEFAULT '0'," +
+		"errorDelay bigint NOT NULL DEFAULT '0'," +
+		"PRIMARY KEY (guildID, channelID)" +
+		")")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS settingsAudit (" +
+		"id varchar(25) NOT NULL," +
+		"guildID varchar(25) NOT NULL DEFAULT ''," +
+		"actorID varchar(25) NOT NULL DEFAULT ''," +
+		"field varchar(64) NOT NULL DEFAULT ''," +
+		"oldValue text NOT NULL DEFAULT ''," +
+		"newValue text NOT NULL DEFAULT ''," +
+		"timestamp timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP," +
+		"PRIMARY KEY (id)" +
+		")")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS reportImports (" +
+		"guildID varchar(25) NOT NULL," +
+		"externalID varchar(64) NOT NULL," +
+		"reportID varchar(25) NOT NULL," +
+		"PRIMARY KEY (guildID, externalID)" +
+		")")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS modmailThreads (" +
+		"threadID varchar(25) NOT NULL," +
+		"guildID varchar(25) NOT NULL," +
+		"userID varchar(25) NOT NULL," +
+		"PRIMARY KEY (threadID)" +
+		")")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS guildRules (" +
+		"guildID varchar(25) NOT NULL," +
+		"channelID varchar(25) NOT NULL DEFAULT ''," +
+		"messageID varchar(25) NOT NULL DEFAULT ''," +
+		"roleID varchar(25) NOT NULL DEFAULT ''," +
+		"content text NOT NULL DEFAULT ''," +
+		"version integer NOT NULL DEFAULT '0'," +
+		"PRIMARY KEY (guildID)" +
+		")")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS giveaways (" +
+		"id varchar(25) NOT NULL," +
+		"guildID varchar(25) NOT NULL," +
+		"channelID varchar(25) NOT NULL DEFAULT ''," +
+		"messageID varchar(25) NOT NULL DEFAULT ''," +
+		"creatorID varchar(25) NOT NULL DEFAULT ''," +
+		"prize text NOT NULL DEFAULT ''," +
+		"winners integer NOT NULL DEFAULT '1'," +
+		"roleID varchar(25) NOT NULL DEFAULT ''," +
+		"minKarma integer NOT NULL DEFAULT '0'," +
+		"expires timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP," +
+		"ended integer NOT NULL DEFAULT '0'," +
+		"winnerIDs text NOT NULL DEFAULT ''," +
+		"PRIMARY KEY (id)" +
+		")")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS tempRoles (" +
+		"id varchar(25) NOT NULL," +
+		"guildID varchar(25) NOT NULL," +
+		"userID varchar(25) NOT NULL," +
+		"roleID varchar(25) NOT NULL," +
+		"executorID varchar(25) NOT NULL DEFAULT ''," +
+		"expires timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP," +
+		"attempts integer NOT NULL DEFAULT '0'," +
+		"PRIMARY KEY (id)" +
+		")")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE INDEX IF NOT EXISTS tempRoles_guildID ON tempRoles (guildID)")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS giveawayEntries (" +
+		"giveawayID varchar(25) NOT NULL," +
+		"guildID varchar(25) NOT NULL," +
+		"userID varchar(25) NOT NULL," +
+		"PRIMARY KEY (giveawayID, userID)" +
+		")")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS guildReportTypes (" +
+		"guildID varchar(25) NOT NULL," +
+		"typeID integer NOT NULL," +
+		"name varchar(32) NOT NULL DEFAULT ''," +
+		"color integer NOT NULL DEFAULT '0'," +
+		"PRIMARY KEY (guildID, typeID)" +
+		")")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS reportEscalation (" +
+		"guildID varchar(25) NOT NULL," +
+		"data text NOT NULL DEFAULT ''," +
+		"PRIMARY KEY (guildID)" +
+		")")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS broadcasts (" +
+		"id varchar(25) NOT NULL," +
+		"authorID varchar(25) NOT NULL DEFAULT ''," +
+		"content text NOT NULL DEFAULT ''," +
+		"created timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP," +
+		"finished integer NOT NULL DEFAULT '0'," +
+		"PRIMARY KEY (id)" +
+		")")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS broadcastDeliveries (" +
+		"broadcastID varchar(25) NOT NULL," +
+		"guildID varchar(25) NOT NULL," +
+		"status varchar(16) NOT NULL DEFAULT ''," +
+		"error text NOT NULL DEFAULT ''," +
+		"PRIMARY KEY (broadcastID, guildID)" +
+		")")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS guildDisabledCommands (" +
+		"guildID varchar(25) NOT NULL," +
+		"command varchar(32) NOT NULL," +
+		"PRIMARY KEY (guildID, command)" +
+		")")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS rulesAcceptances (" +
+		"guildID varchar(25) NOT NULL," +
+		"userID varchar(25) NOT NULL," +
+		"version integer NOT NULL DEFAULT '0'," +
+		"timestamp timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP," +
+		"PRIMARY KEY (guildID, userID)" +
+		")")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS trackedInvites (" +
+		"code varchar(32) NOT NULL," +
+		"guildID varchar(25) NOT NULL," +
+		"channelID varchar(25) NOT NULL DEFAULT ''," +
+		"creatorID varchar(25) NOT NULL DEFAULT ''," +
+		"label text NOT NULL DEFAULT ''," +
+		"created timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP," +
+		"PRIMARY KEY (code)" +
+		")")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS inviteJoins (" +
+		"iid serial," +
+		"guildID varchar(25) NOT NULL," +
+		"userID varchar(25) NOT NULL," +
+		"code varchar(32) NOT NULL DEFAULT ''," +
+		"source varchar(16) NOT NULL DEFAULT ''," +
+		"inviterID varchar(25) NOT NULL DEFAULT ''," +
+		"timestamp timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP," +
+		"PRIMARY KEY (iid)" +
+		")")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE INDEX IF NOT EXISTS inviteJoins_guildID ON inviteJoins (guildID)")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS autoPublish (" +
+		"guildID varchar(25) NOT NULL," +
+		"channelID varchar(25) NOT NULL," +
+		"skipBots integer NOT NULL DEFAULT '0'," +
+		"PRIMARY KEY (guildID, channelID)" +
+		")")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS prefixlessChannels (" +
+		"guildID varchar(25) NOT NULL," +
+		"channelID varchar(25) NOT NULL," +
+		"PRIMARY KEY (guildID, channelID)" +
+		")")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS messageStats (" +
+		"guildID varchar(25) NOT NULL," +
+		"channelID varchar(25) NOT NULL," +
+		"userID varchar(25) NOT NULL," +
+		"hour timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP," +
+		"count integer NOT NULL DEFAULT '0'," +
+		"PRIMARY KEY (guildID, channelID, userID, hour)" +
+		")")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS reactionReportConfig (" +
+		"guildID varchar(25) NOT NULL," +
+		"channelID varchar(25) NOT NULL DEFAULT ''," +
+		"emoji varchar(100) NOT NULL DEFAULT ''," +
+		"cooldown integer NOT NULL DEFAULT '300'," +
+		"PRIMARY KEY (guildID)" +
+		")")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS backupSchedules (" +
+		"guildID varchar(25) NOT NULL," +
+		"spec varchar(100) NOT NULL DEFAULT ''," +
+		"retention integer NOT NULL DEFAULT '10'," +
+		"notifyChannelID varchar(25) NOT NULL DEFAULT ''," +
+		"notifyOwner integer NOT NULL DEFAULT '0'," +
+		"lastRun timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP," +
+		"PRIMARY KEY (guildID)" +
+		")")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS inactivitySettings (" +
+		"guildID varchar(25) NOT NULL," +
+		"enabled integer NOT NULL DEFAULT '0'," +
+		"roleID varchar(25) NOT NULL DEFAULT ''," +
+		"days integer NOT NULL DEFAULT '0'," +
+		"warnDays integer NOT NULL DEFAULT '0'," +
+		"since timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP," +
+		"PRIMARY KEY (guildID)" +
+		")")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS memberActivity (" +
+		"guildID varchar(25) NOT NULL," +
+		"userID varchar(25) NOT NULL," +
+		"lastActive timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP," +
+		"warned timestamptz NULL DEFAULT NULL," +
+		"PRIMARY KEY (guildID, userID)" +
+		")")
+	if err != nil {
+		return
+	}
+
+	err = tx.Commit()
+	return
+}
+
+func (m *PostgresMiddleware) Connect(credentials ...interface{}) (err error) {
+	cfg := credentials[0].(models.DatabasePostgres)
+
+	sqlDb, err := sql.Open("postgres", cfg.DSN)
+	if err != nil {
+		return
+	}
+
+	sqlDb.SetMaxOpenConns(cfg.MaxOpenConns)
+	if cfg.MaxIdleConns > 0 {
+		sqlDb.SetMaxIdleConns(cfg.MaxIdleConns)
+	}
+	sqlDb.SetConnMaxLifetime(time.Duration(cfg.ConnMaxLifetimeSeconds) * time.Second)
+
+	m.Db = &db{sqldb.New(sqlDb, m.queryOpts, m.log)}
+
+	err = m.setup()
+	return
+}
+
+func (m *PostgresMiddleware) Close() {
+	if m.Db != nil {
+		m.Db.Close()
+	}
+}
+
+func (m *PostgresMiddleware) Status() error {
+	return m.Db.Ping()
+}
+
+func (m *PostgresMiddleware) getGuildSetting(guildID, key string) (string, error) {
+	var value string
+	err := m.Db.QueryRow(
+		fmt.Sprintf("SELECT %s FROM guilds WHERE guildID = ?", key),
+		guildID).Scan(&value)
+	err = wrapNotFoundError(err)
+	return value, err
+}
+
+func (m *PostgresMiddleware) setGuildSetting(guildID, key string, value string) (err error) {
+	res, err := m.Db.Exec(
+		fmt.Sprintf("UPDATE guilds SET %s = ? WHERE guildID = ?", key),
+		value, guildID)
+	if err != nil {
+		return
+	}
+
+	ar, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+	if ar == 0 {
+		_, err = m.Db.Exec(
+			fmt.Sprintf("INSERT INTO guilds (guildID, %s) VALUES (?, ?)", key),
+			guildID, value)
+	}
+
+	return nil
+}
+
+func (m *PostgresMiddleware) getUserSetting(userID, key string) (string, error) {
+	var value string
+	err := m.Db.QueryRow(
+		fmt.Sprintf("SELECT %s FROM users WHERE userID = ?", key),
+		userID).Scan(&value)
+	err = wrapNotFoundError(err)
+	return value, err
+}
+
+func (m *PostgresMiddleware) setUserSetting(userID, key string, value string) (err error) {
+	res, err := m.Db.Exec(
+		fmt.Sprintf("UPDATE users SET %s = ? WHERE userID = ?", key),
+		value, userID)
+	if err != nil {
+		return
+	}
+
+	ar, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+	if ar == 0 {
+		_, err = m.Db.Exec(
+			fmt.Sprintf("INSERT INTO users (userID, %s) VALUES (?, ?)", key),
+			userID, value)
+	}
+
+	return nil
+}
+
+func (m *PostgresMiddleware) GetGuildPrefix(guildID string) (string, error) {
+	val, err := m.getGuildSetting(guildID, "prefix")
+	return val, err
+}
+
+func (m *PostgresMiddleware) SetGuildPrefix(guildID, newPrefix string) error {
+	return m.setGuildSetting(guildID, "prefix", newPrefix)
+}
+
+func (m *PostgresMiddleware) GetGuildAutoRole(guildID string) ([]string, error) {
+	val, err := m.getGuildSetting(guildID, "autorole")
+	if val == "" {
+		return []string{}, err
+	}
+	return strings.Split(val, ";"), err
+}
+
+func (m *PostgresMiddleware) SetGuildAutoRole(guildID string, autoRoleIDs []string) error {
+	return m.setGuildSetting(guildID, "autorole", strings.Join(autoRoleIDs, ";"))
+}
+
+func (m *PostgresMiddleware) GetGuildAutoVC(guildID string) ([]string, error) {
+	val, err := m.getGuildSetting(guildID, "autovc")
+	if val == "" {
+		return []string{}, err
+	}
+	return strings.Split(val, ";"), err
+}
+
+func (m *PostgresMiddleware) SetGuildAutoVC(guildID string, autoVCIDs []string) error {
+	return m.setGuildSetting(guildID, "autovc", strings.Join(autoVCIDs, ";"))
+}
+
+func (m *PostgresMiddleware) GetGuildModLog(guildID string) (string, error) {
+	val, err := m.getGuildSetting(guildID, "modlogchanID")
+	return val, err
+}
+
+func (m *PostgresMiddleware) SetGuildModLog(guildID, chanID string) error {
+	return m.setGuildSetting(guildID, "modlogchanID", chanID)
+}
+
+func (m *PostgresMiddleware) GetGuildModLogFormat(guildID string) (models.ModLogFormat, error) {
+	val, err := m.getGuildSetting(guildID, "modlogFormat")
+	return models.ModLogFormat(val), err
+}
+
+func (m *PostgresMiddleware) SetGuildModLogFormat(guildID string, format models.ModLogFormat) error {
+	return m.setGuildSetting(guildID, "modlogFormat", string(format))
+}
+
+func (m *PostgresMiddleware) GetGuildModLogRoute(guildID string, action models.ModLogAction) (channelID string, err error) {
+	err = m.Db.QueryRow(`
+		SELECT channelID
+		FROM modLogRoutes
+		WHERE guildID = ? AND action = ?
+	`, guildID, action).Scan(&channelID)
+	return channelID, wrapNotFoundError(err)
+}
+
+func (m *PostgresMiddleware) GetGuildModLogRoutes(guildID string) (map[models.ModLogAction]string, error) {
+	rows, err := m.Db.Query(`
+		SELECT action, channelID
+		FROM modLogRoutes
+		WHERE guildID = ?
+	`, guildID)
+	if err != nil {
+		return nil, wrapNotFoundError(err)
+	}
+	defer rows.Close()
+
+	res := make(map[models.ModLogAction]string)
+	for rows.Next() {
+		var (
+			action    models.ModLogAction
+			channelID string
+		)
+		if err = rows.Scan(&action, &channelID); err != nil {
+			return nil, err
+		}
+		res[action] = channelID
+	}
+
+	return res, nil
+}
+
+func (m *PostgresMiddleware) SetGuildModLogRoute(guildID string, action models.ModLogAction, channelID string) error {
+	_, err := m.Db.Exec(`
+		INSERT INTO modLogRoutes (guildID, action, channelID)
+		VALUES (?, ?, ?)
+		ON CONFLICT (guildID, action) DO UPDATE SET channelID = ?
+	`, guildID, action, channelID, channelID)
+	return err
+}
+
+func (m *PostgresMiddleware) RemoveGuildModLogRoute(guildID string, action models.ModLogAction) error {
+	_, err := m.Db.Exec(`
+		DELETE FROM modLogRoutes
+		WHERE guildID = ? AND action = ?
+	`, guildID, action)
+	return wrapNotFoundError(err)
+}
+
+func (m *PostgresMiddleware) GetGuildVoiceLog(guildID string) (string, error) {
+	val, err := m.getGuildSetting(guildID, "voicelogchanID")
+	return val, err
+}
+
+func (m *PostgresMiddleware) SetGuildVoiceLog(guildID, chanID string) error {
+	return m.setGuildSetting(guildID, "voicelogchanID", chanID)
+}
+
+func (m *PostgresMiddleware) GetGuildNotifyRole(guildID string) (string, error) {
+	val, err := m.getGuildSetting(guildID, "notifyRoleID")
+	return val, err
+}
+
+func (m *PostgresMiddleware) SetGuildNotifyRole(guildID, roleID string) error {
+	return m.setGuildSetting(guildID, "notifyRoleID", roleID)
+}
+
+func (m *PostgresMiddleware) GetGuildGhostpingMsg(guildID string) (string, error) {
+	val, err := m.getGuildSetting(guildID, "ghostPingMsg")
+	return val, err
+}
+
+func (m *PostgresMiddleware) SetGuildGhostpingMsg(guildID, msg string) error {
+	return m.setGuildSetting(guildID, "ghostPingMsg", msg)
+}
+
+func (m *PostgresMiddleware) GetGuildColorReaction(guildID string) (enabled bool, err error) {
+	val, err := m.getGuildSetting(guildID, "colorReaction")
+	return val == "1", err
+}
+
+func (m *PostgresMiddleware) SetGuildColorReaction(guildID string, enabled bool) error {
+	var val string
+	if enabled {
+		val = "1"
+	}
+	return m.setGuildSetting(guildID, "colorReaction", val)
+}
+
+func (m *PostgresMiddleware) GetGuildPermissions(guildID string) (map[string]permissions.PermissionArray, error) {
+	results := make(map[string]permissions.PermissionArray)
+	rows, err := m.Db.Query("SELECT roleID, permission FROM permissions WHERE guildID = ?",
+		guildID)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var roleID string
+		var permission string
+		err := rows.Scan(&roleID, &permission)
+		if err != nil {
+			return nil, err
+		}
+		results[roleID] = strings.Split(permission, ",")
+	}
+	return results, nil
+}
+
+func (m *PostgresMiddleware) SetGuildRolePermission(guildID, roleID string, p permissions.PermissionArray) error {
+	if len(p) == 0 {
+		_, err := m.Db.Exec("DELETE FROM permissions WHERE roleID = ?", roleID)
+		return err
+	}
+
+	pStr := strings.Join(p, ",")
+	res, err := m.Db.Exec("UPDATE permissions SET permission = ? WHERE roleID = ? AND guildID = ?",
+		pStr, roleID, guildID)
+	if err != nil {
+		return err
+	}
+	ar, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if ar == 0 {
+		_, err = m.Db.Exec("INSERT INTO permissions (roleID, guildID, permission) VALUES (?, ?, ?)",
+			roleID, guildID, pStr)
+	}
+	return err
+}
+
+func (m *PostgresMiddleware) GetGuildJdoodleKey(guildID string) (string, error) {
+	val, err := m.getGuildSetting(guildID, "jdoodleToken")
+	return val, err
+}
+
+func (m *PostgresMiddleware) SetGuildJdoodleKey(guildID, key string) error {
+	return m.setGuildSetting(guildID, "jdoodleToken", key)
+}
+
+func (m *PostgresMiddleware) GetGuildCodeExecEnabled(guildID string) (bool, error) {
+	val, err := m.getGuildSetting(guildID, "codeExecEnabled")
+	return val == "1", err
+}
+
+func (m *PostgresMiddleware) SetGuildCodeExecEnabled(guildID string, enabled bool) error {
+	var val string
+	if enabled {
+		val = "1"
+	}
+	return m.setGuildSetting(guildID, "codeExecEnabled", val)
+}
+
+func (m *PostgresMiddleware) GetGuildCodeExecLanguages(guildID string) ([]string, error) {
+	val, err := m.getGuildSetting(guildID, "codeExecLanguages")
+	if val == "" {
+		return []string{}, err
+	}
+	return strings.Split(val, ","), err
+}
+
+func (m *PostgresMiddleware) SetGuildCodeExecLanguages(guildID string, languages []string) error {
+	return m.setGuildSetting(guildID, "codeExecLanguages", strings.Join(languages, ","))
+}
+
+func (m *PostgresMiddleware) GetGuildBackup(guildID string) (bool, error) {
+	val, err := m.getGuildSetting(guildID, "backup")
+	return val == "1", err
+}
+
+func (m *PostgresMiddleware) SetGuildBackup(guildID string, enabled bool) error {
+	var val string
+	if enabled {
+		val = "1"
+	}
+	return m.setGuildSetting(guildID, "backup", val)
+}
+
+func (m *PostgresMiddleware) GetSetting(setting string) (string, error) {
+	var value string
+	err := m.Db.QueryRow("SELECT value FROM settings WHERE setting = ?", setting).Scan(&value)
+	err = wrapNotFoundError(err)
+	return value, err
+}
+
+func (m *PostgresMiddleware) SetSetting(setting, value string) error {
+	res, err := m.Db.Exec("UPDATE settings SET value = ? WHERE setting = ?", value, setting)
+	ar, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if ar == 0 {
+		_, err = m.Db.Exec("INSERT INTO settings (setting, value) VALUES (?, ?)", setting, value)
+	}
+	return err
+}
+
+func (m *PostgresMiddleware) AddReport(rep models.Report) error {
+	_, err := m.Db.Exec(`
+		INSERT INTO reports (id, type, guildID, executorID, victimID, msg, attachment, timeout)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		rep.ID, rep.Type, rep.GuildID, rep.ExecutorID, rep.VictimID, rep.Msg, rep.AttachmentURL, rep.Timeout)
+	return err
+}
+
+func (m *PostgresMiddleware) DeleteReport(id snowflake.ID) error {
+	_, err := m.Db.Exec("DELETE FROM reports WHERE id = ?", id)
+	return err
+}
+
+func (m *PostgresMiddleware) RevokeReport(id snowflake.ID, rev models.ReportRevocation) error {
+	res, err := m.Db.Exec(`
+		UPDATE reports
+		SET revokedAt = ?, revokedBy = ?, revokeReason = ?
+		WHERE id = ?`, rev.Timestamp, rev.ExecutorID, rev.Reason, id)
+	if err != nil {
+		return err
+	}
+	ar, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if ar == 0 {
+		return database.ErrDatabaseNotFound
+	}
+	return nil
+}
+
+func (m *PostgresMiddleware) GetReport(id snowflake.ID) (models.Report, error) {
+	rep := models.Report{}
+
+	var rev models.ReportRevocation
+	var revokedAt *time.Time
+	row := m.Db.QueryRow(`
+		SELECT id, type, guildID, executorID, victimID, msg, attachment, timeout,
+			revokedAt, revokedBy, revokeReason
+		FROM reports WHERE id = ?`, id)
+	err := row.Scan(&rep.ID, &rep.Type, &rep.GuildID, &rep.ExecutorID, &rep.VictimID, &rep.Msg, &rep.AttachmentURL, &rep.Timeout,
+		&revokedAt, &rev.ExecutorID, &rev.Reason)
+	if err == sql.ErrNoRows {
+		return models.Report{}, database.ErrDatabaseNotFound
+	}
+	if revokedAt != nil {
+		rev.Timestamp = *revokedAt
+		rep.Revocation = &rev
+	}
+
+	return rep, err
+}
+
+func (m *PostgresMiddleware) GetReportsGuild(guildID string, offset, limit int) ([]models.Report, error) {
+	if limit == 0 {
+		limit = 1000
+	}
+
+	rows, err := m.Db.Query(`
+		SELECT id, type, guildID, executorID, victimID, msg, attachment, timeout
+		FROM reports WHERE guildID = ? AND revokedAt IS NULL
+		ORDER BY id DESC
+		LIMIT ? OFFSET ?
+	`, guildID, limit, offset)
+	var results []models.Report
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var rep models.Report
+		err := rows.Scan(&rep.ID, &rep.Type, &rep.GuildID, &rep.ExecutorID,
+			&rep.VictimID, &rep.Msg, &rep.AttachmentURL, &rep.Timeout)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, rep)
+	}
+	return results, nil
+}
+
+func (m *PostgresMiddleware) GetReportsFiltered(guildID, memberID string, repType models.ReportType, offset, limit int) ([]models.Report, error) {
+	args := []interface{}{}
+	query := `SELECT id, type, guildID, executorID, victimID, msg, attachment, timeout FROM reports WHERE revokedAt IS NULL`
+	if guildID != "" {
+		query += " AND guildID = ?"
+		args = append(args, guildID)
+	}
+	if memberID != "" {
+		query += " AND victimID = ?"
+		args = append(args, memberID)
+	}
+	if repType > -1 {
+		query += " AND type = ?"
+		args = append(args, repType)
+	}
+	query += ` ORDER BY id DESC LIMIT ? OFFSET ?`
+	args = append(args, limit, offset)
+
+	rows, err := m.Db.Query(query, args...)
+	var results []models.Report
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var rep models.Report
+		err := rows.Scan(&rep.ID, &rep.Type, &rep.GuildID, &rep.ExecutorID,
+			&rep.VictimID, &rep.Msg, &rep.AttachmentURL, &rep.Timeout)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, rep)
+	}
+	return results, nil
+}
+
+func (m *PostgresMiddleware) GetReportsGuildCount(guildID string) (count int, err error) {
+	err = m.Db.QueryRow("SELECT COUNT(id) FROM reports WHERE guildID = ? AND revokedAt IS NULL", guildID).Scan(&count)
+	return
+}
+
+func (m *PostgresMiddleware) GetRevokedReportsGuild(guildID string, offset, limit int) ([]models.Report, error) {
+	if limit == 0 {
+		limit = 1000
+	}
+
+	rows, err := m.Db.Query(`
+		SELECT id, type, guildID, executorID, victimID, msg, attachment, timeout,
+			revokedAt, revokedBy, revokeReason
+		FROM reports WHERE guildID = ? AND revokedAt IS NOT NULL
+		ORDER BY revokedAt DESC
+		LIMIT ? OFFSET ?
+	`, guildID, limit, offset)
+	var results []models.Report
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var rep models.Report
+		var rev models.ReportRevocation
+		err := rows.Scan(&rep.ID, &rep.Type, &rep.GuildID, &rep.ExecutorID,
+			&rep.VictimID, &rep.Msg, &rep.AttachmentURL, &rep.Timeout,
+			&rev.Timestamp, &rev.ExecutorID, &rev.Reason)
+		if err != nil {
+			return nil, err
+		}
+		rep.Revocation = &rev
+		results = append(results, rep)
+	}
+	return results, nil
+}
+
+func (m *PostgresMiddleware) GetRevokedReportsGuildCount(guildID string) (count int, err error) {
+	err = m.Db.QueryRow("SELECT COUNT(id) FROM reports WHERE guildID = ? AND revokedAt IS NOT NULL", guildID).Scan(&count)
+	return
+}
+
+func (m *PostgresMiddleware) GetReportsFilteredCount(guildID, memberID string, repType int) (count int, err error) {
+	if !stringutil.IsInteger(guildID) {
+		err = fmt.Errorf("invalid argument type")
+		return
+	}
+
+	args := []interface{}{guildID}
+	query := `SELECT COUNT(id) FROM reports WHERE guildID = ? AND revokedAt IS NULL`
+	if memberID != "" {
+		query += " AND victimID = ?"
+		args = append(args, memberID)
+	}
+	if repType > -1 {
+		query += " AND type = ?"
+		args = append(args, repType)
+	}
+
+	err = m.Db.QueryRow(query, args...).Scan(&count)
+	return
+}
+
+func (m *PostgresMiddleware) GetExpiredReports() (results []models.Report, err error) {
+	rows, err := m.Db.Query(`
+		SELECT id, type, guildID, executorID, victimID, msg, attachment, timeout
+		FROM reports
+		WHERE timeout <= CURRENT_TIMESTAMP AND revokedAt IS NULL`)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			err = nil
+		}
+		return
+	}
+
+	results = make([]models.Report, 0)
+	for rows.Next() {
+		var rep models.Report
+		err := rows.Scan(&rep.ID, &rep.Type, &rep.GuildID, &rep.ExecutorID,
+			&rep.VictimID, &rep.Msg, &rep.AttachmentURL, &rep.Timeout)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, rep)
+	}
+
+	return
+}
+
+func (m *PostgresMiddleware) ExpireReports(ids ...string) (err error) {
+	tx, err := m.Db.Begin()
+	if err != nil {
+		return
+	}
+	for _, id := range ids {
+		_, err = m.Db.Exec(`
+			UPDATE reports SET timeout = NULL
+			WHERE id = ?`, id)
+		if err != nil {
+			return
+		}
+	}
+	err = tx.Commit()
+	return
+}
+
+func (m *PostgresMiddleware) GetReportImport(guildID, externalID string) (reportID snowflake.ID, err error) {
+	err = m.Db.QueryRow(`
+		SELECT reportID FROM reportImports
+		WHERE guildID = ? AND externalID = ?`, guildID, externalID).
+		Scan(&reportID)
+	err = wrapNotFoundError(err)
+	return
+}
+
+func (m *PostgresMiddleware) AddReportImport(guildID, externalID string, reportID snowflake.ID) error {
+	_, err := m.Db.Exec(`
+		INSERT INTO reportImports (guildID, externalID, reportID)
+		VALUES (?, ?, ?)`, guildID, externalID, reportID)
+	return err
+}
+
+func (m *PostgresMiddleware) GetGuildReportTypes(guildID string) (res []models.CustomReportType, err error) {
+	rows, err := m.Db.Query(
+		"SELECT typeID, name, color FROM guildReportTypes WHERE guildID = ? ORDER BY typeID ASC", guildID)
+	err = wrapNotFoundError(err)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	res = make([]models.CustomReportType, 0)
+	for rows.Next() {
+		t := models.CustomReportType{GuildID: guildID}
+		if err = rows.Scan(&t.ID, &t.Name, &t.Color); err != nil {
+			return
+		}
+		res = append(res, t)
+	}
+
+	return
+}
+
+func (m *PostgresMiddleware) SetGuildReportType(t models.CustomReportType) (err error) {
+	_, err = m.Db.Exec(`
+		INSERT INTO guildReportTypes (guildID, typeID, name, color)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT (guildID, typeID) DO UPDATE SET name = ?, color = ?`,
+		t.GuildID, t.ID, t.Name, t.Color, t.Name, t.Color)
+	return
+}
+
+func (m *PostgresMiddleware) DeleteGuildReportType(guildID string, id models.ReportType) (err error) {
+	res, err := m.Db.Exec("DELETE FROM guildReportTypes WHERE guildID = ? AND typeID = ?", guildID, id)
+	if err != nil {
+		return
+	}
+	ar, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+	if ar == 0 {
+		err = database.ErrDatabaseNotFound
+	}
+	return
+}
+
+func (m *PostgresMiddleware) GetGuildReportEscalation(guildID string) (e models.ReportEscalation, err error) {
+	var data string
+	err = m.Db.QueryRow("SELECT data FROM reportEscalation WHERE guildID = ?", guildID).
+		Scan(&data)
+	err = wrapNotFoundError(err)
+	if err != nil {
+		return
+	}
+
+	err = json.Unmarshal([]byte(data), &e)
+	e.GuildID = guildID
+	return
+}
+
+func (m *PostgresMiddleware) SetGuildReportEscalation(e models.ReportEscalation) (err error) {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+
+	_, err = m.Db.Exec(`
+		INSERT INTO reportEscalation (guildID, data)
+		VALUES (?, ?)
+		ON CONFLICT (guildID) DO UPDATE SET data = ?`,
+		e.GuildID, string(data), string(data))
+	return
+}
+
+func (m *PostgresMiddleware) GetVotes() (map[string]vote.Vote, error) {
+	rows, err := m.Db.Query("SELECT id, data FROM votes")
+	results := make(map[string]vote.Vote)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var voteID, rawData string
+		err := rows.Scan(&voteID, &rawData)
+		if err != nil {
+			m.log.Error().Err(err).Msg("An error occured reading vote from database")
+			continue
+		}
+		vote, err := vote.Unmarshal(rawData)
+		if err != nil {
+			m.DeleteVote(rawData)
+		} else {
+			results[vote.ID] = vote
+		}
+	}
+	return results, err
+}
+
+func (m *PostgresMiddleware) AddUpdateVote(vote vote.Vote) error {
+	rawData, err := vote.Marshal()
+	if err != nil {
+		return err
+	}
+
+	_, err = m.Db.Exec(
+		"INSERT INTO votes (id, data) VALUES (?, ?) "+
+			"ON CONFLICT (id) DO UPDATE SET data = ?", vote.ID, rawData, rawData)
+
+	return err
+}
+
+func (m *PostgresMiddleware) DeleteVote(voteID string) error {
+	_, err := m.Db.Exec("DELETE FROM votes WHERE id = ?", voteID)
+	return err
+}
+
+func (m *PostgresMiddleware) GetTwitchNotify(twitchUserID, guildID string) (twitchnotify.DBEntry, error) {
+	t := twitchnotify.DBEntry{
+		TwitchUserID: twitchUserID,
+		GuildID:      guildID,
+	}
+	err := m.Db.QueryRow(
+		"SELECT channelID, template, roleID, plain FROM twitchnotify WHERE twitchUserID = ? AND guildID = ?",
+		twitchUserID, guildID).Scan(&t.ChannelID, &t.Template, &t.RoleID, &t.Plain)
+	err = wrapNotFoundError(err)
+	return t, err
+}
+
+func (m *PostgresMiddleware) SetTwitchNotify(twitchNotify twitchnotify.DBEntry) error {
+	res, err := m.Db.Exec(
+		"UPDATE twitchnotify SET channelID = ?, template = ?, roleID = ?, plain = ? "+
+			"WHERE twitchUserID = ? AND guildID = ?",
+		twitchNotify.ChannelID, twitchNotify.Template, twitchNotify.RoleID, twitchNotify.Plain,
+		twitchNotify.TwitchUserID, twitchNotify.GuildID)
+	if err != nil {
+		return err
+	}
+	ar, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if ar == 0 {
+		_, err = m.Db.Exec(
+			"INSERT INTO twitchnotify (twitchUserID, guildID, channelID, template, roleID, plain) "+
+				"VALUES (?, ?, ?, ?, ?, ?)",
+			twitchNotify.TwitchUserID, twitchNotify.GuildID, twitchNotify.ChannelID,
+			twitchNotify.Template, twitchNotify.RoleID, twitchNotify.Plain)
+	}
+	return err
+}
+
+func (m *PostgresMiddleware) DeleteTwitchNotify(twitchUserID, guildID string) error {
+	_, err := m.Db.Exec("DELETE FROM twitchnotify WHERE twitchUserID = ? AND guildID = ?", twitchUserID, guildID)
+	return err
+}
+
+func (m *PostgresMiddleware) GetAllTwitchNotifies(twitchUserID string) ([]twitchnotify.DBEntry, error) {
+	args := []interface{}{}
+	query := "SELECT twitchUserID, guildID, channelID, template, roleID, plain FROM twitchnotify"
+	if twitchUserID != "" {
+		query += " WHERE twitchUserID = ?"
+		args = append(args, twitchUserID)
+	}
+	rows, err := m.Db.Query(query, args...)
+	results := make([]twitchnotify.DBEntry, 0)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var t twitchnotify.DBEntry
+		err = rows.Scan(&t.TwitchUserID, &t.GuildID, &t.ChannelID, &t.Template, &t.RoleID, &t.Plain)
+		if err == nil {
+			results = append(results, t)
+		}
+	}
+	return results, nil
+}
+
+func (m *PostgresMiddleware) AddBackup(guildID, fileID string, manual bool) error {
+	timestamp := time.Now().Unix()
+	_, err := m.Db.Exec("INSERT INTO backups (guildID, timestamp, fileID, manual) VALUES (?, ?, ?, ?)", guildID, timestamp, fileID, manual)
+	return err
+}
+
+func (m *PostgresMiddleware) DeleteBackup(guildID, fileID string) error {
+	_, err := m.Db.Exec("DELETE FROM backups WHERE guildID = ? AND fileID = ?", guildID, fileID)
+	return err
+}
+
+func (m *PostgresMiddleware) GetGuildInviteBlock(guildID string) (string, error) {
+	return m.getGuildSetting(guildID, "inviteBlock")
+}
+
+func (m *PostgresMiddleware) SetGuildInviteBlock(guildID string, data string) error {
+	return m.setGuildSetting(guildID, "inviteBlock", data)
+}
+
+func (m *PostgresMiddleware) GetGuildJoinMsg(guildID string) (string, string, error) {
+	data, err := m.getGuildSetting(guildID, "joinMsg")
+	if err != nil {
+		return "", "", err
+	}
+	if data == "" {
+		return "", "", nil
+	}
+
+	i := strings.Index(data, "|")
+	if i < 0 || len(data) < i+1 {
+		return "", "", nil
+	}
+
+	return data[:i], data[i+1:], nil
+}
+
+func (m *PostgresMiddleware) SetGuildJoinMsg(guildID string, msg string, channelID string) error {
+	return m.setGuildSetting(guildID, "joinMsg", fmt.Sprintf("%s|%s", msg, channelID))
+}
+
+func (m *PostgresMiddleware) GetGuildLeaveMsg(guildID string) (string, string, error) {
+	data, err := m.getGuildSetting(guildID, "leaveMsg")
+	if err != nil {
+		return "", "", err
+	}
+	if data == "" {
+		return "", "", nil
+	}
+
+	i := strings.Index(data, "|")
+	if i < 0 || len(data) < i+1 {
+		return "", "", nil
+	}
+
+	return data[:i], data[i+1:], nil
+}
+
+func (m *PostgresMiddleware) SetGuildLeaveMsg(guildID string, channelID string, msg string) error {
+	return m.setGuildSetting(guildID, "leaveMsg", fmt.Sprintf("%s|%s", channelID, msg))
+}
+
+func (m *PostgresMiddleware) GetBackups(guildID string) ([]backupmodels.Entry, error) {
+	rows, err := m.Db.Query("SELECT guildID, timestamp, fileID, manual FROM backups WHERE guildID = ?", guildID)
+	if err == sql.ErrNoRows {
+		return nil, database.ErrDatabaseNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	backups := make([]backupmodels.Entry, 0)
+	for rows.Next() {
+		var be backupmodels.Entry
+		var timeStampUnix int64
+		err = rows.Scan(&be.GuildID, &timeStampUnix, &be.FileID, &be.Manual)
+		if err != nil {
+			return nil, err
+		}
+		be.Timestamp = time.Unix(timeStampUnix, 0)
+		backups = append(backups, be)
+	}
+
+	return backups, nil
+}
+
+func (m *PostgresMiddleware) GetGuilds() ([]string, error) {
+	rows, err := m.Db.Query("SELECT guildID FROM guilds WHERE backup = '1'")
+	if err == sql.ErrNoRows {
+		return nil, database.ErrDatabaseNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	guilds := make([]string, 0)
+	for rows.Next() {
+		var s string
+		err = rows.Scan(&s)
+		if err != nil {
+			return nil, err
+		}
+		guilds = append(guilds, s)
+	}
+
+	return guilds, err
+}
+
+func (m *PostgresMiddleware) AddTag(tag tag.Tag) error {
+	_, err := m.Db.Exec("INSERT INTO tags (id, ident, creatorID, guildID, content, created, lastEdit) VALUES "+
+		"(?, ?, ?, ?, ?, ?, ?)", tag.ID, tag.Ident, tag.CreatorID, tag.GuildID, tag.Content, tag.Created.Unix(), tag.LastEdit.Unix())
+	return err
+}
+
+func (m *PostgresMiddleware) EditTag(tag tag.Tag) error {
+	_, err := m.Db.Exec("UPDATE tags SET "+
+		"ident = ?, creatorID = ?, guildID = ?, content = ?, created = ?, lastEdit = ? "+
+		"WHERE id = ?", tag.Ident, tag.CreatorID, tag.GuildID, tag.Content, tag.Created.Unix(), tag.LastEdit.Unix(), tag.ID)
+	if err == sql.ErrNoRows {
+		return database.ErrDatabaseNotFound
+	}
+	return err
+}
+
+func (m *PostgresMiddleware) GetTagByID(id snowflake.ID) (tag.Tag, error) {
+	var tag tag.Tag
+
+	var timestampCreated int64
+	var timestampLastEdit int64
+
+	row := m.Db.QueryRow("SELECT id, ident, creatorID, guildID, content, created, lastEdit FROM tags "+
+		"WHERE id = ?", id)
+
+	err := row.Scan(&tag.ID, &tag.Ident, &tag.CreatorID, &tag.GuildID,
+		&tag.Content, &timestampCreated, &timestampLastEdit)
+	if err == sql.ErrNoRows {
+		return tag, database.ErrDatabaseNotFound
+	}
+	if err != nil {
+		return tag, err
+	}
+
+	tag.Created = time.Unix(timestampCreated, 0)
+	tag.LastEdit = time.Unix(timestampLastEdit, 0)
+
+	return tag, nil
+}
+
+func (m *PostgresMiddleware) GetTagByIdent(ident string, guildID string) (tag.Tag, error) {
+	var tag tag.Tag
+	var timestampCreated int64
+	var timestampLastEdit int64
+
+	row := m.Db.QueryRow("SELECT id, ident, creatorID, guildID, content, created, lastEdit FROM tags "+
+		"WHERE ident = ? AND guildID = ?", ident, guildID)
+
+	err := row.Scan(&tag.ID, &tag.Ident, &tag.CreatorID, &tag.GuildID,
+		&tag.Content, &timestampCreated, &timestampLastEdit)
+	if err == sql.ErrNoRows {
+		return tag, database.ErrDatabaseNotFound
+	}
+	if err != nil {
+		return tag, err
+	}
+
+	tag.Created = time.Unix(timestampCreated, 0)
+	tag.LastEdit = time.Unix(timestampLastEdit, 0)
+
+	return tag, nil
+}
+
+func (m *PostgresMiddleware) GetGuildTags(guildID string) ([]tag.Tag, error) {
+	rows, err := m.Db.Query("SELECT id, ident, creatorID, guildID, content, created, lastEdit FROM tags "+
+		"WHERE guildID = ?", guildID)
+	if err == sql.ErrNoRows {
+		return nil, database.ErrDatabaseNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make([]tag.Tag, 0)
+	var timestampCreated int64
+	var timestampLastEdit int64
+	for rows.Next() {
+		var tag tag.Tag
+		err = rows.Scan(&tag.ID, &tag.Ident, &tag.CreatorID, &tag.GuildID,
+			&tag.Content, &timestampCreated, &timestampLastEdit)
+		if err != nil {
+			return nil, err
+		}
+		tag.Created = time.Unix(timestampCreated, 0)
+		tag.LastEdit = time.Unix(timestampLastEdit, 0)
+		tags = append(tags, tag)
+	}
+
+	return tags, nil
+}
+
+func (m *PostgresMiddleware) DeleteTag(id snowflake.ID) error {
+	_, err := m.Db.Exec("DELETE FROM tags WHERE id = ?", id)
+	if err == sql.ErrNoRows {
+		return database.ErrDatabaseNotFound
+	}
+	return err
+}
+
+func (m *PostgresMiddleware) SetAPIToken(token models.APITokenEntry) (err error) {
+	res, err := m.Db.Exec(
+		"UPDATE apitokens SET "+
+			"salt = ?, created = ?, expires = ?, lastAccess = ?, hits = ? "+
+			"WHERE userID = ?",
+		token.Salt, token.Created, token.Expires, token.LastAccess, token.Hits, token.UserID)
+	if err != nil {
+		return
+	}
+
+	ar, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+	if ar == 0 {
+		_, err = m.Db.Exec(
+			"INSERT INTO apitokens "+
+				"(userID, salt, created, expires, lastAccess, hits) "+
+				"VALUES (?, ?, ?, ?, ?, ?)",
+			token.UserID, token.Salt, token.Created, token.Expires, token.LastAccess, token.Hits)
+	}
+	return
+}
+
+func (m *PostgresMiddleware) AddAPITokenHit(userID string, t time.Time) (err error) {
+	_, err = m.Db.Exec(
+		"UPDATE apitokens SET hits = hits + 1, lastAccess = ? WHERE userID = ?",
+		t, userID)
+	return
+}
+
+func (m *PostgresMiddleware) GetAPIToken(userID string) (t models.APITokenEntry, err error) {
+	err = m.Db.QueryRow(
+		"SELECT userID, salt, created, expires, lastAccess, hits "+
+			"FROM apitokens WHERE userID = ?", userID).
+		Scan(&t.UserID, &t.Salt, &t.Created, &t.Expires, &t.LastAccess, &t.Hits)
+	err = wrapNotFoundError(err)
+	return
+}
+
+func (m *PostgresMiddleware) DeleteAPIToken(userID string) error {
+	_, err := m.Db.Exec("DELETE FROM apitokens WHERE userID = ?", userID)
+	if err == sql.ErrNoRows {
+		return database.ErrDatabaseNotFound
+	}
+	return err
+}
+
+func (m *PostgresMiddleware) GetKarma(userID, guildID string) (i int, err error) {
+	err = m.Db.QueryRow("SELECT value FROM karma WHERE userID = ? AND guildID = ?",
+		userID, guildID).Scan(&i)
+	err = wrapNotFoundError(err)
+	return
+}
+
+func (m *PostgresMiddleware) GetKarmaSum(userID string) (i int, err error) {
+	err = m.Db.QueryRow("SELECT COALESCE(SUM(value), 0) FROM karma WHERE userID = ?",
+		userID).Scan(&i)
+	err = wrapNotFoundError(err)
+	return
+}
+
+func (m *PostgresMiddleware) GetKarmaGuild(guildID string, limit int) ([]models.GuildKarma, error) {
+	if limit < 1 {
+		limit = 1000
+	}
+
+	res := make([]models.GuildKarma, limit)
+
+	rows, err := m.Db.Query(
+		`SELECT userID, value FROM karma WHERE guildID = ?
+		ORDER BY value DESC
+		LIMIT ?`,
+		guildID, limit)
+	if err == sql.ErrNoRows {
+		return nil, database.ErrDatabaseNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	i := 0
+	for rows.Next() {
+		var v models.GuildKarma
+		v.GuildID = guildID
+		if err = rows.Scan(&v.UserID, &v.Value); err != nil {
+			return nil, err
+		}
+		res[i] = v
+		i++
+	}
+
+	return res[:i], nil
+}
+
+func (m *PostgresMiddleware) SetKarma(userID, guildID string, val int) (err error) {
+	res, err := m.Db.Exec("UPDATE karma SET value = ? WHERE userID = ? AND guildID = ?",
+		val, userID, guildID)
+	if err != nil {
+		return
+	}
+
+	ar, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+	if ar == 0 {
+		_, err = m.Db.Exec("INSERT INTO karma (userID, guildID, value) VALUES (?, ?, ?)",
+			userID, guildID, val)
+	}
+	return
+}
+
+func (m *PostgresMiddleware) ResetKarmaGuild(guildID string) (err error) {
+	_, err = m.Db.Exec("DELETE FROM karma WHERE guildID = ?", guildID)
+	return
+}
+
+func (m *PostgresMiddleware) UpdateKarma(userID, guildID string, diff int) (err error) {
+	res, err := m.Db.Exec("UPDATE karma SET value = value + ? WHERE userID = ? AND guildID = ?",
+		diff, userID, guildID)
+	if err != nil {
+		return
+	}
+
+	ar, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+	if ar == 0 {
+		_, err = m.Db.Exec("INSERT INTO karma (userID, guildID, value) VALUES (?, ?, ?)",
+			userID, guildID, diff)
+	}
+
+	return
+}
+
+func (m *PostgresMiddleware) SetKarmaState(guildID string, state bool) (err error) {
+	_, err = m.Db.Exec(
+		"INSERT INTO karmaSettings (guildID, state) "+
+			"VALUES (?, ?) "+
+			"ON CONFLICT (guildID) DO UPDATE SET state = ?",
+		guildID, state, state)
+
+	return
+}
+
+func (m *PostgresMiddleware) GetKarmaState(guildID string) (state bool, err error) {
+	err = m.Db.QueryRow("SELECT state FROM karmaSettings WHERE guildID = ?",
+		guildID).Scan(&state)
+	err = wrapNotFoundError(err)
+
+	return
+}
+
+func (m *PostgresMiddleware) SetKarmaEmotes(guildID, emotesInc, emotesDec string) (err error) {
+	_, err = m.Db.Exec(
+		"INSERT INTO karmaSettings (guildID, emotesInc, emotesDec) "+
+			"VALUES (?, ?, ?) "+
+			"ON CONFLICT (guildID) DO UPDATE SET emotesInc = ?, emotesDec = ?",
+		guildID, emotesInc, emotesDec, emotesInc, emotesDec)
+
+	return
+}
+
+func (m *PostgresMiddleware) GetKarmaEmotes(guildID string) (emotesInc, emotesDec string, err error) {
+	err = m.Db.QueryRow("SELECT emotesInc, emotesDec FROM karmaSettings WHERE guildID = ?",
+		guildID).Scan(&emotesInc, &emotesDec)
+	err = wrapNotFoundError(err)
+
+	return
+}
+
+func (m *PostgresMiddleware) SetKarmaTokens(guildID string, tokens int) (err error) {
+	_, err = m.Db.Exec(
+		"INSERT INTO karmaSettings (guildID, tokens) "+
+			"VALUES (?, ?) "+
+			"ON CONFLICT (guildID) DO UPDATE SET tokens = ?",
+		guildID, tokens, tokens)
+
+	return
+}
+
+func (m *PostgresMiddleware) GetKarmaTokens(guildID string) (tokens int, err error) {
+	err = m.Db.QueryRow("SELECT tokens FROM karmaSettings WHERE guildID = ?",
+		guildID).Scan(&tokens)
+	err = wrapNotFoundError(err)
+
+	return
+}
+
+func (m *PostgresMiddleware) SetKarmaPenalty(guildID string, state bool) (err error) {
+	_, err = m.Db.Exec(
+		"INSERT INTO karmaSettings (guildID, penalty) "+
+			"VALUES (?, ?) "+
+			"ON CONFLICT (guildID) DO UPDATE SET penalty = ?",
+		guildID, state, state)
+
+	return
+}
+
+func (m *PostgresMiddleware) GetKarmaPenalty(guildID string) (state bool, err error) {
+	err = m.Db.QueryRow("SELECT penalty FROM karmaSettings WHERE guildID = ?",
+		guildID).Scan(&state)
+	err = wrapNotFoundError(err)
+
+	return
+}
+
+func (m *PostgresMiddleware) SetKarmaLimits(guildID string, limits models.KarmaLimits) (err error) {
+	_, err = m.Db.Exec(
+		"INSERT INTO karmaSettings (guildID, giveCooldown, giveDailyCap, receiveCap, receiveInterval) "+
+			"VALUES (?, ?, ?, ?, ?) "+
+			"ON CONFLICT (guildID) DO UPDATE SET giveCooldown = ?, giveDailyCap = ?, receiveCap = ?, receiveInterval = ?",
+		guildID, limits.GiveCooldown, limits.GiveDailyCap, limits.ReceiveCap, limits.ReceiveInterval,
+		limits.GiveCooldown, limits.GiveDailyCap, limits.ReceiveCap, limits.ReceiveInterval)
+
+	return
+}
+
+func (m *PostgresMiddleware) GetKarmaLimits(guildID string) (limits models.KarmaLimits, err error) {
+	err = m.Db.QueryRow(
+		"SELECT giveCooldown, giveDailyCap, receiveCap, receiveInterval FROM karmaSettings WHERE guildID = ?",
+		guildID).Scan(&limits.GiveCooldown, &limits.GiveDailyCap, &limits.ReceiveCap, &limits.ReceiveInterval)
+	err = wrapNotFoundError(err)
+
+	return
+}
+
+func (m *PostgresMiddleware) GetKarmaBlockList(guildID string) (list []string, err error) {
+	row, err := m.Db.Query("SELECT userID FROM karmaBlocklist WHERE guildID = ?", guildID)
+	err = wrapNotFoundError(err)
+	if err != nil {
+		return
+	}
+
+	list = make([]string, 0)
+	var id string
+	for row.Next() {
+		if err = row.Scan(&id); err != nil {
+			return
+		}
+		list = append(list, id)
+	}
+
+	return
+}
+
+func (m *PostgresMiddleware) IsKarmaBlockListed(guildID, userID string) (ok bool, err error) {
+	err = m.Db.QueryRow("SELECT 1 FROM karmaBlocklist WHERE guildID = ? AND userID = ?",
+		guildID, userID).Scan(&ok)
+	if err != nil && err != sql.ErrNoRows {
+		return
+	}
+
+	err = nil
+
+	return
+}
+
+func (m *PostgresMiddleware) AddKarmaBlockList(guildID, userID string) (err error) {
+	_, err = m.Db.Query("INSERT INTO karmaBlocklist (guildID, userID) VALUES (?, ?)",
+		guildID, userID)
+	return
+}
+
+func (m *PostgresMiddleware) RemoveKarmaBlockList(guildID, userID string) (err error) {
+	_, err = m.Db.Query("DELETE FROM karmaBlocklist WHERE guildID = ? AND userID = ?",
+		guildID, userID)
+	return
+}
+
+func (m *PostgresMiddleware) SetLockChan(chanID, guildID, executorID, permissions string) error {
+	_, err := m.Db.Exec("INSERT INTO chanlock (chanID, guildID, executorID, permissions) VALUES (?, ?, ?, ?)",
+		chanID, guildID, executorID, permissions)
+	return err
+}
+
+func (m *PostgresMiddleware) GetLockChan(chanID string) (guildID, executorID, permissions string, err error) {
+	err = m.Db.QueryRow("SELECT guildID, executorID, permissions FROM chanlock WHERE chanID = ?", chanID).
+		Scan(&guildID, &executorID, &permissions)
+	err = wrapNotFoundError(err)
+	return
+}
+
+func (m *PostgresMiddleware) GetLockChannels(guildID string) (chanIDs []string, err error) {
+	chanIDs = make([]string, 0)
+	rows, err := m.Db.Query("SELECT chanID FROM chanlock WHERE guildID = ?", guildID)
+	err = wrapNotFoundError(err)
+	if err != nil {
+		return
+	}
+
+	for rows.Next() {
+		var id string
+		if err = rows.Scan(&id); err != nil {
+			return
+		}
+		chanIDs = append(chanIDs, id)
+	}
+
+	return
+}
+
+func (m *PostgresMiddleware) DeleteLockChan(chanID string) error {
+	_, err := m.Db.Exec("DELETE FROM chanlock WHERE chanID = ?",
+		chanID)
+	err = wrapNotFoundError(err)
+	return err
+}
+
+func (m *PostgresMiddleware) SetAntiraidState(guildID string, state bool) (err error) {
+	_, err = m.Db.Exec(
+		"INSERT INTO antiraidSettings (guildID, state) "+
+			"VALUES (?, ?) "+
+			"ON CONFLICT (guildID) DO UPDATE SET state = ?",
+		guildID, state, state)
+
+	return
+}
+
+func (m *PostgresMiddleware) GetAntiraidState(guildID string) (state bool, err error) {
+	err = m.Db.QueryRow("SELECT state FROM antiraidSettings WHERE guildID = ?",
+		guildID).Scan(&state)
+	err = wrapNotFoundError(err)
+
+	return
+}
+
+func (m *PostgresMiddleware) SetAntiraidRegeneration(guildID string, limit int) (err error) {
+	_, err = m.Db.Exec(
+		"INSERT INTO antiraidSettings (guildID, \"limit\") "+
+			"VALUES (?, ?) "+
+			"ON CONFLICT (guildID) DO UPDATE SET \"limit\" = ?",
+		guildID, limit, limit)
+
+	return
+}
+
+func (m *PostgresMiddleware) GetAntiraidRegeneration(guildID string) (limit int, err error) {
+	err = m.Db.QueryRow("SELECT \"limit\" FROM antiraidSettings WHERE guildID = ?",
+		guildID).Scan(&limit)
+	err = wrapNotFoundError(err)
+
+	return
+}
+
+func (m *PostgresMiddleware) SetAntiraidBurst(guildID string, burst int) (err error) {
+	_, err = m.Db.Exec(
+		"INSERT INTO antiraidSettings (guildID, burst) "+
+			"VALUES (?, ?) "+
+			"ON CONFLICT (guildID) DO UPDATE SET burst = ?",
+		guildID, burst, burst)
+
+	return
+}
+
+func (m *PostgresMiddleware) GetAntiraidBurst(guildID string) (burst int, err error) {
+	err = m.Db.QueryRow("SELECT burst FROM antiraidSettings WHERE guildID = ?",
+		guildID).Scan(&burst)
+	err = wrapNotFoundError(err)
+
+	return
+}
+
+func (m *PostgresMiddleware) AddToAntiraidJoinList(guildID, userID, userTag string, accountCreated time.Time) (err error) {
+	_, err = m.Db.Exec("INSERT INTO antiraidJoinlog (userID, guildID, tag, accountCreated) "+
+		"VALUES (?, ?, ?, ?) ON CONFLICT DO NOTHING", userID, guildID, userTag, accountCreated)
+	return
+}
+
+func (m *PostgresMiddleware) SetAntiraidVerification(guildID string, state bool) (err error) {
+	_, err = m.Db.Exec(
+		"INSERT INTO antiraidSettings (guildID, verification) "+
+			"VALUES (?, ?) "+
+			"ON CONFLICT (guildID) DO UPDATE SET verification = ?",
+		guildID, state, state)
+
+	return
+}
+
+func (m *PostgresMiddleware) GetAntiraidVerification(guildID string) (state bool, err error) {
+	err = m.Db.QueryRow("SELECT verification FROM antiraidSettings WHERE guildID = ?",
+		guildID).Scan(&state)
+	err = wrapNotFoundError(err)
+
+	return
+}
+
+func (m *PostgresMiddleware) GetAntiraidJoinList(guildID string) (res []models.JoinLogEntry, err error) {
+	query := "SELECT userID, tag, accountCreated, timestamp, guildID FROM antiraidJoinlog"
+	var args []interface{}
+
+	if guildID != "" {
+		query += " WHERE guildID = ?"
+		args = []interface{}{guildID}
+	}
+
+	rows, err := m.Db.Query(query, args...)
+	if err != nil {
+		return
+	}
+
+	for rows.Next() {
+		entry := models.JoinLogEntry{GuildID: guildID}
+		if err = rows.Scan(&entry.UserID, &entry.Tag, &entry.Created, &entry.Timestamp, &entry.GuildID); err != nil {
+			return
+		}
+		res = append(res, entry)
+	}
+
+	return
+}
+
+func (m *PostgresMiddleware) FlushAntiraidJoinList(guildID string) (err error) {
+	_, err = m.Db.Exec("DELETE FROM antiraidJoinlog WHERE guildID = ?", guildID)
+	err = wrapNotFoundError(err)
+
+	return
+}
+
+func (m *PostgresMiddleware) RemoveAntiraidJoinList(guildID, userID string) (err error) {
+	_, err = m.Db.Exec(`DELETE FROM antiraidJoinlog WHERE guildID = ? AND userID = ?`, guildID, userID)
+	err = wrapNotFoundError(err)
+
+	return
+}
+
+func (m *PostgresMiddleware) GetGuildUnbanRequests(guildID string, limit, offset int) (r []models.UnbanRequest, err error) {
+	rows, err := m.Db.Query(`
+		SELECT id, userID, guildID, userTag, message, processedBy, status, processed, processedMessage, reportID
+		FROM unbanRequests
+		WHERE guildID = ?
+		ORDER BY id DESC
+		LIMIT ?
+		OFFSET ?
+	`, guildID, limit, offset)
+	err = wrapNotFoundError(err)
+	if err != nil {
+		return
+	}
+
+	r = make([]models.UnbanRequest, 0)
+	for rows.Next() {
+		var req models.UnbanRequest
+		if err = rows.Scan(
+			&req.ID, &req.UserID, &req.GuildID, &req.UserTag, &req.Message,
+			&req.ProcessedBy, &req.Status, &req.Processed, &req.ProcessedMessage,
+			&req.ReportID,
+		); err != nil {
+			return
+		}
+		r = append(r, req)
+	}
+
+	return
+}
+
+func (m *PostgresMiddleware) GetGuildUnbanRequestsCount(guildID string, state *models.UnbanRequestState) (n int, err error) {
+	query := "SELECT COUNT(id) FROM unbanRequests WHERE guildID = ?"
+	params := []interface{}{guildID}
+	if state != nil {
+		query += " AND status = ?"
+		params = append(params, *state)
+	}
+	err = m.Db.QueryRow(
+		query,
+		params...).Scan(&n)
+	return n, err
+}
+
+func (m *PostgresMiddleware) GetGuildUserUnbanRequests(userID, guildID string) (r []models.UnbanRequest, err error) {
+	query := `SELECT id, userID, guildID, userTag, message, processedBy, status, processed, processedMessage, reportID
+		FROM unbanRequests
+		WHERE userID = ?`
+	params := []interface{}{userID}
+
+	if guildID != "" {
+		query += " AND guildID = ?"
+		params = append(params, guildID)
+	}
+
+	rows, err := m.Db.Query(query, params...)
+	err = wrapNotFoundError(err)
+	if err != nil {
+		return
+	}
+
+	r = make([]models.UnbanRequest, 0)
+	for rows.Next() {
+		var req models.UnbanRequest
+		if err = rows.Scan(
+			&req.ID, &req.UserID, &req.GuildID, &req.UserTag, &req.Message,
+			&req.ProcessedBy, &req.Status, &req.Processed, &req.ProcessedMessage,
+			&req.ReportID,
+		); err != nil {
+			return
+		}
+		r = append(r, req)
+	}
+
+	return
+}
+
+func (m *PostgresMiddleware) GetUnbanRequest(id string) (r models.UnbanRequest, err error) {
+	row := m.Db.QueryRow(
+		`SELECT id, userID, guildID, userTag, message, processedBy, status, processed, processedMessage, reportID
+		FROM unbanRequests
+		WHERE id = ?`, id)
+
+	err = row.Scan(
+		&r.ID, &r.UserID, &r.GuildID, &r.UserTag, &r.Message,
+		&r.ProcessedBy, &r.Status, &r.Processed, &r.ProcessedMessage,
+		&r.ReportID,
+	)
+	err = wrapNotFoundError(err)
+	return
+}
+
+func (m *PostgresMiddleware) AddUnbanRequest(r models.UnbanRequest) (err error) {
+	_, err = m.Db.Exec(
+		`INSERT INTO unbanRequests
+		(id, userID, guildID, userTag, message, processedBy, status, processed, processedMessage, reportID)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		r.ID, r.UserID, r.GuildID, r.UserTag, r.Message, r.ProcessedBy,
+		r.Status, r.Processed, r.ProcessedMessage, r.ReportID)
+
+	return
+}
+
+func (m *PostgresMiddleware) UpdateUnbanRequest(r models.UnbanRequest) (err error) {
+	_, err = m.Db.Exec(
+		`UPDATE unbanRequests
+		SET processedBy = ?, status = ?, processed = ?, processedMessage = ?
+		WHERE id = ?`,
+		r.ProcessedBy, r.Status, r.Processed, r.ProcessedMessage,
+		r.ID)
+	err = wrapNotFoundError(err)
+	return
+}
+
+func (m *PostgresMiddleware) GetUserOTAEnabled(userID string) (enabled bool, err error) {
+	v, err := m.getUserSetting(userID, "enableOTA")
+	enabled = v == "1"
+	return
+}
+
+func (m *PostgresMiddleware) SetUserOTAEnabled(userID string, enabled bool) error {
+	v := "0"
+	if enabled {
+		v = "1"
+	}
+	return m.setUserSetting(userID, "enableOTA", v)
+}
+
+func (m *PostgresMiddleware) GetUserVerified(userID string) (enabled bool, err error) {
+	v, err := m.getUserSetting(userID, "verified")
+	enabled = v == "1"
+	return
+}
+
+func (m *PostgresMiddleware) SetUserVerified(userID string, enabled bool) error {
+	v := "0"
+	if enabled {
+		v = "1"
+	}
+	return m.setUserSetting(userID, "verified", v)
+}
+
+func (m *PostgresMiddleware) GetUserStarboardOptout(userID string) (enabled bool, err error) {
+	v, err := m.getUserSetting(userID, "starboardOptout")
+	enabled = v == "1"
+	return
+}
+
+func (m *PostgresMiddleware) SetUserStarboardOptout(userID string, enabled bool) error {
+	v := "0"
+	if enabled {
+		v = "1"
+	}
+	return m.setUserSetting(userID, "starboardOptout", v)
+}
+
+func (m *PostgresMiddleware) GetGuildVoiceLogIgnores(guildID string) (res []string, err error) {
+	row, err := m.Db.Query("SELECT channelID FROM voicelogBlocklist WHERE guildID = ?", guildID)
+	err = wrapNotFoundError(err)
+	if err != nil {
+		return
+	}
+
+	res = make([]string, 0)
+	var id string
+	for row.Next() {
+		if err = row.Scan(&id); err != nil {
+			return
+		}
+		res = append(res, id)
+	}
+
+	return
+}
+
+func (m *PostgresMiddleware) IsGuildVoiceLogIgnored(guildID, channelID string) (ok bool, err error) {
+	err = m.Db.QueryRow("SELECT 1 FROM voicelogBlocklist WHERE guildID = ? AND channelID = ?",
+		guildID, channelID).Scan(&ok)
+	if err != nil && err != sql.ErrNoRows {
+		return
+	}
+
+	err = nil
+
+	return
+}
+
+func (m *PostgresMiddleware) SetGuildVoiceLogIngore(guildID, channelID string) (err error) {
+	if ok, err := m.IsGuildVoiceLogIgnored(guildID, channelID); err != nil {
+		return err
+	} else if ok {
+		return nil
+	}
+	_, err = m.Db.Exec("INSERT INTO voicelogBlocklist (guildID, channelID) VALUES (?, ?)",
+		guildID, channelID)
+	return
+}
+
+func (m *PostgresMiddleware) RemoveGuildVoiceLogIgnore(guildID, channelID string) (err error) {
+	_, err = m.Db.Exec("DELETE FROM voicelogBlocklist WHERE guildID = ? AND channelID = ?",
+		guildID, channelID)
+	err = wrapNotFoundError(err)
+	return
+}
+
+func (m *PostgresMiddleware) SetStarboardConfig(config models.StarboardConfig) (err error) {
+	var ok bool
+	m.Db.QueryRow("SELECT 1 FROM starboardConfig WHERE guildID = ?",
+		config.GuildID).Scan(&ok)
+
+	if ok {
+		_, err = m.Db.Exec(
+			"UPDATE starboardConfig SET "+
+				"channelID = ?, threshold = ?, emojiID = ?, karmaGain = ? "+
+				"WHERE guildID = ?",
+			config.ChannelID, config.Threshold, config.EmojiID, config.KarmaGain, config.GuildID)
+	} else {
+		_, err = m.Db.Exec(
+			"INSERT INTO starboardConfig "+
+				"(guildID, channelID, threshold, emojiID, karmaGain) "+
+				"VALUES (?, ?, ?, ?, ?)",
+			config.GuildID, config.ChannelID, config.Threshold, config.EmojiID, config.KarmaGain)
+	}
+
+	return
+}
+
+func (m *PostgresMiddleware) GetStarboardConfig(guildID string) (config models.StarboardConfig, err error) {
+	err = m.Db.QueryRow("SELECT channelID, threshold, emojiID, karmaGain FROM starboardConfig WHERE guildID = ?", guildID).
+		Scan(&config.ChannelID, &config.Threshold, &config.EmojiID, &config.KarmaGain)
+	err = wrapNotFoundError(err)
+
+	return
+}
+
+func (m *PostgresMiddleware) SetStarboardEntry(e models.StarboardEntry) (err error) {
+	var ok bool
+	m.Db.QueryRow("SELECT 1 FROM starboardEntries WHERE messageID = ?",
+		e.MessageID).Scan(&ok)
+
+	if ok {
+		_, err = m.Db.Exec(
+			"UPDATE starboardEntries SET "+
+				"score = ?, deleted = ?, starboardID = ? "+
+				"WHERE messageID = ?",
+			e.Score, e.Deleted, e.StarboardID, e.MessageID)
+	} else {
+		_, err = m.Db.Exec(
+			"INSERT INTO starboardEntries "+
+				"(messageID, starboardID, guildID, channelID, authorID, content, mediaURLs, score, deleted) "+
+				"VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)",
+			e.MessageID, e.StarboardID, e.GuildID, e.ChannelID, e.AuthorID, e.Content, e.MediaURLsEncoded(), e.Score, e.Deleted)
+	}
+	return
+}
+
+func (m *PostgresMiddleware) RemoveStarboardEntry(msgID string) (err error) {
+	_, err = m.Db.Exec("DELETE FROM starboardEntries WHERE messageID = ?", msgID)
+	return
+}
+
+func (m *PostgresMiddleware) GetStarboardEntries(
+	guildID string,
+	sortBy models.StarboardSortBy,
+	limit, offset int,
+) (res []models.StarboardEntry, err error) {
+	var sort string
+	switch sortBy {
+	case models.StarboardSortByLatest:
+		sort = "ORDER BY starboardID DESC"
+	case models.StarboardSortByMostRated:
+		sort = "ORDER BY score DESC"
+	}
+
+	query := fmt.Sprintf("SELECT messageID, starboardID, guildID, channelID, authorID, content, mediaURLs, score, deleted "+
+		"FROM starboardEntries "+
+		"WHERE guildID = ? %s LIMIT ? OFFSET ?", sort)
+	row, err := m.Db.Query(query, guildID, limit, offset)
+	err = wrapNotFoundError(err)
+	if err != nil {
+		return
+	}
+
+	res = make([]models.StarboardEntry, 0)
+	for row.Next() {
+		var e models.StarboardEntry
+		var mediaURLencoded string
+		err = row.Scan(&e.MessageID, &e.StarboardID, &e.GuildID, &e.ChannelID, &e.AuthorID, &e.Content, &mediaURLencoded, &e.Score, &e.Deleted)
+		if err != nil {
+			return
+		}
+		if err = e.SetMediaURLs(mediaURLencoded); err != nil {
+			return
+		}
+		res = append(res, e)
+	}
+
+	return
+}
+
+func (m *PostgresMiddleware) GetStarboardEntriesCount(guildID string) (n int, err error) {
+	err = m.Db.QueryRow(
+		"SELECT COUNT(messageID) FROM starboardEntries WHERE guildID = ?", guildID).Scan(&n)
+	return
+}
+
+func (m *PostgresMiddleware) GetStarboardEntry(messageID string) (e models.StarboardEntry, err error) {
+	var mediaURLencoded string
+	err = m.Db.QueryRow(
+		"SELECT messageID, starboardID, guildID, channelID, authorID, content, mediaURLs, score, deleted "+
+			"FROM starboardEntries "+
+			"WHERE messageID = ?",
+		messageID).
+		Scan(&e.MessageID, &e.StarboardID, &e.GuildID, &e.ChannelID, &e.AuthorID, &e.Content, &mediaURLencoded, &e.Score, &e.Deleted)
+	err = wrapNotFoundError(err)
+	if err != nil {
+		return
+	}
+	err = e.SetMediaURLs(mediaURLencoded)
+
+	return
+}
+
+func (m *PostgresMiddleware) GetUserByRefreshToken(token string) (userID string, expires time.Time, err error) {
+	err = m.Db.QueryRow("SELECT userID, expires FROM refreshTokens WHERE token = ?", token).Scan(
+		&userID, &expires)
+	err = wrapNotFoundError(err)
+	return
+}
+
+func (m *PostgresMiddleware) SetUserRefreshToken(userID, token string, expires time.Time) (err error) {
+	res, err := m.Db.Exec(
+		"UPDATE refreshTokens SET "+
+			"token = ?, expires = ? "+
+			"WHERE userID = ?",
+		token, expires, userID)
+	if err != nil {
+		return
+	}
+
+	ar, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+	if ar == 0 {
+		_, err = m.Db.Exec(
+			"INSERT INTO refreshTokens "+
+				"(userID, token, expires) "+
+				"VALUES (?, ?, ?)",
+			userID, token, expires)
+	}
+	return
+}
+
+func (m *PostgresMiddleware) RevokeUserRefreshToken(userID string) (err error) {
+	_, err = m.Db.Exec("DELETE FROM refreshTokens WHERE userID = ?", userID)
+	err = wrapNotFoundError(err)
+	return
+}
+
+func (m *PostgresMiddleware) CleanupExpiredRefreshTokens() (n int64, err error) {
+	res, err := m.Db.Exec("DELETE FROM refreshTokens WHERE expires < CURRENT_TIMESTAMP")
+	if err != nil {
+		return
+	}
+
+	n, err = res.RowsAffected()
+	return
+}
+
+func (m *PostgresMiddleware) GetKarmaRules(guildID string) (res []models.KarmaRule, err error) {
+	rows, err := m.Db.Query("SELECT id, trigger, value, action, argument, checksum "+
+		"FROM karmaRules WHERE guildID = ?", guildID)
+	err = wrapNotFoundError(err)
+	if err != nil {
+		return
+	}
+
+	res = make([]models.KarmaRule, 0)
+	for rows.Next() {
+		var r models.KarmaRule
+		r.GuildID = guildID
+		if err = rows.Scan(&r.ID, &r.Trigger, &r.Value, &r.Action, &r.Argument, &r.Checksum); err != nil {
+			return
+		}
+		res = append(res, r)
+	}
+
+	return
+}
+
+func (m *PostgresMiddleware) CheckKarmaRule(guildID, checksum string) (ok bool, err error) {
+	err = m.Db.QueryRow("SELECT 1 FROM karmaRules WHERE guildID = ? AND checksum = ?",
+		guildID, checksum).Scan(&ok)
+	if err != nil && err != sql.ErrNoRows {
+		return
+	}
+
+	err = nil
+
+	return
+}
+
+func (m *PostgresMiddleware) AddOrUpdateKarmaRule(rule models.KarmaRule) (err error) {
+	var exists bool
+	err = m.Db.QueryRow("SELECT 1 FROM karmaRules WHERE id = ?",
+		rule.ID).Scan(&exists)
+	if err != nil && err != sql.ErrNoRows {
+		return
+	}
+
+	if exists {
+		_, err = m.Db.Exec("UPDATE karmaRules "+
+			"SET trigger = ?, value = ?, action = ?, argument = ?, checksum = ? "+
+			"WHERE guildID = ? AND id = ?",
+			rule.Trigger, rule.Value, rule.Action, rule.Argument, rule.Checksum,
+			rule.GuildID, rule.ID)
+	} else {
+		_, err = m.Db.Exec("INSERT INTO karmaRules "+
+			"(id, guildID, trigger, value, action, argument, checksum) "+
+			"VALUES (?, ?, ?, ?, ?, ?, ?)",
+			rule.ID, rule.GuildID, rule.Trigger, rule.Value, rule.Action, rule.Argument, rule.Checksum)
+	}
+
+	return
+}
+
+func (m *PostgresMiddleware) RemoveKarmaRule(guildID string, id snowflake.ID) (err error) {
+	_, err = m.Db.Exec("DELETE FROM karmaRules WHERE guildID = ? AND id = ?", guildID, id)
+	err = wrapNotFoundError(err)
+	return
+}
+
+func (m *PostgresMiddleware) GetGuildLogDisable(guildID string) (bool, error) {
+	val, err := m.getGuildSetting(guildID, "guildlogDisable")
+	return val == "1", err
+}
+
+func (m *PostgresMiddleware) SetGuildLogDisable(guildID string, enabled bool) error {
+	var val string
+	if enabled {
+		val = "1"
+	}
+	return m.setGuildSetting(guildID, "guildlogDisable", val)
+}
+
+func (m *PostgresMiddleware) GetGuildCommandSuggestionsDisable(guildID string) (bool, error) {
+	val, err := m.getGuildSetting(guildID, "cmdSuggestDisable")
+	return val == "1", err
+}
+
+func (m *PostgresMiddleware) SetGuildCommandSuggestionsDisable(guildID string, disabled bool) error {
+	var val string
+	if disabled {
+		val = "1"
+	}
+	return m.setGuildSetting(guildID, "cmdSuggestDisable", val)
+}
+
+func (m *PostgresMiddleware) GetGuildEmbedColor(guildID string) (int, error) {
+	val, err := m.getGuildSetting(guildID, "embedColor")
+	if err != nil {
+		return 0, err
+	}
+	if val == "" {
+		return 0, database.ErrDatabaseNotFound
+	}
+	return strconv.Atoi(val)
+}
+
+func (m *PostgresMiddleware) SetGuildEmbedColor(guildID string, color int) error {
+	var val string
+	if color >= 0 {
+		val = strconv.Itoa(color)
+	}
+	return m.setGuildSetting(guildID, "embedColor", val)
+}
+
+func (m *PostgresMiddleware) GetGuildDisabledCommands(guildID string) (res []string, err error) {
+	rows, err := m.Db.Query("SELECT command FROM guildDisabledCommands WHERE guildID = ?", guildID)
+	err = wrapNotFoundError(err)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	res = make([]string, 0)
+	var cmd string
+	for rows.Next() {
+		if err = rows.Scan(&cmd); err != nil {
+			return
+		}
+		res = append(res, cmd)
+	}
+
+	return
+}
+
+func (m *PostgresMiddleware) SetGuildDisabledCommand(guildID, command string, disabled bool) (err error) {
+	if disabled {
+		_, err = m.Db.Exec("INSERT INTO guildDisabledCommands (guildID, command) VALUES (?, ?) ON CONFLICT DO NOTHING",
+			guildID, command)
+	} else {
+		_, err = m.Db.Exec("DELETE FROM guildDisabledCommands WHERE guildID = ? AND command = ?",
+			guildID, command)
+	}
+	return
+}
+
+func (m *PostgresMiddleware) GetGuildDisabledCommandMessage(guildID string) (string, bool, error) {
+	data, err := m.getGuildSetting(guildID, "disabledCmdMsg")
+	if err != nil || data == "" {
+		return "", false, err
+	}
+
+	i := strings.Index(data, "|")
+	if i < 0 {
+		return "", false, nil
+	}
+
+	return data[i+1:], data[:i] == "1", nil
+}
+
+func (m *PostgresMiddleware) SetGuildDisabledCommandMessage(guildID string, msg string, silent bool) error {
+	silentS := "0"
+	if silent {
+		silentS = "1"
+	}
+	return m.setGuildSetting(guildID, "disabledCmdMsg", fmt.Sprintf("%s|%s", silentS, msg))
+}
+
+func (m *PostgresMiddleware) GetGuildConfirmActions(guildID string) ([]models.ConfirmAction, error) {
+	val, err := m.getGuildSetting(guildID, "confirmActions")
+	if val == "" {
+		return []models.ConfirmAction{}, err
+	}
+	split := strings.Split(val, ",")
+	actions := make([]models.ConfirmAction, len(split))
+	for i, a := range split {
+		actions[i] = models.ConfirmAction(a)
+	}
+	return actions, err
+}
+
+func (m *PostgresMiddleware) SetGuildConfirmActions(guildID string, actions []models.ConfirmAction) error {
+	split := make([]string, len(actions))
+	for i, a := range actions {
+		split[i] = string(a)
+	}
+	return m.setGuildSetting(guildID, "confirmActions", strings.Join(split, ","))
+}
+
+func (m *PostgresMiddleware) GetGuildPermDeniedMessage(guildID string) (string, bool, error) {
+	data, err := m.getGuildSetting(guildID, "permDeniedMsg")
+	if err != nil || data == "" {
+		return "", false, err
+	}
+
+	i := strings.Index(data, "|")
+	if i < 0 {
+		return "", false, nil
+	}
+
+	return data[i+1:], data[:i] == "1", nil
+}
+
+func (m *PostgresMiddleware) SetGuildPermDeniedMessage(guildID string, msg string, silent bool) error {
+	silentS := "0"
+	if silent {
+		silentS = "1"
+	}
+	return m.setGuildSetting(guildID, "permDeniedMsg", fmt.Sprintf("%s|%s", silentS, msg))
+}
+
+func (m *PostgresMiddleware) GetGuildModmailChannel(guildID string) (string, error) {
+	return m.getGuildSetting(guildID, "modmailChanID")
+}
+
+func (m *PostgresMiddleware) SetGuildModmailChannel(guildID, chanID string) error {
+	return m.setGuildSetting(guildID, "modmailChanID", chanID)
+}
+
+func (m *PostgresMiddleware) GetGuildLogEntries(
+	guildID string,
+	offset, limit int,
+	severity models.GuildLogSeverity,
+	ascending bool,
+) (res []models.GuildLogEntry, err error) {
+	order := "DESC"
+	if ascending {
+		order = "ASC"
+	}
+	rows, err := m.Db.Query(
+		"SELECT id, module, message, severity, timestamp "+
+			"FROM guildlog "+
+			"WHERE guildID = ? AND (? < 0 OR severity = ?)"+
+			"ORDER BY timestamp "+order+" "+
+			"LIMIT ? OFFSET ?",
+		guildID, severity, severity,
+		limit, offset)
+	err = wrapNotFoundError(err)
+	if err != nil {
+		return
+	}
+
+	res = make([]models.GuildLogEntry, 0)
+	for rows.Next() {
+		var r models.GuildLogEntry
+		r.GuildID = guildID
+		if err = rows.Scan(&r.ID, &r.Module, &r.Message, &r.Severity, &r.Timestamp); err != nil {
+			return
+		}
+		res = append(res, r)
+	}
+
+	return
+}
+
+func (m *PostgresMiddleware) GetGuildLogEntriesCount(guildID string, severity models.GuildLogSeverity) (n int, err error) {
+	err = m.Db.QueryRow(
+		"SELECT COUNT(id) FROM guildlog WHERE guildID = ? AND (? < 0 OR severity = ?)",
+		guildID, severity, severity).Scan(&n)
+	return
+}
+
+func (m *PostgresMiddleware) AddGuildLogEntry(e models.GuildLogEntry) (err error) {
+	_, err = m.Db.Exec(
+		"INSERT INTO guildlog (id, guildID, module, message, severity, timestamp) "+
+			"VALUES (?, ?, ?, ?, ?, ?)",
+		e.ID, e.GuildID, e.Module, e.Message, e.Severity, e.Timestamp)
+	return
+}
+
+func (m *PostgresMiddleware) DeleteLogEntry(guildID string, id snowflake.ID) (err error) {
+	_, err = m.Db.Exec("DELETE FROM guildlog WHERE guildID = ? AND id = ?",
+		guildID, id)
+	err = wrapNotFoundError(err)
+	return
+}
+
+func (m *PostgresMiddleware) DeleteLogEntries(guildID string) (err error) {
+	_, err = m.Db.Exec("DELETE FROM guildlog WHERE guildID = ?", guildID)
+	err = wrapNotFoundError(err)
+	return
+}
+
+func (m *PostgresMiddleware) FlushGuildData(guildID string) (err error) {
+	tx, err := m.Db.Begin()
+	if err != nil {
+		return
+	}
+
+	deleteFrom := func(table string) error {
+		_, err = tx.Exec(
+			fmt.Sprintf("DELETE FROM %s WHERE guildID = ?", table),
+			guildID)
+		return err
+	}
+
+	mErr := multierror.New()
+	for _, table := range guildTables {
+		mErr.Append(deleteFrom(table))
+	}
+
+	if mErr.Len() > 0 {
+		return mErr
+	}
+
+	return tx.Commit()
+}
+
+func (m *PostgresMiddleware) GetGuildAPI(guildID string) (settings models.GuildAPISettings, err error) {
+	err = m.Db.QueryRow(`SELECT enabled, origins, tokenHash FROM guildapi WHERE guildID = ?`, guildID).
+		Scan(&settings.Enabled, &settings.AllowedOrigins, &settings.TokenHash)
+	err = wrapNotFoundError(err)
+	return
+}
+
+func (m *PostgresMiddleware) SetGuildAPI(guildID string, settings models.GuildAPISettings) (err error) {
+	var ok bool
+	m.Db.QueryRow("SELECT 1 FROM guildapi WHERE guildID = ?",
+		guildID).Scan(&ok)
+
+	if ok {
+		_, err = m.Db.Exec(`
+			UPDATE guildapi
+			SET enabled = ?, origins = ?, tokenHash = ?
+			WHERE guildID = ?`,
+			settings.Enabled, settings.AllowedOrigins, settings.TokenHash, guildID)
+	} else {
+		_, err = m.Db.Exec(`
+			INSERT INTO guildapi
+			(guildID, enabled, origins, tokenHash)
+			VALUES (?, ?, ?, ?)`,
+			guildID, settings.Enabled, settings.AllowedOrigins, settings.TokenHash)
+	}
+
+	return
+}
+
+func (m *PostgresMiddleware) GetGuildVerificationRequired(guildID string) (bool, error) {
+	val, err := m.getGuildSetting(guildID, "requireUserVerification")
+	return val == "1", err
+}
+
+func (m *PostgresMiddleware) SetGuildVerificationRequired(guildID string, enable bool) error {
+	var val string
+	if enable {
+		val = "1"
+	}
+	return m.setGuildSetting(guildID, "requireUserVerification", val)
+}
+
+func (m *PostgresMiddleware) GetVerificationQueue(guildID, userID string) (res []models.VerificationQueueEntry, err error) {
+	var args []interface{}
+	query := `
+		SELECT guildID, userID, timestamp
+		FROM verificationQueue
+		WHERE true
+	`
+	if guildID != "" {
+		args = append(args, guildID)
+		query += " AND guildID = ?"
+	}
+	if userID != "" {
+		args = append(args, userID)
+		query += " AND userID = ?"
+	}
+
+	rows, err := m.Db.Query(query, args...)
+	if err != nil {
+		return
+	}
+
+	for rows.Next() {
+		var r models.VerificationQueueEntry
+		if err = rows.Scan(&r.GuildID, &r.UserID, &r.Timestamp); err != nil {
+			return
+		}
+		res = append(res, r)
+	}
+	return
+}
+
+func (m *PostgresMiddleware) FlushVerificationQueue(guildID string) (err error) {
+	var args []interface{}
+	query := `DELETE FROM verificationQueue`
+	if guildID != "" {
+		args = []interface{}{guildID}
+		query += " WHERE guildID = ?"
+	}
+	_, err = m.Db.Exec(query, args...)
+	return
+}
+
+func (m *PostgresMiddleware) AddVerificationQueue(e models.VerificationQueueEntry) (err error) {
+	res, err := m.Db.Exec(`
+		UPDATE verificationQueue
+		SET timestamp = ?
+		WHERE guildID = ? AND userID = ?
+	`, e.Timestamp, e.GuildID, e.UserID)
+
+	affected, err := res.RowsAffected()
+	if err != nil && err != sql.ErrNoRows {
+		return
+	}
+
+	if affected == 0 {
+		_, err = m.Db.Exec(`
+			INSERT INTO verificationQueue (guildID, userID, timestamp)
+			VALUES (?, ?, ?)
+		`, e.GuildID, e.UserID, e.Timestamp)
+	}
+	return
+}
+
+func (m *PostgresMiddleware) RemoveVerificationQueue(guildID, userID string) (ok bool, err error) {
+	res, err := m.Db.Exec(`
+		DELETE FROM verificationQueue
+		WHERE guildID = ? AND userID = ?
+	`, guildID, userID)
+	err = wrapNotFoundError(err)
+	affected, err := res.RowsAffected()
+	ok = affected > 0
+	return
+}
+
+func (m *PostgresMiddleware) FlushUserData(userID string) (res map[string]int, err error) {
+	res = make(map[string]int)
+
+	r, err := m.Db.Exec(`
+		UPDATE reports
+		SET executorID = '000000000000000000'
+		WHERE executorID = ? AND victimID != ?
+	`, userID, userID)
+	if err != nil && err != sql.ErrNoRows {
+		return
+	}
+	affected, err := r.RowsAffected()
+	if err != nil {
+		return
+	}
+	res["reports"] = int(affected)
+
+	r, err = m.Db.Exec(`
+		DELETE FROM karma
+		WHERE userID = ?
+		AND value >= 0
+	`, userID)
+	if err != nil && err != sql.ErrNoRows {
+		return
+	}
+	affected, err = r.RowsAffected()
+	if err != nil {
+		return
+	}
+	res["karma"] = int(affected)
+
+	for _, tc := range userTables {
+		r, err = m.Db.Exec(fmt.Sprintf(`
+			DELETE FROM %s
+			WHERE %s = ?
+		`, tc.Table, tc.Column), userID)
+		if err != nil && err != sql.ErrNoRows {
+			return
+		}
+		affected, err = r.RowsAffected()
+		if err != nil {
+			return
+		}
+		res[tc.Table] = int(affected)
+	}
+
+	return
+}
+
+func (m *PostgresMiddleware) GetGuildBirthdayChan(guildID string) (chanID string, err error) {
+	chanID, err = m.getGuildSetting(guildID, "birthdaychanID")
+	return
+}
+
+func (m *PostgresMiddleware) SetGuildBirthdayChan(guildID string, chanID string) (err error) {
+	err = m.setGuildSetting(guildID, "birthdaychanID", chanID)
+	return
+}
+
+func (m *PostgresMiddleware) GetBirthdays(guildID string) (bd []models.Birthday, err error) {
+	query := "SELECT guildID, userID, date, showYear FROM birthdays"
+	var params []interface{}
+
+	if guildID != "" {
+		query += " WHERE guildID = ?"
+		params = []interface{}{guildID}
+	}
+
+	rows, err := m.Db.Query(query, params...)
+	if err != nil {
+		err = wrapNotFoundError(err)
+		return
+	}
+
+	for rows.Next() {
+		var b models.Birthday
+		err = rows.Scan(&b.GuildID, &b.UserID, &b.Date, &b.ShowYear)
+		if err != nil {
+			return
+		}
+		bd = append(bd, b)
+	}
+
+	return
+}
+
+func (m *PostgresMiddleware) SetBirthday(bd models.Birthday) (err error) {
+	res, err := m.Db.Exec(
+		"UPDATE birthdays SET date = ?, showYear = ? "+
+			"WHERE guildID = ? AND userID = ?",
+		bd.Date, bd.ShowYear, bd.GuildID, bd.UserID)
+	if err != nil {
+		return wrapNotFoundError(err)
+	}
+	ar, err := res.RowsAffected()
+	if ar == 0 {
+		_, err = m.Db.Exec(
+			"INSERT INTO birthdays (guildID, userID, date, showYear) "+
+				"VALUES (?, ?, ?, ?)", bd.GuildID, bd.UserID, bd.Date, bd.ShowYear)
+	}
+	return wrapNotFoundError(err)
+}
+
+func (m *PostgresMiddleware) DeleteBirthday(guildID, userID string) (err error) {
+	_, err = m.Db.Exec("DELETE FROM birthdays WHERE guildID = ? AND userID = ?",
+		guildID, userID)
+	return wrapNotFoundError(err)
+}
+
+func (m *PostgresMiddleware) AddRoleSelects(v []models.RoleSelect) error {
+	tx, err := m.Db.Begin()
+	if err != nil {
+		return err
+	}
+
+	for _, rs := range v {
+		_, err = m.Db.Exec(`
+			INSERT INTO roleselect (guildID, channelID, messageID, roleID)
+			VALUES (?, ?, ?, ?)
+			ON CONFLICT DO NOTHING
+		`, rs.GuildID, rs.ChannelID, rs.MessageID, rs.RoleID)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	err = tx.Commit()
+
+	return wrapNotFoundError(err)
+}
+
+func (m *PostgresMiddleware) GetRoleSelects() ([]models.RoleSelect, error) {
+	rows, err := m.Db.Query(`
+		SELECT guildID, channelID, messageID, roleID
+		FROM roleselect
+	`)
+	if err != nil {
+		return nil, wrapNotFoundError(err)
+	}
+
+	var rs []models.RoleSelect
+	for rows.Next() {
+		var r models.RoleSelect
+		err = rows.Scan(&r.GuildID, &r.ChannelID, &r.MessageID, &r.RoleID)
+		if err != nil {
+			return nil, err
+		}
+		rs = append(rs, r)
+	}
+
+	return rs, nil
+}
+
+func (m *PostgresMiddleware) RemoveRoleSelect(guildID, channelID, messageID string) error {
+	_, err := m.Db.Exec(`
+		DELETE FROM roleselect
+		WHERE guildID = ? AND channelID = ? AND messageID = ?
+	`, guildID, channelID, messageID)
+	return wrapNotFoundError(err)
+}
+
+func (m *PostgresMiddleware) GetGuildAnnouncementChannel(guildID string) (string, error) {
+	return m.getGuildSetting(guildID, "announcementChannel")
+}
+
+func (m *PostgresMiddleware) SetGuildAnnouncementChannel(guildID, chanID string) error {
+	return m.setGuildSetting(guildID, "announcementChannel", chanID)
+}
+
+func (m *PostgresMiddleware) GetGuildMemberMsgGrace(guildID string) (time.Duration, bool, error) {
+	data, err := m.getGuildSetting(guildID, "memberMsgGrace")
+	if err != nil || data == "" {
+		return 0, false, err
+	}
+
+	i := strings.Index(data, "|")
+	if i < 0 {
+		return 0, false, nil
+	}
+
+	seconds, err := strconv.Atoi(data[i+1:])
+	if err != nil {
+		return 0, false, err
+	}
+
+	return time.Duration(seconds) * time.Second, data[:i] == "1", nil
+}
+
+func (m *PostgresMiddleware) SetGuildMemberMsgGrace(guildID string, period time.Duration, noteRejoin bool) error {
+	noteS := "0"
+	if noteRejoin {
+		noteS = "1"
+	}
+	return m.setGuildSetting(guildID, "memberMsgGrace", fmt.Sprintf("%s|%d", noteS, int(period.Seconds())))
+}
+
+func (m *PostgresMiddleware) GetGuildPinRotation(guildID string) (bool, error) {
+	val, err := m.getGuildSetting(guildID, "pinRotation")
+	return val == "1", err
+}
+
+func (m *PostgresMiddleware) SetGuildPinRotation(guildID string, enabled bool) error {
+	var val string
+	if enabled {
+		val = "1"
+	}
+	return m.setGuildSetting(guildID, "pinRotation", val)
+}
+
+func (m *PostgresMiddleware) GetGuildMessageStatsEnabled(guildID string) (bool, error) {
+	val, err := m.getGuildSetting(guildID, "messageStats")
+	return val == "1", err
+}
+
+func (m *PostgresMiddleware) SetGuildMessageStatsEnabled(guildID string, enabled bool) error {
+	var val string
+	if enabled {
+		val = "1"
+	}
+	return m.setGuildSetting(guildID, "messageStats", val)
+}
+
+func (m *PostgresMiddleware) GetGuildBotNickname(guildID string) (string, error) {
+	return m.getGuildSetting(guildID, "botNickname")
+}
+
+func (m *PostgresMiddleware) SetGuildBotNickname(guildID, nickname string) error {
+	return m.setGuildSetting(guildID, "botNickname", nickname)
+}
+
+func (m *PostgresMiddleware) GetGuildModNot(guildID string) (string, error) {
+	val, err := m.getGuildSetting(guildID, "modnotchanID")
+	return val, err
+}
+
+func (m *PostgresMiddleware) SetGuildModNot(guildID, chanID string) error {
+	return m.setGuildSetting(guildID, "modnotchanID", chanID)
+}
+
+func (m *PostgresMiddleware) GetAutoDelete(guildID, channelID string) (cfg models.AutoDeleteConfig, err error) {
+	// Channel specific configs take precedence over the
+	// guild wide default config with an empty channelID.
+	err = m.Db.QueryRow(`
+		SELECT guildID, channelID, delay, errorDelay
+		FROM autodelete
+		WHERE guildID = ? AND (channelID = ? OR channelID = '')
+		ORDER BY channelID DESC
+		LIMIT 1
+	`, guildID, channelID).
+		Scan(&cfg.GuildID, &cfg.ChannelID, &cfg.Delay, &cfg.ErrorDelay)
+	return cfg, wrapNotFoundError(err)
+}
+
+func (m *PostgresMiddleware) GetAutoDeletes(guildID string) ([]models.AutoDeleteConfig, error) {
+	rows, err := m.Db.Query(`
+		SELECT guildID, channelID, delay, errorDelay
+		FROM autodelete
+		WHERE guildID = ?
+	`, guildID)
+	if err != nil {
+		return nil, wrapNotFoundError(err)
+	}
+
+	var res []models.AutoDeleteConfig
+	for rows.Next() {
+		var cfg models.AutoDeleteConfig
+		err = rows.Scan(&cfg.GuildID, &cfg.ChannelID, &cfg.Delay, &cfg.ErrorDelay)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, cfg)
+	}
+
+	return res, nil
+}
+
+func (m *PostgresMiddleware) SetAutoDelete(cfg models.AutoDeleteConfig) error {
+	_, err := m.Db.Exec(`
+		INSERT INTO autodelete (guildID, channelID, delay, errorDelay)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT (guildID, channelID) DO UPDATE SET delay = ?, errorDelay = ?
+	`, cfg.GuildID, cfg.ChannelID, cfg.Delay, cfg.ErrorDelay, cfg.Delay, cfg.ErrorDelay)
+	return err
+}
+
+func (m *PostgresMiddleware) RemoveAutoDelete(guildID, channelID string) error {
+	_, err := m.Db.Exec(`
+		DELETE FROM autodelete
+		WHERE guildID = ? AND channelID = ?
+	`, guildID, channelID)
+	return wrapNotFoundError(err)
+}
+
+func (m *PostgresMiddleware) GetAutoThread(guildID, channelID string) (cfg models.AutoThreadConfig, err error) {
+	err = m.Db.QueryRow(`
+		SELECT guildID, channelID, attachmentsOnly, nameSource, cooldown
+		FROM autoThreads
+		WHERE guildID = ? AND channelID = ?
+	`, guildID, channelID).
+		Scan(&cfg.GuildID, &cfg.ChannelID, &cfg.AttachmentsOnly, &cfg.NameSource, &cfg.Cooldown)
+	return cfg, wrapNotFoundError(err)
+}
+
+func (m *PostgresMiddleware) GetAutoThreads(guildID string) ([]models.AutoThreadConfig, error) {
+	rows, err := m.Db.Query(`
+		SELECT guildID, channelID, attachmentsOnly, nameSource, cooldown
+		FROM autoThreads
+		WHERE guildID = ?
+	`, guildID)
+	if err != nil {
+		return nil, wrapNotFoundError(err)
+	}
+	defer rows.Close()
+
+	res := make([]models.AutoThreadConfig, 0)
+	for rows.Next() {
+		var cfg models.AutoThreadConfig
+		err = rows.Scan(&cfg.GuildID, &cfg.ChannelID, &cfg.AttachmentsOnly, &cfg.NameSource, &cfg.Cooldown)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, cfg)
+	}
+
+	return res, nil
+}
+
+func (m *PostgresMiddleware) SetAutoThread(cfg models.AutoThreadConfig) error {
+	_, err := m.Db.Exec(`
+		INSERT INTO autoThreads (guildID, channelID, attachmentsOnly, nameSource, cooldown)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (guildID, channelID) DO UPDATE SET attachmentsOnly = ?, nameSource = ?, cooldown = ?
+	`, cfg.GuildID, cfg.ChannelID, cfg.AttachmentsOnly, cfg.NameSource, cfg.Cooldown,
+		cfg.AttachmentsOnly, cfg.NameSource, cfg.Cooldown)
+	return err
+}
+
+func (m *PostgresMiddleware) RemoveAutoThread(guildID, channelID string) error {
+	_, err := m.Db.Exec(`
+		DELETE FROM autoThreads
+		WHERE guildID = ? AND channelID = ?
+	`, guildID, channelID)
+	return wrapNotFoundError(err)
+}
+
+func (m *PostgresMiddleware) GetStickyMessage(guildID, channelID string) (sm models.StickyMessage, err error) {
+	err = m.Db.QueryRow(`
+		SELECT guildID, channelID, content, messageID
+		FROM stickyMessages
+		WHERE guildID = ? AND channelID = ?
+	`, guildID, channelID).
+		Scan(&sm.GuildID, &sm.ChannelID, &sm.Content, &sm.MessageID)
+	return sm, wrapNotFoundError(err)
+}
+
+func (m *PostgresMiddleware) GetStickyMessages(guildID string) ([]models.StickyMessage, error) {
+	rows, err := m.Db.Query(`
+		SELECT guildID, channelID, content, messageID
+		FROM stickyMessages
+		WHERE guildID = ?
+	`, guildID)
+	if err != nil {
+		return nil, wrapNotFoundError(err)
+	}
+	defer rows.Close()
+
+	res := make([]models.StickyMessage, 0)
+	for rows.Next() {
+		var sm models.StickyMessage
+		err = rows.Scan(&sm.GuildID, &sm.ChannelID, &sm.Content, &sm.MessageID)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, sm)
+	}
+
+	return res, nil
+}
+
+func (m *PostgresMiddleware) SetStickyMessage(sm models.StickyMessage) error {
+	_, err := m.Db.Exec(`
+		INSERT INTO stickyMessages (guildID, channelID, content, messageID)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT (guildID, channelID) DO UPDATE SET content = ?, messageID = ?
+	`, sm.GuildID, sm.ChannelID, sm.Content, sm.MessageID,
+		sm.Content, sm.MessageID)
+	return err
+}
+
+func (m *PostgresMiddleware) RemoveStickyMessage(guildID, channelID string) error {
+	_, err := m.Db.Exec(`
+		DELETE FROM stickyMessages
+		WHERE guildID = ? AND channelID = ?
+	`, guildID, channelID)
+	return wrapNotFoundError(err)
+}
+
+func splitWordFilterList(val string) []string {
+	res := make([]string, 0)
+	for _, v := range strings.Split(val, ";") {
+		if v != "" {
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
+func (m *PostgresMiddleware) GetWordFilterSettings(guildID string) (s models.WordFilterSettings, err error) {
+	var exemptRoles, exemptChannels string
+	err = m.Db.QueryRow(`
+		SELECT guildID, enabled, action, muteDuration, exemptRoles, exemptChannels
+		FROM wordFilterSettings
+		WHERE guildID = ?
+	`, guildID).
+		Scan(&s.GuildID, &s.Enabled, &s.Action, &s.MuteDuration, &exemptRoles, &exemptChannels)
+	s.ExemptRoles = splitWordFilterList(exemptRoles)
+	s.ExemptChannels = splitWordFilterList(exemptChannels)
+	return s, wrapNotFoundError(err)
+}
+
+func (m *PostgresMiddleware) SetWordFilterSettings(s models.WordFilterSettings) error {
+	exemptRoles := strings.Join(s.ExemptRoles, ";")
+	exemptChannels := strings.Join(s.ExemptChannels, ";")
+	_, err := m.Db.Exec(`
+		INSERT INTO wordFilterSettings (guildID, enabled, action, muteDuration, exemptRoles, exemptChannels)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT (guildID) DO UPDATE SET enabled = ?, action = ?, muteDuration = ?, exemptRoles = ?, exemptChannels = ?
+	`, s.GuildID, s.Enabled, s.Action, s.MuteDuration, exemptRoles, exemptChannels,
+		s.Enabled, s.Action, s.MuteDuration, exemptRoles, exemptChannels)
+	return err
+}
+
+func (m *PostgresMiddleware) GetWordFilterEntries(guildID string) ([]models.WordFilterEntry, error) {
+	rows, err := m.Db.Query(`
+		SELECT guildID, pattern, mode
+		FROM wordFilterEntries
+		WHERE guildID = ?
+		ORDER BY pattern ASC
+	`, guildID)
+	if err != nil {
+		return nil, wrapNotFoundError(err)
+	}
+	defer rows.Close()
+
+	res := make([]models.WordFilterEntry, 0)
+	for rows.Next() {
+		var e models.WordFilterEntry
+		if err = rows.Scan(&e.GuildID, &e.Pattern, &e.Mode); err != nil {
+			return nil, err
+		}
+		res = append(res, e)
+	}
+
+	return res, nil
+}
+
+func (m *PostgresMiddleware) SetWordFilterEntry(e models.WordFilterEntry) error {
+	_, err := m.Db.Exec(`
+		INSERT INTO wordFilterEntries (guildID, pattern, mode)
+		VALUES (?, ?, ?)
+		ON CONFLICT (guildID, pattern) DO UPDATE SET mode = ?
+	`, e.GuildID, e.Pattern, e.Mode, e.Mode)
+	return err
+}
+
+func (m *PostgresMiddleware) RemoveWordFilterEntry(guildID, pattern string) error {
+	_, err := m.Db.Exec(`
+		DELETE FROM wordFilterEntries
+		WHERE guildID = ? AND pattern = ?
+	`, guildID, pattern)
+	return wrapNotFoundError(err)
+}
+
+func (m *PostgresMiddleware) AddSettingsAuditEntry(e models.SettingsAuditEntry) error {
+	_, err := m.Db.Exec(`
+		INSERT INTO settingsAudit (id, guildID, actorID, field, oldValue, newValue, timestamp)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`, e.ID, e.GuildID, e.ActorID, e.Field, e.OldValue, e.NewValue, e.Timestamp)
+	return err
+}
+
+func (m *PostgresMiddleware) GetSettingsAuditEntries(guildID string, offset, limit int) ([]models.SettingsAuditEntry, error) {
+	rows, err := m.Db.Query(`
+		SELECT id, actorID, field, oldValue, newValue, timestamp
+		FROM settingsAudit
+		WHERE guildID = ?
+		ORDER BY timestamp DESC
+		LIMIT ? OFFSET ?
+	`, guildID, limit, offset)
+	if err != nil {
+		return nil, wrapNotFoundError(err)
+	}
+
+	res := make([]models.SettingsAuditEntry, 0)
+	for rows.Next() {
+		e := models.SettingsAuditEntry{GuildID: guildID}
+		err = rows.Scan(&e.ID, &e.ActorID, &e.Field, &e.OldValue, &e.NewValue, &e.Timestamp)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, e)
+	}
+
+	return res, nil
+}
+
+func (m *PostgresMiddleware) GetSettingsAuditEntriesCount(guildID string) (n int, err error) {
+	err = m.Db.QueryRow(
+		"SELECT COUNT(id) FROM settingsAudit WHERE guildID = ?",
+		guildID).Scan(&n)
+	return n, wrapNotFoundError(err)
+}
+
+func (m *PostgresMiddleware) GetModmailThread(threadID string) (t models.ModmailThread, err error) {
+	err = m.Db.QueryRow(
+		"SELECT threadID, guildID, userID FROM modmailThreads WHERE threadID = ?",
+		threadID).Scan(&t.ThreadID, &t.GuildID, &t.UserID)
+	err = wrapNotFoundError(err)
+	return
+}
+
+func (m *PostgresMiddleware) GetModmailThreadsByUser(userID string) ([]models.ModmailThread, error) {
+	rows, err := m.Db.Query(
+		"SELECT threadID, guildID, userID FROM modmailThreads WHERE userID = ?",
+		userID)
+	if err != nil {
+		return nil, wrapNotFoundError(err)
+	}
+
+	res := make([]models.ModmailThread, 0)
+	for rows.Next() {
+		var t models.ModmailThread
+		if err = rows.Scan(&t.ThreadID, &t.GuildID, &t.UserID); err != nil {
+			return nil, err
+		}
+		res = append(res, t)
+	}
+
+	return res, nil
+}
+
+func (m *PostgresMiddleware) SetModmailThread(t models.ModmailThread) error {
+	_, err := m.Db.Exec(
+		"INSERT INTO modmailThreads (threadID, guildID, userID) VALUES (?, ?, ?)",
+		t.ThreadID, t.GuildID, t.UserID)
+	return err
+}
+
+func (m *PostgresMiddleware) RemoveModmailThread(threadID string) error {
+	_, err := m.Db.Exec("DELETE FROM modmailThreads WHERE threadID = ?", threadID)
+	return err
+}
+
+func (m *PostgresMiddleware) GetGuildRules(guildID string) (r models.GuildRules, err error) {
+	err = m.Db.QueryRow(
+		"SELECT guildID, channelID, messageID, roleID, content, version "+
+			"FROM guildRules WHERE guildID = ?",
+		guildID).Scan(&r.GuildID, &r.ChannelID, &r.MessageID, &r.RoleID, &r.Content, &r.Version)
+	err = wrapNotFoundError(err)
+	return
+}
+
+func (m *PostgresMiddleware) GetAllGuildRules() ([]models.GuildRules, error) {
+	rows, err := m.Db.Query(
+		"SELECT guildID, channelID, messageID, roleID, content, version FROM guildRules")
+	if err != nil {
+		return nil, wrapNotFoundError(err)
+	}
+
+	res := make([]models.GuildRules, 0)
+	for rows.Next() {
+		var r models.GuildRules
+		err = rows.Scan(&r.GuildID, &r.ChannelID, &r.MessageID, &r.RoleID, &r.Content, &r.Version)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, r)
+	}
+
+	return res, nil
+}
+
+func (m *PostgresMiddleware) SetGuildRules(r models.GuildRules) error {
+	_, err := m.Db.Exec(
+		"INSERT INTO guildRules (guildID, channelID, messageID, roleID, content, version) "+
+			"VALUES (?, ?, ?, ?, ?, ?) "+
+			"ON CONFLICT (guildID) DO UPDATE SET channelID = ?, messageID = ?, roleID = ?, content = ?, version = ?",
+		r.GuildID, r.ChannelID, r.MessageID, r.RoleID, r.Content, r.Version,
+		r.ChannelID, r.MessageID, r.RoleID, r.Content, r.Version)
+	return err
+}
+
+func (m *PostgresMiddleware) GetRulesAcceptance(guildID, userID string) (version int, err error) {
+	err = m.Db.QueryRow(
+		"SELECT version FROM rulesAcceptances WHERE guildID = ? AND userID = ?",
+		guildID, userID).Scan(&version)
+	err = wrapNotFoundError(err)
+	return
+}
+
+func (m *PostgresMiddleware) SetRulesAcceptance(guildID, userID string, version int) error {
+	_, err := m.Db.Exec(
+		"INSERT INTO rulesAcceptances (guildID, userID, version) VALUES (?, ?, ?) "+
+			"ON CONFLICT (guildID, userID) DO UPDATE SET version = ?, timestamp = CURRENT_TIMESTAMP",
+		guildID, userID, version, version)
+	return err
+}
+
+/////////// HELPER ///////////////
+
+func wrapNotFoundError(err error) error {
+	if err == sql.ErrNoRows {
+		err = database.ErrDatabaseNotFound
+	}
+	return err
+}
+
+const giveawayColumns = "id, guildID, channelID, messageID, creatorID, prize, " +
+	"winners, roleID, minKarma, expires, ended, winnerIDs"
+
+func scanGiveaway(row interface{ Scan(...interface{}) error }) (g models.Giveaway, err error) {
+	var winnerIDs string
+	err = row.Scan(&g.ID, &g.GuildID, &g.ChannelID, &g.MessageID, &g.CreatorID, &g.Prize,
+		&g.Winners, &g.RoleID, &g.MinKarma, &g.Expires, &g.Ended, &winnerIDs)
+	g.WinnerIDs = []string{}
+	if winnerIDs != "" {
+		g.WinnerIDs = strings.Split(winnerIDs, ",")
+	}
+	return
+}
+
+func (m *PostgresMiddleware) GetGiveaway(id snowflake.ID) (g models.Giveaway, err error) {
+	g, err = scanGiveaway(m.Db.QueryRow(
+		"SELECT "+giveawayColumns+" FROM giveaways WHERE id = ?", id))
+	err = wrapNotFoundError(err)
+	return
+}
+
+func (m *PostgresMiddleware) GetGiveawayByMessage(messageID string) (g models.Giveaway, err error) {
+	g, err = scanGiveaway(m.Db.QueryRow(
+		"SELECT "+giveawayColumns+" FROM giveaways WHERE messageID = ?", messageID))
+	err = wrapNotFoundError(err)
+	return
+}
+
+func (m *PostgresMiddleware) GetGiveaways(guildID string, activeOnly bool) ([]models.Giveaway, error) {
+	query := "SELECT " + giveawayColumns + " FROM giveaways WHERE true"
+	args := make([]interface{}, 0, 1)
+	if guildID != "" {
+		query += " AND guildID = ?"
+		args = append(args, guildID)
+	}
+	if activeOnly {
+		query += " AND ended = 0"
+	}
+	query += " ORDER BY expires DESC"
+
+	rows, err := m.Db.Query(query, args...)
+	if err != nil {
+		return nil, wrapNotFoundError(err)
+	}
+	defer rows.Close()
+
+	res := make([]models.Giveaway, 0)
+	for rows.Next() {
+		g, err := scanGiveaway(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, g)
+	}
+
+	return res, nil
+}
+
+func (m *PostgresMiddleware) SetGiveaway(g models.Giveaway) error {
+	winnerIDs := strings.Join(g.WinnerIDs, ",")
+	_, err := m.Db.Exec(
+		"INSERT INTO giveaways ("+giveawayColumns+") "+
+			"VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) "+
+			"ON CONFLICT (id) DO UPDATE SET channelID = ?, messageID = ?, prize = ?, winners = ?, "+
+			"roleID = ?, minKarma = ?, expires = ?, ended = ?, winnerIDs = ?",
+		g.ID, g.GuildID, g.ChannelID, g.MessageID, g.CreatorID, g.Prize,
+		g.Winners, g.RoleID, g.MinKarma, g.Expires, g.Ended, winnerIDs,
+		g.ChannelID, g.MessageID, g.Prize, g.Winners,
+		g.RoleID, g.MinKarma, g.Expires, g.Ended, winnerIDs)
+	return err
+}
+
+func (m *PostgresMiddleware) EndGiveaway(id snowflake.ID, winnerIDs []string) (bool, error) {
+	res, err := m.Db.Exec(
+		"UPDATE giveaways SET ended = 1, winnerIDs = ? WHERE id = ? AND ended = 0",
+		strings.Join(winnerIDs, ","), id)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+func (m *PostgresMiddleware) AddGiveawayEntry(id snowflake.ID, guildID, userID string) (bool, error) {
+	res, err := m.Db.Exec(
+		"INSERT INTO giveawayEntries (giveawayID, guildID, userID) VALUES (?, ?, ?) ON CONFLICT DO NOTHING",
+		id, guildID, userID)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+func (m *PostgresMiddleware) GetGiveawayEntries(id snowflake.ID) ([]string, error) {
+	rows, err := m.Db.Query(
+		"SELECT userID FROM giveawayEntries WHERE giveawayID = ?", id)
+	if err != nil {
+		return nil, wrapNotFoundError(err)
+	}
+	defer rows.Close()
+
+	res := make([]string, 0)
+	var userID string
+	for rows.Next() {
+		if err = rows.Scan(&userID); err != nil {
+			return nil, err
+		}
+		res = append(res, userID)
+	}
+
+	return res, nil
+}
+
+const tempRoleColumns = "id, guildID, userID, roleID, executorID, expires, attempts"
+
+func scanTempRole(row interface{ Scan(...interface{}) error }) (t models.TempRole, err error) {
+	err = row.Scan(&t.ID, &t.GuildID, &t.UserID, &t.RoleID, &t.ExecutorID, &t.Expires, &t.Attempts)
+	return
+}
+
+func (m *PostgresMiddleware) GetTempRole(id snowflake.ID) (t models.TempRole, err error) {
+	t, err = scanTempRole(m.Db.QueryRow(
+		"SELECT "+tempRoleColumns+" FROM tempRoles WHERE id = ?", id))
+	err = wrapNotFoundError(err)
+	return
+}
+
+func (m *PostgresMiddleware) GetTempRoles(guildID, userID string) ([]models.TempRole, error) {
+	query := "SELECT " + tempRoleColumns + " FROM tempRoles WHERE true"
+	args := make([]interface{}, 0, 2)
+	if guildID != "" {
+		query += " AND guildID = ?"
+		args = append(args, guildID)
+	}
+	if userID != "" {
+		query += " AND userID = ?"
+		args = append(args, userID)
+	}
+	query += " ORDER BY expires ASC"
+
+	rows, err := m.Db.Query(query, args...)
+	if err != nil {
+		return nil, wrapNotFoundError(err)
+	}
+	defer rows.Close()
+
+	res := make([]models.TempRole, 0)
+	for rows.Next() {
+		t, err := scanTempRole(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, t)
+	}
+
+	return res, nil
+}
+
+func (m *PostgresMiddleware) SetTempRole(t models.TempRole) error {
+	_, err := m.Db.Exec(
+		"INSERT INTO tempRoles ("+tempRoleColumns+") VALUES (?, ?, ?, ?, ?, ?, ?) "+
+			"ON CONFLICT (id) DO UPDATE SET expires = ?, attempts = ?",
+		t.ID, t.GuildID, t.UserID, t.RoleID, t.ExecutorID, t.Expires, t.Attempts,
+		t.Expires, t.Attempts)
+	return err
+}
+
+func (m *PostgresMiddleware) RemoveTempRole(id snowflake.ID) error {
+	_, err := m.Db.Exec("DELETE FROM tempRoles WHERE id = ?", id)
+	return err
+}
+
+const userNoteColumns = "id, guildID, userID, authorID, content, created"
+
+func scanUserNote(row interface{ Scan(...interface{}) error }) (n models.UserNote, err error) {
+	err = row.Scan(&n.ID, &n.GuildID, &n.UserID, &n.AuthorID, &n.Content, &n.Created)
+	return
+}
+
+func (m *PostgresMiddleware) AddUserNote(n models.UserNote) error {
+	_, err := m.Db.Exec(
+		"INSERT INTO userNotes ("+userNoteColumns+") VALUES (?, ?, ?, ?, ?, ?)",
+		n.ID, n.GuildID, n.UserID, n.AuthorID, n.Content, n.Created)
+	return err
+}
+
+func (m *PostgresMiddleware) GetUserNotes(guildID, userID string) ([]models.UserNote, error) {
+	rows, err := m.Db.Query(
+		"SELECT "+userNoteColumns+" FROM userNotes WHERE guildID = ? AND userID = ? ORDER BY created DESC",
+		guildID, userID)
+	if err != nil {
+		return nil, wrapNotFoundError(err)
+	}
+	defer rows.Close()
+
+	res := make([]models.UserNote, 0)
+	for rows.Next() {
+		n, err := scanUserNote(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, n)
+	}
+
+	return res, nil
+}
+
+func (m *PostgresMiddleware) DeleteUserNote(guildID string, id snowflake.ID) error {
+	res, err := m.Db.Exec("DELETE FROM userNotes WHERE guildID = ? AND id = ?", guildID, id)
+	if err != nil {
+		return err
+	}
+	ar, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if ar == 0 {
+		return database.ErrDatabaseNotFound
+	}
+	return nil
+}
+
+const broadcastColumns = "id, authorID, content, created, finished"
+
+func scanBroadcast(row interface{ Scan(...interface{}) error }) (b models.Broadcast, err error) {
+	err = row.Scan(&b.ID, &b.AuthorID, &b.Content, &b.Created, &b.Finished)
+	return
+}
+
+func (m *PostgresMiddleware) AddBroadcast(b models.Broadcast) error {
+	_, err := m.Db.Exec(
+		"INSERT INTO broadcasts ("+broadcastColumns+") VALUES (?, ?, ?, ?, ?)",
+		b.ID, b.AuthorID, b.Content, b.Created, b.Finished)
+	return err
+}
+
+func (m *PostgresMiddleware) SetBroadcastFinished(id snowflake.ID) error {
+	_, err := m.Db.Exec("UPDATE broadcasts SET finished = 1 WHERE id = ?", id)
+	return err
+}
+
+func (m *PostgresMiddleware) GetBroadcast(id snowflake.ID) (b models.Broadcast, err error) {
+	b, err = scanBroadcast(m.Db.QueryRow(
+		"SELECT "+broadcastColumns+" FROM broadcasts WHERE id = ?", id))
+	err = wrapNotFoundError(err)
+	return
+}
+
+func (m *PostgresMiddleware) GetBroadcasts(unfinishedOnly bool) ([]models.Broadcast, error) {
+	query := "SELECT " + broadcastColumns + " FROM broadcasts"
+	if unfinishedOnly {
+		query += " WHERE finished = 0"
+	}
+	query += " ORDER BY created DESC"
+
+	rows, err := m.Db.Query(query)
+	if err != nil {
+		return nil, wrapNotFoundError(err)
+	}
+	defer rows.Close()
+
+	res := make([]models.Broadcast, 0)
+	for rows.Next() {
+		b, err := scanBroadcast(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, b)
+	}
+
+	return res, nil
+}
+
+func (m *PostgresMiddleware) AddBroadcastDelivery(d models.BroadcastDelivery) error {
+	_, err := m.Db.Exec(
+		"INSERT INTO broadcastDeliveries (broadcastID, guildID, status, error) VALUES (?, ?, ?, ?) "+
+			"ON CONFLICT (broadcastID, guildID) DO UPDATE SET status = ?, error = ?",
+		d.BroadcastID, d.GuildID, d.Status, d.Error, d.Status, d.Error)
+	return err
+}
+
+func (m *PostgresMiddleware) GetBroadcastDeliveries(id snowflake.ID) ([]models.BroadcastDelivery, error) {
+	rows, err := m.Db.Query(
+		"SELECT guildID, status, error FROM broadcastDeliveries WHERE broadcastID = ?", id)
+	if err != nil {
+		return nil, wrapNotFoundError(err)
+	}
+	defer rows.Close()
+
+	res := make([]models.BroadcastDelivery, 0)
+	for rows.Next() {
+		d := models.BroadcastDelivery{BroadcastID: id}
+		if err = rows.Scan(&d.GuildID, &d.Status, &d.Error); err != nil {
+			return nil, err
+		}
+		res = append(res, d)
+	}
+
+	return res, nil
+}
+
+func (m *PostgresMiddleware) AddTrackedInvite(inv models.TrackedInvite) error {
+	_, err := m.Db.Exec(
+		"INSERT INTO trackedInvites (code, guildID, channelID, creatorID, label, created) "+
+			"VALUES (?, ?, ?, ?, ?, ?)",
+		inv.Code, inv.GuildID, inv.ChannelID, inv.CreatorID, inv.Label, inv.Created)
+	return err
+}
+
+func (m *PostgresMiddleware) GetTrackedInvites(guildID string) ([]models.TrackedInvite, error) {
+	rows, err := m.Db.Query(
+		"SELECT code, guildID, channelID, creatorID, label, created FROM trackedInvites "+
+			"WHERE guildID = ? ORDER BY created DESC", guildID)
+	if err != nil {
+		return nil, wrapNotFoundError(err)
+	}
+	defer rows.Close()
+
+	res := make([]models.TrackedInvite, 0)
+	for rows.Next() {
+		var inv models.TrackedInvite
+		err = rows.Scan(&inv.Code, &inv.GuildID, &inv.ChannelID, &inv.CreatorID, &inv.Label, &inv.Created)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, inv)
+	}
+
+	return res, nil
+}
+
+func (m *PostgresMiddleware) AddInviteJoin(j models.InviteJoin) error {
+	_, err := m.Db.Exec(
+		"INSERT INTO inviteJoins (guildID, userID, code, source, inviterID, timestamp) VALUES (?, ?, ?, ?, ?, ?)",
+		j.GuildID, j.UserID, j.Code, j.Source, j.InviterID, j.Timestamp)
+	return err
+}
+
+func (m *PostgresMiddleware) GetInviteJoinStats(guildID string) ([]models.InviteJoinStats, error) {
+	rows, err := m.Db.Query(
+		"SELECT j.code, j.source, COALESCE(MAX(t.label), ''), COALESCE(MAX(t.creatorID), ''), "+
+			"MAX(j.inviterID), COUNT(*) AS joins "+
+			"FROM inviteJoins AS j LEFT JOIN trackedInvites AS t ON t.code = j.code AND t.guildID = j.guildID "+
+			"WHERE j.guildID = ? GROUP BY j.code, j.source ORDER BY joins DESC", guildID)
+	if err != nil {
+		return nil, wrapNotFoundError(err)
+	}
+	defer rows.Close()
+
+	res := make([]models.InviteJoinStats, 0)
+	for rows.Next() {
+		var s models.InviteJoinStats
+		if err = rows.Scan(&s.Code, &s.Source, &s.Label, &s.CreatorID, &s.InviterID, &s.Joins); err != nil {
+			return nil, err
+		}
+		res = append(res, s)
+	}
+
+	return res, nil
+}
+
+func (m *PostgresMiddleware) GetAutoPublish(guildID, channelID string) (cfg models.AutoPublishConfig, err error) {
+	err = m.Db.QueryRow(`
+		SELECT guildID, channelID, skipBots
+		FROM autoPublish
+		WHERE guildID = ? AND channelID = ?
+	`, guildID, channelID).
+		Scan(&cfg.GuildID, &cfg.ChannelID, &cfg.SkipBots)
+	return cfg, wrapNotFoundError(err)
+}
+
+func (m *PostgresMiddleware) GetAutoPublishes(guildID string) ([]models.AutoPublishConfig, error) {
+	rows, err := m.Db.Query(`
+		SELECT guildID, channelID, skipBots
+		FROM autoPublish
+		WHERE guildID = ?
+	`, guildID)
+	if err != nil {
+		return nil, wrapNotFoundError(err)
+	}
+	defer rows.Close()
+
+	res := make([]models.AutoPublishConfig, 0)
+	for rows.Next() {
+		var cfg models.AutoPublishConfig
+		if err = rows.Scan(&cfg.GuildID, &cfg.ChannelID, &cfg.SkipBots); err != nil {
+			return nil, err
+		}
+		res = append(res, cfg)
+	}
+
+	return res, nil
+}
+
+func (m *PostgresMiddleware) SetAutoPublish(cfg models.AutoPublishConfig) error {
+	_, err := m.Db.Exec(`
+		INSERT INTO autoPublish (guildID, channelID, skipBots)
+		VALUES (?, ?, ?)
+		ON CONFLICT (guildID, channelID) DO UPDATE SET skipBots = ?
+	`, cfg.GuildID, cfg.ChannelID, cfg.SkipBots, cfg.SkipBots)
+	return err
+}
+
+func (m *PostgresMiddleware) RemoveAutoPublish(guildID, channelID string) error {
+	_, err := m.Db.Exec(`
+		DELETE FROM autoPublish
+		WHERE guildID = ? AND channelID = ?
+	`, guildID, channelID)
+	return wrapNotFoundError(err)
+}
+
+func (m *PostgresMiddleware) GetPrefixlessChannels(guildID string) ([]string, error) {
+	rows, err := m.Db.Query(`
+		SELECT channelID
+		FROM prefixlessChannels
+		WHERE guildID = ?
+	`, guildID)
+	if err != nil {
+		return nil, wrapNotFoundError(err)
+	}
+	defer rows.Close()
+
+	res := make([]string, 0)
+	for rows.Next() {
+		var channelID string
+		if err = rows.Scan(&channelID); err != nil {
+			return nil, err
+		}
+		res = append(res, channelID)
+	}
+
+	return res, nil
+}
+
+func (m *PostgresMiddleware) AddPrefixlessChannel(guildID, channelID string) error {
+	_, err := m.Db.Exec(`
+		INSERT INTO prefixlessChannels (guildID, channelID)
+		VALUES (?, ?)
+		ON CONFLICT (guildID, channelID) DO NOTHING
+	`, guildID, channelID)
+	return err
+}
+
+func (m *PostgresMiddleware) RemovePrefixlessChannel(guildID, channelID string) error {
+	_, err := m.Db.Exec(`
+		DELETE FROM prefixlessChannels
+		WHERE guildID = ? AND channelID = ?
+	`, guildID, channelID)
+	return wrapNotFoundError(err)
+}
+
+func (m *PostgresMiddleware) AddMessageStats(entries []models.MessageStatsEntry) (err error) {
+	tx, err := m.Db.Begin()
+	if err != nil {
+		return
+	}
+
+	for _, e := range entries {
+		_, err = tx.Exec(`
+			INSERT INTO messageStats (guildID, channelID, userID, hour, count)
+			VALUES (?, ?, ?, ?, ?)
+			ON CONFLICT (guildID, channelID, userID, hour) DO UPDATE SET count = messageStats.count + ?
+		`, e.GuildID, e.ChannelID, e.UserID, e.Hour, e.Count, e.Count)
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (m *PostgresMiddleware) GetMessageStats(guildID string, since time.Time) ([]models.MessageStatsEntry, error) {
+	rows, err := m.Db.Query(`
+		SELECT guildID, channelID, userID, hour, count
+		FROM messageStats
+		WHERE guildID = ? AND hour >= ?
+	`, guildID, since)
+	if err != nil {
+		return nil, wrapNotFoundError(err)
+	}
+	defer rows.Close()
+
+	res := make([]models.MessageStatsEntry, 0)
+	for rows.Next() {
+		var e models.MessageStatsEntry
+		if err = rows.Scan(&e.GuildID, &e.ChannelID, &e.UserID, &e.Hour, &e.Count); err != nil {
+			return nil, err
+		}
+		res = append(res, e)
+	}
+
+	return res, nil
+}
+
+func (m *PostgresMiddleware) DeleteMessageStats(guildID string) error {
+	_, err := m.Db.Exec(`
+		DELETE FROM messageStats
+		WHERE guildID = ?
+	`, guildID)
+	return wrapNotFoundError(err)
+}
+
+func (m *PostgresMiddleware) CleanupMessageStats(before time.Time) (n int64, err error) {
+	res, err := m.Db.Exec(`
+		DELETE FROM messageStats
+		WHERE hour < ?
+	`, before)
+	if err != nil {
+		return
+	}
+
+	n, err = res.RowsAffected()
+	return
+}
+
+func (m *PostgresMiddleware) GetReactionReportConfig(guildID string) (cfg models.ReactionReportConfig, err error) {
+	err = m.Db.QueryRow(`
+		SELECT guildID, channelID, emoji, cooldown
+		FROM reactionReportConfig
+		WHERE guildID = ?
+	`, guildID).
+		Scan(&cfg.GuildID, &cfg.ChannelID, &cfg.Emoji, &cfg.Cooldown)
+	return cfg, wrapNotFoundError(err)
+}
+
+func (m *PostgresMiddleware) SetReactionReportConfig(cfg models.ReactionReportConfig) error {
+	_, err := m.Db.Exec(`
+		INSERT INTO reactionReportConfig (guildID, channelID, emoji, cooldown)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT (guildID) DO UPDATE SET channelID = ?, emoji = ?, cooldown = ?
+	`, cfg.GuildID, cfg.ChannelID, cfg.Emoji, cfg.Cooldown,
+		cfg.ChannelID, cfg.Emoji, cfg.Cooldown)
+	return err
+}
+
+func (m *PostgresMiddleware) GetBackupSchedule(guildID string) (s backupmodels.Schedule, err error) {
+	err = m.Db.QueryRow(`
+		SELECT guildID, spec, retention, notifyChannelID, notifyOwner, lastRun
+		FROM backupSchedules
+		WHERE guildID = ?
+	`, guildID).
+		Scan(&s.GuildID, &s.Spec, &s.Retention, &s.NotifyChannelID, &s.NotifyOwner, &s.LastRun)
+	return s, wrapNotFoundError(err)
+}
+
+func (m *PostgresMiddleware) GetBackupSchedules() ([]backupmodels.Schedule, error) {
+	rows, err := m.Db.Query(`
+		SELECT guildID, spec, retention, notifyChannelID, notifyOwner, lastRun
+		FROM backupSchedules
+	`)
+	if err != nil {
+		return nil, wrapNotFoundError(err)
+	}
+	defer rows.Close()
+
+	res := make([]backupmodels.Schedule, 0)
+	for rows.Next() {
+		var s backupmodels.Schedule
+		err = rows.Scan(&s.GuildID, &s.Spec, &s.Retention, &s.NotifyChannelID, &s.NotifyOwner, &s.LastRun)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, s)
+	}
+
+	return res, nil
+}
+
+func (m *PostgresMiddleware) SetBackupSchedule(s backupmodels.Schedule) error {
+	_, err := m.Db.Exec(`
+		INSERT INTO backupSchedules (guildID, spec, retention, notifyChannelID, notifyOwner, lastRun)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT (guildID) DO UPDATE SET spec = ?, retention = ?, notifyChannelID = ?, notifyOwner = ?, lastRun = ?
+	`, s.GuildID, s.Spec, s.Retention, s.NotifyChannelID, s.NotifyOwner, s.LastRun,
+		s.Spec, s.Retention, s.NotifyChannelID, s.NotifyOwner, s.LastRun)
+	return err
+}
+
+func (m *PostgresMiddleware) DeleteBackupSchedule(guildID string) error {
+	_, err := m.Db.Exec(`
+		DELETE FROM backupSchedules
+		WHERE guildID = ?
+	`, guildID)
+	return wrapNotFoundError(err)
+}
+
+func (m *PostgresMiddleware) GetInactivitySettings(guildID string) (s models.InactivitySettings, err error) {
+	err = m.Db.QueryRow(`
+		SELECT guildID, enabled, roleID, days, warnDays, since
+		FROM inactivitySettings
+		WHERE guildID = ?
+	`, guildID).
+		Scan(&s.GuildID, &s.Enabled, &s.RoleID, &s.Days, &s.WarnDays, &s.Since)
+	return s, wrapNotFoundError(err)
+}
+
+func (m *PostgresMiddleware) GetEnabledInactivitySettings() ([]models.InactivitySettings, error) {
+	rows, err := m.Db.Query(`
+		SELECT guildID, enabled, roleID, days, warnDays, since
+		FROM inactivitySettings
+		WHERE enabled = 1
+	`)
+	if err != nil {
+		return nil, wrapNotFoundError(err)
+	}
+	defer rows.Close()
+
+	res := make([]models.InactivitySettings, 0)
+	for rows.Next() {
+		var s models.InactivitySettings
+		err = rows.Scan(&s.GuildID, &s.Enabled, &s.RoleID, &s.Days, &s.WarnDays, &s.Since)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, s)
+	}
+
+	return res, nil
+}
+
+func (m *PostgresMiddleware) SetInactivitySettings(s models.InactivitySettings) error {
+	_, err := m.Db.Exec(`
+		INSERT INTO inactivitySettings (guildID, enabled, roleID, days, warnDays, since)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT (guildID) DO UPDATE SET enabled = ?, roleID = ?, days = ?, warnDays = ?, since = ?
+	`, s.GuildID, s.Enabled, s.RoleID, s.Days, s.WarnDays, s.Since,
+		s.Enabled, s.RoleID, s.Days, s.WarnDays, s.Since)
+	return err
+}
+
+func (m *PostgresMiddleware) GetMemberActivities(guildID string) ([]models.MemberActivity, error) {
+	rows, err := m.Db.Query(`
+		SELECT guildID, userID, lastActive, warned
+		FROM memberActivity
+		WHERE guildID = ?
+	`, guildID)
+	if err != nil {
+		return nil, wrapNotFoundError(err)
+	}
+	defer rows.Close()
+
+	res := make([]models.MemberActivity, 0)
+	for rows.Next() {
+		var a models.MemberActivity
+		if err = rows.Scan(&a.GuildID, &a.UserID, &a.LastActive, &a.Warned); err != nil {
+			return nil, err
+		}
+		res = append(res, a)
+	}
+
+	return res, nil
+}
+
+func (m *PostgresMiddleware) SetMemberActivities(entries []models.MemberActivity) (err error) {
+	tx, err := m.Db.Begin()
+	if err != nil {
+		return
+	}
+
+	for _, a := range entries {
+		_, err = tx.Exec(`
+			INSERT INTO memberActivity (guildID, userID, lastActive, warned)
+			VALUES (?, ?, ?, ?)
+			ON CONFLICT (guildID, userID) DO UPDATE SET lastActive = ?, warned = ?
+		`, a.GuildID, a.UserID, a.LastActive, a.Warned, a.LastActive, a.Warned)
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (m *PostgresMiddleware) SetMemberActivityWarned(a models.MemberActivity) error {
+	_, err := m.Db.Exec(`
+		INSERT INTO memberActivity (guildID, userID, lastActive, warned)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT (guildID, userID) DO UPDATE SET warned = ?
+	`, a.GuildID, a.UserID, a.LastActive, a.Warned, a.Warned)
+	return err
+}
+
+func (m *PostgresMiddleware) DeleteMemberActivities(guildID string) error {
+	_, err := m.Db.Exec(`
+		DELETE FROM memberActivity
+		WHERE guildID = ?
+	`, guildID)
+	return wrapNotFoundError(err)
+}
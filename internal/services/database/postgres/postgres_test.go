@@ -0,0 +1,35 @@
+package postgres
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/services/database/dbtest"
+	"github.com/zekroTJA/shinpuru/internal/services/database/sqldb"
+)
+
+// connect connects to the PostgreSQL database specified by
+// the SP_TEST_POSTGRES_DSN environment variable. If it is
+// not set, the test is skipped.
+func connect(t *testing.T) *PostgresMiddleware {
+	dsn := os.Getenv("SP_TEST_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("SP_TEST_POSTGRES_DSN is not set")
+	}
+
+	db := New(sqldb.Options{})
+	require.Nil(t, db.Connect(models.DatabasePostgres{DSN: dsn}))
+	t.Cleanup(db.Close)
+
+	return db
+}
+
+func TestReportsFilteredCount(t *testing.T) {
+	dbtest.ReportsFilteredCount(t, connect(t))
+}
+
+func TestExpiredReports(t *testing.T) {
+	dbtest.ExpiredReports(t, connect(t))
+}
@@ -0,0 +1,175 @@
+package redis
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/zekroTJA/shinpuru/internal/services/kvcache"
+	"github.com/zekroTJA/shinpuru/pkg/redisguard"
+)
+
+// cacheKeyPrefixes are the prefixes of all keys set
+// by the RedisMiddleware.
+var cacheKeyPrefixes = []string{
+	keySetting + ":",
+	"GUILD:",
+	"KARMA:",
+	"ANTIRAID:",
+	"USER:",
+	"API:",
+}
+
+// Cache accesses the values cached by the
+// RedisMiddleware in Redis.
+//
+// Inspecting and flushing the cache is always scoped
+// to the keys set by the RedisMiddleware, so other
+// values stored in the same Redis database are never
+// affected.
+type Cache struct {
+	client *redis.Client
+	guard  *redisguard.Guard
+
+	hits   uint64
+	misses uint64
+}
+
+var _ kvcache.Inspector = (*Cache)(nil)
+
+func NewCache(client *redis.Client, guard *redisguard.Guard) *Cache {
+	return &Cache{
+		client: client,
+		guard:  guard,
+	}
+}
+
+func (c *Cache) Stats() kvcache.Stats {
+	return kvcache.Stats{
+		Size:   len(c.Keys("")),
+		Hits:   atomic.LoadUint64(&c.hits),
+		Misses: atomic.LoadUint64(&c.misses),
+	}
+}
+
+func (c *Cache) Keys(prefix string) []string {
+	keys := make([]string, 0)
+	if !c.guard.Available() {
+		return keys
+	}
+
+	ctx := context.Background()
+	for _, pattern := range scanPatterns(prefix) {
+		iter := c.client.Scan(ctx, 0, pattern, 100).Iterator()
+		for iter.Next(ctx) {
+			keys = append(keys, iter.Val())
+		}
+		if c.guard.Report(iter.Err()) {
+			return keys
+		}
+	}
+
+	return keys
+}
+
+func (c *Cache) FlushPrefix(prefix string) (n int) {
+	keys := c.Keys(prefix)
+
+	for len(keys) > 0 {
+		batch := keys
+		if len(batch) > 100 {
+			batch = batch[:100]
+		}
+		keys = keys[len(batch):]
+
+		res, err := c.client.Del(context.Background(), batch...).Result()
+		if err != nil {
+			c.guard.Report(err)
+			return
+		}
+		n += int(res)
+	}
+
+	return
+}
+
+// get returns the cached value of the given key. If
+// redis is not available, redis.Nil is returned so
+// that the value is requested from the database.
+func (c *Cache) get(key string) *redis.StringCmd {
+	if !c.guard.Available() {
+		return redis.NewStringResult("", redis.Nil)
+	}
+
+	res := c.client.Get(context.Background(), key)
+	if c.guard.Report(res.Err()) {
+		return redis.NewStringResult("", redis.Nil)
+	}
+
+	if res.Err() == redis.Nil {
+		atomic.AddUint64(&c.misses, 1)
+	} else {
+		atomic.AddUint64(&c.hits, 1)
+	}
+
+	return res
+}
+
+// set caches the given value. If redis is not
+// available, the value is not cached.
+func (c *Cache) set(key string, val interface{}) error {
+	if !c.guard.Available() {
+		return nil
+	}
+
+	err := c.client.Set(context.Background(), key, val, 0).Err()
+	if c.guard.Report(err) {
+		return nil
+	}
+
+	return err
+}
+
+// del removes the given key from the cache. If redis
+// is not available, the key is not removed.
+func (c *Cache) del(key string) error {
+	if !c.guard.Available() {
+		return nil
+	}
+
+	err := c.client.Del(context.Background(), key).Err()
+	if c.guard.Report(err) {
+		return nil
+	}
+
+	return err
+}
+
+// scanPatterns returns the scan patterns matching all
+// cached keys starting with the given prefix.
+func scanPatterns(prefix string) []string {
+	patterns := make([]string, 0, len(cacheKeyPrefixes))
+	for _, p := range cacheKeyPrefixes {
+		switch {
+		case strings.HasPrefix(prefix, p):
+			return []string{escapePattern(prefix) + "*"}
+		case strings.HasPrefix(p, prefix):
+			patterns = append(patterns, p+"*")
+		}
+	}
+	return patterns
+}
+
+// escapePattern escapes all glob-style characters
+// in the given string.
+func escapePattern(s string) string {
+	var sb strings.Builder
+	for _, r := range s {
+		if strings.ContainsRune(`*?[]\`, r) {
+			sb.WriteRune('\\')
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
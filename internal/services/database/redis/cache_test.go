@@ -0,0 +1,80 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/services/database/memory"
+	"github.com/zekroTJA/shinpuru/pkg/redisguard"
+)
+
+func TestScanPatterns(t *testing.T) {
+	assert.Equal(t,
+		[]string{"PROP:*", "GUILD:*", "KARMA:*", "ANTIRAID:*", "USER:*", "API:*"},
+		scanPatterns(""))
+	assert.Equal(t, []string{"GUILD:*"}, scanPatterns("GU"))
+	assert.Equal(t, []string{"GUILD:PREFIX:*"}, scanPatterns("GUILD:PREFIX:"))
+	assert.Equal(t, []string{`GUILD:\*\?*`}, scanPatterns("GUILD:*?"))
+	assert.Empty(t, scanPatterns("dgrs:"))
+	assert.Empty(t, scanPatterns("*"))
+}
+
+type testPinger struct {
+	err error
+}
+
+func (p *testPinger) Ping(ctx context.Context) *redis.StatusCmd {
+	return redis.NewStatusResult("PONG", p.err)
+}
+
+// unreachableMiddleware returns a RedisMiddleware with a
+// redis client which can not connect to any server.
+func unreachableMiddleware(t *testing.T, pingErr error) (*RedisMiddleware, *redisguard.Guard) {
+	client := redis.NewClient(&redis.Options{
+		Addr:        "127.0.0.1:1",
+		DialTimeout: 100 * time.Millisecond,
+		MaxRetries:  -1,
+	})
+	guard := redisguard.New(&testPinger{err: pingErr}, time.Hour)
+	client.AddHook(guard)
+	t.Cleanup(guard.Close)
+
+	return NewRedisMiddleware(memory.New(), NewCache(client, guard)), guard
+}
+
+func TestMiddlewareUnavailable(t *testing.T) {
+	r, guard := unreachableMiddleware(t, errors.New("connection refused"))
+	assert.False(t, guard.Available())
+
+	assert.Nil(t, r.SetGuildPrefix("guild", "!"))
+	prefix, err := r.GetGuildPrefix("guild")
+	assert.Nil(t, err)
+	assert.Equal(t, "!", prefix)
+
+	assert.Empty(t, r.cache.Keys(""))
+	assert.Equal(t, 0, r.cache.FlushPrefix(""))
+}
+
+func TestMiddlewareConnectionLost(t *testing.T) {
+	r, guard := unreachableMiddleware(t, nil)
+	assert.True(t, guard.Available())
+
+	_, err := r.GetGuildPrefix("guild")
+	assert.True(t, database.IsErrDatabaseNotFound(err))
+	assert.False(t, guard.Available())
+
+	prefix, err := r.GetGuildPrefix("guild")
+
+	assert.True(t, database.IsErrDatabaseNotFound(err))
+	assert.Equal(t, "", prefix)
+
+	assert.Nil(t, r.SetGuildPrefix("guild", "!"))
+	prefix, err = r.GetGuildPrefix("guild")
+	assert.Nil(t, err)
+	assert.Equal(t, "!", prefix)
+}
@@ -1,13 +1,15 @@
 package redis
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/zekroTJA/shinpuru/internal/models"
 	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekrotja/rogu"
+	"github.com/zekrotja/rogu/log"
 
 	"github.com/go-redis/redis/v8"
 )
@@ -34,6 +36,9 @@ const (
 	keyGuildAPI                    = "GUILD:API"
 	keyGuildRequireVerificationAPI = "GUILD:REQVER"
 	keyGuildBirthdayChanID         = "GUILD:BIRTHDAYCHAN"
+	keyGuildAutoThread             = "GUILD:AUTOTHREAD"
+	keyGuildStickyMessage          = "GUILD:STICKYMSG"
+	keyGuildAutoPublish            = "GUILD:AUTOPUBLISH"
 
 	keyKarmaState       = "KARMA:STATE"
 	keyKarmaemotesInc   = "KARMA:EMOTES:ENC"
@@ -65,19 +70,30 @@ type getterFunc func() (interface{}, error)
 // value is requested from database and then stored to cache.
 // On setting database values, values are set in database as
 // same as in the cache.
+//
+// While redis is not available, all requests are passed
+// to the database directly. After redis becomes available
+// again, all cached values are flushed because they might
+// be outdated.
 type RedisMiddleware struct {
 	database.Database
 
-	client *redis.Client
+	cache *Cache
+	log   rogu.Logger
 }
 
 var _ database.Database = (*RedisMiddleware)(nil)
 
-func NewRedisMiddleware(db database.Database, rd *redis.Client) *RedisMiddleware {
-	return &RedisMiddleware{
+func NewRedisMiddleware(db database.Database, cache *Cache) *RedisMiddleware {
+	r := &RedisMiddleware{
 		Database: db,
-		client:   rd,
+		cache:    cache,
+		log:      log.Tagged("Database"),
 	}
+
+	cache.guard.OnRecover(r.flush)
+
+	return r
 }
 
 // --- DATABASE INTERFACE IMPLEMENTATIONS -------------------------------------
@@ -87,7 +103,7 @@ func (r *RedisMiddleware) Connect(credentials ...interface{}) error {
 }
 
 func (r *RedisMiddleware) Close() {
-	r.client.Close()
+	r.cache.client.Close()
 	r.Database.Close()
 }
 
@@ -101,7 +117,7 @@ func (r *RedisMiddleware) GetGuildPrefix(guildID string) (string, error) {
 func (r *RedisMiddleware) SetGuildPrefix(guildID, newPrefix string) error {
 	var key = fmt.Sprintf("%s:%s", keyGuildPrefix, guildID)
 
-	if err := r.client.Set(context.Background(), key, newPrefix, 0).Err(); err != nil {
+	if err := r.set(key, newPrefix); err != nil {
 		return err
 	}
 
@@ -111,7 +127,7 @@ func (r *RedisMiddleware) SetGuildPrefix(guildID, newPrefix string) error {
 func (r *RedisMiddleware) GetGuildAutoRole(guildID string) ([]string, error) {
 	var key = fmt.Sprintf("%s:%s", keyGuildAutoRole, guildID)
 
-	valC, err := r.client.Get(context.Background(), key).Result()
+	valC, err := r.get(key).Result()
 	val := strings.Split(valC, ";")
 	if err == redis.Nil {
 		val, err = r.Database.GetGuildAutoRole(guildID)
@@ -119,7 +135,7 @@ func (r *RedisMiddleware) GetGuildAutoRole(guildID string) ([]string, error) {
 			return nil, err
 		}
 
-		err = r.client.Set(context.Background(), key, strings.Join(val, ";"), 0).Err()
+		err = r.set(key, strings.Join(val, ";"))
 		return val, err
 	}
 	if err != nil {
@@ -136,7 +152,7 @@ func (r *RedisMiddleware) GetGuildAutoRole(guildID string) ([]string, error) {
 func (r *RedisMiddleware) SetGuildAutoRole(guildID string, autoRoleIDs []string) error {
 	var key = fmt.Sprintf("%s:%s", keyGuildAutoRole, guildID)
 
-	if err := r.client.Set(context.Background(), key, strings.Join(autoRoleIDs, ";"), 0).Err(); err != nil {
+	if err := r.set(key, strings.Join(autoRoleIDs, ";")); err != nil {
 		return err
 	}
 
@@ -146,7 +162,7 @@ func (r *RedisMiddleware) SetGuildAutoRole(guildID string, autoRoleIDs []string)
 func (r *RedisMiddleware) GetGuildAutoVC(guildID string) ([]string, error) {
 	var key = fmt.Sprintf("%s:%s", keyGuildAutoVC, guildID)
 
-	valC, err := r.client.Get(context.Background(), key).Result()
+	valC, err := r.get(key).Result()
 	val := strings.Split(valC, ";")
 	if err == redis.Nil {
 		val, err = r.Database.GetGuildAutoVC(guildID)
@@ -154,7 +170,7 @@ func (r *RedisMiddleware) GetGuildAutoVC(guildID string) ([]string, error) {
 			return nil, err
 		}
 
-		err = r.client.Set(context.Background(), key, strings.Join(val, ";"), 0).Err()
+		err = r.set(key, strings.Join(val, ";"))
 		return val, err
 	}
 	if err != nil {
@@ -171,7 +187,7 @@ func (r *RedisMiddleware) GetGuildAutoVC(guildID string) ([]string, error) {
 func (r *RedisMiddleware) SetGuildAutoVC(guildID string, autoVCIDs []string) error {
 	var key = fmt.Sprintf("%s:%s", keyGuildAutoVC, guildID)
 
-	if err := r.client.Set(context.Background(), key, strings.Join(autoVCIDs, ";"), 0).Err(); err != nil {
+	if err := r.set(key, strings.Join(autoVCIDs, ";")); err != nil {
 		return err
 	}
 
@@ -188,7 +204,7 @@ func (r *RedisMiddleware) GetGuildModLog(guildID string) (string, error) {
 func (r *RedisMiddleware) SetGuildModLog(guildID, chanID string) error {
 	var key = fmt.Sprintf("%s:%s", keyGuildModLog, guildID)
 
-	if err := r.client.Set(context.Background(), key, chanID, 0).Err(); err != nil {
+	if err := r.set(key, chanID); err != nil {
 		return err
 	}
 
@@ -205,7 +221,7 @@ func (r *RedisMiddleware) GetGuildVoiceLog(guildID string) (string, error) {
 func (r *RedisMiddleware) SetGuildVoiceLog(guildID, chanID string) error {
 	var key = fmt.Sprintf("%s:%s", keyGuildVoiceLog, guildID)
 
-	if err := r.client.Set(context.Background(), key, chanID, 0).Err(); err != nil {
+	if err := r.set(key, chanID); err != nil {
 		return err
 	}
 
@@ -222,7 +238,7 @@ func (r *RedisMiddleware) GetGuildNotifyRole(guildID string) (string, error) {
 func (r *RedisMiddleware) SetGuildNotifyRole(guildID, roleID string) error {
 	var key = fmt.Sprintf("%s:%s", keyGuildNotifyRole, guildID)
 
-	if err := r.client.Set(context.Background(), key, roleID, 0).Err(); err != nil {
+	if err := r.set(key, roleID); err != nil {
 		return err
 	}
 
@@ -239,7 +255,7 @@ func (r *RedisMiddleware) GetGuildGhostpingMsg(guildID string) (string, error) {
 func (r *RedisMiddleware) SetGuildGhostpingMsg(guildID, msg string) error {
 	var key = fmt.Sprintf("%s:%s", keyGuildGhostPingMsg, guildID)
 
-	if err := r.client.Set(context.Background(), key, msg, 0).Err(); err != nil {
+	if err := r.set(key, msg); err != nil {
 		return err
 	}
 
@@ -256,7 +272,7 @@ func (r *RedisMiddleware) GetGuildJdoodleKey(guildID string) (string, error) {
 func (r *RedisMiddleware) SetGuildJdoodleKey(guildID, jdkey string) error {
 	var key = fmt.Sprintf("%s:%s", keyGuildJDoodleKey, guildID)
 
-	if err := r.client.Set(context.Background(), key, jdkey, 0).Err(); err != nil {
+	if err := r.set(key, jdkey); err != nil {
 		return err
 	}
 
@@ -273,7 +289,7 @@ func (r *RedisMiddleware) GetGuildCodeExecEnabled(guildID string) (bool, error)
 func (r *RedisMiddleware) SetGuildCodeExecEnabled(guildID string, enabled bool) error {
 	var key = fmt.Sprintf("%s:%s", keyGuildCodeExecEnabled, guildID)
 
-	if err := r.client.Set(context.Background(), key, enabled, 0).Err(); err != nil {
+	if err := r.set(key, enabled); err != nil {
 		return err
 	}
 
@@ -290,7 +306,7 @@ func (r *RedisMiddleware) GetGuildBackup(guildID string) (bool, error) {
 func (r *RedisMiddleware) SetGuildBackup(guildID string, enabled bool) error {
 	var key = fmt.Sprintf("%s:%s", keyGuildBackupEnabled, guildID)
 
-	if err := r.client.Set(context.Background(), key, enabled, 0).Err(); err != nil {
+	if err := r.set(key, enabled); err != nil {
 		return err
 	}
 
@@ -307,7 +323,7 @@ func (r *RedisMiddleware) GetGuildInviteBlock(guildID string) (string, error) {
 func (r *RedisMiddleware) SetGuildInviteBlock(guildID string, data string) error {
 	var key = fmt.Sprintf("%s:%s", keyGuildInviteBlock, guildID)
 
-	if err := r.client.Set(context.Background(), key, data, 0).Err(); err != nil {
+	if err := r.set(key, data); err != nil {
 		return err
 	}
 
@@ -319,14 +335,14 @@ func (r *RedisMiddleware) GetGuildJoinMsg(guildID string) (string, string, error
 
 	var val1, val2 string
 
-	raw, err := r.client.Get(context.Background(), key).Result()
+	raw, err := r.get(key).Result()
 	if err == redis.Nil {
 		val1, val2, err = r.Database.GetGuildJoinMsg(guildID)
 		if err != nil {
 			return "", "", err
 		}
 
-		err = r.client.Set(context.Background(), key, fmt.Sprintf("%s|%s", val1, val2), 0).Err()
+		err = r.set(key, fmt.Sprintf("%s|%s", val1, val2))
 		return val1, val2, err
 	}
 	if err != nil {
@@ -342,7 +358,7 @@ func (r *RedisMiddleware) GetGuildJoinMsg(guildID string) (string, string, error
 func (r *RedisMiddleware) SetGuildJoinMsg(guildID string, channelID string, msg string) error {
 	var key = fmt.Sprintf("%s:%s", keyGuildJoinMsg, guildID)
 
-	if err := r.client.Set(context.Background(), key, fmt.Sprintf("%s|%s", channelID, msg), 0).Err(); err != nil {
+	if err := r.set(key, fmt.Sprintf("%s|%s", channelID, msg)); err != nil {
 		return err
 	}
 
@@ -354,14 +370,14 @@ func (r *RedisMiddleware) GetGuildLeaveMsg(guildID string) (string, string, erro
 
 	var val1, val2 string
 
-	raw, err := r.client.Get(context.Background(), key).Result()
+	raw, err := r.get(key).Result()
 	if err == redis.Nil {
 		val1, val2, err = r.Database.GetGuildLeaveMsg(guildID)
 		if err != nil {
 			return "", "", err
 		}
 
-		err = r.client.Set(context.Background(), key, fmt.Sprintf("%s|%s", val1, val2), 0).Err()
+		err = r.set(key, fmt.Sprintf("%s|%s", val1, val2))
 		return val1, val2, err
 	}
 	if err != nil {
@@ -377,7 +393,7 @@ func (r *RedisMiddleware) GetGuildLeaveMsg(guildID string) (string, string, erro
 func (r *RedisMiddleware) SetGuildLeaveMsg(guildID string, channelID string, msg string) error {
 	var key = fmt.Sprintf("%s:%s", keyGuildLeaveMsg, guildID)
 
-	if err := r.client.Set(context.Background(), key, fmt.Sprintf("%s|%s", channelID, msg), 0).Err(); err != nil {
+	if err := r.set(key, fmt.Sprintf("%s|%s", channelID, msg)); err != nil {
 		return err
 	}
 
@@ -394,7 +410,7 @@ func (r *RedisMiddleware) GetGuildColorReaction(guildID string) (bool, error) {
 func (r *RedisMiddleware) SetGuildColorReaction(guildID string, enabled bool) error {
 	var key = fmt.Sprintf("%s:%s", keyGuildColorReaction, guildID)
 
-	if err := r.client.Set(context.Background(), key, enabled, 0).Err(); err != nil {
+	if err := r.set(key, enabled); err != nil {
 		return err
 	}
 
@@ -411,7 +427,7 @@ func (r *RedisMiddleware) GetSetting(setting string) (string, error) {
 func (r *RedisMiddleware) SetSetting(setting, value string) error {
 	var key = fmt.Sprintf("%s:%s", keySetting, setting)
 
-	if err := r.client.Set(context.Background(), key, value, 0).Err(); err != nil {
+	if err := r.set(key, value); err != nil {
 		return err
 	}
 
@@ -426,7 +442,7 @@ func (m *RedisMiddleware) SetAPIToken(token models.APITokenEntry) (err error) {
 		return
 	}
 
-	if err = m.client.Set(context.Background(), key, data, 0).Err(); err != nil {
+	if err = m.set(key, data); err != nil {
 		return
 	}
 
@@ -436,7 +452,7 @@ func (m *RedisMiddleware) SetAPIToken(token models.APITokenEntry) (err error) {
 func (m *RedisMiddleware) GetAPIToken(userID string) (t models.APITokenEntry, err error) {
 	var key = fmt.Sprintf("%s:%s", keyUserAPIToken, userID)
 
-	resStr, err := m.client.Get(context.Background(), key).Result()
+	resStr, err := m.get(key).Result()
 	if err == redis.Nil {
 		if t, err = m.Database.GetAPIToken(userID); err != nil {
 			return
@@ -446,7 +462,7 @@ func (m *RedisMiddleware) GetAPIToken(userID string) (t models.APITokenEntry, er
 		if err != nil {
 			return
 		}
-		if err = m.client.Set(context.Background(), key, resB, 0).Err(); err != nil {
+		if err = m.set(key, resB); err != nil {
 			return
 		}
 		return
@@ -457,10 +473,20 @@ func (m *RedisMiddleware) GetAPIToken(userID string) (t models.APITokenEntry, er
 	return
 }
 
+func (m *RedisMiddleware) AddAPITokenHit(userID string, t time.Time) (err error) {
+	var key = fmt.Sprintf("%s:%s", keyUserAPIToken, userID)
+
+	if err = m.del(key); err != nil {
+		return
+	}
+
+	return m.Database.AddAPITokenHit(userID, t)
+}
+
 func (m *RedisMiddleware) DeleteAPIToken(userID string) (err error) {
 	var key = fmt.Sprintf("%s:%s", keyUserAPIToken, userID)
 
-	if err = m.client.Del(context.Background(), key).Err(); err != nil {
+	if err = m.del(key); err != nil {
 		return
 	}
 
@@ -470,7 +496,7 @@ func (m *RedisMiddleware) DeleteAPIToken(userID string) (err error) {
 func (m *RedisMiddleware) SetKarmaState(guildID string, state bool) error {
 	var key = fmt.Sprintf("%s:%s", keyKarmaState, guildID)
 
-	if err := m.client.Set(context.Background(), key, state, 0).Err(); err != nil {
+	if err := m.set(key, state); err != nil {
 		return err
 	}
 
@@ -486,12 +512,12 @@ func (m *RedisMiddleware) GetKarmaState(guildID string) (bool, error) {
 
 func (m *RedisMiddleware) SetKarmaEmotes(guildID, emotesInc, emotesDec string) error {
 	var key = fmt.Sprintf("%s:%s", keyKarmaemotesInc, guildID)
-	if err := m.client.Set(context.Background(), key, emotesInc, 0).Err(); err != nil {
+	if err := m.set(key, emotesInc); err != nil {
 		return err
 	}
 
 	key = fmt.Sprintf("%s:%s", keyKarmaEmotesDec, guildID)
-	if err := m.client.Set(context.Background(), key, emotesDec, 0).Err(); err != nil {
+	if err := m.set(key, emotesDec); err != nil {
 		return err
 	}
 
@@ -500,10 +526,10 @@ func (m *RedisMiddleware) SetKarmaEmotes(guildID, emotesInc, emotesDec string) e
 
 func (m *RedisMiddleware) GetKarmaEmotes(guildID string) (emotesInc, emotesDec string, err error) {
 	var keyEnc = fmt.Sprintf("%s:%s", keyKarmaemotesInc, guildID)
-	emotesInc, err1 := m.client.Get(context.Background(), keyEnc).Result()
+	emotesInc, err1 := m.get(keyEnc).Result()
 
 	var keyDec = fmt.Sprintf("%s:%s", keyKarmaEmotesDec, guildID)
-	emotesDec, err2 := m.client.Get(context.Background(), keyDec).Result()
+	emotesDec, err2 := m.get(keyDec).Result()
 
 	if err1 == redis.Nil || err2 == redis.Nil {
 		emotesInc, emotesDec, err = m.Database.GetKarmaEmotes(guildID)
@@ -511,10 +537,10 @@ func (m *RedisMiddleware) GetKarmaEmotes(guildID string) (emotesInc, emotesDec s
 			return
 		}
 
-		if err = m.client.Set(context.Background(), keyEnc, emotesInc, 0).Err(); err != nil {
+		if err = m.set(keyEnc, emotesInc); err != nil {
 			return
 		}
-		if err = m.client.Set(context.Background(), keyDec, emotesDec, 0).Err(); err != nil {
+		if err = m.set(keyDec, emotesDec); err != nil {
 			return
 		}
 	}
@@ -528,7 +554,7 @@ func (m *RedisMiddleware) GetKarmaEmotes(guildID string) (emotesInc, emotesDec s
 func (m *RedisMiddleware) SetKarmaTokens(guildID string, tokens int) error {
 	var key = fmt.Sprintf("%s:%s", keyKarmaTokens, guildID)
 
-	if err := m.client.Set(context.Background(), key, tokens, 0).Err(); err != nil {
+	if err := m.set(key, tokens); err != nil {
 		return err
 	}
 
@@ -545,7 +571,7 @@ func (m *RedisMiddleware) GetKarmaTokens(guildID string) (int, error) {
 func (m *RedisMiddleware) SetKarmaPenalty(guildID string, state bool) error {
 	var key = fmt.Sprintf("%s:%s", keyKarmaPenalty, guildID)
 
-	if err := m.client.Set(context.Background(), key, state, 0).Err(); err != nil {
+	if err := m.set(key, state); err != nil {
 		return err
 	}
 
@@ -569,7 +595,7 @@ func (m *RedisMiddleware) IsKarmaBlockListed(guildID, userID string) (ok bool, e
 func (m *RedisMiddleware) AddKarmaBlockList(guildID, userID string) (err error) {
 	var key = fmt.Sprintf("%s:%s:%s", keyKarmaBlockListed, guildID, userID)
 
-	if err = m.client.Set(context.Background(), key, true, 0).Err(); err != nil {
+	if err = m.set(key, true); err != nil {
 		return
 	}
 
@@ -579,7 +605,7 @@ func (m *RedisMiddleware) AddKarmaBlockList(guildID, userID string) (err error)
 func (m *RedisMiddleware) RemoveKarmaBlockList(guildID, userID string) (err error) {
 	var key = fmt.Sprintf("%s:%s:%s", keyKarmaBlockListed, guildID, userID)
 
-	if err = m.client.Set(context.Background(), key, false, 0).Err(); err != nil {
+	if err = m.set(key, false); err != nil {
 		return
 	}
 
@@ -589,7 +615,7 @@ func (m *RedisMiddleware) RemoveKarmaBlockList(guildID, userID string) (err erro
 func (m *RedisMiddleware) SetAntiraidState(guildID string, state bool) error {
 	var key = fmt.Sprintf("%s:%s", keyAntiraidState, guildID)
 
-	if err := m.client.Set(context.Background(), key, state, 0).Err(); err != nil {
+	if err := m.set(key, state); err != nil {
 		return err
 	}
 
@@ -606,7 +632,7 @@ func (m *RedisMiddleware) GetAntiraidState(guildID string) (bool, error) {
 func (m *RedisMiddleware) SetAntiraidRegeneration(guildID string, limit int) error {
 	var key = fmt.Sprintf("%s:%s", keyAntiraidLimit, guildID)
 
-	if err := m.client.Set(context.Background(), key, limit, 0).Err(); err != nil {
+	if err := m.set(key, limit); err != nil {
 		return err
 	}
 
@@ -623,7 +649,7 @@ func (m *RedisMiddleware) GetAntiraidRegeneration(guildID string) (int, error) {
 func (m *RedisMiddleware) SetAntiraidBurst(guildID string, burst int) error {
 	var key = fmt.Sprintf("%s:%s", keyAntiraidBurst, guildID)
 
-	if err := m.client.Set(context.Background(), key, burst, 0).Err(); err != nil {
+	if err := m.set(key, burst); err != nil {
 		return err
 	}
 
@@ -647,7 +673,7 @@ func (m *RedisMiddleware) GetUserOTAEnabled(userID string) (bool, error) {
 func (m *RedisMiddleware) SetUserOTAEnabled(userID string, enabled bool) error {
 	var key = fmt.Sprintf("%s:%s", keyUserEnableOTA, userID)
 
-	if err := m.client.Set(context.Background(), key, enabled, 0).Err(); err != nil {
+	if err := m.set(key, enabled); err != nil {
 		return err
 	}
 
@@ -658,7 +684,7 @@ func (m *RedisMiddleware) GetStarboardConfig(guildID string) (config models.Star
 	var key = fmt.Sprintf("%s:%s", keyGuildStarboardConfig, guildID)
 
 	var configB []byte
-	err = m.client.Get(context.Background(), key).Scan(&configB)
+	err = m.get(key).Scan(&configB)
 	if err == redis.Nil {
 		config, err = m.Database.GetStarboardConfig(guildID)
 		if err != nil {
@@ -667,7 +693,7 @@ func (m *RedisMiddleware) GetStarboardConfig(guildID string) (config models.Star
 		if configB, err = json.Marshal(config); err != nil {
 			return
 		}
-		err = m.client.Set(context.Background(), key, configB, 0).Err()
+		err = m.set(key, configB)
 		return
 	}
 	if err != nil {
@@ -684,13 +710,82 @@ func (m *RedisMiddleware) SetStarboardConfig(config models.StarboardConfig) (err
 	if err != nil {
 		return
 	}
-	if err = m.client.Set(context.Background(), key, configB, 0).Err(); err != nil {
+	if err = m.set(key, configB); err != nil {
 		return
 	}
 	err = m.Database.SetStarboardConfig(config)
 	return
 }
 
+func (m *RedisMiddleware) GetAutoThread(guildID, channelID string) (models.AutoThreadConfig, error) {
+	var key = fmt.Sprintf("%s:%s:%s", keyGuildAutoThread, guildID, channelID)
+	return GetOptional(m, key, func() (models.AutoThreadConfig, error) {
+		return m.Database.GetAutoThread(guildID, channelID)
+	})
+}
+
+func (m *RedisMiddleware) SetAutoThread(config models.AutoThreadConfig) (err error) {
+	var key = fmt.Sprintf("%s:%s:%s", keyGuildAutoThread, config.GuildID, config.ChannelID)
+	if err = m.Database.SetAutoThread(config); err != nil {
+		return
+	}
+	return m.del(key)
+}
+
+func (m *RedisMiddleware) RemoveAutoThread(guildID, channelID string) (err error) {
+	var key = fmt.Sprintf("%s:%s:%s", keyGuildAutoThread, guildID, channelID)
+	if err = m.Database.RemoveAutoThread(guildID, channelID); err != nil {
+		return
+	}
+	return m.del(key)
+}
+
+func (m *RedisMiddleware) GetStickyMessage(guildID, channelID string) (models.StickyMessage, error) {
+	var key = fmt.Sprintf("%s:%s:%s", keyGuildStickyMessage, guildID, channelID)
+	return GetOptional(m, key, func() (models.StickyMessage, error) {
+		return m.Database.GetStickyMessage(guildID, channelID)
+	})
+}
+
+func (m *RedisMiddleware) SetStickyMessage(sm models.StickyMessage) (err error) {
+	var key = fmt.Sprintf("%s:%s:%s", keyGuildStickyMessage, sm.GuildID, sm.ChannelID)
+	if err = m.Database.SetStickyMessage(sm); err != nil {
+		return
+	}
+	return m.del(key)
+}
+
+func (m *RedisMiddleware) RemoveStickyMessage(guildID, channelID string) (err error) {
+	var key = fmt.Sprintf("%s:%s:%s", keyGuildStickyMessage, guildID, channelID)
+	if err = m.Database.RemoveStickyMessage(guildID, channelID); err != nil {
+		return
+	}
+	return m.del(key)
+}
+
+func (m *RedisMiddleware) GetAutoPublish(guildID, channelID string) (models.AutoPublishConfig, error) {
+	var key = fmt.Sprintf("%s:%s:%s", keyGuildAutoPublish, guildID, channelID)
+	return GetOptional(m, key, func() (models.AutoPublishConfig, error) {
+		return m.Database.GetAutoPublish(guildID, channelID)
+	})
+}
+
+func (m *RedisMiddleware) SetAutoPublish(cfg models.AutoPublishConfig) (err error) {
+	var key = fmt.Sprintf("%s:%s:%s", keyGuildAutoPublish, cfg.GuildID, cfg.ChannelID)
+	if err = m.Database.SetAutoPublish(cfg); err != nil {
+		return
+	}
+	return m.del(key)
+}
+
+func (m *RedisMiddleware) RemoveAutoPublish(guildID, channelID string) (err error) {
+	var key = fmt.Sprintf("%s:%s:%s", keyGuildAutoPublish, guildID, channelID)
+	if err = m.Database.RemoveAutoPublish(guildID, channelID); err != nil {
+		return
+	}
+	return m.del(key)
+}
+
 func (r *RedisMiddleware) GetGuildLogDisable(guildID string) (bool, error) {
 	var key = fmt.Sprintf("%s:%s", keyGuildLogEnable, guildID)
 	return Get(r, key, func() (bool, error) {
@@ -701,7 +796,7 @@ func (r *RedisMiddleware) GetGuildLogDisable(guildID string) (bool, error) {
 func (r *RedisMiddleware) SetGuildLogDisable(guildID string, enabled bool) error {
 	var key = fmt.Sprintf("%s:%s", keyGuildLogEnable, guildID)
 
-	if err := r.client.Set(context.Background(), key, enabled, 0).Err(); err != nil {
+	if err := r.set(key, enabled); err != nil {
 		return err
 	}
 
@@ -716,7 +811,7 @@ func (m *RedisMiddleware) SetGuildAPI(guildID string, settings models.GuildAPISe
 		return
 	}
 
-	if err = m.client.Set(context.Background(), key, data, 0).Err(); err != nil {
+	if err = m.set(key, data); err != nil {
 		return
 	}
 
@@ -726,7 +821,7 @@ func (m *RedisMiddleware) SetGuildAPI(guildID string, settings models.GuildAPISe
 func (m *RedisMiddleware) GetGuildAPI(guildID string) (settings models.GuildAPISettings, err error) {
 	var key = fmt.Sprintf("%s:%s", keyGuildAPI, guildID)
 
-	resStr, err := m.client.Get(context.Background(), key).Result()
+	resStr, err := m.get(key).Result()
 	if err == redis.Nil {
 		if settings, err = m.Database.GetGuildAPI(guildID); err != nil {
 			return
@@ -736,7 +831,7 @@ func (m *RedisMiddleware) GetGuildAPI(guildID string) (settings models.GuildAPIS
 		if err != nil {
 			return
 		}
-		if err = m.client.Set(context.Background(), key, resB, 0).Err(); err != nil {
+		if err = m.set(key, resB); err != nil {
 			return
 		}
 		return
@@ -757,7 +852,7 @@ func (r *RedisMiddleware) GetGuildVerificationRequired(guildID string) (bool, er
 func (r *RedisMiddleware) SetGuildVerificationRequired(guildID string, enabled bool) error {
 	var key = fmt.Sprintf("%s:%s", keyGuildRequireVerificationAPI, guildID)
 
-	if err := r.client.Set(context.Background(), key, enabled, 0).Err(); err != nil {
+	if err := r.set(key, enabled); err != nil {
 		return err
 	}
 
@@ -774,7 +869,7 @@ func (r *RedisMiddleware) GetGuildBirthdayChan(guildID string) (string, error) {
 func (r *RedisMiddleware) SetGuildBirthdayChan(guildID, newPrefix string) error {
 	var key = fmt.Sprintf("%s:%s", keyGuildBirthdayChanID, guildID)
 
-	if err := r.client.Set(context.Background(), key, newPrefix, 0).Err(); err != nil {
+	if err := r.set(key, newPrefix); err != nil {
 		return err
 	}
 
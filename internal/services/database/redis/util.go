@@ -1,13 +1,19 @@
 package redis
 
 import (
-	"context"
+	"encoding/json"
 
 	"github.com/go-redis/redis/v8"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
 )
 
+type optional[T any] struct {
+	Found bool `json:"found"`
+	Value T    `json:"value"`
+}
+
 func Set[T any](r *RedisMiddleware, key string, val T) error {
-	return r.client.Set(context.Background(), key, val, 0).Err()
+	return r.set(key, val)
 }
 
 func Get[T any](
@@ -15,7 +21,7 @@ func Get[T any](
 	key string,
 	fallback func() (T, error),
 ) (val T, err error) {
-	err = r.client.Get(context.Background(), key).Scan(&val)
+	err = r.get(key).Scan(&val)
 	if err == redis.Nil {
 		if val, err = fallback(); err != nil {
 			return
@@ -24,3 +30,63 @@ func Get[T any](
 	}
 	return
 }
+
+// GetOptional is like Get but values are JSON encoded
+// and not found results of fallback are cached as well.
+// This should be used for values which are requested
+// frequently and are usually not set.
+func GetOptional[T any](
+	r *RedisMiddleware,
+	key string,
+	fallback func() (T, error),
+) (val T, err error) {
+	var (
+		o optional[T]
+		b []byte
+	)
+
+	err = r.get(key).Scan(&b)
+	if err == redis.Nil {
+		o.Value, err = fallback()
+		if err != nil && !database.IsErrDatabaseNotFound(err) {
+			return
+		}
+		o.Found = err == nil
+		if b, err = json.Marshal(o); err != nil {
+			return
+		}
+		if err = r.set(key, b); err != nil {
+			return
+		}
+	} else if err != nil {
+		return
+	} else if err = json.Unmarshal(b, &o); err != nil {
+		return
+	}
+
+	val = o.Value
+	if !o.Found {
+		err = database.ErrDatabaseNotFound
+	}
+	return
+}
+
+func (r *RedisMiddleware) get(key string) *redis.StringCmd {
+	return r.cache.get(key)
+}
+
+func (r *RedisMiddleware) set(key string, val interface{}) error {
+	return r.cache.set(key, val)
+}
+
+func (r *RedisMiddleware) del(key string) error {
+	return r.cache.del(key)
+}
+
+// flush removes all cached values. This is done after
+// redis becomes available again because values which
+// have been changed in the meantime are outdated.
+func (r *RedisMiddleware) flush() {
+	n := r.cache.FlushPrefix("")
+	r.log.Info().Field("n", n).Msg("Flushed outdated database cache values")
+}
@@ -0,0 +1,656 @@
+// Package settingscache provides a database middleware
+// which caches the guild settings in a kvcache.Provider.
+package settingscache
+
+import (
+	"sync"
+	"time"
+
+	"github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/services/kvcache"
+	"github.com/zekroTJA/shinpuru/internal/services/metrics"
+	"github.com/zekroTJA/shinpuru/pkg/permissions"
+)
+
+const (
+	keyPrefix       = "SETTINGSCACHE:"
+	defaultLifetime = 30 * time.Minute
+)
+
+// guildSettings holds the cached settings of a single
+// guild. Settings are loaded lazily on first access.
+//
+// After the entry has been invalidated, no values are
+// stored anymore so that values which have been read
+// from the database before an update can not end up
+// in the cache.
+type guildSettings struct {
+	mtx         sync.RWMutex
+	values      map[string]cachedValue
+	invalidated bool
+}
+
+type cachedValue struct {
+	v   any
+	err error
+}
+
+func (gs *guildSettings) get(field string) (cachedValue, bool) {
+	gs.mtx.RLock()
+	defer gs.mtx.RUnlock()
+
+	v, ok := gs.values[field]
+	return v, ok
+}
+
+func (gs *guildSettings) set(field string, v cachedValue) {
+	gs.mtx.Lock()
+	defer gs.mtx.Unlock()
+
+	if !gs.invalidated {
+		gs.values[field] = v
+	}
+}
+
+func (gs *guildSettings) invalidate() {
+	gs.mtx.Lock()
+	defer gs.mtx.Unlock()
+
+	gs.invalidated = true
+	gs.values = nil
+}
+
+// SettingsCacheMiddleware implements the Database
+// interface and caches the guild settings of each
+// guild in a single cache entry.
+//
+// Guild settings are read through the cache. Any write
+// to the settings of a guild is passed to the consumed
+// database driver and invalidates the whole cache entry
+// of the guild afterwards. All other requests are passed
+// to the consumed database driver directly.
+type SettingsCacheMiddleware struct {
+	database.Database
+
+	kv       kvcache.Provider
+	lifetime time.Duration
+	mtx      sync.Mutex
+}
+
+var _ database.Database = (*SettingsCacheMiddleware)(nil)
+
+// New returns a new SettingsCacheMiddleware consuming
+// the given database driver and storing the settings
+// in the given cache.
+func New(db database.Database, kv kvcache.Provider) *SettingsCacheMiddleware {
+	return &SettingsCacheMiddleware{
+		Database: db,
+		kv:       kv,
+		lifetime: defaultLifetime,
+	}
+}
+
+// Invalidate removes the cached settings of the given
+// guild.
+func (m *SettingsCacheMiddleware) Invalidate(guildID string) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	key := keyPrefix + guildID
+	if gs, ok := m.kv.Get(key).(*guildSettings); ok {
+		gs.invalidate()
+	}
+	m.kv.Del(key)
+}
+
+func (m *SettingsCacheMiddleware) entry(guildID string) *guildSettings {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	key := keyPrefix + guildID
+	gs, ok := m.kv.Get(key).(*guildSettings)
+	if !ok {
+		gs = &guildSettings{values: map[string]cachedValue{}}
+		m.kv.Set(key, gs, m.lifetime)
+	}
+
+	return gs
+}
+
+func (m *SettingsCacheMiddleware) invalidateAfter(guildID string, err error) error {
+	m.Invalidate(guildID)
+	return err
+}
+
+// get returns the cached value of the given field of
+// the guild settings. If the value is not cached, it is
+// requested by calling fallback and stored in the cache.
+//
+// Not found errors are cached as well because most
+// guilds do not have all settings set.
+func get[T any](
+	m *SettingsCacheMiddleware,
+	guildID, field string,
+	fallback func() (T, error),
+) (T, error) {
+	gs := m.entry(guildID)
+
+	if cv, ok := gs.get(field); ok {
+		metrics.SettingsCacheRequests.WithLabelValues("hit").Inc()
+		return cv.v.(T), cv.err
+	}
+
+	metrics.SettingsCacheRequests.WithLabelValues("miss").Inc()
+
+	v, err := fallback()
+	if err == nil || database.IsErrDatabaseNotFound(err) {
+		gs.set(field, cachedValue{v: v, err: err})
+	}
+
+	return v, err
+}
+
+// getSlice is like get but returns a copy of the cached
+// slice so that callers can not alter the cached value.
+func getSlice[T any](
+	m *SettingsCacheMiddleware,
+	guildID, field string,
+	fallback func() ([]T, error),
+) ([]T, error) {
+	v, err := get(m, guildID, field, fallback)
+	if v == nil {
+		return v, err
+	}
+
+	c := make([]T, len(v))
+	copy(c, v)
+	return c, err
+}
+
+type message struct {
+	channelID string
+	msg       string
+}
+
+type silentMessage struct {
+	msg    string
+	silent bool
+}
+
+type gracePeriod struct {
+	period     time.Duration
+	noteRejoin bool
+}
+
+// --- DATABASE INTERFACE IMPLEMENTATIONS -------------------------------------
+
+func (m *SettingsCacheMiddleware) GetGuildPrefix(guildID string) (string, error) {
+	return get(m, guildID, "prefix", func() (string, error) {
+		return m.Database.GetGuildPrefix(guildID)
+	})
+}
+
+func (m *SettingsCacheMiddleware) SetGuildPrefix(guildID, newPrefix string) error {
+	return m.invalidateAfter(guildID, m.Database.SetGuildPrefix(guildID, newPrefix))
+}
+
+func (m *SettingsCacheMiddleware) GetGuildAutoRole(guildID string) ([]string, error) {
+	return getSlice(m, guildID, "autorole", func() ([]string, error) {
+		return m.Database.GetGuildAutoRole(guildID)
+	})
+}
+
+func (m *SettingsCacheMiddleware) SetGuildAutoRole(guildID string, autoRoleIDs []string) error {
+	return m.invalidateAfter(guildID, m.Database.SetGuildAutoRole(guildID, autoRoleIDs))
+}
+
+func (m *SettingsCacheMiddleware) GetGuildAutoVC(guildID string) ([]string, error) {
+	return getSlice(m, guildID, "autovc", func() ([]string, error) {
+		return m.Database.GetGuildAutoVC(guildID)
+	})
+}
+
+func (m *SettingsCacheMiddleware) SetGuildAutoVC(guildID string, autoVCIDs []string) error {
+	return m.invalidateAfter(guildID, m.Database.SetGuildAutoVC(guildID, autoVCIDs))
+}
+
+func (m *SettingsCacheMiddleware) GetGuildModLog(guildID string) (string, error) {
+	return get(m, guildID, "modlog", func() (string, error) {
+		return m.Database.GetGuildModLog(guildID)
+	})
+}
+
+func (m *SettingsCacheMiddleware) SetGuildModLog(guildID, chanID string) error {
+	return m.invalidateAfter(guildID, m.Database.SetGuildModLog(guildID, chanID))
+}
+
+func (m *SettingsCacheMiddleware) GetGuildModLogFormat(guildID string) (models.ModLogFormat, error) {
+	return get(m, guildID, "modlogformat", func() (models.ModLogFormat, error) {
+		return m.Database.GetGuildModLogFormat(guildID)
+	})
+}
+
+func (m *SettingsCacheMiddleware) SetGuildModLogFormat(guildID string, format models.ModLogFormat) error {
+	return m.invalidateAfter(guildID, m.Database.SetGuildModLogFormat(guildID, format))
+}
+
+func (m *SettingsCacheMiddleware) GetGuildVoiceLog(guildID string) (string, error) {
+	return get(m, guildID, "voicelog", func() (string, error) {
+		return m.Database.GetGuildVoiceLog(guildID)
+	})
+}
+
+func (m *SettingsCacheMiddleware) SetGuildVoiceLog(guildID, chanID string) error {
+	return m.invalidateAfter(guildID, m.Database.SetGuildVoiceLog(guildID, chanID))
+}
+
+func (m *SettingsCacheMiddleware) GetGuildVoiceLogIgnores(guildID string) ([]string, error) {
+	return getSlice(m, guildID, "voicelogignores", func() ([]string, error) {
+		return m.Database.GetGuildVoiceLogIgnores(guildID)
+	})
+}
+
+func (m *SettingsCacheMiddleware) IsGuildVoiceLogIgnored(guildID, channelID string) (bool, error) {
+	ignores, err := get(m, guildID, "voicelogignores", func() ([]string, error) {
+		return m.Database.GetGuildVoiceLogIgnores(guildID)
+	})
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return false, err
+	}
+
+	for _, id := range ignores {
+		if id == channelID {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (m *SettingsCacheMiddleware) SetGuildVoiceLogIngore(guildID, channelID string) error {
+	return m.invalidateAfter(guildID, m.Database.SetGuildVoiceLogIngore(guildID, channelID))
+}
+
+func (m *SettingsCacheMiddleware) RemoveGuildVoiceLogIgnore(guildID, channelID string) error {
+	return m.invalidateAfter(guildID, m.Database.RemoveGuildVoiceLogIgnore(guildID, channelID))
+}
+
+func (m *SettingsCacheMiddleware) GetGuildNotifyRole(guildID string) (string, error) {
+	return get(m, guildID, "notifyrole", func() (string, error) {
+		return m.Database.GetGuildNotifyRole(guildID)
+	})
+}
+
+func (m *SettingsCacheMiddleware) SetGuildNotifyRole(guildID, roleID string) error {
+	return m.invalidateAfter(guildID, m.Database.SetGuildNotifyRole(guildID, roleID))
+}
+
+func (m *SettingsCacheMiddleware) GetGuildGhostpingMsg(guildID string) (string, error) {
+	return get(m, guildID, "ghostpingmsg", func() (string, error) {
+		return m.Database.GetGuildGhostpingMsg(guildID)
+	})
+}
+
+func (m *SettingsCacheMiddleware) SetGuildGhostpingMsg(guildID, msg string) error {
+	return m.invalidateAfter(guildID, m.Database.SetGuildGhostpingMsg(guildID, msg))
+}
+
+func (m *SettingsCacheMiddleware) GetGuildPermissions(guildID string) (map[string]permissions.PermissionArray, error) {
+	perms, err := get(m, guildID, "permissions", func() (map[string]permissions.PermissionArray, error) {
+		return m.Database.GetGuildPermissions(guildID)
+	})
+	if perms == nil {
+		return perms, err
+	}
+
+	c := make(map[string]permissions.PermissionArray, len(perms))
+	for roleID, p := range perms {
+		c[roleID] = append(permissions.PermissionArray{}, p...)
+	}
+	return c, err
+}
+
+func (m *SettingsCacheMiddleware) SetGuildRolePermission(guildID, roleID string, p permissions.PermissionArray) error {
+	return m.invalidateAfter(guildID, m.Database.SetGuildRolePermission(guildID, roleID, p))
+}
+
+func (m *SettingsCacheMiddleware) GetGuildJdoodleKey(guildID string) (string, error) {
+	return get(m, guildID, "jdoodlekey", func() (string, error) {
+		return m.Database.GetGuildJdoodleKey(guildID)
+	})
+}
+
+func (m *SettingsCacheMiddleware) SetGuildJdoodleKey(guildID, key string) error {
+	return m.invalidateAfter(guildID, m.Database.SetGuildJdoodleKey(guildID, key))
+}
+
+func (m *SettingsCacheMiddleware) GetGuildCodeExecEnabled(guildID string) (bool, error) {
+	return get(m, guildID, "codeexecenabled", func() (bool, error) {
+		return m.Database.GetGuildCodeExecEnabled(guildID)
+	})
+}
+
+func (m *SettingsCacheMiddleware) SetGuildCodeExecEnabled(guildID string, enabled bool) error {
+	return m.invalidateAfter(guildID, m.Database.SetGuildCodeExecEnabled(guildID, enabled))
+}
+
+func (m *SettingsCacheMiddleware) GetGuildCodeExecLanguages(guildID string) ([]string, error) {
+	return getSlice(m, guildID, "codeexeclanguages", func() ([]string, error) {
+		return m.Database.GetGuildCodeExecLanguages(guildID)
+	})
+}
+
+func (m *SettingsCacheMiddleware) SetGuildCodeExecLanguages(guildID string, languages []string) error {
+	return m.invalidateAfter(guildID, m.Database.SetGuildCodeExecLanguages(guildID, languages))
+}
+
+func (m *SettingsCacheMiddleware) GetGuildBackup(guildID string) (bool, error) {
+	return get(m, guildID, "backup", func() (bool, error) {
+		return m.Database.GetGuildBackup(guildID)
+	})
+}
+
+func (m *SettingsCacheMiddleware) SetGuildBackup(guildID string, enabled bool) error {
+	return m.invalidateAfter(guildID, m.Database.SetGuildBackup(guildID, enabled))
+}
+
+func (m *SettingsCacheMiddleware) GetGuildInviteBlock(guildID string) (string, error) {
+	return get(m, guildID, "inviteblock", func() (string, error) {
+		return m.Database.GetGuildInviteBlock(guildID)
+	})
+}
+
+func (m *SettingsCacheMiddleware) SetGuildInviteBlock(guildID string, data string) error {
+	return m.invalidateAfter(guildID, m.Database.SetGuildInviteBlock(guildID, data))
+}
+
+func (m *SettingsCacheMiddleware) GetGuildJoinMsg(guildID string) (string, string, error) {
+	v, err := get(m, guildID, "joinmsg", func() (v message, err error) {
+		v.channelID, v.msg, err = m.Database.GetGuildJoinMsg(guildID)
+		return
+	})
+	return v.channelID, v.msg, err
+}
+
+func (m *SettingsCacheMiddleware) SetGuildJoinMsg(guildID string, channelID string, msg string) error {
+	return m.invalidateAfter(guildID, m.Database.SetGuildJoinMsg(guildID, channelID, msg))
+}
+
+func (m *SettingsCacheMiddleware) GetGuildLeaveMsg(guildID string) (string, string, error) {
+	v, err := get(m, guildID, "leavemsg", func() (v message, err error) {
+		v.channelID, v.msg, err = m.Database.GetGuildLeaveMsg(guildID)
+		return
+	})
+	return v.channelID, v.msg, err
+}
+
+func (m *SettingsCacheMiddleware) SetGuildLeaveMsg(guildID string, channelID string, msg string) error {
+	return m.invalidateAfter(guildID, m.Database.SetGuildLeaveMsg(guildID, channelID, msg))
+}
+
+func (m *SettingsCacheMiddleware) GetGuildColorReaction(guildID string) (bool, error) {
+	return get(m, guildID, "colorreaction", func() (bool, error) {
+		return m.Database.GetGuildColorReaction(guildID)
+	})
+}
+
+func (m *SettingsCacheMiddleware) SetGuildColorReaction(guildID string, enable bool) error {
+	return m.invalidateAfter(guildID, m.Database.SetGuildColorReaction(guildID, enable))
+}
+
+func (m *SettingsCacheMiddleware) GetGuildLogDisable(guildID string) (bool, error) {
+	return get(m, guildID, "logdisable", func() (bool, error) {
+		return m.Database.GetGuildLogDisable(guildID)
+	})
+}
+
+func (m *SettingsCacheMiddleware) SetGuildLogDisable(guildID string, enabled bool) error {
+	return m.invalidateAfter(guildID, m.Database.SetGuildLogDisable(guildID, enabled))
+}
+
+func (m *SettingsCacheMiddleware) GetGuildConfirmActions(guildID string) ([]models.ConfirmAction, error) {
+	return getSlice(m, guildID, "confirmactions", func() ([]models.ConfirmAction, error) {
+		return m.Database.GetGuildConfirmActions(guildID)
+	})
+}
+
+func (m *SettingsCacheMiddleware) SetGuildConfirmActions(guildID string, actions []models.ConfirmAction) error {
+	return m.invalidateAfter(guildID, m.Database.SetGuildConfirmActions(guildID, actions))
+}
+
+func (m *SettingsCacheMiddleware) GetGuildPermDeniedMessage(guildID string) (string, bool, error) {
+	v, err := get(m, guildID, "permdeniedmsg", func() (v silentMessage, err error) {
+		v.msg, v.silent, err = m.Database.GetGuildPermDeniedMessage(guildID)
+		return
+	})
+	return v.msg, v.silent, err
+}
+
+func (m *SettingsCacheMiddleware) SetGuildPermDeniedMessage(guildID string, msg string, silent bool) error {
+	return m.invalidateAfter(guildID, m.Database.SetGuildPermDeniedMessage(guildID, msg, silent))
+}
+
+func (m *SettingsCacheMiddleware) GetGuildCommandSuggestionsDisable(guildID string) (bool, error) {
+	return get(m, guildID, "commandsuggestionsdisable", func() (bool, error) {
+		return m.Database.GetGuildCommandSuggestionsDisable(guildID)
+	})
+}
+
+func (m *SettingsCacheMiddleware) SetGuildCommandSuggestionsDisable(guildID string, disabled bool) error {
+	return m.invalidateAfter(guildID, m.Database.SetGuildCommandSuggestionsDisable(guildID, disabled))
+}
+
+func (m *SettingsCacheMiddleware) GetPrefixlessChannels(guildID string) ([]string, error) {
+	return getSlice(m, guildID, "prefixlesschannels", func() ([]string, error) {
+		return m.Database.GetPrefixlessChannels(guildID)
+	})
+}
+
+func (m *SettingsCacheMiddleware) AddPrefixlessChannel(guildID, channelID string) error {
+	return m.invalidateAfter(guildID, m.Database.AddPrefixlessChannel(guildID, channelID))
+}
+
+func (m *SettingsCacheMiddleware) RemovePrefixlessChannel(guildID, channelID string) error {
+	return m.invalidateAfter(guildID, m.Database.RemovePrefixlessChannel(guildID, channelID))
+}
+
+func (m *SettingsCacheMiddleware) GetGuildEmbedColor(guildID string) (int, error) {
+	return get(m, guildID, "embedcolor", func() (int, error) {
+		return m.Database.GetGuildEmbedColor(guildID)
+	})
+}
+
+func (m *SettingsCacheMiddleware) SetGuildEmbedColor(guildID string, color int) error {
+	return m.invalidateAfter(guildID, m.Database.SetGuildEmbedColor(guildID, color))
+}
+
+func (m *SettingsCacheMiddleware) GetGuildDisabledCommands(guildID string) ([]string, error) {
+	return getSlice(m, guildID, "disabledcommands", func() ([]string, error) {
+		return m.Database.GetGuildDisabledCommands(guildID)
+	})
+}
+
+func (m *SettingsCacheMiddleware) SetGuildDisabledCommand(guildID, command string, disabled bool) error {
+	return m.invalidateAfter(guildID, m.Database.SetGuildDisabledCommand(guildID, command, disabled))
+}
+
+func (m *SettingsCacheMiddleware) GetGuildDisabledCommandMessage(guildID string) (string, bool, error) {
+	v, err := get(m, guildID, "disabledcommandmsg", func() (v silentMessage, err error) {
+		v.msg, v.silent, err = m.Database.GetGuildDisabledCommandMessage(guildID)
+		return
+	})
+	return v.msg, v.silent, err
+}
+
+func (m *SettingsCacheMiddleware) SetGuildDisabledCommandMessage(guildID string, msg string, silent bool) error {
+	return m.invalidateAfter(guildID, m.Database.SetGuildDisabledCommandMessage(guildID, msg, silent))
+}
+
+func (m *SettingsCacheMiddleware) GetGuildModmailChannel(guildID string) (string, error) {
+	return get(m, guildID, "modmailchannel", func() (string, error) {
+		return m.Database.GetGuildModmailChannel(guildID)
+	})
+}
+
+func (m *SettingsCacheMiddleware) SetGuildModmailChannel(guildID, chanID string) error {
+	return m.invalidateAfter(guildID, m.Database.SetGuildModmailChannel(guildID, chanID))
+}
+
+func (m *SettingsCacheMiddleware) GetGuildAPI(guildID string) (models.GuildAPISettings, error) {
+	return get(m, guildID, "api", func() (models.GuildAPISettings, error) {
+		return m.Database.GetGuildAPI(guildID)
+	})
+}
+
+func (m *SettingsCacheMiddleware) SetGuildAPI(guildID string, settings models.GuildAPISettings) error {
+	return m.invalidateAfter(guildID, m.Database.SetGuildAPI(guildID, settings))
+}
+
+func (m *SettingsCacheMiddleware) GetGuildVerificationRequired(guildID string) (bool, error) {
+	return get(m, guildID, "verificationrequired", func() (bool, error) {
+		return m.Database.GetGuildVerificationRequired(guildID)
+	})
+}
+
+func (m *SettingsCacheMiddleware) SetGuildVerificationRequired(guildID string, enable bool) error {
+	return m.invalidateAfter(guildID, m.Database.SetGuildVerificationRequired(guildID, enable))
+}
+
+func (m *SettingsCacheMiddleware) GetGuildBirthdayChan(guildID string) (string, error) {
+	return get(m, guildID, "birthdaychan", func() (string, error) {
+		return m.Database.GetGuildBirthdayChan(guildID)
+	})
+}
+
+func (m *SettingsCacheMiddleware) SetGuildBirthdayChan(guildID string, chanID string) error {
+	return m.invalidateAfter(guildID, m.Database.SetGuildBirthdayChan(guildID, chanID))
+}
+
+func (m *SettingsCacheMiddleware) GetGuildModNot(guildID string) (string, error) {
+	return get(m, guildID, "modnot", func() (string, error) {
+		return m.Database.GetGuildModNot(guildID)
+	})
+}
+
+func (m *SettingsCacheMiddleware) SetGuildModNot(guildID string, chanID string) error {
+	return m.invalidateAfter(guildID, m.Database.SetGuildModNot(guildID, chanID))
+}
+
+func (m *SettingsCacheMiddleware) GetGuildAnnouncementChannel(guildID string) (string, error) {
+	return get(m, guildID, "announcementchannel", func() (string, error) {
+		return m.Database.GetGuildAnnouncementChannel(guildID)
+	})
+}
+
+func (m *SettingsCacheMiddleware) SetGuildAnnouncementChannel(guildID, chanID string) error {
+	return m.invalidateAfter(guildID, m.Database.SetGuildAnnouncementChannel(guildID, chanID))
+}
+
+func (m *SettingsCacheMiddleware) GetGuildMemberMsgGrace(guildID string) (time.Duration, bool, error) {
+	v, err := get(m, guildID, "membermsggrace", func() (v gracePeriod, err error) {
+		v.period, v.noteRejoin, err = m.Database.GetGuildMemberMsgGrace(guildID)
+		return
+	})
+	return v.period, v.noteRejoin, err
+}
+
+func (m *SettingsCacheMiddleware) SetGuildMemberMsgGrace(guildID string, period time.Duration, noteRejoin bool) error {
+	return m.invalidateAfter(guildID, m.Database.SetGuildMemberMsgGrace(guildID, period, noteRejoin))
+}
+
+func (m *SettingsCacheMiddleware) GetGuildPinRotation(guildID string) (bool, error) {
+	return get(m, guildID, "pinrotation", func() (bool, error) {
+		return m.Database.GetGuildPinRotation(guildID)
+	})
+}
+
+func (m *SettingsCacheMiddleware) SetGuildPinRotation(guildID string, enabled bool) error {
+	return m.invalidateAfter(guildID, m.Database.SetGuildPinRotation(guildID, enabled))
+}
+
+func (m *SettingsCacheMiddleware) GetGuildMessageStatsEnabled(guildID string) (bool, error) {
+	return get(m, guildID, "messagestats", func() (bool, error) {
+		return m.Database.GetGuildMessageStatsEnabled(guildID)
+	})
+}
+
+func (m *SettingsCacheMiddleware) SetGuildMessageStatsEnabled(guildID string, enabled bool) error {
+	return m.invalidateAfter(guildID, m.Database.SetGuildMessageStatsEnabled(guildID, enabled))
+}
+
+func (m *SettingsCacheMiddleware) GetGuildBotNickname(guildID string) (string, error) {
+	return get(m, guildID, "botnickname", func() (string, error) {
+		return m.Database.GetGuildBotNickname(guildID)
+	})
+}
+
+func (m *SettingsCacheMiddleware) SetGuildBotNickname(guildID, nickname string) error {
+	return m.invalidateAfter(guildID, m.Database.SetGuildBotNickname(guildID, nickname))
+}
+
+func (m *SettingsCacheMiddleware) GetInactivitySettings(guildID string) (models.InactivitySettings, error) {
+	return get(m, guildID, "inactivity", func() (models.InactivitySettings, error) {
+		return m.Database.GetInactivitySettings(guildID)
+	})
+}
+
+func (m *SettingsCacheMiddleware) SetInactivitySettings(s models.InactivitySettings) error {
+	return m.invalidateAfter(s.GuildID, m.Database.SetInactivitySettings(s))
+}
+
+func (m *SettingsCacheMiddleware) GetReactionReportConfig(guildID string) (models.ReactionReportConfig, error) {
+	return get(m, guildID, "reactionreport", func() (models.ReactionReportConfig, error) {
+		return m.Database.GetReactionReportConfig(guildID)
+	})
+}
+
+func (m *SettingsCacheMiddleware) SetReactionReportConfig(cfg models.ReactionReportConfig) error {
+	return m.invalidateAfter(cfg.GuildID, m.Database.SetReactionReportConfig(cfg))
+}
+
+func (m *SettingsCacheMiddleware) GetWordFilterSettings(guildID string) (models.WordFilterSettings, error) {
+	s, err := get(m, guildID, "wordfilter", func() (models.WordFilterSettings, error) {
+		return m.Database.GetWordFilterSettings(guildID)
+	})
+	// Copy the slices so that callers can not alter the
+	// cached value.
+	s.ExemptRoles = append([]string(nil), s.ExemptRoles...)
+	s.ExemptChannels = append([]string(nil), s.ExemptChannels...)
+	return s, err
+}
+
+func (m *SettingsCacheMiddleware) SetWordFilterSettings(s models.WordFilterSettings) error {
+	return m.invalidateAfter(s.GuildID, m.Database.SetWordFilterSettings(s))
+}
+
+func (m *SettingsCacheMiddleware) GetWordFilterEntries(guildID string) ([]models.WordFilterEntry, error) {
+	return getSlice(m, guildID, "wordfilterentries", func() ([]models.WordFilterEntry, error) {
+		return m.Database.GetWordFilterEntries(guildID)
+	})
+}
+
+func (m *SettingsCacheMiddleware) SetWordFilterEntry(e models.WordFilterEntry) error {
+	return m.invalidateAfter(e.GuildID, m.Database.SetWordFilterEntry(e))
+}
+
+func (m *SettingsCacheMiddleware) RemoveWordFilterEntry(guildID, pattern string) error {
+	return m.invalidateAfter(guildID, m.Database.RemoveWordFilterEntry(guildID, pattern))
+}
+
+// Global settings are cached in the entry of the
+// empty guild ID.
+
+func (m *SettingsCacheMiddleware) GetSetting(setting string) (string, error) {
+	return get(m, "", "setting."+setting, func() (string, error) {
+		return m.Database.GetSetting(setting)
+	})
+}
+
+func (m *SettingsCacheMiddleware) SetSetting(setting, value string) error {
+	return m.invalidateAfter("", m.Database.SetSetting(setting, value))
+}
+
+func (m *SettingsCacheMiddleware) FlushGuildData(guildID string) error {
+	return m.invalidateAfter(guildID, m.Database.FlushGuildData(guildID))
+}
@@ -0,0 +1,271 @@
+package settingscache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/services/database/memory"
+	"github.com/zekroTJA/shinpuru/internal/services/kvcache"
+)
+
+type countingDatabase struct {
+	*memory.MemoryMiddleware
+
+	prefixReads   int
+	autoRoleReads int
+	ignoreReads   int
+	settingReads  int
+	filterReads   int
+	channelReads  int
+	reportReads   int
+}
+
+func (d *countingDatabase) GetGuildPrefix(guildID string) (string, error) {
+	d.prefixReads++
+	return d.MemoryMiddleware.GetGuildPrefix(guildID)
+}
+
+func (d *countingDatabase) GetGuildAutoRole(guildID string) ([]string, error) {
+	d.autoRoleReads++
+	return d.MemoryMiddleware.GetGuildAutoRole(guildID)
+}
+
+func (d *countingDatabase) GetGuildVoiceLogIgnores(guildID string) ([]string, error) {
+	d.ignoreReads++
+	return d.MemoryMiddleware.GetGuildVoiceLogIgnores(guildID)
+}
+
+func (d *countingDatabase) GetSetting(setting string) (string, error) {
+	d.settingReads++
+	return d.MemoryMiddleware.GetSetting(setting)
+}
+
+func (d *countingDatabase) GetWordFilterSettings(guildID string) (models.WordFilterSettings, error) {
+	d.filterReads++
+	return d.MemoryMiddleware.GetWordFilterSettings(guildID)
+}
+
+func (d *countingDatabase) GetWordFilterEntries(guildID string) ([]models.WordFilterEntry, error) {
+	d.filterReads++
+	return d.MemoryMiddleware.GetWordFilterEntries(guildID)
+}
+
+func (d *countingDatabase) GetPrefixlessChannels(guildID string) ([]string, error) {
+	d.channelReads++
+	return d.MemoryMiddleware.GetPrefixlessChannels(guildID)
+}
+
+func (d *countingDatabase) GetReactionReportConfig(guildID string) (models.ReactionReportConfig, error) {
+	d.reportReads++
+	return d.MemoryMiddleware.GetReactionReportConfig(guildID)
+}
+
+func getMiddleware() (*SettingsCacheMiddleware, *countingDatabase) {
+	db := &countingDatabase{MemoryMiddleware: memory.New()}
+	return New(db, kvcache.NewTimedmapCache(time.Minute)), db
+}
+
+func TestGetCached(t *testing.T) {
+	m, db := getMiddleware()
+
+	assert.Nil(t, db.SetGuildPrefix("guild", "!"))
+
+	for i := 0; i < 3; i++ {
+		prefix, err := m.GetGuildPrefix("guild")
+		assert.Nil(t, err)
+		assert.Equal(t, "!", prefix)
+	}
+	assert.Equal(t, 1, db.prefixReads)
+
+	// Other guilds are cached separately
+	_, err := m.GetGuildPrefix("other")
+	assert.ErrorIs(t, err, database.ErrDatabaseNotFound)
+	assert.Equal(t, 2, db.prefixReads)
+
+	// Not found errors are cached as well
+	_, err = m.GetGuildPrefix("other")
+	assert.ErrorIs(t, err, database.ErrDatabaseNotFound)
+	assert.Equal(t, 2, db.prefixReads)
+}
+
+func TestSetInvalidates(t *testing.T) {
+	m, db := getMiddleware()
+
+	assert.Nil(t, m.SetGuildPrefix("guild", "!"))
+	assert.Nil(t, m.SetGuildPrefix("other", "?"))
+	_, err := m.GetGuildPrefix("guild")
+	assert.Nil(t, err)
+	_, err = m.GetGuildPrefix("other")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, db.prefixReads)
+
+	assert.Nil(t, m.SetGuildPrefix("guild", "$"))
+	prefix, err := m.GetGuildPrefix("guild")
+	assert.Nil(t, err)
+	assert.Equal(t, "$", prefix)
+	assert.Equal(t, 3, db.prefixReads)
+
+	// Writing any setting invalidates all settings of the guild
+	assert.Nil(t, m.SetGuildModLog("guild", "chan"))
+	_, err = m.GetGuildPrefix("guild")
+	assert.Nil(t, err)
+	assert.Equal(t, 4, db.prefixReads)
+
+	// ... but not the settings of other guilds
+	prefix, err = m.GetGuildPrefix("other")
+	assert.Nil(t, err)
+	assert.Equal(t, "?", prefix)
+	assert.Equal(t, 4, db.prefixReads)
+}
+
+func TestInvalidatedEntryIsNotFilled(t *testing.T) {
+	m, db := getMiddleware()
+
+	assert.Nil(t, db.SetGuildPrefix("guild", "!"))
+
+	// Simulates a read which has started before an update
+	// of the settings and finishes after the update.
+	prefix, err := get(m, "guild", "prefix", func() (string, error) {
+		v, err := db.GetGuildPrefix("guild")
+		assert.Nil(t, m.SetGuildPrefix("guild", "$"))
+		return v, err
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "!", prefix)
+
+	prefix, err = m.GetGuildPrefix("guild")
+	assert.Nil(t, err)
+	assert.Equal(t, "$", prefix)
+}
+
+func TestGetSliceCopies(t *testing.T) {
+	m, db := getMiddleware()
+
+	assert.Nil(t, m.SetGuildAutoRole("guild", []string{"a", "b"}))
+
+	roles, err := m.GetGuildAutoRole("guild")
+	assert.Nil(t, err)
+	roles[0] = "c"
+
+	roles, err = m.GetGuildAutoRole("guild")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a", "b"}, roles)
+	assert.Equal(t, 1, db.autoRoleReads)
+}
+
+func TestWordFilterCached(t *testing.T) {
+	m, db := getMiddleware()
+
+	assert.Nil(t, m.SetWordFilterSettings(models.WordFilterSettings{
+		GuildID:     "guild",
+		Enabled:     true,
+		ExemptRoles: []string{"a", "b"},
+	}))
+	assert.Nil(t, m.SetWordFilterEntry(models.WordFilterEntry{
+		GuildID: "guild",
+		Pattern: "foo",
+	}))
+
+	for i := 0; i < 3; i++ {
+		s, err := m.GetWordFilterSettings("guild")
+		assert.Nil(t, err)
+		s.ExemptRoles[0] = "c"
+		entries, err := m.GetWordFilterEntries("guild")
+		assert.Nil(t, err)
+		assert.Len(t, entries, 1)
+	}
+	assert.Equal(t, 2, db.filterReads)
+
+	s, err := m.GetWordFilterSettings("guild")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a", "b"}, s.ExemptRoles)
+
+	assert.Nil(t, m.RemoveWordFilterEntry("guild", "foo"))
+	entries, err := m.GetWordFilterEntries("guild")
+	assert.Nil(t, err)
+	assert.Empty(t, entries)
+	assert.Equal(t, 3, db.filterReads)
+}
+
+func TestIsGuildVoiceLogIgnored(t *testing.T) {
+	m, db := getMiddleware()
+
+	ok, err := m.IsGuildVoiceLogIgnored("guild", "chan")
+	assert.Nil(t, err)
+	assert.False(t, ok)
+
+	assert.Nil(t, m.SetGuildVoiceLogIngore("guild", "chan"))
+	ok, err = m.IsGuildVoiceLogIgnored("guild", "chan")
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	ignores, err := m.GetGuildVoiceLogIgnores("guild")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"chan"}, ignores)
+	assert.Equal(t, 2, db.ignoreReads)
+
+	assert.Nil(t, m.RemoveGuildVoiceLogIgnore("guild", "chan"))
+	ok, err = m.IsGuildVoiceLogIgnored("guild", "chan")
+	assert.Nil(t, err)
+	assert.False(t, ok)
+}
+
+func TestGlobalSettingsCached(t *testing.T) {
+	m, db := getMiddleware()
+
+	assert.Nil(t, m.SetSetting("setting", "a"))
+	for i := 0; i < 3; i++ {
+		v, err := m.GetSetting("setting")
+		assert.Nil(t, err)
+		assert.Equal(t, "a", v)
+	}
+	assert.Equal(t, 1, db.settingReads)
+
+	assert.Nil(t, m.SetSetting("setting", "b"))
+	v, err := m.GetSetting("setting")
+	assert.Nil(t, err)
+	assert.Equal(t, "b", v)
+	assert.Equal(t, 2, db.settingReads)
+}
+
+func TestPrefixlessChannelsCached(t *testing.T) {
+	m, db := getMiddleware()
+
+	assert.Nil(t, m.AddPrefixlessChannel("guild", "a"))
+
+	for i := 0; i < 3; i++ {
+		channels, err := m.GetPrefixlessChannels("guild")
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"a"}, channels)
+	}
+	assert.Equal(t, 1, db.channelReads)
+
+	assert.Nil(t, m.RemovePrefixlessChannel("guild", "a"))
+	channels, err := m.GetPrefixlessChannels("guild")
+	assert.Nil(t, err)
+	assert.Empty(t, channels)
+	assert.Equal(t, 2, db.channelReads)
+}
+
+func TestReactionReportConfigCached(t *testing.T) {
+	m, db := getMiddleware()
+
+	for i := 0; i < 3; i++ {
+		_, err := m.GetReactionReportConfig("guild")
+		assert.True(t, database.IsErrDatabaseNotFound(err))
+	}
+	assert.Equal(t, 1, db.reportReads)
+
+	assert.Nil(t, m.SetReactionReportConfig(models.ReactionReportConfig{
+		GuildID:   "guild",
+		ChannelID: "channel",
+		Emoji:     "🚩",
+	}))
+	cfg, err := m.GetReactionReportConfig("guild")
+	assert.Nil(t, err)
+	assert.Equal(t, "channel", cfg.ChannelID)
+	assert.Equal(t, 2, db.reportReads)
+}
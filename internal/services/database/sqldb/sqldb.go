@@ -0,0 +1,155 @@
+// Package sqldb provides a thin wrapper around *sql.DB
+// which optionally caches prepared statements and
+// instruments the duration of executed queries.
+package sqldb
+
+import (
+	"database/sql"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/zekroTJA/shinpuru/internal/services/metrics"
+	"github.com/zekrotja/rogu"
+)
+
+// Options specifies the behavior of the DB wrapper.
+type Options struct {
+	// CacheStatements enables caching prepared statements
+	// by their query string. Values must therefore always
+	// be passed as arguments and never be formatted into
+	// the query, otherwise each value gets its own cached
+	// statement.
+	CacheStatements bool
+	// MaxCachedStatements limits the number of cached
+	// statements. Queries are executed without caching
+	// when the limit is reached. No limit is applied if
+	// it is 0.
+	MaxCachedStatements int
+	// Instrument enables recording the durations of
+	// queries and logging slow queries.
+	Instrument bool
+	// SlowQueryThreshold is the duration after which a
+	// query is logged as slow. Slow queries are not
+	// logged if it is 0.
+	SlowQueryThreshold time.Duration
+}
+
+// DB wraps a *sql.DB and overrides Exec, Query and
+// QueryRow to use cached prepared statements and to
+// instrument the queries as specified in the Options.
+//
+// Transactions started with Begin are not affected.
+type DB struct {
+	*sql.DB
+
+	opts Options
+	log  rogu.Logger
+
+	stmts  sync.Map
+	nStmts int32
+}
+
+// New wraps the given database with the given options.
+// Slow queries are logged to the passed logger.
+func New(db *sql.DB, opts Options, log rogu.Logger) *DB {
+	return &DB{
+		DB:   db,
+		opts: opts,
+		log:  log,
+	}
+}
+
+func (d *DB) Exec(query string, args ...interface{}) (res sql.Result, err error) {
+	defer d.observe("exec", query, time.Now())
+
+	if stmt := d.stmt(query); stmt != nil {
+		return stmt.Exec(args...)
+	}
+	return d.DB.Exec(query, args...)
+}
+
+func (d *DB) Query(query string, args ...interface{}) (rows *sql.Rows, err error) {
+	defer d.observe("query", query, time.Now())
+
+	if stmt := d.stmt(query); stmt != nil {
+		return stmt.Query(args...)
+	}
+	return d.DB.Query(query, args...)
+}
+
+// QueryRow executes a query which is expected to return
+// at most one row. Because the row is fetched lazily on
+// Scan, the recorded duration only covers executing the
+// query.
+func (d *DB) QueryRow(query string, args ...interface{}) *sql.Row {
+	defer d.observe("queryrow", query, time.Now())
+
+	if stmt := d.stmt(query); stmt != nil {
+		return stmt.QueryRow(args...)
+	}
+	return d.DB.QueryRow(query, args...)
+}
+
+// Close closes all cached statements and the database.
+func (d *DB) Close() error {
+	d.stmts.Range(func(key, value any) bool {
+		value.(*sql.Stmt).Close()
+		d.stmts.Delete(key)
+		return true
+	})
+	atomic.StoreInt32(&d.nStmts, 0)
+	return d.DB.Close()
+}
+
+// stmt returns the cached prepared statement for the
+// given query and prepares it if it is not cached yet.
+// If caching is disabled, the limit of cached statements
+// is reached or preparing fails, nil is returned and the
+// query should be executed directly.
+func (d *DB) stmt(query string) *sql.Stmt {
+	if !d.opts.CacheStatements {
+		return nil
+	}
+
+	if v, ok := d.stmts.Load(query); ok {
+		return v.(*sql.Stmt)
+	}
+
+	if d.opts.MaxCachedStatements > 0 &&
+		int(atomic.LoadInt32(&d.nStmts)) >= d.opts.MaxCachedStatements {
+		return nil
+	}
+
+	stmt, err := d.DB.Prepare(query)
+	if err != nil {
+		return nil
+	}
+
+	if v, loaded := d.stmts.LoadOrStore(query, stmt); loaded {
+		// The same statement has been prepared concurrently
+		// in the meantime, so the cached one is used.
+		stmt.Close()
+		return v.(*sql.Stmt)
+	}
+
+	atomic.AddInt32(&d.nStmts, 1)
+	return stmt
+}
+
+func (d *DB) observe(operation, query string, start time.Time) {
+	if !d.opts.Instrument {
+		return
+	}
+
+	took := time.Since(start)
+	metrics.DatabaseQueryTimes.WithLabelValues(operation).Observe(took.Seconds())
+
+	if d.opts.SlowQueryThreshold > 0 && took >= d.opts.SlowQueryThreshold {
+		d.log.Warn().
+			Field("operation", operation).
+			Field("duration", took.String()).
+			Field("query", query).
+			Msg("Slow database query")
+	}
+}
@@ -0,0 +1,165 @@
+package sqldb
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zekrotja/rogu"
+)
+
+// fakeDriver is a database driver which executes every
+// query by sleeping for the duration passed as first
+// argument and counts the prepared statements.
+type fakeDriver struct {
+	prepared int32
+}
+
+func (d *fakeDriver) Open(name string) (driver.Conn, error) {
+	return &fakeConn{d}, nil
+}
+
+type fakeConn struct {
+	d *fakeDriver
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	atomic.AddInt32(&c.d.prepared, 1)
+	return fakeStmt{}, nil
+}
+
+func (c *fakeConn) Close() error {
+	return nil
+}
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return nil, driver.ErrSkip
+}
+
+type fakeStmt struct{}
+
+func (s fakeStmt) Close() error {
+	return nil
+}
+
+func (s fakeStmt) NumInput() int {
+	return 1
+}
+
+func (s fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	time.Sleep(time.Duration(args[0].(int64)))
+	return driver.RowsAffected(1), nil
+}
+
+func (s fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	time.Sleep(time.Duration(args[0].(int64)))
+	return fakeRows{}, nil
+}
+
+type fakeRows struct{}
+
+func (r fakeRows) Columns() []string {
+	return []string{}
+}
+
+func (r fakeRows) Close() error {
+	return nil
+}
+
+func (r fakeRows) Next(dest []driver.Value) error {
+	return io.EOF
+}
+
+var nDrivers int32
+
+func newTestDB(t *testing.T, opts Options) (*DB, *fakeDriver, *bytes.Buffer) {
+	drv := &fakeDriver{}
+	name := "fake" + string(rune('a'+atomic.AddInt32(&nDrivers, 1)))
+	sql.Register(name, drv)
+
+	sqlDb, err := sql.Open(name, "")
+	assert.Nil(t, err)
+
+	var buf bytes.Buffer
+	log := rogu.NewLogger(rogu.NewJsonWriter(&buf))
+
+	db := New(sqlDb, opts, log)
+	t.Cleanup(func() { db.Close() })
+
+	return db, drv, &buf
+}
+
+func TestSlowQueryLog(t *testing.T) {
+	db, _, buf := newTestDB(t, Options{
+		Instrument:         true,
+		SlowQueryThreshold: 20 * time.Millisecond,
+	})
+
+	_, err := db.Exec("UPDATE fast SET value = ?", int64(0))
+	assert.Nil(t, err)
+	assert.Empty(t, buf.String())
+
+	_, err = db.Exec("UPDATE slow SET value = ?", int64(30*time.Millisecond))
+	assert.Nil(t, err)
+	assert.Contains(t, buf.String(), "Slow database query")
+	assert.Contains(t, buf.String(), "UPDATE slow SET value = ?")
+	assert.NotContains(t, buf.String(), "fast")
+	assert.Equal(t, 1, strings.Count(buf.String(), "\n"))
+
+	buf.Reset()
+	rows, err := db.Query("SELECT slow FROM test WHERE value = ?", int64(30*time.Millisecond))
+	assert.Nil(t, err)
+	rows.Close()
+	assert.Contains(t, buf.String(), "SELECT slow FROM test WHERE value = ?")
+}
+
+func TestSlowQueryLogDisabled(t *testing.T) {
+	// Instrumentation disabled.
+	db, _, buf := newTestDB(t, Options{
+		SlowQueryThreshold: time.Millisecond,
+	})
+
+	_, err := db.Exec("UPDATE slow SET value = ?", int64(5*time.Millisecond))
+	assert.Nil(t, err)
+	assert.Empty(t, buf.String())
+
+	// No threshold.
+	db, _, buf = newTestDB(t, Options{
+		Instrument: true,
+	})
+
+	_, err = db.Exec("UPDATE slow SET value = ?", int64(5*time.Millisecond))
+	assert.Nil(t, err)
+	assert.Empty(t, buf.String())
+}
+
+func TestCacheStatements(t *testing.T) {
+	db, drv, _ := newTestDB(t, Options{
+		CacheStatements:     true,
+		MaxCachedStatements: 2,
+	})
+
+	for i := 0; i < 3; i++ {
+		_, err := db.Exec("UPDATE a SET value = ?", int64(0))
+		assert.Nil(t, err)
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&drv.prepared))
+
+	_, err := db.Exec("UPDATE b SET value = ?", int64(0))
+	assert.Nil(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&drv.prepared))
+
+	// The limit is reached, so further queries are
+	// prepared for each execution.
+	for i := 0; i < 2; i++ {
+		_, err := db.Exec("UPDATE c SET value = ?", int64(0))
+		assert.Nil(t, err)
+	}
+	assert.Equal(t, int32(4), atomic.LoadInt32(&drv.prepared))
+}
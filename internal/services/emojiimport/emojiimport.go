@@ -0,0 +1,269 @@
+// Package emojiimport provides a service to copy the
+// custom emojis of one guild into another guild.
+package emojiimport
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/sarulabs/di/v2"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/pkg/discordutil"
+)
+
+// maxNameLen is the maximum length of emoji names.
+const maxNameLen = 32
+
+var ErrSameGuild = errors.New("source and target guild must differ")
+
+// Strategy specifies how emojis are handled which have
+// the same name as an emoji in the target guild.
+type Strategy string
+
+const (
+	// StrategySkip does not import the emoji.
+	StrategySkip Strategy = "skip"
+	// StrategyRename imports the emoji with a numeric
+	// suffix appended to its name.
+	StrategyRename Strategy = "rename"
+	// StrategyReplace deletes the existing emoji in the
+	// target guild before importing the emoji.
+	StrategyReplace Strategy = "replace"
+)
+
+// Status describes the outcome of importing a
+// single emoji.
+type Status string
+
+const (
+	StatusImported         Status = "imported"
+	StatusRenamed          Status = "renamed"
+	StatusReplaced         Status = "replaced"
+	StatusSkippedCollision Status = "skipped_collision"
+	StatusSkippedLimit     Status = "skipped_limit"
+	StatusFailed           Status = "failed"
+)
+
+// Entry holds the outcome of importing a single emoji.
+type Entry struct {
+	Name     string
+	NewName  string
+	Animated bool
+	Status   Status
+	Err      error
+}
+
+// Result holds the outcomes of all imported emojis.
+type Result struct {
+	Entries []Entry
+}
+
+// Count returns the number of entries with the
+// given status.
+func (r Result) Count(status Status) (n int) {
+	for _, e := range r.Entries {
+		if e.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+type Importer struct {
+	s discordutil.ISession
+
+	download func(url string) ([]byte, error)
+}
+
+func New(ctn di.Container) *Importer {
+	return &Importer{
+		s:        ctn.Get(static.DiDiscordSession).(discordutil.ISession),
+		download: httpDownload,
+	}
+}
+
+// Import copies all custom emojis of the source guild
+// into the target guild. Emojis which would exceed the
+// emoji slots of the target guild are skipped. Name
+// collisions are handled according to the passed
+// strategy.
+//
+// Failing to import a single emoji does not abort the
+// import. The outcome of each emoji is reported in the
+// returned result.
+func (imp *Importer) Import(sourceGuildID, targetGuildID string, strategy Strategy) (res Result, err error) {
+	if sourceGuildID == targetGuildID {
+		err = ErrSameGuild
+		return
+	}
+
+	srcEmojis, err := imp.s.GuildEmojis(sourceGuildID)
+	if err != nil {
+		return
+	}
+
+	target, err := imp.s.Guild(targetGuildID)
+	if err != nil {
+		return
+	}
+
+	dstEmojis, err := imp.s.GuildEmojis(targetGuildID)
+	if err != nil {
+		return
+	}
+
+	existing := make(map[string]*discordgo.Emoji, len(dstEmojis))
+	var nStatic, nAnimated int
+	for _, e := range dstEmojis {
+		existing[e.Name] = e
+		if e.Animated {
+			nAnimated++
+		} else {
+			nStatic++
+		}
+	}
+
+	limit := EmojiLimit(target.PremiumTier)
+
+	res.Entries = make([]Entry, 0, len(srcEmojis))
+	for _, e := range srcEmojis {
+		entry := Entry{
+			Name:     e.Name,
+			NewName:  e.Name,
+			Animated: e.Animated,
+			Status:   StatusImported,
+		}
+
+		count := &nStatic
+		if e.Animated {
+			count = &nAnimated
+		}
+
+		var replace *discordgo.Emoji
+		if curr, ok := existing[e.Name]; ok {
+			switch strategy {
+			case StrategyRename:
+				entry.NewName = uniqueName(e.Name, existing)
+				entry.Status = StatusRenamed
+			case StrategyReplace:
+				replace = curr
+				entry.Status = StatusReplaced
+			default:
+				entry.Status = StatusSkippedCollision
+				res.Entries = append(res.Entries, entry)
+				continue
+			}
+		}
+
+		// Replacing an emoji of the same type frees up the
+		// slot used for the imported emoji.
+		freesSlot := replace != nil && replace.Animated == e.Animated
+		if !freesSlot && *count >= limit {
+			entry.Status = StatusSkippedLimit
+			res.Entries = append(res.Entries, entry)
+			continue
+		}
+
+		if err := imp.importEmoji(targetGuildID, e, entry.NewName, replace); err != nil {
+			entry.Status = StatusFailed
+			entry.Err = err
+			res.Entries = append(res.Entries, entry)
+			continue
+		}
+
+		if replace != nil {
+			if replace.Animated {
+				nAnimated--
+			} else {
+				nStatic--
+			}
+		}
+		*count++
+		existing[entry.NewName] = e
+
+		res.Entries = append(res.Entries, entry)
+	}
+
+	return res, nil
+}
+
+func (imp *Importer) importEmoji(guildID string, e *discordgo.Emoji, name string, replace *discordgo.Emoji) error {
+	url := discordgo.EndpointEmoji(e.ID)
+	mimeType := "image/png"
+	if e.Animated {
+		url = discordgo.EndpointEmojiAnimated(e.ID)
+		mimeType = "image/gif"
+	}
+
+	data, err := imp.download(url)
+	if err != nil {
+		return err
+	}
+
+	// Envelope the base64 data into data uri format
+	dataUri := fmt.Sprintf("data:%s;base64,%s",
+		mimeType, base64.StdEncoding.EncodeToString(data))
+
+	if replace != nil {
+		if err = imp.s.GuildEmojiDelete(guildID, replace.ID); err != nil {
+			return err
+		}
+	}
+
+	_, err = imp.s.GuildEmojiCreate(guildID, &discordgo.EmojiParams{
+		Name:  name,
+		Image: dataUri,
+	})
+	return err
+}
+
+// EmojiLimit returns the number of emoji slots available
+// for each, static and animated emojis, in a guild with
+// the given premium tier.
+func EmojiLimit(tier discordgo.PremiumTier) int {
+	switch tier {
+	case discordgo.PremiumTier1:
+		return 100
+	case discordgo.PremiumTier2:
+		return 150
+	case discordgo.PremiumTier3:
+		return 250
+	default:
+		return 50
+	}
+}
+
+// uniqueName appends the lowest numeric suffix starting
+// from 2 to name which results in a name not contained
+// in existing.
+func uniqueName(name string, existing map[string]*discordgo.Emoji) string {
+	for i := 2; ; i++ {
+		suffix := "_" + strconv.Itoa(i)
+		base := name
+		if len(base)+len(suffix) > maxNameLen {
+			base = base[:maxNameLen-len(suffix)]
+		}
+		if _, ok := existing[base+suffix]; !ok {
+			return base + suffix
+		}
+	}
+}
+
+func httpDownload(url string) ([]byte, error) {
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("downloading emoji failed: %s", resp.Status)
+	}
+
+	return io.ReadAll(resp.Body)
+}
@@ -0,0 +1,146 @@
+package emojiimport
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/zekroTJA/shinpuru/mocks"
+)
+
+func getImporter(
+	src, dst []*discordgo.Emoji,
+	tier discordgo.PremiumTier,
+	f ...func(s *mocks.ISession),
+) (*Importer, *mocks.ISession) {
+	s := &mocks.ISession{}
+	s.On("GuildEmojis", "src").Return(src, nil)
+	s.On("GuildEmojis", "dst").Return(dst, nil)
+	s.On("Guild", "dst").Return(&discordgo.Guild{ID: "dst", PremiumTier: tier}, nil)
+	if len(f) != 0 {
+		f[0](s)
+	}
+	s.On("GuildEmojiCreate", "dst", mock.Anything).Return(&discordgo.Emoji{}, nil)
+	s.On("GuildEmojiDelete", "dst", mock.Anything).Return(nil)
+
+	imp := &Importer{
+		s: s,
+		download: func(url string) ([]byte, error) {
+			return []byte(url), nil
+		},
+	}
+
+	return imp, s
+}
+
+func emojis(animated bool, names ...string) []*discordgo.Emoji {
+	res := make([]*discordgo.Emoji, len(names))
+	for i, name := range names {
+		res[i] = &discordgo.Emoji{ID: "id-" + name, Name: name, Animated: animated}
+	}
+	return res
+}
+
+func statuses(res Result) []Status {
+	s := make([]Status, len(res.Entries))
+	for i, e := range res.Entries {
+		s[i] = e.Status
+	}
+	return s
+}
+
+func TestImport(t *testing.T) {
+	src := append(emojis(false, "a", "b"), emojis(true, "c")...)
+	imp, s := getImporter(src, emojis(false, "x"), discordgo.PremiumTierNone)
+
+	res, err := imp.Import("src", "dst", StrategySkip)
+	assert.Nil(t, err)
+	assert.Equal(t, []Status{StatusImported, StatusImported, StatusImported}, statuses(res))
+	assert.Equal(t, 3, res.Count(StatusImported))
+
+	s.AssertCalled(t, "GuildEmojiCreate", "dst", &discordgo.EmojiParams{
+		Name:  "a",
+		Image: "data:image/png;base64,aHR0cHM6Ly9jZG4uZGlzY29yZGFwcC5jb20vZW1vamlzL2lkLWEucG5n",
+	})
+	s.AssertCalled(t, "GuildEmojiCreate", "dst", mock.MatchedBy(func(p *discordgo.EmojiParams) bool {
+		return p.Name == "c" && strings.HasPrefix(p.Image, "data:image/gif;base64,")
+	}))
+
+	_, err = imp.Import("dst", "dst", StrategySkip)
+	assert.ErrorIs(t, err, ErrSameGuild)
+}
+
+func TestImportCollisions(t *testing.T) {
+	src := emojis(false, "a", "b")
+	dst := emojis(false, "a", "a_2")
+
+	// Skip
+	imp, s := getImporter(src, dst, discordgo.PremiumTierNone)
+	res, err := imp.Import("src", "dst", StrategySkip)
+	assert.Nil(t, err)
+	assert.Equal(t, []Status{StatusSkippedCollision, StatusImported}, statuses(res))
+	s.AssertNumberOfCalls(t, "GuildEmojiCreate", 1)
+
+	// Rename
+	imp, s = getImporter(src, dst, discordgo.PremiumTierNone)
+	res, err = imp.Import("src", "dst", StrategyRename)
+	assert.Nil(t, err)
+	assert.Equal(t, []Status{StatusRenamed, StatusImported}, statuses(res))
+	assert.Equal(t, "a_3", res.Entries[0].NewName)
+	s.AssertCalled(t, "GuildEmojiCreate", "dst", mock.MatchedBy(func(p *discordgo.EmojiParams) bool {
+		return p.Name == "a_3"
+	}))
+
+	// Replace
+	imp, s = getImporter(src, dst, discordgo.PremiumTierNone)
+	res, err = imp.Import("src", "dst", StrategyReplace)
+	assert.Nil(t, err)
+	assert.Equal(t, []Status{StatusReplaced, StatusImported}, statuses(res))
+	s.AssertCalled(t, "GuildEmojiDelete", "dst", "id-a")
+	s.AssertNumberOfCalls(t, "GuildEmojiDelete", 1)
+}
+
+func TestImportLimit(t *testing.T) {
+	dst := make([]string, 0, 99)
+	for i := 0; i < 99; i++ {
+		dst = append(dst, fmt.Sprintf("e%d", i))
+	}
+
+	src := append(emojis(false, "a", "b", "e0"), emojis(true, "c")...)
+
+	imp, _ := getImporter(src, emojis(false, dst...), discordgo.PremiumTier1)
+	res, err := imp.Import("src", "dst", StrategyReplace)
+	assert.Nil(t, err)
+	// Replacing an existing emoji does not require a free
+	// slot and animated emojis have separate slots.
+	assert.Equal(t, []Status{StatusImported, StatusSkippedLimit, StatusReplaced, StatusImported}, statuses(res))
+}
+
+func TestImportFailed(t *testing.T) {
+	errCreate := errors.New("missing permissions")
+	imp, _ := getImporter(emojis(false, "a", "b"), nil, discordgo.PremiumTierNone, func(s *mocks.ISession) {
+		s.On("GuildEmojiCreate", "dst", mock.MatchedBy(func(p *discordgo.EmojiParams) bool {
+			return p.Name == "a"
+		})).Return(nil, errCreate)
+	})
+
+	res, err := imp.Import("src", "dst", StrategySkip)
+	assert.Nil(t, err)
+	assert.Equal(t, []Status{StatusFailed, StatusImported}, statuses(res))
+	assert.ErrorIs(t, res.Entries[0].Err, errCreate)
+}
+
+func TestUniqueName(t *testing.T) {
+	existing := map[string]*discordgo.Emoji{
+		"a_2":                          nil,
+		strings.Repeat("x", 30) + "_2": nil,
+	}
+
+	assert.Equal(t, "a_3", uniqueName("a", existing))
+	assert.Equal(t, "b_2", uniqueName("b", existing))
+	assert.Equal(t, strings.Repeat("x", 30)+"_3", uniqueName(strings.Repeat("x", 32), existing))
+}
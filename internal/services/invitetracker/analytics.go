@@ -0,0 +1,131 @@
+package invitetracker
+
+import (
+	"sort"
+
+	"github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+)
+
+// Analytics returns the join statistics of all invites
+// of the given guild together with the leaderboard of
+// the users who created them.
+//
+// Invites which have expired or have been deleted are
+// contained with their recorded joins and marked as
+// inactive.
+func (t *InviteTracker) Analytics(guildID string) (a models.InviteAnalytics, err error) {
+	stats, err := t.db.GetInviteJoinStats(guildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return
+	}
+
+	tracked, err := t.db.GetTrackedInvites(guildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return
+	}
+
+	curr, err := t.fetch(guildID)
+	if err != nil {
+		return
+	}
+
+	return buildAnalytics(stats, tracked, curr), nil
+}
+
+// buildAnalytics merges the recorded join statistics
+// with the tracked and currently existing invites, so
+// that invites without any joins are listed as well.
+func buildAnalytics(
+	stats []models.InviteJoinStats,
+	tracked []models.TrackedInvite,
+	curr snapshot,
+) (a models.InviteAnalytics) {
+	a.Invites = make([]models.InviteAnalyticsEntry, 0, len(stats)+len(curr))
+	listed := make(map[string]struct{})
+
+	for _, s := range stats {
+		a.TotalJoins += s.Joins
+		_, active := curr[s.Code]
+		if s.Source == models.InviteJoinSourceUnknown {
+			active = false
+		} else {
+			listed[s.Code] = struct{}{}
+		}
+		a.Invites = append(a.Invites, models.InviteAnalyticsEntry{
+			InviteJoinStats: s,
+			Active:          active,
+		})
+	}
+
+	for _, inv := range tracked {
+		if _, ok := listed[inv.Code]; ok {
+			continue
+		}
+		listed[inv.Code] = struct{}{}
+		_, active := curr[inv.Code]
+		a.Invites = append(a.Invites, models.InviteAnalyticsEntry{
+			InviteJoinStats: models.InviteJoinStats{
+				Code:      inv.Code,
+				Source:    models.InviteJoinSourceInvite,
+				Label:     inv.Label,
+				CreatorID: inv.CreatorID,
+			},
+			Active: active,
+		})
+	}
+
+	for code, state := range curr {
+		if _, ok := listed[code]; ok {
+			continue
+		}
+		source := models.InviteJoinSourceInvite
+		if state.Vanity {
+			source = models.InviteJoinSourceVanity
+		}
+		a.Invites = append(a.Invites, models.InviteAnalyticsEntry{
+			InviteJoinStats: models.InviteJoinStats{
+				Code:      code,
+				Source:    source,
+				InviterID: state.InviterID,
+			},
+			Active: true,
+		})
+	}
+
+	sort.SliceStable(a.Invites, func(i, j int) bool {
+		if a.Invites[i].Joins != a.Invites[j].Joins {
+			return a.Invites[i].Joins > a.Invites[j].Joins
+		}
+		return a.Invites[i].Code < a.Invites[j].Code
+	})
+
+	inviters := make(map[string]*models.InviterStats)
+	a.Inviters = make([]models.InviterStats, 0)
+	for _, e := range a.Invites {
+		userID := e.Inviter()
+		if userID == "" {
+			continue
+		}
+		is, ok := inviters[userID]
+		if !ok {
+			is = &models.InviterStats{UserID: userID}
+			inviters[userID] = is
+		}
+		is.Codes = append(is.Codes, e.Code)
+		is.Joins += e.Joins
+	}
+
+	for _, is := range inviters {
+		a.Inviters = append(a.Inviters, *is)
+	}
+
+	sort.Slice(a.Inviters, func(i, j int) bool {
+		if a.Inviters[i].Joins != a.Inviters[j].Joins {
+			return a.Inviters[i].Joins > a.Inviters[j].Joins
+		}
+		return a.Inviters[i].UserID < a.Inviters[j].UserID
+	})
+
+	return
+}
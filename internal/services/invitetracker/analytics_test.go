@@ -0,0 +1,58 @@
+package invitetracker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zekroTJA/shinpuru/internal/models"
+)
+
+func TestBuildAnalytics(t *testing.T) {
+	stats := []models.InviteJoinStats{
+		{Code: "a", Source: models.InviteJoinSourceInvite, CreatorID: "mod-1", Label: "twitter", Joins: 5},
+		{Code: "expired", Source: models.InviteJoinSourceInvite, InviterID: "user-1", Joins: 3},
+		{Code: "", Source: models.InviteJoinSourceUnknown, Joins: 2},
+		{Code: "vanity", Source: models.InviteJoinSourceVanity, Joins: 1},
+	}
+	tracked := []models.TrackedInvite{
+		{Code: "a", CreatorID: "mod-1", Label: "twitter"},
+		{Code: "b", CreatorID: "mod-1", Label: "reddit"},
+	}
+	curr := snapshot{
+		"a":      {Uses: 5, InviterID: "bot"},
+		"b":      {Uses: 0, InviterID: "bot"},
+		"c":      {Uses: 0, InviterID: "user-1"},
+		"vanity": {Uses: 1, Vanity: true},
+	}
+
+	a := buildAnalytics(stats, tracked, curr)
+
+	assert.Equal(t, 11, a.TotalJoins)
+	assert.Equal(t, []models.InviteAnalyticsEntry{
+		{InviteJoinStats: stats[0], Active: true},
+		// Deleted invites keep their recorded joins.
+		{InviteJoinStats: stats[1], Active: false},
+		{InviteJoinStats: stats[2], Active: false},
+		{InviteJoinStats: stats[3], Active: true},
+		// Invites without joins are listed as well.
+		{InviteJoinStats: models.InviteJoinStats{
+			Code: "b", Source: models.InviteJoinSourceInvite, CreatorID: "mod-1", Label: "reddit",
+		}, Active: true},
+		{InviteJoinStats: models.InviteJoinStats{
+			Code: "c", Source: models.InviteJoinSourceInvite, InviterID: "user-1",
+		}, Active: true},
+	}, a.Invites)
+
+	// Invites created via the bot are attributed to the
+	// user who requested them instead of the bot.
+	assert.Equal(t, []models.InviterStats{
+		{UserID: "mod-1", Codes: []string{"a", "b"}, Joins: 5},
+		{UserID: "user-1", Codes: []string{"expired", "c"}, Joins: 3},
+	}, a.Inviters)
+
+	// Empty guild
+	a = buildAnalytics(nil, nil, snapshot{})
+	assert.Equal(t, 0, a.TotalJoins)
+	assert.Empty(t, a.Invites)
+	assert.Empty(t, a.Inviters)
+}
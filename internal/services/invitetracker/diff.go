@@ -0,0 +1,80 @@
+package invitetracker
+
+import "time"
+
+// inviteState is the state of a single invite at the
+// time a snapshot has been taken.
+type inviteState struct {
+	Uses      int
+	MaxUses   int
+	Expires   time.Time
+	Vanity    bool
+	InviterID string
+}
+
+// snapshot maps invite codes of a guild to their state.
+type snapshot map[string]inviteState
+
+func (s snapshot) copy() snapshot {
+	c := make(snapshot, len(s))
+	for code, state := range s {
+		c[code] = state
+	}
+	return c
+}
+
+// attribute determines the invite used by a single joined
+// member by comparing the previous snapshot with the
+// current state of the invites. It returns the attributed
+// invite code, or an empty string if the invite can not
+// be determined, and the snapshot to be stored for the
+// next join.
+//
+// Because members can join faster than their join events
+// are processed, the current state may already contain the
+// uses of following joins. Therefore, only a single use is
+// consumed from the attributed invite so that the following
+// joins are still visible when they are processed. If uses
+// of more than one invite have increased, the join can not
+// be attributed reliably, so all pending uses are discarded.
+func attribute(prev, curr snapshot, now time.Time) (code string, state inviteState, next snapshot) {
+	var candidates []string
+
+	for c, cs := range curr {
+		if cs.Uses > prev[c].Uses {
+			candidates = append(candidates, c)
+		}
+	}
+
+	// Invites are deleted by Discord as soon as their
+	// maximum uses are reached, so an invite which is
+	// missing now and had only one use left has most
+	// likely been used for this join.
+	for c, ps := range prev {
+		if _, ok := curr[c]; ok {
+			continue
+		}
+		if ps.MaxUses > 0 && ps.Uses+1 >= ps.MaxUses &&
+			(ps.Expires.IsZero() || now.Before(ps.Expires)) {
+			candidates = append(candidates, c)
+		}
+	}
+
+	next = curr.copy()
+
+	if len(candidates) != 1 {
+		return "", inviteState{}, next
+	}
+
+	code = candidates[0]
+	state, ok := curr[code]
+	if !ok {
+		return code, prev[code], next
+	}
+
+	consumed := state
+	consumed.Uses = prev[code].Uses + 1
+	next[code] = consumed
+
+	return code, state, next
+}
@@ -0,0 +1,103 @@
+package invitetracker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttribute(t *testing.T) {
+	now := time.Now()
+
+	// Single join via a single invite.
+	code, _, next := attribute(
+		snapshot{"a": {Uses: 1}, "b": {Uses: 5}},
+		snapshot{"a": {Uses: 2}, "b": {Uses: 5}},
+		now)
+	assert.Equal(t, "a", code)
+	assert.Equal(t, snapshot{"a": {Uses: 2}, "b": {Uses: 5}}, next)
+
+	// Invite created after the snapshot has been taken.
+	code, _, _ = attribute(
+		snapshot{"a": {Uses: 1}},
+		snapshot{"a": {Uses: 1}, "c": {Uses: 1}},
+		now)
+	assert.Equal(t, "c", code)
+
+	// No changed uses.
+	code, _, next = attribute(
+		snapshot{"a": {Uses: 1}},
+		snapshot{"a": {Uses: 1}},
+		now)
+	assert.Equal(t, "", code)
+	assert.Equal(t, snapshot{"a": {Uses: 1}}, next)
+
+	// Vanity URL.
+	code, state, _ := attribute(
+		snapshot{"a": {Uses: 1}, "vanity": {Uses: 10, Vanity: true}},
+		snapshot{"a": {Uses: 1}, "vanity": {Uses: 11, Vanity: true}},
+		now)
+	assert.Equal(t, "vanity", code)
+	assert.True(t, state.Vanity)
+}
+
+func TestAttributeDeletedInvite(t *testing.T) {
+	now := time.Now()
+
+	// Invite deleted after its last use.
+	code, _, next := attribute(
+		snapshot{"a": {Uses: 1}, "b": {Uses: 4, MaxUses: 5}},
+		snapshot{"a": {Uses: 1}},
+		now)
+	assert.Equal(t, "b", code)
+	assert.Equal(t, snapshot{"a": {Uses: 1}}, next)
+
+	// Invite deleted with uses left.
+	code, _, _ = attribute(
+		snapshot{"a": {Uses: 1}, "b": {Uses: 2, MaxUses: 5}},
+		snapshot{"a": {Uses: 1}},
+		now)
+	assert.Equal(t, "", code)
+
+	// Invite expired.
+	code, _, _ = attribute(
+		snapshot{"a": {Uses: 1}, "b": {Uses: 0, MaxUses: 1, Expires: now.Add(-time.Minute)}},
+		snapshot{"a": {Uses: 1}},
+		now)
+	assert.Equal(t, "", code)
+}
+
+func TestAttributeMultipleJoins(t *testing.T) {
+	now := time.Now()
+
+	// Two joins via the same invite before the first join
+	// is processed. Only one use is consumed, so the second
+	// join is attributed as well.
+	prev := snapshot{"a": {Uses: 1}, "b": {Uses: 5}}
+	curr := snapshot{"a": {Uses: 3}, "b": {Uses: 5}}
+
+	code, _, next := attribute(prev, curr, now)
+	assert.Equal(t, "a", code)
+	assert.Equal(t, 2, next["a"].Uses)
+
+	code, _, next = attribute(next, curr, now)
+	assert.Equal(t, "a", code)
+	assert.Equal(t, 3, next["a"].Uses)
+
+	code, _, _ = attribute(next, curr, now)
+	assert.Equal(t, "", code)
+
+	// Two joins via different invites before the first
+	// join is processed. Both joins can not be attributed
+	// reliably and the pending uses are discarded.
+	prev = snapshot{"a": {Uses: 1}, "b": {Uses: 5}}
+	curr = snapshot{"a": {Uses: 2}, "b": {Uses: 6}}
+
+	code, _, next = attribute(prev, curr, now)
+	assert.Equal(t, "", code)
+	assert.Equal(t, curr, next)
+
+	code, _, _ = attribute(next, curr, now)
+	assert.Equal(t, "", code)
+}
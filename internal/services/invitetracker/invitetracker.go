@@ -0,0 +1,226 @@
+// Package invitetracker provides a service which keeps
+// track of the uses of guild invites to attribute member
+// joins to the invite used.
+package invitetracker
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/sarulabs/di/v2"
+	"github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/services/timeprovider"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/pkg/discordutil"
+	"github.com/zekrotja/dgrs"
+)
+
+type InviteTracker struct {
+	s  discordutil.ISession
+	db database.Database
+	st dgrs.IState
+	tp timeprovider.Provider
+
+	mtx       sync.Mutex
+	snapshots map[string]snapshot
+	locks     sync.Map
+}
+
+func New(ctn di.Container) *InviteTracker {
+	return &InviteTracker{
+		s:         ctn.Get(static.DiDiscordSession).(discordutil.ISession),
+		db:        ctn.Get(static.DiDatabase).(database.Database),
+		st:        ctn.Get(static.DiState).(dgrs.IState),
+		tp:        ctn.Get(static.DiTimeProvider).(timeprovider.Provider),
+		snapshots: make(map[string]snapshot),
+	}
+}
+
+// Snapshot fetches and stores the current uses of all
+// invites of the given guild.
+func (t *InviteTracker) Snapshot(guildID string) error {
+	unlock := t.lock(guildID)
+	defer unlock()
+
+	curr, err := t.fetch(guildID)
+	if err != nil {
+		return err
+	}
+
+	t.setSnapshot(guildID, curr)
+	return nil
+}
+
+// Forget removes the stored snapshot of the given guild.
+func (t *InviteTracker) Forget(guildID string) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	delete(t.snapshots, guildID)
+}
+
+// Add records a newly created invite in the snapshot of
+// the given guild. If no snapshot exists for the guild,
+// the invite is ignored.
+func (t *InviteTracker) Add(guildID string, inv *discordgo.Invite) {
+	unlock := t.lock(guildID)
+	defer unlock()
+
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	snap, ok := t.snapshots[guildID]
+	if !ok {
+		return
+	}
+
+	if _, ok = snap[inv.Code]; !ok {
+		snap[inv.Code] = stateFromInvite(inv)
+	}
+}
+
+// Create creates a new invite for the given channel and
+// saves it as tracked invite with the given label.
+func (t *InviteTracker) Create(guildID, channelID, creatorID, label string, maxAge, maxUses int) (inv *discordgo.Invite, err error) {
+	inv, err = t.s.ChannelInviteCreate(channelID, discordgo.Invite{
+		MaxAge:  maxAge,
+		MaxUses: maxUses,
+		Unique:  true,
+	})
+	if err != nil {
+		return
+	}
+
+	err = t.db.AddTrackedInvite(models.TrackedInvite{
+		Code:      inv.Code,
+		GuildID:   guildID,
+		ChannelID: channelID,
+		CreatorID: creatorID,
+		Label:     label,
+		Created:   t.tp.Now(),
+	})
+	if err != nil {
+		return
+	}
+
+	t.Add(guildID, inv)
+	return inv, nil
+}
+
+// Attribute determines the invite used by the given
+// member who just joined the guild and saves the
+// resulting attribution.
+//
+// The attribution requires a snapshot of the guild's
+// invites taken before the join. If none exists, a
+// snapshot is taken and the join is saved with an
+// unknown source.
+func (t *InviteTracker) Attribute(guildID, userID string) (j models.InviteJoin, err error) {
+	j = models.InviteJoin{
+		GuildID:   guildID,
+		UserID:    userID,
+		Source:    models.InviteJoinSourceUnknown,
+		Timestamp: t.tp.Now(),
+	}
+
+	unlock := t.lock(guildID)
+	defer unlock()
+
+	curr, err := t.fetch(guildID)
+	if err != nil {
+		return
+	}
+
+	t.mtx.Lock()
+	prev, ok := t.snapshots[guildID]
+	t.mtx.Unlock()
+
+	if ok {
+		code, state, next := attribute(prev, curr, t.tp.Now())
+		curr = next
+		if code != "" {
+			j.Code = code
+			j.InviterID = state.InviterID
+			j.Source = models.InviteJoinSourceInvite
+			if state.Vanity {
+				j.Source = models.InviteJoinSourceVanity
+			}
+		}
+	}
+
+	t.setSnapshot(guildID, curr)
+
+	err = t.db.AddInviteJoin(j)
+	return
+}
+
+func (t *InviteTracker) setSnapshot(guildID string, snap snapshot) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	t.snapshots[guildID] = snap
+}
+
+// lock serializes all operations on the snapshot of a
+// single guild because join events are handled
+// concurrently.
+func (t *InviteTracker) lock(guildID string) func() {
+	v, _ := t.locks.LoadOrStore(guildID, &sync.Mutex{})
+	mtx := v.(*sync.Mutex)
+	mtx.Lock()
+	return mtx.Unlock
+}
+
+func (t *InviteTracker) fetch(guildID string) (snapshot, error) {
+	invs, err := t.s.GuildInvites(guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	snap := make(snapshot, len(invs)+1)
+	for _, inv := range invs {
+		snap[inv.Code] = stateFromInvite(inv)
+	}
+
+	guild, err := t.st.Guild(guildID)
+	if err == nil && guild.VanityURLCode != "" {
+		// The vanity URL is not contained in the list of
+		// invites, so its uses are fetched separately.
+		// Errors are ignored because the vanity URL can
+		// be removed at any time.
+		if uses, err := t.vanityUses(guildID); err == nil {
+			snap[guild.VanityURLCode] = inviteState{Uses: uses, Vanity: true}
+		}
+	}
+
+	return snap, nil
+}
+
+func (t *InviteTracker) vanityUses(guildID string) (int, error) {
+	endpoint := discordgo.EndpointGuild(guildID) + "/vanity-url"
+	body, err := t.s.RequestWithBucketID("GET", endpoint, nil, endpoint)
+	if err != nil {
+		return 0, err
+	}
+
+	var res struct {
+		Uses int `json:"uses"`
+	}
+	err = json.Unmarshal(body, &res)
+	return res.Uses, err
+}
+
+func stateFromInvite(inv *discordgo.Invite) (s inviteState) {
+	s.Uses = inv.Uses
+	s.MaxUses = inv.MaxUses
+	if inv.Inviter != nil {
+		s.InviterID = inv.Inviter.ID
+	}
+	if inv.ExpiresAt != nil {
+		s.Expires = *inv.ExpiresAt
+	} else if inv.MaxAge > 0 {
+		s.Expires = inv.CreatedAt.Add(time.Duration(inv.MaxAge) * time.Second)
+	}
+	return
+}
@@ -1,27 +1,40 @@
 package karma
 
 import (
+	"errors"
 	"fmt"
+	"strconv"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/sarulabs/di/v2"
 	"github.com/zekroTJA/shinpuru/internal/models"
 	"github.com/zekroTJA/shinpuru/internal/services/database"
 	"github.com/zekroTJA/shinpuru/internal/services/guildlog"
+	"github.com/zekroTJA/shinpuru/internal/services/kvcache"
+	"github.com/zekroTJA/shinpuru/internal/services/timeprovider"
+	"github.com/zekroTJA/shinpuru/internal/util/snowflakenodes"
 	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/pkg/discordutil"
 	"github.com/zekroTJA/shinpuru/pkg/embedbuilder"
+	"github.com/zekroTJA/shinpuru/pkg/stringutil"
 	"github.com/zekrotja/dgrs"
 	"github.com/zekrotja/rogu"
 	"github.com/zekrotja/rogu/log"
 )
 
+// ErrBlockListed is returned when the karma of a
+// blocklisted user would be raised.
+var ErrBlockListed = errors.New("the user is karma blocklisted")
+
 // Service provides functionalities to check karma state,
 // karma blocklist and alter karma of a user.
 type Service struct {
-	s   *discordgo.Session
+	s   discordutil.ISession
 	db  database.Database
 	gl  guildlog.Logger
-	st  *dgrs.State
+	st  dgrs.IState
+	kv  kvcache.Provider
+	tp  timeprovider.Provider
 	log rogu.Logger
 }
 
@@ -35,7 +48,9 @@ func NewKarmaService(container di.Container) (k *Service) {
 	k.s = container.Get(static.DiDiscordSession).(*discordgo.Session)
 	k.db = container.Get(static.DiDatabase).(database.Database)
 	k.gl = container.Get(static.DiGuildLog).(guildlog.Logger).Section("karma")
-	k.st = container.Get(static.DiState).(*dgrs.State)
+	k.st = container.Get(static.DiState).(dgrs.IState)
+	k.kv = container.Get(static.DiKVCache).(kvcache.Provider)
+	k.tp = container.Get(static.DiTimeProvider).(timeprovider.Provider)
 	k.log = log.Tagged("Karma")
 
 	return
@@ -184,6 +199,145 @@ func (k *Service) CheckAndUpdate(guildID, executorID string, object *discordgo.U
 	return
 }
 
+// Reset sets the karma of the specified user on the
+// guild to the given value. The reset is recorded in the
+// settings audit log and the roles of the karma rules of
+// the guild are corrected according to the new value.
+//
+// The karma of blocklisted users can not be raised by a
+// reset, in which case ErrBlockListed is returned.
+func (k *Service) Reset(guildID, userID, executorID string, value int) (err error) {
+	before, err := k.db.GetKarma(userID, guildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return
+	}
+
+	if value > before {
+		isBlocklisted, err := k.IsBlockListed(guildID, userID)
+		if err != nil {
+			return err
+		}
+		if isBlocklisted {
+			return ErrBlockListed
+		}
+	}
+
+	if err = k.db.SetKarma(userID, guildID, value); err != nil {
+		return
+	}
+
+	k.audit(guildID, executorID, "karma."+userID, strconv.Itoa(before), strconv.Itoa(value))
+
+	rules, err := k.db.GetKarmaRules(guildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+
+	memb, err := k.st.Member(guildID, userID)
+	if discordutil.IsErrCode(err, discordgo.ErrCodeUnknownMember) {
+		return nil
+	}
+	if err != nil {
+		return
+	}
+
+	k.syncRoles(guildID, memb, value, rules)
+	return nil
+}
+
+// ResetGuild sets the karma of all users on the guild
+// to zero. The reset is recorded in the settings audit
+// log and the roles of the karma rules of the guild are
+// corrected for all members in the background.
+func (k *Service) ResetGuild(guildID, executorID string) (err error) {
+	if err = k.db.ResetKarmaGuild(guildID); err != nil {
+		return
+	}
+
+	k.audit(guildID, executorID, "karma", "", "reset all to 0")
+
+	rules, err := k.db.GetKarmaRules(guildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+
+	// Correcting the roles requires requests for each
+	// member, which takes long on large guilds.
+	go func() {
+		membs, err := k.st.Members(guildID)
+		if err != nil {
+			k.log.Error().Err(err).Field("gid", guildID).Msg("Failed getting members")
+			k.gl.Errorf(guildID, "Failed getting members to correct karma roles: %s", err.Error())
+			return
+		}
+
+		for _, memb := range membs {
+			k.syncRoles(guildID, memb, 0, rules)
+		}
+	}()
+
+	return nil
+}
+
+// syncRoles adds or removes the roles of the toggle role
+// rules so that they match the given karma value of the
+// member.
+func (k *Service) syncRoles(guildID string, memb *discordgo.Member, value int, rules []models.KarmaRule) {
+	for _, rule := range rules {
+		if rule.Action != models.KarmaActionToggleRole {
+			continue
+		}
+
+		var want bool
+		switch rule.Trigger {
+		case models.KarmaTriggerAbove:
+			want = value > rule.Value
+		case models.KarmaTriggerBelow:
+			want = value < rule.Value
+		default:
+			continue
+		}
+
+		if want == stringutil.ContainsAny(rule.Argument, memb.Roles) {
+			continue
+		}
+
+		userID := memb.User.ID
+		if want {
+			if err := k.s.GuildMemberRoleAdd(guildID, userID, rule.Argument); err != nil {
+				k.log.Error().Err(err).Fields("gid", guildID, "uid", userID).Msg("Failed adding role")
+				k.gl.Errorf(guildID, "Failed adding role to user (%s): %s", userID, err.Error())
+			}
+		} else {
+			if err := k.s.GuildMemberRoleRemove(guildID, userID, rule.Argument); err != nil {
+				k.log.Error().Err(err).Fields("gid", guildID, "uid", userID).Msg("Failed removing role")
+				k.gl.Errorf(guildID, "Failed removing role to user (%s): %s", userID, err.Error())
+			}
+		}
+	}
+}
+
+func (k *Service) audit(guildID, executorID, field, oldValue, newValue string) {
+	err := k.db.AddSettingsAuditEntry(models.SettingsAuditEntry{
+		ID:        snowflakenodes.NodeSettingsAudit.Generate(),
+		GuildID:   guildID,
+		ActorID:   executorID,
+		Field:     field,
+		OldValue:  oldValue,
+		NewValue:  newValue,
+		Timestamp: k.tp.Now(),
+	})
+	if err != nil {
+		k.log.Error().Err(err).Field("gid", guildID).Msg("Failed adding settings audit entry")
+	}
+}
+
 func (k *Service) trySendKarmaMessage(userID, guildID string, added bool, value int, content string) {
 	ch, err := k.s.UserChannelCreate(userID)
 	if err != nil {
@@ -0,0 +1,141 @@
+package karma
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/services/database/memory"
+	"github.com/zekroTJA/shinpuru/internal/services/kvcache"
+	"github.com/zekroTJA/shinpuru/internal/services/timeprovider"
+	"github.com/zekroTJA/shinpuru/internal/util/snowflakenodes"
+	"github.com/zekroTJA/shinpuru/mocks"
+	"github.com/zekrotja/rogu/log"
+)
+
+type karmaMock struct {
+	session *mocks.ISession
+	state   *mocks.IState
+	logger  *mocks.Logger
+	db      *memory.MemoryMiddleware
+}
+
+func newResetTestService() (*Service, karmaMock) {
+	snowflakenodes.Setup()
+
+	var m karmaMock
+	m.session = &mocks.ISession{}
+	m.state = &mocks.IState{}
+	m.logger = &mocks.Logger{}
+	m.db = memory.New()
+
+	m.logger.On("Errorf", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	k := &Service{
+		s:   m.session,
+		db:  m.db,
+		gl:  m.logger,
+		st:  m.state,
+		kv:  kvcache.NewTimedmapCache(time.Minute),
+		tp:  timeprovider.Time{},
+		log: log.Tagged("Karma"),
+	}
+
+	return k, m
+}
+
+func addToggleRoleRule(t *testing.T, db *memory.MemoryMiddleware, trigger models.KarmaTriggerType, value int, roleID string) {
+	assert.Nil(t, db.AddOrUpdateKarmaRule(models.KarmaRule{
+		ID:       snowflakenodes.NodeKarmaRules.Generate(),
+		GuildID:  "guild",
+		Trigger:  trigger,
+		Value:    value,
+		Action:   models.KarmaActionToggleRole,
+		Argument: roleID,
+		Checksum: roleID,
+	}))
+}
+
+func TestReset(t *testing.T) {
+	k, m := newResetTestService()
+
+	addToggleRoleRule(t, m.db, models.KarmaTriggerAbove, 10, "role-above")
+	addToggleRoleRule(t, m.db, models.KarmaTriggerBelow, 0, "role-below")
+
+	assert.Nil(t, m.db.SetKarma("user", "guild", 20))
+
+	m.state.On("Member", "guild", "user").Return(&discordgo.Member{
+		User:  &discordgo.User{ID: "user"},
+		Roles: []string{"role-above"},
+	}, nil)
+	m.session.On("GuildMemberRoleRemove", "guild", "user", "role-above").Return(nil)
+
+	assert.Nil(t, k.Reset("guild", "user", "executor", 0))
+
+	v, err := m.db.GetKarma("user", "guild")
+	assert.Nil(t, err)
+	assert.Equal(t, 0, v)
+
+	m.session.AssertCalled(t, "GuildMemberRoleRemove", "guild", "user", "role-above")
+	m.session.AssertNotCalled(t, "GuildMemberRoleAdd", "guild", "user", "role-below")
+
+	entries, err := m.db.GetSettingsAuditEntries("guild", 0, 10)
+	assert.Nil(t, err)
+	if assert.Len(t, entries, 1) {
+		assert.Equal(t, "executor", entries[0].ActorID)
+		assert.Equal(t, "karma.user", entries[0].Field)
+		assert.Equal(t, "20", entries[0].OldValue)
+		assert.Equal(t, "0", entries[0].NewValue)
+	}
+}
+
+func TestResetBlockListed(t *testing.T) {
+	k, m := newResetTestService()
+
+	assert.Nil(t, m.db.SetKarma("user", "guild", 5))
+	assert.Nil(t, m.db.AddKarmaBlockList("guild", "user"))
+
+	// The karma of blocklisted users can not be raised ...
+	assert.ErrorIs(t, k.Reset("guild", "user", "executor", 10), ErrBlockListed)
+	v, _ := m.db.GetKarma("user", "guild")
+	assert.Equal(t, 5, v)
+
+	// ... but lowered.
+	assert.Nil(t, k.Reset("guild", "user", "executor", 0))
+	v, _ = m.db.GetKarma("user", "guild")
+	assert.Equal(t, 0, v)
+}
+
+func TestResetGuild(t *testing.T) {
+	k, m := newResetTestService()
+
+	addToggleRoleRule(t, m.db, models.KarmaTriggerAbove, 10, "role-above")
+
+	assert.Nil(t, m.db.SetKarma("user-a", "guild", 20))
+	assert.Nil(t, m.db.SetKarma("user-b", "guild", -5))
+	assert.Nil(t, m.db.SetKarma("user-a", "other", 3))
+
+	m.state.On("Members", "guild").Return([]*discordgo.Member{
+		{User: &discordgo.User{ID: "user-a"}, Roles: []string{"role-above"}},
+		{User: &discordgo.User{ID: "user-b"}},
+	}, nil)
+	m.session.On("GuildMemberRoleRemove", "guild", "user-a", "role-above").Return(nil)
+
+	assert.Nil(t, k.ResetGuild("guild", "executor"))
+
+	for _, userID := range []string{"user-a", "user-b"} {
+		v, _ := m.db.GetKarma(userID, "guild")
+		assert.Equal(t, 0, v)
+	}
+	v, _ := m.db.GetKarma("user-a", "other")
+	assert.Equal(t, 3, v)
+
+	// Roles are corrected in the background.
+	assert.Eventually(t, func() bool {
+		return len(m.session.Calls) == 1
+	}, time.Second, 10*time.Millisecond)
+	m.session.AssertCalled(t, "GuildMemberRoleRemove", "guild", "user-a", "role-above")
+}
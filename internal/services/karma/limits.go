@@ -0,0 +1,122 @@
+package karma
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+)
+
+// dailyWindow is the time window of the daily cap
+// of given karma.
+const dailyWindow = 24 * time.Hour
+
+// Limit identifies a karma limit.
+type Limit string
+
+const (
+	LimitGiveCooldown Limit = "give_cooldown"
+	LimitGiveDailyCap Limit = "give_daily_cap"
+	LimitReceiveCap   Limit = "receive_cap"
+)
+
+// LimitError is returned by CheckLimits when a karma
+// change exceeds one of the limits of the guild.
+type LimitError struct {
+	// Limit is the limit which has been hit.
+	Limit Limit
+	// RetryAfter is the duration until the limit
+	// is lifted again.
+	RetryAfter time.Duration
+}
+
+func (e *LimitError) Error() string {
+	retry := e.RetryAfter.Round(time.Second)
+	switch e.Limit {
+	case LimitGiveCooldown:
+		return fmt.Sprintf("You need to wait %s before you can give karma again.", retry)
+	case LimitGiveDailyCap:
+		return fmt.Sprintf("You have reached the maximum amount of karma you can give per day. "+
+			"Please try again in %s.", retry)
+	case LimitReceiveCap:
+		return fmt.Sprintf("This user has received the maximum amount of karma for now. "+
+			"Please try again in %s.", retry)
+	}
+	return "karma limit exceeded"
+}
+
+// GetLimits returns the karma limits of the guild or
+// models.DefaultKarmaLimits if the guild has not
+// configured any limits.
+func (k *Service) GetLimits(guildID string) (limits models.KarmaLimits, err error) {
+	limits, err = k.db.GetKarmaLimits(guildID)
+	if database.IsErrDatabaseNotFound(err) {
+		return models.DefaultKarmaLimits, nil
+	}
+	return
+}
+
+// CheckLimits checks if the executor is allowed to
+// change the karma of the receiver according to the
+// karma limits of the guild. If this is the case, the
+// change is counted towards the limits, so it must be
+// applied afterwards. Otherwise, a *LimitError is
+// returned and nothing is counted.
+func (k *Service) CheckLimits(guildID, executorID, receiverID string) (err error) {
+	limits, err := k.GetLimits(guildID)
+	if err != nil {
+		return
+	}
+
+	now := k.tp.Now()
+
+	cooldownKey := limitKey("cooldown", guildID, executorID)
+	if until, ok := k.kv.Get(cooldownKey).(time.Time); ok && now.Before(until) {
+		return &LimitError{Limit: LimitGiveCooldown, RetryAfter: until.Sub(now)}
+	}
+
+	var (
+		giveKey      string
+		giveLifetime time.Duration
+	)
+	if limits.GiveDailyCap > 0 {
+		giveKey, giveLifetime = windowKey("give", guildID, executorID, now, dailyWindow)
+		if k.kv.Incr(giveKey, 1, giveLifetime) > int64(limits.GiveDailyCap) {
+			k.kv.Incr(giveKey, -1, giveLifetime)
+			return &LimitError{Limit: LimitGiveDailyCap, RetryAfter: giveLifetime}
+		}
+	}
+
+	if limits.ReceiveCap > 0 && limits.ReceiveInterval > 0 {
+		interval := time.Duration(limits.ReceiveInterval) * time.Second
+		receiveKey, retryAfter := windowKey("receive", guildID, receiverID, now, interval)
+		if k.kv.Incr(receiveKey, 1, retryAfter) > int64(limits.ReceiveCap) {
+			k.kv.Incr(receiveKey, -1, retryAfter)
+			if giveKey != "" {
+				k.kv.Incr(giveKey, -1, giveLifetime)
+			}
+			return &LimitError{Limit: LimitReceiveCap, RetryAfter: retryAfter}
+		}
+	}
+
+	if limits.GiveCooldown > 0 {
+		cooldown := time.Duration(limits.GiveCooldown) * time.Second
+		k.kv.Set(cooldownKey, now.Add(cooldown), cooldown)
+	}
+
+	return nil
+}
+
+// windowKey returns the cache key of the counter for the
+// fixed time window of the given length which contains
+// now and the remaining duration of that window.
+func windowKey(kind, guildID, userID string, now time.Time, window time.Duration) (string, time.Duration) {
+	bucket := now.UnixNano() / int64(window)
+	end := time.Unix(0, (bucket+1)*int64(window))
+	return fmt.Sprintf("%s:%d", limitKey(kind, guildID, userID), bucket), end.Sub(now)
+}
+
+func limitKey(kind, guildID, userID string) string {
+	return fmt.Sprintf("karma:limit:%s:%s:%s", kind, guildID, userID)
+}
@@ -0,0 +1,128 @@
+package karma
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/services/kvcache"
+	"github.com/zekroTJA/shinpuru/mocks"
+)
+
+func newLimitsTestService(limits models.KarmaLimits) (*Service, *time.Time) {
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	db := &mocks.Database{}
+	db.On("GetKarmaLimits", "guild").Return(limits, nil)
+	db.On("GetKarmaLimits", "unset").Return(models.KarmaLimits{}, database.ErrDatabaseNotFound)
+
+	tp := &mocks.TimeProvider{}
+	tp.On("Now").Return(func() time.Time { return now })
+
+	k := &Service{
+		db: db,
+		kv: kvcache.NewTimedmapCache(time.Minute),
+		tp: tp,
+	}
+
+	return k, &now
+}
+
+func assertLimitError(t *testing.T, err error, limit Limit, retryAfter time.Duration) {
+	t.Helper()
+
+	limitErr, ok := err.(*LimitError)
+	if assert.True(t, ok, "expected *LimitError, got %v", err) {
+		assert.Equal(t, limit, limitErr.Limit)
+		assert.Equal(t, retryAfter, limitErr.RetryAfter)
+	}
+}
+
+func TestGetLimits(t *testing.T) {
+	limits := models.KarmaLimits{GiveCooldown: 1}
+	k, _ := newLimitsTestService(limits)
+
+	res, err := k.GetLimits("guild")
+	assert.Nil(t, err)
+	assert.Equal(t, limits, res)
+
+	res, err = k.GetLimits("unset")
+	assert.Nil(t, err)
+	assert.Equal(t, models.DefaultKarmaLimits, res)
+}
+
+func TestCheckLimitsCooldown(t *testing.T) {
+	k, now := newLimitsTestService(models.KarmaLimits{GiveCooldown: 10})
+
+	assert.Nil(t, k.CheckLimits("guild", "giver", "receiver"))
+
+	*now = now.Add(4 * time.Second)
+	err := k.CheckLimits("guild", "giver", "other")
+	assertLimitError(t, err, LimitGiveCooldown, 6*time.Second)
+
+	// The cooldown is tracked per giver.
+	assert.Nil(t, k.CheckLimits("guild", "other", "receiver"))
+
+	// A rejected change does not extend the cooldown.
+	*now = now.Add(6 * time.Second)
+	assert.Nil(t, k.CheckLimits("guild", "giver", "receiver"))
+}
+
+func TestCheckLimitsGiveDailyCap(t *testing.T) {
+	k, now := newLimitsTestService(models.KarmaLimits{GiveDailyCap: 3})
+
+	for i := 0; i < 3; i++ {
+		assert.Nil(t, k.CheckLimits("guild", "giver", "receiver"))
+	}
+
+	*now = now.Add(time.Hour)
+	err := k.CheckLimits("guild", "giver", "receiver")
+	assertLimitError(t, err, LimitGiveDailyCap, 23*time.Hour)
+
+	// Rejected changes are not counted.
+	err = k.CheckLimits("guild", "giver", "receiver")
+	assertLimitError(t, err, LimitGiveDailyCap, 23*time.Hour)
+
+	// The counter resets after the window.
+	*now = now.Add(23 * time.Hour)
+	for i := 0; i < 3; i++ {
+		assert.Nil(t, k.CheckLimits("guild", "giver", "receiver"))
+	}
+	err = k.CheckLimits("guild", "giver", "receiver")
+	assertLimitError(t, err, LimitGiveDailyCap, 24*time.Hour)
+}
+
+func TestCheckLimitsReceiveCap(t *testing.T) {
+	k, now := newLimitsTestService(models.KarmaLimits{
+		GiveDailyCap:    3,
+		ReceiveCap:      2,
+		ReceiveInterval: 60,
+	})
+
+	assert.Nil(t, k.CheckLimits("guild", "a", "receiver"))
+	assert.Nil(t, k.CheckLimits("guild", "b", "receiver"))
+
+	*now = now.Add(15 * time.Second)
+	err := k.CheckLimits("guild", "c", "receiver")
+	assertLimitError(t, err, LimitReceiveCap, 45*time.Second)
+
+	// The rejected change is not counted towards the
+	// daily cap of the giver.
+	for _, receiverID := range []string{"x", "y", "z"} {
+		assert.Nil(t, k.CheckLimits("guild", "c", receiverID))
+	}
+
+	// The counter resets after the window.
+	*now = now.Add(45 * time.Second)
+	assert.Nil(t, k.CheckLimits("guild", "a", "receiver"))
+}
+
+func TestCheckLimitsDisabled(t *testing.T) {
+	k, _ := newLimitsTestService(models.KarmaLimits{})
+
+	for i := 0; i < 100; i++ {
+		assert.Nil(t, k.CheckLimits("guild", "giver", "receiver"))
+	}
+}
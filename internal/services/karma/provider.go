@@ -1,6 +1,9 @@
 package karma
 
-import "github.com/bwmarrin/discordgo"
+import (
+	"github.com/bwmarrin/discordgo"
+	"github.com/zekroTJA/shinpuru/internal/models"
+)
 
 type Provider interface {
 	GetState(guildID string) (ok bool, err error)
@@ -8,4 +11,8 @@ type Provider interface {
 	Update(guildID, userID, executorID string, value int) (err error)
 	ApplyPenalty(guildID, userID string) (err error)
 	CheckAndUpdate(guildID, executorID string, object *discordgo.User, value int) (ok bool, err error)
+	Reset(guildID, userID, executorID string, value int) (err error)
+	ResetGuild(guildID, executorID string) (err error)
+	GetLimits(guildID string) (limits models.KarmaLimits, err error)
+	CheckLimits(guildID, executorID, receiverID string) (err error)
 }
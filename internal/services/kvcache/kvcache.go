@@ -3,7 +3,42 @@ package kvcache
 import "time"
 
 type Provider interface {
+	Inspector
+
 	Get(key string) interface{}
 	Set(key string, v interface{}, lifetime time.Duration)
 	Del(key string)
+
+	// Incr atomically adds delta to the int64 value
+	// stored at key and returns the new value. A
+	// missing or non-integer value is treated as 0.
+	// The lifetime of the key is reset to the given
+	// lifetime.
+	Incr(key string, delta int64, lifetime time.Duration) int64
+}
+
+// Inspector provides functionalities to inspect
+// and flush the contents of a cache.
+type Inspector interface {
+	// Stats returns the current size and the hit
+	// and miss counts of the cache.
+	Stats() Stats
+
+	// Keys returns all keys of the cache starting
+	// with the given prefix. An empty prefix
+	// matches all keys.
+	Keys(prefix string) []string
+
+	// FlushPrefix removes all keys starting with
+	// the given prefix from the cache and returns
+	// the number of removed keys. An empty prefix
+	// flushes the whole cache.
+	FlushPrefix(prefix string) int
+}
+
+// Stats contains statistics of a cache.
+type Stats struct {
+	Size   int    `json:"size"`
+	Hits   uint64 `json:"hits"`
+	Misses uint64 `json:"misses"`
 }
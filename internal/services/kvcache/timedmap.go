@@ -1,6 +1,9 @@
 package kvcache
 
 import (
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/zekroTJA/timedmap"
@@ -8,6 +11,11 @@ import (
 
 type timedmapCache struct {
 	tm *timedmap.TimedMap
+
+	incrMtx sync.Mutex
+
+	hits   uint64
+	misses uint64
 }
 
 func NewTimedmapCache(tickTime time.Duration) Provider {
@@ -17,7 +25,13 @@ func NewTimedmapCache(tickTime time.Duration) Provider {
 }
 
 func (t *timedmapCache) Get(key string) interface{} {
-	return t.tm.GetValue(key)
+	v := t.tm.GetValue(key)
+	if v == nil {
+		atomic.AddUint64(&t.misses, 1)
+	} else {
+		atomic.AddUint64(&t.hits, 1)
+	}
+	return v
 }
 
 func (t *timedmapCache) Set(key string, v interface{}, lifetime time.Duration) {
@@ -27,3 +41,47 @@ func (t *timedmapCache) Set(key string, v interface{}, lifetime time.Duration) {
 func (t *timedmapCache) Del(key string) {
 	t.tm.Remove(key)
 }
+
+func (t *timedmapCache) Incr(key string, delta int64, lifetime time.Duration) int64 {
+	t.incrMtx.Lock()
+	defer t.incrMtx.Unlock()
+
+	v, _ := t.tm.GetValue(key).(int64)
+	v += delta
+	t.tm.Set(key, v, lifetime)
+
+	return v
+}
+
+func (t *timedmapCache) Stats() Stats {
+	return Stats{
+		Size:   len(t.Keys("")),
+		Hits:   atomic.LoadUint64(&t.hits),
+		Misses: atomic.LoadUint64(&t.misses),
+	}
+}
+
+func (t *timedmapCache) Keys(prefix string) []string {
+	keys := make([]string, 0)
+	for k := range t.tm.Snapshot() {
+		key, ok := k.(string)
+		if ok && strings.HasPrefix(key, prefix) && t.tm.Contains(key) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+func (t *timedmapCache) FlushPrefix(prefix string) int {
+	if prefix == "" {
+		n := len(t.Keys(""))
+		t.tm.Flush()
+		return n
+	}
+
+	keys := t.Keys(prefix)
+	for _, key := range keys {
+		t.tm.Remove(key)
+	}
+	return len(keys)
+}
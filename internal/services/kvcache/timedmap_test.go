@@ -0,0 +1,34 @@
+package kvcache
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimedmapCacheInspect(t *testing.T) {
+	c := NewTimedmapCache(time.Minute)
+
+	c.Set("guild:1:a", 1, time.Minute)
+	c.Set("guild:1:b", 2, time.Minute)
+	c.Set("guild:2:a", 3, time.Minute)
+	c.Set("user:1", 4, time.Minute)
+
+	keys := c.Keys("guild:1:")
+	sort.Strings(keys)
+	assert.Equal(t, []string{"guild:1:a", "guild:1:b"}, keys)
+	assert.Len(t, c.Keys(""), 4)
+
+	c.Get("user:1")
+	c.Get("user:2")
+	assert.Equal(t, Stats{Size: 4, Hits: 1, Misses: 1}, c.Stats())
+
+	assert.Equal(t, 2, c.FlushPrefix("guild:1:"))
+	assert.Nil(t, c.Get("guild:1:a"))
+	assert.Equal(t, 3, c.Get("guild:2:a"))
+
+	assert.Equal(t, 2, c.FlushPrefix(""))
+	assert.Empty(t, c.Keys(""))
+}
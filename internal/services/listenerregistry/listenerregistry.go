@@ -0,0 +1,103 @@
+// Package listenerregistry provides a registry of named
+// Discord event listeners which can be enabled and
+// disabled at runtime.
+package listenerregistry
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+var ErrNotFound = errors.New("listener not found")
+
+// State describes the name and the current state
+// of a registered listener.
+type State struct {
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+}
+
+// Registry keeps track of the enabled state of
+// registered listeners.
+type Registry struct {
+	mtx     sync.RWMutex
+	names   []string
+	enabled map[string]bool
+}
+
+func New() *Registry {
+	return &Registry{
+		enabled: make(map[string]bool),
+	}
+}
+
+// Wrap registers the listener with the given name in
+// the registry and returns a handler which only calls
+// the passed handler if the listener is enabled.
+//
+// Multiple handlers can be wrapped with the same name
+// to enable and disable them together.
+func Wrap[T any](r *Registry, name string, handler func(*discordgo.Session, T)) func(*discordgo.Session, T) {
+	r.register(name)
+	return func(s *discordgo.Session, e T) {
+		if !r.IsEnabled(name) {
+			return
+		}
+		handler(s, e)
+	}
+}
+
+// IsEnabled returns true if the listener with the
+// given name is registered and enabled.
+func (r *Registry) IsEnabled(name string) bool {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	return r.enabled[name]
+}
+
+// SetEnabled sets the enabled state of the listener
+// with the given name. If no listener is registered
+// with the name, ErrNotFound is returned.
+func (r *Registry) SetEnabled(name string, enabled bool) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if _, ok := r.enabled[name]; !ok {
+		return ErrNotFound
+	}
+
+	r.enabled[name] = enabled
+	return nil
+}
+
+// States returns the states of all registered listeners
+// in the order of their registration.
+func (r *Registry) States() []State {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	states := make([]State, 0, len(r.names))
+	for _, name := range r.names {
+		states = append(states, State{
+			Name:    name,
+			Enabled: r.enabled[name],
+		})
+	}
+
+	return states
+}
+
+func (r *Registry) register(name string) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if _, ok := r.enabled[name]; ok {
+		return
+	}
+
+	r.names = append(r.names, name)
+	r.enabled[name] = true
+}
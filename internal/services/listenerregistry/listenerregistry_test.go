@@ -0,0 +1,71 @@
+package listenerregistry
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWrap(t *testing.T) {
+	r := New()
+
+	var calls []string
+	handler := func(name string) func(*discordgo.Session, *discordgo.MessageCreate) {
+		return func(s *discordgo.Session, e *discordgo.MessageCreate) {
+			calls = append(calls, name)
+		}
+	}
+
+	a := Wrap(r, "a", handler("a"))
+	b1 := Wrap(r, "b", handler("b1"))
+	b2 := Wrap(r, "b", handler("b2"))
+
+	assert.Equal(t, []State{{"a", true}, {"b", true}}, r.States())
+
+	a(nil, nil)
+	b1(nil, nil)
+	b2(nil, nil)
+	assert.Equal(t, []string{"a", "b1", "b2"}, calls)
+
+	calls = nil
+	assert.Nil(t, r.SetEnabled("b", false))
+	assert.Equal(t, []State{{"a", true}, {"b", false}}, r.States())
+
+	a(nil, nil)
+	b1(nil, nil)
+	b2(nil, nil)
+	assert.Equal(t, []string{"a"}, calls)
+
+	assert.ErrorIs(t, r.SetEnabled("c", false), ErrNotFound)
+	assert.False(t, r.IsEnabled("c"))
+}
+
+func TestConcurrentToggle(t *testing.T) {
+	r := New()
+
+	var mtx sync.Mutex
+	var n int
+	h := Wrap(r, "a", func(s *discordgo.Session, e *discordgo.Ready) {
+		mtx.Lock()
+		n++
+		mtx.Unlock()
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			r.SetEnabled("a", i%2 == 0)
+		}(i)
+		go func() {
+			defer wg.Done()
+			h(nil, nil)
+		}()
+	}
+	wg.Wait()
+
+	assert.LessOrEqual(t, n, 100)
+}
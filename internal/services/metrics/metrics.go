@@ -53,6 +53,60 @@ var (
 		},
 	}, []string{"method", "status"})
 
+	DatabaseQueryTimes = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "database_queries_duration_seconds",
+		Help: "Duration of database queries by operation.",
+		Buckets: []float64{
+			0.0005,
+			0.001,
+			0.005,
+			0.01,
+			0.025,
+			0.05,
+			0.1,
+			0.25,
+			0.5,
+			1,
+			2.5,
+			5,
+		},
+	}, []string{"operation"})
+
+	SettingsCacheRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "database_settingscache_requests_total",
+		Help: "Total number of guild settings requests by cache result.",
+	}, []string{"result"})
+
+	DiscordSendRetries = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "discord_send_retries_total",
+		Help: "Total number of retried Discord message sends.",
+	})
+
+	DiscordSendFailures = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "discord_send_failures_total",
+		Help: "Total number of Discord message sends which failed finally.",
+	})
+
+	DiscordSendRateLimits = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "discord_send_ratelimits_total",
+		Help: "Total number of Discord message sends which have been rate limited.",
+	})
+
+	DiscordSendQueueDepth = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "discord_send_queue_depth",
+		Help: "Number of Discord messages waiting in the send queue.",
+	})
+
+	CodeExecActive = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "codeexec_active",
+		Help: "Number of currently running code executions.",
+	})
+
+	CodeExecQueued = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "codeexec_queued",
+		Help: "Number of code executions waiting for a free execution slot.",
+	})
+
 	RedisKeyCount = promauto.NewGaugeFunc(prometheus.GaugeOpts{
 		Name: "redis_key_count",
 		Help: "Number of Redis keys.",
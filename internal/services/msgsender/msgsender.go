@@ -0,0 +1,241 @@
+// Package msgsender provides a helper to send Discord
+// messages with bounded retries on transient errors
+// and a per-channel send queue.
+package msgsender
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/sarulabs/di/v2"
+	"github.com/zekroTJA/shinpuru/internal/services/config"
+	"github.com/zekroTJA/shinpuru/internal/services/metrics"
+	"github.com/zekroTJA/shinpuru/internal/services/timeprovider"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/pkg/discordutil"
+	"github.com/zekroTJA/shinpuru/pkg/ringbuffer"
+	"github.com/zekrotja/rogu"
+	"github.com/zekrotja/rogu/log"
+)
+
+// Options specifies how failed sends are retried.
+type Options struct {
+	// MaxAttempts is the maximum number of send
+	// attempts. Values below 1 result in a single
+	// attempt.
+	MaxAttempts int
+	// InitialDelay is the delay before the first retry.
+	// The delay is doubled after each failed attempt.
+	InitialDelay time.Duration
+	// MaxDelay caps the delay between two attempts.
+	// Sends which are rate limited for a longer
+	// duration are not retried.
+	MaxDelay time.Duration
+	// DeadLetters is the number of failed sends which
+	// are kept for diagnostics.
+	DeadLetters int
+	// QueueSize is the maximum number of queued sends
+	// per channel. A value of 0 disables the limit.
+	QueueSize int
+}
+
+// DeadLetter holds information about a send which
+// has failed finally.
+type DeadLetter struct {
+	Time      time.Time `json:"time"`
+	GuildID   string    `json:"guild_id"`
+	ChannelID string    `json:"channel_id"`
+	Attempts  int       `json:"attempts"`
+	Err       string    `json:"error"`
+}
+
+// Sender wraps message sends to retry them on transient
+// errors like server errors or rate limits. Sends which
+// still fail are recorded as dead letters.
+type Sender struct {
+	opts        Options
+	tp          timeprovider.Provider
+	log         rogu.Logger
+	deadLetters *ringbuffer.RingBuffer[DeadLetter]
+
+	queueMtx sync.Mutex
+	queues   map[string][]queuedSend
+
+	sleep func(time.Duration)
+}
+
+func New(ctn di.Container) *Sender {
+	cfg := ctn.Get(static.DiConfig).(config.Provider).Config().Discord.SendRetry
+	return newSender(Options{
+		MaxAttempts:  cfg.MaxAttempts,
+		InitialDelay: time.Duration(cfg.InitialDelayMillis) * time.Millisecond,
+		MaxDelay:     time.Duration(cfg.MaxDelayMillis) * time.Millisecond,
+		DeadLetters:  cfg.DeadLetters,
+		QueueSize:    cfg.QueueSize,
+	}, ctn.Get(static.DiTimeProvider).(timeprovider.Provider))
+}
+
+func newSender(opts Options, tp timeprovider.Provider) *Sender {
+	if opts.MaxAttempts < 1 {
+		opts.MaxAttempts = 1
+	}
+	return &Sender{
+		opts:        opts,
+		tp:          tp,
+		log:         log.Tagged("MessageSender"),
+		deadLetters: ringbuffer.New[DeadLetter](opts.DeadLetters),
+		queues:      make(map[string][]queuedSend),
+		sleep:       time.Sleep,
+	}
+}
+
+// Do executes f and retries it with an exponential
+// backoff as long as it fails with a transient error
+// and the maximum number of attempts has not been
+// reached. When the error is caused by a rate limit,
+// the retry is delayed by the duration requested by
+// Discord.
+//
+// If f finally fails, the last error is returned and
+// the send is recorded as dead letter.
+func (s *Sender) Do(guildID, channelID string, f func() error) (err error) {
+	delay := s.opts.InitialDelay
+
+	for attempt := 1; ; attempt++ {
+		if err = f(); err == nil {
+			return nil
+		}
+
+		if _, limited := rateLimitDelay(err); limited {
+			metrics.DiscordSendRateLimits.Inc()
+		}
+
+		wait, transient := retryDelay(err)
+		if !transient || attempt >= s.opts.MaxAttempts {
+			s.fail(guildID, channelID, attempt, err)
+			return err
+		}
+
+		if wait > 0 {
+			// Waiting for the rate limit to be lifted is
+			// given up on when it would block for too long.
+			if s.opts.MaxDelay > 0 && wait > s.opts.MaxDelay {
+				s.fail(guildID, channelID, attempt, err)
+				return err
+			}
+		} else {
+			wait = delay
+			if s.opts.MaxDelay > 0 && wait > s.opts.MaxDelay {
+				wait = s.opts.MaxDelay
+			}
+			delay *= 2
+		}
+
+		metrics.DiscordSendRetries.Inc()
+		s.log.Debug().Err(err).
+			Field("attempt", attempt).
+			Field("delay", wait).
+			Fields("guildID", guildID, "channelID", channelID).
+			Msg("Sending message failed, retrying ...")
+
+		s.sleep(wait)
+	}
+}
+
+// SendComplex sends the passed message data to the
+// given channel using Do.
+func (s *Sender) SendComplex(
+	session discordutil.ISession,
+	guildID, channelID string,
+	data *discordgo.MessageSend,
+) (msg *discordgo.Message, err error) {
+	err = s.Do(guildID, channelID, func() (err error) {
+		msg, err = session.ChannelMessageSendComplex(channelID, data)
+		return err
+	})
+	return msg, err
+}
+
+// SendEmbed sends the passed embed to the given
+// channel using Do.
+func (s *Sender) SendEmbed(
+	session discordutil.ISession,
+	guildID, channelID string,
+	emb *discordgo.MessageEmbed,
+) (*discordgo.Message, error) {
+	return s.SendComplex(session, guildID, channelID, &discordgo.MessageSend{
+		Embed: emb,
+	})
+}
+
+// DeadLetters returns the most recent sends which
+// have failed finally, ordered from oldest to newest.
+func (s *Sender) DeadLetters() []DeadLetter {
+	return s.deadLetters.Items()
+}
+
+func (s *Sender) fail(guildID, channelID string, attempts int, err error) {
+	metrics.DiscordSendFailures.Inc()
+	s.deadLetters.Push(DeadLetter{
+		Time:      s.tp.Now(),
+		GuildID:   guildID,
+		ChannelID: channelID,
+		Attempts:  attempts,
+		Err:       err.Error(),
+	})
+}
+
+// retryDelay returns whether the passed error is
+// transient. If the error is caused by a rate limit,
+// the duration to wait before retrying is returned.
+func retryDelay(err error) (time.Duration, bool) {
+	if d, ok := rateLimitDelay(err); ok {
+		return d, true
+	}
+
+	var restErr *discordgo.RESTError
+	if errors.As(err, &restErr) {
+		return 0, restErr.Response != nil && restErr.Response.StatusCode >= 500
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return 0, netErr.Timeout()
+	}
+
+	return 0, false
+}
+
+// rateLimitDelay returns the retry-after duration if
+// the passed error is caused by a rate limit.
+func rateLimitDelay(err error) (time.Duration, bool) {
+	var rlErr *discordgo.RateLimitError
+	if errors.As(err, &rlErr) {
+		if rlErr.RateLimit == nil || rlErr.TooManyRequests == nil {
+			return 0, true
+		}
+		return rlErr.RetryAfter, true
+	}
+
+	var restErr *discordgo.RESTError
+	if !errors.As(err, &restErr) || restErr.Response == nil ||
+		restErr.Response.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+
+	var tmr discordgo.TooManyRequests
+	if json.Unmarshal(restErr.ResponseBody, &tmr) == nil && tmr.RetryAfter > 0 {
+		return tmr.RetryAfter, true
+	}
+	if secs, err := strconv.ParseFloat(restErr.Response.Header.Get("Retry-After"), 64); err == nil {
+		return time.Duration(secs * float64(time.Second)), true
+	}
+
+	return 0, true
+}
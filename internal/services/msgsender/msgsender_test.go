@@ -0,0 +1,138 @@
+package msgsender
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/zekroTJA/shinpuru/mocks"
+)
+
+func restError(status int, body string, header http.Header) error {
+	return &discordgo.RESTError{
+		Response:     &http.Response{StatusCode: status, Header: header},
+		ResponseBody: []byte(body),
+	}
+}
+
+func getSender(opts Options) (*Sender, *[]time.Duration) {
+	tp := &mocks.TimeProvider{}
+	tp.On("Now").Return(time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	var sleeps []time.Duration
+	s := newSender(opts, tp)
+	s.sleep = func(d time.Duration) {
+		sleeps = append(sleeps, d)
+	}
+	return s, &sleeps
+}
+
+// failing returns a function which fails with the
+// passed errors in order before succeeding.
+func failing(calls *int, errs ...error) func() error {
+	return func() error {
+		*calls++
+		if *calls <= len(errs) {
+			return errs[*calls-1]
+		}
+		return nil
+	}
+}
+
+func TestDo(t *testing.T) {
+	opts := Options{
+		MaxAttempts:  3,
+		InitialDelay: time.Second,
+		MaxDelay:     3 * time.Second,
+		DeadLetters:  10,
+	}
+
+	// ----- Success -----
+
+	s, sleeps := getSender(opts)
+	var calls int
+	assert.Nil(t, s.Do("g", "c", failing(&calls)))
+	assert.Equal(t, 1, calls)
+	assert.Empty(t, *sleeps)
+	assert.Empty(t, s.DeadLetters())
+
+	// ----- Transient errors with backoff -----
+
+	s, sleeps = getSender(opts)
+	calls = 0
+	assert.Nil(t, s.Do("g", "c", failing(&calls,
+		restError(http.StatusBadGateway, "", nil),
+		restError(http.StatusInternalServerError, "", nil))))
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second}, *sleeps)
+	assert.Empty(t, s.DeadLetters())
+
+	// ----- Attempts exhausted -----
+
+	s, sleeps = getSender(Options{MaxAttempts: 4, InitialDelay: 2 * time.Second, MaxDelay: 3 * time.Second})
+	calls = 0
+	errServer := restError(http.StatusServiceUnavailable, "", nil)
+	err := s.Do("g", "c", failing(&calls, errServer, errServer, errServer, errServer))
+	assert.Equal(t, errServer, err)
+	assert.Equal(t, 4, calls)
+	assert.Equal(t, []time.Duration{2 * time.Second, 3 * time.Second, 3 * time.Second}, *sleeps)
+	assert.Equal(t, []DeadLetter{{
+		Time:      time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+		GuildID:   "g",
+		ChannelID: "c",
+		Attempts:  4,
+		Err:       errServer.Error(),
+	}}, s.DeadLetters())
+
+	// ----- Permanent error -----
+
+	s, sleeps = getSender(opts)
+	calls = 0
+	errForbidden := restError(http.StatusForbidden, "", nil)
+	assert.Equal(t, errForbidden, s.Do("g", "c", failing(&calls, errForbidden)))
+	assert.Equal(t, 1, calls)
+	assert.Empty(t, *sleeps)
+	assert.Equal(t, 1, s.DeadLetters()[0].Attempts)
+
+	s, _ = getSender(opts)
+	calls = 0
+	assert.Error(t, s.Do("g", "c", failing(&calls, errors.New("some error"))))
+	assert.Equal(t, 1, calls)
+}
+
+func TestDoRateLimit(t *testing.T) {
+	opts := Options{
+		MaxAttempts:  3,
+		InitialDelay: time.Second,
+		MaxDelay:     5 * time.Second,
+	}
+
+	s, sleeps := getSender(opts)
+	var calls int
+	assert.Nil(t, s.Do("g", "c", failing(&calls,
+		&discordgo.RateLimitError{RateLimit: &discordgo.RateLimit{
+			TooManyRequests: &discordgo.TooManyRequests{RetryAfter: 1500 * time.Millisecond},
+		}},
+		restError(http.StatusTooManyRequests, `{"retry_after": 2.5}`, nil))))
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, []time.Duration{1500 * time.Millisecond, 2500 * time.Millisecond}, *sleeps)
+
+	s, sleeps = getSender(opts)
+	calls = 0
+	assert.Nil(t, s.Do("g", "c", failing(&calls,
+		restError(http.StatusTooManyRequests, "", http.Header{"Retry-After": {"3"}}))))
+	assert.Equal(t, []time.Duration{3 * time.Second}, *sleeps)
+
+	// Rate limits exceeding the maximum delay are not
+	// waited for.
+	s, sleeps = getSender(opts)
+	calls = 0
+	errRL := restError(http.StatusTooManyRequests, `{"retry_after": 60}`, nil)
+	assert.Equal(t, errRL, s.Do("g", "c", failing(&calls, errRL)))
+	assert.Equal(t, 1, calls)
+	assert.Empty(t, *sleeps)
+	assert.Len(t, s.DeadLetters(), 1)
+}
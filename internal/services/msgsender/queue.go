@@ -0,0 +1,123 @@
+package msgsender
+
+import (
+	"errors"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/zekroTJA/shinpuru/internal/services/metrics"
+	"github.com/zekroTJA/shinpuru/pkg/discordutil"
+)
+
+// ErrQueueFull is returned by Enqueue when the send
+// queue of the channel has reached its maximum size.
+var ErrQueueFull = errors.New("send queue of the channel is full")
+
+// Callback is called with the result of an enqueued
+// send after it has been processed.
+type Callback func(msg *discordgo.Message, err error)
+
+type queuedSend struct {
+	session discordutil.ISession
+	guildID string
+	data    *discordgo.MessageSend
+	cb      Callback
+}
+
+// Enqueue adds the passed message data to the send
+// queue of the given channel and returns without
+// waiting for the message to be sent.
+//
+// Messages of the same channel are sent one after
+// another in the order they have been enqueued using
+// Do. When a send is rate limited, following sends to
+// the channel are held back until the rate limit is
+// lifted.
+//
+// If cb is not nil, it is called with the result of
+// the send. Otherwise, failed sends are logged.
+func (s *Sender) Enqueue(
+	session discordutil.ISession,
+	guildID, channelID string,
+	data *discordgo.MessageSend,
+	cb Callback,
+) error {
+	s.queueMtx.Lock()
+	defer s.queueMtx.Unlock()
+
+	// A channel has a running worker as long as
+	// it is present in the queues map.
+	q, running := s.queues[channelID]
+	if s.opts.QueueSize > 0 && len(q) >= s.opts.QueueSize {
+		return ErrQueueFull
+	}
+
+	s.queues[channelID] = append(q, queuedSend{
+		session: session,
+		guildID: guildID,
+		data:    data,
+		cb:      cb,
+	})
+	metrics.DiscordSendQueueDepth.Inc()
+
+	if !running {
+		go s.work(channelID)
+	}
+
+	return nil
+}
+
+// EnqueueEmbed adds the passed embed to the send queue
+// of the given channel using Enqueue.
+func (s *Sender) EnqueueEmbed(
+	session discordutil.ISession,
+	guildID, channelID string,
+	emb *discordgo.MessageEmbed,
+	cb Callback,
+) error {
+	return s.Enqueue(session, guildID, channelID, &discordgo.MessageSend{
+		Embed: emb,
+	}, cb)
+}
+
+func (s *Sender) work(channelID string) {
+	for {
+		job, ok := s.dequeue(channelID)
+		if !ok {
+			return
+		}
+
+		msg, err := s.SendComplex(job.session, job.guildID, channelID, job.data)
+		if job.cb != nil {
+			job.cb(msg, err)
+		} else if err != nil {
+			s.log.Error().Err(err).
+				Fields("guildID", job.guildID, "channelID", channelID).
+				Msg("Failed sending queued message")
+		}
+
+		if wait, limited := rateLimitDelay(err); limited && wait > 0 {
+			s.sleep(wait)
+		}
+	}
+}
+
+// dequeue pops the next send from the queue of the given
+// channel. When the queue is empty, it is removed and
+// false is returned so that the worker can stop.
+func (s *Sender) dequeue(channelID string) (job queuedSend, ok bool) {
+	s.queueMtx.Lock()
+	defer s.queueMtx.Unlock()
+
+	q := s.queues[channelID]
+	if len(q) == 0 {
+		delete(s.queues, channelID)
+		return job, false
+	}
+
+	job = q[0]
+	q[0] = queuedSend{}
+	s.queues[channelID] = q[1:]
+	metrics.DiscordSendQueueDepth.Dec()
+
+	return job, true
+}
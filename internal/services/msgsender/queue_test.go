@@ -0,0 +1,105 @@
+package msgsender
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/zekroTJA/shinpuru/mocks"
+)
+
+type sendResult struct {
+	msg *discordgo.Message
+	err error
+}
+
+func collect(results chan<- sendResult) Callback {
+	return func(msg *discordgo.Message, err error) {
+		results <- sendResult{msg, err}
+	}
+}
+
+func TestEnqueue(t *testing.T) {
+	s, _ := getSender(Options{MaxAttempts: 1})
+
+	session := &mocks.ISession{}
+	session.On("ChannelMessageSendComplex", "c", mock.Anything).
+		Return(func(_ string, data *discordgo.MessageSend, _ ...discordgo.RequestOption) *discordgo.Message {
+			return &discordgo.Message{Content: data.Content}
+		}, nil)
+
+	results := make(chan sendResult, 3)
+	for _, content := range []string{"a", "b", "c"} {
+		err := s.Enqueue(session, "g", "c", &discordgo.MessageSend{Content: content}, collect(results))
+		assert.Nil(t, err)
+	}
+
+	// Messages of a channel are sent in order
+	for _, content := range []string{"a", "b", "c"} {
+		res := <-results
+		assert.Nil(t, res.err)
+		assert.Equal(t, content, res.msg.Content)
+	}
+}
+
+func TestEnqueueQueueFull(t *testing.T) {
+	s, _ := getSender(Options{MaxAttempts: 1, QueueSize: 1})
+
+	block := make(chan struct{})
+	session := &mocks.ISession{}
+	session.On("ChannelMessageSendComplex", "c", mock.Anything).
+		Run(func(mock.Arguments) { <-block }).
+		Return(&discordgo.Message{}, nil)
+
+	results := make(chan sendResult, 2)
+	data := &discordgo.MessageSend{}
+
+	// The first send is picked up by the worker, which
+	// then blocks, so that the second send stays queued.
+	assert.Nil(t, s.Enqueue(session, "g", "c", data, collect(results)))
+	assert.Eventually(t, func() bool {
+		s.queueMtx.Lock()
+		defer s.queueMtx.Unlock()
+		return len(s.queues["c"]) == 0
+	}, time.Second, time.Millisecond)
+	assert.Nil(t, s.Enqueue(session, "g", "c", data, collect(results)))
+	assert.ErrorIs(t, s.Enqueue(session, "g", "c", data, nil), ErrQueueFull)
+
+	close(block)
+	assert.Nil(t, (<-results).err)
+	assert.Nil(t, (<-results).err)
+
+	// The worker stops after the queue has been drained
+	assert.Eventually(t, func() bool {
+		s.queueMtx.Lock()
+		defer s.queueMtx.Unlock()
+		_, running := s.queues["c"]
+		return !running
+	}, time.Second, time.Millisecond)
+}
+
+func TestEnqueueRateLimit(t *testing.T) {
+	s, sleeps := getSender(Options{MaxAttempts: 3, MaxDelay: time.Second})
+
+	rlErr := restError(http.StatusTooManyRequests, `{"retry_after": 5}`, nil)
+	session := &mocks.ISession{}
+	session.On("ChannelMessageSendComplex", "c", &discordgo.MessageSend{Content: "limited"}).
+		Return(nil, rlErr)
+	session.On("ChannelMessageSendComplex", "c", &discordgo.MessageSend{Content: "ok"}).
+		Return(&discordgo.Message{}, nil)
+
+	results := make(chan sendResult, 2)
+	s.Enqueue(session, "g", "c", &discordgo.MessageSend{Content: "limited"}, collect(results))
+	s.Enqueue(session, "g", "c", &discordgo.MessageSend{Content: "ok"}, collect(results))
+
+	// The rate limit exceeds the maximum delay, so the send
+	// is given up, but the next send waits for the rate
+	// limit to be lifted.
+	assert.Equal(t, rlErr, (<-results).err)
+	assert.Nil(t, (<-results).err)
+	assert.Equal(t, []time.Duration{5 * time.Second}, *sleeps)
+	assert.Len(t, s.DeadLetters(), 1)
+}
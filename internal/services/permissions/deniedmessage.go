@@ -0,0 +1,44 @@
+package permissions
+
+import (
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	// DefaultDeniedMessage is responded when a user is not
+	// permitted to execute a command and no custom message
+	// is set for the guild.
+	DefaultDeniedMessage = "You are not permitted to use this command!"
+
+	// MaxDeniedMessageLength is the maximum length of a
+	// custom permission denied message template.
+	MaxDeniedMessageLength = 1000
+)
+
+// FormatDeniedMessage replaces the placeholders [user],
+// [ment], [cmd] and [perm] in the given template with
+// the user name, the user mention, the command name
+// and the required permission domain.
+//
+// If the template is empty, too long or results in an
+// empty message, DefaultDeniedMessage is returned.
+func FormatDeniedMessage(tmpl string, user *discordgo.User, cmd, domain string) string {
+	if len(tmpl) > MaxDeniedMessageLength {
+		return DefaultDeniedMessage
+	}
+
+	msg := strings.NewReplacer(
+		"[user]", user.Username,
+		"[ment]", user.Mention(),
+		"[cmd]", cmd,
+		"[perm]", "`"+domain+"`",
+	).Replace(tmpl)
+
+	if strings.TrimSpace(msg) == "" {
+		return DefaultDeniedMessage
+	}
+
+	return msg
+}
@@ -23,7 +23,7 @@ import (
 type Permissions struct {
 	db  database.Database
 	cfg config.Provider
-	st  *dgrs.State
+	st  dgrs.IState
 }
 
 var _ Provider = (*Permissions)(nil)
@@ -34,7 +34,7 @@ func NewPermissions(container di.Container) *Permissions {
 	return &Permissions{
 		db:  container.Get(static.DiDatabase).(database.Database),
 		cfg: container.Get(static.DiConfig).(config.Provider),
-		st:  container.Get(static.DiState).(*dgrs.State),
+		st:  container.Get(static.DiState).(dgrs.IState),
 	}
 }
 
@@ -64,7 +64,7 @@ func (m *Permissions) Before(ctx *ken.Ctx) (next bool, err error) {
 	}
 
 	if !ok {
-		err = ctx.RespondError("You are not permitted to use this command!", "Missing Permission")
+		err = m.respondDenied(ctx, cmd)
 		return
 	}
 
@@ -72,6 +72,26 @@ func (m *Permissions) Before(ctx *ken.Ctx) (next bool, err error) {
 	return
 }
 
+func (m *Permissions) respondDenied(ctx *ken.Ctx, cmd PermCommand) error {
+	tmpl, silent, err := m.db.GetGuildPermDeniedMessage(ctx.GetEvent().GuildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return err
+	}
+
+	// Silent denials are not responded to at all so
+	// that they do not spam the channel.
+	if silent {
+		return nil
+	}
+
+	msg := DefaultDeniedMessage
+	if tmpl != "" {
+		msg = FormatDeniedMessage(tmpl, ctx.User(), ctx.Command.Name(), cmd.Domain())
+	}
+
+	return ctx.RespondError(msg, "Missing Permission")
+}
+
 func (pmw *Permissions) HandleWs(s discordutil.ISession, required string) fiber.Handler {
 	if !stringutil.ContainsAny(required, static.AdditionalPermissions) {
 		static.AdditionalPermissions = append(static.AdditionalPermissions, required)
@@ -85,7 +105,9 @@ func (pmw *Permissions) HandleWs(s discordutil.ISession, required string) fiber.
 			return fiber.ErrForbidden
 		}
 
-		if guildID == "" {
+		// Routes without guild ID parameter are checked
+		// against the global permissions.
+		if guildID == "" && stringutil.ContainsAny("guildid", ctx.Route().Params) {
 			return errors.New("guildId is not set (this should actually not happen - " +
 				"if it does so, please create an issue including details where and how this " +
 				"missbehaviour occured)")
@@ -109,8 +131,28 @@ func (pmw *Permissions) HandleWs(s discordutil.ISession, required string) fiber.
 // which is true when the specified user is the bot owner,
 // guild owner or an admin of the guild.
 func (m *Permissions) GetPermissions(s discordutil.ISession, guildID, userID string) (perm permissions.PermissionArray, overrideExplicits bool, err error) {
+	var guildPerms map[string]permissions.PermissionArray
 	if guildID != "" {
-		perm, err = m.GetMemberPermission(s, guildID, userID)
+		guildPerms, err = m.db.GetGuildPermissions(guildID)
+		if err != nil && !database.IsErrDatabaseNotFound(err) {
+			return
+		}
+	}
+
+	return m.ResolvePermissions(s, guildID, userID, guildPerms)
+}
+
+// ResolvePermissions works like GetPermissions but uses the
+// passed guildPerms as permission rules of the guild roles
+// instead of the stored ones. This can be used to preview
+// the effect of permission changes before applying them.
+func (m *Permissions) ResolvePermissions(
+	s discordutil.ISession,
+	guildID, userID string,
+	guildPerms map[string]permissions.PermissionArray,
+) (perm permissions.PermissionArray, overrideExplicits bool, err error) {
+	if guildID != "" {
+		perm, err = m.memberPermission(s, guildID, userID, guildPerms)
 		if err != nil && !database.IsErrDatabaseNotFound(err) {
 			return
 		}
@@ -188,6 +230,18 @@ func (m *Permissions) GetMemberPermission(s discordutil.ISession, guildID string
 		return nil, err
 	}
 
+	return m.memberPermission(s, guildID, memberID, guildPerms)
+}
+
+func (m *Permissions) memberPermission(
+	s discordutil.ISession,
+	guildID, memberID string,
+	guildPerms map[string]permissions.PermissionArray,
+) (permissions.PermissionArray, error) {
+	if len(guildPerms) == 0 {
+		return nil, nil
+	}
+
 	membRoles, err := roleutil.GetSortedMemberRoles(s, guildID, memberID, false, true)
 	if err != nil {
 		return nil, err
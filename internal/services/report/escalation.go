@@ -0,0 +1,150 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/util/modnot"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/pkg/hammertime"
+)
+
+// escalationReportsLimit is the maximum number of the
+// latest reports of a user which are taken into account
+// when accumulating the points of the user.
+const escalationReportsLimit = 1000
+
+// GetUserPoints returns the current escalation points of
+// the given user on the given guild. enabled is false
+// if the escalation system is not enabled on the guild.
+func (r *ReportService) GetUserPoints(guildID, userID string) (points int, enabled bool, err error) {
+	esc, err := r.db.GetGuildReportEscalation(guildID)
+	if database.IsErrDatabaseNotFound(err) {
+		return 0, false, nil
+	}
+	if err != nil || !esc.Enabled {
+		return
+	}
+
+	reps, err := r.db.GetReportsFiltered(guildID, userID, -1, 0, escalationReportsLimit)
+	if err != nil {
+		return
+	}
+
+	return esc.Accumulate(reps, r.tp.Now()), true, nil
+}
+
+// escalate accumulates the points of the victim of the
+// given report and performs the action of the highest
+// threshold crossed by the report, if the escalation
+// system is enabled on the guild. The mods are notified
+// via the mod notification channel.
+//
+// Because the report itself has already been created,
+// errors are only logged and reported in the notification.
+func (r *ReportService) escalate(rep models.Report) {
+	esc, err := r.db.GetGuildReportEscalation(rep.GuildID)
+	if database.IsErrDatabaseNotFound(err) {
+		return
+	}
+	if err != nil {
+		r.log.Error().Err(err).Field("gid", rep.GuildID).Msg("Failed getting escalation config")
+		return
+	}
+	if !esc.Enabled || esc.Points[rep.Type] <= 0 {
+		return
+	}
+
+	reps, err := r.db.GetReportsFiltered(rep.GuildID, rep.VictimID, -1, 0, escalationReportsLimit)
+	if err != nil {
+		r.log.Error().Err(err).Field("gid", rep.GuildID).Msg("Failed getting reports for escalation")
+		return
+	}
+
+	previous := make([]models.Report, 0, len(reps))
+	for _, p := range reps {
+		if p.ID != rep.ID {
+			previous = append(previous, p)
+		}
+	}
+
+	now := r.tp.Now()
+	before := esc.Accumulate(previous, now)
+	after := esc.Accumulate(append(previous, rep), now)
+
+	th, ok := esc.Crossed(before, after)
+	if !ok {
+		return
+	}
+
+	result, err := r.performEscalation(rep, th, after)
+	if err != nil {
+		r.log.Error().Err(err).Fields("gid", rep.GuildID, "uid", rep.VictimID).Msg("Failed performing escalation action")
+		result = fmt.Sprintf("Failed performing %s: %s", th.Action, err.Error())
+	}
+
+	emb := &discordgo.MessageEmbed{
+		Color: static.ColorEmbedOrange,
+		Title: "Escalation Threshold Reached",
+		Description: fmt.Sprintf("<@%s> has reached **%d** points with case `%s` and crossed the threshold of **%d** points.",
+			rep.VictimID, after, rep.ID, th.Points),
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name:  "Action",
+				Value: result,
+			},
+		},
+	}
+
+	if err = modnot.Send(r.db, r.s, rep.GuildID, emb); err != nil {
+		r.log.Error().Err(err).Field("gid", rep.GuildID).Msg("Failed sending escalation notification")
+	}
+}
+
+// performEscalation executes the action of the given
+// threshold against the victim of the given report in
+// the name of the bot and returns a description of the
+// result. Reports created by escalation actions are not
+// escalated again.
+func (r *ReportService) performEscalation(rep models.Report, th models.EscalationThreshold, points int) (string, error) {
+	self, err := r.st.SelfUser()
+	if err != nil {
+		return "", err
+	}
+
+	actionRep := models.Report{
+		GuildID:    rep.GuildID,
+		ExecutorID: self.ID,
+		VictimID:   rep.VictimID,
+		Msg: fmt.Sprintf("Automatic escalation: reached %d points (threshold %d) with case %s.",
+			points, th.Points, rep.ID),
+	}
+	if th.Duration > 0 && th.Action != models.EscalationActionKick {
+		timeout := r.tp.Now().Add(th.GetDuration())
+		actionRep.Timeout = &timeout
+	}
+
+	switch th.Action {
+	case models.EscalationActionMute:
+		actionRep, err = r.pushMute(actionRep, false)
+	case models.EscalationActionKick:
+		actionRep, err = r.pushKick(actionRep, false)
+	case models.EscalationActionBan:
+		actionRep, err = r.pushBan(actionRep, false)
+	default:
+		err = fmt.Errorf("invalid escalation action '%s'", th.Action)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	result := fmt.Sprintf("%s (case `%s`)", strings.ToUpper(string(th.Action)), actionRep.ID)
+	if actionRep.Timeout != nil {
+		result += " until " + hammertime.Format(*actionRep.Timeout, hammertime.LongerDateTime)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,103 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/util/imgstore"
+	"github.com/zekroTJA/shinpuru/pkg/discordutil"
+	"github.com/zekroTJA/shinpuru/pkg/stringutil"
+	"github.com/zekroTJA/shinpuru/pkg/timeutil"
+)
+
+// maxMinimalReasonLen is the maximum length of the reason
+// displayed in minimal mod log entries.
+const maxMinimalReasonLen = 100
+
+// modLogEmbed returns the embed posted in the mod log
+// channel of the reports guild in the format set for
+// the guild.
+func (r *ReportService) modLogEmbed(rep models.Report, types models.ReportTypeSet) *discordgo.MessageEmbed {
+	publicAddr := r.cfg.Config().WebServer.PublicAddr
+
+	format, err := r.db.GetGuildModLogFormat(rep.GuildID)
+	if err != nil || !format.Validate() {
+		format = models.ModLogFormatDefault
+	}
+
+	switch format {
+	case models.ModLogFormatMinimal:
+		return r.modLogEmbedMinimal(rep, types)
+	case models.ModLogFormatDetailed:
+		return r.modLogEmbedDetailed(rep, types, publicAddr)
+	default:
+		return rep.AsEmbed(publicAddr, types)
+	}
+}
+
+func (r *ReportService) modLogEmbedMinimal(rep models.Report, types models.ReportTypeSet) *discordgo.MessageEmbed {
+	reason := stringutil.Truncate(strings.ReplaceAll(rep.Msg, "\n", " "), maxMinimalReasonLen)
+
+	return &discordgo.MessageEmbed{
+		Color: types.Color(rep.Type),
+		Description: fmt.Sprintf("**%s** <@%s> by <@%s>: %s (`%s`)",
+			types.Name(rep.Type), rep.VictimID, rep.ExecutorID, reason, rep.ID),
+	}
+}
+
+func (r *ReportService) modLogEmbedDetailed(
+	rep models.Report,
+	types models.ReportTypeSet,
+	publicAddr string,
+) *discordgo.MessageEmbed {
+	emb := rep.AsEmbed(publicAddr, types)
+
+	accountAge := "*unknown*"
+	if created, err := discordutil.GetDiscordSnowflakeCreationTime(rep.VictimID); err == nil {
+		accountAge = fmt.Sprintf("%s (<t:%d:d>)",
+			timeutil.FormatAge(r.tp.Now().Sub(created)), created.Unix())
+	}
+
+	priorReports := "*unknown*"
+	if n, err := r.priorReportCount(rep); err == nil {
+		priorReports = fmt.Sprint(n)
+	}
+
+	emb.Fields = append(emb.Fields,
+		&discordgo.MessageEmbedField{
+			Inline: true,
+			Name:   "Account Age",
+			Value:  accountAge,
+		},
+		&discordgo.MessageEmbedField{
+			Inline: true,
+			Name:   "Prior Reports",
+			Value:  priorReports,
+		},
+	)
+
+	if rep.AttachmentURL != "" {
+		emb.Fields = append(emb.Fields, &discordgo.MessageEmbedField{
+			Name:  "Attachment",
+			Value: fmt.Sprintf("[open](%s)", imgstore.GetLink(rep.AttachmentURL, publicAddr)),
+		})
+	}
+
+	return emb
+}
+
+// priorReportCount returns the number of reports of the
+// reports victim in the reports guild excluding the
+// passed report itself.
+func (r *ReportService) priorReportCount(rep models.Report) (int, error) {
+	n, err := r.db.GetReportsFilteredCount(rep.GuildID, rep.VictimID, -1)
+	if err != nil {
+		return 0, err
+	}
+	if n > 0 {
+		n--
+	}
+	return n, nil
+}
@@ -0,0 +1,84 @@
+package report
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/bwmarrin/snowflake"
+	"github.com/stretchr/testify/assert"
+	"github.com/zekroTJA/shinpuru/internal/models"
+)
+
+func TestModLogEmbed(t *testing.T) {
+	// The victim ID has been created at 2016-04-30 11:18:25 UTC
+	const victimID = "175928847299117063"
+
+	m := getReportMock(func(m reportMock) {
+		m.db.On("GetGuildModLogFormat", "guild-minimal").
+			Return(models.ModLogFormatMinimal, nil)
+		m.db.On("GetGuildModLogFormat", "guild-detailed").
+			Return(models.ModLogFormatDetailed, nil)
+		m.db.On("GetGuildModLogFormat", "guild-invalid").
+			Return(models.ModLogFormat("verbose"), nil)
+		m.db.On("GetReportsFilteredCount", "guild-detailed", victimID, -1).
+			Return(3, nil)
+		m.tp.On("Now").Return(time.Date(2018, 5, 31, 0, 0, 0, 0, time.UTC))
+	})
+
+	s, err := New(m.ct)
+	assert.Nil(t, err)
+
+	types := models.NewReportTypeSet(nil)
+	rep := models.Report{
+		ID:            snowflake.ParseInt64(1),
+		Type:          models.TypeWarn,
+		GuildID:       "guild-standard",
+		VictimID:      victimID,
+		ExecutorID:    "exec-id",
+		Msg:           "Some\nmessage",
+		AttachmentURL: "https://example.com/image.png",
+	}
+
+	// ----- Standard (default) -----
+
+	assert.Equal(t, rep.AsEmbed("", types), s.modLogEmbed(rep, types))
+
+	rep.GuildID = "guild-invalid"
+	assert.Equal(t, rep.AsEmbed("", types), s.modLogEmbed(rep, types))
+
+	// ----- Minimal -----
+
+	rep.GuildID = "guild-minimal"
+	assert.Equal(t, &discordgo.MessageEmbed{
+		Color:       types.Color(models.TypeWarn),
+		Description: "**WARN** <@175928847299117063> by <@exec-id>: Some message (`1`)",
+	}, s.modLogEmbed(rep, types))
+
+	// Long reasons are truncated by characters.
+	rep.Msg = strings.Repeat("ä", maxMinimalReasonLen+1)
+	assert.Equal(t,
+		"**WARN** <@175928847299117063> by <@exec-id>: "+strings.Repeat("ä", maxMinimalReasonLen-1)+"… (`1`)",
+		s.modLogEmbed(rep, types).Description)
+
+	// ----- Detailed -----
+
+	rep.GuildID = "guild-detailed"
+	emb := s.modLogEmbed(rep, types)
+	fields := emb.Fields[len(emb.Fields)-3:]
+	assert.Equal(t, "Account Age", fields[0].Name)
+	assert.Equal(t, "2 years, 30 days (<t:1462015105:d>)", fields[0].Value)
+	assert.Equal(t, "Prior Reports", fields[1].Name)
+	assert.Equal(t, "2", fields[1].Value)
+	assert.Equal(t, "Attachment", fields[2].Name)
+	assert.Equal(t, "[open](https://example.com/image.png)", fields[2].Value)
+	m.db.AssertCalled(t, "GetReportsFilteredCount", "guild-detailed", victimID, -1)
+
+	rep.VictimID = "invalid"
+	m.db.On("GetReportsFilteredCount", "guild-detailed", "invalid", -1).
+		Return(0, nil)
+	emb = s.modLogEmbed(rep, types)
+	assert.Equal(t, "*unknown*", emb.Fields[len(emb.Fields)-3].Value)
+	assert.Equal(t, "0", emb.Fields[len(emb.Fields)-2].Value)
+}
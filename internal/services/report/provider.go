@@ -8,12 +8,14 @@ import (
 
 type Provider interface {
 	PushReport(rep models.Report) (models.Report, error)
+	GetReportTypes(guildID string) (models.ReportTypeSet, error)
+	GetUserPoints(guildID, userID string) (points int, enabled bool, err error)
 	PushKick(rep models.Report) (models.Report, error)
 	PushBan(rep models.Report) (models.Report, error)
 	PushMute(rep models.Report) (models.Report, error)
 	RevokeMute(guildID, executorID, victimID, reason string) (emb *discordgo.MessageEmbed, err error)
 	RevokeReport(rep models.Report, executorID, reason,
-		wsPublicAddr string,
+		wsPublicAddr string, opts models.ReportRevokeOptions,
 	) (emb *discordgo.MessageEmbed, err error)
 	UnbanReport(
 		unbanReq models.UnbanRequest,
@@ -13,6 +13,7 @@ import (
 	"github.com/zekroTJA/shinpuru/internal/services/config"
 	"github.com/zekroTJA/shinpuru/internal/services/database"
 	"github.com/zekroTJA/shinpuru/internal/services/timeprovider"
+	"github.com/zekroTJA/shinpuru/internal/util/modlog"
 	"github.com/zekroTJA/shinpuru/internal/util/snowflakenodes"
 	"github.com/zekroTJA/shinpuru/internal/util/static"
 	"github.com/zekroTJA/shinpuru/pkg/discordutil"
@@ -26,9 +27,11 @@ import (
 )
 
 var (
-	ErrRoleDiff       = errors.New("You can only ban or kick members with lower permissions than yours.")
+	ErrRoleDiff       = roleutil.ErrActorRoleDiff
 	ErrMemberHasLeft  = errors.New("This user is no more a member of this guild.")
 	ErrInvalidTimeout = errors.New("timeout must be in the future")
+	ErrInvalidType    = errors.New("invalid report type")
+	ErrReportRevoked  = errors.New("report has already been revoked")
 )
 
 type ReportService struct {
@@ -52,6 +55,9 @@ func New(container di.Container) (t *ReportService, err error) {
 			return nil, err
 		}
 	}
+	if snowflakenodes.NodeCustomReports, err = snowflakenodes.RegisterNode(snowflakenodes.NodeIDCustomReports, "report.custom"); err != nil {
+		return nil, err
+	}
 
 	return &ReportService{
 		s:   container.Get(static.DiDiscordSession).(discordutil.ISession),
@@ -68,35 +74,76 @@ func New(container di.Container) (t *ReportService, err error) {
 // using the passed db databse rpovider and an embed is created with the attachment
 // url assembled with publicAddr as image endpoint root. This embed is then sent to
 // the specified mod log channel for this guild, if existent.
+// Afterwards, the escalation thresholds of the guild are
+// evaluated, if enabled.
 func (r *ReportService) PushReport(rep models.Report) (models.Report, error) {
-	repID := snowflakenodes.NodesReport[rep.Type].Generate()
+	rep, err := r.pushReport(rep)
+	if err != nil {
+		return models.Report{}, err
+	}
+
+	r.escalate(rep)
 
-	rep.ID = repID
+	return rep, nil
+}
+
+func (r *ReportService) pushReport(rep models.Report) (models.Report, error) {
+	types, err := r.GetReportTypes(rep.GuildID)
+	if err != nil {
+		return models.Report{}, err
+	}
+	if !types.Has(rep.Type) {
+		return models.Report{}, ErrInvalidType
+	}
+
+	if rep.Type.IsCustom() {
+		rep.ID = snowflakenodes.NodeCustomReports.Generate()
+	} else {
+		rep.ID = snowflakenodes.NodesReport[rep.Type].Generate()
+	}
 
-	err := r.db.AddReport(rep)
+	err = r.db.AddReport(rep)
 	if err != nil {
 		return models.Report{}, err
 	}
 
-	if modlogChan, err := r.db.GetGuildModLog(rep.GuildID); err == nil && modlogChan != "" {
-		_, err = r.s.ChannelMessageSendEmbed(modlogChan, rep.AsEmbed(r.cfg.Config().WebServer.PublicAddr))
+	if modlogChan, err := modlog.Channel(r.db, rep.GuildID, models.ModLogActionOf(rep.Type)); err == nil && modlogChan != "" {
+		_, err = r.s.ChannelMessageSendEmbed(modlogChan, r.modLogEmbed(rep, types))
 	}
 	if err != nil {
 		err = fmt.Errorf("failed sending message to modlog channel: %s", err)
 	}
 
+	if rep.Silent {
+		return rep, nil
+	}
+
 	dmChan, errDm := r.s.UserChannelCreate(rep.VictimID)
 	if errDm == nil && dmChan != nil {
-		r.s.ChannelMessageSendEmbed(dmChan.ID, rep.AsEmbed(r.cfg.Config().WebServer.PublicAddr))
+		r.s.ChannelMessageSendEmbed(dmChan.ID, rep.AsEmbed(r.cfg.Config().WebServer.PublicAddr, types))
 	}
 
 	return rep, nil
 }
 
+// GetReportTypes returns the set of built-in report types
+// merged with the custom report types of the given guild.
+func (r *ReportService) GetReportTypes(guildID string) (models.ReportTypeSet, error) {
+	custom, err := r.db.GetGuildReportTypes(guildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return nil, err
+	}
+	return models.NewReportTypeSet(custom), nil
+}
+
 // PushKick is shorthand for PushReport as member kick action and also
 // kicks the member from the guild with the given reason and case ID
 // for the audit log.
 func (r *ReportService) PushKick(rep models.Report) (models.Report, error) {
+	return r.pushKick(rep, true)
+}
+
+func (r *ReportService) pushKick(rep models.Report, escalate bool) (models.Report, error) {
 	const typ = 0
 	rep.Type = typ
 
@@ -118,12 +165,11 @@ func (r *ReportService) PushKick(rep models.Report) (models.Report, error) {
 		return models.Report{}, err
 	}
 
-	isAdmin := discordutil.IsAdmin(guild, executor)
-	if !isAdmin && roleutil.PositionDiff(victim, executor, guild) >= 0 {
-		return models.Report{}, ErrRoleDiff
+	if err = r.canModerate(guild, victim, executor); err != nil {
+		return models.Report{}, err
 	}
 
-	rep, err = r.PushReport(rep)
+	rep, err = r.pushReport(rep)
 	if err != nil {
 		return models.Report{}, err
 	}
@@ -133,6 +179,10 @@ func (r *ReportService) PushKick(rep models.Report) (models.Report, error) {
 		return models.Report{}, err
 	}
 
+	if escalate {
+		r.escalate(rep)
+	}
+
 	return rep, nil
 }
 
@@ -140,6 +190,10 @@ func (r *ReportService) PushKick(rep models.Report) (models.Report, error) {
 // bans the member from the guild with the given reason and case ID
 // for the audit log.
 func (r *ReportService) PushBan(rep models.Report) (models.Report, error) {
+	return r.pushBan(rep, true)
+}
+
+func (r *ReportService) pushBan(rep models.Report, escalate bool) (models.Report, error) {
 	const typ = 1
 	rep.Type = typ
 
@@ -170,14 +224,12 @@ func (r *ReportService) PushBan(rep models.Report) (models.Report, error) {
 	}
 
 	if !rep.Anonymous {
-		isAdmin := discordutil.IsAdmin(guild, executor)
-		diff := roleutil.PositionDiff(victim, executor, guild)
-		if !isAdmin && diff >= 0 {
-			return models.Report{}, ErrRoleDiff
+		if err = r.canModerate(guild, victim, executor); err != nil {
+			return models.Report{}, err
 		}
 	}
 
-	rep, err = r.PushReport(rep)
+	rep, err = r.pushReport(rep)
 	if err != nil {
 		return models.Report{}, err
 	}
@@ -187,12 +239,20 @@ func (r *ReportService) PushBan(rep models.Report) (models.Report, error) {
 		return models.Report{}, err
 	}
 
+	if escalate {
+		r.escalate(rep)
+	}
+
 	return rep, nil
 }
 
 // PushMute is shorthand for PushReport as member mute action and also
 // adds the mute role to the specified victim.
 func (r *ReportService) PushMute(rep models.Report) (models.Report, error) {
+	return r.pushMute(rep, true)
+}
+
+func (r *ReportService) pushMute(rep models.Report, escalate bool) (models.Report, error) {
 	const typ = 2
 	rep.Type = typ
 
@@ -218,17 +278,15 @@ func (r *ReportService) PushMute(rep models.Report) (models.Report, error) {
 		return models.Report{}, err
 	}
 
-	isAdmin := discordutil.IsAdmin(guild, executor)
-	diff := roleutil.PositionDiff(victim, executor, guild)
-	if !isAdmin && diff >= 0 {
-		return models.Report{}, ErrRoleDiff
+	if err = r.canModerate(guild, victim, executor); err != nil {
+		return models.Report{}, err
 	}
 
 	if rep.Msg == "" {
 		rep.Msg = "no reason specified"
 	}
 
-	rep, err = r.PushReport(rep)
+	rep, err = r.pushReport(rep)
 	if err != nil {
 		return models.Report{}, err
 	}
@@ -239,6 +297,10 @@ func (r *ReportService) PushMute(rep models.Report) (models.Report, error) {
 		return models.Report{}, err
 	}
 
+	if escalate {
+		r.escalate(rep)
+	}
+
 	return rep, nil
 }
 
@@ -260,10 +322,8 @@ func (r *ReportService) RevokeMute(guildID, executorID, victimID, reason string)
 		return nil, err
 	}
 
-	isAdmin := discordutil.IsAdmin(guild, executor)
-	diff := roleutil.PositionDiff(victim, executor, guild)
-	if !isAdmin && diff >= 0 {
-		return nil, ErrRoleDiff
+	if err = r.canModerate(guild, victim, executor); err != nil {
+		return nil, err
 	}
 
 	err = r.s.GuildMemberTimeout(guildID, victimID, nil)
@@ -308,7 +368,7 @@ func (r *ReportService) RevokeMute(guildID, executorID, victimID, reason string)
 		Timestamp: time.Unix(repID.Time()/1000, 0).Format(time.RFC3339),
 	}
 
-	if modlogChan, err := r.db.GetGuildModLog(guildID); err == nil {
+	if modlogChan, err := modlog.Channel(r.db, guildID, models.ModLogActionUnmute); err == nil && modlogChan != "" {
 		_, err = r.s.ChannelMessageSendEmbed(modlogChan, emb)
 	}
 	if err != nil {
@@ -323,27 +383,59 @@ func (r *ReportService) RevokeMute(guildID, executorID, victimID, reason string)
 	return
 }
 
+// RevokeReport revokes the given report. Depending on
+// the configuration, the report is either deleted or
+// kept with the revocation details for auditing.
+//
+// opts specify if the victim of a ban report is unbanned
+// and if the revocation is posted to the mod log. If the
+// report has already been revoked, ErrReportRevoked is
+// returned.
 func (r *ReportService) RevokeReport(
 	rep models.Report,
 	executorID string,
 	reason string,
 	wsPublicAddr string,
+	opts models.ReportRevokeOptions,
 ) (emb *discordgo.MessageEmbed, err error) {
 
-	if err = r.db.DeleteReport(rep.ID); err != nil {
+	if rep.Revocation != nil {
+		return nil, ErrReportRevoked
+	}
+
+	types, err := r.GetReportTypes(rep.GuildID)
+	if err != nil {
+		return
+	}
+
+	hardDelete := r.cfg.Config().Discord.Reports.HardDeleteRevoked
+	if hardDelete {
+		err = r.db.DeleteReport(rep.ID)
+	} else {
+		err = r.db.RevokeReport(rep.ID, models.ReportRevocation{
+			ExecutorID: executorID,
+			Reason:     reason,
+			Timestamp:  r.tp.Now(),
+		})
+	}
+	if err != nil {
 		return
 	}
 
-	if rep.Type == models.TypeBan {
-		if err = r.s.GuildBanDelete(rep.GuildID, rep.VictimID); err != nil {
+	if opts.Unban && rep.Type == models.TypeBan {
+		err = r.s.GuildBanDelete(rep.GuildID, rep.VictimID)
+		if err != nil && !discordutil.IsErrCode(err, discordgo.ErrCodeUnknownBan) {
 			return
 		}
+		err = nil
 	}
 
 	emb = &discordgo.MessageEmbed{
-		Color:       static.ReportRevokedColor,
-		Title:       "REPORT REVOCATION",
-		Description: "Revoked reports are deleted from the database and no more visible in any commands.",
+		Color: static.ReportRevokedColor,
+		Title: "REPORT REVOCATION",
+		Description: inline.II(hardDelete,
+			"Revoked reports are deleted from the database and no more visible in any commands.",
+			"Revoked reports are no more visible in any commands but are kept for auditing."),
 		Fields: []*discordgo.MessageEmbedField{
 			{
 				Name:  "Revoke Executor",
@@ -353,15 +445,14 @@ func (r *ReportService) RevokeReport(
 				Name:  "Revocation Reason",
 				Value: reason,
 			},
-			rep.AsEmbedField(wsPublicAddr),
+			rep.AsEmbedField(wsPublicAddr, types),
 		},
 	}
 
-	if modlogChan, err := r.db.GetGuildModLog(rep.GuildID); err == nil {
-		_, err = r.s.ChannelMessageSendEmbed(modlogChan, emb)
-	}
-	if err != nil {
-		err = fmt.Errorf("failed sending message to modlog channel: %s", err)
+	if opts.Modlog {
+		if modlogChan, err := modlog.Channel(r.db, rep.GuildID, models.ModLogActionRevoke); err == nil && modlogChan != "" {
+			_, err = r.s.ChannelMessageSendEmbed(modlogChan, emb)
+		}
 	}
 
 	dmChan, errDm := r.s.UserChannelCreate(rep.VictimID)
@@ -446,6 +537,22 @@ func (r *ReportService) revokeReportOnExpiration(rep models.Report) (err error)
 	return
 }
 
+// canModerate returns an error if the executor can not
+// moderate the victim; see roleutil.CanModerate.
+func (r *ReportService) canModerate(guild *discordgo.Guild, victim, executor *discordgo.Member) error {
+	self, err := r.st.SelfUser()
+	if err != nil {
+		return err
+	}
+
+	bot, err := r.st.Member(guild.ID, self.ID)
+	if err != nil {
+		return err
+	}
+
+	return roleutil.CanModerate(executor, victim, bot, guild)
+}
+
 func checkTimeout(now time.Time, t *time.Time) (err error) {
 	if t != nil && t.Before(now) {
 		err = ErrInvalidTimeout
@@ -16,6 +16,7 @@ import (
 	"github.com/zekroTJA/shinpuru/internal/util/static"
 	"github.com/zekroTJA/shinpuru/internal/util/testutil"
 	"github.com/zekroTJA/shinpuru/mocks"
+	"github.com/zekroTJA/shinpuru/pkg/roleutil"
 )
 
 func init() {
@@ -53,6 +54,22 @@ func getReportMock(prep ...func(m reportMock)) reportMock {
 		prep[0](t)
 	}
 
+	t.st.On("SelfUser").
+		Return(&discordgo.User{ID: "self-id"}, nil)
+	t.st.On("Member", mock.AnythingOfType("string"), "self-id").
+		Return(&discordgo.Member{
+			User:  &discordgo.User{ID: "self-id"},
+			Roles: []string{"role-bot"},
+		}, nil)
+
+	t.db.On("GetGuildReportTypes", mock.AnythingOfType("string")).
+		Return([]models.CustomReportType{}, nil)
+	t.db.On("GetGuildReportEscalation", mock.AnythingOfType("string")).
+		Return(models.ReportEscalation{}, database.ErrDatabaseNotFound)
+	t.db.On("GetGuildModLogFormat", mock.AnythingOfType("string")).
+		Return(models.ModLogFormat(""), database.ErrDatabaseNotFound)
+	t.db.On("GetGuildModLogRoute", mock.AnythingOfType("string"), mock.Anything).
+		Return("", database.ErrDatabaseNotFound)
 	t.cfg.On("Config").Return(&models.Config{})
 	t.tp.On("Now").Return(time.Time{})
 
@@ -89,6 +106,8 @@ func TestPushReport(t *testing.T) {
 	m := getReportMock(func(m reportMock) {
 		m.db.On("AddReport", mock.AnythingOfType("models.Report")).
 			Return(nil)
+		m.db.On("GetGuildModLogRoute", "guild-routed", models.ModLogActionWarn).
+			Return("channel-warns", nil)
 		m.db.On("GetGuildModLog", "guild-nomodlog-1").
 			Return("", database.ErrDatabaseNotFound)
 		m.db.On("GetGuildModLog", "guild-nomodlog-2").
@@ -128,9 +147,9 @@ func TestPushReport(t *testing.T) {
 	rep.ID = res.ID
 	assert.Equal(t, rep, res)
 	m.db.AssertCalled(t, "AddReport", rep)
-	m.s.AssertCalled(t, "ChannelMessageSendEmbed", "channel-modlog", rep.AsEmbed(""))
+	m.s.AssertCalled(t, "ChannelMessageSendEmbed", "channel-modlog", rep.AsEmbed("", nil))
 	m.s.AssertCalled(t, "UserChannelCreate", "victim-id")
-	m.s.AssertCalled(t, "ChannelMessageSendEmbed", "channel-id", rep.AsEmbed(""))
+	m.s.AssertCalled(t, "ChannelMessageSendEmbed", "channel-id", rep.AsEmbed("", nil))
 
 	// ----- Report Warn Victom with DM and NO Modlog -----
 
@@ -154,7 +173,7 @@ func TestPushReport(t *testing.T) {
 	m.s.AssertNotCalled(t, "ChannelMessageSendEmbed", "channel-modlog", mock.Anything)
 	m.s.AssertNotCalled(t, "ChannelMessageSendEmbed", "", mock.Anything)
 	m.s.AssertCalled(t, "UserChannelCreate", "victim-id")
-	m.s.AssertCalled(t, "ChannelMessageSendEmbed", "channel-id", rep.AsEmbed(""))
+	m.s.AssertCalled(t, "ChannelMessageSendEmbed", "channel-id", rep.AsEmbed("", nil))
 
 	m.s.Calls = nil
 
@@ -176,7 +195,7 @@ func TestPushReport(t *testing.T) {
 	m.s.AssertNotCalled(t, "ChannelMessageSendEmbed", "channel-modlog", mock.Anything)
 	m.s.AssertNotCalled(t, "ChannelMessageSendEmbed", "", mock.Anything)
 	m.s.AssertCalled(t, "UserChannelCreate", "victim-id")
-	m.s.AssertCalled(t, "ChannelMessageSendEmbed", "channel-id", rep.AsEmbed(""))
+	m.s.AssertCalled(t, "ChannelMessageSendEmbed", "channel-id", rep.AsEmbed("", nil))
 
 	// ----- Report Warn Victom with NO DM and Modlog -----
 
@@ -221,6 +240,189 @@ func TestPushReport(t *testing.T) {
 	m.s.AssertCalled(t, "ChannelMessageSendEmbed", "channel-modlog", mock.Anything)
 	m.s.AssertCalled(t, "UserChannelCreate", "victim-nodm-2")
 	m.s.AssertNotCalled(t, "ChannelMessageSendEmbed", "channel-id", mock.Anything)
+
+	// ----- Report Warn Victom silently -----
+
+	m.s.Calls = nil
+
+	rep = models.Report{
+		ID:         snowflake.ParseInt64(1),
+		Type:       models.TypeWarn,
+		GuildID:    "guild-1",
+		VictimID:   "victim-id",
+		ExecutorID: "exec-id",
+		Msg:        "Some message",
+		Silent:     true,
+	}
+	res, err = s.PushReport(rep)
+
+	assert.Nil(t, err)
+	m.s.AssertCalled(t, "ChannelMessageSendEmbed", "channel-modlog", mock.Anything)
+	m.s.AssertNotCalled(t, "UserChannelCreate", "victim-id")
+	m.s.AssertNotCalled(t, "ChannelMessageSendEmbed", "channel-id", mock.Anything)
+
+	// ----- Report Warn with routed Modlog -----
+
+	m.s.Calls = nil
+
+	rep = models.Report{
+		ID:         snowflake.ParseInt64(1),
+		Type:       models.TypeWarn,
+		GuildID:    "guild-routed",
+		VictimID:   "victim-id",
+		ExecutorID: "exec-id",
+		Msg:        "Some message",
+	}
+	res, err = s.PushReport(rep)
+
+	assert.Nil(t, err)
+	m.s.AssertCalled(t, "ChannelMessageSendEmbed", "channel-warns", mock.Anything)
+	m.s.AssertNotCalled(t, "ChannelMessageSendEmbed", "channel-modlog", mock.Anything)
+}
+
+func TestPushReportCustomType(t *testing.T) {
+	custom := models.CustomReportType{
+		GuildID: "guild-id",
+		ID:      models.CustomReportTypeOffset,
+		Name:    "verbal warning",
+		Color:   0x123456,
+	}
+
+	m := getReportMock(func(m reportMock) {
+		m.db.On("GetGuildReportTypes", "guild-id").
+			Return([]models.CustomReportType{custom}, nil)
+		m.db.On("AddReport", mock.AnythingOfType("models.Report")).
+			Return(nil)
+		m.db.On("GetGuildModLog", mock.AnythingOfType("string")).
+			Return("channel-modlog", nil)
+		m.s.On("UserChannelCreate", mock.AnythingOfType("string")).
+			Return(&discordgo.Channel{
+				ID: "channel-id",
+			}, nil)
+		m.s.On("ChannelMessageSendEmbed", mock.AnythingOfType("string"), mock.AnythingOfType("*discordgo.MessageEmbed")).
+			Return(nil, nil)
+	})
+
+	s, err := New(m.ct)
+	assert.Nil(t, err)
+
+	rep := models.Report{
+		Type:       custom.ID,
+		GuildID:    "guild-id",
+		VictimID:   "victim-id",
+		ExecutorID: "exec-id",
+		Msg:        "Some message",
+	}
+	res, err := s.PushReport(rep)
+
+	assert.Nil(t, err)
+	assert.Equal(t, int64(snowflakenodes.NodeIDCustomReports), res.ID.Node())
+	rep.ID = res.ID
+
+	emb := rep.AsEmbed("", models.NewReportTypeSet([]models.CustomReportType{custom}))
+	assert.Equal(t, custom.Color, emb.Color)
+	assert.Equal(t, custom.Name, emb.Fields[2].Value)
+	m.s.AssertCalled(t, "ChannelMessageSendEmbed", "channel-modlog", emb)
+
+	// ----- Unknown custom type of another guild -----
+
+	m.db.Calls = nil
+
+	rep.GuildID = "guild-other"
+	_, err = s.PushReport(rep)
+
+	assert.ErrorIs(t, err, ErrInvalidType)
+	m.db.AssertNotCalled(t, "AddReport", mock.Anything)
+}
+
+func TestPushReportEscalation(t *testing.T) {
+	esc := models.ReportEscalation{
+		GuildID: "guild-id",
+		Enabled: true,
+		Points:  map[models.ReportType]int{models.TypeWarn: 2},
+		Thresholds: []models.EscalationThreshold{
+			{Points: 3, Action: models.EscalationActionMute, Duration: 3600},
+		},
+	}
+
+	m := getReportMock(func(m reportMock) {
+		m.db.On("GetGuildReportEscalation", "guild-id").
+			Return(esc, nil)
+		m.db.On("GetGuildReportEscalation", "guild-disabled").
+			Return(models.ReportEscalation{GuildID: "guild-disabled"}, nil)
+		m.db.On("GetReportsFiltered", "guild-id", "victim-id", models.ReportType(-1), 0, escalationReportsLimit).
+			Return([]models.Report{
+				{ID: snowflake.ParseInt64(1), Type: models.TypeWarn, GuildID: "guild-id", VictimID: "victim-id"},
+			}, nil)
+		m.db.On("AddReport", mock.AnythingOfType("models.Report")).
+			Return(nil)
+		m.db.On("GetGuildModLog", mock.AnythingOfType("string")).
+			Return("channel-modlog", nil)
+		m.db.On("GetGuildModNot", mock.AnythingOfType("string")).
+			Return("channel-modnot", nil)
+
+		m.s.On("UserChannelCreate", mock.AnythingOfType("string")).
+			Return(&discordgo.Channel{
+				ID: "channel-id",
+			}, nil)
+		m.s.On("ChannelMessageSendEmbed", mock.AnythingOfType("string"), mock.AnythingOfType("*discordgo.MessageEmbed")).
+			Return(nil, nil)
+		m.s.On("GuildMemberTimeout", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("*time.Time")).
+			Return(nil)
+
+		m.st.On("SelfUser").
+			Return(&discordgo.User{ID: "self-id"}, nil)
+		m.st.On("Guild", mock.AnythingOfType("string"), mock.AnythingOfType("bool")).
+			Return(&discordgo.Guild{
+				ID: "guild-id",
+				Roles: []*discordgo.Role{
+					{ID: "role-0", Position: 0},
+					{ID: "role-1", Position: 1},
+				},
+			}, nil)
+		m.st.On("Member", "guild-id", "victim-id").
+			Return(&discordgo.Member{
+				User:  &discordgo.User{ID: "victim-id"},
+				Roles: []string{"role-0"},
+			}, nil)
+		m.st.On("Member", "guild-id", "self-id").
+			Return(&discordgo.Member{
+				User:  &discordgo.User{ID: "self-id"},
+				Roles: []string{"role-1"},
+			}, nil)
+	})
+
+	s, err := New(m.ct)
+	assert.Nil(t, err)
+
+	// ----- Threshold crossed -----
+
+	rep := models.Report{
+		Type:       models.TypeWarn,
+		GuildID:    "guild-id",
+		VictimID:   "victim-id",
+		ExecutorID: "exec-id",
+		Msg:        "Some message",
+	}
+	_, err = s.PushReport(rep)
+	assert.Nil(t, err)
+
+	m.s.AssertCalled(t, "GuildMemberTimeout", "guild-id", "victim-id", mock.AnythingOfType("*time.Time"))
+	m.db.AssertCalled(t, "AddReport", mock.MatchedBy(func(r models.Report) bool {
+		return r.Type == models.TypeMute && r.ExecutorID == "self-id" && r.Timeout != nil
+	}))
+	m.s.AssertCalled(t, "ChannelMessageSendEmbed", "channel-modnot", mock.AnythingOfType("*discordgo.MessageEmbed"))
+
+	// ----- Escalation disabled -----
+
+	m.Reset()
+
+	rep.GuildID = "guild-disabled"
+	_, err = s.PushReport(rep)
+	assert.Nil(t, err)
+
+	m.db.AssertNotCalled(t, "GetReportsFiltered", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	m.s.AssertNotCalled(t, "GuildMemberTimeout", mock.Anything, mock.Anything, mock.Anything)
 }
 
 func TestPushKick(t *testing.T) {
@@ -245,6 +447,7 @@ func TestPushKick(t *testing.T) {
 					{ID: "role-admin", Position: 0, Permissions: 0x8},
 					{ID: "role-0", Position: 0},
 					{ID: "role-1", Position: 1},
+					{ID: "role-bot", Position: 10},
 				},
 			}, nil)
 	})
@@ -437,6 +640,34 @@ func TestPushKick(t *testing.T) {
 	assert.EqualError(t, err, ErrMemberHasLeft.Error())
 	m.db.AssertNotCalled(t, "AddReport", mock.Anything)
 	m.s.AssertNotCalled(t, "GuildMemberDeleteWithReason", "guild-id", "victim-id", mock.AnythingOfType("string"))
+
+	// ----- Negative Test: Victim has higher role than the bot -----
+
+	m.Reset()
+
+	m.st.On("Member", "guild-id", "victim-id").
+		Once().
+		Return(&discordgo.Member{
+			User: &discordgo.User{
+				ID: "victim-id",
+			},
+			Roles: []string{"role-bot"},
+		}, nil)
+
+	m.st.On("Member", "guild-id", "executor-admin-id").
+		Once().
+		Return(&discordgo.Member{
+			User: &discordgo.User{
+				ID: "executor-admin-id",
+			},
+			Roles: []string{"role-admin"},
+		}, nil)
+
+	rep.ExecutorID = "executor-admin-id"
+	res, err = s.PushKick(rep)
+	assert.ErrorIs(t, err, roleutil.ErrBotRoleDiff)
+	m.db.AssertNotCalled(t, "AddReport", mock.Anything)
+	m.s.AssertNotCalled(t, "GuildMemberDeleteWithReason", "guild-id", "victim-id", mock.AnythingOfType("string"))
 }
 
 func TestPushBan(t *testing.T) {
@@ -461,6 +692,7 @@ func TestPushBan(t *testing.T) {
 					{ID: "role-admin", Position: 0, Permissions: 0x8},
 					{ID: "role-0", Position: 0},
 					{ID: "role-1", Position: 1},
+					{ID: "role-bot", Position: 10},
 				},
 			}, nil)
 	})
@@ -830,6 +1062,7 @@ func TestPushMute(t *testing.T) {
 					{ID: "role-admin", Position: 0, Permissions: 0x8},
 					{ID: "role-0", Position: 0},
 					{ID: "role-1", Position: 1},
+					{ID: "role-bot", Position: 10},
 				},
 			}, nil)
 	})
@@ -1078,6 +1311,7 @@ func TestRevokeMute(t *testing.T) {
 					{ID: "role-admin", Position: 0, Permissions: 0x8},
 					{ID: "role-0", Position: 0},
 					{ID: "role-1", Position: 1},
+					{ID: "role-bot", Position: 10},
 				},
 			}, nil)
 	})
@@ -1212,3 +1446,62 @@ func TestRevokeMute(t *testing.T) {
 	m.s.AssertCalled(t, "GuildMemberTimeout", "guild-id", "victim-id", testutil.Nil[time.Time]())
 	m.db.AssertNotCalled(t, "ExpireReports", "123")
 }
+
+func TestRevokeReport(t *testing.T) {
+	m := getReportMock(func(m reportMock) {
+		m.db.On("GetGuildModLog", mock.AnythingOfType("string")).
+			Return("channel-modlog", nil)
+		m.db.On("RevokeReport", mock.Anything, mock.Anything).Return(nil)
+
+		m.s.On("UserChannelCreate", mock.AnythingOfType("string")).
+			Return(&discordgo.Channel{
+				ID: "channel-id",
+			}, nil)
+		m.s.On("ChannelMessageSendEmbed", mock.AnythingOfType("string"), mock.AnythingOfType("*discordgo.MessageEmbed")).
+			Return(nil, nil)
+		m.s.On("GuildBanDelete", "guild-id", "victim-id").Return(nil)
+	})
+
+	rep := models.Report{
+		ID:       snowflake.ParseInt64(123),
+		Type:     models.TypeBan,
+		GuildID:  "guild-id",
+		VictimID: "victim-id",
+	}
+
+	// ----- Positive Test -----
+
+	s, err := New(m.ct)
+	assert.Nil(t, err)
+
+	emb, err := s.RevokeReport(rep, "executor-id", "reason", "", models.DefaultReportRevokeOptions)
+	assert.Nil(t, err)
+	m.db.AssertCalled(t, "RevokeReport", rep.ID, models.ReportRevocation{
+		ExecutorID: "executor-id",
+		Reason:     "reason",
+	})
+	m.db.AssertNotCalled(t, "DeleteReport", mock.Anything)
+	m.s.AssertCalled(t, "GuildBanDelete", "guild-id", "victim-id")
+	m.s.AssertCalled(t, "ChannelMessageSendEmbed", "channel-modlog", emb)
+
+	// ----- Positive Test: No side effects -----
+
+	m.Reset()
+
+	_, err = s.RevokeReport(rep, "executor-id", "reason", "", models.ReportRevokeOptions{})
+	assert.Nil(t, err)
+	m.db.AssertCalled(t, "RevokeReport", rep.ID, mock.Anything)
+	m.s.AssertNotCalled(t, "GuildBanDelete", mock.Anything, mock.Anything)
+	m.s.AssertNotCalled(t, "ChannelMessageSendEmbed", "channel-modlog", mock.Anything)
+
+	// ----- Negative Test: Already revoked -----
+
+	m.Reset()
+
+	revoked := rep
+	revoked.Revocation = &models.ReportRevocation{}
+	_, err = s.RevokeReport(revoked, "executor-id", "reason", "", models.DefaultReportRevokeOptions)
+	assert.ErrorIs(t, err, ErrReportRevoked)
+	m.db.AssertNotCalled(t, "RevokeReport", mock.Anything, mock.Anything)
+	m.s.AssertNotCalled(t, "GuildBanDelete", mock.Anything, mock.Anything)
+}
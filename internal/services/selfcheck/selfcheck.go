@@ -0,0 +1,179 @@
+// Package selfcheck provides checks of the dependencies
+// of shinpuru which are performed on startup.
+package selfcheck
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// DefaultTimeout is the duration after which a
+// check is considered as failed.
+const DefaultTimeout = 15 * time.Second
+
+// Status is the outcome of a check.
+type Status string
+
+const (
+	StatusOk   Status = "OK"
+	StatusWarn Status = "WARN"
+	StatusFail Status = "FAIL"
+)
+
+// Result is the result of a single check.
+type Result struct {
+	Name     string        `json:"name"`
+	Status   Status        `json:"status"`
+	Critical bool          `json:"critical"`
+	Message  string        `json:"message,omitempty"`
+	Took     time.Duration `json:"took"`
+}
+
+// Report contains the results of all checks.
+type Report struct {
+	Time    time.Time `json:"time"`
+	Results []Result  `json:"results"`
+	// Failed is true if any critical check failed.
+	Failed bool `json:"failed"`
+}
+
+// Status returns the worst status of all results.
+func (r Report) Status() Status {
+	status := StatusOk
+	for _, res := range r.Results {
+		switch res.Status {
+		case StatusFail:
+			return StatusFail
+		case StatusWarn:
+			status = StatusWarn
+		}
+	}
+	return status
+}
+
+// Table returns a human readable table of all
+// results of the report.
+func (r Report) Table() string {
+	var sb strings.Builder
+	w := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(w, "CHECK\tSTATUS\tTOOK\tMESSAGE")
+	for _, res := range r.Results {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
+			res.Name, res.Status, res.Took.Round(time.Millisecond), res.Message)
+	}
+	w.Flush()
+
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+type check struct {
+	name     string
+	critical bool
+	run      func() error
+}
+
+// SelfCheck performs the registered checks and
+// keeps the report of the last run.
+type SelfCheck struct {
+	timeout time.Duration
+
+	mtx    sync.RWMutex
+	checks []check
+	last   *Report
+}
+
+func New(timeout time.Duration) *SelfCheck {
+	return &SelfCheck{
+		timeout: timeout,
+	}
+}
+
+// Add registers a check with the given name. When a
+// critical check fails, its status is StatusFail.
+// Otherwise, a failure results in StatusWarn.
+func (s *SelfCheck) Add(name string, critical bool, run func() error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.checks = append(s.checks, check{
+		name:     name,
+		critical: critical,
+		run:      run,
+	})
+}
+
+// Run performs all registered checks in the order
+// they have been added and returns the report.
+func (s *SelfCheck) Run() Report {
+	s.mtx.RLock()
+	checks := s.checks
+	s.mtx.RUnlock()
+
+	report := Report{
+		Time:    time.Now(),
+		Results: make([]Result, 0, len(checks)),
+	}
+
+	for _, c := range checks {
+		res := s.run(c)
+		if res.Status == StatusFail {
+			report.Failed = true
+		}
+		report.Results = append(report.Results, res)
+	}
+
+	s.mtx.Lock()
+	s.last = &report
+	s.mtx.Unlock()
+
+	return report
+}
+
+// Last returns the report of the last run. False is
+// returned if no checks have been run yet.
+func (s *SelfCheck) Last() (Report, bool) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	if s.last == nil {
+		return Report{}, false
+	}
+	return *s.last, true
+}
+
+func (s *SelfCheck) run(c check) (res Result) {
+	res.Name = c.name
+	res.Critical = c.critical
+
+	start := time.Now()
+	errC := make(chan error, 1)
+	go func() {
+		errC <- c.run()
+	}()
+
+	var err error
+	select {
+	case err = <-errC:
+	case <-time.After(s.timeout):
+		err = fmt.Errorf("timed out after %s", s.timeout)
+	}
+	res.Took = time.Since(start)
+
+	switch {
+	case err == nil:
+		res.Status = StatusOk
+	case c.critical:
+		res.Status = StatusFail
+	default:
+		res.Status = StatusWarn
+	}
+	if err != nil {
+		res.Message = err.Error()
+	}
+
+	return
+}
@@ -0,0 +1,57 @@
+package selfcheck
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+	s := New(50 * time.Millisecond)
+
+	_, ok := s.Last()
+	assert.False(t, ok)
+
+	s.Add("ok", true, func() error { return nil })
+	s.Add("warn", false, func() error { return errors.New("unreachable") })
+
+	report := s.Run()
+	assert.False(t, report.Failed)
+	assert.Equal(t, StatusWarn, report.Status())
+	assert.Equal(t, StatusOk, report.Results[0].Status)
+	assert.Equal(t, StatusWarn, report.Results[1].Status)
+	assert.Equal(t, "unreachable", report.Results[1].Message)
+
+	s.Add("fail", true, func() error {
+		time.Sleep(time.Second)
+		return nil
+	})
+
+	report = s.Run()
+	assert.True(t, report.Failed)
+	assert.Equal(t, StatusFail, report.Status())
+	assert.Equal(t, StatusFail, report.Results[2].Status)
+	assert.Contains(t, report.Results[2].Message, "timed out")
+
+	last, ok := s.Last()
+	assert.True(t, ok)
+	assert.Equal(t, report, last)
+}
+
+func TestReportTable(t *testing.T) {
+	report := Report{
+		Results: []Result{
+			{Name: "database", Status: StatusOk, Took: 3 * time.Millisecond},
+			{Name: "redis", Status: StatusWarn, Message: "connection refused"},
+		},
+	}
+
+	lines := strings.Split(report.Table(), "\n")
+	assert.Len(t, lines, 3)
+	assert.True(t, strings.HasPrefix(lines[0], "CHECK"))
+	assert.Contains(t, lines[1], "database  OK")
+	assert.Contains(t, lines[2], "connection refused")
+}
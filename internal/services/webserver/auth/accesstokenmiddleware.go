@@ -1,10 +1,13 @@
 package auth
 
 import (
+	"errors"
 	"strings"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/sarulabs/di/v2"
+	"github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/services/timeprovider"
 	"github.com/zekroTJA/shinpuru/internal/util/static"
 	"github.com/zekroTJA/shinpuru/pkg/onetimeauth/v2"
 )
@@ -20,6 +23,7 @@ type AccessTokenMiddleware struct {
 	ath   AccessTokenHandler
 	apith APITokenHandler
 	ota   onetimeauth.OneTimeAuth
+	tp    timeprovider.Provider
 }
 
 // NewAccessTokenMiddleware initializes a new instance
@@ -29,6 +33,7 @@ func NewAccessTokenMiddleware(container di.Container) *AccessTokenMiddleware {
 		ath:   container.Get(static.DiAuthAccessTokenHandler).(AccessTokenHandler),
 		apith: container.Get(static.DiAuthAPITokenHandler).(APITokenHandler),
 		ota:   container.Get(static.DiOneTimeAuth).(onetimeauth.OneTimeAuth),
+		tp:    container.Get(static.DiTimeProvider).(timeprovider.Provider),
 	}
 }
 
@@ -62,6 +67,7 @@ func (m *AccessTokenMiddleware) Handle(ctx *fiber.Ctx) (err error) {
 		if ident, err = m.apith.ValidateAPIToken(split[1]); err != nil || ident == "" {
 			return fiber.ErrUnauthorized
 		}
+		return m.nextRecorded(ctx, ident)
 
 	default:
 		return fiber.ErrUnauthorized
@@ -83,6 +89,38 @@ func (m *AccessTokenMiddleware) checkOta(ctx *fiber.Ctx) (ident string, err erro
 	return
 }
 
+// nextRecorded executes the next handler and records
+// the request in the API token usage history of the
+// given ident afterwards.
+func (m *AccessTokenMiddleware) nextRecorded(ctx *fiber.Ctx, ident string) error {
+	err := next(ctx, ident)
+
+	status := ctx.Response().StatusCode()
+	if err != nil {
+		status = fiber.StatusInternalServerError
+		var fErr *fiber.Error
+		if errors.As(err, &fErr) {
+			status = fErr.Code
+		}
+	}
+
+	// The values are copied because the fiber context
+	// must not be accessed after the handler returned.
+	entry := models.APITokenUsageEntry{
+		Timestamp: m.tp.Now(),
+		Method:    strings.Clone(ctx.Method()),
+		Route:     ctx.Route().Path,
+		Status:    status,
+		IP:        strings.Clone(ctx.IP()),
+	}
+
+	// Recording includes a database update, so it is
+	// done asynchronously to not delay the response.
+	go m.apith.RecordUsage(ident, entry)
+
+	return err
+}
+
 func next(ctx *fiber.Ctx, ident string) error {
 	ctx.Locals("uid", ident)
 	return ctx.Next()
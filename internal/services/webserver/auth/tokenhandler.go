@@ -1,6 +1,10 @@
 package auth
 
-import "time"
+import (
+	"time"
+
+	"github.com/zekroTJA/shinpuru/internal/models"
+)
 
 // RefreshTokenHandler provides functionalities
 // to manage refresh tokens.
@@ -60,6 +64,17 @@ type APITokenHandler interface {
 	// and returned.
 	ValidateAPIToken(token string) (ident string, err error)
 
+	// RecordUsage adds the passed entry to the usage
+	// history of the API token linked to the passed
+	// ident and increments the hit counter of the
+	// token.
+	RecordUsage(ident string, entry models.APITokenUsageEntry)
+
+	// GetUsage returns the recent usage history of
+	// the API token linked to the passed ident
+	// ordered from the oldest to the newest entry.
+	GetUsage(ident string) []models.APITokenUsageEntry
+
 	// RevokeToken marks the token linked to the passed
 	// ident as invalid so it can not be validated
 	// anymore.
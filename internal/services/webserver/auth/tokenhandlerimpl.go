@@ -3,6 +3,7 @@ package auth
 import (
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
@@ -15,6 +16,8 @@ import (
 	"github.com/zekroTJA/shinpuru/internal/util/embedded"
 	"github.com/zekroTJA/shinpuru/internal/util/static"
 	"github.com/zekroTJA/shinpuru/pkg/random"
+	"github.com/zekroTJA/shinpuru/pkg/ringbuffer"
+	"github.com/zekrotja/rogu/log"
 )
 
 // DatabaseRefreshTokenHandler implements RefreshTokenHandler
@@ -170,6 +173,9 @@ type DatabaseAPITokenHandler struct {
 	session *discordgo.Session
 	secret  []byte
 	tp      timeprovider.Provider
+
+	usageMtx sync.Mutex
+	usage    map[string]*ringbuffer.RingBuffer[models.APITokenUsageEntry]
 }
 
 // NewDatabaseAPITokenHandler returns a new instance
@@ -183,6 +189,7 @@ func NewDatabaseAPITokenHandler(container di.Container) (*DatabaseAPITokenHandle
 		session: container.Get(static.DiDiscordSession).(*discordgo.Session),
 		tp:      container.Get(static.DiTimeProvider).(timeprovider.Provider),
 		secret:  secret,
+		usage:   make(map[string]*ringbuffer.RingBuffer[models.APITokenUsageEntry]),
 	}, nil
 }
 
@@ -220,6 +227,8 @@ func (apith *DatabaseAPITokenHandler) GetAPIToken(ident string) (token string, e
 		return
 	}
 
+	apith.clearUsage(ident)
+
 	return
 }
 
@@ -252,13 +261,46 @@ func (apith *DatabaseAPITokenHandler) ValidateAPIToken(token string) (ident stri
 		return "", err
 	}
 
-	tokenEntry.Hits++
-	tokenEntry.LastAccess = apith.tp.Now()
-	apith.db.SetAPIToken(tokenEntry)
-
 	return claims.Subject, nil
 }
 
+func (apith *DatabaseAPITokenHandler) RecordUsage(ident string, entry models.APITokenUsageEntry) {
+	apith.usageMtx.Lock()
+	history, ok := apith.usage[ident]
+	if !ok {
+		history = ringbuffer.New[models.APITokenUsageEntry](static.ApiTokenUsageHistory)
+		apith.usage[ident] = history
+	}
+	apith.usageMtx.Unlock()
+
+	history.Push(entry)
+
+	// The hits are incremented by the database so that
+	// concurrent requests do not overwrite each other.
+	if err := apith.db.AddAPITokenHit(ident, entry.Timestamp); err != nil {
+		log.Error().Tag("APITokenHandler").Err(err).Field("ident", ident).Msg("Failed updating API token hits")
+	}
+}
+
+func (apith *DatabaseAPITokenHandler) GetUsage(ident string) []models.APITokenUsageEntry {
+	apith.usageMtx.Lock()
+	history, ok := apith.usage[ident]
+	apith.usageMtx.Unlock()
+
+	if !ok {
+		return []models.APITokenUsageEntry{}
+	}
+
+	return history.Items()
+}
+
 func (apith *DatabaseAPITokenHandler) RevokeToken(ident string) error {
+	apith.clearUsage(ident)
 	return apith.db.DeleteAPIToken(ident)
 }
+
+func (apith *DatabaseAPITokenHandler) clearUsage(ident string) {
+	apith.usageMtx.Lock()
+	defer apith.usageMtx.Unlock()
+	delete(apith.usage, ident)
+}
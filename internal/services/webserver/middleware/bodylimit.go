@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type BodyLimitOptions struct {
+	// Limit is the maximum request body size in
+	// bytes for all routes.
+	Limit int
+	// Routes maps route path prefixes to maximum
+	// request body sizes in bytes overriding Limit.
+	// If multiple prefixes match a path, the longest
+	// one is used.
+	Routes map[string]int
+}
+
+// Max returns the largest body size limit of all
+// routes.
+//
+// This should be set as body limit of the underlying
+// HTTP server so that bodies exceeding every limit are
+// rejected before they are read completely.
+func (o BodyLimitOptions) Max() int {
+	max := o.Limit
+	for _, limit := range o.Routes {
+		if limit > max {
+			max = limit
+		}
+	}
+	return max
+}
+
+func (o BodyLimitOptions) limitFor(path string) int {
+	limit := o.Limit
+	longest := -1
+	for prefix, l := range o.Routes {
+		if len(prefix) > longest && strings.HasPrefix(path, prefix) {
+			limit = l
+			longest = len(prefix)
+		}
+	}
+	return limit
+}
+
+// NewBodyLimit returns a middleware which rejects
+// requests with 413 when their body exceeds the size
+// limit of the requested route.
+//
+// The announced Content-Length is checked as well as
+// the actual body size, so that chunked requests can
+// not bypass the limit.
+func NewBodyLimit(opt BodyLimitOptions) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		limit := opt.limitFor(ctx.Path())
+		if limit <= 0 {
+			return ctx.Next()
+		}
+
+		if ctx.Request().Header.ContentLength() > limit || len(ctx.Request().Body()) > limit {
+			return fiber.ErrRequestEntityTooLarge
+		}
+
+		return ctx.Next()
+	}
+}
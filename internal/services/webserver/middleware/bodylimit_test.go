@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/valyala/fasthttp"
+)
+
+func TestBodyLimit(t *testing.T) {
+	opt := BodyLimitOptions{
+		Limit: 10,
+		Routes: map[string]int{
+			"/upload":       100,
+			"/upload/small": 5,
+		},
+	}
+
+	assert.Equal(t, 100, opt.Max())
+
+	app := fiber.New(fiber.Config{BodyLimit: opt.Max()})
+	app.Use(NewBodyLimit(opt))
+	app.Post("/*", func(ctx *fiber.Ctx) error {
+		return ctx.SendStatus(fiber.StatusNoContent)
+	})
+
+	request := func(path string, size int, chunked bool) (int, error) {
+		req := httptest.NewRequest(fiber.MethodPost, "http://api.example.com"+path,
+			bytes.NewReader(make([]byte, size)))
+		if chunked {
+			req.ContentLength = 0
+			req.TransferEncoding = []string{"chunked"}
+		}
+		res, err := app.Test(req)
+		if err != nil {
+			return 0, err
+		}
+		return res.StatusCode, nil
+	}
+
+	assertStatus := func(status int, path string, size int, chunked bool) {
+		code, err := request(path, size, chunked)
+		assert.Nil(t, err)
+		assert.Equal(t, status, code)
+	}
+
+	assertStatus(fiber.StatusNoContent, "/reports", 10, false)
+	assertStatus(fiber.StatusRequestEntityTooLarge, "/reports", 11, false)
+	assertStatus(fiber.StatusRequestEntityTooLarge, "/reports", 11, true)
+
+	assertStatus(fiber.StatusNoContent, "/upload/image", 100, false)
+
+	assertStatus(fiber.StatusNoContent, "/upload/small", 5, false)
+	assertStatus(fiber.StatusRequestEntityTooLarge, "/upload/small", 6, false)
+	assertStatus(fiber.StatusRequestEntityTooLarge, "/upload/small", 6, true)
+
+	// Exceeding the limit of all routes is rejected by
+	// the server before the body is read. The test
+	// connection reports this as an error instead of
+	// returning the 413 response.
+	_, err := request("/upload/image", 101, false)
+	assert.ErrorIs(t, err, fasthttp.ErrBodyTooLarge)
+}
+
+func TestBodyLimitDisabled(t *testing.T) {
+	app := fiber.New()
+	app.Use(NewBodyLimit(BodyLimitOptions{}))
+	app.Post("/*", func(ctx *fiber.Ctx) error {
+		return ctx.SendStatus(fiber.StatusNoContent)
+	})
+
+	req := httptest.NewRequest(fiber.MethodPost, "http://api.example.com/reports", bytes.NewReader(make([]byte, 1000)))
+	res, err := app.Test(req)
+	assert.Nil(t, err)
+	assert.Equal(t, fiber.StatusNoContent, res.StatusCode)
+}
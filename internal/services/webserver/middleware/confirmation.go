@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/zekroTJA/shinpuru/pkg/onetimeauth/v2"
+)
+
+const (
+	// HeaderConfirmationToken is the header which must
+	// contain a valid confirmation token on routes
+	// protected by the confirmation middleware.
+	HeaderConfirmationToken = "X-Confirmation-Token"
+
+	// ConfirmationScope is the OTA scope confirmation
+	// tokens are issued for.
+	ConfirmationScope = "action-confirmation"
+)
+
+// NewConfirmation returns a middleware which requires a
+// valid confirmation OTA token issued for the requesting
+// user to be passed in the HeaderConfirmationToken header.
+// Because OTA tokens are only valid once and only for a
+// short time, each request on a protected route requires
+// a freshly obtained token.
+//
+// The middleware must be placed after the authorization
+// middleware.
+func NewConfirmation(ota onetimeauth.OneTimeAuth) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		uid, _ := ctx.Locals("uid").(string)
+		if uid == "" {
+			return fiber.ErrUnauthorized
+		}
+
+		token := ctx.Get(HeaderConfirmationToken)
+		if token == "" {
+			return fiber.NewError(fiber.StatusForbidden, "confirmation token required")
+		}
+
+		ident, err := ota.ValidateKey(token, ConfirmationScope)
+		if err != nil || ident != uid {
+			return fiber.NewError(fiber.StatusForbidden, "invalid confirmation token")
+		}
+
+		return ctx.Next()
+	}
+}
@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/zekroTJA/shinpuru/pkg/onetimeauth/v2"
+)
+
+func TestConfirmation(t *testing.T) {
+	ota, err := onetimeauth.NewJwt(&onetimeauth.JwtOptions{})
+	assert.Nil(t, err)
+
+	app := fiber.New()
+	app.Post("/action", func(ctx *fiber.Ctx) error {
+		ctx.Locals("uid", "user")
+		return ctx.Next()
+	}, NewConfirmation(ota), func(ctx *fiber.Ctx) error {
+		return ctx.SendStatus(fiber.StatusNoContent)
+	})
+
+	request := func(token string) int {
+		req := httptest.NewRequest(fiber.MethodPost, "/action", nil)
+		if token != "" {
+			req.Header.Set(HeaderConfirmationToken, token)
+		}
+		res, err := app.Test(req)
+		assert.Nil(t, err)
+		return res.StatusCode
+	}
+
+	assert.Equal(t, fiber.StatusForbidden, request(""))
+	assert.Equal(t, fiber.StatusForbidden, request("invalid"))
+
+	token, _, err := ota.GetKey("user", ConfirmationScope)
+	assert.Nil(t, err)
+	assert.Equal(t, fiber.StatusNoContent, request(token))
+	assert.Equal(t, fiber.StatusForbidden, request(token), "token must only be valid once")
+
+	token, _, err = ota.GetKey("other-user", ConfirmationScope)
+	assert.Nil(t, err)
+	assert.Equal(t, fiber.StatusForbidden, request(token))
+
+	token, _, err = ota.GetKey("user", "other-scope")
+	assert.Nil(t, err)
+	assert.Equal(t, fiber.StatusForbidden, request(token))
+}
@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	corsAllowMethods = "GET, POST, PUT, PATCH, DELETE, OPTIONS"
+	corsAllowHeaders = "authorization, content-type, set-cookie, cookie, server, x-confirmation-token"
+	corsMaxAge       = "3600"
+)
+
+// NewCORS returns a middleware which handles cross
+// origin requests from the given allowed origins.
+//
+// Requests carrying an Origin header which is not
+// in the allowlist are rejected with 403. Preflight
+// requests are answered directly so that they never
+// pass authorization middlewares.
+func NewCORS(allowOrigins []string) fiber.Handler {
+	allowed := make(map[string]struct{}, len(allowOrigins))
+	for _, o := range allowOrigins {
+		if o = strings.TrimSuffix(strings.ToLower(o), "/"); o != "" {
+			allowed[o] = struct{}{}
+		}
+	}
+
+	return func(ctx *fiber.Ctx) error {
+		ctx.Vary(fiber.HeaderOrigin)
+
+		origin := ctx.Get(fiber.HeaderOrigin)
+		if origin == "" || isSameOrigin(ctx, origin) {
+			return ctx.Next()
+		}
+
+		if _, ok := allowed[strings.ToLower(origin)]; !ok {
+			return fiber.NewError(fiber.StatusForbidden, "origin not allowed")
+		}
+
+		// Because credentials are allowed, the origin must
+		// be set explicitly instead of using a wildcard.
+		ctx.Set(fiber.HeaderAccessControlAllowOrigin, origin)
+		ctx.Set(fiber.HeaderAccessControlAllowCredentials, "true")
+
+		if ctx.Method() != fiber.MethodOptions {
+			return ctx.Next()
+		}
+
+		ctx.Set(fiber.HeaderAccessControlAllowMethods, corsAllowMethods)
+		ctx.Set(fiber.HeaderAccessControlAllowHeaders, corsAllowHeaders)
+		ctx.Set(fiber.HeaderAccessControlMaxAge, corsMaxAge)
+		return ctx.SendStatus(fiber.StatusNoContent)
+	}
+}
+
+func isSameOrigin(ctx *fiber.Ctx, origin string) bool {
+	u, err := url.Parse(origin)
+	return err == nil && strings.EqualFold(u.Host, ctx.Hostname())
+}
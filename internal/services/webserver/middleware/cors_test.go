@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCORS(t *testing.T) {
+	app := fiber.New()
+	app.Use(NewCORS([]string{"https://dashboard.example.com/"}))
+	app.Use(func(ctx *fiber.Ctx) error {
+		return fiber.ErrUnauthorized
+	})
+
+	request := func(method, origin string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "http://api.example.com/api/me", nil)
+		if origin != "" {
+			req.Header.Set(fiber.HeaderOrigin, origin)
+		}
+		res, err := app.Test(req)
+		assert.Nil(t, err)
+		rec := httptest.NewRecorder()
+		rec.Code = res.StatusCode
+		for k, v := range res.Header {
+			rec.Header()[k] = v
+		}
+		return rec
+	}
+
+	// Preflight of allowed origin does not hit the
+	// authorization middleware.
+	res := request(fiber.MethodOptions, "https://dashboard.example.com")
+	assert.Equal(t, fiber.StatusNoContent, res.Code)
+	assert.Equal(t, "https://dashboard.example.com", res.Header().Get(fiber.HeaderAccessControlAllowOrigin))
+	assert.Equal(t, "true", res.Header().Get(fiber.HeaderAccessControlAllowCredentials))
+	assert.NotEmpty(t, res.Header().Get(fiber.HeaderAccessControlAllowMethods))
+	assert.Contains(t, res.Header().Get(fiber.HeaderAccessControlAllowHeaders),
+		strings.ToLower(HeaderConfirmationToken))
+
+	res = request(fiber.MethodGet, "https://dashboard.example.com")
+	assert.Equal(t, fiber.StatusUnauthorized, res.Code)
+	assert.Equal(t, "https://dashboard.example.com", res.Header().Get(fiber.HeaderAccessControlAllowOrigin))
+
+	res = request(fiber.MethodOptions, "https://evil.example.com")
+	assert.Equal(t, fiber.StatusForbidden, res.Code)
+	assert.Empty(t, res.Header().Get(fiber.HeaderAccessControlAllowOrigin))
+
+	res = request(fiber.MethodGet, "http://api.example.com")
+	assert.Equal(t, fiber.StatusUnauthorized, res.Code)
+	assert.Empty(t, res.Header().Get(fiber.HeaderAccessControlAllowOrigin))
+
+	res = request(fiber.MethodGet, "")
+	assert.Equal(t, fiber.StatusUnauthorized, res.Code)
+}
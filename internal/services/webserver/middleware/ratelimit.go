@@ -0,0 +1,138 @@
+package middleware
+
+import (
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/zekroTJA/shinpuru/internal/services/config"
+	"github.com/zekroTJA/shinpuru/internal/services/kvcache"
+)
+
+type RateLimitOptions struct {
+	// Group is used to separate the buckets of
+	// different route groups.
+	Group string
+	// Burst is the maximum amount of requests
+	// which can be done at once.
+	Burst int
+	// Interval is the duration after which a
+	// request token is restored.
+	Interval time.Duration
+	// Skip is called before a request is limited.
+	// If it returns true, the request is passed
+	// without consuming a token.
+	Skip func(ctx *fiber.Ctx) bool
+}
+
+// NewRateLimit returns a token bucket rate limiter
+// middleware which stores its buckets in the passed
+// kvcache provider.
+//
+// Requests are identified by the user ID set by the
+// auth middleware, if it has been passed before, or
+// otherwise by the client IP. Authorization headers
+// are not taken into account before they have been
+// validated, so that clients can not bypass the limit
+// by passing random tokens. When the limit is
+// exceeded, 429 is returned with a Retry-After header.
+func NewRateLimit(kvc kvcache.Provider, opt RateLimitOptions) fiber.Handler {
+	b := &tokenBucket{
+		kvc:      kvc,
+		burst:    opt.Burst,
+		interval: opt.Interval,
+		now:      time.Now,
+	}
+
+	burstS := strconv.Itoa(opt.Burst)
+
+	return func(ctx *fiber.Ctx) error {
+		if opt.Skip != nil && opt.Skip(ctx) {
+			return ctx.Next()
+		}
+
+		ok, remaining, retryAfter := b.take("ratelimit:" + opt.Group + ":" + rateLimitKey(ctx))
+
+		ctx.Set("X-RateLimit-Limit", burstS)
+		ctx.Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+
+		if !ok {
+			ctx.Set(fiber.HeaderRetryAfter,
+				strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			return fiber.ErrTooManyRequests
+		}
+
+		return ctx.Next()
+	}
+}
+
+// NewRateLimitGroup returns a rate limiter middleware
+// for the given route group. If no specific config is
+// set for the group, the general rate limit config is
+// used. Requests of the bot owner are never limited.
+func NewRateLimitGroup(cfg config.Provider, kvc kvcache.Provider, group string) fiber.Handler {
+	c := cfg.Config()
+
+	rlc, ok := c.WebServer.RateLimitGroups[group]
+	if !ok {
+		rlc = c.WebServer.RateLimit
+	}
+
+	return NewRateLimit(kvc, RateLimitOptions{
+		Group:    group,
+		Burst:    rlc.Burst,
+		Interval: time.Duration(rlc.LimitSeconds) * time.Second,
+		Skip: func(ctx *fiber.Ctx) bool {
+			uid, _ := ctx.Locals("uid").(string)
+			return !rlc.Enabled || (uid != "" && uid == c.Discord.OwnerID)
+		},
+	})
+}
+
+func rateLimitKey(ctx *fiber.Ctx) string {
+	if uid, _ := ctx.Locals("uid").(string); uid != "" {
+		return "uid:" + uid
+	}
+
+	return "ip:" + ctx.IP()
+}
+
+// tokenBucket implements a token bucket using the
+// generic cell rate algorithm. For each key, only
+// the theoretical arrival time of the next request
+// is stored, which can be updated using the
+// increment primitive of the kvcache provider.
+type tokenBucket struct {
+	kvc      kvcache.Provider
+	burst    int
+	interval time.Duration
+	now      func() time.Time
+}
+
+// take tries to consume a token of the bucket
+// identified by key. It returns whether the token
+// was taken, the amount of remaining tokens and
+// the duration until the next token is available
+// if the bucket is exhausted.
+func (b *tokenBucket) take(key string) (ok bool, remaining int, retryAfter time.Duration) {
+	now := b.now().UnixNano()
+	interval := int64(b.interval)
+	capacity := int64(b.burst) * interval
+	lifetime := time.Duration(capacity)
+
+	tat := b.kvc.Incr(key, interval, lifetime)
+	if tat < now+interval {
+		// The bucket has been full, so the arrival time
+		// starts from now on.
+		tat = now + interval
+		b.kvc.Set(key, tat, lifetime)
+	}
+
+	if tat-now > capacity {
+		b.kvc.Incr(key, -interval, lifetime)
+		return false, 0, time.Duration(tat - now - capacity)
+	}
+
+	return true, int((capacity - (tat - now)) / interval), 0
+}
@@ -0,0 +1,164 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/services/kvcache"
+	"github.com/zekroTJA/shinpuru/mocks"
+)
+
+func newTestBucket(burst int, interval time.Duration) (*tokenBucket, *time.Time) {
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	b := &tokenBucket{
+		kvc:      kvcache.NewTimedmapCache(time.Minute),
+		burst:    burst,
+		interval: interval,
+		now:      func() time.Time { return now },
+	}
+	return b, &now
+}
+
+func TestTokenBucketExhaustion(t *testing.T) {
+	b, _ := newTestBucket(3, time.Second)
+
+	for i := 2; i >= 0; i-- {
+		ok, remaining, _ := b.take("a")
+		assert.True(t, ok)
+		assert.Equal(t, i, remaining)
+	}
+
+	ok, remaining, retryAfter := b.take("a")
+	assert.False(t, ok)
+	assert.Equal(t, 0, remaining)
+	assert.Equal(t, time.Second, retryAfter)
+
+	// Denied requests must not consume tokens.
+	ok, _, retryAfter = b.take("a")
+	assert.False(t, ok)
+	assert.Equal(t, time.Second, retryAfter)
+
+	// Other keys have their own buckets.
+	ok, _, _ = b.take("b")
+	assert.True(t, ok)
+}
+
+func TestTokenBucketRecovery(t *testing.T) {
+	b, now := newTestBucket(3, time.Second)
+
+	for i := 0; i < 3; i++ {
+		ok, _, _ := b.take("a")
+		assert.True(t, ok)
+	}
+
+	*now = now.Add(500 * time.Millisecond)
+	ok, _, retryAfter := b.take("a")
+	assert.False(t, ok)
+	assert.Equal(t, 500*time.Millisecond, retryAfter)
+
+	*now = now.Add(500 * time.Millisecond)
+	ok, remaining, _ := b.take("a")
+	assert.True(t, ok)
+	assert.Equal(t, 0, remaining)
+
+	ok, _, _ = b.take("a")
+	assert.False(t, ok)
+
+	// After a long time, the bucket is full again
+	// but not larger than the burst.
+	*now = now.Add(time.Hour)
+	for i := 2; i >= 0; i-- {
+		ok, remaining, _ := b.take("a")
+		assert.True(t, ok)
+		assert.Equal(t, i, remaining)
+	}
+	ok, _, _ = b.take("a")
+	assert.False(t, ok)
+}
+
+func TestRateLimitKey(t *testing.T) {
+	kvc := kvcache.NewTimedmapCache(time.Minute)
+	app := fiber.New()
+	app.Use(func(ctx *fiber.Ctx) error {
+		if uid := ctx.Get("X-Test-Uid"); uid != "" {
+			ctx.Locals("uid", uid)
+		}
+		return ctx.Next()
+	})
+	app.Use(NewRateLimit(kvc, RateLimitOptions{Group: "test", Burst: 1, Interval: time.Minute}))
+	app.Get("/", func(ctx *fiber.Ctx) error { return nil })
+
+	do := func(header, value string) int {
+		req := httptest.NewRequest("GET", "/", nil)
+		if header != "" {
+			req.Header.Set(header, value)
+		}
+		res, err := app.Test(req)
+		assert.Nil(t, err)
+		return res.StatusCode
+	}
+
+	assert.Equal(t, fiber.StatusOK, do("", ""))
+	assert.Equal(t, fiber.StatusTooManyRequests, do("", ""))
+
+	// Unvalidated authorization headers must not
+	// give the client a new bucket.
+	assert.Equal(t, fiber.StatusTooManyRequests, do(fiber.HeaderAuthorization, "Bearer random"))
+
+	assert.Equal(t, fiber.StatusOK, do("X-Test-Uid", "user"))
+	assert.Equal(t, fiber.StatusTooManyRequests, do("X-Test-Uid", "user"))
+}
+
+func TestNewRateLimitGroup(t *testing.T) {
+	cfg := &mocks.ConfigProvider{}
+	cfg.On("Config").Return(&models.Config{
+		Discord: models.Discord{OwnerID: "owner"},
+		WebServer: models.WebServer{
+			RateLimit: models.Ratelimit{Enabled: true, Burst: 2, LimitSeconds: 60},
+			RateLimitGroups: map[string]models.Ratelimit{
+				"strict":   {Enabled: true, Burst: 1, LimitSeconds: 60},
+				"disabled": {Enabled: false, Burst: 1, LimitSeconds: 60},
+			},
+		},
+	})
+	kvc := kvcache.NewTimedmapCache(time.Minute)
+
+	app := fiber.New()
+	app.Use(func(ctx *fiber.Ctx) error {
+		if uid := ctx.Get("X-Test-Uid"); uid != "" {
+			ctx.Locals("uid", uid)
+		}
+		return ctx.Next()
+	})
+	for _, group := range []string{"general", "strict", "disabled"} {
+		app.Get("/"+group, NewRateLimitGroup(cfg, kvc, group), func(ctx *fiber.Ctx) error { return nil })
+	}
+
+	do := func(path, uid string) int {
+		req := httptest.NewRequest("GET", path, nil)
+		req.Header.Set("X-Test-Uid", uid)
+		res, err := app.Test(req)
+		assert.Nil(t, err)
+		return res.StatusCode
+	}
+
+	// Groups without specific config use the general one.
+	assert.Equal(t, fiber.StatusOK, do("/general", "user"))
+	assert.Equal(t, fiber.StatusOK, do("/general", "user"))
+	assert.Equal(t, fiber.StatusTooManyRequests, do("/general", "user"))
+
+	assert.Equal(t, fiber.StatusOK, do("/strict", "user"))
+	assert.Equal(t, fiber.StatusTooManyRequests, do("/strict", "user"))
+
+	assert.Equal(t, fiber.StatusOK, do("/disabled", "user"))
+	assert.Equal(t, fiber.StatusOK, do("/disabled", "user"))
+
+	// The bot owner is never limited.
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, fiber.StatusOK, do("/strict", "owner"))
+	}
+}
@@ -0,0 +1,87 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/zekroTJA/shinpuru/internal/services/kvcache"
+	"github.com/zekroTJA/shinpuru/pkg/webhookauth"
+)
+
+// WebhookScheme specifies the signature scheme
+// of an inbound webhook integration.
+type WebhookScheme int
+
+const (
+	// WebhookSchemeGitHub verifies the sha256 HMAC
+	// signature of the raw body.
+	WebhookSchemeGitHub WebhookScheme = iota
+	// WebhookSchemeTwitch verifies the sha256 HMAC
+	// signature of the message ID, timestamp and
+	// raw body of a Twitch EventSub message. Messages
+	// which are too old or have already been received
+	// are rejected.
+	WebhookSchemeTwitch
+)
+
+// NewWebhookAuth returns a middleware which verifies the
+// HMAC signature of inbound webhook payloads using the
+// given scheme and secret. Requests with a missing or
+// invalid signature are rejected with 401 before they
+// are passed to the handler.
+//
+// The passed kvcache provider is used to remember the
+// IDs of received messages for schemes which support
+// replay protection.
+func NewWebhookAuth(scheme WebhookScheme, secret string, kvc kvcache.Provider) fiber.Handler {
+	return newWebhookAuth(scheme, secret, kvc, time.Now)
+}
+
+func newWebhookAuth(scheme WebhookScheme, secret string, kvc kvcache.Provider, now func() time.Time) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		var err error
+
+		switch scheme {
+		case WebhookSchemeGitHub:
+			err = webhookauth.Verify(secret,
+				ctx.Get(webhookauth.HeaderGitHub), ctx.Body())
+		case WebhookSchemeTwitch:
+			err = webhookauth.VerifyTwitch(secret,
+				ctx.Get(webhookauth.HeaderTwitchMessageID),
+				ctx.Get(webhookauth.HeaderTwitchTimestamp),
+				ctx.Get(webhookauth.HeaderTwitchSignature),
+				ctx.Body())
+			if err == nil {
+				err = webhookauth.CheckTimestamp(ctx.Get(webhookauth.HeaderTwitchTimestamp),
+					now(), webhookauth.MaxTwitchMessageAge)
+			}
+		default:
+			err = webhookauth.ErrUnsupportedScheme
+		}
+
+		if err != nil {
+			return fiber.NewError(fiber.StatusUnauthorized, err.Error())
+		}
+
+		if scheme != WebhookSchemeTwitch {
+			return ctx.Next()
+		}
+
+		// Messages are only remembered as long as their
+		// timestamp is accepted, older replays are
+		// rejected by the timestamp check.
+		key := "webhook:twitch:" + ctx.Get(webhookauth.HeaderTwitchMessageID)
+		if kvc.Incr(key, 1, webhookauth.MaxTwitchMessageAge) > 1 {
+			// Twitch expects duplicate messages to be
+			// acknowledged without processing them.
+			return ctx.SendStatus(fiber.StatusNoContent)
+		}
+
+		if err = ctx.Next(); err != nil {
+			// Allow Twitch to retry failed messages.
+			kvc.Del(key)
+		}
+
+		return err
+	}
+}
@@ -0,0 +1,91 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/zekroTJA/shinpuru/internal/services/kvcache"
+	"github.com/zekroTJA/shinpuru/pkg/webhookauth"
+)
+
+func TestWebhookAuth(t *testing.T) {
+	const secret = "secret"
+
+	app := fiber.New()
+	app.Post("/github", NewWebhookAuth(WebhookSchemeGitHub, secret, nil), func(ctx *fiber.Ctx) error {
+		return ctx.SendStatus(fiber.StatusNoContent)
+	})
+
+	body := []byte(`{"action":"opened"}`)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	sig := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	request := func(body []byte, sig string) int {
+		req := httptest.NewRequest(fiber.MethodPost, "/github", bytes.NewReader(body))
+		req.Header.Set(webhookauth.HeaderGitHub, sig)
+		res, err := app.Test(req)
+		assert.Nil(t, err)
+		return res.StatusCode
+	}
+
+	assert.Equal(t, fiber.StatusNoContent, request(body, sig))
+	assert.Equal(t, fiber.StatusUnauthorized, request([]byte(`{"action":"closed"}`), sig))
+	assert.Equal(t, fiber.StatusUnauthorized, request(body, ""))
+}
+
+func TestWebhookAuthTwitch(t *testing.T) {
+	const (
+		secret    = "secret"
+		msgID     = "e76c6bd4-55c9-4987-8304-da1588d8988b"
+		timestamp = "2022-01-01T00:00:00Z"
+	)
+
+	now := time.Date(2022, 1, 1, 0, 5, 0, 0, time.UTC)
+	kvc := kvcache.NewTimedmapCache(time.Minute)
+
+	var handled int
+	app := fiber.New()
+	app.Post("/twitch", newWebhookAuth(WebhookSchemeTwitch, secret, kvc, func() time.Time { return now }),
+		func(ctx *fiber.Ctx) error {
+			handled++
+			return ctx.SendStatus(fiber.StatusOK)
+		})
+
+	body := []byte(`{"subscription":{"type":"stream.online"}}`)
+	sign := func(msgID, timestamp string) string {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write([]byte(msgID + timestamp))
+		mac.Write(body)
+		return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	}
+
+	request := func(msgID, timestamp string) int {
+		req := httptest.NewRequest(fiber.MethodPost, "/twitch", bytes.NewReader(body))
+		req.Header.Set(webhookauth.HeaderTwitchMessageID, msgID)
+		req.Header.Set(webhookauth.HeaderTwitchTimestamp, timestamp)
+		req.Header.Set(webhookauth.HeaderTwitchSignature, sign(msgID, timestamp))
+		res, err := app.Test(req)
+		assert.Nil(t, err)
+		return res.StatusCode
+	}
+
+	assert.Equal(t, fiber.StatusOK, request(msgID, timestamp))
+	assert.Equal(t, 1, handled)
+
+	// Replayed messages are acknowledged but not handled.
+	assert.Equal(t, fiber.StatusNoContent, request(msgID, timestamp))
+	assert.Equal(t, 1, handled)
+
+	// Messages with an old timestamp are rejected.
+	now = now.Add(time.Hour)
+	assert.Equal(t, fiber.StatusUnauthorized, request("other-id", timestamp))
+	assert.Equal(t, 1, handled)
+}
@@ -31,7 +31,7 @@ type AuthController struct {
 	rth          auth.RefreshTokenHandler
 	ath          auth.AccessTokenHandler
 	authMw       auth.Middleware
-	st           *dgrs.State
+	st           dgrs.IState
 	session      *discordgo.Session
 	cmdHandler   *ken.Ken
 	oauthHandler auth.RequestHandler
@@ -83,7 +83,7 @@ func (c *AuthController) Setup(container di.Container, router fiber.Router) {
 	c.rth = container.Get(static.DiAuthRefreshTokenHandler).(auth.RefreshTokenHandler)
 	c.ath = container.Get(static.DiAuthAccessTokenHandler).(auth.AccessTokenHandler)
 	c.authMw = container.Get(static.DiAuthMiddleware).(auth.Middleware)
-	c.st = container.Get(static.DiState).(*dgrs.State)
+	c.st = container.Get(static.DiState).(dgrs.IState)
 	c.session = container.Get(static.DiDiscordSession).(*discordgo.Session)
 	c.oauthHandler = container.Get(static.DiOAuthHandler).(auth.RequestHandler)
 	c.cmdHandler = container.Get(static.DiCommandHandler).(*ken.Ken)
@@ -10,31 +10,46 @@ import (
 	"github.com/bwmarrin/discordgo"
 	"github.com/gofiber/fiber/v2"
 	"github.com/sarulabs/di/v2"
-	_ "github.com/zekroTJA/shinpuru/internal/services/backup/backupmodels"
+	"github.com/zekroTJA/shinpuru/internal/services/backup"
+	"github.com/zekroTJA/shinpuru/internal/services/backup/backupmodels"
 	"github.com/zekroTJA/shinpuru/internal/services/database"
 	"github.com/zekroTJA/shinpuru/internal/services/permissions"
 	"github.com/zekroTJA/shinpuru/internal/services/storage"
+	"github.com/zekroTJA/shinpuru/internal/services/timeprovider"
 	"github.com/zekroTJA/shinpuru/internal/services/webserver/v1/models"
 	"github.com/zekroTJA/shinpuru/internal/util/static"
 	"github.com/zekroTJA/shinpuru/pkg/onetimeauth/v2"
+	"github.com/zekrotja/dgrs"
 )
 
 type GuildBackupsController struct {
-	db  database.Database
-	st  storage.Storage
-	ota onetimeauth.OneTimeAuth
+	db    database.Database
+	st    storage.Storage
+	ota   onetimeauth.OneTimeAuth
+	bck   *backup.GuildBackups
+	tp    timeprovider.Provider
+	state dgrs.IState
 }
 
 func (c *GuildBackupsController) Setup(container di.Container, router fiber.Router) {
 	c.db = container.Get(static.DiDatabase).(database.Database)
 	c.st = container.Get(static.DiObjectStorage).(storage.Storage)
 	c.ota = container.Get(static.DiOneTimeAuth).(onetimeauth.OneTimeAuth)
+	c.bck = container.Get(static.DiBackupHandler).(*backup.GuildBackups)
+	c.tp = container.Get(static.DiTimeProvider).(timeprovider.Provider)
+	c.state = container.Get(static.DiState).(dgrs.IState)
 
 	session := container.Get(static.DiDiscordSession).(*discordgo.Session)
 	pmw := container.Get(static.DiPermissions).(*permissions.Permissions)
 
 	router.Get("", c.getBackups)
+	router.Post("", pmw.HandleWs(session, "sp.guild.admin.backup"), c.postBackup)
 	router.Post("/toggle", pmw.HandleWs(session, "sp.guild.admin.backup"), c.postToggleBackups)
+	router.Get("/diff", pmw.HandleWs(session, "sp.guild.admin.backup"), c.getDiff)
+	router.Get("/schedule", pmw.HandleWs(session, "sp.guild.admin.backup"), c.getSchedule)
+	router.Put("/schedule", pmw.HandleWs(session, "sp.guild.admin.backup"), c.putSchedule)
+	router.Delete("/schedule", pmw.HandleWs(session, "sp.guild.admin.backup"), c.deleteSchedule)
+	router.Delete("/:backupid", pmw.HandleWs(session, "sp.guild.admin.backup"), confirmation(container), c.deleteBackup)
 	router.Post("/:backupid/download", pmw.HandleWs(session, "sp.guild.admin.backup"), c.postDownloadBackup)
 	router.Get("/:backupid/download", c.getDownloadBackup)
 }
@@ -62,6 +77,56 @@ func (c *GuildBackupsController) getBackups(ctx *fiber.Ctx) error {
 	return ctx.JSON(models.NewListResponse(backupEntries))
 }
 
+// @Summary Create Guild Backup
+// @Description Creates a backup of the guild immediately and returns its entry. When the maximum number of backups is exceeded, the oldest backups are removed.
+// @Tags Guild Backups
+// @Accept json
+// @Produce json
+// @Param id path string true "The ID of the guild."
+// @Success 201 {object} backupmodels.Entry
+// @Failure 401 {object} models.Error
+// @Failure 403 {object} models.Error
+// @Failure 409 {object} models.Error
+// @Router /guilds/{id}/backups [post]
+func (c *GuildBackupsController) postBackup(ctx *fiber.Ctx) error {
+	guildID := ctx.Params("guildid")
+
+	entry, err := c.bck.BackupGuild(guildID)
+	if err == backup.ErrBackupRunning {
+		return fiber.NewError(fiber.StatusConflict, err.Error())
+	} else if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(entry)
+}
+
+// @Summary Delete Guild Backup
+// @Description Deletes a single guild backup.
+// @Tags Guild Backups
+// @Accept json
+// @Produce json
+// @Param id path string true "The ID of the guild."
+// @Param backupid path string true "The ID of the backup."
+// @Success 200 {object} models.Status
+// @Failure 401 {object} models.Error
+// @Failure 403 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Router /guilds/{id}/backups/{backupid} [delete]
+func (c *GuildBackupsController) deleteBackup(ctx *fiber.Ctx) error {
+	guildID := ctx.Params("guildid")
+	backupID := ctx.Params("backupid")
+
+	err := c.bck.DeleteBackup(guildID, backupID)
+	if database.IsErrDatabaseNotFound(err) {
+		return fiber.ErrNotFound
+	} else if err != nil {
+		return err
+	}
+
+	return ctx.JSON(models.Ok)
+}
+
 // @Summary Obtain Backup Download OTA Key
 // @Description Returns an OTA key which is used to download a backup entry.
 // @Tags Guild Backups
@@ -180,6 +245,137 @@ func (c *GuildBackupsController) postToggleBackups(ctx *fiber.Ctx) error {
 	return ctx.JSON(models.Ok)
 }
 
+// @Summary Get Guild Backup Diff
+// @Description Returns the added, removed and modified settings, roles and channels between two backups of the guild.
+// @Tags Guild Backups
+// @Accept json
+// @Produce json
+// @Param id path string true "The ID of the guild."
+// @Param from query string true "The ID of the older backup."
+// @Param to query string true "The ID of the newer backup."
+// @Success 200 {object} backupmodels.Diff
+// @Failure 400 {object} models.Error
+// @Failure 401 {object} models.Error
+// @Failure 403 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Router /guilds/{id}/backups/diff [get]
+func (c *GuildBackupsController) getDiff(ctx *fiber.Ctx) error {
+	guildID := ctx.Params("guildid")
+	fromID := ctx.Query("from")
+	toID := ctx.Query("to")
+
+	if fromID == "" || toID == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "from and to must be specified")
+	}
+
+	diff, err := c.bck.DiffBackups(guildID, fromID, toID)
+	if database.IsErrDatabaseNotFound(err) {
+		return fiber.ErrNotFound
+	} else if err != nil {
+		return err
+	}
+
+	return ctx.JSON(diff)
+}
+
+// @Summary Get Guild Backup Schedule
+// @Description Returns the backup schedule of the guild.
+// @Tags Guild Backups
+// @Accept json
+// @Produce json
+// @Param id path string true "The ID of the guild."
+// @Success 200 {object} backupmodels.Schedule
+// @Failure 401 {object} models.Error
+// @Failure 403 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Router /guilds/{id}/backups/schedule [get]
+func (c *GuildBackupsController) getSchedule(ctx *fiber.Ctx) error {
+	guildID := ctx.Params("guildid")
+
+	sched, err := c.db.GetBackupSchedule(guildID)
+	if database.IsErrDatabaseNotFound(err) {
+		return fiber.ErrNotFound
+	} else if err != nil {
+		return err
+	}
+
+	return ctx.JSON(sched)
+}
+
+// @Summary Set Guild Backup Schedule
+// @Description Sets the backup schedule of the guild and enables guild backups. The spec is either a standard cron spec in UTC or one of @daily and @weekly. Scheduled backups must be at least 6 hours apart. The notify channel must be a channel of the guild.
+// @Tags Guild Backups
+// @Accept json
+// @Produce json
+// @Param id path string true "The ID of the guild."
+// @Param payload body backupmodels.Schedule true "The backup schedule."
+// @Success 200 {object} backupmodels.Schedule
+// @Failure 400 {object} models.Error
+// @Failure 401 {object} models.Error
+// @Failure 403 {object} models.Error
+// @Router /guilds/{id}/backups/schedule [put]
+func (c *GuildBackupsController) putSchedule(ctx *fiber.Ctx) error {
+	guildID := ctx.Params("guildid")
+
+	var sched backupmodels.Schedule
+	if err := ctx.BodyParser(&sched); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if _, err := backupmodels.ParseScheduleSpec(sched.Spec, c.tp.Now()); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid spec: "+err.Error())
+	}
+	if sched.NotifyChannelID != "" {
+		ch, err := c.state.Channel(sched.NotifyChannelID)
+		if err != nil || ch.GuildID != guildID {
+			return fiber.NewError(fiber.StatusBadRequest, "notify channel not found")
+		}
+	}
+	if sched.Retention == 0 {
+		sched.Retention = backup.MaxBackups
+	}
+	if sched.Retention < 1 || sched.Retention > backup.MaxBackups {
+		return fiber.NewError(fiber.StatusBadRequest,
+			fmt.Sprintf("retention must be in range [1, %d]", backup.MaxBackups))
+	}
+
+	sched.GuildID = guildID
+	sched.LastRun = c.tp.Now()
+
+	if err := c.db.SetBackupSchedule(sched); err != nil {
+		return err
+	}
+	if err := c.db.SetGuildBackup(guildID, true); err != nil {
+		return err
+	}
+
+	return ctx.JSON(sched)
+}
+
+// @Summary Delete Guild Backup Schedule
+// @Description Removes the backup schedule of the guild. Backups are then created at the global backup times, if enabled.
+// @Tags Guild Backups
+// @Accept json
+// @Produce json
+// @Param id path string true "The ID of the guild."
+// @Success 200 {object} models.Status
+// @Failure 401 {object} models.Error
+// @Failure 403 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Router /guilds/{id}/backups/schedule [delete]
+func (c *GuildBackupsController) deleteSchedule(ctx *fiber.Ctx) error {
+	guildID := ctx.Params("guildid")
+
+	err := c.db.DeleteBackupSchedule(guildID)
+	if database.IsErrDatabaseNotFound(err) {
+		return fiber.ErrNotFound
+	} else if err != nil {
+		return err
+	}
+
+	return ctx.JSON(models.Ok)
+}
+
 // --- HELPERS ---
 
 func getBackupIdent(guildID, backupID string) string {
@@ -0,0 +1,113 @@
+package controllers
+
+import (
+	"github.com/bwmarrin/snowflake"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sarulabs/di/v2"
+	sharedmodels "github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/services/broadcast"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	mw "github.com/zekroTJA/shinpuru/internal/services/webserver/middleware"
+	"github.com/zekroTJA/shinpuru/internal/services/webserver/v1/models"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/pkg/onetimeauth/v2"
+)
+
+type BroadcastsController struct {
+	db database.Database
+	bc *broadcast.BroadcastService
+}
+
+func (c *BroadcastsController) Setup(container di.Container, router fiber.Router) {
+	c.db = container.Get(static.DiDatabase).(database.Database)
+	c.bc = container.Get(static.DiBroadcast).(*broadcast.BroadcastService)
+
+	ota := container.Get(static.DiOneTimeAuth).(onetimeauth.OneTimeAuth)
+
+	router.Use(ownerOnly(container))
+	router.Get("", c.getBroadcasts)
+	router.Post("", mw.NewConfirmation(ota), c.postBroadcast)
+	router.Get("/:id", c.getBroadcast)
+}
+
+// @Summary Get Broadcasts
+// @Description Returns the list of all broadcasts sent by the bot owner.
+// @Tags Broadcasts
+// @Accept json
+// @Produce json
+// @Success 200 {array} sharedmodels.Broadcast "Wrapped in models.ListResponse"
+// @Failure 401 {object} models.Error
+// @Failure 403 {object} models.Error
+// @Router /broadcasts [get]
+func (c *BroadcastsController) getBroadcasts(ctx *fiber.Ctx) error {
+	bcs, err := c.db.GetBroadcasts(false)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return err
+	}
+
+	return ctx.JSON(models.NewListResponse[sharedmodels.Broadcast](bcs))
+}
+
+// @Summary Get Broadcast Report
+// @Description Returns the delivery report of a broadcast.
+// @Tags Broadcasts
+// @Accept json
+// @Produce json
+// @Param id path string true "The ID of the broadcast."
+// @Success 200 {object} sharedmodels.BroadcastReport
+// @Failure 400 {object} models.Error
+// @Failure 401 {object} models.Error
+// @Failure 403 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Router /broadcasts/{id} [get]
+func (c *BroadcastsController) getBroadcast(ctx *fiber.Ctx) error {
+	id, err := snowflake.ParseString(ctx.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	var report sharedmodels.BroadcastReport
+	report, err = c.bc.Report(id)
+	if database.IsErrDatabaseNotFound(err) {
+		return fiber.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(report)
+}
+
+// @Summary Send Broadcast
+// @Description Sends a broadcast to all guilds. The broadcast is posted into the announcement channel of each guild or, if not set, sent to the guild owner via DM. The deliveries are throttled and performed in the background. This route always requires a confirmation token.
+// @Tags Broadcasts
+// @Accept json
+// @Produce json
+// @Param payload body models.BroadcastRequest true "The broadcast payload."
+// @Success 202 {object} sharedmodels.Broadcast
+// @Failure 400 {object} models.Error
+// @Failure 401 {object} models.Error
+// @Failure 403 {object} models.Error
+// @Failure 409 {object} models.Error
+// @Router /broadcasts [post]
+func (c *BroadcastsController) postBroadcast(ctx *fiber.Ctx) error {
+	uid := ctx.Locals("uid").(string)
+
+	var req models.BroadcastRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	bc, err := c.bc.Start(uid, req.Content, req.Force)
+	switch err {
+	case nil:
+	case broadcast.ErrEmpty:
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	case broadcast.ErrRunning, broadcast.ErrDuplicate:
+		return fiber.NewError(fiber.StatusConflict, err.Error())
+	default:
+		return err
+	}
+
+	return ctx.Status(fiber.StatusAccepted).JSON(bc)
+}
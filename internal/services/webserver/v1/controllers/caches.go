@@ -0,0 +1,146 @@
+package controllers
+
+import (
+	"sort"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sarulabs/di/v2"
+	"github.com/zekroTJA/shinpuru/internal/services/config"
+	"github.com/zekroTJA/shinpuru/internal/services/database/redis"
+	"github.com/zekroTJA/shinpuru/internal/services/kvcache"
+	mw "github.com/zekroTJA/shinpuru/internal/services/webserver/middleware"
+	"github.com/zekroTJA/shinpuru/internal/services/webserver/v1/models"
+	"github.com/zekroTJA/shinpuru/internal/services/webserver/wsutil"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/pkg/onetimeauth/v2"
+	"github.com/zekroTJA/shinpuru/pkg/redisguard"
+)
+
+const (
+	cacheNameKV       = "kv"
+	cacheNameDatabase = "database"
+)
+
+type CachesController struct {
+	cfg        config.Provider
+	caches     map[string]kvcache.Inspector
+	rdg        *redisguard.Guard
+	confirm    fiber.Handler
+	confirmAll fiber.Handler
+}
+
+func (c *CachesController) Setup(container di.Container, router fiber.Router) {
+	c.cfg = container.Get(static.DiConfig).(config.Provider)
+	c.rdg = container.Get(static.DiRedisGuard).(*redisguard.Guard)
+	c.confirm = confirmation(container)
+
+	// Flushing a whole cache always requires a confirmation,
+	// even if it is not enabled for other sensitive routes.
+	ota := container.Get(static.DiOneTimeAuth).(onetimeauth.OneTimeAuth)
+	c.confirmAll = mw.NewConfirmation(ota)
+
+	c.caches = map[string]kvcache.Inspector{
+		cacheNameKV: container.Get(static.DiKVCache).(kvcache.Provider),
+	}
+	if c.cfg.Config().Cache.CacheDatabase {
+		c.caches[cacheNameDatabase] = container.Get(static.DiDatabaseCache).(*redis.Cache)
+	}
+
+	router.Use(ownerOnly(container))
+	router.Get("", c.getCaches)
+	router.Get("/:name/keys", c.getCacheKeys)
+	router.Delete("/:name", c.confirmFlush, c.deleteCache)
+}
+
+// confirmFlush requires a confirmation for flushing a
+// whole cache and for flushing keys by prefix if
+// confirmation is enabled.
+func (c *CachesController) confirmFlush(ctx *fiber.Ctx) error {
+	if ctx.Query("prefix") == "" {
+		return c.confirmAll(ctx)
+	}
+	return c.confirm(ctx)
+}
+
+// @Summary Get Caches
+// @Description Returns the size and the hit and miss counts of all caches. The database cache is only listed if it is enabled.
+// @Tags Caches
+// @Accept json
+// @Produce json
+// @Success 200 {array} models.CacheInfo "Wrapped in models.ListResponse"
+// @Failure 401 {object} models.Error
+// @Failure 403 {object} models.Error
+// @Router /caches [get]
+func (c *CachesController) getCaches(ctx *fiber.Ctx) error {
+	res := make([]models.CacheInfo, 0, len(c.caches))
+	for name, cache := range c.caches {
+		res = append(res, models.CacheInfo{
+			Stats:     cache.Stats(),
+			Name:      name,
+			Available: name != cacheNameDatabase || c.rdg.Available(),
+		})
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Name < res[j].Name
+	})
+
+	return ctx.JSON(models.NewListResponse(res))
+}
+
+// @Summary Get Cache Keys
+// @Description Returns the keys of the given cache starting with the given prefix.
+// @Tags Caches
+// @Accept json
+// @Produce json
+// @Param name path string true "The name of the cache."
+// @Param prefix query string false "The prefix of the keys."
+// @Param limit query int false "The maximum amount of returned keys." default(100)
+// @Success 200 {array} string "Wrapped in models.ListResponse"
+// @Failure 401 {object} models.Error
+// @Failure 403 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Router /caches/{name}/keys [get]
+func (c *CachesController) getCacheKeys(ctx *fiber.Ctx) error {
+	cache, ok := c.caches[ctx.Params("name")]
+	if !ok {
+		return fiber.ErrNotFound
+	}
+
+	limit, err := wsutil.GetQueryInt(ctx, "limit", 100, 1, 1000)
+	if err != nil {
+		return err
+	}
+
+	keys := cache.Keys(ctx.Query("prefix"))
+	sort.Strings(keys)
+	if len(keys) > limit {
+		keys = keys[:limit]
+	}
+
+	return ctx.JSON(models.NewListResponse(keys))
+}
+
+// @Summary Flush Cache
+// @Description Removes all keys starting with the given prefix from the given cache. When no prefix is given, the whole cache is flushed, which always requires a confirmation token. Otherwise, a confirmation token is required if confirmation is enabled. Flushing the database cache only affects keys set by the database cache.
+// @Tags Caches
+// @Accept json
+// @Produce json
+// @Param X-Confirmation-Token header string false "Confirmation token obtained via /ota/confirmation. Required if no prefix is given or confirmation is enabled."
+// @Param name path string true "The name of the cache."
+// @Param prefix query string false "The prefix of the keys to be removed."
+// @Success 200 {object} models.CacheFlushResult
+// @Failure 401 {object} models.Error
+// @Failure 403 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Router /caches/{name} [delete]
+func (c *CachesController) deleteCache(ctx *fiber.Ctx) error {
+	cache, ok := c.caches[ctx.Params("name")]
+	if !ok {
+		return fiber.ErrNotFound
+	}
+
+	n := cache.FlushPrefix(ctx.Query("prefix"))
+
+	return ctx.JSON(models.CacheFlushResult{Flushed: n})
+}
@@ -19,14 +19,14 @@ import (
 
 type ChannelController struct {
 	session *discordgo.Session
-	st      *dgrs.State
+	st      dgrs.IState
 	pmw     *permissions.Permissions
 	kv      kvcache.Provider
 }
 
 func (c *ChannelController) Setup(container di.Container, router fiber.Router) {
 	c.session = container.Get(static.DiDiscordSession).(*discordgo.Session)
-	c.st = container.Get(static.DiState).(*dgrs.State)
+	c.st = container.Get(static.DiState).(dgrs.IState)
 	c.pmw = container.Get(static.DiPermissions).(*permissions.Permissions)
 	c.kv = container.Get(static.DiKVCache).(kvcache.Provider)
 
@@ -0,0 +1,67 @@
+package controllers
+
+import (
+	"io"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sarulabs/di/v2"
+	"github.com/zekroTJA/shinpuru/internal/services/codeexec"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/services/storage"
+	"github.com/zekroTJA/shinpuru/internal/services/webserver/auth"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+)
+
+type CodeExecOutputController struct {
+	st  storage.Storage
+	rth auth.RefreshTokenHandler
+}
+
+func (c *CodeExecOutputController) Setup(container di.Container, router fiber.Router) {
+	c.st = container.Get(static.DiObjectStorage).(storage.Storage)
+	c.rth = container.Get(static.DiAuthRefreshTokenHandler).(auth.RefreshTokenHandler)
+
+	router.Get("/:id", c.getOutput)
+}
+
+// getOutput returns the uploaded output with the given
+// ID. Because output links are opened in the browser,
+// the user is identified by the session refresh token
+// cookie. Outputs are only returned to the user who
+// executed the code.
+func (c *CodeExecOutputController) getOutput(ctx *fiber.Ctx) error {
+	outputID := ctx.Params("id")
+	if !codeexec.IsValidOutputID(outputID) {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid output ID")
+	}
+
+	refreshToken := ctx.Cookies(static.RefreshTokenCookieName)
+	if refreshToken == "" {
+		return fiber.ErrUnauthorized
+	}
+
+	uid, err := c.rth.ValidateRefreshToken(refreshToken)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return err
+	}
+	if uid == "" {
+		return fiber.ErrUnauthorized
+	}
+
+	reader, _, err := c.st.GetObject(static.StorageBucketCodeExec, codeexec.OutputObjectName(uid, outputID))
+	if err != nil {
+		return fiber.ErrNotFound
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+
+	ctx.Set("Content-Type", "text/plain; charset=utf-8")
+	// Outputs are never changed after upload but must
+	// not be stored by shared caches.
+	ctx.Set("Cache-Control", "private, max-age=2592000, immutable")
+	return ctx.Send(data)
+}
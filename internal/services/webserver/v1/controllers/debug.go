@@ -13,7 +13,7 @@ type DebugController struct {
 }
 
 func (c *DebugController) Setup(container di.Container, router fiber.Router) {
-	c.st = container.Get(static.DiState).(*dgrs.State)
+	c.st = container.Get(static.DiState).(dgrs.IState)
 
 	router.Get("", c.get)
 }
@@ -1,58 +1,78 @@
 package controllers
 
 import (
-	"context"
-	"fmt"
-	"runtime"
-	"strconv"
+	"strings"
 	"sync/atomic"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
-	"github.com/go-redis/redis/v8"
 	"github.com/gofiber/fiber/v2"
 	"github.com/sarulabs/di/v2"
 	"github.com/zekroTJA/shinpuru/internal/models"
 	_ "github.com/zekroTJA/shinpuru/internal/models"
 	"github.com/zekroTJA/shinpuru/internal/services/config"
 	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/services/kvcache"
+	"github.com/zekroTJA/shinpuru/internal/services/permissions"
+	"github.com/zekroTJA/shinpuru/internal/services/selfcheck"
 	"github.com/zekroTJA/shinpuru/internal/services/storage"
 	"github.com/zekroTJA/shinpuru/internal/services/webserver/auth"
+	mw "github.com/zekroTJA/shinpuru/internal/services/webserver/middleware"
 	apiModels "github.com/zekroTJA/shinpuru/internal/services/webserver/v1/models"
+	"github.com/zekroTJA/shinpuru/internal/services/webserver/wsutil"
 	"github.com/zekroTJA/shinpuru/internal/util"
-	"github.com/zekroTJA/shinpuru/internal/util/embedded"
 	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/internal/util/sysinfo"
 	"github.com/zekroTJA/shinpuru/pkg/discordutil"
+	"github.com/zekroTJA/shinpuru/pkg/redisguard"
 	"github.com/zekrotja/dgrs"
 	"github.com/zekrotja/ken"
 )
 
+// userGuildsCacheLifetime is the duration the guilds
+// of a user including their permission levels are
+// cached for.
+const userGuildsCacheLifetime = 1 * time.Minute
+
 type EtcController struct {
 	session    *discordgo.Session
 	cfg        config.Provider
 	authMw     auth.Middleware
-	st         *dgrs.State
+	st         dgrs.IState
 	storage    storage.Storage
 	db         database.Database
 	cmdHandler *ken.Ken
-	rd         *redis.Client
+	rdg        *redisguard.Guard
+	sc         *selfcheck.SelfCheck
+	pmw        permissions.Provider
+	kvc        kvcache.Provider
 }
 
 func (c *EtcController) Setup(container di.Container, router fiber.Router) {
 	c.session = container.Get(static.DiDiscordSession).(*discordgo.Session)
 	c.cfg = container.Get(static.DiConfig).(config.Provider)
 	c.authMw = container.Get(static.DiAuthMiddleware).(auth.Middleware)
-	c.st = container.Get(static.DiState).(*dgrs.State)
+	c.st = container.Get(static.DiState).(dgrs.IState)
 	c.storage = container.Get(static.DiObjectStorage).(storage.Storage)
 	c.db = container.Get(static.DiDatabase).(database.Database)
 	c.cmdHandler = container.Get(static.DiCommandHandler).(*ken.Ken)
-	c.rd = container.Get(static.DiRedis).(*redis.Client)
+	c.rdg = container.Get(static.DiRedisGuard).(*redisguard.Guard)
+	c.sc = container.Get(static.DiSelfCheck).(*selfcheck.SelfCheck)
+	c.pmw = container.Get(static.DiPermissions).(permissions.Provider)
+	c.kvc = container.Get(static.DiKVCache).(kvcache.Provider)
+
+	// These routes are mounted on the root router, so the
+	// rate limiter is passed to each route except the
+	// health check, which must never be limited.
+	limit := mw.NewRateLimitGroup(c.cfg, c.kvc, "etc")
 
-	router.Get("/me", c.authMw.Handle, c.getMe)
-	router.Get("/sysinfo", c.getSysinfo)
-	router.Get("/privacyinfo", c.getPrivacyinfo)
-	router.Get("/allpermissions", c.getAllPermissions)
+	router.Get("/me", c.authMw.Handle, limit, c.getMe)
+	router.Get("/me/guilds", c.authMw.Handle, limit, c.getMeGuilds)
+	router.Get("/sysinfo", c.optionalAuth, limit, c.getSysinfo)
+	router.Get("/privacyinfo", limit, c.getPrivacyinfo)
+	router.Get("/allpermissions", limit, c.getAllPermissions)
 	router.Get("/healthcheck", c.getHealthcheck)
+	router.Get("/selfcheck", c.authMw.Handle, limit, ownerOnly(container), c.getSelfcheck)
 }
 
 // @Summary Me
@@ -88,59 +108,147 @@ func (c *EtcController) getMe(ctx *fiber.Ctx) error {
 	return ctx.JSON(res)
 }
 
-// @Summary System Information
-// @Description Returns general global system information.
+// @Summary Me Guilds
+// @Description Returns the guilds the authenticated user has in common with shinpuru including the permission level of the user on each guild.
 // @Tags Etc
 // @Accept json
 // @Produce json
-// @Success 200 {object} apiModels.SystemInfo
-// @Router /sysinfo [get]
-func (c *EtcController) getSysinfo(ctx *fiber.Ctx) error {
-	buildTS, _ := strconv.Atoi(embedded.AppDate)
-	buildDate := time.Unix(int64(buildTS), 0)
-
-	var memStats runtime.MemStats
-	runtime.ReadMemStats(&memStats)
-
-	uptime := int64(time.Since(util.StatsStartupTime).Seconds())
+// @Param moderatable query bool false "Only return guilds where the user has any moderation permission or is owner or admin."
+// @Success 200 {array} apiModels.UserGuild "Wrapped in models.ListResponse"
+// @Failure 400 {object} apiModels.Error
+// @Failure 401 {object} apiModels.Error
+// @Router /me/guilds [get]
+func (c *EtcController) getMeGuilds(ctx *fiber.Ctx) error {
+	uid := ctx.Locals("uid").(string)
 
-	self, err := c.st.SelfUser()
+	moderatable, err := wsutil.GetQueryBool(ctx, "moderatable", false)
 	if err != nil {
 		return err
 	}
 
-	guilds, err := c.st.Guilds()
+	guilds, err := c.getUserGuilds(uid)
 	if err != nil {
 		return err
 	}
 
-	res := &apiModels.SystemInfo{
-		Version:    embedded.AppVersion,
-		CommitHash: embedded.AppCommit,
-		BuildDate:  buildDate,
-		GoVersion:  runtime.Version(),
+	if moderatable {
+		filtered := make([]*apiModels.UserGuild, 0, len(guilds))
+		for _, g := range guilds {
+			if g.Moderator {
+				filtered = append(filtered, g)
+			}
+		}
+		guilds = filtered
+	}
+
+	return ctx.JSON(apiModels.NewListResponse(guilds))
+}
+
+// getUserGuilds returns the guilds the given user has in
+// common with shinpuru including the permission level of
+// the user on each guild. Because the permissions of the
+// user need to be resolved for each guild, the result is
+// cached for userGuildsCacheLifetime.
+func (c *EtcController) getUserGuilds(uid string) (res []*apiModels.UserGuild, err error) {
+	cacheKey := "meguilds:" + uid
+	if res, ok := c.kvc.Get(cacheKey).([]*apiModels.UserGuild); ok {
+		return res, nil
+	}
+
+	userGuilds, err := c.st.UserGuilds(uid)
+	if err != nil {
+		return
+	}
+
+	var modPerms []string
+	for _, p := range util.GetAllPermissions(c.cmdHandler).Unwrap() {
+		if strings.HasPrefix(p, "sp.guild.mod.") {
+			modPerms = append(modPerms, p)
+		}
+	}
+
+	botOwnerID := c.cfg.Config().Discord.OwnerID
+
+	res = make([]*apiModels.UserGuild, 0, len(userGuilds))
+	for _, guildID := range userGuilds {
+		guild, err := c.st.Guild(guildID, true)
+		if err != nil {
+			return nil, err
+		}
+
+		member, err := c.st.Member(guildID, uid)
+		if err != nil {
+			return nil, err
+		}
+
+		perms, override, err := c.pmw.GetPermissions(c.session, guildID, uid)
+		if err != nil {
+			return nil, err
+		}
+
+		ug := &apiModels.UserGuild{
+			GuildReduced: apiModels.GuildReducedFromGuild(guild),
+		}
+
+		switch {
+		case discordutil.IsAdmin(guild, member):
+			ug.Dominance = 1
+		case guild.OwnerID == uid:
+			ug.Dominance = 2
+		case botOwnerID == uid:
+			ug.Dominance = 3
+		}
+
+		ug.Moderator = override
+		for i := 0; !ug.Moderator && i < len(modPerms); i++ {
+			ug.Moderator = perms.Check(modPerms[i])
+		}
+
+		res = append(res, ug)
+	}
+
+	c.kvc.Set(cacheKey, res, userGuildsCacheLifetime)
 
-		Uptime:    uptime,
-		UptimeStr: fmt.Sprintf("%d", uptime),
+	return
+}
 
-		OS:          runtime.GOOS,
-		Arch:        runtime.GOARCH,
-		CPUs:        runtime.NumCPU(),
-		GoRoutines:  runtime.NumGoroutine(),
-		StackUse:    memStats.StackInuse,
-		StackUseStr: fmt.Sprintf("%d", memStats.StackInuse),
-		HeapUse:     memStats.HeapInuse,
-		HeapUseStr:  fmt.Sprintf("%d", memStats.HeapInuse),
+// @Summary System Information
+// @Description Returns general global system information. The memory usage is only included for the bot owner unless public resource usage is enabled.
+// @Tags Etc
+// @Accept json
+// @Produce json
+// @Success 200 {object} apiModels.SystemInfo
+// @Router /sysinfo [get]
+func (c *EtcController) getSysinfo(ctx *fiber.Ctx) error {
+	info, err := sysinfo.Get(c.st)
+	if err != nil {
+		return err
+	}
 
-		BotUserID: self.ID,
-		BotInvite: util.GetInviteLink(self.ID),
+	res := apiModels.SystemInfoFromInfo(info)
 
-		Guilds: len(guilds),
+	// Memory usage is only shown to the bot owner
+	// unless it is configured to be public.
+	cfg := c.cfg.Config()
+	if uid, _ := ctx.Locals("uid").(string); !cfg.Discord.PublicResourceUsage &&
+		(uid == "" || uid != cfg.Discord.OwnerID) {
+		res.StackUse, res.StackUseStr = 0, ""
+		res.HeapUse, res.HeapUseStr = 0, ""
 	}
 
 	return ctx.JSON(res)
 }
 
+// optionalAuth authorizes the request if credentials
+// are passed. Otherwise, the request is passed to the
+// next handler without authorization.
+func (c *EtcController) optionalAuth(ctx *fiber.Ctx) error {
+	if ctx.Get(fiber.HeaderAuthorization) == "" && ctx.Query("ota_token") == "" {
+		return ctx.Next()
+	}
+	return c.authMw.Handle(ctx)
+}
+
 // @Summary Privacy Information
 // @Description Returns general global privacy information.
 // @Tags Etc
@@ -165,7 +273,7 @@ func (c *EtcController) getAllPermissions(ctx *fiber.Ctx) error {
 }
 
 // @Summary Healthcheck
-// @Description General system healthcheck.
+// @Description General system healthcheck. When Redis is unavailable, shinpuru falls back to uncached behavior and the system is reported as degraded instead of faulty.
 // @Tags Etc
 // @Accept json
 // @Produce json
@@ -176,14 +284,35 @@ func (c *EtcController) getHealthcheck(ctx *fiber.Ctx) error {
 
 	hc.Database = models.HealthcheckStatusFromError(c.db.Status())
 	hc.Storage = models.HealthcheckStatusFromError(c.storage.Status())
-	hc.Redis = models.HealthcheckStatusFromError(c.rd.Ping(context.Background()).Err())
+	c.rdg.Check()
+	hc.Redis = models.HealthcheckStatusFromError(c.rdg.Err())
 
 	hc.Discord.Ok = atomic.LoadInt32(&util.ConnectedState) == 1
 	if !hc.Discord.Ok {
 		hc.Discord.Message = "gateway connection has been disconnected"
 	}
 
-	hc.AllOk = hc.Database.Ok && hc.Storage.Ok && hc.Redis.Ok && hc.Discord.Ok
+	hc.AllOk = hc.Database.Ok && hc.Storage.Ok && hc.Discord.Ok
+	hc.Degraded = !hc.Redis.Ok
 
 	return ctx.JSON(hc)
 }
+
+// @Summary Self-Check
+// @Description Returns the report of the last self-check which is performed on startup. Only the bot owner can access the report.
+// @Tags Etc
+// @Accept json
+// @Produce json
+// @Success 200 {object} selfcheck.Report
+// @Failure 401 {object} apiModels.Error
+// @Failure 403 {object} apiModels.Error
+// @Failure 404 {object} apiModels.Error
+// @Router /selfcheck [get]
+func (c *EtcController) getSelfcheck(ctx *fiber.Ctx) error {
+	report, ok := c.sc.Last()
+	if !ok {
+		return fiber.NewError(fiber.StatusNotFound, "self-check has not been run yet")
+	}
+
+	return ctx.JSON(report)
+}
@@ -1,29 +1,42 @@
 package controllers
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/gofiber/fiber/v2"
 	"github.com/sarulabs/di/v2"
+	"github.com/zekroTJA/shinpuru/internal/services/config"
 	"github.com/zekroTJA/shinpuru/internal/services/database"
 	"github.com/zekroTJA/shinpuru/internal/services/permissions"
 	"github.com/zekroTJA/shinpuru/internal/services/webserver/v1/models"
+	"github.com/zekroTJA/shinpuru/internal/services/webserver/wsutil"
+	"github.com/zekroTJA/shinpuru/internal/util/cmdblocklist"
 	"github.com/zekroTJA/shinpuru/internal/util/presence"
 	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/pkg/twitchnotify"
 	"github.com/zekrotja/dgrs"
+	"github.com/zekrotja/ken"
 )
 
 type GlobalSettingsController struct {
-	session *discordgo.Session
-	db      database.Database
-	st      *dgrs.State
+	session    *discordgo.Session
+	db         database.Database
+	st         dgrs.IState
+	cfg        config.Provider
+	cmdHandler *ken.Ken
+	tnw        *twitchnotify.NotifyWorker
 }
 
 func (c *GlobalSettingsController) Setup(container di.Container, router fiber.Router) {
 	c.session = container.Get(static.DiDiscordSession).(*discordgo.Session)
 	c.db = container.Get(static.DiDatabase).(database.Database)
-	c.st = container.Get(static.DiState).(*dgrs.State)
+	c.st = container.Get(static.DiState).(dgrs.IState)
+	c.cfg = container.Get(static.DiConfig).(config.Provider)
+	c.tnw, _ = container.Get(static.DiTwitchNotifyWorker).(*twitchnotify.NotifyWorker)
+	c.cmdHandler = container.Get(static.DiCommandHandler).(*ken.Ken)
 
 	pmw := container.Get(static.DiPermissions).(*permissions.Permissions)
 
@@ -31,6 +44,9 @@ func (c *GlobalSettingsController) Setup(container di.Container, router fiber.Ro
 	router.Post("/presence", pmw.HandleWs(c.session, "sp.presence"), c.postPresence)
 	router.Get("/noguildinvite", pmw.HandleWs(c.session, "sp.noguildinvite"), c.getNoGuildInvites)
 	router.Post("/noguildinvite", pmw.HandleWs(c.session, "sp.noguildinvite"), c.postNoGuildInvites)
+	router.Get("/cmdblocklist", pmw.HandleWs(c.session, "sp.cmdblocklist"), c.getCmdBlocklist)
+	router.Post("/cmdblocklist", pmw.HandleWs(c.session, "sp.cmdblocklist"), c.postCmdBlocklist)
+	router.Post("/twitch/test", pmw.HandleWs(c.session, "sp.twitch.test"), c.postTwitchTest)
 }
 
 // @Summary Get Presence
@@ -73,12 +89,8 @@ func (c *GlobalSettingsController) getPresence(ctx *fiber.Ctx) error {
 // @Router /settings/presence [post]
 func (c *GlobalSettingsController) postPresence(ctx *fiber.Ctx) error {
 	pre := new(presence.Presence)
-	if err := ctx.BodyParser(pre); err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, err.Error())
-	}
-
-	if err := pre.Validate(); err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	if err := wsutil.ParseAndValidate(ctx, pre); err != nil {
+		return err
 	}
 
 	if err := c.db.SetSetting(static.SettingPresence, pre.Marshal()); err != nil {
@@ -285,3 +297,104 @@ func (c *GlobalSettingsController) postNoGuildInvites(ctx *fiber.Ctx) error {
 
 	return ctx.JSON(models.Ok)
 }
+
+// @Summary Get Command Blocklist
+// @Description Returns the global command blocklist.
+// @Tags Global Settings
+// @Accept json
+// @Produce json
+// @Success 200 {object} cmdblocklist.Blocklist
+// @Failure 401 {object} models.Error
+// @Failure 403 {object} models.Error
+// @Router /settings/cmdblocklist [get]
+func (c *GlobalSettingsController) getCmdBlocklist(ctx *fiber.Ctx) error {
+	bl, err := cmdblocklist.Get(c.db)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(bl)
+}
+
+// @Summary Set Command Blocklist
+// @Description Replaces the global command blocklist. Blocked commands can not be executed on any guild.
+// @Tags Global Settings
+// @Accept json
+// @Produce json
+// @Param payload body cmdblocklist.Blocklist true "Blocklist Payload"
+// @Success 200 {object} cmdblocklist.Blocklist
+// @Failure 400 {object} models.Error
+// @Failure 401 {object} models.Error
+// @Failure 403 {object} models.Error
+// @Router /settings/cmdblocklist [post]
+func (c *GlobalSettingsController) postCmdBlocklist(ctx *fiber.Ctx) error {
+	var payload cmdblocklist.Blocklist
+	if err := ctx.BodyParser(&payload); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	names := make(map[string]bool)
+	for _, info := range c.cmdHandler.GetCommandInfo() {
+		names[info.ApplicationCommand.Name] = true
+	}
+
+	bl := cmdblocklist.Blocklist{
+		Commands: make([]string, 0, len(payload.Commands)),
+		Silent:   payload.Silent,
+	}
+	for _, name := range payload.Commands {
+		name = strings.ToLower(strings.TrimPrefix(name, "/"))
+		if !names[name] || name == "cmdblocklist" {
+			return fiber.NewError(fiber.StatusBadRequest,
+				fmt.Sprintf("invalid command: %s", name))
+		}
+		bl.Add(name)
+	}
+
+	if err := cmdblocklist.Set(c.db, bl); err != nil {
+		return err
+	}
+
+	return ctx.JSON(bl)
+}
+
+// @Summary Test Twitch Credentials
+// @Description Requests a new token with the configured Twitch app credentials and performs a test request to the Twitch API. Returns whether the authentication succeeded and the current rate limit budget of the app.
+// @Tags Global Settings
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.TwitchCredentialsTestResult
+// @Failure 401 {object} models.Error
+// @Failure 403 {object} models.Error
+// @Router /settings/twitch/test [post]
+func (c *GlobalSettingsController) postTwitchTest(ctx *fiber.Ctx) error {
+	var res models.TwitchCredentialsTestResult
+
+	if c.tnw == nil {
+		res.ErrorType = models.TwitchCredentialsNotConfigured
+		res.Error = "no Twitch app credentials are configured"
+		return ctx.JSON(res)
+	}
+
+	rl, err := c.tnw.TestCredentials()
+	if err != nil {
+		res.ErrorType = models.TwitchCredentialsNetwork
+		if errors.As(err, new(*twitchnotify.AuthError)) {
+			res.ErrorType = models.TwitchCredentialsAuth
+		}
+		res.Error = err.Error()
+		if secret := c.cfg.Config().TwitchApp.ClientSecret; secret != "" {
+			res.Error = strings.ReplaceAll(res.Error, secret, "***")
+		}
+		return ctx.JSON(res)
+	}
+
+	res.OK = true
+	res.RateLimit = &models.TwitchRateLimit{
+		Limit:     rl.Limit,
+		Remaining: rl.Remaining,
+		Reset:     rl.Reset,
+	}
+
+	return ctx.JSON(res)
+}
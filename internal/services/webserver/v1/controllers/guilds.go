@@ -1,8 +1,10 @@
 package controllers
 
 import (
+	"errors"
 	"fmt"
-	"strings"
+	"math"
+	"sort"
 	"time"
 
 	_ "crypto/sha512"
@@ -17,6 +19,8 @@ import (
 	"github.com/zekroTJA/shinpuru/internal/services/config"
 	"github.com/zekroTJA/shinpuru/internal/services/database"
 	"github.com/zekroTJA/shinpuru/internal/services/guildlog"
+	"github.com/zekroTJA/shinpuru/internal/services/invitetracker"
+	"github.com/zekroTJA/shinpuru/internal/services/karma"
 	"github.com/zekroTJA/shinpuru/internal/services/kvcache"
 	permservice "github.com/zekroTJA/shinpuru/internal/services/permissions"
 	"github.com/zekroTJA/shinpuru/internal/services/report"
@@ -25,16 +29,27 @@ import (
 	"github.com/zekroTJA/shinpuru/internal/services/verification"
 	"github.com/zekroTJA/shinpuru/internal/services/webserver/v1/models"
 	"github.com/zekroTJA/shinpuru/internal/services/webserver/wsutil"
+	"github.com/zekroTJA/shinpuru/internal/util/configbundle"
+	"github.com/zekroTJA/shinpuru/internal/util/guildstats"
+	"github.com/zekroTJA/shinpuru/internal/util/messagestats"
 	"github.com/zekroTJA/shinpuru/internal/util/modnot"
+	"github.com/zekroTJA/shinpuru/internal/util/notifications"
+	"github.com/zekroTJA/shinpuru/internal/util/snowflakenodes"
 	"github.com/zekroTJA/shinpuru/internal/util/static"
 	"github.com/zekroTJA/shinpuru/pkg/discordutil"
 	"github.com/zekroTJA/shinpuru/pkg/permissions"
+	"github.com/zekroTJA/shinpuru/pkg/roleutil"
 	"github.com/zekroTJA/shinpuru/pkg/stringutil"
+	"github.com/zekroTJA/shinpuru/pkg/twitchnotify"
 	"github.com/zekrotja/dgrs"
 	"github.com/zekrotja/rogu/log"
 	"github.com/zekrotja/sop"
 )
 
+// permissionsPreviewSampleSize is the number of members
+// previewed when no members are specified.
+const permissionsPreviewSampleSize = 10
+
 type GuildsController struct {
 	db      database.Database
 	st      storage.Storage
@@ -42,12 +57,15 @@ type GuildsController struct {
 	session *discordgo.Session
 	cfg     config.Provider
 	pmw     *permservice.Permissions
-	state   *dgrs.State
+	state   dgrs.IState
 	vs      verification.Provider
 	cef     codeexec.Factory
 	tp      timeprovider.Provider
 	rep     report.Provider
 	gl      guildlog.Logger
+	karma   karma.Provider
+	tnw     *twitchnotify.NotifyWorker
+	it      *invitetracker.InviteTracker
 }
 
 func (c *GuildsController) Setup(container di.Container, router fiber.Router) {
@@ -57,24 +75,43 @@ func (c *GuildsController) Setup(container di.Container, router fiber.Router) {
 	c.pmw = container.Get(static.DiPermissions).(*permservice.Permissions)
 	c.kvc = container.Get(static.DiKVCache).(kvcache.Provider)
 	c.st = container.Get(static.DiObjectStorage).(storage.Storage)
-	c.state = container.Get(static.DiState).(*dgrs.State)
+	c.state = container.Get(static.DiState).(dgrs.IState)
 	c.vs = container.Get(static.DiVerification).(verification.Provider)
 	c.cef = container.Get(static.DiCodeExecFactory).(codeexec.Factory)
 	c.tp = container.Get(static.DiTimeProvider).(timeprovider.Provider)
 	c.rep = container.Get(static.DiReport).(report.Provider)
 	c.gl = container.Get(static.DiGuildLog).(guildlog.Logger)
+	c.karma = container.Get(static.DiKarma).(karma.Provider)
+	c.it = container.Get(static.DiInviteTracker).(*invitetracker.InviteTracker)
+	c.tnw, _ = container.Get(static.DiTwitchNotifyWorker).(*twitchnotify.NotifyWorker)
 
 	router.Get("", c.getGuilds)
 	router.Get("/:guildid", c.getGuild)
+	router.Get("/:guildid/roles", c.getGuildRoles)
+	router.Get("/:guildid/stats", c.pmw.HandleWs(c.session, "sp.chat.guildstats"), c.getGuildStats)
 	router.Get("/:guildid/scoreboard", c.getGuildScoreboard)
+	router.Delete("/:guildid/karma", c.pmw.HandleWs(c.session, "sp.guild.admin.karma"), confirmation(container), c.deleteGuildKarma)
+	router.Delete("/:guildid/karma/:memberid", c.pmw.HandleWs(c.session, "sp.guild.mod.karma"), c.deleteGuildMemberKarma)
 	router.Get("/:guildid/starboard", c.getGuildStarboard)
 	router.Get("/:guildid/starboard/count", c.getGuildStarboardCount)
 	router.Get("/:guildid/antiraid/joinlog", c.pmw.HandleWs(c.session, "sp.guild.config.antiraid"), c.getGuildAntiraidJoinlog)
 	router.Delete("/:guildid/antiraid/joinlog", c.pmw.HandleWs(c.session, "sp.guild.config.antiraid"), c.deleteGuildAntiraidJoinlog)
+	router.Get("/:guildid/invites/stats", c.pmw.HandleWs(c.session, "sp.guild.mod.invite"), c.getGuildInviteStats)
+	router.Get("/:guildid/invites/analytics", c.pmw.HandleWs(c.session, "sp.guild.mod.invite"), c.getGuildInviteAnalytics)
+	router.Get("/:guildid/messagestats", c.pmw.HandleWs(c.session, "sp.guild.mod.messagestats"), c.getGuildMessageStats)
+	router.Get("/:guildid/notifications", c.pmw.HandleWs(c.session, "sp.chat.twitch"), c.getGuildNotifications)
+	router.Delete("/:guildid/notifications/:twitchuserid", c.pmw.HandleWs(c.session, "sp.chat.twitch"), c.deleteGuildNotification)
 	router.Get("/:guildid/reports", c.getReports)
 	router.Get("/:guildid/reports/count", c.getReportsCount)
+	router.Get("/:guildid/reports/revoked", c.pmw.HandleWs(c.session, "sp.guild.mod.report.revoke"), c.getRevokedReports)
+	router.Get("/:guildid/reports/:reportid", c.getReport)
+	router.Delete("/:guildid/reports/:reportid", c.pmw.HandleWs(c.session, "sp.guild.mod.report.revoke"), c.deleteReport)
+	router.Post("/:guildid/reports/import", c.pmw.HandleWs(c.session, "sp.guild.admin.reportimport"), confirmation(container), c.postReportsImport)
+	router.Get("/:guildid/config/bundle", c.pmw.HandleWs(c.session, "sp.guild.admin.configbundle"), c.getGuildConfigBundle)
+	router.Post("/:guildid/config/bundle", c.pmw.HandleWs(c.session, "sp.guild.admin.configbundle"), skipOnDryRun(confirmation(container)), c.postGuildConfigBundle)
 	router.Get("/:guildid/permissions", c.getGuildPermissions)
 	router.Post("/:guildid/permissions", c.pmw.HandleWs(c.session, "sp.guild.config.perms"), c.postGuildPermissions)
+	router.Post("/:guildid/permissions/preview", c.pmw.HandleWs(c.session, "sp.guild.config.perms"), c.postGuildPermissionsPreview)
 	router.Post("/:guildid/inviteblock", c.pmw.HandleWs(c.session, "sp.guild.mod.inviteblock"), c.postGuildToggleInviteblock)
 	router.Get("/:guildid/unbanrequests", c.pmw.HandleWs(c.session, "sp.guild.mod.unbanrequests"), c.getGuildUnbanrequests)
 	router.Get("/:guildid/unbanrequests/count", c.pmw.HandleWs(c.session, "sp.guild.mod.unbanrequests"), c.getGuildUnbanrequestsCount)
@@ -149,6 +186,101 @@ func (c *GuildsController) getGuild(ctx *fiber.Ctx) error {
 	return ctx.JSON(gRes)
 }
 
+// @Summary Get Guild Stats
+// @Description Returns aggregated statistics of the guild. The statistics are cached for a short time.
+// @Tags Guilds
+// @Accept json
+// @Produce json
+// @Param id path string true "The ID of the guild."
+// @Success 200 {object} sharedmodels.GuildStats
+// @Failure 401 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Router /guilds/{id}/stats [get]
+func (c *GuildsController) getGuildStats(ctx *fiber.Ctx) error {
+	uid := ctx.Locals("uid").(string)
+
+	guildID := ctx.Params("guildid")
+
+	if memb, _ := c.state.Member(guildID, uid); memb == nil {
+		return fiber.ErrNotFound
+	}
+
+	stats, err := guildstats.Get(c.state, c.kvc, c.tp, guildID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(stats)
+}
+
+// @Summary Get Guild Roles
+// @Description Returns the roles of the guild sorted by position descending including their member counts.
+// @Tags Guilds
+// @Accept json
+// @Produce json
+// @Param id path string true "The ID of the guild."
+// @Success 200 {array} models.GuildRole "Wrapped in models.ListResponse"
+// @Failure 401 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Router /guilds/{id}/roles [get]
+func (c *GuildsController) getGuildRoles(ctx *fiber.Ctx) error {
+	uid := ctx.Locals("uid").(string)
+
+	guildID := ctx.Params("guildid")
+
+	if memb, _ := c.state.Member(guildID, uid); memb == nil {
+		return fiber.ErrNotFound
+	}
+
+	roles, err := c.state.Roles(guildID)
+	if err != nil {
+		return err
+	}
+
+	counts, err := c.getRoleMemberCounts(guildID)
+	if err != nil {
+		return err
+	}
+
+	self, err := c.state.SelfUser()
+	if err != nil {
+		return err
+	}
+	selfMemb, err := c.state.Member(guildID, self.ID)
+	if err != nil {
+		return err
+	}
+
+	rolePositions := make(map[string]int, len(roles))
+	for _, r := range roles {
+		rolePositions[r.ID] = r.Position
+	}
+	selfMaxPos := 0
+	for _, rID := range selfMemb.Roles {
+		if p := rolePositions[rID]; p > selfMaxPos {
+			selfMaxPos = p
+		}
+	}
+
+	roleutil.SortRoles(roles, true)
+
+	res := make([]*models.GuildRole, len(roles))
+	for i, r := range roles {
+		res[i] = &models.GuildRole{
+			ID:          r.ID,
+			Name:        r.Name,
+			Color:       r.Color,
+			Position:    r.Position,
+			Permissions: r.Permissions,
+			Managed:     r.Managed,
+			MemberCount: counts[r.ID],
+			Manageable:  !r.Managed && r.ID != guildID && r.Position < selfMaxPos,
+		}
+	}
+
+	return ctx.JSON(models.NewListResponse(res))
+}
+
 // @Summary Get Guild Scoreboard
 // @Description Returns a list of scoreboard entries for the given guild.
 // @Tags Guilds
@@ -193,6 +325,80 @@ func (c *GuildsController) getGuildScoreboard(ctx *fiber.Ctx) error {
 	return ctx.JSON(models.NewListResponse(results[:i]))
 }
 
+// @Summary Reset Member Karma
+// @Description Sets the karma of a user on the guild to zero or the given value. The reset is recorded in the settings audit log and karma rule roles of the member are corrected. The karma of karma blocklisted users can not be raised.
+// @Tags Guilds
+// @Accept json
+// @Produce json
+// @Param id path string true "The ID of the guild."
+// @Param memberid path string true "The ID of the user."
+// @Param value query int false "The value the karma is set to." default(0)
+// @Success 200 {object} models.State
+// @Failure 400 {object} models.Error
+// @Failure 401 {object} models.Error
+// @Failure 403 {object} models.Error
+// @Router /guilds/{id}/karma/{memberid} [delete]
+func (c *GuildsController) deleteGuildMemberKarma(ctx *fiber.Ctx) error {
+	uid := ctx.Locals("uid").(string)
+
+	guildID := ctx.Params("guildid")
+	memberID := ctx.Params("memberid")
+
+	value, err := wsutil.GetQueryInt(ctx, "value", 0, math.MinInt32, math.MaxInt32)
+	if err != nil {
+		return err
+	}
+
+	err = c.karma.Reset(guildID, memberID, uid, value)
+	if err == karma.ErrBlockListed {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(models.Ok)
+}
+
+// @Summary Reset Guild Karma
+// @Description Resets the karma of all users on the guild to zero. The reset is recorded in the settings audit log and karma rule roles of all members are corrected.
+// @Tags Guilds
+// @Accept json
+// @Produce json
+// @Param X-Confirmation-Token header string false "Confirmation token obtained via /ota/confirmation. Required if confirmation is enabled."
+// @Param id path string true "The ID of the guild."
+// @Param payload body models.KarmaResetRequest true "The reset payload containing the guild name as validation."
+// @Success 200 {object} models.State
+// @Failure 400 {object} models.Error
+// @Failure 401 {object} models.Error
+// @Failure 403 {object} models.Error
+// @Router /guilds/{id}/karma [delete]
+func (c *GuildsController) deleteGuildKarma(ctx *fiber.Ctx) error {
+	uid := ctx.Locals("uid").(string)
+
+	guildID := ctx.Params("guildid")
+
+	guild, err := c.state.Guild(guildID)
+	if err != nil {
+		return err
+	}
+
+	var payload models.KarmaResetRequest
+	if err = ctx.BodyParser(&payload); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if payload.Validation != guild.Name {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid validation")
+	}
+
+	if err = c.karma.ResetGuild(guildID, uid); err != nil {
+		return err
+	}
+
+	return ctx.JSON(models.Ok)
+}
+
 // @Summary Get Antiraid Joinlog
 // @Description Returns a list of joined members during an antiraid trigger.
 // @Tags Guilds
@@ -211,202 +417,776 @@ func (c *GuildsController) getGuildAntiraidJoinlog(ctx *fiber.Ctx) error {
 		return err
 	}
 
-	if joinlog == nil {
-		joinlog = make([]sharedmodels.JoinLogEntry, 0)
+	if joinlog == nil {
+		joinlog = make([]sharedmodels.JoinLogEntry, 0)
+	}
+
+	return ctx.JSON(models.NewListResponse(joinlog))
+}
+
+// @Summary Get Invite Stats
+// @Description Returns the number of member joins per invite code ordered by the number of joins descending. Joins which could not be attributed to an invite are listed with the source `unknown`.
+// @Tags Guilds
+// @Accept json
+// @Produce json
+// @Param id path string true "The ID of the guild."
+// @Success 200 {array} sharedmodels.InviteJoinStats "Wrapped in models.ListResponse"
+// @Failure 401 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Router /guilds/{id}/invites/stats [get]
+func (c *GuildsController) getGuildInviteStats(ctx *fiber.Ctx) error {
+	guildID := ctx.Params("guildid")
+
+	stats, err := c.db.GetInviteJoinStats(guildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return err
+	}
+
+	if stats == nil {
+		stats = make([]sharedmodels.InviteJoinStats, 0)
+	}
+
+	return ctx.JSON(models.NewListResponse(stats))
+}
+
+// @Summary Get Invite Analytics
+// @Description Returns the number of member joins per invite including invites without joins and expired or deleted invites, as well as the leaderboard of the users who created the invites. Invites created via shinpuru are attributed to the user who requested them.
+// @Tags Guilds
+// @Accept json
+// @Produce json
+// @Param id path string true "The ID of the guild."
+// @Success 200 {object} sharedmodels.InviteAnalytics
+// @Failure 400 {object} models.Error
+// @Failure 401 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Router /guilds/{id}/invites/analytics [get]
+func (c *GuildsController) getGuildInviteAnalytics(ctx *fiber.Ctx) error {
+	guildID := ctx.Params("guildid")
+
+	a, err := c.it.Analytics(guildID)
+	if discordutil.IsErrCode(err, discordgo.ErrCodeMissingPermissions) {
+		return fiber.NewError(fiber.StatusBadRequest, "missing permission to view the invites of the guild")
+	}
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(a)
+}
+
+// @Summary Get Message Stats
+// @Description Returns the message statistics of the guild per channel, the top posters and the number of messages per hour of the day (UTC) over the given period. Message statistics must be enabled for the guild.
+// @Tags Guilds
+// @Accept json
+// @Produce json
+// @Param id path string true "The ID of the guild."
+// @Param days query int false "The number of days to return statistics for." default(7)
+// @Success 200 {object} sharedmodels.MessageStats
+// @Failure 400 {object} models.Error
+// @Failure 401 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Router /guilds/{id}/messagestats [get]
+func (c *GuildsController) getGuildMessageStats(ctx *fiber.Ctx) error {
+	guildID := ctx.Params("guildid")
+
+	days, err := wsutil.GetQueryInt(ctx, "days", messagestats.DefaultPeriodDays, 1, messagestats.MaxPeriodDays)
+	if err != nil {
+		return err
+	}
+
+	enabled, err := c.db.GetGuildMessageStatsEnabled(guildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return err
+	}
+	if !enabled {
+		return fiber.NewError(fiber.StatusNotFound, "message statistics are not enabled")
+	}
+
+	stats, err := messagestats.Get(c.db, c.tp, guildID, days)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(stats)
+}
+
+// @Summary Get Notification Subscriptions
+// @Description Returns the list of notification subscriptions of the guild with their live status. Subscriptions which can not be notified properly are marked as failing with the reasons listed in `problems`.
+// @Tags Guilds
+// @Accept json
+// @Produce json
+// @Param id path string true "The ID of the guild."
+// @Success 200 {array} models.NotificationSubscription "Wrapped in models.ListResponse"
+// @Failure 401 {object} models.Error
+// @Failure 403 {object} models.Error
+// @Router /guilds/{id}/notifications [get]
+func (c *GuildsController) getGuildNotifications(ctx *fiber.Ctx) error {
+	guildID := ctx.Params("guildid")
+
+	subs, err := notifications.List(c.db, c.state, c.watcher(), guildID)
+	if err != nil {
+		return err
+	}
+
+	res := make([]*models.NotificationSubscription, len(subs))
+	for i, sub := range subs {
+		res[i] = &models.NotificationSubscription{
+			Type:         string(sub.Type),
+			ChannelID:    sub.Entry.ChannelID,
+			TwitchUserID: sub.Entry.TwitchUserID,
+			Template:     sub.Entry.Template,
+			RoleID:       sub.Entry.RoleID,
+			Plain:        sub.Entry.Plain,
+			Live:         sub.Live,
+			Failing:      sub.Failing(),
+			Problems:     make([]string, len(sub.Problems)),
+		}
+		if sub.User != nil {
+			res[i].TwitchUserName = sub.User.DisplayName
+		}
+		for j, p := range sub.Problems {
+			res[i].Problems[j] = string(p)
+		}
+	}
+
+	return ctx.JSON(models.NewListResponse(res))
+}
+
+// @Summary Remove Notification Subscription
+// @Description Removes the Twitch notification subscription of the given Twitch user from the guild.
+// @Tags Guilds
+// @Accept json
+// @Produce json
+// @Param id path string true "The ID of the guild."
+// @Param twitchuserid path string true "The ID of the Twitch user."
+// @Success 200 {object} models.Status
+// @Failure 401 {object} models.Error
+// @Failure 403 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Router /guilds/{id}/notifications/{twitchuserid} [delete]
+func (c *GuildsController) deleteGuildNotification(ctx *fiber.Ctx) error {
+	guildID := ctx.Params("guildid")
+	twitchUserID := ctx.Params("twitchuserid")
+
+	_, err := notifications.Remove(c.db, c.watcher(), guildID, twitchUserID)
+	if err != nil {
+		return wsutil.ErrInternalOrNotFound(err)
+	}
+
+	return ctx.JSON(models.Ok)
+}
+
+// watcher returns the Twitch notify worker or nil,
+// if the worker is not available.
+func (c *GuildsController) watcher() notifications.Watcher {
+	if c.tnw == nil {
+		return nil
+	}
+	return c.tnw
+}
+
+// @Summary Reset Antiraid Joinlog
+// @Description Deletes all entries of the antiraid joinlog.
+// @Tags Guilds
+// @Accept json
+// @Produce json
+// @Param id path string true "The ID of the guild."
+// @Success 200 {object} models.Status
+// @Failure 401 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Router /guilds/{id}/antiraid/joinlog [delete]
+func (c *GuildsController) deleteGuildAntiraidJoinlog(ctx *fiber.Ctx) error {
+	guildID := ctx.Params("guildid")
+
+	if err := c.db.FlushAntiraidJoinList(guildID); err != nil && !database.IsErrDatabaseNotFound(err) {
+		return err
+	}
+
+	return ctx.JSON(models.Ok)
+}
+
+// @Summary Get Guild Starboard
+// @Description Returns a list of starboard entries for the given guild.
+// @Tags Guilds
+// @Accept json
+// @Produce json
+// @Param id path string true "The ID of the guild."
+// @Success 200 {array} models.StarboardEntryResponse "Wrapped in models.ListResponse"
+// @Failure 401 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Router /guilds/{id}/starboard [get]
+func (c *GuildsController) getGuildStarboard(ctx *fiber.Ctx) error {
+	guildID := ctx.Params("guildid")
+	limit, err := wsutil.GetQueryInt(ctx, "limit", 20, 1, 100)
+	if err != nil {
+		return err
+	}
+	offset, err := wsutil.GetQueryInt(ctx, "offset", 0, 0, 0)
+	if err != nil {
+		return err
+	}
+	sortQ := ctx.Query("sort")
+
+	var sort sharedmodels.StarboardSortBy
+	switch string(sortQ) {
+	case "latest":
+		sort = sharedmodels.StarboardSortByLatest
+	case "top":
+		sort = sharedmodels.StarboardSortByMostRated
+	default:
+		return fiber.NewError(fiber.StatusBadRequest, "invalid sort property")
+	}
+
+	entries, err := c.db.GetStarboardEntries(guildID, sort, limit, offset)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return err
+	}
+
+	results := make([]*models.StarboardEntryResponse, len(entries))
+
+	var i int
+	for _, e := range entries {
+		if e.Deleted {
+			continue
+		}
+
+		member, err := c.state.Member(guildID, e.AuthorID)
+		if err != nil {
+			continue
+		}
+
+		results[i] = &models.StarboardEntryResponse{
+			StarboardEntry: e,
+			AuthorUsername: member.User.String(),
+			AvatarURL:      member.User.AvatarURL(""),
+			MessageURL: discordutil.GetMessageLink(&discordgo.Message{
+				ChannelID: e.ChannelID,
+				ID:        e.MessageID,
+			}, guildID),
+		}
+
+		i++
+	}
+
+	return ctx.JSON(models.NewListResponse(results[:i]))
+}
+
+// @Summary Get Guild Starboard Count
+// @Description Returns the count of starboard entries for the given guild.
+// @Tags Guilds
+// @Accept json
+// @Produce json
+// @Param id path string true "The ID of the guild."
+// @Success 200 {object} models.Count
+// @Failure 401 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Router /guilds/{id}/starboard/count [get]
+func (c *GuildsController) getGuildStarboardCount(ctx *fiber.Ctx) error {
+	guildID := ctx.Params("guildid")
+
+	count, err := c.db.GetStarboardEntriesCount(guildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return err
+	}
+
+	return ctx.JSON(models.Count{Count: count})
+}
+
+// @Summary Get Guild Modlog
+// @Description Returns a list of guild modlog entries for the given guild.
+// @Tags Guilds
+// @Accept json
+// @Produce json
+// @Param id path string true "The ID of the guild."
+// @Param offset query int false "The offset of returned entries" default(0)
+// @Param limit query int false "The amount of returned entries (0 = all)" default(0)
+// @Success 200 {array} models.Report "Wrapped in models.ListResponse with pagination"
+// @Failure 401 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Router /guilds/{id}/reports [get]
+func (c *GuildsController) getReports(ctx *fiber.Ctx) error {
+	uid := ctx.Locals("uid").(string)
+
+	guildID := ctx.Params("guildid")
+
+	limit, offset, err := wsutil.GetQueryPagination(ctx, 0, 0)
+	if err != nil {
+		return err
+	}
+
+	if memb, _ := c.session.GuildMember(guildID, uid); memb == nil {
+		return fiber.ErrNotFound
+	}
+
+	var reps []sharedmodels.Report
+
+	reps, err = c.db.GetReportsGuild(guildID, offset, limit)
+	if err != nil {
+		return err
+	}
+
+	total, err := c.db.GetReportsGuildCount(guildID)
+	if err != nil {
+		return err
+	}
+
+	types, err := c.rep.GetReportTypes(guildID)
+	if err != nil {
+		return err
+	}
+
+	resReps := make([]models.Report, len(reps))
+	for i, r := range reps {
+		resReps[i] = c.resolveReport(r, types)
+	}
+
+	return ctx.JSON(models.NewPagedListResponse(resReps, limit, offset, total))
+}
+
+// @Summary Get Guild Report
+// @Description Returns a single report of the guild mod log with resolved users. If the authenticated user has the permission sp.guild.mod.note, the notes on the report victim are included.
+// @Tags Guilds
+// @Accept json
+// @Produce json
+// @Param id path string true "The ID of the guild."
+// @Param reportid path string true "The ID of the report."
+// @Success 200 {object} models.ReportDetails
+// @Failure 400 {object} models.Error
+// @Failure 401 {object} models.Error
+// @Failure 403 {object} models.Error "Is returned when the report belongs to another guild."
+// @Failure 404 {object} models.Error
+// @Router /guilds/{id}/reports/{reportid} [get]
+func (c *GuildsController) getReport(ctx *fiber.Ctx) error {
+	uid := ctx.Locals("uid").(string)
+
+	guildID := ctx.Params("guildid")
+
+	if memb, _ := c.session.GuildMember(guildID, uid); memb == nil {
+		return fiber.ErrNotFound
+	}
+
+	id, err := snowflake.ParseString(ctx.Params("reportid"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	rep, err := c.db.GetReport(id)
+	if err != nil {
+		return wsutil.ErrInternalOrNotFound(err)
+	}
+	if rep.GuildID != guildID {
+		return fiber.NewError(fiber.StatusForbidden, "report belongs to another guild")
+	}
+
+	types, err := c.rep.GetReportTypes(guildID)
+	if err != nil {
+		return err
+	}
+
+	res := models.ReportDetails{
+		Report: c.resolveReport(rep, types),
+	}
+
+	ok, _, err := c.pmw.CheckPermissions(c.session, guildID, uid, "sp.guild.mod.note")
+	if err != nil {
+		return err
+	}
+	if ok {
+		if res.Notes, err = c.db.GetUserNotes(guildID, rep.VictimID); err != nil && !database.IsErrDatabaseNotFound(err) {
+			return err
+		}
+	}
+
+	return ctx.JSON(res)
+}
+
+// @Summary Revoke Guild Report
+// @Description Revokes a report of the guild. Revoked reports are no more listed but kept with the revocation details for auditing unless hard deletion of revoked reports is configured. Revoking an already revoked report returns the report without any further actions.
+// @Tags Guilds
+// @Accept json
+// @Produce json
+// @Param id path string true "The ID of the guild."
+// @Param reportid path string true "The ID of the report."
+// @Param unban query bool false "Unban the victim of a ban report." default(true)
+// @Param modlog query bool false "Post the revocation to the mod log channel." default(true)
+// @Param payload body models.ReasonRequest true "The revoke reason payload."
+// @Success 200 {object} models.Report
+// @Failure 400 {object} models.Error
+// @Failure 401 {object} models.Error
+// @Failure 403 {object} models.Error "Is returned when the report belongs to another guild."
+// @Failure 404 {object} models.Error
+// @Router /guilds/{id}/reports/{reportid} [delete]
+func (c *GuildsController) deleteReport(ctx *fiber.Ctx) error {
+	uid := ctx.Locals("uid").(string)
+
+	guildID := ctx.Params("guildid")
+
+	id, err := snowflake.ParseString(ctx.Params("reportid"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	unban, err := wsutil.GetQueryBool(ctx, "unban", true)
+	if err != nil {
+		return err
+	}
+	modlog, err := wsutil.GetQueryBool(ctx, "modlog", true)
+	if err != nil {
+		return err
+	}
+
+	var reason models.ReasonRequest
+	if err = ctx.BodyParser(&reason); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	if reason.Reason == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "reason must be set")
+	}
+
+	rep, err := c.db.GetReport(id)
+	if err != nil {
+		return wsutil.ErrInternalOrNotFound(err)
+	}
+	if rep.GuildID != guildID {
+		return fiber.NewError(fiber.StatusForbidden, "report belongs to another guild")
+	}
+
+	types, err := c.rep.GetReportTypes(guildID)
+	if err != nil {
+		return err
+	}
+
+	if rep.Revocation != nil {
+		return ctx.JSON(c.resolveReport(rep, types))
+	}
+
+	_, err = c.rep.RevokeReport(rep, uid, reason.Reason, c.cfg.Config().WebServer.PublicAddr,
+		sharedmodels.ReportRevokeOptions{
+			Unban:  unban,
+			Modlog: modlog,
+		})
+	if err == report.ErrReportRevoked {
+		err = nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if rev, err := c.db.GetReport(id); err == nil {
+		rep = rev
+	} else if !database.IsErrDatabaseNotFound(err) {
+		return err
+	}
+
+	return ctx.JSON(c.resolveReport(rep, types))
+}
+
+// @Summary Get Revoked Guild Reports
+// @Description Returns a list of revoked reports of the guild including their revocation details for auditing.
+// @Tags Guilds
+// @Accept json
+// @Produce json
+// @Param id path string true "The ID of the guild."
+// @Param offset query int false "The offset of returned entries" default(0)
+// @Param limit query int false "The amount of returned entries (0 = all)" default(0)
+// @Success 200 {array} models.Report "Wrapped in models.ListResponse with pagination"
+// @Failure 400 {object} models.Error
+// @Failure 401 {object} models.Error
+// @Failure 403 {object} models.Error
+// @Router /guilds/{id}/reports/revoked [get]
+func (c *GuildsController) getRevokedReports(ctx *fiber.Ctx) error {
+	guildID := ctx.Params("guildid")
+
+	limit, offset, err := wsutil.GetQueryPagination(ctx, 0, 0)
+	if err != nil {
+		return err
+	}
+
+	reps, err := c.db.GetRevokedReportsGuild(guildID, offset, limit)
+	if err != nil {
+		return err
+	}
+
+	total, err := c.db.GetRevokedReportsGuildCount(guildID)
+	if err != nil {
+		return err
+	}
+
+	types, err := c.rep.GetReportTypes(guildID)
+	if err != nil {
+		return err
+	}
+
+	resReps := make([]models.Report, len(reps))
+	for i, r := range reps {
+		resReps[i] = c.resolveReport(r, types)
 	}
 
-	return ctx.JSON(models.NewListResponse(joinlog))
+	return ctx.JSON(models.NewPagedListResponse(resReps, limit, offset, total))
 }
 
-// @Summary Reset Antiraid Joinlog
-// @Description Deletes all entries of the antiraid joinlog.
+// @Summary Get Guild Modlog Count
+// @Description Returns the total count of entries in the guild mod log.
 // @Tags Guilds
 // @Accept json
 // @Produce json
 // @Param id path string true "The ID of the guild."
-// @Success 200 {object} models.Status
+// @Success 200 {object} models.Count
 // @Failure 401 {object} models.Error
 // @Failure 404 {object} models.Error
-// @Router /guilds/{id}/antiraid/joinlog [delete]
-func (c *GuildsController) deleteGuildAntiraidJoinlog(ctx *fiber.Ctx) error {
+// @Router /guilds/{id}/reports/count [get]
+func (c *GuildsController) getReportsCount(ctx *fiber.Ctx) error {
+	uid := ctx.Locals("uid").(string)
+
 	guildID := ctx.Params("guildid")
 
-	if err := c.db.FlushAntiraidJoinList(guildID); err != nil && !database.IsErrDatabaseNotFound(err) {
+	if memb, _ := c.session.GuildMember(guildID, uid); memb == nil {
+		return fiber.ErrNotFound
+	}
+
+	count, err := c.db.GetReportsGuildCount(guildID)
+	if err != nil {
 		return err
 	}
 
-	return ctx.JSON(models.Ok)
+	return ctx.JSON(&models.Count{Count: count})
 }
 
-// @Summary Get Guild Starboard
-// @Description Returns a list of starboard entries for the given guild.
+// @Summary Import Guild Reports
+// @Description Imports a list of reports, e.g. from another moderation bot. The reports get new IDs but keep their original timestamps. Entries with an external ID which has already been imported are skipped. Invalid entries are reported per row; when atomic is set, nothing is imported if any entry is invalid.
 // @Tags Guilds
 // @Accept json
 // @Produce json
+// @Param X-Confirmation-Token header string false "Confirmation token obtained via /ota/confirmation. Required if confirmation is enabled."
 // @Param id path string true "The ID of the guild."
-// @Success 200 {array} models.StarboardEntryResponse "Wrapped in models.ListResponse"
+// @Param atomic query bool false "Import nothing if any entry is invalid." default(false)
+// @Param payload body []models.ReportImportEntry true "The reports to be imported."
+// @Success 200 {object} models.ReportImportResult
+// @Failure 400 {object} models.Error
 // @Failure 401 {object} models.Error
 // @Failure 404 {object} models.Error
-// @Router /guilds/{id}/starboard [get]
-func (c *GuildsController) getGuildStarboard(ctx *fiber.Ctx) error {
+// @Router /guilds/{id}/reports/import [post]
+func (c *GuildsController) postReportsImport(ctx *fiber.Ctx) error {
 	guildID := ctx.Params("guildid")
-	limit, err := wsutil.GetQueryInt(ctx, "limit", 20, 1, 100)
+
+	atomic, err := wsutil.GetQueryBool(ctx, "atomic", false)
 	if err != nil {
 		return err
 	}
-	offset, err := wsutil.GetQueryInt(ctx, "offset", 0, 0, 0)
+
+	var entries []models.ReportImportEntry
+	if err = ctx.BodyParser(&entries); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	res := &models.ReportImportResult{
+		Errors: make([]models.ReportImportError, 0),
+	}
+
+	reportTypes, err := c.rep.GetReportTypes(guildID)
 	if err != nil {
 		return err
 	}
-	sortQ := ctx.Query("sort")
 
-	var sort sharedmodels.StarboardSortBy
-	switch string(sortQ) {
-	case "latest":
-		sort = sharedmodels.StarboardSortByLatest
-	case "top":
-		sort = sharedmodels.StarboardSortByMostRated
-	default:
-		return fiber.NewError(fiber.StatusBadRequest, "invalid sort property")
+	now := c.tp.Now()
+	types := make([]sharedmodels.ReportType, len(entries))
+	for i, e := range entries {
+		if types[i], err = e.Validate(now, reportTypes); err != nil {
+			res.Errors = append(res.Errors, models.ReportImportError{
+				Index: i, ExternalID: e.ExternalID, Error: err.Error()})
+		}
 	}
 
-	entries, err := c.db.GetStarboardEntries(guildID, sort, limit, offset)
-	if err != nil && !database.IsErrDatabaseNotFound(err) {
-		return err
+	if atomic && len(res.Errors) > 0 {
+		return ctx.Status(fiber.StatusBadRequest).JSON(res)
 	}
 
-	results := make([]*models.StarboardEntryResponse, len(entries))
+	failed := make(map[int]struct{}, len(res.Errors))
+	for _, e := range res.Errors {
+		failed[e.Index] = struct{}{}
+	}
 
-	var i int
-	for _, e := range entries {
-		if e.Deleted {
+	type importedReport struct {
+		externalID string
+		id         snowflake.ID
+	}
+	imported := make([]importedReport, 0, len(entries))
+	seen := make(map[string]struct{})
+
+	for i, e := range entries {
+		if _, ok := failed[i]; ok {
 			continue
 		}
 
-		member, err := c.state.Member(guildID, e.AuthorID)
-		if err != nil {
+		if e.ExternalID != "" {
+			if _, ok := seen[e.ExternalID]; ok {
+				res.Skipped++
+				continue
+			}
+			_, err = c.db.GetReportImport(guildID, e.ExternalID)
+			if err == nil {
+				res.Skipped++
+				continue
+			}
+			if !database.IsErrDatabaseNotFound(err) {
+				return err
+			}
+			seen[e.ExternalID] = struct{}{}
+		}
+
+		nodeID := int64(types[i])
+		if types[i].IsCustom() {
+			nodeID = snowflakenodes.NodeIDCustomReports
+		}
+
+		rep := sharedmodels.Report{
+			ID:         snowflakenodes.GenerateAt(nodeID, e.Timestamp),
+			Type:       types[i],
+			GuildID:    guildID,
+			ExecutorID: e.ExecutorID,
+			VictimID:   e.VictimID,
+			Msg:        e.Reason,
+		}
+		if err = c.db.AddReport(rep); err != nil {
+			res.Errors = append(res.Errors, models.ReportImportError{
+				Index: i, ExternalID: e.ExternalID, Error: err.Error()})
+			if atomic {
+				break
+			}
 			continue
 		}
 
-		results[i] = &models.StarboardEntryResponse{
-			StarboardEntry: e,
-			AuthorUsername: member.User.String(),
-			AvatarURL:      member.User.AvatarURL(""),
-			MessageURL: discordutil.GetMessageLink(&discordgo.Message{
-				ChannelID: e.ChannelID,
-				ID:        e.MessageID,
-			}, guildID),
+		imported = append(imported, importedReport{e.ExternalID, rep.ID})
+	}
+
+	if atomic && len(res.Errors) > 0 {
+		// Roll back the reports which have been created
+		// before the failure.
+		for _, r := range imported {
+			if err = c.db.DeleteReport(r.id); err != nil {
+				return err
+			}
 		}
+		res.Skipped = 0
+		return ctx.Status(fiber.StatusBadRequest).JSON(res)
+	}
 
-		i++
+	for _, r := range imported {
+		if r.externalID == "" {
+			continue
+		}
+		if err = c.db.AddReportImport(guildID, r.externalID, r.id); err != nil {
+			return err
+		}
 	}
 
-	return ctx.JSON(models.NewListResponse(results[:i]))
+	res.Imported = len(imported)
+
+	return ctx.JSON(res)
 }
 
-// @Summary Get Guild Starboard Count
-// @Description Returns the count of starboard entries for the given guild.
+// @Summary Export Guild Config Bundle
+// @Description Returns the shinpuru configuration of the guild as a single versioned bundle which can be imported into another guild.
 // @Tags Guilds
 // @Accept json
 // @Produce json
 // @Param id path string true "The ID of the guild."
-// @Success 200 {object} models.Count
+// @Success 200 {object} configbundle.Bundle
 // @Failure 401 {object} models.Error
 // @Failure 404 {object} models.Error
-// @Router /guilds/{id}/starboard/count [get]
-func (c *GuildsController) getGuildStarboardCount(ctx *fiber.Ctx) error {
+// @Router /guilds/{id}/config/bundle [get]
+func (c *GuildsController) getGuildConfigBundle(ctx *fiber.Ctx) error {
 	guildID := ctx.Params("guildid")
 
-	count, err := c.db.GetStarboardEntriesCount(guildID)
-	if err != nil && !database.IsErrDatabaseNotFound(err) {
+	roles, channels, err := c.getGuildRolesAndChannels(guildID)
+	if err != nil {
 		return err
 	}
 
-	return ctx.JSON(models.Count{Count: count})
+	bundle, err := configbundle.Export(c.db, guildID, roles, channels, c.tp.Now())
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(bundle)
 }
 
-// @Summary Get Guild Modlog
-// @Description Returns a list of guild modlog entries for the given guild.
+// @Summary Import Guild Config Bundle
+// @Description Imports a config bundle into the guild. Roles and channels are mapped by name; settings referencing roles or channels which can not be mapped are dropped. When dryrun is set, only the mapping result is returned and nothing is changed. If the import fails, the previous configuration is restored. Because the bundle contains role permissions, importing it also requires the `sp.guild.config.perms` permission.
 // @Tags Guilds
 // @Accept json
 // @Produce json
+// @Param X-Confirmation-Token header string false "Confirmation token obtained via /ota/confirmation. Required if confirmation is enabled and dryrun is not set."
 // @Param id path string true "The ID of the guild."
-// @Param offset query int false "The offset of returned entries" default(0)
-// @Param limit query int false "The amount of returned entries (0 = all)" default(0)
-// @Success 200 {array} models.Report "Wrapped in models.ListResponse"
+// @Param dryrun query bool false "Only preview the import." default(false)
+// @Param payload body configbundle.Bundle true "The config bundle."
+// @Success 200 {object} configbundle.ImportResult
+// @Failure 400 {object} models.Error
 // @Failure 401 {object} models.Error
 // @Failure 404 {object} models.Error
-// @Router /guilds/{id}/reports [get]
-func (c *GuildsController) getReports(ctx *fiber.Ctx) error {
+// @Router /guilds/{id}/config/bundle [post]
+func (c *GuildsController) postGuildConfigBundle(ctx *fiber.Ctx) error {
 	uid := ctx.Locals("uid").(string)
-
 	guildID := ctx.Params("guildid")
 
-	offset, err := wsutil.GetQueryInt(ctx, "offset", 0, 0, 0)
+	dryRun, err := wsutil.GetQueryBool(ctx, "dryrun", false)
 	if err != nil {
 		return err
 	}
 
-	limit, err := wsutil.GetQueryInt(ctx, "limit", 0, 0, 0)
-	if err != nil {
-		return err
+	var bundle configbundle.Bundle
+	if err = ctx.BodyParser(&bundle); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
 	}
 
-	if memb, _ := c.session.GuildMember(guildID, uid); memb == nil {
-		return fiber.ErrNotFound
+	if !dryRun {
+		if ok, _, err := c.pmw.CheckPermissions(c.session, guildID, uid, "sp.guild.config.perms"); err != nil {
+			return wsutil.ErrInternalOrNotFound(err)
+		} else if !ok {
+			return fiber.ErrUnauthorized
+		}
 	}
 
-	var reps []sharedmodels.Report
+	roles, channels, err := c.getGuildRolesAndChannels(guildID)
+	if err != nil {
+		return err
+	}
 
-	reps, err = c.db.GetReportsGuild(guildID, offset, limit)
+	res, err := configbundle.Import(c.db, guildID, &bundle, roles, channels, dryRun, c.tp.Now())
+	if errors.Is(err, configbundle.ErrInvalidBundle) || errors.Is(err, configbundle.ErrUnsupportedVersion) {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
 	if err != nil {
 		return err
 	}
 
-	resReps := make([]models.Report, 0)
-	if reps != nil {
-		resReps = make([]models.Report, len(reps))
-		for i, r := range reps {
-			resReps[i] = models.ReportFromReport(r, c.cfg.Config().WebServer.PublicAddr)
-			user, err := c.state.User(r.VictimID)
-			if err == nil {
-				resReps[i].Victim = models.FlatUserFromUser(user)
-			}
-			user, err = c.state.User(r.ExecutorID)
-			if err == nil {
-				resReps[i].Executor = models.FlatUserFromUser(user)
-			}
+	if !dryRun {
+		err = c.db.AddSettingsAuditEntry(sharedmodels.SettingsAuditEntry{
+			ID:        snowflakenodes.NodeSettingsAudit.Generate(),
+			GuildID:   guildID,
+			ActorID:   uid,
+			Field:     "configbundle",
+			NewValue:  fmt.Sprintf("imported from guild %s", bundle.GuildID),
+			Timestamp: c.tp.Now(),
+		})
+		if err != nil {
+			ctlLog.Error().Err(err).Field("gid", guildID).Msg("Failed adding settings audit entry")
 		}
 	}
 
-	return ctx.JSON(models.NewListResponse(resReps))
+	return ctx.JSON(res)
 }
 
-// @Summary Get Guild Modlog Count
-// @Description Returns the total count of entries in the guild mod log.
-// @Tags Guilds
-// @Accept json
-// @Produce json
-// @Param id path string true "The ID of the guild."
-// @Success 200 {object} models.Count
-// @Failure 401 {object} models.Error
-// @Failure 404 {object} models.Error
-// @Router /guilds/{id}/reports/count [get]
-func (c *GuildsController) getReportsCount(ctx *fiber.Ctx) error {
-	uid := ctx.Locals("uid").(string)
-
-	guildID := ctx.Params("guildid")
-
-	if memb, _ := c.session.GuildMember(guildID, uid); memb == nil {
-		return fiber.ErrNotFound
-	}
-
-	count, err := c.db.GetReportsGuildCount(guildID)
-	if err != nil {
-		return err
+func (c *GuildsController) getGuildRolesAndChannels(guildID string) (
+	roles []*discordgo.Role,
+	channels []*discordgo.Channel,
+	err error,
+) {
+	if roles, err = c.state.Roles(guildID); err != nil {
+		return
 	}
-
-	return ctx.JSON(&models.Count{Count: count})
+	channels, err = c.state.Channels(guildID)
+	return
 }
 
 // @Summary Get Guild Permission Settings
@@ -454,13 +1234,8 @@ func (c *GuildsController) postGuildPermissions(ctx *fiber.Ctx) error {
 	guildID := ctx.Params("guildid")
 
 	update := new(models.PermissionsUpdate)
-	if err := ctx.BodyParser(update); err != nil {
-		return fiber.ErrBadRequest
-	}
-
-	sperm := update.Perm[1:]
-	if !strings.HasPrefix(sperm, "sp.guild") && !strings.HasPrefix(sperm, "sp.etc") && !strings.HasPrefix(sperm, "sp.chat") {
-		return fiber.NewError(fiber.StatusBadRequest, "you can only give permissions over the domains 'sp.guild', 'sp.etc' and 'sp.chat'")
+	if err := wsutil.ParseAndValidate(ctx, update); err != nil {
+		return err
 	}
 
 	perms, err := c.db.GetGuildPermissions(guildID)
@@ -495,6 +1270,146 @@ func (c *GuildsController) postGuildPermissions(ctx *fiber.Ctx) error {
 	return ctx.JSON(perms)
 }
 
+// @Summary Preview Guild Permission Rules
+// @Description Returns the permission rules of the affected roles and the effective permissions of members before and after applying the passed permission updates. If no members are specified, a sample of members with affected roles is used. Roles are listed in the order of the role hierarchy and must exist in the guild. Nothing is persisted.
+// @Tags Guilds
+// @Accept json
+// @Produce json
+// @Param id path string true "The ID of the guild."
+// @Param payload body models.PermissionsPreviewRequest true "The permission updates to preview."
+// @Success 200 {object} models.PermissionsPreview
+// @Failure 400 {object} models.Error
+// @Failure 401 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Router /guilds/{id}/permissions/preview [post]
+func (c *GuildsController) postGuildPermissionsPreview(ctx *fiber.Ctx) error {
+	guildID := ctx.Params("guildid")
+
+	req := new(models.PermissionsPreviewRequest)
+	if err := wsutil.ParseAndValidate(ctx, req); err != nil {
+		return err
+	}
+
+	res, err := c.permissionsPreview(c.session, guildID, req)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(res)
+}
+
+// permissionsPreview returns the effect of the permission
+// updates of the given request on the roles and members
+// of the given guild.
+func (c *GuildsController) permissionsPreview(
+	s discordutil.ISession,
+	guildID string,
+	req *models.PermissionsPreviewRequest,
+) (res models.PermissionsPreview, err error) {
+	roles, err := c.state.Roles(guildID)
+	if err != nil {
+		return
+	}
+	guildRoles := make(map[string]*discordgo.Role, len(roles))
+	for _, r := range roles {
+		guildRoles[r.ID] = r
+	}
+
+	for _, update := range req.Updates {
+		for _, roleID := range update.RoleIDs {
+			if _, ok := guildRoles[roleID]; !ok {
+				err = fiber.NewError(fiber.StatusBadRequest,
+					fmt.Sprintf("role %s could not be found", roleID))
+				return
+			}
+		}
+	}
+
+	before, err := c.db.GetGuildPermissions(guildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return
+	}
+
+	// The updates are applied to a copy so that the
+	// (possibly cached) stored rules are not modified.
+	after := make(map[string]permissions.PermissionArray, len(before))
+	for roleID, rperms := range before {
+		after[roleID] = rperms
+	}
+
+	affected := make(map[string]struct{})
+	for _, update := range req.Updates {
+		for _, roleID := range update.RoleIDs {
+			rperms, _ := after[roleID].Update(update.Perm, update.Override)
+			if len(rperms) == 0 {
+				delete(after, roleID)
+			} else {
+				after[roleID] = rperms
+			}
+			affected[roleID] = struct{}{}
+		}
+	}
+
+	res.Roles = make([]models.PermissionsPreviewRole, 0, len(affected))
+	for roleID := range affected {
+		res.Roles = append(res.Roles, models.PermissionsPreviewRole{
+			RoleID: roleID,
+			Before: before[roleID],
+			After:  after[roleID],
+		})
+	}
+	// Roles are listed in the order of the role hierarchy.
+	sort.Slice(res.Roles, func(i, j int) bool {
+		a, b := guildRoles[res.Roles[i].RoleID], guildRoles[res.Roles[j].RoleID]
+		return a.Position > b.Position || a.Position == b.Position && a.ID < b.ID
+	})
+
+	members, err := c.getPermissionsPreviewMembers(guildID, req.MemberIDs, affected)
+	if err != nil {
+		return
+	}
+
+	res.Members = make([]models.PermissionsPreviewMember, 0, len(members))
+	for _, m := range members {
+		var pBefore, pAfter permissions.PermissionArray
+		var override bool
+		if pBefore, _, err = c.pmw.ResolvePermissions(s, guildID, m.User.ID, before); err != nil {
+			return
+		}
+		if pAfter, override, err = c.pmw.ResolvePermissions(s, guildID, m.User.ID, after); err != nil {
+			return
+		}
+
+		pm := models.PermissionsPreviewMember{
+			User:     models.FlatUserFromUser(m.User),
+			Roles:    make([]string, 0),
+			Override: override,
+			Before:   pBefore,
+			After:    pAfter,
+			Changed:  !pBefore.Equals(pAfter),
+		}
+		for _, roleID := range m.Roles {
+			if _, ok := after[roleID]; ok {
+				pm.Roles = append(pm.Roles, roleID)
+			}
+		}
+		if _, ok := after[guildID]; ok {
+			pm.Roles = append(pm.Roles, guildID)
+		}
+		for _, dn := range req.Check {
+			pm.Checks = append(pm.Checks, models.PermissionsPreviewCheck{
+				Perm:   dn,
+				Before: pBefore.Check(dn),
+				After:  pAfter.Check(dn),
+			})
+		}
+
+		res.Members = append(res.Members, pm)
+	}
+
+	return
+}
+
 // @Summary Toggle Guild Inviteblock Enable
 // @Description Toggle enabled state of the guild invite block system.
 // @Tags Guilds
@@ -760,6 +1675,18 @@ func (c *GuildsController) postGuildUnbanrequest(ctx *fiber.Ctx) error {
 // ---------------------------------------------------------------------------
 // - HELPERS
 
+// skipOnDryRun returns a handler which passes requests
+// with the dryrun query parameter set directly to the
+// next handler and calls h otherwise.
+func skipOnDryRun(h fiber.Handler) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		if dryRun, _ := wsutil.GetQueryBool(ctx, "dryrun", false); dryRun {
+			return ctx.Next()
+		}
+		return h(ctx)
+	}
+}
+
 func checkEmojis(emojis []string) bool {
 	for _, e := range emojis {
 		if !isemoji.IsEmojiNonStrict(e) {
@@ -768,3 +1695,95 @@ func checkEmojis(emojis []string) bool {
 	}
 	return true
 }
+
+// getRoleMemberCounts returns the amount of members per
+// role ID of the given guild. Because this requires to
+// iterate over all members of the guild, the result is
+// cached for a short time.
+func (c *GuildsController) getRoleMemberCounts(guildID string) (counts map[string]int, err error) {
+	cacheKey := "guildrolecounts:" + guildID
+	if counts, ok := c.kvc.Get(cacheKey).(map[string]int); ok {
+		return counts, nil
+	}
+
+	members, err := c.state.Members(guildID)
+	if err != nil {
+		return
+	}
+
+	counts = make(map[string]int)
+	counts[guildID] = len(members)
+	for _, m := range members {
+		for _, rID := range m.Roles {
+			counts[rID]++
+		}
+	}
+
+	c.kvc.Set(cacheKey, counts, 1*time.Minute)
+	return
+}
+
+// getPermissionsPreviewMembers returns the members with the
+// given IDs or, if no IDs are passed, a sample of members
+// which have at least one of the affected roles.
+func (c *GuildsController) getPermissionsPreviewMembers(
+	guildID string,
+	memberIDs []string,
+	affected map[string]struct{},
+) (members []*discordgo.Member, err error) {
+	if len(memberIDs) != 0 {
+		members = make([]*discordgo.Member, 0, len(memberIDs))
+		for _, id := range memberIDs {
+			m, err := c.state.Member(guildID, id)
+			if discordutil.IsErrCode(err, discordgo.ErrCodeUnknownMember) {
+				return nil, fiber.NewError(fiber.StatusBadRequest,
+					fmt.Sprintf("member %s could not be found", id))
+			}
+			if err != nil {
+				return nil, err
+			}
+			members = append(members, m)
+		}
+		return
+	}
+
+	all, err := c.state.Members(guildID)
+	if err != nil {
+		return
+	}
+
+	// Rules of the @everyone role, which has the ID
+	// of the guild, affect all members.
+	_, everyone := affected[guildID]
+
+	members = make([]*discordgo.Member, 0, permissionsPreviewSampleSize)
+	for _, m := range all {
+		if len(members) == permissionsPreviewSampleSize {
+			break
+		}
+		if m.User == nil || m.User.Bot {
+			continue
+		}
+		if everyone || sop.Slice(m.Roles).Any(func(v string, i int) bool {
+			_, ok := affected[v]
+			return ok
+		}) {
+			members = append(members, m)
+		}
+	}
+
+	return
+}
+
+// resolveReport returns the response model of the
+// given report with resolved victim and executor.
+func (c *GuildsController) resolveReport(r sharedmodels.Report, types sharedmodels.ReportTypeSet) models.Report {
+	res := models.ReportFromReport(r, c.cfg.Config().WebServer.PublicAddr, types)
+	if user, err := c.state.User(r.VictimID); err == nil {
+		res.Victim = models.FlatUserFromUser(user)
+	}
+	if user, err := c.state.User(r.ExecutorID); err == nil {
+		res.Executor = models.FlatUserFromUser(user)
+	}
+	return res
+}
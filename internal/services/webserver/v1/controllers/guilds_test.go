@@ -0,0 +1,116 @@
+package controllers
+
+import (
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sarulabs/di/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sharedmodels "github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/services/database/memory"
+	permservice "github.com/zekroTJA/shinpuru/internal/services/permissions"
+	"github.com/zekroTJA/shinpuru/internal/services/webserver/v1/models"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/mocks"
+	"github.com/zekroTJA/shinpuru/pkg/permissions"
+)
+
+func getPermissionsPreviewController(t *testing.T) (*GuildsController, *mocks.ISession) {
+	db := memory.New()
+	require.Nil(t, db.SetGuildRolePermission("guild", "role-a", permissions.PermissionArray{"+sp.guild.mod.ban"}))
+
+	roles := []*discordgo.Role{
+		{ID: "guild", Position: 0},
+		{ID: "role-b", Position: 1},
+		{ID: "role-a", Position: 2},
+	}
+	member := &discordgo.Member{
+		User:  &discordgo.User{ID: "user"},
+		Roles: []string{"role-b"},
+	}
+
+	st := &mocks.IState{}
+	st.On("Roles", "guild").Return(roles, nil)
+	st.On("Member", "guild", "user").Return(member, nil)
+	st.On("Guild", "guild", true).Return(&discordgo.Guild{ID: "guild", OwnerID: "owner", Roles: roles}, nil)
+
+	s := &mocks.ISession{}
+	s.On("GuildMember", "guild", "user").Return(member, nil)
+	s.On("GuildRoles", "guild").Return(roles, nil)
+
+	cfg := &mocks.ConfigProvider{}
+	cfg.On("Config").Return(&sharedmodels.Config{})
+
+	ct, _ := di.NewBuilder()
+	ct.Add(
+		di.Def{
+			Name:  static.DiDatabase,
+			Build: func(ctn di.Container) (interface{}, error) { return db, nil },
+		},
+		di.Def{
+			Name:  static.DiConfig,
+			Build: func(ctn di.Container) (interface{}, error) { return cfg, nil },
+		},
+		di.Def{
+			Name:  static.DiState,
+			Build: func(ctn di.Container) (interface{}, error) { return st, nil },
+		},
+	)
+
+	return &GuildsController{
+		db:    db,
+		state: st,
+		pmw:   permservice.NewPermissions(ct.Build()),
+	}, s
+}
+
+func TestPermissionsPreview(t *testing.T) {
+	c, s := getPermissionsPreviewController(t)
+
+	res, err := c.permissionsPreview(s, "guild", &models.PermissionsPreviewRequest{
+		Updates: []models.PermissionsUpdate{
+			{Perm: "+sp.guild.config.*", RoleIDs: []string{"role-b", "role-a"}},
+		},
+		MemberIDs: []string{"user"},
+		Check:     []string{"sp.guild.config.perms"},
+	})
+	require.Nil(t, err)
+
+	require.Len(t, res.Roles, 2)
+	assert.Equal(t, "role-a", res.Roles[0].RoleID)
+	assert.Equal(t, permissions.PermissionArray{"+sp.guild.mod.ban"}, res.Roles[0].Before)
+	assert.Equal(t, "role-b", res.Roles[1].RoleID)
+	assert.Empty(t, res.Roles[1].Before)
+	assert.Equal(t, permissions.PermissionArray{"+sp.guild.config.*"}, res.Roles[1].After)
+
+	require.Len(t, res.Members, 1)
+	pm := res.Members[0]
+	assert.Equal(t, "user", pm.User.ID)
+	assert.True(t, pm.Changed)
+	assert.False(t, pm.Override)
+	assert.Equal(t, []string{"role-b"}, pm.Roles)
+	assert.Equal(t, []models.PermissionsPreviewCheck{
+		{Perm: "sp.guild.config.perms", Before: false, After: true},
+	}, pm.Checks)
+
+	// Nothing is persisted.
+	perms, err := c.db.GetGuildPermissions("guild")
+	require.Nil(t, err)
+	assert.NotContains(t, perms, "role-b")
+}
+
+func TestPermissionsPreviewUnknownRole(t *testing.T) {
+	c, s := getPermissionsPreviewController(t)
+
+	_, err := c.permissionsPreview(s, "guild", &models.PermissionsPreviewRequest{
+		Updates: []models.PermissionsUpdate{
+			{Perm: "+sp.guild.config.*", RoleIDs: []string{"role-a", "role-unknown"}},
+		},
+	})
+
+	var ferr *fiber.Error
+	require.ErrorAs(t, err, &ferr)
+	assert.Equal(t, fiber.StatusBadRequest, ferr.Code)
+}
@@ -2,7 +2,10 @@ package controllers
 
 import (
 	"crypto"
+	"encoding/json"
 	"fmt"
+	"reflect"
+	"strconv"
 	"strings"
 	"time"
 
@@ -16,30 +19,43 @@ import (
 	"github.com/zekroTJA/shinpuru/internal/services/database"
 	"github.com/zekroTJA/shinpuru/internal/services/kvcache"
 	permservice "github.com/zekroTJA/shinpuru/internal/services/permissions"
+	"github.com/zekroTJA/shinpuru/internal/services/report"
 	"github.com/zekroTJA/shinpuru/internal/services/storage"
+	"github.com/zekroTJA/shinpuru/internal/services/timeprovider"
 	"github.com/zekroTJA/shinpuru/internal/services/verification"
 	"github.com/zekroTJA/shinpuru/internal/services/webserver/v1/models"
 	"github.com/zekroTJA/shinpuru/internal/services/webserver/wsutil"
 	"github.com/zekroTJA/shinpuru/internal/util"
+	"github.com/zekroTJA/shinpuru/internal/util/botnick"
+	"github.com/zekroTJA/shinpuru/internal/util/guildsettings"
+	"github.com/zekroTJA/shinpuru/internal/util/membermsg"
 	"github.com/zekroTJA/shinpuru/internal/util/snowflakenodes"
 	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/internal/util/stickymsg"
+	"github.com/zekroTJA/shinpuru/pkg/discordutil"
 	"github.com/zekroTJA/shinpuru/pkg/fetch"
 	"github.com/zekroTJA/shinpuru/pkg/hashutil"
 	"github.com/zekroTJA/shinpuru/pkg/jdoodle"
+	"github.com/zekroTJA/shinpuru/pkg/permissions"
 	"github.com/zekroTJA/shinpuru/pkg/stringutil"
+	"github.com/zekroTJA/shinpuru/pkg/twitchnotify"
 	"github.com/zekrotja/dgrs"
+	"github.com/zekrotja/ken"
 )
 
 type GuildsSettingsController struct {
-	db      database.Database
-	st      storage.Storage
-	kvc     kvcache.Provider
-	session *discordgo.Session
-	cfg     config.Provider
-	pmw     *permservice.Permissions
-	state   *dgrs.State
-	vs      verification.Provider
-	cef     codeexec.Factory
+	db         database.Database
+	st         storage.Storage
+	kvc        kvcache.Provider
+	session    *discordgo.Session
+	cfg        config.Provider
+	pmw        *permservice.Permissions
+	state      dgrs.IState
+	vs         verification.Provider
+	cef        codeexec.Factory
+	tp         timeprovider.Provider
+	cmdHandler *ken.Ken
+	rep        report.Provider
 }
 
 func (c *GuildsSettingsController) Setup(container di.Container, router fiber.Router) {
@@ -49,12 +65,16 @@ func (c *GuildsSettingsController) Setup(container di.Container, router fiber.Ro
 	c.pmw = container.Get(static.DiPermissions).(*permservice.Permissions)
 	c.kvc = container.Get(static.DiKVCache).(kvcache.Provider)
 	c.st = container.Get(static.DiObjectStorage).(storage.Storage)
-	c.state = container.Get(static.DiState).(*dgrs.State)
+	c.state = container.Get(static.DiState).(dgrs.IState)
 	c.vs = container.Get(static.DiVerification).(verification.Provider)
 	c.cef = container.Get(static.DiCodeExecFactory).(codeexec.Factory)
+	c.tp = container.Get(static.DiTimeProvider).(timeprovider.Provider)
+	c.cmdHandler = container.Get(static.DiCommandHandler).(*ken.Ken)
+	c.rep = container.Get(static.DiReport).(report.Provider)
 
 	router.Get("", c.getGuildSettings)
 	router.Post("", c.postGuildSettings)
+	router.Get("/effective", c.getGuildSettingsEffective)
 	router.Get("/karma", c.pmw.HandleWs(c.session, "sp.guild.config.karma"), c.getGuildSettingsKarma)
 	router.Post("/karma", c.pmw.HandleWs(c.session, "sp.guild.config.karma"), c.postGuildSettingsKarma)
 	router.Get("/karma/blocklist", c.pmw.HandleWs(c.session, "sp.guild.config.karma"), c.getGuildSettingsKarmaBlocklist)
@@ -73,13 +93,48 @@ func (c *GuildsSettingsController) Setup(container di.Container, router fiber.Ro
 	router.Delete("/logs/:id", c.pmw.HandleWs(c.session, "sp.guild.config.logs"), c.deleteGuildSettingsLogEntries)
 	router.Get("/logs/state", c.pmw.HandleWs(c.session, "sp.guild.config.logs"), c.getGuildSettingsLogsState)
 	router.Post("/logs/state", c.pmw.HandleWs(c.session, "sp.guild.config.logs"), c.postGuildSettingsLogsState)
-	router.Post("/flushguilddata", c.pmw.HandleWs(c.session, "sp.guild.admin.flushdata"), c.postFlushGuildData)
+	router.Post("/flushguilddata", c.pmw.HandleWs(c.session, "sp.guild.admin.flushdata"), confirmation(container), c.postFlushGuildData)
 	router.Get("/api", c.pmw.HandleWs(c.session, "sp.guild.config.api"), c.getGuildSettingsAPI)
 	router.Post("/api", c.pmw.HandleWs(c.session, "sp.guild.config.api"), c.postGuildSettingsAPI)
 	router.Get("/verification", c.pmw.HandleWs(c.session, "sp.guild.config.verification"), c.getGuildSettingsVerification)
 	router.Post("/verification", c.pmw.HandleWs(c.session, "sp.guild.config.verification"), c.postGuildSettingsVerification)
 	router.Get("/codeexec", c.pmw.HandleWs(c.session, "sp.guild.config.exec"), c.getGuildSettingsCodeExec)
 	router.Post("/codeexec", c.pmw.HandleWs(c.session, "sp.guild.config.exec"), c.postGuildSettingsCodeExec)
+	router.Get("/botnick", c.pmw.HandleWs(c.session, "sp.guild.config.botnick"), c.getGuildSettingsBotNick)
+	router.Post("/botnick", c.pmw.HandleWs(c.session, "sp.guild.config.botnick"), c.postGuildSettingsBotNick)
+	router.Get("/audit", c.pmw.HandleWs(c.session, "sp.guild.admin.audit"), c.getGuildSettingsAudit)
+	router.Get("/commands", c.pmw.HandleWs(c.session, "sp.guild.config.commands"), c.getGuildSettingsCommands)
+	router.Post("/commands", c.pmw.HandleWs(c.session, "sp.guild.config.commands"), c.postGuildSettingsCommands)
+	router.Put("/commands/disabled/:name", c.pmw.HandleWs(c.session, "sp.guild.config.commands"), c.putGuildSettingsCommandsDisabled)
+	router.Delete("/commands/disabled/:name", c.pmw.HandleWs(c.session, "sp.guild.config.commands"), c.deleteGuildSettingsCommandsDisabled)
+	router.Get("/modlog/routes", c.pmw.HandleWs(c.session, "sp.guild.config.modlog"), c.getGuildSettingsModLogRoutes)
+	router.Put("/modlog/routes/:action", c.pmw.HandleWs(c.session, "sp.guild.config.modlog"), c.putGuildSettingsModLogRoute)
+	router.Delete("/modlog/routes/:action", c.pmw.HandleWs(c.session, "sp.guild.config.modlog"), c.deleteGuildSettingsModLogRoute)
+	router.Get("/autodelete", c.pmw.HandleWs(c.session, "sp.guild.config.autodelete"), c.getGuildSettingsAutoDeletes)
+	router.Put("/autodelete", c.pmw.HandleWs(c.session, "sp.guild.config.autodelete"), c.putGuildSettingsAutoDelete)
+	router.Delete("/autodelete", c.pmw.HandleWs(c.session, "sp.guild.config.autodelete"), c.deleteGuildSettingsAutoDelete)
+	router.Delete("/autodelete/:channelid", c.pmw.HandleWs(c.session, "sp.guild.config.autodelete"), c.deleteGuildSettingsAutoDelete)
+	router.Get("/autothreads", c.pmw.HandleWs(c.session, "sp.guild.config.autothread"), c.getGuildSettingsAutoThreads)
+	router.Put("/autothreads/:channelid", c.pmw.HandleWs(c.session, "sp.guild.config.autothread"), c.putGuildSettingsAutoThread)
+	router.Delete("/autothreads/:channelid", c.pmw.HandleWs(c.session, "sp.guild.config.autothread"), c.deleteGuildSettingsAutoThread)
+	router.Get("/autopublish", c.pmw.HandleWs(c.session, "sp.guild.config.autopublish"), c.getGuildSettingsAutoPublishes)
+	router.Put("/autopublish/:channelid", c.pmw.HandleWs(c.session, "sp.guild.config.autopublish"), c.putGuildSettingsAutoPublish)
+	router.Delete("/autopublish/:channelid", c.pmw.HandleWs(c.session, "sp.guild.config.autopublish"), c.deleteGuildSettingsAutoPublish)
+	router.Get("/prefixless", c.pmw.HandleWs(c.session, "sp.guild.config.cmdsuggestions"), c.getGuildSettingsPrefixless)
+	router.Put("/prefixless/:channelid", c.pmw.HandleWs(c.session, "sp.guild.config.cmdsuggestions"), c.putGuildSettingsPrefixless)
+	router.Delete("/prefixless/:channelid", c.pmw.HandleWs(c.session, "sp.guild.config.cmdsuggestions"), c.deleteGuildSettingsPrefixless)
+	router.Get("/stickymessages", c.pmw.HandleWs(c.session, "sp.guild.config.sticky"), c.getGuildSettingsStickyMessages)
+	router.Put("/stickymessages/:channelid", c.pmw.HandleWs(c.session, "sp.guild.config.sticky"), c.putGuildSettingsStickyMessage)
+	router.Delete("/stickymessages/:channelid", c.pmw.HandleWs(c.session, "sp.guild.config.sticky"), c.deleteGuildSettingsStickyMessage)
+	router.Get("/reporttypes", c.pmw.HandleWs(c.session, "sp.guild.config.reporttypes"), c.getGuildSettingsReportTypes)
+	router.Post("/reporttypes", c.pmw.HandleWs(c.session, "sp.guild.config.reporttypes"), c.createGuildSettingsReportType)
+	router.Post("/reporttypes/:id", c.pmw.HandleWs(c.session, "sp.guild.config.reporttypes"), c.updateGuildSettingsReportType)
+	router.Delete("/reporttypes/:id", c.pmw.HandleWs(c.session, "sp.guild.config.reporttypes"), c.deleteGuildSettingsReportType)
+	router.Get("/escalation", c.pmw.HandleWs(c.session, "sp.guild.config.escalation"), c.getGuildSettingsEscalation)
+	router.Post("/escalation", c.pmw.HandleWs(c.session, "sp.guild.config.escalation"), c.postGuildSettingsEscalation)
+	router.Post("/joinmessage/preview", c.pmw.HandleWs(c.session, "sp.guild.config.announcements"), c.postGuildSettingsMessagePreview)
+	router.Post("/leavemessage/preview", c.pmw.HandleWs(c.session, "sp.guild.config.announcements"), c.postGuildSettingsMessagePreview)
+	router.Post("/twitchnotify/preview", c.pmw.HandleWs(c.session, "sp.chat.twitch"), c.postGuildSettingsTwitchNotifyPreview)
 }
 
 // @Summary Get Guild Settings
@@ -95,42 +150,51 @@ func (c *GuildsSettingsController) Setup(container di.Container, router fiber.Ro
 func (c *GuildsSettingsController) getGuildSettings(ctx *fiber.Ctx) error {
 	guildID := ctx.Params("guildid")
 
-	gs := new(models.GuildSettings)
-	var err error
-
-	if gs.Prefix, err = c.db.GetGuildPrefix(guildID); err != nil && !database.IsErrDatabaseNotFound(err) {
-		return err
-	}
-
-	if gs.Perms, err = c.db.GetGuildPermissions(guildID); err != nil && !database.IsErrDatabaseNotFound(err) {
-		return err
-	}
-
-	if gs.AutoRoles, err = c.db.GetGuildAutoRole(guildID); err != nil && !database.IsErrDatabaseNotFound(err) {
+	s, err := guildsettings.Effective(c.db, guildID)
+	if err != nil {
 		return err
 	}
 
-	if gs.ModLogChannel, err = c.db.GetGuildModLog(guildID); err != nil && !database.IsErrDatabaseNotFound(err) {
-		return err
+	gs := &models.GuildSettings{
+		Prefix:              guildsettings.Value[string](s, "prefix"),
+		Perms:               guildsettings.Value[map[string]permissions.PermissionArray](s, "perms"),
+		AutoRoles:           guildsettings.Value[[]string](s, "autoroles"),
+		ModLogChannel:       guildsettings.Value[string](s, "modlogchannel"),
+		ModLogFormat:        guildsettings.Value[string](s, "modlogformat"),
+		ModNotChannel:       guildsettings.Value[string](s, "modnotchannel"),
+		VoiceLogChannel:     guildsettings.Value[string](s, "voicelogchannel"),
+		JoinMessageChannel:  guildsettings.Value[string](s, "joinmessagechannel"),
+		JoinMessageText:     guildsettings.Value[string](s, "joinmessagetext"),
+		LeaveMessageChannel: guildsettings.Value[string](s, "leavemessagechannel"),
+		LeaveMessageText:    guildsettings.Value[string](s, "leavemessagetext"),
+		AnnouncementChannel: guildsettings.Value[string](s, "announcementchannel"),
 	}
-
-	if gs.ModNotChannel, err = c.db.GetGuildModNot(guildID); err != nil && !database.IsErrDatabaseNotFound(err) {
-		return err
+	if s["embedcolor"].IsSet {
+		gs.EmbedColor = guildsettings.Value[string](s, "embedcolor")
 	}
 
-	if gs.VoiceLogChannel, err = c.db.GetGuildVoiceLog(guildID); err != nil && !database.IsErrDatabaseNotFound(err) {
-		return err
-	}
+	return ctx.JSON(gs)
+}
 
-	if gs.JoinMessageChannel, gs.JoinMessageText, err = c.db.GetGuildJoinMsg(guildID); err != nil && !database.IsErrDatabaseNotFound(err) {
-		return err
-	}
+// @Summary Get Effective Guild Settings
+// @Description Returns the general guild settings merged with their defaults. Each setting contains the effective value, the default value and whether the setting has been set explicitly.
+// @Tags Guild Settings
+// @Accept json
+// @Produce json
+// @Param id path string true "The ID of the guild."
+// @Success 200 {object} guildsettings.Settings
+// @Failure 401 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Router /guilds/{id}/settings/effective [get]
+func (c *GuildsSettingsController) getGuildSettingsEffective(ctx *fiber.Ctx) error {
+	guildID := ctx.Params("guildid")
 
-	if gs.LeaveMessageChannel, gs.LeaveMessageText, err = c.db.GetGuildLeaveMsg(guildID); err != nil && !database.IsErrDatabaseNotFound(err) {
+	s, err := guildsettings.Effective(c.db, guildID)
+	if err != nil {
 		return err
 	}
 
-	return ctx.JSON(gs)
+	return ctx.JSON(s)
 }
 
 // @Summary Get Guild Settings
@@ -185,9 +249,17 @@ func (c *GuildsSettingsController) postGuildSettings(ctx *fiber.Ctx) error {
 				"Following RoleIDs are not existent on this guild: [%s]", strings.Join(nc, ", ")))
 		}
 
+		oldAutoRoles, err := c.db.GetGuildAutoRole(guildID)
+		if err != nil && !database.IsErrDatabaseNotFound(err) {
+			return wsutil.ErrInternalOrNotFound(err)
+		}
+
 		if err = c.db.SetGuildAutoRole(guildID, gs.AutoRoles); err != nil {
 			return wsutil.ErrInternalOrNotFound(err)
 		}
+
+		c.audit(guildID, uid, "autoroles",
+			strings.Join(oldAutoRoles, ","), strings.Join(gs.AutoRoles, ","))
 	}
 
 	if gs.ModLogChannel != "" {
@@ -201,9 +273,40 @@ func (c *GuildsSettingsController) postGuildSettings(ctx *fiber.Ctx) error {
 			gs.ModLogChannel = ""
 		}
 
+		oldModLog, err := c.db.GetGuildModLog(guildID)
+		if err != nil && !database.IsErrDatabaseNotFound(err) {
+			return wsutil.ErrInternalOrNotFound(err)
+		}
+
 		if err = c.db.SetGuildModLog(guildID, gs.ModLogChannel); err != nil {
 			return wsutil.ErrInternalOrNotFound(err)
 		}
+
+		c.audit(guildID, uid, "modlogchannel", oldModLog, gs.ModLogChannel)
+	}
+
+	if gs.ModLogFormat != "" {
+		if ok, _, err := c.pmw.CheckPermissions(c.session, guildID, uid, "sp.guild.config.modlog"); err != nil {
+			return wsutil.ErrInternalOrNotFound(err)
+		} else if !ok {
+			return fiber.ErrUnauthorized
+		}
+
+		format := sharedmodels.ModLogFormat(gs.ModLogFormat)
+		if !format.Validate() {
+			return fiber.NewError(fiber.StatusBadRequest, "invalid mod log format")
+		}
+
+		oldFormat, err := c.db.GetGuildModLogFormat(guildID)
+		if err != nil && !database.IsErrDatabaseNotFound(err) {
+			return wsutil.ErrInternalOrNotFound(err)
+		}
+
+		if err = c.db.SetGuildModLogFormat(guildID, format); err != nil {
+			return wsutil.ErrInternalOrNotFound(err)
+		}
+
+		c.audit(guildID, uid, "modlogformat", string(oldFormat), gs.ModLogFormat)
 	}
 
 	if gs.ModNotChannel != "" {
@@ -217,9 +320,41 @@ func (c *GuildsSettingsController) postGuildSettings(ctx *fiber.Ctx) error {
 			gs.ModNotChannel = ""
 		}
 
+		oldModNot, err := c.db.GetGuildModNot(guildID)
+		if err != nil && !database.IsErrDatabaseNotFound(err) {
+			return wsutil.ErrInternalOrNotFound(err)
+		}
+
 		if err = c.db.SetGuildModNot(guildID, gs.ModNotChannel); err != nil {
 			return wsutil.ErrInternalOrNotFound(err)
 		}
+
+		c.audit(guildID, uid, "modnotchannel", oldModNot, gs.ModNotChannel)
+	}
+
+	if gs.AnnouncementChannel != "" {
+		if ok, _, err := c.pmw.CheckPermissions(c.session, guildID, uid, "sp.guild.config.announcementchannel"); err != nil {
+			return wsutil.ErrInternalOrNotFound(err)
+		} else if !ok {
+			return fiber.ErrForbidden
+		}
+
+		if gs.AnnouncementChannel == "__RESET__" {
+			gs.AnnouncementChannel = ""
+		} else if ch, err := c.state.Channel(gs.AnnouncementChannel); err != nil || ch.GuildID != guildID {
+			return fiber.NewError(fiber.StatusBadRequest, "channel not found")
+		}
+
+		oldAnnouncementChannel, err := c.db.GetGuildAnnouncementChannel(guildID)
+		if err != nil && !database.IsErrDatabaseNotFound(err) {
+			return wsutil.ErrInternalOrNotFound(err)
+		}
+
+		if err = c.db.SetGuildAnnouncementChannel(guildID, gs.AnnouncementChannel); err != nil {
+			return wsutil.ErrInternalOrNotFound(err)
+		}
+
+		c.audit(guildID, uid, "announcementchannel", oldAnnouncementChannel, gs.AnnouncementChannel)
 	}
 
 	if gs.Prefix != "" {
@@ -233,9 +368,16 @@ func (c *GuildsSettingsController) postGuildSettings(ctx *fiber.Ctx) error {
 			gs.Prefix = ""
 		}
 
+		oldPrefix, err := c.db.GetGuildPrefix(guildID)
+		if err != nil && !database.IsErrDatabaseNotFound(err) {
+			return wsutil.ErrInternalOrNotFound(err)
+		}
+
 		if err = c.db.SetGuildPrefix(guildID, gs.Prefix); err != nil {
 			return wsutil.ErrInternalOrNotFound(err)
 		}
+
+		c.audit(guildID, uid, "prefix", oldPrefix, gs.Prefix)
 	}
 
 	if gs.VoiceLogChannel != "" {
@@ -249,9 +391,16 @@ func (c *GuildsSettingsController) postGuildSettings(ctx *fiber.Ctx) error {
 			gs.VoiceLogChannel = ""
 		}
 
+		oldVoiceLog, err := c.db.GetGuildVoiceLog(guildID)
+		if err != nil && !database.IsErrDatabaseNotFound(err) {
+			return wsutil.ErrInternalOrNotFound(err)
+		}
+
 		if err = c.db.SetGuildVoiceLog(guildID, gs.VoiceLogChannel); err != nil {
 			return wsutil.ErrInternalOrNotFound(err)
 		}
+
+		c.audit(guildID, uid, "voicelogchannel", oldVoiceLog, gs.VoiceLogChannel)
 	}
 
 	if gs.JoinMessageChannel != "" && gs.JoinMessageText != "" {
@@ -264,11 +413,21 @@ func (c *GuildsSettingsController) postGuildSettings(ctx *fiber.Ctx) error {
 		if gs.JoinMessageChannel == "__RESET__" && gs.JoinMessageText == "__RESET__" {
 			gs.JoinMessageChannel = ""
 			gs.JoinMessageText = ""
+		} else if err := membermsg.Validate(gs.JoinMessageText); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		oldChannel, oldText, err := c.db.GetGuildJoinMsg(guildID)
+		if err != nil && !database.IsErrDatabaseNotFound(err) {
+			return wsutil.ErrInternalOrNotFound(err)
 		}
 
 		if err = c.db.SetGuildJoinMsg(guildID, gs.JoinMessageChannel, gs.JoinMessageText); err != nil {
 			return wsutil.ErrInternalOrNotFound(err)
 		}
+
+		c.audit(guildID, uid, "joinmessagechannel", oldChannel, gs.JoinMessageChannel)
+		c.audit(guildID, uid, "joinmessagetext", oldText, gs.JoinMessageText)
 	}
 
 	if gs.LeaveMessageChannel != "" && gs.LeaveMessageText != "" {
@@ -281,16 +440,138 @@ func (c *GuildsSettingsController) postGuildSettings(ctx *fiber.Ctx) error {
 		if gs.LeaveMessageChannel == "__RESET__" && gs.LeaveMessageText == "__RESET__" {
 			gs.LeaveMessageChannel = ""
 			gs.LeaveMessageText = ""
+		} else if err := membermsg.Validate(gs.LeaveMessageText); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		oldChannel, oldText, err := c.db.GetGuildLeaveMsg(guildID)
+		if err != nil && !database.IsErrDatabaseNotFound(err) {
+			return wsutil.ErrInternalOrNotFound(err)
 		}
 
 		if err = c.db.SetGuildLeaveMsg(guildID, gs.LeaveMessageChannel, gs.LeaveMessageText); err != nil {
 			return wsutil.ErrInternalOrNotFound(err)
 		}
+
+		c.audit(guildID, uid, "leavemessagechannel", oldChannel, gs.LeaveMessageChannel)
+		c.audit(guildID, uid, "leavemessagetext", oldText, gs.LeaveMessageText)
+	}
+
+	if gs.EmbedColor != "" {
+		if ok, _, err := c.pmw.CheckPermissions(c.session, guildID, uid, "sp.guild.config.embedcolor"); err != nil {
+			return wsutil.ErrInternalOrNotFound(err)
+		} else if !ok {
+			return fiber.ErrForbidden
+		}
+
+		embedColor := -1
+		if gs.EmbedColor != "__RESET__" {
+			if embedColor, err = util.ParseEmbedColor(gs.EmbedColor); err != nil {
+				return fiber.NewError(fiber.StatusBadRequest, err.Error())
+			}
+		}
+
+		oldEmbedColor, err := c.getEmbedColor(guildID)
+		if err != nil {
+			return wsutil.ErrInternalOrNotFound(err)
+		}
+
+		if err = c.db.SetGuildEmbedColor(guildID, embedColor); err != nil {
+			return wsutil.ErrInternalOrNotFound(err)
+		}
+
+		c.audit(guildID, uid, "embedcolor", guildsettings.EmbedColorHex(oldEmbedColor), guildsettings.EmbedColorHex(embedColor))
 	}
 
 	return ctx.JSON(models.Ok)
 }
 
+// @Summary Get Guild Settings Audit
+// @Description Returns a list of changes made to the guild settings.
+// @Tags Guild Settings
+// @Accept json
+// @Produce json
+// @Param id path string true "The ID of the guild."
+// @Param limit query int false "The amount of values returned." default(50) minimum(1) maximum(1000)
+// @Param offset query int false "The amount of values to be skipped." default(0)
+// @Success 200 {array} models.SettingsAuditEntry "Wrapped in models.ListResponse with pagination"
+// @Failure 400 {object} models.Error
+// @Failure 401 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Router /guilds/{id}/settings/audit [get]
+func (c *GuildsSettingsController) getGuildSettingsAudit(ctx *fiber.Ctx) error {
+	guildID := ctx.Params("guildid")
+
+	limit, offset, err := wsutil.GetQueryPagination(ctx, 50, 1000)
+	if err != nil {
+		return err
+	}
+
+	entries, err := c.db.GetSettingsAuditEntries(guildID, offset, limit)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return err
+	}
+
+	total, err := c.db.GetSettingsAuditEntriesCount(guildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return err
+	}
+
+	actors := make(map[string]*models.FlatUser)
+	res := make([]*models.SettingsAuditEntry, len(entries))
+	for i, e := range entries {
+		actor, ok := actors[e.ActorID]
+		if !ok {
+			if u, err := c.state.User(e.ActorID); err == nil {
+				actor = models.FlatUserFromUser(u)
+			}
+			actors[e.ActorID] = actor
+		}
+		res[i] = &models.SettingsAuditEntry{
+			SettingsAuditEntry: e,
+			Actor:              actor,
+		}
+	}
+
+	return ctx.JSON(models.NewPagedListResponse(res, limit, offset, total))
+}
+
+// audit records a settings audit entry if the
+// old and new value of the setting differ.
+func (c *GuildsSettingsController) audit(guildID, actorID, field, oldValue, newValue string) {
+	if oldValue == newValue {
+		return
+	}
+
+	err := c.db.AddSettingsAuditEntry(sharedmodels.SettingsAuditEntry{
+		ID:        snowflakenodes.NodeSettingsAudit.Generate(),
+		GuildID:   guildID,
+		ActorID:   actorID,
+		Field:     field,
+		OldValue:  oldValue,
+		NewValue:  newValue,
+		Timestamp: c.tp.Now(),
+	})
+	if err != nil {
+		ctlLog.Error().Err(err).Fields("gid", guildID, "field", field).Msg("Failed adding settings audit entry")
+	}
+}
+
+// auditJSON records a settings audit entry with the JSON
+// encoded old and new value. Nil and zero values are
+// recorded as empty value.
+func (c *GuildsSettingsController) auditJSON(guildID, actorID, field string, oldValue, newValue interface{}) {
+	c.audit(guildID, actorID, field, auditValue(oldValue), auditValue(newValue))
+}
+
+func auditValue(v interface{}) string {
+	if v == nil || reflect.ValueOf(v).IsZero() {
+		return ""
+	}
+	data, _ := json.Marshal(v)
+	return string(data)
+}
+
 // @Summary Get Guild Karma Settings
 // @Description Returns the specified guild karma settings.
 // @Tags Guild Settings
@@ -304,30 +585,47 @@ func (c *GuildsSettingsController) postGuildSettings(ctx *fiber.Ctx) error {
 func (c *GuildsSettingsController) getGuildSettingsKarma(ctx *fiber.Ctx) error {
 	guildID := ctx.Params("guildid")
 
+	settings, err := c.karmaSettings(guildID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(settings)
+}
+
+func (c *GuildsSettingsController) karmaSettings(guildID string) (*models.KarmaSettings, error) {
 	settings := new(models.KarmaSettings)
 
 	var err error
 
 	if settings.State, err = c.db.GetKarmaState(guildID); err != nil && !database.IsErrDatabaseNotFound(err) {
-		return err
+		return nil, err
 	}
 
 	if settings.Tokens, err = c.db.GetKarmaTokens(guildID); err != nil && !database.IsErrDatabaseNotFound(err) {
-		return err
+		return nil, err
 	}
 
 	emotesInc, emotesDec, err := c.db.GetKarmaEmotes(guildID)
 	if err != nil && !database.IsErrDatabaseNotFound(err) {
-		return err
+		return nil, err
 	}
 	settings.EmotesIncrease = strings.Split(emotesInc, "")
 	settings.EmotesDecrease = strings.Split(emotesDec, "")
 
 	if settings.Penalty, err = c.db.GetKarmaPenalty(guildID); err != nil && !database.IsErrDatabaseNotFound(err) {
-		return err
+		return nil, err
 	}
 
-	return ctx.JSON(settings)
+	limits, err := c.db.GetKarmaLimits(guildID)
+	if database.IsErrDatabaseNotFound(err) {
+		limits = sharedmodels.DefaultKarmaLimits
+	} else if err != nil {
+		return nil, err
+	}
+	settings.Limits = &limits
+
+	return settings, nil
 }
 
 // @Summary Update Guild Karma Settings
@@ -343,6 +641,7 @@ func (c *GuildsSettingsController) getGuildSettingsKarma(ctx *fiber.Ctx) error {
 // @Failure 404 {object} models.Error
 // @Router /guilds/{id}/settings/karma [post]
 func (c *GuildsSettingsController) postGuildSettingsKarma(ctx *fiber.Ctx) error {
+	uid := ctx.Locals("uid").(string)
 	guildID := ctx.Params("guildid")
 
 	settings := new(models.KarmaSettings)
@@ -352,6 +651,17 @@ func (c *GuildsSettingsController) postGuildSettingsKarma(ctx *fiber.Ctx) error
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
 	}
 
+	if settings.Limits != nil {
+		if err = settings.Limits.Validate(); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	}
+
+	old, err := c.karmaSettings(guildID)
+	if err != nil {
+		return err
+	}
+
 	if err = c.db.SetKarmaState(guildID, settings.State); err != nil {
 		return err
 	}
@@ -374,6 +684,17 @@ func (c *GuildsSettingsController) postGuildSettingsKarma(ctx *fiber.Ctx) error
 		return err
 	}
 
+	if settings.Limits != nil {
+		if err = c.db.SetKarmaLimits(guildID, *settings.Limits); err != nil {
+			return err
+		}
+	}
+
+	if settings, err = c.karmaSettings(guildID); err != nil {
+		return err
+	}
+	c.auditJSON(guildID, uid, "karma", old, settings)
+
 	return ctx.JSON(models.Ok)
 }
 
@@ -424,6 +745,7 @@ func (c *GuildsSettingsController) getGuildSettingsKarmaBlocklist(ctx *fiber.Ctx
 // @Failure 404 {object} models.Error
 // @Router /guilds/{id}/settings/karma/blocklist/{memberid} [put]
 func (c *GuildsSettingsController) putGuildSettingsKarmaBlocklist(ctx *fiber.Ctx) error {
+	uid := ctx.Locals("uid").(string)
 	guildID := ctx.Params("guildid")
 	memberID := ctx.Params("memberid")
 
@@ -447,6 +769,8 @@ func (c *GuildsSettingsController) putGuildSettingsKarmaBlocklist(ctx *fiber.Ctx
 		return err
 	}
 
+	c.audit(guildID, uid, "karmablocklist."+memb.User.ID, "", "blocked")
+
 	return ctx.JSON(memb)
 }
 
@@ -463,6 +787,7 @@ func (c *GuildsSettingsController) putGuildSettingsKarmaBlocklist(ctx *fiber.Ctx
 // @Failure 404 {object} models.Error
 // @Router /guilds/{id}/settings/karma/blocklist/{memberid} [delete]
 func (c *GuildsSettingsController) deleteGuildSettingsKarmaBlocklist(ctx *fiber.Ctx) error {
+	uid := ctx.Locals("uid").(string)
 	guildID := ctx.Params("guildid")
 	memberID := ctx.Params("memberid")
 
@@ -478,6 +803,8 @@ func (c *GuildsSettingsController) deleteGuildSettingsKarmaBlocklist(ctx *fiber.
 		return err
 	}
 
+	c.audit(guildID, uid, "karmablocklist."+memberID, "blocked", "")
+
 	return ctx.JSON(models.Ok)
 }
 
@@ -494,26 +821,35 @@ func (c *GuildsSettingsController) deleteGuildSettingsKarmaBlocklist(ctx *fiber.
 func (c *GuildsSettingsController) getGuildSettingsAntiraid(ctx *fiber.Ctx) error {
 	guildID := ctx.Params("guildid")
 
+	settings, err := c.antiraidSettings(guildID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(settings)
+}
+
+func (c *GuildsSettingsController) antiraidSettings(guildID string) (*models.AntiraidSettings, error) {
 	settings := new(models.AntiraidSettings)
 
 	var err error
 	if settings.State, err = c.db.GetAntiraidState(guildID); err != nil && !database.IsErrDatabaseNotFound(err) {
-		return err
+		return nil, err
 	}
 
 	if settings.RegenerationPeriod, err = c.db.GetAntiraidRegeneration(guildID); err != nil && !database.IsErrDatabaseNotFound(err) {
-		return err
+		return nil, err
 	}
 
 	if settings.Burst, err = c.db.GetAntiraidBurst(guildID); err != nil && !database.IsErrDatabaseNotFound(err) {
-		return err
+		return nil, err
 	}
 
 	if settings.Verification, err = c.db.GetAntiraidVerification(guildID); err != nil && !database.IsErrDatabaseNotFound(err) {
-		return err
+		return nil, err
 	}
 
-	return ctx.JSON(settings)
+	return settings, nil
 }
 
 // @Summary Update Guild Antiraid Settings
@@ -529,6 +865,7 @@ func (c *GuildsSettingsController) getGuildSettingsAntiraid(ctx *fiber.Ctx) erro
 // @Failure 404 {object} models.Error
 // @Router /guilds/{id}/settings/antiraid [post]
 func (c *GuildsSettingsController) postGuildSettingsAntiraid(ctx *fiber.Ctx) error {
+	uid := ctx.Locals("uid").(string)
 	guildID := ctx.Params("guildid")
 
 	settings := new(models.AntiraidSettings)
@@ -543,7 +880,10 @@ func (c *GuildsSettingsController) postGuildSettingsAntiraid(ctx *fiber.Ctx) err
 		return fiber.NewError(fiber.StatusBadRequest, "burst must be larger than 0")
 	}
 
-	var err error
+	old, err := c.antiraidSettings(guildID)
+	if err != nil {
+		return err
+	}
 
 	if err = c.db.SetAntiraidState(guildID, settings.State); err != nil {
 		return err
@@ -561,6 +901,8 @@ func (c *GuildsSettingsController) postGuildSettingsAntiraid(ctx *fiber.Ctx) err
 		return err
 	}
 
+	c.auditJSON(guildID, uid, "antiraid", old, settings)
+
 	return ctx.JSON(models.Ok)
 }
 
@@ -667,15 +1009,12 @@ func (c *GuildsSettingsController) getGuildSettingsKarmaRules(ctx *fiber.Ctx) er
 // @Failure 404 {object} models.Error
 // @Router /guilds/{id}/settings/karma/rules [post]
 func (c *GuildsSettingsController) createGuildSettingsKrameRule(ctx *fiber.Ctx) error {
+	uid := ctx.Locals("uid").(string)
 	guildID := ctx.Params("guildid")
 
 	var rule sharedmodels.KarmaRule
-	if err := ctx.BodyParser(&rule); err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, err.Error())
-	}
-
-	if err := rule.Validate(); err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	if err := wsutil.ParseAndValidate(ctx, &rule); err != nil {
+		return err
 	}
 
 	rule.GuildID = guildID
@@ -702,6 +1041,8 @@ func (c *GuildsSettingsController) createGuildSettingsKrameRule(ctx *fiber.Ctx)
 		return err
 	}
 
+	c.auditJSON(guildID, uid, "karmarule."+rule.ID.String(), nil, rule)
+
 	return ctx.JSON(rule)
 }
 
@@ -719,16 +1060,13 @@ func (c *GuildsSettingsController) createGuildSettingsKrameRule(ctx *fiber.Ctx)
 // @Failure 404 {object} models.Error
 // @Router /guilds/{id}/settings/karma/rules/{ruleid} [post]
 func (c *GuildsSettingsController) updateGuildSettingsKrameRule(ctx *fiber.Ctx) (err error) {
+	uid := ctx.Locals("uid").(string)
 	guildID := ctx.Params("guildid")
 	id := ctx.Params("id")
 
 	var rule sharedmodels.KarmaRule
-	if err := ctx.BodyParser(&rule); err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, err.Error())
-	}
-
-	if err := rule.Validate(); err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	if err := wsutil.ParseAndValidate(ctx, &rule); err != nil {
+		return err
 	}
 
 	rule.GuildID = guildID
@@ -754,10 +1092,17 @@ func (c *GuildsSettingsController) updateGuildSettingsKrameRule(ctx *fiber.Ctx)
 		return fiber.NewError(fiber.StatusBadRequest, "same rule already exists")
 	}
 
+	old, err := c.karmaRule(guildID, rule.ID)
+	if err != nil {
+		return err
+	}
+
 	if err := c.db.AddOrUpdateKarmaRule(rule); err != nil {
 		return err
 	}
 
+	c.auditJSON(guildID, uid, "karmarule."+rule.ID.String(), old, rule)
+
 	return ctx.JSON(rule)
 }
 
@@ -774,6 +1119,7 @@ func (c *GuildsSettingsController) updateGuildSettingsKrameRule(ctx *fiber.Ctx)
 // @Failure 404 {object} models.Error
 // @Router /guilds/{id}/settings/karma/rules/{ruleid} [delete]
 func (c *GuildsSettingsController) deleteGuildSettingsKrameRule(ctx *fiber.Ctx) error {
+	uid := ctx.Locals("uid").(string)
 	guildID := ctx.Params("guildid")
 	id := ctx.Params("id")
 
@@ -782,10 +1128,17 @@ func (c *GuildsSettingsController) deleteGuildSettingsKrameRule(ctx *fiber.Ctx)
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
 	}
 
+	old, err := c.karmaRule(guildID, sfId)
+	if err != nil {
+		return err
+	}
+
 	if err := c.db.RemoveKarmaRule(guildID, sfId); err != nil {
 		return err
 	}
 
+	c.auditJSON(guildID, uid, "karmarule."+sfId.String(), old, nil)
+
 	return ctx.JSON(models.Ok)
 }
 
@@ -798,7 +1151,7 @@ func (c *GuildsSettingsController) deleteGuildSettingsKrameRule(ctx *fiber.Ctx)
 // @Param limit query int false "The amount of values returned." default(50) minimum(1) maximum(1000)
 // @Param offset query int false "The amount of values to be skipped." default(0)
 // @Param severity query sharedmodels.GuildLogSeverity false "Filter by log severity." default(sharedmodels.GLAll)
-// @Success 200 {array} sharedmodels.GuildLogEntry "Wrapped in models.ListResponse"
+// @Success 200 {array} sharedmodels.GuildLogEntry "Wrapped in models.ListResponse with pagination"
 // @Failure 400 {object} models.Error
 // @Failure 401 {object} models.Error
 // @Failure 404 {object} models.Error
@@ -806,11 +1159,7 @@ func (c *GuildsSettingsController) deleteGuildSettingsKrameRule(ctx *fiber.Ctx)
 func (c *GuildsSettingsController) getGuildSettingsLogs(ctx *fiber.Ctx) error {
 	guildID := ctx.Params("guildid")
 
-	limit, err := wsutil.GetQueryInt(ctx, "limit", 50, 1, 1000)
-	if err != nil {
-		return err
-	}
-	offset, err := wsutil.GetQueryInt(ctx, "offset", 0, 0, 0)
+	limit, offset, err := wsutil.GetQueryPagination(ctx, 50, 1000)
 	if err != nil {
 		return err
 	}
@@ -828,7 +1177,12 @@ func (c *GuildsSettingsController) getGuildSettingsLogs(ctx *fiber.Ctx) error {
 		return err
 	}
 
-	return ctx.JSON(models.NewListResponse(res))
+	total, err := c.db.GetGuildLogEntriesCount(guildID, sharedmodels.GuildLogSeverity(severity))
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return err
+	}
+
+	return ctx.JSON(models.NewPagedListResponse(res, limit, offset, total))
 }
 
 // @Summary Get Guild Log Count
@@ -842,7 +1196,7 @@ func (c *GuildsSettingsController) getGuildSettingsLogs(ctx *fiber.Ctx) error {
 // @Failure 400 {object} models.Error
 // @Failure 401 {object} models.Error
 // @Failure 404 {object} models.Error
-// @Router /guilds/{id}/settings/logs [get]
+// @Router /guilds/{id}/settings/logs/count [get]
 func (c *GuildsSettingsController) getGuildSettingsLogsCount(ctx *fiber.Ctx) error {
 	guildID := ctx.Params("guildid")
 
@@ -896,6 +1250,7 @@ func (c *GuildsSettingsController) getGuildSettingsLogsState(ctx *fiber.Ctx) err
 // @Failure 404 {object} models.Error
 // @Router /guilds/{id}/settings/logs/state [post]
 func (c *GuildsSettingsController) postGuildSettingsLogsState(ctx *fiber.Ctx) error {
+	uid := ctx.Locals("uid").(string)
 	guildID := ctx.Params("guildid")
 
 	state := new(models.State)
@@ -903,11 +1258,18 @@ func (c *GuildsSettingsController) postGuildSettingsLogsState(ctx *fiber.Ctx) er
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
 	}
 
-	err := c.db.SetGuildLogDisable(guildID, !state.State)
+	disabled, err := c.db.GetGuildLogDisable(guildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return err
+	}
+
+	err = c.db.SetGuildLogDisable(guildID, !state.State)
 	if err != nil && !database.IsErrDatabaseNotFound(err) {
 		return err
 	}
 
+	c.audit(guildID, uid, "guildlog", strconv.FormatBool(!disabled), strconv.FormatBool(state.State))
+
 	return ctx.JSON(state)
 }
 
@@ -970,6 +1332,7 @@ func (c *GuildsSettingsController) deleteGuildSettingsLogEntries(ctx *fiber.Ctx)
 // @Tags Guild Settings
 // @Accept json
 // @Produce json
+// @Param X-Confirmation-Token header string false "Confirmation token obtained via /ota/confirmation. Required if confirmation is enabled."
 // @Param id path string true "The ID of the guild."
 // @Param payload body models.FlushGuildRequest true "The guild flush payload."
 // @Success 200 {object} models.State
@@ -1049,6 +1412,7 @@ func (c *GuildsSettingsController) getGuildSettingsAPI(ctx *fiber.Ctx) error {
 // @Failure 404 {object} models.Error
 // @Router /guilds/{id}/settings/api [post]
 func (c *GuildsSettingsController) postGuildSettingsAPI(ctx *fiber.Ctx) (err error) {
+	uid := ctx.Locals("uid").(string)
 	guildID := ctx.Params("guildid")
 
 	state, err := c.db.GetGuildAPI(guildID)
@@ -1074,8 +1438,15 @@ func (c *GuildsSettingsController) postGuildSettingsAPI(ctx *fiber.Ctx) (err err
 		return
 	}
 
+	oldTokenHash := state.TokenHash
+	state.Hydrate()
+	state.TokenHash = ""
 	newState.Hydrate()
 	newState.TokenHash = ""
+	c.auditJSON(guildID, uid, "api", state, newState.GuildAPISettings)
+	if newState.NewToken != "" && !newState.ResetToken && oldTokenHash != "" {
+		c.audit(guildID, uid, "api.token", "", "renewed")
+	}
 	return ctx.JSON(newState.GuildAPISettings)
 }
 
@@ -1116,6 +1487,7 @@ func (c *GuildsSettingsController) getGuildSettingsVerification(ctx *fiber.Ctx)
 // @Failure 404 {object} models.Error
 // @Router /guilds/{id}/settings/verification [post]
 func (c *GuildsSettingsController) postGuildSettingsVerification(ctx *fiber.Ctx) (err error) {
+	uid := ctx.Locals("uid").(string)
 	guildID := ctx.Params("guildid")
 
 	var state models.EnableStatus
@@ -1123,11 +1495,18 @@ func (c *GuildsSettingsController) postGuildSettingsVerification(ctx *fiber.Ctx)
 		return
 	}
 
+	old, err := c.vs.GetEnabled(guildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return
+	}
+
 	err = c.vs.SetEnabled(guildID, state.Enabled)
 	if err != nil {
 		return
 	}
 
+	c.audit(guildID, uid, "verification", strconv.FormatBool(old), strconv.FormatBool(state.Enabled))
+
 	return ctx.JSON(state)
 }
 
@@ -1154,6 +1533,11 @@ func (c *GuildsSettingsController) getGuildSettingsCodeExec(ctx *fiber.Ctx) erro
 		return err
 	}
 
+	res.AllowedLanguages, err = c.db.GetGuildCodeExecLanguages(guildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return err
+	}
+
 	res.Type = c.cef.Name()
 
 	if res.Type == "jdoodle" {
@@ -1183,6 +1567,7 @@ func (c *GuildsSettingsController) getGuildSettingsCodeExec(ctx *fiber.Ctx) erro
 // @Failure 404 {object} models.Error
 // @Router /guilds/{id}/settings/codeexec [post]
 func (c *GuildsSettingsController) postGuildSettingsCodeExec(ctx *fiber.Ctx) (err error) {
+	uid := ctx.Locals("uid").(string)
 	guildID := ctx.Params("guildid")
 
 	var state models.CodeExecSettings
@@ -1190,11 +1575,43 @@ func (c *GuildsSettingsController) postGuildSettingsCodeExec(ctx *fiber.Ctx) (er
 		return
 	}
 
+	oldEnabled, err := c.db.GetGuildCodeExecEnabled(guildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return
+	}
+	oldLanguages, err := c.db.GetGuildCodeExecLanguages(guildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return
+	}
+
 	err = c.db.SetGuildCodeExecEnabled(guildID, state.Enabled)
 	if err != nil {
 		return
 	}
 
+	c.audit(guildID, uid, "codeexec", strconv.FormatBool(oldEnabled), strconv.FormatBool(state.Enabled))
+
+	if state.AllowedLanguages != nil {
+		supported, err := codeexec.SupportedLanguages(c.cef)
+		if err != nil {
+			return err
+		}
+		for i, lang := range state.AllowedLanguages {
+			lang = strings.ToLower(strings.TrimSpace(lang))
+			state.AllowedLanguages[i] = lang
+			if !stringutil.ContainsAny(lang, supported) {
+				return fiber.NewError(fiber.StatusBadRequest,
+					fmt.Sprintf("The language '%s' is not supported.", lang))
+			}
+		}
+		err = c.db.SetGuildCodeExecLanguages(guildID, state.AllowedLanguages)
+		if err != nil {
+			return err
+		}
+		c.audit(guildID, uid, "codeexec.languages",
+			strings.Join(oldLanguages, ","), strings.Join(state.AllowedLanguages, ","))
+	}
+
 	if c.cef.Name() == "jdoodle" {
 		var creds string
 		if state.JdoodleClientId == "" && state.JdoodleClientSecret == "" {
@@ -1208,11 +1625,1205 @@ func (c *GuildsSettingsController) postGuildSettingsCodeExec(ctx *fiber.Ctx) (er
 			return fiber.NewError(fiber.StatusBadRequest, "Either both credential values must be empty or both must be defined!")
 		}
 
+		oldCreds, err := c.db.GetGuildJdoodleKey(guildID)
+		if err != nil && !database.IsErrDatabaseNotFound(err) {
+			return err
+		}
+
 		err = c.db.SetGuildJdoodleKey(guildID, creds)
 		if err != nil {
-			return
+			return err
 		}
+
+		// Only the client ID is recorded to not leak
+		// the client secret via the audit log.
+		c.audit(guildID, uid, "codeexec.jdoodleclientid",
+			strings.Split(oldCreds, "#")[0], state.JdoodleClientId)
 	}
 
 	return ctx.JSON(state)
 }
+
+// @Summary Get Guild Settings Bot Nickname
+// @Description Returns the configured nickname of the bot in the guild.
+// @Tags Guild Settings
+// @Accept json
+// @Produce json
+// @Param id path string true "The ID of the guild."
+// @Success 200 {object} models.BotNicknameSettings
+// @Failure 401 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Router /guilds/{id}/settings/botnick [get]
+func (c *GuildsSettingsController) getGuildSettingsBotNick(ctx *fiber.Ctx) (err error) {
+	guildID := ctx.Params("guildid")
+
+	var res models.BotNicknameSettings
+	res.Nickname, err = c.db.GetGuildBotNickname(guildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return
+	}
+
+	return ctx.JSON(res)
+}
+
+// @Summary Set Guild Settings Bot Nickname
+// @Description Sets the nickname of the bot in the guild and applies it. An empty nickname resets the nickname of the bot. If the nickname could not be applied, a warning is returned.
+// @Tags Guild Settings
+// @Accept json
+// @Produce json
+// @Param id path string true "The ID of the guild."
+// @Param payload body models.BotNicknameSettings true "The bot nickname payload."
+// @Success 200 {object} models.BotNicknameSettings
+// @Failure 400 {object} models.Error
+// @Failure 401 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Router /guilds/{id}/settings/botnick [post]
+func (c *GuildsSettingsController) postGuildSettingsBotNick(ctx *fiber.Ctx) (err error) {
+	uid := ctx.Locals("uid").(string)
+	guildID := ctx.Params("guildid")
+
+	var req models.BotNicknameSettings
+	if err = ctx.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	res := models.BotNicknameSettings{
+		Nickname: strings.TrimSpace(req.Nickname),
+	}
+	if err = botnick.Validate(res.Nickname); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	oldNickname, err := c.db.GetGuildBotNickname(guildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return
+	}
+
+	if err = c.db.SetGuildBotNickname(guildID, res.Nickname); err != nil {
+		return
+	}
+	c.audit(guildID, uid, "botnickname", oldNickname, res.Nickname)
+
+	err = botnick.Apply(c.session, guildID, res.Nickname)
+	if err == botnick.ErrMissingPermission {
+		res.Warning = err.Error()
+	} else if err != nil {
+		return
+	}
+
+	return ctx.JSON(res)
+}
+
+// @Summary Get Guild Command Settings
+// @Description Returns all commands and whether they are disabled on the guild.
+// @Tags Guild Settings
+// @Accept json
+// @Produce json
+// @Param id path string true "The ID of the guild."
+// @Success 200 {object} models.GuildCommandSettings
+// @Failure 401 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Router /guilds/{id}/settings/commands [get]
+func (c *GuildsSettingsController) getGuildSettingsCommands(ctx *fiber.Ctx) error {
+	guildID := ctx.Params("guildid")
+
+	disabled, err := c.db.GetGuildDisabledCommands(guildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return err
+	}
+
+	var res models.GuildCommandSettings
+	res.Message, res.Silent, err = c.db.GetGuildDisabledCommandMessage(guildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return err
+	}
+
+	cmdInfo := c.cmdHandler.GetCommandInfo()
+	res.Commands = make([]*models.GuildCommandState, len(cmdInfo))
+	for i, ci := range cmdInfo {
+		info := models.GetSlashCommandInfoFromCommand(ci)
+		res.Commands[i] = &models.GuildCommandState{
+			Name:        info.Name,
+			Description: info.Description,
+			Group:       info.Group,
+			Disabled:    stringutil.ContainsAny(info.Name, disabled),
+			Essential:   stringutil.ContainsAny(info.Name, static.EssentialCommands),
+		}
+	}
+
+	return ctx.JSON(res)
+}
+
+// @Summary Set Guild Disabled Command Message
+// @Description Set the message responded when a disabled command is used on the guild.
+// @Tags Guild Settings
+// @Accept json
+// @Produce json
+// @Param id path string true "The ID of the guild."
+// @Param payload body models.GuildCommandMessage true "The disabled command message payload."
+// @Success 200 {object} models.GuildCommandMessage
+// @Failure 400 {object} models.Error
+// @Failure 401 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Router /guilds/{id}/settings/commands [post]
+func (c *GuildsSettingsController) postGuildSettingsCommands(ctx *fiber.Ctx) (err error) {
+	uid := ctx.Locals("uid").(string)
+	guildID := ctx.Params("guildid")
+
+	var payload models.GuildCommandMessage
+	if err = wsutil.ParseAndValidate(ctx, &payload); err != nil {
+		return
+	}
+
+	var old models.GuildCommandMessage
+	old.Message, old.Silent, err = c.db.GetGuildDisabledCommandMessage(guildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return
+	}
+
+	if err = c.db.SetGuildDisabledCommandMessage(guildID, payload.Message, payload.Silent); err != nil {
+		return
+	}
+
+	c.auditJSON(guildID, uid, "disabledcommandmessage", old, payload)
+
+	return ctx.JSON(payload)
+}
+
+// @Summary Disable Guild Command
+// @Description Disable a command on the guild. Essential commands can not be disabled.
+// @Tags Guild Settings
+// @Accept json
+// @Produce json
+// @Param id path string true "The ID of the guild."
+// @Param name path string true "The name of the command."
+// @Success 200 {object} models.Status
+// @Failure 400 {object} models.Error
+// @Failure 401 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Router /guilds/{id}/settings/commands/disabled/{name} [put]
+func (c *GuildsSettingsController) putGuildSettingsCommandsDisabled(ctx *fiber.Ctx) error {
+	uid := ctx.Locals("uid").(string)
+	guildID := ctx.Params("guildid")
+	name := strings.ToLower(ctx.Params("name"))
+
+	if !c.commandExists(name) {
+		return fiber.ErrNotFound
+	}
+
+	if stringutil.ContainsAny(name, static.EssentialCommands) {
+		return fiber.NewError(fiber.StatusBadRequest, "essential commands can not be disabled")
+	}
+
+	if err := c.db.SetGuildDisabledCommand(guildID, name, true); err != nil {
+		return err
+	}
+
+	c.audit(guildID, uid, "disabledcommand."+name, "", "disabled")
+
+	return ctx.JSON(models.Ok)
+}
+
+// @Summary Enable Guild Command
+// @Description Enable a previously disabled command on the guild.
+// @Tags Guild Settings
+// @Accept json
+// @Produce json
+// @Param id path string true "The ID of the guild."
+// @Param name path string true "The name of the command."
+// @Success 200 {object} models.Status
+// @Failure 401 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Router /guilds/{id}/settings/commands/disabled/{name} [delete]
+func (c *GuildsSettingsController) deleteGuildSettingsCommandsDisabled(ctx *fiber.Ctx) error {
+	uid := ctx.Locals("uid").(string)
+	guildID := ctx.Params("guildid")
+	name := strings.ToLower(ctx.Params("name"))
+
+	if err := c.db.SetGuildDisabledCommand(guildID, name, false); err != nil {
+		return err
+	}
+
+	c.audit(guildID, uid, "disabledcommand."+name, "disabled", "")
+
+	return ctx.JSON(models.Ok)
+}
+
+// @Summary Get Guild Mod Log Routes
+// @Description Returns the default mod log channel and the channels entries of specific actions are routed to.
+// @Tags Guild Settings
+// @Accept json
+// @Produce json
+// @Param id path string true "The ID of the guild."
+// @Success 200 {object} models.ModLogRoutes
+// @Failure 401 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Router /guilds/{id}/settings/modlog/routes [get]
+func (c *GuildsSettingsController) getGuildSettingsModLogRoutes(ctx *fiber.Ctx) (err error) {
+	guildID := ctx.Params("guildid")
+
+	var res models.ModLogRoutes
+
+	res.Default, err = c.db.GetGuildModLog(guildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return err
+	}
+
+	res.Routes, err = c.db.GetGuildModLogRoutes(guildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return err
+	}
+	if res.Routes == nil {
+		res.Routes = make(map[sharedmodels.ModLogAction]string)
+	}
+
+	return ctx.JSON(res)
+}
+
+// @Summary Set Guild Mod Log Route
+// @Description Routes mod log entries of the given action to the given channel.
+// @Tags Guild Settings
+// @Accept json
+// @Produce json
+// @Param id path string true "The ID of the guild."
+// @Param action path string true "The mod log action."
+// @Param payload body models.ModLogRouteRequest true "The target channel."
+// @Success 200 {object} models.Status
+// @Failure 400 {object} models.Error
+// @Failure 401 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Router /guilds/{id}/settings/modlog/routes/{action} [put]
+func (c *GuildsSettingsController) putGuildSettingsModLogRoute(ctx *fiber.Ctx) error {
+	uid := ctx.Locals("uid").(string)
+	guildID := ctx.Params("guildid")
+
+	action := sharedmodels.ModLogAction(ctx.Params("action"))
+	if !action.Validate() {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid mod log action")
+	}
+
+	var req models.ModLogRouteRequest
+	if err := wsutil.ParseAndValidate(ctx, &req); err != nil {
+		return err
+	}
+
+	ch, err := c.state.Channel(req.ChannelID)
+	if err != nil || ch.GuildID != guildID {
+		return fiber.NewError(fiber.StatusBadRequest, "channel not found")
+	}
+	if ch.Type != discordgo.ChannelTypeGuildText {
+		return fiber.NewError(fiber.StatusBadRequest, "channel must be a text channel")
+	}
+
+	old, err := c.db.GetGuildModLogRoute(guildID, action)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return err
+	}
+
+	if err = c.db.SetGuildModLogRoute(guildID, action, req.ChannelID); err != nil {
+		return err
+	}
+
+	c.audit(guildID, uid, "modlogroute."+string(action), old, req.ChannelID)
+
+	return ctx.JSON(models.Ok)
+}
+
+// @Summary Remove Guild Mod Log Route
+// @Description Removes the route of the given action so that its entries are posted in the default mod log channel.
+// @Tags Guild Settings
+// @Accept json
+// @Produce json
+// @Param id path string true "The ID of the guild."
+// @Param action path string true "The mod log action."
+// @Success 200 {object} models.Status
+// @Failure 400 {object} models.Error
+// @Failure 401 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Router /guilds/{id}/settings/modlog/routes/{action} [delete]
+func (c *GuildsSettingsController) deleteGuildSettingsModLogRoute(ctx *fiber.Ctx) error {
+	uid := ctx.Locals("uid").(string)
+	guildID := ctx.Params("guildid")
+
+	action := sharedmodels.ModLogAction(ctx.Params("action"))
+	if !action.Validate() {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid mod log action")
+	}
+
+	old, err := c.db.GetGuildModLogRoute(guildID, action)
+	if database.IsErrDatabaseNotFound(err) {
+		return ctx.JSON(models.Ok)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err = c.db.RemoveGuildModLogRoute(guildID, action); err != nil {
+		return err
+	}
+
+	c.audit(guildID, uid, "modlogroute."+string(action), old, "")
+
+	return ctx.JSON(models.Ok)
+}
+
+// @Summary Get Guild Auto Delete Configs
+// @Description Returns the guild wide and channel specific delays after which command responses are deleted.
+// @Tags Guild Settings
+// @Accept json
+// @Produce json
+// @Param id path string true "The ID of the guild."
+// @Success 200 {array} models.AutoDeleteConfig "Wrapped in models.ListResponse"
+// @Failure 401 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Router /guilds/{id}/settings/autodelete [get]
+func (c *GuildsSettingsController) getGuildSettingsAutoDeletes(ctx *fiber.Ctx) error {
+	guildID := ctx.Params("guildid")
+
+	cfgs, err := c.db.GetAutoDeletes(guildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return err
+	}
+
+	res := make([]models.AutoDeleteConfig, len(cfgs))
+	for i, cfg := range cfgs {
+		res[i] = models.AutoDeleteConfigFromConfig(cfg)
+	}
+
+	return ctx.JSON(models.NewListResponse(res))
+}
+
+// @Summary Set Guild Auto Delete Config
+// @Description Sets the delays after which command responses are deleted in the guild or, if a channel ID is passed, in the given channel.
+// @Tags Guild Settings
+// @Accept json
+// @Produce json
+// @Param id path string true "The ID of the guild."
+// @Param payload body models.AutoDeleteConfig true "The auto delete config."
+// @Success 200 {object} models.AutoDeleteConfig
+// @Failure 400 {object} models.Error
+// @Failure 401 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Router /guilds/{id}/settings/autodelete [put]
+func (c *GuildsSettingsController) putGuildSettingsAutoDelete(ctx *fiber.Ctx) error {
+	uid := ctx.Locals("uid").(string)
+	guildID := ctx.Params("guildid")
+
+	var req models.AutoDeleteConfig
+	if err := wsutil.ParseAndValidate(ctx, &req); err != nil {
+		return err
+	}
+
+	if req.ChannelID != "" {
+		ch, err := c.state.Channel(req.ChannelID)
+		if err != nil || ch.GuildID != guildID {
+			return fiber.NewError(fiber.StatusNotFound, "channel not found")
+		}
+	}
+
+	old, err := c.db.GetAutoDelete(guildID, req.ChannelID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return err
+	}
+
+	cfg := req.ToConfig(guildID)
+	if err = c.db.SetAutoDelete(cfg); err != nil {
+		return err
+	}
+
+	c.auditJSON(guildID, uid, "autodelete."+req.ChannelID, old, cfg)
+
+	return ctx.JSON(req)
+}
+
+// @Summary Remove Guild Auto Delete Config
+// @Description Removes the guild wide auto delete config or, if a channel ID is passed, the config of the given channel.
+// @Tags Guild Settings
+// @Accept json
+// @Produce json
+// @Param id path string true "The ID of the guild."
+// @Param channelid path string false "The ID of the channel."
+// @Success 200 {object} models.Status
+// @Failure 401 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Router /guilds/{id}/settings/autodelete/{channelid} [delete]
+func (c *GuildsSettingsController) deleteGuildSettingsAutoDelete(ctx *fiber.Ctx) error {
+	uid := ctx.Locals("uid").(string)
+	guildID := ctx.Params("guildid")
+	channelID := ctx.Params("channelid")
+
+	old, err := c.db.GetAutoDelete(guildID, channelID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return err
+	}
+
+	err = c.db.RemoveAutoDelete(guildID, channelID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return err
+	}
+
+	c.auditJSON(guildID, uid, "autodelete."+channelID, old, nil)
+
+	return ctx.JSON(models.Ok)
+}
+
+// @Summary Get Guild Auto Thread Channels
+// @Description Returns the configs of all channels in which threads are created automatically.
+// @Tags Guild Settings
+// @Accept json
+// @Produce json
+// @Param id path string true "The ID of the guild."
+// @Success 200 {array} sharedmodels.AutoThreadConfig "Wrapped in models.ListResponse"
+// @Failure 401 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Router /guilds/{id}/settings/autothreads [get]
+func (c *GuildsSettingsController) getGuildSettingsAutoThreads(ctx *fiber.Ctx) error {
+	guildID := ctx.Params("guildid")
+
+	cfgs, err := c.db.GetAutoThreads(guildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return err
+	}
+
+	return ctx.JSON(models.NewListResponse(cfgs))
+}
+
+// @Summary Set Guild Auto Thread Channel
+// @Description Enables or updates the automatic thread creation in the given channel.
+// @Tags Guild Settings
+// @Accept json
+// @Produce json
+// @Param id path string true "The ID of the guild."
+// @Param channelid path string true "The ID of the channel."
+// @Param payload body sharedmodels.AutoThreadConfig true "The auto thread config."
+// @Success 200 {object} sharedmodels.AutoThreadConfig
+// @Failure 400 {object} models.Error
+// @Failure 401 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Router /guilds/{id}/settings/autothreads/{channelid} [put]
+func (c *GuildsSettingsController) putGuildSettingsAutoThread(ctx *fiber.Ctx) error {
+	uid := ctx.Locals("uid").(string)
+	guildID := ctx.Params("guildid")
+	channelID := ctx.Params("channelid")
+
+	var cfg sharedmodels.AutoThreadConfig
+	if err := wsutil.ParseAndValidate(ctx, &cfg); err != nil {
+		return err
+	}
+
+	ch, err := c.state.Channel(channelID)
+	if err != nil || ch.GuildID != guildID {
+		return fiber.NewError(fiber.StatusNotFound, "channel not found")
+	}
+	if ch.Type != discordgo.ChannelTypeGuildText && ch.Type != discordgo.ChannelTypeGuildNews {
+		return fiber.NewError(fiber.StatusBadRequest, "threads can only be created in text or news channels")
+	}
+
+	cfg.GuildID = guildID
+	cfg.ChannelID = channelID
+
+	old, err := c.db.GetAutoThread(guildID, channelID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return err
+	}
+
+	if err = c.db.SetAutoThread(cfg); err != nil {
+		return err
+	}
+
+	c.auditJSON(guildID, uid, "autothread."+channelID, old, cfg)
+
+	return ctx.JSON(cfg)
+}
+
+// @Summary Remove Guild Auto Thread Channel
+// @Description Disables the automatic thread creation in the given channel.
+// @Tags Guild Settings
+// @Accept json
+// @Produce json
+// @Param id path string true "The ID of the guild."
+// @Param channelid path string true "The ID of the channel."
+// @Success 200 {object} models.Status
+// @Failure 401 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Router /guilds/{id}/settings/autothreads/{channelid} [delete]
+func (c *GuildsSettingsController) deleteGuildSettingsAutoThread(ctx *fiber.Ctx) error {
+	uid := ctx.Locals("uid").(string)
+	guildID := ctx.Params("guildid")
+	channelID := ctx.Params("channelid")
+
+	old, err := c.db.GetAutoThread(guildID, channelID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return err
+	}
+
+	err = c.db.RemoveAutoThread(guildID, channelID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return err
+	}
+
+	c.auditJSON(guildID, uid, "autothread."+channelID, old, nil)
+
+	return ctx.JSON(models.Ok)
+}
+
+// @Summary Get Guild Auto Publish Channels
+// @Description Returns the configs of all announcement channels in which new messages are published automatically.
+// @Tags Guild Settings
+// @Accept json
+// @Produce json
+// @Param id path string true "The ID of the guild."
+// @Success 200 {array} sharedmodels.AutoPublishConfig "Wrapped in models.ListResponse"
+// @Failure 401 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Router /guilds/{id}/settings/autopublish [get]
+func (c *GuildsSettingsController) getGuildSettingsAutoPublishes(ctx *fiber.Ctx) error {
+	guildID := ctx.Params("guildid")
+
+	cfgs, err := c.db.GetAutoPublishes(guildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return err
+	}
+
+	return ctx.JSON(models.NewListResponse(cfgs))
+}
+
+// @Summary Set Guild Auto Publish Channel
+// @Description Enables or updates the automatic publishing of new messages in the given announcement channel.
+// @Tags Guild Settings
+// @Accept json
+// @Produce json
+// @Param id path string true "The ID of the guild."
+// @Param channelid path string true "The ID of the channel."
+// @Param payload body sharedmodels.AutoPublishConfig true "The auto publish config."
+// @Success 200 {object} sharedmodels.AutoPublishConfig
+// @Failure 400 {object} models.Error
+// @Failure 401 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Router /guilds/{id}/settings/autopublish/{channelid} [put]
+func (c *GuildsSettingsController) putGuildSettingsAutoPublish(ctx *fiber.Ctx) error {
+	uid := ctx.Locals("uid").(string)
+	guildID := ctx.Params("guildid")
+	channelID := ctx.Params("channelid")
+
+	var cfg sharedmodels.AutoPublishConfig
+	if err := ctx.BodyParser(&cfg); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	ch, err := c.state.Channel(channelID)
+	if err != nil || ch.GuildID != guildID {
+		return fiber.NewError(fiber.StatusNotFound, "channel not found")
+	}
+	if ch.Type != discordgo.ChannelTypeGuildNews {
+		return fiber.NewError(fiber.StatusBadRequest, "messages can only be published in announcement channels")
+	}
+
+	cfg.GuildID = guildID
+	cfg.ChannelID = channelID
+
+	old, err := c.db.GetAutoPublish(guildID, channelID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return err
+	}
+
+	if err = c.db.SetAutoPublish(cfg); err != nil {
+		return err
+	}
+
+	c.auditJSON(guildID, uid, "autopublish."+channelID, old, cfg)
+
+	return ctx.JSON(cfg)
+}
+
+// @Summary Remove Guild Auto Publish Channel
+// @Description Disables the automatic publishing of new messages in the given channel.
+// @Tags Guild Settings
+// @Accept json
+// @Produce json
+// @Param id path string true "The ID of the guild."
+// @Param channelid path string true "The ID of the channel."
+// @Success 200 {object} models.Status
+// @Failure 401 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Router /guilds/{id}/settings/autopublish/{channelid} [delete]
+func (c *GuildsSettingsController) deleteGuildSettingsAutoPublish(ctx *fiber.Ctx) error {
+	uid := ctx.Locals("uid").(string)
+	guildID := ctx.Params("guildid")
+	channelID := ctx.Params("channelid")
+
+	old, err := c.db.GetAutoPublish(guildID, channelID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return err
+	}
+
+	err = c.db.RemoveAutoPublish(guildID, channelID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return err
+	}
+
+	c.auditJSON(guildID, uid, "autopublish."+channelID, old, nil)
+
+	return ctx.JSON(models.Ok)
+}
+
+// @Summary Get Guild Prefix-less Channels
+// @Description Returns the IDs of all channels in which messages are handled without the guild prefix.
+// @Tags Guild Settings
+// @Accept json
+// @Produce json
+// @Param id path string true "The ID of the guild."
+// @Success 200 {array} string "Wrapped in models.ListResponse"
+// @Failure 401 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Router /guilds/{id}/settings/prefixless [get]
+func (c *GuildsSettingsController) getGuildSettingsPrefixless(ctx *fiber.Ctx) error {
+	guildID := ctx.Params("guildid")
+
+	channels, err := c.db.GetPrefixlessChannels(guildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return err
+	}
+
+	return ctx.JSON(models.NewListResponse(channels))
+}
+
+// @Summary Add Guild Prefix-less Channel
+// @Description Enables the prefix-less mode in the given channel.
+// @Tags Guild Settings
+// @Accept json
+// @Produce json
+// @Param id path string true "The ID of the guild."
+// @Param channelid path string true "The ID of the channel."
+// @Success 200 {object} models.Status
+// @Failure 400 {object} models.Error
+// @Failure 401 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Router /guilds/{id}/settings/prefixless/{channelid} [put]
+func (c *GuildsSettingsController) putGuildSettingsPrefixless(ctx *fiber.Ctx) error {
+	uid := ctx.Locals("uid").(string)
+	guildID := ctx.Params("guildid")
+	channelID := ctx.Params("channelid")
+
+	ch, err := c.state.Channel(channelID)
+	if err != nil || ch.GuildID != guildID {
+		return fiber.NewError(fiber.StatusNotFound, "channel not found")
+	}
+	if ch.Type != discordgo.ChannelTypeGuildText {
+		return fiber.NewError(fiber.StatusBadRequest, "prefix-less mode can only be enabled in text channels")
+	}
+
+	if err = c.db.AddPrefixlessChannel(guildID, channelID); err != nil {
+		return err
+	}
+
+	c.audit(guildID, uid, "prefixless."+channelID, "", "enabled")
+
+	return ctx.JSON(models.Ok)
+}
+
+// @Summary Remove Guild Prefix-less Channel
+// @Description Disables the prefix-less mode in the given channel.
+// @Tags Guild Settings
+// @Accept json
+// @Produce json
+// @Param id path string true "The ID of the guild."
+// @Param channelid path string true "The ID of the channel."
+// @Success 200 {object} models.Status
+// @Failure 401 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Router /guilds/{id}/settings/prefixless/{channelid} [delete]
+func (c *GuildsSettingsController) deleteGuildSettingsPrefixless(ctx *fiber.Ctx) error {
+	uid := ctx.Locals("uid").(string)
+	guildID := ctx.Params("guildid")
+	channelID := ctx.Params("channelid")
+
+	err := c.db.RemovePrefixlessChannel(guildID, channelID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return err
+	}
+
+	c.audit(guildID, uid, "prefixless."+channelID, "enabled", "")
+
+	return ctx.JSON(models.Ok)
+}
+
+// @Summary Get Guild Sticky Messages
+// @Description Returns all sticky messages of the guild.
+// @Tags Guild Settings
+// @Accept json
+// @Produce json
+// @Param id path string true "The ID of the guild."
+// @Success 200 {array} sharedmodels.StickyMessage "Wrapped in models.ListResponse"
+// @Failure 401 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Router /guilds/{id}/settings/stickymessages [get]
+func (c *GuildsSettingsController) getGuildSettingsStickyMessages(ctx *fiber.Ctx) error {
+	guildID := ctx.Params("guildid")
+
+	sms, err := c.db.GetStickyMessages(guildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return err
+	}
+
+	return ctx.JSON(models.NewListResponse(sms))
+}
+
+// @Summary Set Guild Sticky Message
+// @Description Sets the sticky message of the given channel and posts it, replacing the previous one.
+// @Tags Guild Settings
+// @Accept json
+// @Produce json
+// @Param id path string true "The ID of the guild."
+// @Param channelid path string true "The ID of the channel."
+// @Param payload body sharedmodels.StickyMessage true "The sticky message."
+// @Success 200 {object} models.Status
+// @Failure 400 {object} models.Error
+// @Failure 401 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Router /guilds/{id}/settings/stickymessages/{channelid} [put]
+func (c *GuildsSettingsController) putGuildSettingsStickyMessage(ctx *fiber.Ctx) error {
+	uid := ctx.Locals("uid").(string)
+	guildID := ctx.Params("guildid")
+	channelID := ctx.Params("channelid")
+
+	var sm sharedmodels.StickyMessage
+	if err := wsutil.ParseAndValidate(ctx, &sm); err != nil {
+		return err
+	}
+
+	ch, err := c.state.Channel(channelID)
+	if err != nil || ch.GuildID != guildID {
+		return fiber.NewError(fiber.StatusNotFound, "channel not found")
+	}
+
+	sm.GuildID = guildID
+	sm.ChannelID = channelID
+
+	old, err := c.db.GetStickyMessage(guildID, channelID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return err
+	}
+
+	err = stickymsg.Set(c.db, c.session, sm)
+	if discordutil.IsErrCode(err, discordgo.ErrCodeMissingPermissions) ||
+		discordutil.IsErrCode(err, discordgo.ErrCodeMissingAccess) {
+		return fiber.NewError(fiber.StatusBadRequest, "missing permission to send messages in the channel")
+	}
+	if err != nil {
+		return err
+	}
+
+	c.audit(guildID, uid, "stickymessage."+channelID, old.Content, sm.Content)
+
+	return ctx.JSON(models.Ok)
+}
+
+// @Summary Remove Guild Sticky Message
+// @Description Removes the sticky message of the given channel.
+// @Tags Guild Settings
+// @Accept json
+// @Produce json
+// @Param id path string true "The ID of the guild."
+// @Param channelid path string true "The ID of the channel."
+// @Success 200 {object} models.Status
+// @Failure 401 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Router /guilds/{id}/settings/stickymessages/{channelid} [delete]
+func (c *GuildsSettingsController) deleteGuildSettingsStickyMessage(ctx *fiber.Ctx) error {
+	uid := ctx.Locals("uid").(string)
+	guildID := ctx.Params("guildid")
+	channelID := ctx.Params("channelid")
+
+	old, err := c.db.GetStickyMessage(guildID, channelID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return err
+	}
+
+	err = stickymsg.Clear(c.db, c.session, guildID, channelID)
+	if err != nil && !discordutil.IsErrCode(err, discordgo.ErrCodeMissingPermissions) &&
+		!discordutil.IsErrCode(err, discordgo.ErrCodeMissingAccess) {
+		return err
+	}
+
+	c.audit(guildID, uid, "stickymessage."+channelID, old.Content, "")
+
+	return ctx.JSON(models.Ok)
+}
+
+func (c *GuildsSettingsController) karmaRule(guildID string, id snowflake.ID) (*sharedmodels.KarmaRule, error) {
+	rules, err := c.db.GetKarmaRules(guildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return nil, err
+	}
+
+	for _, r := range rules {
+		if r.ID == id {
+			return &r, nil
+		}
+	}
+
+	return nil, fiber.NewError(fiber.StatusNotFound, "rule not found")
+}
+
+func (c *GuildsSettingsController) commandExists(name string) bool {
+	for _, ci := range c.cmdHandler.GetCommandInfo() {
+		if ci.ApplicationCommand.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// @Summary Get Guild Settings Report Types
+// @Description Returns the custom report types of the guild.
+// @Tags Guild Settings
+// @Accept json
+// @Produce json
+// @Param id path string true "The ID of the guild."
+// @Success 200 {array} sharedmodels.CustomReportType "Wrapped in models.ListResponse"
+// @Failure 401 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Router /guilds/{id}/settings/reporttypes [get]
+func (c *GuildsSettingsController) getGuildSettingsReportTypes(ctx *fiber.Ctx) error {
+	guildID := ctx.Params("guildid")
+
+	types, err := c.db.GetGuildReportTypes(guildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return err
+	}
+	if types == nil {
+		types = []sharedmodels.CustomReportType{}
+	}
+
+	return ctx.JSON(models.NewListResponse(types))
+}
+
+// @Summary Create Guild Settings Report Type
+// @Description Create a custom report type. The ID of the type is assigned automatically.
+// @Tags Guild Settings
+// @Accept json
+// @Produce json
+// @Param id path string true "The ID of the guild."
+// @Param payload body sharedmodels.CustomReportType true "The report type payload."
+// @Success 200 {object} sharedmodels.CustomReportType
+// @Failure 400 {object} models.Error
+// @Failure 401 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Router /guilds/{id}/settings/reporttypes [post]
+func (c *GuildsSettingsController) createGuildSettingsReportType(ctx *fiber.Ctx) error {
+	uid := ctx.Locals("uid").(string)
+	guildID := ctx.Params("guildid")
+
+	var t sharedmodels.CustomReportType
+	if err := ctx.BodyParser(&t); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	types, err := c.rep.GetReportTypes(guildID)
+	if err != nil {
+		return err
+	}
+
+	if len(types) >= sharedmodels.MaxCustomReportTypes {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf(
+			"a guild can not have more than %d custom report types", sharedmodels.MaxCustomReportTypes))
+	}
+
+	t.GuildID = guildID
+	t.ID = types.NextID()
+	t.Name = strings.TrimSpace(t.Name)
+
+	if err = types.Validate(t); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if err = c.db.SetGuildReportType(t); err != nil {
+		return err
+	}
+
+	c.audit(guildID, uid, fmt.Sprintf("reporttype.%d", t.ID), "", t.Name)
+
+	return ctx.JSON(t)
+}
+
+// @Summary Update Guild Settings Report Type
+// @Description Update the name and color of a custom report type.
+// @Tags Guild Settings
+// @Accept json
+// @Produce json
+// @Param id path string true "The ID of the guild."
+// @Param typeid path int true "The ID of the report type."
+// @Param payload body sharedmodels.CustomReportType true "The report type payload."
+// @Success 200 {object} sharedmodels.CustomReportType
+// @Failure 400 {object} models.Error
+// @Failure 401 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Router /guilds/{id}/settings/reporttypes/{typeid} [post]
+func (c *GuildsSettingsController) updateGuildSettingsReportType(ctx *fiber.Ctx) error {
+	uid := ctx.Locals("uid").(string)
+	guildID := ctx.Params("guildid")
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	var t sharedmodels.CustomReportType
+	if err = ctx.BodyParser(&t); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	types, err := c.rep.GetReportTypes(guildID)
+	if err != nil {
+		return err
+	}
+
+	old, ok := types[sharedmodels.ReportType(id)]
+	if !ok {
+		return fiber.ErrNotFound
+	}
+
+	t.GuildID = guildID
+	t.ID = old.ID
+	t.Name = strings.TrimSpace(t.Name)
+
+	if err = types.Validate(t); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if err = c.db.SetGuildReportType(t); err != nil {
+		return err
+	}
+
+	c.audit(guildID, uid, fmt.Sprintf("reporttype.%d", t.ID), old.Name, t.Name)
+
+	return ctx.JSON(t)
+}
+
+// @Summary Remove Guild Settings Report Type
+// @Description Remove a custom report type. Types which are used by existing reports can not be removed.
+// @Tags Guild Settings
+// @Accept json
+// @Produce json
+// @Param id path string true "The ID of the guild."
+// @Param typeid path int true "The ID of the report type."
+// @Success 200 {object} models.Status
+// @Failure 400 {object} models.Error
+// @Failure 401 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Failure 409 {object} models.Error
+// @Router /guilds/{id}/settings/reporttypes/{typeid} [delete]
+func (c *GuildsSettingsController) deleteGuildSettingsReportType(ctx *fiber.Ctx) error {
+	uid := ctx.Locals("uid").(string)
+	guildID := ctx.Params("guildid")
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	types, err := c.rep.GetReportTypes(guildID)
+	if err != nil {
+		return err
+	}
+
+	old, ok := types[sharedmodels.ReportType(id)]
+	if !ok {
+		return fiber.ErrNotFound
+	}
+
+	// Existing reports must stay resolvable, so types
+	// which are still in use can not be removed.
+	count, err := c.db.GetReportsFilteredCount(guildID, "", id)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return err
+	}
+	if count > 0 {
+		return fiber.NewError(fiber.StatusConflict, fmt.Sprintf(
+			"the report type is used by %d reports and can not be removed", count))
+	}
+
+	if err = c.db.DeleteGuildReportType(guildID, old.ID); err != nil {
+		return wsutil.ErrInternalOrNotFound(err)
+	}
+
+	c.audit(guildID, uid, fmt.Sprintf("reporttype.%d", old.ID), old.Name, "")
+
+	return ctx.JSON(models.Ok)
+}
+
+// @Summary Get Guild Settings Report Escalation
+// @Description Returns the report points and escalation thresholds of the guild.
+// @Tags Guild Settings
+// @Accept json
+// @Produce json
+// @Param id path string true "The ID of the guild."
+// @Success 200 {object} sharedmodels.ReportEscalation
+// @Failure 401 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Router /guilds/{id}/settings/escalation [get]
+func (c *GuildsSettingsController) getGuildSettingsEscalation(ctx *fiber.Ctx) error {
+	guildID := ctx.Params("guildid")
+
+	esc, err := c.db.GetGuildReportEscalation(guildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return err
+	}
+	esc.GuildID = guildID
+	if esc.Points == nil {
+		esc.Points = map[sharedmodels.ReportType]int{}
+	}
+	if esc.Thresholds == nil {
+		esc.Thresholds = []sharedmodels.EscalationThreshold{}
+	}
+
+	return ctx.JSON(esc)
+}
+
+// @Summary Update Guild Settings Report Escalation
+// @Description Update the report points and escalation thresholds of the guild.
+// @Tags Guild Settings
+// @Accept json
+// @Produce json
+// @Param id path string true "The ID of the guild."
+// @Param payload body sharedmodels.ReportEscalation true "The escalation payload."
+// @Success 200 {object} sharedmodels.ReportEscalation
+// @Failure 400 {object} models.Error
+// @Failure 401 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Router /guilds/{id}/settings/escalation [post]
+func (c *GuildsSettingsController) postGuildSettingsEscalation(ctx *fiber.Ctx) error {
+	uid := ctx.Locals("uid").(string)
+	guildID := ctx.Params("guildid")
+
+	var esc sharedmodels.ReportEscalation
+	if err := ctx.BodyParser(&esc); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	esc.GuildID = guildID
+
+	types, err := c.rep.GetReportTypes(guildID)
+	if err != nil {
+		return err
+	}
+
+	if err = esc.Validate(types); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	old, err := c.db.GetGuildReportEscalation(guildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return err
+	}
+
+	if err = c.db.SetGuildReportEscalation(esc); err != nil {
+		return err
+	}
+
+	c.auditJSON(guildID, uid, "escalation", old, esc)
+
+	return ctx.JSON(esc)
+}
+
+// @Summary Preview Join or Leave Message
+// @Description Renders the given join or leave message template using the data of the requesting user.
+// @Tags Guild Settings
+// @Accept json
+// @Produce json
+// @Param id path string true "The ID of the guild."
+// @Param payload body models.MessagePreviewRequest true "The message template."
+// @Success 200 {object} models.MessagePreview
+// @Failure 400 {object} models.Error
+// @Failure 401 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Router /guilds/{id}/settings/joinmessage/preview [post]
+// @Router /guilds/{id}/settings/leavemessage/preview [post]
+func (c *GuildsSettingsController) postGuildSettingsMessagePreview(ctx *fiber.Ctx) error {
+	uid := ctx.Locals("uid").(string)
+	guildID := ctx.Params("guildid")
+
+	var req models.MessagePreviewRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if err := membermsg.Validate(req.Template); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	memb, err := c.state.Member(guildID, uid)
+	if err != nil {
+		return wsutil.ErrInternalOrNotFound(err)
+	}
+
+	return ctx.JSON(models.MessagePreview{
+		Raw:      req.Template,
+		Rendered: membermsg.Render(req.Template, memb.User),
+	})
+}
+
+// @Summary Preview Twitch Notification
+// @Description Renders the given Twitch notification template using example stream data of the requesting user.
+// @Tags Guild Settings
+// @Accept json
+// @Produce json
+// @Param id path string true "The ID of the guild."
+// @Param payload body models.TwitchNotifyPreviewRequest true "The notification template."
+// @Success 200 {object} models.TwitchNotifyPreview
+// @Failure 400 {object} models.Error
+// @Failure 401 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Router /guilds/{id}/settings/twitchnotify/preview [post]
+func (c *GuildsSettingsController) postGuildSettingsTwitchNotifyPreview(ctx *fiber.Ctx) error {
+	uid := ctx.Locals("uid").(string)
+	guildID := ctx.Params("guildid")
+
+	var req models.TwitchNotifyPreviewRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if len(req.Template) > twitchnotify.MaxTemplateLength {
+		return fiber.NewError(fiber.StatusBadRequest,
+			fmt.Sprintf("template must not be longer than %d characters", twitchnotify.MaxTemplateLength))
+	}
+
+	memb, err := c.state.Member(guildID, uid)
+	if err != nil {
+		return wsutil.ErrInternalOrNotFound(err)
+	}
+
+	stream := &twitchnotify.Stream{
+		Title:       "Example Stream",
+		ViewerCount: 42,
+		Game:        &twitchnotify.Game{Name: "Just Chatting"},
+	}
+	user := &twitchnotify.User{
+		DisplayName: memb.User.Username,
+		LoginName:   strings.ToLower(memb.User.Username),
+	}
+	entry := twitchnotify.DBEntry{
+		Template: req.Template,
+		RoleID:   req.RoleID,
+		Plain:    req.Plain,
+	}
+
+	return ctx.JSON(models.TwitchNotifyPreview{
+		MessagePreview: models.MessagePreview{
+			Raw:      req.Template,
+			Rendered: twitchnotify.GetMessageContent(entry, stream, user),
+		},
+		Embed: !req.Plain,
+	})
+}
+
+// getEmbedColor returns the embed color of the given
+// guild or -1 if no embed color is set.
+func (c *GuildsSettingsController) getEmbedColor(guildID string) (int, error) {
+	clr, err := c.db.GetGuildEmbedColor(guildID)
+	if database.IsErrDatabaseNotFound(err) {
+		return -1, nil
+	}
+	return clr, err
+}
@@ -16,13 +16,13 @@ import (
 
 type InviteController struct {
 	session *discordgo.Session
-	st      *dgrs.State
+	st      dgrs.IState
 	kv      kvcache.Provider
 }
 
 func (c *InviteController) Setup(container di.Container, router fiber.Router) {
 	c.session = container.Get(static.DiDiscordSession).(*discordgo.Session)
-	c.st = container.Get(static.DiState).(*dgrs.State)
+	c.st = container.Get(static.DiState).(dgrs.IState)
 	c.kv = container.Get(static.DiKVCache).(kvcache.Provider)
 
 	router.Get("", c.getInvite)
@@ -0,0 +1,68 @@
+package controllers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/sarulabs/di/v2"
+	"github.com/zekroTJA/shinpuru/internal/services/listenerregistry"
+	"github.com/zekroTJA/shinpuru/internal/services/webserver/v1/models"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+)
+
+type ListenersController struct {
+	reg *listenerregistry.Registry
+}
+
+func (c *ListenersController) Setup(container di.Container, router fiber.Router) {
+	c.reg = container.Get(static.DiListenerRegistry).(*listenerregistry.Registry)
+
+	router.Use(ownerOnly(container))
+	router.Get("", c.getListeners)
+	router.Post("/:name", c.postListener)
+}
+
+// @Summary Get Listeners
+// @Description Returns the names and states of all registered event listeners.
+// @Tags Listeners
+// @Accept json
+// @Produce json
+// @Success 200 {array} listenerregistry.State "Wrapped in models.ListResponse"
+// @Failure 401 {object} models.Error
+// @Failure 403 {object} models.Error
+// @Router /listeners [get]
+func (c *ListenersController) getListeners(ctx *fiber.Ctx) error {
+	return ctx.JSON(models.NewListResponse(c.reg.States()))
+}
+
+// @Summary Set Listener State
+// @Description Enables or disables an event listener at runtime. Disabled listeners ignore all events until they are enabled again.
+// @Tags Listeners
+// @Accept json
+// @Produce json
+// @Param name path string true "The name of the listener."
+// @Param payload body models.ListenerStateRequest true "The listener state."
+// @Success 200 {object} listenerregistry.State
+// @Failure 400 {object} models.Error
+// @Failure 401 {object} models.Error
+// @Failure 403 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Router /listeners/{name} [post]
+func (c *ListenersController) postListener(ctx *fiber.Ctx) error {
+	name := ctx.Params("name")
+
+	var req models.ListenerStateRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if err := c.reg.SetEnabled(name, req.Enabled); err != nil {
+		if err == listenerregistry.ErrNotFound {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+		return err
+	}
+
+	return ctx.JSON(listenerregistry.State{
+		Name:    name,
+		Enabled: req.Enabled,
+	})
+}
@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"bytes"
+	"errors"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/gofiber/fiber/v2"
@@ -16,6 +17,7 @@ import (
 	"github.com/zekroTJA/shinpuru/internal/services/webserver/wsutil"
 	"github.com/zekroTJA/shinpuru/internal/util/imgstore"
 	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/pkg/roleutil"
 	"github.com/zekrotja/dgrs"
 )
 
@@ -25,7 +27,7 @@ type MemberReportingController struct {
 	db      database.Database
 	st      storage.Storage
 	repSvc  *report.ReportService
-	state   *dgrs.State
+	state   dgrs.IState
 }
 
 func (c *MemberReportingController) Setup(container di.Container, router fiber.Router) {
@@ -34,7 +36,7 @@ func (c *MemberReportingController) Setup(container di.Container, router fiber.R
 	c.db = container.Get(static.DiDatabase).(database.Database)
 	c.st = container.Get(static.DiObjectStorage).(storage.Storage)
 	c.repSvc = container.Get(static.DiReport).(*report.ReportService)
-	c.state = container.Get(static.DiState).(*dgrs.State)
+	c.state = container.Get(static.DiState).(dgrs.IState)
 
 	pmw := container.Get(static.DiPermissions).(*permissions.Permissions)
 
@@ -65,18 +67,14 @@ func (c *MemberReportingController) postReport(ctx *fiber.Ctx) (err error) {
 	memberID := ctx.Params("memberid")
 
 	repReq := new(models.ReportRequest)
-	if err := ctx.BodyParser(repReq); err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	if err := wsutil.ParseAndValidate(ctx, repReq); err != nil {
+		return err
 	}
 
 	if memberID == uid {
 		return fiber.NewError(fiber.StatusBadRequest, "you can not report yourself")
 	}
 
-	if ok, err := repReq.Validate(false); !ok {
-		return err
-	}
-
 	if err = c.uploadAttachment(repReq.ReasonRequest); err != nil {
 		return
 	}
@@ -90,11 +88,20 @@ func (c *MemberReportingController) postReport(ctx *fiber.Ctx) (err error) {
 		Type:          repReq.Type,
 	})
 
+	if err == report.ErrInvalidType {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid report type")
+	}
+
+	if err != nil {
+		return err
+	}
+
+	types, err := c.repSvc.GetReportTypes(guildID)
 	if err != nil {
 		return err
 	}
 
-	return ctx.JSON(models.ReportFromReport(rep, c.cfg.Config().WebServer.PublicAddr))
+	return ctx.JSON(models.ReportFromReport(rep, c.cfg.Config().WebServer.PublicAddr, types))
 }
 
 // @Summary Create A Member Kick Report
@@ -117,18 +124,14 @@ func (c *MemberReportingController) postKick(ctx *fiber.Ctx) (err error) {
 	memberID := ctx.Params("memberid")
 
 	req := new(models.ReasonRequest)
-	if err := ctx.BodyParser(req); err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	if err := wsutil.ParseAndValidate(ctx, req); err != nil {
+		return err
 	}
 
 	if memberID == uid {
 		return fiber.NewError(fiber.StatusBadRequest, "you can not kick yourself")
 	}
 
-	if ok, err := req.Validate(false); !ok {
-		return err
-	}
-
 	if err = c.uploadAttachment(req); err != nil {
 		return
 	}
@@ -144,12 +147,15 @@ func (c *MemberReportingController) postKick(ctx *fiber.Ctx) (err error) {
 	if err == report.ErrRoleDiff {
 		return fiber.NewError(fiber.StatusBadRequest, "you can not kick members with higher or same permissions than/as yours")
 	}
+	if roleutil.IsModerationError(err) {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
 
 	if err != nil {
 		return err
 	}
 
-	return ctx.JSON(models.ReportFromReport(rep, c.cfg.Config().WebServer.PublicAddr))
+	return ctx.JSON(models.ReportFromReport(rep, c.cfg.Config().WebServer.PublicAddr, nil))
 }
 
 // @Summary Create A Member Ban Report
@@ -177,18 +183,14 @@ func (c *MemberReportingController) postBan(ctx *fiber.Ctx) (err error) {
 	}
 
 	req := new(models.ReasonRequest)
-	if err := ctx.BodyParser(req); err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	if err := wsutil.ParseAndValidate(ctx, req); err != nil {
+		return err
 	}
 
 	if memberID == uid {
 		return fiber.NewError(fiber.StatusBadRequest, "you can not ban yourself")
 	}
 
-	if ok, err := req.Validate(false); !ok {
-		return err
-	}
-
 	if err = c.uploadAttachment(req); err != nil {
 		return
 	}
@@ -206,12 +208,15 @@ func (c *MemberReportingController) postBan(ctx *fiber.Ctx) (err error) {
 	if err == report.ErrRoleDiff {
 		return fiber.NewError(fiber.StatusBadRequest, "you can not ban members with higher or same permissions than/as yours")
 	}
+	if roleutil.IsModerationError(err) {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
 
 	if err != nil {
 		return err
 	}
 
-	return ctx.JSON(models.ReportFromReport(rep, c.cfg.Config().WebServer.PublicAddr))
+	return ctx.JSON(models.ReportFromReport(rep, c.cfg.Config().WebServer.PublicAddr, nil))
 }
 
 // @Summary Create A Member Mute Report
@@ -221,7 +226,7 @@ func (c *MemberReportingController) postBan(ctx *fiber.Ctx) (err error) {
 // @Produce json
 // @Param id path string true "The ID of the guild."
 // @Param memberid path string true "The ID of the victim member."
-// @Param payload body models.ReasonRequest true "The report payload."
+// @Param payload body models.MuteRequest true "The report payload."
 // @Success 200 {object} models.Report
 // @Failure 400 {object} models.Error
 // @Failure 401 {object} models.Error
@@ -233,20 +238,16 @@ func (c *MemberReportingController) postMute(ctx *fiber.Ctx) (err error) {
 	guildID := ctx.Params("guildid")
 	memberID := ctx.Params("memberid")
 
-	req := new(models.ReasonRequest)
-	if err := ctx.BodyParser(req); err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	req := new(models.MuteRequest)
+	if err := wsutil.ParseAndValidate(ctx, req); err != nil {
+		return err
 	}
 
 	if memberID == uid {
 		return fiber.NewError(fiber.StatusBadRequest, "you can not mute yourself")
 	}
 
-	if ok, err := req.Validate(true); !ok {
-		return err
-	}
-
-	if err = c.uploadAttachment(req); err != nil {
+	if err = c.uploadAttachment(&req.ReasonRequest); err != nil {
 		return
 	}
 
@@ -266,12 +267,15 @@ func (c *MemberReportingController) postMute(ctx *fiber.Ctx) (err error) {
 	if err == report.ErrRoleDiff {
 		return fiber.NewError(fiber.StatusBadRequest, "you can not mute members with higher or same permissions than/as yours")
 	}
+	if roleutil.IsModerationError(err) {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
 
 	if err != nil {
 		return err
 	}
 
-	return ctx.JSON(models.ReportFromReport(rep, c.cfg.Config().WebServer.PublicAddr))
+	return ctx.JSON(models.ReportFromReport(rep, c.cfg.Config().WebServer.PublicAddr, nil))
 }
 
 // @Summary Unmute A Member
@@ -286,7 +290,7 @@ func (c *MemberReportingController) postMute(ctx *fiber.Ctx) (err error) {
 // @Failure 400 {object} models.Error
 // @Failure 401 {object} models.Error
 // @Failure 404 {object} models.Error
-// @Router /guilds/{id}/{memberid}/mute [post]
+// @Router /guilds/{id}/{memberid}/unmute [post]
 func (c *MemberReportingController) postUnmute(ctx *fiber.Ctx) (err error) {
 	uid := ctx.Locals("uid").(string)
 
@@ -311,6 +315,9 @@ func (c *MemberReportingController) postUnmute(ctx *fiber.Ctx) (err error) {
 	if err == report.ErrRoleDiff {
 		return fiber.NewError(fiber.StatusBadRequest, "you can not unmute members with higher or same permissions than/as yours")
 	}
+	if roleutil.IsModerationError(err) {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
 
 	if err != nil {
 		return err
@@ -335,7 +342,10 @@ func (c *MemberReportingController) uploadAttachment(repReq *models.ReasonReques
 		img.Size = len(img.Data)
 		img.GenerateID()
 	} else if repReq.Attachment != "" {
-		img, err = imgstore.DownloadFromURL(repReq.Attachment)
+		img, err = imgstore.DownloadFromURL(repReq.Attachment, c.attachmentHosts())
+		if errors.Is(err, imgstore.ErrHostNotAllowed) {
+			return fiber.NewError(fiber.StatusBadRequest, "attachment host is not allowed")
+		}
 		if err != nil {
 			return fiber.NewError(fiber.StatusBadRequest, err.Error())
 		}
@@ -352,3 +362,11 @@ func (c *MemberReportingController) uploadAttachment(repReq *models.ReasonReques
 
 	return
 }
+
+// attachmentHosts returns the configured attachment
+// hosts and the host of the public address, which
+// serves the images stored by shinpuru.
+func (c *MemberReportingController) attachmentHosts() []string {
+	cfg := c.cfg.Config().WebServer
+	return imgstore.AllowedHosts(cfg.PublicAddr, cfg.AttachmentHosts)
+}
@@ -4,16 +4,21 @@ import (
 	"strings"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/bwmarrin/snowflake"
 	"github.com/gofiber/fiber/v2"
 	"github.com/sarulabs/di/v2"
 	sharedmodels "github.com/zekroTJA/shinpuru/internal/models"
 	"github.com/zekroTJA/shinpuru/internal/services/config"
 	"github.com/zekroTJA/shinpuru/internal/services/database"
 	"github.com/zekroTJA/shinpuru/internal/services/permissions"
+	"github.com/zekroTJA/shinpuru/internal/services/report"
+	"github.com/zekroTJA/shinpuru/internal/services/timeprovider"
 	"github.com/zekroTJA/shinpuru/internal/services/webserver/v1/models"
 	"github.com/zekroTJA/shinpuru/internal/services/webserver/wsutil"
 	"github.com/zekroTJA/shinpuru/internal/util"
+	"github.com/zekroTJA/shinpuru/internal/util/snowflakenodes"
 	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/internal/util/temprole"
 	"github.com/zekroTJA/shinpuru/pkg/discordutil"
 	"github.com/zekrotja/dgrs"
 	"github.com/zekrotja/ken"
@@ -26,7 +31,9 @@ type GuildMembersController struct {
 	db         database.Database
 	pmw        *permissions.Permissions
 	cmdHandler *ken.Ken
-	st         *dgrs.State
+	st         dgrs.IState
+	repSvc     report.Provider
+	tp         timeprovider.Provider
 }
 
 func (c *GuildMembersController) Setup(container di.Container, router fiber.Router) {
@@ -35,7 +42,9 @@ func (c *GuildMembersController) Setup(container di.Container, router fiber.Rout
 	c.db = container.Get(static.DiDatabase).(database.Database)
 	c.pmw = container.Get(static.DiPermissions).(*permissions.Permissions)
 	c.cmdHandler = container.Get(static.DiCommandHandler).(*ken.Ken)
-	c.st = container.Get(static.DiState).(*dgrs.State)
+	c.st = container.Get(static.DiState).(dgrs.IState)
+	c.repSvc = container.Get(static.DiReport).(report.Provider)
+	c.tp = container.Get(static.DiTimeProvider).(timeprovider.Provider)
 
 	router.Get("/members", c.getMembers)
 	router.Get("/:memberid", c.getMember)
@@ -45,6 +54,12 @@ func (c *GuildMembersController) Setup(container di.Container, router fiber.Rout
 	router.Get("/:memberid/reports/count", c.getReportsCount)
 	router.Get("/:memberid/unbanrequests", c.pmw.HandleWs(c.session, "sp.guild.mod.unbanrequests"), c.getMemberUnbanrequests)
 	router.Get("/:memberid/unbanrequests/count", c.pmw.HandleWs(c.session, "sp.guild.mod.unbanrequests"), c.getMemberUnbanrequestsCount)
+	router.Get("/:memberid/temproles", c.pmw.HandleWs(c.session, "sp.guild.mod.temprole"), c.getMemberTempRoles)
+	router.Post("/:memberid/temproles", c.pmw.HandleWs(c.session, "sp.guild.mod.temprole"), c.postMemberTempRole)
+	router.Delete("/:memberid/temproles/:tempid", c.pmw.HandleWs(c.session, "sp.guild.mod.temprole"), c.deleteMemberTempRole)
+	router.Get("/:memberid/notes", c.pmw.HandleWs(c.session, "sp.guild.mod.note"), c.getMemberNotes)
+	router.Post("/:memberid/notes", c.pmw.HandleWs(c.session, "sp.guild.mod.note"), c.postMemberNote)
+	router.Delete("/:memberid/notes/:noteid", c.pmw.HandleWs(c.session, "sp.guild.mod.note"), c.deleteMemberNote)
 }
 
 // @Summary Get Guild Member List
@@ -275,11 +290,16 @@ func (c *GuildMembersController) getReports(ctx *fiber.Ctx) (err error) {
 		return err
 	}
 
+	types, err := c.repSvc.GetReportTypes(guildID)
+	if err != nil {
+		return err
+	}
+
 	resReps := make([]models.Report, 0)
 	if reps != nil {
 		resReps = make([]models.Report, len(reps))
 		for i, r := range reps {
-			resReps[i] = models.ReportFromReport(r, c.cfg.Config().WebServer.PublicAddr)
+			resReps[i] = models.ReportFromReport(r, c.cfg.Config().WebServer.PublicAddr, types)
 			user, err := c.st.User(r.VictimID)
 			if err == nil {
 				resReps[i].Victim = models.FlatUserFromUser(user)
@@ -394,3 +414,255 @@ func (c *GuildMembersController) getMemberUnbanrequestsCount(ctx *fiber.Ctx) (er
 
 	return ctx.JSON(&models.Count{Count: count})
 }
+
+// @Summary Get Guild Member Temporary Roles
+// @Description Returns the active temporary role assignments of the given member.
+// @Tags Members
+// @Accept json
+// @Produce json
+// @Param id path string true "The ID of the guild."
+// @Param memberid path string true "The ID of the member."
+// @Success 200 {array} sharedmodels.TempRole "Wrapped in models.ListResponse"
+// @Failure 401 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Router /guilds/{id}/{memberid}/temproles [get]
+func (c *GuildMembersController) getMemberTempRoles(ctx *fiber.Ctx) (err error) {
+	guildID := ctx.Params("guildid")
+	memberID := ctx.Params("memberid")
+
+	tempRoles, err := c.db.GetTempRoles(guildID, memberID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return err
+	}
+	if tempRoles == nil {
+		tempRoles = make([]sharedmodels.TempRole, 0)
+	}
+
+	return ctx.JSON(models.NewListResponse(tempRoles))
+}
+
+// @Summary Add Guild Member Temporary Role
+// @Description Assigns a role to the given member which is removed automatically when it expires. If the role is already temporarily assigned to the member, the expiry is updated.
+// @Tags Members
+// @Accept json
+// @Produce json
+// @Param id path string true "The ID of the guild."
+// @Param memberid path string true "The ID of the member."
+// @Param payload body models.TempRoleRequest true "The temporary role payload."
+// @Success 200 {object} sharedmodels.TempRole
+// @Failure 400 {object} models.Error
+// @Failure 401 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Router /guilds/{id}/{memberid}/temproles [post]
+func (c *GuildMembersController) postMemberTempRole(ctx *fiber.Ctx) (err error) {
+	uid := ctx.Locals("uid").(string)
+
+	guildID := ctx.Params("guildid")
+	memberID := ctx.Params("memberid")
+
+	req := new(models.TempRoleRequest)
+	if err = wsutil.ParseAndValidate(ctx, req); err != nil {
+		return err
+	}
+
+	duration := req.Expires.Sub(c.tp.Now())
+	if duration <= 0 {
+		return fiber.NewError(fiber.StatusBadRequest, "expiry must be in the future")
+	}
+	if duration > temprole.MaxDuration {
+		return fiber.NewError(fiber.StatusBadRequest, "temporary roles can not be assigned for longer than 365 days")
+	}
+
+	memb, err := c.st.Member(guildID, memberID)
+	if err != nil {
+		return fiber.ErrNotFound
+	}
+
+	guild, err := c.st.Guild(guildID, true)
+	if err != nil {
+		return err
+	}
+	executor, err := c.st.Member(guildID, uid)
+	if err != nil {
+		return err
+	}
+	self, err := c.st.SelfUser()
+	if err != nil {
+		return err
+	}
+	selfMember, err := c.st.Member(guildID, self.ID)
+	if err != nil {
+		return err
+	}
+
+	if err = temprole.CheckAssignable(guild, executor, selfMember, req.RoleID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	t, err := temprole.Assign(c.db, c.session, c.tp, guildID, memb, req.RoleID, uid, duration)
+	if err == temprole.ErrRoleHeld {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(t)
+}
+
+// @Summary Cancel Guild Member Temporary Role
+// @Description Removes a temporary role from the given member before it expires.
+// @Tags Members
+// @Accept json
+// @Produce json
+// @Param id path string true "The ID of the guild."
+// @Param memberid path string true "The ID of the member."
+// @Param tempid path string true "The ID of the temporary role assignment."
+// @Success 200 {object} models.Status
+// @Failure 401 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Router /guilds/{id}/{memberid}/temproles/{tempid} [delete]
+func (c *GuildMembersController) deleteMemberTempRole(ctx *fiber.Ctx) (err error) {
+	guildID := ctx.Params("guildid")
+	memberID := ctx.Params("memberid")
+
+	id, err := snowflake.ParseString(ctx.Params("tempid"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	t, err := c.db.GetTempRole(id)
+	if database.IsErrDatabaseNotFound(err) {
+		return fiber.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if t.GuildID != guildID || t.UserID != memberID {
+		return fiber.ErrNotFound
+	}
+
+	if err = temprole.Cancel(c.db, c.session, t); err != nil {
+		return err
+	}
+
+	return ctx.JSON(models.Ok)
+}
+
+// @Summary Get Guild Member Notes
+// @Description Returns the moderator notes on the given user. Notes are kept even if the user has left the guild.
+// @Tags Members
+// @Accept json
+// @Produce json
+// @Param id path string true "The ID of the guild."
+// @Param memberid path string true "The ID of the user."
+// @Success 200 {array} sharedmodels.UserNote "Wrapped in models.ListResponse"
+// @Failure 401 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Router /guilds/{id}/{memberid}/notes [get]
+func (c *GuildMembersController) getMemberNotes(ctx *fiber.Ctx) (err error) {
+	guildID := ctx.Params("guildid")
+	memberID := ctx.Params("memberid")
+
+	notes, err := c.db.GetUserNotes(guildID, memberID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return err
+	}
+	if notes == nil {
+		notes = make([]sharedmodels.UserNote, 0)
+	}
+
+	return ctx.JSON(models.NewListResponse(notes))
+}
+
+// @Summary Add Guild Member Note
+// @Description Adds a moderator note to the given user.
+// @Tags Members
+// @Accept json
+// @Produce json
+// @Param id path string true "The ID of the guild."
+// @Param memberid path string true "The ID of the user."
+// @Param payload body models.UserNoteRequest true "The note payload."
+// @Success 200 {object} sharedmodels.UserNote
+// @Failure 400 {object} models.Error
+// @Failure 401 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Router /guilds/{id}/{memberid}/notes [post]
+func (c *GuildMembersController) postMemberNote(ctx *fiber.Ctx) (err error) {
+	uid := ctx.Locals("uid").(string)
+
+	guildID := ctx.Params("guildid")
+	memberID := ctx.Params("memberid")
+
+	req := new(models.UserNoteRequest)
+	if err = wsutil.ParseAndValidate(ctx, req); err != nil {
+		return err
+	}
+
+	if _, err = c.st.User(memberID); err != nil {
+		return fiber.ErrNotFound
+	}
+
+	note := sharedmodels.UserNote{
+		ID:       snowflakenodes.NodeUserNotes.Generate(),
+		GuildID:  guildID,
+		UserID:   memberID,
+		AuthorID: uid,
+		Content:  req.Content,
+		Created:  c.tp.Now(),
+	}
+
+	if err = c.db.AddUserNote(note); err != nil {
+		return err
+	}
+
+	return ctx.JSON(note)
+}
+
+// @Summary Delete Guild Member Note
+// @Description Deletes a moderator note of the given user.
+// @Tags Members
+// @Accept json
+// @Produce json
+// @Param id path string true "The ID of the guild."
+// @Param memberid path string true "The ID of the user."
+// @Param noteid path string true "The ID of the note."
+// @Success 200 {object} models.Status
+// @Failure 400 {object} models.Error
+// @Failure 401 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Router /guilds/{id}/{memberid}/notes/{noteid} [delete]
+func (c *GuildMembersController) deleteMemberNote(ctx *fiber.Ctx) (err error) {
+	guildID := ctx.Params("guildid")
+	memberID := ctx.Params("memberid")
+
+	id, err := snowflake.ParseString(ctx.Params("noteid"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	notes, err := c.db.GetUserNotes(guildID, memberID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return err
+	}
+	var found bool
+	for _, n := range notes {
+		if n.ID == id {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return fiber.ErrNotFound
+	}
+
+	err = c.db.DeleteUserNote(guildID, id)
+	if database.IsErrDatabaseNotFound(err) {
+		return fiber.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(models.Ok)
+}
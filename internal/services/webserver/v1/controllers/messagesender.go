@@ -0,0 +1,33 @@
+package controllers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/sarulabs/di/v2"
+	"github.com/zekroTJA/shinpuru/internal/services/msgsender"
+	"github.com/zekroTJA/shinpuru/internal/services/webserver/v1/models"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+)
+
+type MessageSenderController struct {
+	sender *msgsender.Sender
+}
+
+func (c *MessageSenderController) Setup(container di.Container, router fiber.Router) {
+	c.sender = container.Get(static.DiMessageSender).(*msgsender.Sender)
+
+	router.Use(ownerOnly(container))
+	router.Get("/deadletters", c.getDeadLetters)
+}
+
+// @Summary Get Dead Letters
+// @Description Returns the most recent message sends which have failed finally, ordered from oldest to newest.
+// @Tags Message Sender
+// @Accept json
+// @Produce json
+// @Success 200 {array} msgsender.DeadLetter "Wrapped in models.ListResponse"
+// @Failure 401 {object} models.Error
+// @Failure 403 {object} models.Error
+// @Router /messagesender/deadletters [get]
+func (c *MessageSenderController) getDeadLetters(ctx *fiber.Ctx) error {
+	return ctx.JSON(models.NewListResponse(c.sender.DeadLetters()))
+}
@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
@@ -11,7 +12,8 @@ import (
 	"github.com/zekroTJA/shinpuru/internal/services/database"
 	"github.com/zekroTJA/shinpuru/internal/services/timeprovider"
 	"github.com/zekroTJA/shinpuru/internal/services/webserver/auth"
-	_ "github.com/zekroTJA/shinpuru/internal/services/webserver/v1/models" // Import for API documentation
+	mw "github.com/zekroTJA/shinpuru/internal/services/webserver/middleware"
+	"github.com/zekroTJA/shinpuru/internal/services/webserver/v1/models"
 	"github.com/zekroTJA/shinpuru/internal/util/static"
 	"github.com/zekroTJA/shinpuru/pkg/discordoauth/v2"
 	"github.com/zekroTJA/shinpuru/pkg/onetimeauth/v2"
@@ -24,6 +26,8 @@ type OTAController struct {
 	ota          onetimeauth.OneTimeAuth
 	oauthHandler auth.RequestHandler
 	tp           timeprovider.Provider
+	ath          auth.AccessTokenHandler
+	rth          auth.RefreshTokenHandler
 }
 
 func (c *OTAController) Setup(container di.Container, router fiber.Router) {
@@ -33,8 +37,11 @@ func (c *OTAController) Setup(container di.Container, router fiber.Router) {
 	c.ota = container.Get(static.DiOneTimeAuth).(onetimeauth.OneTimeAuth)
 	c.oauthHandler = container.Get(static.DiOAuthHandler).(auth.RequestHandler)
 	c.tp = container.Get(static.DiTimeProvider).(timeprovider.Provider)
+	c.ath = container.Get(static.DiAuthAccessTokenHandler).(auth.AccessTokenHandler)
+	c.rth = container.Get(static.DiAuthRefreshTokenHandler).(auth.RefreshTokenHandler)
 
 	router.Get("", c.getOta)
+	router.Post("/confirmation", c.postConfirmation)
 }
 
 // @Summary OTA Login
@@ -84,3 +91,78 @@ func (c *OTAController) getOta(ctx *fiber.Ctx) error {
 		UserID: userID,
 	})
 }
+
+// @Summary Obtain Confirmation Token
+// @Description Returns a confirmation token which must be passed in the `X-Confirmation-Token` header to sensitive routes when `webserver.requireconfirmation` is enabled. The token is only valid once and expires after one minute. The request must be authorized with an access token of the web interface session and must carry the session refresh token cookie, so a token can not be obtained with a leaked access, refresh or API token only.
+// @Description
+// @Description Protected routes: `POST /guilds/{id}/reports/import`, `POST /guilds/{id}/config/bundle` (except dry runs), `DELETE /guilds/{id}/backups/{backupid}`, `POST /guilds/{id}/settings/flushguilddata`, `DELETE /guilds/{id}/karma`, `DELETE /caches/{name}`, `POST /token`, `DELETE /token` and `POST /usersettings/flush`. `POST /broadcasts` and `DELETE /caches/{name}` without prefix always require a confirmation token.
+// @Tags OTA
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.AccessTokenResponse
+// @Failure 401 {object} models.Error
+// @Router /ota/confirmation [post]
+func (c *OTAController) postConfirmation(ctx *fiber.Ctx) error {
+	// Only access tokens are accepted, API tokens and
+	// OTA tokens can not be used to obtain a token.
+	scheme, accessToken, _ := strings.Cut(ctx.Get(fiber.HeaderAuthorization), " ")
+	if !strings.EqualFold(scheme, "accesstoken") || accessToken == "" {
+		return fiber.ErrUnauthorized
+	}
+
+	uid, err := c.ath.ValidateAccessToken(accessToken)
+	if err != nil || uid == "" {
+		return fiber.ErrUnauthorized
+	}
+
+	refreshToken := ctx.Cookies(static.RefreshTokenCookieName)
+	if refreshToken == "" {
+		return fiber.ErrUnauthorized
+	}
+
+	ident, err := c.rth.ValidateRefreshToken(refreshToken)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return err
+	}
+	if ident == "" || ident != uid {
+		return fiber.ErrUnauthorized
+	}
+
+	token, expires, err := c.ota.GetKey(uid, mw.ConfirmationScope)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(&models.AccessTokenResponse{
+		Token:   token,
+		Expires: expires,
+	})
+}
+
+// confirmation returns the confirmation middleware for
+// sensitive routes when confirmation is enabled in the
+// config. Otherwise, a pass-through handler is returned.
+func confirmation(container di.Container) fiber.Handler {
+	cfg := container.Get(static.DiConfig).(config.Provider)
+	if !cfg.Config().WebServer.RequireConfirmation {
+		return func(ctx *fiber.Ctx) error {
+			return ctx.Next()
+		}
+	}
+
+	ota := container.Get(static.DiOneTimeAuth).(onetimeauth.OneTimeAuth)
+	return mw.NewConfirmation(ota)
+}
+
+// ownerOnly returns a handler which only passes
+// requests of the bot owner to the next handler.
+func ownerOnly(container di.Container) fiber.Handler {
+	cfg := container.Get(static.DiConfig).(config.Provider)
+	return func(ctx *fiber.Ctx) error {
+		uid, _ := ctx.Locals("uid").(string)
+		if uid == "" || uid != cfg.Config().Discord.OwnerID {
+			return fiber.ErrForbidden
+		}
+		return ctx.Next()
+	}
+}
@@ -16,13 +16,13 @@ import (
 type PublicController struct {
 	session *discordgo.Session
 	db      database.Database
-	st      *dgrs.State
+	st      dgrs.IState
 }
 
 func (c *PublicController) Setup(container di.Container, router fiber.Router) {
 	c.session = container.Get(static.DiDiscordSession).(*discordgo.Session)
 	c.db = container.Get(static.DiDatabase).(database.Database)
-	c.st = container.Get(static.DiState).(*dgrs.State)
+	c.st = container.Get(static.DiState).(dgrs.IState)
 
 	router.Get("/guilds/:guildid", c.getGuild)
 }
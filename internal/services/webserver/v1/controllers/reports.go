@@ -5,6 +5,7 @@ import (
 	"github.com/bwmarrin/snowflake"
 	"github.com/gofiber/fiber/v2"
 	"github.com/sarulabs/di/v2"
+	sharedmodels "github.com/zekroTJA/shinpuru/internal/models"
 	"github.com/zekroTJA/shinpuru/internal/services/config"
 	"github.com/zekroTJA/shinpuru/internal/services/database"
 	"github.com/zekroTJA/shinpuru/internal/services/permissions"
@@ -59,7 +60,12 @@ func (c *ReportsController) getReport(ctx *fiber.Ctx) (err error) {
 		return err
 	}
 
-	return ctx.JSON(models.ReportFromReport(rep, c.cfg.Config().WebServer.PublicAddr))
+	types, err := c.repSvc.GetReportTypes(rep.GuildID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(models.ReportFromReport(rep, c.cfg.Config().WebServer.PublicAddr, types))
 }
 
 // @Summary Revoke Report
@@ -73,6 +79,7 @@ func (c *ReportsController) getReport(ctx *fiber.Ctx) (err error) {
 // @Failure 400 {object} models.Error
 // @Failure 401 {object} models.Error
 // @Failure 404 {object} models.Error
+// @Failure 409 {object} models.Error "Is returned when the report has already been revoked."
 // @Router /reports/{id}/revoke [post]
 func (c *ReportsController) postRevoke(ctx *fiber.Ctx) (err error) {
 	uid := ctx.Locals("uid").(string)
@@ -110,8 +117,12 @@ func (c *ReportsController) postRevoke(ctx *fiber.Ctx) (err error) {
 		uid,
 		reason.Reason,
 		c.cfg.Config().WebServer.Addr,
+		sharedmodels.DefaultReportRevokeOptions,
 	)
 
+	if err == report.ErrReportRevoked {
+		return fiber.NewError(fiber.StatusConflict, err.Error())
+	}
 	if err != nil {
 		return err
 	}
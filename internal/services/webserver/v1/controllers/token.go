@@ -3,10 +3,12 @@ package controllers
 import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/sarulabs/di/v2"
+	sharedmodels "github.com/zekroTJA/shinpuru/internal/models"
 	"github.com/zekroTJA/shinpuru/internal/services/database"
 	"github.com/zekroTJA/shinpuru/internal/services/timeprovider"
 	"github.com/zekroTJA/shinpuru/internal/services/webserver/auth"
 	"github.com/zekroTJA/shinpuru/internal/services/webserver/v1/models"
+	"github.com/zekroTJA/shinpuru/internal/services/webserver/wsutil"
 	"github.com/zekroTJA/shinpuru/internal/util/static"
 )
 
@@ -22,8 +24,9 @@ func (c *TokenController) Setup(container di.Container, router fiber.Router) {
 	c.tp = container.Get(static.DiTimeProvider).(timeprovider.Provider)
 
 	router.Get("", c.getToken)
-	router.Post("", c.postToken)
-	router.Delete("", c.deleteToken)
+	router.Post("", confirmation(container), c.postToken)
+	router.Delete("", confirmation(container), c.deleteToken)
+	router.Get("/usage", c.getTokenUsage)
 }
 
 // @Summary API Token Info
@@ -60,6 +63,7 @@ func (c *TokenController) getToken(ctx *fiber.Ctx) error {
 // @Tags Tokens
 // @Accept json
 // @Produce json
+// @Param X-Confirmation-Token header string false "Confirmation token obtained via /ota/confirmation. Required if confirmation is enabled."
 // @Success 200 {object} models.APITokenResponse
 // @Failure 401 {object} models.Error
 // @Router /token [post]
@@ -83,6 +87,7 @@ func (c *TokenController) postToken(ctx *fiber.Ctx) error {
 // @Tags Tokens
 // @Accept json
 // @Produce json
+// @Param X-Confirmation-Token header string false "Confirmation token obtained via /ota/confirmation. Required if confirmation is enabled."
 // @Success 200 {object} models.Status
 // @Failure 401 {object} models.Error
 // @Router /token [delete]
@@ -96,3 +101,32 @@ func (c *TokenController) deleteToken(ctx *fiber.Ctx) error {
 
 	return ctx.JSON(models.Ok)
 }
+
+// @Summary API Token Usage
+// @Description Returns the recent requests authenticated with the API token ordered from the oldest to the newest. Only the last requests are kept and the history is reset when the token is regenerated or revoked.
+// @Tags Tokens
+// @Accept json
+// @Produce json
+// @Param limit query int false "The amount of returned entries (0 = all)" default(0)
+// @Param offset query int false "The amount of entries to be skipped." default(0)
+// @Success 200 {array} sharedmodels.APITokenUsageEntry "Wrapped in models.ListResponse with pagination"
+// @Failure 401 {object} models.Error
+// @Failure 404 {object} models.Error "Is returned when no token was generated before."
+// @Router /token/usage [get]
+func (c *TokenController) getTokenUsage(ctx *fiber.Ctx) error {
+	uid := ctx.Locals("uid").(string)
+
+	limit, offset, err := wsutil.GetQueryPagination(ctx, 0, 0)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.db.GetAPIToken(uid)
+	if database.IsErrDatabaseNotFound(err) {
+		return fiber.NewError(fiber.StatusNotFound, "no token found")
+	} else if err != nil {
+		return err
+	}
+
+	return ctx.JSON(models.PaginateList[sharedmodels.APITokenUsageEntry](c.apith.GetUsage(uid), limit, offset))
+}
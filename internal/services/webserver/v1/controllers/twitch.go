@@ -0,0 +1,55 @@
+package controllers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/sarulabs/di/v2"
+	"github.com/zekroTJA/shinpuru/internal/services/config"
+	"github.com/zekroTJA/shinpuru/internal/services/kvcache"
+	mw "github.com/zekroTJA/shinpuru/internal/services/webserver/middleware"
+	_ "github.com/zekroTJA/shinpuru/internal/services/webserver/v1/models"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/pkg/twitchnotify"
+)
+
+type TwitchController struct {
+	tnw *twitchnotify.NotifyWorker
+}
+
+func (c *TwitchController) Setup(container di.Container, router fiber.Router) {
+	cfg := container.Get(static.DiConfig).(config.Provider)
+	kvc := container.Get(static.DiKVCache).(kvcache.Provider)
+	c.tnw = container.Get(static.DiTwitchNotifyWorker).(*twitchnotify.NotifyWorker)
+
+	router.Post("/eventsub",
+		mw.NewWebhookAuth(mw.WebhookSchemeTwitch, cfg.Config().WebServer.WebhookSecrets["twitch"], kvc),
+		c.postEventSub)
+}
+
+// @Summary Twitch EventSub Callback
+// @Description Receives Twitch EventSub webhook messages. The signature of the message is verified using the configured twitch webhook secret.
+// @Tags Twitch
+// @Accept json
+// @Produce plain
+// @Success 200 {string} string "The challenge on callback verification."
+// @Success 204
+// @Failure 400 {object} models.Error
+// @Failure 401 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Router /twitch/eventsub [post]
+func (c *TwitchController) postEventSub(ctx *fiber.Ctx) error {
+	if c.tnw == nil || !c.tnw.UsesEventSub() {
+		return fiber.ErrNotFound
+	}
+
+	res, err := c.tnw.HandleEventSub(ctx.Get(twitchnotify.HeaderEventSubMessageType), ctx.Body())
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if res == "" {
+		return ctx.SendStatus(fiber.StatusNoContent)
+	}
+
+	ctx.Set(fiber.HeaderContentType, fiber.MIMETextPlain)
+	return ctx.SendString(res)
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/zekroTJA/shinpuru/internal/services/guildlog"
 	"github.com/zekroTJA/shinpuru/internal/services/permissions"
 	"github.com/zekroTJA/shinpuru/internal/services/webserver/v1/models"
+	"github.com/zekroTJA/shinpuru/internal/services/webserver/wsutil"
 	"github.com/zekroTJA/shinpuru/internal/util/modnot"
 	"github.com/zekroTJA/shinpuru/internal/util/snowflakenodes"
 	"github.com/zekroTJA/shinpuru/internal/util/static"
@@ -26,7 +27,7 @@ type UnbanrequestsController struct {
 	session *discordgo.Session
 	db      database.Database
 	pmw     *permissions.Permissions
-	st      *dgrs.State
+	st      dgrs.IState
 	cfg     config.Provider
 	gl      guildlog.Logger
 }
@@ -35,7 +36,7 @@ func (c *UnbanrequestsController) Setup(container di.Container, router fiber.Rou
 	c.session = container.Get(static.DiDiscordSession).(*discordgo.Session)
 	c.db = container.Get(static.DiDatabase).(database.Database)
 	c.pmw = container.Get(static.DiPermissions).(*permissions.Permissions)
-	c.st = container.Get(static.DiState).(*dgrs.State)
+	c.st = container.Get(static.DiState).(dgrs.IState)
 	c.cfg = container.Get(static.DiConfig).(config.Provider)
 	c.gl = container.Get(static.DiGuildLog).(guildlog.Logger)
 
@@ -105,10 +106,7 @@ func (c *UnbanrequestsController) postUnbanrequests(ctx *fiber.Ctx) error {
 	}
 
 	req := new(sharedmodels.UnbanRequest)
-	if err := ctx.BodyParser(req); err != nil {
-		return err
-	}
-	if err := req.Validate(); err != nil {
+	if err := wsutil.ParseAndValidate(ctx, req); err != nil {
 		return err
 	}
 
@@ -16,14 +16,14 @@ type UsersController struct {
 	session *discordgo.Session
 	cfg     config.Provider
 	authMw  auth.Middleware
-	st      *dgrs.State
+	st      dgrs.IState
 }
 
 func (c *UsersController) Setup(container di.Container, router fiber.Router) {
 	c.session = container.Get(static.DiDiscordSession).(*discordgo.Session)
 	c.cfg = container.Get(static.DiConfig).(config.Provider)
 	c.authMw = container.Get(static.DiAuthMiddleware).(auth.Middleware)
-	c.st = container.Get(static.DiState).(*dgrs.State)
+	c.st = container.Get(static.DiState).(dgrs.IState)
 
 	router.Get(":id", c.getUser)
 }
@@ -15,21 +15,21 @@ import (
 type UsersettingsController struct {
 	session *discordgo.Session
 	db      database.Database
-	state   *dgrs.State
+	state   dgrs.IState
 	pmw     *permissions.Permissions
 }
 
 func (c *UsersettingsController) Setup(container di.Container, router fiber.Router) {
 	c.session = container.Get(static.DiDiscordSession).(*discordgo.Session)
 	c.db = container.Get(static.DiDatabase).(database.Database)
-	c.state = container.Get(static.DiState).(*dgrs.State)
+	c.state = container.Get(static.DiState).(dgrs.IState)
 	c.pmw = container.Get(static.DiPermissions).(*permissions.Permissions)
 
 	router.Get("/ota", c.getOTA)
 	router.Post("/ota", c.postOTA)
 	router.Get("/privacy", c.getPrivacy)
 	router.Post("/privacy", c.postPrivacy)
-	router.Post("/flush", c.postFlush)
+	router.Post("/flush", confirmation(container), c.postFlush)
 }
 
 // @Summary Get OTA Usersettings State
@@ -138,6 +138,7 @@ func (c *UsersettingsController) postPrivacy(ctx *fiber.Ctx) error {
 // @Tags User Settings
 // @Accept json
 // @Produce json
+// @Param X-Confirmation-Token header string false "Confirmation token obtained via /ota/confirmation. Required if confirmation is enabled."
 // @Success 200 {object} models.UsersettingsOTA
 // @Failure 400 {object} models.Error
 // @Failure 401 {object} models.Error
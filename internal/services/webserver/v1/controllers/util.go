@@ -1,18 +1,24 @@
 package controllers
 
 import (
+	"fmt"
+	"image/color"
 	"strconv"
 	"strings"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/gofiber/fiber/v2"
 	"github.com/sarulabs/di/v2"
+	"github.com/zekroTJA/shinpuru/internal/services/codeexec"
 	"github.com/zekroTJA/shinpuru/internal/services/config"
+	"github.com/zekroTJA/shinpuru/internal/services/timeprovider"
 	"github.com/zekroTJA/shinpuru/internal/services/webserver/v1/models"
 	"github.com/zekroTJA/shinpuru/internal/util"
 	"github.com/zekroTJA/shinpuru/internal/util/static"
 	"github.com/zekroTJA/shinpuru/pkg/colors"
+	"github.com/zekroTJA/shinpuru/pkg/discordutil"
 	"github.com/zekroTJA/shinpuru/pkg/etag"
+	"github.com/zekroTJA/shinpuru/pkg/timeutil"
 	"github.com/zekrotja/dgrs"
 	"github.com/zekrotja/ken"
 )
@@ -21,20 +27,26 @@ type UtilController struct {
 	session    *discordgo.Session
 	cfg        config.Provider
 	cmdHandler *ken.Ken
-	st         *dgrs.State
+	st         dgrs.IState
+	cef        codeexec.Factory
+	tp         timeprovider.Provider
 }
 
 func (c *UtilController) Setup(container di.Container, router fiber.Router) {
 	c.session = container.Get(static.DiDiscordSession).(*discordgo.Session)
 	c.cfg = container.Get(static.DiConfig).(config.Provider)
 	c.cmdHandler = container.Get(static.DiCommandHandler).(*ken.Ken)
-	c.st = container.Get(static.DiState).(*dgrs.State)
+	c.st = container.Get(static.DiState).(dgrs.IState)
+	c.cef = container.Get(static.DiCodeExecFactory).(codeexec.Factory)
+	c.tp = container.Get(static.DiTimeProvider).(timeprovider.Provider)
 
 	router.Get("/landingpageinfo", c.getLandingPageInfo)
 	router.Get("/color/:hexcode", c.getColor)
+	router.Get("/snowflake/:id", c.getSnowflake)
 	router.Get("/commands", c.getSlashCommands)
 	router.Get("/slashcommands", c.getSlashCommands)
 	router.Get("/updateinfo", c.getUpdateInfo)
+	router.Get("/codeexec/languages", c.getCodeExecLanguages)
 }
 
 // @Summary Landing Page Info
@@ -67,13 +79,19 @@ func (c *UtilController) getLandingPageInfo(ctx *fiber.Ctx) error {
 }
 
 // @Summary Color Generator
-// @Description Produces a square image of the given color and size.
+// @Description Produces an image of the given color and size. The hex code may contain an alpha value (e.g. `8e0cf280`); transparent images are returned as PNG with an alpha channel.
 // @Param hexcode path string true "Hex Code of the Color to produce"
-// @Param size query int false "The dimension of the square image" default(24)
+// @Param size query string false "The dimension of the square image or the dimensions as WIDTHxHEIGHT" default(24)
+// @Param alpha query int false "The opacity of the color in range [0..255], overwriting the alpha value of the hex code"
+// @Param border query string false "Hex Code of the color of a border drawn around the image"
+// @Param borderwidth query int false "The width of the border in pixels" default(1)
+// @Param to query string false "Hex Code of a second color; when set, an animated GIF transitioning from the first to the second color is produced"
+// @Param frames query int false "The number of frames of the animated gradient" default(20)
 // @Tags Utilities
 // @Accept json
 // @Produce image/png
-// @Success 200 {file} png image data
+// @Produce image/gif
+// @Success 200 {file} png or gif image data
 // @Router /util/color/{hexcode} [get]
 func (c *UtilController) getColor(ctx *fiber.Ctx) error {
 	hexcode := ctx.Params("hexcode")
@@ -107,26 +125,109 @@ func (c *UtilController) getColor(ctx *fiber.Ctx) error {
 	}
 
 	clr, err := colors.FromHex(hexcode)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid hex code")
+	}
+
+	if alpha := ctx.Query("alpha"); alpha != "" {
+		a, err := strconv.Atoi(alpha)
+		if err != nil || a < 0 || a > 255 {
+			return fiber.NewError(fiber.StatusBadRequest, "invalid alpha parameter; value must be in range [0..255]")
+		}
+		clr.A = uint8(a)
+	}
+
+	if to := ctx.Query("to"); to != "" {
+		return c.getColorGradient(ctx, clr, to, xSize, ySize)
+	}
+
+	var opts colors.ImageOptions
+	if border := ctx.Query("border"); border != "" {
+		if opts.BorderColor, err = colors.FromHex(border); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "invalid border hex code")
+		}
+		if opts.BorderWidth, err = strconv.Atoi(ctx.Query("borderwidth", "1")); err != nil || opts.BorderWidth < 1 {
+			return fiber.NewError(fiber.StatusBadRequest, "invalid borderwidth parameter; value must be a positive integer")
+		}
+	}
+
+	buff, err := colors.CreateImage(clr, xSize, ySize, opts)
 	if err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
 	}
 
-	buff, err := colors.CreateImage(clr, xSize, ySize)
+	return c.sendImage(ctx, buff.Bytes(), "image/png")
+}
+
+func (c *UtilController) getColorGradient(ctx *fiber.Ctx, from *color.RGBA, toHex string, xSize, ySize int) error {
+	to, err := colors.FromHex(toHex)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid to hex code")
+	}
+
+	if xSize != ySize || xSize > colors.MaxGradientSize {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf(
+			"invalid size parameter; gradients must be square with a size in range [1..%d]", colors.MaxGradientSize))
+	}
+
+	frames, err := strconv.Atoi(ctx.Query("frames", "20"))
+	if err != nil || frames < 2 || frames > colors.MaxGradientFrames {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf(
+			"invalid frames parameter; value must be in range [2..%d]", colors.MaxGradientFrames))
+	}
+
+	buff, err := colors.CreateGradientGIF(from, to, frames, xSize)
 	if err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
 	}
 
-	data := buff.Bytes()
+	return c.sendImage(ctx, buff.Bytes(), "image/gif")
+}
 
+func (c *UtilController) sendImage(ctx *fiber.Ctx, data []byte, contentType string) error {
 	etag := etag.Generate(data, false)
 
-	ctx.Context().SetContentType("image/png")
+	ctx.Context().SetContentType(contentType)
 	// 365 days browser caching
 	ctx.Set("Cache-Control", "public, max-age=31536000, immutable")
 	ctx.Set("ETag", etag)
 	return ctx.Send(data)
 }
 
+// @Summary Snowflake Creation Time
+// @Description Returns the creation time and the age of the given Discord snowflake ID.
+// @Param id path string true "The Discord snowflake ID"
+// @Tags Utilities
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.SnowflakeResponse
+// @Failure 400 {object} models.Error
+// @Router /util/snowflake/{id} [get]
+func (c *UtilController) getSnowflake(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+
+	if sf, err := strconv.ParseUint(id, 10, 64); err != nil || sf == 0 {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid snowflake")
+	}
+
+	created, err := discordutil.GetDiscordSnowflakeCreationTime(id)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid snowflake")
+	}
+
+	age := c.tp.Now().Sub(created)
+	if age < 0 {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid snowflake: creation time is in the future")
+	}
+
+	return ctx.JSON(models.SnowflakeResponse{
+		ID:         id,
+		Created:    created,
+		Age:        timeutil.FormatAge(age),
+		AgeSeconds: int64(age.Seconds()),
+	})
+}
+
 // @Summary Slash Command List
 // @Description Returns a list of registered slash commands and their description.
 // @Tags Utilities
@@ -145,6 +246,22 @@ func (c *UtilController) getSlashCommands(ctx *fiber.Ctx) error {
 	return ctx.JSON(models.NewListResponse(res))
 }
 
+// @Summary Code Execution Languages
+// @Description Returns a list of languages supported by the code execution engine.
+// @Tags Utilities
+// @Accept json
+// @Produce json
+// @Success 200 {array} string "Wrapped in models.ListResponse"
+// @Router /util/codeexec/languages [get]
+func (c *UtilController) getCodeExecLanguages(ctx *fiber.Ctx) error {
+	langs, err := codeexec.SupportedLanguages(c.cef)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(models.NewListResponse(langs))
+}
+
 // @Summary Update Information
 // @Description Returns update information.
 // @Tags Utilities
@@ -1,20 +1,25 @@
 package models
 
 import (
-	"errors"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/bwmarrin/snowflake"
 	"github.com/golang-jwt/jwt/v4"
+	"github.com/zekroTJA/shinpuru/internal/middleware"
 	sharedmodels "github.com/zekroTJA/shinpuru/internal/models"
 	"github.com/zekroTJA/shinpuru/internal/services/backup/backupmodels"
 	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/services/kvcache"
 	permService "github.com/zekroTJA/shinpuru/internal/services/permissions"
 	"github.com/zekroTJA/shinpuru/internal/util/imgstore"
+	"github.com/zekroTJA/shinpuru/internal/util/sysinfo"
 	"github.com/zekroTJA/shinpuru/pkg/discordutil"
 	"github.com/zekroTJA/shinpuru/pkg/permissions"
+	"github.com/zekroTJA/shinpuru/pkg/validation"
+	"github.com/zekroTJA/shinpuru/pkg/validators"
 	"github.com/zekroTJA/shinpuru/pkg/versioncheck"
 	"github.com/zekrotja/ken"
 	"github.com/zekrotja/rogu/log"
@@ -36,20 +41,74 @@ type AccessTokenResponse struct {
 }
 
 type Error struct {
-	Error   string `json:"error"`
-	Code    int    `json:"code"`
-	Context string `json:"context,omitempty"`
+	Error   string                  `json:"error"`
+	Code    int                     `json:"code"`
+	Context string                  `json:"context,omitempty"`
+	Fields  []validation.FieldError `json:"fields,omitempty"`
 }
 
 // ListResponse wraps a list response object
 // with the list as Data and N as len(Data).
+//
+// Responses of paginated list endpoints also
+// contain the Pagination of the result.
 type ListResponse[T any] struct {
-	N    int `json:"n"`
-	Data []T `json:"data"`
+	N          int         `json:"n"`
+	Data       []T         `json:"data"`
+	Pagination *Pagination `json:"pagination,omitempty"`
+}
+
+// Pagination describes the window of a
+// paginated list response.
+//
+// Limit and Offset are the values passed with
+// the request, where a Limit of 0 selects all
+// remaining entries. Total is the total amount
+// of entries available. If there are entries
+// after the returned window, Next contains the
+// offset to request the following page.
+type Pagination struct {
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+	Total  int `json:"total"`
+	Next   int `json:"next,omitempty"`
 }
 
 func NewListResponse[T any](data []T) ListResponse[T] {
-	return ListResponse[T]{len(data), data}
+	return ListResponse[T]{N: len(data), Data: data}
+}
+
+// NewPagedListResponse returns a ListResponse of
+// data, which is the window of total entries
+// selected by limit and offset.
+func NewPagedListResponse[T any](data []T, limit, offset, total int) ListResponse[T] {
+	p := &Pagination{
+		Limit:  limit,
+		Offset: offset,
+		Total:  total,
+	}
+	if next := offset + len(data); len(data) > 0 && next < total {
+		p.Next = next
+	}
+
+	return ListResponse[T]{N: len(data), Data: data, Pagination: p}
+}
+
+// PaginateList returns a paged ListResponse of
+// the window of data selected by limit and offset.
+// This can be used for lists which are completely
+// held in memory.
+func PaginateList[T any](data []T, limit, offset int) ListResponse[T] {
+	start := offset
+	if start > len(data) {
+		start = len(data)
+	}
+	end := len(data)
+	if limit > 0 && start+limit < end {
+		end = start + limit
+	}
+
+	return NewPagedListResponse(data[start:end], limit, offset, len(data))
 }
 
 // User extends a discordgo.User as reponse
@@ -118,6 +177,24 @@ type Guild struct {
 	InviteBlockEnabled bool      `json:"invite_block_enabled"`
 }
 
+// GuildRole is a reduced guild role model
+// extended by the amount of members having
+// the role.
+type GuildRole struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Color       int    `json:"color"`
+	Position    int    `json:"position"`
+	Permissions int64  `json:"permissions,string"`
+	Managed     bool   `json:"managed"`
+	MemberCount int    `json:"member_count"`
+	// Manageable is false when the role can not be
+	// managed by shinpuru because it is managed by
+	// an integration or because it is positioned
+	// above or equal to shinpuru's highest role.
+	Manageable bool `json:"manageable"`
+}
+
 // GuildReduced is a Guild model with fewer
 // details than Guild model.
 type GuildReduced struct {
@@ -132,6 +209,18 @@ type GuildReduced struct {
 	OnlineMemberCount int       `json:"online_member_count,omitempty"`
 }
 
+// UserGuild is a GuildReduced model extended by
+// the permission level of a user on the guild.
+type UserGuild struct {
+	*GuildReduced
+
+	// Dominance is set like the Dominance of Member.
+	Dominance int `json:"dominance"`
+	// Moderator is true when the user has any
+	// moderation permission on the guild.
+	Moderator bool `json:"moderator"`
+}
+
 // PermissionsResponse wraps a
 // permissions.PermissionsArra as response
 // model.
@@ -150,6 +239,15 @@ type Report struct {
 	Victim   *FlatUser `json:"victim,omitempty"`
 }
 
+// ReportDetails extends Report with the notes
+// on the report victim, which are only included
+// if the requesting user may read them.
+type ReportDetails struct {
+	Report
+
+	Notes []sharedmodels.UserNote `json:"notes,omitempty"`
+}
+
 // GuildSettings is the response model for
 // guild settings and preferences.
 type GuildSettings struct {
@@ -157,12 +255,23 @@ type GuildSettings struct {
 	Perms               map[string]permissions.PermissionArray `json:"perms"`
 	AutoRoles           []string                               `json:"autoroles"`
 	ModLogChannel       string                                 `json:"modlogchannel"`
+	ModLogFormat        string                                 `json:"modlogformat"`
 	ModNotChannel       string                                 `json:"modnotchannel"`
 	VoiceLogChannel     string                                 `json:"voicelogchannel"`
 	JoinMessageChannel  string                                 `json:"joinmessagechannel"`
 	JoinMessageText     string                                 `json:"joinmessagetext"`
 	LeaveMessageChannel string                                 `json:"leavemessagechannel"`
 	LeaveMessageText    string                                 `json:"leavemessagetext"`
+	EmbedColor          string                                 `json:"embedcolor"`
+	AnnouncementChannel string                                 `json:"announcementchannel"`
+}
+
+// SettingsAuditEntry wraps a guild settings audit
+// entry with the resolved user who made the change.
+type SettingsAuditEntry struct {
+	sharedmodels.SettingsAuditEntry
+
+	Actor *FlatUser `json:"actor,omitempty"`
 }
 
 // PermissionsUpdate is the request model to
@@ -173,6 +282,62 @@ type PermissionsUpdate struct {
 	Override bool     `json:"override"`
 }
 
+// PermissionsPreviewMaxMembers is the maximum number
+// of members of a permissions preview.
+const PermissionsPreviewMaxMembers = 25
+
+// PermissionsPreviewRequest is the request model to
+// preview the effect of permission updates.
+type PermissionsPreviewRequest struct {
+	Updates []PermissionsUpdate `json:"updates"`
+	// MemberIDs are the members to preview. If empty,
+	// a sample of members with affected roles is used.
+	MemberIDs []string `json:"member_ids"`
+	// Check contains permission domain names which are
+	// checked for each member before and after the update.
+	Check []string `json:"check"`
+}
+
+// PermissionsPreviewRole contains the permission rules
+// of a role before and after the update.
+type PermissionsPreviewRole struct {
+	RoleID string                      `json:"role_id"`
+	Before permissions.PermissionArray `json:"before"`
+	After  permissions.PermissionArray `json:"after"`
+}
+
+// PermissionsPreviewCheck contains the result of a
+// permission check before and after the update.
+type PermissionsPreviewCheck struct {
+	Perm   string `json:"perm"`
+	Before bool   `json:"before"`
+	After  bool   `json:"after"`
+}
+
+// PermissionsPreviewMember contains the effective
+// permissions of a member before and after the update.
+type PermissionsPreviewMember struct {
+	User *FlatUser `json:"user"`
+	// Roles contains the IDs of the member's roles which
+	// have permission rules after the update.
+	Roles []string `json:"roles"`
+	// Override is true if the member is the guild owner
+	// or an admin and therefore gets the default admin
+	// rules and overrides explicit rules.
+	Override bool                        `json:"override"`
+	Before   permissions.PermissionArray `json:"before"`
+	After    permissions.PermissionArray `json:"after"`
+	Changed  bool                        `json:"changed"`
+	Checks   []PermissionsPreviewCheck   `json:"checks,omitempty"`
+}
+
+// PermissionsPreview is the response model of a
+// permissions preview.
+type PermissionsPreview struct {
+	Roles   []PermissionsPreviewRole   `json:"roles"`
+	Members []PermissionsPreviewMember `json:"members"`
+}
+
 // ReasonRequest is a request model wrapping a
 // Reason and Attachment URL.
 type ReasonRequest struct {
@@ -182,6 +347,49 @@ type ReasonRequest struct {
 	AttachmentData string     `json:"attachment_data"`
 }
 
+// MuteRequest is the request model to mute
+// a member.
+type MuteRequest struct {
+	ReasonRequest
+}
+
+// TempRoleRequest is the request model to assign
+// a role to a member until the given expiry.
+type TempRoleRequest struct {
+	RoleID  string    `json:"roleid"`
+	Expires time.Time `json:"expires"`
+}
+
+// UserNoteRequest is the request model to add a
+// note to a member.
+type UserNoteRequest struct {
+	Content string `json:"content"`
+}
+
+// ModLogRoutes contains the default mod log channel
+// of a guild and the channels mod log entries of
+// specific actions are routed to.
+type ModLogRoutes struct {
+	Default string                               `json:"default"`
+	Routes  map[sharedmodels.ModLogAction]string `json:"routes"`
+}
+
+// ModLogRouteRequest is the request model to route
+// mod log entries of an action to a channel.
+type ModLogRouteRequest struct {
+	ChannelID string `json:"channelid"`
+}
+
+// AutoDeleteConfig is the request and response model
+// of the delays after which command responses are
+// deleted in a guild or a specific channel. The delays
+// are given in seconds.
+type AutoDeleteConfig struct {
+	ChannelID  string `json:"channelid"`
+	Delay      int    `json:"delay"`
+	ErrorDelay int    `json:"errordelay"`
+}
+
 // ReportRequest extends ReasonRequest by
 // Type of report.
 type ReportRequest struct {
@@ -190,6 +398,55 @@ type ReportRequest struct {
 	Type sharedmodels.ReportType `json:"type"`
 }
 
+// ReportImportEntry is a single report to be
+// imported from another moderation bot.
+type ReportImportEntry struct {
+	ExternalID string    `json:"external_id"`
+	VictimID   string    `json:"victim_id"`
+	ExecutorID string    `json:"executor_id"`
+	Type       string    `json:"type"`
+	Reason     string    `json:"reason"`
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+// Validate returns the resolved report type when the
+// ReportImportEntry is valid. Otherwise, an error is
+// returned describing the invalid fields. The type is
+// resolved from the passed report types.
+func (e *ReportImportEntry) Validate(now time.Time, types sharedmodels.ReportTypeSet) (typ sharedmodels.ReportType, err error) {
+	var errs validation.Errors
+	errs.Assert(validators.IsInteger()(e.VictimID) == nil, "victim_id", "must be a valid user ID")
+	errs.Assert(validators.IsInteger()(e.ExecutorID) == nil, "executor_id", "must be a valid user ID")
+	errs.Assert(len(e.ExternalID) <= 64, "external_id", "must not be longer than 64 characters")
+	errs.Assert(strings.TrimSpace(e.Reason) != "", "reason", "must not be empty")
+	errs.Assert(!e.Timestamp.IsZero() && !e.Timestamp.After(now), "timestamp",
+		"must be set and must not be in the future")
+	// IDs can not be generated for timestamps before
+	// the snowflake epoch (Nov 04 2010).
+	errs.Assert(e.Timestamp.UnixMilli() >= snowflake.Epoch, "timestamp",
+		"must not be before Nov 04 2010")
+	if err = errs.Err(); err != nil {
+		return 0, err
+	}
+	return types.FromName(e.Type)
+}
+
+// ReportImportError describes why the entry at
+// Index of an import request was not imported.
+type ReportImportError struct {
+	Index      int    `json:"index"`
+	ExternalID string `json:"external_id,omitempty"`
+	Error      string `json:"error"`
+}
+
+// ReportImportResult is the response model of
+// a report import request.
+type ReportImportResult struct {
+	Imported int                 `json:"imported"`
+	Skipped  int                 `json:"skipped"`
+	Errors   []ReportImportError `json:"errors"`
+}
+
 // InviteSettingsRequest is the request model
 // for setting the global invite setting.
 type InviteSettingsRequest struct {
@@ -233,10 +490,10 @@ type SystemInfo struct {
 	Arch        string `json:"arch"`
 	CPUs        int    `json:"cpus"`
 	GoRoutines  int    `json:"go_routines"`
-	StackUse    uint64 `json:"stack_use"`
-	StackUseStr string `json:"stack_use_str"`
-	HeapUse     uint64 `json:"heap_use"`
-	HeapUseStr  string `json:"heap_use_str"`
+	StackUse    uint64 `json:"stack_use,omitempty"`
+	StackUseStr string `json:"stack_use_str,omitempty"`
+	HeapUse     uint64 `json:"heap_use,omitempty"`
+	HeapUseStr  string `json:"heap_use_str,omitempty"`
 
 	BotUserID string `json:"bot_user_id"`
 	BotInvite string `json:"bot_invite"`
@@ -302,6 +559,9 @@ type KarmaSettings struct {
 	EmotesDecrease []string `json:"emotes_decrease"`
 	Tokens         int      `json:"tokens"`
 	Penalty        bool     `json:"penalty"`
+	// Limits is left unchanged on update when
+	// it is not set.
+	Limits *sharedmodels.KarmaLimits `json:"limits,omitempty"`
 }
 
 // AntiraidSettings wraps settings properties for
@@ -313,6 +573,41 @@ type AntiraidSettings struct {
 	Verification       bool `json:"verification"`
 }
 
+// GuildCommandSettings wraps the states of all
+// commands on a guild and the message responded
+// when a disabled command is used.
+type GuildCommandSettings struct {
+	Commands []*GuildCommandState `json:"commands"`
+	Message  string               `json:"message"`
+	Silent   bool                 `json:"silent"`
+}
+
+// GuildCommandState wraps the name and
+// description of a command and whether it is
+// disabled on a guild. Essential commands can
+// not be disabled.
+type GuildCommandState struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Group       string `json:"group"`
+	Disabled    bool   `json:"disabled"`
+	Essential   bool   `json:"essential"`
+}
+
+// GuildCommandMessage is the request model to set
+// the message responded on disabled commands.
+type GuildCommandMessage struct {
+	Message string `json:"message"`
+	Silent  bool   `json:"silent"`
+}
+
+func (m *GuildCommandMessage) Validate() error {
+	var errs validation.Errors
+	errs.Assert(len(m.Message) <= middleware.MaxGuildDisabledMessageLength, "message",
+		fmt.Sprintf("must not be longer than %d characters", middleware.MaxGuildDisabledMessageLength))
+	return errs.Err()
+}
+
 type UsersettingsOTA struct {
 	Enabled bool `json:"enabled"`
 }
@@ -343,6 +638,13 @@ type FlushGuildRequest struct {
 	LeaveAfter bool   `json:"leave_after"`
 }
 
+// KarmaResetRequest is the request model to reset
+// the karma of all users of a guild. Validation
+// must match the name of the guild.
+type KarmaResetRequest struct {
+	Validation string `json:"validation"`
+}
+
 type SearchResult struct {
 	Guilds  []*GuildReduced `json:"guilds"`
 	Members []*Member       `json:"members"`
@@ -381,11 +683,53 @@ type CaptchaVerificationRequest struct {
 	Token string `json:"token"`
 }
 
+type MessagePreviewRequest struct {
+	Template string `json:"template"`
+}
+
+type MessagePreview struct {
+	Raw      string `json:"raw"`
+	Rendered string `json:"rendered"`
+}
+
+type TwitchNotifyPreviewRequest struct {
+	Template string `json:"template"`
+	RoleID   string `json:"role_id"`
+	Plain    bool   `json:"plain"`
+}
+
+type TwitchNotifyPreview struct {
+	MessagePreview
+	Embed bool `json:"embed"`
+}
+
+type NotificationSubscription struct {
+	Type           string   `json:"type"`
+	ChannelID      string   `json:"channel_id"`
+	TwitchUserID   string   `json:"twitch_user_id"`
+	TwitchUserName string   `json:"twitch_user_name,omitempty"`
+	Template       string   `json:"template,omitempty"`
+	RoleID         string   `json:"role_id,omitempty"`
+	Plain          bool     `json:"plain"`
+	Live           bool     `json:"live"`
+	Failing        bool     `json:"failing"`
+	Problems       []string `json:"problems"`
+}
+
+// BotNicknameSettings contains the configured nickname
+// of the bot in a guild. Warning is set if the nickname
+// could not be applied.
+type BotNicknameSettings struct {
+	Nickname string `json:"nickname"`
+	Warning  string `json:"warning,omitempty"`
+}
+
 type CodeExecSettings struct {
 	EnableStatus
 
 	Type                string   `json:"type"`
 	TypesOptions        []string `json:"types_options,omitempty"`
+	AllowedLanguages    []string `json:"allowed_languages"`
 	JdoodleClientId     string   `json:"jdoodle_clientid,omitempty"`
 	JdoodleClientSecret string   `json:"jdoodle_clientsecret,omitempty"`
 }
@@ -394,6 +738,35 @@ type PushCodeRequest struct {
 	Code string `json:"code"`
 }
 
+type BroadcastRequest struct {
+	Content string `json:"content"`
+	// Force sends the broadcast even if a broadcast
+	// with the same content has been sent before.
+	Force bool `json:"force"`
+}
+
+type ListenerStateRequest struct {
+	Enabled bool `json:"enabled"`
+}
+
+type CacheInfo struct {
+	kvcache.Stats
+
+	Name      string `json:"name"`
+	Available bool   `json:"available"`
+}
+
+type CacheFlushResult struct {
+	Flushed int `json:"flushed"`
+}
+
+type SnowflakeResponse struct {
+	ID         string    `json:"id"`
+	Created    time.Time `json:"created"`
+	Age        string    `json:"age"`
+	AgeSeconds int64     `json:"age_seconds"`
+}
+
 type UpdateInfoResponse struct {
 	Current    versioncheck.Semver `json:"current"`
 	CurrentStr string              `json:"current_str"`
@@ -402,6 +775,29 @@ type UpdateInfoResponse struct {
 	IsOld      bool                `json:"isold"`
 }
 
+// TwitchCredentialsErrorType identifies the reason
+// why a Twitch credentials test failed.
+type TwitchCredentialsErrorType string
+
+const (
+	TwitchCredentialsNotConfigured TwitchCredentialsErrorType = "not_configured"
+	TwitchCredentialsAuth          TwitchCredentialsErrorType = "auth"
+	TwitchCredentialsNetwork       TwitchCredentialsErrorType = "network"
+)
+
+type TwitchCredentialsTestResult struct {
+	OK        bool                       `json:"ok"`
+	ErrorType TwitchCredentialsErrorType `json:"error_type,omitempty"`
+	Error     string                     `json:"error,omitempty"`
+	RateLimit *TwitchRateLimit           `json:"rate_limit,omitempty"`
+}
+
+type TwitchRateLimit struct {
+	Limit     int       `json:"limit"`
+	Remaining int       `json:"remaining"`
+	Reset     time.Time `json:"reset"`
+}
+
 type RichUnbanRequest struct {
 	sharedmodels.UnbanRequest
 
@@ -409,19 +805,128 @@ type RichUnbanRequest struct {
 	Processor *FlatUser `json:"processor"`
 }
 
-// Validate returns true, when the ReasonRequest is valid.
-// Otherwise, false is returned and an error response is
-// returned.
-func (req *ReasonRequest) Validate(acceptEmptyReason bool) (bool, error) {
-	if !acceptEmptyReason && len(req.Reason) < 3 {
-		return false, errors.New("invalid argument")
+// Validate returns validation.Errors when the reason
+// is shorter than 3 characters or the attachment is
+// not a valid URL to a supported file.
+func (req *ReasonRequest) Validate() error {
+	var errs validation.Errors
+	errs.Assert(len(req.Reason) >= 3, "reason", "must be at least 3 characters long")
+	req.validateAttachment(&errs)
+	return errs.Err()
+}
+
+func (req *ReasonRequest) validateAttachment(errs *validation.Errors) {
+	errs.Assert(req.Attachment == "" || imgstore.ImgUrlSRx.MatchString(req.Attachment), "attachment",
+		"must be a valid url to a file with type of png, jpg, jpeg, gif, ico, tiff, img, bmp or mp4")
+}
+
+// Validate returns validation.Errors when the reason
+// request is invalid.
+func (req *ReportRequest) Validate() error {
+	if req.ReasonRequest == nil {
+		return (&ReasonRequest{}).Validate()
+	}
+	return req.ReasonRequest.Validate()
+}
+
+// Validate returns validation.Errors when the attachment
+// is not a valid URL to a supported file. In contrast to
+// other reason requests, the reason may be empty.
+func (req *MuteRequest) Validate() error {
+	var errs validation.Errors
+	req.validateAttachment(&errs)
+	return errs.Err()
+}
+
+// Validate returns validation.Errors when the role ID
+// or the expiry is not set.
+func (req *TempRoleRequest) Validate() error {
+	var errs validation.Errors
+	errs.Assert(req.RoleID != "", "roleid", "must not be empty")
+	errs.Assert(!req.Expires.IsZero(), "expires", "must be set")
+	return errs.Err()
+}
+
+// Validate returns validation.Errors when the
+// content is not a valid note content.
+func (req *UserNoteRequest) Validate() error {
+	note := sharedmodels.UserNote{Content: req.Content}
+	return note.Validate()
+}
+
+// Validate returns validation.Errors when the
+// channel ID is not set.
+func (req *ModLogRouteRequest) Validate() error {
+	var errs validation.Errors
+	errs.Assert(req.ChannelID != "", "channelid", "must not be empty")
+	return errs.Err()
+}
+
+// Validate returns validation.Errors when any of the
+// delays is negative or exceeds the maximum delay.
+func (req *AutoDeleteConfig) Validate() error {
+	var errs validation.Errors
+	max := int(sharedmodels.AutoDeleteMaxDelay / time.Second)
+	errs.Assert(req.Delay >= 0 && req.Delay <= max, "delay",
+		fmt.Sprintf("must be in range of 0 and %d", max))
+	errs.Assert(req.ErrorDelay >= 0 && req.ErrorDelay <= max, "errordelay",
+		fmt.Sprintf("must be in range of 0 and %d", max))
+	return errs.Err()
+}
+
+// Validate returns validation.Errors when the permission
+// is not prefixed with '+' or '-' or is not part of the
+// domains which can be granted to roles.
+func (req *PermissionsUpdate) Validate() error {
+	var errs validation.Errors
+
+	if len(req.Perm) < 2 || (req.Perm[0] != '+' && req.Perm[0] != '-') {
+		errs.Add("perm", "must be a permission prefixed with '+' or '-'")
+	} else if sperm := req.Perm[1:]; !strings.HasPrefix(sperm, "sp.guild") &&
+		!strings.HasPrefix(sperm, "sp.etc") && !strings.HasPrefix(sperm, "sp.chat") {
+		errs.Add("perm", "you can only give permissions over the domains 'sp.guild', 'sp.etc' and 'sp.chat'")
+	}
+
+	return errs.Err()
+}
+
+// Validate returns validation.Errors when no update is
+// passed, any of the updates is invalid or too many
+// members are requested.
+func (req *PermissionsPreviewRequest) Validate() error {
+	var errs validation.Errors
+
+	errs.Assert(len(req.Updates) != 0, "updates", "must not be empty")
+	for i, u := range req.Updates {
+		for _, fe := range validation.FieldErrors(u.Validate()) {
+			errs.Add(fmt.Sprintf("updates[%d].%s", i, fe.Field), fe.Message)
+		}
 	}
+	errs.Assert(len(req.MemberIDs) <= PermissionsPreviewMaxMembers, "member_ids",
+		fmt.Sprintf("must not contain more than %d members", PermissionsPreviewMaxMembers))
+
+	return errs.Err()
+}
 
-	if req.Attachment != "" && !imgstore.ImgUrlSRx.MatchString(req.Attachment) {
-		return false, fmt.Errorf("attachment must be a valid url to a file with type of png, jpg, jpeg, gif, ico, tiff, img, bmp or mp4.")
+// AutoDeleteConfigFromConfig returns an AutoDeleteConfig
+// model from the passed sharedmodels.AutoDeleteConfig.
+func AutoDeleteConfigFromConfig(cfg sharedmodels.AutoDeleteConfig) AutoDeleteConfig {
+	return AutoDeleteConfig{
+		ChannelID:  cfg.ChannelID,
+		Delay:      int(cfg.Delay / time.Second),
+		ErrorDelay: int(cfg.ErrorDelay / time.Second),
 	}
+}
 
-	return true, nil
+// ToConfig returns the sharedmodels.AutoDeleteConfig of
+// the given guild from the request.
+func (req *AutoDeleteConfig) ToConfig(guildID string) sharedmodels.AutoDeleteConfig {
+	return sharedmodels.AutoDeleteConfig{
+		GuildID:    guildID,
+		ChannelID:  req.ChannelID,
+		Delay:      time.Duration(req.Delay) * time.Second,
+		ErrorDelay: time.Duration(req.ErrorDelay) * time.Second,
+	}
 }
 
 // GuildFromGuild returns a Guild model from the passed
@@ -523,6 +1028,35 @@ func GuildReducedFromGuild(g *discordgo.Guild) *GuildReduced {
 	}
 }
 
+// SystemInfoFromInfo returns a SystemInfo response
+// model from the passed system information.
+func SystemInfoFromInfo(i *sysinfo.Info) *SystemInfo {
+	uptime := int64(i.Uptime.Seconds())
+	return &SystemInfo{
+		Version:    i.Version,
+		CommitHash: i.CommitHash,
+		BuildDate:  i.BuildDate,
+		GoVersion:  i.GoVersion,
+
+		Uptime:    uptime,
+		UptimeStr: fmt.Sprintf("%d", uptime),
+
+		OS:          i.OS,
+		Arch:        i.Arch,
+		CPUs:        i.CPUs,
+		GoRoutines:  i.GoRoutines,
+		StackUse:    i.StackUse,
+		StackUseStr: fmt.Sprintf("%d", i.StackUse),
+		HeapUse:     i.HeapUse,
+		HeapUseStr:  fmt.Sprintf("%d", i.HeapUse),
+
+		BotUserID: i.BotUserID,
+		BotInvite: i.BotInvite,
+
+		Guilds: i.Guilds,
+	}
+}
+
 // MemberFromMember returns a Member from the passed
 // discordgo.Member m.
 func MemberFromMember(m *discordgo.Member) *Member {
@@ -540,9 +1074,10 @@ func MemberFromMember(m *discordgo.Member) *Member {
 
 // ReportFromReport returns a Report from the passed
 // models.Report r and publicAddr to generate an
-// attachment URL.
-func ReportFromReport(r sharedmodels.Report, publicAddr string) Report {
-	rtype := sharedmodels.ReportTypes[r.Type]
+// attachment URL. types is used to resolve the
+// name of custom report types.
+func ReportFromReport(r sharedmodels.Report, publicAddr string, types sharedmodels.ReportTypeSet) Report {
+	rtype := types.Name(r.Type)
 	r.AttachmentURL = imgstore.GetLink(r.AttachmentURL, publicAddr)
 	return Report{
 		Report:   r,
@@ -0,0 +1,164 @@
+package models
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	sharedmodels "github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/pkg/validation"
+)
+
+func fields(err error) []string {
+	var res []string
+	for _, fe := range validation.FieldErrors(err) {
+		res = append(res, fe.Field)
+	}
+	return res
+}
+
+func TestReasonRequestValidate(t *testing.T) {
+	req := &ReasonRequest{Reason: "spam"}
+	assert.Nil(t, req.Validate())
+
+	req.Attachment = "https://example.com/image.png"
+	assert.Nil(t, req.Validate())
+
+	req = &ReasonRequest{Reason: "ab", Attachment: "https://example.com/file.exe"}
+	assert.Equal(t, []string{"reason", "attachment"}, fields(req.Validate()))
+}
+
+func TestReportRequestValidate(t *testing.T) {
+	req := &ReportRequest{ReasonRequest: &ReasonRequest{Reason: "spam"}}
+	assert.Nil(t, req.Validate())
+
+	req = &ReportRequest{}
+	assert.Equal(t, []string{"reason"}, fields(req.Validate()))
+}
+
+func TestMuteRequestValidate(t *testing.T) {
+	req := &MuteRequest{}
+	assert.Nil(t, req.Validate())
+
+	req.Attachment = "not a url"
+	assert.Equal(t, []string{"attachment"}, fields(req.Validate()))
+}
+
+func TestTempRoleRequestValidate(t *testing.T) {
+	req := &TempRoleRequest{RoleID: "role", Expires: time.Now()}
+	assert.Nil(t, req.Validate())
+
+	req = &TempRoleRequest{}
+	assert.Equal(t, []string{"roleid", "expires"}, fields(req.Validate()))
+}
+
+func TestAutoDeleteConfigValidate(t *testing.T) {
+	req := &AutoDeleteConfig{Delay: 10, ErrorDelay: 0}
+	assert.Nil(t, req.Validate())
+
+	req = &AutoDeleteConfig{Delay: -1, ErrorDelay: 15 * 60}
+	assert.Equal(t, []string{"delay", "errordelay"}, fields(req.Validate()))
+}
+
+func TestAutoDeleteConfigConversion(t *testing.T) {
+	req := &AutoDeleteConfig{ChannelID: "channel", Delay: 30, ErrorDelay: 120}
+	cfg := req.ToConfig("guild")
+	assert.Equal(t, sharedmodels.AutoDeleteConfig{
+		GuildID:    "guild",
+		ChannelID:  "channel",
+		Delay:      30 * time.Second,
+		ErrorDelay: 2 * time.Minute,
+	}, cfg)
+	assert.Equal(t, *req, AutoDeleteConfigFromConfig(cfg))
+}
+
+func TestModLogRouteRequestValidate(t *testing.T) {
+	req := &ModLogRouteRequest{ChannelID: "channel"}
+	assert.Nil(t, req.Validate())
+
+	req = &ModLogRouteRequest{}
+	assert.Equal(t, []string{"channelid"}, fields(req.Validate()))
+}
+
+func TestPermissionsUpdateValidate(t *testing.T) {
+	for _, perm := range []string{"+sp.guild.config.karma", "-sp.etc.ping", "+sp.chat.*"} {
+		req := &PermissionsUpdate{Perm: perm}
+		assert.Nil(t, req.Validate(), perm)
+	}
+
+	for _, perm := range []string{"", "+", "sp.guild.config.karma", "+sp.game.something", "-sp.*"} {
+		req := &PermissionsUpdate{Perm: perm}
+		assert.Equal(t, []string{"perm"}, fields(req.Validate()), perm)
+	}
+}
+
+func TestPermissionsPreviewRequestValidate(t *testing.T) {
+	req := &PermissionsPreviewRequest{
+		Updates: []PermissionsUpdate{{Perm: "+sp.guild.config.karma", RoleIDs: []string{"role"}}},
+	}
+	assert.Nil(t, req.Validate())
+
+	req = &PermissionsPreviewRequest{}
+	assert.Equal(t, []string{"updates"}, fields(req.Validate()))
+
+	req = &PermissionsPreviewRequest{
+		Updates: []PermissionsUpdate{
+			{Perm: "+sp.guild.config.karma"},
+			{Perm: "+sp.*"},
+		},
+		MemberIDs: make([]string, PermissionsPreviewMaxMembers+1),
+	}
+	assert.Equal(t, []string{"updates[1].perm", "member_ids"}, fields(req.Validate()))
+}
+
+func TestPaginateList(t *testing.T) {
+	data := []int{0, 1, 2, 3, 4}
+
+	res := PaginateList(data, 2, 0)
+	assert.Equal(t, []int{0, 1}, res.Data)
+	assert.Equal(t, 2, res.N)
+	assert.Equal(t, &Pagination{Limit: 2, Offset: 0, Total: 5, Next: 2}, res.Pagination)
+
+	res = PaginateList(data, 2, 4)
+	assert.Equal(t, []int{4}, res.Data)
+	assert.Equal(t, &Pagination{Limit: 2, Offset: 4, Total: 5}, res.Pagination)
+
+	res = PaginateList(data, 0, 1)
+	assert.Equal(t, []int{1, 2, 3, 4}, res.Data)
+	assert.Equal(t, &Pagination{Limit: 0, Offset: 1, Total: 5}, res.Pagination)
+
+	res = PaginateList(data, 2, 10)
+	assert.Empty(t, res.Data)
+	assert.Equal(t, &Pagination{Limit: 2, Offset: 10, Total: 5}, res.Pagination)
+}
+
+func TestReportImportEntryValidate(t *testing.T) {
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	e := &ReportImportEntry{
+		VictimID:   "123",
+		ExecutorID: "456",
+		Type:       "warn",
+		Reason:     "spam",
+		Timestamp:  now.Add(-time.Hour),
+	}
+	typ, err := e.Validate(now, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, sharedmodels.TypeWarn, typ)
+
+	e.Timestamp = now.Add(time.Hour)
+	_, err = e.Validate(now, nil)
+	assert.Equal(t, []string{"timestamp"}, fields(err))
+
+	e.Timestamp = time.Date(2009, 1, 1, 0, 0, 0, 0, time.UTC)
+	_, err = e.Validate(now, nil)
+	assert.Equal(t, []string{"timestamp"}, fields(err))
+}
+
+func TestGuildCommandMessageValidate(t *testing.T) {
+	req := &GuildCommandMessage{Message: "disabled"}
+	assert.Nil(t, req.Validate())
+
+	req.Message = strings.Repeat("a", 501)
+	assert.Equal(t, []string{"message"}, fields(req.Validate()))
+}
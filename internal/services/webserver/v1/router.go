@@ -3,7 +3,10 @@ package v1
 import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/sarulabs/di/v2"
+	"github.com/zekroTJA/shinpuru/internal/services/config"
+	"github.com/zekroTJA/shinpuru/internal/services/kvcache"
 	"github.com/zekroTJA/shinpuru/internal/services/webserver/auth"
+	mw "github.com/zekroTJA/shinpuru/internal/services/webserver/middleware"
 	"github.com/zekroTJA/shinpuru/internal/services/webserver/v1/controllers"
 	"github.com/zekroTJA/shinpuru/internal/util/embedded"
 	"github.com/zekroTJA/shinpuru/internal/util/static"
@@ -33,6 +36,9 @@ func (r *Router) SetContainer(container di.Container) {
 // @Tag.Name OTA
 // @tag.Description One Time Auth token endpoints.
 //
+// @Tag.Name Twitch
+// @tag.Description Twitch integration endpoints.
+//
 // @Tag.Name Public
 // @tag.Description Public API endpoints.
 //
@@ -84,16 +90,18 @@ func (r *Router) Route(router fiber.Router) {
 	}
 
 	new(controllers.EtcController).Setup(r.container, router)
-	new(controllers.UtilController).Setup(r.container, router.Group("/util"))
-	new(controllers.AuthController).Setup(r.container, router.Group("/auth"))
-	new(controllers.OTAController).Setup(r.container, router.Group("/ota"))
-	new(controllers.PublicController).Setup(r.container, router.Group("/public"))
+	new(controllers.UtilController).Setup(r.container, router.Group("/util", r.rateLimit("util")))
+	new(controllers.AuthController).Setup(r.container, router.Group("/auth", r.rateLimit("auth")))
+	new(controllers.OTAController).Setup(r.container, router.Group("/ota", r.rateLimit("ota")))
+	new(controllers.PublicController).Setup(r.container, router.Group("/public", r.rateLimit("public")))
+	new(controllers.TwitchController).Setup(r.container, router.Group("/twitch", r.rateLimit("twitch")))
 
-	router.Get("/stack", func(ctx *fiber.Ctx) error { return ctx.JSON(ctx.App().Stack()) })
+	router.Get("/stack", r.rateLimit("etc"), func(ctx *fiber.Ctx) error { return ctx.JSON(ctx.App().Stack()) })
 
 	// --- REQUIRES ACCESS TOKEN AUTH ---
 
 	router.Use(authMw.Handle)
+	router.Use(r.rateLimit("api"))
 
 	new(controllers.SearchController).Setup(r.container, router.Group("/search"))
 	new(controllers.TokenController).Setup(r.container, router.Group("/token"))
@@ -109,4 +117,16 @@ func (r *Router) Route(router fiber.Router) {
 	new(controllers.UsersettingsController).Setup(r.container, router.Group("/usersettings"))
 	new(controllers.UnbanrequestsController).Setup(r.container, router.Group("/unbanrequests"))
 	new(controllers.VerificationController).Setup(r.container, router.Group("/verification"))
+	new(controllers.BroadcastsController).Setup(r.container, router.Group("/broadcasts"))
+	new(controllers.ListenersController).Setup(r.container, router.Group("/listeners"))
+	new(controllers.CachesController).Setup(r.container, router.Group("/caches"))
+	new(controllers.MessageSenderController).Setup(r.container, router.Group("/messagesender"))
+}
+
+// rateLimit returns a rate limiter middleware for the
+// given route group.
+func (r *Router) rateLimit(group string) fiber.Handler {
+	cfg := r.container.Get(static.DiConfig).(config.Provider)
+	kvc := r.container.Get(static.DiKVCache).(kvcache.Provider)
+	return mw.NewRateLimitGroup(cfg, kvc, group)
 }
@@ -3,10 +3,8 @@ package webserver
 import (
 	"errors"
 	"fmt"
-	"time"
 
 	"github.com/gofiber/fiber/v2"
-	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/gofiber/fiber/v2/middleware/etag"
 	"github.com/gofiber/fiber/v2/middleware/filesystem"
 	"github.com/sarulabs/di/v2"
@@ -18,9 +16,13 @@ import (
 	"github.com/zekroTJA/shinpuru/internal/services/webserver/wsutil"
 	"github.com/zekroTJA/shinpuru/internal/util/embedded"
 	"github.com/zekroTJA/shinpuru/internal/util/static"
-	"github.com/zekroTJA/shinpuru/pkg/limiter"
+	"github.com/zekroTJA/shinpuru/pkg/validation"
 )
 
+// defaultBodyLimit is the maximum request body size
+// in bytes used when no limit is configured.
+const defaultBodyLimit = 1024 * 1024
+
 // WebServer provides a REST API and static
 // web server service.
 type WebServer struct {
@@ -36,48 +38,42 @@ func New(container di.Container) (ws *WebServer, err error) {
 	ws.container = container
 	ws.cfg = container.Get(static.DiConfig).(config.Provider)
 
+	bodyLimit := mw.BodyLimitOptions{
+		Limit:  ws.cfg.Config().WebServer.BodyLimit.MaxBytes,
+		Routes: ws.cfg.Config().WebServer.BodyLimit.Routes,
+	}
+	if bodyLimit.Limit <= 0 {
+		bodyLimit.Limit = defaultBodyLimit
+	}
+
 	ws.app = fiber.New(fiber.Config{
 		AppName:               "shinpuru",
+		BodyLimit:             bodyLimit.Max(),
 		ErrorHandler:          ws.errorHandler,
 		ServerHeader:          fmt.Sprintf("shinpuru v%s", embedded.AppVersion),
 		DisableStartupMessage: true,
 		ProxyHeader:           "X-Forwarded-For",
 	})
 
+	allowOrigins := append([]string{ws.cfg.Config().WebServer.PublicAddr},
+		ws.cfg.Config().WebServer.CORS.AllowOrigins...)
 	if !embedded.IsRelease() {
-		ws.app.Use(cors.New(cors.Config{
-			AllowOrigins:     ws.cfg.Config().WebServer.DebugPublicAddr,
-			AllowHeaders:     "authorization, content-type, set-cookie, cookie, server",
-			AllowMethods:     "GET, POST, PUT, PATCH, POST, DELETE, OPTIONS",
-			AllowCredentials: true,
-		}))
+		allowOrigins = append(allowOrigins, ws.cfg.Config().WebServer.DebugPublicAddr)
 	}
 
 	ws.app.Use(
+		mw.NewBodyLimit(bodyLimit),
 		etag.New(),
 		mw.NewMetrics(mw.MetricsOptions{IgnorePatterns: []string{`^\/api\/(?:v\d\/)?healthcheck`}}),
 		mw.Logger(),
 	)
 
-	rlc := ws.cfg.Config().WebServer.RateLimit
-	rlh := limiter.New(limiter.Config{
-		Next: func(ctx *fiber.Ctx) bool {
-			return !rlc.Enabled
-		},
-		Burst:           rlc.Burst,
-		Duration:        time.Duration(rlc.LimitSeconds) * time.Second,
-		CleanupInterval: 10 * time.Minute,
-		KeyGenerator: func(ctx *fiber.Ctx) string {
-			return ctx.IP()
-		},
-		OnLimitReached: func(ctx *fiber.Ctx) error {
-			return fiber.ErrTooManyRequests
-		},
-	})
-
 	new(controllers.ImagestoreController).Setup(ws.container, ws.app.Group("/imagestore"))
+	new(controllers.CodeExecOutputController).Setup(ws.container, ws.app.Group("/codeexec"))
 	new(controllers.InviteController).Setup(ws.container, ws.app.Group("/invite"))
-	ws.registerRouter(new(v1.Router), []string{"/api/v1", "/api"}, rlh)
+	// CORS is only handled for API routes so that static
+	// assets and images stay accessible from any origin.
+	ws.registerRouter(new(v1.Router), []string{"/api/v1", "/api"}, mw.NewCORS(allowOrigins))
 
 	fs, err := wsutil.GetFS()
 	if err != nil {
@@ -117,6 +113,15 @@ func (ws *WebServer) registerRouter(router Router, routes []string, middlewares
 }
 
 func (ws *WebServer) errorHandler(ctx *fiber.Ctx, err error) error {
+	if fields := validation.FieldErrors(err); fields != nil {
+		ctx.Status(fiber.StatusBadRequest)
+		return ctx.JSON(&models.Error{
+			Error:  err.Error(),
+			Code:   fiber.StatusBadRequest,
+			Fields: fields,
+		})
+	}
+
 	if fErr, ok := err.(*fiber.Error); ok {
 		if fErr == fiber.ErrUnprocessableEntity {
 			fErr = fiber.ErrBadRequest
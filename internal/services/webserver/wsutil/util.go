@@ -45,6 +45,29 @@ func GetQueryInt(ctx *fiber.Ctx, key string, def, min, max int) (int, error) {
 	return val, nil
 }
 
+// GetQueryPagination returns the pagination query
+// parameters "limit" and "offset".
+//
+// If limit is not provided, defLimit is returned.
+// If maxLimit is larger than 0, limit must be in
+// bounds [1, maxLimit]. Otherwise, a limit of 0
+// is accepted to select all entries.
+//
+// Returned errors are in form of fiber errors with
+// appropriate error codes.
+func GetQueryPagination(ctx *fiber.Ctx, defLimit, maxLimit int) (limit, offset int, err error) {
+	minLimit := 0
+	if maxLimit > 0 {
+		minLimit = 1
+	}
+
+	if limit, err = GetQueryInt(ctx, "limit", defLimit, minLimit, maxLimit); err != nil {
+		return
+	}
+	offset, err = GetQueryInt(ctx, "offset", 0, 0, 0)
+	return
+}
+
 // GetQueryBool tries to get a value from request query
 // and transforms it to an bool value.
 //
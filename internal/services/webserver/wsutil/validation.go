@@ -0,0 +1,29 @@
+package wsutil
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/zekroTJA/shinpuru/pkg/validation"
+)
+
+// ParseAndValidate parses the request body into v and
+// validates it when v implements validation.Validator.
+//
+// Parsing errors are returned as fiber errors with
+// status 400. Field errors returned by the validation
+// are passed unchanged so that the error handler can
+// respond with the invalid fields. Other validation
+// errors are returned as fiber errors with status 400.
+func ParseAndValidate(ctx *fiber.Ctx, v any) error {
+	if err := ctx.BodyParser(v); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if err := validation.Validate(v); err != nil {
+		if validation.FieldErrors(err) != nil {
+			return err
+		}
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package wsutil
+
+import (
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/zekroTJA/shinpuru/pkg/validation"
+)
+
+type validatedModel struct {
+	Name string `json:"name"`
+}
+
+func (m *validatedModel) Validate() error {
+	var errs validation.Errors
+	errs.Assert(m.Name != "", "name", "must not be empty")
+	return errs.Err()
+}
+
+type plainErrorModel struct {
+	Name string `json:"name"`
+}
+
+func (m *plainErrorModel) Validate() error {
+	if m.Name == "" {
+		return errors.New("name must not be empty")
+	}
+	return nil
+}
+
+func TestParseAndValidate(t *testing.T) {
+	var handlerErr error
+	app := fiber.New()
+	app.Post("/:model", func(ctx *fiber.Ctx) error {
+		var v any
+		switch ctx.Params("model") {
+		case "validated":
+			v = new(validatedModel)
+		case "plain":
+			v = new(plainErrorModel)
+		default:
+			v = new(struct {
+				Name string `json:"name"`
+			})
+		}
+		handlerErr = ParseAndValidate(ctx, v)
+		return nil
+	})
+
+	parse := func(model, body string) error {
+		req := httptest.NewRequest(fiber.MethodPost, "/"+model, strings.NewReader(body))
+		req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		_, err := app.Test(req)
+		assert.Nil(t, err)
+		return handlerErr
+	}
+
+	assert.Nil(t, parse("validated", `{"name":"a"}`))
+	assert.Nil(t, parse("none", `{}`))
+
+	err := parse("validated", `{}`)
+	assert.Equal(t, validation.Errors{{Field: "name", Message: "must not be empty"}},
+		validation.FieldErrors(err))
+
+	err = parse("plain", `{}`)
+	var fErr *fiber.Error
+	assert.ErrorAs(t, err, &fErr)
+	assert.Equal(t, fiber.StatusBadRequest, fErr.Code)
+	assert.Equal(t, "name must not be empty", fErr.Message)
+
+	err = parse("validated", `{"name":`)
+	assert.ErrorAs(t, err, &fErr)
+	assert.Equal(t, fiber.StatusBadRequest, fErr.Code)
+}
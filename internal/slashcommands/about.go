@@ -0,0 +1,121 @@
+package slashcommands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/zekroTJA/shinpuru/internal/services/config"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/services/permissions"
+	"github.com/zekroTJA/shinpuru/internal/util"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/internal/util/sysinfo"
+	"github.com/zekroTJA/shinpuru/pkg/bytecount"
+	"github.com/zekrotja/dgrs"
+	"github.com/zekrotja/ken"
+)
+
+type About struct {
+	ken.EphemeralCommand
+}
+
+var (
+	_ ken.SlashCommand        = (*About)(nil)
+	_ permissions.PermCommand = (*About)(nil)
+	_ ken.DmCapable           = (*About)(nil)
+)
+
+func (c *About) Name() string {
+	return "about"
+}
+
+func (c *About) Description() string {
+	return "Display version, uptime and resource usage of this bot instance."
+}
+
+func (c *About) Version() string {
+	return "1.0.0"
+}
+
+func (c *About) Type() discordgo.ApplicationCommandType {
+	return discordgo.ChatApplicationCommand
+}
+
+func (c *About) Options() []*discordgo.ApplicationCommandOption {
+	return []*discordgo.ApplicationCommandOption{}
+}
+
+func (c *About) Domain() string {
+	return "sp.etc.about"
+}
+
+func (c *About) SubDomains() []permissions.SubPermission {
+	return nil
+}
+
+func (c *About) IsDmCapable() bool {
+	return true
+}
+
+func (c *About) Run(ctx ken.Context) (err error) {
+	if err = ctx.Defer(); err != nil {
+		return
+	}
+
+	cfg := ctx.Get(static.DiConfig).(config.Provider).Config()
+	st := ctx.Get(static.DiState).(dgrs.IState)
+	db := ctx.Get(static.DiDatabase).(database.Database)
+
+	info, err := sysinfo.Get(st)
+	if err != nil {
+		return
+	}
+
+	links := fmt.Sprintf("[Invite](%s)", info.BotInvite)
+	if cfg.WebServer.Enabled && cfg.WebServer.PublicAddr != "" {
+		links = fmt.Sprintf("[Dashboard](%s) • %s", cfg.WebServer.PublicAddr, links)
+	}
+
+	runtimeStats := fmt.Sprintf("Go Version: **%s**\nPlatform: **%s/%s**\nCPU Threads: **%d**\nGo Routines: **%d**",
+		info.GoVersion, info.OS, info.Arch, info.CPUs, info.GoRoutines)
+
+	// Memory usage is only shown to the bot owner
+	// unless it is configured to be public.
+	if cfg.Discord.PublicResourceUsage || ctx.User().ID == cfg.Discord.OwnerID {
+		runtimeStats += fmt.Sprintf("\nUsed Heap: **%s**\nUsed Stack: **%s**",
+			bytecount.Format(info.HeapUse), bytecount.Format(info.StackUse))
+	}
+
+	emb := &discordgo.MessageEmbed{
+		Color: util.GuildEmbedColor(db, ctx.GetEvent().GuildID),
+		Title: "About shinpuru",
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name: "Version",
+				Value: fmt.Sprintf("**%s** (commit hash `%s`)\nBuilt <t:%d:R>",
+					info.Version, info.CommitHash, info.BuildDate.Unix()),
+			},
+			{
+				Name:   "Uptime",
+				Value:  info.Uptime.Round(time.Second).String(),
+				Inline: true,
+			},
+			{
+				Name:   "Guilds",
+				Value:  fmt.Sprintf("%d", info.Guilds),
+				Inline: true,
+			},
+			{
+				Name:  "Runtime",
+				Value: runtimeStats,
+			},
+			{
+				Name:  "Links",
+				Value: links,
+			},
+		},
+	}
+
+	return ctx.FollowUpEmbed(emb).Send().Error
+}
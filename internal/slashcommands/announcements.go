@@ -2,10 +2,12 @@ package slashcommands
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/zekroTJA/shinpuru/internal/services/database"
 	"github.com/zekroTJA/shinpuru/internal/services/permissions"
+	"github.com/zekroTJA/shinpuru/internal/util/membermsg"
 	"github.com/zekroTJA/shinpuru/internal/util/static"
 	"github.com/zekroTJA/shinpuru/pkg/stringutil"
 	"github.com/zekrotja/ken"
@@ -34,7 +36,7 @@ func (c *Announcements) Description() string {
 }
 
 func (c *Announcements) Version() string {
-	return "1.0.0"
+	return "1.1.0"
 }
 
 func (c *Announcements) Type() discordgo.ApplicationCommandType {
@@ -80,6 +82,24 @@ func (c *Announcements) Options() []*discordgo.ApplicationCommandOption {
 			Description: "Disable announcements.",
 			Options:     commonOpts,
 		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "grace",
+			Description: "Suppress join and leave messages of members leaving or rejoining within a grace period.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "seconds",
+					Description: fmt.Sprintf("The grace period in seconds (0 to disable, max %d).", int(membermsg.MaxGracePeriod.Seconds())),
+					Required:    true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionBoolean,
+					Name:        "noterejoin",
+					Description: "Send join messages of rejoining members with a note instead of suppressing them.",
+				},
+			},
+		},
 	}
 }
 
@@ -99,6 +119,7 @@ func (c *Announcements) Run(ctx ken.Context) (err error) {
 	err = ctx.HandleSubCommands(
 		ken.SubCommandHandler{"set", c.set},
 		ken.SubCommandHandler{"disable", c.disable},
+		ken.SubCommandHandler{"grace", c.grace},
 	)
 
 	return
@@ -183,3 +204,46 @@ func (c *Announcements) disable(ctx ken.SubCommandContext) (err error) {
 		Description: fmt.Sprintf("%s disabled.", stringutil.Capitalize(string(typ), false)),
 	}).Send().Error
 }
+
+func (c *Announcements) grace(ctx ken.SubCommandContext) (err error) {
+	db := ctx.Get(static.DiDatabase).(database.Database)
+
+	guildID := ctx.GetEvent().GuildID
+
+	period := time.Duration(ctx.Options().GetByName("seconds").IntValue()) * time.Second
+	if period < 0 || period > membermsg.MaxGracePeriod {
+		return ctx.FollowUpError(
+			fmt.Sprintf("The grace period must be between 0 and %d seconds.", int(membermsg.MaxGracePeriod.Seconds())), "").
+			Send().Error
+	}
+
+	_, noteRejoin, err := db.GetGuildMemberMsgGrace(guildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return
+	}
+	if v, ok := ctx.Options().GetByNameOptional("noterejoin"); ok {
+		noteRejoin = v.BoolValue()
+	}
+
+	if err = db.SetGuildMemberMsgGrace(guildID, period, noteRejoin); err != nil {
+		return
+	}
+
+	if period == 0 {
+		return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+			Description: "Grace period disabled.",
+		}).Send().Error
+	}
+
+	rejoinMsg := "Join messages of rejoining members are suppressed."
+	if noteRejoin {
+		rejoinMsg = "Join messages of rejoining members are sent with a note."
+	}
+
+	return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+		Description: fmt.Sprintf(
+			"Leave messages of members leaving within %s after joining are now suppressed.\n%s",
+			period.String(), rejoinMsg),
+		Color: static.ColorEmbedGreen,
+	}).Send().Error
+}
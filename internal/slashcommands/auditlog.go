@@ -0,0 +1,264 @@
+package slashcommands
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/zekroTJA/shinpuru/internal/services/permissions"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/pkg/discordutil"
+	"github.com/zekroTJA/shinpuru/pkg/paginator"
+	"github.com/zekroTJA/shinpuru/pkg/stringutil"
+	"github.com/zekrotja/ken"
+)
+
+const (
+	auditLogDefaultLimit   = 50
+	auditLogEntriesPerPage = 10
+	auditLogMaxPageLen     = 4096
+)
+
+// auditLogActions maps the audit log actions which can
+// be filtered for to their display names.
+var auditLogActions = []struct {
+	Action discordgo.AuditLogAction
+	Name   string
+}{
+	{discordgo.AuditLogActionGuildUpdate, "Guild Update"},
+	{discordgo.AuditLogActionChannelCreate, "Channel Create"},
+	{discordgo.AuditLogActionChannelUpdate, "Channel Update"},
+	{discordgo.AuditLogActionChannelDelete, "Channel Delete"},
+	{discordgo.AuditLogActionChannelOverwriteUpdate, "Channel Permission Update"},
+	{discordgo.AuditLogActionMemberKick, "Member Kick"},
+	{discordgo.AuditLogActionMemberPrune, "Member Prune"},
+	{discordgo.AuditLogActionMemberBanAdd, "Member Ban"},
+	{discordgo.AuditLogActionMemberBanRemove, "Member Unban"},
+	{discordgo.AuditLogActionMemberUpdate, "Member Update"},
+	{discordgo.AuditLogActionMemberRoleUpdate, "Member Role Update"},
+	{discordgo.AuditLogActionMemberMove, "Member Move"},
+	{discordgo.AuditLogActionMemberDisconnect, "Member Disconnect"},
+	{discordgo.AuditLogActionBotAdd, "Bot Add"},
+	{discordgo.AuditLogActionRoleCreate, "Role Create"},
+	{discordgo.AuditLogActionRoleUpdate, "Role Update"},
+	{discordgo.AuditLogActionRoleDelete, "Role Delete"},
+	{discordgo.AuditLogActionInviteCreate, "Invite Create"},
+	{discordgo.AuditLogActionInviteDelete, "Invite Delete"},
+	{discordgo.AuditLogActionWebhookCreate, "Webhook Create"},
+	{discordgo.AuditLogActionWebhookDelete, "Webhook Delete"},
+	{discordgo.AuditLogActionMessageDelete, "Message Delete"},
+	{discordgo.AuditLogActionMessageBulkDelete, "Message Bulk Delete"},
+	{discordgo.AuditLogActionMessagePin, "Message Pin"},
+	{discordgo.AuditLogActionThreadCreate, "Thread Create"},
+}
+
+type AuditLog struct{}
+
+var (
+	_ ken.SlashCommand        = (*AuditLog)(nil)
+	_ permissions.PermCommand = (*AuditLog)(nil)
+)
+
+func (c *AuditLog) Name() string {
+	return "auditlog"
+}
+
+func (c *AuditLog) Description() string {
+	return "Show recent audit log entries of the guild."
+}
+
+func (c *AuditLog) Version() string {
+	return "1.0.0"
+}
+
+func (c *AuditLog) Type() discordgo.ApplicationCommandType {
+	return discordgo.ChatApplicationCommand
+}
+
+func (c *AuditLog) Options() []*discordgo.ApplicationCommandOption {
+	choices := make([]*discordgo.ApplicationCommandOptionChoice, len(auditLogActions))
+	for i, a := range auditLogActions {
+		choices[i] = &discordgo.ApplicationCommandOptionChoice{
+			Name:  a.Name,
+			Value: int(a.Action),
+		}
+	}
+
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionInteger,
+			Name:        "action",
+			Description: "Only show entries of the given action type.",
+			Choices:     choices,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionUser,
+			Name:        "user",
+			Description: "Only show entries executed by the given user.",
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionInteger,
+			Name:        "limit",
+			Description: fmt.Sprintf("The amount of entries to fetch (1-100, default %d).", auditLogDefaultLimit),
+		},
+	}
+}
+
+func (c *AuditLog) Domain() string {
+	return "sp.guild.mod.auditlog"
+}
+
+func (c *AuditLog) SubDomains() []permissions.SubPermission {
+	return nil
+}
+
+func (c *AuditLog) Run(ctx ken.Context) (err error) {
+	ctx.SetEphemeral(true)
+	if err = ctx.Defer(); err != nil {
+		return
+	}
+
+	member := ctx.GetEvent().Member
+	if member == nil || member.Permissions&discordgo.PermissionViewAuditLogs == 0 {
+		return ctx.FollowUpError(
+			"You need the `View Audit Log` permission to use this command.", "").
+			Send().Error
+	}
+
+	var userID string
+	if v, ok := ctx.Options().GetByNameOptional("user"); ok {
+		userID = v.UserValue(ctx).ID
+	}
+
+	var action int
+	if v, ok := ctx.Options().GetByNameOptional("action"); ok {
+		action = int(v.IntValue())
+	}
+
+	limit := auditLogDefaultLimit
+	if v, ok := ctx.Options().GetByNameOptional("limit"); ok {
+		limit = int(v.IntValue())
+		if limit < 1 || limit > 100 {
+			return ctx.FollowUpError("The limit must be in range [1, 100].", "").Send().Error
+		}
+	}
+
+	s := ctx.GetSession()
+	auditLog, err := s.GuildAuditLog(ctx.GetEvent().GuildID, userID, "", action, limit)
+	if discordutil.IsErrCode(err, discordgo.ErrCodeMissingPermissions) ||
+		discordutil.IsErrCode(err, discordgo.ErrCodeMissingAccess) {
+		return ctx.FollowUpError(
+			"I am not permitted to view the audit log of this guild. "+
+				"Please grant me the `View Audit Log` permission.", "").
+			Send().Error
+	}
+	if err != nil {
+		return
+	}
+
+	if len(auditLog.AuditLogEntries) == 0 {
+		return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+			Description: "No audit log entries found.",
+		}).Send().Error
+	}
+
+	pages := c.pages(auditLog)
+
+	_, err = paginator.SendFollowUp(s, ctx.GetEvent().Interaction, pages, &paginator.Options{
+		UserID: ctx.User().ID,
+	})
+	return
+}
+
+func (c *AuditLog) pages(auditLog *discordgo.GuildAuditLog) []*discordgo.MessageEmbed {
+	users := make(map[string]*discordgo.User, len(auditLog.Users))
+	for _, u := range auditLog.Users {
+		users[u.ID] = u
+	}
+
+	var (
+		pages   []*discordgo.MessageEmbed
+		lines   []string
+		pageLen int
+	)
+
+	flush := func() {
+		pages = append(pages, &discordgo.MessageEmbed{
+			Title:       "Audit Log",
+			Color:       static.ColorEmbedDefault,
+			Description: strings.Join(lines, "\n\n"),
+		})
+		lines = nil
+		pageLen = 0
+	}
+
+	// Entries are split into pages of at most
+	// auditLogEntriesPerPage entries so that the
+	// description of a page does not exceed the
+	// maximum embed description length.
+	for _, e := range auditLog.AuditLogEntries {
+		line := stringutil.Truncate(c.formatEntry(e, users), auditLogMaxPageLen)
+		lineLen := utf8.RuneCountInString(line)
+		if len(lines) > 0 {
+			lineLen += 2
+		}
+		if len(lines) == auditLogEntriesPerPage || pageLen+lineLen > auditLogMaxPageLen {
+			flush()
+			lineLen = utf8.RuneCountInString(line)
+		}
+		lines = append(lines, line)
+		pageLen += lineLen
+	}
+	if len(lines) > 0 {
+		flush()
+	}
+
+	return pages
+}
+
+func (c *AuditLog) formatEntry(e *discordgo.AuditLogEntry, users map[string]*discordgo.User) string {
+	var sb strings.Builder
+
+	if t, err := discordgo.SnowflakeTimestamp(e.ID); err == nil {
+		fmt.Fprintf(&sb, "<t:%d:R> ", t.Unix())
+	}
+
+	fmt.Fprintf(&sb, "**%s** by %s", c.actionName(e.ActionType), c.formatUser(e.UserID, users))
+
+	if e.TargetID != "" {
+		if _, ok := users[e.TargetID]; ok {
+			fmt.Fprintf(&sb, "\nTarget: %s", c.formatUser(e.TargetID, users))
+		} else {
+			fmt.Fprintf(&sb, "\nTarget: `%s`", e.TargetID)
+		}
+	}
+
+	if e.Reason != "" {
+		fmt.Fprintf(&sb, "\nReason: %s", e.Reason)
+	}
+
+	return sb.String()
+}
+
+func (c *AuditLog) formatUser(id string, users map[string]*discordgo.User) string {
+	if id == "" {
+		return "unknown"
+	}
+	if u, ok := users[id]; ok {
+		return fmt.Sprintf("%s (%s)", u.String(), u.Mention())
+	}
+	return fmt.Sprintf("<@%s>", id)
+}
+
+func (c *AuditLog) actionName(action *discordgo.AuditLogAction) string {
+	if action == nil {
+		return "Unknown"
+	}
+	for _, a := range auditLogActions {
+		if a.Action == *action {
+			return a.Name
+		}
+	}
+	return fmt.Sprintf("Action %d", *action)
+}
@@ -0,0 +1,213 @@
+package slashcommands
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/services/permissions"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/pkg/timeutil"
+	"github.com/zekrotja/ken"
+)
+
+type Autodelete struct{}
+
+var (
+	_ ken.SlashCommand        = (*Autodelete)(nil)
+	_ permissions.PermCommand = (*Autodelete)(nil)
+)
+
+func (c *Autodelete) Name() string {
+	return "autodelete"
+}
+
+func (c *Autodelete) Description() string {
+	return "Automatically delete command responses after a given delay."
+}
+
+func (c *Autodelete) Version() string {
+	return "1.0.0"
+}
+
+func (c *Autodelete) Type() discordgo.ApplicationCommandType {
+	return discordgo.ChatApplicationCommand
+}
+
+func (c *Autodelete) Options() []*discordgo.ApplicationCommandOption {
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "set",
+			Description: "Set the auto delete delays for the guild or a specific channel.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "delay",
+					Description: "The delay after which responses are deleted (e.g. '30s'; '0' to keep them).",
+					Required:    true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "error_delay",
+					Description: "The delay after which error responses are deleted (same as delay if not specified).",
+				},
+				{
+					Type:         discordgo.ApplicationCommandOptionChannel,
+					Name:         "channel",
+					Description:  "The channel to apply the delays to (guild wide if not specified).",
+					ChannelTypes: []discordgo.ChannelType{discordgo.ChannelTypeGuildText},
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "remove",
+			Description: "Remove the auto delete config for the guild or a specific channel.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:         discordgo.ApplicationCommandOptionChannel,
+					Name:         "channel",
+					Description:  "The channel to remove the config from (guild wide if not specified).",
+					ChannelTypes: []discordgo.ChannelType{discordgo.ChannelTypeGuildText},
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "list",
+			Description: "List all auto delete configs of the guild.",
+		},
+	}
+}
+
+func (c *Autodelete) Domain() string {
+	return "sp.guild.config.autodelete"
+}
+
+func (c *Autodelete) SubDomains() []permissions.SubPermission {
+	return nil
+}
+
+func (c *Autodelete) Run(ctx ken.Context) (err error) {
+	if err = ctx.Defer(); err != nil {
+		return
+	}
+
+	err = ctx.HandleSubCommands(
+		ken.SubCommandHandler{"set", c.set},
+		ken.SubCommandHandler{"remove", c.remove},
+		ken.SubCommandHandler{"list", c.list},
+	)
+
+	return
+}
+
+func (c *Autodelete) set(ctx ken.SubCommandContext) (err error) {
+	db := ctx.Get(static.DiDatabase).(database.Database)
+
+	cfg := models.AutoDeleteConfig{
+		GuildID: ctx.GetEvent().GuildID,
+	}
+
+	if chV, ok := ctx.Options().GetByNameOptional("channel"); ok {
+		cfg.ChannelID = chV.ChannelValue(ctx).ID
+	}
+
+	if cfg.Delay, err = c.parseDelay(ctx.Options().GetByName("delay").StringValue()); err != nil {
+		return ctx.FollowUpError(err.Error(), "").Send().Error
+	}
+
+	cfg.ErrorDelay = cfg.Delay
+	if errDelayV, ok := ctx.Options().GetByNameOptional("error_delay"); ok {
+		if cfg.ErrorDelay, err = c.parseDelay(errDelayV.StringValue()); err != nil {
+			return ctx.FollowUpError(err.Error(), "").Send().Error
+		}
+	}
+
+	if err = db.SetAutoDelete(cfg); err != nil {
+		return
+	}
+
+	return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+		Description: fmt.Sprintf("Auto delete config for %s has been set.\n\n%s",
+			c.target(cfg.ChannelID), c.format(cfg)),
+	}).Send().Error
+}
+
+func (c *Autodelete) remove(ctx ken.SubCommandContext) (err error) {
+	db := ctx.Get(static.DiDatabase).(database.Database)
+
+	var channelID string
+	if chV, ok := ctx.Options().GetByNameOptional("channel"); ok {
+		channelID = chV.ChannelValue(ctx).ID
+	}
+
+	err = db.RemoveAutoDelete(ctx.GetEvent().GuildID, channelID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return
+	}
+
+	return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+		Description: fmt.Sprintf("Auto delete config for %s has been removed.", c.target(channelID)),
+	}).Send().Error
+}
+
+func (c *Autodelete) list(ctx ken.SubCommandContext) (err error) {
+	db := ctx.Get(static.DiDatabase).(database.Database)
+
+	cfgs, err := db.GetAutoDeletes(ctx.GetEvent().GuildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return
+	}
+
+	if len(cfgs) == 0 {
+		return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+			Description: "No auto delete configs are set for this guild.",
+		}).Send().Error
+	}
+
+	lines := make([]string, len(cfgs))
+	for i, cfg := range cfgs {
+		lines[i] = fmt.Sprintf("**%s**\n%s", c.target(cfg.ChannelID), c.format(cfg))
+	}
+
+	return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+		Title:       "Auto Delete Configs",
+		Description: strings.Join(lines, "\n\n"),
+	}).Send().Error
+}
+
+func (c *Autodelete) parseDelay(v string) (d time.Duration, err error) {
+	if v == "0" {
+		return 0, nil
+	}
+	d, err = timeutil.ParseDuration(v)
+	if err != nil {
+		return 0, fmt.Errorf("Invalid delay value:\n```\n%s```", err.Error())
+	}
+	if d < 0 || d > models.AutoDeleteMaxDelay {
+		return 0, fmt.Errorf("The delay must be in range of 0 and %s.", models.AutoDeleteMaxDelay)
+	}
+	return d, nil
+}
+
+func (c *Autodelete) target(channelID string) string {
+	if channelID == "" {
+		return "the guild"
+	}
+	return fmt.Sprintf("<#%s>", channelID)
+}
+
+func (c *Autodelete) format(cfg models.AutoDeleteConfig) string {
+	f := func(d time.Duration) string {
+		if d == 0 {
+			return "never"
+		}
+		return d.String()
+	}
+	return fmt.Sprintf("Responses: `%s`\nErrors: `%s`", f(cfg.Delay), f(cfg.ErrorDelay))
+}
@@ -0,0 +1,167 @@
+package slashcommands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/services/permissions"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekrotja/ken"
+)
+
+type AutoPublish struct{}
+
+var (
+	_ ken.SlashCommand        = (*AutoPublish)(nil)
+	_ permissions.PermCommand = (*AutoPublish)(nil)
+)
+
+func (c *AutoPublish) Name() string {
+	return "autopublish"
+}
+
+func (c *AutoPublish) Description() string {
+	return "Automatically publish new messages in announcement channels."
+}
+
+func (c *AutoPublish) Version() string {
+	return "1.0.0"
+}
+
+func (c *AutoPublish) Type() discordgo.ApplicationCommandType {
+	return discordgo.ChatApplicationCommand
+}
+
+func (c *AutoPublish) Options() []*discordgo.ApplicationCommandOption {
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "set",
+			Description: "Enable or update automatic publishing in an announcement channel.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:         discordgo.ApplicationCommandOptionChannel,
+					Name:         "channel",
+					Description:  "The announcement channel to publish messages in.",
+					Required:     true,
+					ChannelTypes: []discordgo.ChannelType{discordgo.ChannelTypeGuildNews},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionBoolean,
+					Name:        "skip_bots",
+					Description: "Do not publish messages of bots and webhooks (default: false).",
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "remove",
+			Description: "Disable automatic publishing in an announcement channel.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:         discordgo.ApplicationCommandOptionChannel,
+					Name:         "channel",
+					Description:  "The channel to disable automatic publishing in.",
+					Required:     true,
+					ChannelTypes: []discordgo.ChannelType{discordgo.ChannelTypeGuildNews},
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "list",
+			Description: "List all channels with automatic publishing.",
+		},
+	}
+}
+
+func (c *AutoPublish) Domain() string {
+	return "sp.guild.config.autopublish"
+}
+
+func (c *AutoPublish) SubDomains() []permissions.SubPermission {
+	return nil
+}
+
+func (c *AutoPublish) Run(ctx ken.Context) (err error) {
+	if err = ctx.Defer(); err != nil {
+		return
+	}
+
+	err = ctx.HandleSubCommands(
+		ken.SubCommandHandler{"set", c.set},
+		ken.SubCommandHandler{"remove", c.remove},
+		ken.SubCommandHandler{"list", c.list},
+	)
+
+	return
+}
+
+func (c *AutoPublish) set(ctx ken.SubCommandContext) (err error) {
+	db := ctx.Get(static.DiDatabase).(database.Database)
+
+	cfg := models.AutoPublishConfig{
+		GuildID:   ctx.GetEvent().GuildID,
+		ChannelID: ctx.Options().GetByName("channel").ChannelValue(ctx).ID,
+	}
+
+	if v, ok := ctx.Options().GetByNameOptional("skip_bots"); ok {
+		cfg.SkipBots = v.BoolValue()
+	}
+
+	if err = db.SetAutoPublish(cfg); err != nil {
+		return
+	}
+
+	return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+		Description: fmt.Sprintf("New messages in <#%s> will now be published automatically.\n\n%s",
+			cfg.ChannelID, c.format(cfg)),
+	}).Send().Error
+}
+
+func (c *AutoPublish) remove(ctx ken.SubCommandContext) (err error) {
+	db := ctx.Get(static.DiDatabase).(database.Database)
+
+	channelID := ctx.Options().GetByName("channel").ChannelValue(ctx).ID
+
+	err = db.RemoveAutoPublish(ctx.GetEvent().GuildID, channelID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return
+	}
+
+	return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+		Description: fmt.Sprintf("New messages in <#%s> will no longer be published automatically.", channelID),
+	}).Send().Error
+}
+
+func (c *AutoPublish) list(ctx ken.SubCommandContext) (err error) {
+	db := ctx.Get(static.DiDatabase).(database.Database)
+
+	cfgs, err := db.GetAutoPublishes(ctx.GetEvent().GuildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return
+	}
+
+	if len(cfgs) == 0 {
+		return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+			Description: "Automatic publishing is not enabled in any channel.",
+		}).Send().Error
+	}
+
+	lines := make([]string, len(cfgs))
+	for i, cfg := range cfgs {
+		lines[i] = fmt.Sprintf("**<#%s>**\n%s", cfg.ChannelID, c.format(cfg))
+	}
+
+	return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+		Title:       "Auto Publish Channels",
+		Description: strings.Join(lines, "\n\n"),
+	}).Send().Error
+}
+
+func (c *AutoPublish) format(cfg models.AutoPublishConfig) string {
+	return fmt.Sprintf("Skip bots: `%t`", cfg.SkipBots)
+}
@@ -0,0 +1,209 @@
+package slashcommands
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/services/permissions"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/pkg/timeutil"
+	"github.com/zekrotja/ken"
+)
+
+type AutoThread struct{}
+
+var (
+	_ ken.SlashCommand        = (*AutoThread)(nil)
+	_ permissions.PermCommand = (*AutoThread)(nil)
+)
+
+func (c *AutoThread) Name() string {
+	return "autothread"
+}
+
+func (c *AutoThread) Description() string {
+	return "Automatically create threads for new messages in a channel."
+}
+
+func (c *AutoThread) Version() string {
+	return "1.0.0"
+}
+
+func (c *AutoThread) Type() discordgo.ApplicationCommandType {
+	return discordgo.ChatApplicationCommand
+}
+
+func (c *AutoThread) Options() []*discordgo.ApplicationCommandOption {
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "set",
+			Description: "Enable or update automatic thread creation in a channel.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:         discordgo.ApplicationCommandOptionChannel,
+					Name:         "channel",
+					Description:  "The channel to create threads in.",
+					Required:     true,
+					ChannelTypes: []discordgo.ChannelType{discordgo.ChannelTypeGuildText, discordgo.ChannelTypeGuildNews},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionBoolean,
+					Name:        "attachments_only",
+					Description: "Only create threads for messages with attachments (default: false).",
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "name",
+					Description: "What the thread is named after (default: content).",
+					Choices: []*discordgo.ApplicationCommandOptionChoice{
+						{Name: "Message content", Value: string(models.AutoThreadNameContent)},
+						{Name: "Message author", Value: string(models.AutoThreadNameAuthor)},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "cooldown",
+					Description: "The time a member has to wait until a new thread is created for them (e.g. '5m', default: 1m).",
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "remove",
+			Description: "Disable automatic thread creation in a channel.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:         discordgo.ApplicationCommandOptionChannel,
+					Name:         "channel",
+					Description:  "The channel to disable automatic thread creation in.",
+					Required:     true,
+					ChannelTypes: []discordgo.ChannelType{discordgo.ChannelTypeGuildText, discordgo.ChannelTypeGuildNews},
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "list",
+			Description: "List all channels with automatic thread creation.",
+		},
+	}
+}
+
+func (c *AutoThread) Domain() string {
+	return "sp.guild.config.autothread"
+}
+
+func (c *AutoThread) SubDomains() []permissions.SubPermission {
+	return nil
+}
+
+func (c *AutoThread) Run(ctx ken.Context) (err error) {
+	if err = ctx.Defer(); err != nil {
+		return
+	}
+
+	err = ctx.HandleSubCommands(
+		ken.SubCommandHandler{"set", c.set},
+		ken.SubCommandHandler{"remove", c.remove},
+		ken.SubCommandHandler{"list", c.list},
+	)
+
+	return
+}
+
+func (c *AutoThread) set(ctx ken.SubCommandContext) (err error) {
+	db := ctx.Get(static.DiDatabase).(database.Database)
+
+	cfg := models.AutoThreadConfig{
+		GuildID:    ctx.GetEvent().GuildID,
+		ChannelID:  ctx.Options().GetByName("channel").ChannelValue(ctx).ID,
+		NameSource: models.AutoThreadNameContent,
+		Cooldown:   models.AutoThreadDefaultCooldown,
+	}
+
+	if v, ok := ctx.Options().GetByNameOptional("attachments_only"); ok {
+		cfg.AttachmentsOnly = v.BoolValue()
+	}
+
+	if v, ok := ctx.Options().GetByNameOptional("name"); ok {
+		cfg.NameSource = models.AutoThreadNameSource(v.StringValue())
+	}
+
+	if v, ok := ctx.Options().GetByNameOptional("cooldown"); ok && v.StringValue() == "0" {
+		cfg.Cooldown = 0
+	} else if ok {
+		cfg.Cooldown, err = timeutil.ParseDuration(v.StringValue())
+		if err != nil {
+			return ctx.FollowUpError(
+				fmt.Sprintf("Invalid cooldown value:\n```\n%s```", err.Error()), "").
+				Send().Error
+		}
+	}
+
+	if err = cfg.Validate(); err != nil {
+		return ctx.FollowUpError(err.Error(), "").Send().Error
+	}
+
+	if err = db.SetAutoThread(cfg); err != nil {
+		return
+	}
+
+	return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+		Description: fmt.Sprintf("Threads will now be created automatically in <#%s>.\n\n%s",
+			cfg.ChannelID, c.format(cfg)),
+	}).Send().Error
+}
+
+func (c *AutoThread) remove(ctx ken.SubCommandContext) (err error) {
+	db := ctx.Get(static.DiDatabase).(database.Database)
+
+	channelID := ctx.Options().GetByName("channel").ChannelValue(ctx).ID
+
+	err = db.RemoveAutoThread(ctx.GetEvent().GuildID, channelID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return
+	}
+
+	return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+		Description: fmt.Sprintf("Threads will no longer be created automatically in <#%s>.", channelID),
+	}).Send().Error
+}
+
+func (c *AutoThread) list(ctx ken.SubCommandContext) (err error) {
+	db := ctx.Get(static.DiDatabase).(database.Database)
+
+	cfgs, err := db.GetAutoThreads(ctx.GetEvent().GuildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return
+	}
+
+	if len(cfgs) == 0 {
+		return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+			Description: "Automatic thread creation is not enabled in any channel.",
+		}).Send().Error
+	}
+
+	lines := make([]string, len(cfgs))
+	for i, cfg := range cfgs {
+		lines[i] = fmt.Sprintf("**<#%s>**\n%s", cfg.ChannelID, c.format(cfg))
+	}
+
+	return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+		Title:       "Auto Thread Channels",
+		Description: strings.Join(lines, "\n\n"),
+	}).Send().Error
+}
+
+func (c *AutoThread) format(cfg models.AutoThreadConfig) string {
+	cooldown := "none"
+	if cfg.Cooldown > 0 {
+		cooldown = cfg.Cooldown.Round(time.Second).String()
+	}
+	return fmt.Sprintf("Attachments only: `%t`\nName from: `%s`\nCooldown: `%s`",
+		cfg.AttachmentsOnly, cfg.NameSource, cooldown)
+}
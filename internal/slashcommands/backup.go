@@ -7,9 +7,9 @@ import (
 	"time"
 
 	"github.com/bwmarrin/discordgo"
-	"github.com/rs/xid"
 	"github.com/zekroTJA/shinpuru/internal/services/backup"
 	"github.com/zekroTJA/shinpuru/internal/services/backup/backupmodels"
+	"github.com/zekroTJA/shinpuru/internal/services/components"
 	"github.com/zekroTJA/shinpuru/internal/services/database"
 	"github.com/zekroTJA/shinpuru/internal/services/permissions"
 	"github.com/zekroTJA/shinpuru/internal/services/storage"
@@ -21,6 +21,10 @@ import (
 
 const (
 	timeFormat = time.RFC1123
+
+	// backupMenuLifetime is the time after which the
+	// components of the backup menu are removed.
+	backupMenuLifetime = 5 * time.Minute
 )
 
 type Backup struct{}
@@ -102,6 +106,7 @@ func (c *Backup) Run(ctx ken.Context) (err error) {
 
 	db, _ := ctx.Get(static.DiDatabase).(database.Database)
 	st, _ := ctx.Get(static.DiObjectStorage).(storage.Storage)
+	reg, _ := ctx.Get(static.DiComponents).(*components.Registry)
 
 	enabled, err := db.GetGuildBackup(ctx.GetEvent().GuildID)
 	if err != nil && database.IsErrDatabaseNotFound(err) {
@@ -138,10 +143,9 @@ func (c *Backup) Run(ctx ken.Context) (err error) {
 
 	cNext := make(chan string, 1)
 
-	builder := fum.AddComponents().
-		Condition(func(cctx ken.ComponentContext) bool {
-			return cctx.User().ID == ctx.User().ID
-		})
+	builder := reg.Add(fum.ID, fum.ChannelID).
+		Owner(ctx.User().ID).
+		Expires(backupMenuLifetime)
 
 	if len(entries) != 0 {
 		options := make([]discordgo.SelectMenuOption, 0, len(entries))
@@ -154,7 +158,7 @@ func (c *Backup) Run(ctx ken.Context) (err error) {
 
 		builder.AddActionsRow(func(b ken.ComponentAssembler) {
 			b.Add(discordgo.SelectMenu{
-				CustomID:    xid.New().String(),
+				CustomID:    components.NewID(),
 				Options:     options,
 				Placeholder: "Select backup for Restore",
 			}, func(ctx ken.ComponentContext) bool {
@@ -171,7 +175,7 @@ func (c *Backup) Run(ctx ken.Context) (err error) {
 	builder.AddActionsRow(func(b ken.ComponentAssembler) {
 		if enabled {
 			b.Add(discordgo.Button{
-				CustomID: xid.New().String(),
+				CustomID: components.NewID(),
 				Label:    "Disable Guild Backups",
 				Style:    discordgo.DangerButton,
 			}, func(ctx ken.ComponentContext) bool {
@@ -194,7 +198,7 @@ func (c *Backup) Run(ctx ken.Context) (err error) {
 			})
 		} else {
 			b.Add(discordgo.Button{
-				CustomID: xid.New().String(),
+				CustomID: components.NewID(),
 				Label:    "Enable Guild Backups",
 				Style:    discordgo.SuccessButton,
 			}, func(ctx ken.ComponentContext) bool {
@@ -219,7 +223,7 @@ func (c *Backup) Run(ctx ken.Context) (err error) {
 
 		if len(entries) != 0 {
 			b.Add(discordgo.Button{
-				CustomID: xid.New().String(),
+				CustomID: components.NewID(),
 				Label:    "Purge all Backups",
 				Style:    discordgo.DangerButton,
 			}, func(ctx ken.ComponentContext) bool {
@@ -231,7 +235,7 @@ func (c *Backup) Run(ctx ken.Context) (err error) {
 		}
 
 		b.Add(discordgo.Button{
-			CustomID: xid.New().String(),
+			CustomID: components.NewID(),
 			Label:    "Cancel",
 			Style:    discordgo.SecondaryButton,
 		}, func(ctx ken.ComponentContext) bool {
@@ -245,7 +249,11 @@ func (c *Backup) Run(ctx ken.Context) (err error) {
 		return err
 	}
 
-	id := <-cNext
+	var id string
+	select {
+	case id = <-cNext:
+	case <-time.After(backupMenuLifetime):
+	}
 
 	unreg()
 	fum.Delete()
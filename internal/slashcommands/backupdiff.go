@@ -0,0 +1,208 @@
+package slashcommands
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/zekroTJA/shinpuru/internal/services/backup"
+	"github.com/zekroTJA/shinpuru/internal/services/backup/backupmodels"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/services/permissions"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekrotja/ken"
+)
+
+// backupDiffMaxFieldLen is the maximum length of the
+// content of an embed field of the backup diff.
+const backupDiffMaxFieldLen = 1000
+
+type BackupDiff struct{}
+
+var (
+	_ ken.SlashCommand        = (*BackupDiff)(nil)
+	_ permissions.PermCommand = (*BackupDiff)(nil)
+)
+
+func (c *BackupDiff) Name() string {
+	return "backupdiff"
+}
+
+func (c *BackupDiff) Description() string {
+	return "Compare two guild backups."
+}
+
+func (c *BackupDiff) Version() string {
+	return "1.0.0"
+}
+
+func (c *BackupDiff) Type() discordgo.ApplicationCommandType {
+	return discordgo.ChatApplicationCommand
+}
+
+func (c *BackupDiff) Options() []*discordgo.ApplicationCommandOption {
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "from",
+			Description: "The ID or the index (as listed by /backup) of the older backup.",
+			Required:    true,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "to",
+			Description: "The ID or the index of the newer backup (defaults to the latest backup).",
+		},
+	}
+}
+
+func (c *BackupDiff) Domain() string {
+	return "sp.guild.admin.backup"
+}
+
+func (c *BackupDiff) SubDomains() []permissions.SubPermission {
+	return nil
+}
+
+func (c *BackupDiff) Run(ctx ken.Context) (err error) {
+	if err = ctx.Defer(); err != nil {
+		return
+	}
+
+	db := ctx.Get(static.DiDatabase).(database.Database)
+	bck := ctx.Get(static.DiBackupHandler).(*backup.GuildBackups)
+
+	guildID := ctx.GetEvent().GuildID
+
+	backups, err := db.GetBackups(guildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return
+	}
+	if len(backups) == 0 {
+		return ctx.FollowUpError("There are no backups saved for this guild.", "").
+			Send().Error
+	}
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].Timestamp.Before(backups[j].Timestamp)
+	})
+
+	fromID := c.resolveBackupID(ctx.Options().GetByName("from").StringValue(), backups)
+	toID := backups[len(backups)-1].FileID
+	if v, ok := ctx.Options().GetByNameOptional("to"); ok {
+		toID = c.resolveBackupID(v.StringValue(), backups)
+	}
+
+	diff, err := bck.DiffBackups(guildID, fromID, toID)
+	if database.IsErrDatabaseNotFound(err) {
+		return ctx.FollowUpError("Backup could not be found.", "").
+			Send().Error
+	}
+	if err != nil {
+		return
+	}
+
+	return ctx.FollowUpEmbed(c.diffEmbed(diff)).Send().Error
+}
+
+// resolveBackupID returns the file ID of the backup with
+// the given index, if v is a valid index. Otherwise, v is
+// returned as file ID.
+func (c *BackupDiff) resolveBackupID(v string, backups []backupmodels.Entry) string {
+	v = strings.TrimSpace(v)
+	if i, err := strconv.Atoi(v); err == nil && i >= 0 && i < len(backups) {
+		return backups[i].FileID
+	}
+	return v
+}
+
+func (c *BackupDiff) diffEmbed(diff *backupmodels.Diff) *discordgo.MessageEmbed {
+	emb := &discordgo.MessageEmbed{
+		Color: static.ColorEmbedDefault,
+		Title: "Backup Diff",
+		Description: fmt.Sprintf("From: %s\nTo: %s",
+			diff.From.String(), diff.To.String()),
+	}
+
+	if diff.Empty() {
+		emb.Description += "\n\nThere are no differences between both backups."
+	}
+
+	if len(diff.Settings) != 0 {
+		lines := make([]string, len(diff.Settings))
+		for i, ch := range diff.Settings {
+			lines[i] = formatChange("", ch)
+		}
+		emb.Fields = append(emb.Fields, &discordgo.MessageEmbedField{
+			Name:  "Settings",
+			Value: diffBlock(lines),
+		})
+	}
+
+	if !diff.Roles.Empty() {
+		emb.Fields = append(emb.Fields, &discordgo.MessageEmbedField{
+			Name:  "Roles",
+			Value: diffBlock(formatObjectsDiff(diff.Roles)),
+		})
+	}
+
+	if !diff.Channels.Empty() {
+		emb.Fields = append(emb.Fields, &discordgo.MessageEmbedField{
+			Name:  "Channels",
+			Value: diffBlock(formatObjectsDiff(diff.Channels)),
+		})
+	}
+
+	if diff.MembersAdded != 0 || diff.MembersRemoved != 0 {
+		emb.Fields = append(emb.Fields, &discordgo.MessageEmbedField{
+			Name:  "Members",
+			Value: fmt.Sprintf("Joined: `%d`\nLeft: `%d`", diff.MembersAdded, diff.MembersRemoved),
+		})
+	}
+
+	if len(diff.Notes) != 0 {
+		emb.Fields = append(emb.Fields, &discordgo.MessageEmbedField{
+			Name:  "Notes",
+			Value: "- " + strings.Join(diff.Notes, "\n- "),
+		})
+	}
+
+	return emb
+}
+
+func formatObjectsDiff(d backupmodels.ObjectsDiff) (lines []string) {
+	for _, o := range d.Added {
+		lines = append(lines, "+ "+o.Name)
+	}
+	for _, o := range d.Removed {
+		lines = append(lines, "- "+o.Name)
+	}
+	for _, o := range d.Modified {
+		for _, ch := range o.Changes {
+			lines = append(lines, formatChange(o.Name, ch))
+		}
+	}
+	return
+}
+
+func formatChange(name string, ch backupmodels.Change) string {
+	if name != "" {
+		name += " "
+	}
+	return fmt.Sprintf("~ %s%s: %s → %s", name, ch.Field, ch.Old, ch.New)
+}
+
+// diffBlock joins the given lines to a diff code block
+// and truncates it to backupDiffMaxFieldLen.
+func diffBlock(lines []string) string {
+	var sb strings.Builder
+	for i, l := range lines {
+		if sb.Len()+len(l) > backupDiffMaxFieldLen {
+			fmt.Fprintf(&sb, "… and %d more\n", len(lines)-i)
+			break
+		}
+		sb.WriteString(l + "\n")
+	}
+	return "```diff\n" + sb.String() + "```"
+}
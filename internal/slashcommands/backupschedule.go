@@ -0,0 +1,233 @@
+package slashcommands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/zekroTJA/shinpuru/internal/services/backup"
+	"github.com/zekroTJA/shinpuru/internal/services/backup/backupmodels"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/services/permissions"
+	"github.com/zekroTJA/shinpuru/internal/services/timeprovider"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekrotja/ken"
+)
+
+type BackupSchedule struct{}
+
+var (
+	_ ken.SlashCommand        = (*BackupSchedule)(nil)
+	_ permissions.PermCommand = (*BackupSchedule)(nil)
+)
+
+func (c *BackupSchedule) Name() string {
+	return "backupschedule"
+}
+
+func (c *BackupSchedule) Description() string {
+	return "Manage recurring guild backups."
+}
+
+func (c *BackupSchedule) Version() string {
+	return "1.0.0"
+}
+
+func (c *BackupSchedule) Type() discordgo.ApplicationCommandType {
+	return discordgo.ChatApplicationCommand
+}
+
+func (c *BackupSchedule) Options() []*discordgo.ApplicationCommandOption {
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "set",
+			Description: "Set the backup schedule of the guild.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type: discordgo.ApplicationCommandOptionString,
+					Name: "schedule",
+					Description: "'daily', 'weekly' or a cron spec like '0 4 * * 1' " +
+						"(minute, hour, day, month, weekday; UTC).",
+					Required: true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "retention",
+					Description: fmt.Sprintf("The number of backups to keep (1 to %d).", backup.MaxBackups),
+				},
+				{
+					Type:         discordgo.ApplicationCommandOptionChannel,
+					Name:         "notify_channel",
+					Description:  "A channel where a summary is sent to after each backup.",
+					ChannelTypes: []discordgo.ChannelType{discordgo.ChannelTypeGuildText},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionBoolean,
+					Name:        "notify_owner",
+					Description: "Send a summary to the guild owner via DM after each backup.",
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "show",
+			Description: "Show the backup schedule of the guild.",
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "remove",
+			Description: "Remove the backup schedule of the guild.",
+		},
+	}
+}
+
+func (c *BackupSchedule) Domain() string {
+	return "sp.guild.admin.backup"
+}
+
+func (c *BackupSchedule) SubDomains() []permissions.SubPermission {
+	return nil
+}
+
+func (c *BackupSchedule) Run(ctx ken.Context) (err error) {
+	if err = ctx.Defer(); err != nil {
+		return
+	}
+
+	err = ctx.HandleSubCommands(
+		ken.SubCommandHandler{"set", c.set},
+		ken.SubCommandHandler{"show", c.show},
+		ken.SubCommandHandler{"remove", c.remove},
+	)
+
+	return
+}
+
+func (c *BackupSchedule) set(ctx ken.SubCommandContext) (err error) {
+	db := ctx.Get(static.DiDatabase).(database.Database)
+	tp := ctx.Get(static.DiTimeProvider).(timeprovider.Provider)
+
+	guildID := ctx.GetEvent().GuildID
+
+	sched, err := db.GetBackupSchedule(guildID)
+	if database.IsErrDatabaseNotFound(err) {
+		sched = backupmodels.Schedule{Retention: backup.MaxBackups}
+		err = nil
+	}
+	if err != nil {
+		return
+	}
+	sched.GuildID = guildID
+
+	spec := strings.TrimSpace(ctx.Options().GetByName("schedule").StringValue())
+	switch strings.ToLower(spec) {
+	case "daily":
+		spec = "@daily"
+	case "weekly":
+		spec = "@weekly"
+	}
+	if _, err = backupmodels.ParseScheduleSpec(spec, tp.Now()); err != nil {
+		return ctx.FollowUpError(fmt.Sprintf("Invalid schedule: %s", err.Error()), "").
+			Send().Error
+	}
+	sched.Spec = spec
+
+	if v, ok := ctx.Options().GetByNameOptional("retention"); ok {
+		sched.Retention = int(v.IntValue())
+		if sched.Retention < 1 || sched.Retention > backup.MaxBackups {
+			return ctx.FollowUpError(
+				fmt.Sprintf("Retention must be in range of `1` to `%d`.", backup.MaxBackups), "").
+				Send().Error
+		}
+	}
+	if v, ok := ctx.Options().GetByNameOptional("notify_channel"); ok {
+		sched.NotifyChannelID = v.ChannelValue(ctx).ID
+	}
+	if v, ok := ctx.Options().GetByNameOptional("notify_owner"); ok {
+		sched.NotifyOwner = v.BoolValue()
+	}
+
+	// The schedule starts counting from now so that
+	// no backup is created right after setting it.
+	sched.LastRun = tp.Now()
+
+	if err = db.SetBackupSchedule(sched); err != nil {
+		return
+	}
+	if err = db.SetGuildBackup(guildID, true); err != nil {
+		return
+	}
+
+	return ctx.FollowUpEmbed(c.scheduleEmbed(sched, "Backup schedule set. Backups have been enabled for this guild.")).
+		Send().Error
+}
+
+func (c *BackupSchedule) show(ctx ken.SubCommandContext) (err error) {
+	db := ctx.Get(static.DiDatabase).(database.Database)
+
+	sched, err := db.GetBackupSchedule(ctx.GetEvent().GuildID)
+	if database.IsErrDatabaseNotFound(err) {
+		return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+			Description: "No backup schedule is set. Backups are created at the global backup times, " +
+				"if backups are enabled.",
+		}).Send().Error
+	}
+	if err != nil {
+		return
+	}
+
+	return ctx.FollowUpEmbed(c.scheduleEmbed(sched, "")).Send().Error
+}
+
+func (c *BackupSchedule) remove(ctx ken.SubCommandContext) (err error) {
+	db := ctx.Get(static.DiDatabase).(database.Database)
+
+	err = db.DeleteBackupSchedule(ctx.GetEvent().GuildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return
+	}
+
+	return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+		Description: "Backup schedule removed. Backups are now created at the global backup times, " +
+			"if backups are enabled.",
+	}).Send().Error
+}
+
+func (c *BackupSchedule) scheduleEmbed(sched backupmodels.Schedule, description string) *discordgo.MessageEmbed {
+	notify := []string{}
+	if sched.NotifyChannelID != "" {
+		notify = append(notify, "<#"+sched.NotifyChannelID+">")
+	}
+	if sched.NotifyOwner {
+		notify = append(notify, "Guild owner via DM")
+	}
+	if len(notify) == 0 {
+		notify = append(notify, "*none*")
+	}
+
+	return &discordgo.MessageEmbed{
+		Color:       static.ColorEmbedDefault,
+		Description: description,
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name:   "Schedule",
+				Value:  "`" + sched.Spec + "` (UTC)",
+				Inline: true,
+			},
+			{
+				Name:   "Retention",
+				Value:  fmt.Sprintf("`%d` backups", sched.Retention),
+				Inline: true,
+			},
+			{
+				Name:  "Notifications",
+				Value: strings.Join(notify, "\n"),
+			},
+			{
+				Name:  "Next Backup",
+				Value: fmt.Sprintf("<t:%d:F>", sched.Next().Unix()),
+			},
+		},
+	}
+}
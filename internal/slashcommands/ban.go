@@ -1,6 +1,8 @@
 package slashcommands
 
 import (
+	"fmt"
+
 	"github.com/bwmarrin/discordgo"
 	"github.com/zekroTJA/shinpuru/internal/models"
 	"github.com/zekroTJA/shinpuru/internal/services/permissions"
@@ -46,7 +48,7 @@ func (c *Ban) Options() []*discordgo.ApplicationCommandOption {
 		{
 			Type:        discordgo.ApplicationCommandOptionString,
 			Name:        "reason",
-			Description: "A short and concise report reason.",
+			Description: "The report reason. Supports the flags --reason \"...\" and --silent.",
 			Required:    true,
 		},
 		{
@@ -77,5 +79,15 @@ func (c *Ban) Run(ctx ken.Context) (err error) {
 
 	tp := ctx.Get(static.DiTimeProvider).(timeprovider.Provider)
 
+	victim := ctx.Options().GetByName("user").UserValue(ctx)
+	confirmed, err := cmdutil.ConfirmIfRequired(ctx, models.ConfirmActionBan,
+		fmt.Sprintf("Do you really want to ban %s?", victim.Mention()))
+	if err != nil {
+		return
+	}
+	if !confirmed {
+		return ctx.FollowUpError("The ban has been cancelled.", "").Send().Error
+	}
+
 	return cmdutil.CmdReport(ctx, models.TypeBan, tp)
 }
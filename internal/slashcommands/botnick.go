@@ -0,0 +1,146 @@
+package slashcommands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/services/permissions"
+	"github.com/zekroTJA/shinpuru/internal/util/botnick"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekrotja/ken"
+)
+
+type Botnick struct{}
+
+var (
+	_ ken.SlashCommand        = (*Botnick)(nil)
+	_ permissions.PermCommand = (*Botnick)(nil)
+)
+
+func (c *Botnick) Name() string {
+	return "botnick"
+}
+
+func (c *Botnick) Description() string {
+	return "Set the nickname of the bot in this guild."
+}
+
+func (c *Botnick) Version() string {
+	return "1.0.0"
+}
+
+func (c *Botnick) Type() discordgo.ApplicationCommandType {
+	return discordgo.ChatApplicationCommand
+}
+
+func (c *Botnick) Options() []*discordgo.ApplicationCommandOption {
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "set",
+			Description: "Set the nickname of the bot.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "nickname",
+					Description: fmt.Sprintf("The nickname (max. %d characters).", botnick.MaxLength),
+					Required:    true,
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "apply",
+			Description: "Apply the configured nickname again.",
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "reset",
+			Description: "Remove the configured nickname.",
+		},
+	}
+}
+
+func (c *Botnick) Domain() string {
+	return "sp.guild.config.botnick"
+}
+
+func (c *Botnick) SubDomains() []permissions.SubPermission {
+	return nil
+}
+
+func (c *Botnick) Run(ctx ken.Context) (err error) {
+	if err = ctx.Defer(); err != nil {
+		return
+	}
+
+	err = ctx.HandleSubCommands(
+		ken.SubCommandHandler{"set", c.set},
+		ken.SubCommandHandler{"apply", c.apply},
+		ken.SubCommandHandler{"reset", c.reset},
+	)
+
+	return
+}
+
+func (c *Botnick) set(ctx ken.SubCommandContext) (err error) {
+	db := ctx.Get(static.DiDatabase).(database.Database)
+
+	nickname := strings.TrimSpace(ctx.Options().GetByName("nickname").StringValue())
+	if err = botnick.Validate(nickname); err != nil {
+		return ctx.FollowUpError(fmt.Sprintf("The nickname must not be longer than %d characters.",
+			botnick.MaxLength), "").Send().Error
+	}
+
+	if err = db.SetGuildBotNickname(ctx.GetEvent().GuildID, nickname); err != nil {
+		return
+	}
+
+	return c.applyAndRespond(ctx, nickname, fmt.Sprintf("The nickname of the bot has been set to `%s`.", nickname))
+}
+
+func (c *Botnick) apply(ctx ken.SubCommandContext) (err error) {
+	db := ctx.Get(static.DiDatabase).(database.Database)
+
+	nickname, err := db.GetGuildBotNickname(ctx.GetEvent().GuildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return
+	}
+	if nickname == "" {
+		return ctx.FollowUpError("No nickname is configured for this guild.", "").
+			Send().Error
+	}
+
+	return c.applyAndRespond(ctx, nickname, fmt.Sprintf("The nickname `%s` has been applied.", nickname))
+}
+
+func (c *Botnick) reset(ctx ken.SubCommandContext) (err error) {
+	db := ctx.Get(static.DiDatabase).(database.Database)
+
+	if err = db.SetGuildBotNickname(ctx.GetEvent().GuildID, ""); err != nil {
+		return
+	}
+
+	return c.applyAndRespond(ctx, "", "The nickname of the bot has been reset.")
+}
+
+func (c *Botnick) applyAndRespond(ctx ken.SubCommandContext, nickname, msg string) error {
+	err := botnick.Apply(ctx.GetSession(), ctx.GetEvent().GuildID, nickname)
+	if err == botnick.ErrMissingPermission {
+		return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+			Color: static.ColorEmbedOrange,
+			Description: "The nickname has been saved but could not be applied because the bot is missing " +
+				"the **Change Nickname** permission. Grant the permission and use `/botnick apply` to apply it.",
+		}).Send().Error
+	}
+	if err != nil {
+		return err
+	}
+
+	return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+		Color:       static.ColorEmbedGreen,
+		Description: msg,
+	}).Send().Error
+}
@@ -0,0 +1,233 @@
+package slashcommands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/services/permissions"
+	"github.com/zekroTJA/shinpuru/internal/util/cmdblocklist"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekrotja/ken"
+)
+
+type Cmdblocklist struct {
+	ken.EphemeralCommand
+}
+
+var (
+	_ ken.SlashCommand        = (*Cmdblocklist)(nil)
+	_ permissions.PermCommand = (*Cmdblocklist)(nil)
+)
+
+func (c *Cmdblocklist) Name() string {
+	return "cmdblocklist"
+}
+
+func (c *Cmdblocklist) Description() string {
+	return "Manage the global command blocklist."
+}
+
+func (c *Cmdblocklist) Version() string {
+	return "1.0.0"
+}
+
+func (c *Cmdblocklist) Type() discordgo.ApplicationCommandType {
+	return discordgo.ChatApplicationCommand
+}
+
+func (c *Cmdblocklist) Options() []*discordgo.ApplicationCommandOption {
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "list",
+			Description: "List all globally blocked commands.",
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "add",
+			Description: "Block a command on all guilds.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "command",
+					Description: "The name of the command.",
+					Required:    true,
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "remove",
+			Description: "Unblock a globally blocked command.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "command",
+					Description: "The name of the command.",
+					Required:    true,
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "silent",
+			Description: "Set whether blocked commands are ignored instead of answered with a maintenance notice.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionBoolean,
+					Name:        "enable",
+					Description: "Ignore blocked commands silently.",
+					Required:    true,
+				},
+			},
+		},
+	}
+}
+
+func (c *Cmdblocklist) Domain() string {
+	return "sp.cmdblocklist"
+}
+
+func (c *Cmdblocklist) SubDomains() []permissions.SubPermission {
+	return nil
+}
+
+func (c *Cmdblocklist) Run(ctx ken.Context) (err error) {
+	if err = ctx.Defer(); err != nil {
+		return
+	}
+
+	err = ctx.HandleSubCommands(
+		ken.SubCommandHandler{"list", c.list},
+		ken.SubCommandHandler{"add", c.add},
+		ken.SubCommandHandler{"remove", c.remove},
+		ken.SubCommandHandler{"silent", c.silent},
+	)
+
+	return
+}
+
+func (c *Cmdblocklist) list(ctx ken.SubCommandContext) (err error) {
+	db := ctx.Get(static.DiDatabase).(database.Database)
+
+	bl, err := cmdblocklist.Get(db)
+	if err != nil {
+		return
+	}
+
+	desc := "No commands are blocked."
+	if len(bl.Commands) != 0 {
+		desc = "`/" + strings.Join(bl.Commands, "`\n`/") + "`"
+	}
+
+	mode := "maintenance notice"
+	if bl.Silent {
+		mode = "silently ignored"
+	}
+
+	return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+		Title:       "Globally Blocked Commands",
+		Description: desc,
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: "Blocked commands are " + mode,
+		},
+	}).Send().Error
+}
+
+func (c *Cmdblocklist) add(ctx ken.SubCommandContext) (err error) {
+	db := ctx.Get(static.DiDatabase).(database.Database)
+
+	name := strings.ToLower(strings.TrimPrefix(
+		ctx.Options().GetByName("command").StringValue(), "/"))
+
+	if name == c.Name() {
+		return ctx.FollowUpError("This command can not be blocked.", "").Send().Error
+	}
+
+	if !commandExists(ctx.GetKen(), name) {
+		return ctx.FollowUpError(
+			fmt.Sprintf("There is no command with the name `%s`.", name), "").
+			Send().Error
+	}
+
+	bl, err := cmdblocklist.Get(db)
+	if err != nil {
+		return
+	}
+
+	if !bl.Add(name) {
+		return ctx.FollowUpError(
+			fmt.Sprintf("The command `/%s` is already blocked.", name), "").
+			Send().Error
+	}
+
+	if err = cmdblocklist.Set(db, bl); err != nil {
+		return
+	}
+
+	return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+		Color:       static.ColorEmbedOrange,
+		Description: fmt.Sprintf("The command `/%s` is now blocked on all guilds.", name),
+	}).Send().Error
+}
+
+func (c *Cmdblocklist) remove(ctx ken.SubCommandContext) (err error) {
+	db := ctx.Get(static.DiDatabase).(database.Database)
+
+	name := strings.ToLower(strings.TrimPrefix(
+		ctx.Options().GetByName("command").StringValue(), "/"))
+
+	bl, err := cmdblocklist.Get(db)
+	if err != nil {
+		return
+	}
+
+	if !bl.Remove(name) {
+		return ctx.FollowUpError(
+			fmt.Sprintf("The command `/%s` is not blocked.", name), "").
+			Send().Error
+	}
+
+	if err = cmdblocklist.Set(db, bl); err != nil {
+		return
+	}
+
+	return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+		Color:       static.ColorEmbedGreen,
+		Description: fmt.Sprintf("The command `/%s` is no longer blocked.", name),
+	}).Send().Error
+}
+
+func (c *Cmdblocklist) silent(ctx ken.SubCommandContext) (err error) {
+	db := ctx.Get(static.DiDatabase).(database.Database)
+
+	bl, err := cmdblocklist.Get(db)
+	if err != nil {
+		return
+	}
+
+	bl.Silent = ctx.Options().GetByName("enable").BoolValue()
+	if err = cmdblocklist.Set(db, bl); err != nil {
+		return
+	}
+
+	desc := "Blocked commands are now answered with a maintenance notice."
+	if bl.Silent {
+		desc = "Blocked commands are now silently ignored."
+	}
+
+	return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+		Description: desc,
+	}).Send().Error
+}
+
+func commandExists(k ken.IKen, name string) bool {
+	for _, info := range k.GetCommandInfo() {
+		if info.ApplicationCommand.Name == name {
+			return true
+		}
+	}
+	return false
+}
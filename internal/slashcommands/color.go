@@ -0,0 +1,87 @@
+package slashcommands
+
+import (
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/zekroTJA/shinpuru/internal/services/config"
+	"github.com/zekroTJA/shinpuru/internal/services/permissions"
+	"github.com/zekroTJA/shinpuru/internal/util"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/pkg/colors"
+	"github.com/zekrotja/ken"
+)
+
+type Color struct{}
+
+var (
+	_ ken.SlashCommand        = (*Color)(nil)
+	_ permissions.PermCommand = (*Color)(nil)
+)
+
+func (c *Color) Name() string {
+	return "color"
+}
+
+func (c *Color) Description() string {
+	return "Color utilities."
+}
+
+func (c *Color) Version() string {
+	return "1.0.0"
+}
+
+func (c *Color) Type() discordgo.ApplicationCommandType {
+	return discordgo.ChatApplicationCommand
+}
+
+func (c *Color) Options() []*discordgo.ApplicationCommandOption {
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "convert",
+			Description: "Display a color in all supported formats.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "color",
+					Description: "The color as hex, rgb(...), hsl(...), integer or CSS color name.",
+					Required:    true,
+				},
+			},
+		},
+	}
+}
+
+func (c *Color) Domain() string {
+	return "sp.chat.color"
+}
+
+func (c *Color) SubDomains() []permissions.SubPermission {
+	return nil
+}
+
+func (c *Color) Run(ctx ken.Context) (err error) {
+	if err = ctx.Defer(); err != nil {
+		return
+	}
+
+	err = ctx.HandleSubCommands(
+		ken.SubCommandHandler{"convert", c.convert},
+	)
+
+	return
+}
+
+func (c *Color) convert(ctx ken.SubCommandContext) (err error) {
+	cfg := ctx.Get(static.DiConfig).(config.Provider)
+
+	clr, err := colors.ParseAny(ctx.Options().GetByName("color").StringValue())
+	if err != nil {
+		return ctx.FollowUpError(
+			fmt.Sprintf("Invalid color value:\n```\n%s\n```", err.Error()), "").
+			Send().Error
+	}
+
+	return ctx.FollowUpEmbed(util.ColorEmbed(clr, cfg.Config().WebServer.PublicAddr)).Send().Error
+}
@@ -0,0 +1,247 @@
+package slashcommands
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/zekroTJA/shinpuru/internal/middleware"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/services/permissions"
+	"github.com/zekroTJA/shinpuru/internal/util"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/pkg/stringutil"
+	"github.com/zekrotja/ken"
+)
+
+type Commands struct {
+	ken.EphemeralCommand
+}
+
+var (
+	_ ken.SlashCommand        = (*Commands)(nil)
+	_ permissions.PermCommand = (*Commands)(nil)
+)
+
+func (c *Commands) Name() string {
+	return "commands"
+}
+
+func (c *Commands) Description() string {
+	return "Enable or disable commands on this guild."
+}
+
+func (c *Commands) Version() string {
+	return "1.0.0"
+}
+
+func (c *Commands) Type() discordgo.ApplicationCommandType {
+	return discordgo.ChatApplicationCommand
+}
+
+func (c *Commands) Options() []*discordgo.ApplicationCommandOption {
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "list",
+			Description: "List the commands disabled on this guild.",
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "disable",
+			Description: "Disable a command on this guild.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "command",
+					Description: "The name of the command.",
+					Required:    true,
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "enable",
+			Description: "Enable a previously disabled command on this guild.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "command",
+					Description: "The name of the command.",
+					Required:    true,
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "message",
+			Description: "Show or set the message responded when a disabled command is used.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "message",
+					Description: "The message.",
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionBoolean,
+					Name:        "silent",
+					Description: "Ignore disabled commands instead of responding with a message.",
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionBoolean,
+					Name:        "reset",
+					Description: "Reset to the default message.",
+				},
+			},
+		},
+	}
+}
+
+func (c *Commands) Domain() string {
+	return "sp.guild.config.commands"
+}
+
+func (c *Commands) SubDomains() []permissions.SubPermission {
+	return nil
+}
+
+func (c *Commands) Run(ctx ken.Context) (err error) {
+	if err = ctx.Defer(); err != nil {
+		return
+	}
+
+	err = ctx.HandleSubCommands(
+		ken.SubCommandHandler{"list", c.list},
+		ken.SubCommandHandler{"disable", c.disable},
+		ken.SubCommandHandler{"enable", c.enable},
+		ken.SubCommandHandler{"message", c.message},
+	)
+
+	return
+}
+
+func (c *Commands) list(ctx ken.SubCommandContext) (err error) {
+	db := ctx.Get(static.DiDatabase).(database.Database)
+
+	disabled, err := db.GetGuildDisabledCommands(ctx.GetEvent().GuildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return
+	}
+
+	if len(disabled) == 0 {
+		return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+			Description: "There are no commands disabled on this guild.",
+		}).Send().Error
+	}
+
+	sort.Strings(disabled)
+	for i, cmd := range disabled {
+		disabled[i] = fmt.Sprintf("`/%s`", cmd)
+	}
+
+	return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+		Color:       util.GuildEmbedColor(db, ctx.GetEvent().GuildID),
+		Title:       "Disabled Commands",
+		Description: strings.Join(disabled, "\n"),
+	}).Send().Error
+}
+
+func (c *Commands) disable(ctx ken.SubCommandContext) (err error) {
+	return c.setDisabled(ctx, true)
+}
+
+func (c *Commands) enable(ctx ken.SubCommandContext) (err error) {
+	return c.setDisabled(ctx, false)
+}
+
+func (c *Commands) setDisabled(ctx ken.SubCommandContext, disabled bool) (err error) {
+	db := ctx.Get(static.DiDatabase).(database.Database)
+
+	name := strings.ToLower(strings.TrimPrefix(ctx.Options().GetByName("command").StringValue(), "/"))
+
+	if !commandExists(ctx.GetKen(), name) {
+		return ctx.FollowUpError(
+			fmt.Sprintf("There is no command with the name `%s`.", name), "").
+			Send().Error
+	}
+
+	if disabled && stringutil.ContainsAny(name, static.EssentialCommands) {
+		return ctx.FollowUpError(
+			fmt.Sprintf("The command `%s` is essential and can not be disabled.", name), "").
+			Send().Error
+	}
+
+	if err = db.SetGuildDisabledCommand(ctx.GetEvent().GuildID, name, disabled); err != nil {
+		return
+	}
+
+	return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+		Description: fmt.Sprintf("The command `/%s` has been %s on this guild.",
+			name, stringutil.FromBool(disabled, "disabled", "enabled")),
+	}).Send().Error
+}
+
+func (c *Commands) message(ctx ken.SubCommandContext) (err error) {
+	db := ctx.Get(static.DiDatabase).(database.Database)
+	guildID := ctx.GetEvent().GuildID
+
+	if resetV, ok := ctx.Options().GetByNameOptional("reset"); ok && resetV.BoolValue() {
+		if err = db.SetGuildDisabledCommandMessage(guildID, "", false); err != nil {
+			return
+		}
+		return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+			Description: "Disabled command message has been reset to default.",
+		}).Send().Error
+	}
+
+	msg, silent, err := db.GetGuildDisabledCommandMessage(guildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return
+	}
+
+	msgV, okMsg := ctx.Options().GetByNameOptional("message")
+	silentV, okSilent := ctx.Options().GetByNameOptional("silent")
+
+	if okMsg {
+		msg = msgV.StringValue()
+		if len(msg) > middleware.MaxGuildDisabledMessageLength {
+			return ctx.FollowUpError(
+				fmt.Sprintf("The message must not be longer than %d characters.",
+					middleware.MaxGuildDisabledMessageLength), "").
+				Send().Error
+		}
+	}
+	if okSilent {
+		silent = silentV.BoolValue()
+	}
+
+	if okMsg || okSilent {
+		if err = db.SetGuildDisabledCommandMessage(guildID, msg, silent); err != nil {
+			return
+		}
+	}
+
+	if msg == "" {
+		msg = middleware.DefaultGuildDisabledMessage
+	}
+
+	title := "Disabled command message"
+	if okMsg || okSilent {
+		title = "Disabled command message updated"
+	}
+
+	return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+		Title: title,
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name:  "Message",
+				Value: msg,
+			},
+			{
+				Name:  "Silent",
+				Value: stringutil.FromBool(silent, "Yes, disabled commands are ignored.", "No"),
+			},
+		},
+	}).Send().Error
+}
@@ -0,0 +1,186 @@
+package slashcommands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/zekroTJA/shinpuru/internal/middleware"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/services/permissions"
+	"github.com/zekroTJA/shinpuru/internal/util/cmdutil"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/pkg/intutil"
+	"github.com/zekroTJA/shinpuru/pkg/stringutil"
+	"github.com/zekrotja/ken"
+)
+
+type Commandsuggestions struct {
+	ken.EphemeralCommand
+}
+
+var (
+	_ ken.SlashCommand              = (*Commandsuggestions)(nil)
+	_ permissions.PermCommand       = (*Commandsuggestions)(nil)
+	_ middleware.GuildScopedCommand = (*Commandsuggestions)(nil)
+)
+
+func (c *Commandsuggestions) Name() string {
+	return "commandsuggestions"
+}
+
+func (c *Commandsuggestions) Description() string {
+	return "Configure command suggestions and prefix-less channels."
+}
+
+func (c *Commandsuggestions) Version() string {
+	return "1.2.0"
+}
+
+func (c *Commandsuggestions) Type() discordgo.ApplicationCommandType {
+	return discordgo.ChatApplicationCommand
+}
+
+func (c *Commandsuggestions) Options() []*discordgo.ApplicationCommandOption {
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionBoolean,
+			Name:        "enable",
+			Description: "Set the enabled state of command suggestions.",
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionBoolean,
+			Name:        "prefixless",
+			Description: "Set if messages in the channel are handled without the guild prefix.",
+		},
+		{
+			Type:         discordgo.ApplicationCommandOptionChannel,
+			Name:         "channel",
+			Description:  "The channel to configure (defaults to the current channel).",
+			ChannelTypes: []discordgo.ChannelType{discordgo.ChannelTypeGuildText},
+		},
+		cmdutil.GuildOption(),
+	}
+}
+
+func (c *Commandsuggestions) Domain() string {
+	return "sp.guild.config.cmdsuggestions"
+}
+
+func (c *Commandsuggestions) SubDomains() []permissions.SubPermission {
+	return nil
+}
+
+func (c *Commandsuggestions) IsDmCapable() bool {
+	return true
+}
+
+func (c *Commandsuggestions) IsGuildScoped() bool {
+	return true
+}
+
+func (c *Commandsuggestions) Run(ctx ken.Context) (err error) {
+	if err = ctx.Defer(); err != nil {
+		return
+	}
+
+	db := ctx.Get(static.DiDatabase).(database.Database)
+
+	enableV, hasEnable := ctx.Options().GetByNameOptional("enable")
+	prefixlessV, hasPrefixless := ctx.Options().GetByNameOptional("prefixless")
+
+	var enable bool
+	if hasEnable || hasPrefixless {
+		lines := make([]string, 0, 2)
+
+		// Resolve the channel before anything is changed so
+		// that no change is applied when it is missing.
+		var channelID string
+		if hasPrefixless {
+			var ok bool
+			if channelID, ok = c.prefixlessChannel(ctx); !ok {
+				return ctx.FollowUpError("Please specify the channel to configure.", "").Send().Error
+			}
+		}
+
+		if hasEnable {
+			enable = enableV.BoolValue()
+			if err = db.SetGuildCommandSuggestionsDisable(ctx.GetEvent().GuildID, !enable); err != nil {
+				return
+			}
+			lines = append(lines, fmt.Sprintf("Command suggestions have been %s.",
+				stringutil.FromBool(enable, "enabled", "disabled")))
+		}
+
+		if hasPrefixless {
+			prefixless := prefixlessV.BoolValue()
+			if err = c.setPrefixless(db, ctx.GetEvent().GuildID, channelID, prefixless); err != nil {
+				return
+			}
+			lines = append(lines, fmt.Sprintf("Prefix-less mode has been %s in <#%s>.",
+				stringutil.FromBool(prefixless, "enabled", "disabled"), channelID))
+			if !hasEnable {
+				enable = prefixless
+			}
+		}
+
+		err = ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+			Description: strings.Join(lines, "\n"),
+			Color:       intutil.FromBool(enable, static.ColorEmbedGreen, static.ColorEmbedOrange),
+		}).Send().Error
+	} else {
+		var disabled bool
+		disabled, err = db.GetGuildCommandSuggestionsDisable(ctx.GetEvent().GuildID)
+		if err != nil && !database.IsErrDatabaseNotFound(err) {
+			return
+		}
+		enable = !disabled
+
+		var channels []string
+		channels, err = db.GetPrefixlessChannels(ctx.GetEvent().GuildID)
+		if err != nil && !database.IsErrDatabaseNotFound(err) {
+			return
+		}
+		prefixless := "*none*"
+		if len(channels) > 0 {
+			prefixless = "<#" + strings.Join(channels, ">, <#") + ">"
+		}
+
+		err = ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+			Description: fmt.Sprintf("Command suggestions are currently %s.\n"+
+				"Suggestions are only shown for messages starting with the guild prefix, "+
+				"which can be set in the web interface, or for messages in prefix-less channels.\n\n"+
+				"**Prefix-less channels:** %s",
+				stringutil.FromBool(enable, "enabled", "disabled"), prefixless),
+			Color: intutil.FromBool(enable, static.ColorEmbedGreen, static.ColorEmbedOrange),
+		}).Send().Error
+	}
+
+	return
+}
+
+// prefixlessChannel returns the ID of the channel passed
+// via the channel option or the current channel when the
+// command is executed in the guild. ok is false if no
+// channel could be determined.
+func (c *Commandsuggestions) prefixlessChannel(ctx ken.Context) (channelID string, ok bool) {
+	if channelV, ok := ctx.Options().GetByNameOptional("channel"); ok {
+		return channelV.ChannelValue(ctx).ID, true
+	}
+	if ctx.GetEvent().Member != nil {
+		return ctx.GetEvent().ChannelID, true
+	}
+	return "", false
+}
+
+func (c *Commandsuggestions) setPrefixless(db database.Database, guildID, channelID string, enable bool) (err error) {
+	if enable {
+		return db.AddPrefixlessChannel(guildID, channelID)
+	}
+
+	err = db.RemovePrefixlessChannel(guildID, channelID)
+	if database.IsErrDatabaseNotFound(err) {
+		err = nil
+	}
+	return
+}
@@ -0,0 +1,156 @@
+package slashcommands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/services/permissions"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekrotja/ken"
+)
+
+type Confirmations struct{}
+
+var (
+	_ ken.SlashCommand        = (*Confirmations)(nil)
+	_ permissions.PermCommand = (*Confirmations)(nil)
+)
+
+func (c *Confirmations) Name() string {
+	return "confirmations"
+}
+
+func (c *Confirmations) Description() string {
+	return "Set which destructive actions require a confirmation."
+}
+
+func (c *Confirmations) Version() string {
+	return "1.0.0"
+}
+
+func (c *Confirmations) Type() discordgo.ApplicationCommandType {
+	return discordgo.ChatApplicationCommand
+}
+
+func (c *Confirmations) Options() []*discordgo.ApplicationCommandOption {
+	actionChoices := make([]*discordgo.ApplicationCommandOptionChoice, len(models.ConfirmActions))
+	for i, a := range models.ConfirmActions {
+		actionChoices[i] = &discordgo.ApplicationCommandOptionChoice{
+			Name:  string(a),
+			Value: string(a),
+		}
+	}
+
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "set",
+			Description: "Set whether an action requires a confirmation.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "action",
+					Description: "The action.",
+					Required:    true,
+					Choices:     actionChoices,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionBoolean,
+					Name:        "required",
+					Description: "Whether the action requires a confirmation.",
+					Required:    true,
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "list",
+			Description: "List all actions and whether they require a confirmation.",
+		},
+	}
+}
+
+func (c *Confirmations) Domain() string {
+	return "sp.guild.config.confirm"
+}
+
+func (c *Confirmations) SubDomains() []permissions.SubPermission {
+	return nil
+}
+
+func (c *Confirmations) Run(ctx ken.Context) (err error) {
+	if err = ctx.Defer(); err != nil {
+		return
+	}
+
+	err = ctx.HandleSubCommands(
+		ken.SubCommandHandler{"set", c.set},
+		ken.SubCommandHandler{"list", c.list},
+	)
+
+	return
+}
+
+func (c *Confirmations) set(ctx ken.SubCommandContext) (err error) {
+	db := ctx.Get(static.DiDatabase).(database.Database)
+
+	action := models.ConfirmAction(ctx.Options().GetByName("action").StringValue())
+	required := ctx.Options().GetByName("required").BoolValue()
+
+	actions, err := db.GetGuildConfirmActions(ctx.GetEvent().GuildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return
+	}
+
+	newActions := make([]models.ConfirmAction, 0, len(actions)+1)
+	for _, a := range actions {
+		if a != action {
+			newActions = append(newActions, a)
+		}
+	}
+	if required {
+		newActions = append(newActions, action)
+	}
+
+	if err = db.SetGuildConfirmActions(ctx.GetEvent().GuildID, newActions); err != nil {
+		return
+	}
+
+	stateStr := "does no longer require"
+	if required {
+		stateStr = "now requires"
+	}
+
+	return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+		Description: fmt.Sprintf("The action `%s` %s a confirmation.", action, stateStr),
+	}).Send().Error
+}
+
+func (c *Confirmations) list(ctx ken.SubCommandContext) (err error) {
+	db := ctx.Get(static.DiDatabase).(database.Database)
+
+	actions, err := db.GetGuildConfirmActions(ctx.GetEvent().GuildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return
+	}
+
+	lines := make([]string, len(models.ConfirmActions))
+	for i, a := range models.ConfirmActions {
+		state := "not required"
+		for _, r := range actions {
+			if r == a {
+				state = "required"
+				break
+			}
+		}
+		lines[i] = fmt.Sprintf("`%s`: %s", a, state)
+	}
+
+	return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+		Title:       "Action Confirmations",
+		Description: strings.Join(lines, "\n"),
+	}).Send().Error
+}
@@ -0,0 +1,169 @@
+package slashcommands
+
+import (
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/zekroTJA/shinpuru/internal/services/emojiimport"
+	"github.com/zekroTJA/shinpuru/internal/services/permissions"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/pkg/confirm"
+	"github.com/zekroTJA/shinpuru/pkg/stringutil"
+	"github.com/zekrotja/dgrs"
+	"github.com/zekrotja/ken"
+)
+
+type EmojiImport struct{}
+
+var (
+	_ ken.SlashCommand        = (*EmojiImport)(nil)
+	_ permissions.PermCommand = (*EmojiImport)(nil)
+)
+
+func (c *EmojiImport) Name() string {
+	return "emojiimport"
+}
+
+func (c *EmojiImport) Description() string {
+	return "Copy all custom emojis of another guild into this guild."
+}
+
+func (c *EmojiImport) Version() string {
+	return "1.0.0"
+}
+
+func (c *EmojiImport) Type() discordgo.ApplicationCommandType {
+	return discordgo.ChatApplicationCommand
+}
+
+func (c *EmojiImport) Options() []*discordgo.ApplicationCommandOption {
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "source",
+			Description: "The ID of the guild to copy the emojis from.",
+			Required:    true,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "collisions",
+			Description: "How to handle emojis with names already existing in this guild (default: skip).",
+			Choices: []*discordgo.ApplicationCommandOptionChoice{
+				{Name: "skip", Value: string(emojiimport.StrategySkip)},
+				{Name: "rename", Value: string(emojiimport.StrategyRename)},
+				{Name: "replace", Value: string(emojiimport.StrategyReplace)},
+			},
+		},
+	}
+}
+
+func (c *EmojiImport) Domain() string {
+	return "sp.guild.config.emojiimport"
+}
+
+func (c *EmojiImport) SubDomains() []permissions.SubPermission {
+	return nil
+}
+
+func (c *EmojiImport) Run(ctx ken.Context) (err error) {
+	if err = ctx.Defer(); err != nil {
+		return
+	}
+
+	st := ctx.Get(static.DiState).(dgrs.IState)
+	imp := ctx.Get(static.DiEmojiImporter).(*emojiimport.Importer)
+
+	guildID := ctx.GetEvent().GuildID
+	sourceID := ctx.Options().GetByName("source").StringValue()
+
+	strategy := emojiimport.StrategySkip
+	if v, ok := ctx.Options().GetByNameOptional("collisions"); ok {
+		strategy = emojiimport.Strategy(v.StringValue())
+	}
+
+	if sourceID == guildID {
+		return ctx.FollowUpError("The source guild must not be this guild.", "").
+			Send().Error
+	}
+
+	// Only allow importing from guilds the executing user
+	// is a member of to not expose emojis of other guilds.
+	source, err := st.Guild(sourceID)
+	if err == nil {
+		_, err = st.Member(sourceID, ctx.User().ID)
+	}
+	if err != nil {
+		return ctx.FollowUpError(
+			"The source guild could not be found. Both, you and shinpuru, must be a member of it.", "").
+			Send().Error
+	}
+
+	text := fmt.Sprintf("Do you really want to copy all emojis of the guild **%s** into this guild?",
+		source.Name)
+	if strategy == emojiimport.StrategyReplace {
+		text += "\n\n⚠️ Existing emojis with the same names as imported emojis will be **deleted**!"
+	}
+
+	ok, err := confirm.Prompt(ctx.GetSession(), ctx.GetEvent().ChannelID, ctx.User().ID, text)
+	if err != nil {
+		return
+	}
+	if !ok {
+		return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+			Description: "Canceled.",
+		}).Send().Error
+	}
+
+	res, err := imp.Import(sourceID, guildID, strategy)
+	if err != nil {
+		return
+	}
+
+	var failed, skipped []string
+	for _, e := range res.Entries {
+		switch e.Status {
+		case emojiimport.StatusFailed:
+			failed = append(failed, fmt.Sprintf("`%s`: %s", e.Name, e.Err.Error()))
+		case emojiimport.StatusSkippedCollision:
+			skipped = append(skipped, fmt.Sprintf("`%s`: name already exists", e.Name))
+		case emojiimport.StatusSkippedLimit:
+			skipped = append(skipped, fmt.Sprintf("`%s`: no free emoji slot", e.Name))
+		case emojiimport.StatusRenamed:
+			skipped = append(skipped, fmt.Sprintf("`%s`: imported as `%s`", e.Name, e.NewName))
+		}
+	}
+
+	emb := &discordgo.MessageEmbed{
+		Color: static.ColorEmbedDefault,
+		Title: "Emoji Import",
+		Description: fmt.Sprintf(
+			"Imported **%d** of **%d** emojis from **%s**.\n\n"+
+				"Renamed: %d\nReplaced: %d\nSkipped (name collision): %d\n"+
+				"Skipped (no free slot): %d\nFailed: %d",
+			res.Count(emojiimport.StatusImported)+
+				res.Count(emojiimport.StatusRenamed)+
+				res.Count(emojiimport.StatusReplaced),
+			len(res.Entries), source.Name,
+			res.Count(emojiimport.StatusRenamed),
+			res.Count(emojiimport.StatusReplaced),
+			res.Count(emojiimport.StatusSkippedCollision),
+			res.Count(emojiimport.StatusSkippedLimit),
+			res.Count(emojiimport.StatusFailed)),
+	}
+
+	if len(skipped) != 0 {
+		emb.Fields = append(emb.Fields, &discordgo.MessageEmbedField{
+			Name:  "Skipped and Renamed",
+			Value: stringutil.JoinTruncated(skipped, maxEmbedFieldLen, ""),
+		})
+	}
+	if len(failed) != 0 {
+		emb.Color = static.ColorEmbedOrange
+		emb.Fields = append(emb.Fields, &discordgo.MessageEmbedField{
+			Name:  "Failed",
+			Value: stringutil.JoinTruncated(failed, maxEmbedFieldLen, ""),
+		})
+	}
+
+	return ctx.FollowUpEmbed(emb).Send().Error
+}
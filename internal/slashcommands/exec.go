@@ -12,6 +12,7 @@ import (
 	"github.com/zekroTJA/shinpuru/internal/util"
 	"github.com/zekroTJA/shinpuru/internal/util/static"
 	"github.com/zekroTJA/shinpuru/pkg/jdoodle"
+	"github.com/zekroTJA/shinpuru/pkg/stringutil"
 	"github.com/zekrotja/dgrs"
 	"github.com/zekrotja/ken"
 )
@@ -39,7 +40,7 @@ func (c *Exec) Description() string {
 }
 
 func (c *Exec) Version() string {
-	return "1.1.0"
+	return "1.2.0"
 }
 
 func (c *Exec) Type() discordgo.ApplicationCommandType {
@@ -76,6 +77,18 @@ func (c *Exec) Options() []*discordgo.ApplicationCommandOption {
 			Name:        "check",
 			Description: "Show the status of the current code execution setup.",
 		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "languages",
+			Description: "Show or set the languages which are allowed to be executed.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "allowed",
+					Description: "Comma separated list of allowed languages ('all' to allow all languages).",
+				},
+			},
+		},
 	}
 }
 
@@ -92,15 +105,23 @@ func (c *Exec) Run(ctx ken.Context) (err error) {
 		return
 	}
 
-	var ranEnable bool
-	err = ctx.HandleSubCommands(ken.SubCommandHandler{"enable", func(ctx ken.SubCommandContext) error {
-		ranEnable = true
-		return c.enable(ctx)
-	}})
+	// These sub commands are independent of the
+	// used code execution engine.
+	var ranGeneric bool
+	err = ctx.HandleSubCommands(
+		ken.SubCommandHandler{"enable", func(ctx ken.SubCommandContext) error {
+			ranGeneric = true
+			return c.enable(ctx)
+		}},
+		ken.SubCommandHandler{"languages", func(ctx ken.SubCommandContext) error {
+			ranGeneric = true
+			return c.languages(ctx)
+		}},
+	)
 	if err != nil {
 		return
 	}
-	if ranEnable {
+	if ranGeneric {
 		return
 	}
 
@@ -150,7 +171,7 @@ func (c *Exec) setup(ctx ken.SubCommandContext) (err error) {
 	var state int
 	var clientId, clientSecret string
 	removeHandler = ctx.GetSession().AddHandler(func(s *discordgo.Session, e *discordgo.MessageCreate) {
-		st := ctx.Get(static.DiState).(*dgrs.State)
+		st := ctx.Get(static.DiState).(dgrs.IState)
 		self, err := st.SelfUser()
 		if err != nil {
 			return
@@ -253,6 +274,74 @@ func (c *Exec) enable(ctx ken.SubCommandContext) (err error) {
 	}).Send().Error
 }
 
+func (c *Exec) languages(ctx ken.SubCommandContext) (err error) {
+	db, _ := ctx.Get(static.DiDatabase).(database.Database)
+	execFact := ctx.Get(static.DiCodeExecFactory).(codeexec.Factory)
+	guildID := ctx.GetEvent().GuildID
+
+	enabled, err := db.GetGuildCodeExecEnabled(guildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return
+	}
+	if !enabled {
+		return ctx.FollowUpError(
+			"Code execution is not enabled on this guild. Use `exec enable` to enable code execution.", "").
+			Send().
+			Error
+	}
+
+	supported, err := codeexec.SupportedLanguages(execFact)
+	if err != nil {
+		return
+	}
+
+	if allowedV, ok := ctx.Options().GetByNameOptional("allowed"); ok {
+		var allowed []string
+		if v := strings.TrimSpace(allowedV.StringValue()); strings.ToLower(v) != "all" {
+			for _, lang := range strings.Split(v, ",") {
+				lang = strings.ToLower(strings.TrimSpace(lang))
+				if lang == "" {
+					continue
+				}
+				if !stringutil.ContainsAny(lang, supported) {
+					return ctx.FollowUpError(
+						fmt.Sprintf("The language `%s` is not supported.", lang), "").
+						Send().
+						Error
+				}
+				allowed = append(allowed, lang)
+			}
+		}
+		if err = db.SetGuildCodeExecLanguages(guildID, allowed); err != nil {
+			return
+		}
+	}
+
+	allowed, err := db.GetGuildCodeExecLanguages(guildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return
+	}
+
+	allowedStr := "*all supported languages*"
+	if len(allowed) != 0 {
+		allowedStr = fmt.Sprintf("```\n%s\n```", strings.Join(allowed, ", "))
+	}
+
+	return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+		Title: "Code Execution Languages",
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name:  "Allowed",
+				Value: allowedStr,
+			},
+			{
+				Name:  "Supported",
+				Value: fmt.Sprintf("```\n%s\n```", strings.Join(supported, ", ")),
+			},
+		},
+	}).Send().Error
+}
+
 func (c *Exec) check(ctx ken.SubCommandContext) (err error) {
 	db, _ := ctx.Get(static.DiDatabase).(database.Database)
 	key, err := db.GetGuildJdoodleKey(ctx.GetEvent().GuildID)
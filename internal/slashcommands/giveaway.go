@@ -0,0 +1,316 @@
+package slashcommands
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/bwmarrin/snowflake"
+	"github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/services/permissions"
+	"github.com/zekroTJA/shinpuru/internal/services/timeprovider"
+	"github.com/zekroTJA/shinpuru/internal/util/giveaway"
+	"github.com/zekroTJA/shinpuru/internal/util/snowflakenodes"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/pkg/discordutil"
+	"github.com/zekroTJA/shinpuru/pkg/hammertime"
+	"github.com/zekroTJA/shinpuru/pkg/timeutil"
+	"github.com/zekrotja/ken"
+)
+
+const (
+	giveawayMaxWinners  = 20
+	giveawayMaxDuration = 30 * 24 * time.Hour
+)
+
+type Giveaway struct{}
+
+var (
+	_ ken.SlashCommand        = (*Giveaway)(nil)
+	_ permissions.PermCommand = (*Giveaway)(nil)
+)
+
+func (c *Giveaway) Name() string {
+	return "giveaway"
+}
+
+func (c *Giveaway) Description() string {
+	return "Create and manage timed giveaways."
+}
+
+func (c *Giveaway) Version() string {
+	return "1.0.0"
+}
+
+func (c *Giveaway) Type() discordgo.ApplicationCommandType {
+	return discordgo.ChatApplicationCommand
+}
+
+func (c *Giveaway) Options() []*discordgo.ApplicationCommandOption {
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "start",
+			Description: "Start a new giveaway.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "prize",
+					Description: "The prize of the giveaway.",
+					Required:    true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "duration",
+					Description: "The duration of the giveaway (e.g. `30m`, `2h` or `3d`).",
+					Required:    true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "winners",
+					Description: fmt.Sprintf("The number of winners (default 1, max %d).", giveawayMaxWinners),
+				},
+				{
+					Type:         discordgo.ApplicationCommandOptionChannel,
+					Name:         "channel",
+					Description:  "The channel to post the giveaway in (defaults to the current channel).",
+					ChannelTypes: []discordgo.ChannelType{discordgo.ChannelTypeGuildText},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionRole,
+					Name:        "role",
+					Description: "The role members need to enter the giveaway.",
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "minkarma",
+					Description: "The minimum karma members need to enter the giveaway.",
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "end",
+			Description: "End a running giveaway now.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "id",
+					Description: "The ID of the giveaway.",
+					Required:    true,
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "reroll",
+			Description: "Pick new winners of an ended giveaway.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "id",
+					Description: "The ID of the giveaway.",
+					Required:    true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "winners",
+					Description: "The number of new winners (default 1).",
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "list",
+			Description: "List the running giveaways.",
+		},
+	}
+}
+
+func (c *Giveaway) Domain() string {
+	return "sp.guild.mod.giveaway"
+}
+
+func (c *Giveaway) SubDomains() []permissions.SubPermission {
+	return nil
+}
+
+func (c *Giveaway) Run(ctx ken.Context) (err error) {
+	if err = ctx.Defer(); err != nil {
+		return
+	}
+
+	err = ctx.HandleSubCommands(
+		ken.SubCommandHandler{"start", c.start},
+		ken.SubCommandHandler{"end", c.end},
+		ken.SubCommandHandler{"reroll", c.reroll},
+		ken.SubCommandHandler{"list", c.list},
+	)
+
+	return
+}
+
+func (c *Giveaway) start(ctx ken.SubCommandContext) (err error) {
+	db := ctx.Get(static.DiDatabase).(database.Database)
+	tp := ctx.Get(static.DiTimeProvider).(timeprovider.Provider)
+
+	duration, err := timeutil.ParseDuration(ctx.Options().GetByName("duration").StringValue())
+	if err != nil || duration <= 0 {
+		return ctx.FollowUpError(
+			"Invalid duration format. Please take a look "+
+				"[here](https://golang.org/pkg/time/#ParseDuration) how to format duration parameter.", "").
+			Send().Error
+	}
+	if duration > giveawayMaxDuration {
+		return ctx.FollowUpError("A giveaway can not run longer than 30 days.", "").
+			Send().Error
+	}
+
+	g := models.Giveaway{
+		ID:        snowflakenodes.NodeGiveaways.Generate(),
+		GuildID:   ctx.GetEvent().GuildID,
+		ChannelID: ctx.GetEvent().ChannelID,
+		CreatorID: ctx.User().ID,
+		Prize:     ctx.Options().GetByName("prize").StringValue(),
+		Winners:   1,
+		Expires:   tp.Now().Add(duration),
+	}
+
+	if v, ok := ctx.Options().GetByNameOptional("winners"); ok {
+		g.Winners = int(v.IntValue())
+		if g.Winners < 1 || g.Winners > giveawayMaxWinners {
+			return ctx.FollowUpError(
+				fmt.Sprintf("The number of winners must be between 1 and %d.", giveawayMaxWinners), "").
+				Send().Error
+		}
+	}
+	if v, ok := ctx.Options().GetByNameOptional("channel"); ok {
+		g.ChannelID = v.ChannelValue(ctx).ID
+	}
+	if v, ok := ctx.Options().GetByNameOptional("role"); ok {
+		g.RoleID = v.RoleValue(ctx).ID
+	}
+	if v, ok := ctx.Options().GetByNameOptional("minkarma"); ok {
+		g.MinKarma = int(v.IntValue())
+	}
+
+	if err = giveaway.Post(db, ctx.GetSession(), ctx.GetKen().Components(), tp, &g); err != nil {
+		return
+	}
+
+	return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+		Description: fmt.Sprintf("The [giveaway](%s) has been started in <#%s> (ID: `%s`). It ends %s.",
+			discordutil.GetMessageLink(&discordgo.Message{ID: g.MessageID, ChannelID: g.ChannelID}, g.GuildID),
+			g.ChannelID, g.ID, hammertime.Format(g.Expires, hammertime.Span)),
+	}).Send().Error
+}
+
+func (c *Giveaway) end(ctx ken.SubCommandContext) (err error) {
+	db := ctx.Get(static.DiDatabase).(database.Database)
+
+	g, ok, err := c.getGiveaway(ctx)
+	if !ok || err != nil {
+		return
+	}
+
+	if g.Ended {
+		return ctx.FollowUpError("This giveaway has already ended.", "").
+			Send().Error
+	}
+
+	if err = giveaway.End(db, ctx.GetSession(), &g, ""); err != nil {
+		return
+	}
+
+	return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+		Description: fmt.Sprintf("The giveaway `%s` has been ended.", g.ID),
+	}).Send().Error
+}
+
+func (c *Giveaway) reroll(ctx ken.SubCommandContext) (err error) {
+	db := ctx.Get(static.DiDatabase).(database.Database)
+
+	g, ok, err := c.getGiveaway(ctx)
+	if !ok || err != nil {
+		return
+	}
+
+	if !g.Ended {
+		return ctx.FollowUpError("This giveaway is still running. End it first to reroll the winners.", "").
+			Send().Error
+	}
+
+	n := 1
+	if v, ok := ctx.Options().GetByNameOptional("winners"); ok {
+		n = int(v.IntValue())
+		if n < 1 || n > giveawayMaxWinners {
+			return ctx.FollowUpError(
+				fmt.Sprintf("The number of winners must be between 1 and %d.", giveawayMaxWinners), "").
+				Send().Error
+		}
+	}
+
+	winners, err := giveaway.Reroll(db, ctx.GetSession(), &g, n)
+	if err != nil {
+		return
+	}
+
+	if len(winners) == 0 {
+		return ctx.FollowUpError("There are no entries left which have not won yet.", "").
+			Send().Error
+	}
+
+	return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+		Description: fmt.Sprintf("The winners of the giveaway `%s` have been rerolled.", g.ID),
+	}).Send().Error
+}
+
+func (c *Giveaway) list(ctx ken.SubCommandContext) (err error) {
+	db := ctx.Get(static.DiDatabase).(database.Database)
+
+	giveaways, err := db.GetGiveaways(ctx.GetEvent().GuildID, true)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return
+	}
+
+	if len(giveaways) == 0 {
+		return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+			Description: "There are no running giveaways on this guild.",
+		}).Send().Error
+	}
+
+	lines := make([]string, len(giveaways))
+	for i, g := range giveaways {
+		lines[i] = fmt.Sprintf("`%s` - **%s** in <#%s>, ends %s",
+			g.ID, g.Prize, g.ChannelID, hammertime.Format(g.Expires, hammertime.Span))
+	}
+
+	return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+		Title:       "Running Giveaways",
+		Description: strings.Join(lines, "\n"),
+	}).Send().Error
+}
+
+// getGiveaway returns the giveaway of the current guild
+// with the ID passed as option. If no matching giveaway
+// exists, an error is responded and ok is false.
+func (c *Giveaway) getGiveaway(ctx ken.SubCommandContext) (g models.Giveaway, ok bool, err error) {
+	db := ctx.Get(static.DiDatabase).(database.Database)
+
+	id, err := snowflake.ParseString(ctx.Options().GetByName("id").StringValue())
+	if err == nil {
+		g, err = db.GetGiveaway(id)
+	}
+	if err == nil && g.GuildID == ctx.GetEvent().GuildID {
+		return g, true, nil
+	}
+	if err != nil && !database.IsErrDatabaseNotFound(err) && id != 0 {
+		return
+	}
+
+	err = ctx.FollowUpError("There is no giveaway with this ID on this guild.", "").
+		Send().Error
+	return
+}
@@ -6,9 +6,11 @@ import (
 	"time"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/zekroTJA/shinpuru/internal/middleware"
 	"github.com/zekroTJA/shinpuru/internal/models"
 	"github.com/zekroTJA/shinpuru/internal/services/database"
 	"github.com/zekroTJA/shinpuru/internal/services/permissions"
+	"github.com/zekroTJA/shinpuru/internal/util/cmdutil"
 	"github.com/zekroTJA/shinpuru/internal/util/static"
 	"github.com/zekroTJA/shinpuru/pkg/colors"
 	"github.com/zekroTJA/shinpuru/pkg/discordutil"
@@ -22,8 +24,9 @@ type Guild struct {
 }
 
 var (
-	_ ken.SlashCommand        = (*Guild)(nil)
-	_ permissions.PermCommand = (*Guild)(nil)
+	_ ken.SlashCommand              = (*Guild)(nil)
+	_ permissions.PermCommand       = (*Guild)(nil)
+	_ middleware.GuildScopedCommand = (*Guild)(nil)
 )
 
 func (c *Guild) Name() string {
@@ -35,7 +38,7 @@ func (c *Guild) Description() string {
 }
 
 func (c *Guild) Version() string {
-	return "1.0.0"
+	return "1.1.0"
 }
 
 func (c *Guild) Type() discordgo.ApplicationCommandType {
@@ -43,7 +46,9 @@ func (c *Guild) Type() discordgo.ApplicationCommandType {
 }
 
 func (c *Guild) Options() []*discordgo.ApplicationCommandOption {
-	return []*discordgo.ApplicationCommandOption{}
+	return []*discordgo.ApplicationCommandOption{
+		cmdutil.GuildOption(),
+	}
 }
 
 func (c *Guild) Domain() string {
@@ -54,6 +59,14 @@ func (c *Guild) SubDomains() []permissions.SubPermission {
 	return nil
 }
 
+func (c *Guild) IsDmCapable() bool {
+	return true
+}
+
+func (c *Guild) IsGuildScoped() bool {
+	return true
+}
+
 func (c *Guild) Run(ctx ken.Context) (err error) {
 	if err = ctx.Defer(); err != nil {
 		return
@@ -61,7 +74,7 @@ func (c *Guild) Run(ctx ken.Context) (err error) {
 
 	const maxGuildRoles = 30
 
-	st := ctx.Get(static.DiState).(*dgrs.State)
+	st := ctx.Get(static.DiState).(dgrs.IState)
 	db := ctx.Get(static.DiDatabase).(database.Database)
 
 	g, err := st.Guild(ctx.GetEvent().GuildID)
@@ -0,0 +1,105 @@
+package slashcommands
+
+import (
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/services/kvcache"
+	"github.com/zekroTJA/shinpuru/internal/services/permissions"
+	"github.com/zekroTJA/shinpuru/internal/services/timeprovider"
+	"github.com/zekroTJA/shinpuru/internal/util"
+	"github.com/zekroTJA/shinpuru/internal/util/guildstats"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/pkg/embedbuilder"
+	"github.com/zekroTJA/shinpuru/pkg/hammertime"
+	"github.com/zekrotja/dgrs"
+	"github.com/zekrotja/ken"
+)
+
+type Guildstats struct {
+	ken.EphemeralCommand
+}
+
+var (
+	_ ken.SlashCommand        = (*Guildstats)(nil)
+	_ permissions.PermCommand = (*Guildstats)(nil)
+)
+
+func (c *Guildstats) Name() string {
+	return "guildstats"
+}
+
+func (c *Guildstats) Description() string {
+	return "Displays detailed statistics of the current guild."
+}
+
+func (c *Guildstats) Version() string {
+	return "1.0.0"
+}
+
+func (c *Guildstats) Type() discordgo.ApplicationCommandType {
+	return discordgo.ChatApplicationCommand
+}
+
+func (c *Guildstats) Options() []*discordgo.ApplicationCommandOption {
+	return []*discordgo.ApplicationCommandOption{}
+}
+
+func (c *Guildstats) Domain() string {
+	return "sp.chat.guildstats"
+}
+
+func (c *Guildstats) SubDomains() []permissions.SubPermission {
+	return nil
+}
+
+func (c *Guildstats) Run(ctx ken.Context) (err error) {
+	if err = ctx.Defer(); err != nil {
+		return
+	}
+
+	st := ctx.Get(static.DiState).(dgrs.IState)
+	db := ctx.Get(static.DiDatabase).(database.Database)
+	kvc := ctx.Get(static.DiKVCache).(kvcache.Provider)
+	tp := ctx.Get(static.DiTimeProvider).(timeprovider.Provider)
+
+	guildID := ctx.GetEvent().GuildID
+
+	stats, err := guildstats.Get(st, kvc, tp, guildID)
+	if err != nil {
+		return
+	}
+
+	members := fmt.Sprintf("Total: `%d`\nHumans: `%d`\nBots: `%d`",
+		stats.Members, stats.Humans, stats.Bots)
+	if !stats.MembersComplete {
+		members += "\n*Humans and bots are only counted from the members known to shinpuru.*"
+	}
+
+	presences := "*No presence data available.*"
+	if p := stats.Presences; p != nil {
+		presences = fmt.Sprintf("🟢 Online: `%d`\n🌙 Idle: `%d`\n⛔ Do not disturb: `%d`\n⚫ Offline: `%d`",
+			p.Online, p.Idle, p.DoNotDisturb, p.Offline)
+	}
+
+	cs := stats.Channels
+	channels := fmt.Sprintf("Text: `%d`\nAnnouncement: `%d`\nVoice: `%d`\nStage: `%d`\n"+
+		"Forum: `%d`\nCategory: `%d`\nOther: `%d`",
+		cs.Text, cs.News, cs.Voice, cs.Stage, cs.Forum, cs.Category, cs.Other)
+
+	emb := embedbuilder.New().
+		WithColor(util.GuildEmbedColor(db, guildID)).
+		WithTitle("Guild Statistics").
+		AddInlineField("Created", hammertime.Format(stats.Created, hammertime.LongerDate)).
+		AddInlineField("Roles", fmt.Sprintf("`%d`", stats.Roles)).
+		AddInlineField("Boosts", fmt.Sprintf("Tier `%d` with `%d` boosts", stats.PremiumTier, stats.PremiumCount)).
+		AddInlineField("Members", members).
+		AddInlineField("Presences", presences).
+		AddInlineField(fmt.Sprintf("Channels (%d)", cs.Total), channels).
+		WithFooter("Statistics are cached for a short time.", "", "").
+		WithTimestamp(stats.Computed).
+		Build()
+
+	return ctx.FollowUpEmbed(emb).Send().Error
+}
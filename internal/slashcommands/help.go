@@ -6,7 +6,9 @@ import (
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/zekroTJA/shinpuru/internal/services/config"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
 	"github.com/zekroTJA/shinpuru/internal/services/permissions"
+	"github.com/zekroTJA/shinpuru/internal/util"
 	"github.com/zekroTJA/shinpuru/internal/util/static"
 	"github.com/zekroTJA/shinpuru/pkg/embedbuilder"
 	"github.com/zekroTJA/shinpuru/pkg/stringutil"
@@ -81,7 +83,10 @@ func (c *Help) Run(ctx ken.Context) (err error) {
 		return
 	}
 
+	db := ctx.Get(static.DiDatabase).(database.Database)
+
 	emb := &discordgo.MessageEmbed{
+		Color: util.GuildEmbedColor(db, ctx.GetEvent().GuildID),
 		Title: "Help Center",
 		Description: "If you generally need help with the usage or setup of shinpuru, take a look into the " +
 			"[**Wiki**](https://github.com/zekroTJA/shinpuru/wiki). There you can find a lot of useful resources " +
@@ -125,7 +130,10 @@ func (c *Help) cmdHelp(ctx ken.Context, webAddr, name string) (err error) {
 
 	domain := info.Implementations["Domain"][0].(string)
 
+	db := ctx.Get(static.DiDatabase).(database.Database)
+
 	emb := embedbuilder.New().
+		WithColor(util.GuildEmbedColor(db, ctx.GetEvent().GuildID)).
 		WithTitle(fmt.Sprintf("/%s Command Help", name)).
 		WithDescription(info.ApplicationCommand.Description).
 		WithFooter(fmt.Sprintf("Command Version v%s", info.ApplicationCommand.Version), "", "").
@@ -0,0 +1,291 @@
+package slashcommands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/services/kvcache"
+	"github.com/zekroTJA/shinpuru/internal/services/permissions"
+	"github.com/zekroTJA/shinpuru/internal/services/timeprovider"
+	"github.com/zekroTJA/shinpuru/internal/util/inactivity"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/internal/util/temprole"
+	"github.com/zekroTJA/shinpuru/pkg/hammertime"
+	"github.com/zekroTJA/shinpuru/pkg/stringutil"
+	"github.com/zekrotja/dgrs"
+	"github.com/zekrotja/ken"
+)
+
+type Inactivity struct{}
+
+var (
+	_ ken.SlashCommand        = (*Inactivity)(nil)
+	_ permissions.PermCommand = (*Inactivity)(nil)
+)
+
+func (c *Inactivity) Name() string {
+	return "inactivity"
+}
+
+func (c *Inactivity) Description() string {
+	return "Manage the automatic removal of a role from inactive members."
+}
+
+func (c *Inactivity) Version() string {
+	return "1.0.0"
+}
+
+func (c *Inactivity) Type() discordgo.ApplicationCommandType {
+	return discordgo.ChatApplicationCommand
+}
+
+func (c *Inactivity) Options() []*discordgo.ApplicationCommandOption {
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "settings",
+			Description: "Show or change the inactivity role removal settings.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionBoolean,
+					Name:        "enabled",
+					Description: "Enable or disable the inactivity role removal.",
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionRole,
+					Name:        "role",
+					Description: "The role to be removed from inactive members.",
+				},
+				{
+					Type: discordgo.ApplicationCommandOptionInteger,
+					Name: "days",
+					Description: fmt.Sprintf("The number of days after which members are inactive (%d to %d).",
+						models.InactivityMinDays, models.InactivityMaxDays),
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "warndays",
+					Description: "The number of days members are warned via DM before the role is removed.",
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "member",
+			Description: "Show the last recorded activity of a member.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionUser,
+					Name:        "user",
+					Description: "The member to be checked.",
+					Required:    true,
+				},
+			},
+		},
+	}
+}
+
+func (c *Inactivity) Domain() string {
+	return "sp.guild.config.inactivity"
+}
+
+func (c *Inactivity) SubDomains() []permissions.SubPermission {
+	return nil
+}
+
+func (c *Inactivity) Run(ctx ken.Context) (err error) {
+	if err = ctx.Defer(); err != nil {
+		return
+	}
+
+	err = ctx.HandleSubCommands(
+		ken.SubCommandHandler{"settings", c.settings},
+		ken.SubCommandHandler{"member", c.member},
+	)
+
+	return
+}
+
+func (c *Inactivity) settings(ctx ken.SubCommandContext) (err error) {
+	db := ctx.Get(static.DiDatabase).(database.Database)
+	tp := ctx.Get(static.DiTimeProvider).(timeprovider.Provider)
+	kvc := ctx.Get(static.DiKVCache).(kvcache.Provider)
+
+	guildID := ctx.GetEvent().GuildID
+
+	settings, err := c.getSettings(db, guildID)
+	if err != nil {
+		return
+	}
+
+	wasEnabled := settings.Enabled
+	var changed bool
+	if v, ok := ctx.Options().GetByNameOptional("enabled"); ok {
+		settings.Enabled = v.BoolValue()
+		changed = true
+	}
+	if v, ok := ctx.Options().GetByNameOptional("role"); ok {
+		settings.RoleID = v.RoleValue(ctx).ID
+		changed = true
+	}
+	if v, ok := ctx.Options().GetByNameOptional("days"); ok {
+		settings.Days = int(v.IntValue())
+		changed = true
+	}
+	if v, ok := ctx.Options().GetByNameOptional("warndays"); ok {
+		settings.WarnDays = int(v.IntValue())
+		changed = true
+	}
+
+	if changed {
+		if err = settings.Validate(); err != nil {
+			return ctx.FollowUpError(err.Error(), "").Send().Error
+		}
+
+		if settings.Enabled {
+			if err = c.checkRole(ctx, settings.RoleID); err != nil {
+				return ctx.FollowUpError(
+					fmt.Sprintf("The role can not be removed from members: %s.", err.Error()), "").
+					Send().Error
+			}
+		}
+
+		// Activity is only tracked while the removal is
+		// enabled, so members are considered inactive
+		// from the time it has been enabled at most.
+		if settings.Enabled && !wasEnabled {
+			settings.Since = tp.Now()
+		}
+
+		if err = db.SetInactivitySettings(settings); err != nil {
+			return
+		}
+
+		// The recorded activities are not needed anymore
+		// and become outdated while the removal is
+		// disabled.
+		if !settings.Enabled && wasEnabled {
+			if err = inactivity.Reset(db, kvc, guildID); err != nil {
+				return
+			}
+		}
+	}
+
+	return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+		Title:       "Inactivity Role Removal Settings",
+		Description: c.formatSettings(settings),
+	}).Send().Error
+}
+
+func (c *Inactivity) member(ctx ken.SubCommandContext) (err error) {
+	db := ctx.Get(static.DiDatabase).(database.Database)
+	st := ctx.Get(static.DiState).(dgrs.IState)
+
+	guildID := ctx.GetEvent().GuildID
+	user := ctx.Options().GetByName("user").UserValue(ctx)
+
+	settings, err := c.getSettings(db, guildID)
+	if err != nil {
+		return
+	}
+	if !settings.Enabled {
+		return ctx.FollowUpError("The inactivity role removal is not enabled on this guild.", "").Send().Error
+	}
+
+	memb, err := st.Member(guildID, user.ID)
+	if err != nil {
+		return ctx.FollowUpError("The user is not a member of this guild.", "").Send().Error
+	}
+
+	activities, err := db.GetMemberActivities(guildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return
+	}
+
+	activity := models.MemberActivity{LastActive: settings.Since}
+	for _, a := range activities {
+		if a.UserID == user.ID {
+			activity = a
+			break
+		}
+	}
+	last := inactivity.LastActive(settings, activity, memb.JoinedAt)
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Last Activity: %s\n", hammertime.Format(last, hammertime.Span))
+	fmt.Fprintf(&sb, "Has Role: **%s**\n",
+		stringutil.FromBool(stringutil.ContainsAny(settings.RoleID, memb.Roles), "yes", "no"))
+	if activity.Warned != nil && !activity.Warned.Before(last) {
+		fmt.Fprintf(&sb, "Warned: %s", hammertime.Format(*activity.Warned, hammertime.Span))
+	} else {
+		sb.WriteString("Warned: **no**")
+	}
+
+	return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+		Title:       "Member Activity",
+		Description: fmt.Sprintf("<@%s>\n\n%s", user.ID, sb.String()),
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: "Activity is recorded with a delay of a few minutes.",
+		},
+	}).Send().Error
+}
+
+// checkRole returns an error if the role can not be
+// removed from members by the bot and the executor.
+func (c *Inactivity) checkRole(ctx ken.Context, roleID string) (err error) {
+	st := ctx.Get(static.DiState).(dgrs.IState)
+
+	guildID := ctx.GetEvent().GuildID
+
+	guild, err := st.Guild(guildID, true)
+	if err != nil {
+		return
+	}
+	executor, err := st.Member(guildID, ctx.User().ID)
+	if err != nil {
+		return
+	}
+	selfUser, err := st.SelfUser()
+	if err != nil {
+		return
+	}
+	self, err := st.Member(guildID, selfUser.ID)
+	if err != nil {
+		return
+	}
+
+	return temprole.CheckAssignable(guild, executor, self, roleID)
+}
+
+func (c *Inactivity) getSettings(db database.Database, guildID string) (models.InactivitySettings, error) {
+	settings, err := db.GetInactivitySettings(guildID)
+	if database.IsErrDatabaseNotFound(err) {
+		return models.InactivitySettings{
+			GuildID:  guildID,
+			Days:     models.InactivityDefaultDays,
+			WarnDays: models.InactivityDefaultWarnDays,
+		}, nil
+	}
+	return settings, err
+}
+
+func (c *Inactivity) formatSettings(settings models.InactivitySettings) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "Enabled: **%s**\n", stringutil.FromBool(settings.Enabled, "yes", "no"))
+	if settings.Enabled {
+		fmt.Fprintf(&sb, "Enabled Since: %s\n", hammertime.Format(settings.Since, hammertime.LongerDate))
+	}
+	if settings.RoleID != "" {
+		fmt.Fprintf(&sb, "Role: <@&%s>\n", settings.RoleID)
+	} else {
+		sb.WriteString("Role: *none*\n")
+	}
+	fmt.Fprintf(&sb, "Inactivity Period: **%d days**\n", settings.Days)
+	fmt.Fprintf(&sb, "Warning: **%d days** before removal", settings.WarnDays)
+
+	return sb.String()
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/zekroTJA/shinpuru/internal/services/config"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
 	"github.com/zekroTJA/shinpuru/internal/services/permissions"
 	"github.com/zekroTJA/shinpuru/internal/services/timeprovider"
 	"github.com/zekroTJA/shinpuru/internal/util"
@@ -66,7 +67,7 @@ func (c *Info) Run(ctx ken.Context) (err error) {
 		return
 	}
 
-	st := ctx.Get(static.DiState).(*dgrs.State)
+	st := ctx.Get(static.DiState).(dgrs.IState)
 	self, err := st.SelfUser()
 	if err != nil {
 		return err
@@ -83,8 +84,10 @@ func (c *Info) Run(ctx ken.Context) (err error) {
 
 	tp := ctx.Get(static.DiTimeProvider).(timeprovider.Provider)
 
+	db := ctx.Get(static.DiDatabase).(database.Database)
+
 	emb := &discordgo.MessageEmbed{
-		Color: static.ColorEmbedDefault,
+		Color: util.GuildEmbedColor(db, ctx.GetEvent().GuildID),
 		Title: "Info",
 		Thumbnail: &discordgo.MessageEmbedThumbnail{
 			URL: self.AvatarURL(""),
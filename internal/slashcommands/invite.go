@@ -0,0 +1,286 @@
+package slashcommands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/services/invitetracker"
+	"github.com/zekroTJA/shinpuru/internal/services/permissions"
+	"github.com/zekroTJA/shinpuru/internal/util"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/pkg/discordutil"
+	"github.com/zekroTJA/shinpuru/pkg/embedbuilder"
+	"github.com/zekroTJA/shinpuru/pkg/paginator"
+	"github.com/zekrotja/ken"
+)
+
+const (
+	inviteStatsPerPage = 15
+	inviterCodesLimit  = 5
+)
+
+type Invite struct{}
+
+var (
+	_ ken.SlashCommand        = (*Invite)(nil)
+	_ permissions.PermCommand = (*Invite)(nil)
+)
+
+func (c *Invite) Name() string {
+	return "invite"
+}
+
+func (c *Invite) Description() string {
+	return "Create tracked invites and show which invites members joined with."
+}
+
+func (c *Invite) Version() string {
+	return "1.0.0"
+}
+
+func (c *Invite) Type() discordgo.ApplicationCommandType {
+	return discordgo.ChatApplicationCommand
+}
+
+func (c *Invite) Options() []*discordgo.ApplicationCommandOption {
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "create",
+			Description: "Create an invite and track the members joining with it.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "label",
+					Description: "A label to identify the invite in the statistics.",
+				},
+				{
+					Type:         discordgo.ApplicationCommandOptionChannel,
+					Name:         "channel",
+					Description:  "The channel the invite leads to (defaults to the current channel).",
+					ChannelTypes: []discordgo.ChannelType{discordgo.ChannelTypeGuildText},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "max_uses",
+					Description: "The maximum number of uses between 1 and 100 (unlimited by default).",
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "max_age",
+					Description: "The time after which the invite expires (never by default).",
+					Choices: []*discordgo.ApplicationCommandOptionChoice{
+						{Name: "30 minutes", Value: 1800},
+						{Name: "1 hour", Value: 3600},
+						{Name: "6 hours", Value: 21600},
+						{Name: "12 hours", Value: 43200},
+						{Name: "1 day", Value: 86400},
+						{Name: "7 days", Value: 604800},
+						{Name: "Never", Value: 0},
+					},
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "stats",
+			Description: "Show the number of joins per invite.",
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "inviters",
+			Description: "Show the leaderboard of the users whose invites have been used the most.",
+		},
+	}
+}
+
+func (c *Invite) Domain() string {
+	return "sp.guild.mod.invite"
+}
+
+func (c *Invite) SubDomains() []permissions.SubPermission {
+	return nil
+}
+
+func (c *Invite) Run(ctx ken.Context) (err error) {
+	if err = ctx.Defer(); err != nil {
+		return
+	}
+
+	err = ctx.HandleSubCommands(
+		ken.SubCommandHandler{"create", c.create},
+		ken.SubCommandHandler{"stats", c.stats},
+		ken.SubCommandHandler{"inviters", c.inviters},
+	)
+
+	return
+}
+
+func (c *Invite) create(ctx ken.SubCommandContext) (err error) {
+	it := ctx.Get(static.DiInviteTracker).(*invitetracker.InviteTracker)
+
+	channelID := ctx.GetEvent().ChannelID
+	if v, ok := ctx.Options().GetByNameOptional("channel"); ok {
+		channelID = v.ChannelValue(ctx).ID
+	}
+
+	var label string
+	if v, ok := ctx.Options().GetByNameOptional("label"); ok {
+		label = v.StringValue()
+	}
+
+	var maxUses, maxAge int
+	if v, ok := ctx.Options().GetByNameOptional("max_uses"); ok {
+		maxUses = int(v.IntValue())
+		if maxUses < 1 || maxUses > 100 {
+			return ctx.FollowUpError("The maximum number of uses must be between 1 and 100.", "").
+				Send().Error
+		}
+	}
+	if v, ok := ctx.Options().GetByNameOptional("max_age"); ok {
+		maxAge = int(v.IntValue())
+	}
+
+	inv, err := it.Create(ctx.GetEvent().GuildID, channelID, ctx.User().ID, label, maxAge, maxUses)
+	if discordutil.IsErrCode(err, discordgo.ErrCodeMissingPermissions) {
+		return ctx.FollowUpError(fmt.Sprintf(
+			"I need the permission `Create Invite` in <#%s> to create an invite.", channelID), "").
+			Send().Error
+	}
+	if err != nil {
+		return
+	}
+
+	desc := fmt.Sprintf("Created the invite https://discord.gg/%s for <#%s>.\n\n"+
+		"Members joining with this invite are counted in `/invite stats`.", inv.Code, channelID)
+	if label != "" {
+		desc = fmt.Sprintf("Created the invite https://discord.gg/%s labeled **%s** for <#%s>.\n\n"+
+			"Members joining with this invite are counted in `/invite stats`.", inv.Code, label, channelID)
+	}
+
+	return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+		Color:       static.ColorEmbedUpdated,
+		Description: desc,
+	}).Send().Error
+}
+
+func (c *Invite) stats(ctx ken.SubCommandContext) (err error) {
+	a, ok, err := c.analytics(ctx)
+	if !ok || err != nil {
+		return
+	}
+
+	if len(a.Invites) == 0 {
+		return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+			Color:       static.ColorEmbedGray,
+			Description: "There are no invites and no joins have been recorded yet.",
+		}).Send().Error
+	}
+
+	lines := make([]string, 0, len(a.Invites))
+	for _, e := range a.Invites {
+		var name string
+		switch e.Source {
+		case models.InviteJoinSourceUnknown:
+			name = "*Unknown*"
+		case models.InviteJoinSourceVanity:
+			name = fmt.Sprintf("`%s` (vanity URL)", e.Code)
+		default:
+			name = fmt.Sprintf("`%s`", e.Code)
+			if e.Label != "" {
+				name += fmt.Sprintf(" **%s**", e.Label)
+			}
+		}
+
+		line := fmt.Sprintf("%s — Joins: `%d`", name, e.Joins)
+		if inviter := e.Inviter(); inviter != "" {
+			line += fmt.Sprintf(" — by <@%s>", inviter)
+		}
+		if !e.Active && e.Source != models.InviteJoinSourceUnknown {
+			line += " *(expired or deleted)*"
+		}
+		lines = append(lines, line)
+	}
+
+	return c.sendPages(ctx, "Invite Statistics", lines, fmt.Sprintf(
+		"%d joins recorded in total. Joins which happen at the same time "+
+			"via different invites can not be attributed and are listed as unknown.", a.TotalJoins))
+}
+
+func (c *Invite) inviters(ctx ken.SubCommandContext) (err error) {
+	a, ok, err := c.analytics(ctx)
+	if !ok || err != nil {
+		return
+	}
+
+	if len(a.Inviters) == 0 {
+		return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+			Color:       static.ColorEmbedGray,
+			Description: "No invites with a known creator have been found.",
+		}).Send().Error
+	}
+
+	lines := make([]string, 0, len(a.Inviters))
+	for i, is := range a.Inviters {
+		codes := is.Codes
+		var more string
+		if len(codes) > inviterCodesLimit {
+			more = fmt.Sprintf(" and %d more", len(codes)-inviterCodesLimit)
+			codes = codes[:inviterCodesLimit]
+		}
+		lines = append(lines, fmt.Sprintf("%d. <@%s> — Joins: `%d`\nInvites: `%s`%s",
+			i+1, is.UserID, is.Joins, strings.Join(codes, "`, `"), more))
+	}
+
+	return c.sendPages(ctx, "Top Inviters", lines, fmt.Sprintf(
+		"%d joins recorded in total. Joins via expired or deleted invites are still counted.", a.TotalJoins))
+}
+
+// analytics returns the invite analytics of the current
+// guild. If the invites of the guild can not be accessed,
+// an error message is sent and false is returned.
+func (c *Invite) analytics(ctx ken.SubCommandContext) (a models.InviteAnalytics, ok bool, err error) {
+	it := ctx.Get(static.DiInviteTracker).(*invitetracker.InviteTracker)
+
+	a, err = it.Analytics(ctx.GetEvent().GuildID)
+	if discordutil.IsErrCode(err, discordgo.ErrCodeMissingPermissions) {
+		err = ctx.FollowUpError(
+			"I need the permission `Manage Server` to view the invites of this guild.", "").
+			Send().Error
+		return a, false, err
+	}
+
+	return a, err == nil, err
+}
+
+func (c *Invite) sendPages(ctx ken.SubCommandContext, title string, lines []string, footer string) (err error) {
+	db := ctx.Get(static.DiDatabase).(database.Database)
+	color := util.GuildEmbedColor(db, ctx.GetEvent().GuildID)
+
+	pages := make([]*discordgo.MessageEmbed, 0, (len(lines)+inviteStatsPerPage-1)/inviteStatsPerPage)
+	for i := 0; i < len(lines); i += inviteStatsPerPage {
+		end := i + inviteStatsPerPage
+		if end > len(lines) {
+			end = len(lines)
+		}
+		pages = append(pages, embedbuilder.New().
+			WithColor(color).
+			WithTitle(title).
+			WithDescription(strings.Join(lines[i:end], "\n")).
+			WithFooter(footer, "", "").
+			Build())
+	}
+
+	s := ctx.GetSession()
+	_, err = paginator.Send(s, ctx.GetEvent().ChannelID, pages, &paginator.Options{
+		UserID: ctx.User().ID,
+	})
+	if err != nil {
+		return
+	}
+
+	return s.InteractionResponseDelete(ctx.GetEvent().Interaction)
+}
@@ -4,20 +4,21 @@ import (
 	"fmt"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/zekroTJA/shinpuru/internal/middleware"
 	"github.com/zekroTJA/shinpuru/internal/services/database"
 	"github.com/zekroTJA/shinpuru/internal/services/permissions"
+	"github.com/zekroTJA/shinpuru/internal/util/cmdutil"
 	"github.com/zekroTJA/shinpuru/internal/util/static"
 	"github.com/zekrotja/dgrs"
 	"github.com/zekrotja/ken"
 )
 
-type Karma struct {
-	ken.EphemeralCommand
-}
+type Karma struct{}
 
 var (
-	_ ken.SlashCommand        = (*Karma)(nil)
-	_ permissions.PermCommand = (*Karma)(nil)
+	_ ken.SlashCommand              = (*Karma)(nil)
+	_ permissions.PermCommand       = (*Karma)(nil)
+	_ middleware.GuildScopedCommand = (*Karma)(nil)
 )
 
 func (c *Karma) Name() string {
@@ -29,7 +30,7 @@ func (c *Karma) Description() string {
 }
 
 func (c *Karma) Version() string {
-	return "1.0.0"
+	return "3.0.0"
 }
 
 func (c *Karma) Type() discordgo.ApplicationCommandType {
@@ -43,6 +44,12 @@ func (c *Karma) Options() []*discordgo.ApplicationCommandOption {
 			Name:        "user",
 			Description: "Display karma stats of a specific user.",
 		},
+		{
+			Type:        discordgo.ApplicationCommandOptionBoolean,
+			Name:        "private",
+			Description: "Only show the response to you (default true).",
+		},
+		cmdutil.GuildOption(),
 	}
 }
 
@@ -54,17 +61,36 @@ func (c *Karma) SubDomains() []permissions.SubPermission {
 	return nil
 }
 
+func (c *Karma) IsDmCapable() bool {
+	return true
+}
+
+func (c *Karma) IsGuildScoped() bool {
+	return true
+}
+
 func (c *Karma) Run(ctx ken.Context) (err error) {
+	var user *discordgo.User
+	if userV, ok := ctx.Options().GetByNameOptional("user"); ok {
+		user = userV.UserValue(ctx)
+	}
+
+	private := true
+	if privateV, ok := ctx.Options().GetByNameOptional("private"); ok {
+		private = privateV.BoolValue()
+	}
+	ctx.SetEphemeral(private)
+
 	if err = ctx.Defer(); err != nil {
 		return
 	}
 
-	if userV, ok := ctx.Options().GetByNameOptional("user"); ok {
-		return c.userKarma(ctx, userV.UserValue(ctx))
+	if user != nil {
+		return c.userKarma(ctx, user)
 	}
 
 	db := ctx.Get(static.DiDatabase).(database.Database)
-	st := ctx.Get(static.DiState).(*dgrs.State)
+	st := ctx.Get(static.DiState).(dgrs.IState)
 
 	karma, err := db.GetKarma(ctx.User().ID, ctx.GetEvent().GuildID)
 	if err != nil && !database.IsErrDatabaseNotFound(err) {
@@ -127,7 +153,7 @@ func (c *Karma) Run(ctx ken.Context) (err error) {
 }
 
 func (c *Karma) userKarma(ctx ken.Context, user *discordgo.User) error {
-	st := ctx.Get(static.DiState).(*dgrs.State)
+	st := ctx.Get(static.DiState).(dgrs.IState)
 	db := ctx.Get(static.DiDatabase).(database.Database)
 
 	memb, err := st.Member(ctx.GetEvent().GuildID, user.ID)
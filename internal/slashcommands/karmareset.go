@@ -0,0 +1,186 @@
+package slashcommands
+
+import (
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/zekroTJA/shinpuru/internal/middleware"
+	"github.com/zekroTJA/shinpuru/internal/services/karma"
+	"github.com/zekroTJA/shinpuru/internal/services/permissions"
+	"github.com/zekroTJA/shinpuru/internal/util/cmdutil"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/pkg/acceptmsg/v2"
+	"github.com/zekrotja/ken"
+)
+
+type KarmaReset struct{}
+
+var (
+	_ ken.SlashCommand              = (*KarmaReset)(nil)
+	_ permissions.PermCommand       = (*KarmaReset)(nil)
+	_ middleware.GuildScopedCommand = (*KarmaReset)(nil)
+)
+
+func (c *KarmaReset) Name() string {
+	return "karmareset"
+}
+
+func (c *KarmaReset) Description() string {
+	return "Reset the karma of a user or of all users of the guild."
+}
+
+func (c *KarmaReset) Version() string {
+	return "1.0.0"
+}
+
+func (c *KarmaReset) Type() discordgo.ApplicationCommandType {
+	return discordgo.ChatApplicationCommand
+}
+
+func (c *KarmaReset) Options() []*discordgo.ApplicationCommandOption {
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "user",
+			Description: "Reset the karma of a user.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionUser,
+					Name:        "user",
+					Description: "The user to reset the karma of.",
+					Required:    true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "value",
+					Description: "The value the karma is set to (default 0).",
+				},
+				cmdutil.GuildOption(),
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "all",
+			Description: "Reset the karma of all users of the guild.",
+			Options: []*discordgo.ApplicationCommandOption{
+				cmdutil.GuildOption(),
+			},
+		},
+	}
+}
+
+func (c *KarmaReset) Domain() string {
+	return "sp.guild.mod.karma"
+}
+
+func (c *KarmaReset) SubDomains() []permissions.SubPermission {
+	return []permissions.SubPermission{
+		{
+			Term:        "/sp.guild.admin.karma",
+			Explicit:    false,
+			Description: "Reset the karma of all users of the guild.",
+		},
+	}
+}
+
+func (c *KarmaReset) IsDmCapable() bool {
+	return true
+}
+
+func (c *KarmaReset) IsGuildScoped() bool {
+	return true
+}
+
+func (c *KarmaReset) Run(ctx ken.Context) (err error) {
+	ctx.SetEphemeral(true)
+	if err = ctx.Defer(); err != nil {
+		return
+	}
+
+	err = ctx.HandleSubCommands(
+		ken.SubCommandHandler{"user", c.user},
+		ken.SubCommandHandler{"all", c.all},
+	)
+
+	return
+}
+
+func (c *KarmaReset) user(ctx ken.SubCommandContext) (err error) {
+	karmaSvc := ctx.Get(static.DiKarma).(karma.Provider)
+
+	user := ctx.Options().GetByName("user").UserValue(ctx)
+	var value int
+	if valueV, ok := ctx.Options().GetByNameOptional("value"); ok {
+		value = int(valueV.IntValue())
+	}
+
+	err = karmaSvc.Reset(ctx.GetEvent().GuildID, user.ID, ctx.User().ID, value)
+	if err == karma.ErrBlockListed {
+		return ctx.FollowUpError(
+			fmt.Sprintf("%s is karma blocklisted and can not gain karma.", user.Mention()), "").
+			Send().Error
+	}
+	if err != nil {
+		return
+	}
+
+	return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+		Description: fmt.Sprintf("The karma of %s has been set to **%d**.", user.Mention(), value),
+		Color:       static.ColorEmbedGreen,
+	}).Send().Error
+}
+
+func (c *KarmaReset) all(ctx ken.SubCommandContext) (err error) {
+	if ok, err := c.checkPermission(ctx, "sp.guild.admin.karma"); !ok || err != nil {
+		return err
+	}
+
+	karmaSvc := ctx.Get(static.DiKarma).(karma.Provider)
+
+	accMsg := &acceptmsg.AcceptMessage{
+		Ken:            ctx.GetKen(),
+		DeleteMsgAfter: true,
+		UserID:         ctx.User().ID,
+		Embed: &discordgo.MessageEmbed{
+			Color: static.ColorEmbedOrange,
+			Description: ":warning:  **WARNING**  :warning:\n\n" +
+				"By pressing \"Accept\", the karma of **all users** of this guild will be **reset** to 0. " +
+				"This can not be undone!",
+		},
+		DeclineFunc: func(cctx ken.ComponentContext) error {
+			return cctx.RespondError("Canceled.", "")
+		},
+		AcceptFunc: func(cctx ken.ComponentContext) (err error) {
+			if err = cctx.Defer(); err != nil {
+				return
+			}
+			if err = karmaSvc.ResetGuild(ctx.GetEvent().GuildID, ctx.User().ID); err != nil {
+				return
+			}
+			return cctx.FollowUpEmbed(&discordgo.MessageEmbed{
+				Description: "The karma of all users of this guild has been reset.",
+				Color:       static.ColorEmbedGreen,
+			}).Send().Error
+		},
+	}
+
+	if _, err = accMsg.AsFollowUp(ctx); err != nil {
+		return err
+	}
+	return accMsg.Error()
+}
+
+// checkPermission returns false and responds with an
+// error message if the executor lacks the given
+// permission.
+func (c *KarmaReset) checkPermission(ctx ken.Context, perm string) (ok bool, err error) {
+	pmw := ctx.Get(static.DiPermissions).(*permissions.Permissions)
+
+	ok, _, err = pmw.CheckPermissions(ctx.GetSession(), ctx.GetEvent().GuildID, ctx.User().ID, perm)
+	if err != nil || ok {
+		return
+	}
+
+	err = ctx.FollowUpError("You don't have the required permissions.", "").Send().Error
+	return
+}
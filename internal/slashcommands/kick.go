@@ -46,7 +46,7 @@ func (c *Kick) Options() []*discordgo.ApplicationCommandOption {
 		{
 			Type:        discordgo.ApplicationCommandOptionString,
 			Name:        "reason",
-			Description: "A short and concise report reason.",
+			Description: "The report reason. Supports the flags --reason \"...\" and --silent.",
 			Required:    true,
 		},
 		{
@@ -64,7 +64,7 @@ func (c *Lock) Run(ctx ken.Context) (err error) {
 		return
 	}
 
-	st := ctx.Get(static.DiState).(*dgrs.State)
+	st := ctx.Get(static.DiState).(dgrs.IState)
 	db := ctx.Get(static.DiDatabase).(database.Database)
 
 	var ch *discordgo.Channel
@@ -88,7 +88,7 @@ func (c *Lock) Run(ctx ken.Context) (err error) {
 }
 
 func (c *Lock) lock(target *discordgo.Channel, ctx ken.Context) error {
-	st := ctx.Get(static.DiState).(*dgrs.State)
+	st := ctx.Get(static.DiState).(dgrs.IState)
 	db := ctx.Get(static.DiDatabase).(database.Database)
 
 	procMsg := ctx.FollowUpEmbed(&discordgo.MessageEmbed{
@@ -132,7 +132,7 @@ func (c *Maintenance) Run(ctx ken.Context) (err error) {
 }
 
 func (c *Maintenance) flushState(ctx ken.SubCommandContext) (err error) {
-	st := ctx.Get(static.DiState).(*dgrs.State)
+	st := ctx.Get(static.DiState).(dgrs.IState)
 
 	subkeys := ([]string)(nil)
 	if subkeysV, ok := ctx.Options().GetByNameOptional("subkeys"); ok {
@@ -0,0 +1,260 @@
+package slashcommands
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/services/kvcache"
+	"github.com/zekroTJA/shinpuru/internal/services/permissions"
+	"github.com/zekroTJA/shinpuru/internal/services/timeprovider"
+	"github.com/zekroTJA/shinpuru/internal/util"
+	"github.com/zekroTJA/shinpuru/internal/util/messagestats"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/pkg/embedbuilder"
+	"github.com/zekroTJA/shinpuru/pkg/hammertime"
+	"github.com/zekrotja/ken"
+)
+
+const (
+	messageStatsMaxChannels = 10
+	messageStatsMaxHours    = 5
+)
+
+type MessageStats struct{}
+
+var (
+	_ ken.SlashCommand        = (*MessageStats)(nil)
+	_ permissions.PermCommand = (*MessageStats)(nil)
+)
+
+func (c *MessageStats) Name() string {
+	return "messagestats"
+}
+
+func (c *MessageStats) Description() string {
+	return "Show the tracked message statistics of the guild."
+}
+
+func (c *MessageStats) Version() string {
+	return "1.0.0"
+}
+
+func (c *MessageStats) Type() discordgo.ApplicationCommandType {
+	return discordgo.ChatApplicationCommand
+}
+
+func (c *MessageStats) Options() []*discordgo.ApplicationCommandOption {
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "show",
+			Description: "Show the message statistics per channel.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type: discordgo.ApplicationCommandOptionInteger,
+					Name: "days",
+					Description: fmt.Sprintf("The number of days to show statistics for (default: %d).",
+						messagestats.DefaultPeriodDays),
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "enable",
+			Description: "Start tracking message statistics in this guild.",
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "disable",
+			Description: "Stop tracking message statistics and delete all tracked statistics.",
+		},
+	}
+}
+
+func (c *MessageStats) Domain() string {
+	return "sp.guild.mod.messagestats"
+}
+
+func (c *MessageStats) SubDomains() []permissions.SubPermission {
+	return []permissions.SubPermission{
+		{
+			Term:        "/sp.guild.config.messagestats",
+			Explicit:    false,
+			Description: "Allows enabling and disabling message statistics.",
+		},
+	}
+}
+
+func (c *MessageStats) Run(ctx ken.Context) (err error) {
+	if err = ctx.Defer(); err != nil {
+		return
+	}
+
+	err = ctx.HandleSubCommands(
+		ken.SubCommandHandler{"show", c.show},
+		ken.SubCommandHandler{"enable", c.enable},
+		ken.SubCommandHandler{"disable", c.disable},
+	)
+
+	return
+}
+
+func (c *MessageStats) show(ctx ken.SubCommandContext) (err error) {
+	db := ctx.Get(static.DiDatabase).(database.Database)
+	tp := ctx.Get(static.DiTimeProvider).(timeprovider.Provider)
+
+	guildID := ctx.GetEvent().GuildID
+
+	enabled, err := db.GetGuildMessageStatsEnabled(guildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return
+	}
+	if !enabled {
+		return ctx.FollowUpError(
+			"Message statistics are not enabled in this guild. Use `/messagestats enable` to enable them.", "").
+			Send().Error
+	}
+
+	days := messagestats.DefaultPeriodDays
+	if v, ok := ctx.Options().GetByNameOptional("days"); ok {
+		days = int(v.IntValue())
+	}
+	if days < 1 || days > messagestats.MaxPeriodDays {
+		return ctx.FollowUpError(
+			fmt.Sprintf("Days must be in range [1, %d].", messagestats.MaxPeriodDays), "").
+			Send().Error
+	}
+
+	stats, err := messagestats.Get(db, tp, guildID, days)
+	if err != nil {
+		return
+	}
+
+	emb := embedbuilder.New().
+		WithColor(util.GuildEmbedColor(db, guildID)).
+		WithTitle(fmt.Sprintf("Message Statistics (%d days)", days)).
+		WithDescription(fmt.Sprintf("`%d` messages have been sent since %s.",
+			stats.Total, hammertime.Format(stats.Since, hammertime.LongerDateTime))).
+		AddField("Channels", c.formatChannels(stats)).
+		AddInlineField("Top Posters", c.formatTopPosters(stats)).
+		AddInlineField("Most Active Hours (UTC)", c.formatHours(stats)).
+		WithFooter("Statistics are updated every few minutes.", "", "").
+		Build()
+
+	return ctx.FollowUpEmbed(emb).Send().Error
+}
+
+func (c *MessageStats) enable(ctx ken.SubCommandContext) (err error) {
+	db := ctx.Get(static.DiDatabase).(database.Database)
+
+	ok, err := c.checkPermission(ctx, "You are not permitted to enable message statistics.")
+	if !ok {
+		return
+	}
+
+	if err = db.SetGuildMessageStatsEnabled(ctx.GetEvent().GuildID, true); err != nil {
+		return
+	}
+
+	return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+		Description: fmt.Sprintf("Message statistics are now enabled. shinpuru counts the messages sent per "+
+			"channel and user, but never stores any message contents. Statistics are kept for %d days.",
+			messagestats.MaxPeriodDays),
+	}).Send().Error
+}
+
+func (c *MessageStats) disable(ctx ken.SubCommandContext) (err error) {
+	db := ctx.Get(static.DiDatabase).(database.Database)
+	kvc := ctx.Get(static.DiKVCache).(kvcache.Provider)
+
+	ok, err := c.checkPermission(ctx, "You are not permitted to disable message statistics.")
+	if !ok {
+		return
+	}
+
+	if err = messagestats.Disable(db, kvc, ctx.GetEvent().GuildID); err != nil {
+		return
+	}
+
+	return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+		Description: "Message statistics are now disabled and all tracked statistics have been deleted.",
+	}).Send().Error
+}
+
+func (c *MessageStats) checkPermission(ctx ken.Context, message string) (ok bool, err error) {
+	pmw := ctx.Get(static.DiPermissions).(*permissions.Permissions)
+
+	ok, _, err = pmw.CheckPermissions(ctx.GetSession(), ctx.GetEvent().GuildID, ctx.User().ID,
+		"sp.guild.config.messagestats")
+	if err != nil || ok {
+		return
+	}
+
+	err = ctx.FollowUpError(message, "").Send().Error
+	return
+}
+
+func (c *MessageStats) formatChannels(stats models.MessageStats) string {
+	if len(stats.Channels) == 0 {
+		return "*No messages tracked.*"
+	}
+
+	channels := stats.Channels
+	if len(channels) > messageStatsMaxChannels {
+		channels = channels[:messageStatsMaxChannels]
+	}
+
+	lines := make([]string, len(channels))
+	for i, ch := range channels {
+		lines[i] = fmt.Sprintf("<#%s> – `%d` (%.1f%%)",
+			ch.ChannelID, ch.Count, float64(ch.Count)/float64(stats.Total)*100)
+	}
+	if n := len(stats.Channels) - len(channels); n > 0 {
+		lines = append(lines, fmt.Sprintf("*... and %d more*", n))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func (c *MessageStats) formatTopPosters(stats models.MessageStats) string {
+	if len(stats.TopPosters) == 0 {
+		return "*No messages tracked.*"
+	}
+
+	lines := make([]string, len(stats.TopPosters))
+	for i, u := range stats.TopPosters {
+		lines[i] = fmt.Sprintf("%d. <@%s> – `%d`", i+1, u.UserID, u.Count)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func (c *MessageStats) formatHours(stats models.MessageStats) string {
+	hours := make([]int, 0, len(stats.Hours))
+	for h, n := range stats.Hours {
+		if n > 0 {
+			hours = append(hours, h)
+		}
+	}
+	if len(hours) == 0 {
+		return "*No messages tracked.*"
+	}
+
+	sort.SliceStable(hours, func(i, j int) bool {
+		return stats.Hours[hours[i]] > stats.Hours[hours[j]]
+	})
+	if len(hours) > messageStatsMaxHours {
+		hours = hours[:messageStatsMaxHours]
+	}
+
+	lines := make([]string, len(hours))
+	for i, h := range hours {
+		lines[i] = fmt.Sprintf("`%02d:00` – `%d`", h, stats.Hours[h])
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -2,8 +2,10 @@ package slashcommands
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/zekroTJA/shinpuru/internal/models"
 	"github.com/zekroTJA/shinpuru/internal/services/database"
 	"github.com/zekroTJA/shinpuru/internal/services/permissions"
 	"github.com/zekroTJA/shinpuru/internal/util/static"
@@ -54,6 +56,58 @@ func (c *Modlog) Options() []*discordgo.ApplicationCommandOption {
 			Name:        "disable",
 			Description: "Disable modlog.",
 		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "format",
+			Description: "Set the verbosity of mod log entries.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "format",
+					Description: "The format of mod log entries.",
+					Required:    true,
+					Choices: []*discordgo.ApplicationCommandOptionChoice{
+						{
+							Name:  "minimal (one line per report)",
+							Value: string(models.ModLogFormatMinimal),
+						},
+						{
+							Name:  "standard",
+							Value: string(models.ModLogFormatStandard),
+						},
+						{
+							Name:  "detailed (with account age and prior reports)",
+							Value: string(models.ModLogFormatDetailed),
+						},
+					},
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "route",
+			Description: "Post entries of an action in a dedicated channel instead of the mod log channel.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "action",
+					Description: "The action to be routed.",
+					Required:    true,
+					Choices:     c.actionChoices(),
+				},
+				{
+					Type:         discordgo.ApplicationCommandOptionChannel,
+					Name:         "channel",
+					Description:  "The channel to post the entries in (mod log channel if not specified).",
+					ChannelTypes: []discordgo.ChannelType{discordgo.ChannelTypeGuildText},
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "routes",
+			Description: "List the channels mod log entries are posted in.",
+		},
 	}
 }
 
@@ -73,6 +127,9 @@ func (c *Modlog) Run(ctx ken.Context) (err error) {
 	err = ctx.HandleSubCommands(
 		ken.SubCommandHandler{"set", c.set},
 		ken.SubCommandHandler{"disable", c.disable},
+		ken.SubCommandHandler{"format", c.format},
+		ken.SubCommandHandler{"route", c.route},
+		ken.SubCommandHandler{"routes", c.routes},
 	)
 
 	return
@@ -137,3 +194,97 @@ func (c *Modlog) disable(ctx ken.SubCommandContext) (err error) {
 		Description: "Modloging disabled.",
 	}).Send().Error
 }
+
+func (c *Modlog) format(ctx ken.SubCommandContext) (err error) {
+	db := ctx.Get(static.DiDatabase).(database.Database)
+
+	format := models.ModLogFormat(ctx.Options().GetByName("format").StringValue())
+	if !format.Validate() {
+		return ctx.FollowUpError("Invalid mod log format.", "").Send().Error
+	}
+
+	if err = db.SetGuildModLogFormat(ctx.GetEvent().GuildID, format); err != nil {
+		return
+	}
+
+	return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+		Description: fmt.Sprintf("Set mod log format to `%s`.", format),
+	}).Send().Error
+}
+
+func (c *Modlog) route(ctx ken.SubCommandContext) (err error) {
+	db := ctx.Get(static.DiDatabase).(database.Database)
+	guildID := ctx.GetEvent().GuildID
+
+	action := models.ModLogAction(ctx.Options().GetByName("action").StringValue())
+	if !action.Validate() {
+		return ctx.FollowUpError("Invalid mod log action.", "").Send().Error
+	}
+
+	chV, ok := ctx.Options().GetByNameOptional("channel")
+	if !ok {
+		err = db.RemoveGuildModLogRoute(guildID, action)
+		if err != nil && !database.IsErrDatabaseNotFound(err) {
+			return
+		}
+		return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+			Description: fmt.Sprintf("`%s` entries are now posted in the mod log channel.", action),
+		}).Send().Error
+	}
+
+	ch := chV.ChannelValue(ctx)
+
+	if err = db.SetGuildModLogRoute(guildID, action, ch.ID); err != nil {
+		return
+	}
+
+	return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+		Description: fmt.Sprintf("`%s` entries are now posted in <#%s>.", action, ch.ID),
+	}).Send().Error
+}
+
+func (c *Modlog) routes(ctx ken.SubCommandContext) (err error) {
+	db := ctx.Get(static.DiDatabase).(database.Database)
+	guildID := ctx.GetEvent().GuildID
+
+	defChan, err := db.GetGuildModLog(guildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return
+	}
+
+	routes, err := db.GetGuildModLogRoutes(guildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return
+	}
+
+	target := func(chanID string) string {
+		if chanID == "" {
+			return "*disabled*"
+		}
+		return fmt.Sprintf("<#%s>", chanID)
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "**Default:** %s\n", target(defChan))
+	for _, action := range models.ModLogActions {
+		if chanID, ok := routes[action]; ok {
+			fmt.Fprintf(&sb, "\n`%s`: %s", action, target(chanID))
+		}
+	}
+
+	return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+		Title:       "Mod Log Channels",
+		Description: sb.String(),
+	}).Send().Error
+}
+
+func (c *Modlog) actionChoices() []*discordgo.ApplicationCommandOptionChoice {
+	choices := make([]*discordgo.ApplicationCommandOptionChoice, len(models.ModLogActions))
+	for i, action := range models.ModLogActions {
+		choices[i] = &discordgo.ApplicationCommandOptionChoice{
+			Name:  string(action),
+			Value: string(action),
+		}
+	}
+	return choices
+}
@@ -0,0 +1,163 @@
+package slashcommands
+
+import (
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/services/permissions"
+	"github.com/zekroTJA/shinpuru/internal/util/modmail"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekrotja/ken"
+)
+
+type Modmail struct{}
+
+var (
+	_ ken.SlashCommand        = (*Modmail)(nil)
+	_ permissions.PermCommand = (*Modmail)(nil)
+)
+
+func (c *Modmail) Name() string {
+	return "modmail"
+}
+
+func (c *Modmail) Description() string {
+	return "Manage modmail conversations."
+}
+
+func (c *Modmail) Version() string {
+	return "1.0.0"
+}
+
+func (c *Modmail) Type() discordgo.ApplicationCommandType {
+	return discordgo.ChatApplicationCommand
+}
+
+func (c *Modmail) Options() []*discordgo.ApplicationCommandOption {
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "set-channel",
+			Description: "Set the channel where modmail threads are created.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type: discordgo.ApplicationCommandOptionChannel,
+					Name: "channel",
+					ChannelTypes: []discordgo.ChannelType{
+						discordgo.ChannelTypeGuildText,
+					},
+					Description: "The modmail channel.",
+					Required:    true,
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "unset-channel",
+			Description: "Unset the modmail channel and disable modmail.",
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "close",
+			Description: "Close the modmail conversation of the current thread.",
+		},
+	}
+}
+
+func (c *Modmail) Domain() string {
+	return "sp.guild.mod.modmail"
+}
+
+func (c *Modmail) SubDomains() []permissions.SubPermission {
+	return []permissions.SubPermission{
+		{
+			Term:        "/sp.guild.config.modmail",
+			Explicit:    false,
+			Description: "Allows setting the modmail channel.",
+		},
+	}
+}
+
+func (c *Modmail) Run(ctx ken.Context) (err error) {
+	if err = ctx.Defer(); err != nil {
+		return
+	}
+
+	err = ctx.HandleSubCommands(
+		ken.SubCommandHandler{"set-channel", c.setChannel},
+		ken.SubCommandHandler{"unset-channel", c.unsetChannel},
+		ken.SubCommandHandler{"close", c.close},
+	)
+
+	return
+}
+
+func (c *Modmail) setChannel(ctx ken.SubCommandContext) (err error) {
+	db := ctx.Get(static.DiDatabase).(database.Database)
+	pmw := ctx.Get(static.DiPermissions).(*permissions.Permissions)
+
+	ok, err := pmw.CheckSubPerm(ctx, "/sp.guild.config.modmail", false,
+		"You are not permitted to edit the modmail channel.")
+	if !ok {
+		return
+	}
+
+	ch := ctx.Options().GetByName("channel").ChannelValue(ctx)
+	err = db.SetGuildModmailChannel(ctx.GetEvent().GuildID, ch.ID)
+	if err != nil {
+		return
+	}
+
+	return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+		Description: fmt.Sprintf(
+			"Modmail channel has been set to <#%s>. Members can now send a DM "+
+				"to shinpuru to contact the staff.", ch.ID),
+	}).Send().Error
+}
+
+func (c *Modmail) unsetChannel(ctx ken.SubCommandContext) (err error) {
+	db := ctx.Get(static.DiDatabase).(database.Database)
+	pmw := ctx.Get(static.DiPermissions).(*permissions.Permissions)
+
+	ok, err := pmw.CheckSubPerm(ctx, "/sp.guild.config.modmail", false,
+		"You are not permitted to edit the modmail channel.")
+	if !ok {
+		return
+	}
+
+	err = db.SetGuildModmailChannel(ctx.GetEvent().GuildID, "")
+	if err != nil {
+		return
+	}
+
+	return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+		Description: "Modmail channel has been reset and modmail is now disabled.",
+	}).Send().Error
+}
+
+func (c *Modmail) close(ctx ken.SubCommandContext) (err error) {
+	db := ctx.Get(static.DiDatabase).(database.Database)
+
+	t, err := db.GetModmailThread(ctx.GetEvent().ChannelID)
+	if database.IsErrDatabaseNotFound(err) || t.GuildID != ctx.GetEvent().GuildID {
+		return ctx.FollowUpError(
+			"This command must be used in an open modmail thread.", "").
+			Send().Error
+	}
+	if err != nil {
+		return
+	}
+
+	// The response must be sent before the thread is
+	// archived because archived threads can not be
+	// written to anymore.
+	err = ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+		Description: "Closing modmail conversation ...",
+	}).Send().Error
+	if err != nil {
+		return
+	}
+
+	return modmail.Close(db, ctx.GetSession(), t, ctx.User())
+}
@@ -2,6 +2,7 @@ package slashcommands
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"time"
 
@@ -15,6 +16,7 @@ import (
 	"github.com/zekroTJA/shinpuru/internal/services/timeprovider"
 	"github.com/zekroTJA/shinpuru/internal/util/imgstore"
 	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/pkg/roleutil"
 	"github.com/zekroTJA/shinpuru/pkg/timeutil"
 	"github.com/zekrotja/dgrs"
 	"github.com/zekrotja/ken"
@@ -120,7 +122,7 @@ func (c *Mute) toggle(ctx ken.SubCommandContext) (err error) {
 			Send().Error
 	}
 
-	st := ctx.Get(static.DiState).(*dgrs.State)
+	st := ctx.Get(static.DiState).(dgrs.IState)
 
 	// TODO: forcefetch is set to true because dgrs does not
 	//       track member timeout states at the moment.
@@ -138,6 +140,9 @@ func (c *Mute) toggle(ctx ken.SubCommandContext) (err error) {
 			ctx.User().ID,
 			victim.ID,
 			reason)
+		if roleutil.IsModerationError(err) {
+			return ctx.FollowUpError(err.Error(), "").Send().Error
+		}
 		if err != nil {
 			return err
 		}
@@ -153,7 +158,13 @@ func (c *Mute) toggle(ctx ken.SubCommandContext) (err error) {
 
 	var attachment string
 	if imageurl, ok := ctx.Options().GetByNameOptional("imageurl"); ok {
-		img, err := imgstore.DownloadFromURL(imageurl.StringValue())
+		img, err := imgstore.DownloadFromURL(imageurl.StringValue(), imgstore.AllowedHosts(
+			cfg.Config().WebServer.PublicAddr, cfg.Config().WebServer.AttachmentHosts))
+		if errors.Is(err, imgstore.ErrHostNotAllowed) {
+			return ctx.FollowUpError(
+				"Images can only be attached from Discord or other allowed hosts.", "").
+				Send().Error
+		}
 		if err == nil && img != nil {
 			st, _ := ctx.Get(static.DiObjectStorage).(storage.Storage)
 			err = st.PutObject(static.StorageBucketImages, img.ID.String(),
@@ -194,7 +205,7 @@ func (c *Mute) toggle(ctx ken.SubCommandContext) (err error) {
 			"Failed creating report: ```\n"+err.Error()+"\n```", "").
 			Send().Error
 	} else {
-		err = ctx.FollowUpEmbed(rep.AsEmbed(cfg.Config().WebServer.PublicAddr)).
+		err = ctx.FollowUpEmbed(rep.AsEmbed(cfg.Config().WebServer.PublicAddr, nil)).
 			Send().Error
 	}
 
@@ -224,7 +235,7 @@ func (c *Mute) list(ctx ken.SubCommandContext) (err error) {
 		muteReportsMap[r.VictimID] = r
 	}
 
-	st := ctx.Get(static.DiState).(*dgrs.State)
+	st := ctx.Get(static.DiState).(dgrs.IState)
 	membs, err := st.Members(ctx.GetEvent().GuildID)
 	if err != nil {
 		return err
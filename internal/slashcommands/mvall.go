@@ -1,11 +1,10 @@
 package slashcommands
 
 import (
-	"fmt"
-
 	"github.com/bwmarrin/discordgo"
 	"github.com/zekroTJA/shinpuru/internal/services/permissions"
 	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/internal/util/voicemove"
 	"github.com/zekrotja/dgrs"
 	"github.com/zekrotja/ken"
 )
@@ -60,7 +59,7 @@ func (c *Mvall) Run(ctx ken.Context) (err error) {
 		return
 	}
 
-	st := ctx.Get(static.DiState).(*dgrs.State)
+	st := ctx.Get(static.DiState).(dgrs.IState)
 
 	channel := ctx.Options().GetByName("channel").ChannelValue(ctx)
 
@@ -84,19 +83,13 @@ func (c *Mvall) Run(ctx ken.Context) (err error) {
 		return err
 	}
 
-	var i int
-	for _, vs := range vss {
-		if vs.ChannelID == vs.ChannelID {
-			err := ctx.GetSession().GuildMemberMove(ctx.GetEvent().GuildID, vs.UserID, &channel.ID)
-			if err != nil {
-				return err
-			}
-			i++
+	var userIDs []string
+	for _, v := range vss {
+		if v.ChannelID == vs.ChannelID {
+			userIDs = append(userIDs, v.UserID)
 		}
 	}
 
-	return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
-		Description: fmt.Sprintf("Moved %d members to channel %s.",
-			i, channel.Name),
-	}).Send().Error
+	res := voicemove.Move(ctx.GetSession(), ctx.GetEvent().GuildID, channel.ID, userIDs)
+	return ctx.FollowUpEmbed(voiceMoveResultEmbed(res, channel.ID)).Send().Error
 }
@@ -0,0 +1,190 @@
+package slashcommands
+
+import (
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/bwmarrin/snowflake"
+	"github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/services/permissions"
+	"github.com/zekroTJA/shinpuru/internal/services/timeprovider"
+	"github.com/zekroTJA/shinpuru/internal/util/snowflakenodes"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekrotja/ken"
+)
+
+type Note struct {
+	ken.EphemeralCommand
+}
+
+var (
+	_ ken.SlashCommand        = (*Note)(nil)
+	_ permissions.PermCommand = (*Note)(nil)
+)
+
+func (c *Note) Name() string {
+	return "note"
+}
+
+func (c *Note) Description() string {
+	return "Manage private moderator notes on users."
+}
+
+func (c *Note) Version() string {
+	return "1.0.0"
+}
+
+func (c *Note) Type() discordgo.ApplicationCommandType {
+	return discordgo.ChatApplicationCommand
+}
+
+func (c *Note) Options() []*discordgo.ApplicationCommandOption {
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "add",
+			Description: "Add a note to a user.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionUser,
+					Name:        "user",
+					Description: "The user to add the note to.",
+					Required:    true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "content",
+					Description: "The content of the note.",
+					Required:    true,
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "list",
+			Description: "List the notes of a user.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionUser,
+					Name:        "user",
+					Description: "The user to list the notes of.",
+					Required:    true,
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "delete",
+			Description: "Delete a note.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "id",
+					Description: "The ID of the note.",
+					Required:    true,
+				},
+			},
+		},
+	}
+}
+
+func (c *Note) Domain() string {
+	return "sp.guild.mod.note"
+}
+
+func (c *Note) SubDomains() []permissions.SubPermission {
+	return nil
+}
+
+func (c *Note) Run(ctx ken.Context) (err error) {
+	if err = ctx.Defer(); err != nil {
+		return
+	}
+
+	err = ctx.HandleSubCommands(
+		ken.SubCommandHandler{"add", c.add},
+		ken.SubCommandHandler{"list", c.list},
+		ken.SubCommandHandler{"delete", c.delete},
+	)
+
+	return
+}
+
+func (c *Note) add(ctx ken.SubCommandContext) (err error) {
+	db := ctx.Get(static.DiDatabase).(database.Database)
+	tp := ctx.Get(static.DiTimeProvider).(timeprovider.Provider)
+
+	user := ctx.Options().GetByName("user").UserValue(ctx)
+
+	note := models.UserNote{
+		ID:       snowflakenodes.NodeUserNotes.Generate(),
+		GuildID:  ctx.GetEvent().GuildID,
+		UserID:   user.ID,
+		AuthorID: ctx.User().ID,
+		Content:  ctx.Options().GetByName("content").StringValue(),
+		Created:  tp.Now(),
+	}
+
+	if err = note.Validate(); err != nil {
+		return ctx.FollowUpError(err.Error(), "").Send().Error
+	}
+
+	if err = db.AddUserNote(note); err != nil {
+		return
+	}
+
+	return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+		Description: fmt.Sprintf("Note `%s` has been added to %s.", note.ID, user.Mention()),
+	}).Send().Error
+}
+
+func (c *Note) list(ctx ken.SubCommandContext) (err error) {
+	db := ctx.Get(static.DiDatabase).(database.Database)
+
+	user := ctx.Options().GetByName("user").UserValue(ctx)
+
+	notes, err := db.GetUserNotes(ctx.GetEvent().GuildID, user.ID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return
+	}
+
+	emb := &discordgo.MessageEmbed{
+		Color: static.ColorEmbedDefault,
+		Title: fmt.Sprintf("Notes for %s", user.String()),
+	}
+
+	if len(notes) == 0 {
+		emb.Description = "There are no notes on this user."
+	} else {
+		emb.Fields = make([]*discordgo.MessageEmbedField, len(notes))
+		for i, n := range notes {
+			emb.Fields[i] = n.AsEmbedField()
+		}
+	}
+
+	return ctx.FollowUpEmbed(emb).Send().Error
+}
+
+func (c *Note) delete(ctx ken.SubCommandContext) (err error) {
+	db := ctx.Get(static.DiDatabase).(database.Database)
+
+	id, err := snowflake.ParseString(ctx.Options().GetByName("id").StringValue())
+	if err != nil {
+		return ctx.FollowUpError("Invalid note ID.", "").Send().Error
+	}
+
+	err = db.DeleteUserNote(ctx.GetEvent().GuildID, id)
+	if database.IsErrDatabaseNotFound(err) {
+		return ctx.FollowUpError(
+			fmt.Sprintf("There is no note with the ID `%s` on this guild.", id), "").
+			Send().Error
+	}
+	if err != nil {
+		return
+	}
+
+	return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+		Description: fmt.Sprintf("Note `%s` has been deleted.", id),
+	}).Send().Error
+}
@@ -83,7 +83,7 @@ func (c *Notify) Run(ctx ken.Context) (err error) {
 
 func (c *Notify) toggle(ctx ken.SubCommandContext) (err error) {
 	db := ctx.Get(static.DiDatabase).(database.Database)
-	st := ctx.Get(static.DiState).(*dgrs.State)
+	st := ctx.Get(static.DiState).(dgrs.IState)
 
 	ctx.SetEphemeral(true)
 	if err = ctx.Defer(); err != nil {
@@ -143,7 +143,7 @@ func (c *Notify) toggle(ctx ken.SubCommandContext) (err error) {
 func (c *Notify) setup(ctx ken.SubCommandContext) (err error) {
 	pmw := ctx.Get(static.DiPermissions).(*permissions.Permissions)
 	db := ctx.Get(static.DiDatabase).(database.Database)
-	st := ctx.Get(static.DiState).(*dgrs.State)
+	st := ctx.Get(static.DiState).(dgrs.IState)
 
 	if err = ctx.Defer(); err != nil {
 		return
@@ -0,0 +1,201 @@
+package slashcommands
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/zekroTJA/shinpuru/internal/services/config"
+	"github.com/zekroTJA/shinpuru/internal/services/permissions"
+	"github.com/zekroTJA/shinpuru/internal/util/imgstore"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/pkg/colors"
+	"github.com/zekrotja/ken"
+)
+
+const (
+	paletteMaxFileSize   = 8 * 1024 * 1024
+	paletteMaxPixels     = 4096 * 4096
+	paletteDecodeTimeout = 5 * time.Second
+)
+
+type Palette struct{}
+
+var (
+	_ ken.SlashCommand        = (*Palette)(nil)
+	_ permissions.PermCommand = (*Palette)(nil)
+)
+
+func (c *Palette) Name() string {
+	return "palette"
+}
+
+func (c *Palette) Description() string {
+	return "Display the dominant colors of an image."
+}
+
+func (c *Palette) Version() string {
+	return "1.0.0"
+}
+
+func (c *Palette) Type() discordgo.ApplicationCommandType {
+	return discordgo.ChatApplicationCommand
+}
+
+func (c *Palette) Options() []*discordgo.ApplicationCommandOption {
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionAttachment,
+			Name:        "image",
+			Description: "The image to extract the colors from.",
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "url",
+			Description: "The URL of the image to extract the colors from.",
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionInteger,
+			Name:        "colors",
+			Description: "The amount of colors to extract (default 5, max 10).",
+		},
+	}
+}
+
+func (c *Palette) Domain() string {
+	return "sp.chat.palette"
+}
+
+func (c *Palette) SubDomains() []permissions.SubPermission {
+	return nil
+}
+
+func (c *Palette) Run(ctx ken.Context) (err error) {
+	if err = ctx.Defer(); err != nil {
+		return
+	}
+
+	var url string
+	if imageV, ok := ctx.Options().GetByNameOptional("image"); ok {
+		attachment, ok := ctx.GetEvent().ApplicationCommandData().Resolved.Attachments[imageV.StringValue()]
+		if !ok {
+			return ctx.FollowUpError("The attached image could not be resolved.", "").Send().Error
+		}
+		if attachment.Size > paletteMaxFileSize || attachment.Width*attachment.Height > paletteMaxPixels {
+			return ctx.FollowUpError("The attached image is too large.", "").Send().Error
+		}
+		url = attachment.URL
+	} else if urlV, ok := ctx.Options().GetByNameOptional("url"); ok {
+		url = urlV.StringValue()
+	} else {
+		return ctx.FollowUpError("Please attach an image or specify an image URL.", "").Send().Error
+	}
+
+	n := 5
+	if nV, ok := ctx.Options().GetByNameOptional("colors"); ok {
+		n = int(nV.IntValue())
+	}
+	if n < 1 || n > 10 {
+		return ctx.FollowUpError("The amount of colors must be in range of 1 and 10.", "").Send().Error
+	}
+
+	cfg := ctx.Get(static.DiConfig).(config.Provider).Config().WebServer
+	hosts := imgstore.AllowedHosts(cfg.PublicAddr, cfg.AttachmentHosts)
+
+	img, err := c.fetchImage(url, hosts)
+	if errors.Is(err, imgstore.ErrHostNotAllowed) {
+		return ctx.FollowUpError("Images can only be loaded from Discord or the configured image hosts.", "").
+			Send().Error
+	}
+	if err != nil {
+		return ctx.FollowUpError(
+			fmt.Sprintf("Failed reading image:\n```\n%s\n```", err.Error()), "").
+			Send().Error
+	}
+
+	palette, err := colors.ExtractPalette(img, n)
+	if err != nil {
+		return ctx.FollowUpError(
+			fmt.Sprintf("Failed extracting colors:\n```\n%s\n```", err.Error()), "").
+			Send().Error
+	}
+
+	strip, err := colors.CreateStripImage(palette, 64*len(palette), 64)
+	if err != nil {
+		return
+	}
+
+	lines := make([]string, len(palette))
+	for i, clr := range palette {
+		lines[i] = fmt.Sprintf("`#%s`", colors.ToHex(clr))
+	}
+
+	return ctx.FollowUp(true, &discordgo.WebhookParams{
+		Embeds: []*discordgo.MessageEmbed{
+			{
+				Title:       "Color Palette",
+				Color:       colors.ToInt(palette[0]),
+				Description: strings.Join(lines, "\n"),
+				Image: &discordgo.MessageEmbedImage{
+					URL: "attachment://palette.png",
+				},
+			},
+		},
+		Files: []*discordgo.File{
+			{
+				Name:        "palette.png",
+				ContentType: "image/png",
+				Reader:      strip,
+			},
+		},
+	}).Send().Error
+}
+
+func (c *Palette) fetchImage(url string, hosts []string) (img image.Image, err error) {
+	data, _, err := imgstore.Fetch(context.Background(), url, hosts, paletteMaxFileSize)
+	if err != nil {
+		return
+	}
+
+	// Check the dimensions before decoding the whole
+	// image to avoid allocating huge pixel buffers.
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return
+	}
+	if cfg.Width*cfg.Height > paletteMaxPixels {
+		return nil, errors.New("image dimensions are too large")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), paletteDecodeTimeout)
+	defer cancel()
+
+	img, _, err = image.Decode(ctxReader{ctx, bytes.NewReader(data)})
+	if errors.Is(err, context.DeadlineExceeded) {
+		return nil, errors.New("image decoding timed out")
+	}
+	return
+}
+
+// ctxReader fails reading from r as soon as ctx is
+// done, which aborts decoders reading from it.
+type ctxReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (r ctxReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.r.Read(p)
+}
@@ -0,0 +1,104 @@
+package slashcommands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/zekroTJA/shinpuru/internal/services/permissions"
+	"github.com/zekroTJA/shinpuru/internal/util/botperms"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/pkg/roleutil"
+	"github.com/zekrotja/ken"
+)
+
+type Permcheck struct{}
+
+var (
+	_ ken.SlashCommand        = (*Permcheck)(nil)
+	_ permissions.PermCommand = (*Permcheck)(nil)
+)
+
+func (c *Permcheck) Name() string {
+	return "permcheck"
+}
+
+func (c *Permcheck) Description() string {
+	return "Check if shinpuru has all required permissions in this channel."
+}
+
+func (c *Permcheck) Version() string {
+	return "1.0.0"
+}
+
+func (c *Permcheck) Type() discordgo.ApplicationCommandType {
+	return discordgo.ChatApplicationCommand
+}
+
+func (c *Permcheck) Options() []*discordgo.ApplicationCommandOption {
+	return []*discordgo.ApplicationCommandOption{}
+}
+
+func (c *Permcheck) Domain() string {
+	return "sp.etc.permcheck"
+}
+
+func (c *Permcheck) SubDomains() []permissions.SubPermission {
+	return nil
+}
+
+func (c *Permcheck) Run(ctx ken.Context) (err error) {
+	if err = ctx.Defer(); err != nil {
+		return
+	}
+
+	s := ctx.GetSession()
+	selfID := s.State.User.ID
+	guildID := ctx.GetEvent().GuildID
+	channelID := ctx.GetEvent().ChannelID
+
+	roles, err := roleutil.GetSortedMemberRoles(s, guildID, selfID, false, true)
+	if err != nil {
+		return
+	}
+	var guildPerms int64
+	for _, r := range roles {
+		guildPerms |= r.Permissions
+	}
+
+	channelPerms, err := s.UserChannelPermissions(selfID, channelID)
+	if err != nil {
+		return
+	}
+
+	emb := &discordgo.MessageEmbed{
+		Color: static.ColorEmbedGreen,
+		Title: "Permission Check",
+	}
+
+	var lines []string
+	for _, f := range botperms.Features {
+		missingGuild, missingChannel := f.Missing(guildPerms, channelPerms)
+		if missingGuild == 0 && missingChannel == 0 {
+			lines = append(lines, fmt.Sprintf("✅ %s", f.Name))
+			continue
+		}
+
+		emb.Color = static.ColorEmbedOrange
+		var missing []string
+		if missingGuild != 0 {
+			missing = append(missing, fmt.Sprintf("guild: `%s`",
+				strings.Join(botperms.PermissionNames(missingGuild), "`, `")))
+		}
+		if missingChannel != 0 {
+			missing = append(missing, fmt.Sprintf("channel: `%s`",
+				strings.Join(botperms.PermissionNames(missingChannel), "`, `")))
+		}
+		lines = append(lines, fmt.Sprintf("❌ %s\n╰ missing %s", f.Name, strings.Join(missing, "; ")))
+	}
+
+	emb.Description = fmt.Sprintf("Permissions of shinpuru in <#%s>:\n\n%s",
+		channelID, strings.Join(lines, "\n"))
+
+	return ctx.FollowUpEmbed(emb).Send().Error
+}
@@ -41,7 +41,7 @@ func (c *Perms) Description() string {
 }
 
 func (c *Perms) Version() string {
-	return "1.0.0"
+	return "1.1.0"
 }
 
 func (c *Perms) Type() discordgo.ApplicationCommandType {
@@ -110,6 +110,29 @@ func (c *Perms) Options() []*discordgo.ApplicationCommandOption {
 				},
 			},
 		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "deniedmessage",
+			Description: "Show or set the message responded when a user lacks permission.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type: discordgo.ApplicationCommandOptionString,
+					Name: "message",
+					Description: "The message. [user], [ment], [cmd] and [perm] " +
+						"are replaced with the user, mention, command and permission.",
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionBoolean,
+					Name:        "silent",
+					Description: "Do not respond to unpermitted command executions at all.",
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionBoolean,
+					Name:        "reset",
+					Description: "Reset to the default message.",
+				},
+			},
+		},
 		{
 			Type:        discordgo.ApplicationCommandOptionSubCommand,
 			Name:        "help",
@@ -154,6 +177,7 @@ func (c *Perms) Run(ctx ken.Context) (err error) {
 	err = ctx.HandleSubCommands(
 		ken.SubCommandHandler{"list", c.list},
 		ken.SubCommandHandler{"set", c.set},
+		ken.SubCommandHandler{"deniedmessage", c.deniedMessage},
 	)
 
 	return
@@ -239,3 +263,68 @@ func (c *Perms) set(ctx ken.SubCommandContext) (err error) {
 			dns, multipleRoles, strings.Join(rolesIds, ", ")),
 	}).Send().Error
 }
+
+func (c *Perms) deniedMessage(ctx ken.SubCommandContext) (err error) {
+	db, _ := ctx.Get(static.DiDatabase).(database.Database)
+	guildID := ctx.GetEvent().GuildID
+
+	if resetV, ok := ctx.Options().GetByNameOptional("reset"); ok && resetV.BoolValue() {
+		if err = db.SetGuildPermDeniedMessage(guildID, "", false); err != nil {
+			return
+		}
+		return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+			Description: "Permission denied message has been reset to default.",
+		}).Send().Error
+	}
+
+	msg, silent, err := db.GetGuildPermDeniedMessage(guildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return
+	}
+
+	msgV, okMsg := ctx.Options().GetByNameOptional("message")
+	silentV, okSilent := ctx.Options().GetByNameOptional("silent")
+
+	if okMsg {
+		msg = msgV.StringValue()
+		if len(msg) > permService.MaxDeniedMessageLength {
+			return ctx.FollowUpError(
+				fmt.Sprintf("The message must not be longer than %d characters.",
+					permService.MaxDeniedMessageLength), "").
+				Send().Error
+		}
+	}
+	if okSilent {
+		silent = silentV.BoolValue()
+	}
+
+	if okMsg || okSilent {
+		if err = db.SetGuildPermDeniedMessage(guildID, msg, silent); err != nil {
+			return
+		}
+	}
+
+	preview := permService.DefaultDeniedMessage
+	if msg != "" {
+		preview = permService.FormatDeniedMessage(msg, ctx.User(), "perms", c.Domain())
+	}
+
+	title := "Permission denied message"
+	if okMsg || okSilent {
+		title = "Permission denied message updated"
+	}
+
+	return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+		Title: title,
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name:  "Preview",
+				Value: preview,
+			},
+			{
+				Name:  "Silent",
+				Value: fmt.Sprintf("%t", silent),
+			},
+		},
+	}).Send().Error
+}
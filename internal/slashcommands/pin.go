@@ -0,0 +1,314 @@
+package slashcommands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/bwmarrin/snowflake"
+	"github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/services/msgsender"
+	"github.com/zekroTJA/shinpuru/internal/services/permissions"
+	"github.com/zekroTJA/shinpuru/internal/util/modlog"
+	"github.com/zekroTJA/shinpuru/internal/util/pins"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/pkg/discordutil"
+	"github.com/zekrotja/dgrs"
+	"github.com/zekrotja/ken"
+)
+
+type Pin struct{}
+
+var (
+	_ ken.SlashCommand        = (*Pin)(nil)
+	_ permissions.PermCommand = (*Pin)(nil)
+)
+
+func (c *Pin) Name() string {
+	return "pin"
+}
+
+func (c *Pin) Description() string {
+	return "Pin or unpin messages."
+}
+
+func (c *Pin) Version() string {
+	return "1.0.0"
+}
+
+func (c *Pin) Type() discordgo.ApplicationCommandType {
+	return discordgo.ChatApplicationCommand
+}
+
+func (c *Pin) Options() []*discordgo.ApplicationCommandOption {
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "add",
+			Description: "Pin a message.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "message",
+					Description: "The message ID or URL to be pinned.",
+					Required:    true,
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "remove",
+			Description: "Unpin a message.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "message",
+					Description: "The message ID or URL to be unpinned.",
+					Required:    true,
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "rotation",
+			Description: "Unpin the oldest pin when the pin limit of a channel is reached.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionBoolean,
+					Name:        "enabled",
+					Description: "Whether pin rotation is enabled.",
+					Required:    true,
+				},
+			},
+		},
+	}
+}
+
+func (c *Pin) Domain() string {
+	return "sp.guild.mod.pin"
+}
+
+func (c *Pin) SubDomains() []permissions.SubPermission {
+	return []permissions.SubPermission{
+		{
+			Term:        "rotation",
+			Explicit:    true,
+			Description: "Allows enabling or disabling the pin rotation for this guild.",
+		},
+	}
+}
+
+func (c *Pin) Run(ctx ken.Context) (err error) {
+	if err = ctx.Defer(); err != nil {
+		return
+	}
+
+	// When executed as message command, the selected
+	// message is pinned.
+	if resolved := ctx.GetEvent().ApplicationCommandData().Resolved; resolved != nil {
+		for _, msg := range resolved.Messages {
+			return c.pin(ctx, msg.ChannelID, msg.ID)
+		}
+	}
+
+	err = ctx.HandleSubCommands(
+		ken.SubCommandHandler{"add", c.add},
+		ken.SubCommandHandler{"remove", c.remove},
+		ken.SubCommandHandler{"rotation", c.rotation},
+	)
+
+	return
+}
+
+func (c *Pin) add(ctx ken.SubCommandContext) (err error) {
+	channelID, messageID, err := c.parseMessage(ctx)
+	if err != nil {
+		return ctx.FollowUpError(err.Error(), "").Send().Error
+	}
+	return c.pin(ctx, channelID, messageID)
+}
+
+func (c *Pin) remove(ctx ken.SubCommandContext) (err error) {
+	channelID, messageID, err := c.parseMessage(ctx)
+	if err != nil {
+		return ctx.FollowUpError(err.Error(), "").Send().Error
+	}
+
+	if ok, err := c.checkBotPermission(ctx, channelID); err != nil || !ok {
+		return err
+	}
+
+	err = pins.Unpin(ctx.GetSession(), channelID, messageID)
+	if err == pins.ErrNotPinned {
+		return ctx.FollowUpError("The message is not pinned.", "").Send().Error
+	}
+	if discordutil.IsErrCode(err, discordgo.ErrCodeUnknownMessage) {
+		return ctx.FollowUpError("Message could not be found.", "").Send().Error
+	}
+	if err != nil {
+		return
+	}
+
+	c.logModlog(ctx, &discordgo.MessageEmbed{
+		Color: static.ColorEmbedOrange,
+		Title: "Message Unpinned",
+		Description: fmt.Sprintf("<@%s> unpinned [this message](%s) in <#%s>.",
+			ctx.User().ID, c.messageLink(ctx, channelID, messageID), channelID),
+	})
+
+	return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+		Color:       static.ColorEmbedUpdated,
+		Description: fmt.Sprintf("Unpinned [this message](%s).", c.messageLink(ctx, channelID, messageID)),
+	}).Send().Error
+}
+
+func (c *Pin) rotation(ctx ken.SubCommandContext) (err error) {
+	pmw := ctx.Get(static.DiPermissions).(*permissions.Permissions)
+	db := ctx.Get(static.DiDatabase).(database.Database)
+
+	ok, err := pmw.CheckSubPerm(ctx, "rotation", true)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ctx.FollowUpError(
+			"Sorry, but you don't have the permission to change the pin rotation setting.", "").
+			Send().Error
+	}
+
+	enabled := ctx.Options().GetByName("enabled").BoolValue()
+	if err = db.SetGuildPinRotation(ctx.GetEvent().GuildID, enabled); err != nil {
+		return
+	}
+
+	desc := "Pin rotation is now disabled. Pinning is refused when a channel has reached the pin limit."
+	if enabled {
+		desc = fmt.Sprintf("Pin rotation is now enabled. When a channel has reached the limit of %d pins, "+
+			"the oldest pin is removed to make room for the new one.", pins.MaxPins)
+	}
+
+	return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+		Color:       static.ColorEmbedUpdated,
+		Description: desc,
+	}).Send().Error
+}
+
+func (c *Pin) pin(ctx ken.Context, channelID, messageID string) (err error) {
+	db := ctx.Get(static.DiDatabase).(database.Database)
+
+	if ok, err := c.checkBotPermission(ctx, channelID); err != nil || !ok {
+		return err
+	}
+
+	rotate, err := db.GetGuildPinRotation(ctx.GetEvent().GuildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return
+	}
+
+	rotated, err := pins.Pin(ctx.GetSession(), channelID, messageID, rotate)
+	switch {
+	case err == pins.ErrAlreadyPinned:
+		return ctx.FollowUpError("The message is already pinned.", "").Send().Error
+	case err == pins.ErrLimitReached:
+		return ctx.FollowUpError(fmt.Sprintf(
+			"The channel <#%s> has reached the limit of %d pinned messages, so the message could not be pinned.\n\n"+
+				"Unpin another message first or enable the pin rotation via `/pin rotation` to automatically "+
+				"unpin the oldest pin when the limit is reached.",
+			channelID, pins.MaxPins), "Pin limit reached").
+			Send().Error
+	case discordutil.IsErrCode(err, discordgo.ErrCodeUnknownMessage):
+		return ctx.FollowUpError("Message could not be found.", "").Send().Error
+	case err != nil:
+		return
+	}
+
+	link := c.messageLink(ctx, channelID, messageID)
+
+	logEmb := &discordgo.MessageEmbed{
+		Color: static.ColorEmbedCyan,
+		Title: "Message Pinned",
+		Description: fmt.Sprintf("<@%s> pinned [this message](%s) in <#%s>.",
+			ctx.User().ID, link, channelID),
+	}
+	desc := fmt.Sprintf("Pinned [this message](%s).", link)
+
+	if rotated != nil {
+		rotatedLink := discordutil.GetMessageLink(rotated, ctx.GetEvent().GuildID)
+		logEmb.Fields = []*discordgo.MessageEmbedField{
+			{
+				Name: "Rotated Out",
+				Value: fmt.Sprintf("[This message](%s) was unpinned because the pin limit was reached.",
+					rotatedLink),
+			},
+		}
+		desc += fmt.Sprintf("\n\nThe pin limit of the channel was reached, so [the oldest pin](%s) has been removed.",
+			rotatedLink)
+	}
+
+	c.logModlog(ctx, logEmb)
+
+	return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+		Color:       static.ColorEmbedUpdated,
+		Description: desc,
+	}).Send().Error
+}
+
+// parseMessage returns the channel and message ID from
+// the message option, which can either be a message link
+// or a message ID in the current channel.
+func (c *Pin) parseMessage(ctx ken.SubCommandContext) (channelID, messageID string, err error) {
+	st := ctx.Get(static.DiState).(dgrs.IState)
+
+	ident := ctx.Options().GetByName("message").StringValue()
+
+	if linkMatches := linkRx.FindStringSubmatch(ident); len(linkMatches) > 0 {
+		channelID, messageID = linkMatches[1], linkMatches[2]
+		ch, err := st.Channel(channelID)
+		if err != nil || ch.GuildID != ctx.GetEvent().GuildID {
+			return "", "", errors.New("The message must be in a channel of this guild.")
+		}
+		return channelID, messageID, nil
+	}
+
+	if _, err = snowflake.ParseString(ident); err != nil {
+		return "", "", errors.New("Invalid message ID or link.")
+	}
+
+	return ctx.GetEvent().ChannelID, ident, nil
+}
+
+// checkBotPermission returns false and responds with an
+// error message if the bot is not allowed to manage
+// messages in the given channel.
+func (c *Pin) checkBotPermission(ctx ken.Context, channelID string) (ok bool, err error) {
+	s := ctx.GetSession()
+
+	perms, err := s.UserChannelPermissions(s.State.User.ID, channelID)
+	if err != nil {
+		return
+	}
+
+	if perms&discordgo.PermissionManageMessages == 0 {
+		err = ctx.FollowUpError(fmt.Sprintf(
+			"I need the permission `Manage Messages` in <#%s> to pin or unpin messages.", channelID), "").
+			Send().Error
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (c *Pin) logModlog(ctx ken.Context, emb *discordgo.MessageEmbed) {
+	db := ctx.Get(static.DiDatabase).(database.Database)
+	sender := ctx.Get(static.DiMessageSender).(*msgsender.Sender)
+
+	modlog.Send(db, sender, ctx.GetSession(), ctx.GetEvent().GuildID, models.ModLogActionPin, emb)
+}
+
+func (c *Pin) messageLink(ctx ken.Context, channelID, messageID string) string {
+	return discordutil.GetMessageLink(&discordgo.Message{
+		ChannelID: channelID,
+		ID:        messageID,
+	}, ctx.GetEvent().GuildID)
+}
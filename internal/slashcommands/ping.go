@@ -0,0 +1,158 @@
+package slashcommands
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/zekroTJA/shinpuru/internal/services/config"
+	"github.com/zekroTJA/shinpuru/internal/services/permissions"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/pkg/discordutil"
+	"github.com/zekrotja/dgrs"
+	"github.com/zekrotja/ken"
+)
+
+type Ping struct {
+	ken.EphemeralCommand
+}
+
+var (
+	_ ken.SlashCommand        = (*Ping)(nil)
+	_ permissions.PermCommand = (*Ping)(nil)
+	_ ken.DmCapable           = (*Ping)(nil)
+)
+
+func (c *Ping) Name() string {
+	return "ping"
+}
+
+func (c *Ping) Description() string {
+	return "Display the latency to the Discord API and the shard status."
+}
+
+func (c *Ping) Version() string {
+	return "1.0.0"
+}
+
+func (c *Ping) Type() discordgo.ApplicationCommandType {
+	return discordgo.ChatApplicationCommand
+}
+
+func (c *Ping) Options() []*discordgo.ApplicationCommandOption {
+	return []*discordgo.ApplicationCommandOption{}
+}
+
+func (c *Ping) Domain() string {
+	return "sp.etc.ping"
+}
+
+func (c *Ping) SubDomains() []permissions.SubPermission {
+	return nil
+}
+
+func (c *Ping) IsDmCapable() bool {
+	return true
+}
+
+func (c *Ping) Run(ctx ken.Context) (err error) {
+	if err = ctx.Defer(); err != nil {
+		return
+	}
+
+	s := ctx.GetSession()
+
+	// The REST latency is measured by timing a lightweight
+	// request which is not served from the session state.
+	start := time.Now()
+	_, err = s.User("@me")
+	restLatency := time.Since(start)
+
+	restValue := fmt.Sprintf("`%s`", restLatency.Round(time.Millisecond))
+	if err != nil {
+		restValue = "*request failed*"
+	}
+
+	emb := &discordgo.MessageEmbed{
+		Color: static.ColorEmbedDefault,
+		Title: "Pong! 🏓",
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name:   "Gateway Heartbeat",
+				Value:  heartbeatLatency(s),
+				Inline: true,
+			},
+			{
+				Name:   "REST Round Trip",
+				Value:  restValue,
+				Inline: true,
+			},
+		},
+	}
+
+	shardID, shardTotal := discordutil.GetShardOfSession(s)
+	if shardTotal > 1 {
+		shardValue := fmt.Sprintf("`%d` of `%d`", shardID, shardTotal)
+		if guildID := ctx.GetEvent().GuildID; guildID != "" {
+			if guildShard, err := discordutil.GetShardOfGuild(guildID, shardTotal); err == nil {
+				shardValue += fmt.Sprintf("\nThis guild is on shard `%d`.", guildShard)
+			}
+		}
+		emb.Fields = append(emb.Fields, &discordgo.MessageEmbedField{
+			Name:  "Shard",
+			Value: shardValue,
+		})
+
+		cfg := ctx.Get(static.DiConfig).(config.Provider)
+		shardCfg := cfg.Config().Discord.Sharding
+		// Shards are only registered in the state when
+		// their IDs are reserved automatically.
+		if shardCfg.AutoID {
+			st := ctx.Get(static.DiState).(dgrs.IState)
+			if shards, err := st.Shards(shardCfg.Pool); err == nil {
+				emb.Fields = append(emb.Fields, &discordgo.MessageEmbedField{
+					Name:  "Shard Status",
+					Value: shardStatus(shards, shardTotal),
+				})
+			}
+		}
+	}
+
+	return ctx.FollowUpEmbed(emb).Send().Error
+}
+
+// heartbeatLatency returns the formatted latency of the
+// last gateway heartbeat. Until the first heartbeat has
+// been acknowledged, the latency is displayed as being
+// measured.
+func heartbeatLatency(s *discordgo.Session) string {
+	if s.LastHeartbeatSent.IsZero() || s.LastHeartbeatAck.IsZero() {
+		return "*measuring ...*"
+	}
+
+	latency := s.HeartbeatLatency()
+	if latency < 0 {
+		return "*measuring ...*"
+	}
+
+	return fmt.Sprintf("`%s`", latency.Round(time.Millisecond))
+}
+
+func shardStatus(shards []*dgrs.Shard, total int) string {
+	alive := make(map[int]*dgrs.Shard, len(shards))
+	for _, sh := range shards {
+		alive[sh.ID] = sh
+	}
+
+	var sb strings.Builder
+	for id := 0; id < total; id++ {
+		if sh, ok := alive[id]; ok {
+			fmt.Fprintf(&sb, "🟢 `%d` - last heartbeat <t:%d:R>\n", id, sh.LastHeartbeat.Unix())
+		} else {
+			fmt.Fprintf(&sb, "🔴 `%d` - offline\n", id)
+		}
+	}
+
+	return sb.String()
+}
@@ -68,7 +68,7 @@ func (c *Quote) Run(ctx ken.Context) (err error) {
 		return
 	}
 
-	st := ctx.Get(static.DiState).(*dgrs.State)
+	st := ctx.Get(static.DiState).(dgrs.IState)
 
 	var ident, comment string
 
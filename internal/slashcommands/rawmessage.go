@@ -0,0 +1,203 @@
+package slashcommands
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/zekroTJA/shinpuru/internal/services/codeexec"
+	"github.com/zekroTJA/shinpuru/internal/services/permissions"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/pkg/discordutil"
+	"github.com/zekrotja/dgrs"
+	"github.com/zekrotja/ken"
+)
+
+// rawMessageMaxInline is the maximum length of the JSON
+// sent inline in a code block. Larger outputs are
+// uploaded as file instead.
+const rawMessageMaxInline = 1900
+
+type RawMessage struct {
+	ken.EphemeralCommand
+}
+
+var (
+	_ ken.SlashCommand        = (*RawMessage)(nil)
+	_ permissions.PermCommand = (*RawMessage)(nil)
+)
+
+func (c *RawMessage) Name() string {
+	return "rawmessage"
+}
+
+func (c *RawMessage) Description() string {
+	return "Show the raw JSON of a message including embeds, components and attachments."
+}
+
+func (c *RawMessage) Version() string {
+	return "1.0.0"
+}
+
+func (c *RawMessage) Type() discordgo.ApplicationCommandType {
+	return discordgo.ChatApplicationCommand
+}
+
+func (c *RawMessage) Options() []*discordgo.ApplicationCommandOption {
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "message",
+			Description: "The message link or ID.",
+			Required:    true,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionChannel,
+			Name:        "channel",
+			Description: "The channel of the message when passing an ID (selects current channel if not passed).",
+			ChannelTypes: []discordgo.ChannelType{
+				discordgo.ChannelTypeGuildText,
+				discordgo.ChannelTypeGuildNews,
+				discordgo.ChannelTypeGuildPublicThread,
+				discordgo.ChannelTypeGuildPrivateThread,
+				discordgo.ChannelTypeGuildNewsThread,
+			},
+		},
+	}
+}
+
+func (c *RawMessage) Domain() string {
+	return "sp.guild.mod.rawmessage"
+}
+
+func (c *RawMessage) SubDomains() []permissions.SubPermission {
+	return nil
+}
+
+func (c *RawMessage) Run(ctx ken.Context) (err error) {
+	if err = ctx.Defer(); err != nil {
+		return
+	}
+
+	st := ctx.Get(static.DiState).(dgrs.IState)
+
+	guildID := ctx.GetEvent().GuildID
+	channelID := ctx.GetEvent().ChannelID
+	if chV, ok := ctx.Options().GetByNameOptional("channel"); ok {
+		channelID = chV.ChannelValue(ctx).ID
+	}
+
+	msgV := ctx.Options().GetByName("message").StringValue()
+	var messageID string
+	if linkGuildID, linkChannelID, linkMessageID, ok := discordutil.ParseMessageLink(msgV); ok {
+		if linkGuildID != guildID {
+			return ctx.FollowUpError("The linked message is not part of this guild.", "").Send().Error
+		}
+		channelID, messageID = linkChannelID, linkMessageID
+	} else if _, err := strconv.ParseUint(msgV, 10, 64); err == nil {
+		messageID = msgV
+	} else {
+		return ctx.FollowUpError("Please pass a valid message link or ID.", "").Send().Error
+	}
+
+	ch, err := st.Channel(channelID)
+	if err != nil || ch.GuildID != guildID {
+		return ctx.FollowUpError("The channel of the message could not be found.", "").Send().Error
+	}
+
+	guild, err := st.Guild(guildID, true)
+	if err != nil {
+		return
+	}
+	memb, err := st.Member(guildID, ctx.User().ID)
+	if err != nil {
+		return
+	}
+
+	// The invoker must not be able to read messages of
+	// channels they can not see by themselves.
+	if ok, err := c.canViewChannel(ctx.GetSession(), st, guild, ch, memb); err != nil {
+		return err
+	} else if !ok {
+		return ctx.FollowUpError("You are not permitted to see the channel of this message.", "").Send().Error
+	}
+
+	msg, err := ctx.GetSession().ChannelMessage(ch.ID, messageID)
+	if err != nil {
+		if discordutil.IsErrCode(err, discordgo.ErrCodeUnknownMessage) {
+			return ctx.FollowUpError("The message could not be found.", "").Send().Error
+		}
+		return
+	}
+
+	data, err := json.MarshalIndent(msg, "", "  ")
+	if err != nil {
+		return
+	}
+
+	link := discordutil.GetMessageLink(msg, guildID)
+
+	if out, truncated := codeexec.TruncateOutput(string(data), rawMessageMaxInline); !truncated {
+		return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+			Color:       static.ColorEmbedDefault,
+			Title:       "Raw Message",
+			Description: fmt.Sprintf("[Jump to message](%s)\n```json\n%s\n```", link, out),
+		}).Send().Error
+	}
+
+	return ctx.FollowUp(true, &discordgo.WebhookParams{
+		Embeds: []*discordgo.MessageEmbed{
+			{
+				Color: static.ColorEmbedDefault,
+				Title: "Raw Message",
+				Description: fmt.Sprintf("[Jump to message](%s)\n"+
+					"The raw JSON is too large to be displayed inline and is attached as file.", link),
+			},
+		},
+		Files: []*discordgo.File{
+			{
+				Name:        fmt.Sprintf("message-%s.json", msg.ID),
+				ContentType: "application/json",
+				Reader:      bytes.NewReader(data),
+			},
+		},
+	}).Send().Error
+}
+
+// canViewChannel returns true if the given member can see
+// the given channel. Threads inherit the permissions of
+// their parent channel. Private threads can only be seen
+// by their members and by members which can manage
+// threads in the parent channel.
+func (c *RawMessage) canViewChannel(
+	s *discordgo.Session,
+	st dgrs.IState,
+	guild *discordgo.Guild,
+	ch *discordgo.Channel,
+	memb *discordgo.Member,
+) (bool, error) {
+	if !ch.IsThread() {
+		return canView(guild, ch, memb.User.ID, memb.Roles), nil
+	}
+
+	parent, err := st.Channel(ch.ParentID)
+	if err != nil {
+		return false, err
+	}
+
+	perms := discordutil.MemberChannelPermissions(guild, parent, memb.User.ID, memb.Roles)
+	if perms&discordgo.PermissionViewChannel == 0 {
+		return false, nil
+	}
+	if ch.Type != discordgo.ChannelTypeGuildPrivateThread || perms&discordgo.PermissionManageThreads != 0 {
+		return true, nil
+	}
+
+	_, err = s.ThreadMember(ch.ID, memb.User.ID)
+	if discordutil.IsErrCode(err, discordgo.ErrCodeUnknownMember) {
+		return false, nil
+	}
+	return err == nil, err
+}
@@ -0,0 +1,164 @@
+package slashcommands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/services/permissions"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekrotja/ken"
+)
+
+type ReactionReport struct{}
+
+var (
+	_ ken.SlashCommand        = (*ReactionReport)(nil)
+	_ permissions.PermCommand = (*ReactionReport)(nil)
+)
+
+func (c *ReactionReport) Name() string {
+	return "reactionreport"
+}
+
+func (c *ReactionReport) Description() string {
+	return "Let members report messages to the moderators by reacting with an emoji."
+}
+
+func (c *ReactionReport) Version() string {
+	return "1.0.0"
+}
+
+func (c *ReactionReport) Type() discordgo.ApplicationCommandType {
+	return discordgo.ChatApplicationCommand
+}
+
+func (c *ReactionReport) Options() []*discordgo.ApplicationCommandOption {
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "set",
+			Description: "Set reaction report settings.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:         discordgo.ApplicationCommandOptionChannel,
+					Name:         "channel",
+					Description:  "The channel where reported messages are sent to.",
+					ChannelTypes: []discordgo.ChannelType{discordgo.ChannelTypeGuildText},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "emote",
+					Description: "The emote members react with to report a message.",
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "cooldown",
+					Description: "The time in seconds until a member can report another message.",
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "disable",
+			Description: "Disable reaction reports.",
+		},
+	}
+}
+
+func (c *ReactionReport) Domain() string {
+	return "sp.guild.config.reactionreport"
+}
+
+func (c *ReactionReport) SubDomains() []permissions.SubPermission {
+	return nil
+}
+
+func (c *ReactionReport) Run(ctx ken.Context) (err error) {
+	if err = ctx.Defer(); err != nil {
+		return
+	}
+
+	err = ctx.HandleSubCommands(
+		ken.SubCommandHandler{"set", c.set},
+		ken.SubCommandHandler{"disable", c.disable},
+	)
+
+	return
+}
+
+func (c *ReactionReport) set(ctx ken.SubCommandContext) (err error) {
+	cfg, err := c.getConfig(ctx)
+	if err != nil {
+		return
+	}
+
+	if v, ok := ctx.Options().GetByNameOptional("channel"); ok {
+		cfg.ChannelID = v.ChannelValue(ctx).ID
+	}
+	if v, ok := ctx.Options().GetByNameOptional("emote"); ok {
+		cfg.Emoji = strings.TrimSpace(v.StringValue())
+		if !cfg.ValidEmoji() {
+			return ctx.FollowUpError("Please specify a single unicode emoji or custom emote.", "").
+				Send().Error
+		}
+	}
+	if v, ok := ctx.Options().GetByNameOptional("cooldown"); ok {
+		cfg.Cooldown = int(v.IntValue())
+		if cfg.Cooldown < 0 {
+			return ctx.FollowUpError("Cooldown value must be equal or larger than `0`.", "").
+				Send().Error
+		}
+	}
+
+	return c.setConfig(ctx, cfg)
+}
+
+func (c *ReactionReport) disable(ctx ken.SubCommandContext) (err error) {
+	cfg, err := c.getConfig(ctx)
+	if err != nil {
+		return
+	}
+
+	cfg.ChannelID = ""
+
+	return c.setConfig(ctx, cfg)
+}
+
+func (c *ReactionReport) getConfig(ctx ken.SubCommandContext) (cfg models.ReactionReportConfig, err error) {
+	db := ctx.Get(static.DiDatabase).(database.Database)
+
+	cfg, err = db.GetReactionReportConfig(ctx.GetEvent().GuildID)
+	if database.IsErrDatabaseNotFound(err) {
+		cfg = models.ReactionReportConfig{
+			Emoji:    "🚩",
+			Cooldown: models.DefaultReactionReportCooldown,
+		}
+		err = nil
+	}
+	cfg.GuildID = ctx.GetEvent().GuildID
+
+	return
+}
+
+func (c *ReactionReport) setConfig(ctx ken.SubCommandContext, cfg models.ReactionReportConfig) (err error) {
+	db := ctx.Get(static.DiDatabase).(database.Database)
+
+	if err = db.SetReactionReportConfig(cfg); err != nil {
+		return
+	}
+
+	msg := fmt.Sprintf(
+		"Set reaction report config:\n\nChannel: <#%s>\nEmote: %s\nCooldown: `%d` seconds\n\n"+
+			"Reactions are removed right away, so only the moderators can see who reported a message.",
+		cfg.ChannelID, cfg.Emoji, cfg.Cooldown)
+	if cfg.ChannelID == "" {
+		msg = "Reaction reports disabled. Set a channel to enable reaction reports."
+	}
+
+	return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+		Description: msg,
+	}).Send().Error
+}
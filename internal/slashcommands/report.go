@@ -17,6 +17,10 @@ import (
 	"github.com/zekrotja/ken"
 )
 
+// reportListMaxFields is the maximum number of
+// fields of an embed.
+const reportListMaxFields = 25
+
 type Report struct {
 	ken.EphemeralCommand
 }
@@ -35,7 +39,7 @@ func (c *Report) Description() string {
 }
 
 func (c *Report) Version() string {
-	return "1.2.0"
+	return "1.3.0"
 }
 
 func (c *Report) Type() discordgo.ApplicationCommandType {
@@ -74,7 +78,7 @@ func (c *Report) Options() []*discordgo.ApplicationCommandOption {
 				{
 					Type:        discordgo.ApplicationCommandOptionString,
 					Name:        "reason",
-					Description: "A short and concise report reason.",
+					Description: "The report reason. Supports the flags --reason \"...\" and --silent.",
 					Required:    true,
 				},
 				{
@@ -87,6 +91,11 @@ func (c *Report) Options() []*discordgo.ApplicationCommandOption {
 					Name:        "expire",
 					Description: "Expire report after given time.",
 				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "customtype",
+					Description: "Name or ID of a custom report type of this guild used instead of type.",
+				},
 			},
 		},
 		{
@@ -172,6 +181,20 @@ func (c *Report) create(ctx ken.SubCommandContext) (err error) {
 		return
 	}
 
+	if customV, ok := ctx.Options().GetByNameOptional("customtype"); ok {
+		repSvc := ctx.Get(static.DiReport).(report.Provider)
+		types, err := repSvc.GetReportTypes(ctx.GetEvent().GuildID)
+		if err != nil {
+			return err
+		}
+		typ, err = types.FromName(customV.StringValue())
+		if err != nil || !typ.IsCustom() {
+			return ctx.FollowUpError(
+				fmt.Sprintf("There is no custom report type `%s` on this guild.", customV.StringValue()), "").
+				Send().Error
+		}
+	}
+
 	tp := ctx.Get(static.DiTimeProvider).(timeprovider.Provider)
 
 	return cmdutil.CmdReport(ctx, typ, tp)
@@ -205,6 +228,16 @@ func (c *Report) revoke(ctx ken.SubCommandContext) (err error) {
 		}
 		return err
 	}
+	if rep.Revocation != nil {
+		return ctx.FollowUpError(
+			fmt.Sprintf("The report with ID `%d` has already been revoked.", id), "").
+			Send().Error
+	}
+
+	types, err := repSvc.GetReportTypes(rep.GuildID)
+	if err != nil {
+		return
+	}
 
 	aceptMsg := acceptmsg.AcceptMessage{
 		Embed: &discordgo.MessageEmbed{
@@ -212,13 +245,13 @@ func (c *Report) revoke(ctx ken.SubCommandContext) (err error) {
 			Title: "Report Revocation",
 			Description: "Do you really want to revoke this report?\n" +
 				":warning: **WARNING:** Revoking a report will be displayed in the mod log channel (if set) and " +
-				"the revoke will be **deleted** from the database and no more visible again after!",
+				"the report will be no more visible again after!",
 			Fields: []*discordgo.MessageEmbedField{
 				{
 					Name:  "Revocation Reason",
 					Value: reason,
 				},
-				rep.AsEmbedField(cfg.Config().WebServer.PublicAddr),
+				rep.AsEmbedField(cfg.Config().WebServer.PublicAddr, types),
 			},
 		},
 		Ken:            ctx.GetKen(),
@@ -237,6 +270,7 @@ func (c *Report) revoke(ctx ken.SubCommandContext) (err error) {
 				ctx.User().ID,
 				reason,
 				cfg.Config().WebServer.PublicAddr,
+				models.DefaultReportRevokeOptions,
 			)
 
 			if err != nil {
@@ -256,6 +290,7 @@ func (c *Report) revoke(ctx ken.SubCommandContext) (err error) {
 func (c *Report) list(ctx ken.SubCommandContext) (err error) {
 	db, _ := ctx.Get(static.DiDatabase).(database.Database)
 	cfg, _ := ctx.Get(static.DiConfig).(config.Provider)
+	repSvc := ctx.Get(static.DiReport).(report.Provider)
 	pmw := ctx.Get(static.DiPermissions).(*permissions.Permissions)
 
 	ok, err := pmw.CheckSubPerm(ctx, "list", false)
@@ -276,14 +311,45 @@ func (c *Report) list(ctx ken.SubCommandContext) (err error) {
 	if err != nil {
 		return err
 	}
+	types, err := repSvc.GetReportTypes(ctx.GetEvent().GuildID)
+	if err != nil {
+		return err
+	}
+	points, escalation, err := repSvc.GetUserPoints(ctx.GetEvent().GuildID, victim.ID)
+	if err != nil {
+		return err
+	}
+	if escalation {
+		emb.Description += fmt.Sprintf("\n\nThis user currently has **%d** escalation points.", points)
+	}
 	if len(reps) == 0 {
 		emb.Description += "\n\nThis user has a white west. :ok_hand:"
 	} else {
 		emb.Fields = make([]*discordgo.MessageEmbedField, 0)
 		for _, r := range reps {
-			emb.Fields = append(emb.Fields, r.AsEmbedField(cfg.Config().WebServer.PublicAddr))
+			emb.Fields = append(emb.Fields, r.AsEmbedField(cfg.Config().WebServer.PublicAddr, types))
+		}
+	}
+
+	canReadNotes, _, err := pmw.CheckPermissions(ctx.GetSession(), ctx.GetEvent().GuildID, ctx.User().ID, "sp.guild.mod.note")
+	if err != nil {
+		return err
+	}
+	if canReadNotes {
+		notes, err := db.GetUserNotes(ctx.GetEvent().GuildID, victim.ID)
+		if err != nil && !database.IsErrDatabaseNotFound(err) {
+			return err
 		}
+		for _, n := range notes {
+			emb.Fields = append(emb.Fields, n.AsEmbedField())
+		}
+	}
+
+	if len(emb.Fields) > reportListMaxFields {
+		emb.Description += fmt.Sprintf("\n\nOnly %d of %d entries are shown.", reportListMaxFields, len(emb.Fields))
+		emb.Fields = emb.Fields[:reportListMaxFields]
 	}
+
 	err = ctx.FollowUpEmbed(emb).Send().Error
 	return
 }
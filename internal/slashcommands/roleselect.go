@@ -135,7 +135,7 @@ func (c *Roleselect) attach(ctx ken.SubCommandContext) error {
 
 	id := ctx.Options().GetByName("id").StringValue()
 
-	st := ctx.Get(static.DiState).(*dgrs.State)
+	st := ctx.Get(static.DiState).(dgrs.IState)
 
 	msg, err := st.Message(ctx.GetEvent().ChannelID, id)
 	if err != nil {
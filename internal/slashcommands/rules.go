@@ -0,0 +1,276 @@
+package slashcommands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/services/guildlog"
+	"github.com/zekroTJA/shinpuru/internal/services/permissions"
+	"github.com/zekroTJA/shinpuru/internal/util"
+	"github.com/zekroTJA/shinpuru/internal/util/rules"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekrotja/dgrs"
+	"github.com/zekrotja/ken"
+)
+
+type Rules struct{}
+
+var (
+	_ ken.SlashCommand        = (*Rules)(nil)
+	_ permissions.PermCommand = (*Rules)(nil)
+)
+
+func (c *Rules) Name() string {
+	return "rules"
+}
+
+func (c *Rules) Description() string {
+	return "Set up the rules message of the guild and track acceptance."
+}
+
+func (c *Rules) Version() string {
+	return "1.0.0"
+}
+
+func (c *Rules) Type() discordgo.ApplicationCommandType {
+	return discordgo.ChatApplicationCommand
+}
+
+func (c *Rules) Options() []*discordgo.ApplicationCommandOption {
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "set",
+			Description: "Set the rules and post or update the rules message.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "content",
+					Description: "The rules text. You can use markdown as well as `\\n` for line breaks.",
+					Required:    true,
+				},
+				{
+					Type: discordgo.ApplicationCommandOptionChannel,
+					Name: "channel",
+					ChannelTypes: []discordgo.ChannelType{
+						discordgo.ChannelTypeGuildText,
+					},
+					Description: "The channel to post the rules in (defaults to the current rules channel).",
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionBoolean,
+					Name:        "reaccept",
+					Description: "Require members to accept the changed rules again.",
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "role",
+			Description: "Set the role which is given to members accepting the rules.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionRole,
+					Name:        "role",
+					Description: "The role (unsets the role if not specified).",
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "show",
+			Description: "Show the current rules.",
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "status",
+			Description: "Show if a member has accepted the current rules.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionUser,
+					Name:        "user",
+					Description: "The user to check.",
+					Required:    true,
+				},
+			},
+		},
+	}
+}
+
+func (c *Rules) Domain() string {
+	return "sp.guild.config.rules"
+}
+
+func (c *Rules) SubDomains() []permissions.SubPermission {
+	return nil
+}
+
+func (c *Rules) Run(ctx ken.Context) (err error) {
+	if err = ctx.Defer(); err != nil {
+		return
+	}
+
+	err = ctx.HandleSubCommands(
+		ken.SubCommandHandler{"set", c.set},
+		ken.SubCommandHandler{"role", c.role},
+		ken.SubCommandHandler{"show", c.show},
+		ken.SubCommandHandler{"status", c.status},
+	)
+
+	return
+}
+
+func (c *Rules) set(ctx ken.SubCommandContext) (err error) {
+	db := ctx.Get(static.DiDatabase).(database.Database)
+
+	guildID := ctx.GetEvent().GuildID
+
+	r, err := db.GetGuildRules(guildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return
+	}
+	r.GuildID = guildID
+	r.Content = strings.ReplaceAll(ctx.Options().GetByName("content").StringValue(), "\\n", "\n")
+
+	reaccept := false
+	if v, ok := ctx.Options().GetByNameOptional("reaccept"); ok {
+		reaccept = v.BoolValue()
+	}
+	if r.Version == 0 || reaccept {
+		r.Version++
+	}
+
+	channelID := r.ChannelID
+	if v, ok := ctx.Options().GetByNameOptional("channel"); ok {
+		channelID = v.ChannelValue(ctx).ID
+	}
+	if channelID == "" {
+		channelID = ctx.GetEvent().ChannelID
+	}
+
+	// When the rules are moved to another channel, the
+	// old message is removed and a new one is posted.
+	if channelID != r.ChannelID && r.MessageID != "" {
+		ctx.GetSession().ChannelMessageDelete(r.ChannelID, r.MessageID)
+		r.MessageID = ""
+	}
+	r.ChannelID = channelID
+
+	if err = rules.Post(db, ctx.GetSession(), ctx.GetKen().Components(), &r); err != nil {
+		return
+	}
+
+	desc := fmt.Sprintf("The rules have been updated in <#%s>.", r.ChannelID)
+	if reaccept && r.Version > 1 {
+		desc += "\nMembers need to accept the rules again."
+		if r.RoleID != "" {
+			// The ken context is released after the command
+			// has been executed, so all dependencies must be
+			// obtained before starting the go routine.
+			st := ctx.Get(static.DiState).(dgrs.IState)
+			gl := ctx.Get(static.DiGuildLog).(guildlog.Logger).Section("rules")
+			go c.revokeRole(ctx.GetSession(), st, gl, r)
+			desc += fmt.Sprintf(" The role <@&%s> is removed from all members.", r.RoleID)
+		}
+	}
+
+	return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+		Description: desc,
+	}).Send().Error
+}
+
+func (c *Rules) role(ctx ken.SubCommandContext) (err error) {
+	db := ctx.Get(static.DiDatabase).(database.Database)
+
+	r, err := db.GetGuildRules(ctx.GetEvent().GuildID)
+	if database.IsErrDatabaseNotFound(err) {
+		return ctx.FollowUpError(
+			"No rules have been set on this guild. Use `/rules set` to set the rules first.", "").
+			Send().Error
+	}
+	if err != nil {
+		return
+	}
+
+	r.RoleID = ""
+	if v, ok := ctx.Options().GetByNameOptional("role"); ok {
+		r.RoleID = v.RoleValue(ctx).ID
+	}
+
+	if err = db.SetGuildRules(r); err != nil {
+		return
+	}
+
+	desc := "Members will no longer get a role when accepting the rules."
+	if r.RoleID != "" {
+		desc = fmt.Sprintf("Members will now get the role <@&%s> when accepting the rules.", r.RoleID)
+	}
+
+	return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+		Description: desc,
+	}).Send().Error
+}
+
+func (c *Rules) show(ctx ken.SubCommandContext) (err error) {
+	db := ctx.Get(static.DiDatabase).(database.Database)
+
+	r, err := db.GetGuildRules(ctx.GetEvent().GuildID)
+	if database.IsErrDatabaseNotFound(err) {
+		return ctx.FollowUpError("No rules have been set on this guild.", "").
+			Send().Error
+	}
+	if err != nil {
+		return
+	}
+
+	emb := rules.Embed(r)
+	emb.Color = util.GuildEmbedColor(db, r.GuildID)
+
+	return ctx.FollowUpEmbed(emb).Send().Error
+}
+
+func (c *Rules) status(ctx ken.SubCommandContext) (err error) {
+	db := ctx.Get(static.DiDatabase).(database.Database)
+
+	guildID := ctx.GetEvent().GuildID
+	user := ctx.Options().GetByName("user").UserValue(ctx)
+
+	r, err := db.GetGuildRules(guildID)
+	if database.IsErrDatabaseNotFound(err) {
+		return ctx.FollowUpError("No rules have been set on this guild.", "").
+			Send().Error
+	}
+	if err != nil {
+		return
+	}
+
+	accepted, err := db.GetRulesAcceptance(guildID, user.ID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return
+	}
+
+	emb := &discordgo.MessageEmbed{
+		Color:       static.ColorEmbedGreen,
+		Description: fmt.Sprintf("%s has accepted the current rules (version %d).", user.Mention(), r.Version),
+	}
+	if accepted == 0 {
+		emb.Color = static.ColorEmbedOrange
+		emb.Description = fmt.Sprintf("%s has not accepted the rules yet.", user.Mention())
+	} else if accepted < r.Version {
+		emb.Color = static.ColorEmbedOrange
+		emb.Description = fmt.Sprintf("%s has only accepted an older version (%d) of the rules (version %d).",
+			user.Mention(), accepted, r.Version)
+	}
+
+	return ctx.FollowUpEmbed(emb).Send().Error
+}
+
+func (c *Rules) revokeRole(s *discordgo.Session, st dgrs.IState, gl guildlog.Logger, r models.GuildRules) {
+	n, err := rules.RevokeRole(s, st, r.GuildID, r.RoleID)
+	if err != nil {
+		gl.Errorf(r.GuildID, "Failed removing rules role from members after %d removals: %s", n, err.Error())
+	}
+}
@@ -2,13 +2,14 @@ package slashcommands
 
 import (
 	"fmt"
-	"runtime"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/zekroTJA/shinpuru/internal/services/config"
 	"github.com/zekroTJA/shinpuru/internal/services/permissions"
 	"github.com/zekroTJA/shinpuru/internal/util"
 	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/internal/util/sysinfo"
 	"github.com/zekroTJA/shinpuru/pkg/bytecount"
 	"github.com/zekrotja/dgrs"
 	"github.com/zekrotja/ken"
@@ -61,26 +62,23 @@ func (c *Stats) Run(ctx ken.Context) (err error) {
 		return
 	}
 
-	st := ctx.Get(static.DiState).(*dgrs.State)
+	cfg := ctx.Get(static.DiConfig).(config.Provider).Config()
+	st := ctx.Get(static.DiState).(dgrs.IState)
 
-	uptime := time.Since(util.StatsStartupTime)
-
-	guilds, err := st.Guilds()
+	info, err := sysinfo.Get(st)
 	if err != nil {
-		return err
+		return
 	}
 
-	var guildUsers int
-	for _, g := range guilds {
-		guildUsers += g.MemberCount
-	}
+	runtimeStats := fmt.Sprintf("Running Go Routines: **%d**\nUsed CPU Threads: **%d**",
+		info.GoRoutines, info.CPUs)
 
-	nGoroutines := runtime.NumGoroutine()
-	usedCPUs := runtime.NumCPU()
-	var memStats runtime.MemStats
-	runtime.ReadMemStats(&memStats)
-	usedHeap := bytecount.Format(memStats.HeapInuse)
-	usedStack := bytecount.Format(memStats.StackInuse)
+	// Memory usage is only shown to the bot owner
+	// unless it is configured to be public.
+	if cfg.Discord.PublicResourceUsage || ctx.User().ID == cfg.Discord.OwnerID {
+		runtimeStats += fmt.Sprintf("\nUsed Heap: **%s**\nUsed Stack: **%s**",
+			bytecount.Format(info.HeapUse), bytecount.Format(info.StackUse))
+	}
 
 	emb := &discordgo.MessageEmbed{
 		Color: static.ColorEmbedDefault,
@@ -88,7 +86,7 @@ func (c *Stats) Run(ctx ken.Context) (err error) {
 		Fields: []*discordgo.MessageEmbedField{
 			{
 				Name:  "Uptime",
-				Value: uptime.Round(time.Second).String(),
+				Value: info.Uptime.Round(time.Second).String(),
 			},
 			{
 				Name: "Stats since startup",
@@ -98,12 +96,11 @@ func (c *Stats) Run(ctx ken.Context) (err error) {
 			{
 				Name: "Guilds & Members",
 				Value: fmt.Sprintf("Serving **%d** guilds with **%d** members in total.",
-					len(guilds), guildUsers),
+					info.Guilds, info.Members),
 			},
 			{
-				Name: "Runtime Stats",
-				Value: fmt.Sprintf("Running Go Routines: **%d**\nUsed CPU Threads: **%d**\n"+
-					"Used Heap: **%s**\nUsed Stack: **%s**", nGoroutines, usedCPUs, usedHeap, usedStack),
+				Name:  "Runtime Stats",
+				Value: runtimeStats,
 			},
 		},
 	}
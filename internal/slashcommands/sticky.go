@@ -0,0 +1,192 @@
+package slashcommands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/services/permissions"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/internal/util/stickymsg"
+	"github.com/zekroTJA/shinpuru/pkg/discordutil"
+	"github.com/zekrotja/ken"
+)
+
+type Sticky struct{}
+
+var (
+	_ ken.SlashCommand        = (*Sticky)(nil)
+	_ permissions.PermCommand = (*Sticky)(nil)
+)
+
+func (c *Sticky) Name() string {
+	return "sticky"
+}
+
+func (c *Sticky) Description() string {
+	return "Keep a message at the bottom of a channel."
+}
+
+func (c *Sticky) Version() string {
+	return "1.0.0"
+}
+
+func (c *Sticky) Type() discordgo.ApplicationCommandType {
+	return discordgo.ChatApplicationCommand
+}
+
+func (c *Sticky) Options() []*discordgo.ApplicationCommandOption {
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "set",
+			Description: "Set the sticky message of a channel.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:         discordgo.ApplicationCommandOptionChannel,
+					Name:         "channel",
+					Description:  "The channel to set the sticky message in.",
+					Required:     true,
+					ChannelTypes: []discordgo.ChannelType{discordgo.ChannelTypeGuildText, discordgo.ChannelTypeGuildNews},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "content",
+					Description: "The content of the sticky message (use '\\n' for line breaks).",
+					Required:    true,
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "clear",
+			Description: "Remove the sticky message of a channel.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:         discordgo.ApplicationCommandOptionChannel,
+					Name:         "channel",
+					Description:  "The channel to remove the sticky message from.",
+					Required:     true,
+					ChannelTypes: []discordgo.ChannelType{discordgo.ChannelTypeGuildText, discordgo.ChannelTypeGuildNews},
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "list",
+			Description: "List all sticky messages of the guild.",
+		},
+	}
+}
+
+func (c *Sticky) Domain() string {
+	return "sp.guild.config.sticky"
+}
+
+func (c *Sticky) SubDomains() []permissions.SubPermission {
+	return nil
+}
+
+func (c *Sticky) Run(ctx ken.Context) (err error) {
+	if err = ctx.Defer(); err != nil {
+		return
+	}
+
+	err = ctx.HandleSubCommands(
+		ken.SubCommandHandler{"set", c.set},
+		ken.SubCommandHandler{"clear", c.clear},
+		ken.SubCommandHandler{"list", c.list},
+	)
+
+	return
+}
+
+func (c *Sticky) set(ctx ken.SubCommandContext) (err error) {
+	db := ctx.Get(static.DiDatabase).(database.Database)
+
+	sm := models.StickyMessage{
+		GuildID:   ctx.GetEvent().GuildID,
+		ChannelID: ctx.Options().GetByName("channel").ChannelValue(ctx).ID,
+		Content:   strings.ReplaceAll(ctx.Options().GetByName("content").StringValue(), "\\n", "\n"),
+	}
+
+	if err = sm.Validate(); err != nil {
+		return ctx.FollowUpError(err.Error(), "").Send().Error
+	}
+
+	err = stickymsg.Set(db, ctx.GetSession(), sm)
+	if c.isPermissionError(err) {
+		return ctx.FollowUpError(
+			fmt.Sprintf("I am not permitted to send or delete messages in <#%s>.", sm.ChannelID), "").
+			Send().Error
+	}
+	if err != nil {
+		return
+	}
+
+	return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+		Description: fmt.Sprintf("The sticky message of <#%s> has been set.", sm.ChannelID),
+	}).Send().Error
+}
+
+func (c *Sticky) clear(ctx ken.SubCommandContext) (err error) {
+	db := ctx.Get(static.DiDatabase).(database.Database)
+
+	channelID := ctx.Options().GetByName("channel").ChannelValue(ctx).ID
+
+	err = stickymsg.Clear(db, ctx.GetSession(), ctx.GetEvent().GuildID, channelID)
+	if c.isPermissionError(err) {
+		return ctx.FollowUpError(
+			fmt.Sprintf("The sticky message of <#%s> has been removed, but I am not "+
+				"permitted to delete the posted message.", channelID), "").
+			Send().Error
+	}
+	if err != nil {
+		return
+	}
+
+	return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+		Description: fmt.Sprintf("The sticky message of <#%s> has been removed.", channelID),
+	}).Send().Error
+}
+
+func (c *Sticky) list(ctx ken.SubCommandContext) (err error) {
+	db := ctx.Get(static.DiDatabase).(database.Database)
+
+	sms, err := db.GetStickyMessages(ctx.GetEvent().GuildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return
+	}
+
+	if len(sms) == 0 {
+		return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+			Description: "No sticky messages are set for this guild.",
+		}).Send().Error
+	}
+
+	lines := make([]string, len(sms))
+	for i, sm := range sms {
+		lines[i] = fmt.Sprintf("**<#%s>**\n%s", sm.ChannelID, c.preview(sm.Content))
+	}
+
+	return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+		Title:       "Sticky Messages",
+		Description: strings.Join(lines, "\n\n"),
+	}).Send().Error
+}
+
+func (c *Sticky) preview(content string) string {
+	const maxLen = 100
+	content = strings.ReplaceAll(content, "\n", " ")
+	if r := []rune(content); len(r) > maxLen {
+		content = string(r[:maxLen-1]) + "…"
+	}
+	return content
+}
+
+func (c *Sticky) isPermissionError(err error) bool {
+	return discordutil.IsErrCode(err, discordgo.ErrCodeMissingPermissions) ||
+		discordutil.IsErrCode(err, discordgo.ErrCodeMissingAccess)
+}
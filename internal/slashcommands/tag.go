@@ -129,7 +129,7 @@ func (c *Tag) Run(ctx ken.Context) (err error) {
 
 func (c *Tag) show(ctx ken.SubCommandContext) (err error) {
 	db := ctx.Get(static.DiDatabase).(database.Database)
-	st := ctx.Get(static.DiState).(*dgrs.State)
+	st := ctx.Get(static.DiState).(dgrs.IState)
 
 	ident := strings.ToLower(ctx.Options().GetByName("name").StringValue())
 
@@ -146,7 +146,7 @@ func (c *Tag) show(ctx ken.SubCommandContext) (err error) {
 
 func (c *Tag) list(ctx ken.SubCommandContext) (err error) {
 	db := ctx.Get(static.DiDatabase).(database.Database)
-	st := ctx.Get(static.DiState).(*dgrs.State)
+	st := ctx.Get(static.DiState).(dgrs.IState)
 
 	tags, err := db.GetGuildTags(ctx.GetEvent().GuildID)
 	if err != nil {
@@ -166,7 +166,7 @@ func (c *Tag) list(ctx ken.SubCommandContext) (err error) {
 
 func (c *Tag) set(ctx ken.SubCommandContext) (err error) {
 	db := ctx.Get(static.DiDatabase).(database.Database)
-	st := ctx.Get(static.DiState).(*dgrs.State)
+	st := ctx.Get(static.DiState).(dgrs.IState)
 	pmw := ctx.Get(static.DiPermissions).(*permissions.Permissions)
 
 	ident := strings.ToLower(ctx.Options().GetByName("name").StringValue())
@@ -0,0 +1,250 @@
+package slashcommands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/bwmarrin/snowflake"
+	"github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/services/permissions"
+	"github.com/zekroTJA/shinpuru/internal/services/timeprovider"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/internal/util/temprole"
+	"github.com/zekroTJA/shinpuru/pkg/hammertime"
+	"github.com/zekroTJA/shinpuru/pkg/timeutil"
+	"github.com/zekrotja/dgrs"
+	"github.com/zekrotja/ken"
+)
+
+type TempRole struct{}
+
+var (
+	_ ken.SlashCommand        = (*TempRole)(nil)
+	_ permissions.PermCommand = (*TempRole)(nil)
+)
+
+func (c *TempRole) Name() string {
+	return "temprole"
+}
+
+func (c *TempRole) Description() string {
+	return "Assign roles to members which are removed automatically after a given time."
+}
+
+func (c *TempRole) Version() string {
+	return "1.0.0"
+}
+
+func (c *TempRole) Type() discordgo.ApplicationCommandType {
+	return discordgo.ChatApplicationCommand
+}
+
+func (c *TempRole) Options() []*discordgo.ApplicationCommandOption {
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "add",
+			Description: "Assign a role to a member for a given time.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionUser,
+					Name:        "user",
+					Description: "The member to assign the role to.",
+					Required:    true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionRole,
+					Name:        "role",
+					Description: "The role to be assigned.",
+					Required:    true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "duration",
+					Description: "The time after which the role is removed (e.g. `30m`, `2h` or `3d`).",
+					Required:    true,
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "list",
+			Description: "List the temporary roles of a member.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionUser,
+					Name:        "user",
+					Description: "The member to list the temporary roles of.",
+					Required:    true,
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "cancel",
+			Description: "Remove a temporary role before it expires.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "id",
+					Description: "The ID of the temporary role assignment.",
+					Required:    true,
+				},
+			},
+		},
+	}
+}
+
+func (c *TempRole) Domain() string {
+	return "sp.guild.mod.temprole"
+}
+
+func (c *TempRole) SubDomains() []permissions.SubPermission {
+	return nil
+}
+
+func (c *TempRole) Run(ctx ken.Context) (err error) {
+	if err = ctx.Defer(); err != nil {
+		return
+	}
+
+	err = ctx.HandleSubCommands(
+		ken.SubCommandHandler{"add", c.add},
+		ken.SubCommandHandler{"list", c.list},
+		ken.SubCommandHandler{"cancel", c.cancel},
+	)
+
+	return
+}
+
+func (c *TempRole) add(ctx ken.SubCommandContext) (err error) {
+	db := ctx.Get(static.DiDatabase).(database.Database)
+	st := ctx.Get(static.DiState).(dgrs.IState)
+	tp := ctx.Get(static.DiTimeProvider).(timeprovider.Provider)
+
+	guildID := ctx.GetEvent().GuildID
+	user := ctx.Options().GetByName("user").UserValue(ctx)
+	role := ctx.Options().GetByName("role").RoleValue(ctx)
+
+	duration, err := timeutil.ParseDuration(ctx.Options().GetByName("duration").StringValue())
+	if err != nil || duration <= 0 {
+		return ctx.FollowUpError(
+			"Invalid duration format. Please take a look "+
+				"[here](https://golang.org/pkg/time/#ParseDuration) how to format duration parameter.", "").
+			Send().Error
+	}
+	if duration > temprole.MaxDuration {
+		return ctx.FollowUpError("A temporary role can not be assigned for longer than 365 days.", "").
+			Send().Error
+	}
+
+	guild, err := st.Guild(guildID, true)
+	if err != nil {
+		return
+	}
+	executor, err := st.Member(guildID, ctx.User().ID)
+	if err != nil {
+		return
+	}
+	selfUser, err := st.SelfUser()
+	if err != nil {
+		return
+	}
+	self, err := st.Member(guildID, selfUser.ID)
+	if err != nil {
+		return
+	}
+
+	memb, err := st.Member(guildID, user.ID)
+	if err != nil {
+		return ctx.FollowUpError("The user is not a member of this guild.", "").Send().Error
+	}
+
+	if err = temprole.CheckAssignable(guild, executor, self, role.ID); err != nil {
+		return ctx.FollowUpError(tempRoleErrorMessage(err), "").Send().Error
+	}
+
+	t, err := temprole.Assign(db, ctx.GetSession(), tp, guildID, memb, role.ID, ctx.User().ID, duration)
+	if err == temprole.ErrRoleHeld {
+		return ctx.FollowUpError(tempRoleErrorMessage(err), "").Send().Error
+	}
+	if err != nil {
+		return
+	}
+
+	return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+		Description: fmt.Sprintf("<@&%s> has been assigned to <@%s> and will be removed %s (ID: `%s`).",
+			t.RoleID, t.UserID, hammertime.Format(t.Expires, hammertime.Span), t.ID),
+	}).Send().Error
+}
+
+func (c *TempRole) list(ctx ken.SubCommandContext) (err error) {
+	db := ctx.Get(static.DiDatabase).(database.Database)
+
+	user := ctx.Options().GetByName("user").UserValue(ctx)
+
+	tempRoles, err := db.GetTempRoles(ctx.GetEvent().GuildID, user.ID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return
+	}
+
+	if len(tempRoles) == 0 {
+		return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+			Description: fmt.Sprintf("<@%s> has no temporary roles.", user.ID),
+		}).Send().Error
+	}
+
+	lines := make([]string, len(tempRoles))
+	for i, t := range tempRoles {
+		lines[i] = fmt.Sprintf("`%s` - <@&%s> expires %s",
+			t.ID, t.RoleID, hammertime.Format(t.Expires, hammertime.Span))
+	}
+
+	return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+		Title:       "Temporary Roles",
+		Description: fmt.Sprintf("<@%s>\n\n%s", user.ID, strings.Join(lines, "\n")),
+	}).Send().Error
+}
+
+func (c *TempRole) cancel(ctx ken.SubCommandContext) (err error) {
+	db := ctx.Get(static.DiDatabase).(database.Database)
+
+	var t models.TempRole
+	id, err := snowflake.ParseString(ctx.Options().GetByName("id").StringValue())
+	if err == nil {
+		t, err = db.GetTempRole(id)
+	}
+	if err != nil && !database.IsErrDatabaseNotFound(err) && id != 0 {
+		return
+	}
+	if err != nil || t.GuildID != ctx.GetEvent().GuildID {
+		return ctx.FollowUpError("There is no temporary role with this ID on this guild.", "").
+			Send().Error
+	}
+
+	if err = temprole.Cancel(db, ctx.GetSession(), t); err != nil {
+		return
+	}
+
+	return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+		Description: fmt.Sprintf("<@&%s> has been removed from <@%s>.", t.RoleID, t.UserID),
+	}).Send().Error
+}
+
+func tempRoleErrorMessage(err error) string {
+	switch err {
+	case temprole.ErrRoleNotFound:
+		return "This role can not be assigned."
+	case temprole.ErrRoleManaged:
+		return "This role is managed by an integration and can not be assigned."
+	case temprole.ErrRoleDiff:
+		return "You can only assign roles which are below your highest role."
+	case temprole.ErrRoleSelfDiff:
+		return "I can only assign roles which are below my highest role."
+	case temprole.ErrRoleHeld:
+		return "The user already has this role permanently."
+	}
+	return err.Error()
+}
@@ -7,11 +7,19 @@ import (
 	"github.com/bwmarrin/discordgo"
 	"github.com/zekroTJA/shinpuru/internal/services/database"
 	"github.com/zekroTJA/shinpuru/internal/services/permissions"
+	"github.com/zekroTJA/shinpuru/internal/util/notifications"
 	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/pkg/stringutil"
 	"github.com/zekroTJA/shinpuru/pkg/twitchnotify"
+	"github.com/zekrotja/dgrs"
 	"github.com/zekrotja/ken"
 )
 
+// twitchNotifyMaxListLength is the length of the
+// listed notifications after which the remaining
+// ones are omitted.
+const twitchNotifyMaxListLength = 3500
+
 type Twitchnotify struct {
 	ken.EphemeralCommand
 }
@@ -30,7 +38,7 @@ func (c *Twitchnotify) Description() string {
 }
 
 func (c *Twitchnotify) Version() string {
-	return "1.0.0"
+	return "1.1.0"
 }
 
 func (c *Twitchnotify) Type() discordgo.ApplicationCommandType {
@@ -63,6 +71,22 @@ func (c *Twitchnotify) Options() []*discordgo.ApplicationCommandOption {
 					Description:  "The channel where the notifications are sent into (defaultly current channel).",
 					ChannelTypes: []discordgo.ChannelType{discordgo.ChannelTypeGuildText},
 				},
+				{
+					Type: discordgo.ApplicationCommandOptionString,
+					Name: "message",
+					Description: "The notification message. Placeholders: " +
+						"{streamer}, {title}, {game}, {url}, {viewers}",
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionRole,
+					Name:        "role",
+					Description: "A role to be mentioned in the notification.",
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionBoolean,
+					Name:        "plain",
+					Description: "Send the notification as plain message without embed.",
+				},
 			}...),
 		},
 		{
@@ -79,7 +103,13 @@ func (c *Twitchnotify) Domain() string {
 }
 
 func (c *Twitchnotify) SubDomains() []permissions.SubPermission {
-	return nil
+	return []permissions.SubPermission{
+		{
+			Term:        "/sp.guild.config.twitchnotify",
+			Explicit:    false,
+			Description: "Allows setting a role to be mentioned in Twitch notifications.",
+		},
+	}
 }
 
 func (c *Twitchnotify) Run(ctx ken.Context) (err error) {
@@ -114,26 +144,62 @@ func (c *Twitchnotify) Run(ctx ken.Context) (err error) {
 func (c *Twitchnotify) list(ctx ken.SubCommandContext) (err error) {
 	tnw := ctx.Get(static.DiTwitchNotifyWorker).(*twitchnotify.NotifyWorker)
 	db := ctx.Get(static.DiDatabase).(database.Database)
+	st := ctx.Get(static.DiState).(dgrs.IState)
 
-	nots, err := db.GetAllTwitchNotifies("")
+	subs, err := notifications.List(db, st, tnw, ctx.GetEvent().GuildID)
 	if err != nil {
 		return err
 	}
 
+	if len(subs) == 0 {
+		return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+			Title:       "Watched Twitch Channels",
+			Description: "No Twitch channels are watched on this guild.",
+		}).Send().Error
+	}
+
 	var notsStr strings.Builder
 
-	for _, not := range nots {
-		if not.GuildID == ctx.GetEvent().GuildID {
-			if tUser, err := tnw.GetUser(not.TwitchUserID, twitchnotify.IdentID); err == nil {
-				fmt.Fprintf(&notsStr, ":white_small_square:  **%s** in <#%s>\n",
-					tUser.DisplayName, not.ChannelID)
-			}
+	color := static.ColorEmbedDefault
+	for i, sub := range subs {
+		if notsStr.Len() > twitchNotifyMaxListLength {
+			fmt.Fprintf(&notsStr, "*and %d more ...*\n", len(subs)-i)
+			break
 		}
+
+		name := "`" + sub.Entry.TwitchUserID + "`"
+		if sub.User != nil {
+			name = sub.User.DisplayName
+		}
+
+		fmt.Fprintf(&notsStr, ":white_small_square:  **%s** in <#%s>", name, sub.Entry.ChannelID)
+		if sub.Live {
+			notsStr.WriteString(" :red_circle: *live*")
+		}
+		if sub.Entry.RoleID != "" {
+			fmt.Fprintf(&notsStr, "\n Mentions <@&%s>", sub.Entry.RoleID)
+		}
+		if sub.Entry.Template != "" {
+			fmt.Fprintf(&notsStr, "\n Message: `%s`", stringutil.Truncate(sub.Entry.Template, 100))
+		}
+		for _, p := range sub.Problems {
+			fmt.Fprintf(&notsStr, "\n :warning: %s", p)
+		}
+		notsStr.WriteRune('\n')
+
+		if sub.Failing() {
+			color = static.ColorEmbedOrange
+		}
+	}
+
+	if color == static.ColorEmbedOrange {
+		notsStr.WriteString("\nFailing notifications can be removed using `/twitchnotify remove`.")
 	}
 
 	return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
 		Title:       "Watched Twitch Channels",
 		Description: notsStr.String(),
+		Color:       color,
 	}).Send().Error
 }
 
@@ -143,10 +209,31 @@ func (c *Twitchnotify) add(ctx ken.SubCommandContext) (err error) {
 
 	twitchname := ctx.Options().GetByName("twitchname").StringValue()
 
-	channelID := ctx.GetEvent().ChannelID
+	entry := twitchnotify.DBEntry{
+		GuildID:   ctx.GetEvent().GuildID,
+		ChannelID: ctx.GetEvent().ChannelID,
+	}
 	if channelV, ok := ctx.Options().GetByNameOptional("channel"); ok {
 		ch := channelV.ChannelValue(ctx)
-		channelID = ch.ID
+		entry.ChannelID = ch.ID
+	}
+	if messageV, ok := ctx.Options().GetByNameOptional("message"); ok {
+		entry.Template = messageV.StringValue()
+	}
+	if roleV, ok := ctx.Options().GetByNameOptional("role"); ok {
+		if ok, err := c.checkPermission(ctx, "sp.guild.config.twitchnotify"); !ok || err != nil {
+			return err
+		}
+		entry.RoleID = roleV.RoleValue(ctx).ID
+	}
+	if plainV, ok := ctx.Options().GetByNameOptional("plain"); ok {
+		entry.Plain = plainV.BoolValue()
+	}
+
+	if len(entry.Template) > twitchnotify.MaxTemplateLength {
+		return ctx.FollowUpError(fmt.Sprintf(
+			"The message must not be longer than %d characters.", twitchnotify.MaxTemplateLength), "").
+			Send().Error
 	}
 
 	twitchuser, err := tnw.GetUser(twitchname, twitchnotify.IdentLogin)
@@ -165,21 +252,38 @@ func (c *Twitchnotify) add(ctx ken.SubCommandContext) (err error) {
 		return
 	}
 
-	err = db.SetTwitchNotify(twitchnotify.DBEntry{
-		ChannelID:    channelID,
-		GuildID:      ctx.GetEvent().GuildID,
-		TwitchUserID: twitchuser.ID,
-	})
+	err = tnw.Subscribe(twitchuser.ID)
+	if err != nil {
+		return
+	}
+
+	entry.TwitchUserID = twitchuser.ID
+	err = db.SetTwitchNotify(entry)
 	if err != nil {
 		return
 	}
 
 	return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
 		Description: fmt.Sprintf("You will now get a notification in channel <#%s> when `%s` goes live on twitch!",
-			channelID, twitchuser.DisplayName),
+			entry.ChannelID, twitchuser.DisplayName),
 	}).Send().Error
 }
 
+// checkPermission returns false and responds with an
+// error message if the executor lacks the given
+// permission.
+func (c *Twitchnotify) checkPermission(ctx ken.Context, perm string) (ok bool, err error) {
+	pmw := ctx.Get(static.DiPermissions).(*permissions.Permissions)
+
+	ok, _, err = pmw.CheckPermissions(ctx.GetSession(), ctx.GetEvent().GuildID, ctx.User().ID, perm)
+	if err != nil || ok {
+		return
+	}
+
+	err = ctx.FollowUpError("You don't have the required permissions to mention roles.", "").Send().Error
+	return
+}
+
 func (c *Twitchnotify) remove(ctx ken.SubCommandContext) (err error) {
 	tnw := ctx.Get(static.DiTwitchNotifyWorker).(*twitchnotify.NotifyWorker)
 	db := ctx.Get(static.DiDatabase).(database.Database)
@@ -195,24 +299,11 @@ func (c *Twitchnotify) remove(ctx ken.SubCommandContext) (err error) {
 		return
 	}
 
-	nots, err := db.GetAllTwitchNotifies(twitchuser.ID)
-	if err != nil {
-		return err
-	}
-
-	var notify *twitchnotify.DBEntry
-	for _, not := range nots {
-		if not.GuildID == ctx.GetEvent().GuildID {
-			notify = &not
-		}
-	}
-
-	if notify == nil {
-		return ctx.FollowUpError("Twitch user was nto set to be monitored on this guild.", "").
+	notify, err := notifications.Remove(db, tnw, ctx.GetEvent().GuildID, twitchuser.ID)
+	if database.IsErrDatabaseNotFound(err) {
+		return ctx.FollowUpError("Twitch user was not set to be monitored on this guild.", "").
 			Send().Error
 	}
-
-	err = db.DeleteTwitchNotify(notify.TwitchUserID, notify.GuildID)
 	if err != nil {
 		return err
 	}
@@ -65,7 +65,7 @@ func (c *User) Run(ctx ken.Context) (err error) {
 		return
 	}
 
-	st := ctx.Get(static.DiState).(*dgrs.State)
+	st := ctx.Get(static.DiState).(dgrs.IState)
 	cfg := ctx.Get(static.DiConfig).(config.Provider)
 	db := ctx.Get(static.DiDatabase).(database.Database)
 	pmw := ctx.Get(static.DiPermissions).(*permissions.Permissions)
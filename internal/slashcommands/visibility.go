@@ -0,0 +1,160 @@
+package slashcommands
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/zekroTJA/shinpuru/internal/services/permissions"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/pkg/discordutil"
+	"github.com/zekroTJA/shinpuru/pkg/stringutil"
+	"github.com/zekrotja/dgrs"
+	"github.com/zekrotja/ken"
+)
+
+// maxEmbedFieldLen is the maximum length of embed
+// field values.
+const maxEmbedFieldLen = 1024
+
+type Visibility struct {
+	ken.EphemeralCommand
+}
+
+var (
+	_ ken.SlashCommand        = (*Visibility)(nil)
+	_ permissions.PermCommand = (*Visibility)(nil)
+)
+
+func (c *Visibility) Name() string {
+	return "visibility"
+}
+
+func (c *Visibility) Description() string {
+	return "Show which roles and members can see a channel."
+}
+
+func (c *Visibility) Version() string {
+	return "1.0.0"
+}
+
+func (c *Visibility) Type() discordgo.ApplicationCommandType {
+	return discordgo.ChatApplicationCommand
+}
+
+func (c *Visibility) Options() []*discordgo.ApplicationCommandOption {
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionChannel,
+			Name:        "channel",
+			Description: "The channel to be checked (selects current channel if not passed).",
+		},
+	}
+}
+
+func (c *Visibility) Domain() string {
+	return "sp.guild.mod.visibility"
+}
+
+func (c *Visibility) SubDomains() []permissions.SubPermission {
+	return nil
+}
+
+func (c *Visibility) Run(ctx ken.Context) (err error) {
+	if err = ctx.Defer(); err != nil {
+		return
+	}
+
+	st := ctx.Get(static.DiState).(dgrs.IState)
+
+	var ch *discordgo.Channel
+	if chV, ok := ctx.Options().GetByNameOptional("channel"); ok {
+		ch = chV.ChannelValue(ctx)
+	} else {
+		ch, err = st.Channel(ctx.GetEvent().ChannelID)
+		if err != nil {
+			return
+		}
+	}
+
+	guild, err := st.Guild(ctx.GetEvent().GuildID, true)
+	if err != nil {
+		return
+	}
+
+	roles := make([]*discordgo.Role, len(guild.Roles))
+	copy(roles, guild.Roles)
+	sort.Slice(roles, func(i, j int) bool {
+		return roles[i].Position > roles[j].Position
+	})
+
+	// Roles are checked as if a member only has the role
+	// itself in addition to the @everyone role.
+	everyoneCanView := canView(guild, ch, "", nil)
+	var visible, hidden []string
+	for _, role := range roles {
+		if role.ID == guild.ID {
+			continue
+		}
+		if canView(guild, ch, "", []string{role.ID}) {
+			visible = append(visible, "<@&"+role.ID+">")
+		} else {
+			hidden = append(hidden, "<@&"+role.ID+">")
+		}
+	}
+
+	var memberLines []string
+	for _, ow := range ch.PermissionOverwrites {
+		if ow.Type != discordgo.PermissionOverwriteTypeMember {
+			continue
+		}
+		memb, err := st.Member(guild.ID, ow.ID)
+		if err != nil {
+			continue
+		}
+		state := "❌"
+		if canView(guild, ch, memb.User.ID, memb.Roles) {
+			state = "✅"
+		}
+		memberLines = append(memberLines, fmt.Sprintf("%s <@%s>", state, memb.User.ID))
+	}
+
+	everyoneState := "❌ @everyone can **not** see this channel."
+	if everyoneCanView {
+		everyoneState = "✅ @everyone can see this channel."
+	}
+
+	emb := &discordgo.MessageEmbed{
+		Color: static.ColorEmbedDefault,
+		Title: "Channel Visibility",
+		Description: fmt.Sprintf("Visibility of <#%s> taking permission overwrites into account.\n\n%s",
+			ch.ID, everyoneState),
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name:  "Roles which can see the channel",
+				Value: stringutil.JoinTruncated(visible, maxEmbedFieldLen, "*none*"),
+			},
+			{
+				Name:  "Roles which can not see the channel",
+				Value: stringutil.JoinTruncated(hidden, maxEmbedFieldLen, "*none*"),
+			},
+		},
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: "The guild owner and administrators can always see all channels.",
+		},
+	}
+
+	if len(memberLines) != 0 {
+		emb.Fields = append(emb.Fields, &discordgo.MessageEmbedField{
+			Name:  "Members with explicit overwrites",
+			Value: stringutil.JoinTruncated(memberLines, maxEmbedFieldLen, ""),
+		})
+	}
+
+	return ctx.FollowUpEmbed(emb).Send().Error
+}
+
+func canView(guild *discordgo.Guild, ch *discordgo.Channel, memberID string, roles []string) bool {
+	perms := discordutil.MemberChannelPermissions(guild, ch, memberID, roles)
+	return perms&discordgo.PermissionViewChannel != 0
+}
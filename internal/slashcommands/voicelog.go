@@ -206,7 +206,7 @@ func (c *Voicelog) unignore(ctx ken.SubCommandContext) (err error) {
 
 func (c *Voicelog) ignorelist(ctx ken.SubCommandContext) (err error) {
 	db := ctx.Get(static.DiDatabase).(database.Database)
-	st := ctx.Get(static.DiState).(*dgrs.State)
+	st := ctx.Get(static.DiState).(dgrs.IState)
 
 	vcIDs, err := db.GetGuildVoiceLogIgnores(ctx.GetEvent().GuildID)
 	if err != nil && !database.IsErrDatabaseNotFound(err) {
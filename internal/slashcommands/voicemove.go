@@ -0,0 +1,237 @@
+package slashcommands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/zekroTJA/shinpuru/internal/services/permissions"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/internal/util/voicemove"
+	"github.com/zekrotja/dgrs"
+	"github.com/zekrotja/ken"
+)
+
+// voiceMoveMaxFailures is the maximum number of
+// failed moves listed in the response.
+const voiceMoveMaxFailures = 10
+
+type VoiceMove struct {
+	ken.EphemeralCommand
+}
+
+var (
+	_ ken.SlashCommand        = (*VoiceMove)(nil)
+	_ permissions.PermCommand = (*VoiceMove)(nil)
+)
+
+func (c *VoiceMove) Name() string {
+	return "voicemove"
+}
+
+func (c *VoiceMove) Description() string {
+	return "Move members between voice channels."
+}
+
+func (c *VoiceMove) Version() string {
+	return "1.0.0"
+}
+
+func (c *VoiceMove) Type() discordgo.ApplicationCommandType {
+	return discordgo.ChatApplicationCommand
+}
+
+func (c *VoiceMove) Options() []*discordgo.ApplicationCommandOption {
+	voiceChannelTypes := []discordgo.ChannelType{
+		discordgo.ChannelTypeGuildVoice,
+		discordgo.ChannelTypeGuildStageVoice,
+	}
+
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "all",
+			Description: "Move all members of a voice channel to another one.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:         discordgo.ApplicationCommandOptionChannel,
+					Name:         "from",
+					Description:  "The voice channel to move the members from.",
+					Required:     true,
+					ChannelTypes: voiceChannelTypes,
+				},
+				{
+					Type:         discordgo.ApplicationCommandOptionChannel,
+					Name:         "to",
+					Description:  "The voice channel to move the members to.",
+					Required:     true,
+					ChannelTypes: voiceChannelTypes,
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "user",
+			Description: "Move a single member to a voice channel.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionUser,
+					Name:        "user",
+					Description: "The member to be moved.",
+					Required:    true,
+				},
+				{
+					Type:         discordgo.ApplicationCommandOptionChannel,
+					Name:         "to",
+					Description:  "The voice channel to move the member to.",
+					Required:     true,
+					ChannelTypes: voiceChannelTypes,
+				},
+			},
+		},
+	}
+}
+
+func (c *VoiceMove) Domain() string {
+	return "sp.guild.mod.voicemove"
+}
+
+func (c *VoiceMove) SubDomains() []permissions.SubPermission {
+	return nil
+}
+
+func (c *VoiceMove) Run(ctx ken.Context) (err error) {
+	if err = ctx.Defer(); err != nil {
+		return
+	}
+
+	err = ctx.HandleSubCommands(
+		ken.SubCommandHandler{"all", c.all},
+		ken.SubCommandHandler{"user", c.user},
+	)
+
+	return
+}
+
+func (c *VoiceMove) all(ctx ken.SubCommandContext) (err error) {
+	st := ctx.Get(static.DiState).(dgrs.IState)
+
+	from := ctx.Options().GetByName("from").ChannelValue(ctx)
+	to := ctx.Options().GetByName("to").ChannelValue(ctx)
+
+	if from.ID == to.ID {
+		return ctx.FollowUpError(
+			"The source and destination channel must be different.", "").
+			Send().Error
+	}
+
+	if ok, err := c.checkBotPermission(ctx, from.ID, to.ID); !ok || err != nil {
+		return err
+	}
+
+	vss, err := st.VoiceStates(ctx.GetEvent().GuildID)
+	if err != nil {
+		return
+	}
+
+	var userIDs []string
+	for _, vs := range vss {
+		if vs.ChannelID == from.ID {
+			userIDs = append(userIDs, vs.UserID)
+		}
+	}
+
+	if len(userIDs) == 0 {
+		return ctx.FollowUpError(
+			fmt.Sprintf("There are no members connected to <#%s>.", from.ID), "").
+			Send().Error
+	}
+
+	res := voicemove.Move(ctx.GetSession(), ctx.GetEvent().GuildID, to.ID, userIDs)
+	return ctx.FollowUpEmbed(voiceMoveResultEmbed(res, to.ID)).Send().Error
+}
+
+func (c *VoiceMove) user(ctx ken.SubCommandContext) (err error) {
+	st := ctx.Get(static.DiState).(dgrs.IState)
+
+	user := ctx.Options().GetByName("user").UserValue(ctx)
+	to := ctx.Options().GetByName("to").ChannelValue(ctx)
+
+	vs, err := st.VoiceState(ctx.GetEvent().GuildID, user.ID)
+	if err != nil {
+		return
+	}
+	if vs == nil || vs.ChannelID == "" {
+		return ctx.FollowUpError(
+			fmt.Sprintf("%s is not connected to a voice channel.", user.Mention()), "").
+			Send().Error
+	}
+	if vs.ChannelID == to.ID {
+		return ctx.FollowUpError(
+			fmt.Sprintf("%s is already connected to <#%s>.", user.Mention(), to.ID), "").
+			Send().Error
+	}
+
+	if ok, err := c.checkBotPermission(ctx, vs.ChannelID, to.ID); !ok || err != nil {
+		return err
+	}
+
+	res := voicemove.Move(ctx.GetSession(), ctx.GetEvent().GuildID, to.ID, []string{user.ID})
+	return ctx.FollowUpEmbed(voiceMoveResultEmbed(res, to.ID)).Send().Error
+}
+
+// checkBotPermission returns false and responds with an
+// error message if the bot is not allowed to move members
+// out of or into the given channels.
+func (c *VoiceMove) checkBotPermission(ctx ken.Context, channelIDs ...string) (ok bool, err error) {
+	s := ctx.GetSession()
+
+	for _, channelID := range channelIDs {
+		perms, err := s.UserChannelPermissions(s.State.User.ID, channelID)
+		if err != nil {
+			return false, err
+		}
+
+		if perms&discordgo.PermissionAdministrator == 0 &&
+			perms&discordgo.PermissionVoiceMoveMembers == 0 {
+			err = ctx.FollowUpError(fmt.Sprintf(
+				"I need the permission `Move Members` in <#%s> to move members.", channelID), "").
+				Send().Error
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// voiceMoveResultEmbed returns an embed summarizing the
+// result of moving members into the given channel.
+func voiceMoveResultEmbed(res voicemove.Result, channelID string) *discordgo.MessageEmbed {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "Moved %d member(s) to <#%s>.", res.Moved, channelID)
+	if res.Disconnected > 0 {
+		fmt.Fprintf(&sb, "\n%d member(s) disconnected before they could be moved.", res.Disconnected)
+	}
+
+	color := static.ColorEmbedGreen
+	if len(res.Failed) > 0 {
+		color = static.ColorEmbedOrange
+		fmt.Fprintf(&sb, "\n\nFailed moving %d member(s):", len(res.Failed))
+
+		var i int
+		for userID, err := range res.Failed {
+			if i == voiceMoveMaxFailures {
+				fmt.Fprintf(&sb, "\n*and %d more ...*", len(res.Failed)-i)
+				break
+			}
+			fmt.Fprintf(&sb, "\n<@%s>: `%s`", userID, err.Error())
+			i++
+		}
+	}
+
+	return &discordgo.MessageEmbed{
+		Description: sb.String(),
+		Color:       color,
+	}
+}
@@ -0,0 +1,348 @@
+package slashcommands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/services/permissions"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/pkg/stringutil"
+	"github.com/zekroTJA/shinpuru/pkg/timeutil"
+	"github.com/zekrotja/ken"
+)
+
+type WordFilter struct{}
+
+var (
+	_ ken.SlashCommand        = (*WordFilter)(nil)
+	_ permissions.PermCommand = (*WordFilter)(nil)
+)
+
+func (c *WordFilter) Name() string {
+	return "wordfilter"
+}
+
+func (c *WordFilter) Description() string {
+	return "Manage the filter of banned words in messages."
+}
+
+func (c *WordFilter) Version() string {
+	return "1.0.0"
+}
+
+func (c *WordFilter) Type() discordgo.ApplicationCommandType {
+	return discordgo.ChatApplicationCommand
+}
+
+func (c *WordFilter) Options() []*discordgo.ApplicationCommandOption {
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "settings",
+			Description: "Show or change the word filter settings.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionBoolean,
+					Name:        "enabled",
+					Description: "Enable or disable the word filter.",
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "action",
+					Description: "The action performed when a message matches the filter.",
+					Choices: []*discordgo.ApplicationCommandOptionChoice{
+						{Name: "Delete message", Value: string(models.WordFilterActionDelete)},
+						{Name: "Delete message and warn", Value: string(models.WordFilterActionWarn)},
+						{Name: "Delete message and mute", Value: string(models.WordFilterActionMute)},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "muteduration",
+					Description: "The duration members are muted for (e.g. '1h' or '1d').",
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "add",
+			Description: "Add a word or phrase to the filter.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "pattern",
+					Description: "The word or phrase to be filtered ('*' matches any letters).",
+					Required:    true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "mode",
+					Description: "How the pattern is matched (default: whole word).",
+					Choices: []*discordgo.ApplicationCommandOptionChoice{
+						{Name: "Whole word", Value: string(models.WordFilterModeWord)},
+						{Name: "Substring", Value: string(models.WordFilterModeSubstring)},
+					},
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "remove",
+			Description: "Remove a word or phrase from the filter.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "pattern",
+					Description: "The word or phrase to be removed.",
+					Required:    true,
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "exempt",
+			Description: "Toggle the exemption of a role or channel from the filter.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionRole,
+					Name:        "role",
+					Description: "The role to be exempted.",
+				},
+				{
+					Type:         discordgo.ApplicationCommandOptionChannel,
+					Name:         "channel",
+					Description:  "The channel to be exempted.",
+					ChannelTypes: []discordgo.ChannelType{discordgo.ChannelTypeGuildText, discordgo.ChannelTypeGuildNews},
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "list",
+			Description: "List the filtered words and the filter settings.",
+		},
+	}
+}
+
+func (c *WordFilter) Domain() string {
+	return "sp.guild.config.wordfilter"
+}
+
+func (c *WordFilter) SubDomains() []permissions.SubPermission {
+	return nil
+}
+
+func (c *WordFilter) Run(ctx ken.Context) (err error) {
+	if err = ctx.Defer(); err != nil {
+		return
+	}
+
+	err = ctx.HandleSubCommands(
+		ken.SubCommandHandler{"settings", c.settings},
+		ken.SubCommandHandler{"add", c.add},
+		ken.SubCommandHandler{"remove", c.remove},
+		ken.SubCommandHandler{"exempt", c.exempt},
+		ken.SubCommandHandler{"list", c.list},
+	)
+
+	return
+}
+
+func (c *WordFilter) settings(ctx ken.SubCommandContext) (err error) {
+	db := ctx.Get(static.DiDatabase).(database.Database)
+
+	settings, err := c.getSettings(db, ctx.GetEvent().GuildID)
+	if err != nil {
+		return
+	}
+
+	var changed bool
+	if v, ok := ctx.Options().GetByNameOptional("enabled"); ok {
+		settings.Enabled = v.BoolValue()
+		changed = true
+	}
+	if v, ok := ctx.Options().GetByNameOptional("action"); ok {
+		settings.Action = models.WordFilterAction(v.StringValue())
+		changed = true
+	}
+	if v, ok := ctx.Options().GetByNameOptional("muteduration"); ok {
+		if settings.MuteDuration, err = timeutil.ParseDuration(v.StringValue()); err != nil {
+			return ctx.FollowUpError("Invalid mute duration.", "").Send().Error
+		}
+		changed = true
+	}
+
+	if changed {
+		if err = settings.Validate(); err != nil {
+			return ctx.FollowUpError(err.Error(), "").Send().Error
+		}
+		if err = db.SetWordFilterSettings(settings); err != nil {
+			return
+		}
+	}
+
+	return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+		Title:       "Word Filter Settings",
+		Description: c.formatSettings(settings),
+	}).Send().Error
+}
+
+func (c *WordFilter) add(ctx ken.SubCommandContext) (err error) {
+	db := ctx.Get(static.DiDatabase).(database.Database)
+
+	entry := models.WordFilterEntry{
+		GuildID: ctx.GetEvent().GuildID,
+		Pattern: strings.ToLower(strings.TrimSpace(ctx.Options().GetByName("pattern").StringValue())),
+		Mode:    models.WordFilterModeWord,
+	}
+	if v, ok := ctx.Options().GetByNameOptional("mode"); ok {
+		entry.Mode = models.WordFilterMode(v.StringValue())
+	}
+
+	if err = entry.Validate(); err != nil {
+		return ctx.FollowUpError(err.Error(), "").Send().Error
+	}
+
+	if err = db.SetWordFilterEntry(entry); err != nil {
+		return
+	}
+
+	return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+		Description: fmt.Sprintf("`%s` has been added to the word filter.", entry.Pattern),
+	}).Send().Error
+}
+
+func (c *WordFilter) remove(ctx ken.SubCommandContext) (err error) {
+	db := ctx.Get(static.DiDatabase).(database.Database)
+
+	pattern := strings.ToLower(strings.TrimSpace(ctx.Options().GetByName("pattern").StringValue()))
+
+	err = db.RemoveWordFilterEntry(ctx.GetEvent().GuildID, pattern)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return
+	}
+
+	return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+		Description: fmt.Sprintf("`%s` has been removed from the word filter.", pattern),
+	}).Send().Error
+}
+
+func (c *WordFilter) exempt(ctx ken.SubCommandContext) (err error) {
+	db := ctx.Get(static.DiDatabase).(database.Database)
+
+	roleV, okRole := ctx.Options().GetByNameOptional("role")
+	chanV, okChan := ctx.Options().GetByNameOptional("channel")
+	if !okRole && !okChan {
+		return ctx.FollowUpError("Please specify a role or a channel.", "").Send().Error
+	}
+
+	settings, err := c.getSettings(db, ctx.GetEvent().GuildID)
+	if err != nil {
+		return
+	}
+
+	var lines []string
+	if okRole {
+		var added bool
+		id := roleV.RoleValue(ctx).ID
+		settings.ExemptRoles, added = c.toggle(settings.ExemptRoles, id)
+		lines = append(lines, fmt.Sprintf("<@&%s> is %s.", id,
+			stringutil.FromBool(added, "now exempted from the word filter", "no longer exempted from the word filter")))
+	}
+	if okChan {
+		var added bool
+		id := chanV.ChannelValue(ctx).ID
+		settings.ExemptChannels, added = c.toggle(settings.ExemptChannels, id)
+		lines = append(lines, fmt.Sprintf("<#%s> is %s.", id,
+			stringutil.FromBool(added, "now exempted from the word filter", "no longer exempted from the word filter")))
+	}
+
+	if err = db.SetWordFilterSettings(settings); err != nil {
+		return
+	}
+
+	return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+		Description: strings.Join(lines, "\n"),
+	}).Send().Error
+}
+
+func (c *WordFilter) list(ctx ken.SubCommandContext) (err error) {
+	db := ctx.Get(static.DiDatabase).(database.Database)
+
+	settings, err := c.getSettings(db, ctx.GetEvent().GuildID)
+	if err != nil {
+		return
+	}
+
+	entries, err := db.GetWordFilterEntries(ctx.GetEvent().GuildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return
+	}
+
+	patterns := "*No words are filtered.*"
+	if len(entries) != 0 {
+		lines := make([]string, len(entries))
+		for i, e := range entries {
+			lines[i] = fmt.Sprintf("`%s` (%s)", e.Pattern, e.Mode)
+		}
+		patterns = strings.Join(lines, "\n")
+	}
+
+	return ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+		Title:       "Word Filter",
+		Description: c.formatSettings(settings),
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name:  "Filtered Words",
+				Value: stringutil.Truncate(patterns, 1024),
+			},
+		},
+	}).Send().Error
+}
+
+func (c *WordFilter) getSettings(db database.Database, guildID string) (models.WordFilterSettings, error) {
+	settings, err := db.GetWordFilterSettings(guildID)
+	if database.IsErrDatabaseNotFound(err) {
+		return models.WordFilterSettings{
+			GuildID: guildID,
+			Action:  models.WordFilterActionDelete,
+		}, nil
+	}
+	return settings, err
+}
+
+func (c *WordFilter) formatSettings(settings models.WordFilterSettings) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "Enabled: **%s**\n", stringutil.FromBool(settings.Enabled, "yes", "no"))
+	fmt.Fprintf(&sb, "Action: **%s**\n", settings.Action)
+	if settings.Action == models.WordFilterActionMute {
+		fmt.Fprintf(&sb, "Mute Duration: **%s**\n", settings.MuteDuration)
+	}
+
+	roles := make([]string, len(settings.ExemptRoles))
+	for i, id := range settings.ExemptRoles {
+		roles[i] = "<@&" + id + ">"
+	}
+	channels := make([]string, len(settings.ExemptChannels))
+	for i, id := range settings.ExemptChannels {
+		channels[i] = "<#" + id + ">"
+	}
+
+	fmt.Fprintf(&sb, "Exempted Roles: %s\n", stringutil.EnsureNotEmpty(strings.Join(roles, ", "), "*none*"))
+	fmt.Fprintf(&sb, "Exempted Channels: %s", stringutil.EnsureNotEmpty(strings.Join(channels, ", "), "*none*"))
+
+	return sb.String()
+}
+
+// toggle removes id from ids if contained and adds it
+// otherwise. added is true if id has been added.
+func (c *WordFilter) toggle(ids []string, id string) (res []string, added bool) {
+	if i := stringutil.IndexOf(id, ids); i != -1 {
+		return stringutil.Splice(ids, i), false
+	}
+	return append(ids, id), true
+}
@@ -0,0 +1,107 @@
+// Package botnick provides utilities to apply the
+// configured nickname of the bot in a guild.
+package botnick
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/pkg/discordutil"
+	"github.com/zekrotja/dgrs"
+)
+
+// MaxLength is the maximum length of a nickname
+// accepted by Discord.
+const MaxLength = 32
+
+var (
+	ErrTooLong           = fmt.Errorf("nickname must not be longer than %d characters", MaxLength)
+	ErrMissingPermission = errors.New("the bot is missing the permission to change its nickname")
+)
+
+// Validate returns ErrTooLong if the given nickname
+// exceeds MaxLength.
+func Validate(nickname string) error {
+	if len([]rune(nickname)) > MaxLength {
+		return ErrTooLong
+	}
+	return nil
+}
+
+// Apply sets the nickname of the bot in the given guild.
+// An empty nickname resets the nickname of the bot.
+//
+// If the bot is not permitted to change its nickname,
+// ErrMissingPermission is returned.
+func Apply(s discordutil.ISession, guildID, nickname string) error {
+	err := s.GuildMemberNickname(guildID, "@me", nickname)
+	if discordutil.IsErrCode(err, discordgo.ErrCodeMissingPermissions) {
+		return ErrMissingPermission
+	}
+	return err
+}
+
+// Permitted returns true if the bot is permitted to
+// change its nickname in the given guild.
+func Permitted(st dgrs.IState, guildID string) (bool, error) {
+	self, err := st.SelfUser()
+	if err != nil {
+		return false, err
+	}
+
+	guild, err := st.Guild(guildID)
+	if err != nil {
+		return false, err
+	}
+
+	member, err := st.Member(guildID, self.ID)
+	if err != nil {
+		return false, err
+	}
+
+	roles, err := st.Roles(guildID)
+	if err != nil {
+		return false, err
+	}
+
+	// The guild is copied so that the roles are not
+	// set on the instance of the state.
+	g := *guild
+	g.Roles = roles
+
+	perms := discordutil.MemberChannelPermissions(&g, &discordgo.Channel{}, self.ID, member.Roles)
+	return perms&discordgo.PermissionChangeNickname != 0, nil
+}
+
+// ApplyConfigured sets the configured nickname of the bot
+// in the given guild if it differs from the current one.
+// If no nickname is configured, nothing is changed.
+//
+// If the bot is not permitted to change its nickname,
+// ErrMissingPermission is returned without trying to
+// change the nickname.
+func ApplyConfigured(s discordutil.ISession, st dgrs.IState, db database.Database, guildID, current string) error {
+	nickname, err := db.GetGuildBotNickname(guildID)
+	if database.IsErrDatabaseNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if nickname == "" || nickname == current {
+		return nil
+	}
+
+	ok, err := Permitted(st, guildID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrMissingPermission
+	}
+
+	return Apply(s, guildID, nickname)
+}
@@ -0,0 +1,140 @@
+// Package botperms declares the Discord permissions
+// the bot requires for each of its features and
+// provides utilities to check them.
+package botperms
+
+import (
+	"sort"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Feature describes a bot feature and the permissions
+// it requires to work.
+type Feature struct {
+	// Name is the display name of the feature.
+	Name string
+	// Guild contains the permissions required on
+	// guild level, which can not be overwritten
+	// per channel.
+	Guild int64
+	// Channel contains the permissions required in
+	// the channel the feature is used in.
+	Channel int64
+}
+
+// Features contains all features with their required
+// permissions. New features simply need to be added
+// to this list to be covered by the permission check.
+var Features = []Feature{
+	{
+		Name: "Command responses",
+		Channel: discordgo.PermissionViewChannel |
+			discordgo.PermissionSendMessages |
+			discordgo.PermissionEmbedLinks,
+	},
+	{
+		Name:    "File uploads",
+		Channel: discordgo.PermissionAttachFiles,
+	},
+	{
+		Name: "Karma & starboard",
+		Channel: discordgo.PermissionAddReactions |
+			discordgo.PermissionReadMessageHistory,
+	},
+	{
+		Name:    "Color reactions",
+		Guild:   discordgo.PermissionManageEmojis,
+		Channel: discordgo.PermissionAddReactions,
+	},
+	{
+		Name: "Message clearing & auto delete",
+		Channel: discordgo.PermissionManageMessages |
+			discordgo.PermissionReadMessageHistory,
+	},
+	{
+		Name: "Pins",
+		Channel: discordgo.PermissionManageMessages |
+			discordgo.PermissionReadMessageHistory,
+	},
+	{
+		Name:  "Auto roles & role select",
+		Guild: discordgo.PermissionManageRoles,
+	},
+	{
+		Name: "Mute",
+		Guild: discordgo.PermissionManageRoles |
+			discordgo.PermissionManageChannels,
+	},
+	{
+		Name:    "Channel lock",
+		Channel: discordgo.PermissionManageRoles,
+	},
+	{
+		Name:  "Kick",
+		Guild: discordgo.PermissionKickMembers,
+	},
+	{
+		Name:  "Ban",
+		Guild: discordgo.PermissionBanMembers,
+	},
+	{
+		Name:  "Move voice members",
+		Guild: discordgo.PermissionVoiceMoveMembers,
+	},
+	{
+		Name: "Auto voice channels",
+		Guild: discordgo.PermissionManageChannels |
+			discordgo.PermissionVoiceMoveMembers,
+	},
+	{
+		Name: "Guild backups",
+		Guild: discordgo.PermissionManageServer |
+			discordgo.PermissionManageRoles |
+			discordgo.PermissionManageChannels,
+	},
+}
+
+var permissionNames = map[int64]string{
+	discordgo.PermissionViewChannel:         "View Channel",
+	discordgo.PermissionSendMessages:        "Send Messages",
+	discordgo.PermissionEmbedLinks:          "Embed Links",
+	discordgo.PermissionAttachFiles:         "Attach Files",
+	discordgo.PermissionAddReactions:        "Add Reactions",
+	discordgo.PermissionReadMessageHistory:  "Read Message History",
+	discordgo.PermissionManageMessages:      "Manage Messages",
+	discordgo.PermissionManageEmojis:        "Manage Emojis",
+	discordgo.PermissionManageRoles:         "Manage Roles",
+	discordgo.PermissionManageChannels:      "Manage Channels",
+	discordgo.PermissionManageServer:        "Manage Server",
+	discordgo.PermissionKickMembers:         "Kick Members",
+	discordgo.PermissionBanMembers:          "Ban Members",
+	discordgo.PermissionVoiceMoveMembers:    "Move Members",
+	discordgo.PermissionModerateMembers:     "Moderate Members",
+	discordgo.PermissionManageWebhooks:      "Manage Webhooks",
+	discordgo.PermissionManageNicknames:     "Manage Nicknames",
+	discordgo.PermissionViewAuditLogs:       "View Audit Log",
+	discordgo.PermissionCreateInstantInvite: "Create Invite",
+}
+
+// Missing returns the permissions of the feature which
+// are not contained in the passed guild and channel
+// permissions.
+func (f Feature) Missing(guildPerms, channelPerms int64) (guild, channel int64) {
+	if guildPerms&discordgo.PermissionAdministrator != 0 {
+		return 0, 0
+	}
+	return f.Guild &^ guildPerms, f.Channel &^ channelPerms
+}
+
+// PermissionNames returns the sorted display names of
+// all permissions set in perms.
+func PermissionNames(perms int64) (names []string) {
+	for p, name := range permissionNames {
+		if perms&p != 0 {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return
+}
@@ -0,0 +1,51 @@
+package botperms
+
+import (
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMissing(t *testing.T) {
+	f := Feature{
+		Guild:   discordgo.PermissionBanMembers | discordgo.PermissionManageRoles,
+		Channel: discordgo.PermissionSendMessages,
+	}
+
+	g, c := f.Missing(discordgo.PermissionManageRoles, discordgo.PermissionSendMessages)
+	assert.Equal(t, int64(discordgo.PermissionBanMembers), g)
+	assert.Equal(t, int64(0), c)
+
+	g, c = f.Missing(0, 0)
+	assert.Equal(t, f.Guild, g)
+	assert.Equal(t, f.Channel, c)
+
+	g, c = f.Missing(discordgo.PermissionAdministrator, 0)
+	assert.Equal(t, int64(0), g)
+	assert.Equal(t, int64(0), c)
+}
+
+func TestPermissionNames(t *testing.T) {
+	assert.Nil(t, PermissionNames(0))
+	assert.Equal(t,
+		[]string{"Ban Members", "Send Messages"},
+		PermissionNames(discordgo.PermissionSendMessages|discordgo.PermissionBanMembers))
+}
+
+func TestFeaturesNamed(t *testing.T) {
+	// Every declared permission must have a display
+	// name so that it shows up in the check output.
+	for _, f := range Features {
+		for _, perms := range []int64{f.Guild, f.Channel} {
+			for i := 0; i < 63; i++ {
+				p := perms & (1 << i)
+				if p == 0 {
+					continue
+				}
+				_, ok := permissionNames[p]
+				assert.True(t, ok, "permission %d of feature %s has no name", p, f.Name)
+			}
+		}
+	}
+}
@@ -0,0 +1,78 @@
+// Package cmdblocklist provides access to the global
+// command blocklist which is managed by the bot owner
+// to disable commands across all guilds at runtime.
+package cmdblocklist
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/pkg/stringutil"
+)
+
+// Blocklist contains the names of globally blocked
+// commands. If Silent is true, executions of blocked
+// commands are ignored instead of answered with a
+// maintenance notice.
+type Blocklist struct {
+	Commands []string `json:"commands"`
+	Silent   bool     `json:"silent"`
+}
+
+// Get returns the stored global command blocklist.
+// An empty blocklist is returned if none is stored.
+func Get(db database.Database) (b Blocklist, err error) {
+	raw, err := db.GetSetting(static.SettingCommandBlocklist)
+	if database.IsErrDatabaseNotFound(err) || (err == nil && raw == "") {
+		return Blocklist{Commands: []string{}}, nil
+	}
+	if err != nil {
+		return
+	}
+
+	err = json.Unmarshal([]byte(raw), &b)
+	if b.Commands == nil {
+		b.Commands = []string{}
+	}
+	return
+}
+
+// Set stores the given global command blocklist.
+func Set(db database.Database, b Blocklist) error {
+	raw, err := json.Marshal(b)
+	if err != nil {
+		return err
+	}
+	return db.SetSetting(static.SettingCommandBlocklist, string(raw))
+}
+
+// IsBlocked returns true if the command with the
+// given name is on the blocklist.
+func (b Blocklist) IsBlocked(name string) bool {
+	return stringutil.ContainsAny(strings.ToLower(name), b.Commands)
+}
+
+// Add adds the given command name to the blocklist.
+// It returns false if the command was already blocked.
+func (b *Blocklist) Add(name string) bool {
+	name = strings.ToLower(name)
+	if b.IsBlocked(name) {
+		return false
+	}
+	b.Commands = append(b.Commands, name)
+	return true
+}
+
+// Remove removes the given command name from the
+// blocklist. It returns false if the command was
+// not blocked.
+func (b *Blocklist) Remove(name string) bool {
+	i := stringutil.IndexOf(strings.ToLower(name), b.Commands)
+	if i < 0 {
+		return false
+	}
+	b.Commands = stringutil.Splice(b.Commands, i)
+	return true
+}
@@ -0,0 +1,35 @@
+package cmdutil
+
+import (
+	"github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/pkg/confirm"
+	"github.com/zekrotja/ken"
+)
+
+// ConfirmIfRequired prompts the executing user to
+// confirm the given action if the guild requires a
+// confirmation for it. It returns true if the action
+// can be executed.
+func ConfirmIfRequired(ctx ken.Context, action models.ConfirmAction, text string) (bool, error) {
+	db := ctx.Get(static.DiDatabase).(database.Database)
+
+	actions, err := db.GetGuildConfirmActions(ctx.GetEvent().GuildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return false, err
+	}
+
+	required := false
+	for _, a := range actions {
+		if a == action {
+			required = true
+			break
+		}
+	}
+	if !required {
+		return true, nil
+	}
+
+	return confirm.Prompt(ctx.GetSession(), ctx.GetEvent().ChannelID, ctx.User().ID, text)
+}
@@ -0,0 +1,17 @@
+package cmdutil
+
+import "github.com/bwmarrin/discordgo"
+
+// GuildOptionName is the name of the option specifying
+// the guild of guild scoped commands executed in DMs.
+const GuildOptionName = "guild"
+
+// GuildOption returns the option specifying the guild
+// of guild scoped commands executed in DMs.
+func GuildOption() *discordgo.ApplicationCommandOption {
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionString,
+		Name:        GuildOptionName,
+		Description: "The name or ID of the guild (only used in DMs).",
+	}
+}
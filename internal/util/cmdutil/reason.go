@@ -0,0 +1,57 @@
+package cmdutil
+
+import (
+	"strings"
+
+	"github.com/zekroTJA/shinpuru/pkg/argp"
+)
+
+// ParseReason parses the named flags contained in the
+// given report reason.
+//
+// The reason text can either be passed as plain text or
+// via the --reason flag. Passing --silent suppresses the
+// DM notification to the victim. Values containing
+// whitespace can be wrapped in double quotes and quotes
+// can be escaped with a backslash.
+//
+// Flags are only parsed when the reason contains a
+// --reason, --reason=<value> or --silent token. Other
+// reasons are returned unchanged.
+func ParseReason(raw string) (reason string, silent bool, err error) {
+	if !hasReasonFlag(raw) {
+		return raw, false, nil
+	}
+
+	p, err := argp.NewFromString(raw)
+	if err != nil {
+		return
+	}
+
+	if reason, err = p.String("--reason", ""); err != nil {
+		return
+	}
+	if silent, err = p.Bool("--silent", false); err != nil {
+		return
+	}
+
+	if args := p.Args(); len(args) > 0 {
+		if reason != "" {
+			reason += " "
+		}
+		reason += strings.Join(args, " ")
+	}
+
+	return
+}
+
+// hasReasonFlag returns true if one of the whitespace
+// separated tokens of raw is a flag of ParseReason.
+func hasReasonFlag(raw string) bool {
+	for _, tok := range strings.Fields(raw) {
+		if tok == "--reason" || tok == "--silent" || strings.HasPrefix(tok, "--reason=") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+package cmdutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zekroTJA/shinpuru/pkg/argp"
+)
+
+func TestParseReason(t *testing.T) {
+	reason, silent, err := ParseReason(`he said "don't" twice`)
+	assert.Nil(t, err)
+	assert.Equal(t, `he said "don't" twice`, reason)
+	assert.False(t, silent)
+
+	reason, silent, err = ParseReason(`--reason "spamming \"links\"" --silent`)
+	assert.Nil(t, err)
+	assert.Equal(t, `spamming "links"`, reason)
+	assert.True(t, silent)
+
+	reason, silent, err = ParseReason(`spamming in #general --silent`)
+	assert.Nil(t, err)
+	assert.Equal(t, "spamming in #general", reason)
+	assert.True(t, silent)
+
+	reason, silent, err = ParseReason(`--silent=false --reason=spam`)
+	assert.Nil(t, err)
+	assert.Equal(t, "spam", reason)
+	assert.False(t, silent)
+
+	_, _, err = ParseReason(`--reason "spamming --silent`)
+	assert.ErrorIs(t, err, argp.ErrUnbalancedQuotes)
+}
+
+func TestParseReasonUnchanged(t *testing.T) {
+	cases := []string{
+		`he said "don't`,
+		`5" tall`,
+		`"`,
+		`path C:\Users\ and a trailing \`,
+		`escaped \"quotes\"`,
+		"first line\nsecond line",
+		"  multiple   spaces\tand tabs  ",
+		`--silently spamming`,
+		`--reasonable complaint`,
+		`a--reason b`,
+		`-- spam`,
+		`"--silent"`,
+	}
+
+	for _, c := range cases {
+		reason, silent, err := ParseReason(c)
+		assert.Nil(t, err, c)
+		assert.Equal(t, c, reason)
+		assert.False(t, silent, c)
+	}
+}
+
+func TestParseReasonFlagTokens(t *testing.T) {
+	reason, silent, err := ParseReason("spamming\nin #general\n--silent")
+	assert.Nil(t, err)
+	assert.Equal(t, "spamming in #general", reason)
+	assert.True(t, silent)
+
+	reason, silent, err = ParseReason(`--reason=spam   --silent`)
+	assert.Nil(t, err)
+	assert.Equal(t, "spam", reason)
+	assert.True(t, silent)
+
+	reason, silent, err = ParseReason(`--reason "C:\\Users\\" --silent`)
+	assert.Nil(t, err)
+	assert.Equal(t, `C:\Users\`, reason)
+	assert.True(t, silent)
+
+	_, _, err = ParseReason(`5" tall --silent`)
+	assert.ErrorIs(t, err, argp.ErrUnbalancedQuotes)
+
+	_, _, err = ParseReason(`trailing \ --silent \`)
+	assert.ErrorIs(t, err, argp.ErrTrailingEscape)
+}
@@ -2,6 +2,7 @@ package cmdutil
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 
 	"github.com/bwmarrin/discordgo"
@@ -13,6 +14,7 @@ import (
 	"github.com/zekroTJA/shinpuru/internal/util/imgstore"
 	"github.com/zekroTJA/shinpuru/internal/util/static"
 	"github.com/zekroTJA/shinpuru/pkg/acceptmsg/v2"
+	"github.com/zekroTJA/shinpuru/pkg/roleutil"
 	"github.com/zekroTJA/shinpuru/pkg/timeutil"
 	"github.com/zekrotja/ken"
 )
@@ -22,7 +24,23 @@ func CmdReport(ctx ken.Context, typ models.ReportType, tp timeprovider.Provider)
 	repSvc := ctx.Get(static.DiReport).(report.Provider)
 
 	victim := ctx.Options().GetByName("user").UserValue(ctx)
-	reason := ctx.Options().GetByName("reason").StringValue()
+	reason, silent, err := ParseReason(ctx.Options().GetByName("reason").StringValue())
+	if err != nil {
+		return ctx.FollowUpError(
+			fmt.Sprintf("Invalid reason: %s.\nWrap values in double quotes and escape quotes with a backslash.", err.Error()), "").
+			Send().Error
+	}
+	if reason == "" {
+		return ctx.FollowUpError("The reason must not be empty.", "").Send().Error
+	}
+
+	types, err := repSvc.GetReportTypes(ctx.GetEvent().GuildID)
+	if err != nil {
+		return
+	}
+	if !types.Has(typ) {
+		return ctx.FollowUpError("Invalid report type.", "").Send().Error
+	}
 
 	var attachment, expire string
 	if imageurlV, ok := ctx.Options().GetByNameOptional("imageurl"); ok {
@@ -33,7 +51,13 @@ func CmdReport(ctx ken.Context, typ models.ReportType, tp timeprovider.Provider)
 	}
 
 	if attachment != "" {
-		img, err := imgstore.DownloadFromURL(attachment)
+		img, err := imgstore.DownloadFromURL(attachment, imgstore.AllowedHosts(
+			cfg.Config().WebServer.PublicAddr, cfg.Config().WebServer.AttachmentHosts))
+		if errors.Is(err, imgstore.ErrHostNotAllowed) {
+			return ctx.FollowUpError(
+				"Images can only be attached from Discord or other allowed hosts.", "").
+				Send().Error
+		}
 		if err == nil && img != nil {
 			st, _ := ctx.Get(static.DiObjectStorage).(storage.Storage)
 			err = st.PutObject(static.StorageBucketImages, img.ID.String(),
@@ -52,6 +76,7 @@ func CmdReport(ctx ken.Context, typ models.ReportType, tp timeprovider.Provider)
 		Msg:           reason,
 		AttachmentURL: attachment,
 		Type:          typ,
+		Silent:        silent,
 	}
 
 	if expire != "" {
@@ -66,9 +91,12 @@ func CmdReport(ctx ken.Context, typ models.ReportType, tp timeprovider.Provider)
 		rep.Timeout = &expT
 	}
 
-	emb := rep.AsEmbed(cfg.Config().WebServer.PublicAddr)
+	emb := rep.AsEmbed(cfg.Config().WebServer.PublicAddr, types)
 	emb.Title = "Report Check"
 	emb.Description = "Is everything okay so far?"
+	if silent {
+		emb.Description += "\nThe member will not be notified via DM."
+	}
 
 	acceptMsg := acceptmsg.AcceptMessage{
 		Embed:          emb,
@@ -90,12 +118,15 @@ func CmdReport(ctx ken.Context, typ models.ReportType, tp timeprovider.Provider)
 				rep, err = repSvc.PushReport(rep)
 			}
 
+			if roleutil.IsModerationError(err) {
+				return cctx.FollowUpError(err.Error(), "").Send().Error
+			}
 			if err != nil {
 				return
 			}
 
 			return cctx.FollowUpEmbed(
-				rep.AsEmbed(cfg.Config().WebServer.PublicAddr)).
+				rep.AsEmbed(cfg.Config().WebServer.PublicAddr, types)).
 				Send().Error
 		},
 		DeclineFunc: func(cctx ken.ComponentContext) error {
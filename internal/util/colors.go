@@ -0,0 +1,99 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/zekroTJA/colorname"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/pkg/colors"
+)
+
+var ErrInvalidEmbedColor = errors.New("embed color must be a 6 digit hex color code")
+
+// GuildEmbedColor returns the embed color configured
+// for the given guild. If no color is configured or
+// the color could not be retrieved, static.ColorEmbedDefault
+// is returned.
+//
+// This should only be used for informational embeds.
+// Embeds with semantic colors like error or success
+// embeds should keep their color.
+func GuildEmbedColor(db database.Database, guildID string) int {
+	if guildID == "" {
+		return static.ColorEmbedDefault
+	}
+
+	clr, err := db.GetGuildEmbedColor(guildID)
+	if err != nil {
+		return static.ColorEmbedDefault
+	}
+
+	return clr
+}
+
+// ParseEmbedColor parses the given 6 digit hex color
+// code, optionally prefixed with '#', to an integer
+// color value.
+func ParseEmbedColor(hexVal string) (int, error) {
+	hexVal = strings.TrimPrefix(hexVal, "#")
+	if len(hexVal) != 6 {
+		return 0, ErrInvalidEmbedColor
+	}
+
+	clr, err := colors.FromHex(hexVal)
+	if err != nil {
+		return 0, ErrInvalidEmbedColor
+	}
+
+	return colors.ToInt(clr), nil
+}
+
+// ColorEmbed returns an embed showing the passed color
+// in all supported representations as well as the
+// name of the closest known color.
+func ColorEmbed(clr *color.RGBA, publicAddr string) *discordgo.MessageEmbed {
+	hexClr := colors.ToHex(clr)
+	intClr := colors.ToInt(clr)
+	cC, cM, cY, cK := color.RGBToCMYK(clr.R, clr.G, clr.B)
+	yY, yCb, yCr := color.RGBToYCbCr(clr.R, clr.G, clr.B)
+	hH, hS, hL := colors.ToHSL(clr)
+
+	colorName := "*could not be fetched*"
+	matches := colorname.FindRGBA(clr)
+	if len(matches) > 0 {
+		precision := (1 - matches[0].AvgDiff/255) * 100
+		colorName = fmt.Sprintf("**%s** *(%0.1f%%)*", matches[0].Name, precision)
+	}
+
+	desc := fmt.Sprintf(
+		"%s\n\n```\n"+
+			"Hex:    #%s\n"+
+			"Int:    %d\n"+
+			"RGBA:   %03d, %03d, %03d, %03d\n"+
+			"HSL:    %03.0f, %03.0f%%, %03.0f%%\n"+
+			"CMYK:   %03d, %03d, %03d, %03d\n"+
+			"YCbCr:  %03d, %03d, %03d\n"+
+			"```",
+		colorName,
+		hexClr,
+		intClr,
+		clr.R, clr.G, clr.B, clr.A,
+		hH, hS*100, hL*100,
+		cC, cM, cY, cK,
+		yY, yCb, yCr,
+	)
+
+	return &discordgo.MessageEmbed{
+		Color:       intClr,
+		Title:       "#" + hexClr,
+		Description: desc,
+		Thumbnail: &discordgo.MessageEmbedThumbnail{
+			URL: fmt.Sprintf("%s/api/util/color/%s?size=64", publicAddr, hexClr),
+		},
+	}
+}
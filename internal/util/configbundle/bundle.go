@@ -0,0 +1,163 @@
+// Package configbundle provides exporting the shinpuru
+// specific configuration of a guild as a single bundle
+// and importing such a bundle into another guild.
+//
+// Roles and channels referenced in the bundle are mapped
+// to the roles and channels of the target guild by name.
+// Secrets like API or JDoodle keys as well as settings
+// bound to specific messages are not part of the bundle.
+package configbundle
+
+import (
+	"errors"
+	"time"
+
+	"github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/pkg/permissions"
+)
+
+// Version is the current version of the bundle format.
+// It must be increased on every change which can not be
+// read by older versions.
+const Version = 1
+
+var (
+	ErrUnsupportedVersion = errors.New("unsupported bundle version")
+	ErrInvalidBundle      = errors.New("invalid bundle")
+)
+
+// Entity is a role or channel referenced in the bundle.
+type Entity struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// Type is the channel type and is only
+	// set for channels.
+	Type int `json:"type,omitempty"`
+}
+
+// Bundle contains the configuration of a guild.
+type Bundle struct {
+	Version  int       `json:"version"`
+	GuildID  string    `json:"guildid"`
+	Created  time.Time `json:"created"`
+	Roles    []Entity  `json:"roles"`
+	Channels []Entity  `json:"channels"`
+
+	Settings     Settings                               `json:"settings"`
+	Flags        Flags                                  `json:"flags"`
+	Permissions  map[string]permissions.PermissionArray `json:"permissions"`
+	ModLogRoutes map[models.ModLogAction]string         `json:"modlogroutes"`
+	ReportTypes  []ReportType                           `json:"reporttypes"`
+	Escalation   *models.ReportEscalation               `json:"escalation,omitempty"`
+	WordFilter   WordFilter                             `json:"wordfilter"`
+	Karma        Karma                                  `json:"karma"`
+	Antiraid     Antiraid                               `json:"antiraid"`
+	AutoThreads  []AutoThread                           `json:"autothreads"`
+}
+
+// Settings contains the general guild settings.
+type Settings struct {
+	Prefix              string              `json:"prefix"`
+	AutoRoles           []string            `json:"autoroles"`
+	AutoVCs             []string            `json:"autovcs"`
+	ModLogChannel       string              `json:"modlogchannel"`
+	ModLogFormat        models.ModLogFormat `json:"modlogformat"`
+	VoiceLogChannel     string              `json:"voicelogchannel"`
+	VoiceLogIgnores     []string            `json:"voicelogignores"`
+	NotifyRole          string              `json:"notifyrole"`
+	GhostpingMsg        string              `json:"ghostpingmsg"`
+	JoinMsgChannel      string              `json:"joinmsgchannel"`
+	JoinMsg             string              `json:"joinmsg"`
+	LeaveMsgChannel     string              `json:"leavemsgchannel"`
+	LeaveMsg            string              `json:"leavemsg"`
+	ModmailChannel      string              `json:"modmailchannel"`
+	BirthdayChannel     string              `json:"birthdaychannel"`
+	ModNotChannel       string              `json:"modnotchannel"`
+	AnnouncementChannel string              `json:"announcementchannel"`
+	EmbedColor          int                 `json:"embedcolor"` // -1 if not set
+	InviteBlock         string              `json:"inviteblock"`
+	DisabledCommands    []string            `json:"disabledcommands"`
+	CodeExecLanguages   []string            `json:"codeexeclanguages"`
+}
+
+// Flags contains the features which can be
+// enabled or disabled for a guild.
+type Flags struct {
+	ColorReaction             bool `json:"colorreaction"`
+	Backup                    bool `json:"backup"`
+	CodeExec                  bool `json:"codeexec"`
+	LogDisable                bool `json:"logdisable"`
+	CommandSuggestionsDisable bool `json:"commandsuggestionsdisable"`
+	VerificationRequired      bool `json:"verificationrequired"`
+	PinRotation               bool `json:"pinrotation"`
+}
+
+// ReportType is a custom report type.
+type ReportType struct {
+	ID    models.ReportType `json:"id"`
+	Name  string            `json:"name"`
+	Color int               `json:"color"`
+}
+
+// WordFilter contains the word filter
+// settings and entries.
+type WordFilter struct {
+	Enabled        bool                    `json:"enabled"`
+	Action         models.WordFilterAction `json:"action"`
+	MuteDuration   time.Duration           `json:"muteduration"`
+	ExemptRoles    []string                `json:"exemptroles"`
+	ExemptChannels []string                `json:"exemptchannels"`
+	Entries        []WordFilterEntry       `json:"entries"`
+}
+
+// WordFilterEntry is a single filtered
+// word or phrase.
+type WordFilterEntry struct {
+	Pattern string                `json:"pattern"`
+	Mode    models.WordFilterMode `json:"mode"`
+}
+
+// AutoThread contains the auto thread
+// configuration of a channel. The cooldown
+// is specified in seconds.
+type AutoThread struct {
+	ChannelID       string                      `json:"channelid"`
+	AttachmentsOnly bool                        `json:"attachmentsonly"`
+	NameSource      models.AutoThreadNameSource `json:"namesource"`
+	Cooldown        int64                       `json:"cooldown"`
+}
+
+// Karma contains the karma settings.
+type Karma struct {
+	State     bool   `json:"state"`
+	EmotesInc string `json:"emotesinc"`
+	EmotesDec string `json:"emotesdec"`
+	Tokens    int    `json:"tokens"`
+	Penalty   bool   `json:"penalty"`
+}
+
+// Antiraid contains the antiraid settings.
+type Antiraid struct {
+	State        bool `json:"state"`
+	Regeneration int  `json:"regeneration"`
+	Burst        int  `json:"burst"`
+	Verification bool `json:"verification"`
+}
+
+// ImportResult contains the outcome of an import.
+type ImportResult struct {
+	DryRun bool `json:"dryrun"`
+	// MappedRoles and MappedChannels map the IDs
+	// of the bundle to the IDs of the target guild.
+	MappedRoles    map[string]string `json:"mappedroles"`
+	MappedChannels map[string]string `json:"mappedchannels"`
+	// UnmappedRoles and UnmappedChannels contain the
+	// roles and channels which could not be found in
+	// the target guild. Settings referencing them are
+	// dropped on import.
+	UnmappedRoles    []Entity `json:"unmappedroles"`
+	UnmappedChannels []Entity `json:"unmappedchannels"`
+	// UnmappedEmotes contains the karma emotes which
+	// are no unicode emojis and are dropped on import.
+	UnmappedEmotes []string `json:"unmappedemotes"`
+}
@@ -0,0 +1,162 @@
+package configbundle
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/services/database/memory"
+	"github.com/zekroTJA/shinpuru/pkg/permissions"
+)
+
+var (
+	sourceRoles = []*discordgo.Role{
+		{ID: "src-everyone", Name: "@everyone"},
+		{ID: "src-mod", Name: "Moderator"},
+		{ID: "src-vip", Name: "VIP"},
+	}
+	sourceChannels = []*discordgo.Channel{
+		{ID: "src-modlog", Name: "modlog", Type: discordgo.ChannelTypeGuildText},
+		{ID: "src-media", Name: "media", Type: discordgo.ChannelTypeGuildText},
+		{ID: "src-voice", Name: "voice", Type: discordgo.ChannelTypeGuildVoice},
+	}
+	targetRoles = []*discordgo.Role{
+		{ID: "dst-everyone", Name: "@everyone"},
+		{ID: "dst-mod", Name: "moderator"},
+	}
+	targetChannels = []*discordgo.Channel{
+		{ID: "dst-modlog", Name: "modlog", Type: discordgo.ChannelTypeGuildText},
+		{ID: "dst-voice", Name: "media", Type: discordgo.ChannelTypeGuildVoice},
+	}
+)
+
+func setupSource(t *testing.T, db *memory.MemoryMiddleware) {
+	assert.Nil(t, db.SetGuildPrefix("src-everyone", "!"))
+	assert.Nil(t, db.SetGuildAutoRole("src-everyone", []string{"src-mod", "src-vip"}))
+	assert.Nil(t, db.SetGuildModLog("src-everyone", "src-modlog"))
+	assert.Nil(t, db.SetGuildModLogRoute("src-everyone", models.ModLogActionWordFilter, "src-media"))
+	assert.Nil(t, db.SetGuildRolePermission("src-everyone", "src-mod", permissions.PermissionArray{"+sp.guild.mod.*"}))
+	assert.Nil(t, db.SetGuildRolePermission("src-everyone", "src-vip", permissions.PermissionArray{"+sp.chat.vote"}))
+	assert.Nil(t, db.SetGuildColorReaction("src-everyone", true))
+	assert.Nil(t, db.SetGuildReportType(models.CustomReportType{
+		GuildID: "src-everyone", ID: models.CustomReportTypeOffset, Name: "Spam", Color: 0xff0000}))
+	assert.Nil(t, db.SetWordFilterSettings(models.WordFilterSettings{
+		GuildID:        "src-everyone",
+		Enabled:        true,
+		Action:         models.WordFilterActionMute,
+		MuteDuration:   time.Hour,
+		ExemptChannels: []string{"src-modlog", "src-media"},
+	}))
+	assert.Nil(t, db.SetWordFilterEntry(models.WordFilterEntry{
+		GuildID: "src-everyone", Pattern: "bad", Mode: models.WordFilterModeWord}))
+}
+
+func TestExport(t *testing.T) {
+	db := memory.New()
+	setupSource(t, db)
+
+	b, err := Export(db, "src-everyone", sourceRoles, sourceChannels, time.Unix(0, 0))
+	assert.Nil(t, err)
+
+	assert.Equal(t, Version, b.Version)
+	assert.Equal(t, "!", b.Settings.Prefix)
+	assert.True(t, b.Flags.ColorReaction)
+	assert.Equal(t, []WordFilterEntry{{Pattern: "bad", Mode: models.WordFilterModeWord}}, b.WordFilter.Entries)
+	assert.ElementsMatch(t, []Entity{
+		{ID: "src-mod", Name: "Moderator"},
+		{ID: "src-vip", Name: "VIP"},
+	}, b.Roles)
+	assert.ElementsMatch(t, []Entity{
+		{ID: "src-modlog", Name: "modlog", Type: int(discordgo.ChannelTypeGuildText)},
+		{ID: "src-media", Name: "media", Type: int(discordgo.ChannelTypeGuildText)},
+	}, b.Channels)
+}
+
+func TestImport(t *testing.T) {
+	db := memory.New()
+	setupSource(t, db)
+
+	b, err := Export(db, "src-everyone", sourceRoles, sourceChannels, time.Unix(0, 0))
+	assert.Nil(t, err)
+
+	res, err := Import(db, "dst-everyone", b, targetRoles, targetChannels, true, time.Unix(0, 0))
+	assert.Nil(t, err)
+	assert.True(t, res.DryRun)
+	assert.Equal(t, map[string]string{"src-mod": "dst-mod"}, res.MappedRoles)
+	assert.Equal(t, map[string]string{"src-modlog": "dst-modlog"}, res.MappedChannels)
+	assert.Equal(t, []Entity{{ID: "src-vip", Name: "VIP"}}, res.UnmappedRoles)
+	assert.Equal(t, []Entity{{ID: "src-media", Name: "media", Type: int(discordgo.ChannelTypeGuildText)}}, res.UnmappedChannels)
+
+	_, err = db.GetGuildPrefix("dst-everyone")
+	assert.NotNil(t, err, "dry run must not write to the database")
+
+	_, err = Import(db, "dst-everyone", b, targetRoles, targetChannels, false, time.Unix(0, 0))
+	assert.Nil(t, err)
+
+	prefix, _ := db.GetGuildPrefix("dst-everyone")
+	assert.Equal(t, "!", prefix)
+	autoRoles, _ := db.GetGuildAutoRole("dst-everyone")
+	assert.Equal(t, []string{"dst-mod"}, autoRoles)
+	modlog, _ := db.GetGuildModLog("dst-everyone")
+	assert.Equal(t, "dst-modlog", modlog)
+	routes, _ := db.GetGuildModLogRoutes("dst-everyone")
+	assert.Empty(t, routes)
+	perms, _ := db.GetGuildPermissions("dst-everyone")
+	assert.Equal(t, map[string]permissions.PermissionArray{"dst-mod": {"+sp.guild.mod.*"}}, perms)
+	types, _ := db.GetGuildReportTypes("dst-everyone")
+	assert.Equal(t, []models.CustomReportType{{
+		GuildID: "dst-everyone", ID: models.CustomReportTypeOffset, Name: "Spam", Color: 0xff0000}}, types)
+	wf, _ := db.GetWordFilterSettings("dst-everyone")
+	assert.Equal(t, []string{"dst-modlog"}, wf.ExemptChannels)
+	assert.Equal(t, time.Hour, wf.MuteDuration)
+}
+
+func TestImportVersion(t *testing.T) {
+	db := memory.New()
+
+	_, err := Import(db, "guild", &Bundle{Version: Version + 1}, nil, nil, true, time.Unix(0, 0))
+	assert.ErrorIs(t, err, ErrUnsupportedVersion)
+
+	_, err = Import(db, "guild", &Bundle{}, nil, nil, true, time.Unix(0, 0))
+	assert.ErrorIs(t, err, ErrInvalidBundle)
+}
+
+func TestImportKarmaEmotes(t *testing.T) {
+	db := memory.New()
+
+	b := &Bundle{
+		Version: Version,
+		Karma: Karma{
+			State:     true,
+			EmotesInc: "👍❤️👍🏽pog",
+			EmotesDec: "👎🇩🇪",
+		},
+	}
+
+	res, err := Import(db, "guild", b, nil, nil, false, time.Unix(0, 0))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"pog"}, res.UnmappedEmotes)
+
+	inc, dec, err := db.GetKarmaEmotes("guild")
+	assert.Nil(t, err)
+	assert.Equal(t, "👍❤️👍🏽", inc)
+	assert.Equal(t, "👎🇩🇪", dec)
+}
+
+func TestValidate(t *testing.T) {
+	b := &Bundle{Version: Version}
+	assert.Nil(t, b.Validate())
+
+	b.Settings.Prefix = "sp !"
+	assert.ErrorIs(t, b.Validate(), ErrInvalidBundle)
+
+	b.Settings.Prefix = "!"
+	b.Settings.JoinMsg = "Welcome [unknown]!"
+	assert.ErrorIs(t, b.Validate(), ErrInvalidBundle)
+
+	b.Settings.JoinMsg = "Welcome [user]!"
+	b.Settings.DisabledCommands = []string{"help"}
+	assert.ErrorIs(t, b.Validate(), ErrInvalidBundle)
+}
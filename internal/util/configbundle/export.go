@@ -0,0 +1,344 @@
+package configbundle
+
+import (
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/pkg/permissions"
+)
+
+// Export collects the configuration of the guild with
+// the given ID into a bundle. roles and channels are
+// used to resolve the names of referenced roles and
+// channels.
+func Export(
+	db database.Database,
+	guildID string,
+	roles []*discordgo.Role,
+	channels []*discordgo.Channel,
+	now time.Time,
+) (b *Bundle, err error) {
+	b = &Bundle{
+		Version: Version,
+		GuildID: guildID,
+		Created: now,
+	}
+
+	if err = exportSettings(db, guildID, &b.Settings); err != nil {
+		return
+	}
+	if err = exportFlags(db, guildID, &b.Flags); err != nil {
+		return
+	}
+
+	if b.Permissions, err = db.GetGuildPermissions(guildID); ignoreNotFound(err) != nil {
+		return nil, err
+	}
+	if b.ModLogRoutes, err = db.GetGuildModLogRoutes(guildID); ignoreNotFound(err) != nil {
+		return nil, err
+	}
+
+	reportTypes, err := db.GetGuildReportTypes(guildID)
+	if ignoreNotFound(err) != nil {
+		return
+	}
+	b.ReportTypes = make([]ReportType, len(reportTypes))
+	for i, t := range reportTypes {
+		b.ReportTypes[i] = ReportType{ID: t.ID, Name: t.Name, Color: t.Color}
+	}
+
+	escalation, err := db.GetGuildReportEscalation(guildID)
+	if err == nil {
+		escalation.GuildID = ""
+		b.Escalation = &escalation
+	} else if !database.IsErrDatabaseNotFound(err) {
+		return
+	}
+
+	if err = exportWordFilter(db, guildID, &b.WordFilter); err != nil {
+		return
+	}
+	if err = exportKarma(db, guildID, &b.Karma); err != nil {
+		return
+	}
+	if err = exportAntiraid(db, guildID, &b.Antiraid); err != nil {
+		return
+	}
+
+	autoThreads, err := db.GetAutoThreads(guildID)
+	if ignoreNotFound(err) != nil {
+		return
+	}
+	b.AutoThreads = make([]AutoThread, len(autoThreads))
+	for i, t := range autoThreads {
+		b.AutoThreads[i] = AutoThread{
+			ChannelID:       t.ChannelID,
+			AttachmentsOnly: t.AttachmentsOnly,
+			NameSource:      t.NameSource,
+			Cooldown:        int64(t.Cooldown / time.Second),
+		}
+	}
+
+	b.Roles, b.Channels = referencedEntities(b, roles, channels)
+
+	return b, nil
+}
+
+func exportSettings(db database.Database, guildID string, s *Settings) (err error) {
+	if s.Prefix, err = db.GetGuildPrefix(guildID); ignoreNotFound(err) != nil {
+		return
+	}
+	if s.AutoRoles, err = db.GetGuildAutoRole(guildID); ignoreNotFound(err) != nil {
+		return
+	}
+	if s.AutoVCs, err = db.GetGuildAutoVC(guildID); ignoreNotFound(err) != nil {
+		return
+	}
+	if s.ModLogChannel, err = db.GetGuildModLog(guildID); ignoreNotFound(err) != nil {
+		return
+	}
+	if s.ModLogFormat, err = db.GetGuildModLogFormat(guildID); ignoreNotFound(err) != nil {
+		return
+	}
+	if s.VoiceLogChannel, err = db.GetGuildVoiceLog(guildID); ignoreNotFound(err) != nil {
+		return
+	}
+	if s.VoiceLogIgnores, err = db.GetGuildVoiceLogIgnores(guildID); ignoreNotFound(err) != nil {
+		return
+	}
+	if s.NotifyRole, err = db.GetGuildNotifyRole(guildID); ignoreNotFound(err) != nil {
+		return
+	}
+	if s.GhostpingMsg, err = db.GetGuildGhostpingMsg(guildID); ignoreNotFound(err) != nil {
+		return
+	}
+	if s.JoinMsgChannel, s.JoinMsg, err = db.GetGuildJoinMsg(guildID); ignoreNotFound(err) != nil {
+		return
+	}
+	if s.LeaveMsgChannel, s.LeaveMsg, err = db.GetGuildLeaveMsg(guildID); ignoreNotFound(err) != nil {
+		return
+	}
+	if s.ModmailChannel, err = db.GetGuildModmailChannel(guildID); ignoreNotFound(err) != nil {
+		return
+	}
+	if s.BirthdayChannel, err = db.GetGuildBirthdayChan(guildID); ignoreNotFound(err) != nil {
+		return
+	}
+	if s.ModNotChannel, err = db.GetGuildModNot(guildID); ignoreNotFound(err) != nil {
+		return
+	}
+	if s.AnnouncementChannel, err = db.GetGuildAnnouncementChannel(guildID); ignoreNotFound(err) != nil {
+		return
+	}
+	if s.EmbedColor, err = db.GetGuildEmbedColor(guildID); database.IsErrDatabaseNotFound(err) {
+		s.EmbedColor = -1
+	} else if err != nil {
+		return
+	}
+	if s.InviteBlock, err = db.GetGuildInviteBlock(guildID); ignoreNotFound(err) != nil {
+		return
+	}
+	if s.DisabledCommands, err = db.GetGuildDisabledCommands(guildID); ignoreNotFound(err) != nil {
+		return
+	}
+	if s.CodeExecLanguages, err = db.GetGuildCodeExecLanguages(guildID); ignoreNotFound(err) != nil {
+		return
+	}
+	return nil
+}
+
+func exportFlags(db database.Database, guildID string, f *Flags) (err error) {
+	if f.ColorReaction, err = db.GetGuildColorReaction(guildID); ignoreNotFound(err) != nil {
+		return
+	}
+	if f.Backup, err = db.GetGuildBackup(guildID); ignoreNotFound(err) != nil {
+		return
+	}
+	if f.CodeExec, err = db.GetGuildCodeExecEnabled(guildID); ignoreNotFound(err) != nil {
+		return
+	}
+	if f.LogDisable, err = db.GetGuildLogDisable(guildID); ignoreNotFound(err) != nil {
+		return
+	}
+	if f.CommandSuggestionsDisable, err = db.GetGuildCommandSuggestionsDisable(guildID); ignoreNotFound(err) != nil {
+		return
+	}
+	if f.VerificationRequired, err = db.GetGuildVerificationRequired(guildID); ignoreNotFound(err) != nil {
+		return
+	}
+	if f.PinRotation, err = db.GetGuildPinRotation(guildID); ignoreNotFound(err) != nil {
+		return
+	}
+	return nil
+}
+
+func exportWordFilter(db database.Database, guildID string, w *WordFilter) (err error) {
+	settings, err := db.GetWordFilterSettings(guildID)
+	if ignoreNotFound(err) != nil {
+		return
+	}
+	w.Enabled = settings.Enabled
+	w.Action = settings.Action
+	w.MuteDuration = settings.MuteDuration
+	w.ExemptRoles = settings.ExemptRoles
+	w.ExemptChannels = settings.ExemptChannels
+
+	entries, err := db.GetWordFilterEntries(guildID)
+	if ignoreNotFound(err) != nil {
+		return
+	}
+	w.Entries = make([]WordFilterEntry, len(entries))
+	for i, e := range entries {
+		w.Entries[i] = WordFilterEntry{Pattern: e.Pattern, Mode: e.Mode}
+	}
+
+	return nil
+}
+
+func exportKarma(db database.Database, guildID string, k *Karma) (err error) {
+	if k.State, err = db.GetKarmaState(guildID); ignoreNotFound(err) != nil {
+		return
+	}
+	if k.EmotesInc, k.EmotesDec, err = db.GetKarmaEmotes(guildID); ignoreNotFound(err) != nil {
+		return
+	}
+	if k.Tokens, err = db.GetKarmaTokens(guildID); ignoreNotFound(err) != nil {
+		return
+	}
+	if k.Penalty, err = db.GetKarmaPenalty(guildID); ignoreNotFound(err) != nil {
+		return
+	}
+	return nil
+}
+
+func exportAntiraid(db database.Database, guildID string, a *Antiraid) (err error) {
+	if a.State, err = db.GetAntiraidState(guildID); ignoreNotFound(err) != nil {
+		return
+	}
+	if a.Regeneration, err = db.GetAntiraidRegeneration(guildID); ignoreNotFound(err) != nil {
+		return
+	}
+	if a.Burst, err = db.GetAntiraidBurst(guildID); ignoreNotFound(err) != nil {
+		return
+	}
+	if a.Verification, err = db.GetAntiraidVerification(guildID); ignoreNotFound(err) != nil {
+		return
+	}
+	return nil
+}
+
+// referencedEntities returns the roles and channels
+// which are referenced in the bundle.
+func referencedEntities(
+	b *Bundle,
+	roles []*discordgo.Role,
+	channels []*discordgo.Channel,
+) (roleEntities, channelEntities []Entity) {
+	roleNames := make(map[string]string, len(roles))
+	for _, r := range roles {
+		roleNames[r.ID] = r.Name
+	}
+	channelsByID := make(map[string]*discordgo.Channel, len(channels))
+	for _, c := range channels {
+		channelsByID[c.ID] = c
+	}
+
+	seenRoles := make(map[string]bool)
+	seenChannels := make(map[string]bool)
+	roleEntities = []Entity{}
+	channelEntities = []Entity{}
+
+	b.mapReferences(
+		func(id string) string {
+			if !seenRoles[id] {
+				seenRoles[id] = true
+				roleEntities = append(roleEntities, Entity{ID: id, Name: roleNames[id]})
+			}
+			return id
+		},
+		func(id string) string {
+			if !seenChannels[id] {
+				seenChannels[id] = true
+				e := Entity{ID: id}
+				if c, ok := channelsByID[id]; ok {
+					e.Name = c.Name
+					e.Type = int(c.Type)
+				}
+				channelEntities = append(channelEntities, e)
+			}
+			return id
+		},
+	)
+
+	return
+}
+
+// mapReferences replaces all role and channel IDs in
+// the bundle with the result of the given functions.
+// References mapped to an empty string are removed.
+func (b *Bundle) mapReferences(role, channel func(id string) string) {
+	mapOne := func(id *string, f func(string) string) {
+		if *id != "" {
+			*id = f(*id)
+		}
+	}
+	mapList := func(ids []string, f func(string) string) []string {
+		res := make([]string, 0, len(ids))
+		for _, id := range ids {
+			if id = f(id); id != "" {
+				res = append(res, id)
+			}
+		}
+		return res
+	}
+
+	s := &b.Settings
+	s.AutoRoles = mapList(s.AutoRoles, role)
+	mapOne(&s.NotifyRole, role)
+	s.AutoVCs = mapList(s.AutoVCs, channel)
+	mapOne(&s.ModLogChannel, channel)
+	mapOne(&s.VoiceLogChannel, channel)
+	s.VoiceLogIgnores = mapList(s.VoiceLogIgnores, channel)
+	mapOne(&s.JoinMsgChannel, channel)
+	mapOne(&s.LeaveMsgChannel, channel)
+	mapOne(&s.ModmailChannel, channel)
+	mapOne(&s.BirthdayChannel, channel)
+	mapOne(&s.ModNotChannel, channel)
+	mapOne(&s.AnnouncementChannel, channel)
+
+	perms := make(map[string]permissions.PermissionArray, len(b.Permissions))
+	for id, p := range b.Permissions {
+		if id = role(id); id != "" {
+			perms[id] = p
+		}
+	}
+	b.Permissions = perms
+
+	routes := make(map[models.ModLogAction]string, len(b.ModLogRoutes))
+	for action, id := range b.ModLogRoutes {
+		if id = channel(id); id != "" {
+			routes[action] = id
+		}
+	}
+	b.ModLogRoutes = routes
+
+	b.WordFilter.ExemptRoles = mapList(b.WordFilter.ExemptRoles, role)
+	b.WordFilter.ExemptChannels = mapList(b.WordFilter.ExemptChannels, channel)
+
+	autoThreads := make([]AutoThread, 0, len(b.AutoThreads))
+	for _, t := range b.AutoThreads {
+		if t.ChannelID = channel(t.ChannelID); t.ChannelID != "" {
+			autoThreads = append(autoThreads, t)
+		}
+	}
+	b.AutoThreads = autoThreads
+}
+
+func ignoreNotFound(err error) error {
+	if database.IsErrDatabaseNotFound(err) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,565 @@
+package configbundle
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/makeworld-the-better-one/go-isemoji"
+	"github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/util/membermsg"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/pkg/permissions"
+	"github.com/zekroTJA/shinpuru/pkg/stringutil"
+)
+
+// Import applies the configuration of the bundle to the
+// guild with the given ID. Roles and channels referenced
+// in the bundle are mapped to the given roles and
+// channels of the target guild by name. References which
+// can not be mapped are dropped and listed in the result.
+// Karma emotes which are no unicode emojis, like custom
+// emojis of the source guild, are dropped as well.
+//
+// When dryRun is true, only the result is computed and
+// nothing is written to the database.
+//
+// Because the database does not support transactions,
+// the current configuration of the guild is exported
+// before the import and restored if the import fails.
+func Import(
+	db database.Database,
+	guildID string,
+	b *Bundle,
+	roles []*discordgo.Role,
+	channels []*discordgo.Channel,
+	dryRun bool,
+	now time.Time,
+) (res *ImportResult, err error) {
+	if err = b.Validate(); err != nil {
+		return
+	}
+
+	res = &ImportResult{
+		DryRun:           dryRun,
+		MappedRoles:      make(map[string]string),
+		MappedChannels:   make(map[string]string),
+		UnmappedRoles:    []Entity{},
+		UnmappedChannels: []Entity{},
+		UnmappedEmotes:   []string{},
+	}
+
+	for _, e := range b.Roles {
+		if id := findRole(e, roles); id != "" {
+			res.MappedRoles[e.ID] = id
+		} else {
+			res.UnmappedRoles = append(res.UnmappedRoles, e)
+		}
+	}
+	for _, e := range b.Channels {
+		if id := findChannel(e, channels); id != "" {
+			res.MappedChannels[e.ID] = id
+		} else {
+			res.UnmappedChannels = append(res.UnmappedChannels, e)
+		}
+	}
+
+	target, err := b.copy()
+	if err != nil {
+		return
+	}
+	target.mapReferences(
+		func(id string) string { return res.MappedRoles[id] },
+		func(id string) string { return res.MappedChannels[id] },
+	)
+
+	var unmapped []string
+	target.Karma.EmotesInc, unmapped = mapEmotes(target.Karma.EmotesInc)
+	res.UnmappedEmotes = append(res.UnmappedEmotes, unmapped...)
+	target.Karma.EmotesDec, unmapped = mapEmotes(target.Karma.EmotesDec)
+	res.UnmappedEmotes = append(res.UnmappedEmotes, unmapped...)
+
+	if dryRun {
+		return
+	}
+
+	snapshot, err := Export(db, guildID, roles, channels, now)
+	if err != nil {
+		return
+	}
+
+	if err = apply(db, guildID, snapshot, target); err != nil {
+		if errRollback := apply(db, guildID, target, snapshot); errRollback != nil {
+			err = fmt.Errorf("import failed: %s; rollback failed: %s", err.Error(), errRollback.Error())
+		}
+		return
+	}
+
+	return
+}
+
+// Validate returns an error if the bundle can not be
+// imported because of its version or invalid values.
+func (b *Bundle) Validate() error {
+	if b.Version < 1 {
+		return fmt.Errorf("%w: missing version", ErrInvalidBundle)
+	}
+	if b.Version > Version {
+		return fmt.Errorf("%w: %d (latest supported version is %d)", ErrUnsupportedVersion, b.Version, Version)
+	}
+
+	if strings.IndexFunc(b.Settings.Prefix, unicode.IsSpace) != -1 {
+		return fmt.Errorf("%w: prefix must not contain whitespace", ErrInvalidBundle)
+	}
+	if err := membermsg.Validate(b.Settings.JoinMsg); err != nil {
+		return fmt.Errorf("%w: join message: %s", ErrInvalidBundle, err.Error())
+	}
+	if err := membermsg.Validate(b.Settings.LeaveMsg); err != nil {
+		return fmt.Errorf("%w: leave message: %s", ErrInvalidBundle, err.Error())
+	}
+	for _, cmd := range b.Settings.DisabledCommands {
+		if stringutil.ContainsAny(cmd, static.EssentialCommands) {
+			return fmt.Errorf("%w: essential command '%s' can not be disabled", ErrInvalidBundle, cmd)
+		}
+	}
+
+	if len(b.ReportTypes) > models.MaxCustomReportTypes {
+		return fmt.Errorf("%w: more than %d report types", ErrInvalidBundle, models.MaxCustomReportTypes)
+	}
+	custom := make([]models.CustomReportType, len(b.ReportTypes))
+	for i, t := range b.ReportTypes {
+		if !t.ID.IsCustom() {
+			return fmt.Errorf("%w: report type %d is not a custom report type", ErrInvalidBundle, t.ID)
+		}
+		custom[i] = models.CustomReportType{ID: t.ID, Name: t.Name, Color: t.Color}
+	}
+	types := models.NewReportTypeSet(custom)
+	for _, t := range custom {
+		if err := types.Validate(t); err != nil {
+			return fmt.Errorf("%w: report type %d: %s", ErrInvalidBundle, t.ID, err.Error())
+		}
+	}
+
+	if b.Escalation != nil {
+		if err := b.Escalation.Validate(types); err != nil {
+			return fmt.Errorf("%w: escalation: %s", ErrInvalidBundle, err.Error())
+		}
+	}
+
+	wfSettings := b.wordFilterSettings("")
+	if err := wfSettings.Validate(); err != nil {
+		return fmt.Errorf("%w: word filter: %s", ErrInvalidBundle, err.Error())
+	}
+	for _, e := range b.WordFilter.Entries {
+		entry := models.WordFilterEntry{Pattern: e.Pattern, Mode: e.Mode}
+		if err := entry.Validate(); err != nil {
+			return fmt.Errorf("%w: word filter entry '%s': %s", ErrInvalidBundle, e.Pattern, err.Error())
+		}
+	}
+
+	for _, t := range b.AutoThreads {
+		cfg := t.config("")
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("%w: auto thread %s: %s", ErrInvalidBundle, t.ChannelID, err.Error())
+		}
+	}
+
+	return nil
+}
+
+func (b *Bundle) copy() (c *Bundle, err error) {
+	data, err := json.Marshal(b)
+	if err != nil {
+		return
+	}
+	c = new(Bundle)
+	err = json.Unmarshal(data, c)
+	return
+}
+
+func (b *Bundle) wordFilterSettings(guildID string) models.WordFilterSettings {
+	action := b.WordFilter.Action
+	if action == "" {
+		action = models.WordFilterActionDelete
+	}
+	return models.WordFilterSettings{
+		GuildID:        guildID,
+		Enabled:        b.WordFilter.Enabled,
+		Action:         action,
+		MuteDuration:   b.WordFilter.MuteDuration,
+		ExemptRoles:    b.WordFilter.ExemptRoles,
+		ExemptChannels: b.WordFilter.ExemptChannels,
+	}
+}
+
+func (t AutoThread) config(guildID string) models.AutoThreadConfig {
+	return models.AutoThreadConfig{
+		GuildID:         guildID,
+		ChannelID:       t.ChannelID,
+		AttachmentsOnly: t.AttachmentsOnly,
+		NameSource:      t.NameSource,
+		Cooldown:        time.Duration(t.Cooldown) * time.Second,
+	}
+}
+
+// mapEmotes splits the given karma emotes and drops all
+// emotes which are no unicode emojis. The remaining emotes
+// and the dropped ones are returned. Consecutive dropped
+// characters are returned as one entry.
+func mapEmotes(emotes string) (mapped string, unmapped []string) {
+	var sb strings.Builder
+	lastDropped := false
+	for _, e := range splitEmotes(emotes) {
+		if isemoji.IsEmojiNonStrict(e) {
+			sb.WriteString(e)
+			lastDropped = false
+		} else if lastDropped {
+			unmapped[len(unmapped)-1] += e
+		} else {
+			unmapped = append(unmapped, e)
+			lastDropped = true
+		}
+	}
+	return sb.String(), unmapped
+}
+
+// splitEmotes splits a string of concatenated emojis into
+// single emojis. Modifiers, variation selectors, joined
+// sequences and flags are kept together.
+func splitEmotes(s string) (res []string) {
+	var (
+		cur    []rune
+		joined bool
+	)
+	for _, r := range s {
+		cont := joined || isEmoteContinuation(r) ||
+			(len(cur) == 1 && isRegionalIndicator(cur[0]) && isRegionalIndicator(r))
+		if len(cur) > 0 && !cont {
+			res = append(res, string(cur))
+			cur = cur[:0]
+		}
+		cur = append(cur, r)
+		joined = r == '\u200d'
+	}
+	if len(cur) > 0 {
+		res = append(res, string(cur))
+	}
+	return res
+}
+
+func isEmoteContinuation(r rune) bool {
+	return r == '\u200d' || r == '\ufe0e' || r == '\ufe0f' || r == '\u20e3' ||
+		(r >= 0x1f3fb && r <= 0x1f3ff) || // skin tones
+		(r >= 0xe0020 && r <= 0xe007f) // tags
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1f1e6 && r <= 0x1f1ff
+}
+
+// findRole returns the ID of the first role matching
+// the name of the entity (case insensitive).
+func findRole(e Entity, roles []*discordgo.Role) string {
+	if e.Name == "" {
+		return ""
+	}
+	for _, r := range roles {
+		if strings.EqualFold(r.Name, e.Name) {
+			return r.ID
+		}
+	}
+	return ""
+}
+
+// findChannel returns the ID of the first channel
+// matching the name (case insensitive) and type of
+// the entity.
+func findChannel(e Entity, channels []*discordgo.Channel) string {
+	if e.Name == "" {
+		return ""
+	}
+	for _, c := range channels {
+		if int(c.Type) == e.Type && strings.EqualFold(c.Name, e.Name) {
+			return c.ID
+		}
+	}
+	return ""
+}
+
+// apply writes the configuration of next to the guild.
+// cur is the current configuration of the guild and is
+// used to remove entries which are not part of next.
+func apply(db database.Database, guildID string, cur, next *Bundle) (err error) {
+	if err = applySettings(db, guildID, &cur.Settings, &next.Settings); err != nil {
+		return
+	}
+	if err = applyFlags(db, guildID, &next.Flags); err != nil {
+		return
+	}
+
+	for roleID := range cur.Permissions {
+		if _, ok := next.Permissions[roleID]; !ok {
+			if err = db.SetGuildRolePermission(guildID, roleID, permissions.PermissionArray{}); err != nil {
+				return
+			}
+		}
+	}
+	for roleID, p := range next.Permissions {
+		if err = db.SetGuildRolePermission(guildID, roleID, p); err != nil {
+			return
+		}
+	}
+
+	for action := range cur.ModLogRoutes {
+		if _, ok := next.ModLogRoutes[action]; !ok {
+			if err = db.RemoveGuildModLogRoute(guildID, action); ignoreNotFound(err) != nil {
+				return
+			}
+		}
+	}
+	for action, channelID := range next.ModLogRoutes {
+		if err = db.SetGuildModLogRoute(guildID, action, channelID); err != nil {
+			return
+		}
+	}
+
+	for _, t := range cur.ReportTypes {
+		if !containsReportType(next.ReportTypes, t.ID) {
+			if err = db.DeleteGuildReportType(guildID, t.ID); ignoreNotFound(err) != nil {
+				return
+			}
+		}
+	}
+	for _, t := range next.ReportTypes {
+		err = db.SetGuildReportType(models.CustomReportType{
+			GuildID: guildID,
+			ID:      t.ID,
+			Name:    t.Name,
+			Color:   t.Color,
+		})
+		if err != nil {
+			return
+		}
+	}
+
+	escalation := models.ReportEscalation{}
+	if next.Escalation != nil {
+		escalation = *next.Escalation
+	}
+	escalation.GuildID = guildID
+	if next.Escalation != nil || cur.Escalation != nil {
+		if err = db.SetGuildReportEscalation(escalation); err != nil {
+			return
+		}
+	}
+
+	if err = applyWordFilter(db, guildID, cur, next); err != nil {
+		return
+	}
+
+	k := next.Karma
+	if err = db.SetKarmaState(guildID, k.State); err != nil {
+		return
+	}
+	if err = db.SetKarmaEmotes(guildID, k.EmotesInc, k.EmotesDec); err != nil {
+		return
+	}
+	if err = db.SetKarmaTokens(guildID, k.Tokens); err != nil {
+		return
+	}
+	if err = db.SetKarmaPenalty(guildID, k.Penalty); err != nil {
+		return
+	}
+
+	a := next.Antiraid
+	if err = db.SetAntiraidState(guildID, a.State); err != nil {
+		return
+	}
+	if err = db.SetAntiraidRegeneration(guildID, a.Regeneration); err != nil {
+		return
+	}
+	if err = db.SetAntiraidBurst(guildID, a.Burst); err != nil {
+		return
+	}
+	if err = db.SetAntiraidVerification(guildID, a.Verification); err != nil {
+		return
+	}
+
+	for _, t := range cur.AutoThreads {
+		if !containsAutoThread(next.AutoThreads, t.ChannelID) {
+			if err = db.RemoveAutoThread(guildID, t.ChannelID); ignoreNotFound(err) != nil {
+				return
+			}
+		}
+	}
+	for _, t := range next.AutoThreads {
+		if err = db.SetAutoThread(t.config(guildID)); err != nil {
+			return
+		}
+	}
+
+	return nil
+}
+
+func applySettings(db database.Database, guildID string, cur, next *Settings) (err error) {
+	if err = db.SetGuildPrefix(guildID, next.Prefix); err != nil {
+		return
+	}
+	if err = db.SetGuildAutoRole(guildID, next.AutoRoles); err != nil {
+		return
+	}
+	if err = db.SetGuildAutoVC(guildID, next.AutoVCs); err != nil {
+		return
+	}
+	if err = db.SetGuildModLog(guildID, next.ModLogChannel); err != nil {
+		return
+	}
+	if err = db.SetGuildModLogFormat(guildID, next.ModLogFormat); err != nil {
+		return
+	}
+	if err = db.SetGuildVoiceLog(guildID, next.VoiceLogChannel); err != nil {
+		return
+	}
+	for _, id := range cur.VoiceLogIgnores {
+		if !stringutil.ContainsAny(id, next.VoiceLogIgnores) {
+			if err = db.RemoveGuildVoiceLogIgnore(guildID, id); ignoreNotFound(err) != nil {
+				return
+			}
+		}
+	}
+	for _, id := range next.VoiceLogIgnores {
+		if !stringutil.ContainsAny(id, cur.VoiceLogIgnores) {
+			if err = db.SetGuildVoiceLogIngore(guildID, id); err != nil {
+				return
+			}
+		}
+	}
+	if err = db.SetGuildNotifyRole(guildID, next.NotifyRole); err != nil {
+		return
+	}
+	if err = db.SetGuildGhostpingMsg(guildID, next.GhostpingMsg); err != nil {
+		return
+	}
+	if err = db.SetGuildJoinMsg(guildID, next.JoinMsgChannel, next.JoinMsg); err != nil {
+		return
+	}
+	if err = db.SetGuildLeaveMsg(guildID, next.LeaveMsgChannel, next.LeaveMsg); err != nil {
+		return
+	}
+	if err = db.SetGuildModmailChannel(guildID, next.ModmailChannel); err != nil {
+		return
+	}
+	if err = db.SetGuildBirthdayChan(guildID, next.BirthdayChannel); err != nil {
+		return
+	}
+	if err = db.SetGuildModNot(guildID, next.ModNotChannel); err != nil {
+		return
+	}
+	if err = db.SetGuildAnnouncementChannel(guildID, next.AnnouncementChannel); err != nil {
+		return
+	}
+	if err = db.SetGuildEmbedColor(guildID, next.EmbedColor); err != nil {
+		return
+	}
+	if err = db.SetGuildInviteBlock(guildID, next.InviteBlock); err != nil {
+		return
+	}
+	for _, cmd := range cur.DisabledCommands {
+		if !stringutil.ContainsAny(cmd, next.DisabledCommands) {
+			if err = db.SetGuildDisabledCommand(guildID, cmd, false); err != nil {
+				return
+			}
+		}
+	}
+	for _, cmd := range next.DisabledCommands {
+		if err = db.SetGuildDisabledCommand(guildID, cmd, true); err != nil {
+			return
+		}
+	}
+	if err = db.SetGuildCodeExecLanguages(guildID, next.CodeExecLanguages); err != nil {
+		return
+	}
+	return nil
+}
+
+func applyFlags(db database.Database, guildID string, f *Flags) (err error) {
+	if err = db.SetGuildColorReaction(guildID, f.ColorReaction); err != nil {
+		return
+	}
+	if err = db.SetGuildBackup(guildID, f.Backup); err != nil {
+		return
+	}
+	if err = db.SetGuildCodeExecEnabled(guildID, f.CodeExec); err != nil {
+		return
+	}
+	if err = db.SetGuildLogDisable(guildID, f.LogDisable); err != nil {
+		return
+	}
+	if err = db.SetGuildCommandSuggestionsDisable(guildID, f.CommandSuggestionsDisable); err != nil {
+		return
+	}
+	if err = db.SetGuildVerificationRequired(guildID, f.VerificationRequired); err != nil {
+		return
+	}
+	if err = db.SetGuildPinRotation(guildID, f.PinRotation); err != nil {
+		return
+	}
+	return nil
+}
+
+func applyWordFilter(db database.Database, guildID string, cur, next *Bundle) (err error) {
+	if err = db.SetWordFilterSettings(next.wordFilterSettings(guildID)); err != nil {
+		return
+	}
+
+	for _, e := range cur.WordFilter.Entries {
+		if !containsWordFilterEntry(next.WordFilter.Entries, e.Pattern) {
+			if err = db.RemoveWordFilterEntry(guildID, e.Pattern); ignoreNotFound(err) != nil {
+				return
+			}
+		}
+	}
+	for _, e := range next.WordFilter.Entries {
+		err = db.SetWordFilterEntry(models.WordFilterEntry{
+			GuildID: guildID,
+			Pattern: e.Pattern,
+			Mode:    e.Mode,
+		})
+		if err != nil {
+			return
+		}
+	}
+
+	return nil
+}
+
+func containsReportType(types []ReportType, id models.ReportType) bool {
+	for _, t := range types {
+		if t.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func containsWordFilterEntry(entries []WordFilterEntry, pattern string) bool {
+	for _, e := range entries {
+		if e.Pattern == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAutoThread(threads []AutoThread, channelID string) bool {
+	for _, t := range threads {
+		if t.ChannelID == channelID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,343 @@
+// Package giveaway provides utilities to post giveaway
+// messages, handle entries and determine the winners
+// of giveaways.
+package giveaway
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/services/guildlog"
+	"github.com/zekroTJA/shinpuru/internal/services/timeprovider"
+	"github.com/zekroTJA/shinpuru/internal/util"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/pkg/discordutil"
+	"github.com/zekroTJA/shinpuru/pkg/hammertime"
+	"github.com/zekroTJA/shinpuru/pkg/random"
+	"github.com/zekroTJA/shinpuru/pkg/stringutil"
+	"github.com/zekrotja/ken"
+	"github.com/zekrotja/rogu/log"
+)
+
+var tl = log.Tagged("Giveaway")
+
+// Embed returns the embed displaying the given giveaway.
+func Embed(g models.Giveaway) *discordgo.MessageEmbed {
+	emb := &discordgo.MessageEmbed{
+		Color:       static.ColorEmbedDefault,
+		Title:       "🎉 Giveaway",
+		Description: fmt.Sprintf("**%s**", g.Prize),
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name:   "Winners",
+				Value:  fmt.Sprintf("%d", g.Winners),
+				Inline: true,
+			},
+			{
+				Name:   "Ends",
+				Value:  hammertime.Format(g.Expires, hammertime.Span),
+				Inline: true,
+			},
+		},
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: fmt.Sprintf("ID: %s", g.ID),
+		},
+	}
+
+	var requirements []string
+	if g.RoleID != "" {
+		requirements = append(requirements, fmt.Sprintf("Role <@&%s>", g.RoleID))
+	}
+	if g.MinKarma > 0 {
+		requirements = append(requirements, fmt.Sprintf("At least %d karma", g.MinKarma))
+	}
+	if len(requirements) > 0 {
+		emb.Fields = append(emb.Fields, &discordgo.MessageEmbedField{
+			Name:  "Requirements",
+			Value: strings.Join(requirements, "\n"),
+		})
+	}
+
+	if g.Ended {
+		emb.Color = static.ColorEmbedGray
+		emb.Title = "🎉 Giveaway (ended)"
+		emb.Fields[1].Name = "Ended"
+		emb.Fields = append(emb.Fields, &discordgo.MessageEmbedField{
+			Name:  "Winners",
+			Value: winnersList(g.WinnerIDs),
+		})
+	}
+
+	return emb
+}
+
+// Post sends the message of the given giveaway into its
+// channel, attaches the enter button and stores the
+// giveaway.
+func Post(db database.Database, s discordutil.ISession, kc *ken.ComponentHandler, tp timeprovider.Provider, g *models.Giveaway) (err error) {
+	emb := Embed(*g)
+	emb.Color = util.GuildEmbedColor(db, g.GuildID)
+
+	msg, err := s.ChannelMessageSendEmbed(g.ChannelID, emb)
+	if err != nil {
+		return
+	}
+	g.MessageID = msg.ID
+
+	if err = db.SetGiveaway(*g); err != nil {
+		return
+	}
+
+	return AttachEnterButton(db, kc, tp, *g)
+}
+
+// AttachEnterButton attaches the enter button to the
+// giveaway message and registers the button handler.
+func AttachEnterButton(db database.Database, kc *ken.ComponentHandler, tp timeprovider.Provider, g models.Giveaway) (err error) {
+	_, err = kc.Add(g.MessageID, g.ChannelID).
+		AddActionsRow(func(b ken.ComponentAssembler) {
+			b.Add(discordgo.Button{
+				Label:    "Enter",
+				Emoji:    discordgo.ComponentEmoji{Name: "🎉"},
+				Style:    discordgo.PrimaryButton,
+				CustomID: "giveaway-enter-" + g.ID.String(),
+			}, onEnter(db, tp, g))
+		}).
+		Build()
+	return
+}
+
+// End ends the given giveaway by picking the winners
+// from all entries, updating the giveaway message and
+// announcing the winners in the giveaway channel. If
+// note is not empty, it is appended to the
+// announcement.
+//
+// If the giveaway has already been ended concurrently,
+// nothing is announced.
+func End(db database.Database, s discordutil.ISession, g *models.Giveaway, note string) (err error) {
+	entries, err := db.GetGiveawayEntries(g.ID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return
+	}
+
+	winners, err := PickWinners(entries, g.Winners)
+	if err != nil {
+		return
+	}
+
+	ok, err := db.EndGiveaway(g.ID, winners)
+	if err != nil || !ok {
+		return
+	}
+	g.Ended = true
+	g.WinnerIDs = winners
+
+	return announce(s, g, g.WinnerIDs, note)
+}
+
+// Reroll picks n new winners of the given ended giveaway
+// from all entries which have not won before and
+// announces them.
+func Reroll(db database.Database, s discordutil.ISession, g *models.Giveaway, n int) (winners []string, err error) {
+	entries, err := db.GetGiveawayEntries(g.ID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return
+	}
+
+	candidates := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if !stringutil.ContainsAny(e, g.WinnerIDs) {
+			candidates = append(candidates, e)
+		}
+	}
+
+	winners, err = PickWinners(candidates, n)
+	if err != nil || len(winners) == 0 {
+		return
+	}
+
+	g.WinnerIDs = append(g.WinnerIDs, winners...)
+	if err = db.SetGiveaway(*g); err != nil {
+		return
+	}
+
+	err = announce(s, g, winners, "This is a reroll.")
+	return
+}
+
+// EndExpired returns a function which ends all giveaways
+// of guilds handled by the current shard which have
+// expired.
+func EndExpired(db database.Database, s *discordgo.Session, gl guildlog.Logger, tp timeprovider.Provider) func() {
+	gl = gl.Section("giveaway")
+	return func() {
+		giveaways, err := db.GetGiveaways("", true)
+		if err != nil && !database.IsErrDatabaseNotFound(err) {
+			tl.Error().Err(err).Msg("Failed getting active giveaways")
+			return
+		}
+
+		shardID, shardTotal := discordutil.GetShardOfSession(s)
+		now := tp.Now()
+
+		for _, g := range giveaways {
+			if now.Before(g.Expires) {
+				continue
+			}
+			if shardTotal > 1 {
+				if id, err := discordutil.GetShardOfGuild(g.GuildID, shardTotal); err != nil || id != shardID {
+					continue
+				}
+			}
+			if err = End(db, s, &g, ""); err != nil {
+				gl.Errorf(g.GuildID, "Failed ending giveaway %s: %s", g.ID, err.Error())
+				tl.Error().Err(err).Field("id", g.ID).Msg("Failed ending giveaway")
+			}
+		}
+	}
+}
+
+// PickWinners returns n distinct, uniformly randomly
+// picked elements of entries. If entries contains less
+// than n elements, all elements are returned in random
+// order.
+func PickWinners(entries []string, n int) ([]string, error) {
+	pool := make([]string, len(entries))
+	copy(pool, entries)
+
+	if n > len(pool) {
+		n = len(pool)
+	}
+
+	// Partial Fisher-Yates shuffle: each position is
+	// swapped with a uniformly picked element of the
+	// remaining pool.
+	for i := 0; i < n; i++ {
+		j, err := random.GetRandInt(len(pool) - i)
+		if err != nil {
+			return nil, err
+		}
+		j += i
+		pool[i], pool[j] = pool[j], pool[i]
+	}
+
+	return pool[:n], nil
+}
+
+func announce(s discordutil.ISession, g *models.Giveaway, winners []string, note string) (err error) {
+	// The message might have been deleted, so errors
+	// are ignored and the announcement is sent without
+	// a reference to the giveaway message.
+	var ref *discordgo.MessageReference
+	if g.MessageID != "" {
+		_, err = s.ChannelMessageEditComplex(&discordgo.MessageEdit{
+			ID:         g.MessageID,
+			Channel:    g.ChannelID,
+			Embeds:     []*discordgo.MessageEmbed{Embed(*g)},
+			Components: []discordgo.MessageComponent{},
+		})
+		if err == nil {
+			ref = &discordgo.MessageReference{
+				MessageID: g.MessageID,
+				ChannelID: g.ChannelID,
+				GuildID:   g.GuildID,
+			}
+		}
+	}
+
+	content := fmt.Sprintf("🎉 Congratulations %s! You won **%s**.", winnersList(winners), g.Prize)
+	if len(winners) == 0 {
+		content = fmt.Sprintf("Nobody entered the giveaway for **%s**, so there are no winners.", g.Prize)
+	}
+	if note != "" {
+		content += "\n" + note
+	}
+
+	_, err = s.ChannelMessageSendComplex(g.ChannelID, &discordgo.MessageSend{
+		Content:   content,
+		Reference: ref,
+	})
+	return
+}
+
+func winnersList(winners []string) string {
+	if len(winners) == 0 {
+		return "*none*"
+	}
+	mentions := make([]string, len(winners))
+	for i, w := range winners {
+		mentions[i] = "<@" + w + ">"
+	}
+	return strings.Join(mentions, ", ")
+}
+
+func onEnter(db database.Database, tp timeprovider.Provider, g models.Giveaway) ken.ComponentHandlerFunc {
+	return func(ctx ken.ComponentContext) bool {
+		ctx.SetEphemeral(true)
+		if err := ctx.Defer(); err != nil {
+			return false
+		}
+
+		// The giveaway is fetched on each click because
+		// it might have been ended in the meantime.
+		g, err := db.GetGiveaway(g.ID)
+		if err != nil {
+			ctx.FollowUpError("Failed getting the giveaway. Please try again later.", "").Send()
+			return false
+		}
+
+		if g.Ended || tp.Now().After(g.Expires) {
+			ctx.FollowUpError("This giveaway has already ended.", "").Send()
+			return false
+		}
+
+		member := ctx.GetEvent().Member
+		if member == nil {
+			return false
+		}
+
+		if g.RoleID != "" && !stringutil.ContainsAny(g.RoleID, member.Roles) {
+			ctx.FollowUpError(
+				fmt.Sprintf("You need the role <@&%s> to enter this giveaway.", g.RoleID), "").
+				Send()
+			return false
+		}
+
+		if g.MinKarma > 0 {
+			karma, err := db.GetKarma(member.User.ID, g.GuildID)
+			if err != nil && !database.IsErrDatabaseNotFound(err) {
+				ctx.FollowUpError("Failed getting your karma. Please try again later.", "").Send()
+				return false
+			}
+			if karma < g.MinKarma {
+				ctx.FollowUpError(
+					fmt.Sprintf("You need at least %d karma to enter this giveaway (you have %d).",
+						g.MinKarma, karma), "").
+					Send()
+				return false
+			}
+		}
+
+		added, err := db.AddGiveawayEntry(g.ID, g.GuildID, member.User.ID)
+		if err != nil {
+			ctx.FollowUpError("Failed saving your entry. Please try again later.", "").Send()
+			return false
+		}
+
+		desc := "You have entered the giveaway. Good luck!"
+		if !added {
+			desc = "You have already entered this giveaway."
+		}
+
+		ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+			Color:       static.ColorEmbedGreen,
+			Description: desc,
+		}).Send()
+
+		return true
+	}
+}
@@ -0,0 +1,50 @@
+package giveaway
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPickWinners(t *testing.T) {
+	entries := []string{"a", "b", "c", "d", "e"}
+
+	winners, err := PickWinners(entries, 3)
+	assert.Nil(t, err)
+	assert.Len(t, winners, 3)
+	assert.ElementsMatch(t, []string{"a", "b", "c", "d", "e"}, entries, "entries must not be modified")
+
+	seen := make(map[string]bool)
+	for _, w := range winners {
+		assert.Contains(t, entries, w)
+		assert.False(t, seen[w], "winners must be distinct")
+		seen[w] = true
+	}
+
+	winners, err = PickWinners(entries, 10)
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, entries, winners)
+
+	winners, err = PickWinners(nil, 1)
+	assert.Nil(t, err)
+	assert.Empty(t, winners)
+}
+
+func TestPickWinnersUniform(t *testing.T) {
+	entries := []string{"a", "b", "c", "d"}
+
+	const rounds = 8000
+	counts := make(map[string]int)
+	for i := 0; i < rounds; i++ {
+		winners, err := PickWinners(entries, 1)
+		assert.Nil(t, err)
+		counts[winners[0]]++
+	}
+
+	// Each entry is expected to win 2000 times. The
+	// tolerance is far outside of the standard deviation
+	// so that the test does not fail randomly.
+	for _, e := range entries {
+		assert.InDelta(t, rounds/len(entries), counts[e], 300, e)
+	}
+}
@@ -0,0 +1,164 @@
+// Package guildsettings provides the effective general
+// guild settings, which are the stored settings merged
+// with their default values.
+package guildsettings
+
+import (
+	"fmt"
+
+	"github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/pkg/permissions"
+)
+
+// Setting is the effective value of a guild setting.
+type Setting struct {
+	// Value is the stored value of the setting or
+	// the default value, if the setting is not set.
+	Value interface{} `json:"value"`
+	// Default is the value which is used when the
+	// setting is not set.
+	Default interface{} `json:"default"`
+	// IsSet is true if the setting has been set
+	// explicitly.
+	IsSet bool `json:"is_set"`
+}
+
+// Settings maps the JSON field names of the general
+// guild settings to their effective values.
+type Settings map[string]Setting
+
+// Effective returns the effective general guild
+// settings of the given guild.
+//
+// Guild settings are stored as empty values when they
+// are not set, so a setting is considered as set when
+// its stored value is not empty.
+func Effective(db database.Database, guildID string) (s Settings, err error) {
+	s = make(Settings)
+
+	prefix, err := db.GetGuildPrefix(guildID)
+	if ignoreNotFound(err) != nil {
+		return
+	}
+	s.add("prefix", prefix, "")
+
+	perms, err := db.GetGuildPermissions(guildID)
+	if ignoreNotFound(err) != nil {
+		return
+	}
+	if perms == nil {
+		perms = map[string]permissions.PermissionArray{}
+	}
+	s.set("perms", perms, map[string]permissions.PermissionArray{}, len(perms) != 0)
+
+	autoRoles, err := db.GetGuildAutoRole(guildID)
+	if ignoreNotFound(err) != nil {
+		return
+	}
+	if autoRoles == nil {
+		autoRoles = []string{}
+	}
+	s.set("autoroles", autoRoles, []string{}, len(autoRoles) != 0)
+
+	modLogChannel, err := db.GetGuildModLog(guildID)
+	if ignoreNotFound(err) != nil {
+		return
+	}
+	s.add("modlogchannel", modLogChannel, "")
+
+	modLogFormat, err := db.GetGuildModLogFormat(guildID)
+	if ignoreNotFound(err) != nil {
+		return
+	}
+	if !modLogFormat.Validate() {
+		modLogFormat = ""
+	}
+	s.add("modlogformat", string(modLogFormat), string(models.ModLogFormatDefault))
+
+	modNotChannel, err := db.GetGuildModNot(guildID)
+	if ignoreNotFound(err) != nil {
+		return
+	}
+	s.add("modnotchannel", modNotChannel, "")
+
+	voiceLogChannel, err := db.GetGuildVoiceLog(guildID)
+	if ignoreNotFound(err) != nil {
+		return
+	}
+	s.add("voicelogchannel", voiceLogChannel, "")
+
+	joinChannel, joinText, err := db.GetGuildJoinMsg(guildID)
+	if ignoreNotFound(err) != nil {
+		return
+	}
+	s.add("joinmessagechannel", joinChannel, "")
+	s.add("joinmessagetext", joinText, "")
+
+	leaveChannel, leaveText, err := db.GetGuildLeaveMsg(guildID)
+	if ignoreNotFound(err) != nil {
+		return
+	}
+	s.add("leavemessagechannel", leaveChannel, "")
+	s.add("leavemessagetext", leaveText, "")
+
+	embedColor, err := db.GetGuildEmbedColor(guildID)
+	if database.IsErrDatabaseNotFound(err) {
+		embedColor = -1
+	} else if err != nil {
+		return
+	}
+	s.add("embedcolor", EmbedColorHex(embedColor), EmbedColorHex(static.ColorEmbedDefault))
+
+	announcementChannel, err := db.GetGuildAnnouncementChannel(guildID)
+	if ignoreNotFound(err) != nil {
+		return
+	}
+	s.add("announcementchannel", announcementChannel, "")
+
+	return s, nil
+}
+
+// add sets the given string setting, which is
+// considered as set when it is not empty.
+func (s Settings) add(key, value, def string) {
+	isSet := value != ""
+	if !isSet {
+		value = def
+	}
+	s.set(key, value, def, isSet)
+}
+
+// Value returns the effective value of the given
+// setting as T. The zero value of T is returned when
+// the setting does not exist or is not of type T.
+func Value[T any](s Settings, key string) (v T) {
+	v, _ = s[key].Value.(T)
+	return v
+}
+
+func (s Settings) set(key string, value, def interface{}, isSet bool) {
+	s[key] = Setting{
+		Value:   value,
+		Default: def,
+		IsSet:   isSet,
+	}
+}
+
+// EmbedColorHex returns the given embed color as hex
+// string. Negative colors, which represent an unset
+// embed color, result in an empty string.
+func EmbedColorHex(clr int) string {
+	if clr < 0 {
+		return ""
+	}
+	return fmt.Sprintf("%06X", clr)
+}
+
+func ignoreNotFound(err error) error {
+	if database.IsErrDatabaseNotFound(err) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,64 @@
+package guildsettings
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/services/database/memory"
+	"github.com/zekroTJA/shinpuru/pkg/permissions"
+)
+
+func TestEffectiveDefaults(t *testing.T) {
+	db := memory.New()
+
+	s, err := Effective(db, "guild-id")
+	assert.Nil(t, err)
+
+	assert.Equal(t, Setting{Value: "", Default: "", IsSet: false}, s["prefix"])
+	assert.Equal(t, Setting{
+		Value:   string(models.ModLogFormatDefault),
+		Default: string(models.ModLogFormatDefault),
+	}, s["modlogformat"])
+	assert.Equal(t, Setting{Value: "FFC107", Default: "FFC107"}, s["embedcolor"])
+	assert.Equal(t, Setting{Value: []string{}, Default: []string{}}, s["autoroles"])
+	assert.False(t, s["perms"].IsSet)
+	assert.Len(t, s, 13)
+}
+
+func TestEffective(t *testing.T) {
+	db := memory.New()
+	assert.Nil(t, db.SetGuildPrefix("guild-id", "!"))
+	assert.Nil(t, db.SetGuildEmbedColor("guild-id", 0x123456))
+	assert.Nil(t, db.SetGuildJoinMsg("guild-id", "channel-id", "welcome [user]"))
+	assert.Nil(t, db.SetGuildModLogFormat("guild-id", "invalid"))
+	assert.Nil(t, db.SetGuildRolePermission("guild-id", "role-id", permissions.PermissionArray{"+sp.chat.*"}))
+
+	s, err := Effective(db, "guild-id")
+	assert.Nil(t, err)
+
+	assert.Equal(t, Setting{Value: "!", Default: "", IsSet: true}, s["prefix"])
+	assert.Equal(t, Setting{Value: "123456", Default: "FFC107", IsSet: true}, s["embedcolor"])
+	assert.Equal(t, Setting{Value: "channel-id", Default: "", IsSet: true}, s["joinmessagechannel"])
+	assert.Equal(t, Setting{Value: "welcome [user]", Default: "", IsSet: true}, s["joinmessagetext"])
+	assert.False(t, s["leavemessagechannel"].IsSet)
+	// Invalid stored values fall back to the default.
+	assert.False(t, s["modlogformat"].IsSet)
+	assert.True(t, s["perms"].IsSet)
+}
+
+func TestValue(t *testing.T) {
+	s := Settings{}
+	s.add("prefix", "!", "")
+	s.set("autoroles", []string{"a"}, []string{}, true)
+
+	assert.Equal(t, "!", Value[string](s, "prefix"))
+	assert.Equal(t, []string{"a"}, Value[[]string](s, "autoroles"))
+	assert.Equal(t, "", Value[string](s, "autoroles"))
+	assert.Equal(t, "", Value[string](s, "unknown"))
+}
+
+func TestEmbedColorHex(t *testing.T) {
+	assert.Equal(t, "00AB12", EmbedColorHex(0xAB12))
+	assert.Equal(t, "", EmbedColorHex(-1))
+}
@@ -0,0 +1,161 @@
+// Package guildstats provides utilities to compute
+// aggregated statistics of a guild from the state.
+package guildstats
+
+import (
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/services/kvcache"
+	"github.com/zekroTJA/shinpuru/internal/services/timeprovider"
+	"github.com/zekroTJA/shinpuru/pkg/discordutil"
+	"github.com/zekrotja/dgrs"
+)
+
+// CacheLifetime is the duration computed statistics
+// are cached for.
+const CacheLifetime = 1 * time.Minute
+
+// Get returns the statistics of the given guild. Because
+// the computation requires to iterate over all members
+// and presences of the guild, the result is cached for
+// CacheLifetime.
+func Get(st dgrs.IState, kvc kvcache.Provider, tp timeprovider.Provider, guildID string) (stats models.GuildStats, err error) {
+	cacheKey := "guildstats:" + guildID
+	if stats, ok := kvc.Get(cacheKey).(models.GuildStats); ok {
+		return stats, nil
+	}
+
+	guild, err := st.Guild(guildID)
+	if err != nil {
+		return
+	}
+
+	channels, err := st.Channels(guildID)
+	if err != nil {
+		return
+	}
+
+	roles, err := st.Roles(guildID)
+	if err != nil {
+		return
+	}
+
+	members, err := st.Members(guildID)
+	if err != nil {
+		return
+	}
+
+	presences, err := st.Presences(guildID)
+	if err != nil {
+		return
+	}
+
+	stats = Compute(guild, channels, roles, members, presences)
+	stats.Computed = tp.Now()
+
+	kvc.Set(cacheKey, stats, CacheLifetime)
+	return
+}
+
+// Compute returns the statistics of the given guild
+// computed from the passed channels, roles, members
+// and presences.
+func Compute(
+	guild *discordgo.Guild,
+	channels []*discordgo.Channel,
+	roles []*discordgo.Role,
+	members []*discordgo.Member,
+	presences []*discordgo.Presence,
+) (stats models.GuildStats) {
+	stats.GuildID = guild.ID
+	stats.Created, _ = discordutil.GetDiscordSnowflakeCreationTime(guild.ID)
+	stats.PremiumTier = int(guild.PremiumTier)
+	stats.PremiumCount = guild.PremiumSubscriptionCount
+
+	for _, r := range roles {
+		// The @everyone role has the same ID as the guild.
+		if r.ID != guild.ID {
+			stats.Roles++
+		}
+	}
+
+	stats.Channels = computeChannels(channels)
+
+	for _, m := range members {
+		if m.User != nil && m.User.Bot {
+			stats.Bots++
+		} else {
+			stats.Humans++
+		}
+	}
+
+	// The member count of the guild is set on guild create
+	// and kept up to date on member add and remove while
+	// members are only present in the state if they have
+	// been received or fetched before.
+	stats.Members = guild.MemberCount
+	if stats.Members < len(members) {
+		stats.Members = len(members)
+	}
+	stats.MembersComplete = len(members) >= stats.Members
+
+	stats.Presences = computePresences(presences, stats.Members)
+
+	return
+}
+
+func computeChannels(channels []*discordgo.Channel) (cs models.GuildChannelStats) {
+	cs.Total = len(channels)
+	for _, c := range channels {
+		switch c.Type {
+		case discordgo.ChannelTypeGuildText:
+			cs.Text++
+		case discordgo.ChannelTypeGuildNews:
+			cs.News++
+		case discordgo.ChannelTypeGuildVoice:
+			cs.Voice++
+		case discordgo.ChannelTypeGuildStageVoice:
+			cs.Stage++
+		case discordgo.ChannelTypeGuildCategory:
+			cs.Category++
+		case discordgo.ChannelTypeGuildForum:
+			cs.Forum++
+		default:
+			cs.Other++
+		}
+	}
+	return
+}
+
+// computePresences returns the presence statistics.
+// Discord only sends presences of members which are
+// not offline, so all remaining members are counted
+// as offline. If no presences are available at all,
+// for example because the presence intent is not
+// enabled, nil is returned.
+func computePresences(presences []*discordgo.Presence, members int) *models.GuildPresenceStats {
+	if len(presences) == 0 {
+		return nil
+	}
+
+	var ps models.GuildPresenceStats
+	for _, p := range presences {
+		switch p.Status {
+		case discordgo.StatusOnline:
+			ps.Online++
+		case discordgo.StatusIdle:
+			ps.Idle++
+		case discordgo.StatusDoNotDisturb:
+			ps.DoNotDisturb++
+		}
+	}
+
+	ps.Offline = members - ps.Online - ps.Idle - ps.DoNotDisturb
+	if ps.Offline < 0 {
+		ps.Offline = 0
+	}
+
+	return &ps
+}
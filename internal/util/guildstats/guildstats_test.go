@@ -0,0 +1,63 @@
+package guildstats
+
+import (
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/zekroTJA/shinpuru/internal/models"
+)
+
+func TestCompute(t *testing.T) {
+	guild := &discordgo.Guild{
+		ID:                       "123",
+		MemberCount:              5,
+		PremiumTier:              discordgo.PremiumTier2,
+		PremiumSubscriptionCount: 7,
+	}
+	channels := []*discordgo.Channel{
+		{Type: discordgo.ChannelTypeGuildText},
+		{Type: discordgo.ChannelTypeGuildText},
+		{Type: discordgo.ChannelTypeGuildVoice},
+		{Type: discordgo.ChannelTypeGuildCategory},
+		{Type: discordgo.ChannelTypeGuildNews},
+		{Type: discordgo.ChannelTypeGuildStore},
+	}
+	roles := []*discordgo.Role{{ID: "123"}, {ID: "1"}, {ID: "2"}}
+	members := []*discordgo.Member{
+		{User: &discordgo.User{ID: "a"}},
+		{User: &discordgo.User{ID: "b"}},
+		{User: &discordgo.User{ID: "c", Bot: true}},
+	}
+
+	stats := Compute(guild, channels, roles, members, nil)
+
+	assert.Equal(t, "123", stats.GuildID)
+	assert.Equal(t, 5, stats.Members)
+	assert.Equal(t, 2, stats.Humans)
+	assert.Equal(t, 1, stats.Bots)
+	assert.False(t, stats.MembersComplete)
+	assert.Equal(t, 2, stats.Roles)
+	assert.Equal(t, 2, stats.PremiumTier)
+	assert.Equal(t, 7, stats.PremiumCount)
+	assert.Equal(t, models.GuildChannelStats{
+		Total: 6, Text: 2, News: 1, Voice: 1, Category: 1, Other: 1,
+	}, stats.Channels)
+	assert.Nil(t, stats.Presences)
+
+	presences := []*discordgo.Presence{
+		{Status: discordgo.StatusOnline},
+		{Status: discordgo.StatusIdle},
+		{Status: discordgo.StatusDoNotDisturb},
+		{Status: discordgo.StatusOnline},
+	}
+	guild.MemberCount = 2
+
+	stats = Compute(guild, channels, roles, members, presences)
+
+	assert.Equal(t, 3, stats.Members)
+	assert.True(t, stats.MembersComplete)
+	assert.Equal(t, &models.GuildPresenceStats{
+		Online: 2, Idle: 1, DoNotDisturb: 1, Offline: 0,
+	}, stats.Presences)
+}
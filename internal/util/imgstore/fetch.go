@@ -0,0 +1,90 @@
+package imgstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	// MaxDownloadSize is the maximum size of files
+	// downloaded via DownloadFromURL.
+	MaxDownloadSize = 8 * 1024 * 1024
+
+	fetchTimeout      = 10 * time.Second
+	fetchMaxRedirects = 5
+)
+
+var (
+	ErrHostNotAllowed = errors.New("host is not allowed")
+	ErrFileTooLarge   = errors.New("file is too large")
+)
+
+// AllowedHosts returns the passed hosts and the host of
+// the passed public address, which serves the images
+// stored by shinpuru.
+func AllowedHosts(publicAddr string, hosts []string) []string {
+	res := append([]string{}, hosts...)
+	if u, err := url.Parse(publicAddr); err == nil && u.Hostname() != "" {
+		res = append(res, u.Hostname())
+	}
+	return res
+}
+
+// Fetch GETs the file at the passed URL if its host is
+// one of the passed hosts (see IsAllowedHost). Redirects
+// are only followed to allowed hosts. The request times
+// out after 10 seconds and files larger than maxSize
+// bytes are rejected.
+func Fetch(ctx context.Context, rawURL string, hosts []string, maxSize int) (data []byte, contentType string, err error) {
+	if !IsAllowedHost(rawURL, hosts) {
+		return nil, "", ErrHostNotAllowed
+	}
+
+	client := http.Client{
+		Timeout: fetchTimeout,
+		CheckRedirect: func(r *http.Request, via []*http.Request) error {
+			if len(via) >= fetchMaxRedirects {
+				return errors.New("too many redirects")
+			}
+			if !IsAllowedHost(r.URL.String(), hosts) {
+				return ErrHostNotAllowed
+			}
+			return nil
+		},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("unexpected response status: %s", res.Status)
+	}
+	if res.ContentLength > int64(maxSize) {
+		return nil, "", ErrFileTooLarge
+	}
+
+	data, err = io.ReadAll(io.LimitReader(res.Body, int64(maxSize)+1))
+	if err != nil {
+		return
+	}
+	if len(data) > maxSize {
+		return nil, "", ErrFileTooLarge
+	}
+
+	contentType = res.Header.Get("Content-Type")
+
+	return
+}
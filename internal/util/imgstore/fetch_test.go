@@ -0,0 +1,80 @@
+package imgstore
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zekroTJA/shinpuru/internal/util/snowflakenodes"
+)
+
+func TestFetch(t *testing.T) {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/image.png":
+			w.Header().Set("Content-Type", "image/png")
+			w.Write([]byte("image"))
+		case "/large.png":
+			w.Write([]byte(strings.Repeat("a", 11)))
+		case "/redirect":
+			http.Redirect(w, r, strings.Replace(srv.URL, "127.0.0.1", "localhost", 1)+"/image.png",
+				http.StatusFound)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	u, _ := url.Parse(srv.URL)
+	hosts := []string{u.Hostname()}
+	ctx := context.Background()
+
+	data, contentType, err := Fetch(ctx, srv.URL+"/image.png", hosts, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, "image", string(data))
+	assert.Equal(t, "image/png", contentType)
+
+	_, _, err = Fetch(ctx, srv.URL+"/image.png", []string{"cdn.discordapp.com"}, 10)
+	assert.ErrorIs(t, err, ErrHostNotAllowed)
+
+	_, _, err = Fetch(ctx, srv.URL+"/redirect", hosts, 10)
+	assert.ErrorIs(t, err, ErrHostNotAllowed)
+
+	_, _, err = Fetch(ctx, srv.URL+"/large.png", hosts, 10)
+	assert.ErrorIs(t, err, ErrFileTooLarge)
+
+	_, _, err = Fetch(ctx, srv.URL+"/missing.png", hosts, 10)
+	assert.NotNil(t, err)
+}
+
+func TestAllowedHosts(t *testing.T) {
+	assert.Equal(t, []string{"cdn.discordapp.com", "shinpuru.example.com"},
+		AllowedHosts("https://shinpuru.example.com:8080", []string{"cdn.discordapp.com"}))
+	assert.Equal(t, []string{"cdn.discordapp.com"},
+		AllowedHosts("", []string{"cdn.discordapp.com"}))
+}
+
+func TestDownloadFromURL(t *testing.T) {
+	snowflakenodes.Setup()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		w.Write([]byte("image"))
+	}))
+	defer srv.Close()
+
+	u, _ := url.Parse(srv.URL)
+
+	img, err := DownloadFromURL(srv.URL+"/image.png", []string{u.Hostname()})
+	assert.Nil(t, err)
+	assert.Equal(t, "image/png", img.MimeType)
+	assert.Equal(t, 5, img.Size)
+
+	_, err = DownloadFromURL(srv.URL+"/image.png", []string{"cdn.discordapp.com"})
+	assert.ErrorIs(t, err, ErrHostNotAllowed)
+}
@@ -1,14 +1,13 @@
 package imgstore
 
 import (
+	"context"
 	"fmt"
-	"io/ioutil"
 	"net/http"
 	"strings"
 
 	"github.com/bwmarrin/snowflake"
 	"github.com/zekroTJA/shinpuru/internal/util/snowflakenodes"
-	"github.com/zekroTJA/shinpuru/pkg/httpreq"
 )
 
 var defClient = http.Client{
@@ -34,8 +33,15 @@ func (img *Image) GenerateID() {
 // passed resource URL, downloading it and returning
 // the metadata and data of the image as well as
 // occured errors.
-func DownloadFromURL(url string) (img *Image, err error) {
-	body, contentType, err := httpreq.GetFile(url, nil)
+//
+// The image is fetched using Fetch, so the host of
+// the URL must be one of the passed hosts and files
+// larger than MaxDownloadSize are rejected.
+func DownloadFromURL(url string, hosts []string) (img *Image, err error) {
+	data, contentType, err := Fetch(context.Background(), url, hosts, MaxDownloadSize)
+	if err != nil {
+		return nil, err
+	}
 
 	img = new(Image)
 
@@ -44,11 +50,7 @@ func DownloadFromURL(url string) (img *Image, err error) {
 		return nil, fmt.Errorf("mime type not received")
 	}
 
-	img.Data, err = ioutil.ReadAll(body)
-	if err != nil {
-		return nil, err
-	}
-
+	img.Data = data
 	img.Size = len(img.Data)
 
 	if img.Data == nil || img.Size < 1 {
@@ -1,6 +1,7 @@
 package imgstore
 
 import (
+	"net/url"
 	"regexp"
 	"strings"
 
@@ -31,3 +32,32 @@ func ExtractFromMessage(text string, attachments []*discordgo.MessageAttachment)
 
 	return resText, imgLink
 }
+
+// IsAllowedHost returns true when the host of the passed
+// URL matches one of the passed hosts. Hosts prefixed with
+// "*." match all subdomains of the host but not the host
+// itself.
+func IsAllowedHost(rawURL string, hosts []string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+
+	host := strings.ToLower(u.Hostname())
+	if host == "" {
+		return false
+	}
+
+	for _, h := range hosts {
+		h = strings.ToLower(h)
+		if strings.HasPrefix(h, "*.") {
+			if strings.HasSuffix(host, h[1:]) {
+				return true
+			}
+		} else if host == h {
+			return true
+		}
+	}
+
+	return false
+}
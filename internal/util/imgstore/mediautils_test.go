@@ -0,0 +1,44 @@
+package imgstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsAllowedHost(t *testing.T) {
+	hosts := []string{"cdn.discordapp.com", "shinpuru.example.com", "*.media.example.com"}
+
+	allowed := []string{
+		"https://cdn.discordapp.com/attachments/1/2/image.png",
+		"http://CDN.DiscordApp.com/image.png",
+		"https://cdn.discordapp.com:443/image.png",
+		"https://shinpuru.example.com/api/v1/imagestore/123.png",
+		"https://eu.media.example.com/image.png",
+		"https://a.b.media.example.com/image.png",
+	}
+	for _, u := range allowed {
+		assert.True(t, IsAllowedHost(u, hosts), u)
+	}
+
+	disallowed := []string{
+		"https://example.com/image.png",
+		"https://cdn.discordapp.com.evil.com/image.png",
+		"https://evilcdn.discordapp.com/image.png",
+		"https://cdn-discordapp.com/image.png",
+		"https://cdn.discordapp.co/image.png",
+		"https://cdn.discordapp.com@evil.com/image.png",
+		"https://evil.com/cdn.discordapp.com/image.png",
+		"https://evil.com/?host=cdn.discordapp.com&f=image.png",
+		"https://media.example.com/image.png",
+		"https://evilmedia.example.com/image.png",
+		"/relative/image.png",
+		"not a url",
+		"",
+	}
+	for _, u := range disallowed {
+		assert.False(t, IsAllowedHost(u, hosts), u)
+	}
+
+	assert.False(t, IsAllowedHost("https://cdn.discordapp.com/image.png", nil))
+}
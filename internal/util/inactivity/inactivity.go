@@ -0,0 +1,444 @@
+// Package inactivity provides utilities to remove a role
+// from members of guilds which have opted in after the
+// members have not sent any messages or joined any voice
+// channels for the configured number of days.
+//
+// Activity is recorded in the cache first and flushed to
+// the database periodically. Members are warned via DM
+// before the role is removed.
+package inactivity
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/services/guildlog"
+	"github.com/zekroTJA/shinpuru/internal/services/kvcache"
+	"github.com/zekroTJA/shinpuru/internal/services/msgsender"
+	"github.com/zekroTJA/shinpuru/internal/services/timeprovider"
+	"github.com/zekroTJA/shinpuru/internal/util/modlog"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/internal/util/temprole"
+	"github.com/zekroTJA/shinpuru/pkg/discordutil"
+	"github.com/zekroTJA/shinpuru/pkg/hammertime"
+	"github.com/zekroTJA/shinpuru/pkg/stringutil"
+	"github.com/zekrotja/dgrs"
+	"github.com/zekrotja/rogu/log"
+)
+
+const (
+	// MaxRemovalsPerRun is the maximum number of members
+	// the role is removed from per guild and run, so that
+	// a misconfiguration can not strip the role from all
+	// members of a guild at once.
+	MaxRemovalsPerRun = 25
+	// MaxWarningsPerRun is the maximum number of members
+	// which are warned per guild and run. Members which
+	// exceed it are warned in one of the next runs.
+	MaxWarningsPerRun = 50
+
+	keyPrefix = "inactivity:"
+
+	// activityLifetime is the lifetime of recorded
+	// activities in the cache. It must be longer than
+	// the flush interval so that no activity is lost.
+	activityLifetime = 2 * time.Hour
+
+	day = 24 * time.Hour
+)
+
+var tl = log.Tagged("Inactivity")
+
+// flushMtx prevents activities from being recorded while
+// they are taken from the cache by Flush, which would
+// otherwise get lost.
+var flushMtx sync.RWMutex
+
+type action int
+
+const (
+	actionNone action = iota
+	actionWarn
+	actionRemove
+)
+
+// Track records the current time as last activity of
+// the given member.
+func Track(kvc kvcache.Provider, tp timeprovider.Provider, guildID, userID string) {
+	flushMtx.RLock()
+	defer flushMtx.RUnlock()
+
+	kvc.Set(makeKey(guildID, userID), tp.Now(), activityLifetime)
+}
+
+// Flush returns a job which writes all recorded
+// activities in the cache to the database. Writing an
+// activity resets the warning of the member. Activities
+// which could not be written are kept in the cache for
+// the next flush.
+func Flush(db database.Database, kvc kvcache.Provider) func() {
+	return func() {
+		entries := take(kvc)
+		if len(entries) == 0 {
+			return
+		}
+
+		if err := db.SetMemberActivities(entries); err != nil {
+			tl.Error().Err(err).Field("n", len(entries)).Msg("Failed flushing member activities")
+			flushMtx.Lock()
+			defer flushMtx.Unlock()
+			for _, a := range entries {
+				key := makeKey(a.GuildID, a.UserID)
+				// Do not overwrite activities which have
+				// been recorded in the meantime.
+				if curr, ok := kvc.Get(key).(time.Time); !ok || curr.Before(a.LastActive) {
+					kvc.Set(key, a.LastActive, activityLifetime)
+				}
+			}
+		}
+	}
+}
+
+// Reset removes all recorded activities of the given
+// guild from the cache and the database.
+func Reset(db database.Database, kvc kvcache.Provider, guildID string) (err error) {
+	flushMtx.Lock()
+	kvc.FlushPrefix(keyPrefix + guildID + ":")
+	flushMtx.Unlock()
+
+	if err = db.DeleteMemberActivities(guildID); database.IsErrDatabaseNotFound(err) {
+		err = nil
+	}
+	return
+}
+
+// take removes all recorded activities from the cache
+// and returns them.
+func take(kvc kvcache.Provider) []models.MemberActivity {
+	flushMtx.Lock()
+	defer flushMtx.Unlock()
+
+	keys := kvc.Keys(keyPrefix)
+	entries := make([]models.MemberActivity, 0, len(keys))
+	for _, key := range keys {
+		a, ok := parseKey(key)
+		last, isTime := kvc.Get(key).(time.Time)
+		kvc.Del(key)
+		if !ok || !isTime {
+			continue
+		}
+		a.LastActive = last
+		entries = append(entries, a)
+	}
+
+	return entries
+}
+
+// Process returns a job which warns and removes the role
+// from inactive members of all guilds handled by the
+// current shard which have enabled the inactivity role
+// removal.
+func Process(
+	db database.Database,
+	s *discordgo.Session,
+	st dgrs.IState,
+	sender *msgsender.Sender,
+	gl guildlog.Logger,
+	tp timeprovider.Provider,
+) func() {
+	gl = gl.Section("inactivity")
+	return func() {
+		settings, err := db.GetEnabledInactivitySettings()
+		if err != nil && !database.IsErrDatabaseNotFound(err) {
+			tl.Error().Err(err).Msg("Failed getting inactivity settings")
+			return
+		}
+
+		shardID, shardTotal := discordutil.GetShardOfSession(s)
+
+		for _, set := range settings {
+			if shardTotal > 1 {
+				if id, err := discordutil.GetShardOfGuild(set.GuildID, shardTotal); err != nil || id != shardID {
+					continue
+				}
+			}
+			if err = processGuild(db, s, st, sender, tp.Now(), set); err != nil {
+				tl.Error().Err(err).Field("gid", set.GuildID).Msg("Failed processing inactive members")
+				gl.Errorf(set.GuildID, "Failed processing inactive members: %s", err.Error())
+			}
+		}
+	}
+}
+
+func processGuild(
+	db database.Database,
+	s discordutil.ISession,
+	st dgrs.IState,
+	sender *msgsender.Sender,
+	now time.Time,
+	set models.InactivitySettings,
+) error {
+	guild, err := st.Guild(set.GuildID)
+	if err != nil {
+		return err
+	}
+
+	members, err := st.Members(set.GuildID)
+	if err != nil {
+		return err
+	}
+
+	activities, err := db.GetMemberActivities(set.GuildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return err
+	}
+	activityOf := make(map[string]models.MemberActivity, len(activities))
+	for _, a := range activities {
+		activityOf[a.UserID] = a
+	}
+
+	// Members which are currently in a voice channel are
+	// considered active, even if they have joined it
+	// before the last flush.
+	voiceStates, err := st.VoiceStates(set.GuildID)
+	if err != nil {
+		return err
+	}
+	inVoice := make(map[string]bool, len(voiceStates))
+	for _, vs := range voiceStates {
+		inVoice[vs.UserID] = vs.ChannelID != ""
+	}
+
+	roleName := set.RoleID
+	for _, r := range guild.Roles {
+		if r.ID == set.RoleID {
+			roleName = r.Name
+			break
+		}
+	}
+
+	var (
+		active  []models.MemberActivity
+		warned  int
+		removed int
+	)
+
+	for _, m := range members {
+		if m.User == nil || m.User.Bot || !stringutil.ContainsAny(set.RoleID, m.Roles) {
+			continue
+		}
+
+		a, ok := activityOf[m.User.ID]
+		if !ok {
+			a = models.MemberActivity{
+				GuildID:    set.GuildID,
+				UserID:     m.User.ID,
+				LastActive: set.Since,
+			}
+		}
+
+		if inVoice[m.User.ID] {
+			active = append(active, models.MemberActivity{
+				GuildID:    set.GuildID,
+				UserID:     m.User.ID,
+				LastActive: now,
+			})
+			continue
+		}
+
+		switch check(set, a, m.JoinedAt, now) {
+		case actionWarn:
+			if warned >= MaxWarningsPerRun {
+				continue
+			}
+			if err = warn(db, s, sender, guild, roleName, set, a, m.JoinedAt, now); err != nil {
+				return err
+			}
+			warned++
+		case actionRemove:
+			if removed >= MaxRemovalsPerRun {
+				continue
+			}
+			if err = remove(db, s, sender, set, a, m.JoinedAt, now); err != nil {
+				return err
+			}
+			removed++
+		}
+	}
+
+	if len(active) != 0 {
+		return db.SetMemberActivities(active)
+	}
+
+	return nil
+}
+
+// check returns the action to be performed for the
+// given member holding the inactivity role.
+func check(set models.InactivitySettings, a models.MemberActivity, joined, now time.Time) action {
+	last := LastActive(set, a, joined)
+	inactive := now.Sub(last)
+
+	if inactive < time.Duration(set.Days-set.WarnDays)*day {
+		return actionNone
+	}
+
+	// Warnings sent before the last activity belong to
+	// a previous period of inactivity.
+	if a.Warned == nil || a.Warned.Before(last) {
+		return actionWarn
+	}
+
+	// The role is never removed earlier than WarnDays
+	// after the warning, even if the member has been
+	// inactive for longer in the meantime.
+	if inactive >= time.Duration(set.Days)*day &&
+		now.Sub(*a.Warned) >= time.Duration(set.WarnDays)*day {
+		return actionRemove
+	}
+
+	return actionNone
+}
+
+// LastActive returns the last activity of the member.
+// Members are never considered inactive for longer than
+// they are members of the guild or since the removal has
+// been enabled.
+func LastActive(set models.InactivitySettings, a models.MemberActivity, joined time.Time) time.Time {
+	last := a.LastActive
+	if set.Since.After(last) {
+		last = set.Since
+	}
+	if joined.After(last) {
+		last = joined
+	}
+	return last
+}
+
+// removalTime returns the time the role is removed from
+// the member if the member stays inactive after being
+// warned at the given time.
+func removalTime(set models.InactivitySettings, last, warned time.Time) time.Time {
+	t := last.Add(time.Duration(set.Days) * day)
+	if earliest := warned.Add(time.Duration(set.WarnDays) * day); earliest.After(t) {
+		t = earliest
+	}
+	return t
+}
+
+func warn(
+	db database.Database,
+	s discordutil.ISession,
+	sender *msgsender.Sender,
+	guild *discordgo.Guild,
+	roleName string,
+	set models.InactivitySettings,
+	a models.MemberActivity,
+	joined, now time.Time,
+) error {
+	a.Warned = &now
+	if err := db.SetMemberActivityWarned(a); err != nil {
+		return err
+	}
+
+	last := LastActive(set, a, joined)
+
+	// The member is considered warned even if the DM
+	// can not be sent, for example because the member
+	// does not accept DMs from guild members.
+	ch, err := s.UserChannelCreate(a.UserID)
+	if err != nil {
+		tl.Debug().Err(err).Fields("gid", a.GuildID, "uid", a.UserID).Msg("Failed opening DM channel")
+		return nil
+	}
+
+	err = sender.EnqueueEmbed(s, a.GuildID, ch.ID, &discordgo.MessageEmbed{
+		Color: static.ColorEmbedOrange,
+		Title: "Inactivity Warning",
+		Description: fmt.Sprintf(
+			"You have not been active on the guild **%s** since %s. The role **%s** will be removed from you %s "+
+				"if you stay inactive.\n\nSend a message or join a voice channel on the guild to keep the role.",
+			guild.Name, hammertime.Format(last, hammertime.LongerDate), roleName,
+			hammertime.Format(removalTime(set, last, now), hammertime.Span)),
+	}, nil)
+	if err != nil {
+		tl.Error().Err(err).Fields("gid", a.GuildID, "uid", a.UserID).Msg("Failed sending inactivity warning")
+	}
+
+	return nil
+}
+
+func remove(
+	db database.Database,
+	s discordutil.ISession,
+	sender *msgsender.Sender,
+	set models.InactivitySettings,
+	a models.MemberActivity,
+	joined, now time.Time,
+) error {
+	err := s.GuildMemberRoleRemove(set.GuildID, a.UserID, set.RoleID)
+	if temprole.IsErrGone(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	last := LastActive(set, a, joined)
+
+	// The timer of the member is reset so that the role
+	// is not removed again right away if it is assigned
+	// to the member again.
+	err = db.SetMemberActivities([]models.MemberActivity{{
+		GuildID:    set.GuildID,
+		UserID:     a.UserID,
+		LastActive: now,
+	}})
+	if err != nil {
+		return err
+	}
+
+	err = modlog.Send(db, sender, s, set.GuildID, models.ModLogActionInactivity, &discordgo.MessageEmbed{
+		Color: static.ColorEmbedOrange,
+		Title: "Inactivity Role Removed",
+		Description: fmt.Sprintf("The role <@&%s> has been removed from <@%s> because of inactivity.",
+			set.RoleID, a.UserID),
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name:   "Last Activity",
+				Value:  hammertime.Format(last, hammertime.LongerDate),
+				Inline: true,
+			},
+			{
+				Name:   "Inactivity Period",
+				Value:  fmt.Sprintf("%d days", set.Days),
+				Inline: true,
+			},
+		},
+		Timestamp: now.Format(time.RFC3339),
+	})
+	if err != nil {
+		tl.Error().Err(err).Field("gid", set.GuildID).Msg("Failed sending mod log entry")
+	}
+
+	return nil
+}
+
+func makeKey(guildID, userID string) string {
+	return keyPrefix + guildID + ":" + userID
+}
+
+func parseKey(key string) (a models.MemberActivity, ok bool) {
+	split := strings.Split(strings.TrimPrefix(key, keyPrefix), ":")
+	if len(split) != 2 {
+		return
+	}
+	a.GuildID = split[0]
+	a.UserID = split[1]
+	return a, true
+}
@@ -0,0 +1,176 @@
+package inactivity
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/services/database/memory"
+	"github.com/zekroTJA/shinpuru/internal/services/kvcache"
+	"github.com/zekroTJA/shinpuru/mocks"
+)
+
+type fixedTime time.Time
+
+func (t fixedTime) Now() time.Time {
+	return time.Time(t)
+}
+
+func TestCheck(t *testing.T) {
+	since := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	set := models.InactivitySettings{
+		Enabled:  true,
+		RoleID:   "role",
+		Days:     30,
+		WarnDays: 3,
+		Since:    since,
+	}
+	at := func(days int) time.Time {
+		return since.Add(time.Duration(days) * day)
+	}
+	warned := func(days int) *time.Time {
+		t := at(days)
+		return &t
+	}
+
+	// Members without recorded activity are inactive
+	// since the removal has been enabled.
+	assert.Equal(t, actionNone, check(set, models.MemberActivity{}, time.Time{}, at(26)))
+	assert.Equal(t, actionWarn, check(set, models.MemberActivity{}, time.Time{}, at(27)))
+
+	// Members which joined later are inactive since
+	// they joined.
+	assert.Equal(t, actionNone, check(set, models.MemberActivity{}, at(10), at(27)))
+
+	a := models.MemberActivity{LastActive: at(5)}
+	assert.Equal(t, actionNone, check(set, a, time.Time{}, at(31)))
+	assert.Equal(t, actionWarn, check(set, a, time.Time{}, at(32)))
+
+	a.Warned = warned(32)
+	assert.Equal(t, actionNone, check(set, a, time.Time{}, at(34)))
+	assert.Equal(t, actionRemove, check(set, a, time.Time{}, at(35)))
+
+	// The role is not removed earlier than WarnDays
+	// after the warning.
+	a.Warned = warned(40)
+	assert.Equal(t, actionNone, check(set, a, time.Time{}, at(42)))
+	assert.Equal(t, actionRemove, check(set, a, time.Time{}, at(43)))
+
+	// Renewed activity resets the timer and the warning.
+	a.LastActive = at(41)
+	assert.Equal(t, actionNone, check(set, a, time.Time{}, at(43)))
+	assert.Equal(t, actionWarn, check(set, a, time.Time{}, at(68)))
+
+	// Warnings sent before the removal has been enabled
+	// again are not taken into account.
+	set.Since = at(50)
+	a = models.MemberActivity{LastActive: at(5), Warned: warned(32)}
+	assert.Equal(t, actionWarn, check(set, a, time.Time{}, at(77)))
+}
+
+func TestRemovalTime(t *testing.T) {
+	set := models.InactivitySettings{Days: 30, WarnDays: 3}
+	last := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, last.Add(30*day), removalTime(set, last, last.Add(27*day)))
+	assert.Equal(t, last.Add(43*day), removalTime(set, last, last.Add(40*day)))
+}
+
+func TestTrackFlush(t *testing.T) {
+	db := memory.New()
+	kvc := kvcache.NewTimedmapCache(time.Minute)
+	now := time.Date(2022, 1, 1, 13, 30, 0, 0, time.UTC)
+
+	warned := now.Add(-time.Hour)
+	require.Nil(t, db.SetMemberActivities([]models.MemberActivity{
+		{GuildID: "g", UserID: "u1", LastActive: now.Add(-48 * time.Hour), Warned: &warned},
+	}))
+
+	Track(kvc, fixedTime(now.Add(-time.Minute)), "g", "u1")
+	Track(kvc, fixedTime(now), "g", "u1")
+	Track(kvc, fixedTime(now), "g", "u2")
+	Flush(db, kvc)()
+
+	assert.Empty(t, kvc.Keys(keyPrefix))
+
+	activities, err := db.GetMemberActivities("g")
+	require.Nil(t, err)
+	assert.ElementsMatch(t, []models.MemberActivity{
+		{GuildID: "g", UserID: "u1", LastActive: now},
+		{GuildID: "g", UserID: "u2", LastActive: now},
+	}, activities)
+
+	// Flushing without recorded activities does not
+	// change anything.
+	Flush(db, kvc)()
+	activities, err = db.GetMemberActivities("g")
+	require.Nil(t, err)
+	assert.Len(t, activities, 2)
+}
+
+func TestReset(t *testing.T) {
+	db := memory.New()
+	kvc := kvcache.NewTimedmapCache(time.Minute)
+	now := time.Date(2022, 1, 1, 13, 30, 0, 0, time.UTC)
+
+	require.Nil(t, db.SetMemberActivities([]models.MemberActivity{
+		{GuildID: "g1", UserID: "u1", LastActive: now},
+		{GuildID: "g2", UserID: "u1", LastActive: now},
+	}))
+	Track(kvc, fixedTime(now), "g1", "u2")
+	Track(kvc, fixedTime(now), "g2", "u2")
+
+	require.Nil(t, Reset(db, kvc, "g1"))
+
+	assert.Equal(t, []string{makeKey("g2", "u2")}, kvc.Keys(keyPrefix))
+
+	activities, err := db.GetMemberActivities("g1")
+	require.Nil(t, err)
+	assert.Empty(t, activities)
+
+	activities, err = db.GetMemberActivities("g2")
+	require.Nil(t, err)
+	assert.Len(t, activities, 1)
+}
+
+func TestProcessGuildMaxWarnings(t *testing.T) {
+	db := memory.New()
+	st := &mocks.IState{}
+	s := &mocks.ISession{}
+	now := time.Date(2022, 1, 1, 13, 30, 0, 0, time.UTC)
+
+	set := models.InactivitySettings{
+		GuildID:  "g",
+		Enabled:  true,
+		RoleID:   "role",
+		Days:     30,
+		WarnDays: 3,
+		Since:    now.Add(-40 * day),
+	}
+
+	members := make([]*discordgo.Member, MaxWarningsPerRun+10)
+	for i := range members {
+		members[i] = &discordgo.Member{
+			User:  &discordgo.User{ID: fmt.Sprintf("u%d", i)},
+			Roles: []string{"role"},
+		}
+	}
+
+	st.On("Guild", "g").Return(&discordgo.Guild{ID: "g"}, nil)
+	st.On("Members", "g").Return(members, nil)
+	st.On("VoiceStates", "g").Return([]*discordgo.VoiceState{}, nil)
+	s.On("UserChannelCreate", mock.Anything).Return(nil, errors.New("cannot send messages to this user"))
+
+	require.Nil(t, processGuild(db, s, st, nil, now, set))
+
+	activities, err := db.GetMemberActivities("g")
+	require.Nil(t, err)
+	assert.Len(t, activities, MaxWarningsPerRun)
+	s.AssertNumberOfCalls(t, "UserChannelCreate", MaxWarningsPerRun)
+}
@@ -0,0 +1,165 @@
+// Package intents declares the Discord gateway intents
+// the bot requires for each of its features and computes
+// the intents requested on connect.
+package intents
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Base contains the intents which are always requested
+// because the bot can not work without them.
+const Base = discordgo.IntentsGuilds
+
+// Privileged contains all intents which must be enabled
+// for the bot application in the Discord developer portal
+// before they can be requested.
+const Privileged = discordgo.IntentsGuildMembers |
+	discordgo.IntentsGuildPresences |
+	discordgo.IntentsMessageContent
+
+// Feature describes a bot feature and the gateway intents
+// it requires to work.
+type Feature struct {
+	// Name is the key of the feature used in the config.
+	Name string
+	// Reason describes what the intents are used for.
+	Reason string
+	// Intents contains the intents required by the feature.
+	Intents discordgo.Intent
+	// Default is the enabled state of the feature when
+	// it is not configured.
+	Default bool
+}
+
+// Features contains all features which can be toggled in
+// the config with their required intents.
+var Features = []Feature{
+	{
+		Name:    "members",
+		Reason:  "auto roles, join and leave messages, verification, anti raid and member caching",
+		Intents: discordgo.IntentsGuildMembers,
+		Default: true,
+	},
+	{
+		Name:    "messages",
+		Reason:  "message based features like invite blocking, ghost ping detection and code execution",
+		Intents: discordgo.IntentsGuildMessages,
+		Default: true,
+	},
+	{
+		Name:    "reactions",
+		Reason:  "karma, starboard, color reactions and votes",
+		Intents: discordgo.IntentsGuildMessageReactions,
+		Default: true,
+	},
+	{
+		Name:    "directmessages",
+		Reason:  "mod mail and DM sync",
+		Intents: discordgo.IntentsDirectMessages,
+		Default: true,
+	},
+	{
+		Name:    "voice",
+		Reason:  "voice log and auto voice channels",
+		Intents: discordgo.IntentsGuildVoiceStates,
+		Default: true,
+	},
+	{
+		Name:    "bans",
+		Reason:  "tracking of bans not executed via the bot",
+		Intents: discordgo.IntentsGuildBans,
+		Default: true,
+	},
+	{
+		Name:    "invites",
+		Reason:  "tracking of guild invites",
+		Intents: discordgo.IntentsGuildInvites,
+		Default: true,
+	},
+	{
+		Name:    "emojis",
+		Reason:  "emoji caching",
+		Intents: discordgo.IntentsGuildEmojis,
+		Default: true,
+	},
+	{
+		Name:    "integrations",
+		Reason:  "integration updates",
+		Intents: discordgo.IntentsGuildIntegrations,
+		Default: true,
+	},
+	{
+		Name:    "presences",
+		Reason:  "presence based features",
+		Intents: discordgo.IntentsGuildPresences,
+		Default: false,
+	},
+}
+
+var intentNames = map[discordgo.Intent]string{
+	discordgo.IntentsGuilds:                 "Guilds",
+	discordgo.IntentsGuildMembers:           "Guild Members",
+	discordgo.IntentsGuildBans:              "Guild Bans",
+	discordgo.IntentsGuildEmojis:            "Guild Emojis",
+	discordgo.IntentsGuildIntegrations:      "Guild Integrations",
+	discordgo.IntentsGuildWebhooks:          "Guild Webhooks",
+	discordgo.IntentsGuildInvites:           "Guild Invites",
+	discordgo.IntentsGuildVoiceStates:       "Guild Voice States",
+	discordgo.IntentsGuildPresences:         "Guild Presences",
+	discordgo.IntentsGuildMessages:          "Guild Messages",
+	discordgo.IntentsGuildMessageReactions:  "Guild Message Reactions",
+	discordgo.IntentsGuildMessageTyping:     "Guild Message Typing",
+	discordgo.IntentsDirectMessages:         "Direct Messages",
+	discordgo.IntentsDirectMessageReactions: "Direct Message Reactions",
+	discordgo.IntentsDirectMessageTyping:    "Direct Message Typing",
+	discordgo.IntentsMessageContent:         "Message Content",
+	discordgo.IntentsGuildScheduledEvents:   "Guild Scheduled Events",
+}
+
+// Compute returns the intents to request and the enabled
+// features based on the given feature states. Features
+// not contained in states use their default state.
+//
+// An error is returned if states contains an unknown
+// feature name.
+func Compute(states map[string]bool) (intents discordgo.Intent, enabled []Feature, err error) {
+	known := make(map[string]bool, len(Features))
+	for _, f := range Features {
+		known[f.Name] = true
+	}
+	for name := range states {
+		if !known[name] {
+			return 0, nil, fmt.Errorf("unknown intent feature '%s'", name)
+		}
+	}
+
+	intents = Base
+	for _, f := range Features {
+		on, ok := states[f.Name]
+		if !ok {
+			on = f.Default
+		}
+		if on {
+			intents |= f.Intents
+			enabled = append(enabled, f)
+		}
+	}
+
+	return intents, enabled, nil
+}
+
+// IntentNames returns the sorted display names of all
+// intents set in intents.
+func IntentNames(intents discordgo.Intent) (names []string) {
+	for i, name := range intentNames {
+		if intents&i != 0 {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return
+}
@@ -0,0 +1,36 @@
+package intents
+
+import (
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompute(t *testing.T) {
+	intents, enabled, err := Compute(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, len(Features)-1, len(enabled))
+	assert.NotZero(t, intents&Base)
+	assert.NotZero(t, intents&discordgo.IntentsGuildMembers)
+	assert.Zero(t, intents&discordgo.IntentsGuildPresences)
+
+	intents, _, err = Compute(map[string]bool{
+		"members":   false,
+		"presences": true,
+	})
+	assert.Nil(t, err)
+	assert.Zero(t, intents&discordgo.IntentsGuildMembers)
+	assert.NotZero(t, intents&discordgo.IntentsGuildPresences)
+	assert.NotZero(t, intents&Base)
+
+	_, _, err = Compute(map[string]bool{"unknown": true})
+	assert.NotNil(t, err)
+}
+
+func TestIntentNames(t *testing.T) {
+	assert.Nil(t, IntentNames(0))
+	assert.Equal(t,
+		[]string{"Guild Members", "Guilds"},
+		IntentNames(discordgo.IntentsGuilds|discordgo.IntentsGuildMembers))
+}
@@ -0,0 +1,98 @@
+package membermsg
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/zekroTJA/shinpuru/internal/services/kvcache"
+)
+
+const (
+	// MaxGracePeriod is the maximum grace period which
+	// can be configured for a guild.
+	MaxGracePeriod = time.Hour
+
+	// banLifetime is the time a ban is remembered to
+	// identify the member remove event caused by it.
+	banLifetime = 30 * time.Second
+)
+
+// Debouncer tracks recent joins, leaves and bans of
+// members in a kvcache.Provider to suppress join and
+// leave messages of members which leave or rejoin
+// within the grace period of a guild.
+//
+// All entries expire after the grace period, so no
+// state is kept for members which do not return.
+type Debouncer struct {
+	kv kvcache.Provider
+}
+
+// NewDebouncer returns a new Debouncer using the
+// given kvcache.Provider.
+func NewDebouncer(kv kvcache.Provider) *Debouncer {
+	return &Debouncer{kv: kv}
+}
+
+// Join records the join of the given member and
+// returns true if the member has left the guild
+// within the given grace period before.
+//
+// When period is 0, nothing is recorded.
+func (d *Debouncer) Join(guildID, userID string, period time.Duration) (rejoined bool) {
+	if period <= 0 {
+		return false
+	}
+
+	leaveKey := key("leave", guildID, userID)
+	rejoined = d.kv.Get(leaveKey) != nil
+	d.kv.Del(leaveKey)
+	d.kv.Set(key("join", guildID, userID), true, period)
+
+	return rejoined
+}
+
+// Leave records the leave of the given member and
+// returns true if a leave message should be sent.
+//
+// No message should be sent when the member has been
+// banned right before or when the member has joined
+// within the given grace period. When period is 0,
+// only bans are taken into account.
+func (d *Debouncer) Leave(guildID, userID string, period time.Duration) (send bool) {
+	banKey := key("ban", guildID, userID)
+	if d.kv.Get(banKey) != nil {
+		d.kv.Del(banKey)
+		d.kv.Del(key("join", guildID, userID))
+		return false
+	}
+
+	if period <= 0 {
+		return true
+	}
+
+	joinKey := key("join", guildID, userID)
+	joined := d.kv.Get(joinKey) != nil
+	d.kv.Del(joinKey)
+	d.kv.Set(key("leave", guildID, userID), true, period)
+
+	return !joined
+}
+
+// Ban records the ban of the given member so that
+// the following leave is not treated as a regular
+// leave of the member.
+func (d *Debouncer) Ban(guildID, userID string) {
+	d.kv.Set(key("ban", guildID, userID), true, banLifetime)
+}
+
+// Banned returns true if a ban of the given member has
+// been recorded recently. This is used to detect bans
+// which are received after the member remove event.
+func (d *Debouncer) Banned(guildID, userID string) bool {
+	return d.kv.Get(key("ban", guildID, userID)) != nil
+}
+
+func key(event, guildID, userID string) string {
+	return fmt.Sprintf("membermsg:%s:%s:%s", event, guildID, userID)
+}
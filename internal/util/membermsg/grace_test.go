@@ -0,0 +1,48 @@
+package membermsg
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zekroTJA/shinpuru/internal/services/kvcache"
+)
+
+func TestDebouncer(t *testing.T) {
+	d := NewDebouncer(kvcache.NewTimedmapCache(time.Minute))
+	const period = time.Minute
+
+	// Without grace period, all events are passed.
+	assert.False(t, d.Join("guild", "disabled", 0))
+	assert.True(t, d.Leave("guild", "disabled", 0))
+	assert.False(t, d.Join("guild", "disabled", 0))
+
+	// A leave right after a join is suppressed and
+	// the following join is flagged as rejoin.
+	assert.False(t, d.Join("guild", "user", period))
+	assert.False(t, d.Leave("guild", "user", period))
+	assert.True(t, d.Join("guild", "user", period))
+
+	// Events are tracked per guild.
+	assert.True(t, d.Leave("other", "user", period))
+	assert.False(t, d.Join("guild", "other", period))
+
+	// Bans are never reported as leave, also when no
+	// grace period is set.
+	d.Ban("guild", "banned")
+	assert.True(t, d.Banned("guild", "banned"))
+	assert.False(t, d.Banned("guild", "user"))
+	assert.False(t, d.Leave("guild", "banned", 0))
+	assert.False(t, d.Join("guild", "banned", period))
+}
+
+func TestDebouncerExpiry(t *testing.T) {
+	d := NewDebouncer(kvcache.NewTimedmapCache(10 * time.Millisecond))
+	const period = 50 * time.Millisecond
+
+	d.Join("guild", "user", period)
+	time.Sleep(2 * period)
+	assert.True(t, d.Leave("guild", "user", period))
+	time.Sleep(2 * period)
+	assert.False(t, d.Join("guild", "user", period))
+}
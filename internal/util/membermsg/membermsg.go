@@ -0,0 +1,73 @@
+// Package membermsg renders the join and leave
+// messages of guilds.
+package membermsg
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	// PlaceholderUser is replaced with the name of the user.
+	PlaceholderUser = "[user]"
+	// PlaceholderMention is replaced with the mention of the user.
+	PlaceholderMention = "[ment]"
+
+	// escape is put in front of brackets which should
+	// not be treated as a placeholder.
+	escape = `\`
+)
+
+var placeholderRx = regexp.MustCompile(`\[[a-z]+\]`)
+
+// PlaceholderError is returned when a template contains
+// a placeholder which is not supported.
+type PlaceholderError struct {
+	Placeholder string
+}
+
+func (e PlaceholderError) Error() string {
+	return fmt.Sprintf("unknown placeholder '%s' (supported are %s and %s; "+
+		"put a backslash in front of it to use it as text)",
+		e.Placeholder, PlaceholderUser, PlaceholderMention)
+}
+
+// Validate returns a PlaceholderError if the given template
+// contains an unsupported placeholder. Brackets followed by
+// a parenthesis are not treated as placeholders because
+// they are used for markdown links. Brackets escaped with
+// a backslash are not treated as placeholders either.
+func Validate(tmpl string) error {
+	for _, loc := range placeholderRx.FindAllStringIndex(tmpl, -1) {
+		if loc[1] < len(tmpl) && tmpl[loc[1]] == '(' {
+			continue
+		}
+		if strings.HasSuffix(tmpl[:loc[0]], escape) {
+			continue
+		}
+		if p := tmpl[loc[0]:loc[1]]; p != PlaceholderUser && p != PlaceholderMention {
+			return PlaceholderError{Placeholder: p}
+		}
+	}
+	return nil
+}
+
+// Render replaces the placeholders in the given template
+// with the data of the given user. Escaped brackets are
+// rendered without the backslash.
+func Render(tmpl string, user *discordgo.User) string {
+	return strings.NewReplacer(
+		escape+"[", "[",
+		PlaceholderUser, user.Username,
+		PlaceholderMention, user.Mention(),
+	).Replace(tmpl)
+}
+
+// Mentions returns true if the given template mentions
+// the user.
+func Mentions(tmpl string) bool {
+	return strings.Contains(strings.ReplaceAll(tmpl, escape+"[", ""), PlaceholderMention)
+}
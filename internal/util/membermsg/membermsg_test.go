@@ -0,0 +1,35 @@
+package membermsg
+
+import (
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	assert.Nil(t, Validate(""))
+	assert.Nil(t, Validate("Welcome [ment], also known as [user]!"))
+	assert.Nil(t, Validate("Read the [rules](https://example.com)."))
+	assert.Nil(t, Validate("[Rules] and [1]"))
+	assert.Nil(t, Validate(`Read the \[rules] and \[faq]`))
+
+	err := Validate("Welcome [usr]!")
+	assert.Equal(t, PlaceholderError{Placeholder: "[usr]"}, err)
+}
+
+func TestRender(t *testing.T) {
+	user := &discordgo.User{ID: "123", Username: "zekro"}
+
+	assert.Equal(t,
+		"Welcome <@123>, also known as zekro!",
+		Render("Welcome [ment], also known as [user]!", user))
+	assert.Equal(t, "[unknown]", Render("[unknown]", user))
+	assert.Equal(t, "[rules] and [user]", Render(`\[rules] and \[user]`, user))
+}
+
+func TestMentions(t *testing.T) {
+	assert.True(t, Mentions("Hey [ment]"))
+	assert.False(t, Mentions("Hey [user]"))
+	assert.False(t, Mentions(`Hey \[ment]`))
+}
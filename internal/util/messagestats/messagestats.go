@@ -0,0 +1,205 @@
+// Package messagestats provides utilities to count the
+// messages sent per channel and user in guilds which have
+// opted in to message statistics.
+//
+// Messages are counted in the cache first and flushed to
+// the database periodically in hourly buckets. Message
+// contents are never stored.
+package messagestats
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/services/kvcache"
+	"github.com/zekroTJA/shinpuru/internal/services/timeprovider"
+	"github.com/zekrotja/rogu/log"
+)
+
+const (
+	// Retention is the duration after which stored
+	// message statistics are removed.
+	Retention = 90 * 24 * time.Hour
+
+	// MaxPeriodDays is the maximum number of days
+	// statistics can be requested for.
+	MaxPeriodDays = int(Retention / (24 * time.Hour))
+
+	// DefaultPeriodDays is the number of days statistics
+	// are requested for when no period is specified.
+	DefaultPeriodDays = 7
+
+	// TopPosters is the number of users listed in the
+	// top posters of the aggregated statistics.
+	TopPosters = 10
+
+	keyPrefix = "msgstats:"
+
+	// counterLifetime is the lifetime of counters in
+	// the cache. It must be longer than the flush
+	// interval so that no counts are lost.
+	counterLifetime = 2 * time.Hour
+)
+
+var tl = log.Tagged("MessageStats")
+
+// Track increments the message counter of the given user
+// in the given channel for the current hour.
+func Track(kvc kvcache.Provider, tp timeprovider.Provider, guildID, channelID, userID string) {
+	hour := tp.Now().UTC().Truncate(time.Hour)
+	kvc.Incr(makeKey(guildID, channelID, userID, hour), 1, counterLifetime)
+}
+
+// Flush returns a job which writes all counters in the
+// cache to the database. Counts which could not be
+// written are kept in the cache for the next flush.
+func Flush(db database.Database, kvc kvcache.Provider) func() {
+	return func() {
+		keys := kvc.Keys(keyPrefix)
+		if len(keys) == 0 {
+			return
+		}
+
+		entries := make([]models.MessageStatsEntry, 0, len(keys))
+		for _, key := range keys {
+			e, ok := parseKey(key)
+			if !ok {
+				kvc.Del(key)
+				continue
+			}
+			n := kvc.Incr(key, 0, counterLifetime)
+			if n <= 0 {
+				kvc.Del(key)
+				continue
+			}
+			if kvc.Incr(key, -n, counterLifetime) == 0 {
+				kvc.Del(key)
+			}
+			e.Count = int(n)
+			entries = append(entries, e)
+		}
+
+		if len(entries) == 0 {
+			return
+		}
+
+		if err := db.AddMessageStats(entries); err != nil {
+			tl.Error().Err(err).Field("n", len(entries)).Msg("Failed flushing message stats")
+			for _, e := range entries {
+				kvc.Incr(makeKey(e.GuildID, e.ChannelID, e.UserID, e.Hour), int64(e.Count), counterLifetime)
+			}
+		}
+	}
+}
+
+// Disable disables message statistics for the given
+// guild and removes all tracked statistics, including
+// the counters which have not been flushed yet.
+func Disable(db database.Database, kvc kvcache.Provider, guildID string) (err error) {
+	if err = db.SetGuildMessageStatsEnabled(guildID, false); err != nil {
+		return
+	}
+
+	kvc.FlushPrefix(keyPrefix + guildID + ":")
+
+	if err = db.DeleteMessageStats(guildID); database.IsErrDatabaseNotFound(err) {
+		err = nil
+	}
+	return
+}
+
+// Cleanup returns a job which removes all stored message
+// statistics older than Retention.
+func Cleanup(db database.Database, tp timeprovider.Provider) func() {
+	return func() {
+		n, err := db.CleanupMessageStats(tp.Now().Add(-Retention))
+		if err != nil {
+			tl.Error().Err(err).Msg("Failed cleaning up message stats")
+		} else if n > 0 {
+			tl.Info().Field("n", n).Msg("Cleaned up message stats")
+		}
+	}
+}
+
+// Get returns the aggregated message statistics of the
+// given guild of the last days.
+func Get(db database.Database, tp timeprovider.Provider, guildID string, days int) (stats models.MessageStats, err error) {
+	if days < 1 || days > MaxPeriodDays {
+		err = fmt.Errorf("period must be between 1 and %d days", MaxPeriodDays)
+		return
+	}
+
+	since := tp.Now().UTC().Truncate(time.Hour).Add(-time.Duration(days) * 24 * time.Hour)
+	entries, err := db.GetMessageStats(guildID, since)
+	if err != nil {
+		return
+	}
+
+	stats = Aggregate(entries, TopPosters)
+	stats.Since = since
+	return
+}
+
+// Aggregate sums up the given entries per channel, per
+// user and per hour of the day. Channels are sorted by
+// their message count descending and only the topN users
+// with the most messages are listed.
+func Aggregate(entries []models.MessageStatsEntry, topN int) (stats models.MessageStats) {
+	channels := make(map[string]int)
+	users := make(map[string]int)
+
+	for _, e := range entries {
+		stats.Total += e.Count
+		channels[e.ChannelID] += e.Count
+		users[e.UserID] += e.Count
+		stats.Hours[e.Hour.UTC().Hour()] += e.Count
+	}
+
+	stats.Channels = make([]models.MessageStatsChannel, 0, len(channels))
+	for id, n := range channels {
+		stats.Channels = append(stats.Channels, models.MessageStatsChannel{ChannelID: id, Count: n})
+	}
+	sort.Slice(stats.Channels, func(i, j int) bool {
+		a, b := stats.Channels[i], stats.Channels[j]
+		return a.Count > b.Count || a.Count == b.Count && a.ChannelID < b.ChannelID
+	})
+
+	stats.TopPosters = make([]models.MessageStatsUser, 0, len(users))
+	for id, n := range users {
+		stats.TopPosters = append(stats.TopPosters, models.MessageStatsUser{UserID: id, Count: n})
+	}
+	sort.Slice(stats.TopPosters, func(i, j int) bool {
+		a, b := stats.TopPosters[i], stats.TopPosters[j]
+		return a.Count > b.Count || a.Count == b.Count && a.UserID < b.UserID
+	})
+	if len(stats.TopPosters) > topN {
+		stats.TopPosters = stats.TopPosters[:topN]
+	}
+
+	return
+}
+
+func makeKey(guildID, channelID, userID string, hour time.Time) string {
+	return fmt.Sprintf("%s%s:%s:%s:%d", keyPrefix, guildID, channelID, userID, hour.Unix())
+}
+
+func parseKey(key string) (e models.MessageStatsEntry, ok bool) {
+	split := strings.Split(strings.TrimPrefix(key, keyPrefix), ":")
+	if len(split) != 4 {
+		return
+	}
+	hour, err := strconv.ParseInt(split[3], 10, 64)
+	if err != nil {
+		return
+	}
+	e.GuildID = split[0]
+	e.ChannelID = split[1]
+	e.UserID = split[2]
+	e.Hour = time.Unix(hour, 0).UTC()
+	return e, true
+}
@@ -0,0 +1,97 @@
+package messagestats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/services/database/memory"
+	"github.com/zekroTJA/shinpuru/internal/services/kvcache"
+)
+
+type fixedTime time.Time
+
+func (t fixedTime) Now() time.Time {
+	return time.Time(t)
+}
+
+func TestAggregate(t *testing.T) {
+	hour := time.Date(2022, 1, 1, 13, 0, 0, 0, time.UTC)
+	entries := []models.MessageStatsEntry{
+		{ChannelID: "c1", UserID: "u1", Hour: hour, Count: 3},
+		{ChannelID: "c1", UserID: "u2", Hour: hour, Count: 1},
+		{ChannelID: "c2", UserID: "u2", Hour: hour.Add(time.Hour), Count: 5},
+		{ChannelID: "c3", UserID: "u3", Hour: hour.Add(25 * time.Hour), Count: 1},
+	}
+
+	stats := Aggregate(entries, 2)
+
+	assert.Equal(t, 10, stats.Total)
+	assert.Equal(t, []models.MessageStatsChannel{
+		{ChannelID: "c2", Count: 5},
+		{ChannelID: "c1", Count: 4},
+		{ChannelID: "c3", Count: 1},
+	}, stats.Channels)
+	assert.Equal(t, []models.MessageStatsUser{
+		{UserID: "u2", Count: 6},
+		{UserID: "u1", Count: 3},
+	}, stats.TopPosters)
+	assert.Equal(t, 4, stats.Hours[13])
+	assert.Equal(t, 6, stats.Hours[14])
+}
+
+func TestTrackFlush(t *testing.T) {
+	db := memory.New()
+	kvc := kvcache.NewTimedmapCache(time.Minute)
+	now := time.Date(2022, 1, 1, 13, 30, 0, 0, time.UTC)
+	tp := fixedTime(now)
+
+	Track(kvc, tp, "g", "c1", "u1")
+	Track(kvc, tp, "g", "c1", "u1")
+	Track(kvc, tp, "g", "c2", "u2")
+	Flush(db, kvc)()
+
+	assert.Empty(t, kvc.Keys(keyPrefix))
+
+	Track(kvc, tp, "g", "c1", "u1")
+	Flush(db, kvc)()
+
+	stats, err := Get(db, tp, "g", 1)
+	require.NoError(t, err)
+	assert.Equal(t, 4, stats.Total)
+	assert.Equal(t, []models.MessageStatsChannel{
+		{ChannelID: "c1", Count: 3},
+		{ChannelID: "c2", Count: 1},
+	}, stats.Channels)
+	assert.Equal(t, 4, stats.Hours[13])
+
+	_, err = Get(db, tp, "g", MaxPeriodDays+1)
+	assert.Error(t, err)
+}
+
+func TestDisable(t *testing.T) {
+	db := memory.New()
+	kvc := kvcache.NewTimedmapCache(time.Minute)
+	now := time.Date(2022, 1, 1, 13, 30, 0, 0, time.UTC)
+	tp := fixedTime(now)
+
+	require.Nil(t, db.SetGuildMessageStatsEnabled("g", true))
+	Track(kvc, tp, "g", "c1", "u1")
+	Flush(db, kvc)()
+	Track(kvc, tp, "g", "c1", "u1")
+	Track(kvc, tp, "g2", "c1", "u1")
+
+	require.Nil(t, Disable(db, kvc, "g"))
+
+	enabled, err := db.GetGuildMessageStatsEnabled("g")
+	require.Nil(t, err)
+	assert.False(t, enabled)
+	assert.Len(t, kvc.Keys(keyPrefix), 1)
+
+	Flush(db, kvc)()
+	entries, err := db.GetMessageStats("g", now.Add(-time.Hour))
+	require.Nil(t, err)
+	assert.Empty(t, entries)
+}
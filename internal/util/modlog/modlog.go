@@ -0,0 +1,82 @@
+// Package modlog provides utilities to resolve the mod
+// log channel of a guild and to post entries in it.
+package modlog
+
+import (
+	"github.com/bwmarrin/discordgo"
+	"github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/services/msgsender"
+	"github.com/zekroTJA/shinpuru/pkg/discordutil"
+)
+
+// Channel returns the ID of the channel entries of the
+// given action are posted in. If no channel is routed
+// for the action, the default mod log channel of the
+// guild is returned. If neither is set, an empty
+// string is returned.
+func Channel(db database.Database, guildID string, action models.ModLogAction) (string, error) {
+	chanID, err := db.GetGuildModLogRoute(guildID, action)
+	if err == nil && chanID != "" {
+		return chanID, nil
+	}
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return "", err
+	}
+
+	chanID, err = db.GetGuildModLog(guildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return "", err
+	}
+
+	return chanID, nil
+}
+
+// RemoveChannelRoutes removes all routes of the guild
+// pointing to the given channel so that the entries of
+// these actions fall back to the default mod log
+// channel again. This should be called when a channel
+// is deleted.
+func RemoveChannelRoutes(db database.Database, guildID, channelID string) error {
+	routes, err := db.GetGuildModLogRoutes(guildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return err
+	}
+
+	for action, chanID := range routes {
+		if chanID != channelID {
+			continue
+		}
+		if err = db.RemoveGuildModLogRoute(guildID, action); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Send enqueues the embed to be posted in the mod log
+// channel of the given action. Nothing is sent if no
+// mod log channel is set.
+//
+// The returned error only reports failures resolving
+// the channel or enqueueing the message. Failed sends
+// are logged by the sender.
+func Send(
+	db database.Database,
+	sender *msgsender.Sender,
+	s discordutil.ISession,
+	guildID string,
+	action models.ModLogAction,
+	embed *discordgo.MessageEmbed,
+) error {
+	chanID, err := Channel(db, guildID, action)
+	if err != nil {
+		return err
+	}
+	if chanID == "" {
+		return nil
+	}
+
+	return sender.EnqueueEmbed(s, guildID, chanID, embed, nil)
+}
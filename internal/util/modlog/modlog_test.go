@@ -0,0 +1,104 @@
+package modlog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/sarulabs/di/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/services/database/memory"
+	"github.com/zekroTJA/shinpuru/internal/services/msgsender"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/mocks"
+)
+
+func TestChannel(t *testing.T) {
+	db := memory.New()
+
+	chanID, err := Channel(db, "guild", models.ModLogActionBan)
+	assert.Nil(t, err)
+	assert.Equal(t, "", chanID)
+
+	db.SetGuildModLog("guild", "default")
+	db.SetGuildModLogRoute("guild", models.ModLogActionBan, "bans")
+
+	chanID, err = Channel(db, "guild", models.ModLogActionBan)
+	assert.Nil(t, err)
+	assert.Equal(t, "bans", chanID)
+
+	chanID, err = Channel(db, "guild", models.ModLogActionWarn)
+	assert.Nil(t, err)
+	assert.Equal(t, "default", chanID)
+
+	db.RemoveGuildModLogRoute("guild", models.ModLogActionBan)
+	chanID, err = Channel(db, "guild", models.ModLogActionBan)
+	assert.Nil(t, err)
+	assert.Equal(t, "default", chanID)
+}
+
+func TestRemoveChannelRoutes(t *testing.T) {
+	db := memory.New()
+
+	db.SetGuildModLog("guild", "default")
+	db.SetGuildModLogRoute("guild", models.ModLogActionBan, "deleted")
+	db.SetGuildModLogRoute("guild", models.ModLogActionKick, "deleted")
+	db.SetGuildModLogRoute("guild", models.ModLogActionWarn, "warns")
+
+	assert.Nil(t, RemoveChannelRoutes(db, "guild", "deleted"))
+
+	chanID, err := Channel(db, "guild", models.ModLogActionBan)
+	assert.Nil(t, err)
+	assert.Equal(t, "default", chanID)
+
+	chanID, err = Channel(db, "guild", models.ModLogActionKick)
+	assert.Nil(t, err)
+	assert.Equal(t, "default", chanID)
+
+	chanID, err = Channel(db, "guild", models.ModLogActionWarn)
+	assert.Nil(t, err)
+	assert.Equal(t, "warns", chanID)
+}
+
+func getSender() *msgsender.Sender {
+	cfg := &mocks.ConfigProvider{}
+	cfg.On("Config").Return(&models.Config{})
+	tp := &mocks.TimeProvider{}
+	tp.On("Now").Return(time.Time{})
+
+	ct, _ := di.NewBuilder()
+	ct.Add(
+		di.Def{
+			Name:  static.DiConfig,
+			Build: func(ctn di.Container) (interface{}, error) { return cfg, nil },
+		},
+		di.Def{
+			Name:  static.DiTimeProvider,
+			Build: func(ctn di.Container) (interface{}, error) { return tp, nil },
+		},
+	)
+
+	return msgsender.New(ct.Build())
+}
+
+func TestSend(t *testing.T) {
+	db := memory.New()
+	sender := getSender()
+	sent := make(chan *discordgo.MessageSend, 1)
+	s := &mocks.ISession{}
+	s.On("ChannelMessageSendComplex", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { sent <- args.Get(1).(*discordgo.MessageSend) }).
+		Return(&discordgo.Message{}, nil)
+
+	emb := &discordgo.MessageEmbed{}
+
+	assert.Nil(t, Send(db, sender, s, "guild", models.ModLogActionPin, emb))
+	s.AssertNotCalled(t, "ChannelMessageSendComplex", mock.Anything, mock.Anything)
+
+	db.SetGuildModLogRoute("guild", models.ModLogActionPin, "pins")
+	assert.Nil(t, Send(db, sender, s, "guild", models.ModLogActionPin, emb))
+	assert.Equal(t, emb, (<-sent).Embed)
+	s.AssertCalled(t, "ChannelMessageSendComplex", "pins", mock.Anything)
+}
@@ -0,0 +1,148 @@
+// Package modmail provides utilities to relay
+// direct messages of users into threads of the
+// modmail channel of a guild and vice versa.
+package modmail
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/pkg/discordutil"
+)
+
+// threadArchiveDuration is the auto archive duration
+// of modmail threads in minutes (7 days).
+const threadArchiveDuration = 10080
+
+var ErrNotEnabled = errors.New("modmail is not enabled on this guild")
+
+// Open creates a new thread for the given user in the
+// modmail channel of the given guild and stores the
+// mapping of the user to the thread.
+func Open(
+	db database.Database,
+	s *discordgo.Session,
+	guildID string,
+	user *discordgo.User,
+) (t models.ModmailThread, err error) {
+	chanID, err := db.GetGuildModmailChannel(guildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return
+	}
+	if chanID == "" {
+		err = ErrNotEnabled
+		return
+	}
+
+	msg, err := s.ChannelMessageSendEmbed(chanID, &discordgo.MessageEmbed{
+		Color: static.ColorEmbedCyan,
+		Title: "Modmail",
+		Description: fmt.Sprintf(
+			"%s (%s) has opened a modmail conversation.\n\n"+
+				"Messages in the thread are relayed to the user. Use `/modmail close` "+
+				"in the thread to close the conversation.",
+			user.Mention(), user.String()),
+	})
+	if err != nil {
+		return
+	}
+
+	thread, err := s.MessageThreadStart(chanID, msg.ID,
+		fmt.Sprintf("modmail-%s", user.Username), threadArchiveDuration)
+	if err != nil {
+		return
+	}
+
+	t = models.ModmailThread{
+		GuildID:  guildID,
+		UserID:   user.ID,
+		ThreadID: thread.ID,
+	}
+	err = db.SetModmailThread(t)
+
+	return
+}
+
+// Close notifies the user that the conversation has
+// been closed, archives and locks the thread and
+// removes the mapping of the user to the thread.
+func Close(
+	db database.Database,
+	s *discordgo.Session,
+	t models.ModmailThread,
+	executor *discordgo.User,
+) (err error) {
+	if err = db.RemoveModmailThread(t.ThreadID); err != nil {
+		return
+	}
+
+	guildName := t.GuildID
+	if g, err := s.Guild(t.GuildID); err == nil {
+		guildName = g.Name
+	}
+
+	discordutil.SendDMEmbed(s, t.UserID, &discordgo.MessageEmbed{
+		Color: static.ColorEmbedGray,
+		Description: fmt.Sprintf(
+			"Your modmail conversation with the staff of **%s** has been closed.\n"+
+				"Send another message to open a new one.", guildName),
+	})
+
+	s.ChannelMessageSendEmbed(t.ThreadID, &discordgo.MessageEmbed{
+		Color:       static.ColorEmbedGray,
+		Description: fmt.Sprintf("Modmail conversation has been closed by %s.", executor.Mention()),
+	})
+
+	archived, locked := true, true
+	_, err = s.ChannelEditComplex(t.ThreadID, &discordgo.ChannelEdit{
+		Archived: &archived,
+		Locked:   &locked,
+	})
+
+	return
+}
+
+// RelayEmbed creates an embed from the given message
+// with the message author as attribution. Attachments
+// are listed as links and the first image attachment
+// is displayed as embed image.
+func RelayEmbed(msg *discordgo.Message, color int, footer string) *discordgo.MessageEmbed {
+	emb := &discordgo.MessageEmbed{
+		Color: color,
+		Author: &discordgo.MessageEmbedAuthor{
+			Name:    msg.Author.String(),
+			IconURL: msg.Author.AvatarURL("16x16"),
+		},
+		Description: msg.Content,
+		Timestamp:   msg.Timestamp.Format("2006-01-02T15:04:05Z07:00"),
+	}
+
+	if footer != "" {
+		emb.Footer = &discordgo.MessageEmbedFooter{Text: footer}
+	}
+
+	if len(msg.Attachments) == 0 {
+		return emb
+	}
+
+	links := make([]string, len(msg.Attachments))
+	for i, a := range msg.Attachments {
+		links[i] = fmt.Sprintf("[%s](%s)", a.Filename, a.URL)
+		if emb.Image == nil && strings.HasPrefix(a.ContentType, "image/") {
+			emb.Image = &discordgo.MessageEmbedImage{URL: a.URL}
+		}
+	}
+	emb.Fields = []*discordgo.MessageEmbedField{
+		{
+			Name:  "Attachments",
+			Value: strings.Join(links, "\n"),
+		},
+	}
+
+	return emb
+}
@@ -0,0 +1,146 @@
+// Package notifications provides utilities to list and
+// remove the notification subscriptions of a guild.
+package notifications
+
+import (
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/pkg/twitchnotify"
+	"github.com/zekrotja/dgrs"
+)
+
+// Type is the type of a notification subscription.
+type Type string
+
+const (
+	TypeTwitch Type = "twitch"
+)
+
+// Problem describes why a subscription can not be
+// notified properly.
+type Problem string
+
+const (
+	ProblemChannelNotFound Problem = "The target channel does not exist anymore."
+	ProblemRoleNotFound    Problem = "The mentioned role does not exist anymore."
+	ProblemNotWatched      Problem = "The Twitch user could not be found."
+)
+
+// Watcher provides the state of the watched Twitch
+// users of the notify worker.
+type Watcher interface {
+	WatchedUser(userID string) *twitchnotify.User
+	IsLive(userID string) bool
+	RemoveUser(userID string) error
+}
+
+var _ Watcher = (*twitchnotify.NotifyWorker)(nil)
+
+// Subscription is a notification subscription of a
+// guild.
+type Subscription struct {
+	Type  Type
+	Entry twitchnotify.DBEntry
+	// User is the watched Twitch user, if available.
+	User *twitchnotify.User
+	// Live is true when the stream of the user is
+	// currently known to be live.
+	Live bool
+	// Problems lists the reasons why the subscription
+	// can not be notified properly.
+	Problems []Problem
+}
+
+// Failing returns true if the subscription can not be
+// notified properly.
+func (s Subscription) Failing() bool {
+	return len(s.Problems) != 0
+}
+
+// List returns all notification subscriptions of the
+// guild. When w is nil, the state of the watched users
+// is not available.
+func List(db database.Database, st dgrs.IState, w Watcher, guildID string) ([]Subscription, error) {
+	entries, err := db.GetAllTwitchNotifies("")
+	if err != nil {
+		return nil, err
+	}
+
+	channels, err := st.Channels(guildID)
+	if err != nil {
+		return nil, err
+	}
+	channelIDs := make(map[string]bool, len(channels))
+	for _, c := range channels {
+		channelIDs[c.ID] = true
+	}
+
+	roles, err := st.Roles(guildID)
+	if err != nil {
+		return nil, err
+	}
+	roleIDs := make(map[string]bool, len(roles))
+	for _, r := range roles {
+		roleIDs[r.ID] = true
+	}
+
+	subs := make([]Subscription, 0)
+	for _, e := range entries {
+		if e.GuildID != guildID {
+			continue
+		}
+
+		sub := Subscription{
+			Type:     TypeTwitch,
+			Entry:    e,
+			Problems: make([]Problem, 0),
+		}
+
+		if !channelIDs[e.ChannelID] {
+			sub.Problems = append(sub.Problems, ProblemChannelNotFound)
+		}
+		if e.RoleID != "" && !roleIDs[e.RoleID] {
+			sub.Problems = append(sub.Problems, ProblemRoleNotFound)
+		}
+
+		if w != nil {
+			sub.User = w.WatchedUser(e.TwitchUserID)
+			sub.Live = w.IsLive(e.TwitchUserID)
+			if sub.User == nil {
+				sub.Problems = append(sub.Problems, ProblemNotWatched)
+			}
+		}
+
+		subs = append(subs, sub)
+	}
+
+	return subs, nil
+}
+
+// Remove deletes the Twitch notification subscription
+// of the given Twitch user on the guild and returns the
+// removed entry. The user is only unwatched when no
+// other guild has subscribed to the user.
+//
+// database.ErrDatabaseNotFound is returned when no
+// subscription exists.
+func Remove(db database.Database, w Watcher, guildID, twitchUserID string) (entry twitchnotify.DBEntry, err error) {
+	entry, err = db.GetTwitchNotify(twitchUserID, guildID)
+	if err != nil {
+		return
+	}
+
+	if err = db.DeleteTwitchNotify(twitchUserID, guildID); err != nil {
+		return
+	}
+
+	remaining, err := db.GetAllTwitchNotifies(twitchUserID)
+	if err != nil {
+		return
+	}
+
+	if len(remaining) == 0 && w != nil {
+		err = w.RemoveUser(twitchUserID)
+	}
+
+	return
+}
@@ -0,0 +1,117 @@
+package notifications
+
+import (
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/services/database/memory"
+	"github.com/zekroTJA/shinpuru/mocks"
+	"github.com/zekroTJA/shinpuru/pkg/twitchnotify"
+)
+
+type testWatcher struct {
+	users   map[string]*twitchnotify.User
+	live    map[string]bool
+	removed []string
+}
+
+func (w *testWatcher) WatchedUser(userID string) *twitchnotify.User {
+	return w.users[userID]
+}
+
+func (w *testWatcher) IsLive(userID string) bool {
+	return w.live[userID]
+}
+
+func (w *testWatcher) RemoveUser(userID string) error {
+	w.removed = append(w.removed, userID)
+	return nil
+}
+
+func getWatcher() *testWatcher {
+	return &testWatcher{
+		users: map[string]*twitchnotify.User{
+			"live":    {ID: "live"},
+			"offline": {ID: "offline"},
+		},
+		live: map[string]bool{"live": true},
+	}
+}
+
+func getState() *mocks.IState {
+	st := &mocks.IState{}
+	st.On("Channels", "guild").Return([]*discordgo.Channel{{ID: "channel"}}, nil)
+	st.On("Roles", "guild").Return([]*discordgo.Role{{ID: "role"}}, nil)
+	return st
+}
+
+func TestList(t *testing.T) {
+	db := memory.New()
+	entries := []twitchnotify.DBEntry{
+		{GuildID: "guild", ChannelID: "channel", TwitchUserID: "live", RoleID: "role"},
+		{GuildID: "guild", ChannelID: "deleted", TwitchUserID: "offline", RoleID: "deleted"},
+		{GuildID: "guild", ChannelID: "channel", TwitchUserID: "unknown"},
+		{GuildID: "other", ChannelID: "channel", TwitchUserID: "live"},
+	}
+	for _, e := range entries {
+		assert.Nil(t, db.SetTwitchNotify(e))
+	}
+
+	w := getWatcher()
+	subs, err := List(db, getState(), w, "guild")
+	assert.Nil(t, err)
+	assert.Equal(t, []Subscription{
+		{
+			Type:     TypeTwitch,
+			Entry:    entries[0],
+			User:     w.users["live"],
+			Live:     true,
+			Problems: []Problem{},
+		},
+		{
+			Type:     TypeTwitch,
+			Entry:    entries[1],
+			User:     w.users["offline"],
+			Problems: []Problem{ProblemChannelNotFound, ProblemRoleNotFound},
+		},
+		{
+			Type:     TypeTwitch,
+			Entry:    entries[2],
+			Problems: []Problem{ProblemNotWatched},
+		},
+	}, subs)
+
+	assert.False(t, subs[0].Failing())
+	assert.True(t, subs[1].Failing())
+
+	// Without watcher, the user state is not checked.
+	subs, err = List(db, getState(), nil, "guild")
+	assert.Nil(t, err)
+	assert.Len(t, subs, 3)
+	assert.False(t, subs[2].Failing())
+}
+
+func TestRemove(t *testing.T) {
+	db := memory.New()
+	assert.Nil(t, db.SetTwitchNotify(twitchnotify.DBEntry{GuildID: "guild", TwitchUserID: "user"}))
+	assert.Nil(t, db.SetTwitchNotify(twitchnotify.DBEntry{GuildID: "other", TwitchUserID: "user"}))
+
+	w := getWatcher()
+
+	_, err := Remove(db, w, "guild", "unknown")
+	assert.ErrorIs(t, err, database.ErrDatabaseNotFound)
+
+	// The user is still watched for the other guild.
+	entry, err := Remove(db, w, "guild", "user")
+	assert.Nil(t, err)
+	assert.Equal(t, "guild", entry.GuildID)
+	assert.Empty(t, w.removed)
+	_, err = db.GetTwitchNotify("user", "guild")
+	assert.ErrorIs(t, err, database.ErrDatabaseNotFound)
+
+	_, err = Remove(db, w, "other", "user")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"user"}, w.removed)
+}
@@ -0,0 +1,104 @@
+// Package pins provides utilities to pin and unpin
+// messages while handling the pin limit of channels.
+package pins
+
+import (
+	"errors"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/zekroTJA/shinpuru/pkg/discordutil"
+)
+
+// MaxPins is the maximum number of pinned messages
+// per channel allowed by Discord.
+const MaxPins = 50
+
+var (
+	ErrLimitReached  = errors.New("the channel has reached the limit of pinned messages")
+	ErrAlreadyPinned = errors.New("the message is already pinned")
+	ErrNotPinned     = errors.New("the message is not pinned")
+)
+
+// Pin pins the given message. If the channel has reached
+// the pin limit and rotate is true, the oldest pinned
+// message is unpinned and returned as rotated.
+// Otherwise, ErrLimitReached is returned.
+//
+// The oldest pin is only unpinned after the new pin
+// succeeded. If Discord refuses the new pin because of
+// the limit, the oldest pin is unpinned to make room and
+// pinned again if the new pin still fails.
+func Pin(s discordutil.ISession, channelID, messageID string, rotate bool) (rotated *discordgo.Message, err error) {
+	pinned, err := s.ChannelMessagesPinned(channelID)
+	if err != nil {
+		return
+	}
+
+	for _, p := range pinned {
+		if p.ID == messageID {
+			return nil, ErrAlreadyPinned
+		}
+	}
+
+	limitReached := len(pinned) >= MaxPins
+	if limitReached && !rotate {
+		return nil, ErrLimitReached
+	}
+
+	err = s.ChannelMessagePin(channelID, messageID)
+	if err == nil {
+		if limitReached {
+			rotated = oldest(pinned)
+			err = s.ChannelMessageUnpin(channelID, rotated.ID)
+		}
+		return
+	}
+
+	if !IsErrLimitReached(err) {
+		return nil, err
+	}
+	if !rotate || len(pinned) == 0 {
+		return nil, ErrLimitReached
+	}
+
+	rotated = oldest(pinned)
+	if err = s.ChannelMessageUnpin(channelID, rotated.ID); err != nil {
+		return nil, err
+	}
+	if err = s.ChannelMessagePin(channelID, messageID); err != nil {
+		s.ChannelMessagePin(channelID, rotated.ID)
+		if IsErrLimitReached(err) {
+			err = ErrLimitReached
+		}
+		return nil, err
+	}
+
+	return
+}
+
+// oldest returns the oldest of the given pinned messages.
+// Pinned messages are returned by Discord ordered by their
+// pin date descending.
+func oldest(pinned []*discordgo.Message) *discordgo.Message {
+	return pinned[len(pinned)-1]
+}
+
+// Unpin unpins the given message. If the message is not
+// pinned, ErrNotPinned is returned.
+func Unpin(s discordutil.ISession, channelID, messageID string) error {
+	msg, err := s.ChannelMessage(channelID, messageID)
+	if err != nil {
+		return err
+	}
+	if !msg.Pinned {
+		return ErrNotPinned
+	}
+	return s.ChannelMessageUnpin(channelID, messageID)
+}
+
+// IsErrLimitReached returns true if the given error is
+// returned by Discord because the pin limit of the
+// channel has been reached.
+func IsErrLimitReached(err error) bool {
+	return discordutil.IsErrCode(err, discordgo.ErrCodeMaximumPinsReached)
+}
@@ -0,0 +1,103 @@
+package pins
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/zekroTJA/shinpuru/mocks"
+)
+
+func pinnedMessages(n int) []*discordgo.Message {
+	msgs := make([]*discordgo.Message, n)
+	for i := range msgs {
+		msgs[i] = &discordgo.Message{ID: fmt.Sprintf("pinned-%d", i)}
+	}
+	return msgs
+}
+
+func TestPin(t *testing.T) {
+	s := &mocks.ISession{}
+	s.On("ChannelMessagesPinned", "chan-0").Return(pinnedMessages(3), nil)
+	s.On("ChannelMessagePin", "chan-0", "msg-0").Return(nil)
+
+	rotated, err := Pin(s, "chan-0", "msg-0", false)
+	assert.Nil(t, err)
+	assert.Nil(t, rotated)
+	s.AssertCalled(t, "ChannelMessagePin", "chan-0", "msg-0")
+
+	// ----------------
+
+	rotated, err = Pin(s, "chan-0", "pinned-1", false)
+	assert.ErrorIs(t, err, ErrAlreadyPinned)
+	assert.Nil(t, rotated)
+	s.AssertNotCalled(t, "ChannelMessagePin", "chan-0", "pinned-1")
+}
+
+func TestPinLimit(t *testing.T) {
+	s := &mocks.ISession{}
+	s.On("ChannelMessagesPinned", "chan-0").Return(pinnedMessages(MaxPins), nil)
+	s.On("ChannelMessageUnpin", "chan-0", "pinned-49").Return(nil)
+	s.On("ChannelMessagePin", "chan-0", "msg-0").Return(nil)
+
+	rotated, err := Pin(s, "chan-0", "msg-0", false)
+	assert.ErrorIs(t, err, ErrLimitReached)
+	assert.Nil(t, rotated)
+	s.AssertNotCalled(t, "ChannelMessageUnpin", "chan-0", "pinned-49")
+	s.AssertNotCalled(t, "ChannelMessagePin", "chan-0", "msg-0")
+
+	// ----------------
+
+	rotated, err = Pin(s, "chan-0", "msg-0", true)
+	assert.Nil(t, err)
+	assert.Equal(t, "pinned-49", rotated.ID)
+	s.AssertCalled(t, "ChannelMessageUnpin", "chan-0", "pinned-49")
+	s.AssertCalled(t, "ChannelMessagePin", "chan-0", "msg-0")
+}
+
+func TestPinLimitRefused(t *testing.T) {
+	errLimit := &discordgo.RESTError{
+		Message: &discordgo.APIErrorMessage{Code: discordgo.ErrCodeMaximumPinsReached},
+	}
+
+	s := &mocks.ISession{}
+	s.On("ChannelMessagesPinned", "chan-0").Return(pinnedMessages(MaxPins), nil)
+	s.On("ChannelMessagePin", "chan-0", "msg-0").Return(errLimit).Once()
+	s.On("ChannelMessageUnpin", "chan-0", "pinned-49").Return(nil)
+	s.On("ChannelMessagePin", "chan-0", "msg-0").Return(nil).Once()
+
+	rotated, err := Pin(s, "chan-0", "msg-0", true)
+	assert.Nil(t, err)
+	assert.Equal(t, "pinned-49", rotated.ID)
+	s.AssertNumberOfCalls(t, "ChannelMessagePin", 2)
+
+	// ----------------
+
+	// The rotated message is pinned again when the
+	// new pin still fails.
+	s = &mocks.ISession{}
+	s.On("ChannelMessagesPinned", "chan-0").Return(pinnedMessages(MaxPins), nil)
+	s.On("ChannelMessagePin", "chan-0", "msg-0").Return(errLimit)
+	s.On("ChannelMessageUnpin", "chan-0", "pinned-49").Return(nil)
+	s.On("ChannelMessagePin", "chan-0", "pinned-49").Return(nil)
+
+	rotated, err = Pin(s, "chan-0", "msg-0", true)
+	assert.ErrorIs(t, err, ErrLimitReached)
+	assert.Nil(t, rotated)
+	s.AssertCalled(t, "ChannelMessagePin", "chan-0", "pinned-49")
+}
+
+func TestUnpin(t *testing.T) {
+	s := &mocks.ISession{}
+	s.On("ChannelMessage", "chan-0", "msg-0").Return(&discordgo.Message{ID: "msg-0"}, nil)
+	s.On("ChannelMessage", "chan-0", "msg-1").Return(&discordgo.Message{ID: "msg-1", Pinned: true}, nil)
+	s.On("ChannelMessageUnpin", "chan-0", "msg-1").Return(nil)
+
+	err := Unpin(s, "chan-0", "msg-0")
+	assert.ErrorIs(t, err, ErrNotPinned)
+
+	err = Unpin(s, "chan-0", "msg-1")
+	assert.Nil(t, err)
+	s.AssertCalled(t, "ChannelMessageUnpin", "chan-0", "msg-1")
+}
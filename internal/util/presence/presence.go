@@ -7,6 +7,7 @@ import (
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/zekroTJA/shinpuru/pkg/stringutil"
+	"github.com/zekroTJA/shinpuru/pkg/validation"
 )
 
 type Status string
@@ -68,14 +69,10 @@ func (p *Presence) ToUpdateStatusData() discordgo.UpdateStatusData {
 // was specified or the presence text contains the seperator
 // used for serialization and deserialization.
 func (p *Presence) Validate() error {
-	if strings.Contains(p.Game, presenceSeperator) {
-		return fmt.Errorf("`%s` is used as seperator for the settings saving so it can not be contained in the actual message.",
-			presenceSeperator)
-	}
-
-	if !stringutil.ContainsAny(string(p.Status), validStatus) {
-		return fmt.Errorf("invalid status")
-	}
-
-	return nil
+	var errs validation.Errors
+	errs.Assert(!strings.Contains(p.Game, presenceSeperator), "game",
+		fmt.Sprintf("`%s` is used as seperator for the settings saving so it can not be contained in the actual message.",
+			presenceSeperator))
+	errs.Assert(stringutil.ContainsAny(string(p.Status), validStatus), "status", "invalid status")
+	return errs.Err()
 }
@@ -14,7 +14,7 @@ func FlushAllGuildData(
 	s *discordgo.Session,
 	db database.Database,
 	st storage.Storage,
-	state *dgrs.State,
+	state dgrs.IState,
 	guildID string,
 ) (err error) {
 	backups, err := db.GetBackups(guildID)
@@ -60,7 +60,7 @@ func FlushAllGuildData(
 
 func FlushAllUserData(
 	db database.Database,
-	state *dgrs.State,
+	state dgrs.IState,
 	userID string,
 ) (res map[string]int, err error) {
 	res, err = db.FlushUserData(userID)
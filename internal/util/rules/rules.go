@@ -0,0 +1,164 @@
+// Package rules provides utilities to post the rules
+// message of a guild and to track which members have
+// accepted the rules.
+package rules
+
+import (
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/util"
+	"github.com/zekroTJA/shinpuru/internal/util/static"
+	"github.com/zekroTJA/shinpuru/pkg/discordutil"
+	"github.com/zekroTJA/shinpuru/pkg/stringutil"
+	"github.com/zekrotja/dgrs"
+	"github.com/zekrotja/ken"
+)
+
+// Embed returns the embed displaying the given rules.
+func Embed(r models.GuildRules) *discordgo.MessageEmbed {
+	return &discordgo.MessageEmbed{
+		Color:       static.ColorEmbedDefault,
+		Title:       "Rules",
+		Description: r.Content,
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: fmt.Sprintf("Version %d • Click the button below to accept the rules.", r.Version),
+		},
+	}
+}
+
+// Post edits the existing rules message to display the
+// current rules. If the message does not exist anymore,
+// the rules are posted as new message into the rules
+// channel. Afterwards, the accept button is attached
+// and the message reference is stored.
+func Post(
+	db database.Database,
+	s discordutil.ISession,
+	kc *ken.ComponentHandler,
+	r *models.GuildRules,
+) (err error) {
+	emb := Embed(*r)
+	emb.Color = util.GuildEmbedColor(db, r.GuildID)
+
+	if r.MessageID != "" {
+		_, err = s.ChannelMessageEditEmbed(r.ChannelID, r.MessageID, emb)
+		if err != nil && !discordutil.IsErrMessageGone(err) {
+			return
+		}
+		if err != nil {
+			r.MessageID = ""
+		}
+	}
+
+	if r.MessageID == "" {
+		var msg *discordgo.Message
+		msg, err = s.ChannelMessageSendEmbed(r.ChannelID, emb)
+		if err != nil {
+			return
+		}
+		r.MessageID = msg.ID
+	}
+
+	if err = AttachAcceptButton(db, kc, *r); err != nil {
+		return
+	}
+
+	return db.SetGuildRules(*r)
+}
+
+// AttachAcceptButton attaches the accept button to the
+// rules message and registers the button handler.
+func AttachAcceptButton(db database.Database, kc *ken.ComponentHandler, r models.GuildRules) (err error) {
+	_, err = kc.Add(r.MessageID, r.ChannelID).
+		AddActionsRow(func(b ken.ComponentAssembler) {
+			b.Add(discordgo.Button{
+				Label: "Accept Rules",
+				Style: discordgo.SuccessButton,
+				// The ID is static so that re-attaching the button
+				// replaces the previously registered handler.
+				CustomID: "rules-accept-" + r.GuildID,
+			}, onAccept(db, r.GuildID))
+		}).
+		Build()
+	return
+}
+
+// RevokeRole removes the given rules role from all members
+// of the guild so that they need to accept the rules again.
+// The number of members the role was removed from is
+// returned.
+func RevokeRole(s discordutil.ISession, st dgrs.IState, guildID, roleID string) (n int, err error) {
+	membs, err := st.Members(guildID)
+	if err != nil {
+		return
+	}
+
+	for _, m := range membs {
+		if !stringutil.ContainsAny(roleID, m.Roles) {
+			continue
+		}
+		if err = s.GuildMemberRoleRemove(guildID, m.User.ID, roleID); err != nil {
+			return
+		}
+		n++
+	}
+
+	return
+}
+
+func onAccept(db database.Database, guildID string) ken.ComponentHandlerFunc {
+	return func(ctx ken.ComponentContext) bool {
+		ctx.SetEphemeral(true)
+		if err := ctx.Defer(); err != nil {
+			return false
+		}
+
+		// The rules are fetched on each click because the
+		// version might have changed after the button has
+		// been attached.
+		r, err := db.GetGuildRules(guildID)
+		if err != nil {
+			ctx.FollowUpError("Failed getting the rules. Please try again later.", "").Send()
+			return false
+		}
+
+		userID := ctx.User().ID
+
+		accepted, err := db.GetRulesAcceptance(guildID, userID)
+		if err != nil && !database.IsErrDatabaseNotFound(err) {
+			ctx.FollowUpError("Failed getting your acceptance state. Please try again later.", "").Send()
+			return false
+		}
+
+		if accepted < r.Version {
+			if err = db.SetRulesAcceptance(guildID, userID, r.Version); err != nil {
+				ctx.FollowUpError("Failed saving your acceptance. Please try again later.", "").Send()
+				return false
+			}
+		}
+
+		if r.RoleID != "" && !stringutil.ContainsAny(r.RoleID, ctx.GetEvent().Member.Roles) {
+			err = ctx.GetSession().GuildMemberRoleAdd(guildID, userID, r.RoleID)
+			if err != nil {
+				ctx.FollowUpError("Your acceptance has been saved but the role could not be "+
+					"added. Please contact a moderator.", "").Send()
+				return true
+			}
+		}
+
+		desc := "You have accepted the rules. Thank you!"
+		if accepted >= r.Version {
+			desc = "You have already accepted the current rules."
+		}
+
+		ctx.FollowUpEmbed(&discordgo.MessageEmbed{
+			Color:       static.ColorEmbedGreen,
+			Description: desc,
+		}).Send()
+
+		return true
+	}
+}
@@ -0,0 +1,30 @@
+package snowflakenodes
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/bwmarrin/snowflake"
+)
+
+var generateAtStep int64
+
+// GenerateAt creates a snowflake ID for the given node ID
+// with the timestamp t instead of the current time. This
+// is used when importing objects whose creation time is
+// derived from their ID.
+//
+// The step part of the ID is taken from a global counter
+// so that consecutive calls with the same timestamp
+// generate unique IDs.
+func GenerateAt(nodeID int64, t time.Time) snowflake.ID {
+	stepMask := int64(-1 ^ (-1 << snowflake.StepBits))
+	nodeMask := int64(-1 ^ (-1 << snowflake.NodeBits))
+
+	step := atomic.AddInt64(&generateAtStep, 1) & stepMask
+	ms := t.UnixMilli() - snowflake.Epoch
+
+	return snowflake.ID(ms<<(snowflake.NodeBits+snowflake.StepBits) |
+		(nodeID&nodeMask)<<snowflake.StepBits |
+		step)
+}
@@ -0,0 +1,20 @@
+package snowflakenodes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateAt(t *testing.T) {
+	ts := time.Date(2021, 3, 4, 5, 6, 7, 8e6, time.UTC)
+
+	id1 := GenerateAt(3, ts)
+	id2 := GenerateAt(3, ts)
+
+	assert.NotEqual(t, id1, id2)
+	assert.Equal(t, ts.UnixMilli(), id1.Time())
+	assert.Equal(t, ts.UnixMilli(), id2.Time())
+	assert.Equal(t, int64(3), id1.Node())
+}
@@ -4,10 +4,17 @@ import (
 	"github.com/bwmarrin/snowflake"
 )
 
+// NodeIDCustomReports is the ID of the snowflake
+// node for reports of custom report types.
+const NodeIDCustomReports = 200
+
 var (
 	// NodesReport contains snowflake nodes for
 	// each report type.
 	NodesReport []*snowflake.Node
+	// NodeCustomReports is the snowflake node
+	// for reports of custom report types.
+	NodeCustomReports *snowflake.Node
 
 	// NodeBackup is the snowflake node for
 	// backup IDs.
@@ -27,6 +34,21 @@ var (
 	// NodeGuildLog is the snowflake node
 	// for guild logs.
 	NodeGuildLog *snowflake.Node
+	// NodeSettingsAudit is the snowflake node
+	// for guild settings audit entries.
+	NodeSettingsAudit *snowflake.Node
+	// NodeGiveaways is the snowflake node
+	// for giveaways.
+	NodeGiveaways *snowflake.Node
+	// NodeBroadcasts is the snowflake node
+	// for broadcasts.
+	NodeBroadcasts *snowflake.Node
+	// NodeTempRoles is the snowflake node
+	// for temporary role assignments.
+	NodeTempRoles *snowflake.Node
+	// NodeUserNotes is the snowflake node
+	// for moderator notes on users.
+	NodeUserNotes *snowflake.Node
 
 	// nodeMap maps snowflake node IDs with
 	// their identifier strings.
@@ -44,6 +66,11 @@ func Setup() (err error) {
 	NodeUnbanRequests, _ = RegisterNode(140, "unbanrequests")
 	NodeKarmaRules, _ = RegisterNode(150, "karmarules")
 	NodeGuildLog, _ = RegisterNode(160, "karmarules")
+	NodeSettingsAudit, _ = RegisterNode(180, "settingsaudit")
+	NodeGiveaways, _ = RegisterNode(190, "giveaways")
+	NodeBroadcasts, _ = RegisterNode(210, "broadcasts")
+	NodeTempRoles, _ = RegisterNode(220, "temproles")
+	NodeUserNotes, _ = RegisterNode(230, "usernotes")
 
 	return
 }
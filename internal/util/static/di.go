@@ -27,8 +27,17 @@ const (
 	DiGuildLog                = "guildlog"
 	DiKVCache                 = "kvcache"
 	DiRedis                   = "redisclient"
+	DiRedisGuard              = "redisguard"
+	DiDatabaseCache           = "databasecache"
 	DiState                   = "dgstate"
 	DiVerification            = "verification"
 	DiBirthday                = "birthday"
+	DiBroadcast               = "broadcast"
+	DiComponents              = "components"
+	DiInviteTracker           = "invitetracker"
 	DiTimeProvider            = "timeprovider"
+	DiListenerRegistry        = "listenerregistry"
+	DiEmojiImporter           = "emojiimporter"
+	DiMessageSender           = "messagesender"
+	DiSelfCheck               = "selfcheck"
 )
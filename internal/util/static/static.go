@@ -2,8 +2,6 @@ package static
 
 import (
 	"time"
-
-	"github.com/bwmarrin/discordgo"
 )
 
 const (
@@ -25,19 +23,6 @@ const (
 		0x20000000 | // MANAGE WEBHOOKS
 		0x40000000 // MANAGE EMOJIS
 
-	Intents = discordgo.IntentsDirectMessages |
-		discordgo.IntentsGuildBans |
-		discordgo.IntentsGuildEmojis |
-		discordgo.IntentsGuildIntegrations |
-		discordgo.IntentsGuildInvites |
-		discordgo.IntentsGuildMembers |
-		discordgo.IntentsGuildMessageReactions |
-		discordgo.IntentsGuildMessages |
-		// discordgo.IntentsGuildPresences |
-		discordgo.IntentsGuildVoiceStates |
-		discordgo.IntentsGuilds |
-		discordgo.IntentsGuildVoiceStates
-
 	OAuthScopes = "bot%20applications.commands"
 
 	ConfigVersion = 6
@@ -59,13 +44,15 @@ const (
 
 	MutedRoleName = "shinpuru-muted"
 
-	SettingPresence        = "PRESENCE"
-	SettingWIInviteGuildID = "WIINVITEGUILDID"
-	SettingWIInviteCode    = "WIINVITECODE"
-	SettingWIInviteText    = "WIINVITETEXT"
+	SettingPresence         = "PRESENCE"
+	SettingWIInviteGuildID  = "WIINVITEGUILDID"
+	SettingWIInviteCode     = "WIINVITECODE"
+	SettingWIInviteText     = "WIINVITETEXT"
+	SettingCommandBlocklist = "CMDBLOCKLIST"
 
-	StorageBucketImages  = "shinpuru-images"
-	StorageBucketBackups = "shinpuru-backups"
+	StorageBucketImages   = "shinpuru-images"
+	StorageBucketBackups  = "shinpuru-backups"
+	StorageBucketCodeExec = "shinpuru-codeexec"
 
 	DiscordAPIEndpoint = "https://discord.com/api"
 
@@ -78,6 +65,7 @@ const (
 
 	AuthSessionExpiration  = 7 * 24 * time.Hour // 7 Days
 	ApiTokenExpiration     = 365 * 24 * time.Hour
+	ApiTokenUsageHistory   = 50
 	RefreshTokenCookieName = "refreshToken"
 )
 
@@ -101,5 +89,16 @@ var (
 		"sp.chat.autochannel",
 		"sp.chat.colorreactions",
 		"sp.guild.mod.inviteblock.send",
+		"sp.guild.config.announcementchannel",
+	}
+
+	// EssentialCommands can not be disabled on guilds
+	// so that admins can not lock themselves out of
+	// managing the bot.
+	EssentialCommands = []string{
+		"commands",
+		"help",
+		"login",
+		"perms",
 	}
 )
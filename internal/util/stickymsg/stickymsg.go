@@ -0,0 +1,103 @@
+// Package stickymsg provides utilities to keep a
+// message at the bottom of a channel by reposting it.
+package stickymsg
+
+import (
+	"github.com/bwmarrin/discordgo"
+	"github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/pkg/discordutil"
+	"github.com/zekroTJA/shinpuru/pkg/keylock"
+)
+
+// locks holds a mutex for each channel so that
+// concurrent reposts can not result in more than
+// one sticky message in a channel.
+var locks keylock.Locker
+
+func lock(channelID string) func() {
+	return locks.Lock(channelID)
+}
+
+// Set stores the sticky message and posts it to the
+// channel, replacing the previously posted sticky
+// message, if existent.
+func Set(db database.Database, s discordutil.ISession, sm models.StickyMessage) error {
+	defer lock(sm.ChannelID)()
+
+	old, err := db.GetStickyMessage(sm.GuildID, sm.ChannelID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return err
+	}
+
+	sm.MessageID = old.MessageID
+	return post(db, s, sm)
+}
+
+// Repost deletes the currently posted sticky message
+// of the channel and posts it again at the bottom of
+// the channel. Nothing is done if no sticky message
+// is set for the channel.
+func Repost(db database.Database, s discordutil.ISession, guildID, channelID string) error {
+	defer lock(channelID)()
+
+	sm, err := db.GetStickyMessage(guildID, channelID)
+	if database.IsErrDatabaseNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return post(db, s, sm)
+}
+
+// Clear removes the sticky message of the channel and
+// deletes the posted message, if existent. The sticky
+// message is removed even if the posted message can
+// not be deleted.
+func Clear(db database.Database, s discordutil.ISession, guildID, channelID string) error {
+	defer lock(channelID)()
+
+	sm, err := db.GetStickyMessage(guildID, channelID)
+	if database.IsErrDatabaseNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if err = db.RemoveStickyMessage(guildID, channelID); err != nil {
+		return err
+	}
+
+	return deleteMessage(s, sm)
+}
+
+func post(db database.Database, s discordutil.ISession, sm models.StickyMessage) error {
+	if err := deleteMessage(s, sm); err != nil {
+		return err
+	}
+
+	msg, err := s.ChannelMessageSendComplex(sm.ChannelID, &discordgo.MessageSend{
+		Content:         sm.Content,
+		AllowedMentions: &discordgo.MessageAllowedMentions{},
+	})
+	if err != nil {
+		return err
+	}
+
+	sm.MessageID = msg.ID
+	return db.SetStickyMessage(sm)
+}
+
+func deleteMessage(s discordutil.ISession, sm models.StickyMessage) error {
+	if sm.MessageID == "" {
+		return nil
+	}
+	err := s.ChannelMessageDelete(sm.ChannelID, sm.MessageID)
+	if discordutil.IsErrCode(err, discordgo.ErrCodeUnknownMessage) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,79 @@
+package stickymsg
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/services/database/memory"
+	"github.com/zekroTJA/shinpuru/internal/util/testutil"
+	"github.com/zekroTJA/shinpuru/mocks"
+)
+
+func getSession() *mocks.ISession {
+	var n int
+	s := &mocks.ISession{}
+	s.On("ChannelMessageSendComplex", "channel", mock.Anything).
+		Return(func(channelID string, data *discordgo.MessageSend, _ ...discordgo.RequestOption) (*discordgo.Message, error) {
+			n++
+			return &discordgo.Message{ID: "msg-" + strconv.Itoa(n)}, nil
+		})
+	s.On("ChannelMessageDelete", "channel", "msg-gone").
+		Return(testutil.DiscordRestError(discordgo.ErrCodeUnknownMessage))
+	s.On("ChannelMessageDelete", "channel", mock.Anything).Return(nil)
+	return s
+}
+
+func TestSet(t *testing.T) {
+	db := memory.New()
+	s := getSession()
+
+	sm := models.StickyMessage{GuildID: "guild", ChannelID: "channel", Content: "hello"}
+	assert.Nil(t, Set(db, s, sm))
+	s.AssertNotCalled(t, "ChannelMessageDelete", mock.Anything, mock.Anything)
+
+	sm.Content = "hello world"
+	assert.Nil(t, Set(db, s, sm))
+	s.AssertCalled(t, "ChannelMessageDelete", "channel", "msg-1")
+
+	res, err := db.GetStickyMessage("guild", "channel")
+	assert.Nil(t, err)
+	assert.Equal(t, "hello world", res.Content)
+	assert.Equal(t, "msg-2", res.MessageID)
+}
+
+func TestRepost(t *testing.T) {
+	db := memory.New()
+	s := getSession()
+
+	assert.Nil(t, Repost(db, s, "guild", "channel"))
+	s.AssertNotCalled(t, "ChannelMessageSendComplex", mock.Anything, mock.Anything)
+
+	// Already deleted sticky messages are ignored.
+	db.SetStickyMessage(models.StickyMessage{
+		GuildID: "guild", ChannelID: "channel", Content: "hello", MessageID: "msg-gone"})
+	assert.Nil(t, Repost(db, s, "guild", "channel"))
+
+	res, err := db.GetStickyMessage("guild", "channel")
+	assert.Nil(t, err)
+	assert.Equal(t, "msg-1", res.MessageID)
+}
+
+func TestClear(t *testing.T) {
+	db := memory.New()
+	s := getSession()
+
+	assert.Nil(t, Clear(db, s, "guild", "channel"))
+
+	db.SetStickyMessage(models.StickyMessage{
+		GuildID: "guild", ChannelID: "channel", Content: "hello", MessageID: "msg-1"})
+	assert.Nil(t, Clear(db, s, "guild", "channel"))
+	s.AssertCalled(t, "ChannelMessageDelete", "channel", "msg-1")
+
+	_, err := db.GetStickyMessage("guild", "channel")
+	assert.ErrorIs(t, err, database.ErrDatabaseNotFound)
+}
@@ -0,0 +1,84 @@
+// Package sysinfo provides the computation of general
+// system information shared by the API and commands.
+package sysinfo
+
+import (
+	"runtime"
+	"strconv"
+	"time"
+
+	"github.com/zekroTJA/shinpuru/internal/util"
+	"github.com/zekroTJA/shinpuru/internal/util/embedded"
+	"github.com/zekrotja/dgrs"
+)
+
+// Info contains general information about the build,
+// the runtime and the bot instance.
+type Info struct {
+	Version    string
+	CommitHash string
+	BuildDate  time.Time
+	GoVersion  string
+
+	Uptime time.Duration
+
+	OS         string
+	Arch       string
+	CPUs       int
+	GoRoutines int
+	StackUse   uint64
+	HeapUse    uint64
+
+	BotUserID string
+	BotInvite string
+
+	Guilds  int
+	Members int
+}
+
+// Get collects the current system information.
+func Get(st dgrs.IState) (*Info, error) {
+	self, err := st.SelfUser()
+	if err != nil {
+		return nil, err
+	}
+
+	guilds, err := st.Guilds()
+	if err != nil {
+		return nil, err
+	}
+
+	var members int
+	for _, g := range guilds {
+		members += g.MemberCount
+	}
+
+	buildTS, _ := strconv.Atoi(embedded.AppDate)
+
+	var memStats runtime.MemStats
+	runtime.ReadMemStats(&memStats)
+
+	info := &Info{
+		Version:    embedded.AppVersion,
+		CommitHash: embedded.AppCommit,
+		BuildDate:  time.Unix(int64(buildTS), 0),
+		GoVersion:  runtime.Version(),
+
+		Uptime: time.Since(util.StatsStartupTime),
+
+		OS:         runtime.GOOS,
+		Arch:       runtime.GOARCH,
+		CPUs:       runtime.NumCPU(),
+		GoRoutines: runtime.NumGoroutine(),
+		StackUse:   memStats.StackInuse,
+		HeapUse:    memStats.HeapInuse,
+
+		BotUserID: self.ID,
+		BotInvite: util.GetInviteLink(self.ID),
+
+		Guilds:  len(guilds),
+		Members: members,
+	}
+
+	return info, nil
+}
@@ -0,0 +1,34 @@
+package sysinfo
+
+import (
+	"errors"
+	"runtime"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/zekroTJA/shinpuru/internal/util"
+	"github.com/zekroTJA/shinpuru/mocks"
+)
+
+func TestGet(t *testing.T) {
+	st := &mocks.IState{}
+	st.On("SelfUser").Return(&discordgo.User{ID: "self-id"}, nil)
+	st.On("Guilds").Return([]*discordgo.Guild{{ID: "a", MemberCount: 3}, {ID: "b", MemberCount: 4}}, nil)
+
+	info, err := Get(st)
+	assert.Nil(t, err)
+	assert.Equal(t, "self-id", info.BotUserID)
+	assert.Equal(t, util.GetInviteLink("self-id"), info.BotInvite)
+	assert.Equal(t, 2, info.Guilds)
+	assert.Equal(t, 7, info.Members)
+	assert.Equal(t, runtime.GOOS, info.OS)
+	assert.Equal(t, runtime.NumCPU(), info.CPUs)
+
+	errState := errors.New("state error")
+	st = &mocks.IState{}
+	st.On("SelfUser").Return(nil, errState)
+
+	_, err = Get(st)
+	assert.ErrorIs(t, err, errState)
+}
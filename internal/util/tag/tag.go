@@ -31,7 +31,7 @@ type author struct {
 
 // AsEmbed creates a discordgo.MessageEmbed from
 // the tag information.
-func (t *Tag) AsEmbed(s *dgrs.State) *discordgo.MessageEmbed {
+func (t *Tag) AsEmbed(s dgrs.IState) *discordgo.MessageEmbed {
 	footer := ""
 
 	author := t.formattedAuthor(s)
@@ -59,7 +59,7 @@ func (t *Tag) AsEmbed(s *dgrs.State) *discordgo.MessageEmbed {
 
 // AsEntry returns a single formatted string line
 // to represent a tag.
-func (t *Tag) AsEntry(s *dgrs.State) string {
+func (t *Tag) AsEntry(s dgrs.IState) string {
 	author := t.formattedAuthor(s)
 
 	return fmt.Sprintf("**%s** by %s [`%s`]", t.Ident, author.nameTag, t.ID)
@@ -73,7 +73,7 @@ func (t *Tag) RawContent() string {
 
 // formattedAuthor returns an author object from the
 // CreatorID of the tag.
-func (t *Tag) formattedAuthor(s *dgrs.State) *author {
+func (t *Tag) formattedAuthor(s dgrs.IState) *author {
 	authorF := new(author)
 	author, err := s.Member(t.GuildID, t.CreatorID)
 	if err == nil && author != nil {
@@ -0,0 +1,241 @@
+// Package temprole provides utilities to assign roles
+// to members which are removed automatically when they
+// expire.
+package temprole
+
+import (
+	"errors"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/services/guildlog"
+	"github.com/zekroTJA/shinpuru/internal/services/timeprovider"
+	"github.com/zekroTJA/shinpuru/internal/util/snowflakenodes"
+	"github.com/zekroTJA/shinpuru/pkg/discordutil"
+	"github.com/zekroTJA/shinpuru/pkg/stringutil"
+	"github.com/zekrotja/rogu/log"
+)
+
+const (
+	// MaxDuration is the maximum duration a temporary
+	// role can be assigned for.
+	MaxDuration = 365 * 24 * time.Hour
+
+	// retryPeriod is the duration after the expiry in
+	// which failed removals of a role are retried.
+	retryPeriod = 24 * time.Hour
+)
+
+var tl = log.Tagged("TempRole")
+
+var (
+	ErrRoleNotFound = errors.New("role not found")
+	ErrRoleManaged  = errors.New("role is managed by an integration")
+	ErrRoleDiff     = errors.New("role is higher than or equal to the highest role of the executor")
+	ErrRoleSelfDiff = errors.New("role is higher than or equal to the highest role of the bot")
+	ErrRoleHeld     = errors.New("member already holds the role permanently")
+)
+
+// CheckAssignable returns an error if the role can not be
+// assigned by the executor. Roles can only be assigned if
+// they are below the highest role of the bot and, unless
+// the executor is an administrator, below the highest role
+// of the executor.
+func CheckAssignable(guild *discordgo.Guild, executor, self *discordgo.Member, roleID string) error {
+	var role *discordgo.Role
+	for _, r := range guild.Roles {
+		if r.ID == roleID && r.ID != guild.ID {
+			role = r
+			break
+		}
+	}
+	if role == nil {
+		return ErrRoleNotFound
+	}
+	if role.Managed {
+		return ErrRoleManaged
+	}
+	if role.Position >= maxRolePosition(guild, self) {
+		return ErrRoleSelfDiff
+	}
+	if !discordutil.IsAdmin(guild, executor) && role.Position >= maxRolePosition(guild, executor) {
+		return ErrRoleDiff
+	}
+	return nil
+}
+
+// Assign adds the role to the member and stores the
+// assignment so that the role is removed after the
+// given duration. If the role is already temporarily
+// assigned to the member, the expiry of the existing
+// assignment is updated instead.
+//
+// ErrRoleHeld is returned if the member already holds
+// the role without a temporary assignment, so that the
+// role is not removed on expiry.
+func Assign(
+	db database.Database,
+	s discordutil.ISession,
+	tp timeprovider.Provider,
+	guildID string,
+	memb *discordgo.Member,
+	roleID, executorID string,
+	duration time.Duration,
+) (t models.TempRole, err error) {
+	userID := memb.User.ID
+	curr, err := db.GetTempRoles(guildID, userID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		return
+	}
+
+	t = models.TempRole{
+		ID:         snowflakenodes.NodeTempRoles.Generate(),
+		GuildID:    guildID,
+		UserID:     userID,
+		RoleID:     roleID,
+		ExecutorID: executorID,
+	}
+	var existing bool
+	for _, c := range curr {
+		if c.RoleID == roleID {
+			t = c
+			existing = true
+			break
+		}
+	}
+	if !existing && stringutil.ContainsAny(roleID, memb.Roles) {
+		err = ErrRoleHeld
+		return
+	}
+	t.Expires = tp.Now().Add(duration)
+	t.Attempts = 0
+
+	if err = s.GuildMemberRoleAdd(guildID, userID, roleID); err != nil {
+		return
+	}
+
+	err = db.SetTempRole(t)
+	return
+}
+
+// Cancel removes the role of the given assignment from
+// the member and deletes the assignment. It is not
+// treated as error if the member or the role does not
+// exist anymore.
+func Cancel(db database.Database, s discordutil.ISession, t models.TempRole) (err error) {
+	if err = removeRole(s, t); err != nil {
+		return
+	}
+	return db.RemoveTempRole(t.ID)
+}
+
+// RemoveExpired returns a function which removes all
+// expired temporary roles of guilds handled by the
+// current shard.
+//
+// If a role can not be removed, for example because
+// the bot lacks permission, the removal is retried on
+// the next run until the retry period has passed.
+//
+// Roles which are also set as auto roles of the guild
+// are held permanently by the member and are therefore
+// not removed.
+func RemoveExpired(db database.Database, s *discordgo.Session, gl guildlog.Logger, tp timeprovider.Provider) func() {
+	gl = gl.Section("temprole")
+	return func() {
+		tempRoles, err := db.GetTempRoles("", "")
+		if err != nil && !database.IsErrDatabaseNotFound(err) {
+			tl.Error().Err(err).Msg("Failed getting temporary roles")
+			return
+		}
+
+		shardID, shardTotal := discordutil.GetShardOfSession(s)
+		now := tp.Now()
+
+		for _, t := range tempRoles {
+			if now.Before(t.Expires) {
+				continue
+			}
+			if shardTotal > 1 {
+				if id, err := discordutil.GetShardOfGuild(t.GuildID, shardTotal); err != nil || id != shardID {
+					continue
+				}
+			}
+			expire(db, s, gl, now, t)
+		}
+	}
+}
+
+// IsErrGone returns true if the error indicates that the
+// member or the role of a temporary role assignment does
+// not exist anymore.
+func IsErrGone(err error) bool {
+	return discordutil.IsErrCode(err, discordgo.ErrCodeUnknownMember) ||
+		discordutil.IsErrCode(err, discordgo.ErrCodeUnknownRole) ||
+		discordutil.IsErrCode(err, discordgo.ErrCodeUnknownGuild)
+}
+
+func expire(db database.Database, s discordutil.ISession, gl guildlog.Logger, now time.Time, t models.TempRole) {
+	autoRoles, err := db.GetGuildAutoRole(t.GuildID)
+	if err != nil && !database.IsErrDatabaseNotFound(err) {
+		tl.Error().Err(err).Field("gid", t.GuildID).Msg("Failed getting auto roles")
+		return
+	}
+	if stringutil.ContainsAny(t.RoleID, autoRoles) {
+		if err = db.RemoveTempRole(t.ID); err != nil {
+			tl.Error().Err(err).Field("id", t.ID).Msg("Failed removing temporary role entry")
+		}
+		return
+	}
+
+	err = removeRole(s, t)
+	if err == nil {
+		if err = db.RemoveTempRole(t.ID); err != nil {
+			tl.Error().Err(err).Field("id", t.ID).Msg("Failed removing temporary role entry")
+		}
+		return
+	}
+
+	t.Attempts++
+
+	if now.Sub(t.Expires) > retryPeriod {
+		gl.Errorf(t.GuildID, "Giving up removing temporary role %s from %s after %d attempts: %s",
+			t.RoleID, t.UserID, t.Attempts, err.Error())
+		if err = db.RemoveTempRole(t.ID); err != nil {
+			tl.Error().Err(err).Field("id", t.ID).Msg("Failed removing temporary role entry")
+		}
+		return
+	}
+
+	// Only the first failure is logged to avoid flooding
+	// the guild log while the removal is retried.
+	if t.Attempts == 1 {
+		gl.Errorf(t.GuildID, "Failed removing temporary role %s from %s, retrying: %s",
+			t.RoleID, t.UserID, err.Error())
+	}
+	if err = db.SetTempRole(t); err != nil {
+		tl.Error().Err(err).Field("id", t.ID).Msg("Failed updating temporary role entry")
+	}
+}
+
+func maxRolePosition(guild *discordgo.Guild, memb *discordgo.Member) (max int) {
+	if memb.User != nil && memb.User.ID == guild.OwnerID {
+		return len(guild.Roles) + 1
+	}
+	for _, r := range guild.Roles {
+		if r.Position > max && stringutil.ContainsAny(r.ID, memb.Roles) {
+			max = r.Position
+		}
+	}
+	return max
+}
+
+func removeRole(s discordutil.ISession, t models.TempRole) error {
+	err := s.GuildMemberRoleRemove(t.GuildID, t.UserID, t.RoleID)
+	if err != nil && !IsErrGone(err) {
+		return err
+	}
+	return nil
+}
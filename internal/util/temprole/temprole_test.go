@@ -0,0 +1,155 @@
+package temprole
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/zekroTJA/shinpuru/internal/models"
+	"github.com/zekroTJA/shinpuru/internal/services/database"
+	"github.com/zekroTJA/shinpuru/internal/services/database/memory"
+	"github.com/zekroTJA/shinpuru/internal/util/snowflakenodes"
+	"github.com/zekroTJA/shinpuru/internal/util/testutil"
+	"github.com/zekroTJA/shinpuru/mocks"
+)
+
+var now = time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func TestAssign(t *testing.T) {
+	snowflakenodes.Setup()
+
+	db := memory.New()
+	s := &mocks.ISession{}
+	s.On("GuildMemberRoleAdd", "guild", "user", mock.Anything).Return(nil)
+	tp := &mocks.TimeProvider{}
+	tp.On("Now").Return(now)
+
+	memb := &discordgo.Member{User: &discordgo.User{ID: "user"}, Roles: []string{"permanent"}}
+
+	tr, err := Assign(db, s, tp, "guild", memb, "role", "exec", time.Hour)
+	assert.Nil(t, err)
+	assert.Equal(t, now.Add(time.Hour), tr.Expires)
+	s.AssertCalled(t, "GuildMemberRoleAdd", "guild", "user", "role")
+	memb.Roles = append(memb.Roles, "role")
+
+	// Assigning the same role again updates the
+	// expiry of the existing assignment.
+	tr2, err := Assign(db, s, tp, "guild", memb, "role", "other", 2*time.Hour)
+	assert.Nil(t, err)
+	assert.Equal(t, tr.ID, tr2.ID)
+	assert.Equal(t, "exec", tr2.ExecutorID)
+
+	_, err = Assign(db, s, tp, "guild", memb, "role2", "exec", time.Hour)
+	assert.Nil(t, err)
+
+	// Roles held permanently are not assigned
+	// temporarily.
+	_, err = Assign(db, s, tp, "guild", memb, "permanent", "exec", time.Hour)
+	assert.ErrorIs(t, err, ErrRoleHeld)
+	s.AssertNotCalled(t, "GuildMemberRoleAdd", "guild", "user", "permanent")
+
+	all, err := db.GetTempRoles("guild", "user")
+	assert.Nil(t, err)
+	assert.Len(t, all, 2)
+	assert.Equal(t, "role2", all[0].RoleID)
+	assert.Equal(t, now.Add(2*time.Hour), all[1].Expires)
+}
+
+func TestCancel(t *testing.T) {
+	db := memory.New()
+	tr := models.TempRole{ID: 1, GuildID: "guild", UserID: "user", RoleID: "role"}
+	db.SetTempRole(tr)
+
+	s := &mocks.ISession{}
+	s.On("GuildMemberRoleRemove", "guild", "user", "role").
+		Return(testutil.DiscordRestError(discordgo.ErrCodeUnknownMember))
+
+	assert.Nil(t, Cancel(db, s, tr))
+	_, err := db.GetTempRole(1)
+	assert.ErrorIs(t, err, database.ErrDatabaseNotFound)
+}
+
+func TestExpire(t *testing.T) {
+	db := memory.New()
+	gl := &mocks.Logger{}
+	gl.On("Errorf", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	gl.On("Errorf", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	s := &mocks.ISession{}
+	s.On("GuildMemberRoleRemove", "guild", "user", "removable").Return(nil)
+	s.On("GuildMemberRoleRemove", "guild", "user", "forbidden").
+		Return(errors.New("missing permissions"))
+
+	// ----- Removed -----
+
+	tr := models.TempRole{ID: 1, GuildID: "guild", UserID: "user", RoleID: "removable", Expires: now}
+	db.SetTempRole(tr)
+	expire(db, s, gl, now, tr)
+	_, err := db.GetTempRole(1)
+	assert.ErrorIs(t, err, database.ErrDatabaseNotFound)
+
+	// ----- Retried -----
+
+	tr = models.TempRole{ID: 2, GuildID: "guild", UserID: "user", RoleID: "forbidden", Expires: now}
+	db.SetTempRole(tr)
+	expire(db, s, gl, now, tr)
+	tr, err = db.GetTempRole(2)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, tr.Attempts)
+
+	expire(db, s, gl, now.Add(time.Hour), tr)
+	tr, err = db.GetTempRole(2)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, tr.Attempts)
+	gl.AssertNumberOfCalls(t, "Errorf", 1)
+
+	// ----- Given up -----
+
+	expire(db, s, gl, now.Add(retryPeriod+time.Minute), tr)
+	_, err = db.GetTempRole(2)
+	assert.ErrorIs(t, err, database.ErrDatabaseNotFound)
+	gl.AssertNumberOfCalls(t, "Errorf", 2)
+
+	// ----- Auto role -----
+
+	db.SetGuildAutoRole("guild", []string{"auto"})
+	tr = models.TempRole{ID: 3, GuildID: "guild", UserID: "user", RoleID: "auto", Expires: now}
+	db.SetTempRole(tr)
+	expire(db, s, gl, now, tr)
+	_, err = db.GetTempRole(3)
+	assert.ErrorIs(t, err, database.ErrDatabaseNotFound)
+	s.AssertNotCalled(t, "GuildMemberRoleRemove", "guild", "user", "auto")
+}
+
+func TestCheckAssignable(t *testing.T) {
+	guild := &discordgo.Guild{
+		ID:      "guild",
+		OwnerID: "owner",
+		Roles: []*discordgo.Role{
+			{ID: "guild", Position: 0},
+			{ID: "low", Position: 1},
+			{ID: "integration", Position: 2, Managed: true},
+			{ID: "mod", Position: 3},
+			{ID: "bot", Position: 4},
+			{ID: "admin", Position: 5, Permissions: discordgo.PermissionAdministrator},
+		},
+	}
+	self := &discordgo.Member{User: &discordgo.User{ID: "self"}, Roles: []string{"bot"}}
+	mod := &discordgo.Member{User: &discordgo.User{ID: "mod"}, Roles: []string{"mod"}}
+	admin := &discordgo.Member{User: &discordgo.User{ID: "admin"}, Roles: []string{"admin"}}
+	owner := &discordgo.Member{User: &discordgo.User{ID: "owner"}}
+
+	assert.Nil(t, CheckAssignable(guild, mod, self, "low"))
+	assert.Nil(t, CheckAssignable(guild, admin, self, "mod"))
+	assert.Nil(t, CheckAssignable(guild, owner, self, "mod"))
+
+	assert.ErrorIs(t, CheckAssignable(guild, mod, self, "unknown"), ErrRoleNotFound)
+	assert.ErrorIs(t, CheckAssignable(guild, mod, self, "guild"), ErrRoleNotFound)
+	assert.ErrorIs(t, CheckAssignable(guild, mod, self, "integration"), ErrRoleManaged)
+	assert.ErrorIs(t, CheckAssignable(guild, mod, self, "mod"), ErrRoleDiff)
+	assert.ErrorIs(t, CheckAssignable(guild, owner, self, "bot"), ErrRoleSelfDiff)
+	assert.ErrorIs(t, CheckAssignable(guild, admin, self, "admin"), ErrRoleSelfDiff)
+}
@@ -0,0 +1,75 @@
+// Package voicemove provides a helper to move
+// multiple members between voice channels.
+package voicemove
+
+import (
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/zekroTJA/shinpuru/pkg/discordutil"
+)
+
+const (
+	// batchSize is the number of members moved
+	// before pausing.
+	batchSize = 10
+	// batchDelay is the pause between two batches
+	// so that large moves do not exhaust the rate
+	// limit of the guild member endpoint, which is
+	// shared with other requests to the guild.
+	batchDelay = time.Second
+)
+
+// sleep is used to pause between batches and can
+// be replaced in tests.
+var sleep = time.Sleep
+
+// Result summarizes a move operation.
+type Result struct {
+	// Moved is the number of members which have
+	// been moved successfully.
+	Moved int
+	// Disconnected is the number of members which
+	// have left the voice channel or the guild
+	// before they could be moved.
+	Disconnected int
+	// Failed contains the errors of members which
+	// could not be moved by their user ID.
+	Failed map[string]error
+}
+
+// Move moves the members with the given user IDs
+// into the voice channel with the given ID.
+//
+// Members are moved in batches with a pause after
+// each batch. Members which have disconnected in
+// the meantime are skipped and counted in the
+// result. Other errors do not cancel the move of
+// the remaining members and are collected in the
+// result as well.
+func Move(s discordutil.ISession, guildID, channelID string, userIDs []string) (res Result) {
+	res.Failed = make(map[string]error)
+
+	for i, userID := range userIDs {
+		if i > 0 && i%batchSize == 0 {
+			sleep(batchDelay)
+		}
+
+		err := s.GuildMemberMove(guildID, userID, &channelID)
+		switch {
+		case err == nil:
+			res.Moved++
+		case isDisconnected(err):
+			res.Disconnected++
+		default:
+			res.Failed[userID] = err
+		}
+	}
+
+	return res
+}
+
+func isDisconnected(err error) bool {
+	return discordutil.IsErrCode(err, discordgo.ErrCodeTargetIsNotConnectedToVoice) ||
+		discordutil.IsErrCode(err, discordgo.ErrCodeUnknownMember)
+}
@@ -0,0 +1,48 @@
+package voicemove
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/zekroTJA/shinpuru/mocks"
+)
+
+func restErr(code int) error {
+	return &discordgo.RESTError{Message: &discordgo.APIErrorMessage{Code: code}}
+}
+
+func TestMove(t *testing.T) {
+	var sleeps int
+	sleep = func(time.Duration) { sleeps++ }
+
+	s := &mocks.ISession{}
+
+	userIDs := make([]string, 0, 25)
+	for i := 0; i < 25; i++ {
+		userIDs = append(userIDs, fmt.Sprintf("user-%d", i))
+	}
+
+	errFailed := errors.New("failed")
+	s.On("GuildMemberMove", "guild", "user-3", mock.Anything).
+		Return(restErr(discordgo.ErrCodeTargetIsNotConnectedToVoice))
+	s.On("GuildMemberMove", "guild", "user-4", mock.Anything).
+		Return(restErr(discordgo.ErrCodeUnknownMember))
+	s.On("GuildMemberMove", "guild", "user-5", mock.Anything).
+		Return(errFailed)
+	s.On("GuildMemberMove", "guild", mock.Anything, mock.MatchedBy(func(id *string) bool {
+		return *id == "channel"
+	})).Return(nil)
+
+	res := Move(s, "guild", "channel", userIDs)
+
+	assert.Equal(t, 22, res.Moved)
+	assert.Equal(t, 2, res.Disconnected)
+	assert.Equal(t, map[string]error{"user-5": errFailed}, res.Failed)
+	assert.Equal(t, 2, sleeps)
+	s.AssertNumberOfCalls(t, "GuildMemberMove", 25)
+}
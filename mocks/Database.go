@@ -27,13 +27,55 @@ type Database struct {
 	mock.Mock
 }
 
-// AddBackup provides a mock function with given fields: guildID, fileID
-func (_m *Database) AddBackup(guildID string, fileID string) error {
-	ret := _m.Called(guildID, fileID)
+// AddAPITokenHit provides a mock function with given fields: userID, t
+func (_m *Database) AddAPITokenHit(userID string, t time.Time) error {
+	ret := _m.Called(userID, t)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string) error); ok {
-		r0 = rf(guildID, fileID)
+	if rf, ok := ret.Get(0).(func(string, time.Time) error); ok {
+		r0 = rf(userID, t)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AddBackup provides a mock function with given fields: guildID, fileID, manual
+func (_m *Database) AddBackup(guildID string, fileID string, manual bool) error {
+	ret := _m.Called(guildID, fileID, manual)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, bool) error); ok {
+		r0 = rf(guildID, fileID, manual)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AddBroadcast provides a mock function with given fields: b
+func (_m *Database) AddBroadcast(b models.Broadcast) error {
+	ret := _m.Called(b)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(models.Broadcast) error); ok {
+		r0 = rf(b)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AddBroadcastDelivery provides a mock function with given fields: d
+func (_m *Database) AddBroadcastDelivery(d models.BroadcastDelivery) error {
+	ret := _m.Called(d)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(models.BroadcastDelivery) error); ok {
+		r0 = rf(d)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -41,6 +83,30 @@ func (_m *Database) AddBackup(guildID string, fileID string) error {
 	return r0
 }
 
+// AddGiveawayEntry provides a mock function with given fields: id, guildID, userID
+func (_m *Database) AddGiveawayEntry(id snowflake.ID, guildID string, userID string) (bool, error) {
+	ret := _m.Called(id, guildID, userID)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(snowflake.ID, string, string) (bool, error)); ok {
+		return rf(id, guildID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(snowflake.ID, string, string) bool); ok {
+		r0 = rf(id, guildID, userID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(snowflake.ID, string, string) error); ok {
+		r1 = rf(id, guildID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // AddGuildLogEntry provides a mock function with given fields: entry
 func (_m *Database) AddGuildLogEntry(entry models.GuildLogEntry) error {
 	ret := _m.Called(entry)
@@ -55,6 +121,20 @@ func (_m *Database) AddGuildLogEntry(entry models.GuildLogEntry) error {
 	return r0
 }
 
+// AddInviteJoin provides a mock function with given fields: j
+func (_m *Database) AddInviteJoin(j models.InviteJoin) error {
+	ret := _m.Called(j)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(models.InviteJoin) error); ok {
+		r0 = rf(j)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // AddKarmaBlockList provides a mock function with given fields: guildID, userID
 func (_m *Database) AddKarmaBlockList(guildID string, userID string) error {
 	ret := _m.Called(guildID, userID)
@@ -69,6 +149,20 @@ func (_m *Database) AddKarmaBlockList(guildID string, userID string) error {
 	return r0
 }
 
+// AddMessageStats provides a mock function with given fields: entries
+func (_m *Database) AddMessageStats(entries []models.MessageStatsEntry) error {
+	ret := _m.Called(entries)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]models.MessageStatsEntry) error); ok {
+		r0 = rf(entries)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // AddOrUpdateKarmaRule provides a mock function with given fields: rule
 func (_m *Database) AddOrUpdateKarmaRule(rule models.KarmaRule) error {
 	ret := _m.Called(rule)
@@ -83,6 +177,20 @@ func (_m *Database) AddOrUpdateKarmaRule(rule models.KarmaRule) error {
 	return r0
 }
 
+// AddPrefixlessChannel provides a mock function with given fields: guildID, channelID
+func (_m *Database) AddPrefixlessChannel(guildID string, channelID string) error {
+	ret := _m.Called(guildID, channelID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(guildID, channelID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // AddReport provides a mock function with given fields: rep
 func (_m *Database) AddReport(rep models.Report) error {
 	ret := _m.Called(rep)
@@ -97,6 +205,20 @@ func (_m *Database) AddReport(rep models.Report) error {
 	return r0
 }
 
+// AddReportImport provides a mock function with given fields: guildID, externalID, reportID
+func (_m *Database) AddReportImport(guildID string, externalID string, reportID snowflake.ID) error {
+	ret := _m.Called(guildID, externalID, reportID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, snowflake.ID) error); ok {
+		r0 = rf(guildID, externalID, reportID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // AddRoleSelects provides a mock function with given fields: v
 func (_m *Database) AddRoleSelects(v []models.RoleSelect) error {
 	ret := _m.Called(v)
@@ -111,6 +233,20 @@ func (_m *Database) AddRoleSelects(v []models.RoleSelect) error {
 	return r0
 }
 
+// AddSettingsAuditEntry provides a mock function with given fields: e
+func (_m *Database) AddSettingsAuditEntry(e models.SettingsAuditEntry) error {
+	ret := _m.Called(e)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(models.SettingsAuditEntry) error); ok {
+		r0 = rf(e)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // AddTag provides a mock function with given fields: _a0
 func (_m *Database) AddTag(_a0 tag.Tag) error {
 	ret := _m.Called(_a0)
@@ -139,6 +275,20 @@ func (_m *Database) AddToAntiraidJoinList(guildID string, userID string, userTag
 	return r0
 }
 
+// AddTrackedInvite provides a mock function with given fields: inv
+func (_m *Database) AddTrackedInvite(inv models.TrackedInvite) error {
+	ret := _m.Called(inv)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(models.TrackedInvite) error); ok {
+		r0 = rf(inv)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // AddUnbanRequest provides a mock function with given fields: request
 func (_m *Database) AddUnbanRequest(request models.UnbanRequest) error {
 	ret := _m.Called(request)
@@ -167,6 +317,20 @@ func (_m *Database) AddUpdateVote(votes vote.Vote) error {
 	return r0
 }
 
+// AddUserNote provides a mock function with given fields: n
+func (_m *Database) AddUserNote(n models.UserNote) error {
+	ret := _m.Called(n)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(models.UserNote) error); ok {
+		r0 = rf(n)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // AddVerificationQueue provides a mock function with given fields: e
 func (_m *Database) AddVerificationQueue(e models.VerificationQueueEntry) error {
 	ret := _m.Called(e)
@@ -229,6 +393,30 @@ func (_m *Database) CleanupExpiredRefreshTokens() (int64, error) {
 	return r0, r1
 }
 
+// CleanupMessageStats provides a mock function with given fields: before
+func (_m *Database) CleanupMessageStats(before time.Time) (int64, error) {
+	ret := _m.Called(before)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time) (int64, error)); ok {
+		return rf(before)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time) int64); ok {
+		r0 = rf(before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Close provides a mock function with given fields:
 func (_m *Database) Close() {
 	_m.Called()
@@ -278,6 +466,20 @@ func (_m *Database) DeleteBackup(guildID string, fileID string) error {
 	return r0
 }
 
+// DeleteBackupSchedule provides a mock function with given fields: guildID
+func (_m *Database) DeleteBackupSchedule(guildID string) error {
+	ret := _m.Called(guildID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(guildID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteBirthday provides a mock function with given fields: guildID, userID
 func (_m *Database) DeleteBirthday(guildID string, userID string) error {
 	ret := _m.Called(guildID, userID)
@@ -292,6 +494,20 @@ func (_m *Database) DeleteBirthday(guildID string, userID string) error {
 	return r0
 }
 
+// DeleteGuildReportType provides a mock function with given fields: guildID, id
+func (_m *Database) DeleteGuildReportType(guildID string, id models.ReportType) error {
+	ret := _m.Called(guildID, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, models.ReportType) error); ok {
+		r0 = rf(guildID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteLockChan provides a mock function with given fields: chanID
 func (_m *Database) DeleteLockChan(chanID string) error {
 	ret := _m.Called(chanID)
@@ -334,6 +550,34 @@ func (_m *Database) DeleteLogEntry(guildID string, id snowflake.ID) error {
 	return r0
 }
 
+// DeleteMemberActivities provides a mock function with given fields: guildID
+func (_m *Database) DeleteMemberActivities(guildID string) error {
+	ret := _m.Called(guildID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(guildID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteMessageStats provides a mock function with given fields: guildID
+func (_m *Database) DeleteMessageStats(guildID string) error {
+	ret := _m.Called(guildID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(guildID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteReport provides a mock function with given fields: id
 func (_m *Database) DeleteReport(id snowflake.ID) error {
 	ret := _m.Called(id)
@@ -376,6 +620,20 @@ func (_m *Database) DeleteTwitchNotify(twitchUserID string, guildID string) erro
 	return r0
 }
 
+// DeleteUserNote provides a mock function with given fields: guildID, id
+func (_m *Database) DeleteUserNote(guildID string, id snowflake.ID) error {
+	ret := _m.Called(guildID, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, snowflake.ID) error); ok {
+		r0 = rf(guildID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteVote provides a mock function with given fields: voteID
 func (_m *Database) DeleteVote(voteID string) error {
 	ret := _m.Called(voteID)
@@ -404,6 +662,30 @@ func (_m *Database) EditTag(_a0 tag.Tag) error {
 	return r0
 }
 
+// EndGiveaway provides a mock function with given fields: id, winnerIDs
+func (_m *Database) EndGiveaway(id snowflake.ID, winnerIDs []string) (bool, error) {
+	ret := _m.Called(id, winnerIDs)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(snowflake.ID, []string) (bool, error)); ok {
+		return rf(id, winnerIDs)
+	}
+	if rf, ok := ret.Get(0).(func(snowflake.ID, []string) bool); ok {
+		r0 = rf(id, winnerIDs)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(snowflake.ID, []string) error); ok {
+		r1 = rf(id, winnerIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ExpireReports provides a mock function with given fields: id
 func (_m *Database) ExpireReports(id ...string) error {
 	_va := make([]interface{}, len(id))
@@ -516,6 +798,32 @@ func (_m *Database) GetAPIToken(userID string) (models.APITokenEntry, error) {
 	return r0, r1
 }
 
+// GetAllGuildRules provides a mock function with given fields:
+func (_m *Database) GetAllGuildRules() ([]models.GuildRules, error) {
+	ret := _m.Called()
+
+	var r0 []models.GuildRules
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]models.GuildRules, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []models.GuildRules); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.GuildRules)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetAllTwitchNotifies provides a mock function with given fields: twitchUserID
 func (_m *Database) GetAllTwitchNotifies(twitchUserID string) ([]twitchnotify.DBEntry, error) {
 	ret := _m.Called(twitchUserID)
@@ -664,25 +972,23 @@ func (_m *Database) GetAntiraidVerification(guildID string) (bool, error) {
 	return r0, r1
 }
 
-// GetBackups provides a mock function with given fields: guildID
-func (_m *Database) GetBackups(guildID string) ([]backupmodels.Entry, error) {
-	ret := _m.Called(guildID)
+// GetAutoDelete provides a mock function with given fields: guildID, channelID
+func (_m *Database) GetAutoDelete(guildID string, channelID string) (models.AutoDeleteConfig, error) {
+	ret := _m.Called(guildID, channelID)
 
-	var r0 []backupmodels.Entry
+	var r0 models.AutoDeleteConfig
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) ([]backupmodels.Entry, error)); ok {
-		return rf(guildID)
+	if rf, ok := ret.Get(0).(func(string, string) (models.AutoDeleteConfig, error)); ok {
+		return rf(guildID, channelID)
 	}
-	if rf, ok := ret.Get(0).(func(string) []backupmodels.Entry); ok {
-		r0 = rf(guildID)
+	if rf, ok := ret.Get(0).(func(string, string) models.AutoDeleteConfig); ok {
+		r0 = rf(guildID, channelID)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]backupmodels.Entry)
-		}
+		r0 = ret.Get(0).(models.AutoDeleteConfig)
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(guildID)
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(guildID, channelID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -690,20 +996,20 @@ func (_m *Database) GetBackups(guildID string) ([]backupmodels.Entry, error) {
 	return r0, r1
 }
 
-// GetBirthdays provides a mock function with given fields: guildID
-func (_m *Database) GetBirthdays(guildID string) ([]models.Birthday, error) {
+// GetAutoDeletes provides a mock function with given fields: guildID
+func (_m *Database) GetAutoDeletes(guildID string) ([]models.AutoDeleteConfig, error) {
 	ret := _m.Called(guildID)
 
-	var r0 []models.Birthday
+	var r0 []models.AutoDeleteConfig
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) ([]models.Birthday, error)); ok {
+	if rf, ok := ret.Get(0).(func(string) ([]models.AutoDeleteConfig, error)); ok {
 		return rf(guildID)
 	}
-	if rf, ok := ret.Get(0).(func(string) []models.Birthday); ok {
+	if rf, ok := ret.Get(0).(func(string) []models.AutoDeleteConfig); ok {
 		r0 = rf(guildID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.Birthday)
+			r0 = ret.Get(0).([]models.AutoDeleteConfig)
 		}
 	}
 
@@ -716,25 +1022,23 @@ func (_m *Database) GetBirthdays(guildID string) ([]models.Birthday, error) {
 	return r0, r1
 }
 
-// GetExpiredReports provides a mock function with given fields:
-func (_m *Database) GetExpiredReports() ([]models.Report, error) {
-	ret := _m.Called()
+// GetAutoPublish provides a mock function with given fields: guildID, channelID
+func (_m *Database) GetAutoPublish(guildID string, channelID string) (models.AutoPublishConfig, error) {
+	ret := _m.Called(guildID, channelID)
 
-	var r0 []models.Report
+	var r0 models.AutoPublishConfig
 	var r1 error
-	if rf, ok := ret.Get(0).(func() ([]models.Report, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(string, string) (models.AutoPublishConfig, error)); ok {
+		return rf(guildID, channelID)
 	}
-	if rf, ok := ret.Get(0).(func() []models.Report); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(string, string) models.AutoPublishConfig); ok {
+		r0 = rf(guildID, channelID)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.Report)
-		}
+		r0 = ret.Get(0).(models.AutoPublishConfig)
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(guildID, channelID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -742,19 +1046,21 @@ func (_m *Database) GetExpiredReports() ([]models.Report, error) {
 	return r0, r1
 }
 
-// GetGuildAPI provides a mock function with given fields: guildID
-func (_m *Database) GetGuildAPI(guildID string) (models.GuildAPISettings, error) {
+// GetAutoPublishes provides a mock function with given fields: guildID
+func (_m *Database) GetAutoPublishes(guildID string) ([]models.AutoPublishConfig, error) {
 	ret := _m.Called(guildID)
 
-	var r0 models.GuildAPISettings
+	var r0 []models.AutoPublishConfig
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (models.GuildAPISettings, error)); ok {
+	if rf, ok := ret.Get(0).(func(string) ([]models.AutoPublishConfig, error)); ok {
 		return rf(guildID)
 	}
-	if rf, ok := ret.Get(0).(func(string) models.GuildAPISettings); ok {
+	if rf, ok := ret.Get(0).(func(string) []models.AutoPublishConfig); ok {
 		r0 = rf(guildID)
 	} else {
-		r0 = ret.Get(0).(models.GuildAPISettings)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.AutoPublishConfig)
+		}
 	}
 
 	if rf, ok := ret.Get(1).(func(string) error); ok {
@@ -766,25 +1072,23 @@ func (_m *Database) GetGuildAPI(guildID string) (models.GuildAPISettings, error)
 	return r0, r1
 }
 
-// GetGuildAutoRole provides a mock function with given fields: guildID
-func (_m *Database) GetGuildAutoRole(guildID string) ([]string, error) {
-	ret := _m.Called(guildID)
+// GetAutoThread provides a mock function with given fields: guildID, channelID
+func (_m *Database) GetAutoThread(guildID string, channelID string) (models.AutoThreadConfig, error) {
+	ret := _m.Called(guildID, channelID)
 
-	var r0 []string
+	var r0 models.AutoThreadConfig
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) ([]string, error)); ok {
-		return rf(guildID)
+	if rf, ok := ret.Get(0).(func(string, string) (models.AutoThreadConfig, error)); ok {
+		return rf(guildID, channelID)
 	}
-	if rf, ok := ret.Get(0).(func(string) []string); ok {
-		r0 = rf(guildID)
+	if rf, ok := ret.Get(0).(func(string, string) models.AutoThreadConfig); ok {
+		r0 = rf(guildID, channelID)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]string)
-		}
+		r0 = ret.Get(0).(models.AutoThreadConfig)
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(guildID)
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(guildID, channelID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -792,20 +1096,20 @@ func (_m *Database) GetGuildAutoRole(guildID string) ([]string, error) {
 	return r0, r1
 }
 
-// GetGuildAutoVC provides a mock function with given fields: guildID
-func (_m *Database) GetGuildAutoVC(guildID string) ([]string, error) {
+// GetAutoThreads provides a mock function with given fields: guildID
+func (_m *Database) GetAutoThreads(guildID string) ([]models.AutoThreadConfig, error) {
 	ret := _m.Called(guildID)
 
-	var r0 []string
+	var r0 []models.AutoThreadConfig
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) ([]string, error)); ok {
+	if rf, ok := ret.Get(0).(func(string) ([]models.AutoThreadConfig, error)); ok {
 		return rf(guildID)
 	}
-	if rf, ok := ret.Get(0).(func(string) []string); ok {
+	if rf, ok := ret.Get(0).(func(string) []models.AutoThreadConfig); ok {
 		r0 = rf(guildID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]string)
+			r0 = ret.Get(0).([]models.AutoThreadConfig)
 		}
 	}
 
@@ -818,19 +1122,19 @@ func (_m *Database) GetGuildAutoVC(guildID string) ([]string, error) {
 	return r0, r1
 }
 
-// GetGuildBackup provides a mock function with given fields: guildID
-func (_m *Database) GetGuildBackup(guildID string) (bool, error) {
+// GetBackupSchedule provides a mock function with given fields: guildID
+func (_m *Database) GetBackupSchedule(guildID string) (backupmodels.Schedule, error) {
 	ret := _m.Called(guildID)
 
-	var r0 bool
+	var r0 backupmodels.Schedule
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (bool, error)); ok {
+	if rf, ok := ret.Get(0).(func(string) (backupmodels.Schedule, error)); ok {
 		return rf(guildID)
 	}
-	if rf, ok := ret.Get(0).(func(string) bool); ok {
+	if rf, ok := ret.Get(0).(func(string) backupmodels.Schedule); ok {
 		r0 = rf(guildID)
 	} else {
-		r0 = ret.Get(0).(bool)
+		r0 = ret.Get(0).(backupmodels.Schedule)
 	}
 
 	if rf, ok := ret.Get(1).(func(string) error); ok {
@@ -842,23 +1146,25 @@ func (_m *Database) GetGuildBackup(guildID string) (bool, error) {
 	return r0, r1
 }
 
-// GetGuildBirthdayChan provides a mock function with given fields: guildID
-func (_m *Database) GetGuildBirthdayChan(guildID string) (string, error) {
-	ret := _m.Called(guildID)
+// GetBackupSchedules provides a mock function with given fields:
+func (_m *Database) GetBackupSchedules() ([]backupmodels.Schedule, error) {
+	ret := _m.Called()
 
-	var r0 string
+	var r0 []backupmodels.Schedule
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
-		return rf(guildID)
+	if rf, ok := ret.Get(0).(func() ([]backupmodels.Schedule, error)); ok {
+		return rf()
 	}
-	if rf, ok := ret.Get(0).(func(string) string); ok {
-		r0 = rf(guildID)
+	if rf, ok := ret.Get(0).(func() []backupmodels.Schedule); ok {
+		r0 = rf()
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]backupmodels.Schedule)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(guildID)
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -866,19 +1172,21 @@ func (_m *Database) GetGuildBirthdayChan(guildID string) (string, error) {
 	return r0, r1
 }
 
-// GetGuildCodeExecEnabled provides a mock function with given fields: guildID
-func (_m *Database) GetGuildCodeExecEnabled(guildID string) (bool, error) {
+// GetBackups provides a mock function with given fields: guildID
+func (_m *Database) GetBackups(guildID string) ([]backupmodels.Entry, error) {
 	ret := _m.Called(guildID)
 
-	var r0 bool
+	var r0 []backupmodels.Entry
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (bool, error)); ok {
+	if rf, ok := ret.Get(0).(func(string) ([]backupmodels.Entry, error)); ok {
 		return rf(guildID)
 	}
-	if rf, ok := ret.Get(0).(func(string) bool); ok {
+	if rf, ok := ret.Get(0).(func(string) []backupmodels.Entry); ok {
 		r0 = rf(guildID)
 	} else {
-		r0 = ret.Get(0).(bool)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]backupmodels.Entry)
+		}
 	}
 
 	if rf, ok := ret.Get(1).(func(string) error); ok {
@@ -890,19 +1198,21 @@ func (_m *Database) GetGuildCodeExecEnabled(guildID string) (bool, error) {
 	return r0, r1
 }
 
-// GetGuildColorReaction provides a mock function with given fields: guildID
-func (_m *Database) GetGuildColorReaction(guildID string) (bool, error) {
+// GetBirthdays provides a mock function with given fields: guildID
+func (_m *Database) GetBirthdays(guildID string) ([]models.Birthday, error) {
 	ret := _m.Called(guildID)
 
-	var r0 bool
+	var r0 []models.Birthday
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (bool, error)); ok {
+	if rf, ok := ret.Get(0).(func(string) ([]models.Birthday, error)); ok {
 		return rf(guildID)
 	}
-	if rf, ok := ret.Get(0).(func(string) bool); ok {
+	if rf, ok := ret.Get(0).(func(string) []models.Birthday); ok {
 		r0 = rf(guildID)
 	} else {
-		r0 = ret.Get(0).(bool)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Birthday)
+		}
 	}
 
 	if rf, ok := ret.Get(1).(func(string) error); ok {
@@ -914,23 +1224,23 @@ func (_m *Database) GetGuildColorReaction(guildID string) (bool, error) {
 	return r0, r1
 }
 
-// GetGuildGhostpingMsg provides a mock function with given fields: guildID
-func (_m *Database) GetGuildGhostpingMsg(guildID string) (string, error) {
-	ret := _m.Called(guildID)
+// GetBroadcast provides a mock function with given fields: id
+func (_m *Database) GetBroadcast(id snowflake.ID) (models.Broadcast, error) {
+	ret := _m.Called(id)
 
-	var r0 string
+	var r0 models.Broadcast
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
-		return rf(guildID)
+	if rf, ok := ret.Get(0).(func(snowflake.ID) (models.Broadcast, error)); ok {
+		return rf(id)
 	}
-	if rf, ok := ret.Get(0).(func(string) string); ok {
-		r0 = rf(guildID)
+	if rf, ok := ret.Get(0).(func(snowflake.ID) models.Broadcast); ok {
+		r0 = rf(id)
 	} else {
-		r0 = ret.Get(0).(string)
+		r0 = ret.Get(0).(models.Broadcast)
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(guildID)
+	if rf, ok := ret.Get(1).(func(snowflake.ID) error); ok {
+		r1 = rf(id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -938,23 +1248,25 @@ func (_m *Database) GetGuildGhostpingMsg(guildID string) (string, error) {
 	return r0, r1
 }
 
-// GetGuildInviteBlock provides a mock function with given fields: guildID
-func (_m *Database) GetGuildInviteBlock(guildID string) (string, error) {
-	ret := _m.Called(guildID)
+// GetBroadcastDeliveries provides a mock function with given fields: id
+func (_m *Database) GetBroadcastDeliveries(id snowflake.ID) ([]models.BroadcastDelivery, error) {
+	ret := _m.Called(id)
 
-	var r0 string
+	var r0 []models.BroadcastDelivery
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
-		return rf(guildID)
+	if rf, ok := ret.Get(0).(func(snowflake.ID) ([]models.BroadcastDelivery, error)); ok {
+		return rf(id)
 	}
-	if rf, ok := ret.Get(0).(func(string) string); ok {
-		r0 = rf(guildID)
+	if rf, ok := ret.Get(0).(func(snowflake.ID) []models.BroadcastDelivery); ok {
+		r0 = rf(id)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.BroadcastDelivery)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(guildID)
+	if rf, ok := ret.Get(1).(func(snowflake.ID) error); ok {
+		r1 = rf(id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -962,23 +1274,25 @@ func (_m *Database) GetGuildInviteBlock(guildID string) (string, error) {
 	return r0, r1
 }
 
-// GetGuildJdoodleKey provides a mock function with given fields: guildID
-func (_m *Database) GetGuildJdoodleKey(guildID string) (string, error) {
-	ret := _m.Called(guildID)
+// GetBroadcasts provides a mock function with given fields: unfinishedOnly
+func (_m *Database) GetBroadcasts(unfinishedOnly bool) ([]models.Broadcast, error) {
+	ret := _m.Called(unfinishedOnly)
 
-	var r0 string
+	var r0 []models.Broadcast
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
-		return rf(guildID)
+	if rf, ok := ret.Get(0).(func(bool) ([]models.Broadcast, error)); ok {
+		return rf(unfinishedOnly)
 	}
-	if rf, ok := ret.Get(0).(func(string) string); ok {
-		r0 = rf(guildID)
+	if rf, ok := ret.Get(0).(func(bool) []models.Broadcast); ok {
+		r0 = rf(unfinishedOnly)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Broadcast)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(guildID)
+	if rf, ok := ret.Get(1).(func(bool) error); ok {
+		r1 = rf(unfinishedOnly)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -986,85 +1300,99 @@ func (_m *Database) GetGuildJdoodleKey(guildID string) (string, error) {
 	return r0, r1
 }
 
-// GetGuildJoinMsg provides a mock function with given fields: guildID
-func (_m *Database) GetGuildJoinMsg(guildID string) (string, string, error) {
-	ret := _m.Called(guildID)
+// GetEnabledInactivitySettings provides a mock function with given fields:
+func (_m *Database) GetEnabledInactivitySettings() ([]models.InactivitySettings, error) {
+	ret := _m.Called()
 
-	var r0 string
-	var r1 string
-	var r2 error
-	if rf, ok := ret.Get(0).(func(string) (string, string, error)); ok {
-		return rf(guildID)
-	}
-	if rf, ok := ret.Get(0).(func(string) string); ok {
-		r0 = rf(guildID)
-	} else {
-		r0 = ret.Get(0).(string)
+	var r0 []models.InactivitySettings
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]models.InactivitySettings, error)); ok {
+		return rf()
 	}
-
-	if rf, ok := ret.Get(1).(func(string) string); ok {
-		r1 = rf(guildID)
+	if rf, ok := ret.Get(0).(func() []models.InactivitySettings); ok {
+		r0 = rf()
 	} else {
-		r1 = ret.Get(1).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.InactivitySettings)
+		}
 	}
 
-	if rf, ok := ret.Get(2).(func(string) error); ok {
-		r2 = rf(guildID)
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
 	} else {
-		r2 = ret.Error(2)
+		r1 = ret.Error(1)
 	}
 
-	return r0, r1, r2
+	return r0, r1
 }
 
-// GetGuildLeaveMsg provides a mock function with given fields: guildID
-func (_m *Database) GetGuildLeaveMsg(guildID string) (string, string, error) {
-	ret := _m.Called(guildID)
+// GetExpiredReports provides a mock function with given fields:
+func (_m *Database) GetExpiredReports() ([]models.Report, error) {
+	ret := _m.Called()
 
-	var r0 string
-	var r1 string
-	var r2 error
-	if rf, ok := ret.Get(0).(func(string) (string, string, error)); ok {
-		return rf(guildID)
+	var r0 []models.Report
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]models.Report, error)); ok {
+		return rf()
 	}
-	if rf, ok := ret.Get(0).(func(string) string); ok {
-		r0 = rf(guildID)
+	if rf, ok := ret.Get(0).(func() []models.Report); ok {
+		r0 = rf()
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Report)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) string); ok {
-		r1 = rf(guildID)
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
 	} else {
-		r1 = ret.Get(1).(string)
+		r1 = ret.Error(1)
 	}
 
-	if rf, ok := ret.Get(2).(func(string) error); ok {
-		r2 = rf(guildID)
+	return r0, r1
+}
+
+// GetGiveaway provides a mock function with given fields: id
+func (_m *Database) GetGiveaway(id snowflake.ID) (models.Giveaway, error) {
+	ret := _m.Called(id)
+
+	var r0 models.Giveaway
+	var r1 error
+	if rf, ok := ret.Get(0).(func(snowflake.ID) (models.Giveaway, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(snowflake.ID) models.Giveaway); ok {
+		r0 = rf(id)
 	} else {
-		r2 = ret.Error(2)
+		r0 = ret.Get(0).(models.Giveaway)
 	}
 
-	return r0, r1, r2
+	if rf, ok := ret.Get(1).(func(snowflake.ID) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// GetGuildLogDisable provides a mock function with given fields: guildID
-func (_m *Database) GetGuildLogDisable(guildID string) (bool, error) {
-	ret := _m.Called(guildID)
+// GetGiveawayByMessage provides a mock function with given fields: messageID
+func (_m *Database) GetGiveawayByMessage(messageID string) (models.Giveaway, error) {
+	ret := _m.Called(messageID)
 
-	var r0 bool
+	var r0 models.Giveaway
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (bool, error)); ok {
-		return rf(guildID)
+	if rf, ok := ret.Get(0).(func(string) (models.Giveaway, error)); ok {
+		return rf(messageID)
 	}
-	if rf, ok := ret.Get(0).(func(string) bool); ok {
-		r0 = rf(guildID)
+	if rf, ok := ret.Get(0).(func(string) models.Giveaway); ok {
+		r0 = rf(messageID)
 	} else {
-		r0 = ret.Get(0).(bool)
+		r0 = ret.Get(0).(models.Giveaway)
 	}
 
 	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(guildID)
+		r1 = rf(messageID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1072,25 +1400,25 @@ func (_m *Database) GetGuildLogDisable(guildID string) (bool, error) {
 	return r0, r1
 }
 
-// GetGuildLogEntries provides a mock function with given fields: guildID, offset, limit, severity, ascending
-func (_m *Database) GetGuildLogEntries(guildID string, offset int, limit int, severity models.GuildLogSeverity, ascending bool) ([]models.GuildLogEntry, error) {
-	ret := _m.Called(guildID, offset, limit, severity, ascending)
+// GetGiveawayEntries provides a mock function with given fields: id
+func (_m *Database) GetGiveawayEntries(id snowflake.ID) ([]string, error) {
+	ret := _m.Called(id)
 
-	var r0 []models.GuildLogEntry
+	var r0 []string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, int, int, models.GuildLogSeverity, bool) ([]models.GuildLogEntry, error)); ok {
-		return rf(guildID, offset, limit, severity, ascending)
+	if rf, ok := ret.Get(0).(func(snowflake.ID) ([]string, error)); ok {
+		return rf(id)
 	}
-	if rf, ok := ret.Get(0).(func(string, int, int, models.GuildLogSeverity, bool) []models.GuildLogEntry); ok {
-		r0 = rf(guildID, offset, limit, severity, ascending)
+	if rf, ok := ret.Get(0).(func(snowflake.ID) []string); ok {
+		r0 = rf(id)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.GuildLogEntry)
+			r0 = ret.Get(0).([]string)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, int, int, models.GuildLogSeverity, bool) error); ok {
-		r1 = rf(guildID, offset, limit, severity, ascending)
+	if rf, ok := ret.Get(1).(func(snowflake.ID) error); ok {
+		r1 = rf(id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1098,23 +1426,25 @@ func (_m *Database) GetGuildLogEntries(guildID string, offset int, limit int, se
 	return r0, r1
 }
 
-// GetGuildLogEntriesCount provides a mock function with given fields: guildID, severity
-func (_m *Database) GetGuildLogEntriesCount(guildID string, severity models.GuildLogSeverity) (int, error) {
-	ret := _m.Called(guildID, severity)
+// GetGiveaways provides a mock function with given fields: guildID, activeOnly
+func (_m *Database) GetGiveaways(guildID string, activeOnly bool) ([]models.Giveaway, error) {
+	ret := _m.Called(guildID, activeOnly)
 
-	var r0 int
+	var r0 []models.Giveaway
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, models.GuildLogSeverity) (int, error)); ok {
-		return rf(guildID, severity)
+	if rf, ok := ret.Get(0).(func(string, bool) ([]models.Giveaway, error)); ok {
+		return rf(guildID, activeOnly)
 	}
-	if rf, ok := ret.Get(0).(func(string, models.GuildLogSeverity) int); ok {
-		r0 = rf(guildID, severity)
+	if rf, ok := ret.Get(0).(func(string, bool) []models.Giveaway); ok {
+		r0 = rf(guildID, activeOnly)
 	} else {
-		r0 = ret.Get(0).(int)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Giveaway)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, models.GuildLogSeverity) error); ok {
-		r1 = rf(guildID, severity)
+	if rf, ok := ret.Get(1).(func(string, bool) error); ok {
+		r1 = rf(guildID, activeOnly)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1122,19 +1452,19 @@ func (_m *Database) GetGuildLogEntriesCount(guildID string, severity models.Guil
 	return r0, r1
 }
 
-// GetGuildModLog provides a mock function with given fields: guildID
-func (_m *Database) GetGuildModLog(guildID string) (string, error) {
+// GetGuildAPI provides a mock function with given fields: guildID
+func (_m *Database) GetGuildAPI(guildID string) (models.GuildAPISettings, error) {
 	ret := _m.Called(guildID)
 
-	var r0 string
+	var r0 models.GuildAPISettings
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
+	if rf, ok := ret.Get(0).(func(string) (models.GuildAPISettings, error)); ok {
 		return rf(guildID)
 	}
-	if rf, ok := ret.Get(0).(func(string) string); ok {
+	if rf, ok := ret.Get(0).(func(string) models.GuildAPISettings); ok {
 		r0 = rf(guildID)
 	} else {
-		r0 = ret.Get(0).(string)
+		r0 = ret.Get(0).(models.GuildAPISettings)
 	}
 
 	if rf, ok := ret.Get(1).(func(string) error); ok {
@@ -1146,8 +1476,8 @@ func (_m *Database) GetGuildModLog(guildID string) (string, error) {
 	return r0, r1
 }
 
-// GetGuildModNot provides a mock function with given fields: guildID
-func (_m *Database) GetGuildModNot(guildID string) (string, error) {
+// GetGuildAnnouncementChannel provides a mock function with given fields: guildID
+func (_m *Database) GetGuildAnnouncementChannel(guildID string) (string, error) {
 	ret := _m.Called(guildID)
 
 	var r0 string
@@ -1170,19 +1500,21 @@ func (_m *Database) GetGuildModNot(guildID string) (string, error) {
 	return r0, r1
 }
 
-// GetGuildNotifyRole provides a mock function with given fields: guildID
-func (_m *Database) GetGuildNotifyRole(guildID string) (string, error) {
+// GetGuildAutoRole provides a mock function with given fields: guildID
+func (_m *Database) GetGuildAutoRole(guildID string) ([]string, error) {
 	ret := _m.Called(guildID)
 
-	var r0 string
+	var r0 []string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
+	if rf, ok := ret.Get(0).(func(string) ([]string, error)); ok {
 		return rf(guildID)
 	}
-	if rf, ok := ret.Get(0).(func(string) string); ok {
+	if rf, ok := ret.Get(0).(func(string) []string); ok {
 		r0 = rf(guildID)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
 	}
 
 	if rf, ok := ret.Get(1).(func(string) error); ok {
@@ -1194,20 +1526,20 @@ func (_m *Database) GetGuildNotifyRole(guildID string) (string, error) {
 	return r0, r1
 }
 
-// GetGuildPermissions provides a mock function with given fields: guildID
-func (_m *Database) GetGuildPermissions(guildID string) (map[string]permissions.PermissionArray, error) {
+// GetGuildAutoVC provides a mock function with given fields: guildID
+func (_m *Database) GetGuildAutoVC(guildID string) ([]string, error) {
 	ret := _m.Called(guildID)
 
-	var r0 map[string]permissions.PermissionArray
+	var r0 []string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (map[string]permissions.PermissionArray, error)); ok {
+	if rf, ok := ret.Get(0).(func(string) ([]string, error)); ok {
 		return rf(guildID)
 	}
-	if rf, ok := ret.Get(0).(func(string) map[string]permissions.PermissionArray); ok {
+	if rf, ok := ret.Get(0).(func(string) []string); ok {
 		r0 = rf(guildID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(map[string]permissions.PermissionArray)
+			r0 = ret.Get(0).([]string)
 		}
 	}
 
@@ -1220,19 +1552,19 @@ func (_m *Database) GetGuildPermissions(guildID string) (map[string]permissions.
 	return r0, r1
 }
 
-// GetGuildPrefix provides a mock function with given fields: guildID
-func (_m *Database) GetGuildPrefix(guildID string) (string, error) {
+// GetGuildBackup provides a mock function with given fields: guildID
+func (_m *Database) GetGuildBackup(guildID string) (bool, error) {
 	ret := _m.Called(guildID)
 
-	var r0 string
+	var r0 bool
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
+	if rf, ok := ret.Get(0).(func(string) (bool, error)); ok {
 		return rf(guildID)
 	}
-	if rf, ok := ret.Get(0).(func(string) string); ok {
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
 		r0 = rf(guildID)
 	} else {
-		r0 = ret.Get(0).(string)
+		r0 = ret.Get(0).(bool)
 	}
 
 	if rf, ok := ret.Get(1).(func(string) error); ok {
@@ -1244,21 +1576,19 @@ func (_m *Database) GetGuildPrefix(guildID string) (string, error) {
 	return r0, r1
 }
 
-// GetGuildTags provides a mock function with given fields: guildID
-func (_m *Database) GetGuildTags(guildID string) ([]tag.Tag, error) {
+// GetGuildBirthdayChan provides a mock function with given fields: guildID
+func (_m *Database) GetGuildBirthdayChan(guildID string) (string, error) {
 	ret := _m.Called(guildID)
 
-	var r0 []tag.Tag
+	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) ([]tag.Tag, error)); ok {
+	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
 		return rf(guildID)
 	}
-	if rf, ok := ret.Get(0).(func(string) []tag.Tag); ok {
+	if rf, ok := ret.Get(0).(func(string) string); ok {
 		r0 = rf(guildID)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]tag.Tag)
-		}
+		r0 = ret.Get(0).(string)
 	}
 
 	if rf, ok := ret.Get(1).(func(string) error); ok {
@@ -1270,25 +1600,23 @@ func (_m *Database) GetGuildTags(guildID string) ([]tag.Tag, error) {
 	return r0, r1
 }
 
-// GetGuildUnbanRequests provides a mock function with given fields: guildID, limit, offset
-func (_m *Database) GetGuildUnbanRequests(guildID string, limit int, offset int) ([]models.UnbanRequest, error) {
-	ret := _m.Called(guildID, limit, offset)
+// GetGuildBotNickname provides a mock function with given fields: guildID
+func (_m *Database) GetGuildBotNickname(guildID string) (string, error) {
+	ret := _m.Called(guildID)
 
-	var r0 []models.UnbanRequest
+	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, int, int) ([]models.UnbanRequest, error)); ok {
-		return rf(guildID, limit, offset)
+	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
+		return rf(guildID)
 	}
-	if rf, ok := ret.Get(0).(func(string, int, int) []models.UnbanRequest); ok {
-		r0 = rf(guildID, limit, offset)
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(guildID)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.UnbanRequest)
-		}
+		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, int, int) error); ok {
-		r1 = rf(guildID, limit, offset)
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(guildID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1296,23 +1624,23 @@ func (_m *Database) GetGuildUnbanRequests(guildID string, limit int, offset int)
 	return r0, r1
 }
 
-// GetGuildUnbanRequestsCount provides a mock function with given fields: guildID, state
-func (_m *Database) GetGuildUnbanRequestsCount(guildID string, state *models.UnbanRequestState) (int, error) {
-	ret := _m.Called(guildID, state)
+// GetGuildCodeExecEnabled provides a mock function with given fields: guildID
+func (_m *Database) GetGuildCodeExecEnabled(guildID string) (bool, error) {
+	ret := _m.Called(guildID)
 
-	var r0 int
+	var r0 bool
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, *models.UnbanRequestState) (int, error)); ok {
-		return rf(guildID, state)
+	if rf, ok := ret.Get(0).(func(string) (bool, error)); ok {
+		return rf(guildID)
 	}
-	if rf, ok := ret.Get(0).(func(string, *models.UnbanRequestState) int); ok {
-		r0 = rf(guildID, state)
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(guildID)
 	} else {
-		r0 = ret.Get(0).(int)
+		r0 = ret.Get(0).(bool)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, *models.UnbanRequestState) error); ok {
-		r1 = rf(guildID, state)
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(guildID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1320,25 +1648,25 @@ func (_m *Database) GetGuildUnbanRequestsCount(guildID string, state *models.Unb
 	return r0, r1
 }
 
-// GetGuildUserUnbanRequests provides a mock function with given fields: userID, guildID
-func (_m *Database) GetGuildUserUnbanRequests(userID string, guildID string) ([]models.UnbanRequest, error) {
-	ret := _m.Called(userID, guildID)
+// GetGuildCodeExecLanguages provides a mock function with given fields: guildID
+func (_m *Database) GetGuildCodeExecLanguages(guildID string) ([]string, error) {
+	ret := _m.Called(guildID)
 
-	var r0 []models.UnbanRequest
+	var r0 []string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string) ([]models.UnbanRequest, error)); ok {
-		return rf(userID, guildID)
+	if rf, ok := ret.Get(0).(func(string) ([]string, error)); ok {
+		return rf(guildID)
 	}
-	if rf, ok := ret.Get(0).(func(string, string) []models.UnbanRequest); ok {
-		r0 = rf(userID, guildID)
+	if rf, ok := ret.Get(0).(func(string) []string); ok {
+		r0 = rf(guildID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.UnbanRequest)
+			r0 = ret.Get(0).([]string)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(userID, guildID)
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(guildID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1346,8 +1674,8 @@ func (_m *Database) GetGuildUserUnbanRequests(userID string, guildID string) ([]
 	return r0, r1
 }
 
-// GetGuildVerificationRequired provides a mock function with given fields: guildID
-func (_m *Database) GetGuildVerificationRequired(guildID string) (bool, error) {
+// GetGuildColorReaction provides a mock function with given fields: guildID
+func (_m *Database) GetGuildColorReaction(guildID string) (bool, error) {
 	ret := _m.Called(guildID)
 
 	var r0 bool
@@ -1370,19 +1698,19 @@ func (_m *Database) GetGuildVerificationRequired(guildID string) (bool, error) {
 	return r0, r1
 }
 
-// GetGuildVoiceLog provides a mock function with given fields: guildID
-func (_m *Database) GetGuildVoiceLog(guildID string) (string, error) {
+// GetGuildCommandSuggestionsDisable provides a mock function with given fields: guildID
+func (_m *Database) GetGuildCommandSuggestionsDisable(guildID string) (bool, error) {
 	ret := _m.Called(guildID)
 
-	var r0 string
+	var r0 bool
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
+	if rf, ok := ret.Get(0).(func(string) (bool, error)); ok {
 		return rf(guildID)
 	}
-	if rf, ok := ret.Get(0).(func(string) string); ok {
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
 		r0 = rf(guildID)
 	} else {
-		r0 = ret.Get(0).(string)
+		r0 = ret.Get(0).(bool)
 	}
 
 	if rf, ok := ret.Get(1).(func(string) error); ok {
@@ -1394,20 +1722,20 @@ func (_m *Database) GetGuildVoiceLog(guildID string) (string, error) {
 	return r0, r1
 }
 
-// GetGuildVoiceLogIgnores provides a mock function with given fields: guildID
-func (_m *Database) GetGuildVoiceLogIgnores(guildID string) ([]string, error) {
+// GetGuildConfirmActions provides a mock function with given fields: guildID
+func (_m *Database) GetGuildConfirmActions(guildID string) ([]models.ConfirmAction, error) {
 	ret := _m.Called(guildID)
 
-	var r0 []string
+	var r0 []models.ConfirmAction
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) ([]string, error)); ok {
+	if rf, ok := ret.Get(0).(func(string) ([]models.ConfirmAction, error)); ok {
 		return rf(guildID)
 	}
-	if rf, ok := ret.Get(0).(func(string) []string); ok {
+	if rf, ok := ret.Get(0).(func(string) []models.ConfirmAction); ok {
 		r0 = rf(guildID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]string)
+			r0 = ret.Get(0).([]models.ConfirmAction)
 		}
 	}
 
@@ -1420,25 +1748,56 @@ func (_m *Database) GetGuildVoiceLogIgnores(guildID string) ([]string, error) {
 	return r0, r1
 }
 
-// GetGuilds provides a mock function with given fields:
-func (_m *Database) GetGuilds() ([]string, error) {
-	ret := _m.Called()
+// GetGuildDisabledCommandMessage provides a mock function with given fields: guildID
+func (_m *Database) GetGuildDisabledCommandMessage(guildID string) (string, bool, error) {
+	ret := _m.Called(guildID)
+
+	var r0 string
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(string) (string, bool, error)); ok {
+		return rf(guildID)
+	}
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(guildID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) bool); ok {
+		r1 = rf(guildID)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(string) error); ok {
+		r2 = rf(guildID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetGuildDisabledCommands provides a mock function with given fields: guildID
+func (_m *Database) GetGuildDisabledCommands(guildID string) ([]string, error) {
+	ret := _m.Called(guildID)
 
 	var r0 []string
 	var r1 error
-	if rf, ok := ret.Get(0).(func() ([]string, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(string) ([]string, error)); ok {
+		return rf(guildID)
 	}
-	if rf, ok := ret.Get(0).(func() []string); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(string) []string); ok {
+		r0 = rf(guildID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]string)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(guildID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1446,23 +1805,23 @@ func (_m *Database) GetGuilds() ([]string, error) {
 	return r0, r1
 }
 
-// GetKarma provides a mock function with given fields: userID, guildID
-func (_m *Database) GetKarma(userID string, guildID string) (int, error) {
-	ret := _m.Called(userID, guildID)
+// GetGuildEmbedColor provides a mock function with given fields: guildID
+func (_m *Database) GetGuildEmbedColor(guildID string) (int, error) {
+	ret := _m.Called(guildID)
 
 	var r0 int
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string) (int, error)); ok {
-		return rf(userID, guildID)
+	if rf, ok := ret.Get(0).(func(string) (int, error)); ok {
+		return rf(guildID)
 	}
-	if rf, ok := ret.Get(0).(func(string, string) int); ok {
-		r0 = rf(userID, guildID)
+	if rf, ok := ret.Get(0).(func(string) int); ok {
+		r0 = rf(guildID)
 	} else {
 		r0 = ret.Get(0).(int)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(userID, guildID)
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(guildID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1470,21 +1829,19 @@ func (_m *Database) GetKarma(userID string, guildID string) (int, error) {
 	return r0, r1
 }
 
-// GetKarmaBlockList provides a mock function with given fields: guildID
-func (_m *Database) GetKarmaBlockList(guildID string) ([]string, error) {
+// GetGuildGhostpingMsg provides a mock function with given fields: guildID
+func (_m *Database) GetGuildGhostpingMsg(guildID string) (string, error) {
 	ret := _m.Called(guildID)
 
-	var r0 []string
+	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) ([]string, error)); ok {
+	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
 		return rf(guildID)
 	}
-	if rf, ok := ret.Get(0).(func(string) []string); ok {
+	if rf, ok := ret.Get(0).(func(string) string); ok {
 		r0 = rf(guildID)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]string)
-		}
+		r0 = ret.Get(0).(string)
 	}
 
 	if rf, ok := ret.Get(1).(func(string) error); ok {
@@ -1496,14 +1853,13 @@ func (_m *Database) GetKarmaBlockList(guildID string) ([]string, error) {
 	return r0, r1
 }
 
-// GetKarmaEmotes provides a mock function with given fields: guildID
-func (_m *Database) GetKarmaEmotes(guildID string) (string, string, error) {
+// GetGuildInviteBlock provides a mock function with given fields: guildID
+func (_m *Database) GetGuildInviteBlock(guildID string) (string, error) {
 	ret := _m.Called(guildID)
 
 	var r0 string
-	var r1 string
-	var r2 error
-	if rf, ok := ret.Get(0).(func(string) (string, string, error)); ok {
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
 		return rf(guildID)
 	}
 	if rf, ok := ret.Get(0).(func(string) string); ok {
@@ -1512,40 +1868,32 @@ func (_m *Database) GetKarmaEmotes(guildID string) (string, string, error) {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(string) string); ok {
+	if rf, ok := ret.Get(1).(func(string) error); ok {
 		r1 = rf(guildID)
 	} else {
-		r1 = ret.Get(1).(string)
-	}
-
-	if rf, ok := ret.Get(2).(func(string) error); ok {
-		r2 = rf(guildID)
-	} else {
-		r2 = ret.Error(2)
+		r1 = ret.Error(1)
 	}
 
-	return r0, r1, r2
+	return r0, r1
 }
 
-// GetKarmaGuild provides a mock function with given fields: guildID, limit
-func (_m *Database) GetKarmaGuild(guildID string, limit int) ([]models.GuildKarma, error) {
-	ret := _m.Called(guildID, limit)
+// GetGuildJdoodleKey provides a mock function with given fields: guildID
+func (_m *Database) GetGuildJdoodleKey(guildID string) (string, error) {
+	ret := _m.Called(guildID)
 
-	var r0 []models.GuildKarma
+	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, int) ([]models.GuildKarma, error)); ok {
-		return rf(guildID, limit)
+	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
+		return rf(guildID)
 	}
-	if rf, ok := ret.Get(0).(func(string, int) []models.GuildKarma); ok {
-		r0 = rf(guildID, limit)
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(guildID)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.GuildKarma)
-		}
+		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, int) error); ok {
-		r1 = rf(guildID, limit)
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(guildID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1553,58 +1901,70 @@ func (_m *Database) GetKarmaGuild(guildID string, limit int) ([]models.GuildKarm
 	return r0, r1
 }
 
-// GetKarmaPenalty provides a mock function with given fields: guildID
-func (_m *Database) GetKarmaPenalty(guildID string) (bool, error) {
+// GetGuildJoinMsg provides a mock function with given fields: guildID
+func (_m *Database) GetGuildJoinMsg(guildID string) (string, string, error) {
 	ret := _m.Called(guildID)
 
-	var r0 bool
-	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (bool, error)); ok {
+	var r0 string
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(string) (string, string, error)); ok {
 		return rf(guildID)
 	}
-	if rf, ok := ret.Get(0).(func(string) bool); ok {
+	if rf, ok := ret.Get(0).(func(string) string); ok {
 		r0 = rf(guildID)
 	} else {
-		r0 = ret.Get(0).(bool)
+		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
+	if rf, ok := ret.Get(1).(func(string) string); ok {
 		r1 = rf(guildID)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(string)
 	}
 
-	return r0, r1
+	if rf, ok := ret.Get(2).(func(string) error); ok {
+		r2 = rf(guildID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
-// GetKarmaRules provides a mock function with given fields: guildID
-func (_m *Database) GetKarmaRules(guildID string) ([]models.KarmaRule, error) {
+// GetGuildLeaveMsg provides a mock function with given fields: guildID
+func (_m *Database) GetGuildLeaveMsg(guildID string) (string, string, error) {
 	ret := _m.Called(guildID)
 
-	var r0 []models.KarmaRule
-	var r1 error
-	if rf, ok := ret.Get(0).(func(string) ([]models.KarmaRule, error)); ok {
+	var r0 string
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(string) (string, string, error)); ok {
 		return rf(guildID)
 	}
-	if rf, ok := ret.Get(0).(func(string) []models.KarmaRule); ok {
+	if rf, ok := ret.Get(0).(func(string) string); ok {
 		r0 = rf(guildID)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.KarmaRule)
-		}
+		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
+	if rf, ok := ret.Get(1).(func(string) string); ok {
 		r1 = rf(guildID)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(string)
 	}
 
-	return r0, r1
+	if rf, ok := ret.Get(2).(func(string) error); ok {
+		r2 = rf(guildID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
-// GetKarmaState provides a mock function with given fields: guildID
-func (_m *Database) GetKarmaState(guildID string) (bool, error) {
+// GetGuildLogDisable provides a mock function with given fields: guildID
+func (_m *Database) GetGuildLogDisable(guildID string) (bool, error) {
 	ret := _m.Called(guildID)
 
 	var r0 bool
@@ -1627,23 +1987,25 @@ func (_m *Database) GetKarmaState(guildID string) (bool, error) {
 	return r0, r1
 }
 
-// GetKarmaSum provides a mock function with given fields: userID
-func (_m *Database) GetKarmaSum(userID string) (int, error) {
-	ret := _m.Called(userID)
+// GetGuildLogEntries provides a mock function with given fields: guildID, offset, limit, severity, ascending
+func (_m *Database) GetGuildLogEntries(guildID string, offset int, limit int, severity models.GuildLogSeverity, ascending bool) ([]models.GuildLogEntry, error) {
+	ret := _m.Called(guildID, offset, limit, severity, ascending)
 
-	var r0 int
+	var r0 []models.GuildLogEntry
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (int, error)); ok {
-		return rf(userID)
+	if rf, ok := ret.Get(0).(func(string, int, int, models.GuildLogSeverity, bool) ([]models.GuildLogEntry, error)); ok {
+		return rf(guildID, offset, limit, severity, ascending)
 	}
-	if rf, ok := ret.Get(0).(func(string) int); ok {
-		r0 = rf(userID)
+	if rf, ok := ret.Get(0).(func(string, int, int, models.GuildLogSeverity, bool) []models.GuildLogEntry); ok {
+		r0 = rf(guildID, offset, limit, severity, ascending)
 	} else {
-		r0 = ret.Get(0).(int)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.GuildLogEntry)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(userID)
+	if rf, ok := ret.Get(1).(func(string, int, int, models.GuildLogSeverity, bool) error); ok {
+		r1 = rf(guildID, offset, limit, severity, ascending)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1651,23 +2013,23 @@ func (_m *Database) GetKarmaSum(userID string) (int, error) {
 	return r0, r1
 }
 
-// GetKarmaTokens provides a mock function with given fields: guildID
-func (_m *Database) GetKarmaTokens(guildID string) (int, error) {
-	ret := _m.Called(guildID)
+// GetGuildLogEntriesCount provides a mock function with given fields: guildID, severity
+func (_m *Database) GetGuildLogEntriesCount(guildID string, severity models.GuildLogSeverity) (int, error) {
+	ret := _m.Called(guildID, severity)
 
 	var r0 int
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (int, error)); ok {
-		return rf(guildID)
+	if rf, ok := ret.Get(0).(func(string, models.GuildLogSeverity) (int, error)); ok {
+		return rf(guildID, severity)
 	}
-	if rf, ok := ret.Get(0).(func(string) int); ok {
-		r0 = rf(guildID)
+	if rf, ok := ret.Get(0).(func(string, models.GuildLogSeverity) int); ok {
+		r0 = rf(guildID, severity)
 	} else {
 		r0 = ret.Get(0).(int)
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(guildID)
+	if rf, ok := ret.Get(1).(func(string, models.GuildLogSeverity) error); ok {
+		r1 = rf(guildID, severity)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1675,59 +2037,50 @@ func (_m *Database) GetKarmaTokens(guildID string) (int, error) {
 	return r0, r1
 }
 
-// GetLockChan provides a mock function with given fields: chanID
-func (_m *Database) GetLockChan(chanID string) (string, string, string, error) {
-	ret := _m.Called(chanID)
+// GetGuildMemberMsgGrace provides a mock function with given fields: guildID
+func (_m *Database) GetGuildMemberMsgGrace(guildID string) (time.Duration, bool, error) {
+	ret := _m.Called(guildID)
 
-	var r0 string
-	var r1 string
-	var r2 string
-	var r3 error
-	if rf, ok := ret.Get(0).(func(string) (string, string, string, error)); ok {
-		return rf(chanID)
-	}
-	if rf, ok := ret.Get(0).(func(string) string); ok {
-		r0 = rf(chanID)
-	} else {
-		r0 = ret.Get(0).(string)
+	var r0 time.Duration
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(string) (time.Duration, bool, error)); ok {
+		return rf(guildID)
 	}
-
-	if rf, ok := ret.Get(1).(func(string) string); ok {
-		r1 = rf(chanID)
+	if rf, ok := ret.Get(0).(func(string) time.Duration); ok {
+		r0 = rf(guildID)
 	} else {
-		r1 = ret.Get(1).(string)
+		r0 = ret.Get(0).(time.Duration)
 	}
 
-	if rf, ok := ret.Get(2).(func(string) string); ok {
-		r2 = rf(chanID)
+	if rf, ok := ret.Get(1).(func(string) bool); ok {
+		r1 = rf(guildID)
 	} else {
-		r2 = ret.Get(2).(string)
+		r1 = ret.Get(1).(bool)
 	}
 
-	if rf, ok := ret.Get(3).(func(string) error); ok {
-		r3 = rf(chanID)
+	if rf, ok := ret.Get(2).(func(string) error); ok {
+		r2 = rf(guildID)
 	} else {
-		r3 = ret.Error(3)
+		r2 = ret.Error(2)
 	}
 
-	return r0, r1, r2, r3
+	return r0, r1, r2
 }
 
-// GetLockChannels provides a mock function with given fields: guildID
-func (_m *Database) GetLockChannels(guildID string) ([]string, error) {
+// GetGuildMessageStatsEnabled provides a mock function with given fields: guildID
+func (_m *Database) GetGuildMessageStatsEnabled(guildID string) (bool, error) {
 	ret := _m.Called(guildID)
 
-	var r0 []string
+	var r0 bool
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) ([]string, error)); ok {
+	if rf, ok := ret.Get(0).(func(string) (bool, error)); ok {
 		return rf(guildID)
 	}
-	if rf, ok := ret.Get(0).(func(string) []string); ok {
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
 		r0 = rf(guildID)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]string)
-		}
+		r0 = ret.Get(0).(bool)
 	}
 
 	if rf, ok := ret.Get(1).(func(string) error); ok {
@@ -1739,23 +2092,23 @@ func (_m *Database) GetLockChannels(guildID string) ([]string, error) {
 	return r0, r1
 }
 
-// GetReport provides a mock function with given fields: id
-func (_m *Database) GetReport(id snowflake.ID) (models.Report, error) {
-	ret := _m.Called(id)
+// GetGuildModLog provides a mock function with given fields: guildID
+func (_m *Database) GetGuildModLog(guildID string) (string, error) {
+	ret := _m.Called(guildID)
 
-	var r0 models.Report
+	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(snowflake.ID) (models.Report, error)); ok {
-		return rf(id)
+	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
+		return rf(guildID)
 	}
-	if rf, ok := ret.Get(0).(func(snowflake.ID) models.Report); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(guildID)
 	} else {
-		r0 = ret.Get(0).(models.Report)
+		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(snowflake.ID) error); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(guildID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1763,25 +2116,23 @@ func (_m *Database) GetReport(id snowflake.ID) (models.Report, error) {
 	return r0, r1
 }
 
-// GetReportsFiltered provides a mock function with given fields: guildID, memberID, repType, offset, limit
-func (_m *Database) GetReportsFiltered(guildID string, memberID string, repType models.ReportType, offset int, limit int) ([]models.Report, error) {
-	ret := _m.Called(guildID, memberID, repType, offset, limit)
+// GetGuildModLogFormat provides a mock function with given fields: guildID
+func (_m *Database) GetGuildModLogFormat(guildID string) (models.ModLogFormat, error) {
+	ret := _m.Called(guildID)
 
-	var r0 []models.Report
+	var r0 models.ModLogFormat
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string, models.ReportType, int, int) ([]models.Report, error)); ok {
-		return rf(guildID, memberID, repType, offset, limit)
+	if rf, ok := ret.Get(0).(func(string) (models.ModLogFormat, error)); ok {
+		return rf(guildID)
 	}
-	if rf, ok := ret.Get(0).(func(string, string, models.ReportType, int, int) []models.Report); ok {
-		r0 = rf(guildID, memberID, repType, offset, limit)
+	if rf, ok := ret.Get(0).(func(string) models.ModLogFormat); ok {
+		r0 = rf(guildID)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.Report)
-		}
+		r0 = ret.Get(0).(models.ModLogFormat)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string, models.ReportType, int, int) error); ok {
-		r1 = rf(guildID, memberID, repType, offset, limit)
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(guildID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1789,23 +2140,23 @@ func (_m *Database) GetReportsFiltered(guildID string, memberID string, repType
 	return r0, r1
 }
 
-// GetReportsFilteredCount provides a mock function with given fields: guildID, memberID, repType
-func (_m *Database) GetReportsFilteredCount(guildID string, memberID string, repType int) (int, error) {
-	ret := _m.Called(guildID, memberID, repType)
+// GetGuildModLogRoute provides a mock function with given fields: guildID, action
+func (_m *Database) GetGuildModLogRoute(guildID string, action models.ModLogAction) (string, error) {
+	ret := _m.Called(guildID, action)
 
-	var r0 int
+	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string, int) (int, error)); ok {
-		return rf(guildID, memberID, repType)
+	if rf, ok := ret.Get(0).(func(string, models.ModLogAction) (string, error)); ok {
+		return rf(guildID, action)
 	}
-	if rf, ok := ret.Get(0).(func(string, string, int) int); ok {
-		r0 = rf(guildID, memberID, repType)
+	if rf, ok := ret.Get(0).(func(string, models.ModLogAction) string); ok {
+		r0 = rf(guildID, action)
 	} else {
-		r0 = ret.Get(0).(int)
+		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string, int) error); ok {
-		r1 = rf(guildID, memberID, repType)
+	if rf, ok := ret.Get(1).(func(string, models.ModLogAction) error); ok {
+		r1 = rf(guildID, action)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1813,25 +2164,25 @@ func (_m *Database) GetReportsFilteredCount(guildID string, memberID string, rep
 	return r0, r1
 }
 
-// GetReportsGuild provides a mock function with given fields: guildID, offset, limit
-func (_m *Database) GetReportsGuild(guildID string, offset int, limit int) ([]models.Report, error) {
-	ret := _m.Called(guildID, offset, limit)
+// GetGuildModLogRoutes provides a mock function with given fields: guildID
+func (_m *Database) GetGuildModLogRoutes(guildID string) (map[models.ModLogAction]string, error) {
+	ret := _m.Called(guildID)
 
-	var r0 []models.Report
+	var r0 map[models.ModLogAction]string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, int, int) ([]models.Report, error)); ok {
-		return rf(guildID, offset, limit)
+	if rf, ok := ret.Get(0).(func(string) (map[models.ModLogAction]string, error)); ok {
+		return rf(guildID)
 	}
-	if rf, ok := ret.Get(0).(func(string, int, int) []models.Report); ok {
-		r0 = rf(guildID, offset, limit)
+	if rf, ok := ret.Get(0).(func(string) map[models.ModLogAction]string); ok {
+		r0 = rf(guildID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.Report)
+			r0 = ret.Get(0).(map[models.ModLogAction]string)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, int, int) error); ok {
-		r1 = rf(guildID, offset, limit)
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(guildID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1839,19 +2190,19 @@ func (_m *Database) GetReportsGuild(guildID string, offset int, limit int) ([]mo
 	return r0, r1
 }
 
-// GetReportsGuildCount provides a mock function with given fields: guildID
-func (_m *Database) GetReportsGuildCount(guildID string) (int, error) {
+// GetGuildModNot provides a mock function with given fields: guildID
+func (_m *Database) GetGuildModNot(guildID string) (string, error) {
 	ret := _m.Called(guildID)
 
-	var r0 int
+	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (int, error)); ok {
+	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
 		return rf(guildID)
 	}
-	if rf, ok := ret.Get(0).(func(string) int); ok {
+	if rf, ok := ret.Get(0).(func(string) string); ok {
 		r0 = rf(guildID)
 	} else {
-		r0 = ret.Get(0).(int)
+		r0 = ret.Get(0).(string)
 	}
 
 	if rf, ok := ret.Get(1).(func(string) error); ok {
@@ -1863,25 +2214,1195 @@ func (_m *Database) GetReportsGuildCount(guildID string) (int, error) {
 	return r0, r1
 }
 
-// GetRoleSelects provides a mock function with given fields:
-func (_m *Database) GetRoleSelects() ([]models.RoleSelect, error) {
-	ret := _m.Called()
+// GetGuildModmailChannel provides a mock function with given fields: guildID
+func (_m *Database) GetGuildModmailChannel(guildID string) (string, error) {
+	ret := _m.Called(guildID)
 
-	var r0 []models.RoleSelect
+	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func() ([]models.RoleSelect, error)); ok {
-		return rf()
-	}
+	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
+		return rf(guildID)
+	}
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(guildID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(guildID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGuildNotifyRole provides a mock function with given fields: guildID
+func (_m *Database) GetGuildNotifyRole(guildID string) (string, error) {
+	ret := _m.Called(guildID)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
+		return rf(guildID)
+	}
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(guildID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(guildID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGuildPermDeniedMessage provides a mock function with given fields: guildID
+func (_m *Database) GetGuildPermDeniedMessage(guildID string) (string, bool, error) {
+	ret := _m.Called(guildID)
+
+	var r0 string
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(string) (string, bool, error)); ok {
+		return rf(guildID)
+	}
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(guildID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) bool); ok {
+		r1 = rf(guildID)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(string) error); ok {
+		r2 = rf(guildID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetGuildPermissions provides a mock function with given fields: guildID
+func (_m *Database) GetGuildPermissions(guildID string) (map[string]permissions.PermissionArray, error) {
+	ret := _m.Called(guildID)
+
+	var r0 map[string]permissions.PermissionArray
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (map[string]permissions.PermissionArray, error)); ok {
+		return rf(guildID)
+	}
+	if rf, ok := ret.Get(0).(func(string) map[string]permissions.PermissionArray); ok {
+		r0 = rf(guildID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]permissions.PermissionArray)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(guildID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGuildPinRotation provides a mock function with given fields: guildID
+func (_m *Database) GetGuildPinRotation(guildID string) (bool, error) {
+	ret := _m.Called(guildID)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (bool, error)); ok {
+		return rf(guildID)
+	}
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(guildID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(guildID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGuildPrefix provides a mock function with given fields: guildID
+func (_m *Database) GetGuildPrefix(guildID string) (string, error) {
+	ret := _m.Called(guildID)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
+		return rf(guildID)
+	}
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(guildID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(guildID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGuildReportEscalation provides a mock function with given fields: guildID
+func (_m *Database) GetGuildReportEscalation(guildID string) (models.ReportEscalation, error) {
+	ret := _m.Called(guildID)
+
+	var r0 models.ReportEscalation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (models.ReportEscalation, error)); ok {
+		return rf(guildID)
+	}
+	if rf, ok := ret.Get(0).(func(string) models.ReportEscalation); ok {
+		r0 = rf(guildID)
+	} else {
+		r0 = ret.Get(0).(models.ReportEscalation)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(guildID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGuildReportTypes provides a mock function with given fields: guildID
+func (_m *Database) GetGuildReportTypes(guildID string) ([]models.CustomReportType, error) {
+	ret := _m.Called(guildID)
+
+	var r0 []models.CustomReportType
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]models.CustomReportType, error)); ok {
+		return rf(guildID)
+	}
+	if rf, ok := ret.Get(0).(func(string) []models.CustomReportType); ok {
+		r0 = rf(guildID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.CustomReportType)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(guildID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGuildRules provides a mock function with given fields: guildID
+func (_m *Database) GetGuildRules(guildID string) (models.GuildRules, error) {
+	ret := _m.Called(guildID)
+
+	var r0 models.GuildRules
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (models.GuildRules, error)); ok {
+		return rf(guildID)
+	}
+	if rf, ok := ret.Get(0).(func(string) models.GuildRules); ok {
+		r0 = rf(guildID)
+	} else {
+		r0 = ret.Get(0).(models.GuildRules)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(guildID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGuildTags provides a mock function with given fields: guildID
+func (_m *Database) GetGuildTags(guildID string) ([]tag.Tag, error) {
+	ret := _m.Called(guildID)
+
+	var r0 []tag.Tag
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]tag.Tag, error)); ok {
+		return rf(guildID)
+	}
+	if rf, ok := ret.Get(0).(func(string) []tag.Tag); ok {
+		r0 = rf(guildID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]tag.Tag)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(guildID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGuildUnbanRequests provides a mock function with given fields: guildID, limit, offset
+func (_m *Database) GetGuildUnbanRequests(guildID string, limit int, offset int) ([]models.UnbanRequest, error) {
+	ret := _m.Called(guildID, limit, offset)
+
+	var r0 []models.UnbanRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int, int) ([]models.UnbanRequest, error)); ok {
+		return rf(guildID, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(string, int, int) []models.UnbanRequest); ok {
+		r0 = rf(guildID, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.UnbanRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int, int) error); ok {
+		r1 = rf(guildID, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGuildUnbanRequestsCount provides a mock function with given fields: guildID, state
+func (_m *Database) GetGuildUnbanRequestsCount(guildID string, state *models.UnbanRequestState) (int, error) {
+	ret := _m.Called(guildID, state)
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, *models.UnbanRequestState) (int, error)); ok {
+		return rf(guildID, state)
+	}
+	if rf, ok := ret.Get(0).(func(string, *models.UnbanRequestState) int); ok {
+		r0 = rf(guildID, state)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, *models.UnbanRequestState) error); ok {
+		r1 = rf(guildID, state)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGuildUserUnbanRequests provides a mock function with given fields: userID, guildID
+func (_m *Database) GetGuildUserUnbanRequests(userID string, guildID string) ([]models.UnbanRequest, error) {
+	ret := _m.Called(userID, guildID)
+
+	var r0 []models.UnbanRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) ([]models.UnbanRequest, error)); ok {
+		return rf(userID, guildID)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) []models.UnbanRequest); ok {
+		r0 = rf(userID, guildID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.UnbanRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(userID, guildID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGuildVerificationRequired provides a mock function with given fields: guildID
+func (_m *Database) GetGuildVerificationRequired(guildID string) (bool, error) {
+	ret := _m.Called(guildID)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (bool, error)); ok {
+		return rf(guildID)
+	}
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(guildID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(guildID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGuildVoiceLog provides a mock function with given fields: guildID
+func (_m *Database) GetGuildVoiceLog(guildID string) (string, error) {
+	ret := _m.Called(guildID)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
+		return rf(guildID)
+	}
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(guildID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(guildID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGuildVoiceLogIgnores provides a mock function with given fields: guildID
+func (_m *Database) GetGuildVoiceLogIgnores(guildID string) ([]string, error) {
+	ret := _m.Called(guildID)
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]string, error)); ok {
+		return rf(guildID)
+	}
+	if rf, ok := ret.Get(0).(func(string) []string); ok {
+		r0 = rf(guildID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(guildID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGuilds provides a mock function with given fields:
+func (_m *Database) GetGuilds() ([]string, error) {
+	ret := _m.Called()
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]string, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetInactivitySettings provides a mock function with given fields: guildID
+func (_m *Database) GetInactivitySettings(guildID string) (models.InactivitySettings, error) {
+	ret := _m.Called(guildID)
+
+	var r0 models.InactivitySettings
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (models.InactivitySettings, error)); ok {
+		return rf(guildID)
+	}
+	if rf, ok := ret.Get(0).(func(string) models.InactivitySettings); ok {
+		r0 = rf(guildID)
+	} else {
+		r0 = ret.Get(0).(models.InactivitySettings)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(guildID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetInviteJoinStats provides a mock function with given fields: guildID
+func (_m *Database) GetInviteJoinStats(guildID string) ([]models.InviteJoinStats, error) {
+	ret := _m.Called(guildID)
+
+	var r0 []models.InviteJoinStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]models.InviteJoinStats, error)); ok {
+		return rf(guildID)
+	}
+	if rf, ok := ret.Get(0).(func(string) []models.InviteJoinStats); ok {
+		r0 = rf(guildID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.InviteJoinStats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(guildID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetKarma provides a mock function with given fields: userID, guildID
+func (_m *Database) GetKarma(userID string, guildID string) (int, error) {
+	ret := _m.Called(userID, guildID)
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (int, error)); ok {
+		return rf(userID, guildID)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) int); ok {
+		r0 = rf(userID, guildID)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(userID, guildID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetKarmaBlockList provides a mock function with given fields: guildID
+func (_m *Database) GetKarmaBlockList(guildID string) ([]string, error) {
+	ret := _m.Called(guildID)
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]string, error)); ok {
+		return rf(guildID)
+	}
+	if rf, ok := ret.Get(0).(func(string) []string); ok {
+		r0 = rf(guildID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(guildID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetKarmaEmotes provides a mock function with given fields: guildID
+func (_m *Database) GetKarmaEmotes(guildID string) (string, string, error) {
+	ret := _m.Called(guildID)
+
+	var r0 string
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(string) (string, string, error)); ok {
+		return rf(guildID)
+	}
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(guildID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) string); ok {
+		r1 = rf(guildID)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(string) error); ok {
+		r2 = rf(guildID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetKarmaGuild provides a mock function with given fields: guildID, limit
+func (_m *Database) GetKarmaGuild(guildID string, limit int) ([]models.GuildKarma, error) {
+	ret := _m.Called(guildID, limit)
+
+	var r0 []models.GuildKarma
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int) ([]models.GuildKarma, error)); ok {
+		return rf(guildID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(string, int) []models.GuildKarma); ok {
+		r0 = rf(guildID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.GuildKarma)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int) error); ok {
+		r1 = rf(guildID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetKarmaLimits provides a mock function with given fields: guildID
+func (_m *Database) GetKarmaLimits(guildID string) (models.KarmaLimits, error) {
+	ret := _m.Called(guildID)
+
+	var r0 models.KarmaLimits
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (models.KarmaLimits, error)); ok {
+		return rf(guildID)
+	}
+	if rf, ok := ret.Get(0).(func(string) models.KarmaLimits); ok {
+		r0 = rf(guildID)
+	} else {
+		r0 = ret.Get(0).(models.KarmaLimits)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(guildID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetKarmaPenalty provides a mock function with given fields: guildID
+func (_m *Database) GetKarmaPenalty(guildID string) (bool, error) {
+	ret := _m.Called(guildID)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (bool, error)); ok {
+		return rf(guildID)
+	}
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(guildID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(guildID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetKarmaRules provides a mock function with given fields: guildID
+func (_m *Database) GetKarmaRules(guildID string) ([]models.KarmaRule, error) {
+	ret := _m.Called(guildID)
+
+	var r0 []models.KarmaRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]models.KarmaRule, error)); ok {
+		return rf(guildID)
+	}
+	if rf, ok := ret.Get(0).(func(string) []models.KarmaRule); ok {
+		r0 = rf(guildID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.KarmaRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(guildID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetKarmaState provides a mock function with given fields: guildID
+func (_m *Database) GetKarmaState(guildID string) (bool, error) {
+	ret := _m.Called(guildID)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (bool, error)); ok {
+		return rf(guildID)
+	}
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(guildID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(guildID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetKarmaSum provides a mock function with given fields: userID
+func (_m *Database) GetKarmaSum(userID string) (int, error) {
+	ret := _m.Called(userID)
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (int, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(string) int); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetKarmaTokens provides a mock function with given fields: guildID
+func (_m *Database) GetKarmaTokens(guildID string) (int, error) {
+	ret := _m.Called(guildID)
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (int, error)); ok {
+		return rf(guildID)
+	}
+	if rf, ok := ret.Get(0).(func(string) int); ok {
+		r0 = rf(guildID)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(guildID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLockChan provides a mock function with given fields: chanID
+func (_m *Database) GetLockChan(chanID string) (string, string, string, error) {
+	ret := _m.Called(chanID)
+
+	var r0 string
+	var r1 string
+	var r2 string
+	var r3 error
+	if rf, ok := ret.Get(0).(func(string) (string, string, string, error)); ok {
+		return rf(chanID)
+	}
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(chanID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) string); ok {
+		r1 = rf(chanID)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(string) string); ok {
+		r2 = rf(chanID)
+	} else {
+		r2 = ret.Get(2).(string)
+	}
+
+	if rf, ok := ret.Get(3).(func(string) error); ok {
+		r3 = rf(chanID)
+	} else {
+		r3 = ret.Error(3)
+	}
+
+	return r0, r1, r2, r3
+}
+
+// GetLockChannels provides a mock function with given fields: guildID
+func (_m *Database) GetLockChannels(guildID string) ([]string, error) {
+	ret := _m.Called(guildID)
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]string, error)); ok {
+		return rf(guildID)
+	}
+	if rf, ok := ret.Get(0).(func(string) []string); ok {
+		r0 = rf(guildID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(guildID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMemberActivities provides a mock function with given fields: guildID
+func (_m *Database) GetMemberActivities(guildID string) ([]models.MemberActivity, error) {
+	ret := _m.Called(guildID)
+
+	var r0 []models.MemberActivity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]models.MemberActivity, error)); ok {
+		return rf(guildID)
+	}
+	if rf, ok := ret.Get(0).(func(string) []models.MemberActivity); ok {
+		r0 = rf(guildID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.MemberActivity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(guildID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMessageStats provides a mock function with given fields: guildID, since
+func (_m *Database) GetMessageStats(guildID string, since time.Time) ([]models.MessageStatsEntry, error) {
+	ret := _m.Called(guildID, since)
+
+	var r0 []models.MessageStatsEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, time.Time) ([]models.MessageStatsEntry, error)); ok {
+		return rf(guildID, since)
+	}
+	if rf, ok := ret.Get(0).(func(string, time.Time) []models.MessageStatsEntry); ok {
+		r0 = rf(guildID, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.MessageStatsEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, time.Time) error); ok {
+		r1 = rf(guildID, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetModmailThread provides a mock function with given fields: threadID
+func (_m *Database) GetModmailThread(threadID string) (models.ModmailThread, error) {
+	ret := _m.Called(threadID)
+
+	var r0 models.ModmailThread
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (models.ModmailThread, error)); ok {
+		return rf(threadID)
+	}
+	if rf, ok := ret.Get(0).(func(string) models.ModmailThread); ok {
+		r0 = rf(threadID)
+	} else {
+		r0 = ret.Get(0).(models.ModmailThread)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(threadID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetModmailThreadsByUser provides a mock function with given fields: userID
+func (_m *Database) GetModmailThreadsByUser(userID string) ([]models.ModmailThread, error) {
+	ret := _m.Called(userID)
+
+	var r0 []models.ModmailThread
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]models.ModmailThread, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(string) []models.ModmailThread); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ModmailThread)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPrefixlessChannels provides a mock function with given fields: guildID
+func (_m *Database) GetPrefixlessChannels(guildID string) ([]string, error) {
+	ret := _m.Called(guildID)
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]string, error)); ok {
+		return rf(guildID)
+	}
+	if rf, ok := ret.Get(0).(func(string) []string); ok {
+		r0 = rf(guildID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(guildID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReactionReportConfig provides a mock function with given fields: guildID
+func (_m *Database) GetReactionReportConfig(guildID string) (models.ReactionReportConfig, error) {
+	ret := _m.Called(guildID)
+
+	var r0 models.ReactionReportConfig
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (models.ReactionReportConfig, error)); ok {
+		return rf(guildID)
+	}
+	if rf, ok := ret.Get(0).(func(string) models.ReactionReportConfig); ok {
+		r0 = rf(guildID)
+	} else {
+		r0 = ret.Get(0).(models.ReactionReportConfig)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(guildID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReport provides a mock function with given fields: id
+func (_m *Database) GetReport(id snowflake.ID) (models.Report, error) {
+	ret := _m.Called(id)
+
+	var r0 models.Report
+	var r1 error
+	if rf, ok := ret.Get(0).(func(snowflake.ID) (models.Report, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(snowflake.ID) models.Report); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(models.Report)
+	}
+
+	if rf, ok := ret.Get(1).(func(snowflake.ID) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReportImport provides a mock function with given fields: guildID, externalID
+func (_m *Database) GetReportImport(guildID string, externalID string) (snowflake.ID, error) {
+	ret := _m.Called(guildID, externalID)
+
+	var r0 snowflake.ID
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (snowflake.ID, error)); ok {
+		return rf(guildID, externalID)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) snowflake.ID); ok {
+		r0 = rf(guildID, externalID)
+	} else {
+		r0 = ret.Get(0).(snowflake.ID)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(guildID, externalID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReportsFiltered provides a mock function with given fields: guildID, memberID, repType, offset, limit
+func (_m *Database) GetReportsFiltered(guildID string, memberID string, repType models.ReportType, offset int, limit int) ([]models.Report, error) {
+	ret := _m.Called(guildID, memberID, repType, offset, limit)
+
+	var r0 []models.Report
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, models.ReportType, int, int) ([]models.Report, error)); ok {
+		return rf(guildID, memberID, repType, offset, limit)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, models.ReportType, int, int) []models.Report); ok {
+		r0 = rf(guildID, memberID, repType, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Report)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, models.ReportType, int, int) error); ok {
+		r1 = rf(guildID, memberID, repType, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReportsFilteredCount provides a mock function with given fields: guildID, memberID, repType
+func (_m *Database) GetReportsFilteredCount(guildID string, memberID string, repType int) (int, error) {
+	ret := _m.Called(guildID, memberID, repType)
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, int) (int, error)); ok {
+		return rf(guildID, memberID, repType)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, int) int); ok {
+		r0 = rf(guildID, memberID, repType)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, int) error); ok {
+		r1 = rf(guildID, memberID, repType)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReportsGuild provides a mock function with given fields: guildID, offset, limit
+func (_m *Database) GetReportsGuild(guildID string, offset int, limit int) ([]models.Report, error) {
+	ret := _m.Called(guildID, offset, limit)
+
+	var r0 []models.Report
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int, int) ([]models.Report, error)); ok {
+		return rf(guildID, offset, limit)
+	}
+	if rf, ok := ret.Get(0).(func(string, int, int) []models.Report); ok {
+		r0 = rf(guildID, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Report)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int, int) error); ok {
+		r1 = rf(guildID, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReportsGuildCount provides a mock function with given fields: guildID
+func (_m *Database) GetReportsGuildCount(guildID string) (int, error) {
+	ret := _m.Called(guildID)
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (int, error)); ok {
+		return rf(guildID)
+	}
+	if rf, ok := ret.Get(0).(func(string) int); ok {
+		r0 = rf(guildID)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(guildID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRevokedReportsGuild provides a mock function with given fields: guildID, offset, limit
+func (_m *Database) GetRevokedReportsGuild(guildID string, offset int, limit int) ([]models.Report, error) {
+	ret := _m.Called(guildID, offset, limit)
+
+	var r0 []models.Report
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int, int) ([]models.Report, error)); ok {
+		return rf(guildID, offset, limit)
+	}
+	if rf, ok := ret.Get(0).(func(string, int, int) []models.Report); ok {
+		r0 = rf(guildID, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Report)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int, int) error); ok {
+		r1 = rf(guildID, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRevokedReportsGuildCount provides a mock function with given fields: guildID
+func (_m *Database) GetRevokedReportsGuildCount(guildID string) (int, error) {
+	ret := _m.Called(guildID)
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (int, error)); ok {
+		return rf(guildID)
+	}
+	if rf, ok := ret.Get(0).(func(string) int); ok {
+		r0 = rf(guildID)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(guildID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRoleSelects provides a mock function with given fields:
+func (_m *Database) GetRoleSelects() ([]models.RoleSelect, error) {
+	ret := _m.Called()
+
+	var r0 []models.RoleSelect
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]models.RoleSelect, error)); ok {
+		return rf()
+	}
 	if rf, ok := ret.Get(0).(func() []models.RoleSelect); ok {
 		r0 = rf()
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.RoleSelect)
-		}
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.RoleSelect)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRulesAcceptance provides a mock function with given fields: guildID, userID
+func (_m *Database) GetRulesAcceptance(guildID string, userID string) (int, error) {
+	ret := _m.Called(guildID, userID)
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (int, error)); ok {
+		return rf(guildID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) int); ok {
+		r0 = rf(guildID, userID)
+	} else {
+		r0 = ret.Get(0).(int)
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(guildID, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1913,6 +3434,56 @@ func (_m *Database) GetSetting(setting string) (string, error) {
 	return r0, r1
 }
 
+// GetSettingsAuditEntries provides a mock function with given fields: guildID, offset, limit
+func (_m *Database) GetSettingsAuditEntries(guildID string, offset int, limit int) ([]models.SettingsAuditEntry, error) {
+	ret := _m.Called(guildID, offset, limit)
+
+	var r0 []models.SettingsAuditEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int, int) ([]models.SettingsAuditEntry, error)); ok {
+		return rf(guildID, offset, limit)
+	}
+	if rf, ok := ret.Get(0).(func(string, int, int) []models.SettingsAuditEntry); ok {
+		r0 = rf(guildID, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.SettingsAuditEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int, int) error); ok {
+		r1 = rf(guildID, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSettingsAuditEntriesCount provides a mock function with given fields: guildID
+func (_m *Database) GetSettingsAuditEntriesCount(guildID string) (int, error) {
+	ret := _m.Called(guildID)
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (int, error)); ok {
+		return rf(guildID)
+	}
+	if rf, ok := ret.Get(0).(func(string) int); ok {
+		r0 = rf(guildID)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(guildID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetStarboardConfig provides a mock function with given fields: guildID
 func (_m *Database) GetStarboardConfig(guildID string) (models.StarboardConfig, error) {
 	ret := _m.Called(guildID)
@@ -2011,6 +3582,56 @@ func (_m *Database) GetStarboardEntry(messageID string) (models.StarboardEntry,
 	return r0, r1
 }
 
+// GetStickyMessage provides a mock function with given fields: guildID, channelID
+func (_m *Database) GetStickyMessage(guildID string, channelID string) (models.StickyMessage, error) {
+	ret := _m.Called(guildID, channelID)
+
+	var r0 models.StickyMessage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (models.StickyMessage, error)); ok {
+		return rf(guildID, channelID)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) models.StickyMessage); ok {
+		r0 = rf(guildID, channelID)
+	} else {
+		r0 = ret.Get(0).(models.StickyMessage)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(guildID, channelID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStickyMessages provides a mock function with given fields: guildID
+func (_m *Database) GetStickyMessages(guildID string) ([]models.StickyMessage, error) {
+	ret := _m.Called(guildID)
+
+	var r0 []models.StickyMessage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]models.StickyMessage, error)); ok {
+		return rf(guildID)
+	}
+	if rf, ok := ret.Get(0).(func(string) []models.StickyMessage); ok {
+		r0 = rf(guildID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.StickyMessage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(guildID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTagByID provides a mock function with given fields: id
 func (_m *Database) GetTagByID(id snowflake.ID) (tag.Tag, error) {
 	ret := _m.Called(id)
@@ -2059,6 +3680,82 @@ func (_m *Database) GetTagByIdent(ident string, guildID string) (tag.Tag, error)
 	return r0, r1
 }
 
+// GetTempRole provides a mock function with given fields: id
+func (_m *Database) GetTempRole(id snowflake.ID) (models.TempRole, error) {
+	ret := _m.Called(id)
+
+	var r0 models.TempRole
+	var r1 error
+	if rf, ok := ret.Get(0).(func(snowflake.ID) (models.TempRole, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(snowflake.ID) models.TempRole); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(models.TempRole)
+	}
+
+	if rf, ok := ret.Get(1).(func(snowflake.ID) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTempRoles provides a mock function with given fields: guildID, userID
+func (_m *Database) GetTempRoles(guildID string, userID string) ([]models.TempRole, error) {
+	ret := _m.Called(guildID, userID)
+
+	var r0 []models.TempRole
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) ([]models.TempRole, error)); ok {
+		return rf(guildID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) []models.TempRole); ok {
+		r0 = rf(guildID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.TempRole)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(guildID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTrackedInvites provides a mock function with given fields: guildID
+func (_m *Database) GetTrackedInvites(guildID string) ([]models.TrackedInvite, error) {
+	ret := _m.Called(guildID)
+
+	var r0 []models.TrackedInvite
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]models.TrackedInvite, error)); ok {
+		return rf(guildID)
+	}
+	if rf, ok := ret.Get(0).(func(string) []models.TrackedInvite); ok {
+		r0 = rf(guildID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.TrackedInvite)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(guildID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTwitchNotify provides a mock function with given fields: twitchUserID, guildID
 func (_m *Database) GetTwitchNotify(twitchUserID string, guildID string) (twitchnotify.DBEntry, error) {
 	ret := _m.Called(twitchUserID, guildID)
@@ -2138,6 +3835,32 @@ func (_m *Database) GetUserByRefreshToken(token string) (string, time.Time, erro
 	return r0, r1, r2
 }
 
+// GetUserNotes provides a mock function with given fields: guildID, userID
+func (_m *Database) GetUserNotes(guildID string, userID string) ([]models.UserNote, error) {
+	ret := _m.Called(guildID, userID)
+
+	var r0 []models.UserNote
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) ([]models.UserNote, error)); ok {
+		return rf(guildID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) []models.UserNote); ok {
+		r0 = rf(guildID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.UserNote)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(guildID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetUserOTAEnabled provides a mock function with given fields: userID
 func (_m *Database) GetUserOTAEnabled(userID string) (bool, error) {
 	ret := _m.Called(userID)
@@ -2262,6 +3985,56 @@ func (_m *Database) GetVotes() (map[string]vote.Vote, error) {
 	return r0, r1
 }
 
+// GetWordFilterEntries provides a mock function with given fields: guildID
+func (_m *Database) GetWordFilterEntries(guildID string) ([]models.WordFilterEntry, error) {
+	ret := _m.Called(guildID)
+
+	var r0 []models.WordFilterEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]models.WordFilterEntry, error)); ok {
+		return rf(guildID)
+	}
+	if rf, ok := ret.Get(0).(func(string) []models.WordFilterEntry); ok {
+		r0 = rf(guildID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.WordFilterEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(guildID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWordFilterSettings provides a mock function with given fields: guildID
+func (_m *Database) GetWordFilterSettings(guildID string) (models.WordFilterSettings, error) {
+	ret := _m.Called(guildID)
+
+	var r0 models.WordFilterSettings
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (models.WordFilterSettings, error)); ok {
+		return rf(guildID)
+	}
+	if rf, ok := ret.Get(0).(func(string) models.WordFilterSettings); ok {
+		r0 = rf(guildID)
+	} else {
+		r0 = ret.Get(0).(models.WordFilterSettings)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(guildID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // IsGuildVoiceLogIgnored provides a mock function with given fields: guildID, channelID
 func (_m *Database) IsGuildVoiceLogIgnored(guildID string, channelID string) (bool, error) {
 	ret := _m.Called(guildID, channelID)
@@ -2301,22 +4074,78 @@ func (_m *Database) IsKarmaBlockListed(guildID string, userID string) (bool, err
 		r0 = ret.Get(0).(bool)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(guildID, userID)
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(guildID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveAntiraidJoinList provides a mock function with given fields: guildID, userID
+func (_m *Database) RemoveAntiraidJoinList(guildID string, userID string) error {
+	ret := _m.Called(guildID, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(guildID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveAutoDelete provides a mock function with given fields: guildID, channelID
+func (_m *Database) RemoveAutoDelete(guildID string, channelID string) error {
+	ret := _m.Called(guildID, channelID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(guildID, channelID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveAutoPublish provides a mock function with given fields: guildID, channelID
+func (_m *Database) RemoveAutoPublish(guildID string, channelID string) error {
+	ret := _m.Called(guildID, channelID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(guildID, channelID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveAutoThread provides a mock function with given fields: guildID, channelID
+func (_m *Database) RemoveAutoThread(guildID string, channelID string) error {
+	ret := _m.Called(guildID, channelID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(guildID, channelID)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// RemoveAntiraidJoinList provides a mock function with given fields: guildID, userID
-func (_m *Database) RemoveAntiraidJoinList(guildID string, userID string) error {
-	ret := _m.Called(guildID, userID)
+// RemoveGuildModLogRoute provides a mock function with given fields: guildID, action
+func (_m *Database) RemoveGuildModLogRoute(guildID string, action models.ModLogAction) error {
+	ret := _m.Called(guildID, action)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string) error); ok {
-		r0 = rf(guildID, userID)
+	if rf, ok := ret.Get(0).(func(string, models.ModLogAction) error); ok {
+		r0 = rf(guildID, action)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -2366,6 +4195,34 @@ func (_m *Database) RemoveKarmaRule(guildID string, id snowflake.ID) error {
 	return r0
 }
 
+// RemoveModmailThread provides a mock function with given fields: threadID
+func (_m *Database) RemoveModmailThread(threadID string) error {
+	ret := _m.Called(threadID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(threadID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemovePrefixlessChannel provides a mock function with given fields: guildID, channelID
+func (_m *Database) RemovePrefixlessChannel(guildID string, channelID string) error {
+	ret := _m.Called(guildID, channelID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(guildID, channelID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // RemoveRoleSelect provides a mock function with given fields: guildID, channelID, messageID
 func (_m *Database) RemoveRoleSelect(guildID string, channelID string, messageID string) error {
 	ret := _m.Called(guildID, channelID, messageID)
@@ -2394,6 +4251,34 @@ func (_m *Database) RemoveStarboardEntry(msgID string) error {
 	return r0
 }
 
+// RemoveStickyMessage provides a mock function with given fields: guildID, channelID
+func (_m *Database) RemoveStickyMessage(guildID string, channelID string) error {
+	ret := _m.Called(guildID, channelID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(guildID, channelID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveTempRole provides a mock function with given fields: id
+func (_m *Database) RemoveTempRole(id snowflake.ID) error {
+	ret := _m.Called(id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(snowflake.ID) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // RemoveVerificationQueue provides a mock function with given fields: guildID, userID
 func (_m *Database) RemoveVerificationQueue(guildID string, userID string) (bool, error) {
 	ret := _m.Called(guildID, userID)
@@ -2418,6 +4303,48 @@ func (_m *Database) RemoveVerificationQueue(guildID string, userID string) (bool
 	return r0, r1
 }
 
+// RemoveWordFilterEntry provides a mock function with given fields: guildID, pattern
+func (_m *Database) RemoveWordFilterEntry(guildID string, pattern string) error {
+	ret := _m.Called(guildID, pattern)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(guildID, pattern)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResetKarmaGuild provides a mock function with given fields: guildID
+func (_m *Database) ResetKarmaGuild(guildID string) error {
+	ret := _m.Called(guildID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(guildID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeReport provides a mock function with given fields: id, rev
+func (_m *Database) RevokeReport(id snowflake.ID, rev models.ReportRevocation) error {
+	ret := _m.Called(id, rev)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(snowflake.ID, models.ReportRevocation) error); ok {
+		r0 = rf(id, rev)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // RevokeUserRefreshToken provides a mock function with given fields: userID
 func (_m *Database) RevokeUserRefreshToken(userID string) error {
 	ret := _m.Called(userID)
@@ -2502,13 +4429,209 @@ func (_m *Database) SetAntiraidVerification(guildID string, state bool) error {
 	return r0
 }
 
-// SetBirthday provides a mock function with given fields: m
-func (_m *Database) SetBirthday(m models.Birthday) error {
-	ret := _m.Called(m)
+// SetAutoDelete provides a mock function with given fields: cfg
+func (_m *Database) SetAutoDelete(cfg models.AutoDeleteConfig) error {
+	ret := _m.Called(cfg)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(models.AutoDeleteConfig) error); ok {
+		r0 = rf(cfg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetAutoPublish provides a mock function with given fields: cfg
+func (_m *Database) SetAutoPublish(cfg models.AutoPublishConfig) error {
+	ret := _m.Called(cfg)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(models.AutoPublishConfig) error); ok {
+		r0 = rf(cfg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetAutoThread provides a mock function with given fields: cfg
+func (_m *Database) SetAutoThread(cfg models.AutoThreadConfig) error {
+	ret := _m.Called(cfg)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(models.AutoThreadConfig) error); ok {
+		r0 = rf(cfg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetBackupSchedule provides a mock function with given fields: s
+func (_m *Database) SetBackupSchedule(s backupmodels.Schedule) error {
+	ret := _m.Called(s)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(backupmodels.Schedule) error); ok {
+		r0 = rf(s)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetBirthday provides a mock function with given fields: m
+func (_m *Database) SetBirthday(m models.Birthday) error {
+	ret := _m.Called(m)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(models.Birthday) error); ok {
+		r0 = rf(m)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetBroadcastFinished provides a mock function with given fields: id
+func (_m *Database) SetBroadcastFinished(id snowflake.ID) error {
+	ret := _m.Called(id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(snowflake.ID) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetGiveaway provides a mock function with given fields: g
+func (_m *Database) SetGiveaway(g models.Giveaway) error {
+	ret := _m.Called(g)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(models.Giveaway) error); ok {
+		r0 = rf(g)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetGuildAPI provides a mock function with given fields: guildID, settings
+func (_m *Database) SetGuildAPI(guildID string, settings models.GuildAPISettings) error {
+	ret := _m.Called(guildID, settings)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, models.GuildAPISettings) error); ok {
+		r0 = rf(guildID, settings)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetGuildAnnouncementChannel provides a mock function with given fields: guildID, chanID
+func (_m *Database) SetGuildAnnouncementChannel(guildID string, chanID string) error {
+	ret := _m.Called(guildID, chanID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(guildID, chanID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetGuildAutoRole provides a mock function with given fields: guildID, autoRoleIDs
+func (_m *Database) SetGuildAutoRole(guildID string, autoRoleIDs []string) error {
+	ret := _m.Called(guildID, autoRoleIDs)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, []string) error); ok {
+		r0 = rf(guildID, autoRoleIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetGuildAutoVC provides a mock function with given fields: guildID, autoVCIDs
+func (_m *Database) SetGuildAutoVC(guildID string, autoVCIDs []string) error {
+	ret := _m.Called(guildID, autoVCIDs)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, []string) error); ok {
+		r0 = rf(guildID, autoVCIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetGuildBackup provides a mock function with given fields: guildID, enabled
+func (_m *Database) SetGuildBackup(guildID string, enabled bool) error {
+	ret := _m.Called(guildID, enabled)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, bool) error); ok {
+		r0 = rf(guildID, enabled)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetGuildBirthdayChan provides a mock function with given fields: guildID, chanID
+func (_m *Database) SetGuildBirthdayChan(guildID string, chanID string) error {
+	ret := _m.Called(guildID, chanID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(guildID, chanID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetGuildBotNickname provides a mock function with given fields: guildID, nickname
+func (_m *Database) SetGuildBotNickname(guildID string, nickname string) error {
+	ret := _m.Called(guildID, nickname)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(guildID, nickname)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetGuildCodeExecEnabled provides a mock function with given fields: guildID, enabled
+func (_m *Database) SetGuildCodeExecEnabled(guildID string, enabled bool) error {
+	ret := _m.Called(guildID, enabled)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(models.Birthday) error); ok {
-		r0 = rf(m)
+	if rf, ok := ret.Get(0).(func(string, bool) error); ok {
+		r0 = rf(guildID, enabled)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -2516,13 +4639,13 @@ func (_m *Database) SetBirthday(m models.Birthday) error {
 	return r0
 }
 
-// SetGuildAPI provides a mock function with given fields: guildID, settings
-func (_m *Database) SetGuildAPI(guildID string, settings models.GuildAPISettings) error {
-	ret := _m.Called(guildID, settings)
+// SetGuildCodeExecLanguages provides a mock function with given fields: guildID, languages
+func (_m *Database) SetGuildCodeExecLanguages(guildID string, languages []string) error {
+	ret := _m.Called(guildID, languages)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, models.GuildAPISettings) error); ok {
-		r0 = rf(guildID, settings)
+	if rf, ok := ret.Get(0).(func(string, []string) error); ok {
+		r0 = rf(guildID, languages)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -2530,13 +4653,13 @@ func (_m *Database) SetGuildAPI(guildID string, settings models.GuildAPISettings
 	return r0
 }
 
-// SetGuildAutoRole provides a mock function with given fields: guildID, autoRoleIDs
-func (_m *Database) SetGuildAutoRole(guildID string, autoRoleIDs []string) error {
-	ret := _m.Called(guildID, autoRoleIDs)
+// SetGuildColorReaction provides a mock function with given fields: guildID, enable
+func (_m *Database) SetGuildColorReaction(guildID string, enable bool) error {
+	ret := _m.Called(guildID, enable)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, []string) error); ok {
-		r0 = rf(guildID, autoRoleIDs)
+	if rf, ok := ret.Get(0).(func(string, bool) error); ok {
+		r0 = rf(guildID, enable)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -2544,13 +4667,13 @@ func (_m *Database) SetGuildAutoRole(guildID string, autoRoleIDs []string) error
 	return r0
 }
 
-// SetGuildAutoVC provides a mock function with given fields: guildID, autoVCIDs
-func (_m *Database) SetGuildAutoVC(guildID string, autoVCIDs []string) error {
-	ret := _m.Called(guildID, autoVCIDs)
+// SetGuildCommandSuggestionsDisable provides a mock function with given fields: guildID, disabled
+func (_m *Database) SetGuildCommandSuggestionsDisable(guildID string, disabled bool) error {
+	ret := _m.Called(guildID, disabled)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, []string) error); ok {
-		r0 = rf(guildID, autoVCIDs)
+	if rf, ok := ret.Get(0).(func(string, bool) error); ok {
+		r0 = rf(guildID, disabled)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -2558,13 +4681,13 @@ func (_m *Database) SetGuildAutoVC(guildID string, autoVCIDs []string) error {
 	return r0
 }
 
-// SetGuildBackup provides a mock function with given fields: guildID, enabled
-func (_m *Database) SetGuildBackup(guildID string, enabled bool) error {
-	ret := _m.Called(guildID, enabled)
+// SetGuildConfirmActions provides a mock function with given fields: guildID, actions
+func (_m *Database) SetGuildConfirmActions(guildID string, actions []models.ConfirmAction) error {
+	ret := _m.Called(guildID, actions)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, bool) error); ok {
-		r0 = rf(guildID, enabled)
+	if rf, ok := ret.Get(0).(func(string, []models.ConfirmAction) error); ok {
+		r0 = rf(guildID, actions)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -2572,13 +4695,13 @@ func (_m *Database) SetGuildBackup(guildID string, enabled bool) error {
 	return r0
 }
 
-// SetGuildBirthdayChan provides a mock function with given fields: guildID, chanID
-func (_m *Database) SetGuildBirthdayChan(guildID string, chanID string) error {
-	ret := _m.Called(guildID, chanID)
+// SetGuildDisabledCommand provides a mock function with given fields: guildID, command, disabled
+func (_m *Database) SetGuildDisabledCommand(guildID string, command string, disabled bool) error {
+	ret := _m.Called(guildID, command, disabled)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string) error); ok {
-		r0 = rf(guildID, chanID)
+	if rf, ok := ret.Get(0).(func(string, string, bool) error); ok {
+		r0 = rf(guildID, command, disabled)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -2586,13 +4709,13 @@ func (_m *Database) SetGuildBirthdayChan(guildID string, chanID string) error {
 	return r0
 }
 
-// SetGuildCodeExecEnabled provides a mock function with given fields: guildID, enabled
-func (_m *Database) SetGuildCodeExecEnabled(guildID string, enabled bool) error {
-	ret := _m.Called(guildID, enabled)
+// SetGuildDisabledCommandMessage provides a mock function with given fields: guildID, msg, silent
+func (_m *Database) SetGuildDisabledCommandMessage(guildID string, msg string, silent bool) error {
+	ret := _m.Called(guildID, msg, silent)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, bool) error); ok {
-		r0 = rf(guildID, enabled)
+	if rf, ok := ret.Get(0).(func(string, string, bool) error); ok {
+		r0 = rf(guildID, msg, silent)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -2600,13 +4723,13 @@ func (_m *Database) SetGuildCodeExecEnabled(guildID string, enabled bool) error
 	return r0
 }
 
-// SetGuildColorReaction provides a mock function with given fields: guildID, enable
-func (_m *Database) SetGuildColorReaction(guildID string, enable bool) error {
-	ret := _m.Called(guildID, enable)
+// SetGuildEmbedColor provides a mock function with given fields: guildID, color
+func (_m *Database) SetGuildEmbedColor(guildID string, color int) error {
+	ret := _m.Called(guildID, color)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, bool) error); ok {
-		r0 = rf(guildID, enable)
+	if rf, ok := ret.Get(0).(func(string, int) error); ok {
+		r0 = rf(guildID, color)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -2698,6 +4821,34 @@ func (_m *Database) SetGuildLogDisable(guildID string, enabled bool) error {
 	return r0
 }
 
+// SetGuildMemberMsgGrace provides a mock function with given fields: guildID, period, noteRejoin
+func (_m *Database) SetGuildMemberMsgGrace(guildID string, period time.Duration, noteRejoin bool) error {
+	ret := _m.Called(guildID, period, noteRejoin)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, time.Duration, bool) error); ok {
+		r0 = rf(guildID, period, noteRejoin)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetGuildMessageStatsEnabled provides a mock function with given fields: guildID, enabled
+func (_m *Database) SetGuildMessageStatsEnabled(guildID string, enabled bool) error {
+	ret := _m.Called(guildID, enabled)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, bool) error); ok {
+		r0 = rf(guildID, enabled)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetGuildModLog provides a mock function with given fields: guildID, chanID
 func (_m *Database) SetGuildModLog(guildID string, chanID string) error {
 	ret := _m.Called(guildID, chanID)
@@ -2712,6 +4863,34 @@ func (_m *Database) SetGuildModLog(guildID string, chanID string) error {
 	return r0
 }
 
+// SetGuildModLogFormat provides a mock function with given fields: guildID, format
+func (_m *Database) SetGuildModLogFormat(guildID string, format models.ModLogFormat) error {
+	ret := _m.Called(guildID, format)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, models.ModLogFormat) error); ok {
+		r0 = rf(guildID, format)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetGuildModLogRoute provides a mock function with given fields: guildID, action, channelID
+func (_m *Database) SetGuildModLogRoute(guildID string, action models.ModLogAction, channelID string) error {
+	ret := _m.Called(guildID, action, channelID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, models.ModLogAction, string) error); ok {
+		r0 = rf(guildID, action, channelID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetGuildModNot provides a mock function with given fields: guildID, chanID
 func (_m *Database) SetGuildModNot(guildID string, chanID string) error {
 	ret := _m.Called(guildID, chanID)
@@ -2726,6 +4905,20 @@ func (_m *Database) SetGuildModNot(guildID string, chanID string) error {
 	return r0
 }
 
+// SetGuildModmailChannel provides a mock function with given fields: guildID, chanID
+func (_m *Database) SetGuildModmailChannel(guildID string, chanID string) error {
+	ret := _m.Called(guildID, chanID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(guildID, chanID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetGuildNotifyRole provides a mock function with given fields: guildID, roleID
 func (_m *Database) SetGuildNotifyRole(guildID string, roleID string) error {
 	ret := _m.Called(guildID, roleID)
@@ -2740,6 +4933,34 @@ func (_m *Database) SetGuildNotifyRole(guildID string, roleID string) error {
 	return r0
 }
 
+// SetGuildPermDeniedMessage provides a mock function with given fields: guildID, msg, silent
+func (_m *Database) SetGuildPermDeniedMessage(guildID string, msg string, silent bool) error {
+	ret := _m.Called(guildID, msg, silent)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, bool) error); ok {
+		r0 = rf(guildID, msg, silent)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetGuildPinRotation provides a mock function with given fields: guildID, enabled
+func (_m *Database) SetGuildPinRotation(guildID string, enabled bool) error {
+	ret := _m.Called(guildID, enabled)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, bool) error); ok {
+		r0 = rf(guildID, enabled)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetGuildPrefix provides a mock function with given fields: guildID, newPrefix
 func (_m *Database) SetGuildPrefix(guildID string, newPrefix string) error {
 	ret := _m.Called(guildID, newPrefix)
@@ -2754,6 +4975,34 @@ func (_m *Database) SetGuildPrefix(guildID string, newPrefix string) error {
 	return r0
 }
 
+// SetGuildReportEscalation provides a mock function with given fields: e
+func (_m *Database) SetGuildReportEscalation(e models.ReportEscalation) error {
+	ret := _m.Called(e)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(models.ReportEscalation) error); ok {
+		r0 = rf(e)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetGuildReportType provides a mock function with given fields: t
+func (_m *Database) SetGuildReportType(t models.CustomReportType) error {
+	ret := _m.Called(t)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(models.CustomReportType) error); ok {
+		r0 = rf(t)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetGuildRolePermission provides a mock function with given fields: guildID, roleID, p
 func (_m *Database) SetGuildRolePermission(guildID string, roleID string, p permissions.PermissionArray) error {
 	ret := _m.Called(guildID, roleID, p)
@@ -2768,6 +5017,20 @@ func (_m *Database) SetGuildRolePermission(guildID string, roleID string, p perm
 	return r0
 }
 
+// SetGuildRules provides a mock function with given fields: r
+func (_m *Database) SetGuildRules(r models.GuildRules) error {
+	ret := _m.Called(r)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(models.GuildRules) error); ok {
+		r0 = rf(r)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetGuildVerificationRequired provides a mock function with given fields: guildID, enable
 func (_m *Database) SetGuildVerificationRequired(guildID string, enable bool) error {
 	ret := _m.Called(guildID, enable)
@@ -2810,6 +5073,20 @@ func (_m *Database) SetGuildVoiceLogIngore(guildID string, channelID string) err
 	return r0
 }
 
+// SetInactivitySettings provides a mock function with given fields: s
+func (_m *Database) SetInactivitySettings(s models.InactivitySettings) error {
+	ret := _m.Called(s)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(models.InactivitySettings) error); ok {
+		r0 = rf(s)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetKarma provides a mock function with given fields: userID, guildID, val
 func (_m *Database) SetKarma(userID string, guildID string, val int) error {
 	ret := _m.Called(userID, guildID, val)
@@ -2838,6 +5115,20 @@ func (_m *Database) SetKarmaEmotes(guildID string, emotesInc string, emotesDec s
 	return r0
 }
 
+// SetKarmaLimits provides a mock function with given fields: guildID, limits
+func (_m *Database) SetKarmaLimits(guildID string, limits models.KarmaLimits) error {
+	ret := _m.Called(guildID, limits)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, models.KarmaLimits) error); ok {
+		r0 = rf(guildID, limits)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetKarmaPenalty provides a mock function with given fields: guildID, state
 func (_m *Database) SetKarmaPenalty(guildID string, state bool) error {
 	ret := _m.Called(guildID, state)
@@ -2894,6 +5185,76 @@ func (_m *Database) SetLockChan(chanID string, guildID string, executorID string
 	return r0
 }
 
+// SetMemberActivities provides a mock function with given fields: entries
+func (_m *Database) SetMemberActivities(entries []models.MemberActivity) error {
+	ret := _m.Called(entries)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]models.MemberActivity) error); ok {
+		r0 = rf(entries)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetMemberActivityWarned provides a mock function with given fields: a
+func (_m *Database) SetMemberActivityWarned(a models.MemberActivity) error {
+	ret := _m.Called(a)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(models.MemberActivity) error); ok {
+		r0 = rf(a)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetModmailThread provides a mock function with given fields: t
+func (_m *Database) SetModmailThread(t models.ModmailThread) error {
+	ret := _m.Called(t)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(models.ModmailThread) error); ok {
+		r0 = rf(t)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetReactionReportConfig provides a mock function with given fields: cfg
+func (_m *Database) SetReactionReportConfig(cfg models.ReactionReportConfig) error {
+	ret := _m.Called(cfg)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(models.ReactionReportConfig) error); ok {
+		r0 = rf(cfg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetRulesAcceptance provides a mock function with given fields: guildID, userID, version
+func (_m *Database) SetRulesAcceptance(guildID string, userID string, version int) error {
+	ret := _m.Called(guildID, userID, version)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, int) error); ok {
+		r0 = rf(guildID, userID, version)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetSetting provides a mock function with given fields: setting, value
 func (_m *Database) SetSetting(setting string, value string) error {
 	ret := _m.Called(setting, value)
@@ -2936,6 +5297,34 @@ func (_m *Database) SetStarboardEntry(e models.StarboardEntry) error {
 	return r0
 }
 
+// SetStickyMessage provides a mock function with given fields: sm
+func (_m *Database) SetStickyMessage(sm models.StickyMessage) error {
+	ret := _m.Called(sm)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(models.StickyMessage) error); ok {
+		r0 = rf(sm)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetTempRole provides a mock function with given fields: t
+func (_m *Database) SetTempRole(t models.TempRole) error {
+	ret := _m.Called(t)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(models.TempRole) error); ok {
+		r0 = rf(t)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetTwitchNotify provides a mock function with given fields: twitchNotify
 func (_m *Database) SetTwitchNotify(twitchNotify twitchnotify.DBEntry) error {
 	ret := _m.Called(twitchNotify)
@@ -3006,6 +5395,34 @@ func (_m *Database) SetUserVerified(userID string, enabled bool) error {
 	return r0
 }
 
+// SetWordFilterEntry provides a mock function with given fields: e
+func (_m *Database) SetWordFilterEntry(e models.WordFilterEntry) error {
+	ret := _m.Called(e)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(models.WordFilterEntry) error); ok {
+		r0 = rf(e)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetWordFilterSettings provides a mock function with given fields: s
+func (_m *Database) SetWordFilterSettings(s models.WordFilterSettings) error {
+	ret := _m.Called(s)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(models.WordFilterSettings) error); ok {
+		r0 = rf(s)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Status provides a mock function with given fields:
 func (_m *Database) Status() error {
 	ret := _m.Called()
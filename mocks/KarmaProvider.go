@@ -6,6 +6,8 @@ import (
 	discordgo "github.com/bwmarrin/discordgo"
 
 	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/zekroTJA/shinpuru/internal/models"
 )
 
 // KarmaProvider is an autogenerated mock type for the Provider type
@@ -51,6 +53,44 @@ func (_m *KarmaProvider) CheckAndUpdate(guildID string, executorID string, objec
 	return r0, r1
 }
 
+// CheckLimits provides a mock function with given fields: guildID, executorID, receiverID
+func (_m *KarmaProvider) CheckLimits(guildID string, executorID string, receiverID string) error {
+	ret := _m.Called(guildID, executorID, receiverID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
+		r0 = rf(guildID, executorID, receiverID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetLimits provides a mock function with given fields: guildID
+func (_m *KarmaProvider) GetLimits(guildID string) (models.KarmaLimits, error) {
+	ret := _m.Called(guildID)
+
+	var r0 models.KarmaLimits
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (models.KarmaLimits, error)); ok {
+		return rf(guildID)
+	}
+	if rf, ok := ret.Get(0).(func(string) models.KarmaLimits); ok {
+		r0 = rf(guildID)
+	} else {
+		r0 = ret.Get(0).(models.KarmaLimits)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(guildID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetState provides a mock function with given fields: guildID
 func (_m *KarmaProvider) GetState(guildID string) (bool, error) {
 	ret := _m.Called(guildID)
@@ -99,6 +139,34 @@ func (_m *KarmaProvider) IsBlockListed(guildID string, userID string) (bool, err
 	return r0, r1
 }
 
+// Reset provides a mock function with given fields: guildID, userID, executorID, value
+func (_m *KarmaProvider) Reset(guildID string, userID string, executorID string, value int) error {
+	ret := _m.Called(guildID, userID, executorID, value)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string, int) error); ok {
+		r0 = rf(guildID, userID, executorID, value)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResetGuild provides a mock function with given fields: guildID, executorID
+func (_m *KarmaProvider) ResetGuild(guildID string, executorID string) error {
+	ret := _m.Called(guildID, executorID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(guildID, executorID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: guildID, userID, executorID, value
 func (_m *KarmaProvider) Update(guildID string, userID string, executorID string, value int) error {
 	ret := _m.Called(guildID, userID, executorID, value)
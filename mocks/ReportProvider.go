@@ -4,6 +4,7 @@ package mocks
 
 import (
 	discordgo "github.com/bwmarrin/discordgo"
+
 	mock "github.com/stretchr/testify/mock"
 
 	models "github.com/zekroTJA/shinpuru/internal/models"
@@ -46,6 +47,61 @@ func (_m *ReportProvider) ExpireLastReport(guildID string, victimID string, typ
 	return r0
 }
 
+// GetReportTypes provides a mock function with given fields: guildID
+func (_m *ReportProvider) GetReportTypes(guildID string) (models.ReportTypeSet, error) {
+	ret := _m.Called(guildID)
+
+	var r0 models.ReportTypeSet
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (models.ReportTypeSet, error)); ok {
+		return rf(guildID)
+	}
+	if rf, ok := ret.Get(0).(func(string) models.ReportTypeSet); ok {
+		r0 = rf(guildID)
+	} else {
+		r0 = ret.Get(0).(models.ReportTypeSet)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(guildID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserPoints provides a mock function with given fields: guildID, userID
+func (_m *ReportProvider) GetUserPoints(guildID string, userID string) (int, bool, error) {
+	ret := _m.Called(guildID, userID)
+
+	var r0 int
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(string, string) (int, bool, error)); ok {
+		return rf(guildID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) int); ok {
+		r0 = rf(guildID, userID)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) bool); ok {
+		r1 = rf(guildID, userID)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(string, string) error); ok {
+		r2 = rf(guildID, userID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // PushBan provides a mock function with given fields: rep
 func (_m *ReportProvider) PushBan(rep models.Report) (models.Report, error) {
 	ret := _m.Called(rep)
@@ -168,25 +224,25 @@ func (_m *ReportProvider) RevokeMute(guildID string, executorID string, victimID
 	return r0, r1
 }
 
-// RevokeReport provides a mock function with given fields: rep, executorID, reason, wsPublicAddr
-func (_m *ReportProvider) RevokeReport(rep models.Report, executorID string, reason string, wsPublicAddr string) (*discordgo.MessageEmbed, error) {
-	ret := _m.Called(rep, executorID, reason, wsPublicAddr)
+// RevokeReport provides a mock function with given fields: rep, executorID, reason, wsPublicAddr, opts
+func (_m *ReportProvider) RevokeReport(rep models.Report, executorID string, reason string, wsPublicAddr string, opts models.ReportRevokeOptions) (*discordgo.MessageEmbed, error) {
+	ret := _m.Called(rep, executorID, reason, wsPublicAddr, opts)
 
 	var r0 *discordgo.MessageEmbed
 	var r1 error
-	if rf, ok := ret.Get(0).(func(models.Report, string, string, string) (*discordgo.MessageEmbed, error)); ok {
-		return rf(rep, executorID, reason, wsPublicAddr)
+	if rf, ok := ret.Get(0).(func(models.Report, string, string, string, models.ReportRevokeOptions) (*discordgo.MessageEmbed, error)); ok {
+		return rf(rep, executorID, reason, wsPublicAddr, opts)
 	}
-	if rf, ok := ret.Get(0).(func(models.Report, string, string, string) *discordgo.MessageEmbed); ok {
-		r0 = rf(rep, executorID, reason, wsPublicAddr)
+	if rf, ok := ret.Get(0).(func(models.Report, string, string, string, models.ReportRevokeOptions) *discordgo.MessageEmbed); ok {
+		r0 = rf(rep, executorID, reason, wsPublicAddr, opts)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*discordgo.MessageEmbed)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(models.Report, string, string, string) error); ok {
-		r1 = rf(rep, executorID, reason, wsPublicAddr)
+	if rf, ok := ret.Get(1).(func(models.Report, string, string, string, models.ReportRevokeOptions) error); ok {
+		r1 = rf(rep, executorID, reason, wsPublicAddr, opts)
 	} else {
 		r1 = ret.Error(1)
 	}
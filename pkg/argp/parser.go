@@ -40,6 +40,25 @@ func New(args ...[]string) (p *Parser) {
 	return
 }
 
+// NewFromString initializes a new instance of Parser
+// with the arguments contained in the given string.
+//
+// The string is split into arguments using Split, so
+// arguments containing whitespace can be wrapped in
+// double quotes and quotes can be escaped with a
+// backslash. If the string contains unbalanced quotes,
+// an error is returned.
+func NewFromString(s string) (p *Parser, err error) {
+	args, err := Split(s)
+	if err != nil {
+		return
+	}
+	p = &Parser{
+		args: args,
+	}
+	return
+}
+
 // Scan looks for the passed flag (unprefixed) in
 // the arguments array. If the flag was found, the
 // value of the flag is scanned into the pointer
@@ -64,7 +83,7 @@ func (p *Parser) Scan(flag string, val interface{}) (ok bool, err error) {
 	)
 
 	for i, arg = range p.args {
-		if strings.HasPrefix(arg, flag) {
+		if arg == flag || strings.HasPrefix(arg, flag+"=") {
 			found = true
 			break
 		}
@@ -91,11 +110,11 @@ func (p *Parser) Scan(flag string, val interface{}) (ok bool, err error) {
 		sval = split[1]
 	}
 
-	if _, isStr := val.(*string); isStr {
-		sval = "\"" + sval + "\""
+	if str, isStr := val.(*string); isStr {
+		*str = sval
+	} else {
+		err = json.Unmarshal([]byte(sval), val)
 	}
-
-	err = json.Unmarshal([]byte(sval), val)
 	ok = err == nil
 
 	if ok {
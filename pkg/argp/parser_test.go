@@ -206,3 +206,39 @@ func TestArgs(t *testing.T) {
 			rArgs)
 	}
 }
+
+func TestNewFromString(t *testing.T) {
+	{
+		p, err := NewFromString(`spam in chat --reason "said \"hi\"" --silent`)
+		assert.Nil(t, err)
+
+		reason, err := p.String("--reason", "")
+		assert.Equal(t, `said "hi"`, reason)
+		assert.Nil(t, err)
+
+		silent, err := p.Bool("--silent", false)
+		assert.Equal(t, true, silent)
+		assert.Nil(t, err)
+
+		assert.Equal(t, []string{"spam", "in", "chat"}, p.Args())
+	}
+	{
+		p, err := NewFromString(`--silentmode --reason="a b"`)
+		assert.Nil(t, err)
+
+		silent, err := p.Bool("--silent", false)
+		assert.Equal(t, false, silent)
+		assert.Nil(t, err)
+
+		reason, err := p.String("--reason", "")
+		assert.Equal(t, "a b", reason)
+		assert.Nil(t, err)
+
+		assert.Equal(t, []string{"--silentmode"}, p.Args())
+	}
+	{
+		p, err := NewFromString(`--reason "unterminated`)
+		assert.Nil(t, p)
+		assert.ErrorIs(t, err, ErrUnbalancedQuotes)
+	}
+}
@@ -0,0 +1,74 @@
+package argp
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+)
+
+var (
+	// ErrUnbalancedQuotes is returned when a quoted
+	// argument is not terminated.
+	ErrUnbalancedQuotes = errors.New("unbalanced quotes")
+	// ErrTrailingEscape is returned when the arguments
+	// string ends with an escape character.
+	ErrTrailingEscape = errors.New("trailing escape character")
+)
+
+// Split splits the given string into arguments
+// separated by whitespace.
+//
+// Whitespace can be contained in an argument by
+// wrapping it in double quotes. A backslash escapes
+// the following character, so that quotes and
+// backslashes can be contained in an argument as
+// well.
+//
+// Example:
+//
+//	args, err := argp.Split(`spam --reason "said \"hi\"" --silent`)
+//	// args = []string{"spam", "--reason", `said "hi"`, "--silent"}
+//	// err  = nil
+func Split(s string) (args []string, err error) {
+	var (
+		sb      strings.Builder
+		inArg   bool
+		quoted  bool
+		escaped bool
+	)
+
+	for _, r := range s {
+		switch {
+		case escaped:
+			sb.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+			inArg = true
+		case r == '"':
+			quoted = !quoted
+			inArg = true
+		case unicode.IsSpace(r) && !quoted:
+			if inArg {
+				args = append(args, sb.String())
+				sb.Reset()
+				inArg = false
+			}
+		default:
+			sb.WriteRune(r)
+			inArg = true
+		}
+	}
+
+	if escaped {
+		return nil, ErrTrailingEscape
+	}
+	if quoted {
+		return nil, ErrUnbalancedQuotes
+	}
+	if inArg {
+		args = append(args, sb.String())
+	}
+
+	return
+}
@@ -0,0 +1,65 @@
+package argp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplit(t *testing.T) {
+	{
+		res, err := Split("")
+		assert.Nil(t, res)
+		assert.Nil(t, err)
+	}
+	{
+		res, err := Split("  \t ")
+		assert.Nil(t, res)
+		assert.Nil(t, err)
+	}
+	{
+		res, err := Split("a  b\tc\n d")
+		assert.Equal(t, []string{"a", "b", "c", "d"}, res)
+		assert.Nil(t, err)
+	}
+	{
+		res, err := Split(`a "b c" d`)
+		assert.Equal(t, []string{"a", "b c", "d"}, res)
+		assert.Nil(t, err)
+	}
+	{
+		res, err := Split(`--reason="b c"d`)
+		assert.Equal(t, []string{"--reason=b cd"}, res)
+		assert.Nil(t, err)
+	}
+	{
+		res, err := Split(`a "" b`)
+		assert.Equal(t, []string{"a", "", "b"}, res)
+		assert.Nil(t, err)
+	}
+	{
+		res, err := Split(`"said \"hi\"" it\'s`)
+		assert.Equal(t, []string{`said "hi"`, "it's"}, res)
+		assert.Nil(t, err)
+	}
+	{
+		res, err := Split(`a\ b c\\`)
+		assert.Equal(t, []string{"a b", `c\`}, res)
+		assert.Nil(t, err)
+	}
+	{
+		res, err := Split(`don't "escape me`)
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, ErrUnbalancedQuotes)
+	}
+	{
+		res, err := Split(`a "b \" c`)
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, ErrUnbalancedQuotes)
+	}
+	{
+		res, err := Split(`a b\`)
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, ErrTrailingEscape)
+	}
+}
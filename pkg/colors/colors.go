@@ -35,6 +35,10 @@ func FromHex(hexVal string) (*color.RGBA, error) {
 		return nil, err
 	}
 
+	if len(v) != 3 && len(v) != 4 {
+		return nil, errors.New("invalid color format")
+	}
+
 	if len(v) < 4 {
 		v = append(v, 255)
 	}
@@ -54,21 +58,87 @@ func ToHex(clr *color.RGBA) string {
 	return fmt.Sprintf("%06X", ToInt(clr))
 }
 
+// ImageOptions specifies optional properties of
+// images generated by CreateImage.
+type ImageOptions struct {
+	// BorderColor is the color of the border drawn
+	// around the image. No border is drawn if nil.
+	BorderColor *color.RGBA
+	// BorderWidth is the width of the border in
+	// pixels. Defaults to 1 if BorderColor is set.
+	BorderWidth int
+}
+
 // CreateImage generates a PNG image filled with
 // the passed color in the size of the passed
 // xSize and ySize dimensions.
 //
+// The alpha value of the passed color is applied
+// as transparency of the image; in that case, the
+// PNG is encoded with an alpha channel. Optionally,
+// a border can be drawn around the image by passing
+// ImageOptions.
+//
+// The generated image is returned as bytes.Buffer
+// reference. When the image generation fails, an
+// error is returned.
+func CreateImage(clr *color.RGBA, xSize, ySize int, opts ...ImageOptions) (*bytes.Buffer, error) {
+	var opt ImageOptions
+	if len(opts) != 0 {
+		opt = opts[0]
+	}
+
+	if opt.BorderColor == nil {
+		return CreateStripImage([]*color.RGBA{clr}, xSize, ySize)
+	}
+
+	if xSize < 1 || ySize < 1 {
+		return nil, errors.New("invalid image size")
+	}
+
+	if opt.BorderWidth == 0 {
+		opt.BorderWidth = 1
+	}
+	if opt.BorderWidth < 0 || opt.BorderWidth*2 > xSize || opt.BorderWidth*2 > ySize {
+		return nil, errors.New("invalid border width")
+	}
+
+	img := image.NewNRGBA(image.Rect(0, 0, xSize, ySize))
+	draw.Draw(img, img.Bounds(), &image.Uniform{toNRGBA(opt.BorderColor)}, image.Point{}, draw.Src)
+	inner := img.Bounds().Inset(opt.BorderWidth)
+	draw.Draw(img, inner, &image.Uniform{toNRGBA(clr)}, image.Point{}, draw.Src)
+
+	return encodePNG(img)
+}
+
+// CreateStripImage generates a PNG image in the
+// size of the passed xSize and ySize dimensions
+// filled with equally wide vertical stripes of
+// the passed colors in the given order.
+//
 // The generated image is returned as bytes.Buffer
 // reference. When the image generation fails, an
 // error is returned.
-func CreateImage(clr *color.RGBA, xSize, ySize int) (*bytes.Buffer, error) {
-	// Create image and fill it with the color
-	// of the clr color object.
-	img := image.NewRGBA(image.Rect(0, 0, xSize, ySize))
-	draw.Draw(img, img.Bounds(), &image.Uniform{*clr}, image.Point{}, draw.Src)
-
-	// Encode image object to image data using
-	// the png encoder
+func CreateStripImage(clrs []*color.RGBA, xSize, ySize int) (*bytes.Buffer, error) {
+	if len(clrs) == 0 {
+		return nil, errors.New("no colors passed")
+	}
+
+	// Create image and fill each stripe with
+	// the color of the corresponding color
+	// object.
+	img := image.NewNRGBA(image.Rect(0, 0, xSize, ySize))
+	for i, clr := range clrs {
+		rect := image.Rect(xSize*i/len(clrs), 0, xSize*(i+1)/len(clrs), ySize)
+		draw.Draw(img, rect, &image.Uniform{toNRGBA(clr)}, image.Point{}, draw.Src)
+	}
+
+	return encodePNG(img)
+}
+
+// encodePNG encodes the passed image to image
+// data using the png encoder.
+func encodePNG(img image.Image) (*bytes.Buffer, error) {
 	buff := bytes.NewBuffer([]byte{})
 	if err := png.Encode(buff, img); err != nil {
 		return nil, err
@@ -77,6 +147,13 @@ func CreateImage(clr *color.RGBA, xSize, ySize int) (*bytes.Buffer, error) {
 	return buff, nil
 }
 
+// toNRGBA returns the passed color as non-alpha-
+// premultiplied color so that its alpha value is
+// applied as is, like it is parsed by FromHex.
+func toNRGBA(clr *color.RGBA) color.NRGBA {
+	return color.NRGBA{clr.R, clr.G, clr.B, clr.A}
+}
+
 // GetVibrantColorFromImage returns the vribrant accent
 // color of an image passed.
 func GetVibrantColorFromImage(img image.Image) (clr int, err error) {
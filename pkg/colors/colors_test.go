@@ -2,7 +2,9 @@ package colors
 
 import (
 	"fmt"
+	"image"
 	"image/color"
+	"image/png"
 	"testing"
 )
 
@@ -34,6 +36,14 @@ func TestFromHex(t *testing.T) {
 	if _, err := FromHex("zzzzzz"); err == nil {
 		t.Error("no error returned on invalid hex val")
 	}
+
+	if _, err := FromHex("8e0c"); err == nil {
+		t.Error("no error returned on too short hex val")
+	}
+
+	if _, err := FromHex("8e0cf2ff00"); err == nil {
+		t.Error("no error returned on too long hex val")
+	}
 }
 
 func TestToInt(t *testing.T) {
@@ -81,6 +91,37 @@ func TestCreateImage(t *testing.T) {
 	}
 }
 
+func TestCreateImageOptions(t *testing.T) {
+	border := &color.RGBA{255, 255, 255, 255}
+	clr := &color.RGBA{142, 12, 242, 128}
+
+	if _, err := CreateImage(clr, 8, 8, ImageOptions{BorderColor: border, BorderWidth: 5}); err == nil {
+		t.Error("no error when border is wider than the image")
+	}
+
+	buff, err := CreateImage(clr, 8, 8, ImageOptions{BorderColor: border, BorderWidth: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	img, err := png.Decode(buff)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := img.(*image.NRGBA); !ok {
+		t.Errorf("image has no alpha channel: %T", img)
+	}
+
+	if c := color.NRGBAModel.Convert(img.At(1, 1)); c != (color.NRGBA{255, 255, 255, 255}) {
+		t.Errorf("border pixel has wrong color: %+v", c)
+	}
+
+	if c := color.NRGBAModel.Convert(img.At(4, 4)); c != (color.NRGBA{142, 12, 242, 128}) {
+		t.Errorf("inner pixel has wrong color: %+v", c)
+	}
+}
+
 func rgbaEquals(c1, c2 *color.RGBA) bool {
 	return c1.R == c2.R &&
 		c1.G == c2.G &&
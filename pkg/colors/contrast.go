@@ -0,0 +1,66 @@
+package colors
+
+import (
+	"image/color"
+	"math"
+)
+
+// WCAG 2 conformance level names.
+const (
+	WCAGLevelAAA  = "AAA"
+	WCAGLevelAA   = "AA"
+	WCAGLevelFail = "Fail"
+)
+
+// RelativeLuminance returns the relative luminance
+// of the passed color as defined by WCAG 2 in the
+// range of 0 (black) to 1 (white).
+func RelativeLuminance(clr color.Color) float64 {
+	r, g, b, _ := clr.RGBA()
+	return 0.2126*linearize(r) + 0.7152*linearize(g) + 0.0722*linearize(b)
+}
+
+// ContrastRatio returns the WCAG 2 contrast ratio
+// between the two passed colors in the range of
+// 1 (no contrast) to 21 (black on white).
+//
+// The order of the passed colors does not matter.
+func ContrastRatio(a, b color.Color) float64 {
+	la, lb := RelativeLuminance(a), RelativeLuminance(b)
+	if la < lb {
+		la, lb = lb, la
+	}
+	return (la + 0.05) / (lb + 0.05)
+}
+
+// WCAGLevel returns the highest WCAG 2 conformance
+// level the passed contrast ratio passes for either
+// normal or large text.
+//
+// If the ratio does not pass any level, WCAGLevelFail
+// is returned.
+func WCAGLevel(ratio float64, largeText bool) string {
+	aa, aaa := 4.5, 7.0
+	if largeText {
+		aa, aaa = 3.0, 4.5
+	}
+
+	switch {
+	case ratio >= aaa:
+		return WCAGLevelAAA
+	case ratio >= aa:
+		return WCAGLevelAA
+	default:
+		return WCAGLevelFail
+	}
+}
+
+// linearize converts a 16 bit sRGB color channel
+// value to its linear representation.
+func linearize(v uint32) float64 {
+	c := float64(v) / 0xffff
+	if c <= 0.03928 {
+		return c / 12.92
+	}
+	return math.Pow((c+0.055)/1.055, 2.4)
+}
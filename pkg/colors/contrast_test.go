@@ -0,0 +1,57 @@
+package colors
+
+import (
+	"image/color"
+	"math"
+	"testing"
+)
+
+func TestContrastRatio(t *testing.T) {
+	black := &color.RGBA{0, 0, 0, 255}
+	white := &color.RGBA{255, 255, 255, 255}
+
+	cases := []struct {
+		a, b  color.Color
+		ratio float64
+	}{
+		{black, white, 21},
+		{white, black, 21},
+		{white, white, 1},
+		{black, black, 1},
+		{&color.RGBA{119, 119, 119, 255}, white, 4.48},
+		{&color.RGBA{0, 0, 255, 255}, white, 8.59},
+		{&color.RGBA{255, 0, 0, 255}, black, 5.25},
+	}
+
+	for _, c := range cases {
+		if r := ContrastRatio(c.a, c.b); math.Abs(r-c.ratio) > 0.01 {
+			t.Errorf("contrast ratio of %+v and %+v was %.2f (expected %.2f)",
+				c.a, c.b, r, c.ratio)
+		}
+	}
+}
+
+func TestWCAGLevel(t *testing.T) {
+	cases := []struct {
+		ratio     float64
+		largeText bool
+		level     string
+	}{
+		{21, false, WCAGLevelAAA},
+		{7, false, WCAGLevelAAA},
+		{6.9, false, WCAGLevelAA},
+		{4.5, false, WCAGLevelAA},
+		{4.49, false, WCAGLevelFail},
+		{4.5, true, WCAGLevelAAA},
+		{3, true, WCAGLevelAA},
+		{2.99, true, WCAGLevelFail},
+		{1, true, WCAGLevelFail},
+	}
+
+	for _, c := range cases {
+		if l := WCAGLevel(c.ratio, c.largeText); l != c.level {
+			t.Errorf("level of %.2f (large: %t) was %s (expected %s)",
+				c.ratio, c.largeText, l, c.level)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package colors
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/gif"
+)
+
+const (
+	// MaxGradientFrames is the maximum number of
+	// frames of a gradient GIF.
+	MaxGradientFrames = 60
+	// MaxGradientSize is the maximum dimension of
+	// a gradient GIF in pixels.
+	MaxGradientSize = 256
+
+	// gradientFrameDelay is the delay between two
+	// frames in 100ths of a second.
+	gradientFrameDelay = 5
+	// gradientHoldDelay is the delay of the first
+	// and last frame in 100ths of a second.
+	gradientHoldDelay = 50
+)
+
+// CreateGradientGIF generates an animated, endlessly
+// looping GIF image of the passed square size which
+// transitions linearly from the color from to the
+// color to in the given number of frames.
+//
+// frames must be in range [2, MaxGradientFrames] and
+// size must be in range [1, MaxGradientSize]. Because
+// GIF images do not support partial transparency, the
+// alpha values of the colors are ignored.
+//
+// The generated image is returned as bytes.Buffer
+// reference. When the image generation fails, an
+// error is returned.
+func CreateGradientGIF(from, to color.Color, frames, size int) (*bytes.Buffer, error) {
+	if frames < 2 || frames > MaxGradientFrames {
+		return nil, errors.New("invalid frame count")
+	}
+	if size < 1 || size > MaxGradientSize {
+		return nil, errors.New("invalid image size")
+	}
+
+	cFrom := color.NRGBAModel.Convert(from).(color.NRGBA)
+	cTo := color.NRGBAModel.Convert(to).(color.NRGBA)
+
+	anim := &gif.GIF{
+		Image: make([]*image.Paletted, frames),
+		Delay: make([]int, frames),
+	}
+
+	rect := image.Rect(0, 0, size, size)
+	for i := 0; i < frames; i++ {
+		t := float64(i) / float64(frames-1)
+		clr := color.NRGBA{
+			R: lerp(cFrom.R, cTo.R, t),
+			G: lerp(cFrom.G, cTo.G, t),
+			B: lerp(cFrom.B, cTo.B, t),
+			A: 0xff,
+		}
+
+		// All pixels of a paletted image refer to
+		// the first color of the palette by default.
+		anim.Image[i] = image.NewPaletted(rect, color.Palette{clr})
+		anim.Delay[i] = gradientFrameDelay
+	}
+	anim.Delay[0] = gradientHoldDelay
+	anim.Delay[frames-1] = gradientHoldDelay
+
+	buff := bytes.NewBuffer([]byte{})
+	if err := gif.EncodeAll(buff, anim); err != nil {
+		return nil, err
+	}
+
+	return buff, nil
+}
+
+// lerp returns the linear interpolation between
+// a and b at t in range [0, 1].
+func lerp(a, b uint8, t float64) uint8 {
+	return uint8(float64(a) + (float64(b)-float64(a))*t + 0.5)
+}
@@ -0,0 +1,50 @@
+package colors
+
+import (
+	"image/color"
+	"image/gif"
+	"testing"
+)
+
+func TestCreateGradientGIF(t *testing.T) {
+	from := &color.RGBA{0, 0, 0, 255}
+	to := &color.RGBA{255, 128, 0, 255}
+
+	buff, err := CreateGradientGIF(from, to, 10, 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	anim, err := gif.DecodeAll(buff)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(anim.Image) != 10 {
+		t.Errorf("frame count was %d (should be 10)", len(anim.Image))
+	}
+	if b := anim.Image[0].Bounds(); b.Dx() != 32 || b.Dy() != 32 {
+		t.Errorf("image size was %dx%d (should be 32x32)", b.Dx(), b.Dy())
+	}
+
+	first := color.NRGBAModel.Convert(anim.Image[0].At(0, 0)).(color.NRGBA)
+	if first != (color.NRGBA{0, 0, 0, 255}) {
+		t.Errorf("first frame color was %v", first)
+	}
+	last := color.NRGBAModel.Convert(anim.Image[9].At(0, 0)).(color.NRGBA)
+	if last != (color.NRGBA{255, 128, 0, 255}) {
+		t.Errorf("last frame color was %v", last)
+	}
+}
+
+func TestCreateGradientGIFBounds(t *testing.T) {
+	if _, err := CreateGradientGIF(refClr, refClr, 1, 24); err == nil {
+		t.Error("no error on too few frames")
+	}
+	if _, err := CreateGradientGIF(refClr, refClr, MaxGradientFrames+1, 24); err == nil {
+		t.Error("no error on too many frames")
+	}
+	if _, err := CreateGradientGIF(refClr, refClr, 10, MaxGradientSize+1); err == nil {
+		t.Error("no error on too large size")
+	}
+}
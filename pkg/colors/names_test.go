@@ -0,0 +1,29 @@
+package colors
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestFromName(t *testing.T) {
+	cases := map[string]*color.RGBA{
+		"tomato":        {255, 99, 71, 255},
+		"rebeccapurple": {102, 51, 153, 255},
+		"RebeccaPurple": {102, 51, 153, 255},
+		"WHITE":         {255, 255, 255, 255},
+	}
+
+	for name, expected := range cases {
+		if clr, ok := FromName(name); !ok {
+			t.Errorf("name %s was not recognized", name)
+		} else if !rgbaEquals(clr, expected) {
+			t.Errorf("color of %s was %+v", name, clr)
+		}
+	}
+
+	for _, name := range []string{"", "house", "tomatoes", "#ff0000"} {
+		if _, ok := FromName(name); ok {
+			t.Errorf("name %s was recognized", name)
+		}
+	}
+}
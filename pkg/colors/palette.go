@@ -0,0 +1,137 @@
+package colors
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"sort"
+)
+
+// paletteMaxSamples is the maximum amount of pixels
+// which are sampled from an image to extract the
+// color palette.
+const paletteMaxSamples = 1 << 16
+
+// ExtractPalette returns up to n dominant colors of
+// the passed image using the median cut algorithm.
+//
+// The colors are ordered by the amount of pixels they
+// represent, starting with the most dominant color.
+// Pixels with an alpha value below 50% are ignored.
+// Large images are sampled in a regular grid so that
+// at most paletteMaxSamples pixels are processed.
+func ExtractPalette(img image.Image, n int) ([]*color.RGBA, error) {
+	if n < 1 {
+		return nil, errors.New("n must be larger than 0")
+	}
+
+	pixels := samplePixels(img)
+	if len(pixels) == 0 {
+		return nil, errors.New("image contains no opaque pixels")
+	}
+
+	buckets := []colorBucket{pixels}
+	for len(buckets) < n {
+		// Split the bucket with the largest channel
+		// range until n buckets exist or no bucket
+		// can be split anymore.
+		iMax, chMax, rMax := -1, 0, 0
+		for i, b := range buckets {
+			if len(b) < 2 {
+				continue
+			}
+			if ch, r := b.widestChannel(); r > rMax {
+				iMax, chMax, rMax = i, ch, r
+			}
+		}
+		if iMax == -1 {
+			break
+		}
+
+		lower, upper := buckets[iMax].split(chMax)
+		buckets[iMax] = lower
+		buckets = append(buckets, upper)
+	}
+
+	sort.SliceStable(buckets, func(i, j int) bool {
+		return len(buckets[i]) > len(buckets[j])
+	})
+
+	res := make([]*color.RGBA, len(buckets))
+	for i, b := range buckets {
+		res[i] = b.average()
+	}
+
+	return res, nil
+}
+
+type colorBucket [][3]uint8
+
+func (b colorBucket) widestChannel() (ch, r int) {
+	for c := 0; c < 3; c++ {
+		min, max := 255, 0
+		for _, p := range b {
+			v := int(p[c])
+			if v < min {
+				min = v
+			}
+			if v > max {
+				max = v
+			}
+		}
+		if max-min > r {
+			ch, r = c, max-min
+		}
+	}
+	return
+}
+
+func (b colorBucket) split(ch int) (lower, upper colorBucket) {
+	sort.Slice(b, func(i, j int) bool {
+		return b[i][ch] < b[j][ch]
+	})
+
+	// Move the split index to a value boundary so that
+	// equal colors do not end up in different buckets.
+	mid := len(b) / 2
+	v := b[mid][ch]
+	i := sort.Search(len(b), func(i int) bool { return b[i][ch] >= v })
+	if i == 0 {
+		i = sort.Search(len(b), func(i int) bool { return b[i][ch] > v })
+	}
+
+	return b[:i], b[i:]
+}
+
+func (b colorBucket) average() *color.RGBA {
+	var r, g, bl int
+	for _, p := range b {
+		r += int(p[0])
+		g += int(p[1])
+		bl += int(p[2])
+	}
+	l := len(b)
+	return &color.RGBA{uint8(r / l), uint8(g / l), uint8(bl / l), 255}
+}
+
+func samplePixels(img image.Image) colorBucket {
+	bounds := img.Bounds()
+
+	step := 1
+	for (bounds.Dx()/step)*(bounds.Dy()/step) > paletteMaxSamples {
+		step++
+	}
+
+	pixels := make(colorBucket, 0, (bounds.Dx()/step+1)*(bounds.Dy()/step+1))
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += step {
+		for x := bounds.Min.X; x < bounds.Max.X; x += step {
+			c := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+			if c.A < 128 {
+				continue
+			}
+			pixels = append(pixels, [3]uint8{c.R, c.G, c.B})
+		}
+	}
+
+	return pixels
+}
@@ -0,0 +1,64 @@
+package colors
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+)
+
+func TestExtractPalette(t *testing.T) {
+	var (
+		red   = &color.RGBA{255, 0, 0, 255}
+		green = &color.RGBA{0, 255, 0, 255}
+		blue  = &color.RGBA{0, 0, 255, 255}
+	)
+
+	// Red takes up half of the image, green and
+	// blue a quarter each.
+	img := image.NewRGBA(image.Rect(0, 0, 16, 16))
+	draw.Draw(img, image.Rect(0, 0, 8, 16), &image.Uniform{red}, image.Point{}, draw.Src)
+	draw.Draw(img, image.Rect(8, 0, 16, 8), &image.Uniform{green}, image.Point{}, draw.Src)
+	draw.Draw(img, image.Rect(8, 8, 16, 16), &image.Uniform{blue}, image.Point{}, draw.Src)
+
+	if _, err := ExtractPalette(img, 0); err == nil {
+		t.Error("no error when n = 0")
+	}
+
+	if _, err := ExtractPalette(image.NewRGBA(image.Rect(0, 0, 4, 4)), 3); err == nil {
+		t.Error("no error on transparent image")
+	}
+
+	palette, err := ExtractPalette(img, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(palette) != 3 {
+		t.Fatalf("palette length was %d", len(palette))
+	}
+	if !rgbaEquals(palette[0], red) {
+		t.Errorf("dominant color was %+v", palette[0])
+	}
+	if !(rgbaEquals(palette[1], green) && rgbaEquals(palette[2], blue)) &&
+		!(rgbaEquals(palette[1], blue) && rgbaEquals(palette[2], green)) {
+		t.Errorf("wrong palette colors: %+v %+v", palette[1], palette[2])
+	}
+
+	palette, err = ExtractPalette(img, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(palette) != 3 {
+		t.Errorf("palette length was %d", len(palette))
+	}
+}
+
+func TestCreateStripImage(t *testing.T) {
+	if _, err := CreateStripImage(nil, 16, 8); err == nil {
+		t.Error("no error when no colors are passed")
+	}
+
+	if _, err := CreateStripImage([]*color.RGBA{refClr, refClr}, 16, 8); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,250 @@
+package colors
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ParseAny parses the passed string to a color.RGBA
+// object reference. Following formats are supported.
+//
+//   - HEX:     "#8e0cf2", "#f0f", "#8e0cf2ff", "8e0cf2"
+//   - RGB(A):  "rgb(142, 12, 242)", "rgba(142, 12, 242, 0.5)"
+//   - HSL:     "hsl(274, 90%, 50%)"
+//   - Integer: "9309426", "0x8e0cf2"
+//   - Name:    "rebeccapurple"
+//
+// Strings only consisting of decimal digits are
+// interpreted as integer color values. Prefix them
+// with '#' to interpret them as HEX color codes.
+//
+// When the passed string has none of the formats
+// above or is malformed, an error is returned.
+func ParseAny(s string) (*color.RGBA, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" {
+		return nil, fmt.Errorf("empty color value")
+	}
+
+	switch {
+	case strings.HasPrefix(s, "#"):
+		return parseHex(s[1:])
+	case strings.HasPrefix(s, "0x"):
+		return parseInt(s[2:], 16)
+	case strings.HasPrefix(s, "rgb"):
+		return parseRGB(s)
+	case strings.HasPrefix(s, "hsl"):
+		return parseHSL(s)
+	case isDigits(s):
+		return parseInt(s, 10)
+	}
+
+	if clr, ok := FromName(s); ok {
+		return clr, nil
+	}
+
+	if clr, err := parseHex(s); err == nil {
+		return clr, nil
+	}
+
+	return nil, fmt.Errorf("unsupported color format: %s", s)
+}
+
+// ToHSL returns the hue (0-360), saturation (0-1)
+// and lightness (0-1) values of the passed color.
+func ToHSL(clr *color.RGBA) (h, s, l float64) {
+	r, g, b := float64(clr.R)/255, float64(clr.G)/255, float64(clr.B)/255
+	max := math.Max(r, math.Max(g, b))
+	min := math.Min(r, math.Min(g, b))
+
+	l = (max + min) / 2
+	if max == min {
+		return 0, 0, l
+	}
+
+	d := max - min
+	if l > 0.5 {
+		s = d / (2 - max - min)
+	} else {
+		s = d / (max + min)
+	}
+
+	switch max {
+	case r:
+		h = (g - b) / d
+		if g < b {
+			h += 6
+		}
+	case g:
+		h = (b-r)/d + 2
+	default:
+		h = (r-g)/d + 4
+	}
+	h *= 60
+
+	return
+}
+
+// FromHSL returns a color.RGBA object reference from
+// the passed hue (0-360), saturation (0-1) and
+// lightness (0-1) values.
+func FromHSL(h, s, l float64) *color.RGBA {
+	h = math.Mod(h, 360)
+	if h < 0 {
+		h += 360
+	}
+
+	c := (1 - math.Abs(2*l-1)) * s
+	x := c * (1 - math.Abs(math.Mod(h/60, 2)-1))
+	m := l - c/2
+
+	var r, g, b float64
+	switch {
+	case h < 60:
+		r, g, b = c, x, 0
+	case h < 120:
+		r, g, b = x, c, 0
+	case h < 180:
+		r, g, b = 0, c, x
+	case h < 240:
+		r, g, b = 0, x, c
+	case h < 300:
+		r, g, b = x, 0, c
+	default:
+		r, g, b = c, 0, x
+	}
+
+	return &color.RGBA{
+		uint8(math.Round((r + m) * 255)),
+		uint8(math.Round((g + m) * 255)),
+		uint8(math.Round((b + m) * 255)),
+		255,
+	}
+}
+
+func parseHex(s string) (*color.RGBA, error) {
+	if len(s) == 3 {
+		s = string([]byte{s[0], s[0], s[1], s[1], s[2], s[2]})
+	}
+	if len(s) != 6 && len(s) != 8 {
+		return nil, fmt.Errorf("invalid hex color: %s", s)
+	}
+	clr, err := FromHex(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hex color: %s", s)
+	}
+	return clr, nil
+}
+
+func parseInt(s string, base int) (*color.RGBA, error) {
+	v, err := strconv.ParseUint(s, base, 32)
+	if err != nil || v > 0xffffff {
+		return nil, fmt.Errorf("invalid integer color: %s", s)
+	}
+	return &color.RGBA{uint8(v >> 16), uint8(v >> 8), uint8(v), 255}, nil
+}
+
+func parseRGB(s string) (*color.RGBA, error) {
+	args, ok := parseFunc(s, "rgb", "rgba")
+	if !ok || (len(args) != 3 && len(args) != 4) {
+		return nil, fmt.Errorf("invalid rgb color: %s", s)
+	}
+
+	var v [4]uint8
+	v[3] = 255
+	for i, arg := range args {
+		if i == 3 {
+			a, err := parseFraction(arg)
+			if err != nil {
+				return nil, fmt.Errorf("invalid rgb alpha value: %s", arg)
+			}
+			v[3] = uint8(math.Round(a * 255))
+			continue
+		}
+		c, err := strconv.ParseUint(arg, 10, 8)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rgb channel value: %s", arg)
+		}
+		v[i] = uint8(c)
+	}
+
+	return &color.RGBA{v[0], v[1], v[2], v[3]}, nil
+}
+
+func parseHSL(s string) (*color.RGBA, error) {
+	args, ok := parseFunc(s, "hsl", "hsla")
+	if !ok || len(args) != 3 {
+		return nil, fmt.Errorf("invalid hsl color: %s", s)
+	}
+
+	h, err := strconv.ParseFloat(strings.TrimSuffix(args[0], "deg"), 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hsl hue value: %s", args[0])
+	}
+
+	var sl [2]float64
+	for i, arg := range args[1:] {
+		if !strings.HasSuffix(arg, "%") {
+			return nil, fmt.Errorf("invalid hsl percentage value: %s", arg)
+		}
+		if sl[i], err = parseFraction(arg); err != nil {
+			return nil, fmt.Errorf("invalid hsl percentage value: %s", arg)
+		}
+	}
+
+	return FromHSL(h, sl[0], sl[1]), nil
+}
+
+// parseFunc splits a CSS like function call
+// (e.g. "rgb(1, 2, 3)") into its arguments. Arguments
+// can either be separated by commas or whitespaces.
+func parseFunc(s string, names ...string) (args []string, ok bool) {
+	i := strings.IndexRune(s, '(')
+	if i == -1 || !strings.HasSuffix(s, ")") {
+		return nil, false
+	}
+
+	name := strings.TrimSpace(s[:i])
+	for _, n := range names {
+		if n == name {
+			ok = true
+			break
+		}
+	}
+	if !ok {
+		return nil, false
+	}
+
+	args = strings.FieldsFunc(s[i+1:len(s)-1], func(r rune) bool {
+		return r == ',' || r == ' ' || r == '/'
+	})
+	return args, true
+}
+
+// parseFraction parses either a percentage value
+// (e.g. "50%") or a decimal value (e.g. "0.5") to
+// a fraction in range [0, 1].
+func parseFraction(s string) (v float64, err error) {
+	if strings.HasSuffix(s, "%") {
+		v, err = strconv.ParseFloat(s[:len(s)-1], 64)
+		v /= 100
+	} else {
+		v, err = strconv.ParseFloat(s, 64)
+	}
+	if err == nil && (v < 0 || v > 1) {
+		err = fmt.Errorf("value out of range: %s", s)
+	}
+	return
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,68 @@
+package colors
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestParseAny(t *testing.T) {
+	cases := map[string]*color.RGBA{
+		"#8e0cf2":                 refClr,
+		"#8E0CF2FF":               refClr,
+		"8e0cf2":                  refClr,
+		"#f0f":                    {255, 0, 255, 255},
+		"rgb(142, 12, 242)":       refClr,
+		"RGB(142 12 242)":         refClr,
+		"rgba(142, 12, 242, 0.5)": {142, 12, 242, 128},
+		"rgba(142, 12, 242, 50%)": {142, 12, 242, 128},
+		"hsl(0, 100%, 50%)":       {255, 0, 0, 255},
+		"hsl(120deg, 100%, 25%)":  {0, 128, 0, 255},
+		"hsl(274, 90%, 50%)":      {143, 13, 242, 255},
+		"9309426":                 refClr,
+		"0x8e0cf2":                refClr,
+		"  tomato ":               {255, 99, 71, 255},
+	}
+
+	for in, expected := range cases {
+		if clr, err := ParseAny(in); err != nil {
+			t.Errorf("failed parsing %q: %s", in, err.Error())
+		} else if !rgbaEquals(clr, expected) {
+			t.Errorf("color of %q was %+v", in, clr)
+		}
+	}
+
+	invalid := []string{
+		"",
+		"#12",
+		"#zzzzzz",
+		"rgb(1, 2)",
+		"rgb(256, 0, 0)",
+		"rgb(1, 2, 3",
+		"rgba(1, 2, 3, 2)",
+		"hsl(0, 100, 50)",
+		"hsl(x, 100%, 50%)",
+		"16777216",
+		"0xzz",
+		"house",
+	}
+
+	for _, in := range invalid {
+		if _, err := ParseAny(in); err == nil {
+			t.Errorf("no error returned on %q", in)
+		}
+	}
+}
+
+func TestToHSL(t *testing.T) {
+	for _, clr := range []*color.RGBA{
+		refClr,
+		{255, 0, 0, 255},
+		{0, 0, 0, 255},
+		{255, 255, 255, 255},
+		{12, 200, 100, 255},
+	} {
+		if res := FromHSL(ToHSL(clr)); !rgbaEquals(res, clr) {
+			t.Errorf("HSL round trip of %+v resulted in %+v", clr, res)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+// Package confirm provides a simple prompt message
+// for discordgo which asks a user to confirm an
+// action via buttons.
+package confirm
+
+import (
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/rs/xid"
+)
+
+// DefaultTimeout is the duration after which a
+// prompt is treated as cancelled when no timeout
+// is specified.
+const DefaultTimeout = 30 * time.Second
+
+// Prompt sends a message with the given text into
+// the given channel asking the user with the given
+// ID to confirm or cancel an action.
+//
+// Prompt blocks until the user answered or until
+// DefaultTimeout elapsed and returns true if the
+// user confirmed. A timeout is treated as a
+// cancellation. The prompt message is deleted
+// afterwards in any case.
+func Prompt(s *discordgo.Session, channelID, userID, text string) (bool, error) {
+	return PromptTimeout(s, channelID, userID, text, DefaultTimeout)
+}
+
+// PromptTimeout is like Prompt but with a custom
+// timeout duration.
+func PromptTimeout(s *discordgo.Session, channelID, userID, text string, timeout time.Duration) (bool, error) {
+	id := "confirm-" + xid.New().String()
+	idConfirm := id + "-confirm"
+	idCancel := id + "-cancel"
+
+	cRes := make(chan bool, 1)
+	unsub := s.AddHandler(func(s *discordgo.Session, e *discordgo.InteractionCreate) {
+		if e.Type != discordgo.InteractionMessageComponent {
+			return
+		}
+
+		customID := e.MessageComponentData().CustomID
+		if customID != idConfirm && customID != idCancel {
+			return
+		}
+
+		var uid string
+		if e.Member != nil {
+			uid = e.Member.User.ID
+		} else if e.User != nil {
+			uid = e.User.ID
+		}
+
+		if uid != userID {
+			s.InteractionRespond(e.Interaction, &discordgo.InteractionResponse{
+				Type: discordgo.InteractionResponseChannelMessageWithSource,
+				Data: &discordgo.InteractionResponseData{
+					Content: "Only the user who invoked the action can answer this prompt.",
+					Flags:   discordgo.MessageFlagsEphemeral,
+				},
+			})
+			return
+		}
+
+		s.InteractionRespond(e.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseDeferredMessageUpdate,
+		})
+
+		select {
+		case cRes <- customID == idConfirm:
+		default:
+		}
+	})
+	defer unsub()
+
+	msg, err := s.ChannelMessageSendComplex(channelID, &discordgo.MessageSend{
+		Content: "<@" + userID + ">",
+		Embeds: []*discordgo.MessageEmbed{
+			{
+				Color:       0xFB8C00,
+				Title:       "Confirmation",
+				Description: text,
+			},
+		},
+		Components: []discordgo.MessageComponent{
+			discordgo.ActionsRow{
+				Components: []discordgo.MessageComponent{
+					discordgo.Button{
+						CustomID: idConfirm,
+						Label:    "Confirm",
+						Style:    discordgo.DangerButton,
+					},
+					discordgo.Button{
+						CustomID: idCancel,
+						Label:    "Cancel",
+						Style:    discordgo.SecondaryButton,
+					},
+				},
+			},
+		},
+		AllowedMentions: &discordgo.MessageAllowedMentions{
+			Users: []string{userID},
+		},
+	})
+	if err != nil {
+		return false, err
+	}
+	defer s.ChannelMessageDelete(msg.ChannelID, msg.ID)
+
+	select {
+	case confirmed := <-cRes:
+		return confirmed, nil
+	case <-time.After(timeout):
+		return false, nil
+	}
+}
@@ -18,6 +18,46 @@ func GetMessageLink(msg *discordgo.Message, guildID string) string {
 	return fmt.Sprintf("https://discord.com/channels/%s/%s/%s", guildID, msg.ChannelID, msg.ID)
 }
 
+// ParseMessageLink parses a message link as assembled
+// by GetMessageLink and returns the contained guild,
+// channel and message IDs. Links of the canary and ptb
+// clients as well as the legacy discordapp.com domain
+// are accepted.
+//
+// ok is false if the passed string is not a valid
+// message link.
+func ParseMessageLink(link string) (guildID, channelID, messageID string, ok bool) {
+	link = strings.TrimSpace(link)
+	link = strings.TrimPrefix(link, "<")
+	link = strings.TrimSuffix(link, ">")
+
+	if !strings.HasPrefix(link, "https://") {
+		return
+	}
+
+	host, path, found := strings.Cut(link[len("https://"):], "/")
+	if !found {
+		return
+	}
+	switch host {
+	case "discord.com", "canary.discord.com", "ptb.discord.com", "discordapp.com":
+	default:
+		return
+	}
+
+	split := strings.Split(path, "/")
+	if len(split) != 4 || split[0] != "channels" {
+		return
+	}
+	for _, id := range split[1:] {
+		if _, err := strconv.ParseUint(id, 10, 64); err != nil {
+			return
+		}
+	}
+
+	return split[1], split[2], split[3], true
+}
+
 // GetDiscordSnowflakeCreationTime returns the time.Time
 // of creation of the passed snowflake string.
 //
@@ -29,7 +69,7 @@ func GetDiscordSnowflakeCreationTime(snowflake string) (time.Time, error) {
 		return time.Time{}, err
 	}
 	timestamp := (sfI >> 22) + 1420070400000
-	return time.Unix(timestamp/1000, timestamp), nil
+	return time.UnixMilli(timestamp), nil
 }
 
 // IsAdmin returns true if one of the members roles has
@@ -79,6 +119,13 @@ func IsErrCode(err error, code int) bool {
 	return ok && apiErr.Message.Code == code
 }
 
+// IsErrMessageGone returns true if the error indicates that
+// a message or its channel does not exist anymore.
+func IsErrMessageGone(err error) bool {
+	return IsErrCode(err, discordgo.ErrCodeUnknownMessage) ||
+		IsErrCode(err, discordgo.ErrCodeUnknownChannel)
+}
+
 // GetShardOfGuild parses the passed guild ID into a snowflake.
 // Then, the ID of the corresponding shard ID is calculated using
 // the formula
@@ -0,0 +1,48 @@
+package discordutil
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMessageLink(t *testing.T) {
+	for _, link := range []string{
+		"https://discord.com/channels/1/2/3",
+		"https://canary.discord.com/channels/1/2/3",
+		"https://discordapp.com/channels/1/2/3",
+		" <https://ptb.discord.com/channels/1/2/3> ",
+	} {
+		guildID, channelID, messageID, ok := ParseMessageLink(link)
+		assert.True(t, ok, link)
+		assert.Equal(t, []string{"1", "2", "3"}, []string{guildID, channelID, messageID}, link)
+	}
+
+	for _, link := range []string{
+		"",
+		"3",
+		"http://discord.com/channels/1/2/3",
+		"https://example.com/channels/1/2/3",
+		"https://discord.com/channels/1/2",
+		"https://discord.com/channels/1/2/3/4",
+		"https://discord.com/guilds/1/2/3",
+		"https://discord.com/channels/@me/2/3",
+	} {
+		_, _, _, ok := ParseMessageLink(link)
+		assert.False(t, ok, link)
+	}
+}
+
+func TestIsErrMessageGone(t *testing.T) {
+	restErr := func(code int) error {
+		return &discordgo.RESTError{Message: &discordgo.APIErrorMessage{Code: code}}
+	}
+
+	assert.True(t, IsErrMessageGone(restErr(discordgo.ErrCodeUnknownMessage)))
+	assert.True(t, IsErrMessageGone(restErr(discordgo.ErrCodeUnknownChannel)))
+	assert.False(t, IsErrMessageGone(restErr(discordgo.ErrCodeMissingPermissions)))
+	assert.False(t, IsErrMessageGone(errors.New("unknown message")))
+	assert.False(t, IsErrMessageGone(nil))
+}
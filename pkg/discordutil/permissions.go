@@ -0,0 +1,63 @@
+package discordutil
+
+import "github.com/bwmarrin/discordgo"
+
+// MemberChannelPermissions calculates the effective
+// permissions of a member with the given ID and roles in
+// the passed channel of the guild as documented here
+//
+//	https://discord.com/developers/docs/topics/permissions#permission-overwrites
+//
+// The guild must contain its roles. The @everyone role
+// does not need to be contained in memberRoles.
+func MemberChannelPermissions(
+	guild *discordgo.Guild,
+	channel *discordgo.Channel,
+	memberID string,
+	memberRoles []string,
+) (perms int64) {
+	if guild.OwnerID != "" && guild.OwnerID == memberID {
+		return discordgo.PermissionAll
+	}
+
+	hasRole := make(map[string]bool, len(memberRoles))
+	for _, id := range memberRoles {
+		hasRole[id] = true
+	}
+
+	for _, role := range guild.Roles {
+		if role.ID == guild.ID || hasRole[role.ID] {
+			perms |= role.Permissions
+		}
+	}
+
+	if perms&discordgo.PermissionAdministrator != 0 {
+		return discordgo.PermissionAll
+	}
+
+	var everyone, member *discordgo.PermissionOverwrite
+	var allow, deny int64
+	for _, ow := range channel.PermissionOverwrites {
+		switch {
+		case ow.Type == discordgo.PermissionOverwriteTypeRole && ow.ID == guild.ID:
+			everyone = ow
+		case ow.Type == discordgo.PermissionOverwriteTypeRole && hasRole[ow.ID]:
+			allow |= ow.Allow
+			deny |= ow.Deny
+		case ow.Type == discordgo.PermissionOverwriteTypeMember && ow.ID == memberID:
+			member = ow
+		}
+	}
+
+	if everyone != nil {
+		perms = perms&^everyone.Deny | everyone.Allow
+	}
+
+	perms = perms&^deny | allow
+
+	if member != nil {
+		perms = perms&^member.Deny | member.Allow
+	}
+
+	return perms
+}
@@ -0,0 +1,55 @@
+package discordutil
+
+import (
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemberChannelPermissions(t *testing.T) {
+	const view = discordgo.PermissionViewChannel
+	const send = discordgo.PermissionSendMessages
+
+	guild := &discordgo.Guild{
+		ID:      "guild",
+		OwnerID: "owner",
+		Roles: []*discordgo.Role{
+			{ID: "guild", Permissions: view | send},
+			{ID: "mod", Permissions: 0},
+			{ID: "muted", Permissions: 0},
+			{ID: "admin", Permissions: discordgo.PermissionAdministrator},
+		},
+	}
+
+	channel := &discordgo.Channel{
+		PermissionOverwrites: []*discordgo.PermissionOverwrite{
+			{ID: "guild", Type: discordgo.PermissionOverwriteTypeRole, Deny: view},
+			{ID: "mod", Type: discordgo.PermissionOverwriteTypeRole, Allow: view},
+			{ID: "muted", Type: discordgo.PermissionOverwriteTypeRole, Deny: view | send},
+			{ID: "member", Type: discordgo.PermissionOverwriteTypeMember, Allow: view},
+		},
+	}
+
+	// @everyone deny
+	perms := MemberChannelPermissions(guild, channel, "user", nil)
+	assert.Equal(t, int64(send), perms)
+
+	// Role allow overrides @everyone deny
+	perms = MemberChannelPermissions(guild, channel, "user", []string{"mod"})
+	assert.Equal(t, int64(view|send), perms)
+
+	// Role allows take precedence over role denies
+	perms = MemberChannelPermissions(guild, channel, "user", []string{"mod", "muted"})
+	assert.Equal(t, int64(view), perms)
+
+	// Member overwrites take precedence over role overwrites
+	perms = MemberChannelPermissions(guild, channel, "member", []string{"muted"})
+	assert.Equal(t, int64(view), perms)
+
+	// Administrators and the owner bypass overwrites
+	perms = MemberChannelPermissions(guild, channel, "user", []string{"admin"})
+	assert.Equal(t, int64(discordgo.PermissionAll), perms)
+	perms = MemberChannelPermissions(guild, channel, "owner", nil)
+	assert.Equal(t, int64(discordgo.PermissionAll), perms)
+}
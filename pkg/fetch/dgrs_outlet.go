@@ -6,13 +6,13 @@ import (
 )
 
 type DgrsDataOutlet struct {
-	state      *dgrs.State
+	state      dgrs.IState
 	forceFetch bool
 }
 
 var _ DataOutlet = (*DgrsDataOutlet)(nil)
 
-func WrapDrgs(state *dgrs.State, forceFetch ...bool) DgrsDataOutlet {
+func WrapDrgs(state dgrs.IState, forceFetch ...bool) DgrsDataOutlet {
 	ff := len(forceFetch) > 0 && forceFetch[0]
 	return DgrsDataOutlet{state, ff}
 }
@@ -0,0 +1,369 @@
+// Package guardedstate wraps a dgrs state so that
+// Discord objects are requested from the Discord API
+// directly while the redis connection of the state
+// is unavailable.
+package guardedstate
+
+import (
+	"errors"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/zekroTJA/shinpuru/pkg/discordutil"
+	"github.com/zekroTJA/shinpuru/pkg/redisguard"
+	"github.com/zekrotja/dgrs"
+)
+
+// ErrUnavailable is returned for objects which are
+// only known from gateway events and therefore can
+// not be requested while redis is unavailable.
+var ErrUnavailable = errors.New("state is unavailable")
+
+// maxGuildsPage is the maximum amount of guilds which
+// can be requested from the Discord API at once.
+const maxGuildsPage = 200
+
+// maxMembersPage is the maximum amount of members
+// which can be requested from the Discord API at once.
+const maxMembersPage = 1000
+
+// State wraps a dgrs.IState. While redis is available,
+// all calls are passed to the wrapped state. Otherwise,
+// objects are requested from the Discord API and writes
+// to the state are skipped.
+type State struct {
+	dgrs.IState
+
+	s     discordutil.ISession
+	guard *redisguard.Guard
+}
+
+var _ dgrs.IState = (*State)(nil)
+
+// New returns a new State wrapping the given state.
+func New(st dgrs.IState, s discordutil.ISession, guard *redisguard.Guard) *State {
+	return &State{
+		IState: st,
+		s:      s,
+		guard:  guard,
+	}
+}
+
+// --- Reads ---
+
+func (t *State) Guild(id string, hydrate ...bool) (*discordgo.Guild, error) {
+	return fallback(t, func() (*discordgo.Guild, error) {
+		return t.IState.Guild(id, hydrate...)
+	}, func() (g *discordgo.Guild, err error) {
+		if g, err = t.s.Guild(id); err != nil {
+			return
+		}
+		if len(hydrate) != 0 && hydrate[0] {
+			if g.Channels, err = t.s.GuildChannels(id); err != nil {
+				return
+			}
+			g.Members, err = t.Members(id)
+		}
+		return
+	})
+}
+
+func (t *State) Guilds() ([]*discordgo.Guild, error) {
+	return fallback(t, t.IState.Guilds, func() (res []*discordgo.Guild, err error) {
+		var after string
+		for {
+			var ugs []*discordgo.UserGuild
+			if ugs, err = t.s.UserGuilds(maxGuildsPage, "", after); err != nil {
+				return
+			}
+			for _, ug := range ugs {
+				res = append(res, &discordgo.Guild{
+					ID:          ug.ID,
+					Name:        ug.Name,
+					Icon:        ug.Icon,
+					Permissions: ug.Permissions,
+				})
+			}
+			if len(ugs) < maxGuildsPage {
+				return
+			}
+			after = ugs[len(ugs)-1].ID
+		}
+	})
+}
+
+func (t *State) Channel(id string) (*discordgo.Channel, error) {
+	return fallback(t, func() (*discordgo.Channel, error) {
+		return t.IState.Channel(id)
+	}, func() (*discordgo.Channel, error) {
+		return t.s.Channel(id)
+	})
+}
+
+func (t *State) Channels(guildID string, forceFetch ...bool) ([]*discordgo.Channel, error) {
+	return fallback(t, func() ([]*discordgo.Channel, error) {
+		return t.IState.Channels(guildID, forceFetch...)
+	}, func() ([]*discordgo.Channel, error) {
+		return t.s.GuildChannels(guildID)
+	})
+}
+
+func (t *State) Emoji(guildID, emojiID string) (*discordgo.Emoji, error) {
+	return fallback(t, func() (*discordgo.Emoji, error) {
+		return t.IState.Emoji(guildID, emojiID)
+	}, func() (*discordgo.Emoji, error) {
+		return t.s.GuildEmoji(guildID, emojiID)
+	})
+}
+
+func (t *State) Emojis(guildID string, forceFetch ...bool) ([]*discordgo.Emoji, error) {
+	return fallback(t, func() ([]*discordgo.Emoji, error) {
+		return t.IState.Emojis(guildID, forceFetch...)
+	}, func() ([]*discordgo.Emoji, error) {
+		return t.s.GuildEmojis(guildID)
+	})
+}
+
+func (t *State) Member(guildID, memberID string, forceNoFetch ...bool) (*discordgo.Member, error) {
+	return fallback(t, func() (*discordgo.Member, error) {
+		return t.IState.Member(guildID, memberID, forceNoFetch...)
+	}, func() (m *discordgo.Member, err error) {
+		if len(forceNoFetch) != 0 && forceNoFetch[0] {
+			return nil, nil
+		}
+		if m, err = t.s.GuildMember(guildID, memberID); err == nil {
+			m.GuildID = guildID
+		}
+		return
+	})
+}
+
+func (t *State) Members(guildID string, forceFetch ...bool) ([]*discordgo.Member, error) {
+	return t.MembersLimit(guildID, "", 0, forceFetch...)
+}
+
+func (t *State) MembersLimit(guildID, afterID string, limit int, forceFetch ...bool) ([]*discordgo.Member, error) {
+	return fallback(t, func() ([]*discordgo.Member, error) {
+		return t.IState.MembersLimit(guildID, afterID, limit, forceFetch...)
+	}, func() (res []*discordgo.Member, err error) {
+		res = make([]*discordgo.Member, 0)
+		for limit <= 0 || len(res) < limit {
+			n := maxMembersPage
+			if limit > 0 && limit-len(res) < n {
+				n = limit - len(res)
+			}
+			var ms []*discordgo.Member
+			if ms, err = t.s.GuildMembers(guildID, afterID, n); err != nil {
+				return
+			}
+			for _, m := range ms {
+				m.GuildID = guildID
+			}
+			res = append(res, ms...)
+			if len(ms) < n {
+				break
+			}
+			afterID = ms[len(ms)-1].User.ID
+		}
+		return
+	})
+}
+
+func (t *State) Message(channelID, messageID string) (*discordgo.Message, error) {
+	return fallback(t, func() (*discordgo.Message, error) {
+		return t.IState.Message(channelID, messageID)
+	}, func() (*discordgo.Message, error) {
+		return t.s.ChannelMessage(channelID, messageID)
+	})
+}
+
+func (t *State) Messages(channelID string, forceFetch ...bool) ([]*discordgo.Message, error) {
+	return fallback(t, func() ([]*discordgo.Message, error) {
+		return t.IState.Messages(channelID, forceFetch...)
+	}, func() ([]*discordgo.Message, error) {
+		return t.s.ChannelMessages(channelID, 100, "", "", "")
+	})
+}
+
+func (t *State) Role(guildID, roleID string) (*discordgo.Role, error) {
+	return fallback(t, func() (*discordgo.Role, error) {
+		return t.IState.Role(guildID, roleID)
+	}, func() (*discordgo.Role, error) {
+		roles, err := t.s.GuildRoles(guildID)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range roles {
+			if r.ID == roleID {
+				return r, nil
+			}
+		}
+		return nil, nil
+	})
+}
+
+func (t *State) Roles(guildID string, forceFetch ...bool) ([]*discordgo.Role, error) {
+	return fallback(t, func() ([]*discordgo.Role, error) {
+		return t.IState.Roles(guildID, forceFetch...)
+	}, func() ([]*discordgo.Role, error) {
+		return t.s.GuildRoles(guildID)
+	})
+}
+
+func (t *State) User(id string) (*discordgo.User, error) {
+	return fallback(t, func() (*discordgo.User, error) {
+		return t.IState.User(id)
+	}, func() (*discordgo.User, error) {
+		return t.s.User(id)
+	})
+}
+
+func (t *State) SelfUser() (*discordgo.User, error) {
+	return fallback(t, t.IState.SelfUser, func() (*discordgo.User, error) {
+		return t.s.User("@me")
+	})
+}
+
+func (t *State) Users() ([]*discordgo.User, error) {
+	return fallback(t, t.IState.Users, unavailable[[]*discordgo.User])
+}
+
+func (t *State) UserGuilds(id string) ([]string, error) {
+	return fallback(t, func() ([]string, error) {
+		return t.IState.UserGuilds(id)
+	}, unavailable[[]string])
+}
+
+func (t *State) Presence(guildID, userID string) (*discordgo.Presence, error) {
+	return fallback(t, func() (*discordgo.Presence, error) {
+		return t.IState.Presence(guildID, userID)
+	}, unavailable[*discordgo.Presence])
+}
+
+func (t *State) Presences(guildID string) ([]*discordgo.Presence, error) {
+	return fallback(t, func() ([]*discordgo.Presence, error) {
+		return t.IState.Presences(guildID)
+	}, unavailable[[]*discordgo.Presence])
+}
+
+func (t *State) VoiceState(guildID, userID string) (*discordgo.VoiceState, error) {
+	return fallback(t, func() (*discordgo.VoiceState, error) {
+		return t.IState.VoiceState(guildID, userID)
+	}, unavailable[*discordgo.VoiceState])
+}
+
+func (t *State) VoiceStates(guildID string) ([]*discordgo.VoiceState, error) {
+	return fallback(t, func() ([]*discordgo.VoiceState, error) {
+		return t.IState.VoiceStates(guildID)
+	}, unavailable[[]*discordgo.VoiceState])
+}
+
+// --- Writes ---
+
+func (t *State) SetChannel(channel *discordgo.Channel) error {
+	return t.write(func() error { return t.IState.SetChannel(channel) })
+}
+
+func (t *State) RemoveChannel(id string, dehydrate ...bool) error {
+	return t.write(func() error { return t.IState.RemoveChannel(id, dehydrate...) })
+}
+
+func (t *State) SetEmoji(guildID string, emoji *discordgo.Emoji) error {
+	return t.write(func() error { return t.IState.SetEmoji(guildID, emoji) })
+}
+
+func (t *State) RemoveEmoji(guildID, emojiID string) error {
+	return t.write(func() error { return t.IState.RemoveEmoji(guildID, emojiID) })
+}
+
+func (t *State) SetGuild(guild *discordgo.Guild) error {
+	return t.write(func() error { return t.IState.SetGuild(guild) })
+}
+
+func (t *State) RemoveGuild(id string, dehydrate ...bool) error {
+	return t.write(func() error { return t.IState.RemoveGuild(id, dehydrate...) })
+}
+
+func (t *State) SetMember(guildID string, member *discordgo.Member) error {
+	return t.write(func() error { return t.IState.SetMember(guildID, member) })
+}
+
+func (t *State) RemoveMember(guildID, memberID string) error {
+	return t.write(func() error { return t.IState.RemoveMember(guildID, memberID) })
+}
+
+func (t *State) SetMessage(message *discordgo.Message) error {
+	return t.write(func() error { return t.IState.SetMessage(message) })
+}
+
+func (t *State) RemoveMessage(channelID, messageID string) error {
+	return t.write(func() error { return t.IState.RemoveMessage(channelID, messageID) })
+}
+
+func (t *State) SetPresence(guildID string, presence *discordgo.Presence) error {
+	return t.write(func() error { return t.IState.SetPresence(guildID, presence) })
+}
+
+func (t *State) RemovePresence(guildID, userID string) error {
+	return t.write(func() error { return t.IState.RemovePresence(guildID, userID) })
+}
+
+func (t *State) SetRole(guildID string, role *discordgo.Role) error {
+	return t.write(func() error { return t.IState.SetRole(guildID, role) })
+}
+
+func (t *State) RemoveRole(guildID, roleID string) error {
+	return t.write(func() error { return t.IState.RemoveRole(guildID, roleID) })
+}
+
+func (t *State) SetUser(user *discordgo.User) error {
+	return t.write(func() error { return t.IState.SetUser(user) })
+}
+
+func (t *State) RemoveUser(id string) error {
+	return t.write(func() error { return t.IState.RemoveUser(id) })
+}
+
+func (t *State) SetSelfUser(user *discordgo.User) error {
+	return t.write(func() error { return t.IState.SetSelfUser(user) })
+}
+
+func (t *State) SetVoiceState(guildID string, vs *discordgo.VoiceState) error {
+	return t.write(func() error { return t.IState.SetVoiceState(guildID, vs) })
+}
+
+func (t *State) RemoveVoiceState(guildID, userID string) error {
+	return t.write(func() error { return t.IState.RemoveVoiceState(guildID, userID) })
+}
+
+// --- Helpers ---
+
+// fallback returns the result of cached if redis is
+// available. Otherwise or if the connection fails
+// during the call, the result of direct is returned.
+func fallback[T any](t *State, cached, direct func() (T, error)) (T, error) {
+	if t.guard.Available() {
+		v, err := cached()
+		if !t.guard.Report(err) {
+			return v, err
+		}
+	}
+	return direct()
+}
+
+// write executes fn if redis is available. Connection
+// failures are not returned because the value would
+// be outdated anyway when redis is available again.
+func (t *State) write(fn func() error) error {
+	if !t.guard.Available() {
+		return nil
+	}
+	err := fn()
+	if t.guard.Report(err) {
+		return nil
+	}
+	return err
+}
+
+func unavailable[T any]() (v T, err error) {
+	return v, ErrUnavailable
+}
@@ -0,0 +1,120 @@
+package guardedstate
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/zekroTJA/shinpuru/mocks"
+	"github.com/zekroTJA/shinpuru/pkg/redisguard"
+)
+
+type testPinger struct {
+	err error
+}
+
+func (p *testPinger) Ping(ctx context.Context) *redis.StatusCmd {
+	return redis.NewStatusResult("PONG", p.err)
+}
+
+func getState(t *testing.T, pingErr error) (*State, *mocks.IState, *mocks.ISession, *redisguard.Guard) {
+	st := &mocks.IState{}
+	s := &mocks.ISession{}
+	guard := redisguard.New(&testPinger{err: pingErr}, time.Hour)
+	t.Cleanup(guard.Close)
+
+	return New(st, s, guard), st, s, guard
+}
+
+func TestAvailable(t *testing.T) {
+	gs, st, s, _ := getState(t, nil)
+
+	st.On("Member", "guild", "user").Return(&discordgo.Member{GuildID: "guild", Nick: "cached"}, nil)
+	st.On("SetMember", "guild", mock.Anything).Return(nil)
+
+	m, err := gs.Member("guild", "user")
+	assert.Nil(t, err)
+	assert.Equal(t, "cached", m.Nick)
+
+	assert.Nil(t, gs.SetMember("guild", m))
+
+	st.AssertCalled(t, "SetMember", "guild", m)
+	s.AssertNotCalled(t, "GuildMember", mock.Anything, mock.Anything)
+}
+
+func TestUnavailable(t *testing.T) {
+	gs, st, s, _ := getState(t, errors.New("connection refused"))
+
+	s.On("GuildMember", "guild", "user").Return(&discordgo.Member{Nick: "fetched"}, nil)
+	s.On("GuildRoles", "guild").Return([]*discordgo.Role{{ID: "role-1"}, {ID: "role-2"}}, nil)
+
+	m, err := gs.Member("guild", "user")
+	assert.Nil(t, err)
+	assert.Equal(t, "fetched", m.Nick)
+	assert.Equal(t, "guild", m.GuildID)
+
+	m, err = gs.Member("guild", "user", true)
+	assert.Nil(t, err)
+	assert.Nil(t, m)
+
+	r, err := gs.Role("guild", "role-2")
+	assert.Nil(t, err)
+	assert.Equal(t, "role-2", r.ID)
+
+	assert.Nil(t, gs.SetMember("guild", m))
+
+	_, err = gs.VoiceStates("guild")
+	assert.ErrorIs(t, err, ErrUnavailable)
+
+	st.AssertNotCalled(t, "Member", mock.Anything, mock.Anything)
+	st.AssertNotCalled(t, "SetMember", mock.Anything, mock.Anything)
+	st.AssertNotCalled(t, "VoiceStates", mock.Anything)
+}
+
+func TestConnectionLost(t *testing.T) {
+	gs, st, s, guard := getState(t, nil)
+
+	st.On("Channel", "channel").Return(nil, errors.New("dial tcp: connection refused"))
+	s.On("Channel", "channel").Return(&discordgo.Channel{ID: "channel"}, nil)
+
+	c, err := gs.Channel("channel")
+	assert.Nil(t, err)
+	assert.Equal(t, "channel", c.ID)
+	assert.False(t, guard.Available())
+
+	_, err = gs.Channel("channel")
+	assert.Nil(t, err)
+
+	st.AssertNumberOfCalls(t, "Channel", 1)
+	s.AssertNumberOfCalls(t, "Channel", 2)
+}
+
+func TestMembersLimit(t *testing.T) {
+	gs, _, s, _ := getState(t, errors.New("connection refused"))
+
+	page := func(from, n int) []*discordgo.Member {
+		ms := make([]*discordgo.Member, n)
+		for i := range ms {
+			ms[i] = &discordgo.Member{User: &discordgo.User{ID: string(rune('a' + from + i))}}
+		}
+		return ms
+	}
+
+	s.On("GuildMembers", "guild", "", maxMembersPage).Return(page(0, maxMembersPage), nil)
+	s.On("GuildMembers", "guild", mock.Anything, maxMembersPage).Return(page(maxMembersPage, 2), nil)
+	s.On("GuildMembers", "guild", "", 3).Return(page(0, 3), nil)
+
+	ms, err := gs.Members("guild")
+	assert.Nil(t, err)
+	assert.Len(t, ms, maxMembersPage+2)
+	assert.Equal(t, "guild", ms[len(ms)-1].GuildID)
+
+	ms, err = gs.MembersLimit("guild", "", 3)
+	assert.Nil(t, err)
+	assert.Len(t, ms, 3)
+}
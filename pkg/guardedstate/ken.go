@@ -0,0 +1,41 @@
+package guardedstate
+
+import (
+	"github.com/bwmarrin/discordgo"
+	"github.com/zekrotja/dgrs"
+	"github.com/zekrotja/ken/state"
+)
+
+// KenState implements the state of ken using a
+// dgrs.IState, so that ken can be used with State.
+type KenState struct {
+	st dgrs.IState
+}
+
+var _ state.State = (*KenState)(nil)
+
+// NewKenState returns a new KenState using the
+// given state.
+func NewKenState(st dgrs.IState) *KenState {
+	return &KenState{st}
+}
+
+func (s *KenState) SelfUser(_ *discordgo.Session) (*discordgo.User, error) {
+	return s.st.SelfUser()
+}
+
+func (s *KenState) Channel(_ *discordgo.Session, id string) (*discordgo.Channel, error) {
+	return s.st.Channel(id)
+}
+
+func (s *KenState) Guild(_ *discordgo.Session, id string) (*discordgo.Guild, error) {
+	return s.st.Guild(id)
+}
+
+func (s *KenState) Role(_ *discordgo.Session, gID, id string) (*discordgo.Role, error) {
+	return s.st.Role(gID, id)
+}
+
+func (s *KenState) User(_ *discordgo.Session, id string) (*discordgo.User, error) {
+	return s.st.User(id)
+}
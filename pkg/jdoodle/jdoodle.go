@@ -4,6 +4,7 @@ package jdoodle
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
@@ -29,6 +30,12 @@ func NewWrapper(clientId, clientSecret string) *Wrapper {
 // ExecuteScript executes the given script of the
 // given lang using the jdoodle execute API endpoint.
 func (jd *Wrapper) ExecuteScript(lang, script string) (res *ExecResponse, err error) {
+	return jd.ExecuteScriptContext(context.Background(), lang, script)
+}
+
+// ExecuteScriptContext is ExecuteScript which aborts
+// the request when the passed ctx is done.
+func (jd *Wrapper) ExecuteScriptContext(ctx context.Context, lang, script string) (res *ExecResponse, err error) {
 	payload := &execRequestBody{
 		credentialsBody: &credentialsBody{
 			ClientID:     jd.clientId,
@@ -39,7 +46,7 @@ func (jd *Wrapper) ExecuteScript(lang, script string) (res *ExecResponse, err er
 	}
 
 	res = new(ExecResponse)
-	err = request("execute", payload, res)
+	err = request(ctx, "execute", payload, res)
 
 	return
 }
@@ -53,19 +60,19 @@ func (jd *Wrapper) CreditsSpent() (res *CreditsResponse, err error) {
 	}
 
 	res = new(CreditsResponse)
-	err = request("credit-spent", payload, res)
+	err = request(context.Background(), "credit-spent", payload, res)
 
 	return
 }
 
-func request(endpoint string, body interface{}, res interface{}) (err error) {
+func request(ctx context.Context, endpoint string, body interface{}, res interface{}) (err error) {
 	buf := bytes.NewBuffer([]byte{})
 	err = json.NewEncoder(buf).Encode(body)
 	if err != nil {
 		return
 	}
 
-	req, err := http.NewRequest("POST", apiRoot+endpoint, buf)
+	req, err := http.NewRequestWithContext(ctx, "POST", apiRoot+endpoint, buf)
 	if err != nil {
 		return
 	}
@@ -76,6 +83,7 @@ func request(endpoint string, body interface{}, res interface{}) (err error) {
 	if err != nil {
 		return
 	}
+	defer httpRes.Body.Close()
 
 	if httpRes.StatusCode >= 400 {
 		if httpRes.ContentLength == 0 {
@@ -0,0 +1,58 @@
+// Package keylock provides mutexes which are
+// identified by a key and which are only held in
+// memory while they are in use.
+package keylock
+
+import "sync"
+
+type entry struct {
+	mtx sync.Mutex
+	n   int
+}
+
+// Locker holds a mutex for each key which is
+// currently locked or waited for. Entries are
+// removed when they are not used anymore.
+//
+// The zero value is ready to use and a Locker is
+// safe for concurrent use.
+type Locker struct {
+	mtx     sync.Mutex
+	entries map[string]*entry
+}
+
+// Lock locks the mutex of the given key and
+// returns a function which unlocks it again.
+func (l *Locker) Lock(key string) (unlock func()) {
+	l.mtx.Lock()
+	if l.entries == nil {
+		l.entries = make(map[string]*entry)
+	}
+	e, ok := l.entries[key]
+	if !ok {
+		e = &entry{}
+		l.entries[key] = e
+	}
+	e.n++
+	l.mtx.Unlock()
+
+	e.mtx.Lock()
+
+	return func() {
+		e.mtx.Unlock()
+
+		l.mtx.Lock()
+		defer l.mtx.Unlock()
+		if e.n--; e.n == 0 {
+			delete(l.entries, key)
+		}
+	}
+}
+
+// Len returns the number of keys which are
+// currently locked or waited for.
+func (l *Locker) Len() int {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	return len(l.entries)
+}
@@ -0,0 +1,50 @@
+package keylock
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLock(t *testing.T) {
+	var l Locker
+
+	unlock := l.Lock("a")
+	assert.Equal(t, 1, l.Len())
+
+	locked := make(chan struct{})
+	go func() {
+		defer l.Lock("a")()
+		close(locked)
+	}()
+
+	select {
+	case <-locked:
+		t.Fatal("key has been locked twice")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// Other keys are not blocked.
+	l.Lock("b")()
+
+	unlock()
+	<-locked
+}
+
+func TestLockEvicts(t *testing.T) {
+	var l Locker
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(key string) {
+			defer wg.Done()
+			l.Lock(key)()
+		}(string(rune('a' + i%5)))
+	}
+	wg.Wait()
+
+	assert.Equal(t, 0, l.Len())
+}
@@ -0,0 +1,395 @@
+// Package paginator provides a message model for
+// discordgo which displays a list of embed pages
+// which can be switched via buttons or reactions.
+package paginator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/rs/xid"
+)
+
+const defaultTimeout = 2 * time.Minute
+
+var (
+	ErrNoPages = errors.New("no pages specified")
+
+	emojiFirst = "⏮"
+	emojiPrev  = "◀"
+	emojiNext  = "▶"
+	emojiLast  = "⏭"
+)
+
+// Options for Paginator initialization.
+type Options struct {
+	// UserID restricts the page navigation to the
+	// given user. If empty, everyone can navigate.
+	UserID string
+	// Timeout is the inactivity duration after which
+	// the navigation controls are removed. Defaults
+	// to 2 minutes.
+	Timeout time.Duration
+	// Wrap enables jumping from the last page to the
+	// first page and vice versa.
+	Wrap bool
+	// UseReactions uses message reactions instead of
+	// buttons as navigation controls.
+	UseReactions bool
+}
+
+// Paginator wraps a sent message displaying
+// one of multiple embed pages at a time.
+type Paginator struct {
+	*discordgo.Message
+
+	session     *discordgo.Session
+	interaction *discordgo.Interaction
+	pages       []*discordgo.MessageEmbed
+	opts        Options
+	id          string
+
+	mtx     sync.Mutex
+	current int
+	closed  bool
+	timer   *time.Timer
+	unsub   func()
+}
+
+// Send posts the first of the passed pages into the
+// given channel and sets up the navigation controls.
+//
+// When only one page is passed, no controls are
+// added to the message.
+func Send(s *discordgo.Session, channelID string, pages []*discordgo.MessageEmbed, opts *Options) (p *Paginator, err error) {
+	p, err = newPaginator(s, pages, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := &discordgo.MessageSend{
+		Embeds: []*discordgo.MessageEmbed{p.embed(0)},
+	}
+	if len(pages) > 1 && !p.opts.UseReactions {
+		msg.Components = p.components(0)
+	}
+
+	p.Message, err = s.ChannelMessageSendComplex(channelID, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	return p, p.setup()
+}
+
+// SendFollowUp posts the first of the passed pages as
+// ephemeral follow-up message of the given interaction
+// and sets up the navigation controls.
+//
+// Because reactions can not be added to ephemeral
+// messages, buttons are always used as controls. The
+// timeout should not exceed the lifetime of the
+// interaction token of 15 minutes.
+func SendFollowUp(s *discordgo.Session, interaction *discordgo.Interaction, pages []*discordgo.MessageEmbed, opts *Options) (p *Paginator, err error) {
+	p, err = newPaginator(s, pages, opts)
+	if err != nil {
+		return nil, err
+	}
+	p.interaction = interaction
+	p.opts.UseReactions = false
+
+	msg := &discordgo.WebhookParams{
+		Embeds: []*discordgo.MessageEmbed{p.embed(0)},
+		Flags:  discordgo.MessageFlagsEphemeral,
+	}
+	if len(pages) > 1 {
+		msg.Components = p.components(0)
+	}
+
+	p.Message, err = s.FollowupMessageCreate(interaction, true, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	return p, p.setup()
+}
+
+func newPaginator(s *discordgo.Session, pages []*discordgo.MessageEmbed, opts *Options) (*Paginator, error) {
+	if s == nil {
+		return nil, errors.New("session is not defined")
+	}
+	if len(pages) == 0 {
+		return nil, ErrNoPages
+	}
+	if opts == nil {
+		opts = new(Options)
+	}
+
+	p := &Paginator{
+		session: s,
+		pages:   pages,
+		opts:    *opts,
+		id:      "paginator-" + xid.New().String(),
+	}
+
+	if p.opts.Timeout <= 0 {
+		p.opts.Timeout = defaultTimeout
+	}
+
+	return p, nil
+}
+
+// setup registers the navigation handlers for the
+// sent message.
+func (p *Paginator) setup() (err error) {
+	s := p.session
+
+	if len(p.pages) < 2 {
+		p.closed = true
+		return nil
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	p.timer = time.AfterFunc(p.opts.Timeout, p.Close)
+
+	if p.opts.UseReactions {
+		p.unsub = s.AddHandler(p.reactionHandler)
+		for _, e := range []string{emojiFirst, emojiPrev, emojiNext, emojiLast} {
+			if err = s.MessageReactionAdd(p.ChannelID, p.ID, e); err != nil {
+				p.timer.Stop()
+				p.unsub()
+				return err
+			}
+		}
+	} else {
+		p.unsub = s.AddHandler(p.interactionHandler)
+	}
+
+	return nil
+}
+
+// Current returns the index of the currently
+// displayed page.
+func (p *Paginator) Current() int {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	return p.current
+}
+
+// Close removes the navigation controls and stops
+// listening for navigation inputs.
+func (p *Paginator) Close() {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if p.closed {
+		return
+	}
+	p.closed = true
+
+	if p.timer != nil {
+		p.timer.Stop()
+	}
+	if p.unsub != nil {
+		p.unsub()
+	}
+
+	if p.interaction != nil {
+		p.session.FollowupMessageEdit(p.interaction, p.ID, &discordgo.WebhookEdit{
+			Embeds:     &[]*discordgo.MessageEmbed{p.embed(p.current)},
+			Components: &[]discordgo.MessageComponent{},
+		})
+	} else if p.opts.UseReactions {
+		p.session.MessageReactionsRemoveAll(p.ChannelID, p.ID)
+	} else {
+		p.session.ChannelMessageEditComplex(&discordgo.MessageEdit{
+			ID:         p.ID,
+			Channel:    p.ChannelID,
+			Embeds:     []*discordgo.MessageEmbed{p.embed(p.current)},
+			Components: []discordgo.MessageComponent{},
+		})
+	}
+}
+
+func (p *Paginator) interactionHandler(s *discordgo.Session, e *discordgo.InteractionCreate) {
+	if e.Type != discordgo.InteractionMessageComponent {
+		return
+	}
+
+	customID := e.MessageComponentData().CustomID
+	if !strings.HasPrefix(customID, p.id+"-") {
+		return
+	}
+	action := strings.TrimPrefix(customID, p.id+"-")
+
+	var userID string
+	if e.Member != nil {
+		userID = e.Member.User.ID
+	} else if e.User != nil {
+		userID = e.User.ID
+	}
+
+	if p.opts.UserID != "" && userID != p.opts.UserID {
+		s.InteractionRespond(e.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Content: "Only the user who invoked the command can switch pages.",
+				Flags:   discordgo.MessageFlagsEphemeral,
+			},
+		})
+		return
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if p.closed {
+		return
+	}
+
+	p.current = p.navigate(action)
+	p.timer.Reset(p.opts.Timeout)
+
+	s.InteractionRespond(e.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Embeds:     []*discordgo.MessageEmbed{p.embed(p.current)},
+			Components: p.components(p.current),
+		},
+	})
+}
+
+func (p *Paginator) reactionHandler(s *discordgo.Session, e *discordgo.MessageReactionAdd) {
+	if e.MessageID != p.ID || e.UserID == p.Author.ID {
+		return
+	}
+
+	action := map[string]string{
+		emojiFirst: "first",
+		emojiPrev:  "prev",
+		emojiNext:  "next",
+		emojiLast:  "last",
+	}[e.Emoji.Name]
+
+	// Reset the reaction so that the control can
+	// be used again. This requires the permission
+	// to manage messages and fails silently otherwise.
+	s.MessageReactionRemove(e.ChannelID, e.MessageID, e.Emoji.APIName(), e.UserID)
+
+	if action == "" || (p.opts.UserID != "" && e.UserID != p.opts.UserID) {
+		return
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if p.closed {
+		return
+	}
+
+	next := p.navigate(action)
+	p.timer.Reset(p.opts.Timeout)
+	if next == p.current {
+		return
+	}
+	p.current = next
+
+	s.ChannelMessageEditEmbed(p.ChannelID, p.ID, p.embed(p.current))
+}
+
+func (p *Paginator) navigate(action string) int {
+	switch action {
+	case "first":
+		return 0
+	case "prev":
+		return pageIndex(p.current, -1, len(p.pages), p.opts.Wrap)
+	case "next":
+		return pageIndex(p.current, 1, len(p.pages), p.opts.Wrap)
+	case "last":
+		return len(p.pages) - 1
+	default:
+		return p.current
+	}
+}
+
+// embed returns a copy of the page at index i
+// with the page number appended to its footer.
+func (p *Paginator) embed(i int) *discordgo.MessageEmbed {
+	emb := *p.pages[i]
+	if len(p.pages) < 2 {
+		return &emb
+	}
+
+	footer := &discordgo.MessageEmbedFooter{}
+	if emb.Footer != nil {
+		*footer = *emb.Footer
+	}
+	pageText := fmt.Sprintf("Page %d/%d", i+1, len(p.pages))
+	if footer.Text != "" {
+		footer.Text += " • " + pageText
+	} else {
+		footer.Text = pageText
+	}
+	emb.Footer = footer
+
+	return &emb
+}
+
+func (p *Paginator) components(i int) []discordgo.MessageComponent {
+	atStart := !p.opts.Wrap && i == 0
+	atEnd := !p.opts.Wrap && i == len(p.pages)-1
+
+	button := func(action, emoji string, disabled bool) discordgo.Button {
+		return discordgo.Button{
+			CustomID: p.id + "-" + action,
+			Emoji:    discordgo.ComponentEmoji{Name: emoji},
+			Style:    discordgo.SecondaryButton,
+			Disabled: disabled,
+		}
+	}
+
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				button("first", emojiFirst, i == 0),
+				button("prev", emojiPrev, atStart),
+				button("next", emojiNext, atEnd),
+				button("last", emojiLast, i == len(p.pages)-1),
+			},
+		},
+	}
+}
+
+// pageIndex returns the index of the page which is
+// delta pages away from current in a list of count
+// pages. When wrap is enabled, the index wraps around
+// at both ends; otherwise, it is clamped to the
+// bounds of the list.
+func pageIndex(current, delta, count int, wrap bool) int {
+	if count < 1 {
+		return 0
+	}
+
+	i := current + delta
+	if wrap {
+		i %= count
+		if i < 0 {
+			i += count
+		}
+		return i
+	}
+
+	if i < 0 {
+		return 0
+	}
+	if i >= count {
+		return count - 1
+	}
+	return i
+}
@@ -0,0 +1,98 @@
+package paginator
+
+import (
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func TestPageIndex(t *testing.T) {
+	cases := []struct {
+		current, delta, count int
+		wrap                  bool
+		expected              int
+	}{
+		{0, 1, 3, false, 1},
+		{1, 1, 3, false, 2},
+		{2, 1, 3, false, 2},
+		{0, -1, 3, false, 0},
+		{2, -1, 3, false, 1},
+		{0, 5, 3, false, 2},
+		{2, -5, 3, false, 0},
+
+		{2, 1, 3, true, 0},
+		{0, -1, 3, true, 2},
+		{1, 1, 3, true, 2},
+		{1, -1, 3, true, 0},
+		{0, 4, 3, true, 1},
+		{0, -4, 3, true, 2},
+
+		{0, 1, 1, false, 0},
+		{0, 1, 1, true, 0},
+		{0, -1, 1, true, 0},
+		{0, 1, 0, true, 0},
+		{0, 1, 0, false, 0},
+	}
+
+	for _, c := range cases {
+		res := pageIndex(c.current, c.delta, c.count, c.wrap)
+		if res != c.expected {
+			t.Errorf("pageIndex(%d, %d, %d, %t) was %d, expected %d",
+				c.current, c.delta, c.count, c.wrap, res, c.expected)
+		}
+	}
+}
+
+func TestNavigate(t *testing.T) {
+	p := &Paginator{
+		pages: make([]*discordgo.MessageEmbed, 4),
+	}
+
+	actions := []struct {
+		action   string
+		expected int
+	}{
+		{"next", 1},
+		{"last", 3},
+		{"next", 3},
+		{"prev", 2},
+		{"first", 0},
+		{"prev", 0},
+		{"invalid", 0},
+	}
+
+	for _, a := range actions {
+		p.current = p.navigate(a.action)
+		if p.current != a.expected {
+			t.Errorf("%s: page was %d, expected %d", a.action, p.current, a.expected)
+		}
+	}
+
+	p.opts.Wrap = true
+	p.current = 0
+	if p.current = p.navigate("prev"); p.current != 3 {
+		t.Errorf("prev did not wrap around: page was %d", p.current)
+	}
+	if p.current = p.navigate("next"); p.current != 0 {
+		t.Errorf("next did not wrap around: page was %d", p.current)
+	}
+}
+
+func TestEmbed(t *testing.T) {
+	p := &Paginator{
+		pages: []*discordgo.MessageEmbed{
+			{Title: "a"},
+			{Title: "b", Footer: &discordgo.MessageEmbedFooter{Text: "footer"}},
+		},
+	}
+
+	if emb := p.embed(0); emb.Footer == nil || emb.Footer.Text != "Page 1/2" {
+		t.Errorf("invalid footer: %+v", emb.Footer)
+	}
+	if emb := p.embed(1); emb.Footer == nil || emb.Footer.Text != "footer • Page 2/2" {
+		t.Errorf("invalid footer: %+v", emb.Footer)
+	}
+	if p.pages[1].Footer.Text != "footer" {
+		t.Error("original page has been modified")
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"crypto/rand"
 	"encoding/base64"
 	"errors"
+	"math/big"
 )
 
 var ErrInvalidLen = errors.New("invalid length")
@@ -39,6 +40,21 @@ func GetRandByteArray(len int) (data []byte, err error) {
 	return
 }
 
+// GetRandInt returns a cryptographically uniformly
+// distributed random integer in the range [0, max).
+func GetRandInt(max int) (int, error) {
+	if max <= 0 {
+		return 0, ErrInvalidLen
+	}
+
+	v, err := rand.Int(rand.Reader, big.NewInt(int64(max)))
+	if err != nil {
+		return 0, err
+	}
+
+	return int(v.Int64()), nil
+}
+
 // MustGetRandBase64Str executes GetRandBase64Str and
 // panics if an error was returned.
 func MustGetRandBase64Str(len int) string {
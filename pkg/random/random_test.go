@@ -117,3 +117,27 @@ func hasDuplicatesByteArrays(arr [][]byte) bool {
 
 	return false
 }
+
+func TestGetRandInt(t *testing.T) {
+	if _, err := GetRandInt(0); err != ErrInvalidLen {
+		t.Error("invalid max not detected")
+	}
+
+	counts := make([]int, 4)
+	for i := 0; i < 4000; i++ {
+		v, err := GetRandInt(len(counts))
+		if err != nil {
+			t.Fatal("valid errored: ", err)
+		}
+		if v < 0 || v >= len(counts) {
+			t.Fatal("value out of range: ", v)
+		}
+		counts[v]++
+	}
+
+	for v, c := range counts {
+		if c == 0 {
+			t.Error("value never returned: ", v)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+// Package redisguard tracks the availability of a
+// redis connection so that consumers can fall back
+// to alternative behavior while redis is unreachable.
+package redisguard
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// Pinger is a redis client which can be pinged.
+type Pinger interface {
+	Ping(ctx context.Context) *redis.StatusCmd
+}
+
+// Guard periodically pings a redis client and tracks
+// if the connection is available. Connection failures
+// reported by consumers mark the connection as
+// unavailable immediately.
+//
+// Guard implements redis.Hook, so it can be added to a
+// redis client to detect connection failures of all
+// executed commands.
+type Guard struct {
+	client   Pinger
+	interval time.Duration
+	timeout  time.Duration
+
+	mx        sync.RWMutex
+	err       error
+	onFailure []func(err error)
+	onRecover []func()
+
+	stop chan struct{}
+}
+
+var _ redis.Hook = (*Guard)(nil)
+
+// New creates a new Guard for the given client and
+// checks the connection initially. After that, the
+// connection is checked in the given interval until
+// Close is called.
+func New(client Pinger, interval time.Duration) (g *Guard) {
+	g = &Guard{
+		client:   client,
+		interval: interval,
+		timeout:  interval / 2,
+		stop:     make(chan struct{}),
+	}
+
+	g.Check()
+	go g.loop()
+
+	return
+}
+
+// IsFailure returns true if the given error indicates
+// that the redis connection failed. redis.Nil and
+// errors replied by the redis server are no failures.
+func IsFailure(err error) bool {
+	if err == nil || err == redis.Nil {
+		return false
+	}
+	var rErr redis.Error
+	return !errors.As(err, &rErr)
+}
+
+// Available returns true if the redis connection
+// is currently available.
+func (g *Guard) Available() bool {
+	return g.Err() == nil
+}
+
+// Err returns the error of the last connection failure
+// or nil, if the connection is currently available.
+func (g *Guard) Err() error {
+	g.mx.RLock()
+	defer g.mx.RUnlock()
+	return g.err
+}
+
+// OnFailure registers a handler which is called when
+// the connection becomes unavailable.
+func (g *Guard) OnFailure(handler func(err error)) {
+	g.mx.Lock()
+	defer g.mx.Unlock()
+	g.onFailure = append(g.onFailure, handler)
+}
+
+// OnRecover registers a handler which is called when
+// the connection becomes available again.
+func (g *Guard) OnRecover(handler func()) {
+	g.mx.Lock()
+	defer g.mx.Unlock()
+	g.onRecover = append(g.onRecover, handler)
+}
+
+// Report marks the connection as unavailable if the
+// given error is a connection failure. True is returned
+// if this is the case.
+func (g *Guard) Report(err error) bool {
+	if !IsFailure(err) {
+		return false
+	}
+	g.setErr(err)
+	return true
+}
+
+// Check pings the redis client and updates the
+// availability of the connection accordingly.
+func (g *Guard) Check() {
+	ctx, cancel := context.WithTimeout(context.Background(), g.timeout)
+	defer cancel()
+
+	err := g.client.Ping(ctx).Err()
+	if err != nil && !IsFailure(err) {
+		// The server responded, so the connection
+		// itself is available.
+		err = nil
+	}
+	g.setErr(err)
+}
+
+// Close stops the periodic connection check.
+func (g *Guard) Close() {
+	close(g.stop)
+}
+
+func (g *Guard) BeforeProcess(ctx context.Context, _ redis.Cmder) (context.Context, error) {
+	return ctx, nil
+}
+
+func (g *Guard) AfterProcess(_ context.Context, cmd redis.Cmder) error {
+	g.Report(cmd.Err())
+	return nil
+}
+
+func (g *Guard) BeforeProcessPipeline(ctx context.Context, _ []redis.Cmder) (context.Context, error) {
+	return ctx, nil
+}
+
+func (g *Guard) AfterProcessPipeline(_ context.Context, cmds []redis.Cmder) error {
+	for _, cmd := range cmds {
+		if g.Report(cmd.Err()) {
+			break
+		}
+	}
+	return nil
+}
+
+func (g *Guard) loop() {
+	ticker := time.NewTicker(g.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			g.Check()
+		case <-g.stop:
+			return
+		}
+	}
+}
+
+func (g *Guard) setErr(err error) {
+	g.mx.Lock()
+	wasAvailable := g.err == nil
+	g.err = err
+	onFailure := g.onFailure
+	onRecover := g.onRecover
+	g.mx.Unlock()
+
+	switch {
+	case wasAvailable && err != nil:
+		for _, handler := range onFailure {
+			handler(err)
+		}
+	case !wasAvailable && err == nil:
+		for _, handler := range onRecover {
+			handler()
+		}
+	}
+}
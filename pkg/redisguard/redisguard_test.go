@@ -0,0 +1,103 @@
+package redisguard
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+type testPinger struct {
+	mx  sync.Mutex
+	err error
+}
+
+func (p *testPinger) Ping(ctx context.Context) *redis.StatusCmd {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+	return redis.NewStatusResult("PONG", p.err)
+}
+
+func (p *testPinger) setErr(err error) {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+	p.err = err
+}
+
+func TestIsFailure(t *testing.T) {
+	if IsFailure(nil) {
+		t.Error("nil reported as failure")
+	}
+	if IsFailure(redis.Nil) {
+		t.Error("redis.Nil reported as failure")
+	}
+	if !IsFailure(errors.New("dial tcp: connection refused")) {
+		t.Error("connection error not reported as failure")
+	}
+}
+
+func TestGuard(t *testing.T) {
+	p := &testPinger{}
+	g := New(p, time.Hour)
+	defer g.Close()
+
+	var failures, recovers int
+	g.OnFailure(func(err error) { failures++ })
+	g.OnRecover(func() { recovers++ })
+
+	if !g.Available() {
+		t.Fatal("guard not available initially")
+	}
+
+	connErr := errors.New("connection refused")
+	if !g.Report(connErr) {
+		t.Error("failure was not reported")
+	}
+	g.Report(connErr)
+	if g.Available() || g.Err() != connErr {
+		t.Errorf("guard available after failure: %v", g.Err())
+	}
+
+	p.setErr(connErr)
+	g.Check()
+	if g.Available() {
+		t.Error("guard available while ping fails")
+	}
+
+	p.setErr(nil)
+	g.Check()
+	if !g.Available() {
+		t.Error("guard not available after recovery")
+	}
+
+	if failures != 1 || recovers != 1 {
+		t.Errorf("unexpected handler calls: %d failures, %d recovers", failures, recovers)
+	}
+
+	if g.Report(redis.Nil) || !g.Available() {
+		t.Error("redis.Nil marked connection as unavailable")
+	}
+}
+
+func TestGuardInitiallyUnavailable(t *testing.T) {
+	p := &testPinger{err: errors.New("connection refused")}
+	g := New(p, 10*time.Millisecond)
+	defer g.Close()
+
+	if g.Available() {
+		t.Fatal("guard available initially")
+	}
+
+	recovered := make(chan struct{})
+	g.OnRecover(func() { close(recovered) })
+
+	p.setErr(nil)
+	select {
+	case <-recovered:
+	case <-time.After(time.Second):
+		t.Fatal("guard did not recover")
+	}
+}
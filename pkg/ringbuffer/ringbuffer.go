@@ -0,0 +1,78 @@
+// Package ringbuffer provides a generic, fixed size
+// buffer which overwrites the oldest elements when
+// its capacity is exceeded.
+package ringbuffer
+
+import "sync"
+
+// RingBuffer holds up to a fixed number of
+// elements. When the buffer is full, pushing
+// a new element overwrites the oldest one.
+//
+// A RingBuffer is safe for concurrent use.
+type RingBuffer[T any] struct {
+	mtx   sync.RWMutex
+	items []T
+	next  int
+	full  bool
+}
+
+// New returns a new RingBuffer with the given
+// capacity. If size is smaller than 1, a
+// capacity of 1 is used.
+func New[T any](size int) *RingBuffer[T] {
+	if size < 1 {
+		size = 1
+	}
+	return &RingBuffer[T]{
+		items: make([]T, size),
+	}
+}
+
+// Push adds v to the buffer. If the buffer is
+// full, the oldest element is overwritten.
+func (r *RingBuffer[T]) Push(v T) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	r.items[r.next] = v
+	r.next = (r.next + 1) % len(r.items)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// Len returns the number of elements
+// in the buffer.
+func (r *RingBuffer[T]) Len() int {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	if r.full {
+		return len(r.items)
+	}
+	return r.next
+}
+
+// Cap returns the capacity of the buffer.
+func (r *RingBuffer[T]) Cap() int {
+	return len(r.items)
+}
+
+// Items returns a copy of the elements in the
+// buffer ordered from the oldest to the newest.
+func (r *RingBuffer[T]) Items() []T {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	if !r.full {
+		res := make([]T, r.next)
+		copy(res, r.items[:r.next])
+		return res
+	}
+
+	res := make([]T, 0, len(r.items))
+	res = append(res, r.items[r.next:]...)
+	res = append(res, r.items[:r.next]...)
+	return res
+}
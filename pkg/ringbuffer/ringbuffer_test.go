@@ -0,0 +1,58 @@
+package ringbuffer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPush(t *testing.T) {
+	r := New[int](3)
+
+	if r.Len() != 0 || len(r.Items()) != 0 {
+		t.Error("new buffer is not empty")
+	}
+
+	r.Push(1)
+	r.Push(2)
+	if r.Len() != 2 {
+		t.Errorf("invalid length: %d", r.Len())
+	}
+	if items := r.Items(); !reflect.DeepEqual(items, []int{1, 2}) {
+		t.Errorf("invalid items: %v", items)
+	}
+
+	r.Push(3)
+	r.Push(4)
+	r.Push(5)
+	if r.Len() != 3 {
+		t.Errorf("invalid length: %d", r.Len())
+	}
+	if items := r.Items(); !reflect.DeepEqual(items, []int{3, 4, 5}) {
+		t.Errorf("invalid items: %v", items)
+	}
+}
+
+func TestItemsCopy(t *testing.T) {
+	r := New[int](2)
+	r.Push(1)
+
+	items := r.Items()
+	items[0] = 2
+
+	if r.Items()[0] != 1 {
+		t.Error("items are not copied")
+	}
+}
+
+func TestNewInvalidSize(t *testing.T) {
+	r := New[string](0)
+	if r.Cap() != 1 {
+		t.Errorf("invalid capacity: %d", r.Cap())
+	}
+
+	r.Push("a")
+	r.Push("b")
+	if items := r.Items(); !reflect.DeepEqual(items, []string{"b"}) {
+		t.Errorf("invalid items: %v", items)
+	}
+}
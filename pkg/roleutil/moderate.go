@@ -0,0 +1,55 @@
+package roleutil
+
+import (
+	"errors"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/zekroTJA/shinpuru/pkg/discordutil"
+)
+
+var (
+	ErrTargetIsOwner = errors.New("the owner of the guild can not be moderated")
+	ErrActorRoleDiff = errors.New("you can only moderate members whose highest role is lower than yours")
+	ErrBotRoleDiff   = errors.New("the highest role of the bot must be higher than the highest role of the member")
+)
+
+// IsModerationError returns true if err is one of the
+// errors returned by CanModerate.
+func IsModerationError(err error) bool {
+	return errors.Is(err, ErrTargetIsOwner) ||
+		errors.Is(err, ErrActorRoleDiff) ||
+		errors.Is(err, ErrBotRoleDiff)
+}
+
+// CanModerate returns an error describing the reason
+// if the actor can not perform moderation actions
+// like kicks, bans or mutes on the target member.
+//
+// The owner of the guild can not be moderated at all.
+// Otherwise, the highest role of the target must be
+// lower than the highest role of the actor, unless the
+// actor is the owner or an administrator of the guild.
+// Because Discord enforces the role hierarchy on the
+// bot as well, the highest role of the target must
+// also be lower than the highest role of the bot. When
+// bot is nil, the check against the bot is skipped.
+func CanModerate(actor, target, bot *discordgo.Member, guild *discordgo.Guild) error {
+	if isOwner(guild, target) {
+		return ErrTargetIsOwner
+	}
+
+	if !isOwner(guild, actor) && !discordutil.IsAdmin(guild, actor) &&
+		PositionDiff(target, actor, guild) >= 0 {
+		return ErrActorRoleDiff
+	}
+
+	if bot != nil && !isOwner(guild, bot) && PositionDiff(target, bot, guild) >= 0 {
+		return ErrBotRoleDiff
+	}
+
+	return nil
+}
+
+func isOwner(guild *discordgo.Guild, m *discordgo.Member) bool {
+	return m != nil && m.User != nil && guild.OwnerID != "" && m.User.ID == guild.OwnerID
+}
@@ -0,0 +1,58 @@
+package roleutil
+
+import (
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanModerate(t *testing.T) {
+	guild := &discordgo.Guild{
+		OwnerID: "owner",
+		Roles: []*discordgo.Role{
+			{ID: "member", Position: 1},
+			{ID: "mod", Position: 2},
+			{ID: "admin", Position: 3, Permissions: discordgo.PermissionAdministrator},
+			{ID: "bot", Position: 4},
+			{ID: "top", Position: 5},
+		},
+	}
+
+	member := func(id string, roles ...string) *discordgo.Member {
+		return &discordgo.Member{User: &discordgo.User{ID: id}, Roles: roles}
+	}
+
+	owner := member("owner")
+	bot := member("bot", "bot")
+	mod := member("mod", "mod")
+	otherMod := member("othermod", "mod")
+	admin := member("admin", "admin")
+	user := member("user", "member")
+	top := member("top", "top")
+
+	// Actor above target
+	assert.Nil(t, CanModerate(mod, user, bot, guild))
+
+	// Actor below or equal to target
+	assert.ErrorIs(t, CanModerate(user, mod, bot, guild), ErrActorRoleDiff)
+	assert.ErrorIs(t, CanModerate(mod, otherMod, bot, guild), ErrActorRoleDiff)
+
+	// Administrators bypass the actor hierarchy
+	assert.Nil(t, CanModerate(admin, otherMod, bot, guild))
+
+	// Bot below or equal to target
+	assert.ErrorIs(t, CanModerate(owner, top, bot, guild), ErrBotRoleDiff)
+	assert.ErrorIs(t, CanModerate(admin, member("otherbot", "bot"), bot, guild), ErrBotRoleDiff)
+	assert.Nil(t, CanModerate(owner, top, nil, guild))
+
+	// Owner immunity
+	assert.ErrorIs(t, CanModerate(top, owner, bot, guild), ErrTargetIsOwner)
+	assert.ErrorIs(t, CanModerate(admin, owner, nil, guild), ErrTargetIsOwner)
+
+	// Owner bypasses the actor hierarchy
+	assert.Nil(t, CanModerate(owner, admin, bot, guild))
+
+	assert.True(t, IsModerationError(CanModerate(user, mod, bot, guild)))
+	assert.False(t, IsModerationError(nil))
+}
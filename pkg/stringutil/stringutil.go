@@ -3,8 +3,10 @@
 package stringutil
 
 import (
+	"fmt"
 	"regexp"
 	"strings"
+	"unicode/utf8"
 )
 
 var (
@@ -149,3 +151,53 @@ func Capitalize(v string, all bool) string {
 		return strings.ToUpper(string(v[0])) + v[1:]
 	}
 }
+
+// Truncate shortens the given string to at most maxLen
+// characters. If the string is shortened, the last
+// character is replaced with an ellipsis.
+func Truncate(s string, maxLen int) string {
+	if r := []rune(s); len(r) > maxLen {
+		if maxLen <= 0 {
+			return ""
+		}
+		s = string(r[:maxLen-1]) + "…"
+	}
+	return s
+}
+
+// JoinTruncated joins the elements by line breaks so
+// that the result does not exceed maxLen characters.
+// Elements which do not fit anymore are summarized by
+// a note like "*... and 3 more*", for which space is
+// reserved in maxLen. If elems is empty, def is
+// returned.
+func JoinTruncated(elems []string, maxLen int, def string) string {
+	if len(elems) == 0 {
+		return def
+	}
+
+	const noteLen = 32
+
+	total := 0
+	for _, e := range elems {
+		total += utf8.RuneCountInString(e) + 1
+	}
+	if total > maxLen {
+		maxLen -= noteLen
+	}
+
+	var sb strings.Builder
+	n := 0
+	for i, e := range elems {
+		l := utf8.RuneCountInString(e) + 1
+		if n+l > maxLen {
+			fmt.Fprintf(&sb, "*... and %d more*", len(elems)-i)
+			break
+		}
+		sb.WriteString(e)
+		sb.WriteRune('\n')
+		n += l
+	}
+
+	return sb.String()
+}
@@ -1,7 +1,9 @@
 package stringutil
 
 import (
+	"strings"
 	"testing"
+	"unicode/utf8"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -217,3 +219,28 @@ func TestCapitalize(t *testing.T) {
 	assert.Equal(t, "Hey Was Geht Ab", Capitalize("Hey Was Geht Ab", false))
 	assert.Equal(t, "Hey Was Geht Ab", Capitalize("Hey Was Geht Ab", true))
 }
+
+func TestTruncate(t *testing.T) {
+	assert.Equal(t, "", Truncate("", 5))
+	assert.Equal(t, "hello", Truncate("hello", 5))
+	assert.Equal(t, "hell…", Truncate("hello!", 5))
+	assert.Equal(t, "äöü…", Truncate("äöüßäöü", 4))
+	assert.Equal(t, "", Truncate("hello", 0))
+}
+
+func TestJoinTruncated(t *testing.T) {
+	assert.Equal(t, "none", JoinTruncated(nil, 100, "none"))
+	assert.Equal(t, "a\nb\n", JoinTruncated([]string{"a", "b"}, 100, ""))
+
+	elems := make([]string, 100)
+	for i := range elems {
+		elems[i] = strings.Repeat("ä", 9)
+	}
+	res := JoinTruncated(elems, 100, "")
+	assert.LessOrEqual(t, utf8.RuneCountInString(res), 100)
+	assert.True(t, strings.HasSuffix(res, "*... and 94 more*"))
+
+	// The last element does not need space for the note.
+	res = JoinTruncated(elems[:10], 100, "")
+	assert.Equal(t, 100, utf8.RuneCountInString(res))
+}
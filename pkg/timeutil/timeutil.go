@@ -6,6 +6,7 @@ import (
 	"errors"
 	"regexp"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/zekroTJA/shinpuru/pkg/regexputil"
@@ -115,3 +116,49 @@ func ParseDuration(s string) (time.Duration, error) {
 
 	return d, nil
 }
+
+var ageUnits = []struct {
+	name string
+	d    time.Duration
+}{
+	{"year", 365 * 24 * time.Hour},
+	{"day", 24 * time.Hour},
+	{"hour", time.Hour},
+	{"minute", time.Minute},
+	{"second", time.Second},
+}
+
+// FormatAge returns a human readable representation of
+// the passed duration composed of the two largest non-zero
+// units from years down to seconds, for example
+// "2 years, 41 days". Years are counted as 365 days.
+// Durations below one second result in "0 seconds".
+func FormatAge(d time.Duration) string {
+	if d < 0 {
+		d = -d
+	}
+
+	parts := make([]string, 0, 2)
+	for _, u := range ageUnits {
+		if len(parts) == 2 || (len(parts) == 1 && d < u.d) {
+			break
+		}
+		v := d / u.d
+		if v == 0 {
+			continue
+		}
+		d -= v * u.d
+
+		part := strconv.Itoa(int(v)) + " " + u.name
+		if v != 1 {
+			part += "s"
+		}
+		parts = append(parts, part)
+	}
+
+	if len(parts) == 0 {
+		return "0 seconds"
+	}
+
+	return strings.Join(parts, ", ")
+}
@@ -116,3 +116,15 @@ func TestParseDuration(t *testing.T) {
 	_, err = ParseDuration("4h invalid 3s")
 	assert.EqualError(t, err, ErrInvalidDurationFormat.Error())
 }
+
+func TestFormatAge(t *testing.T) {
+	const day = 24 * time.Hour
+
+	assert.Equal(t, "0 seconds", FormatAge(0))
+	assert.Equal(t, "0 seconds", FormatAge(300*time.Millisecond))
+	assert.Equal(t, "1 second", FormatAge(time.Second))
+	assert.Equal(t, "5 minutes, 3 seconds", FormatAge(5*time.Minute+3*time.Second))
+	assert.Equal(t, "1 day, 2 hours", FormatAge(26*time.Hour+5*time.Minute))
+	assert.Equal(t, "2 years, 41 days", FormatAge(2*365*day+41*day+3*time.Hour))
+	assert.Equal(t, "3 hours", FormatAge(-3*time.Hour))
+}
@@ -4,6 +4,16 @@ import "time"
 
 type Config struct {
 	TimerDelay time.Duration `json:"timderdelay"`
+
+	// EventSubCallback is the public URL Twitch sends
+	// EventSub notifications to. When this and
+	// EventSubSecret are set, stream state changes are
+	// received via EventSub webhooks instead of polling.
+	EventSubCallback string `json:"eventsubcallback"`
+	// EventSubSecret is used by Twitch to sign the
+	// EventSub notifications. It must be between 10
+	// and 100 characters long.
+	EventSubSecret string `json:"eventsubsecret"`
 }
 
 func defaultConfig(configs []Config) Config {
@@ -0,0 +1,78 @@
+package twitchnotify
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/valyala/fasthttp"
+	"github.com/zekroTJA/shinpuru/pkg/httpreq"
+)
+
+// AuthError is returned when the twitch API
+// rejects the configured app credentials.
+type AuthError struct {
+	Status  int
+	Message string
+}
+
+func (e *AuthError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("authentication failed with status %d", e.Status)
+	}
+	return fmt.Sprintf("authentication failed with status %d: %s", e.Status, e.Message)
+}
+
+// RateLimit contains the rate limit budget of
+// the twitch API application.
+type RateLimit struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+// TestCredentials retrieves a new bearer token using
+// the configured credentials and performs a test request
+// to the twitch API. The current rate limit budget of
+// the application is returned.
+//
+// If the credentials are rejected, an *AuthError is
+// returned. Other errors indicate that the twitch API
+// could not be reached.
+func (w *NotifyWorker) TestCredentials() (rl RateLimit, err error) {
+	if err = w.getBearerToken(); err != nil {
+		return
+	}
+
+	res, err := w.request("GET", helixEndpoint+"/users?login=twitchdev", nil)
+	if err != nil {
+		return
+	}
+	defer res.Release()
+
+	switch status := res.StatusCode(); {
+	case status == fasthttp.StatusUnauthorized || status == fasthttp.StatusForbidden:
+		return rl, newAuthError(res)
+	case status != fasthttp.StatusOK:
+		return rl, fmt.Errorf("twitch API responded with status %d", status)
+	}
+
+	rl.Limit, _ = strconv.Atoi(string(res.Header.Peek("Ratelimit-Limit")))
+	rl.Remaining, _ = strconv.Atoi(string(res.Header.Peek("Ratelimit-Remaining")))
+	if reset, err := strconv.ParseInt(string(res.Header.Peek("Ratelimit-Reset")), 10, 64); err == nil {
+		rl.Reset = time.Unix(reset, 0)
+	}
+
+	return rl, nil
+}
+
+// newAuthError creates an *AuthError from the
+// given error response of the twitch API.
+func newAuthError(res *httpreq.Response) *AuthError {
+	var body errorResponse
+	res.JSON(&body)
+	return &AuthError{
+		Status:  res.StatusCode(),
+		Message: body.Message,
+	}
+}
@@ -0,0 +1,186 @@
+package twitchnotify
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func testAPI(t *testing.T, handler http.HandlerFunc) {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	oldOAuth2, oldHelix := oAuth2Endpoint, helixEndpoint
+	oAuth2Endpoint, helixEndpoint = srv.URL+"/oauth2/token", srv.URL+"/helix"
+	t.Cleanup(func() {
+		oAuth2Endpoint, helixEndpoint = oldOAuth2, oldHelix
+	})
+
+	noRetryDelay(t)
+}
+
+func noRetryDelay(t *testing.T) {
+	oldDelay := bearerTokenRetryDelay
+	bearerTokenRetryDelay = 0
+	t.Cleanup(func() {
+		bearerTokenRetryDelay = oldDelay
+	})
+}
+
+func credentialsWorker(secret string) *NotifyWorker {
+	return &NotifyWorker{creds: &Credentials{ClientID: "id", ClientSecret: secret}}
+}
+
+func TestTestCredentials(t *testing.T) {
+	var tokens int32
+	testAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/oauth2/token":
+			if r.URL.Query().Get("client_secret") != "secret" {
+				w.WriteHeader(http.StatusForbidden)
+				fmt.Fprint(w, `{"status":403,"message":"invalid client secret"}`)
+				return
+			}
+			n := atomic.AddInt32(&tokens, 1)
+			fmt.Fprintf(w, `{"access_token":"token-%d","expires_in":3600}`, n)
+		case "/helix/users":
+			// Only the latest token is accepted.
+			if r.Header.Get("Authorization") != fmt.Sprintf("Bearer token-%d", atomic.LoadInt32(&tokens)) {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Header().Set("Ratelimit-Limit", "800")
+			w.Header().Set("Ratelimit-Remaining", "799")
+			w.Header().Set("Ratelimit-Reset", "1660000000")
+			fmt.Fprint(w, `{"data":[]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	rl, err := credentialsWorker("secret").TestCredentials()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if rl.Limit != 800 || rl.Remaining != 799 || rl.Reset.Unix() != 1660000000 {
+		t.Errorf("unexpected rate limit: %+v", rl)
+	}
+
+	_, err = credentialsWorker("invalid").TestCredentials()
+	var authErr *AuthError
+	if !errors.As(err, &authErr) {
+		t.Fatalf("expected auth error, got: %v", err)
+	}
+	if authErr.Status != http.StatusForbidden || authErr.Message != "invalid client secret" {
+		t.Errorf("unexpected auth error: %+v", authErr)
+	}
+}
+
+func TestRequestRefreshesToken(t *testing.T) {
+	var tokens int32
+	testAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/oauth2/token":
+			n := atomic.AddInt32(&tokens, 1)
+			fmt.Fprintf(w, `{"access_token":"token-%d","expires_in":3600}`, n)
+		case "/helix/users":
+			if r.Header.Get("Authorization") != "Bearer token-2" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			fmt.Fprint(w, `{"data":[{"id":"1337","login":"zekro"}]}`)
+		}
+	})
+
+	w := credentialsWorker("secret")
+	u, err := w.GetUser("zekro", IdentLogin)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if u.ID != "1337" {
+		t.Errorf("unexpected user: %+v", u)
+	}
+	if n := atomic.LoadInt32(&tokens); n != 2 {
+		t.Errorf("expected token to be refreshed once, got %d tokens", n)
+	}
+}
+
+func TestTestCredentialsNetworkError(t *testing.T) {
+	oldOAuth2 := oAuth2Endpoint
+	oAuth2Endpoint = "http://127.0.0.1:1/oauth2/token"
+	defer func() { oAuth2Endpoint = oldOAuth2 }()
+	noRetryDelay(t)
+
+	_, err := credentialsWorker("secret").TestCredentials()
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	var authErr *AuthError
+	if errors.As(err, &authErr) {
+		t.Errorf("network error reported as auth error: %s", err)
+	}
+}
+
+func TestGetBearerTokenRetries(t *testing.T) {
+	var attempts int32
+	testAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) < bearerTokenAttempts {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"access_token":"token","expires_in":3600}`)
+	})
+
+	w := credentialsWorker("secret")
+	if err := w.getBearerToken(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if w.bearerToken != "token" {
+		t.Errorf("unexpected token: %s", w.bearerToken)
+	}
+	if n := atomic.LoadInt32(&attempts); n != bearerTokenAttempts {
+		t.Errorf("expected %d attempts, got %d", bearerTokenAttempts, n)
+	}
+}
+
+func TestGetBearerTokenServerError(t *testing.T) {
+	var attempts int32
+	testAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	err := credentialsWorker("secret").getBearerToken()
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	var authErr *AuthError
+	if errors.As(err, &authErr) {
+		t.Errorf("server error reported as auth error: %s", err)
+	}
+	if n := atomic.LoadInt32(&attempts); n != bearerTokenAttempts {
+		t.Errorf("expected %d attempts, got %d", bearerTokenAttempts, n)
+	}
+}
+
+func TestGetBearerTokenAuthErrorNotRetried(t *testing.T) {
+	for _, status := range []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden} {
+		var attempts int32
+		testAPI(t, func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&attempts, 1)
+			w.WriteHeader(status)
+		})
+
+		err := credentialsWorker("secret").getBearerToken()
+		var authErr *AuthError
+		if !errors.As(err, &authErr) || authErr.Status != status {
+			t.Errorf("expected auth error with status %d, got: %v", status, err)
+		}
+		if n := atomic.LoadInt32(&attempts); n != 1 {
+			t.Errorf("expected 1 attempt for status %d, got %d", status, n)
+		}
+	}
+}
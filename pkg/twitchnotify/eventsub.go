@@ -0,0 +1,312 @@
+package twitchnotify
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/zekroTJA/shinpuru/pkg/multierror"
+)
+
+const (
+	// HeaderEventSubMessageType is the header containing
+	// the type of a Twitch EventSub message.
+	HeaderEventSubMessageType = "Twitch-Eventsub-Message-Type"
+
+	MessageTypeVerification = "webhook_callback_verification"
+	MessageTypeNotification = "notification"
+	MessageTypeRevocation   = "revocation"
+
+	EventStreamOnline  = "stream.online"
+	EventStreamOffline = "stream.offline"
+
+	statusEnabled             = "enabled"
+	statusVerificationPending = "webhook_callback_verification_pending"
+)
+
+var (
+	ErrInvalidEventSubMessage = errors.New("invalid eventsub message")
+	ErrUnexpectedStatus       = errors.New("unexpected response status")
+)
+
+var eventSubTypes = []string{EventStreamOnline, EventStreamOffline}
+
+// UsesEventSub returns true when stream state changes
+// are received via EventSub webhooks instead of polling.
+func (w *NotifyWorker) UsesEventSub() bool {
+	return w.conf.EventSubCallback != ""
+}
+
+// HandleEventSub handles a received EventSub message of
+// the given message type. The signature of the message
+// must be verified before passing it.
+//
+// The returned string must be sent back to Twitch as
+// plain text response body if it is not empty.
+func (w *NotifyWorker) HandleEventSub(messageType string, body []byte) (string, error) {
+	var msg eventSubMessage
+	if err := json.Unmarshal(body, &msg); err != nil {
+		return "", ErrInvalidEventSubMessage
+	}
+
+	switch messageType {
+
+	case MessageTypeVerification:
+		if msg.Challenge == "" {
+			return "", ErrInvalidEventSubMessage
+		}
+		return msg.Challenge, nil
+
+	case MessageTypeNotification:
+		if msg.Event == nil {
+			return "", ErrInvalidEventSubMessage
+		}
+		switch msg.Subscription.Type {
+		case EventStreamOnline:
+			w.handleStreamOnline(msg.Event)
+		case EventStreamOffline:
+			w.handleStreamOffline(msg.Event)
+		}
+		return "", nil
+
+	case MessageTypeRevocation:
+		// Revoked subscriptions are re-created on the
+		// next SyncSubscriptions call if the user is
+		// still watched.
+		return "", nil
+	}
+
+	return "", ErrInvalidEventSubMessage
+}
+
+// Subscribe creates the EventSub subscriptions for
+// the twitch user with the given ID. When EventSub is
+// not used, this is a no-op.
+func (w *NotifyWorker) Subscribe(userID string) error {
+	if !w.UsesEventSub() {
+		return nil
+	}
+
+	mErr := multierror.New()
+	for _, typ := range eventSubTypes {
+		mErr.Append(w.createSubscription(userID, typ))
+	}
+
+	return mErr.Nillify()
+}
+
+// Unsubscribe deletes all EventSub subscriptions of
+// the twitch user with the given ID.
+func (w *NotifyWorker) Unsubscribe(userID string) error {
+	subs, err := w.getSubscriptions("user_id=" + url.QueryEscape(userID))
+	if err != nil {
+		return err
+	}
+
+	mErr := multierror.New()
+	for _, sub := range subs {
+		if w.isOwnSubscription(sub) {
+			mErr.Append(w.deleteSubscription(sub.ID))
+		}
+	}
+
+	return mErr.Nillify()
+}
+
+// SyncSubscriptions aligns the EventSub subscriptions with
+// the watch list. Missing, revoked or failed subscriptions
+// are (re-)created and subscriptions of users which are
+// not watched anymore are deleted. When EventSub is not
+// used, this is a no-op.
+func (w *NotifyWorker) SyncSubscriptions() error {
+	if !w.UsesEventSub() {
+		return nil
+	}
+
+	subs, err := w.getSubscriptions("")
+	if err != nil {
+		return err
+	}
+
+	mErr := multierror.New()
+
+	existing := make(map[string]bool)
+	for _, sub := range subs {
+		if !w.isOwnSubscription(sub) {
+			continue
+		}
+		watched := w.user(sub.Condition.BroadcasterUserID) != nil
+		if !watched || (sub.Status != statusEnabled && sub.Status != statusVerificationPending) {
+			mErr.Append(w.deleteSubscription(sub.ID))
+			continue
+		}
+		existing[sub.Condition.BroadcasterUserID+sub.Type] = true
+	}
+
+	for _, userID := range w.userIDs() {
+		for _, typ := range eventSubTypes {
+			if !existing[userID+typ] {
+				mErr.Append(w.createSubscription(userID, typ))
+			}
+		}
+	}
+
+	return mErr.Nillify()
+}
+
+// isOwnSubscription returns true if the subscription
+// delivers to the configured callback. Subscriptions
+// of other instances using the same twitch app are
+// left untouched.
+func (w *NotifyWorker) isOwnSubscription(sub *EventSubSubscription) bool {
+	return sub.Transport.Method == "webhook" &&
+		sub.Transport.Callback == w.conf.EventSubCallback
+}
+
+func (w *NotifyWorker) getSubscriptions(query string) ([]*EventSubSubscription, error) {
+	subs := make([]*EventSubSubscription, 0)
+
+	var cursor string
+	for {
+		url := fmt.Sprintf("%s/eventsub/subscriptions?%s", helixEndpoint, query)
+		if cursor != "" {
+			url += "&after=" + cursor
+		}
+
+		data := new(subscriptionsDataWrapper)
+		status, err := w.doAuthenticated("GET", url, nil, data)
+		if err != nil {
+			return nil, err
+		}
+		if status != 200 {
+			return nil, fmt.Errorf("%w: %d", ErrUnexpectedStatus, status)
+		}
+
+		subs = append(subs, data.Data...)
+
+		cursor = data.Pagination.Cursor
+		if cursor == "" {
+			break
+		}
+	}
+
+	return subs, nil
+}
+
+func (w *NotifyWorker) createSubscription(userID, typ string) error {
+	sub := EventSubSubscription{
+		Type:    typ,
+		Version: "1",
+		Condition: EventSubCondition{
+			BroadcasterUserID: userID,
+		},
+		Transport: EventSubTransport{
+			Method:   "webhook",
+			Callback: w.conf.EventSubCallback,
+			Secret:   w.conf.EventSubSecret,
+		},
+	}
+
+	url := fmt.Sprintf("%s/eventsub/subscriptions", helixEndpoint)
+	status, err := w.doAuthenticated("POST", url, sub, nil)
+	if err != nil {
+		return err
+	}
+
+	// 409 Conflict is returned when the
+	// subscription already exists.
+	if status != 202 && status != 409 {
+		return fmt.Errorf("%w: %d", ErrUnexpectedStatus, status)
+	}
+
+	return nil
+}
+
+func (w *NotifyWorker) deleteSubscription(id string) error {
+	endpoint := fmt.Sprintf("%s/eventsub/subscriptions?id=%s", helixEndpoint, url.QueryEscape(id))
+	status, err := w.doAuthenticated("DELETE", endpoint, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	if status != 204 && status != 404 {
+		return fmt.Errorf("%w: %d", ErrUnexpectedStatus, status)
+	}
+
+	return nil
+}
+
+func (w *NotifyWorker) handleStreamOnline(ev *EventSubStreamEvent) {
+	user := w.user(ev.BroadcasterUserID)
+	if user == nil {
+		return
+	}
+
+	registered := &Stream{
+		ID:        ev.ID,
+		UserID:    ev.BroadcasterUserID,
+		UserName:  ev.BroadcasterUserName,
+		Type:      ev.Type,
+		StartedAt: ev.StartedAt,
+	}
+
+	// The stream is registered in the same critical section
+	// as the check, because Twitch might deliver a
+	// notification more than once, even concurrently.
+	w.mx.Lock()
+	for _, s := range w.wereLive {
+		if s.ID == ev.ID {
+			w.mx.Unlock()
+			return
+		}
+	}
+	w.wereLive = append(w.wereLive, registered)
+	w.mx.Unlock()
+
+	// The notification does not contain details like the
+	// title or the game, so they are fetched afterwards.
+	// If the stream is not yet available via the API, the
+	// event data is used.
+	stream := new(Stream)
+	*stream = *registered
+	if streams, err := w.getStreams([]string{ev.BroadcasterUserID}); err == nil && len(streams) > 0 {
+		stream = streams[0]
+	}
+
+	w.hydrateStream(stream)
+
+	// The registered stream is replaced unless the stream
+	// went offline in the meantime.
+	w.mx.Lock()
+	for i, s := range w.wereLive {
+		if s == registered {
+			w.wereLive[i] = stream
+			break
+		}
+	}
+	w.mx.Unlock()
+
+	w.wentOnlineHandler(stream, user)
+}
+
+func (w *NotifyWorker) handleStreamOffline(ev *EventSubStreamEvent) {
+	user := w.user(ev.BroadcasterUserID)
+
+	w.mx.Lock()
+	var stream *Stream
+	for i, s := range w.wereLive {
+		if s.UserID == ev.BroadcasterUserID {
+			stream = s
+			w.wereLive = append(w.wereLive[:i], w.wereLive[i+1:]...)
+			break
+		}
+	}
+	w.mx.Unlock()
+
+	if stream == nil || user == nil {
+		return
+	}
+
+	w.wentOfflineHandler(stream, user)
+}
@@ -0,0 +1,132 @@
+package twitchnotify
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func testWorker(offline NotifyHandler) *NotifyWorker {
+	return &NotifyWorker{
+		conf:               Config{EventSubCallback: "https://example.com/callback"},
+		wentOfflineHandler: offline,
+		mx:                 &sync.Mutex{},
+		usersMx:            &sync.RWMutex{},
+		users:              map[string]*User{"1337": {ID: "1337"}},
+		wereLive:           []*Stream{{ID: "9001", UserID: "1337"}},
+		gameCache:          map[string]*Game{},
+	}
+}
+
+func TestHandleEventSubVerification(t *testing.T) {
+	w := testWorker(nil)
+
+	res, err := w.HandleEventSub(MessageTypeVerification,
+		[]byte(`{"challenge":"pogchamp-kappa-360noscope-vohiyo"}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if res != "pogchamp-kappa-360noscope-vohiyo" {
+		t.Errorf("unexpected challenge response: '%s'", res)
+	}
+
+	if _, err = w.HandleEventSub(MessageTypeVerification, []byte(`{}`)); err != ErrInvalidEventSubMessage {
+		t.Errorf("missing challenge was not rejected: %v", err)
+	}
+}
+
+func TestHandleEventSubInvalid(t *testing.T) {
+	w := testWorker(nil)
+
+	cases := []struct {
+		messageType, body string
+	}{
+		{MessageTypeNotification, `not json`},
+		{MessageTypeNotification, `{"subscription":{"type":"stream.online"}}`},
+		{"unknown", `{}`},
+	}
+
+	for _, c := range cases {
+		if _, err := w.HandleEventSub(c.messageType, []byte(c.body)); err != ErrInvalidEventSubMessage {
+			t.Errorf("message '%s' (%s) was not rejected: %v", c.body, c.messageType, err)
+		}
+	}
+}
+
+func TestHandleEventSubOffline(t *testing.T) {
+	var calls int
+	w := testWorker(func(s *Stream, u *User) {
+		calls++
+		if s.ID != "9001" || u.ID != "1337" {
+			t.Errorf("unexpected stream %s of user %s", s.ID, u.ID)
+		}
+	})
+
+	body := []byte(`{"subscription":{"type":"stream.offline"},` +
+		`"event":{"broadcaster_user_id":"1337"}}`)
+
+	for i := 0; i < 2; i++ {
+		if _, err := w.HandleEventSub(MessageTypeNotification, body); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	if calls != 1 {
+		t.Errorf("offline handler was called %d times", calls)
+	}
+	if len(w.wereLive) != 0 {
+		t.Errorf("stream was not removed from live streams")
+	}
+}
+
+func TestIsLive(t *testing.T) {
+	w := testWorker(nil)
+
+	if !w.IsLive("1337") {
+		t.Error("live user was not reported as live")
+	}
+	if w.IsLive("9001") {
+		t.Error("offline user was reported as live")
+	}
+}
+
+func TestHandleEventSubOnlineConcurrent(t *testing.T) {
+	testAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/oauth2/token" {
+			fmt.Fprint(w, `{"access_token":"token","expires_in":3600}`)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	var calls int32
+	w := testWorker(nil)
+	w.creds = &Credentials{ClientID: "id", ClientSecret: "secret"}
+	w.wentOnlineHandler = func(s *Stream, u *User) {
+		atomic.AddInt32(&calls, 1)
+	}
+
+	body := []byte(`{"subscription":{"type":"stream.online"},` +
+		`"event":{"id":"9002","broadcaster_user_id":"1337","type":"live"}}`)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := w.HandleEventSub(MessageTypeNotification, body); err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if calls != 1 {
+		t.Errorf("online handler was called %d times", calls)
+	}
+	if len(w.wereLive) != 2 || w.wereLive[1].ID != "9002" {
+		t.Errorf("unexpected live streams: %+v", w.wereLive)
+	}
+}
@@ -39,6 +39,17 @@ type DBEntry struct {
 	GuildID      string
 	ChannelID    string
 	TwitchUserID string
+
+	// Template is the message template of the
+	// notification. See RenderTemplate for the
+	// available placeholders.
+	Template string
+	// RoleID is the ID of the role mentioned in
+	// the notification.
+	RoleID string
+	// Plain sends the notification as plain
+	// message without embed.
+	Plain bool
 }
 
 type usersDataWrapper struct {
@@ -57,3 +68,57 @@ type bearerTokenResponse struct {
 	AccessToken string `json:"access_token"`
 	ExpiresIn   int    `json:"expires_in"`
 }
+
+type errorResponse struct {
+	Status  int    `json:"status"`
+	Message string `json:"message"`
+}
+
+// EventSubSubscription wraps information about
+// a Twitch EventSub subscription.
+type EventSubSubscription struct {
+	ID        string            `json:"id,omitempty"`
+	Type      string            `json:"type"`
+	Version   string            `json:"version"`
+	Status    string            `json:"status,omitempty"`
+	Condition EventSubCondition `json:"condition"`
+	Transport EventSubTransport `json:"transport"`
+}
+
+// EventSubCondition specifies the condition under
+// which an EventSub subscription fires.
+type EventSubCondition struct {
+	BroadcasterUserID string `json:"broadcaster_user_id"`
+}
+
+// EventSubTransport specifies how EventSub
+// notifications are delivered.
+type EventSubTransport struct {
+	Method   string `json:"method"`
+	Callback string `json:"callback"`
+	Secret   string `json:"secret,omitempty"`
+}
+
+// EventSubStreamEvent wraps the event data of
+// stream.online and stream.offline notifications.
+type EventSubStreamEvent struct {
+	ID                   string `json:"id"`
+	BroadcasterUserID    string `json:"broadcaster_user_id"`
+	BroadcasterUserLogin string `json:"broadcaster_user_login"`
+	BroadcasterUserName  string `json:"broadcaster_user_name"`
+	Type                 string `json:"type"`
+	StartedAt            string `json:"started_at"`
+}
+
+type eventSubMessage struct {
+	Challenge    string               `json:"challenge"`
+	Subscription EventSubSubscription `json:"subscription"`
+	Event        *EventSubStreamEvent `json:"event"`
+}
+
+type subscriptionsDataWrapper struct {
+	Data       []*EventSubSubscription `json:"data"`
+	Pagination struct {
+		Cursor string `json:"cursor"`
+	} `json:"pagination"`
+}
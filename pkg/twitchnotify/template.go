@@ -0,0 +1,94 @@
+package twitchnotify
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/zekroTJA/shinpuru/pkg/stringutil"
+)
+
+const (
+	PlaceholderStreamer = "{streamer}"
+	PlaceholderTitle    = "{title}"
+	PlaceholderGame     = "{game}"
+	PlaceholderURL      = "{url}"
+	PlaceholderViewers  = "{viewers}"
+
+	// DefaultTemplate is used for plain notifications
+	// when no template is set.
+	DefaultTemplate = "**{streamer}** is now live on Twitch playing {game}!\n{url}"
+
+	// MaxTemplateLength is the maximum length of a
+	// notification template.
+	MaxTemplateLength = 1000
+
+	// maxContentLength is the maximum length of the
+	// content of a Discord message.
+	maxContentLength = 2000
+)
+
+// RenderTemplate replaces the placeholders {streamer},
+// {title}, {game}, {url} and {viewers} in the given
+// template with the data of the given stream and user.
+// Unknown placeholders are left unchanged.
+func RenderTemplate(tmpl string, d *Stream, u *User) string {
+	var game string
+	if d.Game != nil {
+		game = d.Game.Name
+	}
+
+	return strings.NewReplacer(
+		PlaceholderStreamer, u.DisplayName,
+		PlaceholderTitle, d.Title,
+		PlaceholderGame, game,
+		PlaceholderURL, fmt.Sprintf("https://twitch.tv/%s", u.LoginName),
+		PlaceholderViewers, strconv.Itoa(d.ViewerCount),
+	).Replace(tmpl)
+}
+
+// GetMessageContent returns the rendered content of the
+// notification message of the given entry including the
+// mention of the configured role. The content is truncated
+// to the maximum length of a Discord message after the
+// placeholders have been replaced.
+func GetMessageContent(entry DBEntry, d *Stream, u *User) string {
+	tmpl := entry.Template
+	if tmpl == "" && entry.Plain {
+		tmpl = DefaultTemplate
+	}
+
+	parts := make([]string, 0, 2)
+	if entry.RoleID != "" {
+		parts = append(parts, fmt.Sprintf("<@&%s>", entry.RoleID))
+	}
+	if tmpl != "" {
+		parts = append(parts, RenderTemplate(tmpl, d, u))
+	}
+
+	return stringutil.Truncate(strings.Join(parts, " "), maxContentLength)
+}
+
+// GetMessage assembles the notification message for
+// the given entry. Only the configured role can be
+// mentioned by the message.
+//
+// Unless the entry is set to plain, the message
+// contains the embed created by GetEmbed.
+func GetMessage(entry DBEntry, d *Stream, u *User) *discordgo.MessageSend {
+	msg := &discordgo.MessageSend{
+		Content:         GetMessageContent(entry, d, u),
+		AllowedMentions: &discordgo.MessageAllowedMentions{},
+	}
+
+	if entry.RoleID != "" {
+		msg.AllowedMentions.Roles = []string{entry.RoleID}
+	}
+
+	if !entry.Plain {
+		msg.Embeds = []*discordgo.MessageEmbed{GetEmbed(d, u)}
+	}
+
+	return msg
+}
@@ -0,0 +1,84 @@
+package twitchnotify
+
+import (
+	"strings"
+	"testing"
+)
+
+var (
+	testStream = &Stream{
+		Title:       "Coding shinpuru",
+		ViewerCount: 42,
+		Game:        &Game{Name: "Science & Technology"},
+	}
+	testUser = &User{
+		DisplayName: "zekro",
+		LoginName:   "zekrotja",
+	}
+)
+
+func TestRenderTemplate(t *testing.T) {
+	res := RenderTemplate("{streamer} - {title} ({game}) {viewers} {url} {unknown} {streamer}",
+		testStream, testUser)
+
+	exp := "zekro - Coding shinpuru (Science & Technology) 42 https://twitch.tv/zekrotja {unknown} zekro"
+	if res != exp {
+		t.Errorf("unexpected result: '%s'", res)
+	}
+
+	if res = RenderTemplate("{game}", &Stream{}, testUser); res != "" {
+		t.Errorf("unexpected result without game: '%s'", res)
+	}
+}
+
+func TestGetMessageContent(t *testing.T) {
+	cases := []struct {
+		entry DBEntry
+		exp   string
+	}{
+		{DBEntry{}, ""},
+		{DBEntry{RoleID: "1234"}, "<@&1234>"},
+		{DBEntry{Template: "{streamer} is live"}, "zekro is live"},
+		{DBEntry{Template: "{streamer} is live", RoleID: "1234"}, "<@&1234> zekro is live"},
+		{DBEntry{Plain: true}, RenderTemplate(DefaultTemplate, testStream, testUser)},
+	}
+
+	for _, c := range cases {
+		if res := GetMessageContent(c.entry, testStream, testUser); res != c.exp {
+			t.Errorf("unexpected content for %+v: '%s'", c.entry, res)
+		}
+	}
+}
+
+func TestGetMessageContentTruncated(t *testing.T) {
+	// The template is within the template length limit
+	// but exceeds the message length limit after the
+	// placeholders have been rendered.
+	user := &User{DisplayName: strings.Repeat("a", 100)}
+	entry := DBEntry{
+		Template: strings.Repeat("{streamer}", MaxTemplateLength/len(PlaceholderStreamer)),
+		RoleID:   "1234",
+	}
+
+	res := GetMessageContent(entry, testStream, user)
+	if n := len([]rune(res)); n != maxContentLength {
+		t.Errorf("unexpected content length: %d", n)
+	}
+	if !strings.HasPrefix(res, "<@&1234> ") {
+		t.Errorf("role mention has been truncated: '%s'", res[:20])
+	}
+}
+
+func TestGetMessagePlain(t *testing.T) {
+	msg := GetMessage(DBEntry{Plain: true, RoleID: "1234"}, testStream, testUser)
+
+	if len(msg.Embeds) != 0 {
+		t.Error("plain message contains embeds")
+	}
+	if len(msg.AllowedMentions.Parse) != 0 {
+		t.Errorf("unexpected allowed mentions: %+v", msg.AllowedMentions.Parse)
+	}
+	if len(msg.AllowedMentions.Roles) != 1 || msg.AllowedMentions.Roles[0] != "1234" {
+		t.Errorf("unexpected allowed roles: %+v", msg.AllowedMentions.Roles)
+	}
+}
@@ -1,7 +1,7 @@
 // Package twitchnotify provides functionalities
 // to watch the state of twitch streams and
-// notifying changes by polling the twitch REST
-// API.
+// notifying changes either by polling the twitch
+// REST API or by receiving EventSub webhooks.
 package twitchnotify
 
 import (
@@ -15,6 +15,7 @@ import (
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/generaltso/vibrant"
+	"github.com/valyala/fasthttp"
 	"github.com/zekroTJA/shinpuru/pkg/httpreq"
 	"github.com/zekroTJA/shinpuru/pkg/multierror"
 )
@@ -32,15 +33,27 @@ const (
 	// clockDuration = 30 * time.Second
 	clockDuration = 60 * time.Second
 
+	bearerTokenAttempts = 3
+)
+
+// The endpoints of the twitch API can be replaced
+// in tests.
+var (
 	oAuth2Endpoint = "https://id.twitch.tv/oauth2/token"
 	helixEndpoint  = "https://api.twitch.tv/helix"
 )
 
+// bearerTokenRetryDelay is the delay between attempts
+// to retrieve a bearer token. It can be replaced in
+// tests.
+var bearerTokenRetryDelay = 2 * time.Second
+
 var (
-	ErrUserNotFound        = errors.New("user not found")
-	ErrGameNotFound        = errors.New("game not found")
-	ErrInvalidResponseType = errors.New("invalid response type")
-	ErrMaxUsersReached     = errors.New("max registered users reached")
+	ErrUserNotFound          = errors.New("user not found")
+	ErrGameNotFound          = errors.New("game not found")
+	ErrInvalidResponseType   = errors.New("invalid response type")
+	ErrMaxUsersReached       = errors.New("max registered users reached")
+	ErrInvalidEventSubSecret = errors.New("eventsub secret must be between 10 and 100 characters long")
 )
 
 // NotifyHandler describes a callback handler when a
@@ -60,10 +73,12 @@ type Credentials struct {
 // callbacks when a stream goes online or offline.
 type NotifyWorker struct {
 	creds              *Credentials
+	conf               Config
 	wentOnlineHandler  NotifyHandler
 	wentOfflineHandler NotifyHandler
 
 	mx        *sync.Mutex
+	usersMx   *sync.RWMutex
 	timer     *time.Ticker
 	users     map[string]*User
 	wereLive  []*Stream
@@ -83,12 +98,20 @@ func New(
 ) (worker *NotifyWorker, err error) {
 	conf := defaultConfig(config)
 
+	if conf.EventSubCallback != "" &&
+		(len(conf.EventSubSecret) < 10 || len(conf.EventSubSecret) > 100) {
+		err = ErrInvalidEventSubSecret
+		return
+	}
+
 	worker = &NotifyWorker{
 		creds:              &creds,
+		conf:               conf,
 		wentOfflineHandler: wentOfflineHandler,
 		wentOnlineHandler:  wentOnlineHandler,
 
 		mx:        &sync.Mutex{},
+		usersMx:   &sync.RWMutex{},
 		users:     make(map[string]*User),
 		wereLive:  make([]*Stream, 0),
 		gameCache: make(map[string]*Game),
@@ -134,6 +157,9 @@ func (w *NotifyWorker) GetUser(identifyer string, typ UserIdent) (*User, error)
 // list. If maxUserCap is reached, an ErrMaxUsersreached
 // error is returned.
 func (w *NotifyWorker) AddUser(u *User) error {
+	w.usersMx.Lock()
+	defer w.usersMx.Unlock()
+
 	if len(w.users) >= maxUserCap {
 		return ErrMaxUsersReached
 	}
@@ -143,6 +169,63 @@ func (w *NotifyWorker) AddUser(u *User) error {
 	return nil
 }
 
+// RemoveUser removes the twitch user with the given
+// ID from the watch list. If EventSub is used, the
+// subscriptions of the user are deleted as well.
+func (w *NotifyWorker) RemoveUser(userID string) error {
+	w.usersMx.Lock()
+	delete(w.users, userID)
+	w.usersMx.Unlock()
+
+	if !w.UsesEventSub() {
+		return nil
+	}
+
+	return w.Unsubscribe(userID)
+}
+
+// WatchedUser returns the watched user by the given
+// ID or nil if the user is not watched.
+func (w *NotifyWorker) WatchedUser(userID string) *User {
+	return w.user(userID)
+}
+
+// IsLive returns true if a stream of the watched user
+// with the given ID is currently known to be live.
+func (w *NotifyWorker) IsLive(userID string) bool {
+	w.mx.Lock()
+	defer w.mx.Unlock()
+
+	for _, s := range w.wereLive {
+		if s.UserID == userID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// user returns the watched user by the given ID
+// or nil if the user is not watched.
+func (w *NotifyWorker) user(userID string) *User {
+	w.usersMx.RLock()
+	defer w.usersMx.RUnlock()
+	return w.users[userID]
+}
+
+// userIDs returns the IDs of all watched users.
+func (w *NotifyWorker) userIDs() []string {
+	w.usersMx.RLock()
+	defer w.usersMx.RUnlock()
+
+	userIDs := make([]string, 0, len(w.users))
+	for k := range w.users {
+		userIDs = append(userIDs, k)
+	}
+
+	return userIDs
+}
+
 // GetEmbed assembles and returns an embed reference
 // from the given Stream and User objects.
 func GetEmbed(d *Stream, u *User) *discordgo.MessageEmbed {
@@ -182,25 +265,53 @@ func GetEmbed(d *Stream, u *User) *discordgo.MessageEmbed {
 // getBearerToken tries to authenticate with the configured
 // twitch app credentials and retrieves a bearer token which
 // is then used for further request authentication.
-func (w *NotifyWorker) getBearerToken() error {
+//
+// When the credentials are rejected, an *AuthError is
+// returned. Other failures are retried a few times before
+// the last error is returned.
+func (w *NotifyWorker) getBearerToken() (err error) {
+	for i := 0; i < bearerTokenAttempts; i++ {
+		if i > 0 {
+			time.Sleep(bearerTokenRetryDelay)
+		}
+		var retry bool
+		if retry, err = w.tryGetBearerToken(); !retry {
+			return
+		}
+	}
+	return
+}
+
+// tryGetBearerToken executes a single token request. The
+// returned bool indicates whether the request failed
+// temporarily and should be retried.
+func (w *NotifyWorker) tryGetBearerToken() (retry bool, err error) {
 	url := fmt.Sprintf("%s?client_id=%s&client_secret=%s&grant_type=client_credentials",
 		oAuth2Endpoint, w.creds.ClientID, w.creds.ClientSecret)
 
 	res, err := httpreq.Post(url, nil, nil)
 	if err != nil {
-		return err
+		return true, err
 	}
 	defer res.Release()
 
+	switch status := res.StatusCode(); status {
+	case fasthttp.StatusOK:
+	case fasthttp.StatusBadRequest, fasthttp.StatusUnauthorized, fasthttp.StatusForbidden:
+		return false, newAuthError(res)
+	default:
+		return true, fmt.Errorf("twitch API responded with status %d", status)
+	}
+
 	var token bearerTokenResponse
 	if err = res.JSON(&token); err != nil {
-		return err
+		return false, err
 	}
 
 	w.bearerToken = token.AccessToken
 	w.bearerValid = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
 
-	return nil
+	return false, nil
 }
 
 // doAuthenticatedGet executes a GET request to the twitch API
@@ -210,34 +321,69 @@ func (w *NotifyWorker) getBearerToken() error {
 // The request result will be put in the passed data reference
 // and errors occured are returned.
 func (w *NotifyWorker) doAuthenticatedGet(url string, data interface{}) (err error) {
+	_, err = w.doAuthenticated("GET", url, nil, data)
+	return
+}
+
+// doAuthenticated executes a request with the given method
+// to the twitch API like doAuthenticatedGet. The passed body
+// is serialized as JSON. When data is nil, the response body
+// is not parsed. The status code of the response is returned.
+func (w *NotifyWorker) doAuthenticated(method, url string, body, data interface{}) (status int, err error) {
+	res, err := w.request(method, url, body)
+	if err != nil {
+		return
+	}
+	defer res.Release()
+
+	status = res.StatusCode()
+	if data != nil {
+		err = res.JSON(data)
+	}
+
+	return
+}
+
+// request executes a request with the given method to the
+// twitch API authenticated with the bearer token. If the
+// token is unset or has expired, a new token is retrieved
+// before. When the token is rejected by the API, it is
+// refreshed and the request is retried once.
+//
+// The returned response must be released by the caller.
+func (w *NotifyWorker) request(method, url string, body interface{}) (res *httpreq.Response, err error) {
 	if w.bearerToken == "" || time.Now().After(w.bearerValid) {
 		if err = w.getBearerToken(); err != nil {
 			return
 		}
 	}
 
-	res, err := httpreq.Get(url, map[string]string{
-		"Authorization": fmt.Sprintf("Bearer %s", w.bearerToken),
-		"Client-ID":     w.creds.ClientID,
-	})
-	if err != nil {
+	res, err = w.requestWithToken(method, url, body)
+	if err != nil || res.StatusCode() != fasthttp.StatusUnauthorized {
 		return
 	}
-	defer res.Release()
 
-	err = res.JSON(data)
+	res.Release()
+	if err = w.getBearerToken(); err != nil {
+		return nil, err
+	}
 
-	return
+	return w.requestWithToken(method, url, body)
 }
 
-func (w *NotifyWorker) getStreams() ([]*Stream, error) {
-	userIDs := make([]string, len(w.users))
-	var i int
-	for k := range w.users {
-		userIDs[i] = k
-		i++
+func (w *NotifyWorker) requestWithToken(method, url string, body interface{}) (*httpreq.Response, error) {
+	headers := map[string]string{
+		"Authorization": fmt.Sprintf("Bearer %s", w.bearerToken),
+		"Client-ID":     w.creds.ClientID,
 	}
+	if body != nil {
+		headers["Content-Type"] = "application/json"
+	}
+
+	return httpreq.Request(method, url, headers, body)
+}
 
+func (w *NotifyWorker) getStreams(userIDs []string) ([]*Stream, error) {
 	url := fmt.Sprintf("%s/streams?user_id=%s",
 		helixEndpoint, strings.Join(userIDs, "&user_id="))
 
@@ -279,14 +425,33 @@ func (w *NotifyWorker) getGame(gameID string) (*Game, error) {
 	return game, nil
 }
 
+// hydrateStream sets the game of the stream and
+// the size of the thumbnail URL.
+func (w *NotifyWorker) hydrateStream(stream *Stream) (err error) {
+	stream.Game, err = w.getGame(stream.GameID)
+	if err != nil {
+		stream.Game = &Game{}
+	}
+	stream.ThumbnailURL = strings.Replace(stream.ThumbnailURL, "{width}x{height}", "1280x720", 1)
+	return
+}
+
 // Handle is the callback function executed on ech timer tick.
+//
+// When EventSub is used, stream state changes are pushed
+// via HandleEventSub and Handle is a no-op.
 func (w *NotifyWorker) Handle() error {
-	if len(w.users) < 1 {
+	if w.UsesEventSub() {
+		return nil
+	}
+
+	userIDs := w.userIDs()
+	if len(userIDs) < 1 {
 		return nil
 	}
 
 	// Request watched streams which are currently live.
-	streams, err := w.getStreams()
+	streams, err := w.getStreams(userIDs)
 	if err != nil {
 		return err
 	}
@@ -310,13 +475,12 @@ func (w *NotifyWorker) Handle() error {
 			continue
 		}
 
-		game, err := w.getGame(stream.GameID)
-		mErr.Append(err)
-
-		stream.Game = game
-		stream.ThumbnailURL = strings.Replace(stream.ThumbnailURL, "{width}x{height}", "1280x720", 1)
+		user := w.user(stream.UserID)
+		if user == nil {
+			continue
+		}
 
-		user := w.users[stream.UserID]
+		mErr.Append(w.hydrateStream(stream))
 
 		w.wentOnlineHandler(stream, user)
 	}
@@ -336,8 +500,9 @@ func (w *NotifyWorker) Handle() error {
 		}
 
 		if !stillOnline {
-			user := w.users[nd.UserID]
-			w.wentOfflineHandler(nd, user)
+			if user := w.user(nd.UserID); user != nil {
+				w.wentOfflineHandler(nd, user)
+			}
 		}
 	}
 
@@ -0,0 +1,98 @@
+// Package validation provides a common way for models
+// to report invalid values of their fields.
+package validation
+
+import (
+	"errors"
+	"strings"
+)
+
+// Validator describes a model which can validate
+// its own field values.
+type Validator interface {
+	// Validate returns an error when any field of
+	// the model holds an invalid value. Field specific
+	// errors should be returned as Errors.
+	Validate() error
+}
+
+// FieldError describes an invalid value of a
+// single field.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+func (e FieldError) Error() string {
+	if e.Field == "" {
+		return e.Message
+	}
+	return e.Field + ": " + e.Message
+}
+
+// Errors collects the field errors of a model.
+type Errors []FieldError
+
+var _ error = (Errors)(nil)
+
+// Add adds a field error with the given message.
+func (e *Errors) Add(field, message string) {
+	*e = append(*e, FieldError{Field: field, Message: message})
+}
+
+// Check adds a field error with the message of err
+// if err is not nil.
+func (e *Errors) Check(field string, err error) {
+	if err != nil {
+		e.Add(field, err.Error())
+	}
+}
+
+// Assert adds a field error with the given message
+// if ok is false.
+func (e *Errors) Assert(ok bool, field, message string) {
+	if !ok {
+		e.Add(field, message)
+	}
+}
+
+// Err returns nil if no field errors have been
+// added. Otherwise, the Errors are returned.
+func (e Errors) Err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+func (e Errors) Error() string {
+	msgs := make([]string, len(e))
+	for i, fe := range e {
+		msgs[i] = fe.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Validate calls Validate on v if it implements
+// Validator. Otherwise, nil is returned.
+func Validate(v any) error {
+	if validator, ok := v.(Validator); ok {
+		return validator.Validate()
+	}
+	return nil
+}
+
+// FieldErrors returns the field errors contained in
+// err. If err does not contain Errors or a FieldError,
+// nil is returned.
+func FieldErrors(err error) Errors {
+	var errs Errors
+	if errors.As(err, &errs) {
+		return errs
+	}
+	var fe FieldError
+	if errors.As(err, &fe) {
+		return Errors{fe}
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type model struct {
+	Name string
+	Age  int
+}
+
+func (m *model) Validate() error {
+	var errs Errors
+	errs.Assert(m.Name != "", "name", "must not be empty")
+	errs.Assert(m.Age >= 0, "age", "must not be negative")
+	return errs.Err()
+}
+
+func TestErrors(t *testing.T) {
+	var errs Errors
+	assert.Nil(t, errs.Err())
+
+	errs.Check("a", nil)
+	errs.Assert(true, "b", "never")
+	assert.Nil(t, errs.Err())
+
+	errs.Check("a", errors.New("invalid"))
+	errs.Assert(false, "b", "too long")
+	errs.Add("", "general")
+
+	err := errs.Err()
+	assert.Equal(t, "a: invalid; b: too long; general", err.Error())
+	assert.Equal(t, Errors{
+		{Field: "a", Message: "invalid"},
+		{Field: "b", Message: "too long"},
+		{Field: "", Message: "general"},
+	}, FieldErrors(err))
+}
+
+func TestValidate(t *testing.T) {
+	assert.Nil(t, Validate(&model{Name: "a"}))
+	assert.Nil(t, Validate(struct{}{}))
+
+	err := Validate(&model{Age: -1})
+	assert.Equal(t, Errors{
+		{Field: "name", Message: "must not be empty"},
+		{Field: "age", Message: "must not be negative"},
+	}, FieldErrors(err))
+}
+
+func TestFieldErrors(t *testing.T) {
+	assert.Nil(t, FieldErrors(nil))
+	assert.Nil(t, FieldErrors(errors.New("some error")))
+
+	err := fmt.Errorf("wrapped: %w", FieldError{Field: "a", Message: "invalid"})
+	assert.Equal(t, Errors{{Field: "a", Message: "invalid"}}, FieldErrors(err))
+}
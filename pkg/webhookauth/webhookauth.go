@@ -0,0 +1,118 @@
+// Package webhookauth provides HMAC signature
+// verification for inbound webhook payloads.
+package webhookauth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+)
+
+const (
+	// HeaderGitHub is the signature header sent
+	// with GitHub webhook payloads.
+	HeaderGitHub = "X-Hub-Signature-256"
+
+	// HeaderTwitchSignature is the signature header
+	// sent with Twitch EventSub payloads.
+	HeaderTwitchSignature = "Twitch-Eventsub-Message-Signature"
+	// HeaderTwitchMessageID is the header containing
+	// the ID of a Twitch EventSub message.
+	HeaderTwitchMessageID = "Twitch-Eventsub-Message-Id"
+	// HeaderTwitchTimestamp is the header containing
+	// the timestamp of a Twitch EventSub message.
+	HeaderTwitchTimestamp = "Twitch-Eventsub-Message-Timestamp"
+
+	// MaxTwitchMessageAge is the maximum age of Twitch
+	// EventSub messages. Older messages are rejected
+	// to prevent replay attacks.
+	MaxTwitchMessageAge = 10 * time.Minute
+)
+
+var (
+	// ErrNoSecret is returned when no secret has been
+	// passed for verification.
+	ErrNoSecret = errors.New("no secret specified")
+	// ErrMissingSignature is returned when the signature
+	// header is empty.
+	ErrMissingSignature = errors.New("missing signature")
+	// ErrUnsupportedScheme is returned when the signature
+	// is prefixed with an unsupported hash algorithm.
+	ErrUnsupportedScheme = errors.New("unsupported signature scheme")
+	// ErrInvalidSignature is returned when the signature
+	// does not match the payload.
+	ErrInvalidSignature = errors.New("invalid signature")
+	// ErrExpired is returned when the timestamp of a
+	// message is invalid or too old.
+	ErrExpired = errors.New("message expired")
+)
+
+// Verify checks if the passed signature header value is
+// a valid HMAC signature of body generated with secret.
+//
+// The signature must be in the format `<algorithm>=<hex>`,
+// which is used by GitHub (`sha256=...`) and Twitch
+// EventSub. Only sha256 is supported.
+//
+// The signatures are compared in constant time.
+func Verify(secret, signatureHeader string, body []byte) error {
+	if secret == "" {
+		return ErrNoSecret
+	}
+	if signatureHeader == "" {
+		return ErrMissingSignature
+	}
+
+	i := strings.IndexRune(signatureHeader, '=')
+	if i < 0 {
+		return ErrUnsupportedScheme
+	}
+
+	if !strings.EqualFold(signatureHeader[:i], "sha256") {
+		return ErrUnsupportedScheme
+	}
+
+	signature, err := hex.DecodeString(signatureHeader[i+1:])
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	if !hmac.Equal(mac.Sum(nil), signature) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+// VerifyTwitch checks the signature of a Twitch EventSub
+// message. The signed payload is the concatenation of
+// the message ID, the message timestamp and the body.
+func VerifyTwitch(secret, messageID, timestamp, signatureHeader string, body []byte) error {
+	payload := make([]byte, 0, len(messageID)+len(timestamp)+len(body))
+	payload = append(payload, messageID...)
+	payload = append(payload, timestamp...)
+	payload = append(payload, body...)
+	return Verify(secret, signatureHeader, payload)
+}
+
+// CheckTimestamp returns ErrExpired if the passed RFC3339
+// timestamp is invalid or more than maxAge before or
+// after now.
+func CheckTimestamp(timestamp string, now time.Time, maxAge time.Duration) error {
+	t, err := time.Parse(time.RFC3339Nano, timestamp)
+	if err != nil {
+		return ErrExpired
+	}
+
+	if d := now.Sub(t); d > maxAge || d < -maxAge {
+		return ErrExpired
+	}
+
+	return nil
+}
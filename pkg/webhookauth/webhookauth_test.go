@@ -0,0 +1,109 @@
+package webhookauth
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"testing"
+	"time"
+)
+
+const secret = "It's a Secret to Everybody"
+
+func sign(h func() hash.Hash, prefix string, payload []byte) string {
+	mac := hmac.New(h, []byte(secret))
+	mac.Write(payload)
+	return prefix + "=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerifyGitHub(t *testing.T) {
+	body := []byte("Hello, World!")
+
+	// Example taken from the GitHub webhook documentation.
+	sig := "sha256=757107ea0eb2509fc211221cce984b8a37570b6d7586c22c46f4379c8b043e17"
+	if err := Verify(secret, sig, body); err != nil {
+		t.Errorf("valid signature was rejected: %s", err)
+	}
+
+	if err := Verify(secret, sig, []byte("Hello, World?")); err != ErrInvalidSignature {
+		t.Errorf("tampered body was not rejected: %v", err)
+	}
+
+	if err := Verify("wrong secret", sig, body); err != ErrInvalidSignature {
+		t.Errorf("wrong secret was not rejected: %v", err)
+	}
+
+	if err := Verify(secret, sign(sha1.New, "sha1", body), body); err != ErrUnsupportedScheme {
+		t.Errorf("sha1 signature was not rejected: %v", err)
+	}
+}
+
+func TestVerifyTwitch(t *testing.T) {
+	const (
+		msgID     = "e76c6bd4-55c9-4987-8304-da1588d8988b"
+		timestamp = "2019-11-16T10:11:12.634234626Z"
+	)
+	body := []byte(`{"subscription":{"type":"stream.online"}}`)
+
+	sig := sign(sha256.New, "sha256", append([]byte(msgID+timestamp), body...))
+	if err := VerifyTwitch(secret, msgID, timestamp, sig, body); err != nil {
+		t.Errorf("valid signature was rejected: %s", err)
+	}
+
+	if err := VerifyTwitch(secret, msgID, timestamp, sig,
+		[]byte(`{"subscription":{"type":"stream.offline"}}`)); err != ErrInvalidSignature {
+		t.Errorf("tampered body was not rejected: %v", err)
+	}
+
+	if err := VerifyTwitch(secret, "other-id", timestamp, sig, body); err != ErrInvalidSignature {
+		t.Errorf("tampered message ID was not rejected: %v", err)
+	}
+
+	if err := VerifyTwitch(secret, msgID, "2019-11-16T10:11:13Z", sig, body); err != ErrInvalidSignature {
+		t.Errorf("tampered timestamp was not rejected: %v", err)
+	}
+}
+
+func TestCheckTimestamp(t *testing.T) {
+	now := time.Date(2019, 11, 16, 10, 11, 12, 0, time.UTC)
+
+	if err := CheckTimestamp("2019-11-16T10:05:12.634234626Z", now, MaxTwitchMessageAge); err != nil {
+		t.Errorf("valid timestamp was rejected: %s", err)
+	}
+
+	if err := CheckTimestamp("2019-11-16T10:00:12Z", now, MaxTwitchMessageAge); err != ErrExpired {
+		t.Errorf("old timestamp was not rejected: %v", err)
+	}
+
+	if err := CheckTimestamp("2019-11-16T10:22:12Z", now, MaxTwitchMessageAge); err != ErrExpired {
+		t.Errorf("future timestamp was not rejected: %v", err)
+	}
+
+	if err := CheckTimestamp("invalid", now, MaxTwitchMessageAge); err != ErrExpired {
+		t.Errorf("invalid timestamp was not rejected: %v", err)
+	}
+}
+
+func TestVerifyInvalid(t *testing.T) {
+	body := []byte("payload")
+
+	cases := []struct {
+		secret, signature string
+		expected          error
+	}{
+		{"", sign(sha256.New, "sha256", body), ErrNoSecret},
+		{secret, "", ErrMissingSignature},
+		{secret, "abcdef", ErrUnsupportedScheme},
+		{secret, "md5=abcdef", ErrUnsupportedScheme},
+		{secret, "sha256=not-hex", ErrInvalidSignature},
+		{secret, "sha256=abcdef", ErrInvalidSignature},
+	}
+
+	for _, c := range cases {
+		if err := Verify(c.secret, c.signature, body); err != c.expected {
+			t.Errorf("signature '%s': expected %v, got %v", c.signature, c.expected, err)
+		}
+	}
+}
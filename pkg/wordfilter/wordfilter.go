@@ -0,0 +1,121 @@
+// Package wordfilter provides matching of texts against
+// a list of banned words or phrases which is resistant
+// against common character substitutions.
+package wordfilter
+
+import (
+	"regexp"
+	"strings"
+)
+
+const (
+	boundary = `(?:^|[^\p{L}\p{N}])`
+	boundEnd = `(?:[^\p{L}\p{N}]|$)`
+	wildcard = `[\p{L}\p{N}]*`
+
+	trailingPunct = "!?.,:;"
+)
+
+// leetReplacer maps commonly used substitute
+// characters to the letters they resemble.
+var leetReplacer = strings.NewReplacer(
+	"0", "o",
+	"1", "i",
+	"3", "e",
+	"4", "a",
+	"5", "s",
+	"7", "t",
+	"8", "b",
+	"@", "a",
+	"$", "s",
+	"!", "i",
+	"+", "t",
+)
+
+// Pattern is a banned word or phrase. An asterisk
+// (*) in the pattern matches any amount of letters
+// or digits.
+type Pattern struct {
+	Pattern string
+	// WholeWord only matches the pattern if it is not
+	// surrounded by other letters or digits. Otherwise,
+	// the pattern also matches parts of words.
+	WholeWord bool
+}
+
+type compiled struct {
+	pattern string
+	rx      *regexp.Regexp
+}
+
+// Filter matches texts against a set of patterns.
+type Filter struct {
+	patterns []compiled
+}
+
+// Normalize returns the lower case representation of
+// s where common substitute characters are replaced
+// with the letters they resemble.
+//
+// Trailing punctuation of each word is removed before
+// so that, for example, an exclamation mark at the end
+// of a word is not taken as substitute for an 'i'.
+func Normalize(s string) string {
+	words := strings.Fields(strings.ToLower(s))
+	for i, w := range words {
+		words[i] = leetReplacer.Replace(strings.TrimRight(w, trailingPunct))
+	}
+	return strings.Join(words, " ")
+}
+
+// New compiles the given patterns to a Filter.
+//
+// Empty patterns are skipped.
+func New(patterns ...Pattern) (*Filter, error) {
+	f := &Filter{
+		patterns: make([]compiled, 0, len(patterns)),
+	}
+
+	for _, p := range patterns {
+		if strings.Trim(p.Pattern, "* ") == "" {
+			continue
+		}
+
+		parts := strings.Split(Normalize(p.Pattern), "*")
+		for i, part := range parts {
+			parts[i] = regexp.QuoteMeta(part)
+		}
+		expr := strings.Join(parts, wildcard)
+		if p.WholeWord {
+			expr = boundary + expr + boundEnd
+		}
+
+		rx, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, err
+		}
+
+		f.patterns = append(f.patterns, compiled{p.Pattern, rx})
+	}
+
+	return f, nil
+}
+
+// Match checks if the given text matches any of the
+// patterns of the filter and returns the first matching
+// pattern.
+//
+// The text is checked both in its lower case and its
+// normalized representation.
+func (f *Filter) Match(text string) (pattern string, ok bool) {
+	lower := strings.ToLower(text)
+	normalized := Normalize(text)
+
+	for _, p := range f.patterns {
+		if p.rx.MatchString(lower) || p.rx.MatchString(normalized) {
+			return p.pattern, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,84 @@
+package wordfilter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	assert.Equal(t, "badword", Normalize("B@dW0rd"))
+	assert.Equal(t, "test", Normalize("7E$7"))
+}
+
+func TestMatchSubstring(t *testing.T) {
+	f, err := New(Pattern{Pattern: "bad"})
+	assert.Nil(t, err)
+
+	p, ok := f.Match("this is bad")
+	assert.True(t, ok)
+	assert.Equal(t, "bad", p)
+
+	_, ok = f.Match("badminton")
+	assert.True(t, ok)
+
+	_, ok = f.Match("B@D")
+	assert.True(t, ok)
+
+	_, ok = f.Match("this is fine")
+	assert.False(t, ok)
+}
+
+func TestMatchWholeWord(t *testing.T) {
+	f, err := New(Pattern{Pattern: "ass", WholeWord: true})
+	assert.Nil(t, err)
+
+	_, ok := f.Match("a classic example")
+	assert.False(t, ok)
+
+	_, ok = f.Match("don't be an ass!")
+	assert.True(t, ok)
+
+	_, ok = f.Match("@$$")
+	assert.True(t, ok)
+
+	_, ok = f.Match("ass")
+	assert.True(t, ok)
+}
+
+func TestMatchWildcard(t *testing.T) {
+	f, err := New(
+		Pattern{Pattern: "bad*", WholeWord: true},
+		Pattern{Pattern: "*"},
+	)
+	assert.Nil(t, err)
+
+	p, ok := f.Match("you are baddest")
+	assert.True(t, ok)
+	assert.Equal(t, "bad*", p)
+
+	_, ok = f.Match("you are not abad")
+	assert.False(t, ok)
+}
+
+func TestMatchSpecialCharacters(t *testing.T) {
+	f, err := New(Pattern{Pattern: "a.b"})
+	assert.Nil(t, err)
+
+	_, ok := f.Match("a.b")
+	assert.True(t, ok)
+
+	_, ok = f.Match("axb")
+	assert.False(t, ok)
+}
+
+func TestMatchTrailingPunctuation(t *testing.T) {
+	f, err := New(Pattern{Pattern: "bad", WholeWord: true})
+	assert.Nil(t, err)
+
+	_, ok := f.Match("this is b@d!")
+	assert.True(t, ok)
+
+	_, ok = f.Match("bad!!")
+	assert.True(t, ok)
+}
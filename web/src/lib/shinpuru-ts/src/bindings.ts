@@ -1,21 +1,32 @@
 import {
   APIToken,
+  APITokenUsageEntry,
   AccessTokenModel,
   AntiraidAction,
   AntiraidSettings,
+  AutoPublishConfig,
+  AutoThreadConfig,
+  BotNicknameSettings,
   Channel,
   CodeExecSettings,
   CodeResponse,
   CommandInfo,
+  ConfigBundle,
+  ConfigBundleImportResult,
   Count,
+  EffectiveGuildSettings,
   Guild,
   GuildBackup,
+  GuildBackupDiff,
+  GuildBackupSchedule,
   GuildScoreboardEntry,
   GuildSettings,
   GuildSettingsApi,
   GuildStarboardEntry,
+  InviteAnalytics,
   InviteSettingsRequest,
   InviteSettingsResponse,
+  InviteJoinStats,
   JoinlogEntry,
   KarmaRule,
   KarmaSettings,
@@ -23,20 +34,38 @@ import {
   ListResponse,
   Member,
   MessageEmbed,
+  MessagePreview,
+  MessageStats,
+  ModLogAction,
+  ModLogRoutes,
+  NotificationSubscription,
   PermissionResponse,
   PermissionsMap,
+  PermissionsPreview,
+  PermissionsPreviewRequest,
   PermissionsUpdate,
   Presence,
   PrivacyInfo,
   ReasonRequest,
   Report,
+  ReportDetails,
   ReportRequest,
   SearchResult,
+  SnowflakeInfo,
   StarboardSortOrder,
   State,
+  StickyMessage,
   SystemInfo,
+  TempRole,
+  TempRoleRequest,
+  TwitchCredentialsTestResult,
+  TwitchNotifyPreview,
+  TwitchNotifyPreviewRequest,
   UnbanRequest,
   UserSettingsOTA,
+  UserGuild,
+  UserNote,
+  UserNoteRequest,
   UserSettingsPrivacy,
   VerificationSiteKey,
 } from './models';
@@ -54,6 +83,10 @@ export class EtcClient extends SubClient {
     return this.req('GET', 'me');
   }
 
+  meGuilds(moderatable: boolean = false): Promise<ListResponse<UserGuild>> {
+    return this.req('GET', `me/guilds?moderatable=${moderatable}`);
+  }
+
   privacyInfo(): Promise<PrivacyInfo> {
     return this.req('GET', 'privacyinfo');
   }
@@ -87,6 +120,10 @@ export class UtilClient extends SubClient {
   slashcommands(): Promise<ListResponse<CommandInfo>> {
     return this.req('GET', 'slashcommands');
   }
+
+  snowflake(id: string): Promise<SnowflakeInfo> {
+    return this.req('GET', `snowflake/${id}`);
+  }
 }
 
 export class AuthClient extends SubClient {
@@ -137,6 +174,10 @@ export class TokensClient extends SubClient {
   generate(): Promise<APIToken> {
     return this.req('POST', `/`);
   }
+
+  usage(limit = 0, offset = 0): Promise<ListResponse<APITokenUsageEntry>> {
+    return this.req('GET', `usage?limit=${limit}&offset=${offset}`);
+  }
 }
 
 export class GlobalSettingsClient extends SubClient {
@@ -159,6 +200,10 @@ export class GlobalSettingsClient extends SubClient {
   setPresence(presence: Presence): Promise<Presence> {
     return this.req('POST', 'presence', presence);
   }
+
+  testTwitchCredentials(): Promise<TwitchCredentialsTestResult> {
+    return this.req('POST', 'twitch/test');
+  }
 }
 
 export class ReportsClient extends SubClient {
@@ -215,6 +260,30 @@ export class GuildMemberClient extends SubClient {
   report(reason: ReportRequest): Promise<Report> {
     return this.req('POST', 'reports', reason);
   }
+
+  tempRoles(): Promise<ListResponse<TempRole>> {
+    return this.req('GET', 'temproles');
+  }
+
+  addTempRole(req: TempRoleRequest): Promise<TempRole> {
+    return this.req('POST', 'temproles', req);
+  }
+
+  cancelTempRole(id: string): Promise<CodeResponse> {
+    return this.req('DELETE', `temproles/${id}`);
+  }
+
+  notes(): Promise<ListResponse<UserNote>> {
+    return this.req('GET', 'notes');
+  }
+
+  addNote(req: UserNoteRequest): Promise<UserNote> {
+    return this.req('POST', 'notes', req);
+  }
+
+  deleteNote(id: string): Promise<CodeResponse> {
+    return this.req('DELETE', `notes/${id}`);
+  }
 }
 
 export class GuildsClient extends SubClient {
@@ -238,6 +307,26 @@ export class GuildsClient extends SubClient {
     return this.req('DELETE', `${id}/antiraid/joinlog`);
   }
 
+  inviteStats(id: string): Promise<ListResponse<InviteJoinStats>> {
+    return this.req('GET', `${id}/invites/stats`);
+  }
+
+  inviteAnalytics(id: string): Promise<InviteAnalytics> {
+    return this.req('GET', `${id}/invites/analytics`);
+  }
+
+  messageStats(id: string, days = 7): Promise<MessageStats> {
+    return this.req('GET', `${id}/messagestats?days=${days}`);
+  }
+
+  notifications(id: string): Promise<ListResponse<NotificationSubscription>> {
+    return this.req('GET', `${id}/notifications`);
+  }
+
+  removeNotification(id: string, twitchUserID: string): Promise<CodeResponse> {
+    return this.req('DELETE', `${id}/notifications/${twitchUserID}`);
+  }
+
   setInviteBlock(id: string, enabled: boolean): Promise<ListResponse<JoinlogEntry>> {
     return this.req('POST', `${id}/inviteblock`, { enabled });
   }
@@ -250,6 +339,10 @@ export class GuildsClient extends SubClient {
     return this.req('POST', `${id}/permissions`, update);
   }
 
+  previewPermissions(id: string, request: PermissionsPreviewRequest): Promise<PermissionsPreview> {
+    return this.req('POST', `${id}/permissions/preview`, request);
+  }
+
   reports(id: string, limit: number = 20, offset: number = 0): Promise<ListResponse<Report>> {
     return this.req('GET', `${id}/reports?limit=${limit}&offset=${offset}`);
   }
@@ -258,10 +351,52 @@ export class GuildsClient extends SubClient {
     return this.req('GET', `${id}/reports/count`);
   }
 
+  report(id: string, reportID: string): Promise<ReportDetails> {
+    return this.req('GET', `${id}/reports/${reportID}`);
+  }
+
+  revokeReport(
+    id: string,
+    reportID: string,
+    reason: ReasonRequest,
+    unban: boolean = true,
+    modlog: boolean = true,
+  ): Promise<Report> {
+    return this.req(
+      'DELETE',
+      `${id}/reports/${reportID}?unban=${unban}&modlog=${modlog}`,
+      reason,
+    );
+  }
+
+  revokedReports(id: string, limit: number = 20, offset: number = 0): Promise<ListResponse<Report>> {
+    return this.req('GET', `${id}/reports/revoked?limit=${limit}&offset=${offset}`);
+  }
+
+  configBundle(id: string): Promise<ConfigBundle> {
+    return this.req('GET', `${id}/config/bundle`);
+  }
+
+  importConfigBundle(
+    id: string,
+    bundle: ConfigBundle,
+    dryrun: boolean = false,
+  ): Promise<ConfigBundleImportResult> {
+    return this.req('POST', `${id}/config/bundle?dryrun=${dryrun}`, bundle);
+  }
+
   scoreboard(id: string, limit: number = 20): Promise<ListResponse<GuildScoreboardEntry>> {
     return this.req('GET', `${id}/scoreboard?limit=${limit}`);
   }
 
+  resetKarma(id: string, memberID: string, value: number = 0): Promise<CodeResponse> {
+    return this.req('DELETE', `${id}/karma/${memberID}?value=${value}`);
+  }
+
+  resetAllKarma(id: string, validation: string): Promise<CodeResponse> {
+    return this.req('DELETE', `${id}/karma`, { validation });
+  }
+
   starboard(
     id: string,
     sort: StarboardSortOrder = 'latest',
@@ -325,6 +460,10 @@ export class GuildSettingsClient extends SubClient {
     return this.req('POST', '/', settings);
   }
 
+  effectiveSettings(): Promise<EffectiveGuildSettings> {
+    return this.req('GET', 'effective');
+  }
+
   antiraid(): Promise<AntiraidSettings> {
     return this.req('GET', 'antiraid');
   }
@@ -353,6 +492,14 @@ export class GuildSettingsClient extends SubClient {
     return this.req('POST', 'codeexec', settings);
   }
 
+  botNickname(): Promise<BotNicknameSettings> {
+    return this.req('GET', 'botnick');
+  }
+
+  setBotNickname(nickname: string): Promise<BotNicknameSettings> {
+    return this.req('POST', 'botnick', { nickname });
+  }
+
   flushData(leave_after: boolean, validation: string): Promise<CodeResponse> {
     return this.req('POST', 'flushguilddata', { leave_after, validation });
   }
@@ -424,6 +571,78 @@ export class GuildSettingsClient extends SubClient {
   setVerification(state: GuildSettingsVerification): Promise<GuildSettingsVerification> {
     return this.req('POST', 'verification', state);
   }
+
+  previewJoinMessage(template: string): Promise<MessagePreview> {
+    return this.req('POST', 'joinmessage/preview', { template });
+  }
+
+  previewLeaveMessage(template: string): Promise<MessagePreview> {
+    return this.req('POST', 'leavemessage/preview', { template });
+  }
+
+  previewTwitchNotify(req: TwitchNotifyPreviewRequest): Promise<TwitchNotifyPreview> {
+    return this.req('POST', 'twitchnotify/preview', req);
+  }
+
+  modlogRoutes(): Promise<ModLogRoutes> {
+    return this.req('GET', 'modlog/routes');
+  }
+
+  setModlogRoute(action: ModLogAction, channelid: string): Promise<CodeResponse> {
+    return this.req('PUT', `modlog/routes/${action}`, { channelid });
+  }
+
+  removeModlogRoute(action: ModLogAction): Promise<CodeResponse> {
+    return this.req('DELETE', `modlog/routes/${action}`);
+  }
+
+  autoThreads(): Promise<ListResponse<AutoThreadConfig>> {
+    return this.req('GET', 'autothreads');
+  }
+
+  setAutoThread(cfg: AutoThreadConfig): Promise<AutoThreadConfig> {
+    return this.req('PUT', `autothreads/${cfg.channelid}`, cfg);
+  }
+
+  removeAutoThread(channelId: string): Promise<CodeResponse> {
+    return this.req('DELETE', `autothreads/${channelId}`);
+  }
+
+  autoPublishes(): Promise<ListResponse<AutoPublishConfig>> {
+    return this.req('GET', 'autopublish');
+  }
+
+  setAutoPublish(cfg: AutoPublishConfig): Promise<AutoPublishConfig> {
+    return this.req('PUT', `autopublish/${cfg.channelid}`, cfg);
+  }
+
+  removeAutoPublish(channelId: string): Promise<CodeResponse> {
+    return this.req('DELETE', `autopublish/${channelId}`);
+  }
+
+  prefixlessChannels(): Promise<ListResponse<string>> {
+    return this.req('GET', 'prefixless');
+  }
+
+  addPrefixlessChannel(channelId: string): Promise<CodeResponse> {
+    return this.req('PUT', `prefixless/${channelId}`);
+  }
+
+  removePrefixlessChannel(channelId: string): Promise<CodeResponse> {
+    return this.req('DELETE', `prefixless/${channelId}`);
+  }
+
+  stickyMessages(): Promise<ListResponse<StickyMessage>> {
+    return this.req('GET', 'stickymessages');
+  }
+
+  setStickyMessage(channelId: string, content: string): Promise<CodeResponse> {
+    return this.req('PUT', `stickymessages/${channelId}`, { content });
+  }
+
+  removeStickyMessage(channelId: string): Promise<CodeResponse> {
+    return this.req('DELETE', `stickymessages/${channelId}`);
+  }
 }
 
 export class GuildBackupsClient extends SubClient {
@@ -435,6 +654,14 @@ export class GuildBackupsClient extends SubClient {
     return this.req('GET', '/');
   }
 
+  create(): Promise<GuildBackup> {
+    return this.req('POST', '/');
+  }
+
+  delete(id: string): Promise<CodeResponse> {
+    return this.req('DELETE', id);
+  }
+
   download(id: string): Promise<AccessTokenModel> {
     return this.req('POST', `${id}/download`);
   }
@@ -448,6 +675,22 @@ export class GuildBackupsClient extends SubClient {
   toggle(enabled: boolean): Promise<CodeResponse> {
     return this.req('POST', 'toggle', { enabled });
   }
+
+  diff(from: string, to: string): Promise<GuildBackupDiff> {
+    return this.req('GET', `diff?from=${from}&to=${to}`);
+  }
+
+  schedule(): Promise<GuildBackupSchedule> {
+    return this.req('GET', 'schedule');
+  }
+
+  setSchedule(schedule: GuildBackupSchedule): Promise<GuildBackupSchedule> {
+    return this.req('PUT', 'schedule', schedule);
+  }
+
+  deleteSchedule(): Promise<CodeResponse> {
+    return this.req('DELETE', 'schedule');
+  }
 }
 
 export class UnbanRequestsClient extends SubClient {
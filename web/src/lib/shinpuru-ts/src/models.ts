@@ -3,6 +3,14 @@
 export interface ListResponse<T> {
   n: number;
   data: T[];
+  pagination?: Pagination;
+}
+
+export interface Pagination {
+  limit: number;
+  offset: number;
+  total: number;
+  next?: number;
 }
 
 export interface FlatUser {
@@ -97,6 +105,11 @@ export interface Guild {
   channels?: Channel[];
 }
 
+export interface UserGuild extends Guild {
+  dominance: number;
+  moderator: boolean;
+}
+
 export interface PermissionResponse {
   permissions: string[];
 }
@@ -124,6 +137,13 @@ export interface Report {
   attachment_url: string;
   created?: string;
   timeout: string;
+  revocation?: ReportRevocation;
+}
+
+export interface ReportRevocation {
+  executor_id: string;
+  reason: string;
+  timestamp: string;
 }
 
 export interface GuildSettings {
@@ -131,12 +151,53 @@ export interface GuildSettings {
   perms: Map<string, string[]>;
   autoroles: string[];
   modlogchannel: string;
+  modlogformat: string;
   modnotchannel: string;
   voicelogchannel: string;
   joinmessagechannel: string;
   joinmessagetext: string;
   leavemessagechannel: string;
   leavemessagetext: string;
+  embedcolor: string;
+  announcementchannel: string;
+}
+
+export interface EffectiveSetting<T> {
+  value: T;
+  default: T;
+  is_set: boolean;
+}
+
+export type EffectiveGuildSettings = {
+  [K in keyof GuildSettings]: EffectiveSetting<GuildSettings[K]>;
+};
+
+export interface MessagePreview {
+  raw: string;
+  rendered: string;
+}
+
+export interface TwitchNotifyPreviewRequest {
+  template: string;
+  role_id?: string;
+  plain?: boolean;
+}
+
+export interface TwitchNotifyPreview extends MessagePreview {
+  embed: boolean;
+}
+
+export interface NotificationSubscription {
+  type: 'twitch';
+  channel_id: string;
+  twitch_user_id: string;
+  twitch_user_name?: string;
+  template?: string;
+  role_id?: string;
+  plain: boolean;
+  live: boolean;
+  failing: boolean;
+  problems: string[];
 }
 
 export interface PermissionsUpdate {
@@ -145,6 +206,39 @@ export interface PermissionsUpdate {
   override?: boolean;
 }
 
+export interface PermissionsPreviewRequest {
+  updates: PermissionsUpdate[];
+  member_ids?: string[];
+  check?: string[];
+}
+
+export interface PermissionsPreviewRole {
+  role_id: string;
+  before: string[];
+  after: string[];
+}
+
+export interface PermissionsPreviewCheck {
+  perm: string;
+  before: boolean;
+  after: boolean;
+}
+
+export interface PermissionsPreviewMember {
+  user: FlatUser;
+  roles: string[];
+  override: boolean;
+  before: string[];
+  after: string[];
+  changed: boolean;
+  checks?: PermissionsPreviewCheck[];
+}
+
+export interface PermissionsPreview {
+  roles: PermissionsPreviewRole[];
+  members: PermissionsPreviewMember[];
+}
+
 export interface ReasonRequest {
   reason: string;
   attachment?: string;
@@ -175,6 +269,21 @@ export interface InviteSettingsResponse {
   message: string;
 }
 
+export type TwitchCredentialsErrorType = 'not_configured' | 'auth' | 'network';
+
+export interface TwitchRateLimit {
+  limit: number;
+  remaining: number;
+  reset: string;
+}
+
+export interface TwitchCredentialsTestResult {
+  ok: boolean;
+  error_type?: TwitchCredentialsErrorType;
+  error?: string;
+  rate_limit?: TwitchRateLimit;
+}
+
 export interface Count {
   count: number;
 }
@@ -190,10 +299,10 @@ export interface SystemInfo {
   arch: string;
   cpus: number;
   go_routines: number;
-  stack_use: number;
-  stack_use_str: string;
-  heap_use: number;
-  heap_use_str: string;
+  stack_use?: number;
+  stack_use_str?: string;
+  heap_use?: number;
+  heap_use_str?: string;
   bot_user_id: string;
   bot_invite: string;
   guilds: number;
@@ -218,10 +327,60 @@ export interface APIToken {
   token?: string;
 }
 
+export interface APITokenUsageEntry {
+  timestamp: Date;
+  method: string;
+  route: string;
+  status: number;
+  ip: string;
+}
+
 export interface GuildBackup {
   guild_id: string;
   timestamp: Date;
   file_id: string;
+  manual: boolean;
+}
+
+export interface GuildBackupChange {
+  field: string;
+  old: string;
+  new: string;
+}
+
+export interface GuildBackupObjectRef {
+  id: string;
+  name: string;
+}
+
+export interface GuildBackupModifiedObject extends GuildBackupObjectRef {
+  changes: GuildBackupChange[];
+}
+
+export interface GuildBackupObjectsDiff {
+  added: GuildBackupObjectRef[];
+  removed: GuildBackupObjectRef[];
+  modified: GuildBackupModifiedObject[];
+}
+
+export interface GuildBackupDiff {
+  from: GuildBackup;
+  to: GuildBackup;
+  settings: GuildBackupChange[];
+  roles: GuildBackupObjectsDiff;
+  channels: GuildBackupObjectsDiff;
+  members_added: number;
+  members_removed: number;
+  notes: string[];
+}
+
+export interface GuildBackupSchedule {
+  guild_id?: string;
+  spec: string;
+  retention: number;
+  notify_channel_id: string;
+  notify_owner: boolean;
+  last_run?: Date;
 }
 
 export interface GuildScoreboardEntry {
@@ -278,6 +437,14 @@ export interface KarmaSettings {
   emotes_decrease: string[];
   tokens: number;
   penalty: boolean;
+  limits?: KarmaLimits;
+}
+
+export interface KarmaLimits {
+  give_cooldown: number;
+  give_daily_cap: number;
+  receive_cap: number;
+  receive_interval: number;
 }
 
 export interface AntiraidSettings {
@@ -297,12 +464,171 @@ export interface JoinlogEntry {
   selected: boolean;
 }
 
+export interface InviteJoinStats {
+  code: string;
+  source: 'invite' | 'vanity' | 'unknown';
+  label?: string;
+  creator_id?: string;
+  inviter_id?: string;
+  joins: number;
+}
+
+export interface InviteAnalyticsEntry extends InviteJoinStats {
+  active: boolean;
+}
+
+export interface InviterStats {
+  user_id: string;
+  codes: string[];
+  joins: number;
+}
+
+export interface InviteAnalytics {
+  total_joins: number;
+  invites: InviteAnalyticsEntry[];
+  inviters: InviterStats[];
+}
+
+export interface MessageStatsChannel {
+  channel_id: string;
+  count: number;
+}
+
+export interface MessageStatsUser {
+  user_id: string;
+  count: number;
+}
+
+export interface MessageStats {
+  since: Date;
+  total: number;
+  channels: MessageStatsChannel[];
+  top_posters: MessageStatsUser[];
+  hours: number[];
+}
+
+export interface TempRole {
+  id: string;
+  guildid: string;
+  userid: string;
+  roleid: string;
+  executorid: string;
+  expires: string;
+  attempts: number;
+}
+
+export interface TempRoleRequest {
+  roleid: string;
+  expires: string;
+}
+
+export interface UserNote {
+  id: string;
+  guildid: string;
+  userid: string;
+  authorid: string;
+  content: string;
+  created: string;
+}
+
+export interface ReportDetails extends Report {
+  notes?: UserNote[];
+}
+
+export interface UserNoteRequest {
+  content: string;
+}
+
+export type AutoThreadNameSource = 'content' | 'author';
+
+export interface AutoThreadConfig {
+  guildid: string;
+  channelid: string;
+  attachmentsonly: boolean;
+  namesource: AutoThreadNameSource;
+  // Cooldown in seconds
+  cooldown: number;
+}
+
+export interface AutoPublishConfig {
+  guildid: string;
+  channelid: string;
+  skipbots: boolean;
+}
+
+export type ModLogAction =
+  | 'kick'
+  | 'ban'
+  | 'mute'
+  | 'warn'
+  | 'ad'
+  | 'unban'
+  | 'unmute'
+  | 'custom'
+  | 'revoke'
+  | 'pin'
+  | 'antiraid'
+  | 'wordfilter'
+  | 'inactivity';
+
+export interface ModLogRoutes {
+  default: string;
+  routes: { [key in ModLogAction]?: string };
+}
+
+export interface ConfigBundleEntity {
+  id: string;
+  name: string;
+  type?: number;
+}
+
+export interface ConfigBundle {
+  version: number;
+  guildid: string;
+  created: string;
+  roles: ConfigBundleEntity[];
+  channels: ConfigBundleEntity[];
+  settings: { [key: string]: any };
+  flags: { [key: string]: boolean };
+  permissions: PermissionsMap;
+  modlogroutes: { [key in ModLogAction]?: string };
+  reporttypes: { id: number; name: string; color: number }[];
+  escalation?: { [key: string]: any };
+  wordfilter: { [key: string]: any };
+  karma: { [key: string]: any };
+  antiraid: { [key: string]: any };
+  autothreads: Omit<AutoThreadConfig, 'guildid'>[];
+}
+
+export interface ConfigBundleImportResult {
+  dryrun: boolean;
+  mappedroles: { [key: string]: string };
+  mappedchannels: { [key: string]: string };
+  unmappedroles: ConfigBundleEntity[];
+  unmappedchannels: ConfigBundleEntity[];
+  unmappedemotes: string[];
+}
+
+export interface StickyMessage {
+  guildid: string;
+  channelid: string;
+  content: string;
+  messageid: string;
+}
+
 export interface LandingPageInfo {
   localinvite: string;
   publicmaininvite: string;
   publiccaranyinvite: string;
 }
 
+export interface SnowflakeInfo {
+  id: string;
+  created: string;
+  age: string;
+  age_seconds: number;
+}
+
 export enum UnbanRequestState {
   PENDING,
   DECLINED,
@@ -451,6 +777,11 @@ export interface GuildSettingsVerification {
   enabled: boolean;
 }
 
+export interface BotNicknameSettings {
+  nickname: string;
+  warning?: string;
+}
+
 export interface CodeExecSettings {
   enabled: boolean;
   type: string;
@@ -467,8 +798,15 @@ export interface CodeResponse {
   code: number;
 }
 
+export interface FieldError {
+  field: string;
+  message: string;
+}
+
 export interface ErrorReponse extends CodeResponse {
   error: string;
+  context?: string;
+  fields?: FieldError[];
 }
 
 export type PermissionsMap = { [key: string]: string[] };
@@ -109,14 +109,18 @@ const SystemRoute: React.FC<Props> = () => {
               <th>{t('goroutines')}</th>
               <td>{sysinfo.go_routines}</td>
             </tr>
-            <tr>
-              <th>{t('stackuse')}</th>
-              <td>{byteFormatter(sysinfo.stack_use)}</td>
-            </tr>
-            <tr>
-              <th>{t('heapuse')}</th>
-              <td>{byteFormatter(sysinfo.heap_use)}</td>
-            </tr>
+            {sysinfo.stack_use !== undefined && (
+              <tr>
+                <th>{t('stackuse')}</th>
+                <td>{byteFormatter(sysinfo.stack_use)}</td>
+              </tr>
+            )}
+            {sysinfo.heap_use !== undefined && (
+              <tr>
+                <th>{t('heapuse')}</th>
+                <td>{byteFormatter(sysinfo.heap_use)}</td>
+              </tr>
+            )}
             <tr>
               <th>{t('botid')}</th>
               <td>{sysinfo.bot_user_id}</td>